@@ -35,9 +35,12 @@ import (
 	secretstoresLoader "github.com/dapr/dapr/pkg/components/secretstores"
 	stateLoader "github.com/dapr/dapr/pkg/components/state"
 	workflowsLoader "github.com/dapr/dapr/pkg/components/workflows"
+	diag "github.com/dapr/dapr/pkg/diagnostics"
 	"github.com/dapr/dapr/pkg/modes"
 	"github.com/dapr/dapr/pkg/runtime"
+	"github.com/dapr/dapr/pkg/runtime/multiapp"
 	"github.com/dapr/dapr/pkg/runtime/registry"
+	"github.com/dapr/dapr/pkg/runtime/validate"
 	"github.com/dapr/dapr/pkg/security"
 	"github.com/dapr/kit/concurrency"
 	"github.com/dapr/kit/logger"
@@ -63,7 +66,7 @@ func main() {
 
 	if opts.BuildInfo {
 		//nolint:forbidigo
-		fmt.Printf("Version: %s\nGit Commit: %s\nGit Version: %s\n", buildinfo.Version(), buildinfo.Commit(), buildinfo.GitVersion())
+		fmt.Printf("Version: %s\nGit Commit: %s\nGit Version: %s\nCrypto Backend: %s\n", buildinfo.Version(), buildinfo.Commit(), buildinfo.GitVersion(), buildinfo.CryptoBackend())
 		os.Exit(0)
 	}
 
@@ -72,6 +75,30 @@ func main() {
 		os.Exit(0)
 	}
 
+	if opts.ValidateOnly {
+		// The report is written to the standard output, so the logs are written to the standard error to keep it parseable
+		diag.RedirectLogs(os.Stderr)
+
+		resourcesPath := opts.ResourcesPath
+		if len(resourcesPath) == 0 && opts.ComponentsPath != "" {
+			resourcesPath = []string{opts.ComponentsPath}
+		}
+
+		report := validate.Run(validate.Options{
+			AppID:          opts.AppID,
+			Namespace:      os.Getenv("NAMESPACE"),
+			ConfigPaths:    opts.Config,
+			ResourcesPaths: resourcesPath,
+		})
+		if err := report.WriteJSON(os.Stdout); err != nil {
+			log.Fatalf("Failed to write validation report: %v", err)
+		}
+		if !report.Valid {
+			os.Exit(1)
+		}
+		os.Exit(0)
+	}
+
 	// Apply options to all loggers.
 	opts.Logger.SetAppID(opts.AppID)
 
@@ -81,6 +108,7 @@ func main() {
 
 	log.Infof("Starting Dapr Runtime -- version %s -- commit %s", buildinfo.Version(), buildinfo.Commit())
 	log.Infof("Log level set to: %s", opts.Logger.OutputLevel)
+	log.Infof("Crypto backend: %s", buildinfo.CryptoBackend())
 
 	secretstoresLoader.DefaultRegistry.Logger = logContrib
 	stateLoader.DefaultRegistry.Logger = logContrib
@@ -106,6 +134,19 @@ func main() {
 		WithWorkflows(workflowsLoader.DefaultRegistry)
 
 	ctx := signals.Context()
+
+	if opts.RunFile != "" {
+		profile, err := multiapp.LoadProfile(opts.RunFile)
+		if err != nil {
+			log.Fatalf("Failed to load the multi-app run profile: %v", err)
+		}
+		if err = multiapp.Run(ctx, profile, reg); err != nil {
+			log.Fatalf("Fatal error from multi-app run: %v", err)
+		}
+		log.Info("Daprd shutdown gracefully")
+		return
+	}
+
 	secProvider, err := security.New(ctx, security.Options{
 		SentryAddress:           opts.SentryAddress,
 		ControlPlaneTrustDomain: opts.ControlPlaneTrustDomain,
@@ -130,6 +171,8 @@ func main() {
 			rt, rerr := runtime.FromConfig(ctx, &runtime.Config{
 				AppID:                        opts.AppID,
 				PlacementServiceHostAddr:     opts.PlacementServiceHostAddr,
+				SchedulerServiceHostAddr:     opts.SchedulerServiceHostAddr,
+				ActorVersion:                 opts.ActorVersion,
 				AllowedOrigins:               opts.AllowedOrigins,
 				ResourcesPath:                opts.ResourcesPath,
 				ControlPlaneAddress:          opts.ControlPlaneAddress,
@@ -158,6 +201,13 @@ func main() {
 				AppHealthProbeTimeout:        opts.AppHealthProbeTimeout,
 				AppHealthThreshold:           opts.AppHealthThreshold,
 				AppChannelAddress:            opts.AppChannelAddress,
+				AppChannel:                   opts.AppChannel,
+				AppChannelMockConfig:         opts.AppChannelMockConfig,
+				AppChannelRoutes:             opts.AppChannelRoutes,
+				AppGRPCKeepaliveTime:         opts.AppGRPCKeepaliveTime,
+				AppGRPCKeepaliveTimeout:      opts.AppGRPCKeepaliveTimeout,
+				AppGRPCMaxConnectionAge:      opts.AppGRPCMaxConnectionAge,
+				MaxInvokeTimeout:             opts.MaxInvokeTimeout,
 				EnableAPILogging:             opts.EnableAPILogging,
 				Config:                       opts.Config,
 				Metrics:                      opts.Metrics,
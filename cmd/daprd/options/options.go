@@ -50,6 +50,8 @@ type Options struct {
 	RuntimeVersion               bool
 	BuildInfo                    bool
 	WaitCommand                  bool
+	ValidateOnly                 bool
+	RunFile                      string
 	DaprHTTPPort                 string
 	DaprAPIGRPCPort              string
 	ProfilePort                  string
@@ -59,6 +61,8 @@ type Options struct {
 	DaprGracefulShutdownSeconds  int
 	DaprBlockShutdownDuration    *time.Duration
 	PlacementServiceHostAddr     string
+	SchedulerServiceHostAddr     string
+	ActorVersion                 string
 	DaprAPIListenAddresses       string
 	AppHealthProbeInterval       int
 	AppHealthProbeTimeout        int
@@ -71,6 +75,13 @@ type Options struct {
 	DisableBuiltinK8sSecretStore bool
 	AppHealthCheckPath           string
 	AppChannelAddress            string
+	AppChannel                   string
+	AppChannelMockConfig         string
+	AppChannelRoutes             []string
+	AppGRPCKeepaliveTime         time.Duration
+	AppGRPCKeepaliveTimeout      time.Duration
+	AppGRPCMaxConnectionAge      time.Duration
+	MaxInvokeTimeout             time.Duration
 	Logger                       logger.Options
 	Metrics                      *metrics.Options
 }
@@ -112,18 +123,22 @@ func New(origArgs []string) *Options {
 	fs.StringVar(&opts.ComponentsPath, "components-path", "", "Alias for --resources-path")
 	fs.MarkDeprecated("components-path", "use --resources-path")
 	fs.StringSliceVar(&opts.ResourcesPath, "resources-path", nil, "Path for resources directory. If not specified, no resources will be loaded. Can be passed multiple times")
-	fs.StringSliceVar(&opts.Config, "config", nil, "Path to config file, or name of a configuration object. In standalone mode, can be passed multiple times")
+	fs.StringSliceVar(&opts.Config, "config", nil, "Path to config file, or name of a configuration object. Can be passed multiple times, and the configurations are merged in order")
 	fs.StringVar(&opts.AppID, "app-id", "", "A unique ID for Dapr. Used for Service Discovery and state")
 	fs.StringVar(&opts.ControlPlaneAddress, "control-plane-address", "", "Address for a Dapr control plane")
 	fs.StringVar(&opts.SentryAddress, "sentry-address", "", "Address for the Sentry CA service")
 	fs.StringVar(&opts.ControlPlaneTrustDomain, "control-plane-trust-domain", "localhost", "Trust domain of the Dapr control plane")
 	fs.StringVar(&opts.ControlPlaneNamespace, "control-plane-namespace", "default", "Namespace of the Dapr control plane")
 	fs.StringVar(&opts.PlacementServiceHostAddr, "placement-host-address", "", "Addresses for Dapr Actor Placement servers")
+	fs.StringVar(&opts.SchedulerServiceHostAddr, "scheduler-host-address", "", "Address of the Dapr Scheduler service, used by the workflows configured to schedule their reminders with it")
+	fs.StringVar(&opts.ActorVersion, "actor-version", "", "Version of the app, reported to the Placement service to route actors to the app versions hosting them")
 	fs.StringVar(&opts.AllowedOrigins, "allowed-origins", cors.DefaultAllowedOrigins, "Allowed HTTP origins")
 	fs.BoolVar(&opts.EnableProfiling, "enable-profiling", false, "Enable profiling")
 	fs.BoolVar(&opts.RuntimeVersion, "version", false, "Prints the runtime version")
 	fs.BoolVar(&opts.BuildInfo, "build-info", false, "Prints the build info")
 	fs.BoolVar(&opts.WaitCommand, "wait", false, "wait for Dapr outbound ready")
+	fs.BoolVar(&opts.ValidateOnly, "validate-only", false, "Load and validate the configuration and resources, print a JSON report and exit without starting the runtime")
+	fs.StringVar(&opts.RunFile, "run-file", "", "Path to a multi-app run profile. If set, a sidecar is started in this process for each app in the profile, without mTLS; for development and integration testing only")
 	fs.IntVar(&opts.AppMaxConcurrency, "app-max-concurrency", -1, "Controls the concurrency level when forwarding requests to user code; set to -1 for no limits")
 	fs.BoolVar(&opts.EnableMTLS, "enable-mtls", false, "Enables automatic mTLS for daprd-to-daprd communication channels")
 	fs.BoolVar(&opts.AppSSL, "app-ssl", false, "Sets the URI scheme of the app to https and attempts a TLS connection")
@@ -141,6 +156,13 @@ func New(origArgs []string) *Options {
 	fs.IntVar(&opts.AppHealthProbeTimeout, "app-health-probe-timeout", int(config.AppHealthConfigDefaultProbeTimeout/time.Millisecond), "Timeout for app health probes in milliseconds")
 	fs.IntVar(&opts.AppHealthThreshold, "app-health-threshold", int(config.AppHealthConfigDefaultThreshold), "Number of consecutive failures for the app to be considered unhealthy")
 	fs.StringVar(&opts.AppChannelAddress, "app-channel-address", runtime.DefaultChannelAddress, "The network address the application listens on")
+	fs.StringVar(&opts.AppChannel, "app-channel", "", "Set to 'mock' to use a built-in fake app that responds to invocations, subscriptions and binding events with canned responses, for local development only")
+	fs.StringVar(&opts.AppChannelMockConfig, "app-channel-mock-config", "", "Path to a YAML file with the subscriptions and canned responses of the mock app channel; if empty, all requests are echoed back")
+	fs.StringSliceVar(&opts.AppChannelRoutes, "app-channel-route", nil, "Dedicated app channel for a building block, in the format '<building-block>=<protocol>:<port>' (for example 'pubsub=http:3001'); building blocks are invoke, pubsub, and bindings, and protocols are http, https, and h2c. Can be passed multiple times")
+	fs.DurationVar(&opts.AppGRPCKeepaliveTime, "app-grpc-keepalive-time", 0, "Interval for sending keepalive pings on the gRPC connection to the app when it's idle; set to 0 to disable keepalive pings")
+	fs.DurationVar(&opts.AppGRPCKeepaliveTimeout, "app-grpc-keepalive-timeout", runtime.DefaultAppGRPCKeepaliveTimeout, "Time to wait for a response to a keepalive ping on the gRPC connection to the app before closing the connection")
+	fs.DurationVar(&opts.AppGRPCMaxConnectionAge, "app-grpc-max-connection-age", 0, "Maximum age of the gRPC connection to the app, after which a new connection is established; set to 0 for no limits")
+	fs.DurationVar(&opts.MaxInvokeTimeout, "max-invoke-timeout", 0, "Maximum timeout that callers can request for service invocations with the dapr-timeout header; set to 0 for no limits")
 
 	// Add flags for logger and metrics
 	opts.Logger = logger.DefaultOptions()
@@ -35,6 +35,14 @@ func TestAppFlag(t *testing.T) {
 	assert.EqualValues(t, "http", opts.AppProtocol)
 }
 
+func TestRunFileFlag(t *testing.T) {
+	opts := New([]string{"--run-file", "dapr.yaml"})
+	assert.Equal(t, "dapr.yaml", opts.RunFile)
+
+	opts = New([]string{"--app-id", "testapp"})
+	assert.Empty(t, opts.RunFile)
+}
+
 func TestStandaloneGlobalConfig(t *testing.T) {
 	opts := New([]string{
 		"--app-id", "testapp",
@@ -19,6 +19,7 @@ import (
 	"github.com/dapr/dapr/pkg/metrics"
 	"github.com/dapr/dapr/pkg/operator"
 	"github.com/dapr/dapr/pkg/operator/monitoring"
+	"github.com/dapr/dapr/pkg/operator/quota"
 	"github.com/dapr/kit/concurrency"
 	"github.com/dapr/kit/logger"
 	"github.com/dapr/kit/signals"
@@ -55,8 +56,14 @@ func main() {
 		WatchdogEnabled:                     opts.WatchdogEnabled,
 		WatchdogInterval:                    opts.WatchdogInterval,
 		WatchdogCanPatchPodLabels:           opts.WatchdogCanPatchPodLabels,
+		WorkflowMetricsInterval:             opts.WorkflowMetricsInterval,
 		APIPort:                             opts.APIPort,
 		HealthzPort:                         opts.HealthzPort,
+		Quotas: quota.Limits{
+			MaxComponents:    opts.MaxComponentsPerNamespace,
+			MaxSubscriptions: opts.MaxSubscriptionsPerNamespace,
+			MaxResiliencies:  opts.MaxResilienciesPerNamespace,
+		},
 	})
 	if err != nil {
 		log.Fatalf("error creating operator: %v", err)
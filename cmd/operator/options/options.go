@@ -49,11 +49,15 @@ type Options struct {
 	WatchdogInterval                   time.Duration
 	watchdogIntervalStr                string
 	WatchdogCanPatchPodLabels          bool
+	WorkflowMetricsInterval            time.Duration
 	TrustAnchorsFile                   string
 	Logger                             logger.Options
 	Metrics                            *metrics.Options
 	APIPort                            int
 	HealthzPort                        int
+	MaxComponentsPerNamespace          int
+	MaxSubscriptionsPerNamespace       int
+	MaxResilienciesPerNamespace        int
 }
 
 func New() *Options {
@@ -75,11 +79,17 @@ func New() *Options {
 	flag.BoolVar(&opts.EnableArgoRolloutServiceReconciler, "enable-argo-rollout-service-reconciler", false, "Enable the service reconciler for Dapr-enabled Argo Rollouts")
 	flag.BoolVar(&opts.WatchdogCanPatchPodLabels, "watchdog-can-patch-pod-labels", false, "Allow watchdog to patch pod labels to set pods with sidecar present")
 
+	flag.DurationVar(&opts.WorkflowMetricsInterval, "workflow-metrics-interval", 0, "Interval for collecting the workflow metrics of the sidecars and exposing them aggregated by app, e.g. '1m'. Set to '0' to disable")
+
 	flag.StringVar(&opts.TrustAnchorsFile, "trust-anchors-file", securityConsts.ControlPlaneDefaultTrustAnchorsPath, "Filepath to the trust anchors for the Dapr control plane")
 
 	flag.IntVar(&opts.APIPort, "port", 6500, "The port for the operator API server to listen on")
 	flag.IntVar(&opts.HealthzPort, "healthz-port", 8080, "The port for the healthz server to listen on")
 
+	flag.IntVar(&opts.MaxComponentsPerNamespace, "max-components-per-namespace", 0, "Maximum number of components that can be created in each namespace; set to 0 for no limit")
+	flag.IntVar(&opts.MaxSubscriptionsPerNamespace, "max-subscriptions-per-namespace", 0, "Maximum number of subscriptions that can be created in each namespace; set to 0 for no limit")
+	flag.IntVar(&opts.MaxResilienciesPerNamespace, "max-resiliencies-per-namespace", 0, "Maximum number of resiliency policies that can be created in each namespace; set to 0 for no limit")
+
 	opts.Logger = logger.DefaultOptions()
 	opts.Logger.AttachCmdFlags(flag.StringVar, flag.BoolVar)
 
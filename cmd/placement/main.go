@@ -83,9 +83,15 @@ func main() {
 
 	hashing.SetReplicationFactor(opts.ReplicationFactor)
 
+	actorVersionRouting, err := placement.ParseActorVersionRouting(opts.ActorVersionRouting)
+	if err != nil {
+		log.Fatal(err)
+	}
+
 	placementOpts := placement.PlacementServiceOpts{
-		RaftNode:    raftServer,
-		SecProvider: secProvider,
+		RaftNode:            raftServer,
+		SecProvider:         secProvider,
+		ActorVersionRouting: actorVersionRouting,
 	}
 	if opts.MinAPILevel >= 0 && opts.MinAPILevel < math.MaxInt32 {
 		placementOpts.MinAPILevel = uint32(opts.MinAPILevel)
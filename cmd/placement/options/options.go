@@ -61,6 +61,8 @@ type Options struct {
 
 	ReplicationFactor int
 
+	ActorVersionRouting []string
+
 	// Log and metrics configurations
 	Logger  logger.Options
 	Metrics *metrics.Options
@@ -102,6 +104,7 @@ func New(origArgs []string) *Options {
 	fs.IntVar(&opts.MaxAPILevel, "max-api-level", -1, "If set to >= 0, causes the reported 'api-level' in the cluster to never exceed this value")
 	fs.IntVar(&opts.MinAPILevel, "min-api-level", 0, "Enforces a minimum 'api-level' in the cluster")
 	fs.IntVar(&opts.ReplicationFactor, "replicationFactor", defaultReplicationFactor, "sets the replication factor for actor distribution on vnodes")
+	fs.StringArrayVar(&opts.ActorVersionRouting, "actor-version-routing", nil, "Routes the actors of a type to the versions of the apps hosting it, as '<actor type>=<version>:<weight>,...'. Can be repeated for each actor type")
 
 	fs.StringVar(&opts.TrustDomain, "trust-domain", "localhost", "Trust domain for the Dapr control plane")
 	fs.StringVar(&opts.TrustAnchorsFile, "trust-anchors-file", securityConsts.ControlPlaneDefaultTrustAnchorsPath, "Filepath to the trust anchors for the Dapr control plane")
@@ -0,0 +1,205 @@
+/*
+Copyright 2023 The Dapr Authors
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package accesslog
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"math/rand"
+	"os"
+	"strconv"
+	"strings"
+	"sync"
+	"text/template"
+	"time"
+
+	"github.com/dapr/dapr/pkg/config"
+)
+
+const (
+	// FormatJSON formats access log entries as JSON objects.
+	FormatJSON = "json"
+	// FormatCommon formats access log entries using the Common Log Format.
+	FormatCommon = "common"
+
+	// ProtocolHTTP is the protocol of requests received by the HTTP server.
+	ProtocolHTTP = "http"
+	// ProtocolGRPC is the protocol of requests received by the gRPC server.
+	ProtocolGRPC = "grpc"
+
+	commonLogTimeFormat = "02/Jan/2006:15:04:05 -0700"
+)
+
+// Entry is an access log entry, which records an invocation of the Dapr APIs.
+type Entry struct {
+	// Time the request was received.
+	Time time.Time `json:"time"`
+	// ID of the app.
+	AppID string `json:"app_id"`
+	// Protocol of the request: "http" or "grpc".
+	Protocol string `json:"protocol"`
+	// HTTP method of the request, or full name of the gRPC method.
+	Method string `json:"method"`
+	// Path of the request. Empty for gRPC requests.
+	Path string `json:"path,omitempty"`
+	// HTTP status code of the response, or gRPC status code.
+	Status int `json:"status"`
+	// Size of the response body, in bytes. Only set for HTTP requests.
+	Size int `json:"size"`
+	// Time spent serving the request.
+	Duration time.Duration `json:"-"`
+	// Address of the client.
+	RemoteAddr string `json:"remote_addr,omitempty"`
+	// User agent of the client.
+	UserAgent string `json:"user_agent,omitempty"`
+	// True if the request took longer than the slow-request threshold.
+	Slow bool `json:"slow,omitempty"`
+}
+
+// DurationMs returns the duration of the request in milliseconds.
+func (e Entry) DurationMs() float64 {
+	return float64(e.Duration.Microseconds()) / 1000
+}
+
+// Logger writes access log entries.
+// A nil Logger doesn't write any entry.
+type Logger struct {
+	appID         string
+	samplingRate  float64
+	slowThreshold time.Duration
+	format        func(w *bytes.Buffer, e Entry) error
+
+	out  io.Writer
+	lock sync.Mutex
+}
+
+// New returns a Logger for the spec, writing to the standard output.
+// If access logs are not enabled, the returned Logger is nil.
+func New(appID string, spec config.AccessLogSpec) (*Logger, error) {
+	return newLogger(appID, spec, os.Stdout)
+}
+
+func newLogger(appID string, spec config.AccessLogSpec, out io.Writer) (*Logger, error) {
+	if !spec.Enabled {
+		return nil, nil
+	}
+
+	l := &Logger{
+		appID:        appID,
+		samplingRate: 1,
+		out:          out,
+	}
+
+	if spec.SamplingRate != "" {
+		rate, err := strconv.ParseFloat(spec.SamplingRate, 64)
+		if err != nil || rate < 0 || rate > 1 {
+			return nil, fmt.Errorf("invalid access log sampling rate '%s': must be a number between 0 and 1", spec.SamplingRate)
+		}
+		l.samplingRate = rate
+	}
+
+	if spec.SlowThreshold != "" {
+		threshold, err := time.ParseDuration(spec.SlowThreshold)
+		if err != nil {
+			return nil, fmt.Errorf("invalid access log slow threshold '%s': %w", spec.SlowThreshold, err)
+		}
+		l.slowThreshold = threshold
+	}
+
+	switch {
+	case spec.Template != "":
+		tpl, err := template.New("accesslog").Parse(spec.Template)
+		if err != nil {
+			return nil, fmt.Errorf("invalid access log template: %w", err)
+		}
+		l.format = func(w *bytes.Buffer, e Entry) error {
+			return tpl.Execute(w, e)
+		}
+	case spec.Format == "" || strings.EqualFold(spec.Format, FormatJSON):
+		l.format = formatJSON
+	case strings.EqualFold(spec.Format, FormatCommon):
+		l.format = formatCommon
+	default:
+		return nil, fmt.Errorf("invalid access log format '%s'", spec.Format)
+	}
+
+	return l, nil
+}
+
+// Log writes an access log entry, if it's sampled.
+// Entries for slow requests are always written.
+func (l *Logger) Log(e Entry) {
+	if l == nil {
+		return
+	}
+
+	e.AppID = l.appID
+	e.Slow = l.slowThreshold > 0 && e.Duration >= l.slowThreshold
+	if !e.Slow && !l.sample() {
+		return
+	}
+
+	var buf bytes.Buffer
+	if err := l.format(&buf, e); err != nil {
+		return
+	}
+	if b := buf.Bytes(); len(b) == 0 || b[len(b)-1] != '\n' {
+		buf.WriteByte('\n')
+	}
+
+	l.lock.Lock()
+	defer l.lock.Unlock()
+	_, _ = l.out.Write(buf.Bytes())
+}
+
+func (l *Logger) sample() bool {
+	switch {
+	case l.samplingRate >= 1:
+		return true
+	case l.samplingRate <= 0:
+		return false
+	default:
+		//nolint:gosec
+		return rand.Float64() < l.samplingRate
+	}
+}
+
+func formatJSON(w *bytes.Buffer, e Entry) error {
+	return json.NewEncoder(w).Encode(struct {
+		Entry
+		Duration float64 `json:"duration"`
+	}{
+		Entry:    e,
+		Duration: e.DurationMs(),
+	})
+}
+
+// formatCommon formats the entry using the Common Log Format, followed by the duration of the request in milliseconds.
+func formatCommon(w *bytes.Buffer, e Entry) error {
+	remoteAddr := e.RemoteAddr
+	if remoteAddr == "" {
+		remoteAddr = "-"
+	}
+	request := e.Method
+	if e.Protocol == ProtocolHTTP {
+		request += " " + e.Path
+	}
+	_, err := fmt.Fprintf(w, "%s - - [%s] %q %d %d %s\n",
+		remoteAddr, e.Time.Format(commonLogTimeFormat), request, e.Status, e.Size,
+		strconv.FormatFloat(e.DurationMs(), 'f', -1, 64),
+	)
+	return err
+}
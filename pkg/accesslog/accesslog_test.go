@@ -0,0 +1,134 @@
+/*
+Copyright 2023 The Dapr Authors
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package accesslog
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/dapr/dapr/pkg/config"
+)
+
+func TestNew(t *testing.T) {
+	t.Run("disabled", func(t *testing.T) {
+		l, err := New("myapp", config.AccessLogSpec{})
+		require.NoError(t, err)
+		assert.Nil(t, l)
+
+		// A nil logger is a no-op
+		l.Log(Entry{})
+	})
+
+	t.Run("invalid options", func(t *testing.T) {
+		for _, spec := range []config.AccessLogSpec{
+			{Enabled: true, SamplingRate: "foo"},
+			{Enabled: true, SamplingRate: "2"},
+			{Enabled: true, SlowThreshold: "foo"},
+			{Enabled: true, Format: "foo"},
+			{Enabled: true, Template: "{{ .Method "},
+		} {
+			_, err := New("myapp", spec)
+			require.Error(t, err)
+		}
+	})
+}
+
+func TestLog(t *testing.T) {
+	entry := Entry{
+		Time:       time.Date(2023, 10, 10, 13, 55, 36, 0, time.UTC),
+		Protocol:   ProtocolHTTP,
+		Method:     http.MethodGet,
+		Path:       "/v1.0/state/mystore/key",
+		Status:     http.StatusOK,
+		Size:       42,
+		Duration:   1500 * time.Microsecond,
+		RemoteAddr: "10.0.0.1",
+		UserAgent:  "test",
+	}
+
+	t.Run("json", func(t *testing.T) {
+		var buf bytes.Buffer
+		l, err := newLogger("myapp", config.AccessLogSpec{Enabled: true}, &buf)
+		require.NoError(t, err)
+
+		l.Log(entry)
+
+		var logged map[string]any
+		require.NoError(t, json.Unmarshal(buf.Bytes(), &logged))
+		assert.Equal(t, "myapp", logged["app_id"])
+		assert.Equal(t, "GET", logged["method"])
+		assert.Equal(t, "/v1.0/state/mystore/key", logged["path"])
+		assert.InDelta(t, 200, logged["status"], 0)
+		assert.InDelta(t, 1.5, logged["duration"], 0)
+		assert.NotContains(t, logged, "slow")
+	})
+
+	t.Run("common", func(t *testing.T) {
+		var buf bytes.Buffer
+		l, err := newLogger("myapp", config.AccessLogSpec{Enabled: true, Format: FormatCommon}, &buf)
+		require.NoError(t, err)
+
+		l.Log(entry)
+		assert.Equal(t, `10.0.0.1 - - [10/Oct/2023:13:55:36 +0000] "GET /v1.0/state/mystore/key" 200 42 1.5`+"\n", buf.String())
+	})
+
+	t.Run("template", func(t *testing.T) {
+		var buf bytes.Buffer
+		l, err := newLogger("myapp", config.AccessLogSpec{Enabled: true, Template: "{{ .AppID }} {{ .Method }} {{ .Status }} {{ .DurationMs }}"}, &buf)
+		require.NoError(t, err)
+
+		l.Log(entry)
+		assert.Equal(t, "myapp GET 200 1.5\n", buf.String())
+	})
+
+	t.Run("sampling and slow requests", func(t *testing.T) {
+		var buf bytes.Buffer
+		l, err := newLogger("myapp", config.AccessLogSpec{Enabled: true, SamplingRate: "0", SlowThreshold: "1s", Template: "{{ .Path }} {{ .Slow }}"}, &buf)
+		require.NoError(t, err)
+
+		l.Log(entry)
+		assert.Empty(t, buf.String())
+
+		slow := entry
+		slow.Duration = 2 * time.Second
+		l.Log(slow)
+		assert.Equal(t, "/v1.0/state/mystore/key true\n", buf.String())
+	})
+}
+
+func TestHTTPMiddleware(t *testing.T) {
+	var buf bytes.Buffer
+	l, err := newLogger("myapp", config.AccessLogSpec{Enabled: true, Format: FormatCommon}, &buf)
+	require.NoError(t, err)
+
+	h := l.HTTPMiddleware(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+		w.Write([]byte("not found"))
+	}))
+	r := httptest.NewRequest(http.MethodPost, "/v1.0/invoke/app/method/foo", nil)
+	r.RemoteAddr = "10.0.0.1:12345"
+	h.ServeHTTP(httptest.NewRecorder(), r)
+
+	line := buf.String()
+	assert.True(t, strings.HasPrefix(line, "10.0.0.1 - - ["), line)
+	assert.Contains(t, line, `"POST /v1.0/invoke/app/method/foo" 404 9 `)
+}
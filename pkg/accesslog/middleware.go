@@ -0,0 +1,110 @@
+/*
+Copyright 2023 The Dapr Authors
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package accesslog
+
+import (
+	"context"
+	"net"
+	"net/http"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+
+	"github.com/dapr/dapr/pkg/grpc/metadata"
+	"github.com/dapr/dapr/pkg/responsewriter"
+)
+
+// HTTPMiddleware returns the middleware that writes access logs for the requests received by an HTTP server.
+func (l *Logger) HTTPMiddleware(next http.Handler) http.Handler {
+	if l == nil {
+		return next
+	}
+
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		rw := responsewriter.EnsureResponseWriter(w)
+
+		start := time.Now()
+		next.ServeHTTP(rw, r)
+
+		status := rw.Status()
+		if status == 0 {
+			status = http.StatusOK
+		}
+		l.Log(Entry{
+			Time:       start,
+			Protocol:   ProtocolHTTP,
+			Method:     r.Method,
+			Path:       r.URL.Path,
+			Status:     status,
+			Size:       rw.Size(),
+			Duration:   time.Since(start),
+			RemoteAddr: remoteHost(r.RemoteAddr),
+			UserAgent:  r.UserAgent(),
+		})
+	})
+}
+
+// UnaryServerInterceptor returns the interceptor that writes access logs for the unary RPCs received by a gRPC server.
+func (l *Logger) UnaryServerInterceptor() grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+		start := time.Now()
+		res, err := handler(ctx, req)
+		l.logGRPC(ctx, info.FullMethod, start, err)
+		return res, err
+	}
+}
+
+// StreamServerInterceptor returns the interceptor that writes access logs for the streams received by a gRPC server.
+func (l *Logger) StreamServerInterceptor() grpc.StreamServerInterceptor {
+	return func(srv any, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		start := time.Now()
+		err := handler(srv, ss)
+		l.logGRPC(ss.Context(), info.FullMethod, start, err)
+		return err
+	}
+}
+
+func (l *Logger) logGRPC(ctx context.Context, method string, start time.Time, err error) {
+	if l == nil {
+		return
+	}
+
+	e := Entry{
+		Time:     start,
+		Protocol: ProtocolGRPC,
+		Method:   method,
+		Status:   int(status.Code(err)),
+		Duration: time.Since(start),
+	}
+	if p, ok := peer.FromContext(ctx); ok && p.Addr != nil {
+		e.RemoteAddr = remoteHost(p.Addr.String())
+	}
+	if md, ok := metadata.FromIncomingContext(ctx); ok {
+		if val, ok := md["user-agent"]; ok && len(val) > 0 {
+			e.UserAgent = val[0]
+		}
+	}
+	l.Log(e)
+}
+
+// remoteHost returns the host part of a remote address.
+func remoteHost(addr string) string {
+	host, _, err := net.SplitHostPort(addr)
+	if err != nil {
+		return addr
+	}
+	return host
+}
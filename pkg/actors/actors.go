@@ -27,6 +27,7 @@ import (
 
 	"github.com/cenkalti/backoff/v4"
 	"github.com/google/uuid"
+	"go.opentelemetry.io/otel/trace"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
@@ -52,6 +53,7 @@ import (
 	"github.com/dapr/dapr/pkg/retry"
 	"github.com/dapr/dapr/pkg/runtime/compstore"
 	"github.com/dapr/dapr/pkg/security"
+	"github.com/dapr/dapr/utils"
 	"github.com/dapr/kit/logger"
 )
 
@@ -59,6 +61,9 @@ const (
 	daprSeparator        = "||"
 	metadataPartitionKey = "partitionKey"
 
+	// failoverTablesUpdateTimeout is how long an actor invocation waits for the placement tables to be updated after the host of the actor was found unreachable.
+	failoverTablesUpdateTimeout = 10 * time.Second
+
 	errStateStoreNotFound      = "actors: state store does not exist or incorrectly configured"
 	errStateStoreNotConfigured = `actors: state store does not exist or incorrectly configured. Have you set the property '{"name": "actorStateStore", "value": "true"}' in your state store component file?`
 )
@@ -70,6 +75,8 @@ var (
 	ErrReminderOpActorNotHosted      = errors.New("operations on actor reminders are only possible on hosted actor types")
 	ErrTransactionsTooManyOperations = errors.New("the transaction contains more operations than supported by the state store")
 	ErrReminderCanceled              = internal.ErrReminderCanceled
+
+	errActorVersionMismatch = errors.New("actor is pinned to another version of the app")
 )
 
 // ActorRuntime is the main runtime for the actors subsystem.
@@ -79,6 +86,8 @@ type ActorRuntime interface {
 	Init(context.Context) error
 	IsActorHosted(ctx context.Context, req *ActorHostedRequest) bool
 	GetRuntimeStatus(ctx context.Context) *runtimev1pb.ActorRuntime
+	// PlacementTableSize returns the number of entries in the actor placement tables.
+	PlacementTableSize() int
 	RegisterInternalActor(ctx context.Context, actorType string, actor InternalActor, actorIdleTimeout time.Duration) error
 }
 
@@ -127,6 +136,7 @@ type actorsRuntime struct {
 	closed               atomic.Bool
 	closeCh              chan struct{}
 	apiLevel             atomic.Uint32
+	versionPins          *versionPins
 
 	// TODO: @joshvanl Remove in Dapr 1.12 when ActorStateTTL is finalized.
 	stateTTLEnabled bool
@@ -188,6 +198,9 @@ func newActorsWithClock(opts ActorsOpts, clock clock.WithTicker) ActorRuntime {
 	a.actorsReminders.SetStateStoreProviderFn(a.stateStore)
 	a.actorsReminders.SetLookupActorFn(a.isActorLocallyHosted)
 
+	// Init version pins
+	a.versionPins = newVersionPins(a.actorsConfig.Config.ActorVersion, a.stateStore)
+
 	// Init timers
 	a.timers.SetExecuteTimerFn(a.executeTimer)
 
@@ -241,14 +254,16 @@ func (a *actorsRuntime) Init(ctx context.Context) error {
 
 	if a.placement == nil {
 		a.placement = placement.NewActorPlacement(placement.ActorPlacementOpts{
-			ServerAddrs:     a.actorsConfig.Config.PlacementAddresses,
-			Security:        a.sec,
-			AppID:           a.actorsConfig.Config.AppID,
-			RuntimeHostname: a.actorsConfig.GetRuntimeHostname(),
-			PodName:         a.actorsConfig.Config.PodName,
-			ActorTypes:      a.actorsConfig.Config.HostedActorTypes.ListActorTypes(),
-			Resiliency:      a.resiliency,
-			AppHealthFn:     a.getAppHealthCheckChan,
+			ServerAddrs:        a.actorsConfig.Config.PlacementAddresses,
+			Security:           a.sec,
+			AppID:              a.actorsConfig.Config.AppID,
+			RuntimeHostname:    a.actorsConfig.GetRuntimeHostname(),
+			PodName:            a.actorsConfig.Config.PodName,
+			ActorTypes:         a.actorsConfig.Config.HostedActorTypes.ListActorTypes(),
+			ActorVersion:       a.actorsConfig.Config.ActorVersion,
+			Resiliency:         a.resiliency,
+			AppHealthFn:        a.getAppHealthCheckChan,
+			GetPinnedVersionFn: a.versionPins.get,
 			AfterTableUpdateFn: func() {
 				a.drainRebalancedActors()
 				a.actorsReminders.OnPlacementTablesUpdated(ctx)
@@ -336,31 +351,20 @@ func (a *actorsRuntime) haltActor(actorType, actorID string) error {
 // Halts all actors
 func (a *actorsRuntime) haltAllActors() error {
 	// Visit all currently active actors and deactivate them
-	errCh := make(chan error)
-	count := atomic.Int32{}
+	eg := utils.NewErrGroup(0)
 	a.actorsTable.Range(func(key any, value any) bool {
-		count.Add(1)
-		go func(key any) {
-			actorKey := key.(string)
+		actorKey := key.(string)
+		eg.Go(func() error {
 			err := a.haltActor(a.getActorTypeAndIDFromKey(actorKey))
 			if err != nil {
-				errCh <- fmt.Errorf("failed to deactivate actor '%s': %v", actorKey, err)
+				return fmt.Errorf("failed to deactivate actor '%s': %v", actorKey, err)
 			}
-			errCh <- nil
-		}(key)
+			return nil
+		})
 		return true
 	})
 
-	// Collect all errors, which also waits for all goroutines to return
-	errs := []error{}
-	for i := int32(0); i < count.Load(); i++ {
-		err := <-errCh
-		if err != nil {
-			errs = append(errs, err)
-		}
-	}
-
-	return errors.Join(errs...)
+	return eg.Wait()
 }
 
 func (a *actorsRuntime) deactivateActor(act *actor) error {
@@ -392,6 +396,11 @@ func (a *actorsRuntime) deactivateActor(act *actor) error {
 	diag.DefaultMonitoring.ActorDeactivated(act.actorType)
 	log.Debugf("Deactivated actor '%s'", actorKey)
 
+	err = a.versionPins.delete(ctx, act.actorType, act.actorID)
+	if err != nil {
+		log.Warnf("Failed to delete the version pin of actor '%s': %v", actorKey, err)
+	}
+
 	// This uses a background context as it should be unrelated from the caller's context - once the actor is deactivated, it should be reported
 	err = a.placement.ReportActorDeactivation(context.Background(), act.actorType, act.actorID)
 	if err != nil {
@@ -462,8 +471,18 @@ func (a *actorsRuntime) Call(ctx context.Context, req *invokev1.InvokeMethodRequ
 	var resp *invokev1.InvokeMethodResponse
 	if a.isActorLocal(lar.Address, a.actorsConfig.Config.HostAddress, a.actorsConfig.Config.Port) {
 		resp, err = a.callLocalActor(ctx, req)
+		if errors.Is(err, errActorVersionMismatch) {
+			// The actor was pinned by another runtime after it was looked up: the pin is now cached, so the actor is redirected to a host of its version
+			resp, err = a.redirectActorCall(ctx, req, err)
+		}
 	} else {
 		resp, err = a.callRemoteActorWithRetry(ctx, retry.DefaultLinearRetryCount, retry.DefaultLinearBackoffInterval, a.callRemoteActor, lar.Address, lar.AppID, req)
+		if err != nil && a.actorsConfig.Config.FailoverMaxAttempts > 0 && status.Code(err) == codes.Unavailable {
+			if resp != nil {
+				resp.Close()
+			}
+			resp, err = a.failoverActorCall(ctx, req, lar, err)
+		}
 	}
 
 	if err != nil {
@@ -479,6 +498,83 @@ func (a *actorsRuntime) Call(ctx context.Context, req *invokev1.InvokeMethodRequ
 	return resp, nil
 }
 
+// redirectActorCall invokes the actor again after it was found to be pinned to a version of the app other than the one of this runtime.
+func (a *actorsRuntime) redirectActorCall(ctx context.Context, req *invokev1.InvokeMethodRequest, callErr error) (*invokev1.InvokeMethodResponse, error) {
+	actor := req.Actor()
+	lar, err := a.placement.LookupActor(ctx, internal.LookupActorRequest{
+		ActorType: actor.GetActorType(),
+		ActorID:   actor.GetActorId(),
+	})
+	if err != nil {
+		return nil, err
+	}
+	if a.isActorLocal(lar.Address, a.actorsConfig.Config.HostAddress, a.actorsConfig.Config.Port) {
+		// No host of the version of the actor is available
+		return nil, callErr
+	}
+
+	log.Debugf("Actor %s/%s is pinned to another version of the app, redirecting it to %s", actor.GetActorType(), actor.GetActorId(), lar.Address)
+	return a.callRemoteActorWithRetry(ctx, retry.DefaultLinearRetryCount, retry.DefaultLinearBackoffInterval, a.callRemoteActor, lar.Address, lar.AppID, req)
+}
+
+// failoverActorCall invokes the actor again after the host it's placed on was found to be unreachable.
+// It waits for the placement tables to be updated, then looks the actor up again: the placement service disseminates new tables once it removes an unreachable host, and the actor is only invoked on the host it's placed on in those tables, so it's never active on two hosts at once.
+// Up to FailoverMaxAttempts updates are waited for; if the actor is still placed on an unreachable host, the error of the last invocation is returned.
+func (a *actorsRuntime) failoverActorCall(ctx context.Context, req *invokev1.InvokeMethodRequest, lar internal.LookupActorResponse, callErr error) (*invokev1.InvokeMethodResponse, error) {
+	actor := req.Actor()
+	if !req.CanReplay() {
+		// The body of the request was already consumed, so it can't be sent again
+		return nil, callErr
+	}
+
+	for i := 0; i < a.actorsConfig.Config.FailoverMaxAttempts; i++ {
+		waitCtx, cancel := context.WithTimeout(ctx, failoverTablesUpdateTimeout)
+		err := a.placement.WaitForTablesUpdate(waitCtx, lar.TablesVersion)
+		cancel()
+		if err != nil {
+			if ctx.Err() != nil {
+				return nil, ctx.Err()
+			}
+			log.Debugf("Placement tables were not updated after host %s of actor %s/%s was found unreachable", lar.Address, actor.GetActorType(), actor.GetActorId())
+			break
+		}
+
+		unreachableAddress := lar.Address
+		lar, err = a.placement.LookupActor(ctx, internal.LookupActorRequest{
+			ActorType: actor.GetActorType(),
+			ActorID:   actor.GetActorId(),
+		})
+		if err != nil {
+			log.Debugf("Failed to look up actor %s/%s in the updated placement tables: %v", actor.GetActorType(), actor.GetActorId(), err)
+			break
+		}
+		if lar.Address == unreachableAddress {
+			// The placement service didn't remove the host yet
+			continue
+		}
+		log.Debugf("Host %s of actor %s/%s is unreachable, failing over to %s", unreachableAddress, actor.GetActorType(), actor.GetActorId(), lar.Address)
+
+		var resp *invokev1.InvokeMethodResponse
+		if a.isActorLocal(lar.Address, a.actorsConfig.Config.HostAddress, a.actorsConfig.Config.Port) {
+			resp, err = a.callLocalActor(ctx, req)
+		} else {
+			resp, err = a.callRemoteActorWithRetry(ctx, retry.DefaultLinearRetryCount, retry.DefaultLinearBackoffInterval, a.callRemoteActor, lar.Address, lar.AppID, req)
+		}
+		if err == nil || status.Code(err) != codes.Unavailable {
+			diag.DefaultMonitoring.ActorFailover(actor.GetActorType(), err == nil)
+			return resp, err
+		}
+
+		if resp != nil {
+			resp.Close()
+		}
+		callErr = err
+	}
+
+	diag.DefaultMonitoring.ActorFailover(actor.GetActorType(), false)
+	return nil, callErr
+}
+
 // callRemoteActorWithRetry will call a remote actor for the specified number of retries and will only retry in the case of transient failures.
 func (a *actorsRuntime) callRemoteActorWithRetry(
 	ctx context.Context,
@@ -570,6 +666,16 @@ func (a *actorsRuntime) callLocalActor(ctx context.Context, req *invokev1.Invoke
 	}
 	defer act.unlock()
 
+	// Pin the actor to the version of the app, so it keeps being routed here when the version routing changes
+	version, err := a.versionPins.pin(ctx, act.actorType, act.actorID)
+	if err != nil {
+		log.Warnf("Failed to pin actor %s to version %s: %v", act.Key(), a.actorsConfig.Config.ActorVersion, err)
+	} else if version != a.actorsConfig.Config.ActorVersion {
+		// The actor is pinned to another version of the app, so it must not be activated here
+		a.removeActorFromTable(act.actorType, act.actorID)
+		return nil, fmt.Errorf("%w: actor %s is pinned to version %s", errActorVersionMismatch, act.Key(), version)
+	}
+
 	// Replace method to actors method.
 	msg := req.Message()
 	originalMethod := msg.GetMethod()
@@ -647,6 +753,7 @@ func (a *actorsRuntime) callRemoteActor(
 
 	span := diagUtils.SpanFromContext(ctx)
 	ctx = diag.SpanContextToGRPCMetadata(ctx, span.SpanContext())
+	ctx = diag.BaggageToGRPCMetadata(ctx)
 	client := internalv1pb.NewServiceInvocationClient(conn)
 
 	pd, err := req.ProtoWithData()
@@ -673,7 +780,8 @@ func (a *actorsRuntime) callRemoteActor(
 
 func (a *actorsRuntime) isActorLocal(targetActorAddress, hostAddress string, grpcPort int) bool {
 	return strings.Contains(targetActorAddress, "localhost") || strings.Contains(targetActorAddress, "127.0.0.1") ||
-		targetActorAddress == hostAddress+":"+strconv.Itoa(grpcPort)
+		strings.Contains(targetActorAddress, "[::1]") ||
+		targetActorAddress == utils.JoinHostPort(hostAddress, grpcPort)
 }
 
 func (a *actorsRuntime) GetState(ctx context.Context, req *GetStateRequest) (*StateResponse, error) {
@@ -946,6 +1054,20 @@ func (a *actorsRuntime) doExecuteReminderOrTimer(ctx context.Context, reminder *
 	}
 	defer req.Close()
 
+	// Reminders and timers are not triggered by a request, so they start a new trace, which is propagated to the app
+	// span is nil if tracing is disabled (sampling rate is 0)
+	ctx, span := diag.StartInternalCallbackSpan(ctx, "actors/"+reminder.ActorType+"/"+logName, trace.SpanContext{}, &a.tracingSpec)
+	if span != nil {
+		diag.AddAttributesToSpan(span, diag.ConstructActorCallbackSpanAttributes(reminder.ActorType, reminder.ActorID, reminder.Name, isTimer))
+		ctx = diag.SpanContextToGRPCMetadata(ctx, span.SpanContext())
+		defer func() {
+			if !errors.Is(err, internal.ErrReminderCanceled) {
+				diag.UpdateSpanStatusFromGRPCError(span, err)
+			}
+			span.End()
+		}()
+	}
+
 	policyRunner := resiliency.NewRunnerWithOptions(ctx, policyDef,
 		resiliency.RunnerOpts[*invokev1.InvokeMethodResponse]{
 			Disposer: resiliency.DisposerCloser[*invokev1.InvokeMethodResponse],
@@ -1051,6 +1173,13 @@ func (a *actorsRuntime) GetRuntimeStatus(ctx context.Context) *runtimev1pb.Actor
 	return res
 }
 
+func (a *actorsRuntime) PlacementTableSize() int {
+	if a.placement == nil {
+		return 0
+	}
+	return a.placement.PlacementTableSize()
+}
+
 func (a *actorsRuntime) getActiveActorsCount(ctx context.Context) []*runtimev1pb.ActiveActorsCount {
 	actorTypes := a.actorsConfig.Config.HostedActorTypes.ListActorTypes()
 	actorCountMap := make(map[string]int32, len(actorTypes))
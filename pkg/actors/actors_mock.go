@@ -74,6 +74,11 @@ func (p *MockPlacement) LookupActor(ctx context.Context, req internal.LookupActo
 	}, nil
 }
 
+// WaitForTablesUpdate implements internal.PlacementService
+func (*MockPlacement) WaitForTablesUpdate(ctx context.Context, version string) error {
+	return nil
+}
+
 // Start implements internal.PlacementService
 func (*MockPlacement) Start(context.Context) error {
 	return nil
@@ -104,6 +109,11 @@ func (*MockPlacement) StatusMessage() string {
 	return ""
 }
 
+// PlacementTableSize implements internal.PlacementService
+func (*MockPlacement) PlacementTableSize() int {
+	return 0
+}
+
 // ReportActorDeactivation implements implements internal.PlacementService
 func (*MockPlacement) ReportActorDeactivation(ctx context.Context, actorType, actorID string) error {
 	return nil
@@ -339,6 +349,12 @@ func (_m *MockActors) GetRuntimeStatus(ctx context.Context) *runtimev1pb.ActorRu
 	}
 }
 
+// PlacementTableSize provides a mock function
+func (_m *MockActors) PlacementTableSize() int {
+	_m.Called()
+	return 0
+}
+
 type FailingActors struct {
 	Failure daprt.Failure
 }
@@ -418,3 +434,7 @@ func (f *FailingActors) GetRuntimeStatus(ctx context.Context) *runtimev1pb.Actor
 		ActiveActors: []*runtimev1pb.ActiveActorsCount{},
 	}
 }
+
+func (f *FailingActors) PlacementTableSize() int {
+	return 0
+}
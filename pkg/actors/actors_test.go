@@ -25,6 +25,11 @@ import (
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"go.opentelemetry.io/otel/trace"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
 	kclock "k8s.io/utils/clock"
@@ -35,6 +40,7 @@ import (
 	"github.com/dapr/dapr/pkg/apis/resiliency/v1alpha1"
 	"github.com/dapr/dapr/pkg/channel"
 	"github.com/dapr/dapr/pkg/config"
+	diagConsts "github.com/dapr/dapr/pkg/diagnostics/consts"
 	"github.com/dapr/dapr/pkg/health"
 	invokev1 "github.com/dapr/dapr/pkg/messaging/v1"
 	"github.com/dapr/dapr/pkg/modes"
@@ -482,6 +488,61 @@ func TestReminderExecution(t *testing.T) {
 	require.NoError(t, err)
 }
 
+// spanContextAppChannel records the span contexts the actor callbacks are invoked with.
+type spanContextAppChannel struct {
+	channel.AppChannel
+	spanContexts []trace.SpanContext
+}
+
+func (c *spanContextAppChannel) InvokeMethod(ctx context.Context, req *invokev1.InvokeMethodRequest, appID string) (*invokev1.InvokeMethodResponse, error) {
+	c.spanContexts = append(c.spanContexts, trace.SpanContextFromContext(ctx))
+	return invokev1.NewInvokeMethodResponse(200, "OK", nil), nil
+}
+
+func TestReminderAndTimerExecutionTracing(t *testing.T) {
+	recorder := tracetest.NewSpanRecorder()
+	oldTracerProvider := otel.GetTracerProvider()
+	otel.SetTracerProvider(sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder)))
+	t.Cleanup(func() {
+		otel.SetTracerProvider(oldTracerProvider)
+	})
+
+	appChannel := &spanContextAppChannel{}
+	testActorsRuntime := newTestActorsRuntimeWithMock(appChannel)
+	defer testActorsRuntime.Close()
+
+	actorType, actorID := getTestActorTypeAndID()
+	fakeCallAndActivateActor(testActorsRuntime, actorType, actorID, testActorsRuntime.clock)
+
+	period, _ := internal.NewReminderPeriod("2s")
+	for _, isTimer := range []bool{false, true} {
+		err := testActorsRuntime.doExecuteReminderOrTimer(context.Background(), &internal.Reminder{
+			ActorType: actorType,
+			ActorID:   actorID,
+			Name:      "callback1",
+			Period:    period,
+			Data:      json.RawMessage(`"data"`),
+		}, isTimer)
+		require.NoError(t, err)
+	}
+
+	spans := recorder.Ended()
+	require.Len(t, spans, 2)
+	require.Len(t, appChannel.spanContexts, 2)
+	for i, span := range spans {
+		// Each callback starts a new trace, which is propagated to the app
+		assert.False(t, span.Parent().IsValid())
+		assert.Equal(t, span.SpanContext().TraceID(), appChannel.spanContexts[i].TraceID())
+		assert.Contains(t, span.Attributes(), attribute.String(diagConsts.DaprAPIActorTypeID, actorType+"."+actorID))
+	}
+	assert.NotEqual(t, spans[0].SpanContext().TraceID(), spans[1].SpanContext().TraceID())
+
+	assert.Equal(t, "actors/"+actorType+"/reminder", spans[0].Name())
+	assert.Contains(t, spans[0].Attributes(), attribute.String(diagConsts.DaprAPIActorReminderName, "callback1"))
+	assert.Equal(t, "actors/"+actorType+"/timer", spans[1].Name())
+	assert.Contains(t, spans[1].Attributes(), attribute.String(diagConsts.DaprAPIActorTimerName, "callback1"))
+}
+
 func TestConstructActorStateKey(t *testing.T) {
 	delim := "||"
 	testActorsRuntime := newTestActorsRuntime()
@@ -835,6 +896,77 @@ func TestCallLocalActor(t *testing.T) {
 	})
 }
 
+func TestFailoverActorCall(t *testing.T) {
+	const (
+		testActorType = "pet"
+		testActorID   = "dog"
+		testMethod    = "bite"
+	)
+
+	unreachableErr := status.Error(codes.Unavailable, "connection refused")
+
+	t.Run("fails over to the new host", func(t *testing.T) {
+		testActorsRuntime := newTestActorsRuntime()
+		defer testActorsRuntime.Close()
+		testActorsRuntime.actorsConfig.Config.FailoverMaxAttempts = 1
+
+		// Replay is enabled by the first invocation of the remote actor
+		req := invokev1.NewInvokeMethodRequest(testMethod).
+			WithActor(testActorType, testActorID).
+			WithReplay(true)
+		defer req.Close()
+
+		// The mock placement resolves the actor to the local host in the updated tables
+		resp, err := testActorsRuntime.failoverActorCall(context.Background(), req, internal.LookupActorResponse{Address: "10.0.0.1:50002"}, unreachableErr)
+		require.NoError(t, err)
+		assert.NotNil(t, resp)
+		defer resp.Close()
+	})
+
+	t.Run("does not fail over while the actor is placed on the same host", func(t *testing.T) {
+		testActorsRuntime := newTestActorsRuntime()
+		defer testActorsRuntime.Close()
+		testActorsRuntime.actorsConfig.Config.FailoverMaxAttempts = 2
+
+		req := invokev1.NewInvokeMethodRequest(testMethod).
+			WithActor(testActorType, testActorID).
+			WithReplay(true)
+		defer req.Close()
+
+		// The mock placement resolves the actor to the unreachable host
+		resp, err := testActorsRuntime.failoverActorCall(context.Background(), req, internal.LookupActorResponse{Address: "localhost"}, unreachableErr)
+		require.ErrorIs(t, err, unreachableErr)
+		assert.Nil(t, resp)
+	})
+
+	t.Run("does not fail over requests that can't be replayed", func(t *testing.T) {
+		testActorsRuntime := newTestActorsRuntime()
+		defer testActorsRuntime.Close()
+		testActorsRuntime.actorsConfig.Config.FailoverMaxAttempts = 1
+
+		req := invokev1.NewInvokeMethodRequest(testMethod).
+			WithActor(testActorType, testActorID).
+			WithRawData(strings.NewReader("data"))
+		defer req.Close()
+
+		resp, err := testActorsRuntime.failoverActorCall(context.Background(), req, internal.LookupActorResponse{Address: "10.0.0.1:50002"}, unreachableErr)
+		require.ErrorIs(t, err, unreachableErr)
+		assert.Nil(t, resp)
+	})
+
+	t.Run("returns the error when there are no attempts left", func(t *testing.T) {
+		testActorsRuntime := newTestActorsRuntime()
+		defer testActorsRuntime.Close()
+
+		req := invokev1.NewInvokeMethodRequest(testMethod).WithActor(testActorType, testActorID)
+		defer req.Close()
+
+		resp, err := testActorsRuntime.failoverActorCall(context.Background(), req, internal.LookupActorResponse{Address: "10.0.0.1:50002"}, unreachableErr)
+		require.ErrorIs(t, err, unreachableErr)
+		assert.Nil(t, resp)
+	})
+}
+
 func TestTransactionalState(t *testing.T) {
 	ctx := context.Background()
 	t.Run("Single set request succeeds", func(t *testing.T) {
@@ -48,6 +48,9 @@ type ConfigOpts struct {
 	HealthEndpoint     string
 	AppChannelAddress  string
 	PodName            string
+	ActorVersion       string
+	// Maximum number of placement table updates an invocation waits for when the host of the actor is unreachable; 0 disables failover.
+	FailoverMaxAttempts int
 }
 
 // NewConfig returns the actor runtime configuration.
@@ -71,6 +74,8 @@ func NewConfig(opts ConfigOpts) Config {
 		EntityConfigs:                 make(map[string]internal.EntityConfig),
 		AppChannelAddress:             opts.AppChannelAddress,
 		PodName:                       opts.PodName,
+		ActorVersion:                  opts.ActorVersion,
+		FailoverMaxAttempts:           opts.FailoverMaxAttempts,
 	}
 
 	scanDuration, err := time.ParseDuration(opts.AppConfig.ActorScanInterval)
@@ -45,6 +45,8 @@ type Config struct {
 	HealthEndpoint                string
 	AppChannelAddress             string
 	PodName                       string
+	ActorVersion                  string
+	FailoverMaxAttempts           int
 }
 
 func (c Config) GetRuntimeHostname() string {
@@ -32,6 +32,8 @@ type PlacementService interface {
 	Start(context.Context) error
 	WaitUntilReady(ctx context.Context) error
 	LookupActor(ctx context.Context, req LookupActorRequest) (LookupActorResponse, error)
+	// WaitForTablesUpdate waits until the placement tables are updated from the given version.
+	WaitForTablesUpdate(ctx context.Context, version string) error
 	AddHostedActorType(actorType string, idleTimeout time.Duration) error
 	ReportActorDeactivation(ctx context.Context, actorType, actorID string) error
 
@@ -42,6 +44,8 @@ type PlacementService interface {
 	PlacementHealthy() bool
 	// StatusMessage returns a custom status message.
 	StatusMessage() string
+	// PlacementTableSize returns the number of entries in the placement tables.
+	PlacementTableSize() int
 }
 
 // LookupActorRequest is the request for LookupActor.
@@ -59,4 +63,6 @@ func (lar LookupActorRequest) ActorKey() string {
 type LookupActorResponse struct {
 	Address string
 	AppID   string
+	// TablesVersion is the version of the placement tables the actor was looked up in.
+	TablesVersion string
 }
@@ -23,7 +23,10 @@ import (
 type ReminderTrack struct {
 	LastFiredTime  time.Time `json:"lastFiredTime"`
 	RepetitionLeft int       `json:"repetitionLeft"`
-	Etag           *string   `json:",omitempty"`
+	// FencingToken is incremented every time a host takes ownership of the reminder.
+	// A host that sees a token different from the one it acquired must stop executing the reminder.
+	FencingToken int64   `json:"fencingToken,omitempty"`
+	Etag         *string `json:",omitempty"`
 }
 
 func (r *ReminderTrack) MarshalJSON() ([]byte, error) {
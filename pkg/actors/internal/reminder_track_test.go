@@ -31,6 +31,7 @@ func TestReminderTrackJSON(t *testing.T) {
 	type fields struct {
 		LastFiredTime  time.Time
 		RepetitionLeft int
+		FencingToken   int64
 		Etag           *string
 	}
 	tests := []struct {
@@ -48,12 +49,18 @@ func TestReminderTrackJSON(t *testing.T) {
 			fields: fields{LastFiredTime: time1, RepetitionLeft: 2, Etag: ptr.Of("foo")},
 			want:   `{"lastFiredTime":"2023-03-07T18:29:04Z","repetitionLeft":2,"Etag":"foo"}`,
 		},
+		{
+			name:   "has fencing token",
+			fields: fields{LastFiredTime: time1, RepetitionLeft: 2, FencingToken: 3},
+			want:   `{"lastFiredTime":"2023-03-07T18:29:04Z","repetitionLeft":2,"fencingToken":3}`,
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			r := ReminderTrack{
 				LastFiredTime:  tt.fields.LastFiredTime,
 				RepetitionLeft: tt.fields.RepetitionLeft,
+				FencingToken:   tt.fields.FencingToken,
 				Etag:           tt.fields.Etag,
 			}
 
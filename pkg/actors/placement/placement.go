@@ -63,6 +63,13 @@ type actorPlacement struct {
 	runtimeHostName string
 	// name of the pod hosting the actor
 	podName string
+	// actorVersion is the version of the app, reported to the placement service.
+	actorVersion string
+	// versionRouter routes actors to the versions of the apps hosting them, according to the rules in the placement tables.
+	// It is protected by placementTableLock.
+	versionRouter versionRouter
+	// getPinnedVersionFn returns the version of the app an actor is pinned to, if any.
+	getPinnedVersionFn func(ctx context.Context, actorType, actorID string) (string, error)
 
 	// client is the placement client.
 	client *placementClient
@@ -75,10 +82,15 @@ type actorPlacement struct {
 	// placementTables is the consistent hashing table map to
 	// look up Dapr runtime host address to locate actor.
 	placementTables *hashing.ConsistentHashTables
+	// versionTables contains the consistent hashing tables of the hosts running each version of the app,
+	// for the actor types routed by version. It is protected by placementTableLock.
+	versionTables map[string]map[string]*hashing.Consistent
 	// placementTableLock is the lock for placementTables.
 	placementTableLock sync.RWMutex
 	// hasPlacementTablesCh is closed when the placement tables have been received.
 	hasPlacementTablesCh chan struct{}
+	// tablesUpdatedCh is closed, and replaced, every time the placement tables are updated. It is protected by placementTableLock.
+	tablesUpdatedCh chan struct{}
 
 	// apiLevel is the current API level of the cluster
 	apiLevel uint32
@@ -119,9 +131,13 @@ type ActorPlacementOpts struct {
 	RuntimeHostname    string
 	PodName            string
 	ActorTypes         []string
+	ActorVersion       string
 	AppHealthFn        func(ctx context.Context) <-chan bool
 	AfterTableUpdateFn func()
 	Resiliency         resiliency.Provider
+	// GetPinnedVersionFn returns the version of the app an actor was activated on, if any.
+	// Actors routed by version are pinned to that version, so changing the routing doesn't move actors that are already active.
+	GetPinnedVersionFn func(ctx context.Context, actorType, actorID string) (string, error)
 }
 
 // NewActorPlacement initializes ActorPlacement for the actor service.
@@ -132,16 +148,21 @@ func NewActorPlacement(opts ActorPlacementOpts) internal.PlacementService {
 		appID:           opts.AppID,
 		runtimeHostName: opts.RuntimeHostname,
 		podName:         opts.PodName,
+		actorVersion:    opts.ActorVersion,
+		versionRouter:   newVersionRouter(nil),
 		serverAddr:      servers,
 
 		client:          newPlacementClient(getGrpcOptsGetter(servers, opts.Security)),
 		placementTables: &hashing.ConsistentHashTables{Entries: make(map[string]*hashing.Consistent)},
+		versionTables:   make(map[string]map[string]*hashing.Consistent),
+		tablesUpdatedCh: make(chan struct{}),
 
 		unblockSignal:      make(chan struct{}, 1),
 		appHealthFn:        opts.AppHealthFn,
 		afterTableUpdateFn: opts.AfterTableUpdateFn,
 		closeCh:            make(chan struct{}),
 		resiliency:         opts.Resiliency,
+		getPinnedVersionFn: opts.GetPinnedVersionFn,
 	}
 }
 
@@ -156,6 +177,20 @@ func (p *actorPlacement) StatusMessage() string {
 	return "placement: disconnected"
 }
 
+// PlacementTableSize returns the number of entries in the placement tables, which is the number of hosts summed over the actor types.
+func (p *actorPlacement) PlacementTableSize() int {
+	p.placementTableLock.RLock()
+	defer p.placementTableLock.RUnlock()
+
+	n := 0
+	for _, t := range p.placementTables.Entries {
+		t.ReadInternals(func(_ map[uint64]string, _ []uint64, loadMap map[string]*hashing.Host, _ int64) {
+			n += len(loadMap)
+		})
+	}
+	return n
+}
+
 // Register an actor type by adding it to the list of known actor types (if it's not already registered)
 // The placement tables will get updated when the next heartbeat fires
 func (p *actorPlacement) AddHostedActorType(actorType string, idleTimeout time.Duration) error {
@@ -292,7 +327,8 @@ func (p *actorPlacement) Start(ctx context.Context) error {
 				Pod:      p.podName,
 				// Port is redundant because Name should include port number
 				// Port: 0,
-				ApiLevel: internal.ActorAPILevel,
+				ApiLevel:     internal.ActorAPILevel,
+				ActorVersion: p.actorVersion,
 			}
 
 			err := p.client.send(&host)
@@ -359,7 +395,8 @@ func (p *actorPlacement) LookupActor(ctx context.Context, req internal.LookupAct
 	policyDef := p.resiliency.BuiltInPolicy(resiliency.BuiltInActorNotFoundRetries)
 	policyRunner := resiliency.NewRunner[internal.LookupActorResponse](ctx, policyDef)
 	return policyRunner(func(ctx context.Context) (res internal.LookupActorResponse, rErr error) {
-		rAddr, rAppID, rErr := p.doLookupActor(ctx, req.ActorType, req.ActorID)
+		pinnedVersion := p.getPinnedVersion(ctx, req.ActorType, req.ActorID)
+		rAddr, rAppID, rVersion, rErr := p.doLookupActor(ctx, req.ActorType, req.ActorID, pinnedVersion)
 		if rErr != nil {
 			return res, fmt.Errorf("error finding address for actor %s/%s: %w", req.ActorType, req.ActorID, rErr)
 		} else if rAddr == "" {
@@ -367,27 +404,85 @@ func (p *actorPlacement) LookupActor(ctx context.Context, req internal.LookupAct
 		}
 		res.Address = rAddr
 		res.AppID = rAppID
+		res.TablesVersion = rVersion
 		return res, nil
 	})
 }
 
-func (p *actorPlacement) doLookupActor(ctx context.Context, actorType, actorID string) (string, string, error) {
+// WaitForTablesUpdate waits until the placement tables are updated from the given version.
+// It returns immediately if they were already updated.
+func (p *actorPlacement) WaitForTablesUpdate(ctx context.Context, version string) error {
+	p.placementTableLock.RLock()
+	updatedCh := p.tablesUpdatedCh
+	current := p.placementTables.Version
+	p.placementTableLock.RUnlock()
+
+	if current != version {
+		return nil
+	}
+	select {
+	case <-updatedCh:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// getPinnedVersion returns the version of the app the actor is pinned to, or an empty string if it's not pinned or its type isn't routed by version.
+func (p *actorPlacement) getPinnedVersion(ctx context.Context, actorType, actorID string) string {
+	if p.getPinnedVersionFn == nil {
+		return ""
+	}
+	p.placementTableLock.RLock()
+	routed := p.versionRouter.hasRule(actorType)
+	p.placementTableLock.RUnlock()
+	if !routed {
+		return ""
+	}
+
+	// If the pin can't be read, the actor is routed by the rules: that's where it was activated, unless the rules changed since
+	version, err := p.getPinnedVersionFn(ctx, actorType, actorID)
+	if err != nil {
+		log.Warnf("Failed to get the version actor %s/%s is pinned to: %v", actorType, actorID, err)
+		return ""
+	}
+	return version
+}
+
+func (p *actorPlacement) doLookupActor(ctx context.Context, actorType, actorID, pinnedVersion string) (string, string, string, error) {
 	p.placementTableLock.RLock()
 	defer p.placementTableLock.RUnlock()
 
 	if p.placementTables == nil {
-		return "", "", errors.New("placement tables are not set")
+		return "", "", "", errors.New("placement tables are not set")
 	}
 
 	t := p.placementTables.Entries[actorType]
 	if t == nil {
-		return "", "", nil
+		return "", "", "", nil
 	}
+	version := p.placementTables.Version
+
+	// If the actor type is routed by version, look up the actor among the hosts running the version it's pinned to, or else routed to.
+	// When no host is running that version, the actor is placed on any host.
+	actorVersion, ok := pinnedVersion, pinnedVersion != ""
+	if !ok {
+		actorVersion, ok = p.versionRouter.pickVersion(actorType, actorID)
+	}
+	if ok {
+		if vt := p.versionTables[actorType][actorVersion]; vt != nil {
+			host, err := vt.GetHost(actorID)
+			if err == nil && host != nil {
+				return host.Name, host.AppID, version, nil
+			}
+		}
+	}
+
 	host, err := t.GetHost(actorID)
 	if err != nil || host == nil {
-		return "", "", nil //nolint:nilerr
+		return "", "", "", nil //nolint:nilerr
 	}
-	return host.Name, host.AppID, nil
+	return host.Name, host.AppID, version, nil
 }
 
 //nolint:nosnakecase
@@ -528,6 +623,7 @@ func (p *actorPlacement) resetPlacementTables() {
 	}
 	p.hasPlacementTablesCh = make(chan struct{})
 	maps.Clear(p.placementTables.Entries)
+	maps.Clear(p.versionTables)
 	p.placementTables.Version = ""
 }
 
@@ -548,16 +644,33 @@ func (p *actorPlacement) updatePlacements(in *v1pb.PlacementTables) {
 		}
 
 		maps.Clear(p.placementTables.Entries)
+		maps.Clear(p.versionTables)
 		p.placementTables.Version = in.GetVersion()
+		routing := make(map[string][]*v1pb.ActorVersionWeight)
 		for k, v := range in.GetEntries() {
 			loadMap := make(map[string]*hashing.Host, len(v.GetLoadMap()))
+			versions := make(map[string]struct{})
 			for lk, lv := range v.GetLoadMap() {
 				loadMap[lk] = hashing.NewHost(lv.GetName(), lv.GetId(), lv.GetLoad(), lv.GetPort())
+				loadMap[lk].ActorVersion = lv.GetActorVersion()
+				versions[lv.GetActorVersion()] = struct{}{}
 			}
 			p.placementTables.Entries[k] = hashing.NewFromExisting(v.GetHosts(), v.GetSortedSet(), loadMap)
+
+			// Tables are kept for all the versions the hosts run, since actors can be pinned to versions the rules don't route to anymore
+			if len(v.GetVersionRouting()) > 0 {
+				routing[k] = v.GetVersionRouting()
+				p.versionTables[k] = make(map[string]*hashing.Consistent, len(versions))
+				for version := range versions {
+					p.versionTables[k][version] = p.placementTables.Entries[k].FilterVersion(version)
+				}
+			}
 		}
+		p.versionRouter = newVersionRouter(routing)
 
 		updated = true
+		close(p.tablesUpdatedCh)
+		p.tablesUpdatedCh = make(chan struct{})
 		if p.hasPlacementTablesCh != nil {
 			close(p.hasPlacementTablesCh)
 			p.hasPlacementTablesCh = nil
@@ -19,6 +19,7 @@ import (
 	"fmt"
 	"io"
 	"net"
+	"strconv"
 	"sync"
 	"sync/atomic"
 	"testing"
@@ -412,6 +413,162 @@ func TestLookupActor(t *testing.T) {
 		assert.Empty(t, lar.Address)
 		assert.Empty(t, lar.AppID)
 	})
+
+	t.Run("routes actors to versions", func(t *testing.T) {
+		hashing.SetReplicationFactor(10)
+		actorOneHashing := hashing.NewConsistentHash()
+		actorOneHashing.AddWithVersion("127.0.0.1:1001", "testAppID", "v1", 0)
+		actorOneHashing.AddWithVersion("127.0.0.1:1002", "testAppID", "v2", 0)
+
+		testPlacement.placementTables = &hashing.ConsistentHashTables{
+			Version: "2",
+			Entries: map[string]*hashing.Consistent{"actorOne": actorOneHashing},
+		}
+		testPlacement.versionRouter = newVersionRouter(map[string][]*placementv1pb.ActorVersionWeight{
+			"actorOne": {{Version: "v2", Weight: 100}},
+		})
+		testPlacement.versionTables = map[string]map[string]*hashing.Consistent{
+			"actorOne": {
+				"v1": actorOneHashing.FilterVersion("v1"),
+				"v2": actorOneHashing.FilterVersion("v2"),
+			},
+		}
+		defer func() {
+			testPlacement.versionRouter = newVersionRouter(nil)
+			testPlacement.versionTables = map[string]map[string]*hashing.Consistent{}
+			testPlacement.getPinnedVersionFn = nil
+		}()
+
+		for i := 0; i < 10; i++ {
+			lar, err := testPlacement.LookupActor(context.Background(), internal.LookupActorRequest{
+				ActorType: "actorOne",
+				ActorID:   "id" + strconv.Itoa(i),
+			})
+			require.NoError(t, err)
+			assert.Equal(t, "127.0.0.1:1002", lar.Address)
+		}
+
+		// Actors pinned to a version stay on it, even if the rules route them to another one
+		testPlacement.getPinnedVersionFn = func(ctx context.Context, actorType, actorID string) (string, error) {
+			if actorID == "pinned" {
+				return "v1", nil
+			}
+			return "", nil
+		}
+		lar, err := testPlacement.LookupActor(context.Background(), internal.LookupActorRequest{
+			ActorType: "actorOne",
+			ActorID:   "pinned",
+		})
+		require.NoError(t, err)
+		assert.Equal(t, "127.0.0.1:1001", lar.Address)
+		lar, err = testPlacement.LookupActor(context.Background(), internal.LookupActorRequest{
+			ActorType: "actorOne",
+			ActorID:   "id0",
+		})
+		require.NoError(t, err)
+		assert.Equal(t, "127.0.0.1:1002", lar.Address)
+
+		// Falls back to any host if no host runs the version
+		testPlacement.versionTables["actorOne"]["v2"] = hashing.NewConsistentHash()
+		lar, err = testPlacement.LookupActor(context.Background(), internal.LookupActorRequest{
+			ActorType: "actorOne",
+			ActorID:   "id0",
+		})
+		require.NoError(t, err)
+		assert.NotEmpty(t, lar.Address)
+	})
+
+	t.Run("uses the version routing of the tables", func(t *testing.T) {
+		testPlacement.updatePlacements(&placementv1pb.PlacementTables{
+			Version: "routed",
+			Entries: map[string]*placementv1pb.PlacementTable{
+				"actorOne": {
+					LoadMap: map[string]*placementv1pb.Host{
+						"127.0.0.1:1001": {Name: "127.0.0.1:1001", Id: "testAppID", ActorVersion: "v1"},
+						"127.0.0.1:1002": {Name: "127.0.0.1:1002", Id: "testAppID", ActorVersion: "v2"},
+					},
+					VersionRouting: []*placementv1pb.ActorVersionWeight{{Version: "v2", Weight: 100}},
+				},
+				"actorTwo": {},
+			},
+		})
+		defer func() {
+			testPlacement.versionRouter = newVersionRouter(nil)
+			testPlacement.versionTables = map[string]map[string]*hashing.Consistent{}
+		}()
+
+		assert.True(t, testPlacement.versionRouter.hasRule("actorOne"))
+		assert.False(t, testPlacement.versionRouter.hasRule("actorTwo"))
+		// Tables are kept for all the versions the hosts run, including the ones the rules don't route to
+		assert.Len(t, testPlacement.versionTables["actorOne"], 2)
+		assert.NotContains(t, testPlacement.versionTables, "actorTwo")
+	})
+
+	t.Run("returns the version of the tables", func(t *testing.T) {
+		hashing.SetReplicationFactor(10)
+		actorOneHashing := hashing.NewConsistentHash()
+		actorOneHashing.Add("127.0.0.1:1001", "testAppID", 0)
+
+		testPlacement.placementTables = &hashing.ConsistentHashTables{
+			Version: "3",
+			Entries: map[string]*hashing.Consistent{"actorOne": actorOneHashing},
+		}
+
+		lar, err := testPlacement.LookupActor(context.Background(), internal.LookupActorRequest{
+			ActorType: "actorOne",
+			ActorID:   "id0",
+		})
+		require.NoError(t, err)
+		assert.Equal(t, "3", lar.TablesVersion)
+	})
+}
+
+func TestWaitForTablesUpdate(t *testing.T) {
+	testPlacement := NewActorPlacement(ActorPlacementOpts{
+		ServerAddrs:     []string{},
+		AppID:           "testAppID",
+		RuntimeHostname: "127.0.0.1:1000",
+		PodName:         "testPodName",
+		ActorTypes:      []string{"actorOne"},
+		AppHealthFn:     func(ctx context.Context) <-chan bool { return nil },
+		Security:        testSecurity(t),
+		Resiliency:      resiliency.New(logger.NewLogger("test")),
+	}).(*actorPlacement)
+	testPlacement.updatePlacements(&placementv1pb.PlacementTables{
+		Version: "1",
+		Entries: map[string]*placementv1pb.PlacementTable{},
+	})
+
+	t.Run("returns immediately if the tables were already updated", func(t *testing.T) {
+		require.NoError(t, testPlacement.WaitForTablesUpdate(context.Background(), "0"))
+	})
+
+	t.Run("times out if the tables are not updated", func(t *testing.T) {
+		ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+		defer cancel()
+		require.ErrorIs(t, testPlacement.WaitForTablesUpdate(ctx, "1"), context.DeadlineExceeded)
+	})
+
+	t.Run("returns when the tables are updated", func(t *testing.T) {
+		errCh := make(chan error, 1)
+		go func() {
+			errCh <- testPlacement.WaitForTablesUpdate(context.Background(), "1")
+		}()
+
+		// Wait for the goroutine to start waiting
+		time.Sleep(50 * time.Millisecond)
+		testPlacement.updatePlacements(&placementv1pb.PlacementTables{
+			Version: "2",
+			Entries: map[string]*placementv1pb.PlacementTable{},
+		})
+
+		select {
+		case err := <-errCh:
+			require.NoError(t, err)
+		case <-time.After(5 * time.Second):
+			t.Fatal("did not return after the tables were updated")
+		}
+	})
 }
 
 func TestConcurrentUnblockPlacements(t *testing.T) {
@@ -0,0 +1,86 @@
+/*
+Copyright 2023 The Dapr Authors
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package placement
+
+import (
+	"hash/fnv"
+
+	v1pb "github.com/dapr/dapr/pkg/proto/placement/v1"
+)
+
+// versionBuckets is the number of buckets actor IDs are hashed into.
+// Each version is assigned a contiguous range of buckets, proportional to its weight, so changing the weights only moves the actors in the buckets that change version.
+const versionBuckets = 10_000
+
+// versionRouter picks the version of the app that actors of a type are routed to, according to the weighted rules disseminated by the placement service.
+// Each actor ID is assigned to a version deterministically, so all the runtimes route an actor to the same version.
+type versionRouter struct {
+	rules map[string]versionRule
+}
+
+type versionRule struct {
+	versions []*v1pb.ActorVersionWeight
+	total    uint64
+}
+
+// newVersionRouter returns a router for the version routing of the entries of the placement tables.
+func newVersionRouter(routing map[string][]*v1pb.ActorVersionWeight) versionRouter {
+	r := versionRouter{
+		rules: make(map[string]versionRule, len(routing)),
+	}
+	for actorType, weights := range routing {
+		var vr versionRule
+		for _, w := range weights {
+			if w.GetWeight() <= 0 {
+				continue
+			}
+			vr.versions = append(vr.versions, w)
+			vr.total += uint64(w.GetWeight())
+		}
+		if vr.total == 0 {
+			continue
+		}
+		r.rules[actorType] = vr
+	}
+	return r
+}
+
+// hasRule returns true if actors of the type are routed by version.
+func (r versionRouter) hasRule(actorType string) bool {
+	_, ok := r.rules[actorType]
+	return ok
+}
+
+// pickVersion returns the version the actor is routed to.
+// The second return value is false if there's no rule for the actor type.
+func (r versionRouter) pickVersion(actorType, actorID string) (string, bool) {
+	rule, ok := r.rules[actorType]
+	if !ok {
+		return "", false
+	}
+
+	h := fnv.New32a()
+	h.Write([]byte(actorID))
+	bucket := uint64(h.Sum32() % versionBuckets)
+	var cumulative uint64
+	for _, v := range rule.versions {
+		cumulative += uint64(v.GetWeight())
+		if bucket < cumulative*versionBuckets/rule.total {
+			return v.GetVersion(), true
+		}
+	}
+
+	// Should never get here
+	return rule.versions[len(rule.versions)-1].GetVersion(), true
+}
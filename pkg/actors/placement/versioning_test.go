@@ -0,0 +1,79 @@
+/*
+Copyright 2023 The Dapr Authors
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package placement
+
+import (
+	"strconv"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	v1pb "github.com/dapr/dapr/pkg/proto/placement/v1"
+)
+
+func TestVersionRouter(t *testing.T) {
+	r := newVersionRouter(map[string][]*v1pb.ActorVersionWeight{
+		"weighted": {
+			{Version: "v1", Weight: 80},
+			{Version: "v2", Weight: 20},
+		},
+		"disabled": {
+			{Version: "v1", Weight: 0},
+		},
+	})
+
+	t.Run("no rule", func(t *testing.T) {
+		assert.False(t, r.hasRule("other"))
+		assert.False(t, r.hasRule("disabled"))
+		_, ok := r.pickVersion("other", "id")
+		assert.False(t, ok)
+	})
+
+	t.Run("actors are split by weight", func(t *testing.T) {
+		counts := map[string]int{}
+		for i := 0; i < 10000; i++ {
+			v, ok := r.pickVersion("weighted", "actor-"+strconv.Itoa(i))
+			assert.True(t, ok)
+			counts[v]++
+		}
+		assert.InDelta(t, 8000, counts["v1"], 300)
+		assert.InDelta(t, 2000, counts["v2"], 300)
+	})
+
+	t.Run("actors are routed to the same version", func(t *testing.T) {
+		v1, _ := r.pickVersion("weighted", "myactor")
+		for i := 0; i < 10; i++ {
+			v, _ := r.pickVersion("weighted", "myactor")
+			assert.Equal(t, v1, v)
+		}
+	})
+
+	t.Run("changing the weights only moves the actors of the shifted range", func(t *testing.T) {
+		shifted := newVersionRouter(map[string][]*v1pb.ActorVersionWeight{
+			"weighted": {
+				{Version: "v1", Weight: 70},
+				{Version: "v2", Weight: 30},
+			},
+		})
+		for i := 0; i < 10000; i++ {
+			id := "actor-" + strconv.Itoa(i)
+			before, _ := r.pickVersion("weighted", id)
+			after, _ := shifted.pickVersion("weighted", id)
+			if before == "v2" {
+				// Actors routed to the version whose weight grew never move
+				assert.Equal(t, "v2", after)
+			}
+		}
+	})
+}
@@ -35,6 +35,7 @@ import (
 	diag "github.com/dapr/dapr/pkg/diagnostics"
 	internalv1pb "github.com/dapr/dapr/pkg/proto/internals/v1"
 	"github.com/dapr/dapr/pkg/resiliency"
+	"github.com/dapr/dapr/utils"
 	"github.com/dapr/kit/logger"
 	"github.com/dapr/kit/retry"
 )
@@ -44,6 +45,12 @@ var log = logger.NewLogger("dapr.runtime.actor.reminders")
 const (
 	daprSeparator        = "||"
 	metadataPartitionKey = "partitionKey"
+
+	// Maximum number of attempts to update a reminder track when there's an etag mismatch.
+	maxReminderTrackAttempts = 3
+
+	// Time after which a one-shot reminder is fired again when its tick couldn't be claimed.
+	reminderClaimRetryInterval = 5 * time.Second
 )
 
 type remindersMetricsCollectorFn = func(actorType string, reminders int64)
@@ -65,6 +72,7 @@ type reminders struct {
 	config               internal.Config
 	lookUpActorFn        internal.LookupActorFn
 	metricsCollector     remindersMetricsCollectorFn
+	getRemindersFlight   utils.SingleFlight[[]ActorReminderReference]
 }
 
 // NewRemindersProviderOpts contains the options for the NewRemindersProvider function.
@@ -220,7 +228,12 @@ func (r *reminders) Init(ctx context.Context) error {
 }
 
 func (r *reminders) GetReminder(ctx context.Context, req *internal.GetReminderRequest) (*internal.Reminder, error) {
-	list, _, err := r.getRemindersForActorType(ctx, req.ActorType, false)
+	// Concurrent requests for reminders of the same actor type share a single load from the state store
+	// The list is shared, so it must not be modified
+	list, err := r.getRemindersFlight.Do(req.ActorType, func() ([]ActorReminderReference, error) {
+		list, _, err := r.getRemindersForActorType(ctx, req.ActorType, false)
+		return list, err
+	})
 	if err != nil {
 		return nil, err
 	}
@@ -989,9 +1002,9 @@ func constructCompositeKey(keys ...string) string {
 func (r *reminders) startReminder(reminder *internal.Reminder, stopChannel chan struct{}) error {
 	reminderKey := reminder.Key()
 
-	track, err := r.getReminderTrack(context.TODO(), reminderKey)
+	track, err := r.acquireReminderTrack(context.TODO(), reminderKey)
 	if err != nil {
-		return fmt.Errorf("error getting reminder track: %w", err)
+		return fmt.Errorf("error acquiring reminder track: %w", err)
 	}
 
 	reminder.UpdateFromTrack(track)
@@ -1001,7 +1014,7 @@ func (r *reminders) startReminder(reminder *internal.Reminder, stopChannel chan
 			nextTimer clock.Timer
 			err       error
 		)
-		eTag := track.Etag
+		fencingToken := track.FencingToken
 
 		nextTick, active := reminder.NextTick()
 		if !active {
@@ -1044,25 +1057,43 @@ func (r *reminders) startReminder(reminder *internal.Reminder, stopChannel chan
 				break loop
 			}
 
+			// Record the tick before executing the reminder, so it can't fire again on a host that acquired it during a rebalancing
+			var owned bool
+			owned, err = r.claimReminderTick(context.TODO(), reminderKey, fencingToken, reminder.RepeatsLeft(), nextTick)
+			if err != nil {
+				// The tick couldn't be claimed because of a transient error: only this tick is skipped
+				log.Errorf("Error claiming reminder track for reminder %s, skipping the tick at %s: %v", reminderKey, nextTick.Format(time.RFC3339), err)
+				if reminder.HasRepeats() {
+					reminder.RegisteredTime = reminder.Period.GetFollowing(reminder.RegisteredTime)
+					nextTick, active = reminder.NextTick()
+					if !active {
+						log.Infof("Reminder %s with parameters: dueTime: %s, period: %s has expired", reminderKey, reminder.DueTime, reminder.Period)
+						nextTimer = nil
+						break loop
+					}
+				} else {
+					nextTick = r.clock.Now().Add(reminderClaimRetryInterval)
+				}
+				nextTimer.Reset(nextTick.Sub(r.clock.Now()))
+				continue
+			}
+			if !owned {
+				// The reminder was fenced by another host that acquired it, so it is not executed here anymore
+				log.Infof("Reminder %s has been acquired by another host and will not be executed on this host", reminderKey)
+				diag.DefaultMonitoring.ActorReminderFenced(reminder.ActorType)
+				r.activeReminders.CompareAndDelete(reminderKey, stopChannel)
+				nextTimer = nil
+				return
+			}
+
 			if r.executeReminderFn != nil && !r.executeReminderFn(reminder) {
 				nextTimer = nil
 				break loop
 			}
 
 			_, exists = r.activeReminders.Load(reminderKey)
-			if exists {
-				err = r.updateReminderTrack(context.TODO(), reminderKey, reminder.RepeatsLeft(), nextTick, eTag)
-				if err != nil {
-					log.Errorf("Error updating reminder track for reminder %s: %v", reminderKey, err)
-				}
-				track, gErr := r.getReminderTrack(context.TODO(), reminderKey)
-				if gErr != nil {
-					log.Errorf("Error retrieving reminder %s: %v", reminderKey, gErr)
-				} else {
-					eTag = track.Etag
-				}
-			} else {
-				log.Error("Could not find active reminder with key: %s", reminderKey)
+			if !exists {
+				log.Error("Could not find active reminder with key: " + reminderKey)
 				nextTimer = nil
 				return
 			}
@@ -1133,17 +1164,60 @@ func (r *reminders) getReminderTrack(ctx context.Context, key string) (*internal
 	return track, nil
 }
 
-func (r *reminders) updateReminderTrack(ctx context.Context, key string, repetition int, lastInvokeTime time.Time, etag *string) error {
+// acquireReminderTrack takes ownership of the reminder for this host, by incrementing the fencing token in its track.
+// Hosts that were executing the reminder before a rebalancing stop as soon as they see the new token.
+// If the reminder has no track yet, the track is created with a first-write, so only one of the hosts racing to acquire the reminder gets the first token and the others retry with the next one.
+func (r *reminders) acquireReminderTrack(ctx context.Context, key string) (*internal.ReminderTrack, error) {
+	for attempt := 1; ; attempt++ {
+		track, err := r.getReminderTrack(ctx, key)
+		if err != nil {
+			return nil, err
+		}
+
+		track.FencingToken++
+		err = r.updateReminderTrack(ctx, key, track)
+		if err == nil {
+			return track, nil
+		}
+		if !isEtagError(err) || attempt >= maxReminderTrackAttempts {
+			return nil, err
+		}
+	}
+}
+
+// claimReminderTick records in the track of the reminder that it's firing at the given time.
+// It returns false, without updating the track, if another host has acquired the reminder with a newer fencing token.
+func (r *reminders) claimReminderTick(ctx context.Context, key string, fencingToken int64, repetition int, tick time.Time) (bool, error) {
+	for attempt := 1; ; attempt++ {
+		track, err := r.getReminderTrack(ctx, key)
+		if err != nil {
+			return false, err
+		}
+		if track.FencingToken > fencingToken {
+			return false, nil
+		}
+
+		track.LastFiredTime = tick
+		track.RepetitionLeft = repetition
+		track.FencingToken = fencingToken
+		err = r.updateReminderTrack(ctx, key, track)
+		if err == nil {
+			return true, nil
+		}
+		if !isEtagMismatchError(err) || attempt >= maxReminderTrackAttempts {
+			return false, err
+		}
+	}
+}
+
+// updateReminderTrack saves the track of a reminder.
+// If the track has an etag, the track is saved only if it wasn't modified in the meanwhile.
+func (r *reminders) updateReminderTrack(ctx context.Context, key string, track *internal.ReminderTrack) error {
 	store, err := r.stateStoreProviderFn()
 	if err != nil {
 		return err
 	}
 
-	track := internal.ReminderTrack{
-		LastFiredTime:  lastInvokeTime,
-		RepetitionLeft: repetition,
-	}
-
 	var policyDef *resiliency.PolicyDefinition
 	if r.resiliency != nil && !r.resiliency.PolicyDefined(r.storeName, resiliency.ComponentOutboundPolicy) {
 		policyDef = r.resiliency.ComponentOutboundPolicy(r.storeName, resiliency.Statestore)
@@ -1154,9 +1228,13 @@ func (r *reminders) updateReminderTrack(ctx context.Context, key string, repetit
 	}
 	policyRunner := resiliency.NewRunner[any](ctx, policyDef)
 	setReq := &state.SetRequest{
-		Key:   key,
-		Value: track,
-		ETag:  etag,
+		Key: key,
+		Value: internal.ReminderTrack{
+			LastFiredTime:  track.LastFiredTime,
+			RepetitionLeft: track.RepetitionLeft,
+			FencingToken:   track.FencingToken,
+		},
+		ETag: track.Etag,
 		Options: state.SetStateOption{
 			Concurrency: state.FirstWrite,
 		},
@@ -1167,6 +1245,12 @@ func (r *reminders) updateReminderTrack(ctx context.Context, key string, repetit
 	return err
 }
 
+// isEtagError returns true if the error is returned because the item was modified or created by another writer, including when a first-write without an etag finds an existing item.
+func isEtagError(err error) bool {
+	var etagErr *state.ETagError
+	return errors.As(err, &etagErr)
+}
+
 func isEtagMismatchError(err error) bool {
 	if err == nil {
 		return false
@@ -29,6 +29,8 @@ import (
 	"github.com/stretchr/testify/require"
 	clocktesting "k8s.io/utils/clock/testing"
 
+	"github.com/dapr/components-contrib/state"
+
 	"github.com/dapr/dapr/pkg/actors/internal"
 	"github.com/dapr/dapr/pkg/config"
 	diag "github.com/dapr/dapr/pkg/diagnostics"
@@ -91,7 +93,7 @@ func TestStoreIsNotInitialized(t *testing.T) {
 	})
 
 	t.Run("updateReminderTrack", func(t *testing.T) {
-		err := testReminders.updateReminderTrack(context.Background(), "foo||bar", 1, testReminders.clock.Now(), nil)
+		err := testReminders.updateReminderTrack(context.Background(), "foo||bar", &internal.ReminderTrack{RepetitionLeft: 1, LastFiredTime: testReminders.clock.Now()})
 		require.Error(t, err)
 	})
 
@@ -275,7 +277,7 @@ func TestSetReminderTrack(t *testing.T) {
 
 	actorType, actorID := getTestActorTypeAndID()
 	noRepetition := -1
-	err := testReminders.updateReminderTrack(context.Background(), constructCompositeKey(actorType, actorID), noRepetition, testReminders.clock.Now(), nil)
+	err := testReminders.updateReminderTrack(context.Background(), constructCompositeKey(actorType, actorID), &internal.ReminderTrack{RepetitionLeft: noRepetition, LastFiredTime: testReminders.clock.Now()})
 	require.NoError(t, err)
 }
 
@@ -299,7 +301,7 @@ func TestGetReminderTrack(t *testing.T) {
 		actorType, actorID := getTestActorTypeAndID()
 		repetition := 10
 		now := testReminders.clock.Now()
-		testReminders.updateReminderTrack(context.Background(), constructCompositeKey(actorType, actorID), repetition, now, nil)
+		testReminders.updateReminderTrack(context.Background(), constructCompositeKey(actorType, actorID), &internal.ReminderTrack{RepetitionLeft: repetition, LastFiredTime: now})
 		r, err := testReminders.getReminderTrack(context.Background(), constructCompositeKey(actorType, actorID))
 		require.NoError(t, err)
 		assert.NotEmpty(t, r.LastFiredTime)
@@ -1310,6 +1312,177 @@ func TestReminderFires(t *testing.T) {
 	}, time.Second, time.Millisecond)
 }
 
+func TestReminderFencing(t *testing.T) {
+	testReminders := newTestReminders()
+	defer testReminders.Close()
+	executed := make(chan string, 1)
+	testReminders.SetExecuteReminderFn(func(reminder *internal.Reminder) bool {
+		executed <- reminder.Key()
+		return true
+	})
+	testReminders.Init(context.Background())
+
+	clock := testReminders.clock.(*clocktesting.FakeClock)
+
+	actorType, actorID := getTestActorTypeAndID()
+	ctx := context.Background()
+	req := createReminderData(actorID, actorType, "reminder1", "100ms", "100ms", "", "a")
+	reminder, err := req.NewReminder(testReminders.clock.Now())
+	require.NoError(t, err)
+	require.NoError(t, testReminders.CreateReminder(ctx, reminder))
+
+	// The reminder is acquired by this host
+	reminderKey := constructCompositeKey(actorType, actorID, "reminder1")
+	track, err := testReminders.getReminderTrack(ctx, reminderKey)
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), track.FencingToken)
+
+	// Another host acquires the reminder before it fires on this host
+	require.NoError(t, testReminders.updateReminderTrack(ctx, reminderKey, &internal.ReminderTrack{
+		RepetitionLeft: -1,
+		FencingToken:   2,
+	}))
+
+	advanceTickers(t, clock, time.Millisecond*101)
+
+	assert.Eventually(t, func() bool {
+		_, ok := testReminders.activeReminders.Load(reminderKey)
+		return !ok
+	}, time.Second, time.Millisecond)
+
+	select {
+	case <-executed:
+		t.Fatal("reminder should not have been executed")
+	default:
+	}
+
+	// The track and the reminder are left to the host that acquired it
+	track, err = testReminders.getReminderTrack(ctx, reminderKey)
+	require.NoError(t, err)
+	assert.Equal(t, int64(2), track.FencingToken)
+	assert.True(t, track.LastFiredTime.IsZero())
+	_, ok := testReminders.getReminder("reminder1", actorType, actorID)
+	assert.True(t, ok)
+}
+
+// firstWriteStateStore is a fake state store that enforces first-write concurrency on Set, and whose Set can be made to fail.
+type firstWriteStateStore struct {
+	*daprt.FakeStateStore
+
+	lock        sync.Mutex
+	failSet     atomic.Bool
+	setFailures atomic.Int32
+}
+
+func (f *firstWriteStateStore) Set(ctx context.Context, req *state.SetRequest) error {
+	if f.failSet.Load() {
+		f.setFailures.Add(1)
+		return errors.New("simulated")
+	}
+
+	f.lock.Lock()
+	defer f.lock.Unlock()
+
+	if req.Options.Concurrency == state.FirstWrite {
+		res, err := f.FakeStateStore.Get(ctx, &state.GetRequest{Key: req.Key})
+		if err != nil {
+			return err
+		}
+		if (req.ETag == nil && res.ETag != nil) || (req.ETag != nil && (res.ETag == nil || *req.ETag != *res.ETag)) {
+			return state.NewETagError(state.ETagMismatch, nil)
+		}
+	}
+
+	return f.FakeStateStore.Set(ctx, req)
+}
+
+func TestReminderTrackClaims(t *testing.T) {
+	newReminders := func(t *testing.T) (*reminders, *firstWriteStateStore, chan string) {
+		testReminders := newTestReminders()
+		t.Cleanup(func() { testReminders.Close() })
+
+		store := &firstWriteStateStore{FakeStateStore: daprt.NewFakeStateStore()}
+		testReminders.SetStateStoreProviderFn(func() (internal.TransactionalStateStore, error) {
+			return store, nil
+		})
+
+		executed := make(chan string, 1)
+		testReminders.SetExecuteReminderFn(func(reminder *internal.Reminder) bool {
+			executed <- reminder.Key()
+			return true
+		})
+		testReminders.Init(context.Background())
+
+		return testReminders, store, executed
+	}
+
+	t.Run("hosts racing to acquire a new reminder get different tokens", func(t *testing.T) {
+		testReminders, _, _ := newReminders(t)
+
+		const hosts = 3
+		tokens := make(chan int64, hosts)
+		var wg sync.WaitGroup
+		wg.Add(hosts)
+		for i := 0; i < hosts; i++ {
+			go func() {
+				defer wg.Done()
+				track, err := testReminders.acquireReminderTrack(context.Background(), "cat||e485d5de||reminder1")
+				if assert.NoError(t, err) {
+					tokens <- track.FencingToken
+				}
+			}()
+		}
+		wg.Wait()
+		close(tokens)
+
+		seen := make(map[int64]bool, hosts)
+		for token := range tokens {
+			assert.False(t, seen[token], "token %d acquired by more than one host", token)
+			seen[token] = true
+		}
+		assert.Len(t, seen, hosts)
+	})
+
+	t.Run("tick is skipped when it can't be claimed", func(t *testing.T) {
+		testReminders, store, executed := newReminders(t)
+		clock := testReminders.clock.(*clocktesting.FakeClock)
+
+		actorType, actorID := getTestActorTypeAndID()
+		req := createReminderData(actorID, actorType, "reminder1", "100ms", "100ms", "", "a")
+		reminder, err := req.NewReminder(testReminders.clock.Now())
+		require.NoError(t, err)
+		require.NoError(t, testReminders.CreateReminder(context.Background(), reminder))
+
+		store.failSet.Store(true)
+		advanceTickers(t, clock, time.Millisecond*101)
+
+		assert.Eventually(t, func() bool {
+			return store.setFailures.Load() > 0
+		}, time.Second, time.Millisecond)
+
+		select {
+		case <-executed:
+			t.Fatal("reminder should not have been executed")
+		default:
+		}
+
+		// The reminder stays active and fires at the next tick
+		reminderKey := constructCompositeKey(actorType, actorID, "reminder1")
+		_, ok := testReminders.activeReminders.Load(reminderKey)
+		assert.True(t, ok)
+
+		store.failSet.Store(false)
+		advanceTickers(t, clock, time.Millisecond*100)
+
+		select {
+		case key := <-executed:
+			assert.Equal(t, reminderKey, key)
+		case <-time.After(time.Second):
+			t.Fatal("reminder should have been executed at the next tick")
+		}
+	})
+}
+
 func TestReminderDueDate(t *testing.T) {
 	testReminders := newTestReminders()
 	defer testReminders.Close()
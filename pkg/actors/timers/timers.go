@@ -16,15 +16,14 @@ package timers
 import (
 	"context"
 	"fmt"
-	"math/rand"
 	"sync"
 	"sync/atomic"
-	"time"
 
 	"k8s.io/utils/clock"
 
 	"github.com/dapr/dapr/pkg/actors/internal"
 	diag "github.com/dapr/dapr/pkg/diagnostics"
+	"github.com/dapr/dapr/utils"
 	"github.com/dapr/kit/events/queue"
 	"github.com/dapr/kit/logger"
 )
@@ -38,6 +37,7 @@ type timers struct {
 	clock                 clock.WithTicker
 	executeTimerFn        internal.ExecuteTimerFn
 	activeTimers          *sync.Map
+	createLock            utils.KeyedMutex[string]
 	activeTimersCount     map[string]*int64
 	activeTimersCountLock sync.RWMutex
 	metricsCollector      timersMetricsCollector
@@ -113,28 +113,15 @@ func (t *timers) CreateTimer(ctx context.Context, reminder *internal.Reminder) e
 
 	log.Debugf("Create timer: %s", reminder.String())
 
-	// Multiple goroutines could be trying to store this timer, so we need to repeat until we succeed or context is canceled
-	for {
-		_, loaded := t.activeTimers.LoadOrStore(timerKey, reminder)
-		if !loaded {
-			// If we stored the value, all good - let's continue
-			break
-		}
-
-		// If there's already a timer with the same key, stop it so we can replace it
-		prev, loaded := t.activeTimers.LoadAndDelete(timerKey)
-		if loaded && prev != nil {
-			t.processor.Dequeue(prev.(*internal.Reminder).Key())
-			t.updateActiveTimersCount(reminder.ActorType, -1)
-		}
+	// Multiple goroutines could be trying to store this timer, so we need to serialize them
+	unlock := t.createLock.Lock(timerKey)
+	defer unlock()
 
-		// Wait a bit (with some jitter) and re-try
-		select {
-		case <-time.After(time.Duration(30*rand.Float32()) * time.Millisecond): //nolint:gosec
-			// Can re-try
-		case <-ctx.Done():
-			return fmt.Errorf("failed to create timer: %w", ctx.Err())
-		}
+	// If there's already a timer with the same key, stop it so we can replace it
+	prev, loaded := t.activeTimers.Swap(timerKey, reminder)
+	if loaded && prev != nil {
+		t.processor.Dequeue(prev.(*internal.Reminder).Key())
+		t.updateActiveTimersCount(reminder.ActorType, -1)
 	}
 
 	// Check if the reminder hasn't expired, then enqueue it
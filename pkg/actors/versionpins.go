@@ -0,0 +1,171 @@
+/*
+Copyright 2023 The Dapr Authors
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package actors
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	lru "github.com/hashicorp/golang-lru/v2"
+	"github.com/hashicorp/golang-lru/v2/expirable"
+
+	"github.com/dapr/components-contrib/state"
+	"github.com/dapr/dapr/pkg/actors/internal"
+)
+
+const (
+	versionPinPrefix = "dapr-actor-version"
+	// maxCachedVersionPins is the maximum number of version pins, and of actors that aren't pinned, kept in memory.
+	maxCachedVersionPins = 10_000
+	// unpinnedCacheTTL is how long an actor that isn't pinned is remembered as such.
+	// Another runtime may pin the actor in the meantime: that's detected when the actor is activated here, as the pin can't be created.
+	unpinnedCacheTTL = 5 * time.Second
+)
+
+// versionPins pins actors to the version of the app they were first activated on.
+// Actors that are already pinned keep being routed to their version when the weights of the version routing change, so they aren't moved to another version while they're active.
+// Pins are saved in the actor state store, shared by all the apps that host the actor type, and deleted when the actor is deactivated.
+type versionPins struct {
+	version      string
+	stateStoreFn func() (internal.TransactionalStateStore, error)
+	cache        *lru.Cache[string, string]
+	unpinned     *expirable.LRU[string, struct{}]
+}
+
+func newVersionPins(version string, stateStoreFn func() (internal.TransactionalStateStore, error)) *versionPins {
+	// The error is returned only if the size is not positive
+	cache, _ := lru.New[string, string](maxCachedVersionPins)
+	return &versionPins{
+		version:      version,
+		stateStoreFn: stateStoreFn,
+		cache:        cache,
+		unpinned:     expirable.NewLRU[string, struct{}](maxCachedVersionPins, nil, unpinnedCacheTTL),
+	}
+}
+
+func (p *versionPins) key(actorType string, actorID string) string {
+	return constructCompositeKey(versionPinPrefix, actorType, actorID)
+}
+
+// get returns the version the actor is pinned to, or an empty string if the actor isn't pinned.
+func (p *versionPins) get(ctx context.Context, actorType string, actorID string) (string, error) {
+	key := p.key(actorType, actorID)
+	if version, ok := p.cache.Get(key); ok {
+		return version, nil
+	}
+	if _, ok := p.unpinned.Get(key); ok {
+		return "", nil
+	}
+	return p.load(ctx, key)
+}
+
+// load reads the pin of the actor from the state store and caches it.
+func (p *versionPins) load(ctx context.Context, key string) (string, error) {
+	// Without an actor state store no actor can be pinned
+	store, err := p.stateStoreFn()
+	if err != nil {
+		return "", nil //nolint:nilerr
+	}
+	res, err := store.Get(ctx, &state.GetRequest{Key: key})
+	if err != nil {
+		return "", err
+	}
+	if res == nil || len(res.Data) == 0 {
+		p.cache.Remove(key)
+		p.unpinned.Add(key, struct{}{})
+		return "", nil
+	}
+
+	version := strings.Trim(string(res.Data), `"`)
+	p.unpinned.Remove(key)
+	p.cache.Add(key, version)
+	return version, nil
+}
+
+// pin pins the actor to the version of the app of this runtime, unless the actor is already pinned.
+// It returns the version the actor is pinned to, which is empty if this runtime has no version.
+func (p *versionPins) pin(ctx context.Context, actorType string, actorID string) (string, error) {
+	if p.version == "" {
+		return "", nil
+	}
+
+	key := p.key(actorType, actorID)
+	version, ok := p.cache.Get(key)
+	if !ok || version != p.version {
+		// Pins of other versions are read again, as they may have been deleted since they were cached
+		var err error
+		version, err = p.load(ctx, key)
+		if err != nil {
+			return "", err
+		}
+	}
+	if version != "" {
+		return version, nil
+	}
+
+	store, err := p.stateStoreFn()
+	if err != nil {
+		return "", err
+	}
+	// The pin is created with an insert-only write: if another runtime pinned the actor first, its pin is kept
+	err = store.Set(ctx, &state.SetRequest{
+		Key:   key,
+		Value: p.version,
+		Options: state.SetStateOption{
+			Concurrency: state.FirstWrite,
+		},
+	})
+	if err != nil {
+		// Not all state stores return an ETagError on conflicts, so the pin is read again in any case
+		version, getErr := p.load(ctx, key)
+		if getErr != nil || version == "" {
+			return "", err
+		}
+		return version, nil
+	}
+
+	p.unpinned.Remove(key)
+	p.cache.Add(key, p.version)
+	return p.version, nil
+}
+
+// delete removes the pin of the actor, so it's routed by the weights of the version routing again the next time it's activated.
+func (p *versionPins) delete(ctx context.Context, actorType string, actorID string) error {
+	if p.version == "" {
+		return nil
+	}
+
+	key := p.key(actorType, actorID)
+	version, ok := p.cache.Get(key)
+	if !ok {
+		var err error
+		version, err = p.load(ctx, key)
+		if err != nil {
+			return err
+		}
+	}
+	p.cache.Remove(key)
+	p.unpinned.Remove(key)
+	if version != p.version {
+		// Pins of other versions are owned by the runtimes that host the actor
+		return nil
+	}
+
+	store, err := p.stateStoreFn()
+	if err != nil {
+		return nil //nolint:nilerr
+	}
+	return store.Delete(ctx, &state.DeleteRequest{Key: key})
+}
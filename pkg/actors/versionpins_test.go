@@ -0,0 +1,193 @@
+/*
+Copyright 2023 The Dapr Authors
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package actors
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/dapr/components-contrib/state"
+	"github.com/dapr/dapr/pkg/actors/internal"
+	invokev1 "github.com/dapr/dapr/pkg/messaging/v1"
+	daprt "github.com/dapr/dapr/pkg/testing"
+)
+
+func TestVersionPins(t *testing.T) {
+	store := daprt.NewFakeStateStore()
+	stateStoreFn := func() (internal.TransactionalStateStore, error) {
+		return store, nil
+	}
+
+	t.Run("actors are pinned to the version they're activated on", func(t *testing.T) {
+		pins := newVersionPins("v2", stateStoreFn)
+		version, err := pins.pin(context.Background(), "cat", "a")
+		require.NoError(t, err)
+		assert.Equal(t, "v2", version)
+
+		version, err = pins.get(context.Background(), "cat", "a")
+		require.NoError(t, err)
+		assert.Equal(t, "v2", version)
+		assert.Contains(t, store.GetItems(), "dapr-actor-version||cat||a")
+
+		// Pins are shared by the runtimes through the state store
+		version, err = newVersionPins("v1", stateStoreFn).get(context.Background(), "cat", "a")
+		require.NoError(t, err)
+		assert.Equal(t, "v2", version)
+	})
+
+	t.Run("existing pins are kept", func(t *testing.T) {
+		require.NoError(t, store.Set(context.Background(), &state.SetRequest{
+			Key:   "dapr-actor-version||cat||b",
+			Value: "v1",
+		}))
+
+		pins := newVersionPins("v2", stateStoreFn)
+		version, err := pins.pin(context.Background(), "cat", "b")
+		require.NoError(t, err)
+		assert.Equal(t, "v1", version)
+
+		version, err = pins.get(context.Background(), "cat", "b")
+		require.NoError(t, err)
+		assert.Equal(t, "v1", version)
+	})
+
+	t.Run("pins are cached", func(t *testing.T) {
+		pins := newVersionPins("v2", stateStoreFn)
+		_, err := pins.pin(context.Background(), "cat", "c")
+		require.NoError(t, err)
+
+		gets := store.CallCount("Get")
+		for i := 0; i < 3; i++ {
+			version, err := pins.pin(context.Background(), "cat", "c")
+			require.NoError(t, err)
+			assert.Equal(t, "v2", version)
+			version, err = pins.get(context.Background(), "cat", "c")
+			require.NoError(t, err)
+			assert.Equal(t, "v2", version)
+		}
+		assert.Equal(t, gets, store.CallCount("Get"))
+	})
+
+	t.Run("actors that aren't pinned are cached", func(t *testing.T) {
+		pins := newVersionPins("v2", stateStoreFn)
+
+		version, err := pins.get(context.Background(), "cat", "f")
+		require.NoError(t, err)
+		assert.Empty(t, version)
+
+		gets := store.CallCount("Get")
+		for i := 0; i < 3; i++ {
+			version, err = pins.get(context.Background(), "cat", "f")
+			require.NoError(t, err)
+			assert.Empty(t, version)
+		}
+		assert.Equal(t, gets, store.CallCount("Get"))
+
+		// An actor pinned by another runtime in the meantime is found when it's activated
+		_, err = newVersionPins("v1", stateStoreFn).pin(context.Background(), "cat", "f")
+		require.NoError(t, err)
+		version, err = pins.pin(context.Background(), "cat", "f")
+		require.NoError(t, err)
+		assert.Equal(t, "v1", version)
+		version, err = pins.get(context.Background(), "cat", "f")
+		require.NoError(t, err)
+		assert.Equal(t, "v1", version)
+	})
+
+	t.Run("pins are deleted by the runtimes of their version", func(t *testing.T) {
+		v1 := newVersionPins("v1", stateStoreFn)
+		v2 := newVersionPins("v2", stateStoreFn)
+		_, err := v1.pin(context.Background(), "cat", "g")
+		require.NoError(t, err)
+
+		require.NoError(t, v2.delete(context.Background(), "cat", "g"))
+		assert.Contains(t, store.GetItems(), "dapr-actor-version||cat||g")
+
+		require.NoError(t, v1.delete(context.Background(), "cat", "g"))
+		assert.NotContains(t, store.GetItems(), "dapr-actor-version||cat||g")
+
+		// Once the pin is deleted, the actor can be pinned to another version
+		version, err := v2.pin(context.Background(), "cat", "g")
+		require.NoError(t, err)
+		assert.Equal(t, "v2", version)
+	})
+
+	t.Run("actors aren't pinned by runtimes without a version", func(t *testing.T) {
+		pins := newVersionPins("", stateStoreFn)
+		version, err := pins.pin(context.Background(), "cat", "d")
+		require.NoError(t, err)
+		assert.Empty(t, version)
+
+		version, err = pins.get(context.Background(), "cat", "d")
+		require.NoError(t, err)
+		assert.Empty(t, version)
+	})
+
+	t.Run("no actor state store", func(t *testing.T) {
+		pins := newVersionPins("v2", func() (internal.TransactionalStateStore, error) {
+			return nil, errors.New(errStateStoreNotFound)
+		})
+
+		version, err := pins.get(context.Background(), "cat", "e")
+		require.NoError(t, err)
+		assert.Empty(t, version)
+		_, err = pins.pin(context.Background(), "cat", "e")
+		require.Error(t, err)
+	})
+}
+
+func TestCallLocalActorVersionPins(t *testing.T) {
+	testActorsRuntime := newTestActorsRuntime()
+	defer testActorsRuntime.Close()
+	testActorsRuntime.actorsConfig.Config.ActorVersion = "v2"
+	testActorsRuntime.versionPins = newVersionPins("v2", testActorsRuntime.stateStore)
+	v1 := newVersionPins("v1", testActorsRuntime.stateStore)
+
+	t.Run("actors pinned to another version are rejected", func(t *testing.T) {
+		_, err := v1.pin(context.Background(), "cat", "a")
+		require.NoError(t, err)
+
+		req := invokev1.NewInvokeMethodRequest("method").WithActor("cat", "a")
+		defer req.Close()
+		_, err = testActorsRuntime.callLocalActor(context.Background(), req)
+		require.ErrorIs(t, err, errActorVersionMismatch)
+
+		_, ok := testActorsRuntime.actorsTable.Load(constructCompositeKey("cat", "a"))
+		assert.False(t, ok)
+	})
+
+	t.Run("pins are deleted when the actors are deactivated", func(t *testing.T) {
+		req := invokev1.NewInvokeMethodRequest("method").WithActor("cat", "b")
+		defer req.Close()
+		resp, err := testActorsRuntime.callLocalActor(context.Background(), req)
+		require.NoError(t, err)
+		resp.Close()
+
+		version, err := v1.get(context.Background(), "cat", "b")
+		require.NoError(t, err)
+		assert.Equal(t, "v2", version)
+
+		act, ok := testActorsRuntime.actorsTable.Load(constructCompositeKey("cat", "b"))
+		require.True(t, ok)
+		require.NoError(t, testActorsRuntime.deactivateActor(act.(*actor)))
+
+		version, err = newVersionPins("v1", testActorsRuntime.stateStore).get(context.Background(), "cat", "b")
+		require.NoError(t, err)
+		assert.Empty(t, version)
+	})
+}
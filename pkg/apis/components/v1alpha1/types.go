@@ -93,6 +93,13 @@ type ComponentSpec struct {
 	Metadata     []common.NameValuePair `json:"metadata"`
 	//+optional
 	InitTimeout string `json:"initTimeout"`
+	// Maximum time to wait for the in-flight operations on the component to complete before it's closed, on shutdown or when it's reloaded.
+	// Set to 0 to close the component immediately.
+	//+optional
+	DrainTimeout string `json:"drainTimeout,omitempty"`
+	// Names of the components that must be initialized before this component.
+	//+optional
+	DependsOn []string `json:"dependsOn,omitempty"`
 }
 
 // Auth represents authentication details for the component.
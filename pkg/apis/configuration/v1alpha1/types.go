@@ -65,6 +65,142 @@ type ConfigurationSpec struct {
 	WasmSpec *WasmSpec `json:"wasm,omitempty"`
 	// +optional
 	WorkflowSpec *WorkflowSpec `json:"workflow,omitempty"`
+	// +optional
+	DNSCacheSpec *DNSCacheSpec `json:"dnsCache,omitempty"`
+	// +optional
+	CORSSpec *CORSSpec `json:"cors,omitempty"`
+	// +optional
+	PublishDeduplication *PublishDeduplicationSpec `json:"publishDeduplication,omitempty"`
+	// +optional
+	ActorFailover *ActorFailoverSpec `json:"actorFailover,omitempty"`
+	// +optional
+	IdempotentMethods []IdempotentMethodSpec `json:"idempotentMethods,omitempty"`
+	// +optional
+	Transcoding *TranscodingSpec `json:"transcoding,omitempty"`
+	// +optional
+	InvokeCompression *InvokeCompressionSpec `json:"invokeCompression,omitempty"`
+	// +optional
+	RateLimiters []RateLimiterSpec `json:"rateLimiters,omitempty"`
+	// +optional
+	LeaderElections []LeaderElectionSpec `json:"leaderElections,omitempty"`
+}
+
+// InvokeCompressionSpec configures the gzip compression of the service invocation calls to other Dapr sidecars.
+type InvokeCompressionSpec struct {
+	// Enables compressing the calls.
+	// +optional
+	Enabled bool `json:"enabled,omitempty"`
+	// Minimum size, in bytes, of the request bodies that are compressed.
+	// If omitted, the default value of 1024 will be used.
+	// +optional
+	MinSize int `json:"minSize,omitempty"`
+}
+
+// TranscodingSpec configures the transcoding of the bodies of the service invocation requests to the methods of the app, and of their responses, between JSON and protobuf.
+type TranscodingSpec struct {
+	// Path of a file with a serialized FileDescriptorSet containing the message types.
+	DescriptorSetFile string `json:"descriptorSetFile"`
+	// Methods of the app that are transcoded.
+	Methods []TranscodingMethodSpec `json:"methods"`
+}
+
+// TranscodingMethodSpec declares the content type and the message types of a method of the app.
+type TranscodingMethodSpec struct {
+	// Name of the method.
+	Name string `json:"name"`
+	// Content type of the bodies the method accepts and returns: "application/json" or "application/x-protobuf".
+	ContentType string `json:"contentType"`
+	// Full name of the message type of the requests.
+	RequestType string `json:"requestType"`
+	// Full name of the message type of the responses.
+	ResponseType string `json:"responseType"`
+}
+
+// IdempotentMethodSpec marks service invocation methods as idempotent, so they are retried when the connection to the target app is reset.
+type IdempotentMethodSpec struct {
+	// ID of the target app. If empty, the methods are idempotent for all the apps.
+	// +optional
+	AppID string `json:"appId,omitempty"`
+	// Names of the methods. A trailing "*" matches all the methods that start with the prefix.
+	Methods []string `json:"methods"`
+}
+
+// ActorFailoverSpec configures how actor invocations fail over to other hosts when the host an actor is placed on is unreachable.
+type ActorFailoverSpec struct {
+	// Enables invoking the actor again once the placement tables are updated, instead of failing until the next invocation.
+	// +optional
+	Enabled bool `json:"enabled,omitempty"`
+	// Maximum number of placement table updates an invocation waits for to fail over.
+	// If omitted, the default value of 2 will be used.
+	// +optional
+	MaxAttempts int `json:"maxAttempts,omitempty"`
+}
+
+// CORSSpec configures the CORS policy of the Dapr HTTP API.
+type CORSSpec struct {
+	// Default policy, applied to all routes that don't match a route-specific policy.
+	CORSPolicySpec `json:",inline"`
+	// Policies for the routes that start with a path prefix, replacing the default policy.
+	// When multiple prefixes match a request, the longest one is used.
+	// +optional
+	Routes []CORSRouteSpec `json:"routes,omitempty"`
+}
+
+// CORSRouteSpec is the CORS policy for the routes starting with a path prefix.
+type CORSRouteSpec struct {
+	// Path prefix the policy applies to, such as "/v1.0/invoke".
+	PathPrefix     string `json:"pathPrefix"`
+	CORSPolicySpec `json:",inline"`
+}
+
+// CORSPolicySpec is a CORS policy.
+type CORSPolicySpec struct {
+	// List of origins allowed to perform cross-origin requests.
+	// If empty, the origins set with the "--allowed-origins" flag are used.
+	// +optional
+	AllowedOrigins []string `json:"allowedOrigins,omitempty"`
+	// List of methods allowed in cross-origin requests.
+	// +optional
+	AllowedMethods []string `json:"allowedMethods,omitempty"`
+	// List of headers allowed in cross-origin requests.
+	// +optional
+	AllowedHeaders []string `json:"allowedHeaders,omitempty"`
+	// List of response headers exposed to the clients.
+	// +optional
+	ExposedHeaders []string `json:"exposedHeaders,omitempty"`
+	// Allows requests to include credentials, such as cookies and authorization headers.
+	// +optional
+	AllowCredentials bool `json:"allowCredentials,omitempty"`
+	// Number of seconds the response to a preflight request can be cached for.
+	// +optional
+	MaxAge int `json:"maxAge,omitempty"`
+}
+
+// DNSCacheSpec configures the cache of DNS lookups for the connections to other Dapr sidecars.
+type DNSCacheSpec struct {
+	// Enables caching DNS lookups, and re-resolving addresses when connections fail.
+	// +optional
+	Enabled bool `json:"enabled,omitempty"`
+	// Time after which cached addresses are resolved again, as a Go duration.
+	// If omitted, the default value of 30s will be used.
+	// +optional
+	TTL string `json:"ttl,omitempty"`
+}
+
+// PublishDeduplicationSpec configures how the idempotency keys of publish requests are remembered, to suppress duplicate publishes.
+type PublishDeduplicationSpec struct {
+	// Maximum number of idempotency keys kept in memory.
+	// If omitted, the default value of 10000 will be used.
+	// +optional
+	MaxKeys int `json:"maxKeys,omitempty"`
+	// Time idempotency keys are remembered for, as a Go duration.
+	// If omitted, the default value of 10m will be used.
+	// +optional
+	TTL string `json:"ttl,omitempty"`
+	// Name of a state store where idempotency keys are also saved, so they are shared across replicas and restarts.
+	// The state store must support TTLs.
+	// +optional
+	StateStore string `json:"stateStore,omitempty"`
 }
 
 // WorkflowSpec defines the configuration for Dapr workflows.
@@ -79,6 +215,49 @@ type WorkflowSpec struct {
 	// If omitted, the default value of 100 will be used.
 	// +optional
 	MaxConcurrentActivityInvocations int32 `json:"maxConcurrentActivityInvocations,omitempty"`
+	// shutdownTimeout is the time to wait on shutdown for in-flight workflow and activity executions to complete, as a Go duration.
+	// Executions still running after this time are preempted and retried later, possibly by another Dapr instance.
+	// If omitted, the default value of 5s will be used.
+	// +optional
+	ShutdownTimeout string `json:"shutdownTimeout,omitempty"`
+	// retention configures the automatic purge of the completed workflow instances.
+	// If omitted, completed workflow instances are retained until they're purged with the API.
+	// +optional
+	Retention *WorkflowRetentionSpec `json:"retention,omitempty"`
+	// eventBufferTTL is the time the events raised on workflow instances that don't exist yet are buffered for, as a Go duration.
+	// The buffered events are delivered to the instance if it's started within this time, and dropped otherwise.
+	// If omitted, raising an event on a workflow instance that doesn't exist fails.
+	// +optional
+	EventBufferTTL string `json:"eventBufferTTL,omitempty"`
+	// remoteWorkflows are the workflows hosted by other apps in the same namespace, which the workflows of this app can start as child workflows.
+	// +optional
+	RemoteWorkflows []RemoteWorkflowSpec `json:"remoteWorkflows,omitempty"`
+	// remindersBackend is the service the durable timers and the work items of the workflows are scheduled with: "actors" or "scheduler".
+	// With "scheduler", they're scheduled as jobs of the scheduler service instead of actor reminders, which aren't saved in the actor state store.
+	// If omitted, the actor reminders are used.
+	// +optional
+	// +kubebuilder:validation:Enum={"actors","scheduler"}
+	RemindersBackend string `json:"remindersBackend,omitempty"`
+}
+
+// RemoteWorkflowSpec maps the name of a workflow to the app that hosts it.
+type RemoteWorkflowSpec struct {
+	// Name of the workflow, as registered by the app that hosts it.
+	Name string `json:"name"`
+	// ID of the app that hosts the workflow.
+	AppID string `json:"appId"`
+}
+
+// WorkflowRetentionSpec configures the automatic purge of the completed workflow instances.
+// Instances are purged when they exceed either limit.
+type WorkflowRetentionSpec struct {
+	// maxAge is the time after which completed workflow instances are purged, as a Go duration.
+	// +optional
+	MaxAge string `json:"maxAge,omitempty"`
+	// maxCompletedInstances is the maximum number of completed workflow instances that are retained.
+	// The instances that completed first are purged first.
+	// +optional
+	MaxCompletedInstances int32 `json:"maxCompletedInstances,omitempty"`
 }
 
 // APISpec describes the configuration for Dapr APIs.
@@ -89,6 +268,49 @@ type APISpec struct {
 	// List of denied APIs. Can be used in conjunction with allowed.
 	// +optional
 	Denied []APIAccessRule `json:"denied,omitempty"`
+	// Rate limits of the HTTP APIs of the building blocks.
+	// +optional
+	RateLimits []APIRateLimit `json:"rateLimits,omitempty"`
+}
+
+// APIRateLimit limits the number of requests per second to the HTTP API of a building block.
+type APIRateLimit struct {
+	// Name of the API, such as "state", "publish" or "invoke".
+	Name string `json:"name"`
+	// Maximum number of requests per second.
+	RequestsPerSecond int `json:"requestsPerSecond"`
+	// Maximum number of requests allowed in a burst. Defaults to requestsPerSecond.
+	// +optional
+	Burst int `json:"burst,omitempty"`
+	// Name of a rate limiter in rateLimiters the requests are checked against, shared by all the replicas of the app.
+	// +optional
+	RateLimiter string `json:"rateLimiter,omitempty"`
+}
+
+// RateLimiterSpec configures a distributed rate limiter, whose limit is shared by all the replicas of the app.
+type RateLimiterSpec struct {
+	// Name of the rate limiter.
+	Name string `json:"name"`
+	// Name of the state store that keeps the counters, which must support ETags.
+	StateStore string `json:"stateStore"`
+	// Maximum number of units allowed per key in each window.
+	Limit int64 `json:"limit"`
+	// Duration of the windows, such as "1s" or "1m". Defaults to 1s.
+	// +optional
+	Window string `json:"window,omitempty"`
+}
+
+// LeaderElectionSpec configures an election, in which the replicas of the app campaign for a leadership lease.
+type LeaderElectionSpec struct {
+	// Name of the election.
+	Name string `json:"name"`
+	// Name of the state store that keeps the lease, which must support ETags.
+	// If empty, a Kubernetes Lease in the namespace of the app is used.
+	// +optional
+	StateStore string `json:"stateStore,omitempty"`
+	// Duration of the lease, such as "15s". Defaults to 15s.
+	// +optional
+	LeaseDuration string `json:"leaseDuration,omitempty"`
 }
 
 // WasmSpec describes the security profile for all Dapr Wasm components.
@@ -157,6 +379,26 @@ type MTLSSpec struct {
 	// In self-hosted mode, enabling a custom validator will disable the built-in "insecure" validator.
 	// +optional
 	TokenValidators []ValidatorSpec `json:"tokenValidators,omitempty"`
+	// SPIFFE IDs of the app identities whose certificates are revoked.
+	// Sentry doesn't issue certificates for revoked identities, and sidecars reject mTLS connections from and to them.
+	// +optional
+	RevokedIdentities []string `json:"revokedIdentities,omitempty"`
+	// Restrictions on the TLS parameters of mTLS connections, for example for FIPS compliance.
+	// +optional
+	TLS *TLSSpec `json:"tls,omitempty"`
+}
+
+// TLSSpec restricts the TLS parameters negotiated by servers and clients.
+type TLSSpec struct {
+	// Minimum TLS version: "1.2" (default) or "1.3".
+	// +optional
+	MinVersion string `json:"minVersion,omitempty"`
+	// IANA names of the cipher suites allowed for TLS 1.2 connections.
+	// +optional
+	CipherSuites []string `json:"cipherSuites,omitempty"`
+	// Elliptic curves used in ECDHE handshakes, in order of preference: "X25519", "P256", "P384", or "P521".
+	// +optional
+	CurvePreferences []string `json:"curvePreferences,omitempty"`
 }
 
 // GetEnabled returns true if mTLS is enabled.
@@ -194,6 +436,46 @@ type TracingSpec struct {
 	Zipkin *ZipkinSpec `json:"zipkin,omitempty"`
 	// +optional
 	Otel *OtelSpec `json:"otel,omitempty"`
+	// Exports the spans dropped by the sampling rate when they end with an error or are slow.
+	// +optional
+	TailSampling *TailSamplingSpec `json:"tailSampling,omitempty"`
+	// Attributes of the OpenTelemetry resource the spans are reported with.
+	// +optional
+	Resource *OtelResourceSpec `json:"resource,omitempty"`
+	// Attributes set on every span created by the sidecar.
+	// +optional
+	SpanAttributes []SpanAttributeSpec `json:"spanAttributes,omitempty"`
+	// Formats of the trace context propagated in the headers of requests, separated by commas: w3c, b3 and b3multi.
+	// The trace context is read from the first format found in the incoming requests, and written in all the formats.
+	// Defaults to w3c.
+	// +optional
+	Propagation string `json:"propagation,omitempty"`
+}
+
+// SpanAttributeSpec defines an attribute set on the spans created by the sidecar.
+// Exactly one of value, envVar and header must be set.
+type SpanAttributeSpec struct {
+	// Name of the attribute.
+	Name string `json:"name"`
+	// Static value of the attribute.
+	// +optional
+	Value string `json:"value,omitempty"`
+	// Environment variable of the sidecar with the value of the attribute, such as POD_NAME or NODE_NAME on Kubernetes.
+	// +optional
+	EnvVar string `json:"envVar,omitempty"`
+	// Header of the requests received by the sidecar with the value of the attribute.
+	// +optional
+	Header string `json:"header,omitempty"`
+}
+
+// TailSamplingSpec defines the spans that are exported even if they are not sampled.
+type TailSamplingSpec struct {
+	// Exports the spans that end with an error status.
+	// +optional
+	Errors bool `json:"errors,omitempty"`
+	// Exports the spans that take longer than this duration.
+	// +optional
+	LatencyThreshold string `json:"latencyThreshold,omitempty"`
 }
 
 // OtelSpec defines Otel exporter configurations.
@@ -203,6 +485,20 @@ type OtelSpec struct {
 	IsSecure        *bool  `json:"isSecure" yaml:"isSecure"`
 }
 
+// OtelResourceSpec defines the attributes of the OpenTelemetry resource, which identifies the source of the telemetry.
+type OtelResourceSpec struct {
+	// Value of the service.name attribute. Defaults to the app ID.
+	// +optional
+	ServiceName string `json:"serviceName,omitempty"`
+	// Value of the deployment.environment attribute, such as "production" or "staging".
+	// +optional
+	Environment string `json:"environment,omitempty"`
+	// Additional attributes of the resource.
+	// The service name and environment take precedence over the attributes with the same key.
+	// +optional
+	Attributes map[string]string `json:"attributes,omitempty"`
+}
+
 // ZipkinSpec defines Zipkin trace configurations.
 type ZipkinSpec struct {
 	EndpointAddresss string `json:"endpointAddress"`
@@ -213,6 +509,102 @@ type MetricSpec struct {
 	Enabled *bool `json:"enabled"`
 	// +optional
 	Rules []MetricsRule `json:"rules,omitempty"`
+	// Records 1 in N occurrences of the high-frequency measures, such as pub/sub messages, with a weight of N.
+	// +optional
+	SamplingFactor int `json:"samplingFactor,omitempty"`
+	// OTLP endpoint the metrics are pushed to, in addition to the Prometheus endpoint.
+	// Requires the OTelMetrics feature.
+	// +optional
+	Otel *OtelSpec `json:"otel,omitempty"`
+	// Controls the labels of all the metrics, to limit their cardinality.
+	// +optional
+	Labels *MetricLabelsSpec `json:"labels,omitempty"`
+	// Configures the metrics of the HTTP server.
+	// +optional
+	HTTP *MetricHTTPSpec `json:"http,omitempty"`
+	// Bucket boundaries of the latency histograms, per family of metrics.
+	// +optional
+	LatencyBuckets *MetricLatencyBucketsSpec `json:"latencyBuckets,omitempty"`
+	// Configures the metrics of the workflows.
+	// +optional
+	Workflow *MetricWorkflowSpec `json:"workflow,omitempty"`
+	// Attributes of the OpenTelemetry resource the metrics pushed to the OTLP endpoint are reported with.
+	// +optional
+	Resource *OtelResourceSpec `json:"resource,omitempty"`
+	// Limits the custom metrics recorded by the app with the metrics API.
+	// +optional
+	App *MetricAppSpec `json:"app,omitempty"`
+}
+
+// MetricAppSpec limits the custom metrics recorded by the app, to bound their cardinality.
+type MetricAppSpec struct {
+	// Maximum number of distinct custom metrics. Defaults to 100.
+	// +optional
+	MaxMetrics int `json:"maxMetrics,omitempty"`
+	// Maximum number of distinct combinations of label values of each custom metric. Defaults to 1000.
+	// +optional
+	MaxSeries int `json:"maxSeries,omitempty"`
+}
+
+// MetricWorkflowSpec configures the metrics of the workflows.
+type MetricWorkflowSpec struct {
+	// Tags the workflow metrics with the names of the workflows and activities.
+	// +optional
+	NameLabels bool `json:"nameLabels,omitempty"`
+	// Maximum number of distinct workflow and activity names the metrics are tagged with.
+	// The least frequent names are recorded as "other". Defaults to 20.
+	// +optional
+	MaxNames int `json:"maxNames,omitempty"`
+}
+
+// MetricLatencyBucketsSpec sets the bucket boundaries of the latency histograms, in milliseconds, such as "0.5" or "250".
+// The families that are not set use the default boundaries.
+type MetricLatencyBucketsSpec struct {
+	// Boundaries of the latency histograms of the HTTP server and client.
+	// +optional
+	HTTP []string `json:"http,omitempty"`
+	// Boundaries of the latency histograms of the gRPC server and client.
+	// +optional
+	GRPC []string `json:"grpc,omitempty"`
+	// Boundaries of the latency histograms of the workflows.
+	// +optional
+	Workflow []string `json:"workflow,omitempty"`
+	// Boundaries of the latency histograms of the service invocation calls.
+	// +optional
+	ServiceInvocation []string `json:"serviceInvocation,omitempty"`
+}
+
+// MetricHTTPSpec configures the metrics of the HTTP server.
+type MetricHTTPSpec struct {
+	// Templates of the routes of the app, such as "/orders/{id}".
+	// If set, the HTTP server metrics are tagged with the first template that matches the path invoked on the app, or "unmatched".
+	// +optional
+	Routes []string `json:"routes,omitempty"`
+}
+
+// MetricLabelsSpec drops or rewrites the values of the labels of all the metrics before they are recorded.
+type MetricLabelsSpec struct {
+	// If set, only these labels are kept and all the others are dropped.
+	// +optional
+	Allow []string `json:"allow,omitempty"`
+	// Labels dropped from all the metrics.
+	// +optional
+	Deny []string `json:"deny,omitempty"`
+	// Rewrites of the label values, applied in order after the rules of the metric.
+	// +optional
+	Rewrites []MetricLabelRewrite `json:"rewrites,omitempty"`
+}
+
+// MetricLabelRewrite rewrites the values of a label in all the metrics.
+type MetricLabelRewrite struct {
+	Name string `json:"name"`
+	// +optional
+	Regex string `json:"regex,omitempty"`
+	// +optional
+	Replace string `json:"replace,omitempty"`
+	// Replaces HTTP status codes with their class, such as "4xx" for "404".
+	// +optional
+	StatusClass bool `json:"statusClass,omitempty"`
 }
 
 // MetricsRule defines configuration options for a metric.
@@ -256,6 +648,24 @@ type AccessControlSpec struct {
 	TrustDomain string `json:"trustDomain,omitempty" yaml:"trustDomain,omitempty"`
 	// +optional
 	AppPolicies []AppPolicySpec `json:"policies,omitempty" yaml:"policies,omitempty"`
+	// +optional
+	WorkflowPolicies []WorkflowPolicySpec `json:"workflows,omitempty" yaml:"workflows,omitempty"`
+	// +optional
+	ActorPolicies []ActorPolicySpec `json:"actors,omitempty" yaml:"actors,omitempty"`
+}
+
+// WorkflowPolicySpec restricts which apps may perform operations on workflows with a given name.
+type WorkflowPolicySpec struct {
+	WorkflowName string `json:"name" yaml:"name"`
+	// +optional
+	Operations     []string `json:"operations,omitempty" yaml:"operations,omitempty"`
+	AllowedCallers []string `json:"allowedCallers" yaml:"allowedCallers"`
+}
+
+// ActorPolicySpec restricts which apps may invoke actors of a given type.
+type ActorPolicySpec struct {
+	ActorType      string   `json:"actorType" yaml:"actorType"`
+	AllowedCallers []string `json:"allowedCallers" yaml:"allowedCallers"`
 }
 
 // FeatureSpec defines the features that are enabled/disabled.
@@ -269,6 +679,22 @@ type ComponentsSpec struct {
 	// Denylist of component types that cannot be instantiated
 	// +optional
 	Deny []string `json:"deny,omitempty" yaml:"deny,omitempty"`
+	// Connectivity checks of the pubsub and binding components before the sidecar is marked as ready
+	// +optional
+	Warmup *ComponentWarmupSpec `json:"warmup,omitempty" yaml:"warmup,omitempty"`
+}
+
+// ComponentWarmupSpec configures the connectivity checks of the pubsub and binding components that delay the readiness of the sidecar.
+type ComponentWarmupSpec struct {
+	// Enables the connectivity checks
+	// +optional
+	Enabled bool `json:"enabled,omitempty" yaml:"enabled,omitempty"`
+	// Maximum time the readiness of the sidecar is delayed by the checks, as a Go duration; defaults to 30s
+	// +optional
+	Timeout string `json:"timeout,omitempty" yaml:"timeout,omitempty"`
+	// Names of the components that are not checked
+	// +optional
+	Skip []string `json:"skip,omitempty" yaml:"skip,omitempty"`
 }
 
 // LoggingSpec defines the configuration for logging.
@@ -276,6 +702,33 @@ type LoggingSpec struct {
 	// Configure API logging.
 	// +optional
 	APILogging *APILoggingSpec `json:"apiLogging,omitempty" yaml:"apiLogging,omitempty"`
+	// Configure access logs.
+	// +optional
+	AccessLog *AccessLogSpec `json:"accessLog,omitempty" yaml:"accessLog,omitempty"`
+	// OTLP endpoint the logs are exported to, in addition to the standard output.
+	// Log entries written within a traced request include its trace and span IDs.
+	// +optional
+	Otel *OtelSpec `json:"otel,omitempty" yaml:"otel,omitempty"`
+}
+
+// AccessLogSpec defines the configuration for the access logs, which record all invocations of the Dapr APIs.
+type AccessLogSpec struct {
+	// Enables access logs. Default: false.
+	// +optional
+	Enabled *bool `json:"enabled,omitempty" yaml:"enabled,omitempty"`
+	// Format of the access logs: "json" or "common" (Common Log Format). Default: "json".
+	// This option has no effect if a template is set.
+	// +optional
+	Format string `json:"format,omitempty" yaml:"format,omitempty"`
+	// Go template used to format each access log entry, overriding the format.
+	// +optional
+	Template string `json:"template,omitempty" yaml:"template,omitempty"`
+	// Fraction of the requests that are logged, between 0 and 1. Default: "1".
+	// +optional
+	SamplingRate string `json:"samplingRate,omitempty" yaml:"samplingRate,omitempty"`
+	// Requests that take longer than this duration are always logged, regardless of the sampling rate, and are marked as slow.
+	// +optional
+	SlowThreshold string `json:"slowThreshold,omitempty" yaml:"slowThreshold,omitempty"`
 }
 
 // APILoggingSpec defines the configuration for API logging.
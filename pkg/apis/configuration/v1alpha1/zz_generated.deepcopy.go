@@ -70,6 +70,21 @@ func (in *APILoggingSpec) DeepCopy() *APILoggingSpec {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *APIRateLimit) DeepCopyInto(out *APIRateLimit) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new APIRateLimit.
+func (in *APIRateLimit) DeepCopy() *APIRateLimit {
+	if in == nil {
+		return nil
+	}
+	out := new(APIRateLimit)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *APISpec) DeepCopyInto(out *APISpec) {
 	*out = *in
@@ -83,6 +98,11 @@ func (in *APISpec) DeepCopyInto(out *APISpec) {
 		*out = make([]APIAccessRule, len(*in))
 		copy(*out, *in)
 	}
+	if in.RateLimits != nil {
+		in, out := &in.RateLimits, &out.RateLimits
+		*out = make([]APIRateLimit, len(*in))
+		copy(*out, *in)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new APISpec.
@@ -105,6 +125,20 @@ func (in *AccessControlSpec) DeepCopyInto(out *AccessControlSpec) {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
+	if in.WorkflowPolicies != nil {
+		in, out := &in.WorkflowPolicies, &out.WorkflowPolicies
+		*out = make([]WorkflowPolicySpec, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.ActorPolicies != nil {
+		in, out := &in.ActorPolicies, &out.ActorPolicies
+		*out = make([]ActorPolicySpec, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AccessControlSpec.
@@ -117,6 +151,61 @@ func (in *AccessControlSpec) DeepCopy() *AccessControlSpec {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ActorPolicySpec) DeepCopyInto(out *ActorPolicySpec) {
+	*out = *in
+	if in.AllowedCallers != nil {
+		in, out := &in.AllowedCallers, &out.AllowedCallers
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ActorPolicySpec.
+func (in *ActorPolicySpec) DeepCopy() *ActorPolicySpec {
+	if in == nil {
+		return nil
+	}
+	out := new(ActorPolicySpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AccessLogSpec) DeepCopyInto(out *AccessLogSpec) {
+	*out = *in
+	if in.Enabled != nil {
+		in, out := &in.Enabled, &out.Enabled
+		*out = new(bool)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AccessLogSpec.
+func (in *AccessLogSpec) DeepCopy() *AccessLogSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(AccessLogSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ActorFailoverSpec) DeepCopyInto(out *ActorFailoverSpec) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ActorFailoverSpec.
+func (in *ActorFailoverSpec) DeepCopy() *ActorFailoverSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(ActorFailoverSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *AppOperationAction) DeepCopyInto(out *AppOperationAction) {
 	*out = *in
@@ -159,6 +248,100 @@ func (in *AppPolicySpec) DeepCopy() *AppPolicySpec {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CORSPolicySpec) DeepCopyInto(out *CORSPolicySpec) {
+	*out = *in
+	if in.AllowedOrigins != nil {
+		in, out := &in.AllowedOrigins, &out.AllowedOrigins
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.AllowedMethods != nil {
+		in, out := &in.AllowedMethods, &out.AllowedMethods
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.AllowedHeaders != nil {
+		in, out := &in.AllowedHeaders, &out.AllowedHeaders
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.ExposedHeaders != nil {
+		in, out := &in.ExposedHeaders, &out.ExposedHeaders
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CORSPolicySpec.
+func (in *CORSPolicySpec) DeepCopy() *CORSPolicySpec {
+	if in == nil {
+		return nil
+	}
+	out := new(CORSPolicySpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CORSRouteSpec) DeepCopyInto(out *CORSRouteSpec) {
+	*out = *in
+	in.CORSPolicySpec.DeepCopyInto(&out.CORSPolicySpec)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CORSRouteSpec.
+func (in *CORSRouteSpec) DeepCopy() *CORSRouteSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(CORSRouteSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CORSSpec) DeepCopyInto(out *CORSSpec) {
+	*out = *in
+	in.CORSPolicySpec.DeepCopyInto(&out.CORSPolicySpec)
+	if in.Routes != nil {
+		in, out := &in.Routes, &out.Routes
+		*out = make([]CORSRouteSpec, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CORSSpec.
+func (in *CORSSpec) DeepCopy() *CORSSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(CORSSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ComponentWarmupSpec) DeepCopyInto(out *ComponentWarmupSpec) {
+	*out = *in
+	if in.Skip != nil {
+		in, out := &in.Skip, &out.Skip
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ComponentWarmupSpec.
+func (in *ComponentWarmupSpec) DeepCopy() *ComponentWarmupSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(ComponentWarmupSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ComponentsSpec) DeepCopyInto(out *ComponentsSpec) {
 	*out = *in
@@ -167,6 +350,11 @@ func (in *ComponentsSpec) DeepCopyInto(out *ComponentsSpec) {
 		*out = make([]string, len(*in))
 		copy(*out, *in)
 	}
+	if in.Warmup != nil {
+		in, out := &in.Warmup, &out.Warmup
+		*out = new(ComponentWarmupSpec)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ComponentsSpec.
@@ -315,8 +503,55 @@ func (in *ConfigurationSpec) DeepCopyInto(out *ConfigurationSpec) {
 	if in.WorkflowSpec != nil {
 		in, out := &in.WorkflowSpec, &out.WorkflowSpec
 		*out = new(WorkflowSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.DNSCacheSpec != nil {
+		in, out := &in.DNSCacheSpec, &out.DNSCacheSpec
+		*out = new(DNSCacheSpec)
 		**out = **in
 	}
+	if in.CORSSpec != nil {
+		in, out := &in.CORSSpec, &out.CORSSpec
+		*out = new(CORSSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.PublishDeduplication != nil {
+		in, out := &in.PublishDeduplication, &out.PublishDeduplication
+		*out = new(PublishDeduplicationSpec)
+		**out = **in
+	}
+	if in.ActorFailover != nil {
+		in, out := &in.ActorFailover, &out.ActorFailover
+		*out = new(ActorFailoverSpec)
+		**out = **in
+	}
+	if in.IdempotentMethods != nil {
+		in, out := &in.IdempotentMethods, &out.IdempotentMethods
+		*out = make([]IdempotentMethodSpec, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Transcoding != nil {
+		in, out := &in.Transcoding, &out.Transcoding
+		*out = new(TranscodingSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.InvokeCompression != nil {
+		in, out := &in.InvokeCompression, &out.InvokeCompression
+		*out = new(InvokeCompressionSpec)
+		**out = **in
+	}
+	if in.RateLimiters != nil {
+		in, out := &in.RateLimiters, &out.RateLimiters
+		*out = make([]RateLimiterSpec, len(*in))
+		copy(*out, *in)
+	}
+	if in.LeaderElections != nil {
+		in, out := &in.LeaderElections, &out.LeaderElections
+		*out = make([]LeaderElectionSpec, len(*in))
+		copy(*out, *in)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ConfigurationSpec.
@@ -329,6 +564,21 @@ func (in *ConfigurationSpec) DeepCopy() *ConfigurationSpec {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DNSCacheSpec) DeepCopyInto(out *DNSCacheSpec) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DNSCacheSpec.
+func (in *DNSCacheSpec) DeepCopy() *DNSCacheSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(DNSCacheSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *DynamicValue) DeepCopyInto(out *DynamicValue) {
 	*out = *in
@@ -385,6 +635,56 @@ func (in *HandlerSpec) DeepCopy() *HandlerSpec {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *IdempotentMethodSpec) DeepCopyInto(out *IdempotentMethodSpec) {
+	*out = *in
+	if in.Methods != nil {
+		in, out := &in.Methods, &out.Methods
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new IdempotentMethodSpec.
+func (in *IdempotentMethodSpec) DeepCopy() *IdempotentMethodSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(IdempotentMethodSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *InvokeCompressionSpec) DeepCopyInto(out *InvokeCompressionSpec) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new InvokeCompressionSpec.
+func (in *InvokeCompressionSpec) DeepCopy() *InvokeCompressionSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(InvokeCompressionSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *LeaderElectionSpec) DeepCopyInto(out *LeaderElectionSpec) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new LeaderElectionSpec.
+func (in *LeaderElectionSpec) DeepCopy() *LeaderElectionSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(LeaderElectionSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *LoggingSpec) DeepCopyInto(out *LoggingSpec) {
 	*out = *in
@@ -393,6 +693,16 @@ func (in *LoggingSpec) DeepCopyInto(out *LoggingSpec) {
 		*out = new(APILoggingSpec)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.AccessLog != nil {
+		in, out := &in.AccessLog, &out.AccessLog
+		*out = new(AccessLogSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Otel != nil {
+		in, out := &in.Otel, &out.Otel
+		*out = new(OtelSpec)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new LoggingSpec.
@@ -430,6 +740,16 @@ func (in *MTLSSpec) DeepCopyInto(out *MTLSSpec) {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
+	if in.RevokedIdentities != nil {
+		in, out := &in.RevokedIdentities, &out.RevokedIdentities
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.TLS != nil {
+		in, out := &in.TLS, &out.TLS
+		*out = new(TLSSpec)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MTLSSpec.
@@ -442,6 +762,41 @@ func (in *MTLSSpec) DeepCopy() *MTLSSpec {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *MetricAppSpec) DeepCopyInto(out *MetricAppSpec) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MetricAppSpec.
+func (in *MetricAppSpec) DeepCopy() *MetricAppSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(MetricAppSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *MetricHTTPSpec) DeepCopyInto(out *MetricHTTPSpec) {
+	*out = *in
+	if in.Routes != nil {
+		in, out := &in.Routes, &out.Routes
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MetricHTTPSpec.
+func (in *MetricHTTPSpec) DeepCopy() *MetricHTTPSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(MetricHTTPSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *MetricLabel) DeepCopyInto(out *MetricLabel) {
 	*out = *in
@@ -464,6 +819,86 @@ func (in *MetricLabel) DeepCopy() *MetricLabel {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *MetricLabelRewrite) DeepCopyInto(out *MetricLabelRewrite) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MetricLabelRewrite.
+func (in *MetricLabelRewrite) DeepCopy() *MetricLabelRewrite {
+	if in == nil {
+		return nil
+	}
+	out := new(MetricLabelRewrite)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *MetricLatencyBucketsSpec) DeepCopyInto(out *MetricLatencyBucketsSpec) {
+	*out = *in
+	if in.HTTP != nil {
+		in, out := &in.HTTP, &out.HTTP
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.GRPC != nil {
+		in, out := &in.GRPC, &out.GRPC
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Workflow != nil {
+		in, out := &in.Workflow, &out.Workflow
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.ServiceInvocation != nil {
+		in, out := &in.ServiceInvocation, &out.ServiceInvocation
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MetricLatencyBucketsSpec.
+func (in *MetricLatencyBucketsSpec) DeepCopy() *MetricLatencyBucketsSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(MetricLatencyBucketsSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *MetricLabelsSpec) DeepCopyInto(out *MetricLabelsSpec) {
+	*out = *in
+	if in.Allow != nil {
+		in, out := &in.Allow, &out.Allow
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Deny != nil {
+		in, out := &in.Deny, &out.Deny
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Rewrites != nil {
+		in, out := &in.Rewrites, &out.Rewrites
+		*out = make([]MetricLabelRewrite, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MetricLabelsSpec.
+func (in *MetricLabelsSpec) DeepCopy() *MetricLabelsSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(MetricLabelsSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *MetricSpec) DeepCopyInto(out *MetricSpec) {
 	*out = *in
@@ -479,6 +914,41 @@ func (in *MetricSpec) DeepCopyInto(out *MetricSpec) {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
+	if in.Otel != nil {
+		in, out := &in.Otel, &out.Otel
+		*out = new(OtelSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Labels != nil {
+		in, out := &in.Labels, &out.Labels
+		*out = new(MetricLabelsSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.HTTP != nil {
+		in, out := &in.HTTP, &out.HTTP
+		*out = new(MetricHTTPSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.LatencyBuckets != nil {
+		in, out := &in.LatencyBuckets, &out.LatencyBuckets
+		*out = new(MetricLatencyBucketsSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Workflow != nil {
+		in, out := &in.Workflow, &out.Workflow
+		*out = new(MetricWorkflowSpec)
+		**out = **in
+	}
+	if in.Resource != nil {
+		in, out := &in.Resource, &out.Resource
+		*out = new(OtelResourceSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.App != nil {
+		in, out := &in.App, &out.App
+		*out = new(MetricAppSpec)
+		**out = **in
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MetricSpec.
@@ -491,6 +961,21 @@ func (in *MetricSpec) DeepCopy() *MetricSpec {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *MetricWorkflowSpec) DeepCopyInto(out *MetricWorkflowSpec) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MetricWorkflowSpec.
+func (in *MetricWorkflowSpec) DeepCopy() *MetricWorkflowSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(MetricWorkflowSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *MetricsRule) DeepCopyInto(out *MetricsRule) {
 	*out = *in
@@ -533,6 +1018,28 @@ func (in *NameResolutionSpec) DeepCopy() *NameResolutionSpec {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *OtelResourceSpec) DeepCopyInto(out *OtelResourceSpec) {
+	*out = *in
+	if in.Attributes != nil {
+		in, out := &in.Attributes, &out.Attributes
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OtelResourceSpec.
+func (in *OtelResourceSpec) DeepCopy() *OtelResourceSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(OtelResourceSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *OtelSpec) DeepCopyInto(out *OtelSpec) {
 	*out = *in
@@ -575,6 +1082,51 @@ func (in *PipelineSpec) DeepCopy() *PipelineSpec {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PublishDeduplicationSpec) DeepCopyInto(out *PublishDeduplicationSpec) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PublishDeduplicationSpec.
+func (in *PublishDeduplicationSpec) DeepCopy() *PublishDeduplicationSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(PublishDeduplicationSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RateLimiterSpec) DeepCopyInto(out *RateLimiterSpec) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RateLimiterSpec.
+func (in *RateLimiterSpec) DeepCopy() *RateLimiterSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(RateLimiterSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RemoteWorkflowSpec) DeepCopyInto(out *RemoteWorkflowSpec) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RemoteWorkflowSpec.
+func (in *RemoteWorkflowSpec) DeepCopy() *RemoteWorkflowSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(RemoteWorkflowSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *SecretsScope) DeepCopyInto(out *SecretsScope) {
 	*out = *in
@@ -657,6 +1209,61 @@ func (in *SelectorSpec) DeepCopy() *SelectorSpec {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SpanAttributeSpec) DeepCopyInto(out *SpanAttributeSpec) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SpanAttributeSpec.
+func (in *SpanAttributeSpec) DeepCopy() *SpanAttributeSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(SpanAttributeSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TLSSpec) DeepCopyInto(out *TLSSpec) {
+	*out = *in
+	if in.CipherSuites != nil {
+		in, out := &in.CipherSuites, &out.CipherSuites
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.CurvePreferences != nil {
+		in, out := &in.CurvePreferences, &out.CurvePreferences
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TLSSpec.
+func (in *TLSSpec) DeepCopy() *TLSSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(TLSSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TailSamplingSpec) DeepCopyInto(out *TailSamplingSpec) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TailSamplingSpec.
+func (in *TailSamplingSpec) DeepCopy() *TailSamplingSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(TailSamplingSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *TracingSpec) DeepCopyInto(out *TracingSpec) {
 	*out = *in
@@ -675,6 +1282,21 @@ func (in *TracingSpec) DeepCopyInto(out *TracingSpec) {
 		*out = new(OtelSpec)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.TailSampling != nil {
+		in, out := &in.TailSampling, &out.TailSampling
+		*out = new(TailSamplingSpec)
+		**out = **in
+	}
+	if in.Resource != nil {
+		in, out := &in.Resource, &out.Resource
+		*out = new(OtelResourceSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.SpanAttributes != nil {
+		in, out := &in.SpanAttributes, &out.SpanAttributes
+		*out = make([]SpanAttributeSpec, len(*in))
+		copy(*out, *in)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TracingSpec.
@@ -687,6 +1309,41 @@ func (in *TracingSpec) DeepCopy() *TracingSpec {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TranscodingMethodSpec) DeepCopyInto(out *TranscodingMethodSpec) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TranscodingMethodSpec.
+func (in *TranscodingMethodSpec) DeepCopy() *TranscodingMethodSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(TranscodingMethodSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TranscodingSpec) DeepCopyInto(out *TranscodingSpec) {
+	*out = *in
+	if in.Methods != nil {
+		in, out := &in.Methods, &out.Methods
+		*out = make([]TranscodingMethodSpec, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TranscodingSpec.
+func (in *TranscodingSpec) DeepCopy() *TranscodingSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(TranscodingSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ValidatorSpec) DeepCopyInto(out *ValidatorSpec) {
 	*out = *in
@@ -722,9 +1379,34 @@ func (in *WasmSpec) DeepCopy() *WasmSpec {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *WorkflowRetentionSpec) DeepCopyInto(out *WorkflowRetentionSpec) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new WorkflowRetentionSpec.
+func (in *WorkflowRetentionSpec) DeepCopy() *WorkflowRetentionSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(WorkflowRetentionSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *WorkflowSpec) DeepCopyInto(out *WorkflowSpec) {
 	*out = *in
+	if in.Retention != nil {
+		in, out := &in.Retention, &out.Retention
+		*out = new(WorkflowRetentionSpec)
+		**out = **in
+	}
+	if in.RemoteWorkflows != nil {
+		in, out := &in.RemoteWorkflows, &out.RemoteWorkflows
+		*out = make([]RemoteWorkflowSpec, len(*in))
+		copy(*out, *in)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new WorkflowSpec.
@@ -751,3 +1433,28 @@ func (in *ZipkinSpec) DeepCopy() *ZipkinSpec {
 	in.DeepCopyInto(out)
 	return out
 }
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *WorkflowPolicySpec) DeepCopyInto(out *WorkflowPolicySpec) {
+	*out = *in
+	if in.Operations != nil {
+		in, out := &in.Operations, &out.Operations
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.AllowedCallers != nil {
+		in, out := &in.AllowedCallers, &out.AllowedCallers
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new WorkflowPolicySpec.
+func (in *WorkflowPolicySpec) DeepCopy() *WorkflowPolicySpec {
+	if in == nil {
+		return nil
+	}
+	out := new(WorkflowPolicySpec)
+	in.DeepCopyInto(out)
+	return out
+}
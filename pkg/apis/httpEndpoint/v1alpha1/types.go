@@ -89,6 +89,11 @@ func (h HTTPEndpoint) HasTLSRootCASecret() bool {
 	return h.Spec.ClientTLS != nil && h.Spec.ClientTLS.RootCA != nil && h.Spec.ClientTLS.RootCA.SecretKeyRef != nil && h.Spec.ClientTLS.RootCA.SecretKeyRef.Name != ""
 }
 
+// HasProxy returns a bool indicating if the HTTP endpoint overrides the proxy settings
+func (h HTTPEndpoint) HasProxy() bool {
+	return h.Spec.Proxy != nil
+}
+
 // HasTLSRootCA returns a bool indicating if the HTTP endpoint contains a tls root ca
 func (h HTTPEndpoint) HasTLSRootCA() bool {
 	return h.Spec.ClientTLS != nil && h.Spec.ClientTLS.RootCA != nil && h.Spec.ClientTLS.RootCA.Value != nil
@@ -123,6 +128,24 @@ type HTTPEndpointSpec struct {
 	Headers []common.NameValuePair `json:"headers"`
 	//+optional
 	ClientTLS *common.TLS `json:"clientTLS,omitempty"`
+	//+optional
+	Proxy *HTTPEndpointProxy `json:"proxy,omitempty"`
+}
+
+// HTTPEndpointProxy overrides the proxy settings that are read from the environment (HTTP_PROXY, HTTPS_PROXY and NO_PROXY) for an HTTPEndpoint.
+type HTTPEndpointProxy struct {
+	// HTTPProxy is the URL of the proxy used for requests to "http" endpoints.
+	//+optional
+	HTTPProxy string `json:"httpProxy,omitempty"`
+	// HTTPSProxy is the URL of the proxy used for requests to "https" endpoints.
+	//+optional
+	HTTPSProxy string `json:"httpsProxy,omitempty"`
+	// NoProxy is a comma-separated list of hosts that are not reached through the proxy, with the same format as NO_PROXY.
+	//+optional
+	NoProxy string `json:"noProxy,omitempty"`
+	// Bypass disables the proxy for the HTTPEndpoint, including any configured in the environment.
+	//+optional
+	Bypass bool `json:"bypass,omitempty"`
 }
 
 // Auth represents authentication details for the component.
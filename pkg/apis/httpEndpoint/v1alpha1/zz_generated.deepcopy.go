@@ -101,6 +101,21 @@ func (in *HTTPEndpointList) DeepCopyObject() runtime.Object {
 	return nil
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *HTTPEndpointProxy) DeepCopyInto(out *HTTPEndpointProxy) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new HTTPEndpointProxy.
+func (in *HTTPEndpointProxy) DeepCopy() *HTTPEndpointProxy {
+	if in == nil {
+		return nil
+	}
+	out := new(HTTPEndpointProxy)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *HTTPEndpointSpec) DeepCopyInto(out *HTTPEndpointSpec) {
 	*out = *in
@@ -116,6 +131,11 @@ func (in *HTTPEndpointSpec) DeepCopyInto(out *HTTPEndpointSpec) {
 		*out = new(common.TLS)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.Proxy != nil {
+		in, out := &in.Proxy, &out.Proxy
+		*out = new(HTTPEndpointProxy)
+		**out = **in
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new HTTPEndpointSpec.
@@ -48,6 +48,7 @@ type Policies struct {
 	Timeouts        map[string]string         `json:"timeouts,omitempty" yaml:"timeouts,omitempty"`
 	Retries         map[string]Retry          `json:"retries,omitempty" yaml:"retries,omitempty"`
 	CircuitBreakers map[string]CircuitBreaker `json:"circuitBreakers,omitempty" yaml:"circuitBreakers,omitempty"`
+	Faults          map[string]Fault          `json:"faults,omitempty" yaml:"faults,omitempty"`
 }
 
 type Retry struct {
@@ -64,6 +65,14 @@ type CircuitBreaker struct {
 	Trip        string `json:"trip,omitempty" yaml:"trip,omitempty"`
 }
 
+// Fault is a fault injection policy, used to test resiliency policies.
+// It is applied only when the "FaultInjection" preview feature is enabled.
+type Fault struct {
+	Delay           string `json:"delay,omitempty" yaml:"delay,omitempty"`
+	DelayPercentage int    `json:"delayPercentage,omitempty" yaml:"delayPercentage,omitempty"`
+	ErrorPercentage int    `json:"errorPercentage,omitempty" yaml:"errorPercentage,omitempty"`
+}
+
 type Targets struct {
 	Apps       map[string]EndpointPolicyNames  `json:"apps,omitempty" yaml:"apps,omitempty"`
 	Actors     map[string]ActorPolicyNames     `json:"actors,omitempty" yaml:"actors,omitempty"`
@@ -79,6 +88,7 @@ type PolicyNames struct {
 	Timeout        string `json:"timeout,omitempty" yaml:"timeout,omitempty"`
 	Retry          string `json:"retry,omitempty" yaml:"retry,omitempty"`
 	CircuitBreaker string `json:"circuitBreaker,omitempty" yaml:"circuitBreaker,omitempty"`
+	Fault          string `json:"fault,omitempty" yaml:"fault,omitempty"`
 }
 
 type EndpointPolicyNames struct {
@@ -86,6 +96,7 @@ type EndpointPolicyNames struct {
 	Retry                   string `json:"retry,omitempty" yaml:"retry,omitempty"`
 	CircuitBreaker          string `json:"circuitBreaker,omitempty" yaml:"circuitBreaker,omitempty"`
 	CircuitBreakerCacheSize int    `json:"circuitBreakerCacheSize,omitempty" yaml:"circuitBreakerCacheSize,omitempty"`
+	Fault                   string `json:"fault,omitempty" yaml:"fault,omitempty"`
 }
 
 type ActorPolicyNames struct {
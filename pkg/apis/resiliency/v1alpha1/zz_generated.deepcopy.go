@@ -87,6 +87,21 @@ func (in *EndpointPolicyNames) DeepCopy() *EndpointPolicyNames {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *Fault) DeepCopyInto(out *Fault) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Fault.
+func (in *Fault) DeepCopy() *Fault {
+	if in == nil {
+		return nil
+	}
+	out := new(Fault)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *Policies) DeepCopyInto(out *Policies) {
 	*out = *in
@@ -111,6 +126,13 @@ func (in *Policies) DeepCopyInto(out *Policies) {
 			(*out)[key] = val
 		}
 	}
+	if in.Faults != nil {
+		in, out := &in.Faults, &out.Faults
+		*out = make(map[string]Fault, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Policies.
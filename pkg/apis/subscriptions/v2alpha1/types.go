@@ -78,7 +78,31 @@ type Rule struct {
 	Match string `json:"match"`
 
 	// The path for events that match this rule.
-	Path string `json:"path"`
+	// +optional
+	Path string `json:"path,omitempty"`
+
+	// The output binding events that match this rule are sent to, instead of being delivered to the app.
+	// +optional
+	Binding *RuleBinding `json:"binding,omitempty"`
+
+	// The optional CEL expression used to transform the events that match this rule.
+	// The expression is evaluated with the event as the "event" variable, and its result
+	// replaces the data of the event before it's delivered.
+	// +optional
+	Transform string `json:"transform,omitempty"`
+}
+
+// RuleBinding is used to send the events matching a rule
+// to an output binding, as JSON-encoded CloudEvents.
+type RuleBinding struct {
+	// The name of the output binding component.
+	Name string `json:"name"`
+	// The operation invoked on the output binding; defaults to "create".
+	// +optional
+	Operation string `json:"operation,omitempty"`
+	// The optional metadata passed to the output binding.
+	// +optional
+	Metadata map[string]string `json:"metadata,omitempty"`
 }
 
 // +kubebuilder:object:root=true
@@ -24,6 +24,7 @@ import (
 	"k8s.io/utils/clock"
 
 	"github.com/dapr/dapr/pkg/config"
+	diag "github.com/dapr/dapr/pkg/diagnostics"
 	"github.com/dapr/kit/logger"
 )
 
@@ -194,7 +195,9 @@ func (h *AppHealth) doProbe(parentCtx context.Context) {
 	ctx, cancel := context.WithTimeout(parentCtx, h.config.ProbeTimeout)
 	defer cancel()
 
+	start := h.clock.Now()
 	successful, err := h.probeFn(ctx)
+	diag.DefaultAppHealthMonitoring.ProbeCompleted(parentCtx, successful && err == nil, h.clock.Since(start))
 	if err != nil {
 		h.setResult(parentCtx, false)
 		log.Errorf("App health probe could not complete with error: %v", err)
@@ -238,8 +241,10 @@ func (h *AppHealth) setResult(ctx context.Context, successful bool) {
 		// Reset the failure count
 		// If the previous value was >= threshold, we need to report a health change
 		prev := h.failureCount.Swap(0)
+		diag.DefaultAppHealthMonitoring.ConsecutiveFailures(ctx, 0)
 		if prev >= h.config.Threshold {
 			log.Info("App entered healthy status")
+			diag.DefaultAppHealthMonitoring.StatusChanged(ctx, diag.AppHealthStatusHealthy)
 			if h.changeCb != nil {
 				h.wg.Add(1)
 				go func() {
@@ -257,10 +262,12 @@ func (h *AppHealth) setResult(ctx context.Context, successful bool) {
 	// First, check if we've overflown
 	if failures < 0 {
 		// Reset to the threshold + 1
-		h.failureCount.Store(h.config.Threshold + 1)
+		failures = h.config.Threshold + 1
+		h.failureCount.Store(failures)
 	} else if failures == h.config.Threshold {
 		// If we're here, we just passed the threshold right now
 		log.Warn("App entered un-healthy status")
+		diag.DefaultAppHealthMonitoring.StatusChanged(ctx, diag.AppHealthStatusUnhealthy)
 		if h.changeCb != nil {
 			h.wg.Add(1)
 			go func() {
@@ -269,6 +276,7 @@ func (h *AppHealth) setResult(ctx context.Context, successful bool) {
 			}()
 		}
 	}
+	diag.DefaultAppHealthMonitoring.ConsecutiveFailures(ctx, failures)
 }
 
 func (h *AppHealth) Close() error {
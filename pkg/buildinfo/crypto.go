@@ -0,0 +1,36 @@
+/*
+Copyright 2023 The Dapr Authors
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package buildinfo
+
+// Names of the crypto backends Dapr can be built against.
+const (
+	// CryptoBackendGo is the Go standard library's native crypto implementation.
+	CryptoBackendGo = "go"
+	// CryptoBackendBoringCrypto is the FIPS 140 validated BoringCrypto module, used when building with GOEXPERIMENT=boringcrypto.
+	CryptoBackendBoringCrypto = "boringcrypto"
+)
+
+// CryptoBackend returns the name of the crypto backend in use.
+func CryptoBackend() string {
+	if fipsEnabled() {
+		return CryptoBackendBoringCrypto
+	}
+	return CryptoBackendGo
+}
+
+// FIPSEnabled returns true if Dapr is running with a FIPS 140 validated crypto module.
+// In this mode, TLS connections are restricted to FIPS-approved versions, cipher suites, and curves.
+func FIPSEnabled() bool {
+	return fipsEnabled()
+}
@@ -192,7 +192,7 @@ func (h *Channel) HealthProbe(ctx context.Context) (bool, error) {
 
 	channelResp, err := h.client.Do(channelReq)
 
-	elapsedMs := float64(time.Since(startRequest) / time.Millisecond)
+	elapsedMs := diag.ElapsedSince(startRequest)
 
 	if err != nil {
 		// Errors here are network-level errors, so we are not returning them as errors
@@ -260,7 +260,7 @@ func (h *Channel) invokeMethodV1(ctx context.Context, req *invokev1.InvokeMethod
 		resp, err = h.client.Do(channelReq)
 	}
 
-	elapsedMs := float64(time.Since(startRequest) / time.Millisecond)
+	elapsedMs := diag.ElapsedSince(startRequest)
 
 	var contentLength int64
 	if resp != nil {
@@ -348,13 +348,12 @@ func (h *Channel) constructRequest(ctx context.Context, req *invokev1.InvokeMeth
 		channelReq.ContentLength = v
 	}
 
-	// HTTP client needs to inject traceparent header for proper tracing stack.
+	// HTTP client needs to inject the trace context headers, such as traceparent, for proper tracing stack.
 	span := diagUtils.SpanFromContext(ctx)
-	tp := diag.SpanContextToW3CString(span.SpanContext())
-	ts := diag.TraceStateToW3CString(span.SpanContext())
-	channelReq.Header.Set("traceparent", tp)
-	if ts != "" {
-		channelReq.Header.Set("tracestate", ts)
+	diag.SpanContextToHTTPHeaders(span.SpanContext(), channelReq.Header.Set)
+	// The baggage of the request is forwarded as-is if it was received in the metadata, for example in service invocation.
+	if channelReq.Header.Get(diag.BaggageHeader) == "" {
+		diag.BaggageToHTTPHeaders(ctx, channelReq.Header.Set)
 	}
 
 	if h.appHeaderToken != "" {
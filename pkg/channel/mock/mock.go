@@ -0,0 +1,181 @@
+/*
+Copyright 2023 The Dapr Authors
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package mock contains an app channel that acts as a fake app, responding to the requests sent by the runtime with canned responses.
+// It allows running end-to-end smoke tests of components without a real app, and it's meant for local development only.
+package mock
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"os"
+	"strings"
+
+	"sigs.k8s.io/yaml"
+
+	"github.com/dapr/dapr/pkg/apphealth"
+	"github.com/dapr/dapr/pkg/channel"
+	"github.com/dapr/dapr/pkg/config"
+	invokev1 "github.com/dapr/dapr/pkg/messaging/v1"
+	"github.com/dapr/kit/logger"
+)
+
+// AppChannelName is the value of the --app-channel flag that enables the mock app channel.
+const AppChannelName = "mock"
+
+// subscribeMethod is the method invoked by the runtime to retrieve the programmatic subscriptions of the app.
+const subscribeMethod = "dapr/subscribe"
+
+var log = logger.NewLogger("dapr.channel.mock")
+
+// Config contains the configuration of the mock app channel.
+type Config struct {
+	// Subscriptions are returned to the runtime as the programmatic subscriptions of the app, in the same format used by apps.
+	Subscriptions json.RawMessage `json:"subscriptions,omitempty"`
+	// Responses are the canned responses returned to the runtime.
+	// They are matched against the invoked method in order, and the first match is used.
+	// Requests that don't match any response are echoed back with status 200.
+	Responses []Response `json:"responses,omitempty"`
+}
+
+// Response is a canned response returned by the mock app channel.
+type Response struct {
+	// Method the response applies to, such as the name of a method invoked on the app, the route of a subscription or the name of an input binding.
+	// A trailing "*" matches all methods with the given prefix; an empty value or "*" matches all methods.
+	Method string `json:"method,omitempty"`
+	// HTTP verb the response applies to; if empty, the response applies to all verbs.
+	Verb string `json:"verb,omitempty"`
+	// Status code of the response; defaults to 200.
+	Status int `json:"status,omitempty"`
+	// Content type of the response; defaults to "application/json" if the body is set.
+	ContentType string `json:"contentType,omitempty"`
+	// Body of the response.
+	Body string `json:"body,omitempty"`
+	// If true, the body of the request is echoed back instead of Body.
+	Echo bool `json:"echo,omitempty"`
+}
+
+// LoadConfig loads the configuration of the mock app channel from a YAML or JSON file.
+// If path is empty, the returned configuration echoes back all requests and declares no subscriptions.
+func LoadConfig(path string) (*Config, error) {
+	cfg := &Config{}
+	if path == "" {
+		return cfg, nil
+	}
+
+	b, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read mock app channel configuration: %w", err)
+	}
+	err = yaml.Unmarshal(b, cfg)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse mock app channel configuration: %w", err)
+	}
+	return cfg, nil
+}
+
+// Channel is an app channel that responds to the requests sent by the runtime with canned responses.
+type Channel struct {
+	cfg *Config
+}
+
+var _ channel.AppChannel = (*Channel)(nil)
+
+// New returns a new mock app channel.
+func New(cfg *Config) *Channel {
+	if cfg == nil {
+		cfg = &Config{}
+	}
+	return &Channel{cfg: cfg}
+}
+
+// GetAppConfig returns an empty app configuration.
+func (c *Channel) GetAppConfig(ctx context.Context, appID string) (*config.ApplicationConfig, error) {
+	return &config.ApplicationConfig{}, nil
+}
+
+// HealthProbe always reports the app as healthy.
+func (c *Channel) HealthProbe(ctx context.Context) (bool, error) {
+	return true, nil
+}
+
+// SetAppHealth is a no-op, since the mock app is always healthy.
+func (c *Channel) SetAppHealth(ah *apphealth.AppHealth) {}
+
+// InvokeMethod responds to a request with the first canned response that matches it.
+func (c *Channel) InvokeMethod(ctx context.Context, req *invokev1.InvokeMethodRequest, appID string) (*invokev1.InvokeMethodResponse, error) {
+	method := strings.TrimPrefix(req.Message().GetMethod(), "/")
+	verb := req.Message().GetHttpExtension().GetVerb().String()
+	log.Debugf("Mock app received %s request for method '%s'", verb, method)
+
+	for _, r := range c.cfg.Responses {
+		if r.matches(method, verb) {
+			return r.response(req)
+		}
+	}
+
+	if method == subscribeMethod {
+		subs := c.cfg.Subscriptions
+		if len(subs) == 0 {
+			subs = json.RawMessage("[]")
+		}
+		return invokev1.NewInvokeMethodResponse(http.StatusOK, "", nil).
+			WithContentType(invokev1.JSONContentType).
+			WithRawDataBytes(subs), nil
+	}
+
+	return Response{Echo: true}.response(req)
+}
+
+func (r Response) matches(method, verb string) bool {
+	if r.Verb != "" && !strings.EqualFold(r.Verb, verb) {
+		return false
+	}
+	pattern := strings.TrimPrefix(r.Method, "/")
+	switch {
+	case pattern == "" || pattern == "*":
+		return true
+	case strings.HasSuffix(pattern, "*"):
+		return strings.HasPrefix(method, strings.TrimSuffix(pattern, "*"))
+	default:
+		return method == pattern
+	}
+}
+
+func (r Response) response(req *invokev1.InvokeMethodRequest) (*invokev1.InvokeMethodResponse, error) {
+	status := r.Status
+	if status == 0 {
+		status = http.StatusOK
+	}
+	res := invokev1.NewInvokeMethodResponse(int32(status), http.StatusText(status), nil)
+
+	if r.Echo {
+		body, err := req.RawDataFull()
+		if err != nil {
+			return nil, fmt.Errorf("failed to read request body: %w", err)
+		}
+		return res.
+			WithContentType(req.ContentType()).
+			WithRawDataBytes(body), nil
+	}
+
+	contentType := r.ContentType
+	if contentType == "" && r.Body != "" {
+		contentType = invokev1.JSONContentType
+	}
+	return res.
+		WithContentType(contentType).
+		WithRawDataString(r.Body), nil
+}
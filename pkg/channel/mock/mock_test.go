@@ -0,0 +1,134 @@
+/*
+Copyright 2023 The Dapr Authors
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package mock
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	invokev1 "github.com/dapr/dapr/pkg/messaging/v1"
+)
+
+func invoke(t *testing.T, c *Channel, method, verb, body string) (int32, string, string) {
+	t.Helper()
+
+	req := invokev1.NewInvokeMethodRequest(method).
+		WithHTTPExtension(verb, "").
+		WithContentType("text/plain").
+		WithRawDataString(body)
+	defer req.Close()
+
+	res, err := c.InvokeMethod(context.Background(), req, "")
+	require.NoError(t, err)
+	defer res.Close()
+
+	data, err := io.ReadAll(res.RawData())
+	require.NoError(t, err)
+	return res.Status().GetCode(), res.ContentType(), string(data)
+}
+
+func TestInvokeMethod(t *testing.T) {
+	c := New(&Config{
+		Subscriptions: []byte(`[{"pubsubname":"pubsub","topic":"orders","route":"/orders"}]`),
+		Responses: []Response{
+			{Method: "fail", Status: http.StatusInternalServerError, Body: `{"error":"boom"}`},
+			{Method: "orders", Verb: "POST", Body: `{"status":"SUCCESS"}`},
+			{Method: "binding*", Status: http.StatusAccepted},
+		},
+	})
+
+	t.Run("echoes requests that don't match a response", func(t *testing.T) {
+		status, contentType, body := invoke(t, c, "hello", http.MethodPost, "ciao")
+		assert.Equal(t, int32(http.StatusOK), status)
+		assert.Equal(t, "text/plain", contentType)
+		assert.Equal(t, "ciao", body)
+	})
+
+	t.Run("canned response", func(t *testing.T) {
+		status, contentType, body := invoke(t, c, "fail", http.MethodGet, "")
+		assert.Equal(t, int32(http.StatusInternalServerError), status)
+		assert.Equal(t, invokev1.JSONContentType, contentType)
+		assert.Equal(t, `{"error":"boom"}`, body)
+	})
+
+	t.Run("matches verb", func(t *testing.T) {
+		_, _, body := invoke(t, c, "/orders", http.MethodPost, "event")
+		assert.Equal(t, `{"status":"SUCCESS"}`, body)
+
+		_, _, body = invoke(t, c, "orders", http.MethodPut, "event")
+		assert.Equal(t, "event", body)
+	})
+
+	t.Run("matches prefix", func(t *testing.T) {
+		status, _, body := invoke(t, c, "binding-1", http.MethodOptions, "")
+		assert.Equal(t, int32(http.StatusAccepted), status)
+		assert.Empty(t, body)
+	})
+
+	t.Run("returns subscriptions", func(t *testing.T) {
+		status, _, body := invoke(t, c, "dapr/subscribe", http.MethodGet, "")
+		assert.Equal(t, int32(http.StatusOK), status)
+		assert.JSONEq(t, `[{"pubsubname":"pubsub","topic":"orders","route":"/orders"}]`, body)
+
+		_, _, body = invoke(t, New(nil), "dapr/subscribe", http.MethodGet, "")
+		assert.Equal(t, "[]", body)
+	})
+
+	t.Run("is always healthy", func(t *testing.T) {
+		healthy, err := c.HealthProbe(context.Background())
+		require.NoError(t, err)
+		assert.True(t, healthy)
+	})
+}
+
+func TestLoadConfig(t *testing.T) {
+	t.Run("empty path", func(t *testing.T) {
+		cfg, err := LoadConfig("")
+		require.NoError(t, err)
+		assert.Empty(t, cfg.Responses)
+		assert.Empty(t, cfg.Subscriptions)
+	})
+
+	t.Run("YAML file", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), "mock.yaml")
+		require.NoError(t, os.WriteFile(path, []byte(`
+subscriptions:
+- pubsubname: pubsub
+  topic: orders
+  route: /orders
+responses:
+- method: orders
+  status: 200
+  body: '{"status":"RETRY"}'
+`), 0o600))
+
+		cfg, err := LoadConfig(path)
+		require.NoError(t, err)
+		assert.JSONEq(t, `[{"pubsubname":"pubsub","topic":"orders","route":"/orders"}]`, string(cfg.Subscriptions))
+		require.Len(t, cfg.Responses, 1)
+		assert.Equal(t, Response{Method: "orders", Status: 200, Body: `{"status":"RETRY"}`}, cfg.Responses[0])
+	})
+
+	t.Run("missing file", func(t *testing.T) {
+		_, err := LoadConfig(filepath.Join(t.TempDir(), "missing.yaml"))
+		require.Error(t, err)
+	})
+}
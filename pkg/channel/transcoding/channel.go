@@ -0,0 +1,155 @@
+/*
+Copyright 2023 The Dapr Authors
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package transcoding
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/dapr/dapr/pkg/channel"
+	invokev1 "github.com/dapr/dapr/pkg/messaging/v1"
+	"github.com/dapr/kit/logger"
+)
+
+// acceptHeader is the header in which the caller declares the content type of the responses it accepts.
+const acceptHeader = "accept"
+
+var log = logger.NewLogger("dapr.channel.transcoding")
+
+// transcodingChannel is an app channel that transcodes the bodies of the requests to the app, and of their responses, between the content type of the caller and the one of the app.
+type transcodingChannel struct {
+	channel.AppChannel
+
+	transcoder *Transcoder
+}
+
+// WrapChannel returns an app channel that transcodes the bodies of the requests sent with the channel, and of their responses.
+// The requests to methods that aren't transcoded are sent as-is.
+func (t *Transcoder) WrapChannel(ch channel.AppChannel) channel.AppChannel {
+	if t == nil || ch == nil {
+		return ch
+	}
+	return &transcodingChannel{
+		AppChannel: ch,
+		transcoder: t,
+	}
+}
+
+// InvokeMethod transcodes the body of the request to the content type of the app, invokes the method of the app, and transcodes the body of the response to the content type accepted by the caller.
+// The caller declares the content type of the request body in the Content-Type, and the content type it accepts in the Accept header, which defaults to the one of the request.
+func (c *transcodingChannel) InvokeMethod(ctx context.Context, req *invokev1.InvokeMethodRequest, appID string) (*invokev1.InvokeMethodResponse, error) {
+	m, ok := c.transcoder.methods[req.Message().GetMethod()]
+	if !ok {
+		return c.AppChannel.InvokeMethod(ctx, req, appID)
+	}
+
+	reqFormat := formatOf(req.ContentType())
+	accepted := formatOf(headerValue(req.Metadata(), acceptHeader))
+	if accepted == formatUnknown {
+		accepted = reqFormat
+	}
+
+	if reqFormat != formatUnknown && reqFormat != m.format {
+		if err := transcodeRequest(req, m); err != nil {
+			return nil, err
+		}
+	}
+
+	resp, err := c.AppChannel.InvokeMethod(ctx, req, appID)
+	if err != nil || resp == nil {
+		return resp, err
+	}
+
+	if accepted != formatUnknown && accepted != m.format && isSuccess(resp) && formatOf(resp.ContentType()) == m.format {
+		if err = transcodeResponse(resp, m, accepted); err != nil {
+			resp.Close()
+			return nil, err
+		}
+	}
+
+	return resp, nil
+}
+
+func transcodeRequest(req *invokev1.InvokeMethodRequest, m *method) error {
+	data, err := req.RawDataFull()
+	if err != nil {
+		return fmt.Errorf("failed to read the request body: %w", err)
+	}
+	if len(data) > 0 {
+		data, err = transcode(data, m.request, formatOf(req.ContentType()), m.format)
+		if err != nil {
+			return fmt.Errorf("failed to transcode the request body: %w", err)
+		}
+	}
+
+	log.Debugf("Transcoded the request body of method '%s' to %s", req.Message().GetMethod(), m.format)
+	req.WithRawDataBytes(data).
+		WithDataTypeURL("").
+		WithContentType(m.contentType)
+	deleteHeader(req.Metadata(), invokev1.ContentLengthHeader)
+	return nil
+}
+
+func transcodeResponse(resp *invokev1.InvokeMethodResponse, m *method, to format) error {
+	if resp.Message() == nil {
+		return nil
+	}
+
+	data, err := resp.RawDataFull()
+	if err != nil {
+		return fmt.Errorf("failed to read the response body: %w", err)
+	}
+	if len(data) > 0 {
+		data, err = transcode(data, m.response, m.format, to)
+		if err != nil {
+			return fmt.Errorf("failed to transcode the response body: %w", err)
+		}
+	}
+
+	resp.WithRawDataBytes(data).
+		WithDataTypeURL("").
+		WithContentType(to.contentType())
+	deleteHeader(resp.Headers(), invokev1.ContentLengthHeader)
+	return nil
+}
+
+// isSuccess returns true if the response of the app is successful, so its body is a message of the response type.
+func isSuccess(resp *invokev1.InvokeMethodResponse) bool {
+	code := resp.Status().GetCode()
+	if resp.IsHTTPResponse() {
+		return code >= http.StatusOK && code < http.StatusMultipleChoices
+	}
+	return code == 0
+}
+
+// headerValue returns the first value of the header, whose name is case-insensitive.
+func headerValue(md invokev1.DaprInternalMetadata, key string) string {
+	for k, v := range md {
+		if strings.EqualFold(k, key) && len(v.GetValues()) > 0 {
+			return v.GetValues()[0]
+		}
+	}
+	return ""
+}
+
+// deleteHeader deletes the header, whose name is case-insensitive.
+func deleteHeader(md invokev1.DaprInternalMetadata, key string) {
+	for k := range md {
+		if strings.EqualFold(k, key) {
+			delete(md, k)
+		}
+	}
+}
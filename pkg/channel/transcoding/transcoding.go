@@ -0,0 +1,194 @@
+/*
+Copyright 2023 The Dapr Authors
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package transcoding converts the bodies of the service invocation requests to the app, and of their responses, between JSON and protobuf.
+package transcoding
+
+import (
+	"fmt"
+	"mime"
+	"os"
+	"strings"
+
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protodesc"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/reflect/protoregistry"
+	"google.golang.org/protobuf/types/descriptorpb"
+	"google.golang.org/protobuf/types/dynamicpb"
+
+	"github.com/dapr/dapr/pkg/config"
+	invokev1 "github.com/dapr/dapr/pkg/messaging/v1"
+)
+
+// format is the format of a body that can be transcoded.
+type format int
+
+const (
+	formatUnknown format = iota
+	formatJSON
+	formatProtobuf
+)
+
+func (f format) String() string {
+	switch f {
+	case formatJSON:
+		return "JSON"
+	case formatProtobuf:
+		return "protobuf"
+	default:
+		return "unknown"
+	}
+}
+
+// contentType returns the content type of the bodies in the format.
+func (f format) contentType() string {
+	if f == formatProtobuf {
+		return invokev1.ProtobufContentType
+	}
+	return invokev1.JSONContentType
+}
+
+// formatOf returns the format of the bodies with the content type, or formatUnknown if they can't be transcoded.
+func formatOf(contentType string) format {
+	if contentType == "" {
+		return formatUnknown
+	}
+	mediaType, _, err := mime.ParseMediaType(contentType)
+	if err != nil {
+		return formatUnknown
+	}
+
+	switch {
+	case mediaType == "application/json" || strings.HasSuffix(mediaType, "+json"):
+		return formatJSON
+	case mediaType == "application/x-protobuf" || mediaType == "application/protobuf":
+		return formatProtobuf
+	default:
+		return formatUnknown
+	}
+}
+
+// method is a method of the app that is transcoded.
+type method struct {
+	contentType string
+	format      format
+	request     protoreflect.MessageDescriptor
+	response    protoreflect.MessageDescriptor
+}
+
+// Transcoder transcodes the bodies of the requests to the methods of the app, and of their responses, using the message types of the methods.
+type Transcoder struct {
+	methods map[string]*method
+}
+
+// New returns a Transcoder for the methods in the spec, whose message types are loaded from the descriptor set file.
+// It returns nil if the spec is nil.
+func New(spec *config.TranscodingSpec) (*Transcoder, error) {
+	if spec == nil {
+		return nil, nil
+	}
+
+	files, err := loadDescriptorSet(spec.DescriptorSetFile)
+	if err != nil {
+		return nil, err
+	}
+
+	t := &Transcoder{
+		methods: make(map[string]*method, len(spec.Methods)),
+	}
+	for _, m := range spec.Methods {
+		if m.Name == "" {
+			return nil, fmt.Errorf("invalid transcoding method: name is required")
+		}
+		if _, ok := t.methods[m.Name]; ok {
+			return nil, fmt.Errorf("duplicate transcoding method '%s'", m.Name)
+		}
+
+		f := formatOf(m.ContentType)
+		if f == formatUnknown {
+			return nil, fmt.Errorf("invalid content type '%s' for transcoding method '%s': must be a JSON or protobuf content type", m.ContentType, m.Name)
+		}
+		req, err := findMessage(files, m.RequestType)
+		if err != nil {
+			return nil, fmt.Errorf("invalid request type for transcoding method '%s': %w", m.Name, err)
+		}
+		res, err := findMessage(files, m.ResponseType)
+		if err != nil {
+			return nil, fmt.Errorf("invalid response type for transcoding method '%s': %w", m.Name, err)
+		}
+
+		t.methods[m.Name] = &method{
+			contentType: m.ContentType,
+			format:      f,
+			request:     req,
+			response:    res,
+		}
+	}
+
+	return t, nil
+}
+
+func loadDescriptorSet(path string) (*protoregistry.Files, error) {
+	if path == "" {
+		return nil, fmt.Errorf("the descriptor set file for transcoding is required")
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read the descriptor set file for transcoding: %w", err)
+	}
+
+	fds := &descriptorpb.FileDescriptorSet{}
+	if err = proto.Unmarshal(data, fds); err != nil {
+		return nil, fmt.Errorf("failed to parse the descriptor set file for transcoding: %w", err)
+	}
+	files, err := protodesc.NewFiles(fds)
+	if err != nil {
+		return nil, fmt.Errorf("invalid descriptor set file for transcoding: %w", err)
+	}
+	return files, nil
+}
+
+func findMessage(files *protoregistry.Files, name string) (protoreflect.MessageDescriptor, error) {
+	desc, err := files.FindDescriptorByName(protoreflect.FullName(name))
+	if err != nil {
+		return nil, fmt.Errorf("message type '%s' not found in the descriptor set", name)
+	}
+	md, ok := desc.(protoreflect.MessageDescriptor)
+	if !ok {
+		return nil, fmt.Errorf("'%s' is not a message type", name)
+	}
+	return md, nil
+}
+
+// transcode converts the body of a message of the type from a format to another.
+func transcode(data []byte, desc protoreflect.MessageDescriptor, from, to format) ([]byte, error) {
+	msg := dynamicpb.NewMessage(desc)
+
+	var err error
+	switch from {
+	case formatJSON:
+		err = protojson.Unmarshal(data, msg)
+	case formatProtobuf:
+		err = proto.Unmarshal(data, msg)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("invalid %s body for message type '%s': %w", from, desc.FullName(), err)
+	}
+
+	if to == formatJSON {
+		return protojson.Marshal(msg)
+	}
+	return proto.Marshal(msg)
+}
@@ -0,0 +1,241 @@
+/*
+Copyright 2023 The Dapr Authors
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package transcoding
+
+import (
+	"context"
+	"net/http"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protodesc"
+	"google.golang.org/protobuf/types/descriptorpb"
+	"google.golang.org/protobuf/types/known/anypb"
+
+	"github.com/dapr/dapr/pkg/channel"
+	"github.com/dapr/dapr/pkg/config"
+	invokev1 "github.com/dapr/dapr/pkg/messaging/v1"
+	commonv1pb "github.com/dapr/dapr/pkg/proto/common/v1"
+)
+
+const testMethod = "savestate"
+
+// writeTestDescriptorSet writes the descriptor set of the common protos to a temporary file.
+func writeTestDescriptorSet(t *testing.T) string {
+	t.Helper()
+
+	fds := &descriptorpb.FileDescriptorSet{
+		File: []*descriptorpb.FileDescriptorProto{
+			protodesc.ToFileDescriptorProto(anypb.File_google_protobuf_any_proto),
+			protodesc.ToFileDescriptorProto(commonv1pb.File_dapr_proto_common_v1_common_proto),
+		},
+	}
+	data, err := proto.Marshal(fds)
+	require.NoError(t, err)
+
+	path := filepath.Join(t.TempDir(), "descriptors.pb")
+	require.NoError(t, os.WriteFile(path, data, 0o600))
+	return path
+}
+
+func testSpec(t *testing.T, contentType string) *config.TranscodingSpec {
+	return &config.TranscodingSpec{
+		DescriptorSetFile: writeTestDescriptorSet(t),
+		Methods: []config.TranscodingMethodSpec{{
+			Name:         testMethod,
+			ContentType:  contentType,
+			RequestType:  "dapr.proto.common.v1.StateItem",
+			ResponseType: "dapr.proto.common.v1.Etag",
+		}},
+	}
+}
+
+// fakeChannel is an app channel that records the requests and responds with a canned response.
+type fakeChannel struct {
+	channel.AppChannel
+
+	reqContentType string
+	reqBody        []byte
+	reqMetadata    invokev1.DaprInternalMetadata
+	respond        func() *invokev1.InvokeMethodResponse
+}
+
+func (f *fakeChannel) InvokeMethod(ctx context.Context, req *invokev1.InvokeMethodRequest, appID string) (*invokev1.InvokeMethodResponse, error) {
+	f.reqContentType = req.ContentType()
+	f.reqBody, _ = req.RawDataFull()
+	f.reqMetadata = req.Metadata()
+	return f.respond(), nil
+}
+
+func TestNew(t *testing.T) {
+	t.Run("nil spec", func(t *testing.T) {
+		tr, err := New(nil)
+		require.NoError(t, err)
+		assert.Nil(t, tr)
+	})
+
+	t.Run("valid spec", func(t *testing.T) {
+		tr, err := New(testSpec(t, "application/x-protobuf"))
+		require.NoError(t, err)
+		require.Contains(t, tr.methods, testMethod)
+		assert.Equal(t, formatProtobuf, tr.methods[testMethod].format)
+	})
+
+	t.Run("missing descriptor set file", func(t *testing.T) {
+		spec := testSpec(t, "application/json")
+		spec.DescriptorSetFile = filepath.Join(t.TempDir(), "missing.pb")
+		_, err := New(spec)
+		require.ErrorContains(t, err, "failed to read the descriptor set file")
+	})
+
+	t.Run("unknown message type", func(t *testing.T) {
+		spec := testSpec(t, "application/json")
+		spec.Methods[0].RequestType = "dapr.proto.common.v1.Missing"
+		_, err := New(spec)
+		require.ErrorContains(t, err, "dapr.proto.common.v1.Missing")
+	})
+
+	t.Run("invalid content type", func(t *testing.T) {
+		_, err := New(testSpec(t, "text/plain"))
+		require.ErrorContains(t, err, "text/plain")
+	})
+
+	t.Run("duplicate method", func(t *testing.T) {
+		spec := testSpec(t, "application/json")
+		spec.Methods = append(spec.Methods, spec.Methods[0])
+		_, err := New(spec)
+		require.ErrorContains(t, err, "duplicate")
+	})
+}
+
+func TestFormatOf(t *testing.T) {
+	assert.Equal(t, formatJSON, formatOf("application/json; charset=utf-8"))
+	assert.Equal(t, formatJSON, formatOf("application/cloudevents+json"))
+	assert.Equal(t, formatProtobuf, formatOf("application/x-protobuf"))
+	assert.Equal(t, formatProtobuf, formatOf("application/protobuf"))
+	assert.Equal(t, formatUnknown, formatOf("text/plain"))
+	assert.Equal(t, formatUnknown, formatOf(""))
+}
+
+func TestInvokeMethod(t *testing.T) {
+	tr, err := New(testSpec(t, "application/x-protobuf"))
+	require.NoError(t, err)
+
+	etag := &commonv1pb.Etag{Value: "1"}
+	etagBytes, err := proto.Marshal(etag)
+	require.NoError(t, err)
+	protoResponse := func() *invokev1.InvokeMethodResponse {
+		return invokev1.NewInvokeMethodResponse(http.StatusOK, "", nil).
+			WithRawDataBytes(etagBytes).
+			WithContentType(invokev1.ProtobufContentType)
+	}
+
+	t.Run("JSON request to protobuf app", func(t *testing.T) {
+		app := &fakeChannel{respond: protoResponse}
+		req := invokev1.NewInvokeMethodRequest(testMethod).
+			WithHTTPExtension(http.MethodPost, "").
+			WithMetadata(map[string][]string{"Content-Length": {"28"}}).
+			WithRawDataString(`{"key":"mykey","value":"AQI="}`).
+			WithContentType("application/json")
+		defer req.Close()
+
+		resp, err := tr.WrapChannel(app).InvokeMethod(context.Background(), req, "")
+		require.NoError(t, err)
+		defer resp.Close()
+
+		assert.Equal(t, invokev1.ProtobufContentType, app.reqContentType)
+		assert.NotContains(t, app.reqMetadata, "Content-Length")
+		item := &commonv1pb.StateItem{}
+		require.NoError(t, proto.Unmarshal(app.reqBody, item))
+		assert.Equal(t, "mykey", item.GetKey())
+		assert.Equal(t, []byte{1, 2}, item.GetValue())
+
+		assert.Equal(t, invokev1.JSONContentType, resp.ContentType())
+		body, err := resp.RawDataFull()
+		require.NoError(t, err)
+		got := &commonv1pb.Etag{}
+		require.NoError(t, protojson.Unmarshal(body, got))
+		assert.Equal(t, "1", got.GetValue())
+	})
+
+	t.Run("protobuf request accepting JSON", func(t *testing.T) {
+		app := &fakeChannel{respond: protoResponse}
+		itemBytes, err := proto.Marshal(&commonv1pb.StateItem{Key: "mykey"})
+		require.NoError(t, err)
+		req := invokev1.NewInvokeMethodRequest(testMethod).
+			WithMetadata(map[string][]string{"Accept": {"application/json"}}).
+			WithRawDataBytes(itemBytes).
+			WithContentType(invokev1.ProtobufContentType)
+		defer req.Close()
+
+		resp, err := tr.WrapChannel(app).InvokeMethod(context.Background(), req, "")
+		require.NoError(t, err)
+		defer resp.Close()
+
+		assert.Equal(t, itemBytes, app.reqBody)
+		assert.Equal(t, invokev1.JSONContentType, resp.ContentType())
+	})
+
+	t.Run("error responses are not transcoded", func(t *testing.T) {
+		app := &fakeChannel{respond: func() *invokev1.InvokeMethodResponse {
+			return invokev1.NewInvokeMethodResponse(http.StatusInternalServerError, "", nil).
+				WithRawDataString("failed").
+				WithContentType(invokev1.ProtobufContentType)
+		}}
+		req := invokev1.NewInvokeMethodRequest(testMethod).
+			WithRawDataString(`{"key":"mykey"}`).
+			WithContentType("application/json")
+		defer req.Close()
+
+		resp, err := tr.WrapChannel(app).InvokeMethod(context.Background(), req, "")
+		require.NoError(t, err)
+		defer resp.Close()
+
+		body, err := resp.RawDataFull()
+		require.NoError(t, err)
+		assert.Equal(t, "failed", string(body))
+	})
+
+	t.Run("invalid request body", func(t *testing.T) {
+		app := &fakeChannel{respond: protoResponse}
+		req := invokev1.NewInvokeMethodRequest(testMethod).
+			WithRawDataString(`{"unknown":1}`).
+			WithContentType("application/json")
+		defer req.Close()
+
+		_, err := tr.WrapChannel(app).InvokeMethod(context.Background(), req, "")
+		require.ErrorContains(t, err, "failed to transcode the request body")
+		assert.Nil(t, app.reqBody)
+	})
+
+	t.Run("methods that aren't transcoded", func(t *testing.T) {
+		app := &fakeChannel{respond: protoResponse}
+		req := invokev1.NewInvokeMethodRequest("other").
+			WithRawDataString(`{"key":"mykey"}`).
+			WithContentType("application/json")
+		defer req.Close()
+
+		resp, err := tr.WrapChannel(app).InvokeMethod(context.Background(), req, "")
+		require.NoError(t, err)
+		defer resp.Close()
+
+		assert.Equal(t, `{"key":"mykey"}`, string(app.reqBody))
+		assert.Equal(t, invokev1.ProtobufContentType, resp.ContentType())
+	})
+}
@@ -0,0 +1,212 @@
+/*
+Copyright 2023 The Dapr Authors
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package admin is a client for the operational APIs of the Dapr sidecar: the metadata, the boot report, and the health checks.
+// It's meant for tooling that inspects sidecars, and returns typed models instead of the raw responses of the HTTP API.
+package admin
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+)
+
+const (
+	apiVersion = "v1.0"
+
+	// apiTokenHeader is the header with the token that authenticates the requests to the Dapr API.
+	apiTokenHeader = "dapr-api-token"
+
+	defaultTimeout = 10 * time.Second
+)
+
+// Client is a client for the operational APIs of a Dapr sidecar.
+type Client struct {
+	baseURL    *url.URL
+	httpClient *http.Client
+	apiToken   string
+}
+
+// Option is an option of the client.
+type Option func(c *Client)
+
+// WithHTTPClient sets the HTTP client used to send the requests.
+// By default, a client with a timeout of 10s is used.
+func WithHTTPClient(httpClient *http.Client) Option {
+	return func(c *Client) {
+		c.httpClient = httpClient
+	}
+}
+
+// WithAPIToken sets the token that authenticates the requests, if the sidecar requires one.
+func WithAPIToken(token string) Option {
+	return func(c *Client) {
+		c.apiToken = token
+	}
+}
+
+// Error is the error returned when the sidecar responds with an error status code.
+type Error struct {
+	StatusCode int
+	// ErrorCode is the Dapr error code, such as "ERR_HEALTH_NOT_READY", if the response contains one.
+	ErrorCode string
+	Message   string
+}
+
+func (e *Error) Error() string {
+	if e.ErrorCode == "" {
+		return fmt.Sprintf("dapr API responded with status code %d: %s", e.StatusCode, e.Message)
+	}
+	return fmt.Sprintf("dapr API responded with status code %d: %s (%s)", e.StatusCode, e.Message, e.ErrorCode)
+}
+
+// New returns a client for the sidecar at the address of its HTTP API, such as "http://localhost:3500".
+func New(address string, opts ...Option) (*Client, error) {
+	if !strings.Contains(address, "://") {
+		address = "http://" + address
+	}
+	baseURL, err := url.Parse(address)
+	if err != nil {
+		return nil, fmt.Errorf("invalid address '%s': %w", address, err)
+	}
+	if baseURL.Host == "" {
+		return nil, fmt.Errorf("invalid address '%s': missing host", address)
+	}
+
+	c := &Client{
+		baseURL:    baseURL,
+		httpClient: &http.Client{Timeout: defaultTimeout},
+	}
+	for _, opt := range opts {
+		opt(c)
+	}
+	return c, nil
+}
+
+// GetMetadata returns the metadata of the sidecar.
+func (c *Client) GetMetadata(ctx context.Context) (*Metadata, error) {
+	res := &Metadata{}
+	if err := c.do(ctx, http.MethodGet, "metadata", nil, res); err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+
+// SetMetadata sets an attribute of the extended metadata of the sidecar.
+func (c *Client) SetMetadata(ctx context.Context, key string, value string) error {
+	if key == "" {
+		return errors.New("metadata key is empty")
+	}
+	return c.do(ctx, http.MethodPut, "metadata/"+url.PathEscape(key), strings.NewReader(value), nil)
+}
+
+// GetBootReport returns the summary of the startup sequence of the sidecar.
+func (c *Client) GetBootReport(ctx context.Context) (*BootReport, error) {
+	res := &BootReport{}
+	if err := c.do(ctx, http.MethodGet, "metadata/boot-report", nil, res); err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+
+// GetHealthDetails runs the health checks of the sidecar.
+// Failed checks are reported in the result, and an error is returned only if a check couldn't be performed.
+func (c *Client) GetHealthDetails(ctx context.Context) (*HealthDetails, error) {
+	res := &HealthDetails{}
+	checks := []struct {
+		name   string
+		path   string
+		result *bool
+	}{
+		{name: "healthz", path: "healthz", result: &res.Healthy},
+		{name: "outbound", path: "healthz/outbound", result: &res.OutboundHealthy},
+		{name: "workflows", path: "healthz/workflows", result: &res.WorkflowsHealthy},
+	}
+	for _, check := range checks {
+		err := c.do(ctx, http.MethodGet, check.path, nil, nil)
+		var apiErr *Error
+		switch {
+		case err == nil:
+			*check.result = true
+		case errors.As(err, &apiErr):
+			if res.Errors == nil {
+				res.Errors = make(map[string]string, len(checks))
+			}
+			res.Errors[check.name] = apiErr.Error()
+		default:
+			return nil, err
+		}
+	}
+	return res, nil
+}
+
+// do sends a request to the API of the sidecar, and decodes the response into out if it's not nil.
+func (c *Client) do(ctx context.Context, method string, path string, body io.Reader, out any) error {
+	u := c.baseURL.JoinPath(apiVersion, path)
+	req, err := http.NewRequestWithContext(ctx, method, u.String(), body)
+	if err != nil {
+		return fmt.Errorf("failed to create the request: %w", err)
+	}
+	if c.apiToken != "" {
+		req.Header.Set(apiTokenHeader, c.apiToken)
+	}
+	if body != nil {
+		req.Header.Set("Content-Type", "text/plain")
+	}
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to send the request: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < http.StatusOK || resp.StatusCode >= http.StatusMultipleChoices {
+		return responseError(resp)
+	}
+
+	if out == nil {
+		_, _ = io.Copy(io.Discard, resp.Body)
+		return nil
+	}
+	if err = json.NewDecoder(resp.Body).Decode(out); err != nil {
+		return fmt.Errorf("failed to decode the response: %w", err)
+	}
+	return nil
+}
+
+// responseError returns the error of a response with an error status code.
+func responseError(resp *http.Response) error {
+	apiErr := &Error{StatusCode: resp.StatusCode}
+
+	data, _ := io.ReadAll(io.LimitReader(resp.Body, 64<<10))
+	var errBody struct {
+		ErrorCode string `json:"errorCode"`
+		Message   string `json:"message"`
+	}
+	if json.Unmarshal(data, &errBody) == nil && (errBody.ErrorCode != "" || errBody.Message != "") {
+		apiErr.ErrorCode = errBody.ErrorCode
+		apiErr.Message = errBody.Message
+	} else {
+		apiErr.Message = strings.TrimSpace(string(data))
+	}
+	if apiErr.Message == "" {
+		apiErr.Message = http.StatusText(resp.StatusCode)
+	}
+	return apiErr
+}
@@ -0,0 +1,132 @@
+/*
+Copyright 2023 The Dapr Authors
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package admin
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestClient(t *testing.T, handler http.HandlerFunc) *Client {
+	t.Helper()
+
+	srv := httptest.NewServer(handler)
+	t.Cleanup(srv.Close)
+
+	c, err := New(srv.URL, WithAPIToken("token"))
+	require.NoError(t, err)
+	return c
+}
+
+func TestNew(t *testing.T) {
+	c, err := New("localhost:3500")
+	require.NoError(t, err)
+	assert.Equal(t, "http://localhost:3500", c.baseURL.String())
+
+	_, err = New("http://")
+	require.Error(t, err)
+}
+
+func TestGetMetadata(t *testing.T) {
+	c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, http.MethodGet, r.Method)
+		assert.Equal(t, "/v1.0/metadata", r.URL.Path)
+		assert.Equal(t, "token", r.Header.Get("dapr-api-token"))
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"id":"myapp","runtimeVersion":"edge","components":[{"name":"statestore","type":"state.redis","version":"v1","capabilities":["ETAG"]}],"extended":{"cliPID":"1"},"subscriptions":[{"pubsubname":"pubsub","topic":"orders","rules":[{"path":"/orders"}],"deadLetterTopic":""}],"actorRuntime":{"runtimeStatus":"RUNNING","activeActors":[{"type":"cart","count":2}],"hostReady":true}}`))
+	})
+
+	md, err := c.GetMetadata(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, "myapp", md.ID)
+	require.Len(t, md.Components, 1)
+	assert.Equal(t, "state.redis", md.Components[0].Type)
+	assert.Equal(t, []string{"ETAG"}, md.Components[0].Capabilities)
+	assert.Equal(t, "1", md.Extended["cliPID"])
+	require.Len(t, md.Subscriptions, 1)
+	assert.Equal(t, "/orders", md.Subscriptions[0].Rules[0].Path)
+	assert.Equal(t, "RUNNING", md.ActorRuntime.Status)
+	assert.Equal(t, []ActorCount{{Type: "cart", Count: 2}}, md.ActorRuntime.ActiveActors)
+}
+
+func TestSetMetadata(t *testing.T) {
+	c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, http.MethodPut, r.Method)
+		assert.Equal(t, "/v1.0/metadata/owner", r.URL.Path)
+		body, _ := io.ReadAll(r.Body)
+		assert.Equal(t, "team-a", string(body))
+		w.WriteHeader(http.StatusNoContent)
+	})
+
+	require.NoError(t, c.SetMetadata(context.Background(), "owner", "team-a"))
+	require.Error(t, c.SetMetadata(context.Background(), "", "team-a"))
+}
+
+func TestGetBootReport(t *testing.T) {
+	t.Run("report", func(t *testing.T) {
+		c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+			assert.Equal(t, "/v1.0/metadata/boot-report", r.URL.Path)
+			w.Write([]byte(`{"appID":"myapp","ready":true,"startTime":"2023-10-01T12:00:00Z","components":[{"name":"statestore","type":"state.redis","version":"v1","initDurationMs":12,"warmup":{"status":"passed","attempts":1}}],"ports":{"http":3500}}`))
+		})
+
+		report, err := c.GetBootReport(context.Background())
+		require.NoError(t, err)
+		assert.True(t, report.Ready)
+		assert.Equal(t, 3500, report.Ports.HTTP)
+		require.Len(t, report.Components, 1)
+		assert.Equal(t, "passed", report.Components[0].Warmup.Status)
+	})
+
+	t.Run("error", func(t *testing.T) {
+		c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusInternalServerError)
+			w.Write([]byte(`{"errorCode":"ERR_BOOT_REPORT_NOT_AVAILABLE","message":"boot report not available"}`))
+		})
+
+		_, err := c.GetBootReport(context.Background())
+		var apiErr *Error
+		require.ErrorAs(t, err, &apiErr)
+		assert.Equal(t, http.StatusInternalServerError, apiErr.StatusCode)
+		assert.Equal(t, "ERR_BOOT_REPORT_NOT_AVAILABLE", apiErr.ErrorCode)
+		assert.Equal(t, "boot report not available", apiErr.Message)
+	})
+}
+
+func TestGetHealthDetails(t *testing.T) {
+	c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/v1.0/healthz", "/v1.0/healthz/outbound":
+			w.WriteHeader(http.StatusNoContent)
+		case "/v1.0/healthz/workflows":
+			w.WriteHeader(http.StatusInternalServerError)
+			w.Write([]byte(`{"errorCode":"ERR_WORKFLOW_HEALTH_NOT_READY","message":"workflows not ready"}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	})
+
+	health, err := c.GetHealthDetails(context.Background())
+	require.NoError(t, err)
+	assert.True(t, health.Healthy)
+	assert.True(t, health.OutboundHealthy)
+	assert.False(t, health.WorkflowsHealthy)
+	assert.Contains(t, health.Errors["workflows"], "ERR_WORKFLOW_HEALTH_NOT_READY")
+	assert.NotContains(t, health.Errors, "healthz")
+}
@@ -0,0 +1,155 @@
+/*
+Copyright 2023 The Dapr Authors
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package admin
+
+import "time"
+
+// Metadata is the response of the metadata API of the sidecar.
+type Metadata struct {
+	ID                      string                  `json:"id,omitempty"`
+	RuntimeVersion          string                  `json:"runtimeVersion,omitempty"`
+	EnabledFeatures         []string                `json:"enabledFeatures,omitempty"`
+	Components              []Component             `json:"components,omitempty"`
+	Extended                map[string]string       `json:"extended,omitempty"`
+	Subscriptions           []Subscription          `json:"subscriptions,omitempty"`
+	HTTPEndpoints           []HTTPEndpoint          `json:"httpEndpoints,omitempty"`
+	AppConnectionProperties AppConnectionProperties `json:"appConnectionProperties,omitempty"`
+	ActorRuntime            ActorRuntime            `json:"actorRuntime,omitempty"`
+}
+
+// Component is a component loaded by the sidecar.
+type Component struct {
+	Name            string   `json:"name,omitempty"`
+	Type            string   `json:"type,omitempty"`
+	Version         string   `json:"version,omitempty"`
+	Capabilities    []string `json:"capabilities,omitempty"`
+	OpenConnections int64    `json:"openConnections,omitempty"`
+	// LastSuccessTime is the time of the last successful operation of the component, as a RFC 3339 string.
+	LastSuccessTime string `json:"lastSuccessTime,omitempty"`
+	LastError       string `json:"lastError,omitempty"`
+}
+
+// Subscription is a subscription of the app to a topic.
+type Subscription struct {
+	PubsubName      string             `json:"pubsubname"`
+	Topic           string             `json:"topic"`
+	Metadata        map[string]string  `json:"metadata,omitempty"`
+	Rules           []SubscriptionRule `json:"rules,omitempty"`
+	DeadLetterTopic string             `json:"deadLetterTopic"`
+}
+
+// SubscriptionRule routes the messages matching an expression to a path of the app.
+type SubscriptionRule struct {
+	Match string `json:"match,omitempty"`
+	Path  string `json:"path,omitempty"`
+}
+
+// HTTPEndpoint is an HTTP endpoint resource loaded by the sidecar.
+type HTTPEndpoint struct {
+	Name string `json:"name,omitempty"`
+}
+
+// AppConnectionProperties describes how the sidecar connects to the app.
+type AppConnectionProperties struct {
+	Port           int32                          `json:"port,omitempty"`
+	Protocol       string                         `json:"protocol,omitempty"`
+	ChannelAddress string                         `json:"channelAddress,omitempty"`
+	MaxConcurrency int32                          `json:"maxConcurrency,omitempty"`
+	Health         *AppConnectionHealthProperties `json:"health,omitempty"`
+}
+
+// AppConnectionHealthProperties describes the health checks of the app.
+type AppConnectionHealthProperties struct {
+	HealthCheckPath     string `json:"healthCheckPath,omitempty"`
+	HealthProbeInterval string `json:"healthProbeInterval,omitempty"`
+	HealthProbeTimeout  string `json:"healthProbeTimeout,omitempty"`
+	HealthThreshold     int32  `json:"healthThreshold,omitempty"`
+}
+
+// ActorRuntime is the status of the actor runtime of the sidecar.
+type ActorRuntime struct {
+	// Status is the status of the actor runtime, such as "RUNNING" or "DISABLED".
+	Status       string       `json:"runtimeStatus"`
+	ActiveActors []ActorCount `json:"activeActors,omitempty"`
+	HostReady    bool         `json:"hostReady"`
+	Placement    string       `json:"placement,omitempty"`
+}
+
+// ActorCount is the number of active actors of a type.
+type ActorCount struct {
+	Type  string `json:"type"`
+	Count int32  `json:"count"`
+}
+
+// BootReport is the summary of the startup sequence of the sidecar.
+type BootReport struct {
+	AppID          string `json:"appID"`
+	RuntimeVersion string `json:"runtimeVersion"`
+	GitCommit      string `json:"gitCommit,omitempty"`
+	GoVersion      string `json:"goVersion"`
+	Mode           string `json:"mode"`
+	// Ready is true once the sidecar has completed its startup sequence.
+	Ready     bool      `json:"ready"`
+	StartTime time.Time `json:"startTime"`
+	// InitDurationMs is the time, in milliseconds, the sidecar took to complete its startup sequence; it's 0 until Ready is true.
+	InitDurationMs  int64                 `json:"initDurationMs,omitempty"`
+	Components      []BootReportComponent `json:"components"`
+	EnabledFeatures []string              `json:"enabledFeatures"`
+	Ports           BootReportPorts       `json:"ports"`
+}
+
+// BootReportComponent is a component initialized during the startup sequence of the sidecar.
+type BootReportComponent struct {
+	Name    string `json:"name"`
+	Type    string `json:"type"`
+	Version string `json:"version"`
+	// InitDurationMs is the time, in milliseconds, the component took to initialize.
+	InitDurationMs int64 `json:"initDurationMs"`
+	// Warmup is the result of the connectivity check of the component, if checks are enabled.
+	Warmup *BootReportWarmup `json:"warmup,omitempty"`
+}
+
+// BootReportWarmup is the result of the connectivity check of a component.
+type BootReportWarmup struct {
+	// Status is one of "passed", "failed", "skipped" or "unsupported".
+	Status string `json:"status"`
+	// Error is the error of the last failed attempt.
+	Error string `json:"error,omitempty"`
+	// Attempts is the number of times the component was checked.
+	Attempts int `json:"attempts,omitempty"`
+}
+
+// BootReportPorts contains the ports the sidecar listens on, and the port of the app.
+// Ports of servers that are disabled are 0.
+type BootReportPorts struct {
+	HTTP         int `json:"http,omitempty"`
+	Public       int `json:"public,omitempty"`
+	GRPC         int `json:"grpc,omitempty"`
+	InternalGRPC int `json:"internalGRPC,omitempty"`
+	Metrics      int `json:"metrics,omitempty"`
+	Profile      int `json:"profile,omitempty"`
+	App          int `json:"app,omitempty"`
+}
+
+// HealthDetails is the result of the health checks of the sidecar.
+type HealthDetails struct {
+	// Healthy is true when the sidecar has completed its initialization and the app is healthy.
+	Healthy bool `json:"healthy"`
+	// OutboundHealthy is true when the sidecar can be used by the app, even if the initialization isn't complete.
+	OutboundHealthy bool `json:"outboundHealthy"`
+	// WorkflowsHealthy is true when the workflow engine of the sidecar is ready.
+	WorkflowsHealthy bool `json:"workflowsHealthy"`
+	// Errors contains the errors returned by the failed health checks, keyed by check: "healthz", "outbound" or "workflows".
+	Errors map[string]string `json:"errors,omitempty"`
+}
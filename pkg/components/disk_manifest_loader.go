@@ -54,26 +54,42 @@ func (m *DiskManifestLoader[T]) SetZeroValueFn(zvFn func() T) {
 
 // load loads manifests for the given directory.
 func (m DiskManifestLoader[T]) Load() ([]T, error) {
+	manifests, errs, err := m.LoadWithErrors()
+	if err != nil {
+		return nil, err
+	}
+	for _, err := range errs {
+		log.Warnf("daprd load %s error: %v", m.kind, err)
+	}
+	return manifests, nil
+}
+
+// LoadWithErrors loads manifests for the given directory.
+// Errors encountered while reading or parsing individual files are returned in the second value, and those files are skipped.
+func (m DiskManifestLoader[T]) LoadWithErrors() ([]T, []error, error) {
 	manifests := []T{}
+	errs := []error{}
 	for _, path := range m.paths {
-		loaded, err := m.loadManifestsFromPath(path)
+		loaded, loadErrs, err := m.loadManifestsFromPath(path)
 		if err != nil {
-			return nil, err
+			return nil, nil, err
 		}
 		if len(loaded) > 0 {
 			manifests = append(manifests, loaded...)
 		}
+		errs = append(errs, loadErrs...)
 	}
-	return manifests, nil
+	return manifests, errs, nil
 }
 
-func (m DiskManifestLoader[T]) loadManifestsFromPath(path string) ([]T, error) {
+func (m DiskManifestLoader[T]) loadManifestsFromPath(path string) ([]T, []error, error) {
 	files, err := os.ReadDir(path)
 	if err != nil {
-		return nil, err
+		return nil, nil, err
 	}
 
 	manifests := make([]T, 0)
+	errs := []error{}
 
 	for _, file := range files {
 		if !file.IsDir() {
@@ -82,28 +98,26 @@ func (m DiskManifestLoader[T]) loadManifestsFromPath(path string) ([]T, error) {
 				log.Warnf("A non-YAML %s file %s was detected, it will not be loaded", m.kind, fileName)
 				continue
 			}
-			fileManifests := m.loadManifestsFromFile(filepath.Join(path, fileName))
+			fileManifests, fileErrs := m.loadManifestsFromFile(filepath.Join(path, fileName))
 			manifests = append(manifests, fileManifests...)
+			errs = append(errs, fileErrs...)
 		}
 	}
 
-	return manifests, nil
+	return manifests, errs, nil
 }
 
-func (m DiskManifestLoader[T]) loadManifestsFromFile(manifestPath string) []T {
-	var errors []error
-
-	manifests := make([]T, 0)
+func (m DiskManifestLoader[T]) loadManifestsFromFile(manifestPath string) ([]T, []error) {
 	b, err := os.ReadFile(manifestPath)
 	if err != nil {
-		log.Warnf("daprd load %s error when reading file %s: %v", m.kind, manifestPath, err)
-		return manifests
+		return []T{}, []error{fmt.Errorf("error when reading file %s: %w", manifestPath, err)}
 	}
-	manifests, errors = m.decodeYaml(b)
-	for _, err := range errors {
-		log.Warnf("daprd load %s error when parsing manifests yaml resource in %s: %v", m.kind, manifestPath, err)
+
+	manifests, errs := m.decodeYaml(b)
+	for i, err := range errs {
+		errs[i] = fmt.Errorf("error when parsing manifests yaml resource in %s: %w", manifestPath, err)
 	}
-	return manifests
+	return manifests, errs
 }
 
 type typeInfo struct {
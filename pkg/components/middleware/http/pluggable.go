@@ -0,0 +1,259 @@
+/*
+Copyright 2023 The Dapr Authors
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package http
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	nethttp "net/http"
+	"net/url"
+
+	"github.com/dapr/components-contrib/middleware"
+	"github.com/dapr/dapr/pkg/components/pluggable"
+	httpMiddleware "github.com/dapr/dapr/pkg/middleware/http"
+	proto "github.com/dapr/dapr/pkg/proto/components/v1"
+	"github.com/dapr/kit/logger"
+)
+
+// bodyChunkSize is the maximum size of a single body chunk sent to the middleware.
+const bodyChunkSize = 32 * 1024
+
+// grpcMiddleware is a implementation of an HTTP middleware over a gRPC Protocol.
+type grpcMiddleware struct {
+	*pluggable.GRPCConnector[proto.HTTPMiddlewareClient]
+	logger logger.Logger
+}
+
+// Init initializes the grpc middleware passing out the metadata to the grpc component.
+func (gm *grpcMiddleware) Init(metadata middleware.Metadata) error {
+	if err := gm.Dial(metadata.Name); err != nil {
+		return err
+	}
+
+	_, err := gm.Client.Init(gm.Context, &proto.HTTPMiddlewareInitRequest{
+		Metadata: &proto.MetadataRequest{
+			Properties: metadata.Properties,
+		},
+	})
+	return err
+}
+
+// Handler wraps the next handler, passing each request through the grpc middleware first.
+func (gm *grpcMiddleware) Handler(next nethttp.Handler) nethttp.Handler {
+	return nethttp.HandlerFunc(func(w nethttp.ResponseWriter, r *nethttp.Request) {
+		if err := gm.handle(w, r, next); err != nil {
+			gm.logger.Errorf("error handling request in pluggable middleware: %v", err)
+			nethttp.Error(w, "failed to handle request in middleware", nethttp.StatusInternalServerError)
+		}
+	})
+}
+
+// handle streams the request to the grpc middleware and acts on its answer.
+// An error is only returned when nothing was written to the response yet.
+//
+//nolint:nosnakecase
+func (gm *grpcMiddleware) handle(w nethttp.ResponseWriter, r *nethttp.Request, next nethttp.Handler) error {
+	ctx, cancel := context.WithCancel(r.Context())
+	defer cancel()
+
+	stream, err := gm.Client.Handle(ctx)
+	if err != nil {
+		return fmt.Errorf("unable to open handle stream: %w", err)
+	}
+
+	err = stream.Send(&proto.HandleHTTPRequest{
+		Message: &proto.HandleHTTPRequest_Request{
+			Request: &proto.HTTPRequestHeaders{
+				Method:     r.Method,
+				Uri:        r.URL.RequestURI(),
+				Headers:    toProtoHeaders(r.Header),
+				RemoteAddr: r.RemoteAddr,
+				Host:       r.Host,
+			},
+		},
+	})
+	if err != nil {
+		return fmt.Errorf("unable to send request headers: %w", err)
+	}
+
+	// The request body is sent in background, so the middleware can start answering before it is fully received.
+	sendDone := make(chan struct{})
+	go func() {
+		defer close(sendDone)
+		if sendErr := sendBody(stream, r.Body); sendErr != nil {
+			gm.logger.Debugf("could not send request body to pluggable middleware: %v", sendErr)
+		}
+	}()
+	// The middleware may answer without reading the whole body: the stream is canceled so the sender stops, and it must return before the request body is closed.
+	defer func() {
+		cancel()
+		<-sendDone
+	}()
+
+	first, err := stream.Recv()
+	if err != nil {
+		return fmt.Errorf("unable to receive middleware answer: %w", err)
+	}
+
+	body := &bodyReader{stream: stream}
+	switch msg := first.GetMessage().(type) {
+	case *proto.HandleHTTPResponse_Next:
+		req, err := toHTTPRequest(r, msg.Next, body)
+		if err != nil {
+			return err
+		}
+		next.ServeHTTP(w, req)
+	case *proto.HandleHTTPResponse_Response:
+		for k, v := range msg.Response.GetHeaders() {
+			w.Header()[k] = v.GetValues()
+		}
+		w.WriteHeader(int(msg.Response.GetStatusCode()))
+		if _, err := io.Copy(w, body); err != nil {
+			gm.logger.Errorf("error writing pluggable middleware response body: %v", err)
+		}
+	default:
+		return errors.New("unexpected first message from middleware: expected next or response")
+	}
+
+	return nil
+}
+
+// sendBody sends the given body to the middleware in chunks and closes the sending side of the stream.
+//
+//nolint:nosnakecase
+func sendBody(stream proto.HTTPMiddleware_HandleClient, body io.Reader) error {
+	if body != nil {
+		buf := make([]byte, bodyChunkSize)
+		for {
+			n, err := body.Read(buf)
+			if n > 0 {
+				sendErr := stream.Send(&proto.HandleHTTPRequest{
+					Message: &proto.HandleHTTPRequest_Body{
+						Body: &proto.HTTPBodyChunk{
+							Data: append([]byte(nil), buf[:n]...),
+						},
+					},
+				})
+				if sendErr != nil {
+					return sendErr
+				}
+			}
+			if err == io.EOF {
+				break
+			}
+			if err != nil {
+				return err
+			}
+		}
+	}
+	return stream.CloseSend()
+}
+
+// bodyReader reads the body chunks sent by the middleware after its first answer.
+type bodyReader struct {
+	//nolint:nosnakecase
+	stream proto.HTTPMiddleware_HandleClient
+	buf    []byte
+}
+
+// Read implements io.Reader. It returns io.EOF once the middleware ends the stream.
+//
+//nolint:nosnakecase
+func (b *bodyReader) Read(p []byte) (int, error) {
+	for len(b.buf) == 0 {
+		msg, err := b.stream.Recv()
+		if err != nil {
+			return 0, err
+		}
+		chunk, ok := msg.GetMessage().(*proto.HandleHTTPResponse_Body)
+		if !ok {
+			return 0, errors.New("unexpected message from middleware: expected a body chunk")
+		}
+		b.buf = chunk.Body.GetData()
+	}
+	n := copy(p, b.buf)
+	b.buf = b.buf[n:]
+	return n, nil
+}
+
+// Close implements io.Closer. The stream is closed when the request is done.
+func (b *bodyReader) Close() error {
+	return nil
+}
+
+// toHTTPRequest returns a copy of the original request with the values forwarded by the middleware.
+func toHTTPRequest(original *nethttp.Request, headers *proto.HTTPRequestHeaders, body io.ReadCloser) (*nethttp.Request, error) {
+	u, err := url.ParseRequestURI(headers.GetUri())
+	if err != nil {
+		return nil, fmt.Errorf("invalid request uri from middleware: %w", err)
+	}
+
+	req := original.Clone(original.Context())
+	req.Method = headers.GetMethod()
+	req.URL = u
+	req.RequestURI = headers.GetUri()
+	req.Header = make(nethttp.Header, len(headers.GetHeaders()))
+	for k, v := range headers.GetHeaders() {
+		req.Header[k] = v.GetValues()
+	}
+	if host := headers.GetHost(); host != "" {
+		req.Host = host
+	}
+	if remoteAddr := headers.GetRemoteAddr(); remoteAddr != "" {
+		req.RemoteAddr = remoteAddr
+	}
+	// The body can be rewritten by the middleware, so its length is unknown.
+	req.Body = body
+	req.ContentLength = -1
+	req.Header.Del("Content-Length")
+	return req, nil
+}
+
+func toProtoHeaders(h nethttp.Header) map[string]*proto.HTTPHeaderValues {
+	headers := make(map[string]*proto.HTTPHeaderValues, len(h))
+	for k, v := range h {
+		headers[k] = &proto.HTTPHeaderValues{Values: v}
+	}
+	return headers
+}
+
+// fromConnector creates a new GRPC middleware using the given underlying connector.
+func fromConnector(l logger.Logger, connector *pluggable.GRPCConnector[proto.HTTPMiddlewareClient]) *grpcMiddleware {
+	return &grpcMiddleware{
+		GRPCConnector: connector,
+		logger:        l,
+	}
+}
+
+// newGRPCMiddleware creates a new grpc middleware factory for the given pluggable component.
+func newGRPCMiddleware(dialer pluggable.GRPCConnectionDialer) func(l logger.Logger) FactoryMethod {
+	return func(l logger.Logger) FactoryMethod {
+		return func(metadata middleware.Metadata) (httpMiddleware.Middleware, error) {
+			gm := fromConnector(l, pluggable.NewGRPCConnectorWithDialer(dialer, proto.NewHTTPMiddlewareClient))
+			if err := gm.Init(metadata); err != nil {
+				return nil, err
+			}
+			return gm.Handler, nil
+		}
+	}
+}
+
+func init() {
+	//nolint:nosnakecase
+	pluggable.AddServiceDiscoveryCallback(proto.HTTPMiddleware_ServiceDesc.ServiceName, func(name string, dialer pluggable.GRPCConnectionDialer) {
+		DefaultRegistry.RegisterComponent(newGRPCMiddleware(dialer), name)
+	})
+}
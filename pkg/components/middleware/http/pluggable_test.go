@@ -0,0 +1,217 @@
+/*
+Copyright 2023 The Dapr Authors
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package http
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	nethttp "net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+
+	"github.com/dapr/dapr/pkg/components/pluggable"
+	proto "github.com/dapr/dapr/pkg/proto/components/v1"
+	testingGrpc "github.com/dapr/dapr/pkg/testing/grpc"
+	"github.com/dapr/kit/logger"
+)
+
+var testLogger = logger.NewLogger("http-middleware-pluggable-logger")
+
+type server struct {
+	proto.UnimplementedHTTPMiddlewareServer
+	// onHandle receives the request headers and the full request body and returns the answer to send.
+	onHandle  func(*proto.HTTPRequestHeaders, []byte) (*proto.HandleHTTPResponse, []byte)
+	handleErr error
+	// answerEarly makes the middleware answer as soon as it receives the request headers, without reading the body.
+	answerEarly bool
+}
+
+//nolint:nosnakecase
+func (s *server) Handle(stream proto.HTTPMiddleware_HandleServer) error {
+	if s.handleErr != nil {
+		return s.handleErr
+	}
+
+	var headers *proto.HTTPRequestHeaders
+	var body []byte
+	for {
+		msg, err := stream.Recv()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return err
+		}
+		if req := msg.GetRequest(); req != nil {
+			headers = req
+			if s.answerEarly {
+				break
+			}
+		}
+		body = append(body, msg.GetBody().GetData()...)
+	}
+
+	answer, answerBody := s.onHandle(headers, body)
+	if err := stream.Send(answer); err != nil {
+		return err
+	}
+	return stream.Send(&proto.HandleHTTPResponse{
+		Message: &proto.HandleHTTPResponse_Body{
+			Body: &proto.HTTPBodyChunk{Data: answerBody},
+		},
+	})
+}
+
+func TestComponentCalls(t *testing.T) {
+	getMiddleware := testingGrpc.TestServerFor(testLogger, func(s *grpc.Server, svc *server) {
+		proto.RegisterHTTPMiddlewareServer(s, svc)
+	}, func(cci grpc.ClientConnInterface) *grpcMiddleware {
+		client := proto.NewHTTPMiddlewareClient(cci)
+		gm := fromConnector(testLogger, pluggable.NewGRPCConnector("/tmp/socket.sock", proto.NewHTTPMiddlewareClient))
+		gm.Client = client
+		return gm
+	})
+
+	t.Run("next should forward the modified request", func(t *testing.T) {
+		gm, cleanup, err := getMiddleware(&server{
+			onHandle: func(req *proto.HTTPRequestHeaders, body []byte) (*proto.HandleHTTPResponse, []byte) {
+				assert.Equal(t, nethttp.MethodPost, req.GetMethod())
+				assert.Equal(t, "/v1.0/invoke/app/method/foo?a=b", req.GetUri())
+				assert.Equal(t, []string{"bar"}, req.GetHeaders()["X-Foo"].GetValues())
+				req.Headers["X-Middleware"] = &proto.HTTPHeaderValues{Values: []string{"pluggable"}}
+				return &proto.HandleHTTPResponse{
+					Message: &proto.HandleHTTPResponse_Next{Next: req},
+				}, bytes.ToUpper(body)
+			},
+		})
+		require.NoError(t, err)
+		defer cleanup()
+
+		var nextCalled bool
+		next := nethttp.HandlerFunc(func(w nethttp.ResponseWriter, r *nethttp.Request) {
+			nextCalled = true
+			assert.Equal(t, "pluggable", r.Header.Get("X-Middleware"))
+			assert.Equal(t, "b", r.URL.Query().Get("a"))
+			b, err := io.ReadAll(r.Body)
+			require.NoError(t, err)
+			assert.Equal(t, "HELLO", string(b))
+			w.WriteHeader(nethttp.StatusAccepted)
+		})
+
+		r := httptest.NewRequest(nethttp.MethodPost, "/v1.0/invoke/app/method/foo?a=b", strings.NewReader("hello"))
+		r.Header.Set("X-Foo", "bar")
+		w := httptest.NewRecorder()
+		gm.Handler(next).ServeHTTP(w, r)
+
+		assert.True(t, nextCalled)
+		assert.Equal(t, nethttp.StatusAccepted, w.Code)
+	})
+
+	t.Run("response should short-circuit the pipeline", func(t *testing.T) {
+		gm, cleanup, err := getMiddleware(&server{
+			onHandle: func(req *proto.HTTPRequestHeaders, body []byte) (*proto.HandleHTTPResponse, []byte) {
+				return &proto.HandleHTTPResponse{
+					Message: &proto.HandleHTTPResponse_Response{
+						Response: &proto.HTTPResponseHeaders{
+							StatusCode: nethttp.StatusForbidden,
+							Headers: map[string]*proto.HTTPHeaderValues{
+								"Content-Type": {Values: []string{"text/plain"}},
+							},
+						},
+					},
+				}, []byte("denied")
+			},
+		})
+		require.NoError(t, err)
+		defer cleanup()
+
+		next := nethttp.HandlerFunc(func(w nethttp.ResponseWriter, r *nethttp.Request) {
+			assert.Fail(t, "next should not be called")
+		})
+
+		r := httptest.NewRequest(nethttp.MethodGet, "/v1.0/state/store/key", nil)
+		w := httptest.NewRecorder()
+		gm.Handler(next).ServeHTTP(w, r)
+
+		assert.Equal(t, nethttp.StatusForbidden, w.Code)
+		assert.Equal(t, "text/plain", w.Header().Get("Content-Type"))
+		assert.Equal(t, "denied", w.Body.String())
+	})
+
+	t.Run("body sender should stop when the middleware answers early", func(t *testing.T) {
+		gm, cleanup, err := getMiddleware(&server{
+			answerEarly: true,
+			onHandle: func(req *proto.HTTPRequestHeaders, body []byte) (*proto.HandleHTTPResponse, []byte) {
+				return &proto.HandleHTTPResponse{
+					Message: &proto.HandleHTTPResponse_Response{
+						Response: &proto.HTTPResponseHeaders{StatusCode: nethttp.StatusTooManyRequests},
+					},
+				}, nil
+			},
+		})
+		require.NoError(t, err)
+		defer cleanup()
+
+		next := nethttp.HandlerFunc(func(w nethttp.ResponseWriter, r *nethttp.Request) {
+			assert.Fail(t, "next should not be called")
+		})
+
+		body := &endlessReader{}
+		r := httptest.NewRequest(nethttp.MethodPost, "/v1.0/state/store", body)
+		w := httptest.NewRecorder()
+		gm.Handler(next).ServeHTTP(w, r)
+		assert.Equal(t, nethttp.StatusTooManyRequests, w.Code)
+
+		// The body is not read anymore once the handler returns
+		reads := body.reads.Load()
+		time.Sleep(50 * time.Millisecond)
+		assert.Equal(t, reads, body.reads.Load())
+	})
+
+	t.Run("handle errors should return an internal server error", func(t *testing.T) {
+		gm, cleanup, err := getMiddleware(&server{
+			handleErr: errors.New("fake-handle-err"),
+		})
+		require.NoError(t, err)
+		defer cleanup()
+
+		next := nethttp.HandlerFunc(func(w nethttp.ResponseWriter, r *nethttp.Request) {
+			assert.Fail(t, "next should not be called")
+		})
+
+		r := httptest.NewRequest(nethttp.MethodGet, "/v1.0/state/store/key", nil)
+		w := httptest.NewRecorder()
+		gm.Handler(next).ServeHTTP(w, r)
+
+		assert.Equal(t, nethttp.StatusInternalServerError, w.Code)
+	})
+}
+
+// endlessReader is a request body that never ends.
+type endlessReader struct {
+	reads atomic.Int64
+}
+
+func (r *endlessReader) Read(p []byte) (int, error) {
+	r.reads.Add(1)
+	return len(p), nil
+}
@@ -16,12 +16,15 @@ package pluggable
 import (
 	"context"
 	"fmt"
+	"sync"
+	"time"
 
 	"github.com/dapr/kit/logger"
 
 	proto "github.com/dapr/dapr/pkg/proto/components/v1"
 
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/connectivity"
 	"google.golang.org/grpc/credentials/insecure"
 	"google.golang.org/grpc/metadata"
 	"google.golang.org/grpc/status"
@@ -71,6 +74,10 @@ type GRPCConnector[TClient GRPCClient] struct {
 	dialer        GRPCConnectionDialer
 	conn          *grpc.ClientConn
 	clientFactory func(grpc.ClientConnInterface) TClient
+
+	statsLock       sync.Mutex
+	lastSuccessTime time.Time
+	lastError       error
 }
 
 // metadataInstanceID is used to differentiate between multiples instance of the same component.
@@ -113,9 +120,52 @@ func SocketDial(ctx context.Context, socket string, additionalOpts ...grpc.DialO
 	return grpcConn, nil
 }
 
+// statsUnaryInterceptor returns a grpc client unary interceptor that records the outcome of the calls to the component.
+func (g *GRPCConnector[TClient]) statsUnaryInterceptor() grpc.UnaryClientInterceptor {
+	return func(ctx context.Context, method string, req, reply interface{}, cc *grpc.ClientConn, invoker grpc.UnaryInvoker, opts ...grpc.CallOption) error {
+		err := invoker(ctx, method, req, reply, cc, opts...)
+		g.recordCall(err)
+		return err
+	}
+}
+
+// statsStreamInterceptor returns a grpc client stream interceptor that records the outcome of opening streams to the component.
+func (g *GRPCConnector[TClient]) statsStreamInterceptor() grpc.StreamClientInterceptor {
+	return func(ctx context.Context, desc *grpc.StreamDesc, cc *grpc.ClientConn, method string, streamer grpc.Streamer, opts ...grpc.CallOption) (grpc.ClientStream, error) {
+		stream, err := streamer(ctx, desc, cc, method, opts...)
+		g.recordCall(err)
+		return stream, err
+	}
+}
+
+func (g *GRPCConnector[TClient]) recordCall(err error) {
+	g.statsLock.Lock()
+	defer g.statsLock.Unlock()
+	if err != nil {
+		g.lastError = err
+	} else {
+		g.lastSuccessTime = time.Now()
+	}
+}
+
+// Stats returns the runtime statistics of the component: the connection to the component, and the outcome of the calls to it.
+// It implements the components.Stats interface, so the statistics of pluggable components are included in the response of the metadata API.
+func (g *GRPCConnector[TClient]) Stats() (openConnections int64, lastSuccessTime time.Time, lastError error) {
+	if g.conn != nil && g.conn.GetState() == connectivity.Ready {
+		openConnections = 1
+	}
+
+	g.statsLock.Lock()
+	defer g.statsLock.Unlock()
+	return openConnections, g.lastSuccessTime, g.lastError
+}
+
 // Dial opens a grpcConnection and creates a new client instance.
 func (g *GRPCConnector[TClient]) Dial(name string) error {
-	grpcConn, err := g.dialer(g.Context, name)
+	grpcConn, err := g.dialer(g.Context, name,
+		grpc.WithChainUnaryInterceptor(g.statsUnaryInterceptor()),
+		grpc.WithChainStreamInterceptor(g.statsStreamInterceptor()),
+	)
 	if err != nil {
 		return fmt.Errorf("unable to open GRPC connection using the dialer: %w", err)
 	}
@@ -0,0 +1,93 @@
+/*
+Copyright 2023 The Dapr Authors
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package state
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"github.com/dapr/components-contrib/state"
+)
+
+// FeatureChangeFeed is the feature of the state stores that support change feeds.
+const FeatureChangeFeed state.Feature = "CHANGE_FEED"
+
+// ChangeType is the type of a change of a key in a state store.
+type ChangeType string
+
+const (
+	// ChangeUpsert is the change of a key that was created or updated.
+	ChangeUpsert ChangeType = "upsert"
+	// ChangeDelete is the change of a key that was deleted.
+	ChangeDelete ChangeType = "delete"
+)
+
+// Change is a change of a key in a state store, as reported by its change feed.
+type Change struct {
+	// ID uniquely identifies the change, such as the resume token of a change stream or the LSN of a WAL record.
+	// If empty, an ID is generated.
+	ID   string
+	Key  string
+	Type ChangeType
+	// Value is the value of the key after the change, and it's empty for deletes.
+	Value       []byte
+	ETag        *string
+	ContentType *string
+	Metadata    map[string]string
+	// Time defaults to the time the change is received.
+	Time time.Time
+}
+
+// ChangeFeedRequest is the request to subscribe to the change feed of a state store.
+type ChangeFeedRequest struct {
+	// KeyPrefix is the prefix of the keys to receive the changes of, including the prefix added by Dapr.
+	KeyPrefix string
+	Metadata  map[string]string
+}
+
+// ChangeFeedHandler is invoked for each change of the keys a subscription is for.
+type ChangeFeedHandler func(ctx context.Context, change *Change) error
+
+// ChangeFeeder is implemented by state stores that support change feeds, such as the change streams of MongoDB and Cosmos DB or the logical decoding of PostgreSQL.
+// State stores implementing it must also list FeatureChangeFeed in their features, since pluggable state stores implement it whether or not the component supports change feeds.
+type ChangeFeeder interface {
+	// SubscribeChanges invokes the handler for each change of the keys with the prefix, in order.
+	// It blocks until the context is canceled, the handler returns an error, or the change feed fails.
+	SubscribeChanges(ctx context.Context, req ChangeFeedRequest, handler ChangeFeedHandler) error
+}
+
+// GetChangeFeeder returns the change feed of the state store, and false if the state store doesn't support change feeds.
+func GetChangeFeeder(store state.Store) (ChangeFeeder, bool) {
+	feeder, ok := store.(ChangeFeeder)
+	if !ok || !FeatureChangeFeed.IsPresent(store.Features()) {
+		return nil, false
+	}
+	return feeder, true
+}
+
+// IsChangeFeedEnabled returns true if the change feed of the state store with the given name can be subscribed to.
+// The change feed is enabled per component, with the "changeFeed" metadata property.
+func IsChangeFeedEnabled(storeName string) bool {
+	return getStateConfiguration(storeName).changeFeed
+}
+
+// GetOriginalChangeKey returns the key of a change without the prefix added by Dapr for the app, and false if the key doesn't belong to the app.
+func GetOriginalChangeKey(modifiedStateKey, storeName, appID string) (string, bool) {
+	prefix, err := GetModifiedStateKey("", storeName, appID)
+	if err != nil {
+		return "", false
+	}
+	return strings.CutPrefix(modifiedStateKey, prefix)
+}
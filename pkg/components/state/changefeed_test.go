@@ -0,0 +1,61 @@
+/*
+Copyright 2023 The Dapr Authors
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package state
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/dapr/components-contrib/state"
+)
+
+func TestIsChangeFeedEnabled(t *testing.T) {
+	require.NoError(t, SaveStateConfiguration("changefeed1", map[string]string{"changeFeed": "true", strategyKey: strategyNone}))
+	require.NoError(t, SaveStateConfiguration("changefeed2", map[string]string{"changeFeed": "false"}))
+
+	assert.True(t, IsChangeFeedEnabled("changefeed1"))
+	assert.False(t, IsChangeFeedEnabled("changefeed2"))
+	assert.False(t, IsChangeFeedEnabled("store1"))
+	assert.Equal(t, strategyNone, getStateConfiguration("changefeed1").keyPrefixStrategy)
+}
+
+func TestGetChangeFeeder(t *testing.T) {
+	store := &grpcStateStore{}
+	_, ok := GetChangeFeeder(store)
+	assert.False(t, ok)
+
+	store.features = []state.Feature{FeatureChangeFeed}
+	feeder, ok := GetChangeFeeder(store)
+	assert.True(t, ok)
+	assert.Equal(t, store, feeder)
+}
+
+func TestGetOriginalChangeKey(t *testing.T) {
+	key, ok := GetOriginalChangeKey("appid1||key1", "store2", "appid1")
+	assert.True(t, ok)
+	assert.Equal(t, "key1", key)
+
+	_, ok = GetOriginalChangeKey("appid2||key1", "store2", "appid1")
+	assert.False(t, ok)
+
+	key, ok = GetOriginalChangeKey("key1", "store1", "appid1")
+	assert.True(t, ok)
+	assert.Equal(t, "key1", key)
+
+	key, ok = GetOriginalChangeKey("store4||key1", "store4", "appid1")
+	assert.True(t, ok)
+	assert.Equal(t, "key1", key)
+}
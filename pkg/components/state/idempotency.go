@@ -0,0 +1,272 @@
+/*
+Copyright 2023 The Dapr Authors
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package state
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/hashicorp/golang-lru/v2/expirable"
+
+	contribMetadata "github.com/dapr/components-contrib/metadata"
+	"github.com/dapr/components-contrib/state"
+	"github.com/dapr/dapr/utils"
+	"github.com/dapr/kit/logger"
+)
+
+const (
+	// IdempotencyKeyHeader is the HTTP header, or gRPC metadata key, of state writes that contains the idempotency key set by the client.
+	IdempotencyKeyHeader = "dapr-idempotency-key"
+
+	// idempotencyStatePrefix is the prefix of the keys of the idempotency records in the state stores.
+	// State keys of the apps can't start with it, so the apps can't forge the records.
+	idempotencyStatePrefix = "dapr-idempotency"
+	// rateLimitStatePrefix is the prefix of the keys of the counters of the rate limiters in the state stores, see pkg/ratelimit.
+	// State keys of the apps can't start with it, so the apps can't reset the counters.
+	rateLimitStatePrefix = "dapr-ratelimit"
+	// maxIdempotencyKeys is the maximum number of idempotency keys remembered in memory for each state store.
+	maxIdempotencyKeys = 10_000
+	// idempotencyClaimTTL is the TTL of the record that claims an idempotency key while the request is written.
+	// It bounds the time for which the key can't be used if the sidecar stops before the write completes.
+	idempotencyClaimTTL = time.Minute
+)
+
+var (
+	// ErrIdempotencyKeyReused is returned when an idempotency key is used again for a different request.
+	ErrIdempotencyKeyReused = errors.New("idempotency key was already used for a different request")
+	// ErrIdempotencyKeyInUse is returned when a request with the same idempotency key is being written by another replica.
+	ErrIdempotencyKeyInUse = errors.New("idempotency key is used by a request that is in progress")
+)
+
+var idempotencyLogger = logger.NewLogger("dapr.state.idempotency")
+
+// idempotencyRecord is the record of an idempotency key in the state store.
+type idempotencyRecord struct {
+	// Fingerprint is the hash of the request.
+	Fingerprint string `json:"fingerprint"`
+	// Completed is false while the request is written, and true once it succeeded.
+	Completed bool `json:"completed,omitempty"`
+}
+
+// GetIdempotencyKeyTTL returns how long the idempotency keys of the writes to the state store with the given name are remembered for.
+// Idempotency keys are enabled per component, with the "idempotencyKeyTTL" metadata property, and the value is 0 if they're not enabled.
+func GetIdempotencyKeyTTL(storeName string) time.Duration {
+	return getStateConfiguration(storeName).idempotencyKeyTTL
+}
+
+// IdempotencyTracker suppresses the duplicate writes of clients that retry requests with the same idempotency key.
+// Idempotency keys are remembered in a bounded in-memory cache and, if the state store supports TTLs and ETags, in the state store itself, so they are shared across replicas.
+// A replica claims the key in the state store with a first-write before writing the request, so a request is written by a single replica.
+type IdempotencyTracker struct {
+	appID           string
+	getStateStoreFn func(string) (state.Store, bool)
+
+	cachesLock sync.Mutex
+	caches     map[string]*expirable.LRU[string, string]
+	locks      utils.KeyedMutex[string]
+}
+
+// NewIdempotencyTracker returns a new IdempotencyTracker.
+func NewIdempotencyTracker(appID string, getStateStoreFn func(string) (state.Store, bool)) *IdempotencyTracker {
+	return &IdempotencyTracker{
+		appID:           appID,
+		getStateStoreFn: getStateStoreFn,
+		caches:          map[string]*expirable.LRU[string, string]{},
+	}
+}
+
+// Write invokes writeFn, unless a request with the same idempotency key was already written to the state store.
+// request is the body of the request, and it's used to detect idempotency keys that are reused for different requests.
+// It returns true if the request was a duplicate and writeFn was not invoked: the original request succeeded, so the duplicate does too.
+// Keys are remembered only after a successful write, so clients can retry failed requests with the same key.
+func (t *IdempotencyTracker) Write(ctx context.Context, storeName string, idempotencyKey string, request []byte, writeFn func() error) (bool, error) {
+	if t == nil || idempotencyKey == "" {
+		return false, writeFn()
+	}
+	ttl := GetIdempotencyKeyTTL(storeName)
+	if ttl <= 0 {
+		return false, writeFn()
+	}
+
+	key := idempotencyStatePrefix + daprSeparator + t.appID + daprSeparator + idempotencyKey
+	sum := sha256.Sum256(request)
+	fingerprint := hex.EncodeToString(sum[:])
+
+	// Concurrent requests with the same key wait for each other, so only one of them is written
+	unlock := t.locks.Lock(storeName + daprSeparator + key)
+	defer unlock()
+
+	cache := t.getCache(storeName, ttl)
+	if previous, ok := cache.Get(key); ok {
+		return checkIdempotencyFingerprint(previous, fingerprint)
+	}
+
+	store, ok := t.getStore(storeName)
+	if !ok {
+		if err := writeFn(); err != nil {
+			return false, err
+		}
+		cache.Add(key, fingerprint)
+		return false, nil
+	}
+
+	claimed, err := t.claim(ctx, store, key, fingerprint)
+	if err != nil {
+		// If the state store can't be reached, the request is written: the client would retry it anyway
+		idempotencyLogger.Warnf("Failed to claim idempotency key in state store %s: %v", storeName, err)
+	} else if !claimed {
+		previous, completed, err := t.load(ctx, store, key)
+		switch {
+		case err != nil:
+			return false, err
+		case completed:
+			cache.Add(key, previous)
+			return checkIdempotencyFingerprint(previous, fingerprint)
+		case previous == "":
+			// The claim was released or expired after the first-write failed: the client can retry
+			return false, ErrIdempotencyKeyInUse
+		case previous != fingerprint:
+			return false, ErrIdempotencyKeyReused
+		default:
+			return false, ErrIdempotencyKeyInUse
+		}
+	}
+
+	err = writeFn()
+	if err != nil {
+		if claimed {
+			t.release(ctx, store, storeName, key)
+		}
+		return false, err
+	}
+
+	cache.Add(key, fingerprint)
+	t.save(ctx, store, storeName, key, idempotencyRecord{Fingerprint: fingerprint, Completed: true}, ttl)
+	return false, nil
+}
+
+// checkIdempotencyFingerprint returns true if the request with the fingerprint is a duplicate of the request written with the idempotency key, or an error if it's a different request.
+func checkIdempotencyFingerprint(previous string, fingerprint string) (bool, error) {
+	if previous != fingerprint {
+		return false, ErrIdempotencyKeyReused
+	}
+	return true, nil
+}
+
+func (t *IdempotencyTracker) getCache(storeName string, ttl time.Duration) *expirable.LRU[string, string] {
+	t.cachesLock.Lock()
+	defer t.cachesLock.Unlock()
+
+	cache, ok := t.caches[storeName]
+	if !ok {
+		cache = expirable.NewLRU[string, string](maxIdempotencyKeys, nil, ttl)
+		t.caches[storeName] = cache
+	}
+	return cache
+}
+
+// claim saves the record of the key, if it doesn't exist yet.
+// It returns false if the key was already claimed by another request.
+func (t *IdempotencyTracker) claim(ctx context.Context, store state.Store, key string, fingerprint string) (bool, error) {
+	err := store.Set(ctx, &state.SetRequest{
+		Key:   key,
+		Value: idempotencyRecord{Fingerprint: fingerprint},
+		Options: state.SetStateOption{
+			Concurrency: state.FirstWrite,
+		},
+		Metadata: map[string]string{
+			contribMetadata.TTLMetadataKey: strconv.Itoa(int(idempotencyClaimTTL.Seconds())),
+		},
+	})
+	var etagErr *state.ETagError
+	if errors.As(err, &etagErr) {
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+// load returns the fingerprint of the request with the key, and whether the request was written.
+// The fingerprint is empty if the key isn't claimed.
+func (t *IdempotencyTracker) load(ctx context.Context, store state.Store, key string) (string, bool, error) {
+	res, err := store.Get(ctx, &state.GetRequest{Key: key})
+	if err != nil {
+		return "", false, fmt.Errorf("failed to look up idempotency key: %w", err)
+	}
+	if res == nil || len(res.Data) == 0 {
+		return "", false, nil
+	}
+	var record idempotencyRecord
+	if err = json.Unmarshal(res.Data, &record); err != nil {
+		return "", false, fmt.Errorf("failed to unmarshal idempotency record: %w", err)
+	}
+	return record.Fingerprint, record.Completed, nil
+}
+
+func (t *IdempotencyTracker) save(ctx context.Context, store state.Store, storeName string, key string, record idempotencyRecord, ttl time.Duration) {
+	err := store.Set(ctx, &state.SetRequest{
+		Key:   key,
+		Value: record,
+		Metadata: map[string]string{
+			contribMetadata.TTLMetadataKey: strconv.Itoa(max(int(ttl.Seconds()), 1)),
+		},
+	})
+	if err != nil {
+		idempotencyLogger.Warnf("Failed to save idempotency key in state store %s: %v", storeName, err)
+	}
+}
+
+// release deletes the claim of the key after the request failed, so that the client can retry it.
+func (t *IdempotencyTracker) release(ctx context.Context, store state.Store, storeName string, key string) {
+	err := store.Delete(ctx, &state.DeleteRequest{Key: key})
+	if err != nil {
+		idempotencyLogger.Warnf("Failed to release idempotency key in state store %s, it can be used again after %v: %v", storeName, idempotencyClaimTTL, err)
+	}
+}
+
+// getStore returns the state store, if it supports TTLs and ETags: idempotency keys are saved only in memory otherwise, since they would never expire or couldn't be claimed.
+func (t *IdempotencyTracker) getStore(storeName string) (state.Store, bool) {
+	if t.getStateStoreFn == nil {
+		return nil, false
+	}
+	store, ok := t.getStateStoreFn(storeName)
+	if !ok {
+		return nil, false
+	}
+	features := store.Features()
+	if !state.FeatureTTL.IsPresent(features) || !state.FeatureETag.IsPresent(features) {
+		return nil, false
+	}
+	return store, true
+}
+
+// checkKeyReserved returns an error if the key of the state of an app is in the namespace of the keys reserved by Dapr.
+func checkKeyReserved(modifiedKey string) error {
+	for _, prefix := range []string{idempotencyStatePrefix, rateLimitStatePrefix} {
+		if strings.HasPrefix(modifiedKey, prefix+daprSeparator) {
+			return fmt.Errorf("input key '%s' is reserved by Dapr", modifiedKey)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,207 @@
+/*
+Copyright 2023 The Dapr Authors
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package state
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	contribMetadata "github.com/dapr/components-contrib/metadata"
+	"github.com/dapr/components-contrib/state"
+)
+
+// ttlStateStore is an in-memory state store that supports TTLs.
+type ttlStateStore struct {
+	state.Store
+
+	lock  sync.Mutex
+	items map[string][]byte
+	ttls  map[string]string
+}
+
+func newTTLStateStore() *ttlStateStore {
+	return &ttlStateStore{
+		items: map[string][]byte{},
+		ttls:  map[string]string{},
+	}
+}
+
+func (s *ttlStateStore) Features() []state.Feature {
+	return []state.Feature{state.FeatureTTL, state.FeatureETag}
+}
+
+func (s *ttlStateStore) Get(ctx context.Context, req *state.GetRequest) (*state.GetResponse, error) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	return &state.GetResponse{Data: s.items[req.Key]}, nil
+}
+
+func (s *ttlStateStore) Set(ctx context.Context, req *state.SetRequest) error {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	if _, ok := s.items[req.Key]; ok && req.Options.Concurrency == state.FirstWrite {
+		return state.NewETagError(state.ETagMismatch, nil)
+	}
+	data, err := json.Marshal(req.Value)
+	if err != nil {
+		return err
+	}
+	s.items[req.Key] = data
+	s.ttls[req.Key] = req.Metadata[contribMetadata.TTLMetadataKey]
+	return nil
+}
+
+func (s *ttlStateStore) Delete(ctx context.Context, req *state.DeleteRequest) error {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	delete(s.items, req.Key)
+	delete(s.ttls, req.Key)
+	return nil
+}
+
+func TestSaveStateConfigurationIdempotencyKeyTTL(t *testing.T) {
+	require.NoError(t, SaveStateConfiguration("idempotency1", map[string]string{"idempotencyKeyTTL": "5m"}))
+	assert.Equal(t, 5*time.Minute, GetIdempotencyKeyTTL("idempotency1"))
+	assert.Equal(t, time.Duration(0), GetIdempotencyKeyTTL("store1"))
+
+	require.Error(t, SaveStateConfiguration("idempotency2", map[string]string{"idempotencyKeyTTL": "foo"}))
+	require.Error(t, SaveStateConfiguration("idempotency2", map[string]string{"idempotencyKeyTTL": "-1m"}))
+}
+
+func TestIdempotencyTracker(t *testing.T) {
+	require.NoError(t, SaveStateConfiguration("idempotent", map[string]string{"idempotencyKeyTTL": "10m"}))
+	store := newTTLStateStore()
+	getStateStoreFn := func(name string) (state.Store, bool) {
+		return store, name == "idempotent"
+	}
+	tracker := NewIdempotencyTracker("myapp", getStateStoreFn)
+
+	var writes int
+	writeFn := func() error {
+		writes++
+		return nil
+	}
+
+	t.Run("duplicate requests are written once", func(t *testing.T) {
+		duplicate, err := tracker.Write(context.Background(), "idempotent", "key-1", []byte("request"), writeFn)
+		require.NoError(t, err)
+		assert.False(t, duplicate)
+		duplicate, err = tracker.Write(context.Background(), "idempotent", "key-1", []byte("request"), writeFn)
+		require.NoError(t, err)
+		assert.True(t, duplicate)
+		assert.Equal(t, 1, writes)
+
+		// The key is saved in the state store, and expires with the TTL
+		assert.Contains(t, store.items, "dapr-idempotency||myapp||key-1")
+		assert.Equal(t, "600", store.ttls["dapr-idempotency||myapp||key-1"])
+	})
+
+	t.Run("key reused for a different request", func(t *testing.T) {
+		_, err := tracker.Write(context.Background(), "idempotent", "key-1", []byte("other request"), writeFn)
+		require.ErrorIs(t, err, ErrIdempotencyKeyReused)
+		assert.Equal(t, 1, writes)
+	})
+
+	t.Run("key claimed by a request in progress on another replica", func(t *testing.T) {
+		other := NewIdempotencyTracker("myapp", getStateStoreFn)
+		_, err := other.Write(context.Background(), "idempotent", "key-3", []byte("request"), func() error {
+			// The key is claimed while the request is written
+			assert.Contains(t, string(store.items["dapr-idempotency||myapp||key-3"]), `"fingerprint"`)
+			assert.Equal(t, "60", store.ttls["dapr-idempotency||myapp||key-3"])
+
+			_, err := tracker.Write(context.Background(), "idempotent", "key-3", []byte("request"), writeFn)
+			require.ErrorIs(t, err, ErrIdempotencyKeyInUse)
+			_, err = tracker.Write(context.Background(), "idempotent", "key-3", []byte("other request"), writeFn)
+			require.ErrorIs(t, err, ErrIdempotencyKeyReused)
+			return nil
+		})
+		require.NoError(t, err)
+		assert.Equal(t, 1, writes)
+
+		duplicate, err := tracker.Write(context.Background(), "idempotent", "key-3", []byte("request"), writeFn)
+		require.NoError(t, err)
+		assert.True(t, duplicate)
+		assert.Equal(t, 1, writes)
+	})
+
+	t.Run("keys are shared by the replicas through the state store", func(t *testing.T) {
+		other := NewIdempotencyTracker("myapp", getStateStoreFn)
+		duplicate, err := other.Write(context.Background(), "idempotent", "key-1", []byte("request"), writeFn)
+		require.NoError(t, err)
+		assert.True(t, duplicate)
+		assert.Equal(t, 1, writes)
+	})
+
+	t.Run("failed writes can be retried", func(t *testing.T) {
+		_, err := tracker.Write(context.Background(), "idempotent", "key-2", []byte("request"), func() error {
+			return errors.New("simulated")
+		})
+		require.Error(t, err)
+		// The claim is released
+		assert.NotContains(t, store.items, "dapr-idempotency||myapp||key-2")
+		duplicate, err := tracker.Write(context.Background(), "idempotent", "key-2", []byte("request"), writeFn)
+		require.NoError(t, err)
+		assert.False(t, duplicate)
+		assert.Equal(t, 2, writes)
+	})
+
+	t.Run("requests without idempotency key are always written", func(t *testing.T) {
+		for i := 0; i < 2; i++ {
+			duplicate, err := tracker.Write(context.Background(), "idempotent", "", []byte("request"), writeFn)
+			require.NoError(t, err)
+			assert.False(t, duplicate)
+		}
+		assert.Equal(t, 4, writes)
+	})
+
+	t.Run("idempotency keys not enabled for the state store", func(t *testing.T) {
+		for i := 0; i < 2; i++ {
+			duplicate, err := tracker.Write(context.Background(), "store1", "key-1", []byte("request"), writeFn)
+			require.NoError(t, err)
+			assert.False(t, duplicate)
+		}
+		assert.Equal(t, 6, writes)
+	})
+
+	t.Run("nil tracker", func(t *testing.T) {
+		var nilTracker *IdempotencyTracker
+		duplicate, err := nilTracker.Write(context.Background(), "idempotent", "key-1", []byte("request"), writeFn)
+		require.NoError(t, err)
+		assert.False(t, duplicate)
+		assert.Equal(t, 7, writes)
+	})
+}
+
+func TestReservedStateKeys(t *testing.T) {
+	require.NoError(t, SaveStateConfiguration("reserved1", map[string]string{"keyPrefix": "none"}))
+	require.NoError(t, SaveStateConfiguration("reserved2", map[string]string{"keyPrefix": "dapr-idempotency"}))
+
+	_, err := GetModifiedStateKey("dapr-idempotency", "reserved1", "myapp")
+	require.NoError(t, err)
+	_, err = GetModifiedStateKey("key", "reserved2", "myapp")
+	require.ErrorContains(t, err, "reserved")
+	_, err = GetModifiedStateKey("dapr-idempotency", "store1", "myapp")
+	require.NoError(t, err)
+
+	require.NoError(t, SaveStateConfiguration("reserved3", map[string]string{"keyPrefix": "dapr-ratelimit"}))
+	_, err = GetModifiedStateKey("key", "reserved3", "myapp")
+	require.ErrorContains(t, err, "reserved")
+}
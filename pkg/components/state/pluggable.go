@@ -18,6 +18,7 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
+	"io"
 	"strconv"
 
 	"github.com/dapr/components-contrib/state"
@@ -321,6 +322,31 @@ func (ss *grpcStateStore) Multi(ctx context.Context, request *state.Transactiona
 	return err
 }
 
+// SubscribeChanges streams the changes of the keys with the prefix from the component, and invokes the handler for each of them.
+// It's supported only by the components that list the change feed feature.
+func (ss *grpcStateStore) SubscribeChanges(ctx context.Context, req ChangeFeedRequest, handler ChangeFeedHandler) error {
+	stream, err := ss.Client.SubscribeChanges(ctx, &proto.SubscribeChangesRequest{
+		KeyPrefix: req.KeyPrefix,
+		Metadata:  req.Metadata,
+	})
+	if err != nil {
+		return err
+	}
+
+	for {
+		change, err := stream.Recv()
+		if err != nil {
+			if errors.Is(err, io.EOF) || ctx.Err() != nil {
+				return nil
+			}
+			return err
+		}
+		if err = handler(ctx, fromStateChange(change)); err != nil {
+			return err
+		}
+	}
+}
+
 // mappers and helpers.
 //
 //nolint:nosnakecase
@@ -526,6 +552,26 @@ type stateStoreClient struct {
 	proto.StateStoreClient
 	proto.TransactionalStateStoreClient
 	proto.QueriableStateStoreClient
+	proto.StateStoreChangeFeedClient
+}
+
+func fromStateChange(change *proto.StateChange) *Change {
+	c := &Change{
+		ID:          change.GetId(),
+		Key:         change.GetKey(),
+		Type:        ChangeUpsert,
+		Value:       change.GetValue(),
+		ETag:        fromETagResponse(change.GetEtag()),
+		ContentType: strNilIfEmpty(change.GetContentType()),
+		Metadata:    change.GetMetadata(),
+	}
+	if change.GetType() == proto.StateChange_DELETE { //nolint:nosnakecase
+		c.Type = ChangeDelete
+	}
+	if change.GetTime() != nil {
+		c.Time = change.GetTime().AsTime()
+	}
+	return c
 }
 
 // strNilIfEmpty returns nil if string is empty
@@ -550,6 +596,7 @@ func newStateStoreClient(cc grpc.ClientConnInterface) stateStoreClient {
 		StateStoreClient:              proto.NewStateStoreClient(cc),
 		TransactionalStateStoreClient: proto.NewTransactionalStateStoreClient(cc),
 		QueriableStateStoreClient:     proto.NewQueriableStateStoreClient(cc),
+		StateStoreChangeFeedClient:    proto.NewStateStoreChangeFeedClient(cc),
 	}
 }
 
@@ -22,6 +22,7 @@ import (
 	"runtime"
 	"sync/atomic"
 	"testing"
+	"time"
 
 	guuid "github.com/google/uuid"
 	"github.com/stretchr/testify/assert"
@@ -29,10 +30,12 @@ import (
 	"google.golang.org/genproto/googleapis/rpc/errdetails"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
 
 	contribMetadata "github.com/dapr/components-contrib/metadata"
 	"github.com/dapr/components-contrib/state"
 	"github.com/dapr/components-contrib/state/query"
+	"github.com/dapr/dapr/pkg/components"
 	"github.com/dapr/dapr/pkg/components/pluggable"
 	proto "github.com/dapr/dapr/pkg/proto/components/v1"
 	testingGrpc "github.com/dapr/dapr/pkg/testing/grpc"
@@ -73,6 +76,20 @@ type server struct {
 	onQueryCalled      func(*proto.QueryRequest)
 	queryResp          *proto.QueryResponse
 	queryErr           error
+	onSubscribeChanges func(*proto.SubscribeChangesRequest)
+	changes            []*proto.StateChange
+}
+
+func (s *server) SubscribeChanges(req *proto.SubscribeChangesRequest, stream proto.StateStoreChangeFeed_SubscribeChangesServer) error { //nolint:nosnakecase
+	if s.onSubscribeChanges != nil {
+		s.onSubscribeChanges(req)
+	}
+	for _, c := range s.changes {
+		if err := stream.Send(c); err != nil {
+			return err
+		}
+	}
+	return nil
 }
 
 func (s *server) Query(_ context.Context, req *proto.QueryRequest) (*proto.QueryResponse, error) {
@@ -167,6 +184,7 @@ func TestComponentCalls(t *testing.T) {
 			proto.RegisterStateStoreServer(s, svc)
 			proto.RegisterTransactionalStateStoreServer(s, svc)
 			proto.RegisterQueriableStateStoreServer(s, svc)
+			proto.RegisterStateStoreChangeFeedServer(s, svc)
 		})
 		dialer, cleanup, err := withSvc(srv)
 		require.NoError(t, err)
@@ -230,6 +248,37 @@ func TestComponentCalls(t *testing.T) {
 		assert.Equal(t, state.FeatureETag, stStore.Features()[0])
 	})
 
+	t.Run("stats should report the connection and the outcome of the calls", func(t *testing.T) {
+		const fakeKey = "fakeKey"
+		fakeErr := errors.New("my-fake-err")
+
+		svc := &server{}
+		stStore, cleanup, err := getStateStore(svc)
+		require.NoError(t, err)
+		defer cleanup()
+
+		before := time.Now()
+		require.NoError(t, stStore.Delete(context.Background(), &state.DeleteRequest{
+			Key: fakeKey,
+		}))
+
+		stats, ok := components.GetStats(stStore)
+		require.True(t, ok)
+		assert.Equal(t, int64(1), stats.OpenConnections)
+		assert.False(t, stats.LastSuccessTime.Before(before))
+		require.NoError(t, stats.LastError)
+
+		svc.deleteErr = fakeErr
+		require.Error(t, stStore.Delete(context.Background(), &state.DeleteRequest{
+			Key: fakeKey,
+		}))
+
+		stats, ok = components.GetStats(stStore)
+		require.True(t, ok)
+		require.Error(t, stats.LastError)
+		assert.Contains(t, stats.LastError.Error(), fakeErr.Error())
+	})
+
 	t.Run("delete should call delete grpc method", func(t *testing.T) {
 		const fakeKey = "fakeKey"
 
@@ -776,6 +825,51 @@ func TestComponentCalls(t *testing.T) {
 		assert.Len(t, resp.Results, len(results))
 		assert.Equal(t, int64(1), svc.queryCalled.Load())
 	})
+
+	t.Run("subscribe changes should invoke the handler with the changes streamed by the component", func(t *testing.T) {
+		now := time.Now()
+		svc := &server{
+			onSubscribeChanges: func(req *proto.SubscribeChangesRequest) {
+				assert.Equal(t, "app||", req.GetKeyPrefix())
+			},
+			changes: []*proto.StateChange{
+				{Id: "1", Key: "app||a", Value: []byte("1"), Etag: &proto.Etag{Value: "e1"}, Time: timestamppb.New(now)},
+				{Id: "2", Key: "app||a", Type: proto.StateChange_DELETE}, //nolint:nosnakecase
+			},
+		}
+		stStore, cleanup, err := getStateStore(svc)
+		require.NoError(t, err)
+		defer cleanup()
+
+		changes := []*Change{}
+		err = stStore.SubscribeChanges(context.Background(), ChangeFeedRequest{KeyPrefix: "app||"}, func(_ context.Context, change *Change) error {
+			changes = append(changes, change)
+			return nil
+		})
+
+		require.NoError(t, err)
+		require.Len(t, changes, 2)
+		assert.Equal(t, ChangeUpsert, changes[0].Type)
+		assert.Equal(t, []byte("1"), changes[0].Value)
+		assert.Equal(t, "e1", *changes[0].ETag)
+		assert.True(t, now.Equal(changes[0].Time))
+		assert.Equal(t, ChangeDelete, changes[1].Type)
+		assert.True(t, changes[1].Time.IsZero())
+	})
+
+	t.Run("subscribe changes should return the error of the handler", func(t *testing.T) {
+		svc := &server{
+			changes: []*proto.StateChange{{Id: "1", Key: "a"}},
+		}
+		stStore, cleanup, err := getStateStore(svc)
+		require.NoError(t, err)
+		defer cleanup()
+
+		err = stStore.SubscribeChanges(context.Background(), ChangeFeedRequest{}, func(context.Context, *Change) error {
+			return errors.New("fake-handler-err")
+		})
+		require.Error(t, err)
+	})
 }
 
 //nolint:nosnakecase
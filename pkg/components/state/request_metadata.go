@@ -0,0 +1,180 @@
+/*
+Copyright 2023 The Dapr Authors
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package state
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	contribMetadata "github.com/dapr/components-contrib/metadata"
+	"github.com/dapr/components-contrib/state"
+)
+
+// partitionKeyMetadataKey is the request metadata key used by partitioned state stores.
+const partitionKeyMetadataKey = "partitionKey"
+
+// commonRequestMetadata contains the request metadata keys (and their types) that are accepted by every state store.
+var commonRequestMetadata = contribMetadata.MetadataMap{
+	contribMetadata.TTLMetadataKey: {Type: "int"},
+	contribMetadata.ContentType:    {Type: "string"},
+	contribMetadata.QueryIndexName: {Type: "string"},
+	partitionKeyMetadataKey:        {Type: "string"},
+}
+
+// defaultRequestMetadataContract is the contract of state stores that haven't been configured.
+var defaultRequestMetadataContract = newRequestMetadataContract(false, nil)
+
+// RequestMetadataContract is the set of typed metadata keys a state store accepts on requests.
+// The values of the keys in the contract are always validated, while keys that aren't in the contract are rejected only if the contract is strict.
+type RequestMetadataContract struct {
+	// Keys are lowercased to make lookups case-insensitive.
+	fields map[string]contribMetadata.MetadataField
+	names  []string
+	strict bool
+}
+
+// GetRequestMetadataContract returns the request metadata contract for the state store with the given name.
+func GetRequestMetadataContract(storeName string) *RequestMetadataContract {
+	return getStateConfiguration(storeName).requestMetadata
+}
+
+// newRequestMetadataContract returns a contract with the common request metadata keys and the additional keys accepted by the state store.
+// Additional keys have no declared type, so any value is accepted for them.
+func newRequestMetadataContract(strict bool, keys []string) *RequestMetadataContract {
+	c := &RequestMetadataContract{
+		fields: make(map[string]contribMetadata.MetadataField, len(commonRequestMetadata)+len(keys)),
+		names:  make([]string, 0, len(commonRequestMetadata)+len(keys)),
+		strict: strict,
+	}
+	add := func(name string, field contribMetadata.MetadataField) {
+		lk := strings.ToLower(name)
+		if _, ok := c.fields[lk]; ok {
+			return
+		}
+		c.fields[lk] = field
+		c.names = append(c.names, name)
+	}
+	for name, field := range commonRequestMetadata {
+		add(name, field)
+	}
+	for _, name := range keys {
+		add(name, contribMetadata.MetadataField{Type: "string"})
+	}
+	sort.Strings(c.names)
+
+	return c
+}
+
+// ValidKeys returns the sorted list of metadata keys accepted by the contract.
+func (c *RequestMetadataContract) ValidKeys() []string {
+	return c.names
+}
+
+// Validate checks that the values of the keys in the request metadata can be parsed as the declared type.
+// If the contract is strict, it also checks that all keys are part of the contract.
+// A nil contract is the contract of state stores that haven't been configured.
+func (c *RequestMetadataContract) Validate(metadata map[string]string) error {
+	if c == nil {
+		c = defaultRequestMetadataContract
+	}
+
+	for k, v := range metadata {
+		field, ok := c.fields[strings.ToLower(k)]
+		if !ok {
+			if c.strict {
+				return fmt.Errorf("unknown metadata key '%s'; valid keys are: %s", k, strings.Join(c.names, ", "))
+			}
+			continue
+		}
+		if err := validateMetadataValue(field.Type, v); err != nil {
+			return fmt.Errorf("invalid value for metadata key '%s': %w", k, err)
+		}
+		// A TTL of -1 means that the item never expires
+		if strings.EqualFold(k, contribMetadata.TTLMetadataKey) && v != "" {
+			if ttl, _ := strconv.ParseInt(v, 10, 64); ttl < -1 {
+				return fmt.Errorf("invalid value for metadata key '%s': expected an integer greater than or equal to -1, got '%s'", k, v)
+			}
+		}
+	}
+
+	return nil
+}
+
+// validateMetadataValue checks that the value can be parsed as the given Go type name, as declared in the contract.
+// Types that can't be checked are accepted as-is.
+func validateMetadataValue(typ string, val string) error {
+	typ = strings.TrimPrefix(typ, "*")
+	if val == "" {
+		return nil
+	}
+
+	var err error
+	switch typ {
+	case "int", "int8", "int16", "int32", "int64":
+		_, err = strconv.ParseInt(val, 10, 64)
+	case "uint", "uint8", "uint16", "uint32", "uint64":
+		_, err = strconv.ParseUint(val, 10, 64)
+	case "float32", "float64":
+		_, err = strconv.ParseFloat(val, 64)
+	case "bool":
+		switch strings.ToLower(val) {
+		case "1", "true", "t", "yes", "y", "on", "0", "false", "f", "no", "n", "off":
+		default:
+			err = fmt.Errorf("invalid boolean value")
+		}
+	case "time.Duration":
+		// Durations can be expressed as a Go duration string or as a number
+		_, err = time.ParseDuration(val)
+		if err != nil {
+			_, err = strconv.ParseInt(val, 10, 64)
+		}
+	default:
+		return nil
+	}
+
+	if err != nil {
+		return fmt.Errorf("expected a value of type %s, got '%s'", typ, val)
+	}
+	return nil
+}
+
+// ValidateOperationMetadata validates the metadata of an operation in a state transaction against the request metadata contract of the state store.
+// Operations accept the same metadata as single requests.
+func ValidateOperationMetadata(storeName string, metadata map[string]string) error {
+	return GetRequestMetadataContract(storeName).Validate(metadata)
+}
+
+// SyncSetRequestContentType makes sure that the content type of an upsert operation is set both in the request and in its metadata.
+// State stores read the content type from the metadata, while Dapr (for example, the outbox) reads it from the request.
+func SyncSetRequestContentType(req *state.SetRequest) {
+	if req.ContentType == nil {
+		if ct := req.Metadata[contribMetadata.ContentType]; ct != "" {
+			req.ContentType = &ct
+		}
+		return
+	}
+
+	if _, ok := req.Metadata[contribMetadata.ContentType]; ok {
+		return
+	}
+	md := make(map[string]string, len(req.Metadata)+1)
+	for k, v := range req.Metadata {
+		md[k] = v
+	}
+	md[contribMetadata.ContentType] = *req.ContentType
+	req.Metadata = md
+}
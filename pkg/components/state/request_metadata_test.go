@@ -0,0 +1,139 @@
+/*
+Copyright 2023 The Dapr Authors
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package state
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/dapr/components-contrib/state"
+	"github.com/dapr/kit/ptr"
+)
+
+func TestRequestMetadataContract(t *testing.T) {
+	t.Run("unconfigured store accepts any metadata", func(t *testing.T) {
+		c := GetRequestMetadataContract("unconfigured")
+		require.NoError(t, c.Validate(map[string]string{"anything": "goes", "ttlInSeconds": "10"}))
+		require.Error(t, c.Validate(map[string]string{"ttlInSeconds": "ten"}))
+	})
+
+	t.Run("non-strict contract accepts unknown keys", func(t *testing.T) {
+		require.NoError(t, SaveStateConfiguration("lenient", map[string]string{}))
+		c := GetRequestMetadataContract("lenient")
+		require.NoError(t, c.Validate(map[string]string{"anything": "goes", "consistency": "strong"}))
+		require.Error(t, c.Validate(map[string]string{"ttlInSeconds": "ten"}))
+	})
+
+	require.NoError(t, SaveStateConfiguration("strict", map[string]string{
+		"strictRequestMetadata": "true",
+		"requestMetadataKeys":   "consistencyLevel, sessionToken,",
+		// Init metadata is not part of the request metadata contract
+		"maxRetries": "3",
+	}))
+	c := GetRequestMetadataContract("strict")
+
+	t.Run("valid keys are sorted and include common keys", func(t *testing.T) {
+		assert.Equal(t, []string{"consistencyLevel", "contentType", "partitionKey", "queryIndexName", "sessionToken", "ttlInSeconds"}, c.ValidKeys())
+	})
+
+	t.Run("valid metadata", func(t *testing.T) {
+		require.NoError(t, c.Validate(map[string]string{
+			"ttlInSeconds":     "10",
+			"CONTENTTYPE":      "application/json",
+			"partitionKey":     "p1",
+			"consistencyLevel": "strong",
+		}))
+	})
+
+	t.Run("unknown key", func(t *testing.T) {
+		err := c.Validate(map[string]string{"maxRetries": "3"})
+		require.Error(t, err)
+		assert.ErrorContains(t, err, "unknown metadata key 'maxRetries'")
+		assert.ErrorContains(t, err, "valid keys are: consistencyLevel, contentType")
+	})
+
+	t.Run("invalid typed values", func(t *testing.T) {
+		require.Error(t, c.Validate(map[string]string{"ttlInSeconds": "ten"}))
+		require.Error(t, c.Validate(map[string]string{"ttlInSeconds": "1.5"}))
+	})
+}
+
+func TestValidateOperationMetadata(t *testing.T) {
+	require.NoError(t, SaveStateConfiguration("opstrict", map[string]string{"strictRequestMetadata": "true"}))
+	require.NoError(t, SaveStateConfiguration("oplenient", map[string]string{}))
+
+	t.Run("valid metadata", func(t *testing.T) {
+		require.NoError(t, ValidateOperationMetadata("opstrict", map[string]string{"ttlInSeconds": "-1", "contentType": "application/json"}))
+		require.NoError(t, ValidateOperationMetadata("oplenient", map[string]string{"ttlInSeconds": "60", "anything": "goes"}))
+		require.NoError(t, ValidateOperationMetadata("oplenient", nil))
+	})
+
+	t.Run("metadata not in the contract", func(t *testing.T) {
+		require.Error(t, ValidateOperationMetadata("opstrict", map[string]string{"foo": "bar"}))
+	})
+
+	t.Run("TTL is validated without a strict contract", func(t *testing.T) {
+		for _, ttl := range []string{"ten", "1.5", "-2"} {
+			err := ValidateOperationMetadata("oplenient", map[string]string{"ttlInSeconds": ttl})
+			require.Error(t, err, ttl)
+			assert.ErrorContains(t, err, "metadata key 'ttlInSeconds'")
+		}
+		require.Error(t, ValidateOperationMetadata("oplenient", map[string]string{"TTLInSeconds": "-2"}))
+	})
+
+	t.Run("operations accept the same metadata as single requests", func(t *testing.T) {
+		for _, md := range []map[string]string{
+			{"ttlInSeconds": "-2"},
+			{"ttlInSeconds": "ten"},
+			{"foo": "bar"},
+			{"partitionKey": "p1"},
+		} {
+			assert.Equal(t, GetRequestMetadataContract("opstrict").Validate(md) == nil, ValidateOperationMetadata("opstrict", md) == nil, md)
+		}
+	})
+}
+
+func TestSyncSetRequestContentType(t *testing.T) {
+	t.Run("from metadata", func(t *testing.T) {
+		req := state.SetRequest{Metadata: map[string]string{"contentType": "application/json"}}
+		SyncSetRequestContentType(&req)
+		require.NotNil(t, req.ContentType)
+		assert.Equal(t, "application/json", *req.ContentType)
+	})
+
+	t.Run("from request", func(t *testing.T) {
+		md := map[string]string{"ttlInSeconds": "10"}
+		req := state.SetRequest{ContentType: ptr.Of("text/plain"), Metadata: md}
+		SyncSetRequestContentType(&req)
+		assert.Equal(t, map[string]string{"ttlInSeconds": "10", "contentType": "text/plain"}, req.Metadata)
+		// The original map is not modified
+		assert.Len(t, md, 1)
+	})
+
+	t.Run("both set are left unchanged", func(t *testing.T) {
+		req := state.SetRequest{ContentType: ptr.Of("text/plain"), Metadata: map[string]string{"contentType": "application/json"}}
+		SyncSetRequestContentType(&req)
+		assert.Equal(t, "text/plain", *req.ContentType)
+		assert.Equal(t, "application/json", req.Metadata["contentType"])
+	})
+
+	t.Run("no content type", func(t *testing.T) {
+		req := state.SetRequest{}
+		SyncSetRequestContentType(&req)
+		assert.Nil(t, req.ContentType)
+		assert.Nil(t, req.Metadata)
+	})
+}
@@ -18,10 +18,19 @@ import (
 	"os"
 	"strings"
 	"sync"
+	"time"
+
+	"github.com/dapr/kit/utils"
 )
 
 const (
-	strategyKey = "keyprefix"
+	strategyKey          = "keyprefix"
+	changeFeedKey        = "changefeed"
+	idempotencyKeyTTLKey = "idempotencykeyttl"
+	// strictRequestMetadataKey enables rejecting request metadata keys that aren't in the request metadata contract of the state store.
+	strictRequestMetadataKey = "strictrequestmetadata"
+	// requestMetadataKeysKey is a comma-separated list of the request metadata keys accepted by the state store, in addition to the common ones.
+	requestMetadataKeysKey = "requestmetadatakeys"
 
 	strategyNamespace = "namespace"
 	strategyAppid     = "appid"
@@ -29,6 +38,11 @@ const (
 	strategyNone      = "none"
 	strategyDefault   = strategyAppid
 
+	// Placeholders supported in key prefix templates.
+	placeholderNamespace = "namespace"
+	placeholderAppid     = "appid"
+	placeholderStoreName = "name"
+
 	daprSeparator = "||"
 )
 
@@ -40,14 +54,62 @@ var (
 
 type StoreConfiguration struct {
 	keyPrefixStrategy string
+	keyPrefixTemplate []keyPrefixSegment
+	requestMetadata   *RequestMetadataContract
+	changeFeed        bool
+	idempotencyKeyTTL time.Duration
+}
+
+// keyPrefixSegment is a segment of a key prefix template: either a static string or a placeholder.
+type keyPrefixSegment struct {
+	value       string
+	placeholder bool
 }
 
 func SaveStateConfiguration(storeName string, metadata map[string]string) error {
 	strategy := strategyDefault
+	var template []keyPrefixSegment
+	changeFeed := false
+	var idempotencyKeyTTL time.Duration
+	strictRequestMetadata := false
+	var requestMetadataKeys []string
 	for k, v := range metadata {
-		if strings.ToLower(k) == strategyKey { //nolint:gocritic
-			strategy = strings.ToLower(v)
-			break
+		switch strings.ToLower(k) {
+		case changeFeedKey:
+			changeFeed = utils.IsTruthy(v)
+		case strictRequestMetadataKey:
+			strictRequestMetadata = utils.IsTruthy(v)
+		case requestMetadataKeysKey:
+			for _, key := range strings.Split(v, ",") {
+				key = strings.TrimSpace(key)
+				if key != "" {
+					requestMetadataKeys = append(requestMetadataKeys, key)
+				}
+			}
+		case idempotencyKeyTTLKey:
+			if v == "" {
+				continue
+			}
+			var err error
+			idempotencyKeyTTL, err = time.ParseDuration(v)
+			if err != nil {
+				return fmt.Errorf("invalid idempotencyKeyTTL '%s': %w", v, err)
+			}
+			if idempotencyKeyTTL < 0 {
+				return fmt.Errorf("invalid idempotencyKeyTTL '%s': must not be negative", v)
+			}
+		case strategyKey:
+			// Values with placeholders are templates, and they are case-sensitive
+			if strings.ContainsAny(v, "{}") {
+				var err error
+				template, err = parseKeyPrefixTemplate(v)
+				if err != nil {
+					return err
+				}
+				strategy = v
+			} else {
+				strategy = strings.ToLower(v)
+			}
 		}
 	}
 
@@ -57,7 +119,13 @@ func SaveStateConfiguration(storeName string, metadata map[string]string) error
 	}
 
 	statesConfigurationLock.Lock()
-	statesConfiguration[storeName] = &StoreConfiguration{keyPrefixStrategy: strategy}
+	statesConfiguration[storeName] = &StoreConfiguration{
+		keyPrefixStrategy: strategy,
+		keyPrefixTemplate: template,
+		requestMetadata:   newRequestMetadataContract(strictRequestMetadata, requestMetadataKeys),
+		changeFeed:        changeFeed,
+		idempotencyKeyTTL: idempotencyKeyTTL,
+	}
 	statesConfigurationLock.Unlock()
 	return nil
 }
@@ -67,28 +135,44 @@ func GetModifiedStateKey(key, storeName, appID string) (string, error) {
 		return "", err
 	}
 
+	modifiedKey := modifyStateKey(key, storeName, appID)
+	if err := checkKeyReserved(modifiedKey); err != nil {
+		return "", err
+	}
+	return modifiedKey, nil
+}
+
+func modifyStateKey(key, storeName, appID string) string {
 	stateConfiguration := getStateConfiguration(storeName)
+	if stateConfiguration.keyPrefixTemplate != nil {
+		prefix := renderKeyPrefixTemplate(stateConfiguration.keyPrefixTemplate, storeName, appID)
+		if prefix == "" {
+			return key
+		}
+		return prefix + daprSeparator + key
+	}
+
 	switch stateConfiguration.keyPrefixStrategy {
 	case strategyNone:
-		return key, nil
+		return key
 	case strategyStoreName:
-		return storeName + daprSeparator + key, nil
+		return storeName + daprSeparator + key
 	case strategyAppid:
 		if appID == "" {
-			return key, nil
+			return key
 		}
-		return appID + daprSeparator + key, nil
+		return appID + daprSeparator + key
 	case strategyNamespace:
 		if appID == "" {
-			return key, nil
+			return key
 		}
 		if namespace == "" {
 			// if namespace is empty, fallback to app id strategy
-			return appID + daprSeparator + key, nil
+			return appID + daprSeparator + key
 		}
-		return namespace + "." + appID + daprSeparator + key, nil
+		return namespace + "." + appID + daprSeparator + key
 	default:
-		return stateConfiguration.keyPrefixStrategy + daprSeparator + key, nil
+		return stateConfiguration.keyPrefixStrategy + daprSeparator + key
 	}
 }
 
@@ -131,3 +215,61 @@ func checkKeyIllegal(key string) error {
 	}
 	return nil
 }
+
+// parseKeyPrefixTemplate parses a key prefix template, such as "{namespace}.{appID}.orders".
+// Supported placeholders are {namespace}, {appID} and {name} (the name of the state store), and they are case-insensitive.
+func parseKeyPrefixTemplate(template string) ([]keyPrefixSegment, error) {
+	if err := checkKeyIllegal(template); err != nil {
+		return nil, err
+	}
+
+	var segments []keyPrefixSegment
+	rest := template
+	for rest != "" {
+		start := strings.IndexAny(rest, "{}")
+		if start < 0 {
+			segments = append(segments, keyPrefixSegment{value: rest})
+			break
+		}
+		if rest[start] == '}' {
+			return nil, fmt.Errorf("invalid keyPrefix template '%s': unexpected '}'", template)
+		}
+		if start > 0 {
+			segments = append(segments, keyPrefixSegment{value: rest[:start]})
+		}
+
+		end := strings.IndexAny(rest[start+1:], "{}")
+		if end < 0 || rest[start+1+end] != '}' {
+			return nil, fmt.Errorf("invalid keyPrefix template '%s': unterminated placeholder", template)
+		}
+		placeholder := strings.ToLower(rest[start+1 : start+1+end])
+		switch placeholder {
+		case placeholderNamespace, placeholderAppid, placeholderStoreName:
+			segments = append(segments, keyPrefixSegment{value: placeholder, placeholder: true})
+		default:
+			return nil, fmt.Errorf("invalid keyPrefix template '%s': unsupported placeholder '{%s}'", template, rest[start+1:start+1+end])
+		}
+		rest = rest[start+1+end+1:]
+	}
+
+	return segments, nil
+}
+
+func renderKeyPrefixTemplate(segments []keyPrefixSegment, storeName, appID string) string {
+	var b strings.Builder
+	for _, s := range segments {
+		if !s.placeholder {
+			b.WriteString(s.value)
+			continue
+		}
+		switch s.value {
+		case placeholderNamespace:
+			b.WriteString(namespace)
+		case placeholderAppid:
+			b.WriteString(appID)
+		case placeholderStoreName:
+			b.WriteString(storeName)
+		}
+	}
+	return b.String()
+}
@@ -19,6 +19,7 @@ func TestMain(m *testing.M) {
 	SaveStateConfiguration("store4", map[string]string{strings.ToUpper(strategyKey): strategyStoreName})
 	SaveStateConfiguration("store5", map[string]string{strategyKey: "other-fixed-prefix"})
 	SaveStateConfiguration("store7", map[string]string{strategyKey: strategyNamespace})
+	SaveStateConfiguration("store8", map[string]string{strategyKey: "{namespace}.{appID}.Orders-{NAME}"})
 	// if strategyKey not set
 	SaveStateConfiguration("store6", map[string]string{})
 	os.Exit(m.Run())
@@ -147,6 +148,41 @@ func TestOtherFixedPrefix(t *testing.T) {
 	require.Equal(t, key, originalStateKey)
 }
 
+func TestTemplatePrefix(t *testing.T) {
+	t.Run("with namespace", func(t *testing.T) {
+		namespace = "ns1"
+
+		modifiedStateKey, _ := GetModifiedStateKey(key, "store8", "appid1")
+		require.Equal(t, "ns1.appid1.Orders-store8||state-key-1234567", modifiedStateKey)
+
+		originalStateKey := GetOriginalStateKey(modifiedStateKey)
+		require.Equal(t, key, originalStateKey)
+	})
+
+	t.Run("with empty namespace", func(t *testing.T) {
+		namespace = ""
+
+		modifiedStateKey, _ := GetModifiedStateKey(key, "store8", "appid1")
+		require.Equal(t, ".appid1.Orders-store8||state-key-1234567", modifiedStateKey)
+	})
+
+	t.Run("invalid templates", func(t *testing.T) {
+		for _, template := range []string{
+			"{namespace",
+			"namespace}",
+			"{appid}.{region}",
+			"{app{id}}",
+			"{}",
+			"{appid}||orders",
+		} {
+			err := SaveStateConfiguration("statestore02", map[string]string{
+				strategyKey: template,
+			})
+			require.Errorf(t, err, "template %s", template)
+		}
+	})
+}
+
 func TestLegacyPrefix(t *testing.T) {
 	modifiedStateKey, _ := GetModifiedStateKey(key, "store6", "appid1")
 	require.Equal(t, "appid1||state-key-1234567", modifiedStateKey)
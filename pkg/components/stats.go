@@ -0,0 +1,48 @@
+/*
+Copyright 2023 The Dapr Authors
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package components
+
+import (
+	"time"
+)
+
+// Stats is an optional interface for components that report runtime statistics, which are included in the response of the metadata API.
+// It only uses types of the standard library, so components can implement it without depending on Dapr.
+type Stats interface {
+	// Stats returns the number of connections currently open by the component, the time of the last operation completed successfully by the component, and the last error returned by the component.
+	// Zero values are not reported.
+	Stats() (openConnections int64, lastSuccessTime time.Time, lastError error)
+}
+
+// ComponentStats contains the runtime statistics reported by a component.
+// Fields with a zero value are not reported.
+type ComponentStats struct {
+	// Number of connections currently open by the component.
+	OpenConnections int64
+	// Time of the last operation completed successfully by the component.
+	LastSuccessTime time.Time
+	// Last error returned by the component.
+	LastError error
+}
+
+// GetStats returns the runtime statistics of a component, if it implements the Stats interface.
+func GetStats(component any) (ComponentStats, bool) {
+	s, ok := component.(Stats)
+	if !ok {
+		return ComponentStats{}, false
+	}
+	var stats ComponentStats
+	stats.OpenConnections, stats.LastSuccessTime, stats.LastError = s.Stats()
+	return stats, true
+}
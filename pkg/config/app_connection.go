@@ -14,6 +14,9 @@ limitations under the License.
 package config
 
 import (
+	"fmt"
+	"strconv"
+	"strings"
 	"time"
 
 	"github.com/dapr/dapr/pkg/config/protocol"
@@ -28,6 +31,13 @@ const (
 	AppHealthConfigDefaultThreshold = int32(3)
 )
 
+// Building blocks that can be routed to a dedicated app channel.
+const (
+	AppChannelRouteServiceInvocation = "invoke"
+	AppChannelRoutePubSub            = "pubsub"
+	AppChannelRouteBindings          = "bindings"
+)
+
 // AppHealthConfig is the configuration object for the app health probes.
 type AppHealthConfig struct {
 	ProbeInterval time.Duration
@@ -44,4 +54,73 @@ type AppConnectionConfig struct {
 	MaxConcurrency      int
 	Port                int
 	Protocol            protocol.Protocol
+	// Interval for keepalive pings on the gRPC connection to the app. If 0, keepalive pings are disabled.
+	GRPCKeepaliveTime time.Duration
+	// Time to wait for a response to a keepalive ping before closing the gRPC connection to the app.
+	GRPCKeepaliveTimeout time.Duration
+	// Maximum age of the gRPC connection to the app, after which the connection is re-established. If 0, there's no limit.
+	GRPCMaxConnectionAge time.Duration
+	// Dedicated app channels for building blocks, keyed by building block.
+	// Building blocks without a dedicated channel use the main app channel.
+	Routes map[string]AppChannelRoute
+}
+
+// AppChannelRoute is a dedicated connection to the app for a building block, on a different port than the main app channel.
+type AppChannelRoute struct {
+	Port     int
+	Protocol protocol.Protocol
+}
+
+// ProtocolFor returns the protocol used to communicate with the app for the building block.
+func (c AppConnectionConfig) ProtocolFor(buildingBlock string) protocol.Protocol {
+	if route, ok := c.Routes[buildingBlock]; ok {
+		return route.Protocol
+	}
+	return c.Protocol
+}
+
+// ParseAppChannelRoutes parses the dedicated app channels for building blocks, in the format "<building-block>=<protocol>:<port>", for example "pubsub=http:3001".
+// Only HTTP protocols are supported for dedicated app channels.
+func ParseAppChannelRoutes(routes []string) (map[string]AppChannelRoute, error) {
+	if len(routes) == 0 {
+		return nil, nil
+	}
+
+	res := make(map[string]AppChannelRoute, len(routes))
+	for _, r := range routes {
+		buildingBlock, target, ok := strings.Cut(r, "=")
+		if !ok {
+			return nil, fmt.Errorf("invalid app channel route '%s': must be in the format '<building-block>=<protocol>:<port>'", r)
+		}
+
+		buildingBlock = strings.ToLower(strings.TrimSpace(buildingBlock))
+		switch buildingBlock {
+		case AppChannelRouteServiceInvocation, AppChannelRoutePubSub, AppChannelRouteBindings:
+		default:
+			return nil, fmt.Errorf("invalid building block '%s' in app channel route: supported values are '%s', '%s', and '%s'", buildingBlock, AppChannelRouteServiceInvocation, AppChannelRoutePubSub, AppChannelRouteBindings)
+		}
+		if _, ok := res[buildingBlock]; ok {
+			return nil, fmt.Errorf("duplicate app channel route for building block '%s'", buildingBlock)
+		}
+
+		proto, portStr, ok := strings.Cut(strings.TrimSpace(target), ":")
+		if !ok {
+			return nil, fmt.Errorf("invalid app channel route '%s': must be in the format '<building-block>=<protocol>:<port>'", r)
+		}
+		p := protocol.Protocol(strings.ToLower(proto))
+		if !p.IsHTTP() {
+			return nil, fmt.Errorf("invalid protocol '%s' in app channel route for building block '%s': only http, https, and h2c are supported", proto, buildingBlock)
+		}
+		port, err := strconv.Atoi(portStr)
+		if err != nil || port <= 0 || port > 65535 {
+			return nil, fmt.Errorf("invalid port '%s' in app channel route for building block '%s'", portStr, buildingBlock)
+		}
+
+		res[buildingBlock] = AppChannelRoute{
+			Port:     port,
+			Protocol: p,
+		}
+	}
+
+	return res, nil
 }
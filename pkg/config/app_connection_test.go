@@ -0,0 +1,71 @@
+/*
+Copyright 2023 The Dapr Authors
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package config
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/dapr/dapr/pkg/config/protocol"
+)
+
+func TestParseAppChannelRoutes(t *testing.T) {
+	t.Run("no routes", func(t *testing.T) {
+		routes, err := ParseAppChannelRoutes(nil)
+		require.NoError(t, err)
+		assert.Nil(t, routes)
+	})
+
+	t.Run("valid routes", func(t *testing.T) {
+		routes, err := ParseAppChannelRoutes([]string{"pubsub=http:3001", " Bindings = H2C:3002"})
+		require.NoError(t, err)
+		assert.Equal(t, map[string]AppChannelRoute{
+			AppChannelRoutePubSub:   {Port: 3001, Protocol: protocol.HTTPProtocol},
+			AppChannelRouteBindings: {Port: 3002, Protocol: protocol.H2CProtocol},
+		}, routes)
+	})
+
+	invalid := map[string]string{
+		"missing separator":      "pubsub",
+		"unknown building block": "actors=http:3001",
+		"missing port":           "pubsub=http",
+		"gRPC protocol":          "pubsub=grpc:3001",
+		"invalid port":           "pubsub=http:abc",
+		"port out of range":      "pubsub=http:70000",
+	}
+	for name, route := range invalid {
+		t.Run(name, func(t *testing.T) {
+			_, err := ParseAppChannelRoutes([]string{route})
+			require.Error(t, err)
+		})
+	}
+
+	t.Run("duplicate building block", func(t *testing.T) {
+		_, err := ParseAppChannelRoutes([]string{"pubsub=http:3001", "pubsub=http:3002"})
+		require.ErrorContains(t, err, "duplicate")
+	})
+}
+
+func TestProtocolFor(t *testing.T) {
+	c := AppConnectionConfig{
+		Protocol: protocol.GRPCProtocol,
+		Routes: map[string]AppChannelRoute{
+			AppChannelRoutePubSub: {Port: 3001, Protocol: protocol.HTTPProtocol},
+		},
+	}
+	assert.Equal(t, protocol.HTTPProtocol, c.ProtocolFor(AppChannelRoutePubSub))
+	assert.Equal(t, protocol.GRPCProtocol, c.ProtocolFor(AppChannelRouteServiceInvocation))
+}
@@ -45,6 +45,10 @@ const (
 	ActorStateTTL Feature = "ActorStateTTL"
 	// Enables support for hot reloading of Daprd Components and HTTPEndpoints.
 	HotReload Feature = "HotReload"
+	// Enables the fault injection policies defined in Resiliency resources.
+	FaultInjection Feature = "FaultInjection"
+	// Enables the OpenTelemetry metrics pipeline, which pushes metrics to the OTLP endpoint in the metric spec.
+	OTelMetrics Feature = "OTelMetrics"
 )
 
 // end feature flags section
@@ -59,8 +63,23 @@ const (
 	ActionPolicyApp     = "app"
 	ActionPolicyGlobal  = "global"
 
+	WorkflowRemindersBackendActors    = "actors"
+	WorkflowRemindersBackendScheduler = "scheduler"
+
 	defaultMaxWorkflowConcurrentInvocations = 100
 	defaultMaxActivityConcurrentInvocations = 100
+	defaultWorkflowShutdownTimeout          = 5 * time.Second
+	defaultComponentWarmupTimeout           = 30 * time.Second
+	defaultDNSCacheTTL                      = 30 * time.Second
+	defaultPublishDeduplicationMaxKeys      = 10000
+	defaultPublishDeduplicationTTL          = 10 * time.Minute
+	defaultActorFailoverMaxAttempts         = 2
+	defaultInvokeCompressionMinSize         = 1024
+	defaultMetricsWorkflowMaxNames          = 20
+	defaultMetricsAppMaxMetrics             = 100
+	defaultMetricsAppMaxSeries              = 1000
+	defaultRateLimiterWindow                = time.Second
+	defaultLeaderElectionLeaseDuration      = 15 * time.Second
 )
 
 // Configuration is an internal (and duplicate) representation of Dapr's Configuration CRD.
@@ -99,21 +118,218 @@ type AccessControlListOperationAction struct {
 }
 
 type ConfigurationSpec struct {
-	HTTPPipelineSpec    *PipelineSpec       `json:"httpPipeline,omitempty"    yaml:"httpPipeline,omitempty"`
-	AppHTTPPipelineSpec *PipelineSpec       `json:"appHttpPipeline,omitempty" yaml:"appHttpPipeline,omitempty"`
-	TracingSpec         *TracingSpec        `json:"tracing,omitempty"         yaml:"tracing,omitempty"`
-	MTLSSpec            *MTLSSpec           `json:"mtls,omitempty"            yaml:"mtls,omitempty"`
-	MetricSpec          *MetricSpec         `json:"metric,omitempty"          yaml:"metric,omitempty"`
-	MetricsSpec         *MetricSpec         `json:"metrics,omitempty"         yaml:"metrics,omitempty"`
-	Secrets             *SecretsSpec        `json:"secrets,omitempty"         yaml:"secrets,omitempty"`
-	AccessControlSpec   *AccessControlSpec  `json:"accessControl,omitempty"   yaml:"accessControl,omitempty"`
-	NameResolutionSpec  *NameResolutionSpec `json:"nameResolution,omitempty"  yaml:"nameResolution,omitempty"`
-	Features            []FeatureSpec       `json:"features,omitempty"        yaml:"features,omitempty"`
-	APISpec             *APISpec            `json:"api,omitempty"             yaml:"api,omitempty"`
-	ComponentsSpec      *ComponentsSpec     `json:"components,omitempty"      yaml:"components,omitempty"`
-	LoggingSpec         *LoggingSpec        `json:"logging,omitempty"         yaml:"logging,omitempty"`
-	WasmSpec            *WasmSpec           `json:"wasm,omitempty"            yaml:"wasm,omitempty"`
-	WorkflowSpec        *WorkflowSpec       `json:"workflow,omitempty"        yaml:"workflow,omitempty"`
+	HTTPPipelineSpec     *PipelineSpec             `json:"httpPipeline,omitempty"         yaml:"httpPipeline,omitempty"`
+	AppHTTPPipelineSpec  *PipelineSpec             `json:"appHttpPipeline,omitempty"      yaml:"appHttpPipeline,omitempty"`
+	TracingSpec          *TracingSpec              `json:"tracing,omitempty"              yaml:"tracing,omitempty"`
+	MTLSSpec             *MTLSSpec                 `json:"mtls,omitempty"                 yaml:"mtls,omitempty"`
+	MetricSpec           *MetricSpec               `json:"metric,omitempty"               yaml:"metric,omitempty"`
+	MetricsSpec          *MetricSpec               `json:"metrics,omitempty"              yaml:"metrics,omitempty"`
+	Secrets              *SecretsSpec              `json:"secrets,omitempty"              yaml:"secrets,omitempty"`
+	AccessControlSpec    *AccessControlSpec        `json:"accessControl,omitempty"        yaml:"accessControl,omitempty"`
+	NameResolutionSpec   *NameResolutionSpec       `json:"nameResolution,omitempty"       yaml:"nameResolution,omitempty"`
+	Features             []FeatureSpec             `json:"features,omitempty"             yaml:"features,omitempty"`
+	APISpec              *APISpec                  `json:"api,omitempty"                  yaml:"api,omitempty"`
+	ComponentsSpec       *ComponentsSpec           `json:"components,omitempty"           yaml:"components,omitempty"`
+	LoggingSpec          *LoggingSpec              `json:"logging,omitempty"              yaml:"logging,omitempty"`
+	WasmSpec             *WasmSpec                 `json:"wasm,omitempty"                 yaml:"wasm,omitempty"`
+	WorkflowSpec         *WorkflowSpec             `json:"workflow,omitempty"             yaml:"workflow,omitempty"`
+	DNSCacheSpec         *DNSCacheSpec             `json:"dnsCache,omitempty"             yaml:"dnsCache,omitempty"`
+	CORSSpec             *CORSSpec                 `json:"cors,omitempty"                 yaml:"cors,omitempty"`
+	PublishDeduplication *PublishDeduplicationSpec `json:"publishDeduplication,omitempty" yaml:"publishDeduplication,omitempty"`
+	ActorFailover        *ActorFailoverSpec        `json:"actorFailover,omitempty"        yaml:"actorFailover,omitempty"`
+	IdempotentMethods    []IdempotentMethodSpec    `json:"idempotentMethods,omitempty"    yaml:"idempotentMethods,omitempty"`
+	Transcoding          *TranscodingSpec          `json:"transcoding,omitempty"          yaml:"transcoding,omitempty"`
+	InvokeCompression    *InvokeCompressionSpec    `json:"invokeCompression,omitempty"    yaml:"invokeCompression,omitempty"`
+	RateLimiters         []RateLimiterSpec         `json:"rateLimiters,omitempty"         yaml:"rateLimiters,omitempty"`
+	LeaderElections      []LeaderElectionSpec      `json:"leaderElections,omitempty"      yaml:"leaderElections,omitempty"`
+}
+
+// CORSSpec configures the CORS policy of the Dapr HTTP API.
+type CORSSpec struct {
+	// Default policy, applied to all routes that don't match a route-specific policy.
+	CORSPolicySpec `json:",inline" yaml:",inline"`
+	// Policies for the routes that start with a path prefix, replacing the default policy.
+	// When multiple prefixes match a request, the longest one is used.
+	Routes []CORSRouteSpec `json:"routes,omitempty" yaml:"routes,omitempty"`
+}
+
+// CORSRouteSpec is the CORS policy for the routes starting with a path prefix.
+type CORSRouteSpec struct {
+	// Path prefix the policy applies to, such as "/v1.0/invoke".
+	PathPrefix     string `json:"pathPrefix" yaml:"pathPrefix"`
+	CORSPolicySpec `json:",inline" yaml:",inline"`
+}
+
+// CORSPolicySpec is a CORS policy.
+type CORSPolicySpec struct {
+	// List of origins allowed to perform cross-origin requests.
+	// If empty, the origins set with the "--allowed-origins" flag are used.
+	AllowedOrigins []string `json:"allowedOrigins,omitempty" yaml:"allowedOrigins,omitempty"`
+	// List of methods allowed in cross-origin requests.
+	AllowedMethods []string `json:"allowedMethods,omitempty" yaml:"allowedMethods,omitempty"`
+	// List of headers allowed in cross-origin requests.
+	AllowedHeaders []string `json:"allowedHeaders,omitempty" yaml:"allowedHeaders,omitempty"`
+	// List of response headers exposed to the clients.
+	ExposedHeaders []string `json:"exposedHeaders,omitempty" yaml:"exposedHeaders,omitempty"`
+	// Allows requests to include credentials, such as cookies and authorization headers.
+	AllowCredentials bool `json:"allowCredentials,omitempty" yaml:"allowCredentials,omitempty"`
+	// Number of seconds the response to a preflight request can be cached for.
+	MaxAge int `json:"maxAge,omitempty" yaml:"maxAge,omitempty"`
+}
+
+// DNSCacheSpec configures the cache of DNS lookups for the connections to other Dapr sidecars.
+type DNSCacheSpec struct {
+	// Enables caching DNS lookups, and re-resolving addresses when connections fail.
+	Enabled bool `json:"enabled,omitempty" yaml:"enabled,omitempty"`
+	// Time after which cached addresses are resolved again, as a Go duration.
+	// If omitted, the default value of 30s will be used.
+	TTL string `json:"ttl,omitempty" yaml:"ttl,omitempty"`
+}
+
+// GetTTL returns the TTL of cached DNS lookups, or 0 if the cache is disabled.
+func (d *DNSCacheSpec) GetTTL() (time.Duration, error) {
+	if d == nil || !d.Enabled {
+		return 0, nil
+	}
+	if d.TTL == "" {
+		return defaultDNSCacheTTL, nil
+	}
+	ttl, err := time.ParseDuration(d.TTL)
+	if err != nil {
+		return 0, fmt.Errorf("invalid DNS cache TTL '%s': %w", d.TTL, err)
+	}
+	if ttl <= 0 {
+		return 0, fmt.Errorf("invalid DNS cache TTL '%s': must be positive", d.TTL)
+	}
+	return ttl, nil
+}
+
+// ActorFailoverSpec configures how actor invocations fail over to other hosts when the host an actor is placed on is unreachable.
+type ActorFailoverSpec struct {
+	// Enables invoking the actor again once the placement tables are updated, instead of failing until the next invocation.
+	Enabled bool `json:"enabled,omitempty" yaml:"enabled,omitempty"`
+	// Maximum number of placement table updates an invocation waits for to fail over.
+	// If omitted, the default value of 2 will be used.
+	MaxAttempts int `json:"maxAttempts,omitempty" yaml:"maxAttempts,omitempty"`
+}
+
+// GetMaxAttempts returns the maximum number of hosts an actor invocation fails over to, or 0 if failover is disabled.
+func (f *ActorFailoverSpec) GetMaxAttempts() int {
+	if f == nil || !f.Enabled {
+		return 0
+	}
+	if f.MaxAttempts <= 0 {
+		return defaultActorFailoverMaxAttempts
+	}
+	return f.MaxAttempts
+}
+
+// IdempotentMethodSpec marks service invocation methods as idempotent.
+// Invocations of these methods are retried when the connection to the target app is reset while the call is in flight,
+// which is not safe for methods that may have been executed by the target.
+type IdempotentMethodSpec struct {
+	// ID of the target app. If empty, the methods are idempotent for all the apps.
+	AppID string `json:"appId,omitempty" yaml:"appId,omitempty"`
+	// Names of the methods. A trailing "*" matches all the methods that start with the prefix.
+	Methods []string `json:"methods" yaml:"methods"`
+}
+
+// TranscodingSpec configures the transcoding of the bodies of the service invocation requests to the methods of the app, and of their responses, between JSON and protobuf.
+// Callers declare the content type of the request with the Content-Type header, and the content type they accept in the response with the Accept header, or the one of the request if absent.
+// The bodies are transcoded when the caller and the app declare different content types.
+type TranscodingSpec struct {
+	// Path of a file with a serialized FileDescriptorSet containing the message types, such as the output of "protoc --include_imports --descriptor_set_out".
+	DescriptorSetFile string `json:"descriptorSetFile" yaml:"descriptorSetFile"`
+	// Methods of the app that are transcoded.
+	Methods []TranscodingMethodSpec `json:"methods" yaml:"methods"`
+}
+
+// TranscodingMethodSpec declares the content type and the message types of a method of the app.
+type TranscodingMethodSpec struct {
+	// Name of the method.
+	Name string `json:"name" yaml:"name"`
+	// Content type of the bodies the method accepts and returns: "application/json" or "application/x-protobuf".
+	ContentType string `json:"contentType" yaml:"contentType"`
+	// Full name of the message type of the requests, such as "orders.v1.CreateOrderRequest".
+	RequestType string `json:"requestType" yaml:"requestType"`
+	// Full name of the message type of the responses.
+	ResponseType string `json:"responseType" yaml:"responseType"`
+}
+
+// InvokeCompressionSpec configures the gzip compression of the service invocation calls to other Dapr sidecars.
+// Calls are compressed only once the target sidecar has advertised that it supports gzip compression.
+type InvokeCompressionSpec struct {
+	// Enables compressing the calls.
+	Enabled bool `json:"enabled,omitempty" yaml:"enabled,omitempty"`
+	// Minimum size, in bytes, of the request bodies that are compressed. Bodies whose size is not known in advance are not compressed.
+	// If omitted, the default value of 1024 will be used.
+	MinSize int `json:"minSize,omitempty" yaml:"minSize,omitempty"`
+}
+
+// GetMinSize returns the minimum size of the request bodies that are compressed, or 0 if the compression is disabled.
+func (c *InvokeCompressionSpec) GetMinSize() int {
+	if c == nil || !c.Enabled {
+		return 0
+	}
+	if c.MinSize <= 0 {
+		return defaultInvokeCompressionMinSize
+	}
+	return c.MinSize
+}
+
+// IsIdempotentMethod returns true if the method of the app is marked as idempotent by one of the specs.
+func IsIdempotentMethod(specs []IdempotentMethodSpec, appID string, method string) bool {
+	for _, s := range specs {
+		if s.AppID != "" && s.AppID != appID {
+			continue
+		}
+		for _, m := range s.Methods {
+			if prefix, ok := strings.CutSuffix(m, "*"); ok {
+				if strings.HasPrefix(method, prefix) {
+					return true
+				}
+			} else if m == method {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// PublishDeduplicationSpec configures how the idempotency keys of publish requests are remembered, to suppress duplicate publishes.
+type PublishDeduplicationSpec struct {
+	// Maximum number of idempotency keys kept in memory.
+	// If omitted, the default value of 10000 will be used.
+	MaxKeys int `json:"maxKeys,omitempty" yaml:"maxKeys,omitempty"`
+	// Time idempotency keys are remembered for, as a Go duration.
+	// If omitted, the default value of 10m will be used.
+	TTL string `json:"ttl,omitempty" yaml:"ttl,omitempty"`
+	// Name of a state store where idempotency keys are also saved, so they are shared across replicas and restarts.
+	// The state store must support TTLs.
+	StateStore string `json:"stateStore,omitempty" yaml:"stateStore,omitempty"`
+}
+
+// GetMaxKeys returns the maximum number of idempotency keys kept in memory.
+func (p PublishDeduplicationSpec) GetMaxKeys() int {
+	if p.MaxKeys <= 0 {
+		return defaultPublishDeduplicationMaxKeys
+	}
+	return p.MaxKeys
+}
+
+// GetTTL returns the time idempotency keys are remembered for.
+func (p PublishDeduplicationSpec) GetTTL() (time.Duration, error) {
+	if p.TTL == "" {
+		return defaultPublishDeduplicationTTL, nil
+	}
+	ttl, err := time.ParseDuration(p.TTL)
+	if err != nil {
+		return 0, fmt.Errorf("invalid publish deduplication TTL '%s': %w", p.TTL, err)
+	}
+	if ttl <= 0 {
+		return 0, fmt.Errorf("invalid publish deduplication TTL '%s': must be positive", p.TTL)
+	}
+	return ttl, nil
 }
 
 // WorkflowSpec defines the configuration for Dapr workflows.
@@ -126,6 +342,41 @@ type WorkflowSpec struct {
 	// Attempted invocations beyond this will be queued until the number of concurrent invocations drops below this value.
 	// If omitted, the default value of 100 will be used.
 	MaxConcurrentActivityInvocations int32 `json:"maxConcurrentActivityInvocations,omitempty" yaml:"maxConcurrentActivityInvocations,omitempty"`
+	// shutdownTimeout is the time to wait on shutdown for in-flight workflow and activity executions to complete, as a Go duration.
+	// Executions still running after this time are preempted and retried later, possibly by another Dapr instance.
+	// If omitted, the default value of 5s will be used.
+	ShutdownTimeout string `json:"shutdownTimeout,omitempty" yaml:"shutdownTimeout,omitempty"`
+	// retention configures the automatic purge of the completed workflow instances.
+	// If omitted, completed workflow instances are retained until they're purged with the API.
+	Retention *WorkflowRetentionSpec `json:"retention,omitempty" yaml:"retention,omitempty"`
+	// eventBufferTTL is the time the events raised on workflow instances that don't exist yet are buffered for, as a Go duration.
+	// The buffered events are delivered to the instance if it's started within this time, and dropped otherwise.
+	// If omitted, raising an event on a workflow instance that doesn't exist fails.
+	EventBufferTTL string `json:"eventBufferTTL,omitempty" yaml:"eventBufferTTL,omitempty"`
+	// remoteWorkflows are the workflows hosted by other apps in the same namespace, which the workflows of this app can start as child workflows.
+	RemoteWorkflows []RemoteWorkflowSpec `json:"remoteWorkflows,omitempty" yaml:"remoteWorkflows,omitempty"`
+	// remindersBackend is the service the durable timers and the work items of the workflows are scheduled with: "actors" or "scheduler".
+	// With "scheduler", they're scheduled as jobs of the scheduler service instead of actor reminders, which aren't saved in the actor state store.
+	// If omitted, the actor reminders are used.
+	RemindersBackend string `json:"remindersBackend,omitempty" yaml:"remindersBackend,omitempty"`
+}
+
+// RemoteWorkflowSpec maps the name of a workflow to the app that hosts it.
+type RemoteWorkflowSpec struct {
+	// Name of the workflow, as registered by the app that hosts it.
+	Name string `json:"name" yaml:"name"`
+	// ID of the app that hosts the workflow.
+	AppID string `json:"appId" yaml:"appId"`
+}
+
+// WorkflowRetentionSpec configures the automatic purge of the completed workflow instances.
+// Instances are purged when they exceed either limit.
+type WorkflowRetentionSpec struct {
+	// maxAge is the time after which completed workflow instances are purged, as a Go duration.
+	MaxAge string `json:"maxAge,omitempty" yaml:"maxAge,omitempty"`
+	// maxCompletedInstances is the maximum number of completed workflow instances that are retained.
+	// The instances that completed first are purged first.
+	MaxCompletedInstances int32 `json:"maxCompletedInstances,omitempty" yaml:"maxCompletedInstances,omitempty"`
 }
 
 func (w *WorkflowSpec) GetMaxConcurrentWorkflowInvocations() int32 {
@@ -142,6 +393,91 @@ func (w *WorkflowSpec) GetMaxConcurrentActivityInvocations() int32 {
 	return w.MaxConcurrentActivityInvocations
 }
 
+// GetShutdownTimeout returns the time to wait on shutdown for in-flight workflow and activity executions to complete.
+func (w *WorkflowSpec) GetShutdownTimeout() (time.Duration, error) {
+	if w == nil || w.ShutdownTimeout == "" {
+		return defaultWorkflowShutdownTimeout, nil
+	}
+	timeout, err := time.ParseDuration(w.ShutdownTimeout)
+	if err != nil {
+		return 0, fmt.Errorf("invalid workflow shutdown timeout '%s': %w", w.ShutdownTimeout, err)
+	}
+	if timeout < 0 {
+		return 0, fmt.Errorf("invalid workflow shutdown timeout '%s': must not be negative", w.ShutdownTimeout)
+	}
+	return timeout, nil
+}
+
+// GetEventBufferTTL returns the time the events raised on workflow instances that don't exist yet are buffered for.
+// It returns 0 if events are not buffered.
+func (w *WorkflowSpec) GetEventBufferTTL() (time.Duration, error) {
+	if w == nil || w.EventBufferTTL == "" {
+		return 0, nil
+	}
+	ttl, err := time.ParseDuration(w.EventBufferTTL)
+	if err != nil {
+		return 0, fmt.Errorf("invalid workflow event buffer TTL '%s': %w", w.EventBufferTTL, err)
+	}
+	if ttl < 0 {
+		return 0, fmt.Errorf("invalid workflow event buffer TTL '%s': must not be negative", w.EventBufferTTL)
+	}
+	return ttl, nil
+}
+
+// GetRemoteWorkflows returns the IDs of the apps that host the remote workflows, keyed by workflow name.
+func (w *WorkflowSpec) GetRemoteWorkflows() (map[string]string, error) {
+	if w == nil || len(w.RemoteWorkflows) == 0 {
+		return nil, nil
+	}
+	apps := make(map[string]string, len(w.RemoteWorkflows))
+	for _, rw := range w.RemoteWorkflows {
+		if rw.Name == "" || rw.AppID == "" {
+			return nil, fmt.Errorf("invalid remote workflow '%s': name and appId are required", rw.Name)
+		}
+		if _, ok := apps[rw.Name]; ok {
+			return nil, fmt.Errorf("duplicate remote workflow '%s'", rw.Name)
+		}
+		apps[rw.Name] = rw.AppID
+	}
+	return apps, nil
+}
+
+// UseScheduler returns true if the durable timers and the work items of the workflows are scheduled with the scheduler service instead of actor reminders.
+func (w *WorkflowSpec) UseScheduler() (bool, error) {
+	if w == nil {
+		return false, nil
+	}
+	switch w.RemindersBackend {
+	case "", WorkflowRemindersBackendActors:
+		return false, nil
+	case WorkflowRemindersBackendScheduler:
+		return true, nil
+	default:
+		return false, fmt.Errorf("invalid workflow reminders backend '%s': must be '%s' or '%s'", w.RemindersBackend, WorkflowRemindersBackendActors, WorkflowRemindersBackendScheduler)
+	}
+}
+
+// GetRetention returns the retention policy of the completed workflow instances.
+// A maxAge or maxCompleted of 0 means the corresponding limit is not set.
+func (w *WorkflowSpec) GetRetention() (maxAge time.Duration, maxCompleted int, err error) {
+	if w == nil || w.Retention == nil {
+		return 0, 0, nil
+	}
+	if w.Retention.MaxAge != "" {
+		maxAge, err = time.ParseDuration(w.Retention.MaxAge)
+		if err != nil {
+			return 0, 0, fmt.Errorf("invalid workflow retention max age '%s': %w", w.Retention.MaxAge, err)
+		}
+		if maxAge <= 0 {
+			return 0, 0, fmt.Errorf("invalid workflow retention max age '%s': must be positive", w.Retention.MaxAge)
+		}
+	}
+	if w.Retention.MaxCompletedInstances < 0 {
+		return 0, 0, fmt.Errorf("invalid workflow retention max completed instances %d: must not be negative", w.Retention.MaxCompletedInstances)
+	}
+	return maxAge, int(w.Retention.MaxCompletedInstances), nil
+}
+
 type SecretsSpec struct {
 	Scopes []SecretsScope `json:"scopes,omitempty"`
 }
@@ -164,6 +500,84 @@ type APISpec struct {
 	Allowed APIAccessRules `json:"allowed,omitempty"`
 	// List of denied APIs. Can be used in conjunction with allowed.
 	Denied APIAccessRules `json:"denied,omitempty"`
+	// Rate limits of the HTTP APIs of the building blocks.
+	RateLimits []APIRateLimit `json:"rateLimits,omitempty"`
+}
+
+// APIRateLimit limits the number of requests per second to the HTTP API of a building block.
+type APIRateLimit struct {
+	// Name of the API, which is the name of the endpoint group, such as "state", "publish" or "invoke".
+	Name string `json:"name"`
+	// Maximum number of requests per second, across all the versions of the API.
+	RequestsPerSecond int `json:"requestsPerSecond"`
+	// Maximum number of requests allowed in a burst. Defaults to RequestsPerSecond.
+	Burst int `json:"burst,omitempty"`
+	// Name of a rate limiter in rateLimiters the requests are checked against, with the name of the API as key.
+	// Its limit is shared by all the replicas of the app, and replaces RequestsPerSecond and Burst.
+	RateLimiter string `json:"rateLimiter,omitempty"`
+}
+
+// GetBurst returns the maximum number of requests allowed in a burst.
+func (l APIRateLimit) GetBurst() int {
+	if l.Burst < 1 {
+		return l.RequestsPerSecond
+	}
+	return l.Burst
+}
+
+// RateLimiterSpec configures a distributed rate limiter, whose limit is shared by all the replicas of the app.
+// The counters are kept in a state store, which must support ETags and should support TTLs, such as Redis.
+type RateLimiterSpec struct {
+	// Name of the rate limiter, used in the rate limit API.
+	Name string `json:"name" yaml:"name"`
+	// Name of the state store that keeps the counters.
+	StateStore string `json:"stateStore" yaml:"stateStore"`
+	// Maximum number of units allowed per key in each window.
+	Limit int64 `json:"limit" yaml:"limit"`
+	// Duration of the windows, such as "1s" or "1m". Defaults to 1s.
+	Window string `json:"window,omitempty" yaml:"window,omitempty"`
+}
+
+// GetWindow returns the duration of the windows of the rate limiter.
+func (s RateLimiterSpec) GetWindow() (time.Duration, error) {
+	if s.Window == "" {
+		return defaultRateLimiterWindow, nil
+	}
+	window, err := time.ParseDuration(s.Window)
+	if err != nil {
+		return 0, fmt.Errorf("invalid window for rate limiter '%s': %w", s.Name, err)
+	}
+	if window < time.Millisecond {
+		return 0, fmt.Errorf("invalid window for rate limiter '%s': must be at least 1ms", s.Name)
+	}
+	return window, nil
+}
+
+// LeaderElectionSpec configures an election, in which the replicas of the app campaign for a leadership lease.
+// The lease is kept in a state store, which must support ETags, or in a Kubernetes Lease.
+type LeaderElectionSpec struct {
+	// Name of the election, used in the leader election API.
+	Name string `json:"name" yaml:"name"`
+	// Name of the state store that keeps the lease.
+	// If empty, a Kubernetes Lease in the namespace of the app is used, which is only available in Kubernetes mode.
+	StateStore string `json:"stateStore,omitempty" yaml:"stateStore,omitempty"`
+	// Duration of the lease, such as "15s". The leader renews it before it expires. Defaults to 15s.
+	LeaseDuration string `json:"leaseDuration,omitempty" yaml:"leaseDuration,omitempty"`
+}
+
+// GetLeaseDuration returns the duration of the lease of the election.
+func (s LeaderElectionSpec) GetLeaseDuration() (time.Duration, error) {
+	if s.LeaseDuration == "" {
+		return defaultLeaderElectionLeaseDuration, nil
+	}
+	d, err := time.ParseDuration(s.LeaseDuration)
+	if err != nil {
+		return 0, fmt.Errorf("invalid lease duration for election '%s': %w", s.Name, err)
+	}
+	if d < time.Second {
+		return 0, fmt.Errorf("invalid lease duration for election '%s': must be at least 1s", s.Name)
+	}
+	return d, nil
 }
 
 // APIAccessRule describes an access rule for allowing a Dapr API to be enabled and accessible by an app.
@@ -224,6 +638,40 @@ type TracingSpec struct {
 	Stdout       bool        `json:"stdout,omitempty"       yaml:"stdout,omitempty"`
 	Zipkin       *ZipkinSpec `json:"zipkin,omitempty"       yaml:"zipkin,omitempty"`
 	Otel         *OtelSpec   `json:"otel,omitempty"         yaml:"otel,omitempty"`
+	// Exports the spans dropped by the sampling rate when they end with an error or are slow.
+	TailSampling *TailSamplingSpec `json:"tailSampling,omitempty" yaml:"tailSampling,omitempty"`
+	// Attributes of the OpenTelemetry resource the spans are reported with.
+	Resource *OtelResourceSpec `json:"resource,omitempty" yaml:"resource,omitempty"`
+	// Attributes set on every span created by the sidecar.
+	SpanAttributes []SpanAttributeSpec `json:"spanAttributes,omitempty" yaml:"spanAttributes,omitempty"`
+	// Formats of the trace context propagated in the headers of requests, separated by commas: w3c, b3 and b3multi.
+	// The trace context is read from the first format found in the incoming requests, and written in all the formats.
+	// Defaults to w3c.
+	Propagation string `json:"propagation,omitempty" yaml:"propagation,omitempty"`
+}
+
+// SpanAttributeSpec defines an attribute set on the spans created by the sidecar.
+// Exactly one of value, envVar and header must be set.
+type SpanAttributeSpec struct {
+	// Name of the attribute.
+	Name string `json:"name" yaml:"name"`
+	// Static value of the attribute.
+	Value string `json:"value,omitempty" yaml:"value,omitempty"`
+	// Environment variable of the sidecar with the value of the attribute, such as POD_NAME or NODE_NAME on Kubernetes.
+	// The attribute is not set if the variable is empty.
+	EnvVar string `json:"envVar,omitempty" yaml:"envVar,omitempty"`
+	// Header of the requests received by the sidecar with the value of the attribute.
+	// The attribute is only set on the spans of the requests that have the header.
+	Header string `json:"header,omitempty" yaml:"header,omitempty"`
+}
+
+// TailSamplingSpec defines the spans that are exported even if they are not sampled.
+// The decision is made when the span ends, so it only applies to the spans created by this sidecar: the sampling flag propagated to other services is unchanged.
+type TailSamplingSpec struct {
+	// Exports the spans that end with an error status.
+	Errors bool `json:"errors,omitempty" yaml:"errors,omitempty"`
+	// Exports the spans that take longer than this duration.
+	LatencyThreshold string `json:"latencyThreshold,omitempty" yaml:"latencyThreshold,omitempty"`
 }
 
 // ZipkinSpec defines Zipkin exporter configurations.
@@ -245,11 +693,102 @@ func (o OtelSpec) GetIsSecure() bool {
 	return o.IsSecure == nil || *o.IsSecure
 }
 
+// OtelResourceSpec defines the attributes of the OpenTelemetry resource, which identifies the source of the telemetry.
+// By default, the resource only has the service.name attribute, set to the app ID.
+type OtelResourceSpec struct {
+	// Value of the service.name attribute. Defaults to the app ID.
+	ServiceName string `json:"serviceName,omitempty" yaml:"serviceName,omitempty"`
+	// Value of the deployment.environment attribute, such as "production" or "staging".
+	Environment string `json:"environment,omitempty" yaml:"environment,omitempty"`
+	// Additional attributes of the resource.
+	// The service name and environment take precedence over the attributes with the same key.
+	Attributes map[string]string `json:"attributes,omitempty" yaml:"attributes,omitempty"`
+}
+
 // MetricSpec configuration for metrics.
 type MetricSpec struct {
 	// Defaults to true
 	Enabled *bool         `json:"enabled,omitempty" yaml:"enabled,omitempty"`
 	Rules   []MetricsRule `json:"rules,omitempty"   yaml:"rules,omitempty"`
+	// Records 1 in N occurrences of the high-frequency measures, such as pub/sub messages, with a weight of N.
+	// Defaults to 1, which records every occurrence.
+	SamplingFactor int `json:"samplingFactor,omitempty" yaml:"samplingFactor,omitempty"`
+	// OTLP endpoint the metrics are pushed to, in addition to the Prometheus endpoint.
+	// Requires the OTelMetrics feature.
+	Otel *OtelSpec `json:"otel,omitempty" yaml:"otel,omitempty"`
+	// Controls the labels of all the metrics, to limit their cardinality.
+	Labels *MetricLabelsSpec `json:"labels,omitempty" yaml:"labels,omitempty"`
+	// Configures the metrics of the HTTP server.
+	HTTP *MetricHTTPSpec `json:"http,omitempty" yaml:"http,omitempty"`
+	// Bucket boundaries of the latency histograms, per family of metrics.
+	LatencyBuckets *MetricLatencyBucketsSpec `json:"latencyBuckets,omitempty" yaml:"latencyBuckets,omitempty"`
+	// Configures the metrics of the workflows.
+	Workflow *MetricWorkflowSpec `json:"workflow,omitempty" yaml:"workflow,omitempty"`
+	// Attributes of the OpenTelemetry resource the metrics pushed to the OTLP endpoint are reported with.
+	Resource *OtelResourceSpec `json:"resource,omitempty" yaml:"resource,omitempty"`
+	// Limits the custom metrics recorded by the app with the metrics API.
+	App *MetricAppSpec `json:"app,omitempty" yaml:"app,omitempty"`
+}
+
+// MetricAppSpec limits the custom metrics recorded by the app, to bound their cardinality.
+// Measurements that would exceed the limits are rejected.
+type MetricAppSpec struct {
+	// Maximum number of distinct custom metrics. Defaults to 100.
+	MaxMetrics int `json:"maxMetrics,omitempty" yaml:"maxMetrics,omitempty"`
+	// Maximum number of distinct combinations of label values of each custom metric. Defaults to 1000.
+	MaxSeries int `json:"maxSeries,omitempty" yaml:"maxSeries,omitempty"`
+}
+
+// MetricWorkflowSpec configures the metrics of the workflows.
+type MetricWorkflowSpec struct {
+	// Tags the workflow metrics with the names of the workflows and activities.
+	// Names are an unbounded set, so only the most frequent ones are used as tags and the others are recorded as "other".
+	NameLabels bool `json:"nameLabels,omitempty" yaml:"nameLabels,omitempty"`
+	// Maximum number of distinct workflow and activity names the metrics are tagged with.
+	// Defaults to 20.
+	MaxNames int `json:"maxNames,omitempty" yaml:"maxNames,omitempty"`
+}
+
+// MetricLatencyBucketsSpec sets the bucket boundaries of the latency histograms, in milliseconds, such as "0.5" or "250".
+// The boundaries must be positive and in increasing order. The families that are not set use the default boundaries.
+type MetricLatencyBucketsSpec struct {
+	// Boundaries of the latency histograms of the HTTP server and client.
+	HTTP []string `json:"http,omitempty" yaml:"http,omitempty"`
+	// Boundaries of the latency histograms of the gRPC server and client.
+	GRPC []string `json:"grpc,omitempty" yaml:"grpc,omitempty"`
+	// Boundaries of the latency histograms of the workflows.
+	Workflow []string `json:"workflow,omitempty" yaml:"workflow,omitempty"`
+	// Boundaries of the latency histograms of the service invocation calls.
+	ServiceInvocation []string `json:"serviceInvocation,omitempty" yaml:"serviceInvocation,omitempty"`
+}
+
+// MetricHTTPSpec configures the metrics of the HTTP server.
+type MetricHTTPSpec struct {
+	// Templates of the routes of the app, such as "/orders/{id}".
+	// If set, the HTTP server metrics are tagged with the first template that matches the path invoked on the app, or "unmatched".
+	// A segment in braces matches any single segment, and a trailing "*" matches any number of segments.
+	Routes []string `json:"routes,omitempty" yaml:"routes,omitempty"`
+}
+
+// MetricLabelsSpec drops or rewrites the values of the labels of all the metrics before they are recorded.
+type MetricLabelsSpec struct {
+	// If set, only these labels are kept and all the others are dropped.
+	Allow []string `json:"allow,omitempty" yaml:"allow,omitempty"`
+	// Labels dropped from all the metrics.
+	Deny []string `json:"deny,omitempty" yaml:"deny,omitempty"`
+	// Rewrites of the label values, applied in order after the rules of the metric.
+	Rewrites []MetricLabelRewrite `json:"rewrites,omitempty" yaml:"rewrites,omitempty"`
+}
+
+// MetricLabelRewrite rewrites the values of a label in all the metrics.
+type MetricLabelRewrite struct {
+	// Name of the label, such as "path" or "status".
+	Name string `json:"name" yaml:"name"`
+	// Regular expression matched against the value; the matches are replaced with Replace.
+	Regex   string `json:"regex,omitempty"   yaml:"regex,omitempty"`
+	Replace string `json:"replace,omitempty" yaml:"replace,omitempty"`
+	// Replaces HTTP status codes with their class, such as "4xx" for "404".
+	StatusClass bool `json:"statusClass,omitempty" yaml:"statusClass,omitempty"`
 }
 
 // GetEnabled returns true if metrics are enabled.
@@ -258,6 +797,56 @@ func (m MetricSpec) GetEnabled() bool {
 	return m.Enabled == nil || *m.Enabled
 }
 
+// GetHTTPRoutes returns the templates of the app routes the HTTP server metrics are tagged with.
+func (m MetricSpec) GetHTTPRoutes() []string {
+	if m.HTTP == nil {
+		return nil
+	}
+	return m.HTTP.Routes
+}
+
+// GetLatencyBuckets returns the bucket boundaries of the latency histograms.
+// The families that are not configured have no boundaries.
+func (m MetricSpec) GetLatencyBuckets() MetricLatencyBucketsSpec {
+	if m.LatencyBuckets == nil {
+		return MetricLatencyBucketsSpec{}
+	}
+	return *m.LatencyBuckets
+}
+
+// GetWorkflowMaxNames returns the maximum number of distinct names the workflow metrics are tagged with, which is 0 if they are not tagged with names.
+func (m MetricSpec) GetWorkflowMaxNames() int {
+	if m.Workflow == nil || !m.Workflow.NameLabels {
+		return 0
+	}
+	if m.Workflow.MaxNames < 1 {
+		return defaultMetricsWorkflowMaxNames
+	}
+	return m.Workflow.MaxNames
+}
+
+// GetAppMetricsLimits returns the maximum number of custom metrics of the app, and of combinations of label values of each of them.
+func (m MetricSpec) GetAppMetricsLimits() (maxMetrics int, maxSeries int) {
+	maxMetrics, maxSeries = defaultMetricsAppMaxMetrics, defaultMetricsAppMaxSeries
+	if m.App != nil {
+		if m.App.MaxMetrics > 0 {
+			maxMetrics = m.App.MaxMetrics
+		}
+		if m.App.MaxSeries > 0 {
+			maxSeries = m.App.MaxSeries
+		}
+	}
+	return maxMetrics, maxSeries
+}
+
+// GetSamplingFactor returns the sampling factor of the high-frequency measures, which is 1 if they are not sampled.
+func (m MetricSpec) GetSamplingFactor() int {
+	if m.SamplingFactor < 1 {
+		return 1
+	}
+	return m.SamplingFactor
+}
+
 // MetricsRu le defines configuration options for a metric.
 type MetricsRule struct {
 	Name   string        `json:"name,omitempty"   yaml:"name,omitempty"`
@@ -288,9 +877,33 @@ type AppOperation struct {
 
 // AccessControlSpec is the spec object in ConfigurationSpec.
 type AccessControlSpec struct {
-	DefaultAction string          `json:"defaultAction,omitempty" yaml:"defaultAction,omitempty"`
-	TrustDomain   string          `json:"trustDomain,omitempty"   yaml:"trustDomain,omitempty"`
-	AppPolicies   []AppPolicySpec `json:"policies,omitempty"      yaml:"policies,omitempty"`
+	DefaultAction    string               `json:"defaultAction,omitempty" yaml:"defaultAction,omitempty"`
+	TrustDomain      string               `json:"trustDomain,omitempty"   yaml:"trustDomain,omitempty"`
+	AppPolicies      []AppPolicySpec      `json:"policies,omitempty"      yaml:"policies,omitempty"`
+	WorkflowPolicies []WorkflowPolicySpec `json:"workflows,omitempty"     yaml:"workflows,omitempty"`
+	ActorPolicies    []ActorPolicySpec    `json:"actors,omitempty"        yaml:"actors,omitempty"`
+}
+
+// WorkflowPolicySpec restricts which apps may perform operations on workflows with a given name.
+// If no policy matches a workflow name and operation, the operation is allowed.
+// Policies are enforced by the sidecar of the app that hosts the workflows, with the app ID of the caller from its mTLS identity.
+type WorkflowPolicySpec struct {
+	// Name of the workflow, or "*" to match all workflows.
+	WorkflowName string `json:"name"                 yaml:"name"`
+	// Operations the policy applies to, such as "start", "terminate", or "purge". If empty, the policy applies to all operations.
+	Operations []string `json:"operations,omitempty" yaml:"operations,omitempty"`
+	// IDs of the apps that are allowed to perform the operations.
+	AllowedCallers []string `json:"allowedCallers"       yaml:"allowedCallers"`
+}
+
+// ActorPolicySpec restricts which apps may invoke actors of a given type.
+// If no policy matches an actor type, invocations are allowed.
+// Policies are enforced by the sidecar of the app that hosts the actors, with the app ID of the caller from its mTLS identity.
+type ActorPolicySpec struct {
+	// Type of the actor, or "*" to match all actor types.
+	ActorType string `json:"actorType"      yaml:"actorType"`
+	// IDs of the apps that are allowed to invoke the actors.
+	AllowedCallers []string `json:"allowedCallers" yaml:"allowedCallers"`
 }
 
 type NameResolutionSpec struct {
@@ -310,6 +923,21 @@ type MTLSSpec struct {
 	// When Dapr is running in Kubernetes mode, this is in addition to the built-in "kubernetes" validator.
 	// In self-hosted mode, enabling a custom validator will disable the built-in "insecure" validator.
 	TokenValidators []ValidatorSpec `json:"tokenValidators,omitempty" yaml:"tokenValidators,omitempty"`
+	// SPIFFE IDs of the app identities whose certificates are revoked.
+	// Sentry doesn't issue certificates for revoked identities, and sidecars reject mTLS connections from and to them.
+	RevokedIdentities []string `json:"revokedIdentities,omitempty" yaml:"revokedIdentities,omitempty"`
+	// Restrictions on the TLS parameters of mTLS connections, for example for FIPS compliance.
+	TLS *TLSSpec `json:"tls,omitempty" yaml:"tls,omitempty"`
+}
+
+// TLSSpec restricts the TLS parameters negotiated by servers and clients.
+type TLSSpec struct {
+	// Minimum TLS version: "1.2" (default) or "1.3".
+	MinVersion string `json:"minVersion,omitempty" yaml:"minVersion,omitempty"`
+	// IANA names of the cipher suites allowed for TLS 1.2 connections.
+	CipherSuites []string `json:"cipherSuites,omitempty" yaml:"cipherSuites,omitempty"`
+	// Elliptic curves used in ECDHE handshakes, in order of preference: "X25519", "P256", "P384", or "P521".
+	CurvePreferences []string `json:"curvePreferences,omitempty" yaml:"curvePreferences,omitempty"`
 }
 
 // ValidatorSpec contains additional token validators to use.
@@ -340,6 +968,35 @@ type FeatureSpec struct {
 type ComponentsSpec struct {
 	// Denylist of component types that cannot be instantiated
 	Deny []string `json:"deny,omitempty" yaml:"deny,omitempty"`
+	// Connectivity checks of the pubsub and binding components before the sidecar is marked as ready
+	Warmup *ComponentWarmupSpec `json:"warmup,omitempty" yaml:"warmup,omitempty"`
+}
+
+// ComponentWarmupSpec configures the connectivity checks of the pubsub and binding components that delay the readiness of the sidecar.
+// Components that don't pass the checks within the timeout are reported in the boot report, and the sidecar is marked as ready anyway.
+type ComponentWarmupSpec struct {
+	// Enables the connectivity checks.
+	Enabled bool `json:"enabled,omitempty" yaml:"enabled,omitempty"`
+	// Maximum time the readiness of the sidecar is delayed by the checks, as a Go duration.
+	// If omitted, the default value of 30s will be used.
+	Timeout string `json:"timeout,omitempty" yaml:"timeout,omitempty"`
+	// Names of the components that are not checked.
+	Skip []string `json:"skip,omitempty" yaml:"skip,omitempty"`
+}
+
+// GetTimeout returns the maximum time the readiness of the sidecar is delayed by the checks.
+func (w ComponentWarmupSpec) GetTimeout() (time.Duration, error) {
+	if w.Timeout == "" {
+		return defaultComponentWarmupTimeout, nil
+	}
+	timeout, err := time.ParseDuration(w.Timeout)
+	if err != nil {
+		return 0, fmt.Errorf("invalid component warmup timeout '%s': %w", w.Timeout, err)
+	}
+	if timeout <= 0 {
+		return 0, fmt.Errorf("invalid component warmup timeout '%s': must be positive", w.Timeout)
+	}
+	return timeout, nil
 }
 
 // WasmSpec describes the security profile for all Dapr Wasm components.
@@ -359,6 +1016,27 @@ func (w *WasmSpec) GetStrictSandbox() bool {
 type LoggingSpec struct {
 	// Configure API logging.
 	APILogging *APILoggingSpec `json:"apiLogging,omitempty" yaml:"apiLogging,omitempty"`
+	// Configure access logs.
+	AccessLog *AccessLogSpec `json:"accessLog,omitempty" yaml:"accessLog,omitempty"`
+	// OTLP endpoint the logs are exported to, in addition to the standard output.
+	// Log entries written within a traced request include its trace and span IDs.
+	Otel *OtelSpec `json:"otel,omitempty" yaml:"otel,omitempty"`
+}
+
+// AccessLogSpec defines the configuration for the access logs, which record all invocations of the Dapr APIs.
+// Access logs are written to the standard output regardless of the log level.
+type AccessLogSpec struct {
+	// Enables access logs. Default: false.
+	Enabled bool `json:"enabled,omitempty" yaml:"enabled,omitempty"`
+	// Format of the access logs: "json" or "common" (Common Log Format). Default: "json".
+	// This option has no effect if a template is set.
+	Format string `json:"format,omitempty" yaml:"format,omitempty"`
+	// Go template used to format each access log entry, overriding the format.
+	Template string `json:"template,omitempty" yaml:"template,omitempty"`
+	// Fraction of the requests that are logged, between 0 and 1. Default: "1".
+	SamplingRate string `json:"samplingRate,omitempty" yaml:"samplingRate,omitempty"`
+	// Requests that take longer than this duration are always logged, regardless of the sampling rate, and are marked as slow.
+	SlowThreshold string `json:"slowThreshold,omitempty" yaml:"slowThreshold,omitempty"`
 }
 
 // APILoggingSpec defines the configuration for API logging.
@@ -433,27 +1111,31 @@ func LoadStandaloneConfiguration(configs ...string) (*Configuration, error) {
 	return conf, nil
 }
 
-// LoadKubernetesConfiguration gets configuration from the Kubernetes operator with a given name.
-func LoadKubernetesConfiguration(config string, namespace string, podName string, operatorClient operatorv1pb.OperatorClient) (*Configuration, error) {
-	resp, err := operatorClient.GetConfiguration(context.Background(), &operatorv1pb.GetConfigurationRequest{
-		Name:      config,
-		Namespace: namespace,
-		PodName:   podName,
-	}, grpcRetry.WithMax(operatorMaxRetries), grpcRetry.WithPerRetryTimeout(operatorCallTimeout))
-	if err != nil {
-		return nil, err
-	}
-	b := resp.GetConfiguration()
-	if len(b) == 0 {
-		return nil, fmt.Errorf("configuration %s not found", config)
-	}
+// LoadKubernetesConfiguration gets configurations from the Kubernetes operator with the given names.
+// Like in standalone mode, the configurations are applied in order on top of the default config, so later ones override earlier ones.
+func LoadKubernetesConfiguration(configs []string, namespace string, podName string, operatorClient operatorv1pb.OperatorClient) (*Configuration, error) {
 	conf := LoadDefaultConfiguration()
-	err = json.Unmarshal(b, conf)
-	if err != nil {
-		return nil, err
+
+	for _, config := range configs {
+		resp, err := operatorClient.GetConfiguration(context.Background(), &operatorv1pb.GetConfigurationRequest{
+			Name:      config,
+			Namespace: namespace,
+			PodName:   podName,
+		}, grpcRetry.WithMax(operatorMaxRetries), grpcRetry.WithPerRetryTimeout(operatorCallTimeout))
+		if err != nil {
+			return nil, err
+		}
+		b := resp.GetConfiguration()
+		if len(b) == 0 {
+			return nil, fmt.Errorf("configuration %s not found", config)
+		}
+		err = json.Unmarshal(b, conf)
+		if err != nil {
+			return nil, err
+		}
 	}
 
-	err = conf.sortAndValidateSecretsConfiguration()
+	err := conf.sortAndValidateSecretsConfiguration()
 	if err != nil {
 		return nil, err
 	}
@@ -614,6 +1296,77 @@ func (c Configuration) GetAPILoggingSpec() APILoggingSpec {
 	return *c.Spec.LoggingSpec.APILogging
 }
 
+// GetAccessLogSpec returns the Logging.AccessLog spec.
+// It's a short-hand that includes nil-checks for safety.
+func (c *Configuration) GetAccessLogSpec() AccessLogSpec {
+	if c == nil || c.Spec.LoggingSpec == nil || c.Spec.LoggingSpec.AccessLog == nil {
+		return AccessLogSpec{}
+	}
+	return *c.Spec.LoggingSpec.AccessLog
+}
+
+// GetCORSSpec returns the CORS spec.
+// It's a short-hand that includes nil-checks for safety.
+func (c *Configuration) GetCORSSpec() CORSSpec {
+	if c == nil || c.Spec.CORSSpec == nil {
+		return CORSSpec{}
+	}
+	return *c.Spec.CORSSpec
+}
+
+// GetPublishDeduplicationSpec returns the PublishDeduplication spec.
+// It's a short-hand that includes nil-checks for safety.
+func (c *Configuration) GetPublishDeduplicationSpec() PublishDeduplicationSpec {
+	if c == nil || c.Spec.PublishDeduplication == nil {
+		return PublishDeduplicationSpec{}
+	}
+	return *c.Spec.PublishDeduplication
+}
+
+// GetIdempotentMethods returns the IdempotentMethods spec.
+// It's a short-hand that includes nil-checks for safety.
+func (c *Configuration) GetIdempotentMethods() []IdempotentMethodSpec {
+	if c == nil {
+		return nil
+	}
+	return c.Spec.IdempotentMethods
+}
+
+// GetTranscodingSpec returns the Transcoding spec, or nil if the transcoding is not configured.
+func (c *Configuration) GetTranscodingSpec() *TranscodingSpec {
+	if c == nil || c.Spec.Transcoding == nil || len(c.Spec.Transcoding.Methods) == 0 {
+		return nil
+	}
+	return c.Spec.Transcoding
+}
+
+// GetInvokeCompressionSpec returns the InvokeCompression spec.
+// It's a short-hand that includes nil-checks for safety.
+func (c *Configuration) GetInvokeCompressionSpec() *InvokeCompressionSpec {
+	if c == nil {
+		return nil
+	}
+	return c.Spec.InvokeCompression
+}
+
+// GetActorFailoverSpec returns the ActorFailover spec.
+// It's a short-hand that includes nil-checks for safety.
+func (c *Configuration) GetActorFailoverSpec() *ActorFailoverSpec {
+	if c == nil {
+		return nil
+	}
+	return c.Spec.ActorFailover
+}
+
+// GetComponentWarmupSpec returns the Components.Warmup spec.
+// It's a short-hand that includes nil-checks for safety.
+func (c *Configuration) GetComponentWarmupSpec() ComponentWarmupSpec {
+	if c == nil || c.Spec.ComponentsSpec == nil || c.Spec.ComponentsSpec.Warmup == nil {
+		return ComponentWarmupSpec{}
+	}
+	return *c.Spec.ComponentsSpec.Warmup
+}
+
 // GetWorkflowSpec returns the Workflow spec.
 // It's a short-hand that includes nil-checks for safety.
 func (c *Configuration) GetWorkflowSpec() WorkflowSpec {
@@ -654,6 +1407,22 @@ func (c *Configuration) sortMetricsSpec() {
 		if len(c.Spec.MetricsSpec.Rules) > 0 {
 			c.Spec.MetricSpec.Rules = c.Spec.MetricsSpec.Rules
 		}
+
+		if c.Spec.MetricsSpec.Labels != nil {
+			c.Spec.MetricSpec.Labels = c.Spec.MetricsSpec.Labels
+		}
+
+		if c.Spec.MetricsSpec.HTTP != nil {
+			c.Spec.MetricSpec.HTTP = c.Spec.MetricsSpec.HTTP
+		}
+
+		if c.Spec.MetricsSpec.LatencyBuckets != nil {
+			c.Spec.MetricSpec.LatencyBuckets = c.Spec.MetricsSpec.LatencyBuckets
+		}
+
+		if c.Spec.MetricsSpec.Workflow != nil {
+			c.Spec.MetricSpec.Workflow = c.Spec.MetricsSpec.Workflow
+		}
 	}
 }
 
@@ -15,16 +15,20 @@ package config
 
 import (
 	"bytes"
+	"context"
 	"os"
 	"reflect"
 	"sort"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	"golang.org/x/exp/maps"
+	"google.golang.org/grpc"
 
 	"github.com/dapr/dapr/pkg/buildinfo"
+	operatorv1pb "github.com/dapr/dapr/pkg/proto/operator/v1"
 	"github.com/dapr/kit/ptr"
 )
 
@@ -234,6 +238,57 @@ func TestLoadStandaloneConfiguration(t *testing.T) {
 	})
 }
 
+// fakeOperatorClient returns the configurations in the map, encoded as JSON like the operator does.
+type fakeOperatorClient struct {
+	operatorv1pb.OperatorClient
+	configurations map[string]string
+}
+
+func (c *fakeOperatorClient) GetConfiguration(ctx context.Context, in *operatorv1pb.GetConfigurationRequest, opts ...grpc.CallOption) (*operatorv1pb.GetConfigurationResponse, error) {
+	return &operatorv1pb.GetConfigurationResponse{Configuration: []byte(c.configurations[in.GetName()])}, nil
+}
+
+func TestLoadKubernetesConfiguration(t *testing.T) {
+	client := &fakeOperatorClient{
+		configurations: map[string]string{
+			"base": `{"kind":"Configuration","metadata":{"name":"base"},"spec":{
+				"tracing":{"samplingRate":"0.1"},
+				"mtls":{"enabled":true,"workloadCertTTL":"25s"},
+				"features":[{"name":"Actor.Reentrancy","enabled":true}]}}`,
+			"overlay": `{"kind":"Configuration","metadata":{"name":"overlay"},"spec":{
+				"tracing":{"samplingRate":"1"},
+				"mtls":{"enabled":false}}}`,
+		},
+	}
+
+	t.Run("single configuration", func(t *testing.T) {
+		config, err := LoadKubernetesConfiguration([]string{"base"}, "default", "pod", client)
+		require.NoError(t, err)
+		assert.Equal(t, "0.1", config.GetTracingSpec().SamplingRate)
+		assert.True(t, config.GetMTLSSpec().Enabled)
+	})
+
+	t.Run("multiple configurations are merged in order", func(t *testing.T) {
+		config, err := LoadKubernetesConfiguration([]string{"base", "overlay"}, "default", "pod", client)
+		require.NoError(t, err)
+
+		// Overridden by overlay
+		assert.Equal(t, "1", config.GetTracingSpec().SamplingRate)
+		assert.False(t, config.GetMTLSSpec().Enabled)
+
+		// From base
+		assert.Equal(t, "25s", config.GetMTLSSpec().WorkloadCertTTL)
+		config.LoadFeatures()
+		assert.True(t, config.IsFeatureEnabled("Actor.Reentrancy"))
+		assert.Equal(t, "overlay", config.ObjectMeta.Name)
+	})
+
+	t.Run("configuration not found", func(t *testing.T) {
+		_, err := LoadKubernetesConfiguration([]string{"base", "notfound"}, "default", "pod", client)
+		require.EqualError(t, err, "configuration notfound not found")
+	})
+}
+
 func compareWithFile(t *testing.T, file string, expect string) {
 	f, err := os.ReadFile(file)
 	require.NoError(t, err)
@@ -589,3 +644,273 @@ func TestSortMetrics(t *testing.T) {
 		assert.Equal(t, "rule", config.Spec.MetricSpec.Rules[0].Name)
 	})
 }
+
+func TestDNSCacheSpecGetTTL(t *testing.T) {
+	testCases := []struct {
+		name      string
+		spec      *DNSCacheSpec
+		expected  time.Duration
+		expectErr bool
+	}{
+		{name: "nil", spec: nil, expected: 0},
+		{name: "disabled", spec: &DNSCacheSpec{TTL: "10s"}, expected: 0},
+		{name: "default TTL", spec: &DNSCacheSpec{Enabled: true}, expected: 30 * time.Second},
+		{name: "custom TTL", spec: &DNSCacheSpec{Enabled: true, TTL: "5s"}, expected: 5 * time.Second},
+		{name: "invalid TTL", spec: &DNSCacheSpec{Enabled: true, TTL: "foo"}, expectErr: true},
+		{name: "negative TTL", spec: &DNSCacheSpec{Enabled: true, TTL: "-1s"}, expectErr: true},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ttl, err := tc.spec.GetTTL()
+			if tc.expectErr {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tc.expected, ttl)
+		})
+	}
+}
+
+func TestRateLimiterSpecGetWindow(t *testing.T) {
+	testCases := []struct {
+		name      string
+		spec      RateLimiterSpec
+		expected  time.Duration
+		expectErr bool
+	}{
+		{name: "default window", spec: RateLimiterSpec{}, expected: time.Second},
+		{name: "custom window", spec: RateLimiterSpec{Window: "1m"}, expected: time.Minute},
+		{name: "invalid window", spec: RateLimiterSpec{Window: "foo"}, expectErr: true},
+		{name: "window too short", spec: RateLimiterSpec{Window: "100us"}, expectErr: true},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			window, err := tc.spec.GetWindow()
+			if tc.expectErr {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tc.expected, window)
+		})
+	}
+}
+
+func TestLeaderElectionSpecGetLeaseDuration(t *testing.T) {
+	testCases := []struct {
+		name      string
+		spec      LeaderElectionSpec
+		expected  time.Duration
+		expectErr bool
+	}{
+		{name: "default lease duration", spec: LeaderElectionSpec{}, expected: 15 * time.Second},
+		{name: "custom lease duration", spec: LeaderElectionSpec{LeaseDuration: "1m"}, expected: time.Minute},
+		{name: "invalid lease duration", spec: LeaderElectionSpec{LeaseDuration: "foo"}, expectErr: true},
+		{name: "lease duration too short", spec: LeaderElectionSpec{LeaseDuration: "500ms"}, expectErr: true},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			d, err := tc.spec.GetLeaseDuration()
+			if tc.expectErr {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tc.expected, d)
+		})
+	}
+}
+
+func TestWorkflowSpecGetShutdownTimeout(t *testing.T) {
+	testCases := []struct {
+		name      string
+		spec      *WorkflowSpec
+		expected  time.Duration
+		expectErr bool
+	}{
+		{name: "nil", spec: nil, expected: 5 * time.Second},
+		{name: "default timeout", spec: &WorkflowSpec{}, expected: 5 * time.Second},
+		{name: "custom timeout", spec: &WorkflowSpec{ShutdownTimeout: "30s"}, expected: 30 * time.Second},
+		{name: "no wait", spec: &WorkflowSpec{ShutdownTimeout: "0s"}, expected: 0},
+		{name: "invalid timeout", spec: &WorkflowSpec{ShutdownTimeout: "foo"}, expectErr: true},
+		{name: "negative timeout", spec: &WorkflowSpec{ShutdownTimeout: "-1s"}, expectErr: true},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			timeout, err := tc.spec.GetShutdownTimeout()
+			if tc.expectErr {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tc.expected, timeout)
+		})
+	}
+}
+
+func TestWorkflowSpecGetEventBufferTTL(t *testing.T) {
+	testCases := []struct {
+		name      string
+		spec      *WorkflowSpec
+		expected  time.Duration
+		expectErr bool
+	}{
+		{name: "nil", spec: nil, expected: 0},
+		{name: "not buffered", spec: &WorkflowSpec{}, expected: 0},
+		{name: "custom TTL", spec: &WorkflowSpec{EventBufferTTL: "10m"}, expected: 10 * time.Minute},
+		{name: "invalid TTL", spec: &WorkflowSpec{EventBufferTTL: "foo"}, expectErr: true},
+		{name: "negative TTL", spec: &WorkflowSpec{EventBufferTTL: "-1s"}, expectErr: true},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ttl, err := tc.spec.GetEventBufferTTL()
+			if tc.expectErr {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tc.expected, ttl)
+		})
+	}
+}
+
+func TestWorkflowSpecGetRemoteWorkflows(t *testing.T) {
+	t.Run("no remote workflows", func(t *testing.T) {
+		apps, err := (&WorkflowSpec{}).GetRemoteWorkflows()
+		require.NoError(t, err)
+		assert.Nil(t, apps)
+	})
+
+	t.Run("remote workflows", func(t *testing.T) {
+		apps, err := (&WorkflowSpec{RemoteWorkflows: []RemoteWorkflowSpec{
+			{Name: "a", AppID: "app1"},
+			{Name: "b", AppID: "app2"},
+		}}).GetRemoteWorkflows()
+		require.NoError(t, err)
+		assert.Equal(t, map[string]string{"a": "app1", "b": "app2"}, apps)
+	})
+
+	t.Run("missing app ID", func(t *testing.T) {
+		_, err := (&WorkflowSpec{RemoteWorkflows: []RemoteWorkflowSpec{{Name: "a"}}}).GetRemoteWorkflows()
+		require.Error(t, err)
+	})
+
+	t.Run("duplicate name", func(t *testing.T) {
+		_, err := (&WorkflowSpec{RemoteWorkflows: []RemoteWorkflowSpec{
+			{Name: "a", AppID: "app1"},
+			{Name: "a", AppID: "app2"},
+		}}).GetRemoteWorkflows()
+		require.Error(t, err)
+	})
+}
+
+func TestWorkflowSpecUseScheduler(t *testing.T) {
+	testCases := []struct {
+		name      string
+		spec      *WorkflowSpec
+		expected  bool
+		expectErr bool
+	}{
+		{name: "nil", spec: nil},
+		{name: "default", spec: &WorkflowSpec{}},
+		{name: "actors", spec: &WorkflowSpec{RemindersBackend: "actors"}},
+		{name: "scheduler", spec: &WorkflowSpec{RemindersBackend: "scheduler"}, expected: true},
+		{name: "invalid", spec: &WorkflowSpec{RemindersBackend: "foo"}, expectErr: true},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			useScheduler, err := tc.spec.UseScheduler()
+			if tc.expectErr {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tc.expected, useScheduler)
+		})
+	}
+}
+
+func TestWorkflowSpecGetRetention(t *testing.T) {
+	testCases := []struct {
+		name                 string
+		spec                 *WorkflowSpec
+		expectedMaxAge       time.Duration
+		expectedMaxCompleted int
+		expectErr            bool
+	}{
+		{name: "nil", spec: nil},
+		{name: "no retention", spec: &WorkflowSpec{}},
+		{name: "max age", spec: &WorkflowSpec{Retention: &WorkflowRetentionSpec{MaxAge: "72h"}}, expectedMaxAge: 72 * time.Hour},
+		{name: "max completed instances", spec: &WorkflowSpec{Retention: &WorkflowRetentionSpec{MaxCompletedInstances: 1000}}, expectedMaxCompleted: 1000},
+		{name: "both limits", spec: &WorkflowSpec{Retention: &WorkflowRetentionSpec{MaxAge: "1h", MaxCompletedInstances: 10}}, expectedMaxAge: time.Hour, expectedMaxCompleted: 10},
+		{name: "invalid max age", spec: &WorkflowSpec{Retention: &WorkflowRetentionSpec{MaxAge: "foo"}}, expectErr: true},
+		{name: "zero max age", spec: &WorkflowSpec{Retention: &WorkflowRetentionSpec{MaxAge: "0s"}}, expectErr: true},
+		{name: "negative max completed instances", spec: &WorkflowSpec{Retention: &WorkflowRetentionSpec{MaxCompletedInstances: -1}}, expectErr: true},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			maxAge, maxCompleted, err := tc.spec.GetRetention()
+			if tc.expectErr {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tc.expectedMaxAge, maxAge)
+			assert.Equal(t, tc.expectedMaxCompleted, maxCompleted)
+		})
+	}
+}
+
+func TestActorFailoverSpecGetMaxAttempts(t *testing.T) {
+	testCases := []struct {
+		name     string
+		spec     *ActorFailoverSpec
+		expected int
+	}{
+		{name: "nil spec", spec: nil, expected: 0},
+		{name: "disabled", spec: &ActorFailoverSpec{MaxAttempts: 5}, expected: 0},
+		{name: "default attempts", spec: &ActorFailoverSpec{Enabled: true}, expected: 2},
+		{name: "custom attempts", spec: &ActorFailoverSpec{Enabled: true, MaxAttempts: 5}, expected: 5},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.expected, tc.spec.GetMaxAttempts())
+		})
+	}
+}
+
+func TestMetricSpecGetSamplingFactor(t *testing.T) {
+	assert.Equal(t, 1, MetricSpec{}.GetSamplingFactor())
+	assert.Equal(t, 1, MetricSpec{SamplingFactor: -5}.GetSamplingFactor())
+	assert.Equal(t, 100, MetricSpec{SamplingFactor: 100}.GetSamplingFactor())
+}
+
+func TestMetricSpecGetWorkflowMaxNames(t *testing.T) {
+	assert.Equal(t, 0, MetricSpec{}.GetWorkflowMaxNames())
+	assert.Equal(t, 0, MetricSpec{Workflow: &MetricWorkflowSpec{MaxNames: 5}}.GetWorkflowMaxNames())
+	assert.Equal(t, 20, MetricSpec{Workflow: &MetricWorkflowSpec{NameLabels: true}}.GetWorkflowMaxNames())
+	assert.Equal(t, 5, MetricSpec{Workflow: &MetricWorkflowSpec{NameLabels: true, MaxNames: 5}}.GetWorkflowMaxNames())
+}
+
+func TestIsIdempotentMethod(t *testing.T) {
+	specs := []IdempotentMethodSpec{
+		{AppID: "orders", Methods: []string{"list", "orders/*"}},
+		{Methods: []string{"healthz"}},
+	}
+
+	assert.True(t, IsIdempotentMethod(specs, "orders", "list"))
+	assert.True(t, IsIdempotentMethod(specs, "orders", "orders/123"))
+	assert.True(t, IsIdempotentMethod(specs, "payments", "healthz"))
+	assert.False(t, IsIdempotentMethod(specs, "payments", "list"))
+	assert.False(t, IsIdempotentMethod(specs, "orders", "checkout"))
+	assert.False(t, IsIdempotentMethod(nil, "orders", "list"))
+}
@@ -0,0 +1,99 @@
+/*
+Copyright 2023 The Dapr Authors
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cors
+
+import (
+	"net/http"
+	"sort"
+	"strings"
+
+	chicors "github.com/go-chi/cors"
+
+	"github.com/dapr/dapr/pkg/config"
+)
+
+// routePolicy is a CORS policy that applies to the routes starting with a path prefix.
+type routePolicy struct {
+	pathPrefix string
+	cors       *chicors.Cors
+}
+
+// Middleware returns the HTTP middleware that enforces the CORS policies in the spec.
+// allowedOrigins is the comma-separated list of origins allowed by policies that don't set any, as set with the "--allowed-origins" flag.
+// If there's no CORS policy to enforce, the returned value is nil.
+func Middleware(spec config.CORSSpec, allowedOrigins string) func(next http.Handler) http.Handler {
+	// When the spec is not set, keep the behavior of the "--allowed-origins" flag, which doesn't enforce a policy with the default value
+	if len(spec.Routes) == 0 && isEmptyPolicy(spec.CORSPolicySpec) && allowedOrigins == DefaultAllowedOrigins {
+		return nil
+	}
+
+	defaultOrigins := strings.Split(allowedOrigins, ",")
+	defaultPolicy := newCors(spec.CORSPolicySpec, defaultOrigins)
+
+	routes := make([]routePolicy, len(spec.Routes))
+	for i, r := range spec.Routes {
+		routes[i] = routePolicy{
+			pathPrefix: r.PathPrefix,
+			cors:       newCors(r.CORSPolicySpec, defaultOrigins),
+		}
+	}
+	// Sort by length of the prefix, so the longest match is found first
+	sort.SliceStable(routes, func(i, j int) bool {
+		return len(routes[i].pathPrefix) > len(routes[j].pathPrefix)
+	})
+
+	return func(next http.Handler) http.Handler {
+		defaultHandler := defaultPolicy.Handler(next)
+		routeHandlers := make([]http.Handler, len(routes))
+		for i := range routes {
+			routeHandlers[i] = routes[i].cors.Handler(next)
+		}
+
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			for i := range routes {
+				if strings.HasPrefix(r.URL.Path, routes[i].pathPrefix) {
+					routeHandlers[i].ServeHTTP(w, r)
+					return
+				}
+			}
+			defaultHandler.ServeHTTP(w, r)
+		})
+	}
+}
+
+func newCors(policy config.CORSPolicySpec, defaultOrigins []string) *chicors.Cors {
+	origins := policy.AllowedOrigins
+	if len(origins) == 0 {
+		origins = defaultOrigins
+	}
+
+	return chicors.New(chicors.Options{
+		AllowedOrigins:   origins,
+		AllowedMethods:   policy.AllowedMethods,
+		AllowedHeaders:   policy.AllowedHeaders,
+		ExposedHeaders:   policy.ExposedHeaders,
+		AllowCredentials: policy.AllowCredentials,
+		MaxAge:           policy.MaxAge,
+		Debug:            false,
+	})
+}
+
+func isEmptyPolicy(policy config.CORSPolicySpec) bool {
+	return len(policy.AllowedOrigins) == 0 &&
+		len(policy.AllowedMethods) == 0 &&
+		len(policy.AllowedHeaders) == 0 &&
+		len(policy.ExposedHeaders) == 0 &&
+		!policy.AllowCredentials &&
+		policy.MaxAge == 0
+}
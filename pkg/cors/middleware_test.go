@@ -0,0 +1,116 @@
+/*
+Copyright 2023 The Dapr Authors
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cors
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/dapr/dapr/pkg/config"
+)
+
+func TestMiddleware(t *testing.T) {
+	hf := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	})
+
+	preflight := func(h http.Handler, path, origin string) http.Header {
+		w := httptest.NewRecorder()
+		r := httptest.NewRequest(http.MethodOptions, path, nil)
+		r.Header.Set("Origin", origin)
+		r.Header.Set("Access-Control-Request-Method", http.MethodPut)
+		h.ServeHTTP(w, r)
+		return w.Header()
+	}
+
+	t.Run("no spec and default origins", func(t *testing.T) {
+		assert.Nil(t, Middleware(config.CORSSpec{}, DefaultAllowedOrigins))
+	})
+
+	t.Run("no spec and custom origins", func(t *testing.T) {
+		mw := Middleware(config.CORSSpec{}, "http://test.com")
+		require.NotNil(t, mw)
+
+		h := mw(hf)
+		w := httptest.NewRecorder()
+		r := httptest.NewRequest(http.MethodGet, "/v1.0/state/mystore", nil)
+		r.Header.Set("Origin", "http://test.com")
+		h.ServeHTTP(w, r)
+		assert.Equal(t, "http://test.com", w.Header().Get("Access-Control-Allow-Origin"))
+	})
+
+	t.Run("full policy", func(t *testing.T) {
+		mw := Middleware(config.CORSSpec{
+			CORSPolicySpec: config.CORSPolicySpec{
+				AllowedOrigins:   []string{"http://test.com"},
+				AllowedMethods:   []string{http.MethodGet, http.MethodPut},
+				AllowedHeaders:   []string{"X-Custom"},
+				AllowCredentials: true,
+				MaxAge:           300,
+			},
+		}, DefaultAllowedOrigins)
+		require.NotNil(t, mw)
+
+		header := preflight(mw(hf), "/v1.0/state/mystore", "http://test.com")
+		assert.Equal(t, "http://test.com", header.Get("Access-Control-Allow-Origin"))
+		assert.Equal(t, http.MethodPut, header.Get("Access-Control-Allow-Methods"))
+		assert.Equal(t, "true", header.Get("Access-Control-Allow-Credentials"))
+		assert.Equal(t, "300", header.Get("Access-Control-Max-Age"))
+
+		header = preflight(mw(hf), "/v1.0/state/mystore", "http://other.com")
+		assert.Empty(t, header.Get("Access-Control-Allow-Origin"))
+	})
+
+	t.Run("route overrides", func(t *testing.T) {
+		mw := Middleware(config.CORSSpec{
+			CORSPolicySpec: config.CORSPolicySpec{
+				AllowedMethods: []string{http.MethodPut},
+			},
+			Routes: []config.CORSRouteSpec{
+				{
+					PathPrefix: "/v1.0/invoke",
+					CORSPolicySpec: config.CORSPolicySpec{
+						AllowedOrigins: []string{"http://invoke.com"},
+						AllowedMethods: []string{http.MethodPut},
+					},
+				},
+				{
+					PathPrefix: "/v1.0/invoke/myapp",
+					CORSPolicySpec: config.CORSPolicySpec{
+						AllowedOrigins: []string{"http://myapp.com"},
+						AllowedMethods: []string{http.MethodPut},
+					},
+				},
+			},
+		}, "http://test.com")
+		require.NotNil(t, mw)
+		h := mw(hf)
+
+		// Default policy uses the origins from the flag
+		assert.Equal(t, "http://test.com", preflight(h, "/v1.0/state/mystore", "http://test.com").Get("Access-Control-Allow-Origin"))
+		assert.Empty(t, preflight(h, "/v1.0/state/mystore", "http://invoke.com").Get("Access-Control-Allow-Origin"))
+
+		// Route policies replace the default one
+		assert.Equal(t, "http://invoke.com", preflight(h, "/v1.0/invoke/otherapp/method/foo", "http://invoke.com").Get("Access-Control-Allow-Origin"))
+		assert.Empty(t, preflight(h, "/v1.0/invoke/otherapp/method/foo", "http://test.com").Get("Access-Control-Allow-Origin"))
+
+		// The longest prefix wins
+		assert.Equal(t, "http://myapp.com", preflight(h, "/v1.0/invoke/myapp/method/foo", "http://myapp.com").Get("Access-Control-Allow-Origin"))
+		assert.Empty(t, preflight(h, "/v1.0/invoke/myapp/method/foo", "http://invoke.com").Get("Access-Control-Allow-Origin"))
+	})
+}
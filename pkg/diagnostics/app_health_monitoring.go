@@ -0,0 +1,105 @@
+/*
+Copyright 2023 The Dapr Authors
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package diagnostics
+
+import (
+	"context"
+	"strconv"
+	"sync/atomic"
+	"time"
+
+	"go.opencensus.io/stats"
+	"go.opencensus.io/stats/view"
+	"go.opencensus.io/tag"
+
+	diagUtils "github.com/dapr/dapr/pkg/diagnostics/utils"
+)
+
+// Values of the status tag of the app health status changes.
+const (
+	AppHealthStatusHealthy   = "healthy"
+	AppHealthStatusUnhealthy = "unhealthy"
+)
+
+// appHealthMetrics holds the metrics of the health checks of the app.
+type appHealthMetrics struct {
+	probeLatency        *stats.Float64Measure
+	consecutiveFailures *stats.Int64Measure
+	statusChanges       *stats.Int64Measure
+
+	appID   string
+	enabled atomic.Bool
+}
+
+func newAppHealthMetrics() *appHealthMetrics {
+	return &appHealthMetrics{
+		probeLatency: stats.Float64(
+			"runtime/app_health/probe_latency",
+			"The latency of the health probes of the app.",
+			stats.UnitMilliseconds),
+		consecutiveFailures: stats.Int64(
+			"runtime/app_health/consecutive_failures",
+			"The number of consecutive failed health checks of the app.",
+			stats.UnitDimensionless),
+		statusChanges: stats.Int64(
+			"runtime/app_health/status_changes_total",
+			"The number of times the app became healthy or unhealthy.",
+			stats.UnitDimensionless),
+	}
+}
+
+// Init registers the app health metrics views.
+func (m *appHealthMetrics) Init(appID string) error {
+	m.appID = appID
+	m.enabled.Store(true)
+
+	return registerViews(
+		diagUtils.NewMeasureView(m.probeLatency, []tag.Key{appIDKey, successKey}, defaultLatencyDistribution),
+		diagUtils.NewMeasureView(m.consecutiveFailures, []tag.Key{appIDKey}, view.LastValue()),
+		diagUtils.NewMeasureView(m.statusChanges, []tag.Key{appIDKey, statusKey}, view.Count()),
+	)
+}
+
+// ProbeCompleted records the latency of a health probe of the app.
+func (m *appHealthMetrics) ProbeCompleted(ctx context.Context, successful bool, elapsed time.Duration) {
+	if !m.enabled.Load() {
+		return
+	}
+
+	recordWithExemplar(ctx,
+		diagUtils.WithTags(m.probeLatency.Name(), appIDKey, m.appID, successKey, strconv.FormatBool(successful)),
+		m.probeLatency.M(float64(elapsed)/float64(time.Millisecond)))
+}
+
+// ConsecutiveFailures records the number of consecutive failed health checks of the app, which is 0 once a check succeeds.
+func (m *appHealthMetrics) ConsecutiveFailures(ctx context.Context, failures int32) {
+	if !m.enabled.Load() {
+		return
+	}
+
+	stats.RecordWithTags(ctx,
+		diagUtils.WithTags(m.consecutiveFailures.Name(), appIDKey, m.appID),
+		m.consecutiveFailures.M(int64(failures)))
+}
+
+// StatusChanged records a change of the health status of the app.
+func (m *appHealthMetrics) StatusChanged(ctx context.Context, status string) {
+	if !m.enabled.Load() {
+		return
+	}
+
+	stats.RecordWithTags(ctx,
+		diagUtils.WithTags(m.statusChanges.Name(), appIDKey, m.appID, statusKey, status),
+		m.statusChanges.M(1))
+}
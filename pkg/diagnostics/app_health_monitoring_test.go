@@ -0,0 +1,91 @@
+/*
+Copyright 2023 The Dapr Authors
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package diagnostics
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opencensus.io/stats/view"
+)
+
+func TestAppHealthMetrics(t *testing.T) {
+	m := newAppHealthMetrics()
+	require.NoError(t, m.Init("test"))
+	t.Cleanup(func() {
+		view.Unregister(
+			view.Find("runtime/app_health/probe_latency"),
+			view.Find("runtime/app_health/consecutive_failures"),
+			view.Find("runtime/app_health/status_changes_total"),
+		)
+	})
+
+	ctx := context.Background()
+
+	t.Run("probe latency", func(t *testing.T) {
+		m.ProbeCompleted(ctx, true, 5*time.Millisecond)
+		m.ProbeCompleted(ctx, false, 15*time.Millisecond)
+
+		viewData, err := view.RetrieveData("runtime/app_health/probe_latency")
+		require.NoError(t, err)
+		require.Len(t, viewData, 2)
+		means := map[string]float64{}
+		for _, row := range viewData {
+			allTagsPresent(t, view.Find("runtime/app_health/probe_latency"), row.Tags)
+			for _, tag := range row.Tags {
+				if tag.Key == successKey {
+					means[tag.Value] = row.Data.(*view.DistributionData).Mean
+				}
+			}
+		}
+		assert.Equal(t, map[string]float64{"true": 5, "false": 15}, means)
+	})
+
+	t.Run("consecutive failures", func(t *testing.T) {
+		m.ConsecutiveFailures(ctx, 3)
+
+		viewData, err := view.RetrieveData("runtime/app_health/consecutive_failures")
+		require.NoError(t, err)
+		require.Len(t, viewData, 1)
+		assert.InDelta(t, float64(3), viewData[0].Data.(*view.LastValueData).Value, 0)
+
+		m.ConsecutiveFailures(ctx, 0)
+
+		viewData, err = view.RetrieveData("runtime/app_health/consecutive_failures")
+		require.NoError(t, err)
+		require.Len(t, viewData, 1)
+		assert.InDelta(t, float64(0), viewData[0].Data.(*view.LastValueData).Value, 0)
+	})
+
+	t.Run("status changes", func(t *testing.T) {
+		m.StatusChanged(ctx, AppHealthStatusHealthy)
+		m.StatusChanged(ctx, AppHealthStatusUnhealthy)
+		m.StatusChanged(ctx, AppHealthStatusHealthy)
+
+		viewData, err := view.RetrieveData("runtime/app_health/status_changes_total")
+		require.NoError(t, err)
+		counts := map[string]int64{}
+		for _, row := range viewData {
+			for _, tag := range row.Tags {
+				if tag.Key == statusKey {
+					counts[tag.Value] = row.Data.(*view.CountData).Value
+				}
+			}
+		}
+		assert.Equal(t, map[string]int64{AppHealthStatusHealthy: 2, AppHealthStatusUnhealthy: 1}, counts)
+	})
+}
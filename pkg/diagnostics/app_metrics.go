@@ -0,0 +1,330 @@
+/*
+Copyright 2023 The Dapr Authors
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package diagnostics
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"regexp"
+	"sort"
+	"strings"
+	"sync"
+	"sync/atomic"
+
+	"go.opencensus.io/stats"
+	"go.opencensus.io/stats/view"
+	"go.opencensus.io/tag"
+
+	diagUtils "github.com/dapr/dapr/pkg/diagnostics/utils"
+)
+
+const (
+	// appMetricsPrefix is the prefix of the views of the custom metrics, so they don't collide with the metrics of the sidecar.
+	appMetricsPrefix = "app/"
+
+	appMetricsMaxNameLength       = 100
+	appMetricsMaxLabels           = 10
+	appMetricsMaxLabelValueLength = 256
+	appMetricsMaxBuckets          = 50
+)
+
+// AppMetricType is the type of a custom metric of the app.
+type AppMetricType int
+
+const (
+	// AppMetricCounter is the sum of the measurements, which must not be negative.
+	AppMetricCounter AppMetricType = iota
+	// AppMetricHistogram is the distribution of the measurements in buckets.
+	AppMetricHistogram
+)
+
+func (t AppMetricType) String() string {
+	switch t {
+	case AppMetricCounter:
+		return "counter"
+	case AppMetricHistogram:
+		return "histogram"
+	default:
+		return fmt.Sprintf("AppMetricType(%d)", int(t))
+	}
+}
+
+var (
+	// ErrAppMetricsLimit is returned when recording a measurement would exceed the limits of the custom metrics.
+	ErrAppMetricsLimit = errors.New("limit of the custom metrics exceeded")
+
+	appMetricNameRegex = regexp.MustCompile(`^[a-zA-Z_][a-zA-Z0-9_]*$`)
+
+	// Default bucket boundaries of the custom histograms, which have no unit.
+	defaultAppHistogramDistribution = view.Distribution(1, 2, 5, 10, 25, 50, 100, 250, 500, 1000, 2500, 5000, 10000)
+)
+
+// AppMeasurement is a measurement of a custom metric of the app.
+type AppMeasurement struct {
+	Name   string
+	Type   AppMetricType
+	Value  float64
+	Labels map[string]string
+	// Description and Buckets are only used when the metric is recorded for the first time.
+	Description string
+	Buckets     []float64
+}
+
+// AppMetricsRecorder records the custom metrics of the app.
+// It is implemented by the custom metrics, and allows injecting a recorder in the API layer that serves both the HTTP and gRPC frontends.
+type AppMetricsRecorder interface {
+	IsEnabled() bool
+	Record(ctx context.Context, measurements []AppMeasurement) error
+}
+
+// appMetric is a custom metric registered by the app.
+type appMetric struct {
+	typ       AppMetricType
+	measure   *stats.Float64Measure
+	labelKeys []tag.Key
+	// series contains the combinations of label values recorded so far.
+	series map[string]struct{}
+}
+
+// appMetrics holds the custom metrics recorded by the app through the metrics API.
+// The metrics are registered on their first measurement, with the type and label names of that measurement.
+type appMetrics struct {
+	appID      string
+	namespace  string
+	enabled    atomic.Bool
+	maxMetrics int
+	maxSeries  int
+
+	lock    sync.Mutex
+	metrics map[string]*appMetric
+}
+
+func newAppMetrics() *appMetrics {
+	return &appMetrics{
+		metrics: map[string]*appMetric{},
+	}
+}
+
+// IsEnabled returns true if the custom metrics are enabled.
+func (a *appMetrics) IsEnabled() bool {
+	return a != nil && a.enabled.Load()
+}
+
+// Init enables the custom metrics, with up to maxMetrics metrics and maxSeries combinations of label values per metric.
+func (a *appMetrics) Init(appID, namespace string, maxMetrics, maxSeries int) {
+	a.lock.Lock()
+	defer a.lock.Unlock()
+
+	a.appID = appID
+	a.namespace = namespace
+	a.maxMetrics = maxMetrics
+	a.maxSeries = maxSeries
+	a.enabled.Store(true)
+	// The views of the metrics recorded before are unregistered when the metrics are shut down
+	a.metrics = map[string]*appMetric{}
+}
+
+// disable disables the custom metrics and forgets them, when the metrics are shut down.
+func (a *appMetrics) disable() {
+	a.lock.Lock()
+	defer a.lock.Unlock()
+
+	a.enabled.Store(false)
+	a.metrics = map[string]*appMetric{}
+}
+
+// Record validates and records the measurements.
+// All measurements are validated before any is recorded, but a measurement that exceeds the limits stops the recording of the following ones.
+func (a *appMetrics) Record(ctx context.Context, measurements []AppMeasurement) error {
+	for i := range measurements {
+		if err := measurements[i].validate(); err != nil {
+			return err
+		}
+	}
+
+	for i := range measurements {
+		if err := a.record(ctx, &measurements[i]); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (a *appMetrics) record(ctx context.Context, m *AppMeasurement) error {
+	metric, err := a.getOrRegister(m)
+	if err != nil {
+		return err
+	}
+
+	tags := make([]any, 0, 2*(len(metric.labelKeys)+2))
+	tags = append(tags, appIDKey, a.appID, namespaceKey, a.namespace)
+	for _, k := range metric.labelKeys {
+		tags = append(tags, k, m.Labels[k.Name()])
+	}
+	return stats.RecordWithTags(
+		ctx,
+		diagUtils.WithTags(metric.measure.Name(), tags...),
+		metric.measure.M(m.Value),
+	)
+}
+
+// getOrRegister returns the metric of the measurement, registering it if it's recorded for the first time.
+func (a *appMetrics) getOrRegister(m *AppMeasurement) (*appMetric, error) {
+	a.lock.Lock()
+	defer a.lock.Unlock()
+
+	metric, ok := a.metrics[m.Name]
+	if !ok {
+		if len(a.metrics) >= a.maxMetrics {
+			return nil, fmt.Errorf("%w: cannot record more than %d metrics", ErrAppMetricsLimit, a.maxMetrics)
+		}
+
+		var err error
+		metric, err = newAppMetric(m)
+		if err != nil {
+			return nil, err
+		}
+		a.metrics[m.Name] = metric
+	} else if err := metric.accepts(m); err != nil {
+		return nil, err
+	}
+
+	values := make([]string, len(metric.labelKeys))
+	for i, k := range metric.labelKeys {
+		values[i] = m.Labels[k.Name()]
+	}
+	series := strings.Join(values, "\x00")
+	if _, ok := metric.series[series]; !ok {
+		if len(metric.series) >= a.maxSeries {
+			return nil, fmt.Errorf("%w: cannot record more than %d combinations of label values for metric '%s'", ErrAppMetricsLimit, a.maxSeries, m.Name)
+		}
+		metric.series[series] = struct{}{}
+	}
+
+	return metric, nil
+}
+
+func newAppMetric(m *AppMeasurement) (*appMetric, error) {
+	names := make([]string, 0, len(m.Labels))
+	for k := range m.Labels {
+		names = append(names, k)
+	}
+	sort.Strings(names)
+
+	labelKeys := make([]tag.Key, len(names))
+	for i, name := range names {
+		k, err := tag.NewKey(name)
+		if err != nil {
+			return nil, fmt.Errorf("invalid label '%s' for metric '%s': %w", name, m.Name, err)
+		}
+		labelKeys[i] = k
+	}
+
+	aggregation := view.Sum()
+	if m.Type == AppMetricHistogram {
+		aggregation = defaultAppHistogramDistribution
+		if len(m.Buckets) > 0 {
+			aggregation = view.Distribution(m.Buckets...)
+		}
+	}
+
+	metric := &appMetric{
+		typ:       m.Type,
+		measure:   stats.Float64(appMetricsPrefix+m.Name, m.Description, stats.UnitDimensionless),
+		labelKeys: labelKeys,
+		series:    map[string]struct{}{},
+	}
+	err := registerViews(diagUtils.NewMeasureView(metric.measure, append([]tag.Key{appIDKey, namespaceKey}, labelKeys...), aggregation))
+	if err != nil {
+		return nil, fmt.Errorf("failed to register metric '%s': %w", m.Name, err)
+	}
+	return metric, nil
+}
+
+// accepts returns an error if the measurement doesn't have the type and labels the metric was registered with.
+func (metric *appMetric) accepts(m *AppMeasurement) error {
+	if m.Type != metric.typ {
+		return fmt.Errorf("metric '%s' is a %s, not a %s", m.Name, metric.typ, m.Type)
+	}
+	if len(m.Labels) != len(metric.labelKeys) {
+		return fmt.Errorf("metric '%s' must have exactly the labels %s", m.Name, metric.labelNames())
+	}
+	for _, k := range metric.labelKeys {
+		if _, ok := m.Labels[k.Name()]; !ok {
+			return fmt.Errorf("metric '%s' must have exactly the labels %s", m.Name, metric.labelNames())
+		}
+	}
+	return nil
+}
+
+func (metric *appMetric) labelNames() string {
+	names := make([]string, len(metric.labelKeys))
+	for i, k := range metric.labelKeys {
+		names[i] = k.Name()
+	}
+	return "[" + strings.Join(names, ", ") + "]"
+}
+
+// validate returns an error if the measurement is invalid, regardless of the metrics registered so far.
+func (m *AppMeasurement) validate() error {
+	if len(m.Name) > appMetricsMaxNameLength || !appMetricNameRegex.MatchString(m.Name) {
+		return fmt.Errorf("invalid metric name '%s': must contain only letters, digits and underscores, not start with a digit, and be at most %d characters long", m.Name, appMetricsMaxNameLength)
+	}
+
+	switch m.Type {
+	case AppMetricCounter:
+		if m.Value < 0 {
+			return fmt.Errorf("invalid value for counter '%s': must not be negative", m.Name)
+		}
+	case AppMetricHistogram:
+		if err := validateAppMetricBuckets(m.Buckets); err != nil {
+			return fmt.Errorf("invalid buckets for histogram '%s': %w", m.Name, err)
+		}
+	default:
+		return fmt.Errorf("invalid type for metric '%s'", m.Name)
+	}
+	if math.IsNaN(m.Value) || math.IsInf(m.Value, 0) {
+		return fmt.Errorf("invalid value for metric '%s': must be a finite number", m.Name)
+	}
+
+	if len(m.Labels) > appMetricsMaxLabels {
+		return fmt.Errorf("metric '%s' has %d labels, exceeding the max of %d", m.Name, len(m.Labels), appMetricsMaxLabels)
+	}
+	for k, v := range m.Labels {
+		if !appMetricNameRegex.MatchString(k) || strings.HasPrefix(k, "__") || k == appIDKey.Name() || k == namespaceKey.Name() {
+			return fmt.Errorf("invalid label '%s' for metric '%s'", k, m.Name)
+		}
+		if len(v) > appMetricsMaxLabelValueLength {
+			return fmt.Errorf("value of label '%s' for metric '%s' exceeds the max length of %d characters", k, m.Name, appMetricsMaxLabelValueLength)
+		}
+	}
+	return nil
+}
+
+func validateAppMetricBuckets(buckets []float64) error {
+	if len(buckets) > appMetricsMaxBuckets {
+		return fmt.Errorf("%d buckets exceed the max of %d", len(buckets), appMetricsMaxBuckets)
+	}
+	for i, b := range buckets {
+		if math.IsNaN(b) || math.IsInf(b, 0) || b <= 0 {
+			return errors.New("boundaries must be positive numbers")
+		}
+		if i > 0 && b <= buckets[i-1] {
+			return errors.New("boundaries must be in increasing order")
+		}
+	}
+	return nil
+}
@@ -0,0 +1,101 @@
+/*
+Copyright 2023 The Dapr Authors
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package diagnostics
+
+import (
+	"context"
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opencensus.io/stats/view"
+)
+
+func TestAppMetricsRecord(t *testing.T) {
+	m := newAppMetrics()
+	m.Init("myapp", "default", 2, 2)
+	ctx := context.Background()
+
+	t.Run("counter", func(t *testing.T) {
+		require.NoError(t, m.Record(ctx, []AppMeasurement{
+			{Name: "orders_total", Type: AppMetricCounter, Value: 1, Labels: map[string]string{"status": "ok"}},
+			{Name: "orders_total", Type: AppMetricCounter, Value: 2, Labels: map[string]string{"status": "ok"}},
+		}))
+
+		rows, err := view.RetrieveData("app/orders_total")
+		require.NoError(t, err)
+		require.Len(t, rows, 1)
+		assert.InEpsilon(t, 3.0, rows[0].Data.(*view.SumData).Value, 0.001)
+		assert.Len(t, rows[0].Tags, 3)
+	})
+
+	t.Run("histogram with buckets", func(t *testing.T) {
+		require.NoError(t, m.Record(ctx, []AppMeasurement{
+			{Name: "order_size", Type: AppMetricHistogram, Value: 7, Buckets: []float64{5, 10}},
+		}))
+
+		rows, err := view.RetrieveData("app/order_size")
+		require.NoError(t, err)
+		require.Len(t, rows, 1)
+		assert.Equal(t, []int64{0, 1, 0}, rows[0].Data.(*view.DistributionData).CountPerBucket)
+	})
+
+	t.Run("type and labels are fixed by the first measurement", func(t *testing.T) {
+		err := m.Record(ctx, []AppMeasurement{{Name: "orders_total", Type: AppMetricHistogram, Value: 1, Labels: map[string]string{"status": "ok"}}})
+		require.ErrorContains(t, err, "is a counter")
+
+		err = m.Record(ctx, []AppMeasurement{{Name: "orders_total", Type: AppMetricCounter, Value: 1, Labels: map[string]string{"result": "ok"}}})
+		require.ErrorContains(t, err, "[status]")
+	})
+
+	t.Run("limits", func(t *testing.T) {
+		require.NoError(t, m.Record(ctx, []AppMeasurement{{Name: "orders_total", Type: AppMetricCounter, Value: 1, Labels: map[string]string{"status": "failed"}}}))
+
+		err := m.Record(ctx, []AppMeasurement{{Name: "orders_total", Type: AppMetricCounter, Value: 1, Labels: map[string]string{"status": "canceled"}}})
+		require.ErrorIs(t, err, ErrAppMetricsLimit)
+
+		err = m.Record(ctx, []AppMeasurement{{Name: "payments_total", Type: AppMetricCounter, Value: 1}})
+		require.ErrorIs(t, err, ErrAppMetricsLimit)
+	})
+}
+
+func TestAppMeasurementValidate(t *testing.T) {
+	valid := func() AppMeasurement {
+		return AppMeasurement{Name: "requests", Type: AppMetricCounter, Value: 1, Labels: map[string]string{"route": "/orders"}}
+	}
+	require.NoError(t, (&AppMeasurement{Name: "_requests_2", Type: AppMetricHistogram, Value: -1}).validate())
+
+	tests := map[string]func(m *AppMeasurement){
+		"empty name":         func(m *AppMeasurement) { m.Name = "" },
+		"invalid name":       func(m *AppMeasurement) { m.Name = "requests-total" },
+		"leading digit":      func(m *AppMeasurement) { m.Name = "2requests" },
+		"negative counter":   func(m *AppMeasurement) { m.Value = -1 },
+		"not finite":         func(m *AppMeasurement) { m.Value = math.Inf(1) },
+		"invalid type":       func(m *AppMeasurement) { m.Type = AppMetricType(5) },
+		"reserved label":     func(m *AppMeasurement) { m.Labels["app_id"] = "other" },
+		"invalid label":      func(m *AppMeasurement) { m.Labels["http.route"] = "x" },
+		"internal label":     func(m *AppMeasurement) { m.Labels["__name__"] = "x" },
+		"unordered buckets":  func(m *AppMeasurement) { m.Type, m.Buckets = AppMetricHistogram, []float64{10, 5} },
+		"negative buckets":   func(m *AppMeasurement) { m.Type, m.Buckets = AppMetricHistogram, []float64{-1, 5} },
+		"label value length": func(m *AppMeasurement) { m.Labels["route"] = string(make([]byte, appMetricsMaxLabelValueLength+1)) },
+	}
+	for name, modify := range tests {
+		t.Run(name, func(t *testing.T) {
+			m := valid()
+			modify(&m)
+			require.Error(t, m.validate())
+		})
+	}
+}
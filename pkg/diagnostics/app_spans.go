@@ -0,0 +1,202 @@
+/*
+Copyright 2023 The Dapr Authors
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package diagnostics
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+
+	diagUtils "github.com/dapr/dapr/pkg/diagnostics/utils"
+)
+
+const (
+	// appTracerName is the name of the tracer of the spans recorded by the app, so they can be told apart from the spans of the sidecar.
+	appTracerName = "dapr-app"
+
+	appSpansMaxNameLength = 256
+	appSpansMaxAttributes = 64
+	appSpansMaxEvents     = 128
+)
+
+var appTracer trace.Tracer = otel.Tracer(appTracerName)
+
+// AppSpan is a span recorded by the app through the tracing API.
+type AppSpan struct {
+	Name string
+	// Parent of the span, in the W3C traceparent format.
+	// If empty, the parent is the span in the context, if any.
+	Traceparent string
+	Tracestate  string
+	// StartTime and EndTime default to the time the span is recorded.
+	StartTime  time.Time
+	EndTime    time.Time
+	Attributes map[string]string
+	Events     []AppSpanEvent
+	// Status is codes.Unset, codes.Ok or codes.Error.
+	Status        codes.Code
+	StatusMessage string
+}
+
+// AppSpanEvent is an event of a span recorded by the app.
+type AppSpanEvent struct {
+	Name string
+	// Time defaults to the time the event is recorded.
+	Time       time.Time
+	Attributes map[string]string
+}
+
+// RecordAppSpans validates the spans and records them with the tracer provider of the sidecar, so they're exported like the spans of the sidecar.
+// The events are added to the span in the context, which is the span of the request of the app, if it's recorded.
+// It returns the span contexts of the recorded spans, which the app can use as parents of other spans.
+// If any span or event is invalid, nothing is recorded.
+func RecordAppSpans(ctx context.Context, spans []AppSpan, events []AppSpanEvent) ([]trace.SpanContext, error) {
+	for i := range spans {
+		if err := spans[i].validate(); err != nil {
+			return nil, err
+		}
+	}
+	for i := range events {
+		if err := events[i].validate(); err != nil {
+			return nil, err
+		}
+	}
+
+	res := make([]trace.SpanContext, len(spans))
+	for i := range spans {
+		res[i] = spans[i].record(ctx)
+	}
+	if span := diagUtils.SpanFromContext(ctx); len(events) > 0 && span != nil && span.IsRecording() {
+		addAppSpanEvents(span, events, time.Now())
+	}
+	return res, nil
+}
+
+func (s *AppSpan) record(ctx context.Context) trace.SpanContext {
+	if s.Traceparent != "" {
+		// The traceparent was validated already.
+		sc, _ := SpanContextFromW3CString(s.Traceparent)
+		if s.Tracestate != "" {
+			sc = sc.WithTraceState(*TraceStateFromW3CString(s.Tracestate))
+		}
+		ctx = trace.ContextWithRemoteSpanContext(ctx, sc)
+	} else if span := diagUtils.SpanFromContext(ctx); span != nil {
+		ctx = trace.ContextWithSpan(ctx, span)
+	}
+
+	now := time.Now()
+	start := s.StartTime
+	if start.IsZero() {
+		start = now
+	}
+	end := s.EndTime
+	if end.IsZero() {
+		end = now
+	}
+	if end.Before(start) {
+		// The start time is in the future, and the end time wasn't set.
+		end = start
+	}
+
+	_, span := appTracer.Start(ctx, s.Name,
+		trace.WithTimestamp(start),
+		trace.WithAttributes(appSpanAttributes(s.Attributes)...),
+	)
+	addAppSpanEvents(span, s.Events, now)
+	if s.Status != codes.Unset {
+		span.SetStatus(s.Status, s.StatusMessage)
+	}
+	span.End(trace.WithTimestamp(end))
+
+	return span.SpanContext()
+}
+
+func addAppSpanEvents(span trace.Span, events []AppSpanEvent, now time.Time) {
+	for _, e := range events {
+		ts := e.Time
+		if ts.IsZero() {
+			ts = now
+		}
+		span.AddEvent(e.Name, trace.WithTimestamp(ts), trace.WithAttributes(appSpanAttributes(e.Attributes)...))
+	}
+}
+
+func appSpanAttributes(attributes map[string]string) []attribute.KeyValue {
+	attrs := make([]attribute.KeyValue, 0, len(attributes))
+	for k, v := range attributes {
+		attrs = append(attrs, attribute.String(k, v))
+	}
+	return attrs
+}
+
+// validate returns an error if the span is invalid.
+func (s *AppSpan) validate() error {
+	if s.Name == "" || len(s.Name) > appSpansMaxNameLength {
+		return fmt.Errorf("invalid span name '%s': must not be empty and be at most %d characters long", s.Name, appSpansMaxNameLength)
+	}
+	if s.Traceparent != "" {
+		if _, ok := SpanContextFromW3CString(s.Traceparent); !ok {
+			return fmt.Errorf("invalid traceparent '%s' for span '%s'", s.Traceparent, s.Name)
+		}
+	}
+	if !s.StartTime.IsZero() && !s.EndTime.IsZero() && s.EndTime.Before(s.StartTime) {
+		return fmt.Errorf("end time of span '%s' is before its start time", s.Name)
+	}
+	if err := validateAppSpanAttributes(s.Attributes); err != nil {
+		return fmt.Errorf("invalid attributes for span '%s': %w", s.Name, err)
+	}
+	switch s.Status {
+	case codes.Unset, codes.Ok, codes.Error:
+	default:
+		return fmt.Errorf("invalid status for span '%s'", s.Name)
+	}
+	if len(s.Events) > appSpansMaxEvents {
+		return fmt.Errorf("span '%s' has %d events, exceeding the max of %d", s.Name, len(s.Events), appSpansMaxEvents)
+	}
+	for i := range s.Events {
+		if err := s.Events[i].validate(); err != nil {
+			return fmt.Errorf("invalid event for span '%s': %w", s.Name, err)
+		}
+	}
+	return nil
+}
+
+// validate returns an error if the event is invalid.
+func (e *AppSpanEvent) validate() error {
+	if e.Name == "" || len(e.Name) > appSpansMaxNameLength {
+		return fmt.Errorf("invalid event name '%s': must not be empty and be at most %d characters long", e.Name, appSpansMaxNameLength)
+	}
+	if err := validateAppSpanAttributes(e.Attributes); err != nil {
+		return fmt.Errorf("invalid attributes for event '%s': %w", e.Name, err)
+	}
+	return nil
+}
+
+func validateAppSpanAttributes(attributes map[string]string) error {
+	if len(attributes) > appSpansMaxAttributes {
+		return fmt.Errorf("%d attributes exceed the max of %d", len(attributes), appSpansMaxAttributes)
+	}
+	for k := range attributes {
+		if k == "" {
+			return errors.New("attribute names must not be empty")
+		}
+	}
+	return nil
+}
@@ -0,0 +1,117 @@
+/*
+Copyright 2023 The Dapr Authors
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package diagnostics
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+)
+
+func TestRecordAppSpans(t *testing.T) {
+	recorder := tracetest.NewSpanRecorder()
+	oldTracerProvider := otel.GetTracerProvider()
+	tp := sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder))
+	otel.SetTracerProvider(tp)
+	t.Cleanup(func() {
+		otel.SetTracerProvider(oldTracerProvider)
+	})
+
+	t.Run("child of the traceparent", func(t *testing.T) {
+		start := time.Date(2023, 10, 1, 12, 0, 0, 0, time.UTC)
+		scs, err := RecordAppSpans(context.Background(), []AppSpan{{
+			Name:        "checkout",
+			Traceparent: "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01",
+			Tracestate:  "vendor=value",
+			StartTime:   start,
+			EndTime:     start.Add(time.Second),
+			Attributes:  map[string]string{"order": "42"},
+			Events: []AppSpanEvent{
+				{Name: "paid", Time: start.Add(500 * time.Millisecond), Attributes: map[string]string{"amount": "10"}},
+			},
+			Status:        codes.Error,
+			StatusMessage: "out of stock",
+		}}, nil)
+		require.NoError(t, err)
+		require.Len(t, scs, 1)
+		assert.Equal(t, "4bf92f3577b34da6a3ce929d0e0e4736", scs[0].TraceID().String())
+
+		ended := recorder.Ended()
+		require.NotEmpty(t, ended)
+		span := ended[len(ended)-1]
+		assert.Equal(t, "checkout", span.Name())
+		assert.Equal(t, "00f067aa0ba902b7", span.Parent().SpanID().String())
+		assert.Equal(t, "vendor=value", span.SpanContext().TraceState().String())
+		assert.Equal(t, start, span.StartTime())
+		assert.Equal(t, start.Add(time.Second), span.EndTime())
+		assert.Contains(t, span.Attributes(), attribute.String("order", "42"))
+		assert.Equal(t, codes.Error, span.Status().Code)
+		assert.Equal(t, "out of stock", span.Status().Description)
+		require.Len(t, span.Events(), 1)
+		assert.Equal(t, "paid", span.Events()[0].Name)
+		assert.Equal(t, start.Add(500*time.Millisecond), span.Events()[0].Time)
+		assert.Equal(t, []attribute.KeyValue{attribute.String("amount", "10")}, span.Events()[0].Attributes)
+	})
+
+	t.Run("child of the span in the context", func(t *testing.T) {
+		ctx, parent := tp.Tracer("test").Start(context.Background(), "request")
+		scs, err := RecordAppSpans(ctx, []AppSpan{{Name: "work"}}, []AppSpanEvent{{Name: "started"}})
+		require.NoError(t, err)
+		parent.End()
+
+		require.Len(t, scs, 1)
+		assert.Equal(t, parent.SpanContext().TraceID(), scs[0].TraceID())
+
+		ended := recorder.Ended()
+		require.GreaterOrEqual(t, len(ended), 2)
+		work, request := ended[len(ended)-2], ended[len(ended)-1]
+		assert.Equal(t, "work", work.Name())
+		assert.Equal(t, parent.SpanContext().SpanID(), work.Parent().SpanID())
+		require.Len(t, request.Events(), 1)
+		assert.Equal(t, "started", request.Events()[0].Name)
+	})
+
+	t.Run("invalid spans are not recorded", func(t *testing.T) {
+		before := len(recorder.Ended())
+		tests := map[string]AppSpan{
+			"no name":          {},
+			"long name":        {Name: strings.Repeat("a", appSpansMaxNameLength+1)},
+			"bad traceparent":  {Name: "span", Traceparent: "00-invalid"},
+			"end before start": {Name: "span", StartTime: time.Now(), EndTime: time.Now().Add(-time.Second)},
+			"empty attribute":  {Name: "span", Attributes: map[string]string{"": "v"}},
+			"invalid status":   {Name: "span", Status: codes.Code(7)},
+			"invalid event":    {Name: "span", Events: []AppSpanEvent{{}}},
+		}
+		for name, s := range tests {
+			t.Run(name, func(t *testing.T) {
+				_, err := RecordAppSpans(context.Background(), []AppSpan{{Name: "valid"}, s}, nil)
+				require.Error(t, err)
+			})
+		}
+
+		_, err := RecordAppSpans(context.Background(), []AppSpan{{Name: "valid"}}, []AppSpanEvent{{Name: ""}})
+		require.Error(t, err)
+
+		assert.Len(t, recorder.Ended(), before)
+	})
+}
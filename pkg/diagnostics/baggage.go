@@ -0,0 +1,88 @@
+/*
+Copyright 2023 The Dapr Authors
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package diagnostics
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/valyala/fasthttp"
+	"go.opentelemetry.io/otel/baggage"
+	grpcMetadata "google.golang.org/grpc/metadata"
+)
+
+// BaggageHeader is the W3C Baggage header, which carries user-defined properties along with the trace context.
+// It is also the name of the CloudEvent extension and of the binding metadata key the baggage is propagated with.
+const BaggageHeader = "baggage"
+
+// ContextWithBaggageString returns a context carrying the baggage in the W3C Baggage format h.
+// The context is returned unchanged if h is empty or invalid.
+func ContextWithBaggageString(ctx context.Context, h string) context.Context {
+	if h == "" {
+		return ctx
+	}
+	b, err := baggage.Parse(h)
+	if err != nil || b.Len() == 0 {
+		return ctx
+	}
+	return baggage.ContextWithBaggage(ctx, b)
+}
+
+// BaggageString returns the baggage stored in ctx in the W3C Baggage format, or an empty string if there is none.
+func BaggageString(ctx context.Context) string {
+	// TODO: Remove fasthttp compatibility when no HTTP API using contexts depend on fasthttp
+	if reqCtx, ok := ctx.(*fasthttp.RequestCtx); ok {
+		ctx = ContextWithBaggageString(context.Background(), string(reqCtx.Request.Header.Peek(BaggageHeader)))
+	}
+	return baggage.FromContext(ctx).String()
+}
+
+// AddBaggageToRequest stores the baggage of the incoming request in its context.
+func AddBaggageToRequest(r *http.Request) {
+	if h := r.Header.Get(BaggageHeader); h != "" {
+		*r = *(r.WithContext(ContextWithBaggageString(r.Context(), h)))
+	}
+}
+
+// BaggageToHTTPHeaders adds the baggage stored in ctx to the baggage header, if there is any.
+func BaggageToHTTPHeaders(ctx context.Context, setHeader func(string, string)) {
+	if h := BaggageString(ctx); h != "" {
+		setHeader(BaggageHeader, h)
+	}
+}
+
+// ContextWithBaggageFromIncomingGRPCMetadata returns a context carrying the baggage of the incoming gRPC metadata, if there is any.
+func ContextWithBaggageFromIncomingGRPCMetadata(ctx context.Context) context.Context {
+	md, ok := grpcMetadata.FromIncomingContext(ctx)
+	if !ok {
+		return ctx
+	}
+	vals := md.Get(BaggageHeader)
+	if len(vals) == 0 {
+		return ctx
+	}
+	return ContextWithBaggageString(ctx, vals[0])
+}
+
+// BaggageToGRPCMetadata appends the baggage stored in ctx to the outgoing gRPC metadata, if there is any.
+func BaggageToGRPCMetadata(ctx context.Context) context.Context {
+	h := BaggageString(ctx)
+	if h == "" {
+		return ctx
+	}
+	if md, ok := grpcMetadata.FromOutgoingContext(ctx); ok && len(md.Get(BaggageHeader)) > 0 {
+		return ctx
+	}
+	return grpcMetadata.AppendToOutgoingContext(ctx, BaggageHeader, h)
+}
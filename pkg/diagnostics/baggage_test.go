@@ -0,0 +1,79 @@
+/*
+Copyright 2023 The Dapr Authors
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package diagnostics
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/valyala/fasthttp"
+	grpcMetadata "google.golang.org/grpc/metadata"
+)
+
+func TestBaggageContext(t *testing.T) {
+	t.Run("valid baggage", func(t *testing.T) {
+		ctx := ContextWithBaggageString(context.Background(), "userId=alice,isProduction=false")
+		assert.ElementsMatch(t, []string{"userId=alice", "isProduction=false"}, strings.Split(BaggageString(ctx), ","))
+	})
+
+	t.Run("invalid baggage is ignored", func(t *testing.T) {
+		ctx := ContextWithBaggageString(context.Background(), "userId=alice")
+		ctx = ContextWithBaggageString(ctx, "not a baggage")
+		assert.Equal(t, "userId=alice", BaggageString(ctx))
+	})
+
+	t.Run("no baggage", func(t *testing.T) {
+		assert.Equal(t, "", BaggageString(context.Background()))
+	})
+
+	t.Run("fasthttp request", func(t *testing.T) {
+		reqCtx := &fasthttp.RequestCtx{}
+		reqCtx.Request.Header.Set(BaggageHeader, "userId=alice")
+		assert.Equal(t, "userId=alice", BaggageString(reqCtx))
+	})
+}
+
+func TestBaggageHTTP(t *testing.T) {
+	r := httptest.NewRequest(http.MethodGet, "/v1.0/invoke/app/method/test", nil)
+	r.Header.Set(BaggageHeader, "userId=alice")
+	AddBaggageToRequest(r)
+	assert.Equal(t, "userId=alice", BaggageString(r.Context()))
+
+	headers := http.Header{}
+	BaggageToHTTPHeaders(r.Context(), headers.Set)
+	assert.Equal(t, "userId=alice", headers.Get(BaggageHeader))
+
+	headers = http.Header{}
+	BaggageToHTTPHeaders(context.Background(), headers.Set)
+	assert.Empty(t, headers)
+}
+
+func TestBaggageGRPC(t *testing.T) {
+	ctx := grpcMetadata.NewIncomingContext(context.Background(), grpcMetadata.Pairs(BaggageHeader, "userId=alice"))
+	ctx = ContextWithBaggageFromIncomingGRPCMetadata(ctx)
+	assert.Equal(t, "userId=alice", BaggageString(ctx))
+
+	ctx = BaggageToGRPCMetadata(ctx)
+	ctx = BaggageToGRPCMetadata(ctx)
+	md, _ := grpcMetadata.FromOutgoingContext(ctx)
+	assert.Equal(t, []string{"userId=alice"}, md.Get(BaggageHeader))
+
+	ctx = BaggageToGRPCMetadata(context.Background())
+	_, ok := grpcMetadata.FromOutgoingContext(ctx)
+	assert.False(t, ok)
+}
@@ -2,20 +2,27 @@ package diagnostics
 
 import (
 	"context"
+	"errors"
 	"strconv"
+	"strings"
+	"sync/atomic"
 	"time"
 
 	"go.opencensus.io/stats"
 	"go.opencensus.io/stats/view"
 	"go.opencensus.io/tag"
+	"google.golang.org/grpc/status"
 
+	"github.com/dapr/components-contrib/state"
 	diagUtils "github.com/dapr/dapr/pkg/diagnostics/utils"
+	"github.com/dapr/dapr/utils"
 )
 
 var (
 	processStatusKey = tag.MustNewKey("process_status")
 	successKey       = tag.MustNewKey("success")
 	topicKey         = tag.MustNewKey("topic")
+	errorTypeKey     = tag.MustNewKey("error_type")
 )
 
 const (
@@ -29,6 +36,16 @@ const (
 	BulkGet                  = "bulk_get"
 	BulkDelete               = "bulk_delete"
 	CryptoOp                 = "crypto_op"
+	Publish                  = "publish"
+)
+
+// Error types of the failed component operations.
+const (
+	ErrorTypeTimeout      = "timeout"
+	ErrorTypeCanceled     = "canceled"
+	ErrorTypeETagMismatch = "etag_mismatch"
+	ErrorTypeETagInvalid  = "etag_invalid"
+	ErrorTypeOther        = "other"
 )
 
 // componentMetrics holds dapr runtime metrics for components.
@@ -43,6 +60,7 @@ type componentMetrics struct {
 	bulkPubsubEgressCount       *stats.Int64Measure
 	bulkPubsubEventEgressCount  *stats.Int64Measure
 	bulkPubsubEgressLatency     *stats.Float64Measure
+	pubsubEgressBacklog         *stats.Int64Measure
 
 	inputBindingCount    *stats.Int64Measure
 	inputBindingLatency  *stats.Float64Measure
@@ -61,9 +79,17 @@ type componentMetrics struct {
 	cryptoCount   *stats.Int64Measure
 	cryptoLatency *stats.Float64Measure
 
+	errorCount *stats.Int64Measure
+	health     *stats.Int64Measure
+
+	// types contains the types of the initialized components, keyed by component name.
+	types utils.AtomicMap[string, string]
+
 	appID     string
-	enabled   bool
+	enabled   atomic.Bool
 	namespace string
+	// sampler samples the pub/sub messages recorded in the per-message measures.
+	sampler metricsSampler
 }
 
 // newComponentMetrics returns a componentMetrics instance with default stats.
@@ -109,6 +135,10 @@ func newComponentMetrics() *componentMetrics {
 			"component/pubsub_egress/bulk/latencies",
 			"The latency of the response for the bulk publish call from the pub/sub component.",
 			stats.UnitMilliseconds),
+		pubsubEgressBacklog: stats.Int64(
+			"component/pubsub_egress/backlog",
+			"The number of messages waiting to be delivered on the topic, as reported by the pub/sub component after a publish.",
+			stats.UnitDimensionless),
 		inputBindingCount: stats.Int64(
 			"component/input_binding/count",
 			"The number of incoming events arriving from the input binding component.",
@@ -157,23 +187,34 @@ func newComponentMetrics() *componentMetrics {
 			"component/crypto/latencies",
 			"The latency of the response from the crypto component.",
 			stats.UnitMilliseconds),
+		errorCount: stats.Int64(
+			"component/errors_total",
+			"The number of failed operations on a component, by type of error.",
+			stats.UnitDimensionless),
+		health: stats.Int64(
+			"component/health",
+			"Whether the component is healthy (1) or not (0), as of its last initialization.",
+			stats.UnitDimensionless),
 	}
 }
 
 // Init registers the component metrics views.
-func (c *componentMetrics) Init(appID, namespace string) error {
+func (c *componentMetrics) Init(appID, namespace string, samplingFactor int) error {
 	c.appID = appID
-	c.enabled = true
+	c.enabled.Store(true)
 	c.namespace = namespace
+	c.sampler = newMetricsSampler(samplingFactor)
 
-	return view.Register(
-		diagUtils.NewMeasureView(c.pubsubIngressLatency, []tag.Key{appIDKey, componentKey, namespaceKey, processStatusKey, topicKey}, defaultLatencyDistribution),
-		diagUtils.NewMeasureView(c.pubsubIngressCount, []tag.Key{appIDKey, componentKey, namespaceKey, processStatusKey, topicKey}, view.Count()),
+	return registerViews(
+		// The per-message measures are sampled if enabled, so the counts are then the sum of the weights of the sampled messages
+		c.sampler.describe(diagUtils.NewMeasureView(c.pubsubIngressLatency, []tag.Key{appIDKey, componentKey, namespaceKey, processStatusKey, topicKey}, defaultLatencyDistribution)),
+		c.sampler.describe(diagUtils.NewMeasureView(c.pubsubIngressCount, []tag.Key{appIDKey, componentKey, namespaceKey, processStatusKey, topicKey}, c.sampler.countAggregation())),
 		diagUtils.NewMeasureView(c.bulkPubsubIngressLatency, []tag.Key{appIDKey, componentKey, namespaceKey, processStatusKey, topicKey}, defaultLatencyDistribution),
 		diagUtils.NewMeasureView(c.bulkPubsubIngressCount, []tag.Key{appIDKey, componentKey, namespaceKey, processStatusKey, topicKey}, view.Count()),
 		diagUtils.NewMeasureView(c.bulkPubsubEventIngressCount, []tag.Key{appIDKey, componentKey, namespaceKey, processStatusKey, topicKey}, view.Count()),
-		diagUtils.NewMeasureView(c.pubsubEgressLatency, []tag.Key{appIDKey, componentKey, namespaceKey, successKey, topicKey}, defaultLatencyDistribution),
-		diagUtils.NewMeasureView(c.pubsubEgressCount, []tag.Key{appIDKey, componentKey, namespaceKey, successKey, topicKey}, view.Count()),
+		c.sampler.describe(diagUtils.NewMeasureView(c.pubsubEgressLatency, []tag.Key{appIDKey, componentKey, namespaceKey, successKey, topicKey}, defaultLatencyDistribution)),
+		c.sampler.describe(diagUtils.NewMeasureView(c.pubsubEgressCount, []tag.Key{appIDKey, componentKey, namespaceKey, successKey, topicKey}, c.sampler.countAggregation())),
+		diagUtils.NewMeasureView(c.pubsubEgressBacklog, []tag.Key{appIDKey, componentKey, namespaceKey, topicKey}, view.LastValue()),
 		diagUtils.NewMeasureView(c.inputBindingLatency, []tag.Key{appIDKey, componentKey, namespaceKey, successKey}, defaultLatencyDistribution),
 		diagUtils.NewMeasureView(c.inputBindingCount, []tag.Key{appIDKey, componentKey, namespaceKey, successKey}, view.Count()),
 		diagUtils.NewMeasureView(c.outputBindingLatency, []tag.Key{appIDKey, componentKey, namespaceKey, operationKey, successKey}, defaultLatencyDistribution),
@@ -186,21 +227,82 @@ func (c *componentMetrics) Init(appID, namespace string) error {
 		diagUtils.NewMeasureView(c.secretCount, []tag.Key{appIDKey, componentKey, namespaceKey, operationKey, successKey}, view.Count()),
 		diagUtils.NewMeasureView(c.cryptoLatency, []tag.Key{appIDKey, componentKey, namespaceKey, operationKey, successKey}, defaultLatencyDistribution),
 		diagUtils.NewMeasureView(c.cryptoCount, []tag.Key{appIDKey, componentKey, namespaceKey, operationKey, successKey}, view.Count()),
+		diagUtils.NewMeasureView(c.errorCount, []tag.Key{appIDKey, componentKey, typeKey, namespaceKey, operationKey, errorTypeKey}, view.Count()),
+		diagUtils.NewMeasureView(c.health, []tag.Key{appIDKey, componentKey, typeKey, namespaceKey}, view.LastValue()),
 	)
 }
 
+// ComponentInitialized records the health of a component after its initialization, and remembers its type to tag the errors of its operations.
+func (c *componentMetrics) ComponentInitialized(ctx context.Context, component, componentType string, success bool) {
+	c.types.Store(component, componentType)
+
+	if c.enabled.Load() {
+		var health int64
+		if success {
+			health = 1
+		}
+		stats.RecordWithTags(
+			ctx,
+			diagUtils.WithTags(c.health.Name(), appIDKey, c.appID, componentKey, component, typeKey, componentType, namespaceKey, c.namespace),
+			c.health.M(health))
+	}
+}
+
+// OperationFailed records the type of the error returned by an operation on a component.
+// It does nothing if err is nil, so it can be called unconditionally after each operation.
+func (c *componentMetrics) OperationFailed(ctx context.Context, component, operation string, err error) {
+	if !c.enabled.Load() || err == nil {
+		return
+	}
+
+	componentType, _ := c.types.Load(component)
+	stats.RecordWithTags(
+		ctx,
+		diagUtils.WithTags(c.errorCount.Name(), appIDKey, c.appID, componentKey, component, typeKey, componentType, namespaceKey, c.namespace, operationKey, operation, errorTypeKey, componentErrorType(err)),
+		c.errorCount.M(1))
+}
+
+// componentErrorType returns the type of an error returned by a component, with a low cardinality.
+func componentErrorType(err error) string {
+	switch {
+	case errors.Is(err, context.DeadlineExceeded):
+		return ErrorTypeTimeout
+	case errors.Is(err, context.Canceled):
+		return ErrorTypeCanceled
+	}
+
+	var etagErr *state.ETagError
+	if errors.As(err, &etagErr) {
+		if etagErr.Kind() == state.ETagInvalid {
+			return ErrorTypeETagInvalid
+		}
+		return ErrorTypeETagMismatch
+	}
+
+	// Pluggable components return gRPC status codes
+	if s, ok := status.FromError(err); ok {
+		return strings.ToLower(s.Code().String())
+	}
+	return ErrorTypeOther
+}
+
 // PubsubIngressEvent records the metrics for a pub/sub ingress event.
 func (c *componentMetrics) PubsubIngressEvent(ctx context.Context, component, processStatus, topic string, elapsed float64) {
-	if c.enabled {
+	if c.enabled.Load() {
+		weight := c.sampler.sample()
+		if weight == 0 {
+			return
+		}
+
 		stats.RecordWithTags(
 			ctx,
-			diagUtils.WithTags(c.pubsubIngressCount.Name(), appIDKey, c.appID, componentKey, component, namespaceKey, c.namespace, processStatusKey, processStatus, topicKey, topic),
-			c.pubsubIngressCount.M(1))
+			diagUtils.WithCachedTags(c.pubsubIngressCount.Name(), appIDKey, c.appID, componentKey, component, namespaceKey, c.namespace, processStatusKey, processStatus, topicKey, topic),
+			c.pubsubIngressCount.M(weight))
 
 		if elapsed > 0 {
-			stats.RecordWithTags(
+			recordWithExemplar(
 				ctx,
-				diagUtils.WithTags(c.pubsubIngressLatency.Name(), appIDKey, c.appID, componentKey, component, namespaceKey, c.namespace, processStatusKey, processStatus, topicKey, topic),
+				diagUtils.WithCachedTags(c.pubsubIngressLatency.Name(), appIDKey, c.appID, componentKey, component, namespaceKey, c.namespace, processStatusKey, processStatus, topicKey, topic),
 				c.pubsubIngressLatency.M(elapsed))
 		}
 	}
@@ -208,16 +310,16 @@ func (c *componentMetrics) PubsubIngressEvent(ctx context.Context, component, pr
 
 // BulkPubsubIngressEvent records the metrics for a bulk pub/sub ingress event.
 func (c *componentMetrics) BulkPubsubIngressEvent(ctx context.Context, component, topic string, elapsed float64) {
-	if c.enabled {
+	if c.enabled.Load() {
 		stats.RecordWithTags(
 			ctx,
-			diagUtils.WithTags(c.bulkPubsubIngressCount.Name(), appIDKey, c.appID, componentKey, component, namespaceKey, c.namespace, topicKey, topic),
+			diagUtils.WithCachedTags(c.bulkPubsubIngressCount.Name(), appIDKey, c.appID, componentKey, component, namespaceKey, c.namespace, topicKey, topic),
 			c.bulkPubsubIngressCount.M(1))
 
 		if elapsed > 0 {
-			stats.RecordWithTags(
+			recordWithExemplar(
 				ctx,
-				diagUtils.WithTags(c.bulkPubsubIngressLatency.Name(), appIDKey, c.appID, componentKey, component, namespaceKey, c.namespace, topicKey, topic),
+				diagUtils.WithCachedTags(c.bulkPubsubIngressLatency.Name(), appIDKey, c.appID, componentKey, component, namespaceKey, c.namespace, topicKey, topic),
 				c.bulkPubsubIngressLatency.M(elapsed))
 		}
 	}
@@ -225,10 +327,10 @@ func (c *componentMetrics) BulkPubsubIngressEvent(ctx context.Context, component
 
 // BulkPubsubIngressEventEntries records the metrics for entries inside a bulk pub/sub ingress event.
 func (c *componentMetrics) BulkPubsubIngressEventEntries(ctx context.Context, component, topic string, processStatus string, eventCount int64) {
-	if c.enabled && eventCount > 0 {
+	if c.enabled.Load() && eventCount > 0 {
 		stats.RecordWithTags(
 			ctx,
-			diagUtils.WithTags(c.bulkPubsubEventIngressCount.Name(), appIDKey, c.appID, componentKey, component, namespaceKey, c.namespace, processStatusKey, processStatus, topicKey, topic),
+			diagUtils.WithCachedTags(c.bulkPubsubEventIngressCount.Name(), appIDKey, c.appID, componentKey, component, namespaceKey, c.namespace, processStatusKey, processStatus, topicKey, topic),
 			c.bulkPubsubEventIngressCount.M(eventCount))
 	}
 }
@@ -236,22 +338,22 @@ func (c *componentMetrics) BulkPubsubIngressEventEntries(ctx context.Context, co
 // BulkPubsubEgressEvent records the metris for a pub/sub egress event.
 // eventCount if greater than zero implies successful publish of few/all events in the bulk publish call
 func (c *componentMetrics) BulkPubsubEgressEvent(ctx context.Context, component, topic string, success bool, eventCount int64, elapsed float64) {
-	if c.enabled {
+	if c.enabled.Load() {
 		stats.RecordWithTags(
 			ctx,
-			diagUtils.WithTags(c.bulkPubsubEgressCount.Name(), appIDKey, c.appID, componentKey, component, namespaceKey, c.namespace, successKey, strconv.FormatBool(success), topicKey, topic),
+			diagUtils.WithCachedTags(c.bulkPubsubEgressCount.Name(), appIDKey, c.appID, componentKey, component, namespaceKey, c.namespace, successKey, strconv.FormatBool(success), topicKey, topic),
 			c.bulkPubsubEgressCount.M(1))
 		if eventCount > 0 {
 			// There is at leaset one success in the bulk publish call even if overall success of the call might be a failure
 			stats.RecordWithTags(
 				ctx,
-				diagUtils.WithTags(c.bulkPubsubEventEgressCount.Name(), appIDKey, c.appID, componentKey, component, namespaceKey, c.namespace, successKey, true, topicKey, topic),
+				diagUtils.WithCachedTags(c.bulkPubsubEventEgressCount.Name(), appIDKey, c.appID, componentKey, component, namespaceKey, c.namespace, successKey, true, topicKey, topic),
 				c.bulkPubsubEventEgressCount.M(eventCount))
 		}
 		if elapsed > 0 {
-			stats.RecordWithTags(
+			recordWithExemplar(
 				ctx,
-				diagUtils.WithTags(c.bulkPubsubEgressLatency.Name(), appIDKey, c.appID, componentKey, component, namespaceKey, c.namespace, successKey, strconv.FormatBool(success), topicKey, topic),
+				diagUtils.WithCachedTags(c.bulkPubsubEgressLatency.Name(), appIDKey, c.appID, componentKey, component, namespaceKey, c.namespace, successKey, strconv.FormatBool(success), topicKey, topic),
 				c.bulkPubsubEgressLatency.M(elapsed))
 		}
 	}
@@ -259,31 +361,46 @@ func (c *componentMetrics) BulkPubsubEgressEvent(ctx context.Context, component,
 
 // PubsubEgressEvent records the metris for a pub/sub egress event.
 func (c *componentMetrics) PubsubEgressEvent(ctx context.Context, component, topic string, success bool, elapsed float64) {
-	if c.enabled {
+	if c.enabled.Load() {
+		weight := c.sampler.sample()
+		if weight == 0 {
+			return
+		}
+
 		stats.RecordWithTags(
 			ctx,
-			diagUtils.WithTags(c.pubsubEgressCount.Name(), appIDKey, c.appID, componentKey, component, namespaceKey, c.namespace, successKey, strconv.FormatBool(success), topicKey, topic),
-			c.pubsubEgressCount.M(1))
+			diagUtils.WithCachedTags(c.pubsubEgressCount.Name(), appIDKey, c.appID, componentKey, component, namespaceKey, c.namespace, successKey, strconv.FormatBool(success), topicKey, topic),
+			c.pubsubEgressCount.M(weight))
 
 		if elapsed > 0 {
-			stats.RecordWithTags(
+			recordWithExemplar(
 				ctx,
-				diagUtils.WithTags(c.pubsubEgressLatency.Name(), appIDKey, c.appID, componentKey, component, namespaceKey, c.namespace, successKey, strconv.FormatBool(success), topicKey, topic),
+				diagUtils.WithCachedTags(c.pubsubEgressLatency.Name(), appIDKey, c.appID, componentKey, component, namespaceKey, c.namespace, successKey, strconv.FormatBool(success), topicKey, topic),
 				c.pubsubEgressLatency.M(elapsed))
 		}
 	}
 }
 
+// PubsubEgressBacklog records the backlog of a topic reported by the pub/sub component.
+func (c *componentMetrics) PubsubEgressBacklog(ctx context.Context, component, topic string, backlog int64) {
+	if c.enabled.Load() {
+		stats.RecordWithTags(
+			ctx,
+			diagUtils.WithCachedTags(c.pubsubEgressBacklog.Name(), appIDKey, c.appID, componentKey, component, namespaceKey, c.namespace, topicKey, topic),
+			c.pubsubEgressBacklog.M(backlog))
+	}
+}
+
 // InputBindingEvent records the metrics for an input binding event.
 func (c *componentMetrics) InputBindingEvent(ctx context.Context, component string, success bool, elapsed float64) {
-	if c.enabled {
+	if c.enabled.Load() {
 		stats.RecordWithTags(
 			ctx,
 			diagUtils.WithTags(c.inputBindingCount.Name(), appIDKey, c.appID, componentKey, component, namespaceKey, c.namespace, successKey, strconv.FormatBool(success)),
 			c.inputBindingCount.M(1))
 
 		if elapsed > 0 {
-			stats.RecordWithTags(
+			recordWithExemplar(
 				ctx,
 				diagUtils.WithTags(c.inputBindingLatency.Name(), appIDKey, c.appID, componentKey, component, namespaceKey, c.namespace, successKey, strconv.FormatBool(success)),
 				c.inputBindingLatency.M(elapsed))
@@ -293,14 +410,14 @@ func (c *componentMetrics) InputBindingEvent(ctx context.Context, component stri
 
 // OutputBindingEvent records the metrics for an output binding event.
 func (c *componentMetrics) OutputBindingEvent(ctx context.Context, component, operation string, success bool, elapsed float64) {
-	if c.enabled {
+	if c.enabled.Load() {
 		stats.RecordWithTags(
 			ctx,
 			diagUtils.WithTags(c.outputBindingCount.Name(), appIDKey, c.appID, componentKey, component, namespaceKey, c.namespace, operationKey, operation, successKey, strconv.FormatBool(success)),
 			c.outputBindingCount.M(1))
 
 		if elapsed > 0 {
-			stats.RecordWithTags(
+			recordWithExemplar(
 				ctx,
 				diagUtils.WithTags(c.outputBindingLatency.Name(), appIDKey, c.appID, componentKey, component, namespaceKey, c.namespace, operationKey, operation, successKey, strconv.FormatBool(success)),
 				c.outputBindingLatency.M(elapsed))
@@ -310,14 +427,14 @@ func (c *componentMetrics) OutputBindingEvent(ctx context.Context, component, op
 
 // StateInvoked records the metrics for a state event.
 func (c *componentMetrics) StateInvoked(ctx context.Context, component, operation string, success bool, elapsed float64) {
-	if c.enabled {
+	if c.enabled.Load() {
 		stats.RecordWithTags(
 			ctx,
 			diagUtils.WithTags(c.stateCount.Name(), appIDKey, c.appID, componentKey, component, namespaceKey, c.namespace, operationKey, operation, successKey, strconv.FormatBool(success)),
 			c.stateCount.M(1))
 
 		if elapsed > 0 {
-			stats.RecordWithTags(
+			recordWithExemplar(
 				ctx,
 				diagUtils.WithTags(c.stateLatency.Name(), appIDKey, c.appID, componentKey, component, namespaceKey, c.namespace, operationKey, operation, successKey, strconv.FormatBool(success)),
 				c.stateLatency.M(elapsed))
@@ -327,14 +444,14 @@ func (c *componentMetrics) StateInvoked(ctx context.Context, component, operatio
 
 // ConfigurationInvoked records the metrics for a configuration event.
 func (c *componentMetrics) ConfigurationInvoked(ctx context.Context, component, operation string, success bool, elapsed float64) {
-	if c.enabled {
+	if c.enabled.Load() {
 		stats.RecordWithTags(
 			ctx,
 			diagUtils.WithTags(c.configurationCount.Name(), appIDKey, c.appID, componentKey, component, namespaceKey, c.namespace, operationKey, operation, successKey, strconv.FormatBool(success)),
 			c.configurationCount.M(1))
 
 		if elapsed > 0 {
-			stats.RecordWithTags(
+			recordWithExemplar(
 				ctx,
 				diagUtils.WithTags(c.configurationLatency.Name(), appIDKey, c.appID, componentKey, component, namespaceKey, c.namespace, operationKey, operation, successKey, strconv.FormatBool(success)),
 				c.configurationLatency.M(elapsed))
@@ -344,14 +461,14 @@ func (c *componentMetrics) ConfigurationInvoked(ctx context.Context, component,
 
 // SecretInvoked records the metrics for a secret event.
 func (c *componentMetrics) SecretInvoked(ctx context.Context, component, operation string, success bool, elapsed float64) {
-	if c.enabled {
+	if c.enabled.Load() {
 		stats.RecordWithTags(
 			ctx,
 			diagUtils.WithTags(c.secretCount.Name(), appIDKey, c.appID, componentKey, component, namespaceKey, c.namespace, operationKey, operation, successKey, strconv.FormatBool(success)),
 			c.secretCount.M(1))
 
 		if elapsed > 0 {
-			stats.RecordWithTags(
+			recordWithExemplar(
 				ctx,
 				diagUtils.WithTags(c.secretLatency.Name(), appIDKey, c.appID, componentKey, component, namespaceKey, c.namespace, operationKey, operation, successKey, strconv.FormatBool(success)),
 				c.secretLatency.M(elapsed))
@@ -361,14 +478,14 @@ func (c *componentMetrics) SecretInvoked(ctx context.Context, component, operati
 
 // CryptoInvoked records the metrics for a crypto event.
 func (c *componentMetrics) CryptoInvoked(ctx context.Context, component, operation string, success bool, elapsed float64) {
-	if c.enabled {
+	if c.enabled.Load() {
 		stats.RecordWithTags(
 			ctx,
 			diagUtils.WithTags(c.cryptoCount.Name(), appIDKey, c.appID, componentKey, component, namespaceKey, c.namespace, operationKey, operation, successKey, strconv.FormatBool(success)),
 			c.cryptoCount.M(1))
 
 		if elapsed > 0 {
-			stats.RecordWithTags(
+			recordWithExemplar(
 				ctx,
 				diagUtils.WithTags(c.cryptoLatency.Name(), appIDKey, c.appID, componentKey, component, namespaceKey, c.namespace, operationKey, operation, successKey, strconv.FormatBool(success)),
 				c.cryptoLatency.M(elapsed))
@@ -377,5 +494,5 @@ func (c *componentMetrics) CryptoInvoked(ctx context.Context, component, operati
 }
 
 func ElapsedSince(start time.Time) float64 {
-	return float64(time.Since(start) / time.Millisecond)
+	return Timer{start: start}.Elapsed()
 }
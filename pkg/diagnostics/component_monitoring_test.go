@@ -2,11 +2,18 @@ package diagnostics
 
 import (
 	"context"
+	"errors"
+	"fmt"
 	"testing"
 	"time"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 	"go.opencensus.io/stats/view"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/dapr/components-contrib/state"
 )
 
 const (
@@ -15,7 +22,7 @@ const (
 
 func componentsMetrics() *componentMetrics {
 	c := newComponentMetrics()
-	c.Init("test", "default")
+	c.Init("test", "default", 1)
 
 	return c
 }
@@ -30,6 +37,7 @@ func TestPubSub(t *testing.T) {
 		v := view.Find("component/pubsub_ingress/count")
 
 		allTagsPresent(t, v, viewData[0].Tags)
+		assert.Equal(t, view.AggTypeCount, v.Aggregation.Type)
 	})
 
 	t.Run("record ingress latency", func(t *testing.T) {
@@ -45,6 +53,42 @@ func TestPubSub(t *testing.T) {
 		assert.InEpsilon(t, 1, viewData[0].Data.(*view.DistributionData).Min, 0)
 	})
 
+	t.Run("record sampled ingress count", func(t *testing.T) {
+		// The per-message views are registered again, with the description of the sampling
+		unregisterPubsubViews := func() {
+			componentsMetrics()
+			view.Unregister(
+				view.Find("component/pubsub_ingress/count"), view.Find("component/pubsub_ingress/latencies"),
+				view.Find("component/pubsub_egress/count"), view.Find("component/pubsub_egress/latencies"),
+			)
+		}
+		unregisterPubsubViews()
+		c := newComponentMetrics()
+		require.NoError(t, c.Init("test", "default", 5))
+		t.Cleanup(func() {
+			unregisterPubsubViews()
+			componentsMetrics()
+		})
+
+		for i := 0; i < 1000; i++ {
+			c.PubsubIngressEvent(context.Background(), componentName, "success", "sampled", 0)
+		}
+
+		viewData, _ := view.RetrieveData("component/pubsub_ingress/count")
+		v := view.Find("component/pubsub_ingress/count")
+		assert.Contains(t, v.Description, "Sampled: 1 in 5 occurrences")
+
+		for _, row := range viewData {
+			for _, tag := range row.Tags {
+				if tag.Key == topicKey && tag.Value == "sampled" {
+					sum := row.Data.(*view.SumData).Value
+					assert.Zero(t, int(sum)%5)
+					assert.InDelta(t, 1000, sum, 500)
+				}
+			}
+		}
+	})
+
 	t.Run("record egress latency", func(t *testing.T) {
 		c := componentsMetrics()
 
@@ -57,6 +101,20 @@ func TestPubSub(t *testing.T) {
 
 		assert.InEpsilon(t, 1, viewData[0].Data.(*view.DistributionData).Min, 0)
 	})
+
+	t.Run("record egress backlog", func(t *testing.T) {
+		c := componentsMetrics()
+
+		c.PubsubEgressBacklog(context.Background(), componentName, "A", 10)
+		c.PubsubEgressBacklog(context.Background(), componentName, "A", 42)
+
+		viewData, _ := view.RetrieveData("component/pubsub_egress/backlog")
+		v := view.Find("component/pubsub_egress/backlog")
+
+		allTagsPresent(t, v, viewData[0].Tags)
+
+		assert.InEpsilon(t, 42, viewData[0].Data.(*view.LastValueData).Value, 0)
+	})
 }
 
 func TestBindings(t *testing.T) {
@@ -188,7 +246,7 @@ func TestSecrets(t *testing.T) {
 
 func TestComponentMetricsInit(t *testing.T) {
 	c := componentsMetrics()
-	assert.True(t, c.enabled)
+	assert.True(t, c.enabled.Load())
 	assert.Equal(t, "test", c.appID)
 	assert.Equal(t, "default", c.namespace)
 }
@@ -200,3 +258,38 @@ func TestElapsedSince(t *testing.T) {
 	elapsed := ElapsedSince(start)
 	assert.GreaterOrEqual(t, elapsed, float64(1000))
 }
+
+func TestComponentErrors(t *testing.T) {
+	t.Run("record component health", func(t *testing.T) {
+		c := componentsMetrics()
+
+		c.ComponentInitialized(context.Background(), componentName, "state.redis", true)
+
+		viewData, _ := view.RetrieveData("component/health")
+		v := view.Find("component/health")
+
+		allTagsPresent(t, v, viewData[0].Tags)
+		assert.InEpsilon(t, 1, viewData[0].Data.(*view.LastValueData).Value, 0)
+	})
+
+	t.Run("record operation error", func(t *testing.T) {
+		c := componentsMetrics()
+
+		c.ComponentInitialized(context.Background(), componentName, "state.redis", true)
+		c.OperationFailed(context.Background(), componentName, "get", context.DeadlineExceeded)
+
+		viewData, _ := view.RetrieveData("component/errors_total")
+		v := view.Find("component/errors_total")
+
+		allTagsPresent(t, v, viewData[0].Tags)
+	})
+}
+
+func TestComponentErrorType(t *testing.T) {
+	assert.Equal(t, ErrorTypeTimeout, componentErrorType(fmt.Errorf("failed: %w", context.DeadlineExceeded)))
+	assert.Equal(t, ErrorTypeCanceled, componentErrorType(context.Canceled))
+	assert.Equal(t, ErrorTypeETagMismatch, componentErrorType(state.NewETagError(state.ETagMismatch, nil)))
+	assert.Equal(t, ErrorTypeETagInvalid, componentErrorType(state.NewETagError(state.ETagInvalid, nil)))
+	assert.Equal(t, "unavailable", componentErrorType(status.Error(codes.Unavailable, "unavailable")))
+	assert.Equal(t, ErrorTypeOther, componentErrorType(errors.New("failed")))
+}
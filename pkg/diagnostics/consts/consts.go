@@ -41,6 +41,19 @@ const (
 	DaprAPIProtocolSpanAttributeKey   = "dapr.protocol"
 	DaprAPIInvokeMethod               = "dapr.invoke_method"
 	DaprAPIActorTypeID                = "dapr.actor"
+	DaprAPIActorReminderName          = "dapr.actor.reminder"
+	DaprAPIActorTimerName             = "dapr.actor.timer"
+
+	DaprWorkflowInstanceID     = "dapr.workflow.instance_id"
+	DaprWorkflowName           = "dapr.workflow.name"
+	DaprWorkflowStatus         = "dapr.workflow.status"
+	DaprWorkflowActivityName   = "dapr.workflow.activity"
+	DaprWorkflowActivityTaskID = "dapr.workflow.activity.task_id"
+	DaprWorkflowTimerFireAt    = "dapr.workflow.timer.fire_at"
+	DaprWorkflowEventName      = "dapr.workflow.event"
+
+	DaprPubsubDeadLetterReason = "dapr.pubsub.deadletter.reason"
+	DaprPubsubDeadLetterTopic  = "dapr.pubsub.deadletter.topic"
 
 	DaprAPIHTTPSpanAttrValue = "http"
 	DaprAPIGRPCSpanAttrValue = "grpc"
@@ -15,6 +15,7 @@ package diagnostics
 
 import (
 	"context"
+	"sync/atomic"
 	"time"
 
 	"go.opencensus.io/stats"
@@ -25,7 +26,6 @@ import (
 	"google.golang.org/protobuf/proto"
 
 	diagUtils "github.com/dapr/dapr/pkg/diagnostics/utils"
-	"github.com/dapr/dapr/pkg/grpc/metadata"
 )
 
 // This implementation is inspired by
@@ -42,6 +42,9 @@ var (
 
 const appHealthCheckMethod = "/dapr.proto.runtime.v1.AppCallbackHealthCheck/HealthCheck"
 
+// defaultStreamMessagesDistribution is the distribution of the number of messages per stream.
+var defaultStreamMessagesDistribution = view.Distribution(1, 2, 5, 10, 25, 50, 100, 250, 500, 1000, 2500, 5000, 10000, 50000, 100000)
+
 type grpcMetrics struct {
 	serverReceivedBytes *stats.Int64Measure
 	serverSentBytes     *stats.Int64Measure
@@ -56,8 +59,14 @@ type grpcMetrics struct {
 	healthProbeCompletedCount  *stats.Int64Measure
 	healthProbeRoundripLatency *stats.Float64Measure
 
+	proxyStreamSentMessages     *stats.Int64Measure
+	proxyStreamReceivedMessages *stats.Int64Measure
+	proxyStreamSentBytes        *stats.Int64Measure
+	proxyStreamReceivedBytes    *stats.Int64Measure
+	proxyStreamDuration         *stats.Float64Measure
+
 	appID   string
-	enabled bool
+	enabled atomic.Bool
 }
 
 func newGRPCMetrics() *grpcMetrics {
@@ -105,30 +114,57 @@ func newGRPCMetrics() *grpcMetrics {
 			"Time between first byte of health probes sent to last byte of response received, or terminal error",
 			stats.UnitMilliseconds),
 
-		enabled: false,
+		proxyStreamSentMessages: stats.Int64(
+			"grpc.io/proxy/sent_messages_per_stream",
+			"Total messages sent to the caller per proxied stream.",
+			stats.UnitDimensionless),
+		proxyStreamReceivedMessages: stats.Int64(
+			"grpc.io/proxy/received_messages_per_stream",
+			"Total messages received from the caller per proxied stream.",
+			stats.UnitDimensionless),
+		proxyStreamSentBytes: stats.Int64(
+			"grpc.io/proxy/sent_bytes_per_stream",
+			"Total bytes sent to the caller across all messages per proxied stream.",
+			stats.UnitBytes),
+		proxyStreamReceivedBytes: stats.Int64(
+			"grpc.io/proxy/received_bytes_per_stream",
+			"Total bytes received from the caller across all messages per proxied stream.",
+			stats.UnitBytes),
+		proxyStreamDuration: stats.Float64(
+			"grpc.io/proxy/stream_duration",
+			"Time between the start of a proxied stream and its end, or terminal error.",
+			stats.UnitMilliseconds),
 	}
 }
 
-func (g *grpcMetrics) Init(appID string) error {
+// Init registers the gRPC metrics views.
+// If latency is nil, the latency views use the default distribution.
+func (g *grpcMetrics) Init(appID string, latency *view.Aggregation) error {
 	g.appID = appID
-	g.enabled = true
+	g.enabled.Store(true)
+	latency = latencyOrDefault(latency)
 
-	return view.Register(
+	return registerViews(
 		diagUtils.NewMeasureView(g.serverReceivedBytes, []tag.Key{appIDKey, KeyServerMethod}, defaultSizeDistribution),
 		diagUtils.NewMeasureView(g.serverSentBytes, []tag.Key{appIDKey, KeyServerMethod}, defaultSizeDistribution),
-		diagUtils.NewMeasureView(g.serverLatency, []tag.Key{appIDKey, KeyServerMethod, KeyServerStatus}, defaultLatencyDistribution),
+		diagUtils.NewMeasureView(g.serverLatency, []tag.Key{appIDKey, KeyServerMethod, KeyServerStatus}, latency),
 		diagUtils.NewMeasureView(g.serverCompletedRpcs, []tag.Key{appIDKey, KeyServerMethod, KeyServerStatus}, view.Count()),
 		diagUtils.NewMeasureView(g.clientSentBytes, []tag.Key{appIDKey, KeyClientMethod}, defaultSizeDistribution),
 		diagUtils.NewMeasureView(g.clientReceivedBytes, []tag.Key{appIDKey, KeyClientMethod}, defaultSizeDistribution),
-		diagUtils.NewMeasureView(g.clientRoundtripLatency, []tag.Key{appIDKey, KeyClientMethod, KeyClientStatus}, defaultLatencyDistribution),
+		diagUtils.NewMeasureView(g.clientRoundtripLatency, []tag.Key{appIDKey, KeyClientMethod, KeyClientStatus}, latency),
 		diagUtils.NewMeasureView(g.clientCompletedRpcs, []tag.Key{appIDKey, KeyClientMethod, KeyClientStatus}, view.Count()),
-		diagUtils.NewMeasureView(g.healthProbeRoundripLatency, []tag.Key{appIDKey, KeyClientStatus}, defaultLatencyDistribution),
+		diagUtils.NewMeasureView(g.healthProbeRoundripLatency, []tag.Key{appIDKey, KeyClientStatus}, latency),
 		diagUtils.NewMeasureView(g.healthProbeCompletedCount, []tag.Key{appIDKey, KeyClientStatus}, view.Count()),
+		diagUtils.NewMeasureView(g.proxyStreamSentMessages, []tag.Key{appIDKey, serverKindKey, KeyServerMethod}, defaultStreamMessagesDistribution),
+		diagUtils.NewMeasureView(g.proxyStreamReceivedMessages, []tag.Key{appIDKey, serverKindKey, KeyServerMethod}, defaultStreamMessagesDistribution),
+		diagUtils.NewMeasureView(g.proxyStreamSentBytes, []tag.Key{appIDKey, serverKindKey, KeyServerMethod}, defaultSizeDistribution),
+		diagUtils.NewMeasureView(g.proxyStreamReceivedBytes, []tag.Key{appIDKey, serverKindKey, KeyServerMethod}, defaultSizeDistribution),
+		diagUtils.NewMeasureView(g.proxyStreamDuration, []tag.Key{appIDKey, serverKindKey, KeyServerMethod, KeyServerStatus}, latency),
 	)
 }
 
 func (g *grpcMetrics) IsEnabled() bool {
-	return g != nil && g.enabled
+	return g != nil && g.enabled.Load()
 }
 
 func (g *grpcMetrics) ServerRequestSent(ctx context.Context, method, status string, reqContentSize, resContentSize int64, start time.Time) {
@@ -136,7 +172,7 @@ func (g *grpcMetrics) ServerRequestSent(ctx context.Context, method, status stri
 		return
 	}
 
-	elapsed := float64(time.Since(start) / time.Millisecond)
+	elapsed := ElapsedSince(start)
 	stats.RecordWithTags(ctx,
 		diagUtils.WithTags(g.serverCompletedRpcs.Name(), appIDKey, g.appID, KeyServerMethod, method, KeyServerStatus, status),
 		g.serverCompletedRpcs.M(1))
@@ -146,7 +182,7 @@ func (g *grpcMetrics) ServerRequestSent(ctx context.Context, method, status stri
 	stats.RecordWithTags(ctx,
 		diagUtils.WithTags(g.serverSentBytes.Name(), appIDKey, g.appID, KeyServerMethod, method),
 		g.serverSentBytes.M(resContentSize))
-	stats.RecordWithTags(ctx,
+	recordWithExemplar(ctx,
 		diagUtils.WithTags(g.serverLatency.Name(), appIDKey, g.appID, KeyServerMethod, method, KeyServerStatus, status),
 		g.serverLatency.M(elapsed))
 }
@@ -156,11 +192,11 @@ func (g *grpcMetrics) StreamServerRequestSent(ctx context.Context, method, statu
 		return
 	}
 
-	elapsed := float64(time.Since(start) / time.Millisecond)
+	elapsed := ElapsedSince(start)
 	stats.RecordWithTags(ctx,
 		diagUtils.WithTags(g.serverCompletedRpcs.Name(), appIDKey, g.appID, KeyServerMethod, method, KeyServerStatus, status),
 		g.serverCompletedRpcs.M(1))
-	stats.RecordWithTags(ctx,
+	recordWithExemplar(ctx,
 		diagUtils.WithTags(g.serverLatency.Name(), appIDKey, g.appID, KeyServerMethod, method, KeyServerStatus, status),
 		g.serverLatency.M(elapsed))
 }
@@ -170,11 +206,11 @@ func (g *grpcMetrics) StreamClientRequestSent(ctx context.Context, method, statu
 		return
 	}
 
-	elapsed := float64(time.Since(start) / time.Millisecond)
+	elapsed := ElapsedSince(start)
 	stats.RecordWithTags(ctx,
 		diagUtils.WithTags(g.clientCompletedRpcs.Name(), appIDKey, g.appID, KeyClientMethod, method, KeyClientStatus, status),
 		g.clientCompletedRpcs.M(1))
-	stats.RecordWithTags(ctx,
+	recordWithExemplar(ctx,
 		diagUtils.WithTags(g.clientRoundtripLatency.Name(), appIDKey, g.appID, KeyClientMethod, method, KeyClientStatus, status),
 		g.clientRoundtripLatency.M(elapsed))
 }
@@ -184,11 +220,11 @@ func (g *grpcMetrics) ClientRequestReceived(ctx context.Context, method, status
 		return
 	}
 
-	elapsed := float64(time.Since(start) / time.Millisecond)
+	elapsed := ElapsedSince(start)
 	stats.RecordWithTags(ctx,
 		diagUtils.WithTags(g.clientCompletedRpcs.Name(), appIDKey, g.appID, KeyClientMethod, method, KeyClientStatus, status),
 		g.clientCompletedRpcs.M(1))
-	stats.RecordWithTags(ctx,
+	recordWithExemplar(ctx,
 		diagUtils.WithTags(g.clientRoundtripLatency.Name(), appIDKey, g.appID, KeyClientMethod, method, KeyClientStatus, status),
 		g.clientRoundtripLatency.M(elapsed))
 	stats.RecordWithTags(ctx,
@@ -204,31 +240,41 @@ func (g *grpcMetrics) AppHealthProbeCompleted(ctx context.Context, status string
 		return
 	}
 
-	elapsed := float64(time.Since(start) / time.Millisecond)
+	elapsed := ElapsedSince(start)
 	stats.RecordWithTags(ctx,
 		diagUtils.WithTags(g.healthProbeCompletedCount.Name(), appIDKey, g.appID, KeyClientStatus, status),
 		g.healthProbeCompletedCount.M(1))
-	stats.RecordWithTags(ctx,
+	recordWithExemplar(ctx,
 		diagUtils.WithTags(g.healthProbeRoundripLatency.Name(), appIDKey, g.appID, KeyClientStatus, status),
 		g.healthProbeRoundripLatency.M(elapsed))
 }
 
-func (g *grpcMetrics) getPayloadSize(payload interface{}) int {
-	return proto.Size(payload.(proto.Message))
+// ProxyStreamCompleted records the messages and bytes that went through a proxied stream, and its duration.
+func (g *grpcMetrics) ProxyStreamCompleted(ctx context.Context, kind ServerKind, method, status string, sent, received, sentBytes, receivedBytes int64, start time.Time) {
+	if !g.IsEnabled() {
+		return
+	}
+
+	elapsed := ElapsedSince(start)
+	stats.RecordWithTags(ctx,
+		diagUtils.WithTags(g.proxyStreamSentMessages.Name(), appIDKey, g.appID, serverKindKey, string(kind), KeyServerMethod, method),
+		g.proxyStreamSentMessages.M(sent))
+	stats.RecordWithTags(ctx,
+		diagUtils.WithTags(g.proxyStreamReceivedMessages.Name(), appIDKey, g.appID, serverKindKey, string(kind), KeyServerMethod, method),
+		g.proxyStreamReceivedMessages.M(received))
+	stats.RecordWithTags(ctx,
+		diagUtils.WithTags(g.proxyStreamSentBytes.Name(), appIDKey, g.appID, serverKindKey, string(kind), KeyServerMethod, method),
+		g.proxyStreamSentBytes.M(sentBytes))
+	stats.RecordWithTags(ctx,
+		diagUtils.WithTags(g.proxyStreamReceivedBytes.Name(), appIDKey, g.appID, serverKindKey, string(kind), KeyServerMethod, method),
+		g.proxyStreamReceivedBytes.M(receivedBytes))
+	recordWithExemplar(ctx,
+		diagUtils.WithTags(g.proxyStreamDuration.Name(), appIDKey, g.appID, serverKindKey, string(kind), KeyServerMethod, method, KeyServerStatus, status),
+		g.proxyStreamDuration.M(elapsed))
 }
 
-// UnaryServerInterceptor is a gRPC server-side interceptor for Unary RPCs.
-func (g *grpcMetrics) UnaryServerInterceptor() func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
-	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
-		start := time.Now()
-		resp, err := handler(ctx, req)
-		size := 0
-		if err == nil {
-			size = g.getPayloadSize(resp)
-		}
-		g.ServerRequestSent(ctx, info.FullMethod, status.Code(err).String(), int64(g.getPayloadSize(req)), int64(size), start)
-		return resp, err
-	}
+func (g *grpcMetrics) getPayloadSize(payload interface{}) int {
+	return proto.Size(payload.(proto.Message))
 }
 
 // UnaryClientInterceptor is a gRPC client-side interceptor for Unary RPCs.
@@ -251,39 +297,3 @@ func (g *grpcMetrics) UnaryClientInterceptor() func(ctx context.Context, method
 		return err
 	}
 }
-
-// StreamingServerInterceptor is a stream interceptor for gRPC proxying calls that arrive from the application to Dapr
-func (g *grpcMetrics) StreamingServerInterceptor() grpc.StreamServerInterceptor {
-	return func(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
-		ctx := ss.Context()
-		md, _ := metadata.FromIncomingContext(ctx)
-		vals, ok := md[GRPCProxyAppIDKey]
-		if !ok || len(vals) == 0 {
-			return handler(srv, ss)
-		}
-
-		now := time.Now()
-		err := handler(srv, ss)
-		g.StreamServerRequestSent(ctx, info.FullMethod, status.Code(err).String(), now)
-
-		return err
-	}
-}
-
-// StreamingClientInterceptor is a stream interceptor for gRPC proxying calls that arrive from a remote Dapr sidecar
-func (g *grpcMetrics) StreamingClientInterceptor() grpc.StreamServerInterceptor {
-	return func(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
-		ctx := ss.Context()
-		md, _ := metadata.FromIncomingContext(ctx)
-		vals, ok := md[GRPCProxyAppIDKey]
-		if !ok || len(vals) == 0 {
-			return handler(srv, ss)
-		}
-
-		now := time.Now()
-		err := handler(srv, ss)
-		g.StreamClientRequestSent(ctx, info.FullMethod, status.Code(err).String(), now)
-
-		return err
-	}
-}
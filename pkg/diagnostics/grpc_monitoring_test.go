@@ -24,6 +24,7 @@ import (
 	grpcMetadata "google.golang.org/grpc/metadata"
 
 	"github.com/dapr/dapr/pkg/grpc/metadata"
+	commonv1pb "github.com/dapr/dapr/pkg/proto/common/v1"
 )
 
 type fakeProxyStream struct {
@@ -63,9 +64,9 @@ func (f *fakeProxyStream) RecvMsg(m interface{}) error {
 func TestStreamingServerInterceptor(t *testing.T) {
 	t.Run("not a proxy request, do not run pipeline", func(t *testing.T) {
 		m := newGRPCMetrics()
-		m.Init("test")
+		m.Init("test", nil)
 
-		i := m.StreamingServerInterceptor()
+		i := newServerMetrics(newHTTPMetrics(), m).StreamServerInterceptor(ServerKindAPI)
 		s := &fakeProxyStream{}
 		f := func(srv interface{}, stream grpc.ServerStream) error {
 			return nil
@@ -85,9 +86,9 @@ func TestStreamingServerInterceptor(t *testing.T) {
 
 	t.Run("proxy request, run pipeline", func(t *testing.T) {
 		m := newGRPCMetrics()
-		m.Init("test")
+		m.Init("test", nil)
 
-		i := m.StreamingServerInterceptor()
+		i := newServerMetrics(newHTTPMetrics(), m).StreamServerInterceptor(ServerKindAPI)
 		s := &fakeProxyStream{
 			appID: "test",
 		}
@@ -112,14 +113,55 @@ func TestStreamingServerInterceptor(t *testing.T) {
 		assert.Equal(t, "grpc_server_method", rows[0].Tags[1].Key.Name())
 		assert.Equal(t, "grpc_server_status", rows[0].Tags[2].Key.Name())
 	})
+
+	t.Run("proxy request, record stream messages", func(t *testing.T) {
+		m := newGRPCMetrics()
+		m.Init("test", nil)
+
+		i := newServerMetrics(newHTTPMetrics(), m).StreamServerInterceptor(ServerKindAPI)
+		s := &fakeProxyStream{
+			appID: "test",
+		}
+		f := func(srv interface{}, stream grpc.ServerStream) error {
+			stream.SendMsg(&commonv1pb.Etag{Value: "hello"})
+			stream.SendMsg(&commonv1pb.Etag{Value: "world"})
+			stream.RecvMsg(&commonv1pb.Etag{})
+			return nil
+		}
+
+		err := i(nil, s, &grpc.StreamServerInfo{FullMethod: "/appv1.Stream"}, f)
+		require.NoError(t, err)
+
+		// Returns the distribution recorded for the stream, as the views are shared with the other tests
+		streamData := func(name string) *view.DistributionData {
+			rows, err := view.RetrieveData(name)
+			require.NoError(t, err)
+			for _, row := range rows {
+				for _, tag := range row.Tags {
+					if tag.Key == KeyServerMethod && tag.Value == "/appv1.Stream" {
+						return row.Data.(*view.DistributionData)
+					}
+				}
+			}
+			require.Failf(t, "missing row", "no row for the stream in view %s", name)
+			return nil
+		}
+
+		sent := streamData("grpc.io/proxy/sent_messages_per_stream")
+		assert.Equal(t, int64(1), sent.Count)
+		assert.Equal(t, float64(2), sent.Mean)
+		assert.Equal(t, float64(7), streamData("grpc.io/proxy/sent_bytes_per_stream").Mean)
+		assert.Equal(t, float64(1), streamData("grpc.io/proxy/received_messages_per_stream").Mean)
+		assert.Equal(t, int64(1), streamData("grpc.io/proxy/stream_duration").Count)
+	})
 }
 
 func TestStreamingClientInterceptor(t *testing.T) {
 	t.Run("not a proxy request, do not run pipeline", func(t *testing.T) {
 		m := newGRPCMetrics()
-		m.Init("test")
+		m.Init("test", nil)
 
-		i := m.StreamingClientInterceptor()
+		i := newServerMetrics(newHTTPMetrics(), m).StreamServerInterceptor(ServerKindInternal)
 		s := &fakeProxyStream{}
 		f := func(srv interface{}, stream grpc.ServerStream) error {
 			return nil
@@ -139,9 +181,9 @@ func TestStreamingClientInterceptor(t *testing.T) {
 
 	t.Run("proxy request, run pipeline", func(t *testing.T) {
 		m := newGRPCMetrics()
-		m.Init("test")
+		m.Init("test", nil)
 
-		i := m.StreamingClientInterceptor()
+		i := newServerMetrics(newHTTPMetrics(), m).StreamServerInterceptor(ServerKindInternal)
 		s := &fakeProxyStream{
 			appID: "test",
 		}
@@ -0,0 +1,130 @@
+/*
+Copyright 2023 The Dapr Authors
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package diagnostics
+
+import (
+	"sync/atomic"
+	"time"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+	"google.golang.org/grpc"
+	"google.golang.org/protobuf/proto"
+
+	"github.com/dapr/dapr/pkg/grpc/proxy/codec"
+)
+
+const (
+	// proxyStreamProgressEvent is the name of the span events with the progress of long-lived proxied streams.
+	proxyStreamProgressEvent = "grpc.stream.progress"
+
+	proxyStreamSentMessagesAttr     = "rpc.grpc.stream.sent_messages"
+	proxyStreamReceivedMessagesAttr = "rpc.grpc.stream.received_messages"
+	proxyStreamSentBytesAttr        = "rpc.grpc.stream.sent_bytes"
+	proxyStreamReceivedBytesAttr    = "rpc.grpc.stream.received_bytes"
+)
+
+// proxyStreamEventInterval is the interval at which the progress of proxied streams is added to their spans as events.
+var proxyStreamEventInterval = 30 * time.Second
+
+// streamStats counts the messages and bytes sent and received on a stream.
+type streamStats struct {
+	sentMessages     atomic.Int64
+	receivedMessages atomic.Int64
+	sentBytes        atomic.Int64
+	receivedBytes    atomic.Int64
+}
+
+// attributes returns the counters as span attributes.
+func (s *streamStats) attributes() []attribute.KeyValue {
+	return []attribute.KeyValue{
+		attribute.Int64(proxyStreamSentMessagesAttr, s.sentMessages.Load()),
+		attribute.Int64(proxyStreamReceivedMessagesAttr, s.receivedMessages.Load()),
+		attribute.Int64(proxyStreamSentBytesAttr, s.sentBytes.Load()),
+		attribute.Int64(proxyStreamReceivedBytesAttr, s.receivedBytes.Load()),
+	}
+}
+
+// countingServerStream is a grpc.ServerStream that counts the messages and bytes sent and received.
+type countingServerStream struct {
+	grpc.ServerStream
+
+	stats *streamStats
+}
+
+func newCountingServerStream(ss grpc.ServerStream) *countingServerStream {
+	return &countingServerStream{
+		ServerStream: ss,
+		stats:        &streamStats{},
+	}
+}
+
+func (s *countingServerStream) SendMsg(m any) error {
+	err := s.ServerStream.SendMsg(m)
+	if err == nil {
+		s.stats.sentMessages.Add(1)
+		s.stats.sentBytes.Add(streamMessageSize(m))
+	}
+	return err
+}
+
+func (s *countingServerStream) RecvMsg(m any) error {
+	err := s.ServerStream.RecvMsg(m)
+	if err == nil {
+		s.stats.receivedMessages.Add(1)
+		s.stats.receivedBytes.Add(streamMessageSize(m))
+	}
+	return err
+}
+
+// streamMessageSize returns the size of a message of a stream.
+// Proxied streams carry raw frames, whose size is the size of their payload.
+func streamMessageSize(m any) int64 {
+	switch v := m.(type) {
+	case *codec.Frame:
+		return int64(len(v.Raw()))
+	case proto.Message:
+		return int64(proto.Size(v))
+	default:
+		return 0
+	}
+}
+
+// startStreamSpanEvents periodically adds the progress of the stream to the span as events, until the returned function is called.
+func startStreamSpanEvents(span trace.Span, stats *streamStats) (stop func()) {
+	if !span.IsRecording() {
+		return func() {}
+	}
+
+	done := make(chan struct{})
+	stopped := make(chan struct{})
+	go func() {
+		defer close(stopped)
+		ticker := time.NewTicker(proxyStreamEventInterval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-done:
+				return
+			case <-ticker.C:
+				span.AddEvent(proxyStreamProgressEvent, trace.WithAttributes(stats.attributes()...))
+			}
+		}
+	}()
+
+	return func() {
+		close(done)
+		<-stopped
+	}
+}
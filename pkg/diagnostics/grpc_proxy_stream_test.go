@@ -0,0 +1,100 @@
+/*
+Copyright 2023 The Dapr Authors
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package diagnostics
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"google.golang.org/grpc"
+	grpcMetadata "google.golang.org/grpc/metadata"
+
+	"github.com/dapr/dapr/pkg/config"
+	"github.com/dapr/dapr/pkg/grpc/metadata"
+	"github.com/dapr/dapr/pkg/grpc/proxy/codec"
+	commonv1pb "github.com/dapr/dapr/pkg/proto/common/v1"
+)
+
+func testFrame(t *testing.T, payload string) *codec.Frame {
+	t.Helper()
+
+	f := &codec.Frame{}
+	require.NoError(t, (&codec.Proxy{}).Unmarshal([]byte(payload), f))
+	return f
+}
+
+func TestStreamMessageSize(t *testing.T) {
+	assert.Equal(t, int64(5), streamMessageSize(testFrame(t, "hello")))
+	assert.Equal(t, int64(7), streamMessageSize(&commonv1pb.Etag{Value: "hello"}))
+	assert.Equal(t, int64(0), streamMessageSize("hello"))
+}
+
+func TestCountingServerStream(t *testing.T) {
+	s := newCountingServerStream(&fakeStream{})
+	require.NoError(t, s.SendMsg(testFrame(t, "hello")))
+	require.NoError(t, s.SendMsg(testFrame(t, "world!")))
+	require.NoError(t, s.RecvMsg(&codec.Frame{}))
+
+	assert.Equal(t, int64(2), s.stats.sentMessages.Load())
+	assert.Equal(t, int64(11), s.stats.sentBytes.Load())
+	assert.Equal(t, int64(1), s.stats.receivedMessages.Load())
+	assert.Equal(t, int64(0), s.stats.receivedBytes.Load())
+}
+
+func TestProxyStreamSpanEvents(t *testing.T) {
+	recorder := tracetest.NewSpanRecorder()
+	oldTracerProvider := otel.GetTracerProvider()
+	otel.SetTracerProvider(sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder)))
+	oldInterval := proxyStreamEventInterval
+	proxyStreamEventInterval = 10 * time.Millisecond
+	t.Cleanup(func() {
+		otel.SetTracerProvider(oldTracerProvider)
+		proxyStreamEventInterval = oldInterval
+	})
+
+	interceptor := GRPCTraceStreamServerInterceptor("test", config.TracingSpec{SamplingRate: "1"})
+
+	ctx := grpcMetadata.NewIncomingContext(context.Background(), grpcMetadata.New(map[string]string{
+		GRPCProxyAppIDKey: "myapp",
+	}))
+	ctx, _ = metadata.SetMetadataInTapHandle(ctx, nil)
+
+	handler := func(srv any, stream grpc.ServerStream) error {
+		require.NoError(t, stream.SendMsg(testFrame(t, "hello")))
+		time.Sleep(50 * time.Millisecond)
+		return nil
+	}
+	err := interceptor(nil, &fakeStream{ctx}, &grpc.StreamServerInfo{FullMethod: "/myapp.v1.DoSomething"}, handler)
+	require.NoError(t, err)
+
+	ended := recorder.Ended()
+	require.Len(t, ended, 1)
+	span := ended[0]
+
+	events := span.Events()
+	require.NotEmpty(t, events)
+	assert.Equal(t, proxyStreamProgressEvent, events[0].Name)
+	assert.Contains(t, events[0].Attributes, attribute.Int64(proxyStreamSentMessagesAttr, 1))
+
+	assert.Contains(t, span.Attributes(), attribute.Int64(proxyStreamSentMessagesAttr, 1))
+	assert.Contains(t, span.Attributes(), attribute.Int64(proxyStreamSentBytesAttr, 5))
+	assert.Contains(t, span.Attributes(), attribute.Int64(proxyStreamReceivedMessagesAttr, 0))
+}
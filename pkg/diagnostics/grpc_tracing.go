@@ -65,11 +65,12 @@ func GRPCTraceUnaryServerInterceptor(appID string, spec config.TracingSpec) grpc
 		}
 
 		ctx = trace.ContextWithRemoteSpanContext(ctx, sc)
+		ctx = ContextWithBaggageFromIncomingGRPCMetadata(ctx)
 		ctx, span = tracer.Start(ctx, info.FullMethod, spanKind)
 
 		resp, err := handler(ctx, req)
 
-		if span.SpanContext().IsSampled() {
+		if span.IsRecording() {
 			// users can add dapr- prefix if they want to see the header values in span attributes.
 			prefixedMetadata = userDefinedMetadata(ctx)
 			reqSpanAttr = spanAttributesMapFromGRPC(appID, req, info.FullMethod)
@@ -79,6 +80,7 @@ func GRPCTraceUnaryServerInterceptor(appID string, spec config.TracingSpec) grpc
 				prefixedMetadata[key] = value
 			}
 			AddAttributesToSpan(span, prefixedMetadata)
+			AddAttributesToSpan(span, headerSpanAttributes(spec, incomingMetadataValue(ctx)))
 
 			// Correct the span name based on API.
 			if sname, ok := reqSpanAttr[diagConsts.DaprAPISpanNameInternal]; ok {
@@ -144,13 +146,24 @@ func GRPCTraceStreamServerInterceptor(appID string, spec config.TracingSpec) grp
 		// Overwrite context
 		sc, _ := SpanContextFromIncomingGRPCMetadata(ctx)
 		ctx = trace.ContextWithRemoteSpanContext(ctx, sc)
+		ctx = ContextWithBaggageFromIncomingGRPCMetadata(ctx)
 		ctx, span = tracer.Start(ctx, info.FullMethod, spanKind)
 		wrapped := grpcMiddleware.WrapServerStream(ss)
 		wrapped.WrappedContext = ctx
 
-		err := handler(srv, wrapped)
+		var err error
+		if isProxied {
+			// Proxied streams can be long-lived, so their progress is periodically added to the span
+			counting := newCountingServerStream(wrapped)
+			stop := startStreamSpanEvents(span, counting.stats)
+			err = handler(srv, counting)
+			stop()
+			span.SetAttributes(counting.stats.attributes()...)
+		} else {
+			err = handler(srv, wrapped)
+		}
 
-		if span.SpanContext().IsSampled() {
+		if span.IsRecording() {
 			var (
 				prefixedMetadata map[string]string
 				reqSpanAttr      map[string]string
@@ -171,6 +184,7 @@ func GRPCTraceStreamServerInterceptor(appID string, spec config.TracingSpec) grp
 				prefixedMetadata[key] = value
 			}
 			AddAttributesToSpan(span, prefixedMetadata)
+			AddAttributesToSpan(span, headerSpanAttributes(spec, incomingMetadataValue(ctx)))
 
 			// Correct the span name based on API.
 			if sname, ok := reqSpanAttr[diagConsts.DaprAPISpanNameInternal]; ok {
@@ -191,6 +205,18 @@ func GRPCTraceStreamServerInterceptor(appID string, spec config.TracingSpec) grp
 	}
 }
 
+// incomingMetadataValue returns a function that reads the last value of a key of the incoming metadata in ctx.
+func incomingMetadataValue(ctx context.Context) func(key string) string {
+	md, _ := metadata.FromIncomingContext(ctx)
+	return func(key string) string {
+		vals := md.Get(key)
+		if len(vals) == 0 {
+			return ""
+		}
+		return vals[len(vals)-1]
+	}
+}
+
 // userDefinedMetadata returns dapr- prefixed header from incoming metadata.
 // Users can add dapr- prefixed headers that they want to see in span attributes.
 func userDefinedMetadata(ctx context.Context) map[string]string {
@@ -245,30 +271,37 @@ func SpanContextFromIncomingGRPCMetadata(ctx context.Context) (trace.SpanContext
 	if len(traceContext) > 0 {
 		sc, ok = diagUtils.SpanContextFromBinary([]byte(traceContext[0]))
 	} else {
-		// add workaround to fallback on checking traceparent header
+		// add workaround to fallback on checking the headers of the propagation formats, such as traceparent
 		// as grpc-trace-bin is not yet there in OpenTelemetry unlike OpenCensus , tracking issue https://github.com/open-telemetry/opentelemetry-specification/issues/639
 		// and grpc-dotnet client adheres to OpenTelemetry Spec which only supports http based traceparent header in gRPC path
 		// TODO : Remove this workaround fix once grpc-dotnet supports grpc-trace-bin header. Tracking issue https://github.com/dapr/dapr/issues/1827
-		traceContext = md[TraceparentHeader]
-		if len(traceContext) > 0 {
-			sc, ok = SpanContextFromW3CString(traceContext[0])
-			if ok && len(md[TracestateHeader]) > 0 {
-				ts := TraceStateFromW3CString(md[TracestateHeader][0])
-				sc.WithTraceState(*ts)
+		sc, ok = spanContextFromHeaders(func(key string) string {
+			if vals := md[key]; len(vals) > 0 {
+				return vals[0]
 			}
-		}
+			return ""
+		})
 	}
 	return sc, ok
 }
 
 // SpanContextToGRPCMetadata appends binary serialized SpanContext to the outgoing GRPC context.
+// The SpanContext is also appended in the headers of the B3 propagation formats, if enabled, for the services that don't understand grpc-trace-bin.
 func SpanContextToGRPCMetadata(ctx context.Context, spanContext trace.SpanContext) context.Context {
 	traceContextBinary := diagUtils.BinaryFromSpanContext(spanContext)
 	if len(traceContextBinary) == 0 {
 		return ctx
 	}
 
-	return grpcMetadata.AppendToOutgoingContext(ctx, GRPCTraceContextKey, string(traceContextBinary))
+	kv := []string{GRPCTraceContextKey, string(traceContextBinary)}
+	for _, f := range getPropagationFormats() {
+		if f != PropagationW3C {
+			spanContextToHeaders(spanContext, []PropagationFormat{f}, func(key, val string) {
+				kv = append(kv, key, val)
+			})
+		}
+	}
+	return grpcMetadata.AppendToOutgoingContext(ctx, kv...)
 }
 
 // spanAttributesMapFromGRPC builds the span trace attributes map for gRPC calls based on given parameters as per open-telemetry specs.
@@ -16,17 +16,14 @@ package diagnostics
 import (
 	"context"
 	"net/http"
-	"strconv"
 	"strings"
-	"time"
+	"sync/atomic"
 
 	"go.opencensus.io/stats"
 	"go.opencensus.io/stats/view"
 	"go.opencensus.io/tag"
 
 	diagUtils "github.com/dapr/dapr/pkg/diagnostics/utils"
-	"github.com/dapr/dapr/pkg/http/endpoints"
-	"github.com/dapr/dapr/pkg/responsewriter"
 )
 
 // To track the metrics for fasthttp using opencensus, this implementation is inspired by
@@ -36,6 +33,7 @@ import (
 var (
 	httpStatusCodeKey = tag.MustNewKey("status")
 	httpMethodKey     = tag.MustNewKey("method")
+	httpRouteKey      = tag.MustNewKey("route")
 )
 
 var (
@@ -49,6 +47,7 @@ type httpMetrics struct {
 	serverResponseBytes *stats.Int64Measure
 	serverLatency       *stats.Float64Measure
 	serverRequestCount  *stats.Int64Measure
+	serverRateLimited   *stats.Int64Measure
 
 	clientSentBytes        *stats.Int64Measure
 	clientReceivedBytes    *stats.Int64Measure
@@ -58,8 +57,11 @@ type httpMetrics struct {
 	healthProbeCompletedCount  *stats.Int64Measure
 	healthProbeRoundripLatency *stats.Float64Measure
 
+	// routes are the templates of the app routes the server metrics are tagged with; nil if not configured.
+	routes *httpRoutes
+
 	appID   string
-	enabled bool
+	enabled atomic.Bool
 }
 
 func newHTTPMetrics() *httpMetrics {
@@ -80,6 +82,10 @@ func newHTTPMetrics() *httpMetrics {
 			"http/server/request_count",
 			"Count of HTTP requests processed by the server.",
 			stats.UnitDimensionless),
+		serverRateLimited: stats.Int64(
+			"http/server/rate_limited_count",
+			"Count of HTTP requests rejected by the server because they exceeded the rate limit of their API.",
+			stats.UnitDimensionless),
 		clientSentBytes: stats.Int64(
 			"http/client/sent_bytes",
 			"Total bytes sent in request body (not including headers)",
@@ -104,27 +110,27 @@ func newHTTPMetrics() *httpMetrics {
 			"http/healthprobes/roundtrip_latency",
 			"Time between first byte of health probes headers sent to last byte of response received, or terminal error",
 			stats.UnitMilliseconds),
-
-		enabled: false,
 	}
 }
 
 func (h *httpMetrics) IsEnabled() bool {
-	return h != nil && h.enabled
+	return h != nil && h.enabled.Load()
 }
 
-func (h *httpMetrics) ServerRequestCompleted(ctx context.Context, method string, status string, reqContentSize, resContentSize int64, elapsed float64) {
+// ServerRequestCompleted records a request completed by the server.
+// route is the template of the app route that was invoked, which is empty if the routes are not configured.
+func (h *httpMetrics) ServerRequestCompleted(ctx context.Context, method, route, status string, reqContentSize, resContentSize int64, elapsed float64) {
 	if !h.IsEnabled() {
 		return
 	}
 
 	stats.RecordWithTags(
 		ctx,
-		diagUtils.WithTags(h.serverRequestCount.Name(), appIDKey, h.appID, httpMethodKey, method, httpStatusCodeKey, status),
+		diagUtils.WithTags(h.serverRequestCount.Name(), appIDKey, h.appID, httpMethodKey, method, httpRouteKey, route, httpStatusCodeKey, status),
 		h.serverRequestCount.M(1))
-	stats.RecordWithTags(
+	recordWithExemplar(
 		ctx,
-		diagUtils.WithTags(h.serverLatency.Name(), appIDKey, h.appID, httpMethodKey, method, httpStatusCodeKey, status),
+		diagUtils.WithTags(h.serverLatency.Name(), appIDKey, h.appID, httpMethodKey, method, httpRouteKey, route, httpStatusCodeKey, status),
 		h.serverLatency.M(elapsed))
 	stats.RecordWithTags(
 		ctx, diagUtils.WithTags(h.serverRequestBytes.Name(), appIDKey, h.appID),
@@ -134,6 +140,18 @@ func (h *httpMetrics) ServerRequestCompleted(ctx context.Context, method string,
 		h.serverResponseBytes.M(resContentSize))
 }
 
+// ServerRequestRateLimited records a request rejected because it exceeded the rate limit of the API of the building block.
+func (h *httpMetrics) ServerRequestRateLimited(ctx context.Context, buildingBlock string) {
+	if !h.IsEnabled() {
+		return
+	}
+
+	stats.RecordWithTags(
+		ctx,
+		diagUtils.WithTags(h.serverRateLimited.Name(), appIDKey, h.appID, buildingBlockKey, buildingBlock),
+		h.serverRateLimited.M(1))
+}
+
 func (h *httpMetrics) ClientRequestStarted(ctx context.Context, contentSize int64) {
 	if !h.IsEnabled() {
 		return
@@ -154,7 +172,7 @@ func (h *httpMetrics) ClientRequestCompleted(ctx context.Context, status string,
 		ctx,
 		diagUtils.WithTags(h.clientCompletedCount.Name(), appIDKey, h.appID, httpStatusCodeKey, status),
 		h.clientCompletedCount.M(1))
-	stats.RecordWithTags(
+	recordWithExemplar(
 		ctx,
 		diagUtils.WithTags(h.clientRoundtripLatency.Name(), appIDKey, h.appID, httpStatusCodeKey, status),
 		h.clientRoundtripLatency.M(elapsed))
@@ -180,63 +198,43 @@ func (h *httpMetrics) AppHealthProbeCompleted(ctx context.Context, status string
 		ctx,
 		diagUtils.WithTags(h.healthProbeCompletedCount.Name(), appIDKey, h.appID, httpStatusCodeKey, status),
 		h.healthProbeCompletedCount.M(1))
-	stats.RecordWithTags(
+	recordWithExemplar(
 		ctx,
 		diagUtils.WithTags(h.healthProbeRoundripLatency.Name(), appIDKey, h.appID, httpStatusCodeKey, status),
 		h.healthProbeRoundripLatency.M(elapsed))
 }
 
-func (h *httpMetrics) Init(appID string) error {
+// Init registers the HTTP metrics views.
+// If routes is not empty, the server metrics are tagged with the template of the app route that was invoked.
+// If latency is nil, the latency views use the default distribution.
+func (h *httpMetrics) Init(appID string, routes []string, latency *view.Aggregation) error {
 	h.appID = appID
-	h.enabled = true
+	h.enabled.Store(true)
+	h.routes = newHTTPRoutes(routes)
+	latency = latencyOrDefault(latency)
 
 	tags := []tag.Key{appIDKey}
-	return view.Register(
+	return registerViews(
 		diagUtils.NewMeasureView(h.serverRequestBytes, tags, defaultSizeDistribution),
 		diagUtils.NewMeasureView(h.serverResponseBytes, tags, defaultSizeDistribution),
-		diagUtils.NewMeasureView(h.serverLatency, []tag.Key{appIDKey, httpMethodKey, httpStatusCodeKey}, defaultLatencyDistribution),
-		diagUtils.NewMeasureView(h.serverRequestCount, []tag.Key{appIDKey, httpMethodKey, httpStatusCodeKey}, view.Count()),
+		diagUtils.NewMeasureView(h.serverLatency, []tag.Key{appIDKey, httpMethodKey, httpRouteKey, httpStatusCodeKey}, latency),
+		diagUtils.NewMeasureView(h.serverRequestCount, []tag.Key{appIDKey, httpMethodKey, httpRouteKey, httpStatusCodeKey}, view.Count()),
+		diagUtils.NewMeasureView(h.serverRateLimited, []tag.Key{appIDKey, buildingBlockKey}, view.Count()),
 		diagUtils.NewMeasureView(h.clientSentBytes, []tag.Key{appIDKey, httpStatusCodeKey}, defaultSizeDistribution),
 		diagUtils.NewMeasureView(h.clientReceivedBytes, tags, defaultSizeDistribution),
-		diagUtils.NewMeasureView(h.clientRoundtripLatency, []tag.Key{appIDKey, httpStatusCodeKey}, defaultLatencyDistribution),
+		diagUtils.NewMeasureView(h.clientRoundtripLatency, []tag.Key{appIDKey, httpStatusCodeKey}, latency),
 		diagUtils.NewMeasureView(h.clientCompletedCount, []tag.Key{appIDKey, httpStatusCodeKey}, view.Count()),
-		diagUtils.NewMeasureView(h.healthProbeRoundripLatency, []tag.Key{appIDKey, httpStatusCodeKey}, defaultLatencyDistribution),
+		diagUtils.NewMeasureView(h.healthProbeRoundripLatency, []tag.Key{appIDKey, httpStatusCodeKey}, latency),
 		diagUtils.NewMeasureView(h.healthProbeCompletedCount, []tag.Key{appIDKey, httpStatusCodeKey}, view.Count()),
 	)
 }
 
-// HTTPMiddleware is the middleware to track HTTP server-side requests.
-func (h *httpMetrics) HTTPMiddleware(next http.Handler) http.Handler {
-	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		var reqContentSize int64
-		if cl := r.Header.Get("content-length"); cl != "" {
-			reqContentSize, _ = strconv.ParseInt(cl, 10, 64)
-			if reqContentSize < 0 {
-				reqContentSize = 0
-			}
-		}
-
-		// Wrap the writer in a ResponseWriter so we can collect stats such as status code and size
-		rw := responsewriter.EnsureResponseWriter(w)
-
-		// Process the request
-		start := time.Now()
-		next.ServeHTTP(rw, r)
-
-		elapsed := float64(time.Since(start) / time.Millisecond)
-		status := strconv.Itoa(rw.Status())
-		respSize := int64(rw.Size())
-
-		// Check if the context contains a MethodName method
-		endpointData, _ := r.Context().Value(endpoints.EndpointCtxKey{}).(*endpoints.EndpointCtxData)
-		method := endpointData.GetEndpointName()
-		if endpointData != nil && endpointData.Group != nil && endpointData.Group.MethodName != nil {
-			method = endpointData.Group.MethodName(r)
-		}
-
-		// Record the request
-		h.ServerRequestCompleted(r.Context(), method, status, reqContentSize, respSize, elapsed)
-	})
+// serverRoute returns the template of the app route invoked by the request, or an empty string if the routes are not configured.
+func (h *httpMetrics) serverRoute(r *http.Request) string {
+	if h.routes == nil {
+		return ""
+	}
+	return h.routes.match(appRoutePath(r))
 }
 
 // convertPathToMetricLabel removes the variant parameters in URL path for low cardinality label space
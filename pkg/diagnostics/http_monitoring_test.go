@@ -21,9 +21,9 @@ func TestHTTPMiddleware(t *testing.T) {
 
 	// create test httpMetrics
 	testHTTP := newHTTPMetrics()
-	testHTTP.Init("fakeID")
+	testHTTP.Init("fakeID", nil, nil)
 
-	handler := testHTTP.HTTPMiddleware(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+	handler := newServerMetrics(testHTTP, newGRPCMetrics()).HTTPMiddleware(ServerKindAPI)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		time.Sleep(100 * time.Millisecond)
 		w.Write([]byte(responseBody))
 	}))
@@ -66,14 +66,14 @@ func TestHTTPMiddlewareWhenMetricsDisabled(t *testing.T) {
 
 	// create test httpMetrics
 	testHTTP := newHTTPMetrics()
-	testHTTP.enabled = false
+	testHTTP.enabled.Store(false)
 
-	testHTTP.Init("fakeID")
+	testHTTP.Init("fakeID", nil, nil)
 	v := view.Find("http/server/request_count")
 	views := []*view.View{v}
 	view.Unregister(views...)
 
-	handler := testHTTP.HTTPMiddleware(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+	handler := newServerMetrics(testHTTP, newGRPCMetrics()).HTTPMiddleware(ServerKindAPI)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		time.Sleep(100 * time.Millisecond)
 		w.Write([]byte(responseBody))
 	}))
@@ -130,6 +130,91 @@ func TestConvertPathToMethodName(t *testing.T) {
 	}
 }
 
+func TestHTTPRoutes(t *testing.T) {
+	routes := newHTTPRoutes([]string{
+		"/orders/{id}",
+		"/orders/{id}/items",
+		"/static/*",
+		"healthz",
+	})
+
+	matchTests := []struct {
+		in  string
+		out string
+	}{
+		{"/orders/123", "/orders/{id}"},
+		{"/orders/123/", "/orders/{id}"},
+		{"/orders/123/items", "/orders/{id}/items"},
+		{"/orders", httpRouteUnmatched},
+		{"/orders/123/items/1", httpRouteUnmatched},
+		{"/static/css/site.css", "/static/*"},
+		{"/static", httpRouteUnmatched},
+		{"/healthz", "healthz"},
+		{"/", httpRouteUnmatched},
+	}
+	for _, tt := range matchTests {
+		t.Run(tt.in, func(t *testing.T) {
+			assert.Equal(t, tt.out, routes.match(tt.in))
+		})
+	}
+
+	t.Run("no templates", func(t *testing.T) {
+		assert.Nil(t, newHTTPRoutes(nil))
+	})
+}
+
+func TestAppRoutePath(t *testing.T) {
+	pathTests := []struct {
+		in  string
+		out string
+	}{
+		{"http://localhost:3500/v1.0/invoke/myapp/method/orders/123", "/orders/123"},
+		{"http://localhost:3500/v1.0/invoke/myapp.ns/method/orders", "/orders"},
+		{"http://localhost:3500/orders/123", "/orders/123"},
+		{"http://localhost:3500/v1.0/state/statestore", "/v1.0/state/statestore"},
+	}
+	for _, tt := range pathTests {
+		t.Run(tt.in, func(t *testing.T) {
+			req, err := http.NewRequest(http.MethodGet, tt.in, nil)
+			require.NoError(t, err)
+			assert.Equal(t, tt.out, appRoutePath(req))
+		})
+	}
+}
+
+func TestHTTPMiddlewareWithRoutes(t *testing.T) {
+	testHTTP := newHTTPMetrics()
+	require.NoError(t, testHTTP.Init("fakeID", []string{"/orders/{id}"}, nil))
+	t.Cleanup(func() {
+		view.Unregister(view.Find("http/server/request_count"))
+	})
+
+	handler := newServerMetrics(testHTTP, newGRPCMetrics()).HTTPMiddleware(ServerKindAPI)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+
+	for _, path := range []string{"/v1.0/invoke/myapp/method/orders/1", "/v1.0/invoke/myapp/method/orders/2", "/v1.0/invoke/myapp/method/customers/1"} {
+		req, err := http.NewRequest(http.MethodGet, "http://localhost:3500"+path, nil)
+		require.NoError(t, err)
+		handler.ServeHTTP(httptest.NewRecorder(), req)
+	}
+
+	rows, err := view.RetrieveData("http/server/request_count")
+	require.NoError(t, err)
+	counts := map[string]int64{}
+	for _, row := range rows {
+		for _, tag := range row.Tags {
+			if tag.Key.Name() == "route" {
+				counts[tag.Value] += row.Data.(*view.CountData).Value
+			}
+		}
+	}
+	assert.Equal(t, map[string]int64{
+		"/orders/{id}":     2,
+		httpRouteUnmatched: 1,
+	}, counts)
+}
+
 func fakeHTTPRequest(body string) *http.Request {
 	req, err := http.NewRequest(http.MethodPost, "http://dapr.io/invoke/method/testmethod", strings.NewReader(body))
 	if err != nil {
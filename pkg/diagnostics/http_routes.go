@@ -0,0 +1,103 @@
+/*
+Copyright 2023 The Dapr Authors
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package diagnostics
+
+import (
+	"net/http"
+	"strings"
+)
+
+// httpRouteUnmatched is the route of the requests that don't match any of the route templates.
+const httpRouteUnmatched = "unmatched"
+
+// httpRoutes matches the paths invoked on the app against the route templates configured by the user.
+// Tagging the metrics with the template instead of the path gives per-route metrics with a bounded cardinality.
+type httpRoutes struct {
+	templates []httpRouteTemplate
+}
+
+type httpRouteTemplate struct {
+	template string
+	segments []string
+	// If true, the last segment is "*" and matches any number of segments.
+	wildcard bool
+}
+
+func newHTTPRoutes(templates []string) *httpRoutes {
+	if len(templates) == 0 {
+		return nil
+	}
+
+	r := &httpRoutes{
+		templates: make([]httpRouteTemplate, 0, len(templates)),
+	}
+	for _, t := range templates {
+		segments := splitPath(t)
+		wildcard := len(segments) > 0 && segments[len(segments)-1] == "*"
+		if wildcard {
+			segments = segments[:len(segments)-1]
+		}
+		r.templates = append(r.templates, httpRouteTemplate{
+			template: t,
+			segments: segments,
+			wildcard: wildcard,
+		})
+	}
+	return r
+}
+
+// match returns the first template that matches the path, or httpRouteUnmatched.
+func (r *httpRoutes) match(path string) string {
+	segments := splitPath(path)
+	for _, t := range r.templates {
+		if t.matches(segments) {
+			return t.template
+		}
+	}
+	return httpRouteUnmatched
+}
+
+func (t httpRouteTemplate) matches(segments []string) bool {
+	if len(segments) < len(t.segments) || (!t.wildcard && len(segments) != len(t.segments)) {
+		return false
+	}
+	for i, s := range t.segments {
+		isParam := len(s) > 2 && s[0] == '{' && s[len(s)-1] == '}'
+		if !isParam && s != segments[i] {
+			return false
+		}
+	}
+	return true
+}
+
+func splitPath(path string) []string {
+	path = strings.Trim(path, "/")
+	if path == "" {
+		return nil
+	}
+	return strings.Split(path, "/")
+}
+
+// appRoutePath returns the path invoked on the app by a request received by the HTTP server.
+// For service invocation with the invoke API, it's the method in "/v1.0/invoke/<app-id>/method/<method>"; otherwise, such as
+// when the target app is set with the "dapr-app-id" header, it's the path of the request.
+func appRoutePath(r *http.Request) string {
+	p := r.URL.Path
+	if rest, ok := strings.CutPrefix(p, "/v1.0/invoke/"); ok {
+		if idx := strings.Index(rest, "/method/"); idx >= 0 {
+			return rest[idx+len("/method"):]
+		}
+	}
+	return p
+}
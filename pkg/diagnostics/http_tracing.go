@@ -48,6 +48,7 @@ func HTTPTraceMiddleware(next http.Handler, appID string, spec config.TracingSpe
 			return
 		}
 
+		AddBaggageToRequest(r)
 		span := startTracingClientSpanFromHTTPRequest(r, path, spec)
 
 		// Wrap the writer in a ResponseWriter so we can collect stats such as status code and size
@@ -55,9 +56,10 @@ func HTTPTraceMiddleware(next http.Handler, appID string, spec config.TracingSpe
 
 		// Before the response is written, we need to add the tracing headers
 		rw.Before(func(rw responsewriter.ResponseWriter) {
-			// Add span attributes only if it is sampled, which reduced the perf impact.
-			if span.SpanContext().IsSampled() {
+			// Add span attributes only if it is recorded (sampled, or kept for tail sampling), which reduced the perf impact.
+			if span.IsRecording() {
 				AddAttributesToSpan(span, userDefinedHTTPHeaders(r))
+				AddAttributesToSpan(span, headerSpanAttributes(spec, r.Header.Get))
 				spanAttr := spanAttributesMapFromHTTPContext(rw, r)
 				AddAttributesToSpan(span, spanAttr)
 
@@ -67,10 +69,10 @@ func HTTPTraceMiddleware(next http.Handler, appID string, spec config.TracingSpe
 				}
 			}
 
-			// Check if response has traceparent header and add if absent
-			if rw.Header().Get(TraceparentHeader) == "" {
+			// Check if response has the trace context headers and add if absent
+			if !hasTraceContextHeaders(rw.Header().Get) {
 				span = diagUtils.SpanFromContext(r.Context())
-				// Using Header.Set here because we know the traceparent header isn't set
+				// Using Header.Set here because we know the trace context headers aren't set
 				SpanContextToHTTPHeaders(span.SpanContext(), rw.Header().Set)
 			}
 
@@ -120,17 +122,9 @@ func StartProducerSpanChildFromParent(ctx *fasthttp.RequestCtx, parentSpan trace
 	return span
 }
 
-// SpanContextFromRequest extracts a span context from incoming requests.
+// SpanContextFromRequest extracts a span context from incoming requests, in the first propagation format found in the headers.
 func SpanContextFromRequest(r *http.Request) (sc trace.SpanContext) {
-	h := r.Header.Get(TraceparentHeader)
-	if h == "" {
-		return trace.SpanContext{}
-	}
-	sc, ok := SpanContextFromW3CString(h)
-	if ok {
-		ts := tracestateFromRequest(r)
-		sc = sc.WithTraceState(*ts)
-	}
+	sc, _ = spanContextFromHeaders(r.Header.Get)
 	return sc
 }
 
@@ -162,20 +156,13 @@ func traceStatusFromHTTPCode(httpCode int) (otelcodes.Code, string) {
 	return code, ""
 }
 
-func tracestateFromRequest(r *http.Request) *trace.TraceState {
-	h := r.Header.Get(TracestateHeader)
-	return TraceStateFromW3CString(h)
-}
-
-// SpanContextToHTTPHeaders adds the spancontext in traceparent and tracestate headers.
+// SpanContextToHTTPHeaders adds the spancontext in the headers of the propagation formats, such as the traceparent and tracestate headers.
 func SpanContextToHTTPHeaders(sc trace.SpanContext, setHeader func(string, string)) {
 	// if sc is empty context, no ops.
 	if sc.Equal(trace.SpanContext{}) {
 		return
 	}
-	h := SpanContextToW3CString(sc)
-	setHeader(TraceparentHeader, h)
-	tracestateToHeader(sc, setHeader)
+	spanContextToHeaders(sc, getPropagationFormats(), setHeader)
 }
 
 func tracestateToHeader(sc trace.SpanContext, setHeader func(string, string)) {
@@ -0,0 +1,28 @@
+/*
+Copyright 2023 The Dapr Authors
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package loggers gives access to the registry of the Dapr loggers.
+package loggers
+
+import (
+	// Required by go:linkname.
+	_ "unsafe"
+
+	"github.com/dapr/kit/logger"
+)
+
+// Registered returns a copy of the registry of the loggers created with logger.NewLogger, keyed by name.
+// The registry isn't exported by the logger package yet, so it's linked directly; this package must be removed once it's exported.
+//
+//go:linkname Registered github.com/dapr/kit/logger.getLoggers
+func Registered() map[string]logger.Logger
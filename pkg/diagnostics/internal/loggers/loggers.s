@@ -0,0 +1 @@
+// This file allows declaring functions without a body, which are linked with go:linkname.
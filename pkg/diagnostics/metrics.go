@@ -14,6 +14,11 @@ limitations under the License.
 package diagnostics
 
 import (
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+	"sync"
 	"time"
 
 	"go.opencensus.io/stats/view"
@@ -36,29 +41,71 @@ var (
 	DefaultGRPCMonitoring = newGRPCMetrics()
 	// DefaultHTTPMonitoring holds default HTTP monitoring handlers and middlewares.
 	DefaultHTTPMonitoring = newHTTPMetrics()
+	// DefaultServerMonitoring holds the metrics middlewares used by all HTTP and gRPC servers.
+	DefaultServerMonitoring = newServerMetrics(DefaultHTTPMonitoring, DefaultGRPCMonitoring)
+	// DefaultMiddlewareMonitoring holds the metrics for the HTTP middleware pipelines.
+	DefaultMiddlewareMonitoring = newMiddlewareMetrics()
 	// DefaultComponentMonitoring holds component specific metrics.
 	DefaultComponentMonitoring = newComponentMetrics()
 	// DefaultResiliencyMonitoring holds resiliency specific metrics.
 	DefaultResiliencyMonitoring = newResiliencyMetrics()
+	// DefaultWorkflowMonitoring holds workflow specific metrics.
+	DefaultWorkflowMonitoring = newWorkflowMetrics()
+	// DefaultRuntimeMetrics holds the metrics sampled from the internals of the sidecar.
+	DefaultRuntimeMetrics = newRuntimeMetrics()
+	// DefaultAppHealthMonitoring holds the metrics of the health checks of the app.
+	DefaultAppHealthMonitoring = newAppHealthMetrics()
+	// DefaultAppMetrics holds the custom metrics recorded by the app.
+	DefaultAppMetrics = newAppMetrics()
 	// Rules holds regex expressions for metrics labels
 	Rules map[string]string
+
+	// registeredViews are the names of the views registered by the metrics of this package, which are unregistered by ShutdownMetrics.
+	registeredViews     = map[string]struct{}{}
+	registeredViewsLock sync.Mutex
 )
 
-// InitMetrics initializes metrics.
-func InitMetrics(appID, namespace string, rules []config.MetricsRule) error {
-	if err := DefaultMonitoring.Init(appID); err != nil {
+// InitMetrics initializes metrics with the configuration in spec.
+func InitMetrics(appID, namespace string, spec config.MetricSpec) error {
+	buckets := spec.GetLatencyBuckets()
+	httpLatency, err := latencyDistribution("http", buckets.HTTP)
+	if err != nil {
+		return err
+	}
+	grpcLatency, err := latencyDistribution("grpc", buckets.GRPC)
+	if err != nil {
+		return err
+	}
+	workflowLatency, err := latencyDistribution("workflow", buckets.Workflow)
+	if err != nil {
+		return err
+	}
+	serviceInvocationLatency, err := latencyDistribution("service invocation", buckets.ServiceInvocation)
+	if err != nil {
+		return err
+	}
+
+	if err := DefaultMonitoring.Init(appID, serviceInvocationLatency); err != nil {
+		return err
+	}
+
+	if err := DefaultGRPCMonitoring.Init(appID, grpcLatency); err != nil {
 		return err
 	}
 
-	if err := DefaultGRPCMonitoring.Init(appID); err != nil {
+	if err := DefaultHTTPMonitoring.Init(appID, spec.GetHTTPRoutes(), httpLatency); err != nil {
 		return err
 	}
 
-	if err := DefaultHTTPMonitoring.Init(appID); err != nil {
+	if err := DefaultServerMonitoring.Init(appID); err != nil {
 		return err
 	}
 
-	if err := DefaultComponentMonitoring.Init(appID, namespace); err != nil {
+	if err := DefaultMiddlewareMonitoring.Init(appID); err != nil {
+		return err
+	}
+
+	if err := DefaultComponentMonitoring.Init(appID, namespace, spec.GetSamplingFactor()); err != nil {
 		return err
 	}
 
@@ -66,7 +113,112 @@ func InitMetrics(appID, namespace string, rules []config.MetricsRule) error {
 		return err
 	}
 
+	if err := DefaultWorkflowMonitoring.Init(appID, namespace, workflowLatency, spec.GetWorkflowMaxNames()); err != nil {
+		return err
+	}
+
+	if err := DefaultRuntimeMetrics.Init(appID, namespace); err != nil {
+		return err
+	}
+
+	if err := DefaultAppHealthMonitoring.Init(appID); err != nil {
+		return err
+	}
+
+	maxAppMetrics, maxAppSeries := spec.GetAppMetricsLimits()
+	DefaultAppMetrics.Init(appID, namespace, maxAppMetrics, maxAppSeries)
+
 	// Set reporting period of views
 	view.SetReportingPeriod(DefaultReportingPeriod)
-	return utils.CreateRulesMap(rules)
+	if err := utils.CreateRulesMap(spec.Rules); err != nil {
+		return err
+	}
+	return utils.CreateLabelFilters(spec.Labels)
+}
+
+// ShutdownMetrics disables the metrics and unregisters their views, so that their data is dropped and they can be initialized again with InitMetrics.
+// It allows running the runtime multiple times in the same process, such as when it's embedded or in tests.
+// The OpenTelemetry pipeline, which exports the views through the OpenCensus bridge, must be shut down before, see OtelMetrics.Shutdown.
+func ShutdownMetrics() {
+	DefaultMonitoring.enabled.Store(false)
+	DefaultGRPCMonitoring.enabled.Store(false)
+	DefaultHTTPMonitoring.enabled.Store(false)
+	DefaultServerMonitoring.enabled.Store(false)
+	DefaultMiddlewareMonitoring.enabled.Store(false)
+	DefaultComponentMonitoring.enabled.Store(false)
+	DefaultResiliencyMonitoring.enabled.Store(false)
+	DefaultWorkflowMonitoring.enabled.Store(false)
+	DefaultRuntimeMetrics.enabled.Store(false)
+	DefaultAppHealthMonitoring.enabled.Store(false)
+	DefaultAppMetrics.disable()
+
+	registeredViewsLock.Lock()
+	defer registeredViewsLock.Unlock()
+
+	views := make([]*view.View, 0, len(registeredViews))
+	for name := range registeredViews {
+		if v := view.Find(name); v != nil {
+			views = append(views, v)
+		}
+	}
+	view.Unregister(views...)
+	registeredViews = map[string]struct{}{}
+}
+
+// registerViews registers the views, and records them so they're unregistered by ShutdownMetrics.
+// Registering a view that is already registered with the same definition is a no-op, so the metrics can be initialized more than once.
+// A view already registered with a different definition, such as other latency buckets, is replaced.
+func registerViews(views ...*view.View) error {
+	registeredViewsLock.Lock()
+	defer registeredViewsLock.Unlock()
+
+	for _, v := range views {
+		if existing := view.Find(v.Name); existing != nil && !sameView(existing, v) {
+			view.Unregister(existing)
+		}
+	}
+	if err := view.Register(views...); err != nil {
+		return err
+	}
+	for _, v := range views {
+		registeredViews[v.Name] = struct{}{}
+	}
+	return nil
+}
+
+// sameView returns true if the views have the same definition.
+func sameView(a, b *view.View) bool {
+	return a.Measure.Name() == b.Measure.Name() &&
+		reflect.DeepEqual(a.TagKeys, b.TagKeys) &&
+		a.Aggregation.Type == b.Aggregation.Type &&
+		reflect.DeepEqual(a.Aggregation.Buckets, b.Aggregation.Buckets)
+}
+
+// latencyDistribution returns the distribution of the latency histograms of a family of metrics, with the bucket boundaries in milliseconds.
+// It returns nil if no boundaries are set, so the default distribution is used.
+func latencyDistribution(family string, buckets []string) (*view.Aggregation, error) {
+	if len(buckets) == 0 {
+		return nil, nil
+	}
+
+	bounds := make([]float64, len(buckets))
+	for i, b := range buckets {
+		v, err := strconv.ParseFloat(strings.TrimSpace(b), 64)
+		if err != nil || v <= 0 {
+			return nil, fmt.Errorf("invalid latency bucket '%s' for %s metrics: must be a positive number of milliseconds", b, family)
+		}
+		if i > 0 && v <= bounds[i-1] {
+			return nil, fmt.Errorf("invalid latency buckets for %s metrics: must be in increasing order", family)
+		}
+		bounds[i] = v
+	}
+	return view.Distribution(bounds...), nil
+}
+
+// latencyOrDefault returns the distribution if set, or the default latency distribution.
+func latencyOrDefault(latency *view.Aggregation) *view.Aggregation {
+	if latency == nil {
+		return defaultLatencyDistribution
+	}
+	return latency
 }
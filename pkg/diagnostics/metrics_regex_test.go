@@ -18,7 +18,7 @@ func TestRegexRulesSingle(t *testing.T) {
 	methodKey := tag.MustNewKey("method")
 	testStat := stats.Int64(statName, "Stat used in unit test", stats.UnitDimensionless)
 
-	InitMetrics("testAppId2", "", []config.MetricsRule{
+	InitMetrics("testAppId2", "", config.MetricSpec{Rules: []config.MetricsRule{
 		{
 			Name: statName,
 			Labels: []config.MetricLabel{
@@ -31,7 +31,7 @@ func TestRegexRulesSingle(t *testing.T) {
 				},
 			},
 		},
-	})
+	}})
 
 	t.Run("single regex rule applied", func(t *testing.T) {
 		view.Register(
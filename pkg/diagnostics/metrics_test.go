@@ -0,0 +1,90 @@
+package diagnostics
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opencensus.io/stats/view"
+
+	"github.com/dapr/dapr/pkg/config"
+)
+
+func TestLatencyDistribution(t *testing.T) {
+	t.Run("not set", func(t *testing.T) {
+		latency, err := latencyDistribution("http", nil)
+		require.NoError(t, err)
+		assert.Nil(t, latency)
+		assert.Equal(t, defaultLatencyDistribution, latencyOrDefault(latency))
+	})
+
+	t.Run("custom buckets", func(t *testing.T) {
+		latency, err := latencyDistribution("http", []string{"0.5", " 1 ", "2.5", "10"})
+		require.NoError(t, err)
+		assert.Equal(t, []float64{0.5, 1, 2.5, 10}, latency.Buckets)
+		assert.Equal(t, latency, latencyOrDefault(latency))
+	})
+
+	t.Run("invalid bucket", func(t *testing.T) {
+		_, err := latencyDistribution("grpc", []string{"1", "fast"})
+		require.ErrorContains(t, err, "invalid latency bucket 'fast' for grpc metrics")
+	})
+
+	t.Run("bucket not positive", func(t *testing.T) {
+		_, err := latencyDistribution("grpc", []string{"0", "1"})
+		require.Error(t, err)
+	})
+
+	t.Run("buckets not in increasing order", func(t *testing.T) {
+		_, err := latencyDistribution("workflow", []string{"10", "5"})
+		require.ErrorContains(t, err, "must be in increasing order")
+	})
+}
+
+func TestInitMetricsMultipleTimes(t *testing.T) {
+	t.Cleanup(ShutdownMetrics)
+
+	t.Run("same configuration", func(t *testing.T) {
+		require.NoError(t, InitMetrics("test", "default", config.MetricSpec{}))
+		require.NoError(t, InitMetrics("test", "default", config.MetricSpec{}))
+		assert.NotNil(t, view.Find("http/server/latency"))
+	})
+
+	t.Run("different configuration", func(t *testing.T) {
+		require.NoError(t, InitMetrics("test", "default", config.MetricSpec{
+			LatencyBuckets: &config.MetricLatencyBucketsSpec{HTTP: []string{"1", "10"}},
+		}))
+		v := view.Find("http/server/latency")
+		require.NotNil(t, v)
+		assert.Equal(t, []float64{1, 10}, v.Aggregation.Buckets)
+	})
+
+	t.Run("service invocation buckets", func(t *testing.T) {
+		require.NoError(t, InitMetrics("test", "default", config.MetricSpec{
+			LatencyBuckets: &config.MetricLatencyBucketsSpec{ServiceInvocation: []string{"5", "50", "500"}},
+		}))
+		for _, name := range []string{
+			"runtime/service_invocation/res_recv_latency_ms",
+			"runtime/service_invocation/network_latency_ms",
+			"runtime/service_invocation/app_latency_ms",
+		} {
+			v := view.Find(name)
+			require.NotNil(t, v, name)
+			assert.Equal(t, []float64{5, 50, 500}, v.Aggregation.Buckets, name)
+		}
+	})
+
+	t.Run("after shutdown", func(t *testing.T) {
+		ShutdownMetrics()
+		assert.Nil(t, view.Find("http/server/latency"))
+		assert.Nil(t, view.Find("runtime/workflow/execution/count"))
+		assert.False(t, DefaultHTTPMonitoring.enabled.Load())
+		assert.False(t, DefaultWorkflowMonitoring.IsEnabled())
+		assert.False(t, DefaultAppMetrics.IsEnabled())
+
+		require.NoError(t, InitMetrics("test", "default", config.MetricSpec{}))
+		v := view.Find("http/server/latency")
+		require.NotNil(t, v)
+		assert.Equal(t, defaultLatencyDistribution.Buckets, v.Aggregation.Buckets)
+	})
+}
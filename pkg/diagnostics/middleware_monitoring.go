@@ -0,0 +1,158 @@
+/*
+Copyright 2023 The Dapr Authors
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package diagnostics
+
+import (
+	"context"
+	"net/http"
+	"strconv"
+	"sync/atomic"
+	"time"
+
+	"go.opencensus.io/stats"
+	"go.opencensus.io/stats/view"
+	"go.opencensus.io/tag"
+
+	diagUtils "github.com/dapr/dapr/pkg/diagnostics/utils"
+	"github.com/dapr/dapr/pkg/responsewriter"
+)
+
+const (
+	// MiddlewarePipelineHTTP is the pipeline of middlewares applied to requests received by the Dapr HTTP server.
+	MiddlewarePipelineHTTP = "http"
+	// MiddlewarePipelineAppChannel is the pipeline of middlewares applied to requests sent to the app.
+	MiddlewarePipelineAppChannel = "app_channel"
+)
+
+var (
+	middlewareNameKey     = tag.MustNewKey("middleware")
+	middlewarePipelineKey = tag.MustNewKey("pipeline")
+)
+
+// middlewareMetrics holds the metrics for the HTTP middleware pipelines.
+type middlewareMetrics struct {
+	// latency records the time spent in each middleware, excluding the time spent in the rest of the pipeline.
+	latency *stats.Float64Measure
+	// abortCount records the requests that a middleware completed without invoking the rest of the pipeline.
+	abortCount *stats.Int64Measure
+	// errorCount records the requests that a middleware completed without invoking the rest of the pipeline, responding with an error.
+	errorCount *stats.Int64Measure
+
+	appID   string
+	enabled atomic.Bool
+}
+
+func newMiddlewareMetrics() *middlewareMetrics {
+	return &middlewareMetrics{
+		latency: stats.Float64(
+			"runtime/middleware/latency",
+			"The time spent executing an HTTP middleware, excluding the time spent in the rest of the pipeline.",
+			stats.UnitMilliseconds),
+		abortCount: stats.Int64(
+			"runtime/middleware/abort_count",
+			"The number of requests that an HTTP middleware completed without invoking the rest of the pipeline.",
+			stats.UnitDimensionless),
+		errorCount: stats.Int64(
+			"runtime/middleware/error_count",
+			"The number of requests that an HTTP middleware completed with an error response, without invoking the rest of the pipeline.",
+			stats.UnitDimensionless),
+	}
+}
+
+// Init registers the middleware metrics views.
+func (m *middlewareMetrics) Init(appID string) error {
+	m.appID = appID
+	m.enabled.Store(true)
+
+	return registerViews(
+		diagUtils.NewMeasureView(m.latency, []tag.Key{appIDKey, middlewareNameKey, middlewarePipelineKey}, defaultLatencyDistribution),
+		diagUtils.NewMeasureView(m.abortCount, []tag.Key{appIDKey, middlewareNameKey, middlewarePipelineKey, statusKey}, view.Count()),
+		diagUtils.NewMeasureView(m.errorCount, []tag.Key{appIDKey, middlewareNameKey, middlewarePipelineKey, statusKey}, view.Count()),
+	)
+}
+
+// IsEnabled returns true if the middleware metrics are enabled.
+func (m *middlewareMetrics) IsEnabled() bool {
+	return m != nil && m.enabled.Load()
+}
+
+// middlewareCall tracks the invocation of the rest of the pipeline by a middleware.
+type middlewareCall struct {
+	nextInvoked bool
+	nextElapsed time.Duration
+}
+
+type middlewareCallCtxKey struct {
+	// Each instrumented middleware uses its own key, so nested middlewares don't see each other's state.
+	_ byte
+}
+
+// Instrument wraps an HTTP middleware of the given pipeline so its execution is tracked.
+// The name is the name of the middleware component. If the metrics are not enabled, the middleware is returned as-is.
+func (m *middlewareMetrics) Instrument(name, pipeline string, middleware func(next http.Handler) http.Handler) func(next http.Handler) http.Handler {
+	if !m.IsEnabled() {
+		return middleware
+	}
+
+	return func(next http.Handler) http.Handler {
+		ctxKey := &middlewareCallCtxKey{}
+		handler := middleware(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			call, _ := r.Context().Value(ctxKey).(*middlewareCall)
+			if call == nil {
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			call.nextInvoked = true
+			start := time.Now()
+			next.ServeHTTP(w, r)
+			call.nextElapsed += time.Since(start)
+		}))
+
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			rw := responsewriter.EnsureResponseWriter(w)
+			call := &middlewareCall{}
+
+			start := time.Now()
+			handler.ServeHTTP(rw, r.WithContext(context.WithValue(r.Context(), ctxKey, call)))
+			elapsed := time.Since(start) - call.nextElapsed
+
+			m.middlewareExecuted(r.Context(), name, pipeline, elapsed, call.nextInvoked, rw.Status())
+		})
+	}
+}
+
+func (m *middlewareMetrics) middlewareExecuted(ctx context.Context, name, pipeline string, elapsed time.Duration, nextInvoked bool, statusCode int) {
+	recordLatency(ctx, m.latency,
+		diagUtils.WithTags(m.latency.Name(), appIDKey, m.appID, middlewareNameKey, name, middlewarePipelineKey, pipeline),
+		durationToMs(elapsed))
+
+	if nextInvoked {
+		return
+	}
+
+	if statusCode == 0 {
+		// The response is sent with the default status code when nothing was written
+		statusCode = http.StatusOK
+	}
+	status := strconv.Itoa(statusCode)
+	stats.RecordWithTags(ctx,
+		diagUtils.WithTags(m.abortCount.Name(), appIDKey, m.appID, middlewareNameKey, name, middlewarePipelineKey, pipeline, statusKey, status),
+		m.abortCount.M(1))
+	if statusCode >= http.StatusBadRequest {
+		stats.RecordWithTags(ctx,
+			diagUtils.WithTags(m.errorCount.Name(), appIDKey, m.appID, middlewareNameKey, name, middlewarePipelineKey, pipeline, statusKey, status),
+			m.errorCount.M(1))
+	}
+}
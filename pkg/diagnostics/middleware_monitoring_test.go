@@ -0,0 +1,83 @@
+/*
+Copyright 2023 The Dapr Authors
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package diagnostics
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opencensus.io/stats/view"
+)
+
+func TestMiddlewareMetrics(t *testing.T) {
+	passthrough := func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			next.ServeHTTP(w, r)
+		})
+	}
+	deny := func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusForbidden)
+		})
+	}
+	app := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	})
+
+	t.Run("disabled", func(t *testing.T) {
+		m := newMiddlewareMetrics()
+		assert.False(t, m.IsEnabled())
+
+		handler := m.Instrument("passthrough", MiddlewarePipelineHTTP, passthrough)(app)
+		handler.ServeHTTP(httptest.NewRecorder(), fakeHTTPRequest("body"))
+
+		_, err := view.RetrieveData("runtime/middleware/latency")
+		require.Error(t, err)
+	})
+
+	t.Run("enabled", func(t *testing.T) {
+		m := newMiddlewareMetrics()
+		require.NoError(t, m.Init("fakeID"))
+		t.Cleanup(func() {
+			view.Unregister(view.Find("runtime/middleware/latency"), view.Find("runtime/middleware/abort_count"), view.Find("runtime/middleware/error_count"))
+		})
+
+		handler := m.Instrument("passthrough", MiddlewarePipelineHTTP, passthrough)(
+			m.Instrument("deny", MiddlewarePipelineHTTP, deny)(app),
+		)
+		res := httptest.NewRecorder()
+		handler.ServeHTTP(res, fakeHTTPRequest("body"))
+		assert.Equal(t, http.StatusForbidden, res.Code)
+
+		rows, err := view.RetrieveData("runtime/middleware/latency")
+		require.NoError(t, err)
+		assert.Len(t, rows, 2)
+
+		rows, err = view.RetrieveData("runtime/middleware/abort_count")
+		require.NoError(t, err)
+		require.Len(t, rows, 1)
+		tags := serverMetricsTags(t, rows[0])
+		assert.Equal(t, "deny", tags["middleware"])
+		assert.Equal(t, "http", tags["pipeline"])
+		assert.Equal(t, "403", tags["status"])
+
+		rows, err = view.RetrieveData("runtime/middleware/error_count")
+		require.NoError(t, err)
+		require.Len(t, rows, 1)
+		assert.Equal(t, "deny", serverMetricsTags(t, rows[0])["middleware"])
+	})
+}
@@ -0,0 +1,102 @@
+/*
+Copyright 2023 The Dapr Authors
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package diagnostics
+
+import (
+	"sync"
+)
+
+const (
+	// otherName is the tag value of the names that are not among the most frequent ones.
+	otherName = "other"
+
+	// nameLimiterTrackedFactor is the number of names whose frequency is tracked, as a multiple of the number of names used as tags.
+	nameLimiterTrackedFactor = 10
+)
+
+// nameLimiter bounds the cardinality of a tag whose values are user-defined names, such as the names of workflows.
+// The most frequent names are used as values of the tag, and all the others are replaced with "other".
+// The frequencies are only tracked for a bounded number of names, evicting the least frequent ones, so the memory used is bounded too.
+type nameLimiter struct {
+	max        int
+	maxTracked int
+
+	lock   sync.Mutex
+	counts map[string]int64
+	top    map[string]struct{}
+}
+
+func newNameLimiter(maxNames int) *nameLimiter {
+	if maxNames < 1 {
+		return nil
+	}
+	return &nameLimiter{
+		max:        maxNames,
+		maxTracked: maxNames * nameLimiterTrackedFactor,
+		counts:     make(map[string]int64),
+		top:        make(map[string]struct{}, maxNames),
+	}
+}
+
+// value counts an occurrence of name and returns the value of the tag it must be recorded with.
+// It returns an empty string, so the tag is not set, if the limiter is nil or the name is empty.
+func (l *nameLimiter) value(name string) string {
+	if l == nil || name == "" {
+		return ""
+	}
+
+	l.lock.Lock()
+	defer l.lock.Unlock()
+
+	if _, ok := l.counts[name]; !ok && len(l.counts) >= l.maxTracked {
+		l.evict()
+	}
+	l.counts[name]++
+
+	if _, ok := l.top[name]; ok {
+		return name
+	}
+	if len(l.top) < l.max {
+		l.top[name] = struct{}{}
+		return name
+	}
+
+	// Promote the name if it has become more frequent than the least frequent of the top names
+	least, leastCount := "", int64(-1)
+	for top := range l.top {
+		if leastCount < 0 || l.counts[top] < leastCount {
+			least, leastCount = top, l.counts[top]
+		}
+	}
+	if l.counts[name] > leastCount {
+		delete(l.top, least)
+		l.top[name] = struct{}{}
+		return name
+	}
+	return otherName
+}
+
+// evict stops tracking the least frequent name that is not among the top names.
+func (l *nameLimiter) evict() {
+	least, leastCount := "", int64(-1)
+	for name, count := range l.counts {
+		if _, ok := l.top[name]; ok {
+			continue
+		}
+		if leastCount < 0 || count < leastCount {
+			least, leastCount = name, count
+		}
+	}
+	delete(l.counts, least)
+}
@@ -0,0 +1,60 @@
+/*
+Copyright 2023 The Dapr Authors
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package diagnostics
+
+import (
+	"strconv"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNameLimiter(t *testing.T) {
+	t.Run("disabled", func(t *testing.T) {
+		l := newNameLimiter(0)
+		assert.Nil(t, l)
+		assert.Equal(t, "", l.value("order"))
+	})
+
+	t.Run("empty name", func(t *testing.T) {
+		l := newNameLimiter(2)
+		assert.Equal(t, "", l.value(""))
+	})
+
+	t.Run("names beyond the limit are other", func(t *testing.T) {
+		l := newNameLimiter(2)
+		assert.Equal(t, "order", l.value("order"))
+		assert.Equal(t, "payment", l.value("payment"))
+		assert.Equal(t, otherName, l.value("shipping"))
+		assert.Equal(t, "order", l.value("order"))
+	})
+
+	t.Run("frequent names are promoted", func(t *testing.T) {
+		l := newNameLimiter(1)
+		assert.Equal(t, "order", l.value("order"))
+		assert.Equal(t, otherName, l.value("payment"))
+		assert.Equal(t, "payment", l.value("payment"))
+		assert.Equal(t, otherName, l.value("order"))
+	})
+
+	t.Run("tracked names are bounded", func(t *testing.T) {
+		l := newNameLimiter(1)
+		for i := 0; i < 100; i++ {
+			l.value("name-" + strconv.Itoa(i))
+		}
+		assert.Len(t, l.counts, nameLimiterTrackedFactor)
+		assert.Len(t, l.top, 1)
+		assert.Contains(t, l.counts, "name-0")
+	})
+}
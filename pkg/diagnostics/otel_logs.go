@@ -0,0 +1,445 @@
+/*
+Copyright 2023 The Dapr Authors
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package diagnostics
+
+import (
+	"bytes"
+	"context"
+	"crypto/tls"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"go.opentelemetry.io/otel/trace"
+	collogspb "go.opentelemetry.io/proto/otlp/collector/logs/v1"
+	commonpb "go.opentelemetry.io/proto/otlp/common/v1"
+	logspb "go.opentelemetry.io/proto/otlp/logs/v1"
+	resourcepb "go.opentelemetry.io/proto/otlp/resource/v1"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/protobuf/proto"
+
+	"github.com/dapr/dapr/pkg/config"
+)
+
+const (
+	// LogFieldTraceID and LogFieldSpanID are the fields of the log entries that correlate them with a span.
+	LogFieldTraceID = "trace_id"
+	LogFieldSpanID  = "span_id"
+
+	// Fields set by the Dapr loggers, which are mapped to the fields of the OTLP log records.
+	logFieldTime    = "time"
+	logFieldLevel   = "level"
+	logFieldMessage = "msg"
+
+	otelLogsBatchSize     = 512
+	otelLogsQueueSize     = 4096
+	otelLogsFlushInterval = time.Second
+	otelLogsExportTimeout = 10 * time.Second
+)
+
+// AddTraceLogFields adds the trace and span IDs of the span context to the fields of a log entry, so the log entry can be correlated with the trace.
+func AddTraceLogFields(fields map[string]any, sc trace.SpanContext) {
+	if !sc.IsValid() {
+		return
+	}
+	fields[LogFieldTraceID] = sc.TraceID().String()
+	fields[LogFieldSpanID] = sc.SpanID().String()
+}
+
+// otelLogsClient sends a batch of log records to the collector.
+type otelLogsClient interface {
+	Export(ctx context.Context, req *collogspb.ExportLogsServiceRequest) error
+	Close() error
+}
+
+// OtelLogs exports the sidecar logs to a collector using OTLP.
+// It's an io.Writer that receives the log entries formatted by the Dapr loggers, one per line, either as JSON or as text.
+// The entries are exported in batches, in background; when the queue is full, new entries are dropped.
+type OtelLogs struct {
+	client   otelLogsClient
+	resource *resourcepb.Resource
+
+	// Incomplete line from the previous write.
+	partial []byte
+	lock    sync.Mutex
+
+	queue   chan *logspb.LogRecord
+	closeCh chan struct{}
+	wg      sync.WaitGroup
+
+	// Export errors are written to errOut, which is not copied to the exporter.
+	errOut io.Writer
+	// Number of records that couldn't be exported since the last successful export.
+	failed int
+}
+
+// NewOtelLogs creates the exporter of the logs for the OTLP endpoint in spec.
+func NewOtelLogs(appID string, spec config.OtelSpec) (*OtelLogs, error) {
+	if spec.EndpointAddress == "" {
+		return nil, errors.New("endpoint address is required for the Otel logs exporter")
+	}
+
+	var (
+		client otelLogsClient
+		err    error
+	)
+	switch spec.Protocol {
+	case "http":
+		client = newOtelLogsHTTPClient(spec)
+	case "grpc":
+		client, err = newOtelLogsGRPCClient(spec)
+	default:
+		return nil, fmt.Errorf("invalid protocol %v provided for Otel logs endpoint", spec.Protocol)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to create Otel logs exporter: %w", err)
+	}
+
+	return newOtelLogs(appID, client), nil
+}
+
+func newOtelLogs(appID string, client otelLogsClient) *OtelLogs {
+	l := &OtelLogs{
+		client: client,
+		resource: &resourcepb.Resource{
+			Attributes: []*commonpb.KeyValue{
+				otelLogsStringAttribute("service.name", appID),
+			},
+		},
+		queue:   make(chan *logspb.LogRecord, otelLogsQueueSize),
+		closeCh: make(chan struct{}),
+		errOut:  os.Stderr,
+	}
+
+	l.wg.Add(1)
+	go l.run()
+	return l
+}
+
+// Write implements io.Writer.
+func (l *OtelLogs) Write(p []byte) (int, error) {
+	l.lock.Lock()
+	defer l.lock.Unlock()
+
+	data := p
+	if len(l.partial) > 0 {
+		data = append(l.partial, p...)
+		l.partial = nil
+	}
+	for {
+		i := bytes.IndexByte(data, '\n')
+		if i < 0 {
+			break
+		}
+		if line := bytes.TrimSpace(data[:i]); len(line) > 0 {
+			l.enqueue(parseLogLine(line, time.Now()))
+		}
+		data = data[i+1:]
+	}
+	if len(data) > 0 {
+		l.partial = append([]byte(nil), data...)
+	}
+
+	return len(p), nil
+}
+
+func (l *OtelLogs) enqueue(record *logspb.LogRecord) {
+	select {
+	case l.queue <- record:
+	default:
+		// Queue is full: drop the entry rather than blocking the loggers
+	}
+}
+
+func (l *OtelLogs) run() {
+	defer l.wg.Done()
+
+	ticker := time.NewTicker(otelLogsFlushInterval)
+	defer ticker.Stop()
+
+	batch := make([]*logspb.LogRecord, 0, otelLogsBatchSize)
+	for {
+		select {
+		case record := <-l.queue:
+			batch = append(batch, record)
+			if len(batch) >= otelLogsBatchSize {
+				batch = l.export(batch)
+			}
+		case <-ticker.C:
+			batch = l.export(batch)
+		case <-l.closeCh:
+			// Drain the queue before exiting
+			for {
+				select {
+				case record := <-l.queue:
+					batch = append(batch, record)
+				default:
+					l.export(batch)
+					return
+				}
+			}
+		}
+	}
+}
+
+// export sends the batch to the collector and returns it emptied.
+// Errors are written to the standard error rather than logged, since logging them would create more entries to export.
+// Only the first error of a sequence of failed exports is reported, together with the number of records lost when the exports succeed again.
+func (l *OtelLogs) export(batch []*logspb.LogRecord) []*logspb.LogRecord {
+	if len(batch) == 0 {
+		return batch
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), otelLogsExportTimeout)
+	defer cancel()
+	err := l.client.Export(ctx, &collogspb.ExportLogsServiceRequest{
+		ResourceLogs: []*logspb.ResourceLogs{{
+			Resource: l.resource,
+			ScopeLogs: []*logspb.ScopeLogs{{
+				Scope:      &commonpb.InstrumentationScope{Name: meterName},
+				LogRecords: append([]*logspb.LogRecord(nil), batch...),
+			}},
+		}},
+	})
+	switch {
+	case err != nil:
+		if l.failed == 0 {
+			fmt.Fprintf(l.errOut, "Failed to export the logs to the Otel logs endpoint: %v\n", err)
+		}
+		l.failed += len(batch)
+	case l.failed > 0:
+		fmt.Fprintf(l.errOut, "Exporting the logs to the Otel logs endpoint succeeded again; %d log entries were not exported\n", l.failed)
+		l.failed = 0
+	}
+	return batch[:0]
+}
+
+// Shutdown exports the pending log entries and closes the connection to the collector.
+func (l *OtelLogs) Shutdown(ctx context.Context) error {
+	close(l.closeCh)
+
+	done := make(chan struct{})
+	go func() {
+		l.wg.Wait()
+		close(done)
+	}()
+	select {
+	case <-done:
+	case <-ctx.Done():
+		return errors.Join(ctx.Err(), l.client.Close())
+	}
+	return l.client.Close()
+}
+
+// parseLogLine converts a log entry written by a Dapr logger to a log record.
+// Lines that are not in the JSON or text format of the loggers are exported as they are.
+func parseLogLine(line []byte, observed time.Time) *logspb.LogRecord {
+	record := &logspb.LogRecord{
+		ObservedTimeUnixNano: uint64(observed.UnixNano()),
+	}
+
+	var fields map[string]any
+	if line[0] == '{' {
+		if err := json.Unmarshal(line, &fields); err != nil {
+			fields = nil
+		}
+	} else {
+		fields = parseLogfmt(string(line))
+	}
+	if fields == nil || fields[logFieldMessage] == nil {
+		record.Body = &commonpb.AnyValue{Value: &commonpb.AnyValue_StringValue{StringValue: string(line)}}
+		return record
+	}
+
+	for k, v := range fields {
+		switch k {
+		case logFieldMessage:
+			record.Body = &commonpb.AnyValue{Value: &commonpb.AnyValue_StringValue{StringValue: fmt.Sprint(v)}}
+		case logFieldTime:
+			if t, err := time.Parse(time.RFC3339Nano, fmt.Sprint(v)); err == nil {
+				record.TimeUnixNano = uint64(t.UnixNano())
+			}
+		case logFieldLevel:
+			record.SeverityText = fmt.Sprint(v)
+			record.SeverityNumber = otelLogsSeverity(record.SeverityText)
+		case LogFieldTraceID:
+			if id, err := hex.DecodeString(fmt.Sprint(v)); err == nil && len(id) == 16 {
+				record.TraceId = id
+			}
+		case LogFieldSpanID:
+			if id, err := hex.DecodeString(fmt.Sprint(v)); err == nil && len(id) == 8 {
+				record.SpanId = id
+			}
+		default:
+			record.Attributes = append(record.Attributes, otelLogsAttribute(k, v))
+		}
+	}
+	return record
+}
+
+// parseLogfmt parses a log entry in the text format of the loggers, made of key=value pairs where the values may be quoted.
+// It returns nil if the line is not in that format.
+func parseLogfmt(line string) map[string]any {
+	fields := map[string]any{}
+	for line = strings.TrimSpace(line); line != ""; line = strings.TrimLeft(line, " ") {
+		eq := strings.IndexByte(line, '=')
+		if eq <= 0 || strings.ContainsAny(line[:eq], " \"") {
+			return nil
+		}
+		key := line[:eq]
+		line = line[eq+1:]
+
+		var value string
+		if strings.HasPrefix(line, `"`) {
+			quoted, err := strconv.QuotedPrefix(line)
+			if err != nil {
+				return nil
+			}
+			value, _ = strconv.Unquote(quoted)
+			line = line[len(quoted):]
+		} else {
+			end := strings.IndexByte(line, ' ')
+			if end < 0 {
+				end = len(line)
+			}
+			value = line[:end]
+			line = line[end:]
+		}
+		fields[key] = value
+	}
+	return fields
+}
+
+func otelLogsSeverity(level string) logspb.SeverityNumber {
+	switch strings.ToLower(level) {
+	case "debug":
+		return logspb.SeverityNumber_SEVERITY_NUMBER_DEBUG
+	case "info":
+		return logspb.SeverityNumber_SEVERITY_NUMBER_INFO
+	case "warn", "warning":
+		return logspb.SeverityNumber_SEVERITY_NUMBER_WARN
+	case "error":
+		return logspb.SeverityNumber_SEVERITY_NUMBER_ERROR
+	case "fatal", "panic":
+		return logspb.SeverityNumber_SEVERITY_NUMBER_FATAL
+	default:
+		return logspb.SeverityNumber_SEVERITY_NUMBER_UNSPECIFIED
+	}
+}
+
+func otelLogsAttribute(key string, v any) *commonpb.KeyValue {
+	switch val := v.(type) {
+	case bool:
+		return &commonpb.KeyValue{Key: key, Value: &commonpb.AnyValue{Value: &commonpb.AnyValue_BoolValue{BoolValue: val}}}
+	case float64:
+		if val == float64(int64(val)) {
+			return &commonpb.KeyValue{Key: key, Value: &commonpb.AnyValue{Value: &commonpb.AnyValue_IntValue{IntValue: int64(val)}}}
+		}
+		return &commonpb.KeyValue{Key: key, Value: &commonpb.AnyValue{Value: &commonpb.AnyValue_DoubleValue{DoubleValue: val}}}
+	case string:
+		return otelLogsStringAttribute(key, val)
+	default:
+		b, _ := json.Marshal(val)
+		return otelLogsStringAttribute(key, string(b))
+	}
+}
+
+func otelLogsStringAttribute(key, value string) *commonpb.KeyValue {
+	return &commonpb.KeyValue{Key: key, Value: &commonpb.AnyValue{Value: &commonpb.AnyValue_StringValue{StringValue: value}}}
+}
+
+// otelLogsGRPCClient exports the log records using OTLP over gRPC.
+type otelLogsGRPCClient struct {
+	conn   *grpc.ClientConn
+	client collogspb.LogsServiceClient
+}
+
+func newOtelLogsGRPCClient(spec config.OtelSpec) (*otelLogsGRPCClient, error) {
+	creds := insecure.NewCredentials()
+	if spec.GetIsSecure() {
+		creds = credentials.NewTLS(&tls.Config{MinVersion: tls.VersionTLS12})
+	}
+	conn, err := grpc.Dial(spec.EndpointAddress, grpc.WithTransportCredentials(creds))
+	if err != nil {
+		return nil, err
+	}
+	return &otelLogsGRPCClient{
+		conn:   conn,
+		client: collogspb.NewLogsServiceClient(conn),
+	}, nil
+}
+
+func (c *otelLogsGRPCClient) Export(ctx context.Context, req *collogspb.ExportLogsServiceRequest) error {
+	_, err := c.client.Export(ctx, req)
+	return err
+}
+
+func (c *otelLogsGRPCClient) Close() error {
+	return c.conn.Close()
+}
+
+// otelLogsHTTPClient exports the log records using OTLP over HTTP, with the binary protobuf encoding.
+type otelLogsHTTPClient struct {
+	url    string
+	client *http.Client
+}
+
+func newOtelLogsHTTPClient(spec config.OtelSpec) *otelLogsHTTPClient {
+	scheme := "http"
+	if spec.GetIsSecure() {
+		scheme = "https"
+	}
+	return &otelLogsHTTPClient{
+		url:    scheme + "://" + strings.TrimSuffix(spec.EndpointAddress, "/") + "/v1/logs",
+		client: &http.Client{},
+	}
+}
+
+func (c *otelLogsHTTPClient) Export(ctx context.Context, req *collogspb.ExportLogsServiceRequest) error {
+	body, err := proto.Marshal(req)
+	if err != nil {
+		return err
+	}
+	httpReq, err := http.NewRequestWithContext(ctx, http.MethodPost, c.url, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	httpReq.Header.Set("Content-Type", "application/x-protobuf")
+
+	res, err := c.client.Do(httpReq)
+	if err != nil {
+		return err
+	}
+	defer res.Body.Close()
+	_, _ = io.Copy(io.Discard, res.Body)
+	if res.StatusCode/100 != 2 {
+		return fmt.Errorf("unexpected status code %d from the Otel logs endpoint", res.StatusCode)
+	}
+	return nil
+}
+
+func (c *otelLogsHTTPClient) Close() error {
+	c.client.CloseIdleConnections()
+	return nil
+}
@@ -0,0 +1,46 @@
+/*
+Copyright 2023 The Dapr Authors
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package diagnostics
+
+import (
+	"io"
+	"os"
+
+	"github.com/dapr/dapr/pkg/diagnostics/internal/loggers"
+)
+
+// TeeLogs copies the entries written by the Dapr loggers to w, in addition to the standard output.
+// The entries are written to the standard output first and synchronously, so they are never lost because of w.
+// Only the loggers that exist when TeeLogs is invoked are affected; the returned function restores their output.
+func TeeLogs(w io.Writer) (restore func()) {
+	all := loggers.Registered()
+	out := io.MultiWriter(os.Stdout, w)
+	for _, l := range all {
+		l.SetOutput(out)
+	}
+
+	return func() {
+		for _, l := range all {
+			l.SetOutput(os.Stdout)
+		}
+	}
+}
+
+// RedirectLogs writes the entries of the Dapr loggers to w instead of the standard output.
+// Only the loggers that exist when RedirectLogs is invoked are affected.
+func RedirectLogs(w io.Writer) {
+	for _, l := range loggers.Registered() {
+		l.SetOutput(w)
+	}
+}
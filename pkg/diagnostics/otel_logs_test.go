@@ -0,0 +1,207 @@
+/*
+Copyright 2023 The Dapr Authors
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package diagnostics
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"os"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel/trace"
+	collogspb "go.opentelemetry.io/proto/otlp/collector/logs/v1"
+	commonpb "go.opentelemetry.io/proto/otlp/common/v1"
+	logspb "go.opentelemetry.io/proto/otlp/logs/v1"
+
+	"github.com/dapr/dapr/pkg/config"
+	"github.com/dapr/kit/logger"
+)
+
+type fakeOtelLogsClient struct {
+	lock    sync.Mutex
+	records []*logspb.LogRecord
+	closed  bool
+	err     error
+}
+
+func (c *fakeOtelLogsClient) Export(ctx context.Context, req *collogspb.ExportLogsServiceRequest) error {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	if c.err != nil {
+		return c.err
+	}
+	for _, rl := range req.GetResourceLogs() {
+		for _, sl := range rl.GetScopeLogs() {
+			c.records = append(c.records, sl.GetLogRecords()...)
+		}
+	}
+	return nil
+}
+
+func (c *fakeOtelLogsClient) Close() error {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	c.closed = true
+	return nil
+}
+
+func TestAddTraceLogFields(t *testing.T) {
+	t.Run("valid span context", func(t *testing.T) {
+		sc := trace.NewSpanContext(trace.SpanContextConfig{
+			TraceID: trace.TraceID{0x4b, 0xf9, 0x2f, 0x35, 0x77, 0xb3, 0x4d, 0xa6, 0xa3, 0xce, 0x92, 0x9d, 0x0e, 0x0e, 0x47, 0x36},
+			SpanID:  trace.SpanID{0x00, 0xf0, 0x67, 0xaa, 0x0b, 0xa9, 0x02, 0xb7},
+		})
+		fields := map[string]any{"method": "GET /v1.0/state"}
+		AddTraceLogFields(fields, sc)
+		assert.Equal(t, map[string]any{
+			"method":        "GET /v1.0/state",
+			LogFieldTraceID: "4bf92f3577b34da6a3ce929d0e0e4736",
+			LogFieldSpanID:  "00f067aa0ba902b7",
+		}, fields)
+	})
+
+	t.Run("invalid span context", func(t *testing.T) {
+		fields := map[string]any{}
+		AddTraceLogFields(fields, trace.SpanContext{})
+		assert.Empty(t, fields)
+	})
+}
+
+func TestParseLogLine(t *testing.T) {
+	observed := time.Now()
+
+	t.Run("JSON", func(t *testing.T) {
+		record := parseLogLine([]byte(`{"app_id":"myapp","code":200,"level":"info","msg":"HTTP API Called","scope":"dapr.runtime.http-info","span_id":"00f067aa0ba902b7","time":"2023-10-01T10:00:00.5Z","trace_id":"4bf92f3577b34da6a3ce929d0e0e4736"}`), observed)
+		assert.Equal(t, "HTTP API Called", record.GetBody().GetStringValue())
+		assert.Equal(t, "info", record.GetSeverityText())
+		assert.Equal(t, logspb.SeverityNumber_SEVERITY_NUMBER_INFO, record.GetSeverityNumber())
+		assert.Equal(t, uint64(time.Date(2023, 10, 1, 10, 0, 0, 5e8, time.UTC).UnixNano()), record.GetTimeUnixNano())
+		assert.Equal(t, uint64(observed.UnixNano()), record.GetObservedTimeUnixNano())
+		assert.Equal(t, []byte{0x4b, 0xf9, 0x2f, 0x35, 0x77, 0xb3, 0x4d, 0xa6, 0xa3, 0xce, 0x92, 0x9d, 0x0e, 0x0e, 0x47, 0x36}, record.GetTraceId())
+		assert.Equal(t, []byte{0x00, 0xf0, 0x67, 0xaa, 0x0b, 0xa9, 0x02, 0xb7}, record.GetSpanId())
+
+		attrs := map[string]any{}
+		for _, kv := range record.GetAttributes() {
+			switch v := kv.GetValue().GetValue().(type) {
+			case *commonpb.AnyValue_StringValue:
+				attrs[kv.GetKey()] = v.StringValue
+			case *commonpb.AnyValue_IntValue:
+				attrs[kv.GetKey()] = v.IntValue
+			}
+		}
+		assert.Equal(t, map[string]any{
+			"app_id": "myapp",
+			"code":   int64(200),
+			"scope":  "dapr.runtime.http-info",
+		}, attrs)
+	})
+
+	t.Run("text", func(t *testing.T) {
+		record := parseLogLine([]byte(`time="2023-10-01T10:00:00Z" level=warning msg="Component is not ready" app_id=myapp scope=dapr.runtime`), observed)
+		assert.Equal(t, "Component is not ready", record.GetBody().GetStringValue())
+		assert.Equal(t, logspb.SeverityNumber_SEVERITY_NUMBER_WARN, record.GetSeverityNumber())
+		assert.Len(t, record.GetAttributes(), 2)
+		assert.Empty(t, record.GetTraceId())
+	})
+
+	t.Run("unstructured", func(t *testing.T) {
+		record := parseLogLine([]byte(`panic: runtime error`), observed)
+		assert.Equal(t, "panic: runtime error", record.GetBody().GetStringValue())
+		assert.Empty(t, record.GetSeverityText())
+		assert.Empty(t, record.GetAttributes())
+	})
+}
+
+func TestOtelLogsWrite(t *testing.T) {
+	client := &fakeOtelLogsClient{}
+	l := newOtelLogs("myapp", client)
+
+	// Lines may be split across multiple writes
+	_, err := l.Write([]byte(`{"level":"info","msg":"first"}` + "\n" + `{"level":"error",`))
+	require.NoError(t, err)
+	_, err = l.Write([]byte(`"msg":"second"}` + "\n\n"))
+	require.NoError(t, err)
+	_, err = l.Write([]byte(`{"level":"debug","msg":"incomplete"}`))
+	require.NoError(t, err)
+
+	require.NoError(t, l.Shutdown(context.Background()))
+	assert.True(t, client.closed)
+	require.Len(t, client.records, 2)
+	assert.Equal(t, "first", client.records[0].GetBody().GetStringValue())
+	assert.Equal(t, "second", client.records[1].GetBody().GetStringValue())
+	assert.Equal(t, logspb.SeverityNumber_SEVERITY_NUMBER_ERROR, client.records[1].GetSeverityNumber())
+}
+
+func TestOtelLogsExportErrors(t *testing.T) {
+	client := &fakeOtelLogsClient{err: errors.New("unavailable")}
+	errOut := &bytes.Buffer{}
+	l := &OtelLogs{client: client, errOut: errOut}
+	batch := []*logspb.LogRecord{{}, {}}
+
+	// Only the first error is reported
+	assert.Empty(t, l.export(batch))
+	assert.Empty(t, l.export(batch))
+	assert.Equal(t, "Failed to export the logs to the Otel logs endpoint: unavailable\n", errOut.String())
+
+	errOut.Reset()
+	client.err = nil
+	l.export(batch)
+	assert.Equal(t, "Exporting the logs to the Otel logs endpoint succeeded again; 4 log entries were not exported\n", errOut.String())
+
+	errOut.Reset()
+	l.export(batch)
+	assert.Empty(t, errOut.String())
+	assert.Len(t, client.records, 4)
+}
+
+func TestTeeLogs(t *testing.T) {
+	l := logger.NewLogger("dapr.test.teelogs")
+	buf := &bytes.Buffer{}
+
+	restore := TeeLogs(buf)
+	l.Info("copied")
+	restore()
+	l.Info("not copied")
+
+	assert.Contains(t, buf.String(), "copied")
+	assert.NotContains(t, buf.String(), "not copied")
+}
+
+func TestRedirectLogs(t *testing.T) {
+	l := logger.NewLogger("dapr.test.redirectlogs")
+	buf := &bytes.Buffer{}
+
+	RedirectLogs(buf)
+	t.Cleanup(func() { RedirectLogs(os.Stdout) })
+	l.Info("redirected")
+
+	assert.Contains(t, buf.String(), "redirected")
+}
+
+func TestNewOtelLogs(t *testing.T) {
+	_, err := NewOtelLogs("myapp", config.OtelSpec{Protocol: "http"})
+	require.Error(t, err)
+
+	_, err = NewOtelLogs("myapp", config.OtelSpec{Protocol: "udp", EndpointAddress: "localhost:4318"})
+	require.Error(t, err)
+
+	l, err := NewOtelLogs("myapp", config.OtelSpec{Protocol: "http", EndpointAddress: "localhost:4318"})
+	require.NoError(t, err)
+	require.NoError(t, l.Shutdown(context.Background()))
+}
@@ -0,0 +1,101 @@
+/*
+Copyright 2023 The Dapr Authors
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package diagnostics
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"go.opentelemetry.io/otel"
+	ocbridge "go.opentelemetry.io/otel/bridge/opencensus"
+	"go.opentelemetry.io/otel/exporters/otlp/otlpmetric/otlpmetricgrpc"
+	"go.opentelemetry.io/otel/exporters/otlp/otlpmetric/otlpmetrichttp"
+	"go.opentelemetry.io/otel/metric"
+	"go.opentelemetry.io/otel/metric/noop"
+	sdkmetric "go.opentelemetry.io/otel/sdk/metric"
+	"go.opentelemetry.io/otel/sdk/resource"
+
+	"github.com/dapr/dapr/pkg/config"
+)
+
+// meterName is the name of the meter used by the native OpenTelemetry instruments of Dapr.
+const meterName = "github.com/dapr/dapr"
+
+// OtelMetrics is the OpenTelemetry metrics pipeline, which pushes the metrics to a collector using OTLP.
+// It runs side by side with the OpenCensus pipeline scraped via the Prometheus endpoint: the measures recorded
+// with OpenCensus are exported through a bridge, while new instruments can be created with Meter.
+type OtelMetrics struct {
+	provider *sdkmetric.MeterProvider
+}
+
+// NewOtelMetrics creates the OpenTelemetry metrics pipeline for the OTLP exporter in spec, and sets it as the global meter provider.
+// The metrics are reported with the resource res, see NewOtelResource.
+func NewOtelMetrics(ctx context.Context, res *resource.Resource, spec config.OtelSpec) (*OtelMetrics, error) {
+	if spec.EndpointAddress == "" {
+		return nil, errors.New("endpoint address is required for the Otel metrics exporter")
+	}
+
+	var (
+		exporter sdkmetric.Exporter
+		err      error
+	)
+	switch spec.Protocol {
+	case "http":
+		opts := []otlpmetrichttp.Option{otlpmetrichttp.WithEndpoint(spec.EndpointAddress)}
+		if !spec.GetIsSecure() {
+			opts = append(opts, otlpmetrichttp.WithInsecure())
+		}
+		exporter, err = otlpmetrichttp.New(ctx, opts...)
+	case "grpc":
+		opts := []otlpmetricgrpc.Option{otlpmetricgrpc.WithEndpoint(spec.EndpointAddress)}
+		if !spec.GetIsSecure() {
+			opts = append(opts, otlpmetricgrpc.WithInsecure())
+		}
+		exporter, err = otlpmetricgrpc.New(ctx, opts...)
+	default:
+		return nil, fmt.Errorf("invalid protocol %v provided for Otel metrics endpoint", spec.Protocol)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to create Otel metrics exporter: %w", err)
+	}
+
+	reader := sdkmetric.NewPeriodicReader(exporter,
+		sdkmetric.WithInterval(DefaultReportingPeriod),
+		// Exports the measures that are still recorded with OpenCensus
+		sdkmetric.WithProducer(ocbridge.NewMetricProducer()),
+	)
+	provider := sdkmetric.NewMeterProvider(
+		sdkmetric.WithReader(reader),
+		sdkmetric.WithResource(res),
+	)
+	otel.SetMeterProvider(provider)
+
+	return &OtelMetrics{provider: provider}, nil
+}
+
+// Meter returns the meter to create native OpenTelemetry instruments with.
+func (m *OtelMetrics) Meter() metric.Meter {
+	return m.provider.Meter(meterName)
+}
+
+// Shutdown flushes the pending metrics and stops the pipeline.
+// Shutting down the provider shuts down the OTLP exporter and detaches the OpenCensus bridge, which is read by the provider only.
+// The global meter provider is reset, so that the instruments created afterwards don't record to the stopped pipeline.
+func (m *OtelMetrics) Shutdown(ctx context.Context) error {
+	if otel.GetMeterProvider() == metric.MeterProvider(m.provider) {
+		otel.SetMeterProvider(noop.NewMeterProvider())
+	}
+	return m.provider.Shutdown(ctx)
+}
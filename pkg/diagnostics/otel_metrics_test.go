@@ -0,0 +1,61 @@
+/*
+Copyright 2023 The Dapr Authors
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package diagnostics
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/metric"
+
+	"github.com/dapr/dapr/pkg/config"
+)
+
+func TestNewOtelMetrics(t *testing.T) {
+	t.Run("missing endpoint address", func(t *testing.T) {
+		_, err := NewOtelMetrics(context.Background(), NewOtelResource("test", nil), config.OtelSpec{Protocol: "grpc"})
+		require.Error(t, err)
+	})
+
+	t.Run("invalid protocol", func(t *testing.T) {
+		_, err := NewOtelMetrics(context.Background(), NewOtelResource("test", nil), config.OtelSpec{
+			Protocol:        "udp",
+			EndpointAddress: "localhost:4317",
+		})
+		require.ErrorContains(t, err, "invalid protocol")
+	})
+
+	for _, protocol := range []string{"grpc", "http"} {
+		t.Run("valid "+protocol+" exporter", func(t *testing.T) {
+			insecure := false
+			m, err := NewOtelMetrics(context.Background(), NewOtelResource("test", nil), config.OtelSpec{
+				Protocol:        protocol,
+				EndpointAddress: "localhost:4317",
+				IsSecure:        &insecure,
+			})
+			require.NoError(t, err)
+			require.NotNil(t, m.Meter())
+			assert.Equal(t, metric.MeterProvider(m.provider), otel.GetMeterProvider())
+
+			// The context is canceled so shutting down does not wait for the unreachable endpoint
+			ctx, cancel := context.WithCancel(context.Background())
+			cancel()
+			_ = m.Shutdown(ctx)
+			assert.NotEqual(t, metric.MeterProvider(m.provider), otel.GetMeterProvider())
+		})
+	}
+}
@@ -0,0 +1,50 @@
+/*
+Copyright 2023 The Dapr Authors
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package diagnostics
+
+import (
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/sdk/resource"
+	semconv "go.opentelemetry.io/otel/semconv/v1.10.0"
+
+	"github.com/dapr/dapr/pkg/config"
+)
+
+// NewOtelResource returns the OpenTelemetry resource the spans and metrics of the sidecar are reported with.
+// The service name defaults to the app ID when it's not set in spec, which may be nil.
+func NewOtelResource(appID string, spec *config.OtelResourceSpec) *resource.Resource {
+	if spec == nil {
+		spec = &config.OtelResourceSpec{}
+	}
+
+	serviceName := spec.ServiceName
+	if serviceName == "" {
+		serviceName = spec.Attributes[string(semconv.ServiceNameKey)]
+	}
+	if serviceName == "" {
+		serviceName = appID
+	}
+
+	// When there are duplicate keys, the last attribute is used: the service name and environment take precedence
+	attrs := make([]attribute.KeyValue, 0, len(spec.Attributes)+2)
+	for k, v := range spec.Attributes {
+		attrs = append(attrs, attribute.String(k, v))
+	}
+	attrs = append(attrs, semconv.ServiceNameKey.String(serviceName))
+	if spec.Environment != "" {
+		attrs = append(attrs, semconv.DeploymentEnvironmentKey.String(spec.Environment))
+	}
+
+	return resource.NewWithAttributes(semconv.SchemaURL, attrs...)
+}
@@ -0,0 +1,66 @@
+/*
+Copyright 2023 The Dapr Authors
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package diagnostics
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"go.opentelemetry.io/otel/attribute"
+
+	"github.com/dapr/dapr/pkg/config"
+)
+
+func TestNewOtelResource(t *testing.T) {
+	attrs := func(spec *config.OtelResourceSpec) map[attribute.Key]string {
+		res := map[attribute.Key]string{}
+		for _, kv := range NewOtelResource("myapp", spec).Attributes() {
+			res[kv.Key] = kv.Value.AsString()
+		}
+		return res
+	}
+
+	t.Run("defaults to the app ID", func(t *testing.T) {
+		assert.Equal(t, map[attribute.Key]string{"service.name": "myapp"}, attrs(nil))
+		assert.Equal(t, map[attribute.Key]string{"service.name": "myapp"}, attrs(&config.OtelResourceSpec{}))
+	})
+
+	t.Run("service name, environment and attributes", func(t *testing.T) {
+		assert.Equal(t, map[attribute.Key]string{
+			"service.name":           "checkout",
+			"deployment.environment": "production",
+			"team":                   "payments",
+		}, attrs(&config.OtelResourceSpec{
+			ServiceName: "checkout",
+			Environment: "production",
+			Attributes: map[string]string{
+				"team":                   "payments",
+				"service.name":           "ignored",
+				"deployment.environment": "ignored",
+			},
+		}))
+	})
+
+	t.Run("service name from the attributes", func(t *testing.T) {
+		assert.Equal(t, map[attribute.Key]string{
+			"service.name":           "checkout",
+			"deployment.environment": "staging",
+		}, attrs(&config.OtelResourceSpec{
+			Attributes: map[string]string{
+				"service.name":           "checkout",
+				"deployment.environment": "staging",
+			},
+		}))
+	})
+}
@@ -2,6 +2,7 @@ package diagnostics
 
 import (
 	"context"
+	"sync/atomic"
 
 	"go.opencensus.io/stats"
 	"go.opencensus.io/stats/view"
@@ -30,7 +31,7 @@ type resiliencyMetrics struct {
 
 	appID   string
 	ctx     context.Context
-	enabled bool
+	enabled atomic.Bool
 }
 
 func newResiliencyMetrics() *resiliencyMetrics {
@@ -49,16 +50,15 @@ func newResiliencyMetrics() *resiliencyMetrics {
 			stats.UnitDimensionless),
 
 		// TODO: how to use correct context
-		ctx:     context.Background(),
-		enabled: false,
+		ctx: context.Background(),
 	}
 }
 
 // Init registers the resiliency metrics views.
 func (m *resiliencyMetrics) Init(id string) error {
-	m.enabled = true
+	m.enabled.Store(true)
 	m.appID = id
-	return view.Register(
+	return registerViews(
 		diagUtils.NewMeasureView(m.policiesLoadCount, []tag.Key{appIDKey, resiliencyNameKey, namespaceKey}, view.Count()),
 		diagUtils.NewMeasureView(m.executionCount, []tag.Key{appIDKey, resiliencyNameKey, policyKey, namespaceKey, flowDirectionKey, targetKey, statusKey}, view.Count()),
 		diagUtils.NewMeasureView(m.activationsCount, []tag.Key{appIDKey, resiliencyNameKey, policyKey, namespaceKey, flowDirectionKey, targetKey, statusKey}, view.Count()),
@@ -67,7 +67,7 @@ func (m *resiliencyMetrics) Init(id string) error {
 
 // PolicyLoaded records metric when policy is loaded.
 func (m *resiliencyMetrics) PolicyLoaded(resiliencyName, namespace string) {
-	if m.enabled {
+	if m.enabled.Load() {
 		_ = stats.RecordWithTags(
 			m.ctx,
 			diagUtils.WithTags(m.policiesLoadCount.Name(), appIDKey, m.appID, resiliencyNameKey, resiliencyName, namespaceKey, namespace),
@@ -78,7 +78,7 @@ func (m *resiliencyMetrics) PolicyLoaded(resiliencyName, namespace string) {
 
 // PolicyWithStatusExecuted records metric when policy is executed with added status information (e.g., circuit breaker open).
 func (m *resiliencyMetrics) PolicyWithStatusExecuted(resiliencyName, namespace string, policy PolicyType, flowDirection PolicyFlowDirection, target string, status string) {
-	if m.enabled {
+	if m.enabled.Load() {
 		_ = stats.RecordWithTags(
 			m.ctx,
 			diagUtils.WithTags(m.executionCount.Name(), appIDKey, m.appID, resiliencyNameKey, resiliencyName, policyKey, string(policy),
@@ -100,7 +100,7 @@ func (m *resiliencyMetrics) PolicyActivated(resiliencyName, namespace string, po
 
 // PolicyWithStatusActivated records metric when policy is activated after a failure or in the case of circuit breaker after a state change. with added state/status (e.g., circuit breaker open).
 func (m *resiliencyMetrics) PolicyWithStatusActivated(resiliencyName, namespace string, policy PolicyType, flowDirection PolicyFlowDirection, target string, status string) {
-	if m.enabled {
+	if m.enabled.Load() {
 		_ = stats.RecordWithTags(
 			m.ctx,
 			diagUtils.WithTags(m.activationsCount.Name(), appIDKey, m.appID, resiliencyNameKey, resiliencyName, policyKey, string(policy),
@@ -0,0 +1,200 @@
+/*
+Copyright 2023 The Dapr Authors
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package diagnostics
+
+import (
+	"context"
+	"runtime"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"go.opencensus.io/stats"
+	"go.opencensus.io/stats/view"
+	"go.opencensus.io/tag"
+
+	diagUtils "github.com/dapr/dapr/pkg/diagnostics/utils"
+)
+
+const (
+	// Connection pools of the sidecar.
+	PoolGRPCApp    = "grpc_app"
+	PoolGRPCRemote = "grpc_remote"
+	PoolHTTPApp    = "http_app"
+
+	// defaultRuntimeMetricsInterval is the interval at which the runtime metrics are sampled.
+	defaultRuntimeMetricsInterval = 15 * time.Second
+)
+
+var (
+	poolKey = tag.MustNewKey("pool")
+
+	// GC pauses are much shorter than requests, so they have their own buckets, in milliseconds.
+	gcPauseDistribution = view.Distribution(0.01, 0.025, 0.05, 0.1, 0.25, 0.5, 1, 2.5, 5, 10, 25, 50, 100)
+)
+
+// runtimeMetrics periodically samples the internals of the sidecar, to detect resource leaks without a separate exporter.
+type runtimeMetrics struct {
+	// goroutines records the number of goroutines.
+	goroutines *stats.Int64Measure
+	// heapAlloc records the bytes of allocated heap objects.
+	heapAlloc *stats.Int64Measure
+	// heapObjects records the number of allocated heap objects.
+	heapObjects *stats.Int64Measure
+	// gcPause records the duration of the GC stop-the-world pauses since the previous sample.
+	gcPause *stats.Float64Measure
+	// connectionPoolSize records the number of connections in the pools of the sidecar.
+	connectionPoolSize *stats.Int64Measure
+	// placementTableSize records the number of entries in the actor placement tables.
+	placementTableSize *stats.Int64Measure
+
+	appID     string
+	namespace string
+	enabled   atomic.Bool
+	interval  time.Duration
+
+	lock             sync.Mutex
+	poolSizeFns      map[string]func() int
+	placementTableFn func() int
+	lastNumGC        uint32
+}
+
+func newRuntimeMetrics() *runtimeMetrics {
+	return &runtimeMetrics{
+		goroutines: stats.Int64(
+			"runtime/process/goroutines",
+			"The number of goroutines in the sidecar.",
+			stats.UnitDimensionless),
+		heapAlloc: stats.Int64(
+			"runtime/process/heap_alloc_bytes",
+			"The bytes of allocated heap objects in the sidecar.",
+			stats.UnitBytes),
+		heapObjects: stats.Int64(
+			"runtime/process/heap_objects",
+			"The number of allocated heap objects in the sidecar.",
+			stats.UnitDimensionless),
+		gcPause: stats.Float64(
+			"runtime/process/gc_pause",
+			"The durations of the garbage collection pauses in the sidecar.",
+			stats.UnitMilliseconds),
+		connectionPoolSize: stats.Int64(
+			"runtime/connection_pool/size",
+			"The number of connections in the connection pools of the sidecar.",
+			stats.UnitDimensionless),
+		placementTableSize: stats.Int64(
+			"runtime/actors/placement_table/size",
+			"The number of hosts in the actor placement tables, summed over the actor types.",
+			stats.UnitDimensionless),
+		interval:    defaultRuntimeMetricsInterval,
+		poolSizeFns: map[string]func() int{},
+	}
+}
+
+// IsEnabled returns true if the runtime metrics are enabled.
+func (r *runtimeMetrics) IsEnabled() bool {
+	return r != nil && r.enabled.Load()
+}
+
+// Init registers the runtime metrics views.
+func (r *runtimeMetrics) Init(appID, namespace string) error {
+	r.appID = appID
+	r.namespace = namespace
+	r.enabled.Store(true)
+
+	tags := []tag.Key{appIDKey, namespaceKey}
+	return registerViews(
+		diagUtils.NewMeasureView(r.goroutines, tags, view.LastValue()),
+		diagUtils.NewMeasureView(r.heapAlloc, tags, view.LastValue()),
+		diagUtils.NewMeasureView(r.heapObjects, tags, view.LastValue()),
+		diagUtils.NewMeasureView(r.gcPause, tags, gcPauseDistribution),
+		diagUtils.NewMeasureView(r.connectionPoolSize, []tag.Key{appIDKey, namespaceKey, poolKey}, view.LastValue()),
+		diagUtils.NewMeasureView(r.placementTableSize, tags, view.LastValue()),
+	)
+}
+
+// SetConnectionPoolSizeFn sets the function that returns the number of connections in a pool of the sidecar.
+func (r *runtimeMetrics) SetConnectionPoolSizeFn(pool string, fn func() int) {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	r.poolSizeFns[pool] = fn
+}
+
+// SetPlacementTableSizeFn sets the function that returns the number of entries in the actor placement tables.
+// It's set once the actors runtime is initialized.
+func (r *runtimeMetrics) SetPlacementTableSizeFn(fn func() int) {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	r.placementTableFn = fn
+}
+
+// Run samples the runtime metrics periodically, until the context is canceled.
+func (r *runtimeMetrics) Run(ctx context.Context) error {
+	if !r.IsEnabled() {
+		<-ctx.Done()
+		return nil
+	}
+
+	ticker := time.NewTicker(r.interval)
+	defer ticker.Stop()
+	for {
+		r.sample(ctx)
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+		}
+	}
+}
+
+func (r *runtimeMetrics) sample(ctx context.Context) {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	var ms runtime.MemStats
+	runtime.ReadMemStats(&ms)
+
+	stats.RecordWithTags(ctx,
+		diagUtils.WithTags(r.goroutines.Name(), appIDKey, r.appID, namespaceKey, r.namespace),
+		r.goroutines.M(int64(runtime.NumGoroutine())))
+	stats.RecordWithTags(ctx,
+		diagUtils.WithTags(r.heapAlloc.Name(), appIDKey, r.appID, namespaceKey, r.namespace),
+		r.heapAlloc.M(int64(ms.HeapAlloc)))
+	stats.RecordWithTags(ctx,
+		diagUtils.WithTags(r.heapObjects.Name(), appIDKey, r.appID, namespaceKey, r.namespace),
+		r.heapObjects.M(int64(ms.HeapObjects)))
+
+	// PauseNs is a circular buffer of the most recent pauses, so pauses are lost if more than its size happened since the previous sample
+	pauses := ms.NumGC - r.lastNumGC
+	if pauses > uint32(len(ms.PauseNs)) {
+		pauses = uint32(len(ms.PauseNs))
+	}
+	gcPauseMutators := diagUtils.WithTags(r.gcPause.Name(), appIDKey, r.appID, namespaceKey, r.namespace)
+	for i := uint32(0); i < pauses; i++ {
+		pause := ms.PauseNs[(ms.NumGC-i+uint32(len(ms.PauseNs))-1)%uint32(len(ms.PauseNs))]
+		stats.RecordWithTags(ctx, gcPauseMutators, r.gcPause.M(float64(pause)/float64(time.Millisecond)))
+	}
+	r.lastNumGC = ms.NumGC
+
+	for pool, fn := range r.poolSizeFns {
+		stats.RecordWithTags(ctx,
+			diagUtils.WithTags(r.connectionPoolSize.Name(), appIDKey, r.appID, namespaceKey, r.namespace, poolKey, pool),
+			r.connectionPoolSize.M(int64(fn())))
+	}
+
+	if r.placementTableFn != nil {
+		stats.RecordWithTags(ctx,
+			diagUtils.WithTags(r.placementTableSize.Name(), appIDKey, r.appID, namespaceKey, r.namespace),
+			r.placementTableSize.M(int64(r.placementTableFn())))
+	}
+}
@@ -0,0 +1,67 @@
+package diagnostics
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opencensus.io/stats/view"
+)
+
+func TestRuntimeMetrics(t *testing.T) {
+	r := newRuntimeMetrics()
+	require.NoError(t, r.Init("test", "default"))
+	t.Cleanup(func() {
+		view.Unregister(
+			view.Find("runtime/process/goroutines"), view.Find("runtime/process/heap_alloc_bytes"),
+			view.Find("runtime/process/heap_objects"), view.Find("runtime/process/gc_pause"),
+			view.Find("runtime/connection_pool/size"), view.Find("runtime/actors/placement_table/size"),
+		)
+	})
+
+	r.SetConnectionPoolSizeFn(PoolGRPCApp, func() int { return 1 })
+	r.SetConnectionPoolSizeFn(PoolHTTPApp, func() int { return 3 })
+	r.sample(context.Background())
+
+	t.Run("process", func(t *testing.T) {
+		viewData, err := view.RetrieveData("runtime/process/goroutines")
+		require.NoError(t, err)
+		require.Len(t, viewData, 1)
+		allTagsPresent(t, view.Find("runtime/process/goroutines"), viewData[0].Tags)
+		assert.Greater(t, viewData[0].Data.(*view.LastValueData).Value, float64(0))
+
+		viewData, err = view.RetrieveData("runtime/process/heap_alloc_bytes")
+		require.NoError(t, err)
+		require.Len(t, viewData, 1)
+		assert.Greater(t, viewData[0].Data.(*view.LastValueData).Value, float64(0))
+	})
+
+	t.Run("connection pools", func(t *testing.T) {
+		viewData, err := view.RetrieveData("runtime/connection_pool/size")
+		require.NoError(t, err)
+		sizes := map[string]float64{}
+		for _, row := range viewData {
+			for _, tag := range row.Tags {
+				if tag.Key == poolKey {
+					sizes[tag.Value] = row.Data.(*view.LastValueData).Value
+				}
+			}
+		}
+		assert.Equal(t, map[string]float64{PoolGRPCApp: 1, PoolHTTPApp: 3}, sizes)
+	})
+
+	t.Run("placement table is recorded once set", func(t *testing.T) {
+		viewData, err := view.RetrieveData("runtime/actors/placement_table/size")
+		require.NoError(t, err)
+		assert.Empty(t, viewData)
+
+		r.SetPlacementTableSizeFn(func() int { return 4 })
+		r.sample(context.Background())
+
+		viewData, err = view.RetrieveData("runtime/actors/placement_table/size")
+		require.NoError(t, err)
+		require.Len(t, viewData, 1)
+		assert.InDelta(t, float64(4), viewData[0].Data.(*view.LastValueData).Value, 0)
+	})
+}
@@ -0,0 +1,69 @@
+/*
+Copyright 2023 The Dapr Authors
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package diagnostics
+
+import (
+	"math/rand"
+	"strconv"
+
+	"go.opencensus.io/stats/view"
+)
+
+// metricsSampler samples the occurrences of high-frequency measures, to bound the overhead of recording them.
+// Each occurrence is recorded with probability 1/factor and a weight of factor, so the recorded counts are unbiased estimates of the actual counts.
+type metricsSampler struct {
+	factor int64
+}
+
+func newMetricsSampler(factor int) metricsSampler {
+	if factor < 1 {
+		factor = 1
+	}
+	return metricsSampler{factor: int64(factor)}
+}
+
+// enabled returns true if occurrences are sampled.
+func (s metricsSampler) enabled() bool {
+	return s.factor > 1
+}
+
+// sample returns the weight an occurrence must be recorded with, or 0 if it must not be recorded.
+func (s metricsSampler) sample() int64 {
+	if !s.enabled() {
+		return 1
+	}
+	// The top-level functions of math/rand don't lock when the generator isn't seeded
+	if rand.Int63n(s.factor) != 0 { //nolint:gosec
+		return 0
+	}
+	return s.factor
+}
+
+// countAggregation returns the aggregation of the views that count the occurrences of a sampled measure.
+// Sampled occurrences are recorded with their weight, so they're summed; without sampling they're counted, so the views are unchanged.
+func (s metricsSampler) countAggregation() *view.Aggregation {
+	if s.enabled() {
+		return view.Sum()
+	}
+	return view.Count()
+}
+
+// describe adds the accuracy of the sampled measure to the description of its view.
+func (s metricsSampler) describe(v *view.View) *view.View {
+	if s.enabled() {
+		v.Description += " Sampled: 1 in " + strconv.FormatInt(s.factor, 10) + " occurrences is recorded with a weight of " + strconv.FormatInt(s.factor, 10) +
+			"; counts are unbiased estimates with a relative standard error of about sqrt(" + strconv.FormatInt(s.factor, 10) + "/count), and latencies are computed from the sampled occurrences only."
+	}
+	return v
+}
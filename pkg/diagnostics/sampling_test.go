@@ -0,0 +1,59 @@
+/*
+Copyright 2023 The Dapr Authors
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package diagnostics
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"go.opencensus.io/stats/view"
+)
+
+func TestMetricsSampler(t *testing.T) {
+	t.Run("disabled", func(t *testing.T) {
+		for _, factor := range []int{-1, 0, 1} {
+			s := newMetricsSampler(factor)
+			assert.False(t, s.enabled())
+			for i := 0; i < 100; i++ {
+				assert.Equal(t, int64(1), s.sample())
+			}
+
+			v := s.describe(&view.View{Description: "desc"})
+			assert.Equal(t, "desc", v.Description)
+			assert.Equal(t, view.AggTypeCount, s.countAggregation().Type)
+		}
+	})
+
+	t.Run("sampled", func(t *testing.T) {
+		s := newMetricsSampler(10)
+		assert.True(t, s.enabled())
+
+		const n = 100_000
+		var total int64
+		for i := 0; i < n; i++ {
+			w := s.sample()
+			if w != 0 {
+				assert.Equal(t, int64(10), w)
+			}
+			total += w
+		}
+
+		// The estimate has a relative standard error of about 1%, so this never fails in practice
+		assert.InEpsilon(t, n, total, 0.1)
+
+		v := s.describe(&view.View{Description: "desc"})
+		assert.Contains(t, v.Description, "Sampled: 1 in 10 occurrences")
+		assert.Equal(t, view.AggTypeSum, s.countAggregation().Type)
+	})
+}
@@ -0,0 +1,257 @@
+/*
+Copyright 2023 The Dapr Authors
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package diagnostics
+
+import (
+	"context"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync/atomic"
+	"time"
+
+	"go.opencensus.io/stats"
+	"go.opencensus.io/stats/view"
+	"go.opencensus.io/tag"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	diagUtils "github.com/dapr/dapr/pkg/diagnostics/utils"
+	"github.com/dapr/dapr/pkg/grpc/metadata"
+	"github.com/dapr/dapr/pkg/http/endpoints"
+	"github.com/dapr/dapr/pkg/responsewriter"
+)
+
+// ServerKind identifies the server that received a request.
+type ServerKind string
+
+const (
+	// ServerKindAPI is the server exposing the Dapr APIs to the application.
+	ServerKindAPI ServerKind = "api"
+	// ServerKindPublic is the public HTTP server, exposing the health and metadata endpoints.
+	ServerKindPublic ServerKind = "public"
+	// ServerKindInternal is the server used for communication between Dapr sidecars.
+	ServerKindInternal ServerKind = "internal"
+
+	serverProtocolHTTP = "http"
+	serverProtocolGRPC = "grpc"
+
+	// Stability levels of the Dapr APIs.
+	APIVersionAlpha  = "alpha"
+	APIVersionBeta   = "beta"
+	APIVersionStable = "stable"
+)
+
+// Tag key definitions for the RED metrics of all servers.
+var (
+	serverKindKey     = tag.MustNewKey("server")
+	serverProtocolKey = tag.MustNewKey("protocol")
+	serverMethodKey   = tag.MustNewKey("method")
+	serverStatusKey   = tag.MustNewKey("status")
+	buildingBlockKey  = tag.MustNewKey("building_block")
+	apiVersionKey     = tag.MustNewKey("api_version")
+)
+
+// serverMetrics collects the RED (rate, errors, duration) metrics for all servers in the runtime, with the same tags regardless of the protocol.
+// It also records the protocol-specific metrics of the HTTP and gRPC servers, so it's the only middleware that servers need to install.
+type serverMetrics struct {
+	requestCount *stats.Int64Measure
+	errorCount   *stats.Int64Measure
+	latency      *stats.Float64Measure
+	apiUsage     *stats.Int64Measure
+
+	http *httpMetrics
+	grpc *grpcMetrics
+
+	appID   string
+	enabled atomic.Bool
+}
+
+func newServerMetrics(httpM *httpMetrics, grpcM *grpcMetrics) *serverMetrics {
+	return &serverMetrics{
+		requestCount: stats.Int64(
+			"server/request_count",
+			"Count of requests processed by the Dapr servers.",
+			stats.UnitDimensionless),
+		errorCount: stats.Int64(
+			"server/error_count",
+			"Count of requests processed by the Dapr servers that completed with an error.",
+			stats.UnitDimensionless),
+		latency: stats.Float64(
+			"server/latency",
+			"End-to-end latency of requests processed by the Dapr servers.",
+			stats.UnitMilliseconds),
+		apiUsage: stats.Int64(
+			"runtime/api/usage",
+			"Count of calls to the Dapr APIs, by building block and stability of the API version.",
+			stats.UnitDimensionless),
+
+		http: httpM,
+		grpc: grpcM,
+	}
+}
+
+// Init registers the server metrics views.
+func (s *serverMetrics) Init(appID string) error {
+	s.appID = appID
+	s.enabled.Store(true)
+
+	tags := []tag.Key{appIDKey, serverKindKey, serverProtocolKey, serverMethodKey, serverStatusKey}
+	return registerViews(
+		diagUtils.NewMeasureView(s.requestCount, tags, view.Count()),
+		diagUtils.NewMeasureView(s.errorCount, tags, view.Count()),
+		diagUtils.NewMeasureView(s.latency, tags, defaultLatencyDistribution),
+		diagUtils.NewMeasureView(s.apiUsage, []tag.Key{appIDKey, buildingBlockKey, apiVersionKey, serverProtocolKey}, view.Count()),
+	)
+}
+
+// IsEnabled returns true if the server metrics are enabled.
+func (s *serverMetrics) IsEnabled() bool {
+	return s != nil && s.enabled.Load()
+}
+
+// RequestCompleted records the RED metrics for a request.
+func (s *serverMetrics) RequestCompleted(ctx context.Context, kind ServerKind, protocol, method, status string, failed bool, elapsed float64) {
+	if !s.IsEnabled() {
+		return
+	}
+
+	tags := diagUtils.WithTags(s.requestCount.Name(), appIDKey, s.appID, serverKindKey, string(kind), serverProtocolKey, protocol, serverMethodKey, method, serverStatusKey, status)
+	stats.RecordWithTags(ctx, tags, s.requestCount.M(1))
+	recordLatency(ctx, s.latency, tags, elapsed)
+	if failed {
+		stats.RecordWithTags(ctx, tags, s.errorCount.M(1))
+	}
+}
+
+// APIUsed records a call to a Dapr API of the given building block and version, such as "v1alpha1".
+func (s *serverMetrics) APIUsed(ctx context.Context, buildingBlock, version, protocol string) {
+	if !s.IsEnabled() {
+		return
+	}
+
+	stats.RecordWithTags(
+		ctx,
+		diagUtils.WithTags(s.apiUsage.Name(), appIDKey, s.appID, buildingBlockKey, buildingBlock, apiVersionKey, APIVersionStability(version), serverProtocolKey, protocol),
+		s.apiUsage.M(1))
+}
+
+// GRPCAPIUsed records a call to a Dapr API of the given building block and version received by the gRPC server.
+func (s *serverMetrics) GRPCAPIUsed(ctx context.Context, buildingBlock, version string) {
+	s.APIUsed(ctx, buildingBlock, version, serverProtocolGRPC)
+}
+
+// APIVersionStability returns the stability level of an API version, such as "v1alpha1" or "v1.0-beta1".
+func APIVersionStability(version string) string {
+	switch {
+	case strings.Contains(version, "alpha"):
+		return APIVersionAlpha
+	case strings.Contains(version, "beta"):
+		return APIVersionBeta
+	default:
+		return APIVersionStable
+	}
+}
+
+// HTTPMiddleware returns the middleware that tracks the requests received by an HTTP server.
+func (s *serverMetrics) HTTPMiddleware(kind ServerKind) func(next http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			var reqContentSize int64
+			if cl := r.Header.Get("content-length"); cl != "" {
+				reqContentSize, _ = strconv.ParseInt(cl, 10, 64)
+				if reqContentSize < 0 {
+					reqContentSize = 0
+				}
+			}
+
+			// Wrap the writer in a ResponseWriter so we can collect stats such as status code and size
+			rw := responsewriter.EnsureResponseWriter(w)
+
+			// Process the request
+			start := time.Now()
+			next.ServeHTTP(rw, r)
+
+			elapsed := ElapsedSince(start)
+			statusCode := rw.Status()
+			status := strconv.Itoa(statusCode)
+			respSize := int64(rw.Size())
+
+			// Check if the context contains a MethodName method
+			endpointData, _ := r.Context().Value(endpoints.EndpointCtxKey{}).(*endpoints.EndpointCtxData)
+			method := endpointData.GetEndpointName()
+			if endpointData != nil && endpointData.Group != nil && endpointData.Group.MethodName != nil {
+				method = endpointData.Group.MethodName(r)
+			}
+
+			// Record the calls to the Dapr APIs by building block, to track the usage of the alpha and beta APIs
+			if kind == ServerKindAPI && endpointData != nil && endpointData.Group != nil {
+				s.APIUsed(r.Context(), string(endpointData.Group.Name), string(endpointData.Group.Version), serverProtocolHTTP)
+			}
+
+			// Record the request
+			s.http.ServerRequestCompleted(r.Context(), method, s.http.serverRoute(r), status, reqContentSize, respSize, elapsed)
+			s.RequestCompleted(r.Context(), kind, serverProtocolHTTP, method, status, statusCode >= http.StatusBadRequest, elapsed)
+		})
+	}
+}
+
+// UnaryServerInterceptor returns the interceptor that tracks the unary RPCs received by a gRPC server.
+func (s *serverMetrics) UnaryServerInterceptor(kind ServerKind) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+		start := time.Now()
+		resp, err := handler(ctx, req)
+		size := 0
+		if err == nil {
+			size = s.grpc.getPayloadSize(resp)
+		}
+
+		code := status.Code(err)
+		s.grpc.ServerRequestSent(ctx, info.FullMethod, code.String(), int64(s.grpc.getPayloadSize(req)), int64(size), start)
+		s.RequestCompleted(ctx, kind, serverProtocolGRPC, info.FullMethod, code.String(), code != codes.OK, ElapsedSince(start))
+		return resp, err
+	}
+}
+
+// StreamServerInterceptor returns the interceptor that tracks the streams received by a gRPC server.
+// Only calls that are proxied to another app are tracked, as the other streams are long-lived.
+// On the API server, these are calls that arrive from the application, while on the internal server they arrive from a remote Dapr sidecar.
+func (s *serverMetrics) StreamServerInterceptor(kind ServerKind) grpc.StreamServerInterceptor {
+	return func(srv any, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		ctx := ss.Context()
+		md, _ := metadata.FromIncomingContext(ctx)
+		vals, ok := md[GRPCProxyAppIDKey]
+		if !ok || len(vals) == 0 {
+			return handler(srv, ss)
+		}
+
+		start := time.Now()
+		counting := newCountingServerStream(ss)
+		err := handler(srv, counting)
+
+		code := status.Code(err)
+		if kind == ServerKindInternal {
+			s.grpc.StreamClientRequestSent(ctx, info.FullMethod, code.String(), start)
+		} else {
+			s.grpc.StreamServerRequestSent(ctx, info.FullMethod, code.String(), start)
+		}
+		st := counting.stats
+		s.grpc.ProxyStreamCompleted(ctx, kind, info.FullMethod, code.String(),
+			st.sentMessages.Load(), st.receivedMessages.Load(), st.sentBytes.Load(), st.receivedBytes.Load(), start)
+		s.RequestCompleted(ctx, kind, serverProtocolGRPC, info.FullMethod, code.String(), code != codes.OK, ElapsedSince(start))
+
+		return err
+	}
+}
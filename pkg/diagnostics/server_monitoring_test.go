@@ -0,0 +1,160 @@
+/*
+Copyright 2023 The Dapr Authors
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package diagnostics
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opencensus.io/stats/view"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/emptypb"
+
+	"github.com/dapr/dapr/pkg/http/endpoints"
+)
+
+func serverMetricsTags(t *testing.T, row *view.Row) map[string]string {
+	t.Helper()
+	tags := make(map[string]string, len(row.Tags))
+	for _, tag := range row.Tags {
+		tags[tag.Key.Name()] = tag.Value
+	}
+	return tags
+}
+
+func TestAPIVersionStability(t *testing.T) {
+	assert.Equal(t, APIVersionAlpha, APIVersionStability("v1alpha1"))
+	assert.Equal(t, APIVersionAlpha, APIVersionStability("v1.0-alpha1"))
+	assert.Equal(t, APIVersionBeta, APIVersionStability("v1beta1"))
+	assert.Equal(t, APIVersionStable, APIVersionStability("v1"))
+}
+
+func TestServerMetrics(t *testing.T) {
+	t.Run("HTTP requests", func(t *testing.T) {
+		m := newServerMetrics(newHTTPMetrics(), newGRPCMetrics())
+		require.NoError(t, m.Init("fakeID"))
+		t.Cleanup(func() {
+			view.Unregister(view.Find("server/request_count"), view.Find("server/error_count"), view.Find("server/latency"), view.Find("runtime/api/usage"))
+		})
+
+		handler := m.HTTPMiddleware(ServerKindPublic)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusInternalServerError)
+		}))
+		handler.ServeHTTP(httptest.NewRecorder(), fakeHTTPRequest("body"))
+
+		rows, err := view.RetrieveData("server/request_count")
+		require.NoError(t, err)
+		require.Len(t, rows, 1)
+		tags := serverMetricsTags(t, rows[0])
+		assert.Equal(t, "fakeID", tags["app_id"])
+		assert.Equal(t, "public", tags["server"])
+		assert.Equal(t, "http", tags["protocol"])
+		assert.Equal(t, "500", tags["status"])
+
+		rows, err = view.RetrieveData("server/error_count")
+		require.NoError(t, err)
+		require.Len(t, rows, 1)
+
+		rows, err = view.RetrieveData("server/latency")
+		require.NoError(t, err)
+		require.Len(t, rows, 1)
+	})
+
+	t.Run("gRPC unary requests", func(t *testing.T) {
+		m := newServerMetrics(newHTTPMetrics(), newGRPCMetrics())
+		require.NoError(t, m.Init("fakeID"))
+		t.Cleanup(func() {
+			view.Unregister(view.Find("server/request_count"), view.Find("server/error_count"), view.Find("server/latency"), view.Find("runtime/api/usage"))
+		})
+
+		i := m.UnaryServerInterceptor(ServerKindInternal)
+		info := &grpc.UnaryServerInfo{FullMethod: "/dapr.proto.internals.v1.ServiceInvocation/CallLocal"}
+		_, err := i(context.Background(), &emptypb.Empty{}, info, func(ctx context.Context, req any) (any, error) {
+			return &emptypb.Empty{}, nil
+		})
+		require.NoError(t, err)
+
+		rows, err := view.RetrieveData("server/request_count")
+		require.NoError(t, err)
+		require.Len(t, rows, 1)
+		tags := serverMetricsTags(t, rows[0])
+		assert.Equal(t, "internal", tags["server"])
+		assert.Equal(t, "grpc", tags["protocol"])
+		assert.Equal(t, info.FullMethod, tags["method"])
+		assert.Equal(t, "OK", tags["status"])
+
+		rows, err = view.RetrieveData("server/error_count")
+		require.NoError(t, err)
+		assert.Empty(t, rows)
+
+		_, err = i(context.Background(), &emptypb.Empty{}, info, func(ctx context.Context, req any) (any, error) {
+			return nil, status.Error(codes.Unavailable, "unavailable")
+		})
+		require.Error(t, err)
+
+		rows, err = view.RetrieveData("server/error_count")
+		require.NoError(t, err)
+		require.Len(t, rows, 1)
+		assert.Equal(t, "Unavailable", serverMetricsTags(t, rows[0])["status"])
+	})
+
+	t.Run("API usage", func(t *testing.T) {
+		m := newServerMetrics(newHTTPMetrics(), newGRPCMetrics())
+		require.NoError(t, m.Init("fakeID"))
+		t.Cleanup(func() {
+			view.Unregister(view.Find("server/request_count"), view.Find("server/error_count"), view.Find("server/latency"), view.Find("runtime/api/usage"))
+		})
+
+		handler := m.HTTPMiddleware(ServerKindAPI)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+		req := fakeHTTPRequest("body")
+		req = req.WithContext(context.WithValue(req.Context(), endpoints.EndpointCtxKey{}, &endpoints.EndpointCtxData{
+			Group: &endpoints.EndpointGroup{
+				Name:    endpoints.EndpointGroupState,
+				Version: endpoints.EndpointGroupVersion1alpha1,
+			},
+		}))
+		handler.ServeHTTP(httptest.NewRecorder(), req)
+		m.GRPCAPIUsed(context.Background(), "workflows", "v1beta1")
+
+		rows, err := view.RetrieveData("runtime/api/usage")
+		require.NoError(t, err)
+		require.Len(t, rows, 2)
+		usage := make(map[string]map[string]string, len(rows))
+		for _, row := range rows {
+			tags := serverMetricsTags(t, row)
+			usage[tags["protocol"]] = tags
+		}
+		assert.Equal(t, "state", usage["http"]["building_block"])
+		assert.Equal(t, "alpha", usage["http"]["api_version"])
+		assert.Equal(t, "workflows", usage["grpc"]["building_block"])
+		assert.Equal(t, "beta", usage["grpc"]["api_version"])
+	})
+
+	t.Run("disabled", func(t *testing.T) {
+		m := newServerMetrics(newHTTPMetrics(), newGRPCMetrics())
+		assert.False(t, m.IsEnabled())
+
+		handler := m.HTTPMiddleware(ServerKindAPI)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+		handler.ServeHTTP(httptest.NewRecorder(), fakeHTTPRequest("body"))
+
+		_, err := view.RetrieveData("server/request_count")
+		require.Error(t, err)
+	})
+}
@@ -3,6 +3,7 @@ package diagnostics
 import (
 	"context"
 	"strconv"
+	"sync/atomic"
 	"time"
 
 	"go.opencensus.io/stats"
@@ -43,6 +44,8 @@ type serviceMetrics struct {
 	componentLoaded        *stats.Int64Measure
 	componentInitCompleted *stats.Int64Measure
 	componentInitFailed    *stats.Int64Measure
+	componentOpsDrained    *stats.Int64Measure
+	componentOpsAborted    *stats.Int64Measure
 
 	// mTLS metrics
 	mtlsInitCompleted             *stats.Int64Measure
@@ -62,6 +65,8 @@ type serviceMetrics struct {
 	actorReminderFiredTotal      *stats.Int64Measure
 	actorTimers                  *stats.Int64Measure
 	actorTimerFiredTotal         *stats.Int64Measure
+	actorFailoverTotal           *stats.Int64Measure
+	actorReminderFencedTotal     *stats.Int64Measure
 
 	// Access Control Lists for Service Invocation metrics
 	appPolicyActionAllowed    *stats.Int64Measure
@@ -75,10 +80,16 @@ type serviceMetrics struct {
 	serviceInvocationResponseSentTotal       *stats.Int64Measure
 	serviceInvocationResponseReceivedTotal   *stats.Int64Measure
 	serviceInvocationResponseReceivedLatency *stats.Float64Measure
+	serviceInvocationRequestCompressedTotal  *stats.Int64Measure
+	serviceInvocationRequestCompressedBytes  *stats.Int64Measure
+	serviceInvocationCallerSidecarLatency    *stats.Float64Measure
+	serviceInvocationNetworkLatency          *stats.Float64Measure
+	serviceInvocationCalleeSidecarLatency    *stats.Float64Measure
+	serviceInvocationAppLatency              *stats.Float64Measure
 
 	appID   string
 	ctx     context.Context
-	enabled bool
+	enabled atomic.Bool
 }
 
 // newServiceMetrics returns serviceMetrics instance with default service metric stats.
@@ -97,6 +108,14 @@ func newServiceMetrics() *serviceMetrics {
 			"runtime/component/init_fail_total",
 			"The number of component initialization failures.",
 			stats.UnitDimensionless),
+		componentOpsDrained: stats.Int64(
+			"runtime/component/drained_operations_total",
+			"The number of in-flight component operations that completed while the component was drained before being closed.",
+			stats.UnitDimensionless),
+		componentOpsAborted: stats.Int64(
+			"runtime/component/aborted_operations_total",
+			"The number of in-flight component operations that were still running when the component was closed.",
+			stats.UnitDimensionless),
 
 		// mTLS
 		mtlsInitCompleted: stats.Int64(
@@ -161,6 +180,14 @@ func newServiceMetrics() *serviceMetrics {
 			"runtime/actor/timers_fired_total",
 			"The number of actor timers fired requests.",
 			stats.UnitDimensionless),
+		actorFailoverTotal: stats.Int64(
+			"runtime/actor/failover_total",
+			"The number of actor invocations that failed over to another host because the host of the actor was unreachable.",
+			stats.UnitDimensionless),
+		actorReminderFencedTotal: stats.Int64(
+			"runtime/actor/reminders_fenced_total",
+			"The number of actor reminder executions that were skipped because another host took ownership of the reminder, preventing a double fire.",
+			stats.UnitDimensionless),
 
 		// Access Control Lists for service invocation
 		appPolicyActionAllowed: stats.Int64(
@@ -201,21 +228,48 @@ func newServiceMetrics() *serviceMetrics {
 			"runtime/service_invocation/res_recv_latency_ms",
 			"The latency of service invocation response.",
 			stats.UnitMilliseconds),
+		serviceInvocationRequestCompressedTotal: stats.Int64(
+			"runtime/service_invocation/req_compressed_total",
+			"The number of requests sent via service invocation that were compressed.",
+			stats.UnitDimensionless),
+		serviceInvocationRequestCompressedBytes: stats.Int64(
+			"runtime/service_invocation/req_compressed_bytes",
+			"The size of the bodies of the requests sent via service invocation that were compressed, before compression.",
+			stats.UnitBytes),
+		serviceInvocationCallerSidecarLatency: stats.Float64(
+			"runtime/service_invocation/caller_sidecar_latency_ms",
+			"The time spent by the sidecar of the caller processing a service invocation request before sending it.",
+			stats.UnitMilliseconds),
+		serviceInvocationNetworkLatency: stats.Float64(
+			"runtime/service_invocation/network_latency_ms",
+			"The time spent on the network between the sidecars of the caller and of the callee on a service invocation request.",
+			stats.UnitMilliseconds),
+		serviceInvocationCalleeSidecarLatency: stats.Float64(
+			"runtime/service_invocation/callee_sidecar_latency_ms",
+			"The time spent by the sidecar of the callee processing a service invocation request before sending it to the app.",
+			stats.UnitMilliseconds),
+		serviceInvocationAppLatency: stats.Float64(
+			"runtime/service_invocation/app_latency_ms",
+			"The time spent by the callee app handling a service invocation request.",
+			stats.UnitMilliseconds),
 
 		// TODO: use the correct context for each request
-		ctx:     context.Background(),
-		enabled: false,
+		ctx: context.Background(),
 	}
 }
 
 // Init initialize metrics views for metrics.
-func (s *serviceMetrics) Init(appID string) error {
+// latency is the distribution of the latency histograms of the service invocation calls, and the default distribution is used if it's nil.
+func (s *serviceMetrics) Init(appID string, latency *view.Aggregation) error {
 	s.appID = appID
-	s.enabled = true
-	return view.Register(
+	s.enabled.Store(true)
+	latency = latencyOrDefault(latency)
+	return registerViews(
 		diagUtils.NewMeasureView(s.componentLoaded, []tag.Key{appIDKey}, view.Count()),
 		diagUtils.NewMeasureView(s.componentInitCompleted, []tag.Key{appIDKey, componentKey}, view.Count()),
 		diagUtils.NewMeasureView(s.componentInitFailed, []tag.Key{appIDKey, componentKey, failReasonKey, componentNameKey}, view.Count()),
+		diagUtils.NewMeasureView(s.componentOpsDrained, []tag.Key{appIDKey, componentKey, componentNameKey}, view.Sum()),
+		diagUtils.NewMeasureView(s.componentOpsAborted, []tag.Key{appIDKey, componentKey, componentNameKey}, view.Sum()),
 
 		diagUtils.NewMeasureView(s.mtlsInitCompleted, []tag.Key{appIDKey}, view.Count()),
 		diagUtils.NewMeasureView(s.mtlsInitFailed, []tag.Key{appIDKey, failReasonKey}, view.Count()),
@@ -233,6 +287,8 @@ func (s *serviceMetrics) Init(appID string) error {
 		diagUtils.NewMeasureView(s.actorReminders, []tag.Key{appIDKey, actorTypeKey}, view.LastValue()),
 		diagUtils.NewMeasureView(s.actorReminderFiredTotal, []tag.Key{appIDKey, actorTypeKey, successKey}, view.Count()),
 		diagUtils.NewMeasureView(s.actorTimerFiredTotal, []tag.Key{appIDKey, actorTypeKey, successKey}, view.Count()),
+		diagUtils.NewMeasureView(s.actorFailoverTotal, []tag.Key{appIDKey, actorTypeKey, successKey}, view.Count()),
+		diagUtils.NewMeasureView(s.actorReminderFencedTotal, []tag.Key{appIDKey, actorTypeKey}, view.Count()),
 
 		diagUtils.NewMeasureView(s.appPolicyActionAllowed, []tag.Key{appIDKey, trustDomainKey, namespaceKey}, view.Count()),
 		diagUtils.NewMeasureView(s.globalPolicyActionAllowed, []tag.Key{appIDKey, trustDomainKey, namespaceKey}, view.Count()),
@@ -243,20 +299,26 @@ func (s *serviceMetrics) Init(appID string) error {
 		diagUtils.NewMeasureView(s.serviceInvocationRequestReceivedTotal, []tag.Key{appIDKey, sourceAppIDKey}, view.Count()),
 		diagUtils.NewMeasureView(s.serviceInvocationResponseSentTotal, []tag.Key{appIDKey, destinationAppIDKey, statusKey}, view.Count()),
 		diagUtils.NewMeasureView(s.serviceInvocationResponseReceivedTotal, []tag.Key{appIDKey, sourceAppIDKey, statusKey, typeKey}, view.Count()),
-		diagUtils.NewMeasureView(s.serviceInvocationResponseReceivedLatency, []tag.Key{appIDKey, sourceAppIDKey, statusKey}, defaultLatencyDistribution),
+		diagUtils.NewMeasureView(s.serviceInvocationResponseReceivedLatency, []tag.Key{appIDKey, sourceAppIDKey, statusKey}, latency),
+		diagUtils.NewMeasureView(s.serviceInvocationRequestCompressedTotal, []tag.Key{appIDKey, destinationAppIDKey}, view.Count()),
+		diagUtils.NewMeasureView(s.serviceInvocationRequestCompressedBytes, []tag.Key{appIDKey, destinationAppIDKey}, view.Sum()),
+		diagUtils.NewMeasureView(s.serviceInvocationCallerSidecarLatency, []tag.Key{appIDKey, destinationAppIDKey}, latency),
+		diagUtils.NewMeasureView(s.serviceInvocationNetworkLatency, []tag.Key{appIDKey, destinationAppIDKey}, latency),
+		diagUtils.NewMeasureView(s.serviceInvocationCalleeSidecarLatency, []tag.Key{appIDKey, destinationAppIDKey}, latency),
+		diagUtils.NewMeasureView(s.serviceInvocationAppLatency, []tag.Key{appIDKey, destinationAppIDKey}, latency),
 	)
 }
 
 // ComponentLoaded records metric when component is loaded successfully.
 func (s *serviceMetrics) ComponentLoaded() {
-	if s.enabled {
+	if s.enabled.Load() {
 		stats.RecordWithTags(s.ctx, diagUtils.WithTags(s.componentLoaded.Name(), appIDKey, s.appID), s.componentLoaded.M(1))
 	}
 }
 
 // ComponentInitialized records metric when component is initialized.
 func (s *serviceMetrics) ComponentInitialized(component string) {
-	if s.enabled {
+	if s.enabled.Load() {
 		stats.RecordWithTags(
 			s.ctx,
 			diagUtils.WithTags(s.componentInitCompleted.Name(), appIDKey, s.appID, componentKey, component),
@@ -266,7 +328,7 @@ func (s *serviceMetrics) ComponentInitialized(component string) {
 
 // ComponentInitFailed records metric when component initialization is failed.
 func (s *serviceMetrics) ComponentInitFailed(component string, reason string, name string) {
-	if s.enabled {
+	if s.enabled.Load() {
 		stats.RecordWithTags(
 			s.ctx,
 			diagUtils.WithTags(s.componentInitFailed.Name(), appIDKey, s.appID, componentKey, component, failReasonKey, reason, componentNameKey, name),
@@ -274,16 +336,35 @@ func (s *serviceMetrics) ComponentInitFailed(component string, reason string, na
 	}
 }
 
+// ComponentDrained records the number of in-flight operations that completed and that were aborted when the component was closed.
+func (s *serviceMetrics) ComponentDrained(component string, name string, drained int, aborted int) {
+	if !s.enabled.Load() {
+		return
+	}
+	if drained > 0 {
+		stats.RecordWithTags(
+			s.ctx,
+			diagUtils.WithTags(s.componentOpsDrained.Name(), appIDKey, s.appID, componentKey, component, componentNameKey, name),
+			s.componentOpsDrained.M(int64(drained)))
+	}
+	if aborted > 0 {
+		stats.RecordWithTags(
+			s.ctx,
+			diagUtils.WithTags(s.componentOpsAborted.Name(), appIDKey, s.appID, componentKey, component, componentNameKey, name),
+			s.componentOpsAborted.M(int64(aborted)))
+	}
+}
+
 // MTLSInitCompleted records metric when component is initialized.
 func (s *serviceMetrics) MTLSInitCompleted() {
-	if s.enabled {
+	if s.enabled.Load() {
 		stats.RecordWithTags(s.ctx, diagUtils.WithTags(s.mtlsInitCompleted.Name(), appIDKey, s.appID), s.mtlsInitCompleted.M(1))
 	}
 }
 
 // MTLSInitFailed records metric when component initialization is failed.
 func (s *serviceMetrics) MTLSInitFailed(reason string) {
-	if s.enabled {
+	if s.enabled.Load() {
 		stats.RecordWithTags(
 			s.ctx, diagUtils.WithTags(s.mtlsInitFailed.Name(), appIDKey, s.appID, failReasonKey, reason),
 			s.mtlsInitFailed.M(1))
@@ -292,14 +373,14 @@ func (s *serviceMetrics) MTLSInitFailed(reason string) {
 
 // MTLSWorkLoadCertRotationCompleted records metric when workload certificate rotation is succeeded.
 func (s *serviceMetrics) MTLSWorkLoadCertRotationCompleted() {
-	if s.enabled {
+	if s.enabled.Load() {
 		stats.RecordWithTags(s.ctx, diagUtils.WithTags(s.mtlsWorkloadCertRotated.Name(), appIDKey, s.appID), s.mtlsWorkloadCertRotated.M(1))
 	}
 }
 
 // MTLSWorkLoadCertRotationFailed records metric when workload certificate rotation is failed.
 func (s *serviceMetrics) MTLSWorkLoadCertRotationFailed(reason string) {
-	if s.enabled {
+	if s.enabled.Load() {
 		stats.RecordWithTags(
 			s.ctx, diagUtils.WithTags(s.mtlsWorkloadCertRotatedFailed.Name(), appIDKey, s.appID, failReasonKey, reason),
 			s.mtlsWorkloadCertRotatedFailed.M(1))
@@ -308,7 +389,7 @@ func (s *serviceMetrics) MTLSWorkLoadCertRotationFailed(reason string) {
 
 // ActorStatusReported records metrics when status is reported to placement service.
 func (s *serviceMetrics) ActorStatusReported(operation string) {
-	if s.enabled {
+	if s.enabled.Load() {
 		stats.RecordWithTags(
 			s.ctx, diagUtils.WithTags(s.actorStatusReportTotal.Name(), appIDKey, s.appID, operationKey, operation),
 			s.actorStatusReportTotal.M(1))
@@ -317,7 +398,7 @@ func (s *serviceMetrics) ActorStatusReported(operation string) {
 
 // ActorStatusReportFailed records metrics when status report to placement service is failed.
 func (s *serviceMetrics) ActorStatusReportFailed(operation string, reason string) {
-	if s.enabled {
+	if s.enabled.Load() {
 		stats.RecordWithTags(
 			s.ctx, diagUtils.WithTags(s.actorStatusReportFailedTotal.Name(), appIDKey, s.appID, operationKey, operation, failReasonKey, reason),
 			s.actorStatusReportFailedTotal.M(1))
@@ -326,7 +407,7 @@ func (s *serviceMetrics) ActorStatusReportFailed(operation string, reason string
 
 // ActorPlacementTableOperationReceived records metric when runtime receives table operation.
 func (s *serviceMetrics) ActorPlacementTableOperationReceived(operation string) {
-	if s.enabled {
+	if s.enabled.Load() {
 		stats.RecordWithTags(
 			s.ctx, diagUtils.WithTags(s.actorTableOperationRecvTotal.Name(), appIDKey, s.appID, operationKey, operation),
 			s.actorTableOperationRecvTotal.M(1))
@@ -335,7 +416,7 @@ func (s *serviceMetrics) ActorPlacementTableOperationReceived(operation string)
 
 // ActorRebalanced records metric when actors are drained.
 func (s *serviceMetrics) ActorRebalanced(actorType string) {
-	if s.enabled {
+	if s.enabled.Load() {
 		stats.RecordWithTags(
 			s.ctx,
 			diagUtils.WithTags(s.actorRebalancedTotal.Name(), appIDKey, s.appID, actorTypeKey, actorType),
@@ -345,7 +426,7 @@ func (s *serviceMetrics) ActorRebalanced(actorType string) {
 
 // ActorDeactivated records metric when actor is deactivated.
 func (s *serviceMetrics) ActorDeactivated(actorType string) {
-	if s.enabled {
+	if s.enabled.Load() {
 		stats.RecordWithTags(
 			s.ctx,
 			diagUtils.WithTags(s.actorDeactivationTotal.Name(), appIDKey, s.appID, actorTypeKey, actorType),
@@ -355,7 +436,7 @@ func (s *serviceMetrics) ActorDeactivated(actorType string) {
 
 // ActorDeactivationFailed records metric when actor deactivation is failed.
 func (s *serviceMetrics) ActorDeactivationFailed(actorType string, reason string) {
-	if s.enabled {
+	if s.enabled.Load() {
 		stats.RecordWithTags(
 			s.ctx,
 			diagUtils.WithTags(s.actorDeactivationFailedTotal.Name(), appIDKey, s.appID, actorTypeKey, actorType, failReasonKey, reason),
@@ -365,7 +446,7 @@ func (s *serviceMetrics) ActorDeactivationFailed(actorType string, reason string
 
 // ActorReminderFired records metric when actor reminder is fired.
 func (s *serviceMetrics) ActorReminderFired(actorType string, success bool) {
-	if s.enabled {
+	if s.enabled.Load() {
 		stats.RecordWithTags(
 			s.ctx,
 			diagUtils.WithTags(s.actorReminderFiredTotal.Name(), appIDKey, s.appID, actorTypeKey, actorType, successKey, strconv.FormatBool(success)),
@@ -375,7 +456,7 @@ func (s *serviceMetrics) ActorReminderFired(actorType string, success bool) {
 
 // ActorTimerFired records metric when actor timer is fired.
 func (s *serviceMetrics) ActorTimerFired(actorType string, success bool) {
-	if s.enabled {
+	if s.enabled.Load() {
 		stats.RecordWithTags(
 			s.ctx,
 			diagUtils.WithTags(s.actorTimerFiredTotal.Name(), appIDKey, s.appID, actorTypeKey, actorType, successKey, strconv.FormatBool(success)),
@@ -383,9 +464,29 @@ func (s *serviceMetrics) ActorTimerFired(actorType string, success bool) {
 	}
 }
 
+// ActorReminderFenced records metric when the execution of an actor reminder is skipped because another host owns it.
+func (s *serviceMetrics) ActorReminderFenced(actorType string) {
+	if s.enabled.Load() {
+		stats.RecordWithTags(
+			s.ctx,
+			diagUtils.WithTags(s.actorReminderFencedTotal.Name(), appIDKey, s.appID, actorTypeKey, actorType),
+			s.actorReminderFencedTotal.M(1))
+	}
+}
+
+// ActorFailover records metric when an actor invocation fails over to another host.
+func (s *serviceMetrics) ActorFailover(actorType string, success bool) {
+	if s.enabled.Load() {
+		stats.RecordWithTags(
+			s.ctx,
+			diagUtils.WithTags(s.actorFailoverTotal.Name(), appIDKey, s.appID, actorTypeKey, actorType, successKey, strconv.FormatBool(success)),
+			s.actorFailoverTotal.M(1))
+	}
+}
+
 // ActorReminders records the current number of reminders for an actor type.
 func (s *serviceMetrics) ActorReminders(actorType string, reminders int64) {
-	if s.enabled {
+	if s.enabled.Load() {
 		stats.RecordWithTags(
 			s.ctx,
 			diagUtils.WithTags(s.actorReminders.Name(), appIDKey, s.appID, actorTypeKey, actorType),
@@ -395,7 +496,7 @@ func (s *serviceMetrics) ActorReminders(actorType string, reminders int64) {
 
 // ActorTimers records the current number of timers for an actor type.
 func (s *serviceMetrics) ActorTimers(actorType string, timers int64) {
-	if s.enabled {
+	if s.enabled.Load() {
 		stats.RecordWithTags(
 			s.ctx,
 			diagUtils.WithTags(s.actorTimers.Name(), appIDKey, s.appID, actorTypeKey, actorType),
@@ -405,7 +506,7 @@ func (s *serviceMetrics) ActorTimers(actorType string, timers int64) {
 
 // ReportActorPendingCalls records the current pending actor locks.
 func (s *serviceMetrics) ReportActorPendingCalls(actorType string, pendingLocks int32) {
-	if s.enabled {
+	if s.enabled.Load() {
 		stats.RecordWithTags(
 			s.ctx,
 			diagUtils.WithTags(s.actorPendingCalls.Name(), appIDKey, s.appID, actorTypeKey, actorType),
@@ -415,7 +516,7 @@ func (s *serviceMetrics) ReportActorPendingCalls(actorType string, pendingLocks
 
 // RequestAllowedByAppAction records the requests allowed due to a match with the action specified in the access control policy for the app.
 func (s *serviceMetrics) RequestAllowedByAppAction(spiffeID *spiffe.Parsed) {
-	if s.enabled {
+	if s.enabled.Load() {
 		stats.RecordWithTags(
 			s.ctx,
 			diagUtils.WithTags(
@@ -429,7 +530,7 @@ func (s *serviceMetrics) RequestAllowedByAppAction(spiffeID *spiffe.Parsed) {
 
 // RequestBlockedByAppAction records the requests blocked due to a match with the action specified in the access control policy for the app.
 func (s *serviceMetrics) RequestBlockedByAppAction(spiffeID *spiffe.Parsed) {
-	if s.enabled {
+	if s.enabled.Load() {
 		stats.RecordWithTags(
 			s.ctx,
 			diagUtils.WithTags(
@@ -443,7 +544,7 @@ func (s *serviceMetrics) RequestBlockedByAppAction(spiffeID *spiffe.Parsed) {
 
 // RequestAllowedByGlobalAction records the requests allowed due to a match with the global action in the access control policy.
 func (s *serviceMetrics) RequestAllowedByGlobalAction(spiffeID *spiffe.Parsed) {
-	if s.enabled {
+	if s.enabled.Load() {
 		stats.RecordWithTags(
 			s.ctx,
 			diagUtils.WithTags(
@@ -457,7 +558,7 @@ func (s *serviceMetrics) RequestAllowedByGlobalAction(spiffeID *spiffe.Parsed) {
 
 // RequestBlockedByGlobalAction records the requests blocked due to a match with the global action in the access control policy.
 func (s *serviceMetrics) RequestBlockedByGlobalAction(spiffeID *spiffe.Parsed) {
-	if s.enabled {
+	if s.enabled.Load() {
 		stats.RecordWithTags(
 			s.ctx,
 			diagUtils.WithTags(
@@ -471,7 +572,7 @@ func (s *serviceMetrics) RequestBlockedByGlobalAction(spiffeID *spiffe.Parsed) {
 
 // ServiceInvocationRequestSent records the number of service invocation requests sent.
 func (s *serviceMetrics) ServiceInvocationRequestSent(destinationAppID string) {
-	if s.enabled {
+	if s.enabled.Load() {
 		stats.RecordWithTags(
 			s.ctx,
 			diagUtils.WithTags(
@@ -486,7 +587,7 @@ func (s *serviceMetrics) ServiceInvocationRequestSent(destinationAppID string) {
 
 // ServiceInvocationRequestSent records the number of service invocation requests sent.
 func (s *serviceMetrics) ServiceInvocationStreamingRequestSent(destinationAppID string) {
-	if s.enabled {
+	if s.enabled.Load() {
 		stats.RecordWithTags(
 			s.ctx,
 			diagUtils.WithTags(
@@ -498,9 +599,31 @@ func (s *serviceMetrics) ServiceInvocationStreamingRequestSent(destinationAppID
 	}
 }
 
+// ServiceInvocationRequestCompressed records a service invocation request that was compressed, and the size of its body if known.
+func (s *serviceMetrics) ServiceInvocationRequestCompressed(destinationAppID string, size int64) {
+	if s.enabled.Load() {
+		stats.RecordWithTags(
+			s.ctx,
+			diagUtils.WithTags(
+				s.serviceInvocationRequestCompressedTotal.Name(),
+				appIDKey, s.appID,
+				destinationAppIDKey, destinationAppID),
+			s.serviceInvocationRequestCompressedTotal.M(1))
+		if size > 0 {
+			stats.RecordWithTags(
+				s.ctx,
+				diagUtils.WithTags(
+					s.serviceInvocationRequestCompressedBytes.Name(),
+					appIDKey, s.appID,
+					destinationAppIDKey, destinationAppID),
+				s.serviceInvocationRequestCompressedBytes.M(size))
+		}
+	}
+}
+
 // ServiceInvocationRequestReceived records the number of service invocation requests received.
 func (s *serviceMetrics) ServiceInvocationRequestReceived(sourceAppID string) {
-	if s.enabled {
+	if s.enabled.Load() {
 		stats.RecordWithTags(
 			s.ctx,
 			diagUtils.WithTags(
@@ -513,7 +636,7 @@ func (s *serviceMetrics) ServiceInvocationRequestReceived(sourceAppID string) {
 
 // ServiceInvocationResponseSent records the number of service invocation responses sent.
 func (s *serviceMetrics) ServiceInvocationResponseSent(destinationAppID string, status int32) {
-	if s.enabled {
+	if s.enabled.Load() {
 		statusCode := strconv.Itoa(int(status))
 		stats.RecordWithTags(
 			s.ctx,
@@ -528,7 +651,7 @@ func (s *serviceMetrics) ServiceInvocationResponseSent(destinationAppID string,
 
 // ServiceInvocationResponseReceived records the number of service invocation responses received.
 func (s *serviceMetrics) ServiceInvocationResponseReceived(sourceAppID string, status int32, start time.Time) {
-	if s.enabled {
+	if s.enabled.Load() {
 		statusCode := strconv.Itoa(int(status))
 		stats.RecordWithTags(
 			s.ctx,
@@ -550,10 +673,31 @@ func (s *serviceMetrics) ServiceInvocationResponseReceived(sourceAppID string, s
 	}
 }
 
+// ServiceInvocationLatencyBreakdown records how the latency of a service invocation call is split between the sidecar of the caller, the network, the sidecar of the callee, and the callee app.
+// The times spent by the callee are reported by its sidecar in the headers of the response.
+func (s *serviceMetrics) ServiceInvocationLatencyBreakdown(destinationAppID string, callerSidecar, network, calleeSidecar, app time.Duration) {
+	if s.enabled.Load() {
+		record := func(measure *stats.Float64Measure, elapsed time.Duration) {
+			recordLatency(
+				s.ctx,
+				measure,
+				diagUtils.WithTags(
+					measure.Name(),
+					appIDKey, s.appID,
+					destinationAppIDKey, destinationAppID),
+				durationToMs(elapsed))
+		}
+		record(s.serviceInvocationCallerSidecarLatency, callerSidecar)
+		record(s.serviceInvocationNetworkLatency, network)
+		record(s.serviceInvocationCalleeSidecarLatency, calleeSidecar)
+		record(s.serviceInvocationAppLatency, app)
+	}
+}
+
 // ServiceInvocationStreamingResponseReceived records the number of service invocation responses received for streaming operations.
 // this is mainly targeted to recording errors for proxying gRPC streaming calls
 func (s *serviceMetrics) ServiceInvocationStreamingResponseReceived(sourceAppID string, status int32) {
-	if s.enabled {
+	if s.enabled.Load() {
 		statusCode := strconv.Itoa(int(status))
 		stats.RecordWithTags(
 			s.ctx,
@@ -10,7 +10,7 @@ import (
 
 func servicesMetrics() *serviceMetrics {
 	s := newServiceMetrics()
-	s.Init("testAppId")
+	s.Init("testAppId", nil)
 
 	return s
 }
@@ -77,11 +77,43 @@ func TestServiceInvocation(t *testing.T) {
 
 		allTagsPresent(t, v2, viewData2[0].Tags)
 	})
+
+	t.Run("record service invocation latency breakdown", func(t *testing.T) {
+		s := servicesMetrics()
+
+		s.ServiceInvocationLatencyBreakdown("testAppId2", time.Millisecond, 2*time.Millisecond, 3*time.Millisecond, 4*time.Millisecond)
+
+		for _, name := range []string{
+			"runtime/service_invocation/caller_sidecar_latency_ms",
+			"runtime/service_invocation/network_latency_ms",
+			"runtime/service_invocation/callee_sidecar_latency_ms",
+			"runtime/service_invocation/app_latency_ms",
+		} {
+			viewData, _ := view.RetrieveData(name)
+			v := view.Find(name)
+
+			allTagsPresent(t, v, viewData[0].Tags)
+			RequireTagExist(t, viewData, NewTag(destinationAppIDKey.Name(), "testAppId2"))
+		}
+	})
+}
+
+func TestActorFailover(t *testing.T) {
+	s := servicesMetrics()
+
+	s.ActorFailover("testActorType", true)
+
+	viewData, _ := view.RetrieveData("runtime/actor/failover_total")
+	v := view.Find("runtime/actor/failover_total")
+
+	allTagsPresent(t, v, viewData[0].Tags)
+	RequireTagExist(t, viewData, NewTag(actorTypeKey.Name(), "testActorType"))
+	RequireTagExist(t, viewData, NewTag(successKey.Name(), "true"))
 }
 
 func TestSerivceMonitoringInit(t *testing.T) {
 	c := servicesMetrics()
-	assert.True(t, c.enabled)
+	assert.True(t, c.enabled.Load())
 	assert.Equal(t, "testAppId", c.appID)
 }
 
@@ -0,0 +1,85 @@
+/*
+Copyright 2023 The Dapr Authors
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package diagnostics
+
+import (
+	"context"
+	"time"
+
+	"go.opencensus.io/metric/metricdata"
+	"go.opencensus.io/stats"
+	"go.opencensus.io/tag"
+	octrace "go.opencensus.io/trace"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// Timer captures the elapsed time of an operation, to be recorded as a latency metric.
+// It relies on the monotonic clock reading of time.Time, so it's not affected by changes to the wall clock.
+//
+// The zero value is a timer that was never started, and reports no elapsed time.
+type Timer struct {
+	start time.Time
+}
+
+// StartTimer returns a Timer that starts now.
+func StartTimer() Timer {
+	return Timer{start: time.Now()}
+}
+
+// Elapsed returns the time elapsed since the timer was started, in milliseconds and with sub-millisecond resolution.
+// It returns 0 if the timer was never started.
+func (t Timer) Elapsed() float64 {
+	if t.start.IsZero() {
+		return 0
+	}
+	return durationToMs(time.Since(t.start))
+}
+
+// Record records the time elapsed since the timer was started on the measure, with the given tags.
+// Nothing is recorded if the elapsed time is not positive.
+func (t Timer) Record(ctx context.Context, measure *stats.Float64Measure, mutators ...tag.Mutator) {
+	recordLatency(ctx, measure, mutators, t.Elapsed())
+}
+
+// recordLatency records a latency in milliseconds on the measure, skipping it if it's not positive.
+// This happens when the operation was never timed, and recording the value would skew the distribution.
+func recordLatency(ctx context.Context, measure *stats.Float64Measure, mutators []tag.Mutator, elapsed float64) {
+	if elapsed <= 0 {
+		return
+	}
+	recordWithExemplar(ctx, mutators, measure.M(elapsed))
+}
+
+// recordWithExemplar records a measurement like stats.RecordWithTags, attaching the span context of the sampled trace in ctx, if any.
+// The span context is kept as an exemplar of the histogram bucket the measurement falls in, linking latency metrics to traces.
+func recordWithExemplar(ctx context.Context, mutators []tag.Mutator, measurement stats.Measurement) {
+	opts := []stats.Options{
+		stats.WithTags(mutators...),
+		stats.WithMeasurements(measurement),
+	}
+	if sc := trace.SpanContextFromContext(ctx); sc.IsSampled() {
+		opts = append(opts, stats.WithAttachments(metricdata.Attachments{
+			metricdata.AttachmentKeySpanContext: octrace.SpanContext{
+				TraceID:      octrace.TraceID(sc.TraceID()),
+				SpanID:       octrace.SpanID(sc.SpanID()),
+				TraceOptions: octrace.TraceOptions(sc.TraceFlags()),
+			},
+		}))
+	}
+	_ = stats.RecordWithOptions(ctx, opts...)
+}
+
+func durationToMs(d time.Duration) float64 {
+	return float64(d) / float64(time.Millisecond)
+}
@@ -0,0 +1,96 @@
+/*
+Copyright 2023 The Dapr Authors
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package diagnostics
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opencensus.io/metric/metricdata"
+	"go.opencensus.io/stats"
+	"go.opencensus.io/stats/view"
+	octrace "go.opencensus.io/trace"
+	"go.opentelemetry.io/otel/trace"
+)
+
+func TestTimer(t *testing.T) {
+	t.Run("zero timer reports no elapsed time", func(t *testing.T) {
+		var timer Timer
+		assert.Zero(t, timer.Elapsed())
+	})
+
+	t.Run("elapsed has sub-millisecond resolution", func(t *testing.T) {
+		timer := StartTimer()
+		time.Sleep(1500 * time.Microsecond)
+		elapsed := timer.Elapsed()
+		assert.GreaterOrEqual(t, elapsed, 1.5)
+		assert.NotEqual(t, float64(int64(elapsed)), elapsed)
+	})
+
+	t.Run("record skips timers that were never started", func(t *testing.T) {
+		measure := stats.Float64("test/timer/latency", "", stats.UnitMilliseconds)
+		v := &view.View{Measure: measure, Aggregation: view.Count()}
+		require.NoError(t, view.Register(v))
+		t.Cleanup(func() {
+			view.Unregister(v)
+		})
+
+		Timer{}.Record(context.Background(), measure)
+		rows, err := view.RetrieveData(measure.Name())
+		require.NoError(t, err)
+		assert.Empty(t, rows)
+
+		timer := StartTimer()
+		time.Sleep(time.Millisecond)
+		timer.Record(context.Background(), measure)
+		rows, err = view.RetrieveData(measure.Name())
+		require.NoError(t, err)
+		require.Len(t, rows, 1)
+	})
+}
+
+func TestRecordWithExemplar(t *testing.T) {
+	measure := stats.Float64("test/exemplar/latency", "", stats.UnitMilliseconds)
+	v := &view.View{Measure: measure, Aggregation: view.Distribution(1, 10)}
+	require.NoError(t, view.Register(v))
+	t.Cleanup(func() {
+		view.Unregister(v)
+	})
+
+	sc := trace.NewSpanContext(trace.SpanContextConfig{
+		TraceID:    trace.TraceID{1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12, 13, 14, 15, 16},
+		SpanID:     trace.SpanID{1, 2, 3, 4, 5, 6, 7, 8},
+		TraceFlags: trace.FlagsSampled,
+	})
+	recordWithExemplar(trace.ContextWithSpanContext(context.Background(), sc), nil, measure.M(5))
+	// Not sampled, so no exemplar is attached
+	recordWithExemplar(trace.ContextWithSpanContext(context.Background(), sc.WithTraceFlags(0)), nil, measure.M(50))
+
+	rows, err := view.RetrieveData(measure.Name())
+	require.NoError(t, err)
+	require.Len(t, rows, 1)
+	exemplars := rows[0].Data.(*view.DistributionData).ExemplarsPerBucket
+	require.Len(t, exemplars, 3)
+
+	require.NotNil(t, exemplars[1])
+	assert.Equal(t, octrace.SpanContext{
+		TraceID:      octrace.TraceID(sc.TraceID()),
+		SpanID:       octrace.SpanID(sc.SpanID()),
+		TraceOptions: 1,
+	}, exemplars[1].Attachments[metricdata.AttachmentKeySpanContext])
+	assert.Nil(t, exemplars[2])
+}
@@ -155,6 +155,18 @@ func ConstructInputBindingSpanAttributes(bindingName, url string) map[string]str
 	}
 }
 
+// ConstructActorCallbackSpanAttributes creates span attributes for the reminders and timers of actors.
+func ConstructActorCallbackSpanAttributes(actorType, actorID, name string, isTimer bool) map[string]string {
+	nameKey := diagConsts.DaprAPIActorReminderName
+	if isTimer {
+		nameKey = diagConsts.DaprAPIActorTimerName
+	}
+	return map[string]string{
+		diagConsts.DaprAPIActorTypeID: actorType + "." + actorID,
+		nameKey:                       name,
+	}
+}
+
 // ConstructSubscriptionSpanAttributes creates span attributes for Pubsub subscription.
 func ConstructSubscriptionSpanAttributes(topic string) map[string]string {
 	return map[string]string{
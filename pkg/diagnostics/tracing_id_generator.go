@@ -0,0 +1,73 @@
+/*
+Copyright 2023 The Dapr Authors
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package diagnostics
+
+import (
+	"context"
+	crand "crypto/rand"
+	"encoding/binary"
+	"math/rand"
+	"sync"
+
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/trace"
+)
+
+type spanIDContextKey struct{}
+
+// ContextWithSpanID returns a context that makes the tracer provider of the sidecar assign the given ID to the span started with it.
+// This allows emitting a span whose ID was chosen in advance, for example because it was already used as parent of other spans.
+func ContextWithSpanID(ctx context.Context, id trace.SpanID) context.Context {
+	return context.WithValue(ctx, spanIDContextKey{}, id)
+}
+
+// idGenerator generates random trace and span IDs, like the default generator of the OpenTelemetry SDK, unless the span ID is set in the context with ContextWithSpanID.
+type idGenerator struct {
+	lock sync.Mutex
+	rand *rand.Rand
+}
+
+// NewIDGenerator returns the generator of the IDs of the spans created by the sidecar.
+func NewIDGenerator() sdktrace.IDGenerator {
+	var seed int64
+	_ = binary.Read(crand.Reader, binary.LittleEndian, &seed)
+	return &idGenerator{
+		rand: rand.New(rand.NewSource(seed)), //nolint:gosec
+	}
+}
+
+func (g *idGenerator) NewIDs(ctx context.Context) (trace.TraceID, trace.SpanID) {
+	g.lock.Lock()
+	defer g.lock.Unlock()
+
+	tid := trace.TraceID{}
+	_, _ = g.rand.Read(tid[:])
+	return tid, g.newSpanID(ctx)
+}
+
+func (g *idGenerator) NewSpanID(ctx context.Context, traceID trace.TraceID) trace.SpanID {
+	g.lock.Lock()
+	defer g.lock.Unlock()
+
+	return g.newSpanID(ctx)
+}
+
+func (g *idGenerator) newSpanID(ctx context.Context) trace.SpanID {
+	if id, ok := ctx.Value(spanIDContextKey{}).(trace.SpanID); ok && id.IsValid() {
+		return id
+	}
+	sid := trace.SpanID{}
+	_, _ = g.rand.Read(sid[:])
+	return sid
+}
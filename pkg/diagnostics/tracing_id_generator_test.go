@@ -0,0 +1,44 @@
+/*
+Copyright 2023 The Dapr Authors
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package diagnostics
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/trace"
+)
+
+func TestIDGenerator(t *testing.T) {
+	tp := sdktrace.NewTracerProvider(sdktrace.WithIDGenerator(NewIDGenerator()))
+	tracer := tp.Tracer("test")
+
+	ctx, parent := tracer.Start(context.Background(), "parent")
+	assert.True(t, parent.SpanContext().IsValid())
+
+	_, child := tracer.Start(ctx, "child")
+	assert.True(t, child.SpanContext().IsValid())
+	assert.NotEqual(t, parent.SpanContext().SpanID(), child.SpanContext().SpanID())
+
+	id := trace.SpanID{1, 2, 3, 4, 5, 6, 7, 8}
+	_, span := tracer.Start(ContextWithSpanID(ctx, id), "preset")
+	assert.Equal(t, id, span.SpanContext().SpanID())
+	assert.Equal(t, parent.SpanContext().TraceID(), span.SpanContext().TraceID())
+
+	_, root := tracer.Start(ContextWithSpanID(context.Background(), id), "root")
+	assert.Equal(t, id, root.SpanContext().SpanID())
+	assert.NotEqual(t, parent.SpanContext().TraceID(), root.SpanContext().TraceID())
+}
@@ -0,0 +1,239 @@
+/*
+Copyright 2023 The Dapr Authors
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package diagnostics
+
+import (
+	"fmt"
+	"strings"
+	"sync/atomic"
+
+	"go.opentelemetry.io/otel/trace"
+)
+
+// PropagationFormat is a format of the trace context propagated in the headers of requests.
+type PropagationFormat string
+
+const (
+	// PropagationW3C is the W3C trace context, in the traceparent and tracestate headers.
+	PropagationW3C PropagationFormat = "w3c"
+	// PropagationB3 is the Zipkin B3 trace context, in the single b3 header.
+	PropagationB3 PropagationFormat = "b3"
+	// PropagationB3Multi is the Zipkin B3 trace context, in the X-B3-* headers.
+	PropagationB3Multi PropagationFormat = "b3multi"
+)
+
+// Headers of the Zipkin B3 trace context.
+// Reference: https://github.com/openzipkin/b3-propagation
+const (
+	B3Header             = "b3"
+	B3TraceIDHeader      = "x-b3-traceid"
+	B3SpanIDHeader       = "x-b3-spanid"
+	B3ParentSpanIDHeader = "x-b3-parentspanid"
+	B3SampledHeader      = "x-b3-sampled"
+	B3FlagsHeader        = "x-b3-flags"
+)
+
+var (
+	defaultPropagationFormats = []PropagationFormat{PropagationW3C}
+
+	// propagationFormats are the formats of the trace context read from and written to the headers of requests.
+	propagationFormats atomic.Pointer[[]PropagationFormat]
+)
+
+// ParsePropagationFormats parses the comma-separated formats of the propagation option of the tracing configuration.
+// It returns the default formats if the option is empty.
+func ParsePropagationFormats(val string) ([]PropagationFormat, error) {
+	if strings.TrimSpace(val) == "" {
+		return defaultPropagationFormats, nil
+	}
+
+	parts := strings.Split(val, ",")
+	formats := make([]PropagationFormat, 0, len(parts))
+	for _, p := range parts {
+		f := PropagationFormat(strings.ToLower(strings.TrimSpace(p)))
+		switch f {
+		case PropagationW3C, PropagationB3, PropagationB3Multi:
+		default:
+			return nil, fmt.Errorf("invalid trace propagation format '%s': supported formats are %s, %s and %s", strings.TrimSpace(p), PropagationW3C, PropagationB3, PropagationB3Multi)
+		}
+		for _, existing := range formats {
+			if existing == f {
+				return nil, fmt.Errorf("duplicate trace propagation format '%s'", f)
+			}
+		}
+		formats = append(formats, f)
+	}
+	return formats, nil
+}
+
+// SetPropagationFormats sets the formats of the trace context propagated in the headers of requests, in order of precedence.
+func SetPropagationFormats(formats []PropagationFormat) {
+	propagationFormats.Store(&formats)
+}
+
+func getPropagationFormats() []PropagationFormat {
+	if f := propagationFormats.Load(); f != nil && len(*f) > 0 {
+		return *f
+	}
+	return defaultPropagationFormats
+}
+
+// spanContextFromHeaders returns the trace context of the first propagation format found in the headers.
+func spanContextFromHeaders(get func(key string) string) (trace.SpanContext, bool) {
+	for _, f := range getPropagationFormats() {
+		var (
+			sc trace.SpanContext
+			ok bool
+		)
+		switch f {
+		case PropagationW3C:
+			sc, ok = SpanContextFromW3CString(get(TraceparentHeader))
+			if ok {
+				sc = sc.WithTraceState(*TraceStateFromW3CString(get(TracestateHeader)))
+			}
+		case PropagationB3:
+			sc, ok = SpanContextFromB3String(get(B3Header))
+		case PropagationB3Multi:
+			sc, ok = spanContextFromB3Headers(get)
+		}
+		if ok {
+			return sc, true
+		}
+	}
+	return trace.SpanContext{}, false
+}
+
+// hasTraceContextHeaders returns true if the headers contain the trace context in any of the propagation formats.
+func hasTraceContextHeaders(get func(key string) string) bool {
+	for _, f := range getPropagationFormats() {
+		var key string
+		switch f {
+		case PropagationW3C:
+			key = TraceparentHeader
+		case PropagationB3:
+			key = B3Header
+		case PropagationB3Multi:
+			key = B3TraceIDHeader
+		}
+		if get(key) != "" {
+			return true
+		}
+	}
+	return false
+}
+
+// spanContextToHeaders writes the trace context in the headers, in each of the given propagation formats.
+func spanContextToHeaders(sc trace.SpanContext, formats []PropagationFormat, setHeader func(string, string)) {
+	for _, f := range formats {
+		switch f {
+		case PropagationW3C:
+			setHeader(TraceparentHeader, SpanContextToW3CString(sc))
+			tracestateToHeader(sc, setHeader)
+		case PropagationB3:
+			setHeader(B3Header, SpanContextToB3String(sc))
+		case PropagationB3Multi:
+			setHeader(B3TraceIDHeader, sc.TraceID().String())
+			setHeader(B3SpanIDHeader, sc.SpanID().String())
+			setHeader(B3SampledHeader, b3SamplingState(sc))
+		}
+	}
+}
+
+// SpanContextToB3String returns the representation of the SpanContext in the single b3 header.
+func SpanContextToB3String(sc trace.SpanContext) string {
+	return sc.TraceID().String() + "-" + sc.SpanID().String() + "-" + b3SamplingState(sc)
+}
+
+// SpanContextFromB3String extracts a span context from the single b3 header.
+// The header is {TraceId}-{SpanId}, optionally followed by -{SamplingState} and -{ParentSpanId}.
+func SpanContextFromB3String(h string) (trace.SpanContext, bool) {
+	sections := strings.Split(h, "-")
+	if len(sections) < 2 || len(sections) > 4 {
+		// Either empty, or only the sampling state, which doesn't propagate a trace context
+		return trace.SpanContext{}, false
+	}
+
+	var sampled string
+	if len(sections) > 2 {
+		sampled = sections[2]
+		if sampled != "0" && sampled != "1" && sampled != "d" {
+			return trace.SpanContext{}, false
+		}
+	}
+	if len(sections) == 4 {
+		if _, err := trace.SpanIDFromHex(sections[3]); err != nil || len(sections[3]) != 16 {
+			return trace.SpanContext{}, false
+		}
+	}
+
+	return b3SpanContext(sections[0], sections[1], sampled == "1" || sampled == "d")
+}
+
+// spanContextFromB3Headers extracts a span context from the X-B3-* headers.
+func spanContextFromB3Headers(get func(key string) string) (trace.SpanContext, bool) {
+	traceID := get(B3TraceIDHeader)
+	if traceID == "" {
+		return trace.SpanContext{}, false
+	}
+
+	// The debug flag implies the span is sampled
+	sampled := get(B3FlagsHeader) == "1"
+	switch strings.ToLower(get(B3SampledHeader)) {
+	case "1", "true":
+		sampled = true
+	case "", "0", "false":
+	default:
+		return trace.SpanContext{}, false
+	}
+
+	return b3SpanContext(traceID, get(B3SpanIDHeader), sampled)
+}
+
+// b3SpanContext returns the span context with the trace ID and span ID in hex.
+// The trace ID can be 64 or 128-bit long, and 64-bit trace IDs are left-padded with zeros.
+func b3SpanContext(traceIDHex, spanIDHex string, sampled bool) (trace.SpanContext, bool) {
+	if len(traceIDHex) == 16 {
+		traceIDHex = strings.Repeat("0", 16) + traceIDHex
+	}
+	if len(traceIDHex) != 32 || len(spanIDHex) != 16 {
+		return trace.SpanContext{}, false
+	}
+
+	// TraceIDFromHex and SpanIDFromHex fail on all-zero IDs
+	tid, err := trace.TraceIDFromHex(traceIDHex)
+	if err != nil {
+		return trace.SpanContext{}, false
+	}
+	sid, err := trace.SpanIDFromHex(spanIDHex)
+	if err != nil {
+		return trace.SpanContext{}, false
+	}
+
+	var flags trace.TraceFlags
+	if sampled {
+		flags = trace.FlagsSampled
+	}
+	return trace.NewSpanContext(trace.SpanContextConfig{
+		TraceID:    tid,
+		SpanID:     sid,
+		TraceFlags: flags,
+	}), true
+}
+
+func b3SamplingState(sc trace.SpanContext) string {
+	if sc.IsSampled() {
+		return "1"
+	}
+	return "0"
+}
@@ -0,0 +1,204 @@
+/*
+Copyright 2023 The Dapr Authors
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package diagnostics
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	grpcMetadata "google.golang.org/grpc/metadata"
+
+	"github.com/dapr/dapr/pkg/grpc/metadata"
+)
+
+const (
+	testB3TraceID = "4bf92f3577b34da6a3ce929d0e0e4736"
+	testB3SpanID  = "00f067aa0ba902b7"
+)
+
+// setTestPropagationFormats sets the propagation formats for the duration of the test.
+func setTestPropagationFormats(t *testing.T, val string) {
+	t.Helper()
+
+	formats, err := ParsePropagationFormats(val)
+	require.NoError(t, err)
+	SetPropagationFormats(formats)
+	t.Cleanup(func() {
+		SetPropagationFormats(nil)
+	})
+}
+
+func TestParsePropagationFormats(t *testing.T) {
+	formats, err := ParsePropagationFormats("")
+	require.NoError(t, err)
+	assert.Equal(t, []PropagationFormat{PropagationW3C}, formats)
+
+	formats, err = ParsePropagationFormats("B3Multi, w3c")
+	require.NoError(t, err)
+	assert.Equal(t, []PropagationFormat{PropagationB3Multi, PropagationW3C}, formats)
+
+	_, err = ParsePropagationFormats("w3c,jaeger")
+	require.ErrorContains(t, err, "jaeger")
+
+	_, err = ParsePropagationFormats("b3,b3")
+	require.Error(t, err)
+}
+
+func TestSpanContextFromB3String(t *testing.T) {
+	testCases := []struct {
+		name    string
+		header  string
+		ok      bool
+		traceID string
+		sampled bool
+	}{
+		{name: "sampled", header: testB3TraceID + "-" + testB3SpanID + "-1", ok: true, traceID: testB3TraceID, sampled: true},
+		{name: "debug", header: testB3TraceID + "-" + testB3SpanID + "-d", ok: true, traceID: testB3TraceID, sampled: true},
+		{name: "not sampled with parent", header: testB3TraceID + "-" + testB3SpanID + "-0-" + testB3SpanID, ok: true, traceID: testB3TraceID},
+		{name: "deferred", header: testB3TraceID + "-" + testB3SpanID, ok: true, traceID: testB3TraceID},
+		{name: "64-bit trace ID", header: "a3ce929d0e0e4736-" + testB3SpanID + "-1", ok: true, traceID: "0000000000000000a3ce929d0e0e4736", sampled: true},
+		{name: "sampling state only", header: "0"},
+		{name: "empty", header: ""},
+		{name: "invalid sampling state", header: testB3TraceID + "-" + testB3SpanID + "-x"},
+		{name: "invalid span ID", header: testB3TraceID + "-00f067aa"},
+		{name: "zero trace ID", header: "00000000000000000000000000000000-" + testB3SpanID},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			sc, ok := SpanContextFromB3String(tc.header)
+			require.Equal(t, tc.ok, ok)
+			if !ok {
+				return
+			}
+			assert.Equal(t, tc.traceID, sc.TraceID().String())
+			assert.Equal(t, testB3SpanID, sc.SpanID().String())
+			assert.Equal(t, tc.sampled, sc.IsSampled())
+		})
+	}
+}
+
+func TestSpanContextFromRequestPropagation(t *testing.T) {
+	newRequest := func(headers map[string]string) *http.Request {
+		r, _ := http.NewRequest(http.MethodGet, "http://localhost/v1.0/state/store", nil)
+		for k, v := range headers {
+			r.Header.Set(k, v)
+		}
+		return r
+	}
+	traceparent := "00-0af7651916cd43dd8448eb211c80319c-b7ad6b7169203331-01"
+
+	t.Run("w3c by default", func(t *testing.T) {
+		sc := SpanContextFromRequest(newRequest(map[string]string{
+			"X-B3-TraceId":    testB3TraceID,
+			"X-B3-SpanId":     testB3SpanID,
+			TraceparentHeader: traceparent,
+		}))
+		assert.Equal(t, "0af7651916cd43dd8448eb211c80319c", sc.TraceID().String())
+	})
+
+	t.Run("b3multi", func(t *testing.T) {
+		setTestPropagationFormats(t, "b3multi")
+
+		sc := SpanContextFromRequest(newRequest(map[string]string{
+			"X-B3-TraceId": testB3TraceID,
+			"X-B3-SpanId":  testB3SpanID,
+			"X-B3-Sampled": "1",
+		}))
+		assert.Equal(t, testB3TraceID, sc.TraceID().String())
+		assert.Equal(t, testB3SpanID, sc.SpanID().String())
+		assert.True(t, sc.IsSampled())
+
+		sc = SpanContextFromRequest(newRequest(map[string]string{
+			"X-B3-TraceId": testB3TraceID,
+			"X-B3-SpanId":  testB3SpanID,
+			"X-B3-Flags":   "1",
+		}))
+		assert.True(t, sc.IsSampled())
+
+		sc = SpanContextFromRequest(newRequest(map[string]string{TraceparentHeader: traceparent}))
+		assert.False(t, sc.IsValid())
+	})
+
+	t.Run("composite uses the first format found", func(t *testing.T) {
+		setTestPropagationFormats(t, "w3c,b3")
+
+		sc := SpanContextFromRequest(newRequest(map[string]string{
+			B3Header: testB3TraceID + "-" + testB3SpanID + "-1",
+		}))
+		assert.Equal(t, testB3TraceID, sc.TraceID().String())
+
+		sc = SpanContextFromRequest(newRequest(map[string]string{
+			B3Header:          testB3TraceID + "-" + testB3SpanID + "-1",
+			TraceparentHeader: traceparent,
+		}))
+		assert.Equal(t, "0af7651916cd43dd8448eb211c80319c", sc.TraceID().String())
+	})
+}
+
+func TestSpanContextToHTTPHeadersPropagation(t *testing.T) {
+	sc, ok := SpanContextFromW3CString("00-" + testB3TraceID + "-" + testB3SpanID + "-01")
+	require.True(t, ok)
+
+	t.Run("w3c by default", func(t *testing.T) {
+		h := http.Header{}
+		SpanContextToHTTPHeaders(sc, h.Set)
+		assert.Equal(t, http.Header{"Traceparent": {"00-" + testB3TraceID + "-" + testB3SpanID + "-01"}}, h)
+	})
+
+	t.Run("composite", func(t *testing.T) {
+		setTestPropagationFormats(t, "w3c,b3,b3multi")
+
+		h := http.Header{}
+		SpanContextToHTTPHeaders(sc, h.Set)
+		assert.Equal(t, "00-"+testB3TraceID+"-"+testB3SpanID+"-01", h.Get(TraceparentHeader))
+		assert.Equal(t, testB3TraceID+"-"+testB3SpanID+"-1", h.Get(B3Header))
+		assert.Equal(t, testB3TraceID, h.Get(B3TraceIDHeader))
+		assert.Equal(t, testB3SpanID, h.Get(B3SpanIDHeader))
+		assert.Equal(t, "1", h.Get(B3SampledHeader))
+		assert.True(t, hasTraceContextHeaders(h.Get))
+	})
+}
+
+func TestSpanContextGRPCMetadataPropagation(t *testing.T) {
+	setTestPropagationFormats(t, "b3multi,w3c")
+
+	sc, ok := SpanContextFromW3CString("00-" + testB3TraceID + "-" + testB3SpanID + "-00")
+	require.True(t, ok)
+
+	ctx := SpanContextToGRPCMetadata(context.Background(), sc)
+	md, _ := grpcMetadata.FromOutgoingContext(ctx)
+	assert.Len(t, md.Get(GRPCTraceContextKey), 1)
+	assert.Equal(t, []string{testB3TraceID}, md.Get(B3TraceIDHeader))
+	assert.Equal(t, []string{"0"}, md.Get(B3SampledHeader))
+	// The W3C trace context is propagated in grpc-trace-bin
+	assert.Empty(t, md.Get(TraceparentHeader))
+
+	// Incoming metadata without grpc-trace-bin falls back to the propagation formats
+	ctx = grpcMetadata.NewIncomingContext(context.Background(), grpcMetadata.Pairs(
+		B3TraceIDHeader, testB3TraceID,
+		B3SpanIDHeader, testB3SpanID,
+	))
+	metadata.SetMetadataInContextUnary(ctx, nil, nil, func(c context.Context, req any) (any, error) {
+		ctx = c
+		return nil, nil
+	})
+	got, ok := SpanContextFromIncomingGRPCMetadata(ctx)
+	require.True(t, ok)
+	assert.Equal(t, sc.TraceID(), got.TraceID())
+	assert.Equal(t, sc.SpanID(), got.SpanID())
+}
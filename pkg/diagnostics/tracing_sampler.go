@@ -13,6 +13,8 @@ type DaprTraceSampler struct {
 	sdktrace.Sampler
 	ProbabilitySampler sdktrace.Sampler
 	SamplingRate       float64
+	// TailSampling records the spans that are not sampled instead of dropping them, so a tail sampling processor can still export them when they end.
+	TailSampling bool
 }
 
 /**
@@ -28,10 +30,18 @@ func (d *DaprTraceSampler) ShouldSample(p sdktrace.SamplingParameters) sdktrace.
 	}
 
 	// Parent is invalid or does not have sampling enabled -> sample probabilistically
-	return d.ProbabilitySampler.ShouldSample(p)
+	res := d.ProbabilitySampler.ShouldSample(p)
+	if d.TailSampling && res.Decision == sdktrace.Drop {
+		// Not sampled -> record only, the span is exported if the tail sampling policy keeps it
+		res.Decision = sdktrace.RecordOnly
+	}
+	return res
 }
 
 func (d *DaprTraceSampler) Description() string {
+	if d.TailSampling {
+		return fmt.Sprintf("DaprTraceSampler(P=%f, tail sampling)", d.SamplingRate)
+	}
 	return fmt.Sprintf("DaprTraceSampler(P=%f)", d.SamplingRate)
 }
 
@@ -0,0 +1,105 @@
+/*
+Copyright 2023 The Dapr Authors
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package diagnostics
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+
+	"go.opentelemetry.io/otel/attribute"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+
+	"github.com/dapr/dapr/pkg/config"
+)
+
+// ValidateSpanAttributes returns an error if the span attributes of the tracing spec are invalid.
+func ValidateSpanAttributes(specs []config.SpanAttributeSpec) error {
+	for _, s := range specs {
+		if s.Name == "" {
+			return errors.New("invalid span attribute: name is required")
+		}
+		sources := 0
+		for _, v := range []string{s.Value, s.EnvVar, s.Header} {
+			if v != "" {
+				sources++
+			}
+		}
+		if sources != 1 {
+			return fmt.Errorf("invalid span attribute '%s': exactly one of value, envVar and header must be set", s.Name)
+		}
+	}
+	return nil
+}
+
+// StaticSpanAttributes returns the span attributes of the tracing spec whose values are known when the sidecar starts, from static values and environment variables.
+func StaticSpanAttributes(specs []config.SpanAttributeSpec) []attribute.KeyValue {
+	attrs := make([]attribute.KeyValue, 0, len(specs))
+	for _, s := range specs {
+		switch {
+		case s.Value != "":
+			attrs = append(attrs, attribute.String(s.Name, s.Value))
+		case s.EnvVar != "":
+			if v := os.Getenv(s.EnvVar); v != "" {
+				attrs = append(attrs, attribute.String(s.Name, v))
+			}
+		}
+	}
+	return attrs
+}
+
+// headerSpanAttributes returns the span attributes of the tracing spec whose values are in the headers of a request, using get to read the headers.
+func headerSpanAttributes(spec config.TracingSpec, get func(header string) string) map[string]string {
+	var attrs map[string]string
+	for _, s := range spec.SpanAttributes {
+		if s.Header == "" {
+			continue
+		}
+		if v := get(strings.ToLower(s.Header)); v != "" {
+			if attrs == nil {
+				attrs = make(map[string]string, len(spec.SpanAttributes))
+			}
+			attrs[s.Name] = v
+		}
+	}
+	return attrs
+}
+
+// spanAttributesProcessor sets attributes on every span when it starts.
+type spanAttributesProcessor struct {
+	attrs []attribute.KeyValue
+}
+
+// NewSpanAttributesProcessor returns a span processor that sets the attributes on every span created by the tracer provider, such as the ones returned by StaticSpanAttributes.
+func NewSpanAttributesProcessor(attrs []attribute.KeyValue) sdktrace.SpanProcessor {
+	return &spanAttributesProcessor{
+		attrs: attrs,
+	}
+}
+
+func (p *spanAttributesProcessor) OnStart(parent context.Context, s sdktrace.ReadWriteSpan) {
+	s.SetAttributes(p.attrs...)
+}
+
+func (p *spanAttributesProcessor) OnEnd(s sdktrace.ReadOnlySpan) {}
+
+func (p *spanAttributesProcessor) Shutdown(ctx context.Context) error {
+	return nil
+}
+
+func (p *spanAttributesProcessor) ForceFlush(ctx context.Context) error {
+	return nil
+}
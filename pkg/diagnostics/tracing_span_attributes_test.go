@@ -0,0 +1,86 @@
+/*
+Copyright 2023 The Dapr Authors
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package diagnostics
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel/attribute"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+
+	"github.com/dapr/dapr/pkg/config"
+)
+
+func TestValidateSpanAttributes(t *testing.T) {
+	require.NoError(t, ValidateSpanAttributes(nil))
+	require.NoError(t, ValidateSpanAttributes([]config.SpanAttributeSpec{
+		{Name: "version", Value: "1.0"},
+		{Name: "pod", EnvVar: "POD_NAME"},
+		{Name: "tenant", Header: "x-tenant"},
+	}))
+
+	require.Error(t, ValidateSpanAttributes([]config.SpanAttributeSpec{{Value: "1.0"}}))
+	require.Error(t, ValidateSpanAttributes([]config.SpanAttributeSpec{{Name: "version"}}))
+	require.Error(t, ValidateSpanAttributes([]config.SpanAttributeSpec{{Name: "version", Value: "1.0", Header: "x-version"}}))
+}
+
+func TestStaticSpanAttributes(t *testing.T) {
+	t.Setenv("DAPR_TEST_NODE_NAME", "node1")
+
+	attrs := StaticSpanAttributes([]config.SpanAttributeSpec{
+		{Name: "version", Value: "1.0"},
+		{Name: "node", EnvVar: "DAPR_TEST_NODE_NAME"},
+		{Name: "missing", EnvVar: "DAPR_TEST_MISSING"},
+		{Name: "tenant", Header: "x-tenant"},
+	})
+	assert.Equal(t, []attribute.KeyValue{
+		attribute.String("version", "1.0"),
+		attribute.String("node", "node1"),
+	}, attrs)
+}
+
+func TestHeaderSpanAttributes(t *testing.T) {
+	spec := config.TracingSpec{
+		SpanAttributes: []config.SpanAttributeSpec{
+			{Name: "version", Value: "1.0"},
+			{Name: "tenant", Header: "X-Tenant"},
+			{Name: "user", Header: "x-user"},
+		},
+	}
+
+	h := http.Header{}
+	h.Set("X-Tenant", "contoso")
+	assert.Equal(t, map[string]string{"tenant": "contoso"}, headerSpanAttributes(spec, h.Get))
+	assert.Nil(t, headerSpanAttributes(spec, http.Header{}.Get))
+}
+
+func TestSpanAttributesProcessor(t *testing.T) {
+	recorder := tracetest.NewSpanRecorder()
+	tp := sdktrace.NewTracerProvider(
+		sdktrace.WithSpanProcessor(NewSpanAttributesProcessor([]attribute.KeyValue{attribute.String("version", "1.0")})),
+		sdktrace.WithSpanProcessor(recorder),
+	)
+
+	_, span := tp.Tracer("test").Start(context.Background(), "span")
+	span.End()
+
+	ended := recorder.Ended()
+	require.Len(t, ended, 1)
+	assert.Contains(t, ended[0].Attributes(), attribute.String("version", "1.0"))
+}
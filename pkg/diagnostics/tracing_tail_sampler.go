@@ -0,0 +1,117 @@
+/*
+Copyright 2023 The Dapr Authors
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package diagnostics
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"go.opentelemetry.io/otel/codes"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/trace"
+
+	"github.com/dapr/dapr/pkg/config"
+)
+
+// TailSamplingPolicy decides, when a span ends, whether a span that was not sampled must be exported anyway.
+type TailSamplingPolicy struct {
+	// Errors keeps the spans that end with an error status.
+	Errors bool
+	// LatencyThreshold keeps the spans that take longer than this duration, if positive.
+	LatencyThreshold time.Duration
+}
+
+// NewTailSamplingPolicy returns the tail sampling policy from the tracing spec.
+// It returns nil if tail sampling is not configured.
+func NewTailSamplingPolicy(spec *config.TailSamplingSpec) (*TailSamplingPolicy, error) {
+	if spec == nil {
+		return nil, nil
+	}
+
+	p := &TailSamplingPolicy{
+		Errors: spec.Errors,
+	}
+	if spec.LatencyThreshold != "" {
+		threshold, err := time.ParseDuration(spec.LatencyThreshold)
+		if err != nil || threshold <= 0 {
+			return nil, fmt.Errorf("invalid tail sampling latency threshold '%s': must be a positive duration", spec.LatencyThreshold)
+		}
+		p.LatencyThreshold = threshold
+	}
+
+	if !p.Errors && p.LatencyThreshold == 0 {
+		return nil, nil
+	}
+	return p, nil
+}
+
+// Keep returns true if the span must be exported.
+func (p *TailSamplingPolicy) Keep(s sdktrace.ReadOnlySpan) bool {
+	if p.Errors && s.Status().Code == codes.Error {
+		return true
+	}
+	return p.LatencyThreshold > 0 && s.EndTime().Sub(s.StartTime()) > p.LatencyThreshold
+}
+
+func (p *TailSamplingPolicy) String() string {
+	return fmt.Sprintf("TailSamplingPolicy(errors=%t, latencyThreshold=%s)", p.Errors, p.LatencyThreshold)
+}
+
+// tailSamplingProcessor forwards the sampled spans to the next processor, and the spans that were only recorded when the tail sampling policy keeps them.
+type tailSamplingProcessor struct {
+	next   sdktrace.SpanProcessor
+	policy *TailSamplingPolicy
+}
+
+// NewTailSamplingProcessor returns a span processor that applies the tail sampling policy before the spans reach the next processor, which usually exports them.
+// The spans that are not sampled are only available when the sampler records them, see DaprTraceSampler.TailSampling.
+func NewTailSamplingProcessor(next sdktrace.SpanProcessor, policy *TailSamplingPolicy) sdktrace.SpanProcessor {
+	return &tailSamplingProcessor{
+		next:   next,
+		policy: policy,
+	}
+}
+
+func (t *tailSamplingProcessor) OnStart(parent context.Context, s sdktrace.ReadWriteSpan) {
+	t.next.OnStart(parent, s)
+}
+
+func (t *tailSamplingProcessor) OnEnd(s sdktrace.ReadOnlySpan) {
+	if s.SpanContext().IsSampled() {
+		t.next.OnEnd(s)
+		return
+	}
+	if t.policy.Keep(s) {
+		t.next.OnEnd(forceSampledSpan{s})
+	}
+}
+
+func (t *tailSamplingProcessor) Shutdown(ctx context.Context) error {
+	return t.next.Shutdown(ctx)
+}
+
+func (t *tailSamplingProcessor) ForceFlush(ctx context.Context) error {
+	return t.next.ForceFlush(ctx)
+}
+
+// forceSampledSpan is a span that was recorded but not sampled, reported as sampled so the exporters don't skip it.
+type forceSampledSpan struct {
+	sdktrace.ReadOnlySpan
+}
+
+func (s forceSampledSpan) SpanContext() trace.SpanContext {
+	sc := s.ReadOnlySpan.SpanContext()
+	return sc.WithTraceFlags(sc.TraceFlags().WithSampled(true))
+}
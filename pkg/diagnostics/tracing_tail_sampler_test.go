@@ -0,0 +1,98 @@
+/*
+Copyright 2023 The Dapr Authors
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package diagnostics
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel/codes"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"go.opentelemetry.io/otel/trace"
+
+	"github.com/dapr/dapr/pkg/config"
+)
+
+func TestNewTailSamplingPolicy(t *testing.T) {
+	t.Run("not configured", func(t *testing.T) {
+		p, err := NewTailSamplingPolicy(nil)
+		require.NoError(t, err)
+		assert.Nil(t, p)
+
+		p, err = NewTailSamplingPolicy(&config.TailSamplingSpec{})
+		require.NoError(t, err)
+		assert.Nil(t, p)
+	})
+
+	t.Run("errors and latency", func(t *testing.T) {
+		p, err := NewTailSamplingPolicy(&config.TailSamplingSpec{Errors: true, LatencyThreshold: "500ms"})
+		require.NoError(t, err)
+		assert.Equal(t, &TailSamplingPolicy{Errors: true, LatencyThreshold: 500 * time.Millisecond}, p)
+	})
+
+	t.Run("invalid latency threshold", func(t *testing.T) {
+		_, err := NewTailSamplingPolicy(&config.TailSamplingSpec{LatencyThreshold: "-1s"})
+		require.Error(t, err)
+
+		_, err = NewTailSamplingPolicy(&config.TailSamplingSpec{LatencyThreshold: "slow"})
+		require.Error(t, err)
+	})
+}
+
+func TestTailSampling(t *testing.T) {
+	recorder := tracetest.NewSpanRecorder()
+	sampler := NewDaprTraceSampler("0")
+	sampler.TailSampling = true
+	tp := sdktrace.NewTracerProvider(
+		sdktrace.WithSampler(sampler),
+		sdktrace.WithSpanProcessor(NewTailSamplingProcessor(recorder, &TailSamplingPolicy{
+			Errors:           true,
+			LatencyThreshold: time.Second,
+		})),
+	)
+	tracer := tp.Tracer("test")
+	start := time.Now()
+
+	_, span := tracer.Start(context.Background(), "ok", trace.WithTimestamp(start))
+	assert.True(t, span.IsRecording())
+	assert.False(t, span.SpanContext().IsSampled())
+	span.End(trace.WithTimestamp(start.Add(time.Millisecond)))
+
+	_, span = tracer.Start(context.Background(), "error", trace.WithTimestamp(start))
+	span.SetStatus(codes.Error, "failed")
+	span.End(trace.WithTimestamp(start.Add(time.Millisecond)))
+
+	_, span = tracer.Start(context.Background(), "slow", trace.WithTimestamp(start))
+	span.End(trace.WithTimestamp(start.Add(2 * time.Second)))
+
+	ended := recorder.Ended()
+	require.Len(t, ended, 2)
+	assert.Equal(t, "error", ended[0].Name())
+	assert.True(t, ended[0].SpanContext().IsSampled())
+	assert.Equal(t, "slow", ended[1].Name())
+	assert.True(t, ended[1].SpanContext().IsSampled())
+}
+
+func TestTailSamplingDisabled(t *testing.T) {
+	sampler := NewDaprTraceSampler("0")
+	tp := sdktrace.NewTracerProvider(sdktrace.WithSampler(sampler))
+
+	_, span := tp.Tracer("test").Start(context.Background(), "dropped")
+	assert.False(t, span.IsRecording())
+	span.End()
+}
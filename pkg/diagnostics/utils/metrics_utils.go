@@ -23,15 +23,76 @@ import (
 	"go.opencensus.io/tag"
 
 	"github.com/dapr/dapr/pkg/config"
+	"github.com/dapr/dapr/utils"
 )
 
-var metricsRules map[string][]regexPair
+// maxCachedTagMutators is the maximum number of entries in the cache of tag mutators.
+// Once reached, mutators for new combinations of tags are not cached, to bound the memory used with high-cardinality tags.
+const maxCachedTagMutators = 4096
+
+var (
+	metricsRules map[string][]regexPair
+
+	// labelFilters drops or rewrites the tags of all the measures; nil if not configured.
+	labelFilters *labelFilter
+
+	// tagMutatorsCache contains the tag mutators returned by WithCachedTags, keyed by measure name and tag keys and values.
+	tagMutatorsCache utils.AtomicMap[string, []tag.Mutator]
+)
 
 type regexPair struct {
 	regex   *regexp.Regexp
 	replace string
 }
 
+// labelFilter drops or rewrites the values of the tags of all the measures, to limit their cardinality.
+type labelFilter struct {
+	allow    map[string]struct{}
+	deny     map[string]struct{}
+	rewrites map[string][]labelRewrite
+}
+
+type labelRewrite struct {
+	regex       *regexp.Regexp
+	replace     string
+	statusClass bool
+}
+
+// apply returns the value of the tag after the rewrites, and false if the tag is dropped.
+func (f *labelFilter) apply(key string, value string) (string, bool) {
+	if f.allow != nil {
+		if _, ok := f.allow[key]; !ok {
+			return "", false
+		}
+	}
+	if _, ok := f.deny[key]; ok {
+		return "", false
+	}
+
+	for _, r := range f.rewrites[key] {
+		if r.statusClass {
+			value = statusClass(value)
+			continue
+		}
+		value = r.regex.ReplaceAllString(value, r.replace)
+	}
+	return value, value != ""
+}
+
+// statusClass returns the class of an HTTP status code, such as "4xx" for "404".
+// Values that are not HTTP status codes are returned unchanged.
+func statusClass(code string) string {
+	if len(code) != 3 || code[0] < '1' || code[0] > '5' {
+		return code
+	}
+	for i := 1; i < 3; i++ {
+		if code[i] < '0' || code[i] > '9' {
+			return code
+		}
+	}
+	return code[:1] + "xx"
+}
+
 // NewMeasureView creates opencensus View instance using stats.Measure.
 func NewMeasureView(measure stats.Measure, keys []tag.Key, aggregation *view.Aggregation) *view.View {
 	return &view.View{
@@ -70,11 +131,55 @@ func WithTags(name string, opts ...interface{}) []tag.Mutator {
 			}
 		}
 
+		if labelFilters != nil {
+			var ok bool
+			value, ok = labelFilters.apply(key.Name(), value)
+			if !ok {
+				continue
+			}
+		}
+
 		tagMutators = append(tagMutators, tag.Upsert(key, value))
 	}
 	return tagMutators
 }
 
+// WithCachedTags is like WithTags, but it caches the returned tag mutators for each combination of measure name, tag keys, and tag values.
+// It's meant to be used in hot paths, such as metrics that are recorded for each message, where the same tags are used repeatedly.
+// The returned slice is shared and must not be modified.
+func WithCachedTags(name string, opts ...interface{}) []tag.Mutator {
+	key := tagMutatorsCacheKey(name, opts)
+	if tagMutators, ok := tagMutatorsCache.Load(key); ok {
+		return tagMutators
+	}
+
+	tagMutators := WithTags(name, opts...)
+	if tagMutatorsCache.Len() < maxCachedTagMutators {
+		tagMutatorsCache.Store(key, tagMutators)
+	}
+	return tagMutators
+}
+
+func tagMutatorsCacheKey(name string, opts []interface{}) string {
+	var b strings.Builder
+	b.WriteString(name)
+	for i := 0; i < len(opts)-1; i += 2 {
+		key, ok := opts[i].(tag.Key)
+		if !ok {
+			break
+		}
+		value, ok := opts[i+1].(string)
+		if !ok {
+			break
+		}
+		b.WriteByte(0)
+		b.WriteString(key.Name())
+		b.WriteByte(0)
+		b.WriteString(value)
+	}
+	return b.String()
+}
+
 // AddNewTagKey adds new tag keys to existing view.
 func AddNewTagKey(views []*view.View, key *tag.Key) []*view.View {
 	for _, v := range views {
@@ -114,5 +219,55 @@ func CreateRulesMap(rules []config.MetricsRule) error {
 	}
 
 	metricsRules = newMetricsRules
+
+	// Rules change the values of the tags, so cached mutators can't be used anymore
+	tagMutatorsCache.Clear()
+	return nil
+}
+
+// CreateLabelFilters sets the label controls applied to the tags of all the measures.
+// Passing nil removes them.
+func CreateLabelFilters(spec *config.MetricLabelsSpec) error {
+	if spec == nil || (len(spec.Allow) == 0 && len(spec.Deny) == 0 && len(spec.Rewrites) == 0) {
+		labelFilters = nil
+		tagMutatorsCache.Clear()
+		return nil
+	}
+
+	f := &labelFilter{
+		deny:     make(map[string]struct{}, len(spec.Deny)),
+		rewrites: make(map[string][]labelRewrite, len(spec.Rewrites)),
+	}
+	if len(spec.Allow) > 0 {
+		f.allow = make(map[string]struct{}, len(spec.Allow))
+		for _, l := range spec.Allow {
+			f.allow[l] = struct{}{}
+		}
+	}
+	for _, l := range spec.Deny {
+		f.deny[l] = struct{}{}
+	}
+	for i, r := range spec.Rewrites {
+		if r.Name == "" {
+			return fmt.Errorf("label rewrite %d has no name", i)
+		}
+		switch {
+		case r.StatusClass:
+			f.rewrites[r.Name] = append(f.rewrites[r.Name], labelRewrite{statusClass: true})
+		case r.Regex != "":
+			regex, err := regexp.Compile(r.Regex)
+			if err != nil {
+				return fmt.Errorf("failed to compile regex for label rewrite %s: %w", r.Name, err)
+			}
+			f.rewrites[r.Name] = append(f.rewrites[r.Name], labelRewrite{regex: regex, replace: r.Replace})
+		default:
+			return fmt.Errorf("label rewrite %s must have either a regex or statusClass set", r.Name)
+		}
+	}
+
+	labelFilters = f
+
+	// Filters change the tags, so cached mutators can't be used anymore
+	tagMutatorsCache.Clear()
 	return nil
 }
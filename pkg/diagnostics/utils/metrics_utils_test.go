@@ -14,6 +14,7 @@ limitations under the License.
 package utils
 
 import (
+	"context"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -61,6 +62,30 @@ func TestWithTags(t *testing.T) {
 	})
 }
 
+func TestWithCachedTags(t *testing.T) {
+	appKey := tag.MustNewKey("app_id")
+	operationKey := tag.MustNewKey("operation")
+	t.Cleanup(tagMutatorsCache.Clear)
+
+	mutators := WithCachedTags("test/measure", appKey, "test", operationKey, "op")
+	assert.Len(t, mutators, 2)
+	assert.Equal(t, 1, tagMutatorsCache.Len())
+
+	// Same tags return the cached mutators
+	cached := WithCachedTags("test/measure", appKey, "test", operationKey, "op")
+	assert.Same(t, &mutators[0], &cached[0])
+	assert.Equal(t, 1, tagMutatorsCache.Len())
+
+	// Different values or measures are cached separately
+	WithCachedTags("test/measure", appKey, "test", operationKey, "op2")
+	WithCachedTags("test/measure2", appKey, "test", operationKey, "op")
+	assert.Equal(t, 3, tagMutatorsCache.Len())
+
+	// Changing the rules invalidates the cache
+	require.NoError(t, CreateRulesMap(nil))
+	assert.Equal(t, 0, tagMutatorsCache.Len())
+}
+
 func TestCreateRulesMap(t *testing.T) {
 	t.Run("invalid rule", func(t *testing.T) {
 		err := CreateRulesMap([]config.MetricsRule{
@@ -102,3 +127,93 @@ func TestCreateRulesMap(t *testing.T) {
 		assert.NotNil(t, metricsRules["testlabel"][0].regex)
 	})
 }
+
+func TestCreateLabelFilters(t *testing.T) {
+	appKey := tag.MustNewKey("app_id")
+	pathKey := tag.MustNewKey("path")
+	statusKey := tag.MustNewKey("status")
+	operationKey := tag.MustNewKey("operation")
+	t.Cleanup(func() {
+		require.NoError(t, CreateLabelFilters(nil))
+	})
+
+	tagValues := func(mutators []tag.Mutator) map[string]string {
+		ctx, err := tag.New(context.Background(), mutators...)
+		require.NoError(t, err)
+		res := map[string]string{}
+		for _, k := range []tag.Key{appKey, pathKey, statusKey, operationKey} {
+			if v, ok := tag.FromContext(ctx).Value(k); ok {
+				res[k.Name()] = v
+			}
+		}
+		return res
+	}
+
+	t.Run("invalid rewrites", func(t *testing.T) {
+		require.Error(t, CreateLabelFilters(&config.MetricLabelsSpec{
+			Rewrites: []config.MetricLabelRewrite{{Name: "path", Regex: "["}},
+		}))
+		require.Error(t, CreateLabelFilters(&config.MetricLabelsSpec{
+			Rewrites: []config.MetricLabelRewrite{{Name: "path"}},
+		}))
+		require.Error(t, CreateLabelFilters(&config.MetricLabelsSpec{
+			Rewrites: []config.MetricLabelRewrite{{Regex: ".+"}},
+		}))
+	})
+
+	t.Run("deny list", func(t *testing.T) {
+		require.NoError(t, CreateLabelFilters(&config.MetricLabelsSpec{
+			Deny: []string{"path"},
+		}))
+		assert.Equal(t, map[string]string{
+			"app_id": "test",
+			"status": "404",
+		}, tagValues(WithTags("test/measure", appKey, "test", pathKey, "/v1.0/state/store", statusKey, "404")))
+	})
+
+	t.Run("allow list", func(t *testing.T) {
+		require.NoError(t, CreateLabelFilters(&config.MetricLabelsSpec{
+			Allow: []string{"app_id", "operation"},
+		}))
+		assert.Equal(t, map[string]string{
+			"app_id":    "test",
+			"operation": "get",
+		}, tagValues(WithTags("test/measure", appKey, "test", pathKey, "/v1.0/state/store", operationKey, "get")))
+	})
+
+	t.Run("rewrites", func(t *testing.T) {
+		require.NoError(t, CreateLabelFilters(&config.MetricLabelsSpec{
+			Rewrites: []config.MetricLabelRewrite{
+				{Name: "path", Regex: "^/v1.0/state/[^/]+/.+$", Replace: "/v1.0/state/{storeName}/{key}"},
+				{Name: "status", StatusClass: true},
+			},
+		}))
+		assert.Equal(t, map[string]string{
+			"app_id": "test",
+			"path":   "/v1.0/state/{storeName}/{key}",
+			"status": "4xx",
+		}, tagValues(WithTags("test/measure", appKey, "test", pathKey, "/v1.0/state/store/mykey", statusKey, "404")))
+
+		// Values that are not HTTP status codes are kept
+		assert.Equal(t, map[string]string{
+			"status": "NotFound",
+		}, tagValues(WithTags("test/measure", statusKey, "NotFound")))
+	})
+
+	t.Run("changing the filters invalidates the cache", func(t *testing.T) {
+		WithCachedTags("test/measure", appKey, "test")
+		assert.Equal(t, 1, tagMutatorsCache.Len())
+		require.NoError(t, CreateLabelFilters(nil))
+		assert.Equal(t, 0, tagMutatorsCache.Len())
+		assert.Nil(t, labelFilters)
+	})
+}
+
+func TestStatusClass(t *testing.T) {
+	assert.Equal(t, "2xx", statusClass("200"))
+	assert.Equal(t, "5xx", statusClass("503"))
+	assert.Equal(t, "OK", statusClass("OK"))
+	assert.Equal(t, "600", statusClass("600"))
+	assert.Equal(t, "4x4", statusClass("4x4"))
+	assert.Equal(t, "2000", statusClass("2000"))
+}
@@ -0,0 +1,311 @@
+/*
+Copyright 2023 The Dapr Authors
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package diagnostics
+
+import (
+	"context"
+	"sync/atomic"
+
+	"go.opencensus.io/stats"
+	"go.opencensus.io/stats/view"
+	"go.opencensus.io/tag"
+
+	diagUtils "github.com/dapr/dapr/pkg/diagnostics/utils"
+)
+
+var (
+	workflowNameKey = tag.MustNewKey("workflow_name")
+	activityNameKey = tag.MustNewKey("activity_name")
+)
+
+const (
+	StatusSuccess = "success"
+	StatusFailed  = "failed"
+	// StatusRecoverable is the status of workflow and activity executions that failed and will be retried.
+	StatusRecoverable = "recoverable"
+
+	CreateWorkflow    = "create_workflow"
+	GetWorkflow       = "get_workflow"
+	AddEvent          = "add_event"
+	PurgeWorkflow     = "purge_workflow"
+	TerminateWorkflow = "terminate_workflow"
+	SuspendWorkflow   = "suspend_workflow"
+	ResumeWorkflow    = "resume_workflow"
+	ListWorkflows     = "list_workflows"
+
+	// Types of the work items executed by the workflow engine, and of the reminders that trigger them.
+	WorkflowReminder = "workflow"
+	ActivityReminder = "activity"
+
+	// Reasons for which completed workflow instances are purged by the retention policy.
+	RetentionMaxAge       = "max_age"
+	RetentionMaxInstances = "max_instances"
+
+	// Operations on the reminders used by the workflow engine.
+	CreateReminder = "create_reminder"
+	FireReminder   = "fire_reminder"
+
+	// Outcomes of the events raised on workflow instances before they're created.
+	EventBuffered  = "buffered"
+	EventDelivered = "delivered"
+	EventExpired   = "expired"
+)
+
+// WorkflowOperationRecorder records the metrics for the workflow management operations.
+// It is implemented by the workflow metrics, and allows injecting a recorder in the API layer that serves both the HTTP and gRPC frontends.
+type WorkflowOperationRecorder interface {
+	WorkflowOperationEvent(ctx context.Context, operation, workflowName, status string, elapsed float64)
+}
+
+// workflowMetrics holds dapr runtime metrics for workflows.
+type workflowMetrics struct {
+	// workflowOperationCount records count of Successful/Failed requests to Create/Get/Purge Workflow and Add Events.
+	workflowOperationCount *stats.Int64Measure
+	// workflowOperationLatency records latency of response for workflow operation requests.
+	workflowOperationLatency *stats.Float64Measure
+	// workflowRemindersCount records count of Successful/Failed reminders created and fired by the workflow engine.
+	// These are counted separately from the reminders of user actors, to isolate the load workflows put on the state store.
+	workflowRemindersCount *stats.Int64Measure
+	// workflowWorkItemsPreempted records count of workflow and activity executions that were preempted by a shutdown of the workflow engine.
+	workflowWorkItemsPreempted *stats.Int64Measure
+	// workflowExecutionCount records count of Successful/Failed workflow and activity executions.
+	workflowExecutionCount *stats.Int64Measure
+	// workflowExecutionLatency records latency of workflow and activity executions, including the time spent in the app.
+	workflowExecutionLatency *stats.Float64Measure
+	// workflowSchedulingLatency records the time between the reminder of a workflow or activity firing and the execution starting.
+	// It includes the time spent loading the state of the workflow or activity and waiting for the engine to accept the execution: high values indicate that too many executions are in-flight.
+	workflowSchedulingLatency *stats.Float64Measure
+	// workflowRetentionPurgedCount records count of completed workflow instances purged by the retention policy.
+	workflowRetentionPurgedCount *stats.Int64Measure
+	// workflowRetentionReclaimedKeys records the number of state store keys deleted when purging workflow instances with the retention policy.
+	workflowRetentionReclaimedKeys *stats.Int64Measure
+	// workflowSuspendedInstances records the number of suspended workflow instances of the app, computed from the workflow index.
+	// It's recorded by a single sidecar of the app at a time.
+	workflowSuspendedInstances *stats.Int64Measure
+	// workflowBufferedEventsCount records count of events raised on workflow instances before they were created, by outcome.
+	workflowBufferedEventsCount *stats.Int64Measure
+
+	appID     string
+	enabled   atomic.Bool
+	namespace string
+	// names bounds the cardinality of the workflow and activity name tags. It is nil if the metrics are not tagged with names.
+	names *nameLimiter
+}
+
+func newWorkflowMetrics() *workflowMetrics {
+	return &workflowMetrics{
+		workflowOperationCount: stats.Int64(
+			"runtime/workflow/operation/count",
+			"The number of successful/failed workflow operation requests.",
+			stats.UnitDimensionless),
+		workflowOperationLatency: stats.Float64(
+			"runtime/workflow/operation/latency",
+			"The latencies of responses for workflow operation requests.",
+			stats.UnitMilliseconds),
+		workflowRemindersCount: stats.Int64(
+			"runtime/workflow/reminders/count",
+			"The number of successful/failed reminders created and fired by the workflow engine.",
+			stats.UnitDimensionless),
+		workflowWorkItemsPreempted: stats.Int64(
+			"runtime/workflow/work_items/preempted/count",
+			"The number of workflow and activity executions that were not started or were canceled because the workflow engine was shutting down.",
+			stats.UnitDimensionless),
+		workflowExecutionCount: stats.Int64(
+			"runtime/workflow/execution/count",
+			"The number of successful/failed workflow and activity executions.",
+			stats.UnitDimensionless),
+		workflowExecutionLatency: stats.Float64(
+			"runtime/workflow/execution/latency",
+			"The latencies of workflow and activity executions.",
+			stats.UnitMilliseconds),
+		workflowSchedulingLatency: stats.Float64(
+			"runtime/workflow/scheduling/latency",
+			"The latencies between the reminder of a workflow or activity firing and its execution starting.",
+			stats.UnitMilliseconds),
+		workflowRetentionPurgedCount: stats.Int64(
+			"runtime/workflow/retention/purged/count",
+			"The number of completed workflow instances purged by the retention policy.",
+			stats.UnitDimensionless),
+		workflowRetentionReclaimedKeys: stats.Int64(
+			"runtime/workflow/retention/reclaimed_keys/count",
+			"The number of state store keys deleted when purging completed workflow instances with the retention policy.",
+			stats.UnitDimensionless),
+		workflowSuspendedInstances: stats.Int64(
+			"runtime/workflow/suspended_instances",
+			"The number of workflow instances that are suspended.",
+			stats.UnitDimensionless),
+		workflowBufferedEventsCount: stats.Int64(
+			"runtime/workflow/buffered_events/count",
+			"The number of events raised on workflow instances before they were created, that were buffered, delivered or expired.",
+			stats.UnitDimensionless),
+	}
+}
+
+// IsEnabled returns true if the workflow metrics are enabled.
+func (w *workflowMetrics) IsEnabled() bool {
+	return w != nil && w.enabled.Load()
+}
+
+// Init registers the workflow metrics views.
+// If latency is nil, the latency views use the default distribution.
+// If maxNames is greater than 0, the operation and execution metrics are tagged with the names of the workflows and activities,
+// up to maxNames distinct names; the less frequent names are recorded as "other".
+func (w *workflowMetrics) Init(appID, namespace string, latency *view.Aggregation, maxNames int) error {
+	w.appID = appID
+	w.enabled.Store(true)
+	w.namespace = namespace
+	w.names = newNameLimiter(maxNames)
+	latency = latencyOrDefault(latency)
+
+	operationKeys := []tag.Key{appIDKey, namespaceKey, operationKey, statusKey}
+	executionKeys := []tag.Key{appIDKey, namespaceKey, typeKey, statusKey}
+	if w.names != nil {
+		operationKeys = append(operationKeys, workflowNameKey)
+		executionKeys = append(executionKeys, workflowNameKey, activityNameKey)
+	}
+
+	return registerViews(
+		diagUtils.NewMeasureView(w.workflowOperationCount, operationKeys, view.Count()),
+		diagUtils.NewMeasureView(w.workflowOperationLatency, operationKeys, latency),
+		diagUtils.NewMeasureView(w.workflowRemindersCount, []tag.Key{appIDKey, namespaceKey, typeKey, operationKey, statusKey}, view.Count()),
+		diagUtils.NewMeasureView(w.workflowWorkItemsPreempted, []tag.Key{appIDKey, namespaceKey, typeKey}, view.Count()),
+		diagUtils.NewMeasureView(w.workflowExecutionCount, executionKeys, view.Count()),
+		diagUtils.NewMeasureView(w.workflowExecutionLatency, executionKeys, latency),
+		diagUtils.NewMeasureView(w.workflowSchedulingLatency, []tag.Key{appIDKey, namespaceKey, typeKey}, latency),
+		diagUtils.NewMeasureView(w.workflowRetentionPurgedCount, []tag.Key{appIDKey, namespaceKey, failReasonKey}, view.Sum()),
+		diagUtils.NewMeasureView(w.workflowRetentionReclaimedKeys, []tag.Key{appIDKey, namespaceKey, failReasonKey}, view.Sum()),
+		diagUtils.NewMeasureView(w.workflowSuspendedInstances, []tag.Key{appIDKey, namespaceKey}, view.LastValue()),
+		diagUtils.NewMeasureView(w.workflowBufferedEventsCount, []tag.Key{appIDKey, namespaceKey, statusKey}, view.Sum()),
+	)
+}
+
+// WorkflowOperationEvent records the total number of successful/failed workflow operation requests, and the latency of those requests.
+// workflowName is empty for the operations on workflow instances, whose name isn't known.
+func (w *workflowMetrics) WorkflowOperationEvent(ctx context.Context, operation, workflowName, status string, elapsed float64) {
+	if !w.IsEnabled() {
+		return
+	}
+
+	workflowName = w.names.value(workflowName)
+
+	stats.RecordWithTags(
+		ctx,
+		diagUtils.WithTags(w.workflowOperationCount.Name(), appIDKey, w.appID, namespaceKey, w.namespace, operationKey, operation, statusKey, status, workflowNameKey, workflowName),
+		w.workflowOperationCount.M(1))
+
+	recordLatency(ctx, w.workflowOperationLatency,
+		diagUtils.WithTags(w.workflowOperationLatency.Name(), appIDKey, w.appID, namespaceKey, w.namespace, operationKey, operation, statusKey, status, workflowNameKey, workflowName),
+		elapsed)
+}
+
+// WorkflowReminderEvent records the total number of successful/failed creations and executions of the reminders used by the workflow engine.
+func (w *workflowMetrics) WorkflowReminderEvent(ctx context.Context, reminderType, operation, status string) {
+	if !w.IsEnabled() {
+		return
+	}
+
+	stats.RecordWithTags(
+		ctx,
+		diagUtils.WithTags(w.workflowRemindersCount.Name(), appIDKey, w.appID, namespaceKey, w.namespace, typeKey, reminderType, operationKey, operation, statusKey, status),
+		w.workflowRemindersCount.M(1))
+}
+
+// WorkflowWorkItemPreempted records a workflow or activity execution that was preempted by a shutdown of the workflow engine.
+func (w *workflowMetrics) WorkflowWorkItemPreempted(ctx context.Context, workItemType string) {
+	if !w.IsEnabled() {
+		return
+	}
+
+	stats.RecordWithTags(
+		ctx,
+		diagUtils.WithTags(w.workflowWorkItemsPreempted.Name(), appIDKey, w.appID, namespaceKey, w.namespace, typeKey, workItemType),
+		w.workflowWorkItemsPreempted.M(1))
+}
+
+// WorkflowExecutionEvent records the total number of successful/failed workflow and activity executions, and the latency of those executions.
+// name is the name of the workflow or of the activity that was executed, depending on executionType, and is empty if it isn't known.
+func (w *workflowMetrics) WorkflowExecutionEvent(ctx context.Context, executionType, name, status string, elapsed float64) {
+	if !w.IsEnabled() {
+		return
+	}
+
+	nameKey := workflowNameKey
+	if executionType == ActivityReminder {
+		nameKey = activityNameKey
+	}
+	name = w.names.value(name)
+
+	stats.RecordWithTags(
+		ctx,
+		diagUtils.WithTags(w.workflowExecutionCount.Name(), appIDKey, w.appID, namespaceKey, w.namespace, typeKey, executionType, statusKey, status, nameKey, name),
+		w.workflowExecutionCount.M(1))
+
+	recordLatency(ctx, w.workflowExecutionLatency,
+		diagUtils.WithTags(w.workflowExecutionLatency.Name(), appIDKey, w.appID, namespaceKey, w.namespace, typeKey, executionType, statusKey, status, nameKey, name),
+		elapsed)
+}
+
+// WorkflowSchedulingEvent records the time between the reminder of a workflow or activity firing and its execution starting.
+func (w *workflowMetrics) WorkflowSchedulingEvent(ctx context.Context, executionType string, elapsed float64) {
+	if !w.IsEnabled() {
+		return
+	}
+
+	recordLatency(ctx, w.workflowSchedulingLatency,
+		diagUtils.WithTags(w.workflowSchedulingLatency.Name(), appIDKey, w.appID, namespaceKey, w.namespace, typeKey, executionType),
+		elapsed)
+}
+
+// WorkflowRetentionPurged records the completed workflow instances purged by the retention policy, and the number of state store keys deleted with them.
+// reason is either RetentionMaxAge or RetentionMaxInstances.
+func (w *workflowMetrics) WorkflowRetentionPurged(ctx context.Context, reason string, instances, keys int64) {
+	if !w.IsEnabled() || instances == 0 {
+		return
+	}
+
+	stats.RecordWithTags(
+		ctx,
+		diagUtils.WithTags(w.workflowRetentionPurgedCount.Name(), appIDKey, w.appID, namespaceKey, w.namespace, failReasonKey, reason),
+		w.workflowRetentionPurgedCount.M(instances))
+	stats.RecordWithTags(
+		ctx,
+		diagUtils.WithTags(w.workflowRetentionReclaimedKeys.Name(), appIDKey, w.appID, namespaceKey, w.namespace, failReasonKey, reason),
+		w.workflowRetentionReclaimedKeys.M(keys))
+}
+
+// WorkflowSuspendedInstances records the number of suspended workflow instances of the app.
+// The sidecar that stops recording it records 0, so the number can be summed over the sidecars.
+func (w *workflowMetrics) WorkflowSuspendedInstances(ctx context.Context, count int64) {
+	if !w.IsEnabled() {
+		return
+	}
+
+	stats.RecordWithTags(
+		ctx,
+		diagUtils.WithTags(w.workflowSuspendedInstances.Name(), appIDKey, w.appID, namespaceKey, w.namespace),
+		w.workflowSuspendedInstances.M(count))
+}
+
+// WorkflowBufferedEvents records events raised on workflow instances before they were created.
+// status is EventBuffered when the events are buffered, EventDelivered when the instance is created before they expire, and EventExpired otherwise.
+func (w *workflowMetrics) WorkflowBufferedEvents(ctx context.Context, status string, count int64) {
+	if !w.IsEnabled() || count == 0 {
+		return
+	}
+
+	stats.RecordWithTags(
+		ctx,
+		diagUtils.WithTags(w.workflowBufferedEventsCount.Name(), appIDKey, w.appID, namespaceKey, w.namespace, statusKey, status),
+		w.workflowBufferedEventsCount.M(count))
+}
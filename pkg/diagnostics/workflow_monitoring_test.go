@@ -0,0 +1,202 @@
+package diagnostics
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opencensus.io/stats/view"
+	"go.opencensus.io/tag"
+)
+
+func workflowsMetrics() *workflowMetrics {
+	w := newWorkflowMetrics()
+	w.Init("test", "default", nil, 0)
+
+	return w
+}
+
+func TestWorkflowReminders(t *testing.T) {
+	t.Run("record reminder count", func(t *testing.T) {
+		w := workflowsMetrics()
+
+		w.WorkflowReminderEvent(context.Background(), WorkflowReminder, CreateReminder, StatusSuccess)
+		w.WorkflowReminderEvent(context.Background(), ActivityReminder, FireReminder, StatusFailed)
+
+		viewData, _ := view.RetrieveData("runtime/workflow/reminders/count")
+		v := view.Find("runtime/workflow/reminders/count")
+
+		require.Len(t, viewData, 2)
+		allTagsPresent(t, v, viewData[0].Tags)
+		allTagsPresent(t, v, viewData[1].Tags)
+	})
+
+	t.Run("disabled", func(t *testing.T) {
+		var w *workflowMetrics
+		assert.NotPanics(t, func() {
+			w.WorkflowReminderEvent(context.Background(), WorkflowReminder, FireReminder, StatusSuccess)
+		})
+	})
+}
+
+func TestWorkflowWorkItemPreempted(t *testing.T) {
+	w := workflowsMetrics()
+
+	w.WorkflowWorkItemPreempted(context.Background(), ActivityReminder)
+
+	viewData, _ := view.RetrieveData("runtime/workflow/work_items/preempted/count")
+	v := view.Find("runtime/workflow/work_items/preempted/count")
+
+	require.Len(t, viewData, 1)
+	allTagsPresent(t, v, viewData[0].Tags)
+}
+
+func TestWorkflowRetentionPurged(t *testing.T) {
+	w := workflowsMetrics()
+
+	w.WorkflowRetentionPurged(context.Background(), RetentionMaxAge, 2, 10)
+	w.WorkflowRetentionPurged(context.Background(), RetentionMaxAge, 1, 4)
+	w.WorkflowRetentionPurged(context.Background(), RetentionMaxInstances, 0, 0)
+
+	viewData, _ := view.RetrieveData("runtime/workflow/retention/purged/count")
+	v := view.Find("runtime/workflow/retention/purged/count")
+	require.Len(t, viewData, 1)
+	allTagsPresent(t, v, viewData[0].Tags)
+	assert.InEpsilon(t, 3.0, viewData[0].Data.(*view.SumData).Value, 0.001)
+
+	viewData, _ = view.RetrieveData("runtime/workflow/retention/reclaimed_keys/count")
+	require.Len(t, viewData, 1)
+	assert.InEpsilon(t, 14.0, viewData[0].Data.(*view.SumData).Value, 0.001)
+}
+
+func TestWorkflowSuspendedInstances(t *testing.T) {
+	w := workflowsMetrics()
+
+	w.WorkflowSuspendedInstances(context.Background(), 3)
+	w.WorkflowSuspendedInstances(context.Background(), 2)
+
+	viewData, _ := view.RetrieveData("runtime/workflow/suspended_instances")
+	v := view.Find("runtime/workflow/suspended_instances")
+	require.Len(t, viewData, 1)
+	allTagsPresent(t, v, viewData[0].Tags)
+	assert.InEpsilon(t, 2.0, viewData[0].Data.(*view.LastValueData).Value, 0.001)
+}
+
+func TestWorkflowBufferedEvents(t *testing.T) {
+	w := workflowsMetrics()
+
+	w.WorkflowBufferedEvents(context.Background(), EventBuffered, 3)
+	w.WorkflowBufferedEvents(context.Background(), EventDelivered, 2)
+	w.WorkflowBufferedEvents(context.Background(), EventExpired, 0)
+
+	viewData, _ := view.RetrieveData("runtime/workflow/buffered_events/count")
+	v := view.Find("runtime/workflow/buffered_events/count")
+	require.Len(t, viewData, 2)
+	allTagsPresent(t, v, viewData[0].Tags)
+	allTagsPresent(t, v, viewData[1].Tags)
+}
+
+func TestWorkflowExecution(t *testing.T) {
+	t.Run("record execution count and latency", func(t *testing.T) {
+		w := workflowsMetrics()
+
+		w.WorkflowExecutionEvent(context.Background(), WorkflowReminder, "", StatusSuccess, 10)
+		w.WorkflowExecutionEvent(context.Background(), ActivityReminder, "", StatusRecoverable, 20)
+
+		viewData, _ := view.RetrieveData("runtime/workflow/execution/count")
+		v := view.Find("runtime/workflow/execution/count")
+
+		require.Len(t, viewData, 2)
+		allTagsPresent(t, v, viewData[0].Tags)
+		allTagsPresent(t, v, viewData[1].Tags)
+
+		viewData, _ = view.RetrieveData("runtime/workflow/execution/latency")
+		v = view.Find("runtime/workflow/execution/latency")
+
+		require.Len(t, viewData, 2)
+		allTagsPresent(t, v, viewData[0].Tags)
+	})
+
+	t.Run("record scheduling latency", func(t *testing.T) {
+		w := workflowsMetrics()
+
+		w.WorkflowSchedulingEvent(context.Background(), WorkflowReminder, 5)
+
+		viewData, _ := view.RetrieveData("runtime/workflow/scheduling/latency")
+		v := view.Find("runtime/workflow/scheduling/latency")
+
+		require.Len(t, viewData, 1)
+		allTagsPresent(t, v, viewData[0].Tags)
+		assert.InEpsilon(t, 5, viewData[0].Data.(*view.DistributionData).Min, 0)
+	})
+
+	t.Run("disabled", func(t *testing.T) {
+		var w *workflowMetrics
+		assert.NotPanics(t, func() {
+			w.WorkflowExecutionEvent(context.Background(), WorkflowReminder, "", StatusSuccess, 1)
+			w.WorkflowSchedulingEvent(context.Background(), WorkflowReminder, 1)
+		})
+	})
+}
+
+// unregisterWorkflowViews unregisters the workflow views before and after the test, so it can register them with different options.
+func unregisterWorkflowViews(t *testing.T) {
+	names := []string{
+		"runtime/workflow/operation/count", "runtime/workflow/operation/latency",
+		"runtime/workflow/reminders/count", "runtime/workflow/work_items/preempted/count",
+		"runtime/workflow/execution/count", "runtime/workflow/execution/latency",
+		"runtime/workflow/scheduling/latency",
+	}
+	unregister := func() {
+		for _, name := range names {
+			if v := view.Find(name); v != nil {
+				view.Unregister(v)
+			}
+		}
+	}
+	unregister()
+	t.Cleanup(unregister)
+}
+
+func TestWorkflowLatencyBuckets(t *testing.T) {
+	unregisterWorkflowViews(t)
+
+	latency, err := latencyDistribution("workflow", []string{"100", "1000", "60000"})
+	require.NoError(t, err)
+	w := newWorkflowMetrics()
+	require.NoError(t, w.Init("test", "default", latency, 0))
+
+	for _, name := range []string{"runtime/workflow/operation/latency", "runtime/workflow/execution/latency", "runtime/workflow/scheduling/latency"} {
+		v := view.Find(name)
+		require.NotNil(t, v)
+		assert.Equal(t, []float64{100, 1000, 60000}, v.Aggregation.Buckets)
+	}
+}
+
+func TestWorkflowNameTags(t *testing.T) {
+	unregisterWorkflowViews(t)
+
+	w := newWorkflowMetrics()
+	require.NoError(t, w.Init("test", "default", nil, 1))
+
+	w.WorkflowOperationEvent(context.Background(), CreateWorkflow, "order", StatusSuccess, 1)
+	w.WorkflowOperationEvent(context.Background(), CreateWorkflow, "payment", StatusSuccess, 1)
+	w.WorkflowOperationEvent(context.Background(), GetWorkflow, "", StatusSuccess, 1)
+	w.WorkflowExecutionEvent(context.Background(), ActivityReminder, "charge", StatusSuccess, 1)
+
+	viewData, _ := view.RetrieveData("runtime/workflow/operation/count")
+	workflowNames := []string{}
+	for _, row := range viewData {
+		for _, rowTag := range row.Tags {
+			if rowTag.Key == workflowNameKey {
+				workflowNames = append(workflowNames, rowTag.Value)
+			}
+		}
+	}
+	assert.ElementsMatch(t, []string{"order", otherName}, workflowNames)
+
+	viewData, _ = view.RetrieveData("runtime/workflow/execution/count")
+	require.Len(t, viewData, 1)
+	assert.Contains(t, viewData[0].Tags, tag.Tag{Key: activityNameKey, Value: otherName})
+}
@@ -65,3 +65,30 @@ func BenchmarkEval(b *testing.B) {
 	}
 	result = r
 }
+
+func TestEvalJSON(t *testing.T) {
+	var e expr.Expr
+	err := e.DecodeString(`{"id": event.id, "total": event.data.items.map(i, i.price).size(), "first": event.data.items[0]}`)
+	require.NoError(t, err)
+	result, err := e.EvalJSON(map[string]interface{}{
+		"event": map[string]interface{}{
+			"id": "1234",
+			"data": map[string]interface{}{
+				"items": []interface{}{
+					map[string]interface{}{"name": "a", "price": 1.5},
+					map[string]interface{}{"name": "b", "price": 2.5},
+				},
+			},
+		},
+	})
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"id":"1234","total":2,"first":{"name":"a","price":1.5}}`, string(result))
+
+	err = e.DecodeString(`event.id`)
+	require.NoError(t, err)
+	result, err = e.EvalJSON(map[string]interface{}{
+		"event": map[string]interface{}{"id": "1234"},
+	})
+	require.NoError(t, err)
+	assert.JSONEq(t, `"1234"`, string(result))
+}
@@ -29,6 +29,7 @@ import (
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
 	"google.golang.org/protobuf/types/known/emptypb"
 
 	"github.com/dapr/components-contrib/bindings"
@@ -170,6 +171,7 @@ func (a *api) PublishEvent(ctx context.Context, in *runtimev1pb.PublishEventRequ
 			Data:            body,
 			TraceID:         corID,
 			TraceState:      traceState,
+			Baggage:         diag.BaggageString(ctx),
 			Pubsub:          in.GetPubsubName(),
 		}, in.GetMetadata())
 		if err != nil {
@@ -201,6 +203,7 @@ func (a *api) PublishEvent(ctx context.Context, in *runtimev1pb.PublishEventRequ
 	elapsed := diag.ElapsedSince(start)
 
 	diag.DefaultComponentMonitoring.PubsubEgressEvent(context.Background(), pubsubName, topic, err == nil, elapsed)
+	diag.DefaultComponentMonitoring.OperationFailed(context.Background(), pubsubName, diag.Publish, err)
 
 	if err != nil {
 		nerr := status.Errorf(codes.Internal, messages.ErrPubsubPublishMessage, topic, pubsubName, err.Error())
@@ -215,9 +218,17 @@ func (a *api) PublishEvent(ctx context.Context, in *runtimev1pb.PublishEventRequ
 		return &emptypb.Empty{}, nerr
 	}
 
+	a.setTopicBacklogHeader(ctx, pubsubName, topic)
 	return &emptypb.Empty{}, nil
 }
 
+// setTopicBacklogHeader adds the backlog of the topic to the response headers, if the pub/sub component reports it.
+func (a *api) setTopicBacklogHeader(ctx context.Context, pubsubName, topic string) {
+	if backlog, ok := a.pubsubAdapter.TopicBacklog(ctx, pubsubName, topic); ok {
+		grpc.SetHeader(ctx, metadata.Pairs(runtimePubsub.BacklogHeader, strconv.FormatInt(backlog, 10)))
+	}
+}
+
 type invokeServiceResp struct {
 	message  *commonv1pb.InvokeResponse
 	headers  metadata.MD
@@ -254,6 +265,12 @@ func (a *api) InvokeService(ctx context.Context, in *runtimev1pb.InvokeServiceRe
 		req.WithMetadata(incomingMD)
 	}
 
+	ctx, cancel, err := invokev1.WithInvokeTimeout(ctx, req, a.UniversalAPI.MaxInvokeTimeout)
+	if err != nil {
+		return nil, messages.ErrDirectInvokeTimeout.WithFormat(err)
+	}
+	defer cancel()
+
 	policyRunner := resiliency.NewRunner[*invokeServiceResp](ctx, policyDef)
 	resp, err := policyRunner(func(ctx context.Context) (*invokeServiceResp, error) {
 		rResp := &invokeServiceResp{}
@@ -378,6 +395,7 @@ func (a *api) BulkPublishEventAlpha1(ctx context.Context, in *runtimev1pb.BulkPu
 				Data:            entries[i].Event,
 				TraceID:         corID,
 				TraceState:      traceState,
+				Baggage:         diag.BaggageString(ctx),
 				Pubsub:          pubsubName,
 			}, entries[i].Metadata)
 			if err != nil {
@@ -447,6 +465,7 @@ func (a *api) BulkPublishEventAlpha1(ctx context.Context, in *runtimev1pb.BulkPu
 		}
 		bulkRes.FailedEntries = append(bulkRes.GetFailedEntries(), &resEntry)
 	}
+	a.setTopicBacklogHeader(ctx, pubsubName, topic)
 	closeChildSpans(ctx, nil)
 	// even on partial failures, err is nil. As when error is set, the response is expected to not be processed.
 	return &bulkRes, nil
@@ -476,6 +495,7 @@ func (a *api) InvokeBinding(ctx context.Context, in *runtimev1pb.InvokeBindingRe
 	elapsed := diag.ElapsedSince(start)
 
 	diag.DefaultComponentMonitoring.OutputBindingEvent(context.Background(), in.GetName(), in.GetOperation(), err == nil, elapsed)
+	diag.DefaultComponentMonitoring.OperationFailed(context.Background(), in.GetName(), in.GetOperation(), err)
 
 	if err != nil {
 		err = status.Errorf(codes.Internal, messages.ErrInvokeOutputBinding, in.GetName(), err.Error())
@@ -527,6 +547,7 @@ func (a *api) GetBulkState(ctx context.Context, in *runtimev1pb.GetBulkStateRequ
 
 	elapsed := diag.ElapsedSince(start)
 	diag.DefaultComponentMonitoring.StateInvoked(ctx, in.GetStoreName(), diag.BulkGet, err == nil, elapsed)
+	diag.DefaultComponentMonitoring.OperationFailed(ctx, in.GetStoreName(), diag.BulkGet, err)
 
 	if err != nil {
 		return bulkResp, err
@@ -594,6 +615,7 @@ func (a *api) GetState(ctx context.Context, in *runtimev1pb.GetStateRequest) (*r
 	elapsed := diag.ElapsedSince(start)
 
 	diag.DefaultComponentMonitoring.StateInvoked(ctx, in.GetStoreName(), diag.Get, err == nil, elapsed)
+	diag.DefaultComponentMonitoring.OperationFailed(ctx, in.GetStoreName(), diag.Get, err)
 
 	if err != nil {
 		err = status.Errorf(codes.Internal, messages.ErrStateGet, in.GetKey(), in.GetStoreName(), err.Error())
@@ -632,6 +654,7 @@ func (a *api) SaveState(ctx context.Context, in *runtimev1pb.SaveStateRequest) (
 		// Error has already been logged
 		return empty, err
 	}
+	defer a.UniversalAPI.CompStore.TrackOperation(in.GetStoreName())()
 
 	l := len(in.GetStates())
 	if l == 0 {
@@ -685,22 +708,53 @@ func (a *api) SaveState(ctx context.Context, in *runtimev1pb.SaveStateRequest) (
 		reqs[i] = req
 	}
 
-	start := time.Now()
-	err = stateLoader.PerformBulkStoreOperation(ctx, reqs,
-		a.UniversalAPI.Resiliency.ComponentOutboundPolicy(in.GetStoreName(), resiliency.Statestore),
-		state.BulkStoreOpts{},
-		store.Set,
-		store.BulkSet,
-	)
-	elapsed := diag.ElapsedSince(start)
+	var idempotencyKey string
+	if md, ok := metadata.FromIncomingContext(ctx); ok {
+		if v := md.Get(stateLoader.IdempotencyKeyHeader); len(v) > 0 {
+			idempotencyKey = v[0]
+		}
+	}
+	var fingerprint []byte
+	if idempotencyKey != "" {
+		fingerprint, err = proto.MarshalOptions{Deterministic: true}.Marshal(in)
+		if err != nil {
+			return empty, err
+		}
+	}
 
-	diag.DefaultComponentMonitoring.StateInvoked(ctx, in.GetStoreName(), diag.Set, err == nil, elapsed)
+	duplicate, err := a.UniversalAPI.StateIdempotency.Write(ctx, in.GetStoreName(), idempotencyKey, fingerprint, func() error {
+		start := time.Now()
+		rErr := stateLoader.PerformBulkStoreOperation(ctx, reqs,
+			a.UniversalAPI.Resiliency.ComponentOutboundPolicy(in.GetStoreName(), resiliency.Statestore),
+			state.BulkStoreOpts{},
+			store.Set,
+			store.BulkSet,
+		)
+		elapsed := diag.ElapsedSince(start)
 
+		diag.DefaultComponentMonitoring.StateInvoked(ctx, in.GetStoreName(), diag.Set, rErr == nil, elapsed)
+		diag.DefaultComponentMonitoring.OperationFailed(ctx, in.GetStoreName(), diag.Set, rErr)
+		return rErr
+	})
+
+	if errors.Is(err, stateLoader.ErrIdempotencyKeyReused) {
+		err = messages.ErrStateIdempotencyKeyReused.WithFormat(idempotencyKey, in.GetStoreName())
+		a.UniversalAPI.Logger.Debug(err)
+		return empty, err
+	}
+	if errors.Is(err, stateLoader.ErrIdempotencyKeyInUse) {
+		err = messages.ErrStateIdempotencyKeyInUse.WithFormat(idempotencyKey, in.GetStoreName())
+		a.UniversalAPI.Logger.Debug(err)
+		return empty, err
+	}
 	if err != nil {
 		err = a.stateErrorResponse(err, messages.ErrStateSave, in.GetStoreName(), err.Error())
 		a.UniversalAPI.Logger.Debug(err)
 		return empty, err
 	}
+	if duplicate {
+		a.UniversalAPI.Logger.Debugf("Request with idempotency key %s was already saved in state store %s", idempotencyKey, in.GetStoreName())
+	}
 	return empty, nil
 }
 
@@ -727,6 +781,7 @@ func (a *api) DeleteState(ctx context.Context, in *runtimev1pb.DeleteStateReques
 		// Error has already been logged
 		return empty, err
 	}
+	defer a.UniversalAPI.CompStore.TrackOperation(in.GetStoreName())()
 
 	key, err := stateLoader.GetModifiedStateKey(in.GetKey(), in.GetStoreName(), a.UniversalAPI.AppID)
 	if err != nil {
@@ -756,6 +811,7 @@ func (a *api) DeleteState(ctx context.Context, in *runtimev1pb.DeleteStateReques
 	elapsed := diag.ElapsedSince(start)
 
 	diag.DefaultComponentMonitoring.StateInvoked(ctx, in.GetStoreName(), diag.Delete, err == nil, elapsed)
+	diag.DefaultComponentMonitoring.OperationFailed(ctx, in.GetStoreName(), diag.Delete, err)
 
 	if err != nil {
 		err = a.stateErrorResponse(err, messages.ErrStateDelete, in.GetKey(), err.Error())
@@ -773,6 +829,7 @@ func (a *api) DeleteBulkState(ctx context.Context, in *runtimev1pb.DeleteBulkSta
 		// Error has already been logged
 		return empty, err
 	}
+	defer a.UniversalAPI.CompStore.TrackOperation(in.GetStoreName())()
 
 	reqs := make([]state.DeleteRequest, len(in.GetStates()))
 	for i, item := range in.GetStates() {
@@ -806,6 +863,7 @@ func (a *api) DeleteBulkState(ctx context.Context, in *runtimev1pb.DeleteBulkSta
 	elapsed := diag.ElapsedSince(start)
 
 	diag.DefaultComponentMonitoring.StateInvoked(ctx, in.GetStoreName(), diag.BulkDelete, err == nil, elapsed)
+	diag.DefaultComponentMonitoring.OperationFailed(ctx, in.GetStoreName(), diag.BulkDelete, err)
 
 	if err != nil {
 		err = a.stateErrorResponse(err, messages.ErrStateDeleteBulk, in.GetStoreName(), err.Error())
@@ -829,6 +887,7 @@ func (a *api) ExecuteStateTransaction(ctx context.Context, in *runtimev1pb.Execu
 		// Error has already been logged
 		return &emptypb.Empty{}, storeErr
 	}
+	defer a.UniversalAPI.CompStore.TrackOperation(in.GetStoreName())()
 
 	transactionalStore, ok := store.(state.TransactionalStore)
 	if !ok {
@@ -837,6 +896,13 @@ func (a *api) ExecuteStateTransaction(ctx context.Context, in *runtimev1pb.Execu
 		return &emptypb.Empty{}, err
 	}
 
+	err := stateLoader.GetRequestMetadataContract(in.GetStoreName()).Validate(in.GetMetadata())
+	if err != nil {
+		err = messages.ErrStateInvalidMetadata.WithFormat(in.GetStoreName(), err)
+		apiServerLogger.Debug(err)
+		return &emptypb.Empty{}, err
+	}
+
 	operations := make([]state.TransactionalStateOperation, 0, len(in.GetOperations()))
 	for _, inputReq := range in.GetOperations() {
 		req := inputReq.GetRequest()
@@ -846,6 +912,12 @@ func (a *api) ExecuteStateTransaction(ctx context.Context, in *runtimev1pb.Execu
 		if err != nil {
 			return &emptypb.Empty{}, err
 		}
+		err = stateLoader.ValidateOperationMetadata(in.GetStoreName(), req.GetMetadata())
+		if err != nil {
+			err = messages.ErrStateInvalidMetadata.WithFormat(in.GetStoreName(), err)
+			apiServerLogger.Debug(err)
+			return &emptypb.Empty{}, err
+		}
 		switch state.OperationType(inputReq.GetOperationType()) {
 		case state.OperationUpsert:
 			setReq := state.SetRequest{
@@ -866,6 +938,7 @@ func (a *api) ExecuteStateTransaction(ctx context.Context, in *runtimev1pb.Execu
 					Consistency: stateConsistencyToString(req.GetOptions().GetConsistency()),
 				}
 			}
+			stateLoader.SyncSetRequestContentType(&setReq)
 
 			operations = append(operations, setReq)
 
@@ -943,12 +1016,13 @@ func (a *api) ExecuteStateTransaction(ctx context.Context, in *runtimev1pb.Execu
 		Operations: operations,
 		Metadata:   in.GetMetadata(),
 	}
-	_, err := policyRunner(func(ctx context.Context) (struct{}, error) {
+	_, err = policyRunner(func(ctx context.Context) (struct{}, error) {
 		return struct{}{}, transactionalStore.Multi(ctx, storeReq)
 	})
 	elapsed := diag.ElapsedSince(start)
 
 	diag.DefaultComponentMonitoring.StateInvoked(ctx, in.GetStoreName(), diag.StateTransaction, err == nil, elapsed)
+	diag.DefaultComponentMonitoring.OperationFailed(ctx, in.GetStoreName(), diag.StateTransaction, err)
 
 	if err != nil {
 		err = status.Errorf(codes.Internal, messages.ErrStateTransaction, err.Error())
@@ -1270,6 +1344,7 @@ func (a *api) GetConfiguration(ctx context.Context, in *runtimev1pb.GetConfigura
 	elapsed := diag.ElapsedSince(start)
 
 	diag.DefaultComponentMonitoring.ConfigurationInvoked(ctx, in.GetStoreName(), diag.Get, err == nil, elapsed)
+	diag.DefaultComponentMonitoring.OperationFailed(ctx, in.GetStoreName(), diag.Get, err)
 
 	if err != nil {
 		err = status.Errorf(codes.Internal, messages.ErrConfigurationGet, req.Keys, in.GetStoreName(), err.Error())
@@ -1423,6 +1498,7 @@ func (a *api) subscribeConfiguration(ctx context.Context, request *runtimev1pb.S
 	elapsed := diag.ElapsedSince(start)
 
 	diag.DefaultComponentMonitoring.ConfigurationInvoked(context.Background(), request.GetStoreName(), diag.ConfigurationSubscribe, err == nil, elapsed)
+	diag.DefaultComponentMonitoring.OperationFailed(context.Background(), request.GetStoreName(), diag.ConfigurationSubscribe, err)
 
 	if err != nil {
 		err = status.Errorf(codes.InvalidArgument, messages.ErrConfigurationSubscribe, componentReq.Keys, request.GetStoreName(), err)
@@ -1447,6 +1523,7 @@ func (a *api) unsubscribeConfiguration(ctx context.Context, subscribeID string,
 	elapsed := diag.ElapsedSince(start)
 
 	diag.DefaultComponentMonitoring.ConfigurationInvoked(context.Background(), storeName, diag.ConfigurationUnsubscribe, err == nil, elapsed)
+	diag.DefaultComponentMonitoring.OperationFailed(context.Background(), storeName, diag.ConfigurationUnsubscribe, err)
 
 	return err
 }
@@ -18,13 +18,17 @@ import (
 	"errors"
 	"fmt"
 	"io"
+	"time"
 
+	"google.golang.org/grpc"
 	"google.golang.org/grpc/codes"
+	grpcMetadata "google.golang.org/grpc/metadata"
 	"google.golang.org/grpc/status"
 	"google.golang.org/protobuf/types/known/anypb"
 
 	"github.com/dapr/dapr/pkg/acl"
 	actorerrors "github.com/dapr/dapr/pkg/actors/errors"
+	"github.com/dapr/dapr/pkg/config"
 	diag "github.com/dapr/dapr/pkg/diagnostics"
 	diagConsts "github.com/dapr/dapr/pkg/diagnostics/consts"
 	"github.com/dapr/dapr/pkg/grpc/metadata"
@@ -33,21 +37,32 @@ import (
 	invokev1 "github.com/dapr/dapr/pkg/messaging/v1"
 	commonv1pb "github.com/dapr/dapr/pkg/proto/common/v1"
 	internalv1pb "github.com/dapr/dapr/pkg/proto/internals/v1"
+	"github.com/dapr/dapr/pkg/security/spiffe"
 )
 
 // CallLocal is used for internal dapr to dapr calls. It is invoked by another Dapr instance with a request to the local app.
 func (a *api) CallLocal(ctx context.Context, in *internalv1pb.InternalInvokeRequest) (*internalv1pb.InternalInvokeResponse, error) {
-	appChannel := a.channels.AppChannel()
+	received := time.Now()
+	appChannel := a.channels.AppChannelFor(config.AppChannelRouteServiceInvocation)
 	if appChannel == nil {
 		return nil, status.Error(codes.Internal, messages.ErrChannelNotFound)
 	}
 
+	// Advertise the compressors accepted by this sidecar, so the caller can compress the next calls
+	_ = grpc.SetHeader(ctx, grpcMetadata.Pairs(messaging.AcceptEncodingKey, messaging.AcceptedEncodings))
+
 	req, err := invokev1.InternalInvokeRequest(in)
 	if err != nil {
 		return nil, status.Errorf(codes.InvalidArgument, messages.ErrInternalInvokeRequest, err.Error())
 	}
 	defer req.Close()
 
+	ctx, cancel, err := invokev1.WithInvokeTimeout(ctx, req, a.UniversalAPI.MaxInvokeTimeout)
+	if err != nil {
+		return nil, messages.ErrDirectInvokeTimeout.WithFormat(err)
+	}
+	defer cancel()
+
 	// Check the ACL
 	err = a.callLocalValidateACL(ctx, req)
 	if err != nil {
@@ -63,6 +78,7 @@ func (a *api) CallLocal(ctx context.Context, in *internalv1pb.InternalInvokeRequ
 	}()
 
 	// stausCode will be read by the deferred method above
+	appStart := time.Now()
 	res, err := appChannel.InvokeMethod(ctx, req, "")
 	if err != nil {
 		statusCode = int32(codes.Internal)
@@ -72,17 +88,24 @@ func (a *api) CallLocal(ctx context.Context, in *internalv1pb.InternalInvokeRequ
 	}
 	defer res.Close()
 
+	// Report the time spent by this sidecar and by the app, so the caller can break down the latency of the call
+	_ = grpc.SetHeader(ctx, messaging.LatencyHeader(appStart.Sub(received), time.Since(appStart)))
+
 	return res.ProtoWithData()
 }
 
 // CallLocalStream is a variant of CallLocal that uses gRPC streams to send data in chunks, rather than in an unary RPC.
 // It is invoked by another Dapr instance with a request to the local app.
 func (a *api) CallLocalStream(stream internalv1pb.ServiceInvocation_CallLocalStreamServer) error { //nolint:nosnakecase
-	appChannel := a.channels.AppChannel()
+	received := time.Now()
+	appChannel := a.channels.AppChannelFor(config.AppChannelRouteServiceInvocation)
 	if appChannel == nil {
 		return status.Error(codes.Internal, messages.ErrChannelNotFound)
 	}
 
+	// Advertise the compressors accepted by this sidecar, so the caller can compress the next calls
+	_ = stream.SetHeader(grpcMetadata.Pairs(messaging.AcceptEncodingKey, messaging.AcceptedEncodings))
+
 	// Read the first chunk of the incoming request
 	// This contains the metadata of the request
 	chunk := &internalv1pb.InternalInvokeRequestStream{}
@@ -118,6 +141,12 @@ func (a *api) CallLocalStream(stream internalv1pb.ServiceInvocation_CallLocalStr
 	ctx, cancel := context.WithCancel(stream.Context())
 	defer cancel()
 
+	ctx, cancelTimeout, err := invokev1.WithInvokeTimeout(ctx, req, a.UniversalAPI.MaxInvokeTimeout)
+	if err != nil {
+		return messages.ErrDirectInvokeTimeout.WithFormat(err)
+	}
+	defer cancelTimeout()
+
 	a.wg.Add(1)
 	go func() {
 		defer a.wg.Done()
@@ -196,6 +225,7 @@ func (a *api) CallLocalStream(stream internalv1pb.ServiceInvocation_CallLocalStr
 	}()
 
 	// Submit the request to the app
+	appStart := time.Now()
 	res, err := appChannel.InvokeMethod(ctx, req, "")
 	if err != nil {
 		statusCode = int32(codes.Internal)
@@ -204,6 +234,10 @@ func (a *api) CallLocalStream(stream internalv1pb.ServiceInvocation_CallLocalStr
 	defer res.Close()
 	statusCode = res.Status().GetCode()
 
+	// Report the time spent by this sidecar and by the app, so the caller can break down the latency of the call
+	// The headers are sent with the first chunk of the response
+	_ = stream.SetHeader(messaging.LatencyHeader(appStart.Sub(received), time.Since(appStart)))
+
 	// Respond to the caller
 	buf := invokev1.BufPool.Get().(*[]byte)
 	defer func() {
@@ -287,6 +321,17 @@ func (a *api) CallActor(ctx context.Context, in *internalv1pb.InternalInvokeRequ
 	}
 	defer req.Close()
 
+	// The access control policies of actors and workflows are enforced by the sidecar that hosts them, with the identity of the caller from mTLS
+	var callerAppID string
+	if id, ok, _ := spiffe.FromGRPCContext(ctx); ok {
+		callerAppID = id.AppID()
+	}
+	err = a.UniversalAPI.AuthorizeActorCall(ctx, callerAppID, in.GetActor().GetActorType(), in.GetActor().GetActorId(), in.GetMessage().GetMethod(), in.GetMessage().GetData().GetValue())
+	if err != nil {
+		apiServerLogger.Debug(err)
+		return nil, err
+	}
+
 	// We don't do resiliency here as it is handled in the API layer. See InvokeActor().
 	resp, err := a.Actors.Call(ctx, req)
 	if err != nil {
@@ -0,0 +1,42 @@
+/*
+Copyright 2023 The Dapr Authors
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package grpc
+
+import (
+	"github.com/dapr/dapr/pkg/messages"
+	runtimev1pb "github.com/dapr/dapr/pkg/proto/runtime/v1"
+)
+
+// WatchLeadershipAlpha1 streams the leader of an election, then every change of leader, until the client disconnects.
+func (a *api) WatchLeadershipAlpha1(request *runtimev1pb.WatchLeadershipRequest, stream runtimev1pb.Dapr_WatchLeadershipAlpha1Server) error { //nolint:nosnakecase
+	election, err := a.UniversalAPI.GetElection(request.GetElection())
+	if err != nil {
+		// Error has already been logged
+		return err
+	}
+
+	err = election.Watch(stream.Context(), func(leaderID string) error {
+		return stream.Send(&runtimev1pb.WatchLeadershipResponse{
+			Election: request.GetElection(),
+			LeaderId: leaderID,
+		})
+	})
+	if err != nil && stream.Context().Err() == nil {
+		err = messages.ErrLeaderElectionFailed.WithFormat("watch", request.GetElection(), err)
+		a.Logger.Debug(err)
+		return err
+	}
+
+	return nil
+}
@@ -0,0 +1,28 @@
+/*
+Copyright 2023 The Dapr Authors
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package grpc
+
+import (
+	runtimev1pb "github.com/dapr/dapr/pkg/proto/runtime/v1"
+)
+
+// SubscribeStateChangesAlpha1 streams the changes of the keys of the app in a state store, as CloudEvents, until the client disconnects.
+func (a *api) SubscribeStateChangesAlpha1(request *runtimev1pb.SubscribeStateChangesRequest, stream runtimev1pb.Dapr_SubscribeStateChangesAlpha1Server) error { //nolint:nosnakecase
+	subscribe, err := a.UniversalAPI.SubscribeStateChanges(request)
+	if err != nil {
+		// Error has already been logged
+		return err
+	}
+	return subscribe(stream.Context(), stream.Send)
+}
@@ -0,0 +1,150 @@
+/*
+Copyright 2023 The Dapr Authors
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package grpc
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/dapr/components-contrib/state"
+	stateLoader "github.com/dapr/dapr/pkg/components/state"
+	"github.com/dapr/dapr/pkg/grpc/universalapi"
+	runtimev1pb "github.com/dapr/dapr/pkg/proto/runtime/v1"
+	"github.com/dapr/dapr/pkg/resiliency"
+	"github.com/dapr/dapr/pkg/runtime/compstore"
+	daprt "github.com/dapr/dapr/pkg/testing"
+	"github.com/dapr/kit/logger"
+	"github.com/dapr/kit/ptr"
+)
+
+// changeFeedStateStore is a state store that reports the given changes, then waits for the subscription to be canceled.
+type changeFeedStateStore struct {
+	*daprt.FakeStateStore
+	changes []*stateLoader.Change
+	reqCh   chan stateLoader.ChangeFeedRequest
+}
+
+func (s *changeFeedStateStore) Features() []state.Feature {
+	return []state.Feature{stateLoader.FeatureChangeFeed}
+}
+
+func (s *changeFeedStateStore) SubscribeChanges(ctx context.Context, req stateLoader.ChangeFeedRequest, handler stateLoader.ChangeFeedHandler) error {
+	s.reqCh <- req
+	for _, c := range s.changes {
+		if err := handler(ctx, c); err != nil {
+			return err
+		}
+	}
+	<-ctx.Done()
+	return nil
+}
+
+func TestSubscribeStateChangesAlpha1(t *testing.T) {
+	now := time.Now()
+	feeder := &changeFeedStateStore{
+		FakeStateStore: daprt.NewFakeStateStore(),
+		changes: []*stateLoader.Change{
+			{ID: "1", Key: "fakeAPI||orders-1", Type: stateLoader.ChangeUpsert, Value: []byte(`{"a":"b"}`), ETag: ptr.Of("e1"), Time: now},
+			{ID: "2", Key: "otherApp||orders-2", Type: stateLoader.ChangeUpsert, Value: []byte(`{}`)},
+			{ID: "3", Key: "fakeAPI||users-1", Type: stateLoader.ChangeUpsert, Value: []byte(`{}`)},
+			{ID: "4", Key: "fakeAPI||orders-1", Type: stateLoader.ChangeDelete, Time: now},
+		},
+		reqCh: make(chan stateLoader.ChangeFeedRequest, 1),
+	}
+	require.NoError(t, stateLoader.SaveStateConfiguration("feed", map[string]string{"changeFeed": "true"}))
+	require.NoError(t, stateLoader.SaveStateConfiguration("disabled", map[string]string{}))
+
+	compStore := compstore.New()
+	compStore.AddStateStore("feed", feeder)
+	compStore.AddStateStore("disabled", feeder)
+	compStore.AddStateStore("unsupported", daprt.NewFakeStateStore())
+	server, lis := startTestServerAPI(&api{
+		UniversalAPI: &universalapi.UniversalAPI{
+			AppID:      "fakeAPI",
+			Logger:     logger.NewLogger("grpc.api.test"),
+			CompStore:  compStore,
+			Resiliency: resiliency.New(nil),
+		},
+	})
+	defer server.Stop()
+
+	clientConn := createTestClient(lis)
+	defer clientConn.Close()
+	client := runtimev1pb.NewDaprClient(clientConn)
+
+	t.Run("changes of the keys with the prefix", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+		stream, err := client.SubscribeStateChangesAlpha1(ctx, &runtimev1pb.SubscribeStateChangesRequest{
+			StoreName: "feed",
+			KeyPrefix: "orders-",
+		})
+		require.NoError(t, err)
+
+		upsert, err := stream.Recv()
+		require.NoError(t, err)
+		assert.Equal(t, "1", upsert.GetId())
+		assert.Equal(t, "feed", upsert.GetSource())
+		assert.Equal(t, "com.dapr.state.upsert", upsert.GetType())
+		assert.Equal(t, "1.0", upsert.GetSpecVersion())
+		assert.Equal(t, "orders-1", upsert.GetSubject())
+		assert.Equal(t, "application/json", upsert.GetDataContentType())
+		assert.Equal(t, []byte(`{"a":"b"}`), upsert.GetData())
+		assert.Equal(t, "e1", upsert.GetEtag())
+		assert.True(t, now.Equal(upsert.GetTime().AsTime()))
+
+		del, err := stream.Recv()
+		require.NoError(t, err)
+		assert.Equal(t, "4", del.GetId())
+		assert.Equal(t, "com.dapr.state.delete", del.GetType())
+		assert.Equal(t, "orders-1", del.GetSubject())
+		assert.Empty(t, del.GetData())
+		assert.Empty(t, del.GetDataContentType())
+
+		assert.Equal(t, "fakeAPI||orders-", (<-feeder.reqCh).KeyPrefix)
+	})
+
+	t.Run("change feed not enabled", func(t *testing.T) {
+		stream, err := client.SubscribeStateChangesAlpha1(context.Background(), &runtimev1pb.SubscribeStateChangesRequest{
+			StoreName: "disabled",
+		})
+		require.NoError(t, err)
+		_, err = stream.Recv()
+		assert.Equal(t, codes.FailedPrecondition, status.Code(err))
+	})
+
+	t.Run("change feed not supported", func(t *testing.T) {
+		stream, err := client.SubscribeStateChangesAlpha1(context.Background(), &runtimev1pb.SubscribeStateChangesRequest{
+			StoreName: "unsupported",
+		})
+		require.NoError(t, err)
+		_, err = stream.Recv()
+		assert.Equal(t, codes.Unimplemented, status.Code(err))
+	})
+
+	t.Run("state store not found", func(t *testing.T) {
+		stream, err := client.SubscribeStateChangesAlpha1(context.Background(), &runtimev1pb.SubscribeStateChangesRequest{
+			StoreName: "notfound",
+		})
+		require.NoError(t, err)
+		_, err = stream.Recv()
+		assert.Equal(t, codes.InvalidArgument, status.Code(err))
+	})
+}
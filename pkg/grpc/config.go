@@ -13,6 +13,10 @@ limitations under the License.
 
 package grpc
 
+import (
+	"github.com/dapr/dapr/pkg/accesslog"
+)
+
 // ServerConfig is the config object for a grpc server.
 type ServerConfig struct {
 	AppID                string
@@ -25,4 +29,5 @@ type ServerConfig struct {
 	UnixDomainSocket     string
 	ReadBufferSizeKB     int
 	EnableAPILogging     bool
+	AccessLog            *accesslog.Logger
 }
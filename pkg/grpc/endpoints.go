@@ -21,6 +21,7 @@ import (
 	"google.golang.org/grpc"
 
 	"github.com/dapr/dapr/pkg/config"
+	diag "github.com/dapr/dapr/pkg/diagnostics"
 	invokev1 "github.com/dapr/dapr/pkg/messaging/v1"
 )
 
@@ -40,6 +41,7 @@ var endpoints = map[string][]string{
 	},
 	"state.v1alpha1": {
 		daprRuntimePrefix + "v1.Dapr/QueryStateAlpha1",
+		daprRuntimePrefix + "v1.Dapr/SubscribeStateChangesAlpha1",
 	},
 	"publish.v1": {
 		daprRuntimePrefix + "v1.Dapr/PublishEvent",
@@ -104,12 +106,16 @@ var endpoints = map[string][]string{
 		daprRuntimePrefix + "v1.Dapr/PurgeWorkflowAlpha1",
 		daprRuntimePrefix + "v1.Dapr/PauseWorkflowAlpha1",
 		daprRuntimePrefix + "v1.Dapr/ResumeWorkflowAlpha1",
+		daprRuntimePrefix + "v1.Dapr/ListWorkflowsAlpha1",
+		daprRuntimePrefix + "v1.Dapr/GetWorkflowHistoryAlpha1",
+		daprRuntimePrefix + "v1.Dapr/ReplayWorkflowHistoryAlpha1",
 	},
 	"workflows.v1beta1": {
 		daprRuntimePrefix + "v1.Dapr/StartWorkflowBeta1",
 		daprRuntimePrefix + "v1.Dapr/GetWorkflowBeta1",
 		daprRuntimePrefix + "v1.Dapr/TerminateWorkflowBeta1",
 		daprRuntimePrefix + "v1.Dapr/RaiseEventWorkflowBeta1",
+		daprRuntimePrefix + "v1.Dapr/RaiseEventWorkflowBatchBeta1",
 		daprRuntimePrefix + "v1.Dapr/PurgeWorkflowBeta1",
 		daprRuntimePrefix + "v1.Dapr/PauseWorkflowBeta1",
 		daprRuntimePrefix + "v1.Dapr/ResumeWorkflowBeta1",
@@ -117,6 +123,54 @@ var endpoints = map[string][]string{
 	"shutdown.v1": {
 		daprRuntimePrefix + "v1.Dapr/Shutdown",
 	},
+	"metrics.v1alpha1": {
+		daprRuntimePrefix + "v1.Dapr/RecordMetricsAlpha1",
+	},
+	"tracing.v1alpha1": {
+		daprRuntimePrefix + "v1.Dapr/RecordSpansAlpha1",
+	},
+	"ratelimit.v1alpha1": {
+		daprRuntimePrefix + "v1.Dapr/CheckRateLimitAlpha1",
+	},
+	"leaderelection.v1alpha1": {
+		daprRuntimePrefix + "v1.Dapr/CampaignLeadershipAlpha1",
+		daprRuntimePrefix + "v1.Dapr/ResignLeadershipAlpha1",
+		daprRuntimePrefix + "v1.Dapr/WatchLeadershipAlpha1",
+	},
+}
+
+// apiEndpoint is the building block and version of a Dapr API method.
+type apiEndpoint struct {
+	name    string
+	version string
+}
+
+// endpointsByMethod maps the gRPC full method names in endpoints to the building block and version they belong to.
+var endpointsByMethod = func() map[string]apiEndpoint {
+	res := make(map[string]apiEndpoint)
+	for key, methods := range endpoints {
+		name, version, _ := strings.Cut(key, ".")
+		for _, method := range methods {
+			res[method] = apiEndpoint{name: name, version: version}
+		}
+	}
+	return res
+}()
+
+// Returns the middlewares (unary and stream) that record the usage of the Dapr APIs by building block and version.
+func setAPIUsageMiddlewares() (grpc.UnaryServerInterceptor, grpc.StreamServerInterceptor) {
+	return func(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+			if ep, ok := endpointsByMethod[info.FullMethod]; ok {
+				diag.DefaultServerMonitoring.GRPCAPIUsed(ctx, ep.name, ep.version)
+			}
+			return handler(ctx, req)
+		},
+		func(srv any, stream grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+			if ep, ok := endpointsByMethod[info.FullMethod]; ok {
+				diag.DefaultServerMonitoring.GRPCAPIUsed(stream.Context(), ep.name, ep.version)
+			}
+			return handler(srv, stream)
+		}
 }
 
 // Returns the middlewares (unary and stream) for supporting API allowlist
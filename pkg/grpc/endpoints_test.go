@@ -83,6 +83,15 @@ func testMiddleware(u grpc.UnaryServerInterceptor, s grpc.StreamServerIntercepto
 	}
 }
 
+func TestEndpointsByMethod(t *testing.T) {
+	assert.Equal(t, apiEndpoint{name: "state", version: "v1"}, endpointsByMethod[daprRuntimePrefix+"v1.Dapr/GetState"])
+	assert.Equal(t, apiEndpoint{name: "state", version: "v1alpha1"}, endpointsByMethod[daprRuntimePrefix+"v1.Dapr/QueryStateAlpha1"])
+	assert.Equal(t, apiEndpoint{name: "workflows", version: "v1beta1"}, endpointsByMethod[daprRuntimePrefix+"v1.Dapr/StartWorkflowBeta1"])
+
+	_, ok := endpointsByMethod["/dapr.proto.internals.v1.ServiceInvocation/CallLocal"]
+	assert.False(t, ok)
+}
+
 func TestSetAPIEndpointsMiddleware(t *testing.T) {
 	t.Run("state.v1 endpoints allowed", func(t *testing.T) {
 		allowed := []config.APIAccessRule{
@@ -0,0 +1,194 @@
+/*
+Copyright 2023 The Dapr Authors
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package manager
+
+import (
+	"context"
+	"net"
+	"sync"
+	"time"
+
+	"google.golang.org/grpc/resolver"
+)
+
+const (
+	// dnsCacheScheme is the scheme of the gRPC resolver that uses the DNS cache.
+	dnsCacheScheme = "dapr-dns"
+	// dnsMinReResolveInterval is the minimum interval between re-resolutions of a host caused by connection errors.
+	dnsMinReResolveInterval = time.Second
+)
+
+type dnsLookupFn = func(ctx context.Context, host string) ([]string, error)
+
+// dnsCache is a cache of DNS lookups, with entries that expire after a TTL.
+// It's shared by all connections to other Dapr sidecars, so a host is resolved once for all of them.
+type dnsCache struct {
+	ttl      time.Duration
+	lookupFn dnsLookupFn
+	entries  map[string]dnsCacheEntry
+	lock     sync.Mutex
+}
+
+type dnsCacheEntry struct {
+	addrs   []string
+	expires time.Time
+}
+
+func newDNSCache(ttl time.Duration) *dnsCache {
+	return &dnsCache{
+		ttl:      ttl,
+		lookupFn: net.DefaultResolver.LookupHost,
+		entries:  make(map[string]dnsCacheEntry),
+	}
+}
+
+// Lookup returns the addresses of the host, from the cache if the entry hasn't expired.
+func (c *dnsCache) Lookup(ctx context.Context, host string) ([]string, error) {
+	c.lock.Lock()
+	entry, ok := c.entries[host]
+	c.lock.Unlock()
+	if ok && time.Now().Before(entry.expires) {
+		return entry.addrs, nil
+	}
+
+	addrs, err := c.lookupFn(ctx, host)
+	if err != nil {
+		return nil, err
+	}
+
+	c.lock.Lock()
+	c.entries[host] = dnsCacheEntry{
+		addrs:   addrs,
+		expires: time.Now().Add(c.ttl),
+	}
+	c.lock.Unlock()
+	return addrs, nil
+}
+
+// Invalidate removes the host from the cache, so the next lookup resolves it again.
+func (c *dnsCache) Invalidate(host string) {
+	c.lock.Lock()
+	delete(c.entries, host)
+	c.lock.Unlock()
+}
+
+// Build implements resolver.Builder.
+func (c *dnsCache) Build(target resolver.Target, cc resolver.ClientConn, _ resolver.BuildOptions) (resolver.Resolver, error) {
+	host, port, err := net.SplitHostPort(target.Endpoint())
+	if err != nil {
+		return nil, err
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	r := &dnsCacheResolver{
+		cache:      c,
+		cc:         cc,
+		host:       host,
+		port:       port,
+		resolveNow: make(chan struct{}, 1),
+		cancel:     cancel,
+	}
+
+	// IP addresses don't need to be resolved
+	if net.ParseIP(host) != nil {
+		err = cc.UpdateState(resolver.State{
+			Addresses: []resolver.Address{{Addr: net.JoinHostPort(host, port)}},
+		})
+		cancel()
+		return r, err
+	}
+
+	r.wg.Add(1)
+	go r.watch(ctx)
+	return r, nil
+}
+
+// Scheme implements resolver.Builder.
+func (c *dnsCache) Scheme() string {
+	return dnsCacheScheme
+}
+
+// dnsCacheResolver is a gRPC resolver that resolves a host using the DNS cache.
+// Addresses are resolved again when the cache entry expires, so changes in the endpoints of headless services are picked up proactively, and immediately when gRPC reports a connection error.
+type dnsCacheResolver struct {
+	cache       *dnsCache
+	cc          resolver.ClientConn
+	host        string
+	port        string
+	resolveNow  chan struct{}
+	cancel      context.CancelFunc
+	wg          sync.WaitGroup
+	lastResolve time.Time
+}
+
+func (r *dnsCacheResolver) watch(ctx context.Context) {
+	defer r.wg.Done()
+
+	t := time.NewTimer(0)
+	defer t.Stop()
+	resetTimer := func(d time.Duration) {
+		if !t.Stop() {
+			select {
+			case <-t.C:
+			default:
+			}
+		}
+		t.Reset(d)
+	}
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-t.C:
+		case <-r.resolveNow:
+			// Invoked by gRPC when there's a connection error: the cached addresses may be stale
+			r.cache.Invalidate(r.host)
+			if wait := dnsMinReResolveInterval - time.Since(r.lastResolve); wait > 0 {
+				resetTimer(wait)
+				continue
+			}
+		}
+
+		r.lastResolve = time.Now()
+		addrs, err := r.cache.Lookup(ctx, r.host)
+		if err != nil {
+			r.cc.ReportError(err)
+		} else {
+			state := resolver.State{
+				Addresses: make([]resolver.Address, len(addrs)),
+			}
+			for i, addr := range addrs {
+				state.Addresses[i] = resolver.Address{Addr: net.JoinHostPort(addr, r.port)}
+			}
+			r.cc.UpdateState(state)
+		}
+
+		resetTimer(r.cache.ttl)
+	}
+}
+
+// ResolveNow implements resolver.Resolver.
+func (r *dnsCacheResolver) ResolveNow(resolver.ResolveNowOptions) {
+	select {
+	case r.resolveNow <- struct{}{}:
+	default:
+	}
+}
+
+// Close implements resolver.Resolver.
+func (r *dnsCacheResolver) Close() {
+	r.cancel()
+	r.wg.Wait()
+}
@@ -0,0 +1,138 @@
+/*
+Copyright 2023 The Dapr Authors
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package manager
+
+import (
+	"context"
+	"errors"
+	"net/url"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/resolver"
+)
+
+type fakeResolverClientConn struct {
+	resolver.ClientConn
+
+	lock   sync.Mutex
+	states []resolver.State
+	errs   []error
+}
+
+func (f *fakeResolverClientConn) UpdateState(state resolver.State) error {
+	f.lock.Lock()
+	defer f.lock.Unlock()
+	f.states = append(f.states, state)
+	return nil
+}
+
+func (f *fakeResolverClientConn) ReportError(err error) {
+	f.lock.Lock()
+	defer f.lock.Unlock()
+	f.errs = append(f.errs, err)
+}
+
+func (f *fakeResolverClientConn) lastState() (resolver.State, int) {
+	f.lock.Lock()
+	defer f.lock.Unlock()
+	if len(f.states) == 0 {
+		return resolver.State{}, 0
+	}
+	return f.states[len(f.states)-1], len(f.states)
+}
+
+func TestDNSCache(t *testing.T) {
+	var lookups atomic.Int32
+	cache := newDNSCache(time.Minute)
+	cache.lookupFn = func(ctx context.Context, host string) ([]string, error) {
+		if host == "fail" {
+			return nil, errors.New("lookup failed")
+		}
+		lookups.Add(1)
+		return []string{"10.0.0.1", "10.0.0.2"}, nil
+	}
+
+	t.Run("lookups are cached", func(t *testing.T) {
+		addrs, err := cache.Lookup(context.Background(), "myapp")
+		require.NoError(t, err)
+		assert.Equal(t, []string{"10.0.0.1", "10.0.0.2"}, addrs)
+		_, err = cache.Lookup(context.Background(), "myapp")
+		require.NoError(t, err)
+		assert.Equal(t, int32(1), lookups.Load())
+
+		cache.Invalidate("myapp")
+		_, err = cache.Lookup(context.Background(), "myapp")
+		require.NoError(t, err)
+		assert.Equal(t, int32(2), lookups.Load())
+	})
+
+	t.Run("resolver updates the state", func(t *testing.T) {
+		cc := &fakeResolverClientConn{}
+		r, err := cache.Build(resolver.Target{URL: *mustParseURL(t, "dapr-dns:///myapp:50002")}, cc, resolver.BuildOptions{})
+		require.NoError(t, err)
+		defer r.Close()
+
+		assert.Eventually(t, func() bool {
+			state, _ := cc.lastState()
+			return len(state.Addresses) == 2
+		}, time.Second, 10*time.Millisecond)
+		state, n := cc.lastState()
+		assert.Equal(t, "10.0.0.1:50002", state.Addresses[0].Addr)
+
+		// Connection errors cause the address to be resolved again, after the minimum interval
+		lookupsBefore := lookups.Load()
+		r.ResolveNow(resolver.ResolveNowOptions{})
+		assert.Eventually(t, func() bool {
+			_, m := cc.lastState()
+			return m > n
+		}, 3*dnsMinReResolveInterval, 10*time.Millisecond)
+		assert.Greater(t, lookups.Load(), lookupsBefore)
+	})
+
+	t.Run("resolver with IP address", func(t *testing.T) {
+		cc := &fakeResolverClientConn{}
+		r, err := cache.Build(resolver.Target{URL: *mustParseURL(t, "dapr-dns:///127.0.0.1:50002")}, cc, resolver.BuildOptions{})
+		require.NoError(t, err)
+		defer r.Close()
+
+		state, _ := cc.lastState()
+		require.Len(t, state.Addresses, 1)
+		assert.Equal(t, "127.0.0.1:50002", state.Addresses[0].Addr)
+	})
+
+	t.Run("resolver reports errors", func(t *testing.T) {
+		cc := &fakeResolverClientConn{}
+		r, err := cache.Build(resolver.Target{URL: *mustParseURL(t, "dapr-dns:///fail:50002")}, cc, resolver.BuildOptions{})
+		require.NoError(t, err)
+		defer r.Close()
+
+		assert.Eventually(t, func() bool {
+			cc.lock.Lock()
+			defer cc.lock.Unlock()
+			return len(cc.errs) > 0
+		}, time.Second, 10*time.Millisecond)
+	})
+}
+
+func mustParseURL(t *testing.T, u string) *url.URL {
+	t.Helper()
+	parsed, err := url.Parse(u)
+	require.NoError(t, err)
+	return parsed
+}
@@ -17,6 +17,7 @@ import (
 	"context"
 	"crypto/tls"
 	"fmt"
+	"io"
 	"net"
 	"strconv"
 	"sync"
@@ -27,6 +28,7 @@ import (
 	"google.golang.org/grpc/backoff"
 	"google.golang.org/grpc/credentials"
 	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/keepalive"
 
 	"github.com/dapr/dapr/pkg/channel"
 	grpcChannel "github.com/dapr/dapr/pkg/channel/grpc"
@@ -55,6 +57,8 @@ type AppChannelConfig struct {
 	MaxRequestBodySizeMB int
 	ReadBufferSizeKB     int
 	BaseAddress          string
+	KeepaliveTime        time.Duration
+	KeepaliveTimeout     time.Duration
 }
 
 // Manager is a wrapper around gRPC connection pooling.
@@ -66,6 +70,7 @@ type Manager struct {
 	localConnLock sync.RWMutex
 	appClientConn grpc.ClientConnInterface
 	sec           security.Handler
+	dnsCache      *dnsCache
 	wg            sync.WaitGroup
 	closed        atomic.Bool
 	closeCh       chan struct{}
@@ -121,6 +126,24 @@ func (g *Manager) GetAppClient() (grpc.ClientConnInterface, error) {
 	return g.appClientConn, nil
 }
 
+// ResetAppClient discards the gRPC connection to the local app, so a new connection is established the next time the app client is requested.
+// The previous connection is closed after the drain timeout, allowing in-flight RPCs to complete.
+func (g *Manager) ResetAppClient(drainTimeout time.Duration) {
+	g.localConnLock.Lock()
+	prev := g.appClientConn
+	g.appClientConn = nil
+	g.localConnLock.Unlock()
+
+	closer, ok := prev.(io.Closer)
+	if !ok {
+		return
+	}
+	time.AfterFunc(drainTimeout, func() {
+		// Errors are ignored as the connection is not used anymore
+		_ = closer.Close()
+	})
+}
+
 // SetAppClientConn is used by tests to override the default connection
 func (g *Manager) SetAppClientConn(conn grpc.ClientConnInterface) {
 	g.appClientConn = conn
@@ -155,6 +178,14 @@ func (g *Manager) createLocalConnection(parentCtx context.Context, port int, ena
 		Backoff:           backoff.DefaultConfig,
 		MinConnectTimeout: 1 * time.Second,
 	}))
+	if g.channelConfig.KeepaliveTime > 0 {
+		// Keep the connection alive when idle, for apps behind proxies that drop idle connections
+		opts = append(opts, grpc.WithKeepaliveParams(keepalive.ClientParameters{
+			Time:                g.channelConfig.KeepaliveTime,
+			Timeout:             g.channelConfig.KeepaliveTimeout,
+			PermitWithoutStream: true,
+		}))
+	}
 
 	dialPrefix := GetDialAddressPrefix(g.mode)
 	address := net.JoinHostPort(g.channelConfig.BaseAddress, strconv.Itoa(port))
@@ -164,6 +195,13 @@ func (g *Manager) createLocalConnection(parentCtx context.Context, port int, ena
 	return grpc.DialContext(ctx, dialPrefix+address, opts...)
 }
 
+// EnableDNSCache enables caching the DNS lookups for connections to other Dapr sidecars, with the given TTL.
+// Addresses are also resolved again when a connection fails, so changes to the endpoints are picked up quickly.
+// It must be invoked before any connection is established.
+func (g *Manager) EnableDNSCache(ttl time.Duration) {
+	g.dnsCache = newDNSCache(ttl)
+}
+
 // GetGRPCConnection returns a new grpc connection for a given address and inits one if doesn't exist.
 func (g *Manager) GetGRPCConnection(
 	parentCtx context.Context,
@@ -205,6 +243,10 @@ func (g *Manager) connectRemote(
 	opts = append(opts, customOpts...)
 
 	dialPrefix := GetDialAddressPrefix(g.mode)
+	if g.dnsCache != nil {
+		dialPrefix = dnsCacheScheme + ":///"
+		opts = append(opts, grpc.WithResolvers(g.dnsCache))
+	}
 
 	ctx, cancel := context.WithTimeout(parentCtx, dialTimeout)
 	defer cancel()
@@ -247,6 +289,18 @@ func (g *Manager) StartCollector() {
 	}()
 }
 
+// AppConnectionCount returns the number of connections to the app in the pool.
+func (g *Manager) AppConnectionCount() int {
+	g.localConnLock.RLock()
+	defer g.localConnLock.RUnlock()
+	return g.localConn.Len()
+}
+
+// RemoteConnectionCount returns the number of connections to other sidecars in the pool.
+func (g *Manager) RemoteConnectionCount() int {
+	return g.remoteConns.Len()
+}
+
 func (g *Manager) Close() error {
 	defer g.wg.Wait()
 	if g.closed.CompareAndSwap(false, true) {
@@ -188,6 +188,13 @@ func (p *ConnectionPool) Destroy(conn grpc.ClientConnInterface) {
 	p.connections = p.connections[:n]
 }
 
+// Len returns the number of connections in the pool.
+func (p *ConnectionPool) Len() int {
+	p.lock.RLock()
+	defer p.lock.RUnlock()
+	return len(p.connections)
+}
+
 // DestroyAll closes all connections in the poll.
 func (p *ConnectionPool) DestroyAll() {
 	p.lock.Lock()
@@ -308,6 +315,16 @@ func (p *RemoteConnectionPool) Purge() {
 	})
 }
 
+// Len returns the number of connections in the pool, for all addresses.
+func (p *RemoteConnectionPool) Len() int {
+	n := 0
+	p.pool.Range(func(_ any, item any) bool {
+		n += item.(*ConnectionPool).Len()
+		return true
+	})
+	return n
+}
+
 func (p *RemoteConnectionPool) loadOrStoreItem(address string) *ConnectionPool {
 	item, ok := p.pool.Load(address)
 	if !ok {
@@ -107,6 +107,7 @@ func TestConnectionPool(t *testing.T) {
 		// Register the second connection
 		cp.Register(conns[1])
 		require.Len(t, cp.connections, 2)
+		require.Equal(t, 2, cp.Len())
 		require.Equal(t, int32(0), cp.connections[0].referenceCount)
 		require.Equal(t, int32(0), cp.connections[1].referenceCount)
 
@@ -698,7 +698,7 @@ func (s *proxyTestSuite) TestResiliencyStreaming() {
 func setupMetrics(s *proxyTestSuite) {
 	s.T().Helper()
 	metricsCleanup()
-	s.Require().NoError(diag.DefaultMonitoring.Init(testAppID))
+	s.Require().NoError(diag.DefaultMonitoring.Init(testAppID, nil))
 }
 
 func (s *proxyTestSuite) initServer() {
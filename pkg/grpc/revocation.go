@@ -0,0 +1,61 @@
+/*
+Copyright 2023 The Dapr Authors
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package grpc
+
+import (
+	"context"
+
+	"github.com/spiffe/go-spiffe/v2/spiffegrpc/grpccredentials"
+	"github.com/spiffe/go-spiffe/v2/spiffeid"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// peerIDFromContext returns the SPIFFE ID of the peer of the connection.
+// It's a variable so it can be replaced in tests.
+var peerIDFromContext = grpccredentials.PeerIDFromContext
+
+// getRevocationMiddlewares returns the middlewares that reject calls from peers whose identity is revoked.
+// The revocation is also checked at the TLS handshake, but connections established before the identity was revoked are kept open, so it's checked again on each call.
+func getRevocationMiddlewares(isRevoked func(spiffeid.ID) bool) (grpc.UnaryServerInterceptor, grpc.StreamServerInterceptor) {
+	return func(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+			err := checkPeerNotRevoked(ctx, isRevoked)
+			if err != nil {
+				return nil, err
+			}
+			return handler(ctx, req)
+		},
+		func(srv any, stream grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+			err := checkPeerNotRevoked(stream.Context(), isRevoked)
+			if err != nil {
+				return err
+			}
+			return handler(srv, stream)
+		}
+}
+
+// Checks if the identity of the peer in the gRPC request's context is revoked; returns an error if so.
+func checkPeerNotRevoked(ctx context.Context, isRevoked func(spiffeid.ID) bool) error {
+	id, ok := peerIDFromContext(ctx)
+	if !ok {
+		return nil
+	}
+
+	if isRevoked(id) {
+		return status.Errorf(codes.PermissionDenied, "identity %q has been revoked", id)
+	}
+
+	return nil
+}
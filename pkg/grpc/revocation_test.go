@@ -0,0 +1,112 @@
+/*
+Copyright 2023 The Dapr Authors
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package grpc
+
+import (
+	"context"
+	"testing"
+
+	"github.com/spiffe/go-spiffe/v2/spiffeid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	grpcGo "google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+type peerIDCtxKey struct{}
+
+// contextStream is a grpc.ServerStream that only returns its context.
+type contextStream struct {
+	grpcGo.ServerStream
+	ctx context.Context
+}
+
+func (s *contextStream) Context() context.Context {
+	return s.ctx
+}
+
+func TestRevocationMiddlewares(t *testing.T) {
+	orig := peerIDFromContext
+	t.Cleanup(func() { peerIDFromContext = orig })
+	peerIDFromContext = func(ctx context.Context) (spiffeid.ID, bool) {
+		id, ok := ctx.Value(peerIDCtxKey{}).(spiffeid.ID)
+		return id, ok
+	}
+
+	revokedID := spiffeid.RequireFromString("spiffe://public/ns/default/revoked")
+	validID := spiffeid.RequireFromString("spiffe://public/ns/default/valid")
+
+	// The list of revoked identities is read on each call, so it can change while connections are open.
+	var revoked bool
+	unary, stream := getRevocationMiddlewares(func(id spiffeid.ID) bool {
+		return revoked && id == revokedID
+	})
+
+	handler := func(ctx context.Context, req any) (any, error) {
+		return "ok", nil
+	}
+	streamHandler := func(srv any, stream grpcGo.ServerStream) error {
+		return nil
+	}
+	callUnary := func(id *spiffeid.ID) (any, error) {
+		ctx := context.Background()
+		if id != nil {
+			ctx = context.WithValue(ctx, peerIDCtxKey{}, *id)
+		}
+		return unary(ctx, nil, &grpcGo.UnaryServerInfo{}, handler)
+	}
+	callStream := func(id *spiffeid.ID) error {
+		ctx := context.Background()
+		if id != nil {
+			ctx = context.WithValue(ctx, peerIDCtxKey{}, *id)
+		}
+		return stream(nil, &contextStream{ctx: ctx}, &grpcGo.StreamServerInfo{}, streamHandler)
+	}
+
+	t.Run("calls are allowed before the identity is revoked", func(t *testing.T) {
+		revoked = false
+		res, err := callUnary(&revokedID)
+		require.NoError(t, err)
+		assert.Equal(t, "ok", res)
+		require.NoError(t, callStream(&revokedID))
+	})
+
+	t.Run("calls from a revoked identity are rejected", func(t *testing.T) {
+		revoked = true
+		_, err := callUnary(&revokedID)
+		require.Error(t, err)
+		assert.Equal(t, codes.PermissionDenied, status.Code(err))
+
+		err = callStream(&revokedID)
+		require.Error(t, err)
+		assert.Equal(t, codes.PermissionDenied, status.Code(err))
+	})
+
+	t.Run("calls from other identities are allowed", func(t *testing.T) {
+		revoked = true
+		res, err := callUnary(&validID)
+		require.NoError(t, err)
+		assert.Equal(t, "ok", res)
+		require.NoError(t, callStream(&validID))
+	})
+
+	t.Run("calls without a peer identity are allowed", func(t *testing.T) {
+		revoked = true
+		res, err := callUnary(nil)
+		require.NoError(t, err)
+		assert.Equal(t, "ok", res)
+		require.NoError(t, callStream(nil))
+	})
+}
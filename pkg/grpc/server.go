@@ -19,7 +19,6 @@ import (
 	"fmt"
 	"io"
 	"net"
-	"strconv"
 	"sync"
 	"sync/atomic"
 	"time"
@@ -42,6 +41,7 @@ import (
 	"github.com/dapr/dapr/pkg/runtime/wfengine"
 	"github.com/dapr/dapr/pkg/security"
 	securityConsts "github.com/dapr/dapr/pkg/security/consts"
+	"github.com/dapr/dapr/utils"
 	"github.com/dapr/kit/logger"
 )
 
@@ -138,7 +138,7 @@ func (s *server) StartNonBlocking() error {
 		listeners = append(listeners, l)
 	} else {
 		for _, apiListenAddress := range s.config.APIListenAddresses {
-			addr := apiListenAddress + ":" + strconv.Itoa(s.config.Port)
+			addr := utils.JoinHostPort(apiListenAddress, s.config.Port)
 			l, err := net.Listen("tcp", addr)
 			if err != nil {
 				s.logger.Errorf("Failed to listen for gRPC server on TCP address %s with error: %v", addr, err)
@@ -214,8 +214,8 @@ func (s *server) getMiddlewareOptions() []grpcGo.ServerOption {
 	// We initialize these slices with an initial capacity to give the compiler a "hint" of how much memory we may use.
 	// These capacities are the worst-case scenario below (max number of items added to each slice).
 	// Specifying an initial capacity helps us reducing the risk that we may need to re-allocate the slice, which is wasteful both on the allocator and on the GC.
-	intr := make([]grpcGo.UnaryServerInterceptor, 0, 6)
-	intrStream := make([]grpcGo.StreamServerInterceptor, 0, 5)
+	intr := make([]grpcGo.UnaryServerInterceptor, 0, 9)
+	intrStream := make([]grpcGo.StreamServerInterceptor, 0, 8)
 
 	intr = append(intr, metadata.SetMetadataInContextUnary)
 
@@ -235,6 +235,12 @@ func (s *server) getMiddlewareOptions() []grpcGo.ServerOption {
 		intrStream = append(intrStream, stream)
 	}
 
+	if s.kind == internalServer && s.sec != nil {
+		unary, stream := getRevocationMiddlewares(s.sec.IsRevoked)
+		intr = append(intr, unary)
+		intrStream = append(intrStream, stream)
+	}
+
 	if diagUtils.IsTracingEnabled(s.tracingSpec.SamplingRate) {
 		s.logger.Info("Enabled gRPC tracing middleware")
 		intr = append(intr, diag.GRPCTraceUnaryServerInterceptor(s.config.AppID, s.tracingSpec))
@@ -243,15 +249,26 @@ func (s *server) getMiddlewareOptions() []grpcGo.ServerOption {
 
 	if s.metricSpec.GetEnabled() {
 		s.logger.Info("Enabled gRPC metrics middleware")
-		intr = append(intr, diag.DefaultGRPCMonitoring.UnaryServerInterceptor())
+		kind := diag.ServerKindAPI
+		if s.kind == internalServer {
+			kind = diag.ServerKindInternal
+		}
+		intr = append(intr, diag.DefaultServerMonitoring.UnaryServerInterceptor(kind))
+		intrStream = append(intrStream, diag.DefaultServerMonitoring.StreamServerInterceptor(kind))
 
 		if s.kind == apiServer {
-			intrStream = append(intrStream, diag.DefaultGRPCMonitoring.StreamingServerInterceptor())
-		} else if s.kind == internalServer {
-			intrStream = append(intrStream, diag.DefaultGRPCMonitoring.StreamingClientInterceptor())
+			unary, stream := setAPIUsageMiddlewares()
+			intr = append(intr, unary)
+			intrStream = append(intrStream, stream)
 		}
 	}
 
+	if s.kind == apiServer && s.config.AccessLog != nil {
+		s.logger.Info("Enabled access logs on gRPC server")
+		intr = append(intr, s.config.AccessLog.UnaryServerInterceptor())
+		intrStream = append(intrStream, s.config.AccessLog.StreamServerInterceptor())
+	}
+
 	if s.config.EnableAPILogging && s.infoLogger != nil {
 		unary, stream := s.getGRPCAPILoggingMiddlewares()
 		intr = append(intr, unary)
@@ -334,5 +351,7 @@ func (s *server) printAPILog(ctx context.Context, method string, duration time.D
 	// Report duration in milliseconds
 	fields["duration"] = duration.Milliseconds()
 	fields["code"] = int32(code)
+	// Correlate the log entry with the trace of the call
+	diag.AddTraceLogFields(fields, diagUtils.SpanFromContext(ctx).SpanContext())
 	s.infoLogger.WithFields(fields).Info("gRPC API Called")
 }
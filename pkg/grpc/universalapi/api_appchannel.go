@@ -0,0 +1,37 @@
+/*
+Copyright 2023 The Dapr Authors
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package universalapi
+
+import (
+	"context"
+
+	"github.com/dapr/dapr/pkg/messages"
+)
+
+// ResetAppChannel closes the connections to the app and re-establishes them, without restarting the sidecar.
+func (a *UniversalAPI) ResetAppChannel(ctx context.Context) error {
+	if a.ResetAppChannelFn == nil || a.AppConnectionConfig.Port == 0 {
+		err := messages.ErrAppChannelNotConfigured
+		a.Logger.Debug(err)
+		return err
+	}
+
+	if err := a.ResetAppChannelFn(); err != nil {
+		err = messages.ErrAppChannelReset.WithFormat(err)
+		a.Logger.Debug(err)
+		return err
+	}
+
+	return nil
+}
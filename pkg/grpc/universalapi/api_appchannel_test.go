@@ -0,0 +1,67 @@
+/*
+Copyright 2023 The Dapr Authors
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package universalapi
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/dapr/dapr/pkg/config"
+	"github.com/dapr/dapr/pkg/messages"
+)
+
+func TestResetAppChannel(t *testing.T) {
+	t.Run("app channel not configured", func(t *testing.T) {
+		fakeAPI := &UniversalAPI{
+			Logger:            testLogger,
+			ResetAppChannelFn: func() error { return nil },
+		}
+
+		err := fakeAPI.ResetAppChannel(context.Background())
+		require.ErrorIs(t, err, messages.ErrAppChannelNotConfigured)
+	})
+
+	t.Run("reset successfully", func(t *testing.T) {
+		var called bool
+		fakeAPI := &UniversalAPI{
+			Logger:              testLogger,
+			AppConnectionConfig: config.AppConnectionConfig{Port: 3000},
+			ResetAppChannelFn: func() error {
+				called = true
+				return nil
+			},
+		}
+
+		require.NoError(t, fakeAPI.ResetAppChannel(context.Background()))
+		assert.True(t, called)
+	})
+
+	t.Run("reset fails", func(t *testing.T) {
+		fakeAPI := &UniversalAPI{
+			Logger:              testLogger,
+			AppConnectionConfig: config.AppConnectionConfig{Port: 3000},
+			ResetAppChannelFn: func() error {
+				return errors.New("boom")
+			},
+		}
+
+		err := fakeAPI.ResetAppChannel(context.Background())
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "boom")
+	})
+}
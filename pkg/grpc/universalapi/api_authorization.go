@@ -0,0 +1,186 @@
+/*
+Copyright 2023 The Dapr Authors
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package universalapi
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/microsoft/durabletask-go/api"
+	"github.com/microsoft/durabletask-go/backend"
+	"golang.org/x/exp/slices"
+
+	"github.com/dapr/components-contrib/workflows"
+	"github.com/dapr/dapr/pkg/config"
+	"github.com/dapr/dapr/pkg/messages"
+	"github.com/dapr/dapr/pkg/runtime/wfengine"
+)
+
+// Names of the workflow operations that can be restricted with access control policies.
+const (
+	workflowOperationStart      = "start"
+	workflowOperationTerminate  = "terminate"
+	workflowOperationRaiseEvent = "raiseEvent"
+	workflowOperationPause      = "pause"
+	workflowOperationResume     = "resume"
+	workflowOperationPurge      = "purge"
+)
+
+// Matches all workflow names or actor types in access control policies.
+const policyWildcard = "*"
+
+func (a *UniversalAPI) accessControlSpec() *config.AccessControlSpec {
+	if a.GlobalConfig == nil {
+		return nil
+	}
+	return a.GlobalConfig.Spec.AccessControlSpec
+}
+
+// AuthorizeActorCall returns an error if the app with ID callerAppID is not allowed to invoke the method of an actor hosted by this app.
+// It's enforced by the sidecar that hosts the actor, on the calls received from other sidecars: callerAppID must be the app ID in the mTLS identity of the peer, and it's empty if the peer has no identity.
+// The invocations of the workflow actors are also checked against the workflow policies.
+// The app that hosts the actors is always allowed to invoke them, since calls between its own replicas are received as remote calls too.
+func (a *UniversalAPI) AuthorizeActorCall(ctx context.Context, callerAppID string, actorType string, actorID string, method string, data []byte) error {
+	acl := a.accessControlSpec()
+	if acl == nil || callerAppID == a.AppID {
+		return nil
+	}
+
+	if err := a.authorizeActorInvocation(callerAppID, actorType); err != nil {
+		return err
+	}
+
+	if len(acl.WorkflowPolicies) == 0 || actorType != wfengine.GetWorkflowActorType(a.AppID) {
+		return nil
+	}
+	operation, workflowName, err := workflowActorCallOperation(method, data)
+	if err != nil {
+		return messages.ErrBadRequest.WithFormat(err)
+	}
+	switch {
+	case operation == "":
+		return nil
+	case workflowName != "":
+		return a.authorizeWorkflowOperation(callerAppID, operation, workflowName)
+	default:
+		return a.authorizeWorkflowInstanceOperation(ctx, callerAppID, operation, actorID)
+	}
+}
+
+// workflowActorCallOperation returns the workflow operation performed by an invocation of the workflow actor, and the name of the workflow if the request contains it.
+// The operation is empty for the invocations that aren't restricted by workflow policies, such as the events that parent and child workflows send to each other.
+func workflowActorCallOperation(method string, data []byte) (operation string, workflowName string, err error) {
+	switch method {
+	case wfengine.CreateWorkflowInstanceMethod:
+		var req wfengine.CreateWorkflowInstanceRequest
+		if err = json.Unmarshal(data, &req); err != nil {
+			return "", "", fmt.Errorf("invalid request to create a workflow instance: %w", err)
+		}
+		e, err := backend.UnmarshalHistoryEvent(req.StartEventBytes)
+		if err != nil {
+			return "", "", fmt.Errorf("invalid execution start event: %w", err)
+		}
+		return workflowOperationStart, e.GetExecutionStarted().GetName(), nil
+	case wfengine.AddWorkflowEventMethod:
+		e, err := backend.UnmarshalHistoryEvent(data)
+		if err != nil {
+			return "", "", fmt.Errorf("invalid workflow event: %w", err)
+		}
+		switch {
+		case e.GetEventRaised() != nil:
+			return workflowOperationRaiseEvent, "", nil
+		case e.GetExecutionTerminated() != nil:
+			return workflowOperationTerminate, "", nil
+		case e.GetExecutionSuspended() != nil:
+			return workflowOperationPause, "", nil
+		case e.GetExecutionResumed() != nil:
+			return workflowOperationResume, "", nil
+		}
+	case wfengine.PurgeWorkflowStateMethod:
+		return workflowOperationPurge, "", nil
+	}
+	return "", "", nil
+}
+
+// authorizeWorkflowOperation returns an error if the app with ID callerAppID is not allowed to perform the operation on workflows with the given name.
+func (a *UniversalAPI) authorizeWorkflowOperation(callerAppID string, operation string, workflowName string) error {
+	acl := a.accessControlSpec()
+	if acl == nil || len(acl.WorkflowPolicies) == 0 {
+		return nil
+	}
+
+	var matched bool
+	for _, p := range acl.WorkflowPolicies {
+		if p.WorkflowName != workflowName && p.WorkflowName != policyWildcard {
+			continue
+		}
+		if len(p.Operations) > 0 && !slices.Contains(p.Operations, operation) {
+			continue
+		}
+		if callerAppID != "" && slices.Contains(p.AllowedCallers, callerAppID) {
+			return nil
+		}
+		matched = true
+	}
+
+	if matched {
+		return messages.ErrWorkflowOperationForbidden.WithFormat(callerAppID, operation, workflowName)
+	}
+	return nil
+}
+
+// authorizeWorkflowInstanceOperation is like authorizeWorkflowOperation, but it looks up the name of the workflow from the instance ID.
+func (a *UniversalAPI) authorizeWorkflowInstanceOperation(ctx context.Context, callerAppID string, operation string, instanceID string) error {
+	workflowComponent, ok := a.CompStore.GetWorkflow(wfengine.ComponentDefinition.Name)
+	if !ok {
+		return messages.ErrWorkflowComponentDoesNotExist.WithFormat(wfengine.ComponentDefinition.Name)
+	}
+
+	res, err := workflowComponent.Get(ctx, &workflows.GetRequest{InstanceID: instanceID})
+	if err != nil {
+		if errors.Is(err, api.ErrInstanceNotFound) {
+			// There's no workflow to protect, and the operation fails anyway
+			return nil
+		}
+		return messages.ErrWorkflowGetResponse.WithFormat(instanceID, err)
+	}
+
+	return a.authorizeWorkflowOperation(callerAppID, operation, res.Workflow.WorkflowName)
+}
+
+// authorizeActorInvocation returns an error if the app with ID callerAppID is not allowed to invoke actors of the given type.
+func (a *UniversalAPI) authorizeActorInvocation(callerAppID string, actorType string) error {
+	acl := a.accessControlSpec()
+	if acl == nil || len(acl.ActorPolicies) == 0 {
+		return nil
+	}
+
+	var matched bool
+	for _, p := range acl.ActorPolicies {
+		if p.ActorType != actorType && p.ActorType != policyWildcard {
+			continue
+		}
+		if callerAppID != "" && slices.Contains(p.AllowedCallers, callerAppID) {
+			return nil
+		}
+		matched = true
+	}
+
+	if matched {
+		return messages.ErrActorInvokeForbidden.WithFormat(callerAppID, actorType)
+	}
+	return nil
+}
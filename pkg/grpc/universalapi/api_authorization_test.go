@@ -0,0 +1,225 @@
+/*
+Copyright 2023 The Dapr Authors
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package universalapi
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/microsoft/durabletask-go/backend"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/encoding/protojson"
+
+	"github.com/dapr/dapr/pkg/config"
+	"github.com/dapr/dapr/pkg/messages"
+	"github.com/dapr/dapr/pkg/resiliency"
+	"github.com/dapr/dapr/pkg/runtime/compstore"
+	"github.com/dapr/dapr/pkg/runtime/wfengine"
+	daprt "github.com/dapr/dapr/pkg/testing"
+	"github.com/dapr/kit/logger"
+)
+
+// newAuthorizationTestAPI returns the API of the app "host", which hosts the actors and workflows protected by the policies.
+func newAuthorizationTestAPI(acl *config.AccessControlSpec) *UniversalAPI {
+	compStore := compstore.New()
+	compStore.AddWorkflow(wfengine.ComponentDefinition.Name, &daprt.MockWorkflow{})
+
+	fakeAPI := &UniversalAPI{
+		AppID:      "host",
+		Logger:     logger.NewLogger("test"),
+		Resiliency: resiliency.New(nil),
+		CompStore:  compStore,
+		GlobalConfig: &config.Configuration{
+			Spec: config.ConfigurationSpec{
+				AccessControlSpec: acl,
+			},
+		},
+	}
+	fakeAPI.InitUniversalAPI()
+	fakeAPI.SetActorsInitDone()
+	return fakeAPI
+}
+
+func marshalHistoryEvent(t *testing.T, event string) []byte {
+	t.Helper()
+
+	e := &backend.HistoryEvent{}
+	require.NoError(t, protojson.Unmarshal([]byte(event), e))
+	data, err := backend.MarshalHistoryEvent(e)
+	require.NoError(t, err)
+	return data
+}
+
+func TestAuthorizeWorkflowOperation(t *testing.T) {
+	acl := &config.AccessControlSpec{
+		WorkflowPolicies: []config.WorkflowPolicySpec{
+			{WorkflowName: "payments", Operations: []string{workflowOperationTerminate, workflowOperationPurge}, AllowedCallers: []string{"admin"}},
+			{WorkflowName: "*", Operations: []string{workflowOperationPurge}, AllowedCallers: []string{"janitor"}},
+		},
+	}
+
+	tests := []struct {
+		name         string
+		callerAppID  string
+		operation    string
+		workflowName string
+		allowed      bool
+	}{
+		{name: "allowed caller", callerAppID: "admin", operation: workflowOperationTerminate, workflowName: "payments", allowed: true},
+		{name: "caller not allowed", callerAppID: "frontend", operation: workflowOperationTerminate, workflowName: "payments", allowed: false},
+		{name: "caller without identity", callerAppID: "", operation: workflowOperationTerminate, workflowName: "payments", allowed: false},
+		{name: "operation not restricted", callerAppID: "frontend", operation: workflowOperationStart, workflowName: "payments", allowed: true},
+		{name: "workflow not restricted", callerAppID: "frontend", operation: workflowOperationTerminate, workflowName: "orders", allowed: true},
+		{name: "wildcard policy", callerAppID: "frontend", operation: workflowOperationPurge, workflowName: "orders", allowed: false},
+		{name: "allowed by any matching policy", callerAppID: "janitor", operation: workflowOperationPurge, workflowName: "payments", allowed: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := newAuthorizationTestAPI(acl).authorizeWorkflowOperation(tt.callerAppID, tt.operation, tt.workflowName)
+			if tt.allowed {
+				require.NoError(t, err)
+			} else {
+				require.ErrorIs(t, err, messages.ErrWorkflowOperationForbidden.WithFormat(tt.callerAppID, tt.operation, tt.workflowName))
+			}
+		})
+	}
+
+	t.Run("no policies", func(t *testing.T) {
+		require.NoError(t, newAuthorizationTestAPI(nil).authorizeWorkflowOperation("frontend", workflowOperationPurge, "payments"))
+	})
+}
+
+func TestAuthorizeWorkflowActorCall(t *testing.T) {
+	// The mock workflow component reports "mockWorkflowName" as the name of all instances
+	acl := &config.AccessControlSpec{
+		WorkflowPolicies: []config.WorkflowPolicySpec{
+			{WorkflowName: "mockWorkflowName", Operations: []string{workflowOperationStart, workflowOperationTerminate, workflowOperationRaiseEvent}, AllowedCallers: []string{"admin"}},
+		},
+	}
+	workflowActorType := wfengine.GetWorkflowActorType("host")
+
+	createRequest := func(workflowName string) []byte {
+		data, err := json.Marshal(wfengine.CreateWorkflowInstanceRequest{
+			StartEventBytes: marshalHistoryEvent(t, `{"eventId": -1, "executionStarted": {"name": "`+workflowName+`"}}`),
+		})
+		require.NoError(t, err)
+		return data
+	}
+	terminateEvent := marshalHistoryEvent(t, `{"eventId": -1, "executionTerminated": {}}`)
+	raiseEvent := marshalHistoryEvent(t, `{"eventId": -1, "eventRaised": {"name": "approval"}}`)
+	childCompletedEvent := marshalHistoryEvent(t, `{"eventId": -1, "subOrchestrationInstanceCompleted": {"taskScheduledId": 1}}`)
+
+	tests := []struct {
+		name        string
+		callerAppID string
+		method      string
+		data        []byte
+		err         error
+	}{
+		{
+			name:        "start is denied",
+			callerAppID: "frontend",
+			method:      wfengine.CreateWorkflowInstanceMethod,
+			data:        createRequest("mockWorkflowName"),
+			err:         messages.ErrWorkflowOperationForbidden.WithFormat("frontend", workflowOperationStart, "mockWorkflowName"),
+		},
+		{
+			name:        "start of another workflow is allowed",
+			callerAppID: "frontend",
+			method:      wfengine.CreateWorkflowInstanceMethod,
+			data:        createRequest("orders"),
+		},
+		{
+			name:        "start is allowed",
+			callerAppID: "admin",
+			method:      wfengine.CreateWorkflowInstanceMethod,
+			data:        createRequest("mockWorkflowName"),
+		},
+		{
+			name:        "terminate is denied",
+			callerAppID: "frontend",
+			method:      wfengine.AddWorkflowEventMethod,
+			data:        terminateEvent,
+			err:         messages.ErrWorkflowOperationForbidden.WithFormat("frontend", workflowOperationTerminate, "mockWorkflowName"),
+		},
+		{
+			name:        "raise event is denied without identity",
+			callerAppID: "",
+			method:      wfengine.AddWorkflowEventMethod,
+			data:        raiseEvent,
+			err:         messages.ErrWorkflowOperationForbidden.WithFormat("", workflowOperationRaiseEvent, "mockWorkflowName"),
+		},
+		{
+			name:        "events between parent and child workflows are not restricted",
+			callerAppID: "frontend",
+			method:      wfengine.AddWorkflowEventMethod,
+			data:        childCompletedEvent,
+		},
+		{
+			name:        "purge is not restricted",
+			callerAppID: "frontend",
+			method:      wfengine.PurgeWorkflowStateMethod,
+		},
+		{
+			name:        "host app is always allowed",
+			callerAppID: "host",
+			method:      wfengine.AddWorkflowEventMethod,
+			data:        terminateEvent,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := newAuthorizationTestAPI(acl).AuthorizeActorCall(context.Background(), tt.callerAppID, workflowActorType, fakeInstanceID, tt.method, tt.data)
+			if tt.err == nil {
+				require.NoError(t, err)
+			} else {
+				require.ErrorIs(t, err, tt.err)
+			}
+		})
+	}
+
+	t.Run("failure to look up the workflow", func(t *testing.T) {
+		err := newAuthorizationTestAPI(acl).AuthorizeActorCall(context.Background(), "admin", workflowActorType, daprt.ErrorInstanceID, wfengine.AddWorkflowEventMethod, terminateEvent)
+		require.ErrorIs(t, err, messages.ErrWorkflowGetResponse.WithFormat(daprt.ErrorInstanceID, daprt.ErrFakeWorkflowComponentError))
+	})
+
+	t.Run("invalid request", func(t *testing.T) {
+		err := newAuthorizationTestAPI(acl).AuthorizeActorCall(context.Background(), "admin", workflowActorType, fakeInstanceID, wfengine.AddWorkflowEventMethod, []byte("foo"))
+		require.Error(t, err)
+	})
+}
+
+func TestAuthorizeActorCall(t *testing.T) {
+	acl := &config.AccessControlSpec{
+		ActorPolicies: []config.ActorPolicySpec{
+			{ActorType: "bankAccount", AllowedCallers: []string{"teller"}},
+		},
+	}
+
+	require.NoError(t, newAuthorizationTestAPI(acl).AuthorizeActorCall(context.Background(), "teller", "bankAccount", "1", "deposit", nil))
+	require.ErrorIs(t,
+		newAuthorizationTestAPI(acl).AuthorizeActorCall(context.Background(), "frontend", "bankAccount", "1", "deposit", nil),
+		messages.ErrActorInvokeForbidden.WithFormat("frontend", "bankAccount"),
+	)
+	require.ErrorIs(t,
+		newAuthorizationTestAPI(acl).AuthorizeActorCall(context.Background(), "", "bankAccount", "1", "deposit", nil),
+		messages.ErrActorInvokeForbidden.WithFormat("", "bankAccount"),
+	)
+	require.NoError(t, newAuthorizationTestAPI(acl).AuthorizeActorCall(context.Background(), "host", "bankAccount", "1", "deposit", nil))
+	require.NoError(t, newAuthorizationTestAPI(acl).AuthorizeActorCall(context.Background(), "frontend", "cart", "1", "add", nil))
+	require.NoError(t, newAuthorizationTestAPI(nil).AuthorizeActorCall(context.Background(), "frontend", "bankAccount", "1", "deposit", nil))
+}
@@ -0,0 +1,46 @@
+/*
+Copyright 2023 The Dapr Authors
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package universalapi
+
+import (
+	"context"
+
+	"github.com/dapr/dapr/pkg/messages"
+)
+
+// BindingDeadLetterReplay is the result of replaying the invocations of an output binding that failed and were stored in a state store.
+type BindingDeadLetterReplay struct {
+	// Replayed is the number of invocations that succeeded and were removed from the state store.
+	Replayed int `json:"replayed"`
+	// Failed is the number of invocations that failed again and were kept in the state store.
+	Failed int `json:"failed"`
+	// Expired is the number of invocations that expired before they could be replayed.
+	Expired int `json:"expired"`
+}
+
+// ReplayBindingDeadLetters invokes an output binding again with the invocations that failed and were stored in its dead-letter state store.
+func (a *UniversalAPI) ReplayBindingDeadLetters(ctx context.Context, name string) (*BindingDeadLetterReplay, error) {
+	if a.ReplayBindingDeadLettersFn == nil {
+		err := messages.ErrBindingDeadLetterNotConfigured.WithFormat(name)
+		a.Logger.Debug(err)
+		return nil, err
+	}
+
+	res, err := a.ReplayBindingDeadLettersFn(ctx, name)
+	if err != nil {
+		a.Logger.Debug(err)
+		return nil, err
+	}
+	return res, nil
+}
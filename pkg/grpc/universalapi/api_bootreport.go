@@ -0,0 +1,90 @@
+/*
+Copyright 2023 The Dapr Authors
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package universalapi
+
+import (
+	"time"
+
+	"github.com/dapr/dapr/pkg/messages"
+)
+
+// BootReport is a machine-readable summary of the startup sequence of the sidecar, which can be used by deployment tooling to verify rollouts.
+type BootReport struct {
+	AppID          string `json:"appID"`
+	RuntimeVersion string `json:"runtimeVersion"`
+	GitCommit      string `json:"gitCommit,omitempty"`
+	GoVersion      string `json:"goVersion"`
+	Mode           string `json:"mode"`
+	// Ready is true once the sidecar has completed its startup sequence.
+	Ready     bool      `json:"ready"`
+	StartTime time.Time `json:"startTime"`
+	// InitDurationMs is the time, in milliseconds, the sidecar took to complete its startup sequence; it's 0 until Ready is true.
+	InitDurationMs  int64                 `json:"initDurationMs,omitempty"`
+	Components      []BootReportComponent `json:"components"`
+	EnabledFeatures []string              `json:"enabledFeatures"`
+	Ports           BootReportPorts       `json:"ports"`
+}
+
+// BootReportComponent is a component loaded by the sidecar.
+type BootReportComponent struct {
+	Name    string `json:"name"`
+	Type    string `json:"type"`
+	Version string `json:"version"`
+	// InitDurationMs is the time, in milliseconds, the component took to initialize.
+	InitDurationMs int64 `json:"initDurationMs"`
+	// Warmup is the result of the connectivity check of the component, if checks are enabled.
+	Warmup *BootReportWarmup `json:"warmup,omitempty"`
+}
+
+// Statuses of the connectivity checks of the components.
+const (
+	WarmupPassed = "passed"
+	// WarmupFailed is the status of the components that didn't pass the check before the timeout.
+	WarmupFailed = "failed"
+	// WarmupSkipped is the status of the components that are excluded from the checks in the configuration.
+	WarmupSkipped = "skipped"
+	// WarmupUnsupported is the status of the components that don't implement a ping operation.
+	WarmupUnsupported = "unsupported"
+)
+
+// BootReportWarmup is the result of the connectivity check of a component, performed before the sidecar is marked as ready.
+type BootReportWarmup struct {
+	Status string `json:"status"`
+	// Error is the error of the last failed attempt.
+	Error string `json:"error,omitempty"`
+	// Attempts is the number of times the component was checked.
+	Attempts int `json:"attempts,omitempty"`
+}
+
+// BootReportPorts contains the ports the sidecar listens on, and the port of the app.
+// Ports of servers that are disabled are omitted.
+type BootReportPorts struct {
+	HTTP         int `json:"http,omitempty"`
+	Public       int `json:"public,omitempty"`
+	GRPC         int `json:"grpc,omitempty"`
+	InternalGRPC int `json:"internalGRPC,omitempty"`
+	Metrics      int `json:"metrics,omitempty"`
+	Profile      int `json:"profile,omitempty"`
+	App          int `json:"app,omitempty"`
+}
+
+// GetBootReport returns the boot report of the sidecar.
+func (a *UniversalAPI) GetBootReport() (*BootReport, error) {
+	if a.GetBootReportFn == nil {
+		err := messages.ErrBootReportNotAvailable
+		a.Logger.Debug(err)
+		return nil, err
+	}
+	return a.GetBootReportFn(), nil
+}
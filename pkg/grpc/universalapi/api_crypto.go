@@ -49,6 +49,7 @@ func (a *UniversalAPI) CryptoGetWrapKeyFn(ctx context.Context, componentName str
 		elapsed := diag.ElapsedSince(start)
 
 		diag.DefaultComponentMonitoring.CryptoInvoked(ctx, componentName, diag.CryptoOp, err == nil, elapsed)
+		diag.DefaultComponentMonitoring.OperationFailed(ctx, componentName, diag.CryptoOp, err)
 
 		if err != nil {
 			return nil, nil, err
@@ -69,6 +70,7 @@ func (a *UniversalAPI) CryptoGetUnwrapKeyFn(ctx context.Context, componentName s
 		elapsed := diag.ElapsedSince(start)
 
 		diag.DefaultComponentMonitoring.CryptoInvoked(ctx, componentName, diag.CryptoOp, err == nil, elapsed)
+		diag.DefaultComponentMonitoring.OperationFailed(ctx, componentName, diag.CryptoOp, err)
 
 		if err != nil {
 			return nil, err
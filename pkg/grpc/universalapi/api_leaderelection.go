@@ -0,0 +1,82 @@
+/*
+Copyright 2023 The Dapr Authors
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package universalapi
+
+import (
+	"context"
+
+	"google.golang.org/protobuf/types/known/emptypb"
+
+	"github.com/dapr/dapr/pkg/leaderelection"
+	"github.com/dapr/dapr/pkg/messages"
+	runtimev1pb "github.com/dapr/dapr/pkg/proto/runtime/v1"
+)
+
+// CampaignLeadershipAlpha1 campaigns for the leadership of an election on behalf of a candidate.
+func (a *UniversalAPI) CampaignLeadershipAlpha1(ctx context.Context, in *runtimev1pb.CampaignLeadershipRequest) (*runtimev1pb.CampaignLeadershipResponse, error) {
+	election, err := a.GetElection(in.GetElection())
+	if err != nil {
+		return nil, err
+	}
+	if in.GetCandidateId() == "" {
+		err = messages.ErrLeaderElectionInvalid.WithFormat(leaderelection.ErrInvalidCandidate)
+		a.Logger.Debug(err)
+		return nil, err
+	}
+
+	leaderID, err := election.Campaign(ctx, in.GetCandidateId())
+	if err != nil {
+		err = messages.ErrLeaderElectionFailed.WithFormat("campaign", in.GetElection(), err)
+		a.Logger.Debug(err)
+		return nil, err
+	}
+
+	return &runtimev1pb.CampaignLeadershipResponse{
+		LeaderId: leaderID,
+		IsLeader: leaderID == in.GetCandidateId(),
+	}, nil
+}
+
+// ResignLeadershipAlpha1 stops campaigning for the leadership of an election on behalf of a candidate.
+func (a *UniversalAPI) ResignLeadershipAlpha1(ctx context.Context, in *runtimev1pb.ResignLeadershipRequest) (*emptypb.Empty, error) {
+	election, err := a.GetElection(in.GetElection())
+	if err != nil {
+		return nil, err
+	}
+	if in.GetCandidateId() == "" {
+		err = messages.ErrLeaderElectionInvalid.WithFormat(leaderelection.ErrInvalidCandidate)
+		a.Logger.Debug(err)
+		return nil, err
+	}
+
+	err = election.Resign(ctx, in.GetCandidateId())
+	if err != nil {
+		err = messages.ErrLeaderElectionFailed.WithFormat("resign", in.GetElection(), err)
+		a.Logger.Debug(err)
+		return nil, err
+	}
+
+	return &emptypb.Empty{}, nil
+}
+
+// GetElection returns the election with the given name.
+func (a *UniversalAPI) GetElection(name string) (*leaderelection.Election, error) {
+	election, ok := a.LeaderElections[name]
+	if !ok {
+		err := messages.ErrElectionNotFound.WithFormat(name)
+		a.Logger.Debug(err)
+		return nil, err
+	}
+	return election, nil
+}
@@ -0,0 +1,115 @@
+/*
+Copyright 2023 The Dapr Authors
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package universalapi
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/dapr/components-contrib/state"
+	"github.com/dapr/dapr/pkg/config"
+	"github.com/dapr/dapr/pkg/leaderelection"
+	"github.com/dapr/dapr/pkg/messages"
+	runtimev1pb "github.com/dapr/dapr/pkg/proto/runtime/v1"
+	daprt "github.com/dapr/dapr/pkg/testing"
+)
+
+func TestLeaderElection(t *testing.T) {
+	store := daprt.NewFakeStateStore()
+	elections, err := leaderelection.New(leaderelection.Options{
+		AppID: "fakeAPI",
+		Specs: []config.LeaderElectionSpec{
+			{Name: "election", StateStore: "store"},
+			{Name: "missing", StateStore: "other"},
+		},
+		GetStateStoreFn: func(name string) (state.Store, bool) {
+			if name != "store" {
+				return nil, false
+			}
+			return store, true
+		},
+	})
+	require.NoError(t, err)
+	defer elections.Close()
+
+	fakeAPI := &UniversalAPI{
+		Logger:          testLogger,
+		LeaderElections: elections,
+	}
+
+	t.Run("first candidate becomes the leader", func(t *testing.T) {
+		res, err := fakeAPI.CampaignLeadershipAlpha1(context.Background(), &runtimev1pb.CampaignLeadershipRequest{
+			Election:    "election",
+			CandidateId: "pod-1",
+		})
+		require.NoError(t, err)
+		assert.True(t, res.GetIsLeader())
+		assert.Equal(t, "pod-1", res.GetLeaderId())
+
+		res, err = fakeAPI.CampaignLeadershipAlpha1(context.Background(), &runtimev1pb.CampaignLeadershipRequest{
+			Election:    "election",
+			CandidateId: "pod-2",
+		})
+		require.NoError(t, err)
+		assert.False(t, res.GetIsLeader())
+		assert.Equal(t, "pod-1", res.GetLeaderId())
+	})
+
+	t.Run("leader resigns", func(t *testing.T) {
+		_, err := fakeAPI.ResignLeadershipAlpha1(context.Background(), &runtimev1pb.ResignLeadershipRequest{
+			Election:    "election",
+			CandidateId: "pod-1",
+		})
+		require.NoError(t, err)
+
+		res, err := fakeAPI.CampaignLeadershipAlpha1(context.Background(), &runtimev1pb.CampaignLeadershipRequest{
+			Election:    "election",
+			CandidateId: "pod-2",
+		})
+		require.NoError(t, err)
+		assert.True(t, res.GetIsLeader())
+	})
+
+	t.Run("election not found", func(t *testing.T) {
+		_, err := fakeAPI.CampaignLeadershipAlpha1(context.Background(), &runtimev1pb.CampaignLeadershipRequest{
+			Election:    "foo",
+			CandidateId: "pod-1",
+		})
+		require.ErrorIs(t, err, messages.ErrElectionNotFound)
+
+		_, err = fakeAPI.ResignLeadershipAlpha1(context.Background(), &runtimev1pb.ResignLeadershipRequest{
+			Election:    "foo",
+			CandidateId: "pod-1",
+		})
+		require.ErrorIs(t, err, messages.ErrElectionNotFound)
+	})
+
+	t.Run("empty candidate", func(t *testing.T) {
+		_, err := fakeAPI.CampaignLeadershipAlpha1(context.Background(), &runtimev1pb.CampaignLeadershipRequest{
+			Election: "election",
+		})
+		require.ErrorIs(t, err, messages.ErrLeaderElectionInvalid)
+	})
+
+	t.Run("state store not found", func(t *testing.T) {
+		_, err := fakeAPI.CampaignLeadershipAlpha1(context.Background(), &runtimev1pb.CampaignLeadershipRequest{
+			Election:    "missing",
+			CandidateId: "pod-1",
+		})
+		require.ErrorIs(t, err, messages.ErrLeaderElectionFailed)
+	})
+}
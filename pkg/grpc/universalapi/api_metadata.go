@@ -17,18 +17,23 @@ import (
 	"context"
 
 	"google.golang.org/protobuf/types/known/emptypb"
+	"google.golang.org/protobuf/types/known/timestamppb"
 
 	"github.com/dapr/dapr/pkg/buildinfo"
+	"github.com/dapr/dapr/pkg/components"
 	"github.com/dapr/dapr/pkg/config/protocol"
 	runtimev1pb "github.com/dapr/dapr/pkg/proto/runtime/v1"
 	runtimePubsub "github.com/dapr/dapr/pkg/runtime/pubsub"
 )
 
-const daprRuntimeVersionKey = "daprRuntimeVersion"
+const (
+	daprRuntimeVersionKey = "daprRuntimeVersion"
+	daprCryptoBackendKey  = "daprCryptoBackend"
+)
 
 func (a *UniversalAPI) GetMetadata(ctx context.Context, in *runtimev1pb.GetMetadataRequest) (*runtimev1pb.GetMetadataResponse, error) {
 	// Extended metadata
-	extendedMetadata := make(map[string]string, len(a.ExtendedMetadata)+1)
+	extendedMetadata := make(map[string]string, len(a.ExtendedMetadata)+2)
 	a.extendedMetadataLock.RLock()
 	for k, v := range a.ExtendedMetadata {
 		extendedMetadata[k] = v
@@ -37,6 +42,7 @@ func (a *UniversalAPI) GetMetadata(ctx context.Context, in *runtimev1pb.GetMetad
 
 	// This is deprecated, but we still need to support it for backward compatibility.
 	extendedMetadata[daprRuntimeVersionKey] = buildinfo.Version()
+	extendedMetadata[daprCryptoBackendKey] = buildinfo.CryptoBackend()
 
 	// Actor runtime
 	var actorRuntime *runtimev1pb.ActorRuntime
@@ -73,16 +79,23 @@ func (a *UniversalAPI) GetMetadata(ctx context.Context, in *runtimev1pb.GetMetad
 	}
 
 	// Components
-	components := a.CompStore.ListComponents()
-	registeredComponents := make([]*runtimev1pb.RegisteredComponents, len(components))
+	comps := a.CompStore.ListComponents()
+	registeredComponents := make([]*runtimev1pb.RegisteredComponents, len(comps))
 	componentsCapabilities := a.GetComponentsCapabilitiesFn()
-	for i, comp := range components {
+	var componentsStats map[string]components.ComponentStats
+	if a.GetComponentsStatsFn != nil {
+		componentsStats = a.GetComponentsStatsFn()
+	}
+	for i, comp := range comps {
 		registeredComponents[i] = &runtimev1pb.RegisteredComponents{
 			Name:         comp.Name,
 			Version:      comp.Spec.Version,
 			Type:         comp.Spec.Type,
 			Capabilities: metadataGetOrDefaultCapabilities(componentsCapabilities, comp.Name),
 		}
+		if stats, ok := componentsStats[comp.Name]; ok {
+			metadataSetComponentStats(registeredComponents[i], stats)
+		}
 	}
 
 	// Subscriptions
@@ -145,6 +158,16 @@ func metadataGetOrDefaultCapabilities(dict map[string][]string, key string) []st
 	return make([]string, 0)
 }
 
+func metadataSetComponentStats(comp *runtimev1pb.RegisteredComponents, stats components.ComponentStats) {
+	comp.OpenConnections = stats.OpenConnections
+	if !stats.LastSuccessTime.IsZero() {
+		comp.LastSuccessTime = timestamppb.New(stats.LastSuccessTime)
+	}
+	if stats.LastError != nil {
+		comp.LastError = stats.LastError.Error()
+	}
+}
+
 func metadataConvertPubSubSubscriptionRules(rules []*runtimePubsub.Rule) *runtimev1pb.PubsubSubscriptionRules {
 	out := &runtimev1pb.PubsubSubscriptionRules{
 		Rules: make([]*runtimev1pb.PubsubSubscriptionRule, len(rules)),
@@ -16,6 +16,7 @@ package universalapi
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"testing"
 	"time"
 
@@ -24,6 +25,7 @@ import (
 
 	"github.com/dapr/dapr/pkg/actors"
 	componentsV1alpha "github.com/dapr/dapr/pkg/apis/components/v1alpha1"
+	"github.com/dapr/dapr/pkg/components"
 	"github.com/dapr/dapr/pkg/config"
 	"github.com/dapr/dapr/pkg/expr"
 	runtimev1pb "github.com/dapr/dapr/pkg/proto/runtime/v1"
@@ -120,7 +122,7 @@ func TestGetMetadata(t *testing.T) {
 			expectedResponse := `{"id":"fakeAPI",` +
 				`"active_actors_count":[{"type":"abcd","count":10},{"type":"xyz","count":5}],` +
 				`"registered_components":[{"name":"testComponent","capabilities":["mock.feat.testComponent"]}],` +
-				`"extended_metadata":{"daprRuntimeVersion":"edge","testKey":"testValue"},` +
+				`"extended_metadata":{"daprCryptoBackend":"go","daprRuntimeVersion":"edge","testKey":"testValue"},` +
 				`"subscriptions":[{"pubsub_name":"test","topic":"topic","rules":{"rules":[{"path":"path"}]},"dead_letter_topic":"dead"}],` +
 				`"app_connection_properties":{"port":1234,"protocol":"http","channel_address":"1.2.3.4","max_concurrency":10` +
 				healthCheckJSON +
@@ -130,6 +132,55 @@ func TestGetMetadata(t *testing.T) {
 	}
 }
 
+func TestGetMetadataComponentStats(t *testing.T) {
+	compStore := compstore.New()
+	for _, name := range []string{"withStats", "withoutStats"} {
+		comp := componentsV1alpha.Component{}
+		comp.Name = name
+		require.NoError(t, compStore.AddPendingComponentForCommit(comp))
+		require.NoError(t, compStore.CommitPendingComponent())
+	}
+
+	lastSuccess := time.Date(2023, 7, 1, 12, 0, 0, 0, time.UTC)
+	fakeAPI := &UniversalAPI{
+		AppID:     "fakeAPI",
+		CompStore: compStore,
+		GetComponentsCapabilitiesFn: func() map[string][]string {
+			return map[string][]string{}
+		},
+		GetComponentsStatsFn: func() map[string]components.ComponentStats {
+			return map[string]components.ComponentStats{
+				"withStats": {
+					OpenConnections: 4,
+					LastSuccessTime: lastSuccess,
+					LastError:       errors.New("connection reset"),
+				},
+			}
+		},
+		GlobalConfig: &config.Configuration{},
+	}
+	fakeAPI.actorsReady.Store(true)
+
+	response, err := fakeAPI.GetMetadata(context.Background(), &runtimev1pb.GetMetadataRequest{})
+	require.NoError(t, err)
+	require.Len(t, response.GetRegisteredComponents(), 2)
+
+	for _, comp := range response.GetRegisteredComponents() {
+		switch comp.GetName() {
+		case "withStats":
+			assert.Equal(t, int64(4), comp.GetOpenConnections())
+			assert.Equal(t, lastSuccess, comp.GetLastSuccessTime().AsTime())
+			assert.Equal(t, "connection reset", comp.GetLastError())
+		case "withoutStats":
+			assert.Zero(t, comp.GetOpenConnections())
+			assert.Nil(t, comp.GetLastSuccessTime())
+			assert.Empty(t, comp.GetLastError())
+		default:
+			t.Fatalf("unexpected component %s", comp.GetName())
+		}
+	}
+}
+
 func TestSetMetadata(t *testing.T) {
 	fakeComponent := componentsV1alpha.Component{}
 	fakeComponent.Name = "testComponent"
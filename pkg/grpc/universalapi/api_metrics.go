@@ -0,0 +1,74 @@
+/*
+Copyright 2023 The Dapr Authors
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package universalapi
+
+import (
+	"context"
+	"errors"
+
+	"google.golang.org/protobuf/types/known/emptypb"
+
+	diag "github.com/dapr/dapr/pkg/diagnostics"
+	"github.com/dapr/dapr/pkg/messages"
+	runtimev1pb "github.com/dapr/dapr/pkg/proto/runtime/v1"
+)
+
+// RecordMetricsAlpha1 records measurements of custom metrics of the app, which are exposed on the metrics endpoint of the sidecar.
+func (a *UniversalAPI) RecordMetricsAlpha1(ctx context.Context, in *runtimev1pb.RecordMetricsRequest) (*emptypb.Empty, error) {
+	if a.AppMetrics == nil || !a.AppMetrics.IsEnabled() {
+		err := messages.ErrMetricsNotEnabled
+		a.Logger.Debug(err)
+		return nil, err
+	}
+
+	if len(in.GetMeasurements()) == 0 {
+		err := messages.ErrMetricsInvalid.WithFormat("no measurements were provided")
+		a.Logger.Debug(err)
+		return nil, err
+	}
+
+	measurements := make([]diag.AppMeasurement, len(in.GetMeasurements()))
+	for i, m := range in.GetMeasurements() {
+		measurements[i] = diag.AppMeasurement{
+			Name:        m.GetName(),
+			Value:       m.GetValue(),
+			Labels:      m.GetLabels(),
+			Description: m.GetDescription(),
+			Buckets:     m.GetBuckets(),
+		}
+		switch m.GetType() {
+		case runtimev1pb.MetricMeasurement_COUNTER:
+			measurements[i].Type = diag.AppMetricCounter
+		case runtimev1pb.MetricMeasurement_HISTOGRAM:
+			measurements[i].Type = diag.AppMetricHistogram
+		default:
+			err := messages.ErrMetricsInvalid.WithFormat("invalid type for metric '" + m.GetName() + "'")
+			a.Logger.Debug(err)
+			return nil, err
+		}
+	}
+
+	err := a.AppMetrics.Record(ctx, measurements)
+	if err != nil {
+		if errors.Is(err, diag.ErrAppMetricsLimit) {
+			err = messages.ErrMetricsLimitExceeded.WithFormat(err)
+		} else {
+			err = messages.ErrMetricsInvalid.WithFormat(err)
+		}
+		a.Logger.Debug(err)
+		return nil, err
+	}
+
+	return &emptypb.Empty{}, nil
+}
@@ -0,0 +1,106 @@
+/*
+Copyright 2023 The Dapr Authors
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package universalapi
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	diag "github.com/dapr/dapr/pkg/diagnostics"
+	"github.com/dapr/dapr/pkg/messages"
+	runtimev1pb "github.com/dapr/dapr/pkg/proto/runtime/v1"
+)
+
+type fakeAppMetrics struct {
+	enabled      bool
+	err          error
+	measurements []diag.AppMeasurement
+}
+
+func (f *fakeAppMetrics) IsEnabled() bool {
+	return f.enabled
+}
+
+func (f *fakeAppMetrics) Record(ctx context.Context, measurements []diag.AppMeasurement) error {
+	f.measurements = append(f.measurements, measurements...)
+	return f.err
+}
+
+func TestRecordMetrics(t *testing.T) {
+	req := &runtimev1pb.RecordMetricsRequest{
+		Measurements: []*runtimev1pb.MetricMeasurement{
+			{Name: "orders_total", Value: 1, Labels: map[string]string{"status": "ok"}},
+			{Name: "order_size", Type: runtimev1pb.MetricMeasurement_HISTOGRAM, Value: 7, Buckets: []float64{5, 10}},
+		},
+	}
+
+	t.Run("metrics not enabled", func(t *testing.T) {
+		fakeAPI := &UniversalAPI{
+			Logger:     testLogger,
+			AppMetrics: &fakeAppMetrics{},
+		}
+
+		_, err := fakeAPI.RecordMetricsAlpha1(context.Background(), req)
+		require.ErrorIs(t, err, messages.ErrMetricsNotEnabled)
+	})
+
+	t.Run("no measurements", func(t *testing.T) {
+		fakeAPI := &UniversalAPI{
+			Logger:     testLogger,
+			AppMetrics: &fakeAppMetrics{enabled: true},
+		}
+
+		_, err := fakeAPI.RecordMetricsAlpha1(context.Background(), &runtimev1pb.RecordMetricsRequest{})
+		require.ErrorIs(t, err, messages.ErrMetricsInvalid)
+	})
+
+	t.Run("record successfully", func(t *testing.T) {
+		recorder := &fakeAppMetrics{enabled: true}
+		fakeAPI := &UniversalAPI{
+			Logger:     testLogger,
+			AppMetrics: recorder,
+		}
+
+		_, err := fakeAPI.RecordMetricsAlpha1(context.Background(), req)
+		require.NoError(t, err)
+		assert.Equal(t, []diag.AppMeasurement{
+			{Name: "orders_total", Type: diag.AppMetricCounter, Value: 1, Labels: map[string]string{"status": "ok"}},
+			{Name: "order_size", Type: diag.AppMetricHistogram, Value: 7, Buckets: []float64{5, 10}},
+		}, recorder.measurements)
+	})
+
+	t.Run("invalid measurement", func(t *testing.T) {
+		fakeAPI := &UniversalAPI{
+			Logger:     testLogger,
+			AppMetrics: &fakeAppMetrics{enabled: true, err: fmt.Errorf("invalid metric name")},
+		}
+
+		_, err := fakeAPI.RecordMetricsAlpha1(context.Background(), req)
+		require.ErrorIs(t, err, messages.ErrMetricsInvalid)
+	})
+
+	t.Run("limit exceeded", func(t *testing.T) {
+		fakeAPI := &UniversalAPI{
+			Logger:     testLogger,
+			AppMetrics: &fakeAppMetrics{enabled: true, err: fmt.Errorf("%w: too many metrics", diag.ErrAppMetricsLimit)},
+		}
+
+		_, err := fakeAPI.RecordMetricsAlpha1(context.Background(), req)
+		require.ErrorIs(t, err, messages.ErrMetricsLimitExceeded)
+	})
+}
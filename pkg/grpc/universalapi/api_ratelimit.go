@@ -0,0 +1,60 @@
+/*
+Copyright 2023 The Dapr Authors
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package universalapi
+
+import (
+	"context"
+	"errors"
+
+	"github.com/dapr/dapr/pkg/messages"
+	runtimev1pb "github.com/dapr/dapr/pkg/proto/runtime/v1"
+	"github.com/dapr/dapr/pkg/ratelimit"
+)
+
+// CheckRateLimitAlpha1 consumes units of a distributed rate limiter, whose limit is shared by all the replicas of the app.
+func (a *UniversalAPI) CheckRateLimitAlpha1(ctx context.Context, in *runtimev1pb.CheckRateLimitRequest) (*runtimev1pb.CheckRateLimitResponse, error) {
+	limiter, ok := a.RateLimiters[in.GetName()]
+	if !ok {
+		err := messages.ErrRateLimiterNotFound.WithFormat(in.GetName())
+		a.Logger.Debug(err)
+		return nil, err
+	}
+
+	if in.GetKey() == "" {
+		err := messages.ErrRateLimitInvalid.WithFormat("key is empty")
+		a.Logger.Debug(err)
+		return nil, err
+	}
+	units := in.GetUnits()
+	if units == 0 {
+		units = 1
+	}
+
+	res, err := limiter.Allow(ctx, in.GetKey(), units)
+	if err != nil {
+		if errors.Is(err, ratelimit.ErrInvalidUnits) {
+			err = messages.ErrRateLimitInvalid.WithFormat(err)
+		} else {
+			err = messages.ErrRateLimitCheckFailed.WithFormat(in.GetName(), err)
+		}
+		a.Logger.Debug(err)
+		return nil, err
+	}
+
+	return &runtimev1pb.CheckRateLimitResponse{
+		Allowed:      res.Allowed,
+		Remaining:    res.Remaining,
+		ResetAfterMs: res.ResetAfter.Milliseconds(),
+	}, nil
+}
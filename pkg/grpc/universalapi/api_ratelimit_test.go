@@ -0,0 +1,115 @@
+/*
+Copyright 2023 The Dapr Authors
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package universalapi
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/dapr/components-contrib/state"
+	"github.com/dapr/dapr/pkg/config"
+	"github.com/dapr/dapr/pkg/messages"
+	runtimev1pb "github.com/dapr/dapr/pkg/proto/runtime/v1"
+	"github.com/dapr/dapr/pkg/ratelimit"
+	daprt "github.com/dapr/dapr/pkg/testing"
+)
+
+// ttlFakeStateStore is a fake state store that supports TTLs, as required by the rate limiters.
+type ttlFakeStateStore struct {
+	*daprt.FakeStateStore
+}
+
+func (s ttlFakeStateStore) Features() []state.Feature {
+	return append(s.FakeStateStore.Features(), state.FeatureTTL)
+}
+
+func TestCheckRateLimit(t *testing.T) {
+	store := ttlFakeStateStore{daprt.NewFakeStateStore()}
+	limiters, err := ratelimit.New(ratelimit.Options{
+		AppID: "fakeAPI",
+		Specs: []config.RateLimiterSpec{
+			{Name: "orders", StateStore: "store", Limit: 3, Window: "24h"},
+			{Name: "missing", StateStore: "other", Limit: 3},
+		},
+		GetStateStoreFn: func(name string) (state.Store, bool) {
+			if name != "store" {
+				return nil, false
+			}
+			return store, true
+		},
+	})
+	require.NoError(t, err)
+
+	fakeAPI := &UniversalAPI{
+		Logger:       testLogger,
+		RateLimiters: limiters,
+	}
+
+	t.Run("units default to 1", func(t *testing.T) {
+		res, err := fakeAPI.CheckRateLimitAlpha1(context.Background(), &runtimev1pb.CheckRateLimitRequest{
+			Name: "orders",
+			Key:  "customer-1",
+		})
+		require.NoError(t, err)
+		assert.True(t, res.GetAllowed())
+		assert.Equal(t, int64(2), res.GetRemaining())
+		assert.Positive(t, res.GetResetAfterMs())
+	})
+
+	t.Run("units over the limit are rejected", func(t *testing.T) {
+		res, err := fakeAPI.CheckRateLimitAlpha1(context.Background(), &runtimev1pb.CheckRateLimitRequest{
+			Name:  "orders",
+			Key:   "customer-1",
+			Units: 3,
+		})
+		require.NoError(t, err)
+		assert.False(t, res.GetAllowed())
+		assert.Equal(t, int64(2), res.GetRemaining())
+	})
+
+	t.Run("rate limiter not found", func(t *testing.T) {
+		_, err := fakeAPI.CheckRateLimitAlpha1(context.Background(), &runtimev1pb.CheckRateLimitRequest{
+			Name: "foo",
+			Key:  "customer-1",
+		})
+		require.ErrorIs(t, err, messages.ErrRateLimiterNotFound)
+	})
+
+	t.Run("empty key", func(t *testing.T) {
+		_, err := fakeAPI.CheckRateLimitAlpha1(context.Background(), &runtimev1pb.CheckRateLimitRequest{
+			Name: "orders",
+		})
+		require.ErrorIs(t, err, messages.ErrRateLimitInvalid)
+	})
+
+	t.Run("negative units", func(t *testing.T) {
+		_, err := fakeAPI.CheckRateLimitAlpha1(context.Background(), &runtimev1pb.CheckRateLimitRequest{
+			Name:  "orders",
+			Key:   "customer-1",
+			Units: -1,
+		})
+		require.ErrorIs(t, err, messages.ErrRateLimitInvalid)
+	})
+
+	t.Run("state store not found", func(t *testing.T) {
+		_, err := fakeAPI.CheckRateLimitAlpha1(context.Background(), &runtimev1pb.CheckRateLimitRequest{
+			Name: "missing",
+			Key:  "customer-1",
+		})
+		require.ErrorIs(t, err, messages.ErrRateLimitCheckFailed)
+	})
+}
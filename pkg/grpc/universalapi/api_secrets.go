@@ -54,6 +54,7 @@ func (a *UniversalAPI) GetSecret(ctx context.Context, in *runtimev1pb.GetSecretR
 	elapsed := diag.ElapsedSince(start)
 
 	diag.DefaultComponentMonitoring.SecretInvoked(ctx, in.GetStoreName(), diag.Get, err == nil, elapsed)
+	diag.DefaultComponentMonitoring.OperationFailed(ctx, in.GetStoreName(), diag.Get, err)
 
 	if err != nil {
 		err = messages.ErrSecretGet.WithFormat(req.Name, in.GetStoreName(), err.Error())
@@ -92,6 +93,7 @@ func (a *UniversalAPI) GetBulkSecret(ctx context.Context, in *runtimev1pb.GetBul
 	elapsed := diag.ElapsedSince(start)
 
 	diag.DefaultComponentMonitoring.SecretInvoked(ctx, in.GetStoreName(), diag.BulkGet, err == nil, elapsed)
+	diag.DefaultComponentMonitoring.OperationFailed(ctx, in.GetStoreName(), diag.BulkGet, err)
 
 	if err != nil {
 		err = messages.ErrBulkSecretGet.WithFormat(in.GetStoreName(), err.Error())
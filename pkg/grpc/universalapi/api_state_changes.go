@@ -0,0 +1,134 @@
+/*
+Copyright 2023 The Dapr Authors
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package universalapi
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	stateLoader "github.com/dapr/dapr/pkg/components/state"
+	"github.com/dapr/dapr/pkg/encryption"
+	"github.com/dapr/dapr/pkg/messages"
+	runtimev1pb "github.com/dapr/dapr/pkg/proto/runtime/v1"
+)
+
+const (
+	stateChangeEventTypePrefix  = "com.dapr.state."
+	stateChangeEventSpecVersion = "1.0"
+	stateChangeEventContentType = "application/json"
+)
+
+// StateChangesSubscriber subscribes to the change feed of a state store, and invokes the handler with the CloudEvent of each change until the context is canceled.
+type StateChangesSubscriber func(ctx context.Context, handler func(event *runtimev1pb.StateChangeEvent) error) error
+
+// SubscribeStateChanges validates the request to subscribe to the changes of the keys of the app in a state store, and returns the function that subscribes to them.
+// Invalid requests are reported before subscribing, so the HTTP API can respond before delivering the changes to the app.
+func (a *UniversalAPI) SubscribeStateChanges(in *runtimev1pb.SubscribeStateChangesRequest) (StateChangesSubscriber, error) {
+	storeName := in.GetStoreName()
+	store, err := a.GetStateStore(storeName)
+	if err != nil {
+		// Error has already been logged
+		return nil, err
+	}
+
+	feeder, ok := stateLoader.GetChangeFeeder(store)
+	if !ok {
+		err = messages.ErrStateChangeFeedUnsupported.WithFormat(storeName)
+		a.Logger.Debug(err)
+		return nil, err
+	}
+	if !stateLoader.IsChangeFeedEnabled(storeName) {
+		err = messages.ErrStateChangeFeedNotEnabled.WithFormat(storeName)
+		a.Logger.Debug(err)
+		return nil, err
+	}
+
+	keyPrefix, err := stateLoader.GetModifiedStateKey(in.GetKeyPrefix(), storeName, a.AppID)
+	if err != nil {
+		err = messages.ErrBadRequest.WithFormat(err)
+		a.Logger.Debug(err)
+		return nil, err
+	}
+
+	req := stateLoader.ChangeFeedRequest{
+		KeyPrefix: keyPrefix,
+		Metadata:  in.GetMetadata(),
+	}
+	return func(ctx context.Context, handler func(event *runtimev1pb.StateChangeEvent) error) error {
+		err := feeder.SubscribeChanges(ctx, req, func(ctx context.Context, change *stateLoader.Change) error {
+			// The state store may report the changes of keys of other apps that share the prefix
+			key, ok := stateLoader.GetOriginalChangeKey(change.Key, storeName, a.AppID)
+			if !ok || !strings.HasPrefix(change.Key, keyPrefix) {
+				return nil
+			}
+
+			event, err := newStateChangeEvent(storeName, key, change)
+			if err != nil {
+				return err
+			}
+			return handler(event)
+		})
+		if err != nil && ctx.Err() == nil {
+			err = messages.ErrStateChangeFeed.WithFormat(storeName, err)
+			a.Logger.Debug(err)
+			return err
+		}
+		return nil
+	}, nil
+}
+
+// newStateChangeEvent returns the CloudEvent of a change of the key of the app in the state store.
+func newStateChangeEvent(storeName, key string, change *stateLoader.Change) (*runtimev1pb.StateChangeEvent, error) {
+	event := &runtimev1pb.StateChangeEvent{
+		Id:          change.ID,
+		Source:      storeName,
+		Type:        stateChangeEventTypePrefix + string(change.Type),
+		SpecVersion: stateChangeEventSpecVersion,
+		Subject:     key,
+		Metadata:    change.Metadata,
+	}
+	if event.GetId() == "" {
+		event.Id = uuid.NewString()
+	}
+	if change.Time.IsZero() {
+		event.Time = timestamppb.New(time.Now())
+	} else {
+		event.Time = timestamppb.New(change.Time)
+	}
+	if change.ETag != nil {
+		event.Etag = *change.ETag
+	}
+
+	if change.Type != stateLoader.ChangeDelete && len(change.Value) > 0 {
+		event.Data = change.Value
+		if encryption.EncryptedStateStore(storeName) {
+			val, err := encryption.TryDecryptValue(storeName, change.Value)
+			if err != nil {
+				return nil, err
+			}
+			event.Data = val
+		}
+
+		event.DataContentType = stateChangeEventContentType
+		if change.ContentType != nil {
+			event.DataContentType = *change.ContentType
+		}
+	}
+
+	return event, nil
+}
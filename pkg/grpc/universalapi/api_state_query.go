@@ -83,6 +83,7 @@ func (a *UniversalAPI) QueryStateAlpha1(ctx context.Context, in *runtimev1pb.Que
 	elapsed := diag.ElapsedSince(start)
 
 	diag.DefaultComponentMonitoring.StateInvoked(ctx, in.GetStoreName(), diag.StateQuery, err == nil, elapsed)
+	diag.DefaultComponentMonitoring.OperationFailed(ctx, in.GetStoreName(), diag.StateQuery, err)
 
 	if err != nil {
 		err = messages.ErrStateQueryFailed.WithFormat(in.GetStoreName(), err.Error())
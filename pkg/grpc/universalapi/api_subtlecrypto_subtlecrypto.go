@@ -62,6 +62,7 @@ func (a *UniversalAPI) SubtleGetKeyAlpha1(ctx context.Context, in *runtimev1pb.S
 	elapsed := diag.ElapsedSince(start)
 
 	diag.DefaultComponentMonitoring.CryptoInvoked(ctx, in.ComponentName, diag.Get, err == nil, elapsed)
+	diag.DefaultComponentMonitoring.OperationFailed(ctx, in.ComponentName, diag.Get, err)
 
 	if err != nil {
 		err = messages.ErrCryptoGetKey.WithFormat(in.Name, err)
@@ -145,6 +146,7 @@ func (a *UniversalAPI) SubtleEncryptAlpha1(ctx context.Context, in *runtimev1pb.
 	elapsed := diag.ElapsedSince(start)
 
 	diag.DefaultComponentMonitoring.CryptoInvoked(ctx, in.ComponentName, diag.CryptoOp, err == nil, elapsed)
+	diag.DefaultComponentMonitoring.OperationFailed(ctx, in.ComponentName, diag.CryptoOp, err)
 
 	if err != nil {
 		// We are not going to return the exact error from the component to the user, because an error that is too specific could allow for various side channel attacks (e.g. AES-CBC and padding oracle attacks)
@@ -177,6 +179,7 @@ func (a *UniversalAPI) SubtleDecryptAlpha1(ctx context.Context, in *runtimev1pb.
 	elapsed := diag.ElapsedSince(start)
 
 	diag.DefaultComponentMonitoring.CryptoInvoked(ctx, in.ComponentName, diag.CryptoOp, err == nil, elapsed)
+	diag.DefaultComponentMonitoring.OperationFailed(ctx, in.ComponentName, diag.CryptoOp, err)
 
 	if err != nil {
 		// We are not going to return the exact error from the component to the user, because an error that is too specific could allow for various side channel attacks (e.g. AES-CBC and padding oracle attacks)
@@ -219,6 +222,7 @@ func (a *UniversalAPI) SubtleWrapKeyAlpha1(ctx context.Context, in *runtimev1pb.
 	elapsed := diag.ElapsedSince(start)
 
 	diag.DefaultComponentMonitoring.CryptoInvoked(ctx, in.ComponentName, diag.CryptoOp, err == nil, elapsed)
+	diag.DefaultComponentMonitoring.OperationFailed(ctx, in.ComponentName, diag.CryptoOp, err)
 
 	if err != nil {
 		// We are not going to return the exact error from the component to the user, because an error that is too specific could allow for various side channel attacks (e.g. AES-CBC and padding oracle attacks)
@@ -251,6 +255,7 @@ func (a *UniversalAPI) SubtleUnwrapKeyAlpha1(ctx context.Context, in *runtimev1p
 	elapsed := diag.ElapsedSince(start)
 
 	diag.DefaultComponentMonitoring.CryptoInvoked(ctx, in.ComponentName, diag.CryptoOp, err == nil, elapsed)
+	diag.DefaultComponentMonitoring.OperationFailed(ctx, in.ComponentName, diag.CryptoOp, err)
 
 	if err != nil {
 		// We are not going to return the exact error from the component to the user, because an error that is too specific could allow for various side channel attacks (e.g. AES-CBC and padding oracle attacks)
@@ -292,6 +297,7 @@ func (a *UniversalAPI) SubtleSignAlpha1(ctx context.Context, in *runtimev1pb.Sub
 	elapsed := diag.ElapsedSince(start)
 
 	diag.DefaultComponentMonitoring.CryptoInvoked(ctx, in.ComponentName, diag.CryptoOp, err == nil, elapsed)
+	diag.DefaultComponentMonitoring.OperationFailed(ctx, in.ComponentName, diag.CryptoOp, err)
 
 	if err != nil {
 		// We are not going to return the exact error from the component to the user, because an error that is too specific could allow for various side channel attacks (e.g. AES-CBC and padding oracle attacks)
@@ -323,6 +329,7 @@ func (a *UniversalAPI) SubtleVerifyAlpha1(ctx context.Context, in *runtimev1pb.S
 	elapsed := diag.ElapsedSince(start)
 
 	diag.DefaultComponentMonitoring.CryptoInvoked(ctx, in.ComponentName, diag.CryptoOp, err == nil, elapsed)
+	diag.DefaultComponentMonitoring.OperationFailed(ctx, in.ComponentName, diag.CryptoOp, err)
 
 	if err != nil {
 		// We are not going to return the exact error from the component to the user, because an error that is too specific could allow for various side channel attacks (e.g. AES-CBC and padding oracle attacks)
@@ -0,0 +1,102 @@
+/*
+Copyright 2023 The Dapr Authors
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package universalapi
+
+import (
+	"context"
+
+	"go.opentelemetry.io/otel/codes"
+
+	diag "github.com/dapr/dapr/pkg/diagnostics"
+	diagUtils "github.com/dapr/dapr/pkg/diagnostics/utils"
+	"github.com/dapr/dapr/pkg/messages"
+	runtimev1pb "github.com/dapr/dapr/pkg/proto/runtime/v1"
+)
+
+// RecordSpansAlpha1 records spans of the app, and events of the trace of the request, which are exported with the tracing configuration of the sidecar.
+func (a *UniversalAPI) RecordSpansAlpha1(ctx context.Context, in *runtimev1pb.RecordSpansRequest) (*runtimev1pb.RecordSpansResponse, error) {
+	if a.GlobalConfig == nil || !diagUtils.IsTracingEnabled(a.GlobalConfig.GetTracingSpec().SamplingRate) {
+		err := messages.ErrTracingNotEnabled
+		a.Logger.Debug(err)
+		return nil, err
+	}
+
+	if len(in.GetSpans()) == 0 && len(in.GetEvents()) == 0 {
+		err := messages.ErrTracingInvalid.WithFormat("no spans or events were provided")
+		a.Logger.Debug(err)
+		return nil, err
+	}
+
+	spans := make([]diag.AppSpan, len(in.GetSpans()))
+	for i, s := range in.GetSpans() {
+		spans[i] = diag.AppSpan{
+			Name:          s.GetName(),
+			Traceparent:   s.GetTraceparent(),
+			Tracestate:    s.GetTracestate(),
+			Attributes:    s.GetAttributes(),
+			Events:        appSpanEventsFromProto(s.GetEvents()),
+			StatusMessage: s.GetStatusMessage(),
+		}
+		if s.GetStartTime() != nil {
+			spans[i].StartTime = s.GetStartTime().AsTime()
+		}
+		if s.GetEndTime() != nil {
+			spans[i].EndTime = s.GetEndTime().AsTime()
+		}
+		switch s.GetStatus() {
+		case runtimev1pb.AppSpan_UNSET:
+			spans[i].Status = codes.Unset
+		case runtimev1pb.AppSpan_OK:
+			spans[i].Status = codes.Ok
+		case runtimev1pb.AppSpan_ERROR:
+			spans[i].Status = codes.Error
+		default:
+			err := messages.ErrTracingInvalid.WithFormat("invalid status for span '" + s.GetName() + "'")
+			a.Logger.Debug(err)
+			return nil, err
+		}
+	}
+
+	scs, err := diag.RecordAppSpans(ctx, spans, appSpanEventsFromProto(in.GetEvents()))
+	if err != nil {
+		err = messages.ErrTracingInvalid.WithFormat(err)
+		a.Logger.Debug(err)
+		return nil, err
+	}
+
+	res := &runtimev1pb.RecordSpansResponse{
+		Traceparents: make([]string, len(scs)),
+	}
+	for i, sc := range scs {
+		res.Traceparents[i] = diag.SpanContextToW3CString(sc)
+	}
+	return res, nil
+}
+
+func appSpanEventsFromProto(in []*runtimev1pb.AppSpanEvent) []diag.AppSpanEvent {
+	if len(in) == 0 {
+		return nil
+	}
+	events := make([]diag.AppSpanEvent, len(in))
+	for i, e := range in {
+		events[i] = diag.AppSpanEvent{
+			Name:       e.GetName(),
+			Attributes: e.GetAttributes(),
+		}
+		if e.GetTime() != nil {
+			events[i].Time = e.GetTime().AsTime()
+		}
+	}
+	return events
+}
@@ -0,0 +1,92 @@
+/*
+Copyright 2023 The Dapr Authors
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package universalapi
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/dapr/dapr/pkg/config"
+	"github.com/dapr/dapr/pkg/messages"
+	runtimev1pb "github.com/dapr/dapr/pkg/proto/runtime/v1"
+)
+
+func TestRecordSpans(t *testing.T) {
+	tracingConfig := func(rate string) *config.Configuration {
+		return &config.Configuration{
+			Spec: config.ConfigurationSpec{
+				TracingSpec: &config.TracingSpec{SamplingRate: rate},
+			},
+		}
+	}
+
+	t.Run("tracing not enabled", func(t *testing.T) {
+		fakeAPI := &UniversalAPI{
+			Logger:       testLogger,
+			GlobalConfig: tracingConfig("0"),
+		}
+
+		_, err := fakeAPI.RecordSpansAlpha1(context.Background(), &runtimev1pb.RecordSpansRequest{
+			Spans: []*runtimev1pb.AppSpan{{Name: "work"}},
+		})
+		require.ErrorIs(t, err, messages.ErrTracingNotEnabled)
+	})
+
+	t.Run("no spans or events", func(t *testing.T) {
+		fakeAPI := &UniversalAPI{
+			Logger:       testLogger,
+			GlobalConfig: tracingConfig("1"),
+		}
+
+		_, err := fakeAPI.RecordSpansAlpha1(context.Background(), &runtimev1pb.RecordSpansRequest{})
+		require.ErrorIs(t, err, messages.ErrTracingInvalid)
+	})
+
+	t.Run("invalid span", func(t *testing.T) {
+		fakeAPI := &UniversalAPI{
+			Logger:       testLogger,
+			GlobalConfig: tracingConfig("1"),
+		}
+
+		_, err := fakeAPI.RecordSpansAlpha1(context.Background(), &runtimev1pb.RecordSpansRequest{
+			Spans: []*runtimev1pb.AppSpan{{Name: "work", Traceparent: "invalid"}},
+		})
+		require.ErrorIs(t, err, messages.ErrTracingInvalid)
+
+		_, err = fakeAPI.RecordSpansAlpha1(context.Background(), &runtimev1pb.RecordSpansRequest{
+			Spans: []*runtimev1pb.AppSpan{{Name: "work", Status: runtimev1pb.AppSpan_StatusCode(9)}},
+		})
+		require.ErrorIs(t, err, messages.ErrTracingInvalid)
+	})
+
+	t.Run("record successfully", func(t *testing.T) {
+		fakeAPI := &UniversalAPI{
+			Logger:       testLogger,
+			GlobalConfig: tracingConfig("1"),
+		}
+
+		res, err := fakeAPI.RecordSpansAlpha1(context.Background(), &runtimev1pb.RecordSpansRequest{
+			Spans: []*runtimev1pb.AppSpan{
+				{Name: "work", Traceparent: "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01"},
+			},
+			Events: []*runtimev1pb.AppSpanEvent{{Name: "started"}},
+		})
+		require.NoError(t, err)
+		require.Len(t, res.GetTraceparents(), 1)
+		assert.Contains(t, res.GetTraceparents()[0], "4bf92f3577b34da6a3ce929d0e0e4736")
+	})
+}
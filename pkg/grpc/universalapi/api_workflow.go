@@ -15,6 +15,7 @@ package universalapi
 
 import (
 	"context"
+	"encoding/json"
 	"errors"
 	"unicode"
 
@@ -23,12 +24,30 @@ import (
 	"google.golang.org/protobuf/types/known/timestamppb"
 
 	"github.com/dapr/components-contrib/workflows"
+	diag "github.com/dapr/dapr/pkg/diagnostics"
 	"github.com/dapr/dapr/pkg/messages"
 	runtimev1pb "github.com/dapr/dapr/pkg/proto/runtime/v1"
+	"github.com/dapr/dapr/pkg/runtime/wfengine"
 )
 
+// workflowLister is implemented by the workflow components that can list their instances, such as the built-in workflow engine.
+type workflowLister interface {
+	ListWorkflows(ctx context.Context, req *wfengine.ListWorkflowsRequest) (*wfengine.ListWorkflowsResponse, error)
+}
+
+// workflowHistorian is implemented by the workflow components that can export and replay the histories of their instances, such as the built-in workflow engine.
+type workflowHistorian interface {
+	ExportHistory(ctx context.Context, instanceID string) (*wfengine.WorkflowHistory, error)
+	ReplayHistory(ctx context.Context, history *wfengine.WorkflowHistory) (*wfengine.ReplayReport, error)
+}
+
 // GetWorkflowBeta1 is the API handler for getting workflow details
-func (a *UniversalAPI) GetWorkflowBeta1(ctx context.Context, in *runtimev1pb.GetWorkflowRequest) (*runtimev1pb.GetWorkflowResponse, error) {
+func (a *UniversalAPI) GetWorkflowBeta1(ctx context.Context, in *runtimev1pb.GetWorkflowRequest) (_ *runtimev1pb.GetWorkflowResponse, err error) {
+	timer := diag.StartTimer()
+	defer func() {
+		a.workflowOperationEvent(ctx, diag.GetWorkflow, "", timer, err)
+	}()
+
 	if err := a.validateInstanceID(in.GetInstanceId(), false /* isCreate */); err != nil {
 		a.Logger.Debug(err)
 		return &runtimev1pb.GetWorkflowResponse{}, err
@@ -69,7 +88,12 @@ func (a *UniversalAPI) GetWorkflowBeta1(ctx context.Context, in *runtimev1pb.Get
 }
 
 // StartWorkflowBeta1 is the API handler for starting a workflow
-func (a *UniversalAPI) StartWorkflowBeta1(ctx context.Context, in *runtimev1pb.StartWorkflowRequest) (*runtimev1pb.StartWorkflowResponse, error) {
+func (a *UniversalAPI) StartWorkflowBeta1(ctx context.Context, in *runtimev1pb.StartWorkflowRequest) (_ *runtimev1pb.StartWorkflowResponse, err error) {
+	timer := diag.StartTimer()
+	defer func() {
+		a.workflowOperationEvent(ctx, diag.CreateWorkflow, in.GetWorkflowName(), timer, err)
+	}()
+
 	if err := a.validateInstanceID(in.GetInstanceId(), true /* isCreate */); err != nil {
 		a.Logger.Debug(err)
 		return &runtimev1pb.StartWorkflowResponse{}, err
@@ -110,7 +134,12 @@ func (a *UniversalAPI) StartWorkflowBeta1(ctx context.Context, in *runtimev1pb.S
 }
 
 // TerminateWorkflowBeta1 is the API handler for terminating a workflow
-func (a *UniversalAPI) TerminateWorkflowBeta1(ctx context.Context, in *runtimev1pb.TerminateWorkflowRequest) (*emptypb.Empty, error) {
+func (a *UniversalAPI) TerminateWorkflowBeta1(ctx context.Context, in *runtimev1pb.TerminateWorkflowRequest) (_ *emptypb.Empty, err error) {
+	timer := diag.StartTimer()
+	defer func() {
+		a.workflowOperationEvent(ctx, diag.TerminateWorkflow, "", timer, err)
+	}()
+
 	emptyResponse := &emptypb.Empty{}
 	if err := a.validateInstanceID(in.GetInstanceId(), false /* isCreate */); err != nil {
 		a.Logger.Debug(err)
@@ -142,7 +171,12 @@ func (a *UniversalAPI) TerminateWorkflowBeta1(ctx context.Context, in *runtimev1
 }
 
 // RaiseEventWorkflowBeta1 is the API handler for raising an event to a workflow
-func (a *UniversalAPI) RaiseEventWorkflowBeta1(ctx context.Context, in *runtimev1pb.RaiseEventWorkflowRequest) (*emptypb.Empty, error) {
+func (a *UniversalAPI) RaiseEventWorkflowBeta1(ctx context.Context, in *runtimev1pb.RaiseEventWorkflowRequest) (_ *emptypb.Empty, err error) {
+	timer := diag.StartTimer()
+	defer func() {
+		a.workflowOperationEvent(ctx, diag.AddEvent, "", timer, err)
+	}()
+
 	emptyResponse := &emptypb.Empty{}
 	if err := a.validateInstanceID(in.GetInstanceId(), false /* isCreate */); err != nil {
 		a.Logger.Debug(err)
@@ -180,7 +214,12 @@ func (a *UniversalAPI) RaiseEventWorkflowBeta1(ctx context.Context, in *runtimev
 }
 
 // PauseWorkflowBeta1 is the API handler for pausing a workflow
-func (a *UniversalAPI) PauseWorkflowBeta1(ctx context.Context, in *runtimev1pb.PauseWorkflowRequest) (*emptypb.Empty, error) {
+func (a *UniversalAPI) PauseWorkflowBeta1(ctx context.Context, in *runtimev1pb.PauseWorkflowRequest) (_ *emptypb.Empty, err error) {
+	timer := diag.StartTimer()
+	defer func() {
+		a.workflowOperationEvent(ctx, diag.SuspendWorkflow, "", timer, err)
+	}()
+
 	emptyResponse := &emptypb.Empty{}
 	if err := a.validateInstanceID(in.GetInstanceId(), false /* isCreate */); err != nil {
 		a.Logger.Debug(err)
@@ -208,7 +247,12 @@ func (a *UniversalAPI) PauseWorkflowBeta1(ctx context.Context, in *runtimev1pb.P
 }
 
 // ResumeWorkflowBeta1 is the API handler for resuming a workflow
-func (a *UniversalAPI) ResumeWorkflowBeta1(ctx context.Context, in *runtimev1pb.ResumeWorkflowRequest) (*emptypb.Empty, error) {
+func (a *UniversalAPI) ResumeWorkflowBeta1(ctx context.Context, in *runtimev1pb.ResumeWorkflowRequest) (_ *emptypb.Empty, err error) {
+	timer := diag.StartTimer()
+	defer func() {
+		a.workflowOperationEvent(ctx, diag.ResumeWorkflow, "", timer, err)
+	}()
+
 	emptyResponse := &emptypb.Empty{}
 	if err := a.validateInstanceID(in.GetInstanceId(), false /* isCreate */); err != nil {
 		a.Logger.Debug(err)
@@ -236,7 +280,12 @@ func (a *UniversalAPI) ResumeWorkflowBeta1(ctx context.Context, in *runtimev1pb.
 }
 
 // PurgeWorkflowBeta1 is the API handler for purging a workflow
-func (a *UniversalAPI) PurgeWorkflowBeta1(ctx context.Context, in *runtimev1pb.PurgeWorkflowRequest) (*emptypb.Empty, error) {
+func (a *UniversalAPI) PurgeWorkflowBeta1(ctx context.Context, in *runtimev1pb.PurgeWorkflowRequest) (_ *emptypb.Empty, err error) {
+	timer := diag.StartTimer()
+	defer func() {
+		a.workflowOperationEvent(ctx, diag.PurgeWorkflow, "", timer, err)
+	}()
+
 	emptyResponse := &emptypb.Empty{}
 	if err := a.validateInstanceID(in.GetInstanceId(), false /* isCreate */); err != nil {
 		a.Logger.Debug(err)
@@ -269,6 +318,150 @@ func (a *UniversalAPI) PurgeWorkflowBeta1(ctx context.Context, in *runtimev1pb.P
 	return emptyResponse, nil
 }
 
+// ListWorkflowsAlpha1 is the API handler for listing the workflow instances
+func (a *UniversalAPI) ListWorkflowsAlpha1(ctx context.Context, in *runtimev1pb.ListWorkflowsRequest) (_ *runtimev1pb.ListWorkflowsResponse, err error) {
+	timer := diag.StartTimer()
+	defer func() {
+		a.workflowOperationEvent(ctx, diag.ListWorkflows, in.GetWorkflowName(), timer, err)
+	}()
+
+	// Workflow requires actors to be ready
+	a.WaitForActorsReady(ctx)
+
+	workflowComponent, err := a.getWorkflowComponent(in.GetWorkflowComponent())
+	if err != nil {
+		a.Logger.Debug(err)
+		return &runtimev1pb.ListWorkflowsResponse{}, err
+	}
+
+	lister, ok := workflowComponent.(workflowLister)
+	if !ok {
+		err = messages.ErrWorkflowListNotSupported.WithFormat(in.GetWorkflowComponent())
+		a.Logger.Debug(err)
+		return &runtimev1pb.ListWorkflowsResponse{}, err
+	}
+
+	req := &wfengine.ListWorkflowsRequest{
+		RuntimeStatuses:   in.GetRuntimeStatus(),
+		WorkflowName:      in.GetWorkflowName(),
+		PageSize:          int(in.GetPageSize()),
+		ContinuationToken: in.GetContinuationToken(),
+	}
+	if in.GetCreatedAfter() != nil {
+		req.CreatedAfter = in.GetCreatedAfter().AsTime()
+	}
+	if in.GetCreatedBefore() != nil {
+		req.CreatedBefore = in.GetCreatedBefore().AsTime()
+	}
+
+	resp, err := lister.ListWorkflows(ctx, req)
+	if err != nil {
+		if errors.Is(err, wfengine.ErrInvalidContinuationToken) {
+			err = messages.ErrListWorkflowsInvalidToken.WithFormat(in.GetContinuationToken())
+		} else {
+			err = messages.ErrListWorkflows.WithFormat(err)
+		}
+		a.Logger.Debug(err)
+		return &runtimev1pb.ListWorkflowsResponse{}, err
+	}
+
+	res := &runtimev1pb.ListWorkflowsResponse{
+		Instances:         make([]*runtimev1pb.WorkflowInstance, len(resp.Instances)),
+		ContinuationToken: resp.ContinuationToken,
+	}
+	for i, instance := range resp.Instances {
+		res.Instances[i] = &runtimev1pb.WorkflowInstance{
+			InstanceId:    instance.InstanceID,
+			WorkflowName:  instance.WorkflowName,
+			CreatedAt:     timestamppb.New(instance.CreatedAt),
+			LastUpdatedAt: timestamppb.New(instance.LastUpdatedAt),
+			RuntimeStatus: instance.RuntimeStatus,
+		}
+	}
+	return res, nil
+}
+
+// GetWorkflowHistoryAlpha1 is the API handler for exporting the history of a workflow instance
+func (a *UniversalAPI) GetWorkflowHistoryAlpha1(ctx context.Context, in *runtimev1pb.GetWorkflowHistoryRequest) (*runtimev1pb.GetWorkflowHistoryResponse, error) {
+	if err := a.validateInstanceID(in.GetInstanceId(), false); err != nil {
+		a.Logger.Debug(err)
+		return &runtimev1pb.GetWorkflowHistoryResponse{}, err
+	}
+
+	// Workflow requires actors to be ready
+	a.WaitForActorsReady(ctx)
+
+	historian, err := a.getWorkflowHistorian(in.GetWorkflowComponent())
+	if err != nil {
+		a.Logger.Debug(err)
+		return &runtimev1pb.GetWorkflowHistoryResponse{}, err
+	}
+
+	history, err := historian.ExportHistory(ctx, in.GetInstanceId())
+	if err != nil {
+		if errors.Is(err, api.ErrInstanceNotFound) {
+			err = messages.ErrWorkflowInstanceNotFound.WithFormat(in.GetInstanceId(), err)
+		} else {
+			err = messages.ErrWorkflowGetHistory.WithFormat(in.GetInstanceId(), err)
+		}
+		a.Logger.Debug(err)
+		return &runtimev1pb.GetWorkflowHistoryResponse{}, err
+	}
+
+	b, err := json.Marshal(history)
+	if err != nil {
+		err = messages.ErrWorkflowGetHistory.WithFormat(in.GetInstanceId(), err)
+		a.Logger.Debug(err)
+		return &runtimev1pb.GetWorkflowHistoryResponse{}, err
+	}
+	return &runtimev1pb.GetWorkflowHistoryResponse{History: b}, nil
+}
+
+// ReplayWorkflowHistoryAlpha1 is the API handler for replaying the history of a workflow instance against the workflow code of the app
+func (a *UniversalAPI) ReplayWorkflowHistoryAlpha1(ctx context.Context, in *runtimev1pb.ReplayWorkflowHistoryRequest) (*runtimev1pb.ReplayWorkflowHistoryResponse, error) {
+	if err := a.validateInstanceID(in.GetInstanceId(), false); err != nil {
+		a.Logger.Debug(err)
+		return &runtimev1pb.ReplayWorkflowHistoryResponse{}, err
+	}
+
+	historian, err := a.getWorkflowHistorian(in.GetWorkflowComponent())
+	if err != nil {
+		a.Logger.Debug(err)
+		return &runtimev1pb.ReplayWorkflowHistoryResponse{}, err
+	}
+
+	var history wfengine.WorkflowHistory
+	err = json.Unmarshal(in.GetHistory(), &history)
+	if err != nil {
+		err = messages.ErrWorkflowHistoryMalformed.WithFormat(in.GetInstanceId(), err)
+		a.Logger.Debug(err)
+		return &runtimev1pb.ReplayWorkflowHistoryResponse{}, err
+	}
+	history.InstanceID = in.GetInstanceId()
+
+	report, err := historian.ReplayHistory(ctx, &history)
+	if err != nil {
+		err = messages.ErrReplayWorkflow.WithFormat(in.GetInstanceId(), err)
+		a.Logger.Debug(err)
+		return &runtimev1pb.ReplayWorkflowHistoryResponse{}, err
+	}
+
+	res := &runtimev1pb.ReplayWorkflowHistoryResponse{
+		InstanceId:  report.InstanceID,
+		Episodes:    int32(report.Episodes),
+		Divergences: make([]*runtimev1pb.WorkflowReplayDivergence, len(report.Divergences)),
+	}
+	for i, d := range report.Divergences {
+		res.Divergences[i] = &runtimev1pb.WorkflowReplayDivergence{
+			Episode:  int32(d.Episode),
+			Action:   int32(d.Action),
+			Expected: d.Expected,
+			Actual:   d.Actual,
+		}
+	}
+	return res, nil
+}
+
 // GetWorkflowAlpha1 is the API handler for getting workflow details
 func (a *UniversalAPI) GetWorkflowAlpha1(ctx context.Context, in *runtimev1pb.GetWorkflowRequest) (*runtimev1pb.GetWorkflowResponse, error) {
 	return a.GetWorkflowBeta1(ctx, in)
@@ -304,6 +497,20 @@ func (a *UniversalAPI) PurgeWorkflowAlpha1(ctx context.Context, in *runtimev1pb.
 	return a.PurgeWorkflowBeta1(ctx, in)
 }
 
+// workflowOperationEvent records the metrics for a workflow management operation, if a recorder is set.
+// workflowName is empty for the operations that target a workflow instance, since its name isn't part of the request.
+func (a *UniversalAPI) workflowOperationEvent(ctx context.Context, operation, workflowName string, timer diag.Timer, err error) {
+	if a.WorkflowMetrics == nil {
+		return
+	}
+
+	status := diag.StatusSuccess
+	if err != nil {
+		status = diag.StatusFailed
+	}
+	a.WorkflowMetrics.WorkflowOperationEvent(ctx, operation, workflowName, status, timer.Elapsed())
+}
+
 func (a *UniversalAPI) validateInstanceID(instanceID string, isCreate bool) error {
 	if instanceID == "" {
 		return messages.ErrMissingOrEmptyInstance
@@ -340,3 +547,16 @@ func (a *UniversalAPI) getWorkflowComponent(componentName string) (workflows.Wor
 	}
 	return workflowComponent, nil
 }
+
+func (a *UniversalAPI) getWorkflowHistorian(componentName string) (workflowHistorian, error) {
+	workflowComponent, err := a.getWorkflowComponent(componentName)
+	if err != nil {
+		return nil, err
+	}
+
+	historian, ok := workflowComponent.(workflowHistorian)
+	if !ok {
+		return nil, messages.ErrWorkflowHistoryNotSupported.WithFormat(componentName)
+	}
+	return historian, nil
+}
@@ -0,0 +1,106 @@
+/*
+Copyright 2023 The Dapr Authors
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package universalapi
+
+import (
+	"context"
+	"errors"
+	"sync"
+
+	diag "github.com/dapr/dapr/pkg/diagnostics"
+	"github.com/dapr/dapr/pkg/messages"
+	runtimev1pb "github.com/dapr/dapr/pkg/proto/runtime/v1"
+)
+
+const (
+	// Maximum number of events in a batch.
+	maxRaiseEventBatchSize = 10_000
+	// Maximum number of events in a batch that are raised at the same time.
+	raiseEventBatchConcurrency = 32
+)
+
+// RaiseEventWorkflowBatchBeta1 is the API handler for raising events on many workflow instances in a single call.
+// Failing to raise an event on an instance doesn't fail the request: the outcome for each instance is reported in the response.
+func (a *UniversalAPI) RaiseEventWorkflowBatchBeta1(ctx context.Context, in *runtimev1pb.RaiseEventWorkflowBatchRequest) (*runtimev1pb.RaiseEventWorkflowBatchResponse, error) {
+	events := in.GetEvents()
+	if len(events) == 0 {
+		err := messages.ErrRaiseEventWorkflowBatchEmpty
+		a.Logger.Debug(err)
+		return nil, err
+	}
+	if len(events) > maxRaiseEventBatchSize {
+		err := messages.ErrRaiseEventWorkflowBatchTooLarge.WithFormat(len(events), maxRaiseEventBatchSize)
+		a.Logger.Debug(err)
+		return nil, err
+	}
+
+	// Validate the component once, rather than failing every event
+	if _, err := a.getWorkflowComponent(in.GetWorkflowComponent()); err != nil {
+		a.Logger.Debug(err)
+		return nil, err
+	}
+
+	res := &runtimev1pb.RaiseEventWorkflowBatchResponse{
+		Results: make([]*runtimev1pb.RaiseEventWorkflowBatchResult, len(events)),
+	}
+	// The outcome of each event is reported in its result, so the events are raised in a wait group rather than an error group
+	var wg sync.WaitGroup
+	sem := make(chan struct{}, raiseEventBatchConcurrency)
+	for i := range events {
+		sem <- struct{}{}
+		wg.Add(1)
+		go func(i int) {
+			defer func() {
+				<-sem
+				wg.Done()
+			}()
+			res.Results[i] = a.raiseEventWorkflowBatchEvent(ctx, in, events[i])
+		}(i)
+	}
+	wg.Wait()
+
+	return res, nil
+}
+
+// raiseEventWorkflowBatchEvent raises an event of a batch, using the name and data of the batch if the event doesn't set them.
+func (a *UniversalAPI) raiseEventWorkflowBatchEvent(ctx context.Context, in *runtimev1pb.RaiseEventWorkflowBatchRequest, ev *runtimev1pb.RaiseEventWorkflowBatchEvent) *runtimev1pb.RaiseEventWorkflowBatchResult {
+	req := &runtimev1pb.RaiseEventWorkflowRequest{
+		InstanceId:        ev.GetInstanceId(),
+		WorkflowComponent: in.GetWorkflowComponent(),
+		EventName:         ev.GetEventName(),
+		EventData:         ev.GetEventData(),
+	}
+	if req.GetEventName() == "" {
+		req.EventName = in.GetEventName()
+	}
+	if len(req.GetEventData()) == 0 {
+		req.EventData = in.GetEventData()
+	}
+
+	result := &runtimev1pb.RaiseEventWorkflowBatchResult{
+		InstanceId: ev.GetInstanceId(),
+		Status:     diag.StatusSuccess,
+	}
+	_, err := a.RaiseEventWorkflowBeta1(ctx, req)
+	if err != nil {
+		result.Status = diag.StatusFailed
+		result.Message = err.Error()
+		var apiErr messages.APIError
+		if errors.As(err, &apiErr) {
+			result.ErrorCode = apiErr.Tag()
+			result.Message = apiErr.Message()
+		}
+	}
+	return result
+}
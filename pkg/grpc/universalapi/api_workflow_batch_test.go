@@ -0,0 +1,95 @@
+/*
+Copyright 2023 The Dapr Authors
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package universalapi
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/dapr/dapr/pkg/messages"
+	runtimev1pb "github.com/dapr/dapr/pkg/proto/runtime/v1"
+	"github.com/dapr/dapr/pkg/resiliency"
+	"github.com/dapr/dapr/pkg/runtime/compstore"
+	daprt "github.com/dapr/dapr/pkg/testing"
+	"github.com/dapr/kit/logger"
+)
+
+func TestRaiseEventWorkflowBatch(t *testing.T) {
+	compStore := compstore.New()
+	compStore.AddWorkflow(fakeComponentName, &daprt.MockWorkflow{})
+
+	fakeAPI := &UniversalAPI{
+		Logger:     logger.NewLogger("test"),
+		Resiliency: resiliency.New(nil),
+		CompStore:  compStore,
+	}
+	fakeAPI.InitUniversalAPI()
+	fakeAPI.SetActorsInitDone()
+
+	t.Run("no events", func(t *testing.T) {
+		_, err := fakeAPI.RaiseEventWorkflowBatchBeta1(context.Background(), &runtimev1pb.RaiseEventWorkflowBatchRequest{
+			WorkflowComponent: fakeComponentName,
+		})
+		require.ErrorIs(t, err, messages.ErrRaiseEventWorkflowBatchEmpty)
+	})
+
+	t.Run("too many events", func(t *testing.T) {
+		_, err := fakeAPI.RaiseEventWorkflowBatchBeta1(context.Background(), &runtimev1pb.RaiseEventWorkflowBatchRequest{
+			WorkflowComponent: fakeComponentName,
+			Events:            make([]*runtimev1pb.RaiseEventWorkflowBatchEvent, maxRaiseEventBatchSize+1),
+		})
+		require.ErrorIs(t, err, messages.ErrRaiseEventWorkflowBatchTooLarge)
+	})
+
+	t.Run("workflow component does not exist", func(t *testing.T) {
+		_, err := fakeAPI.RaiseEventWorkflowBatchBeta1(context.Background(), &runtimev1pb.RaiseEventWorkflowBatchRequest{
+			WorkflowComponent: "fakeWorkflowNotExist",
+			EventName:         "market_closed",
+			Events:            []*runtimev1pb.RaiseEventWorkflowBatchEvent{{InstanceId: fakeInstanceID}},
+		})
+		require.ErrorIs(t, err, messages.ErrWorkflowComponentDoesNotExist)
+	})
+
+	t.Run("results for each instance", func(t *testing.T) {
+		res, err := fakeAPI.RaiseEventWorkflowBatchBeta1(context.Background(), &runtimev1pb.RaiseEventWorkflowBatchRequest{
+			WorkflowComponent: fakeComponentName,
+			EventName:         "market_closed",
+			EventData:         []byte(`{"market":"NYSE"}`),
+			Events: []*runtimev1pb.RaiseEventWorkflowBatchEvent{
+				{InstanceId: fakeInstanceID},
+				{InstanceId: daprt.ErrorInstanceID},
+				{InstanceId: ""},
+				{InstanceId: "other", EventName: "custom_event"},
+			},
+		})
+		require.NoError(t, err)
+		require.Len(t, res.GetResults(), 4)
+
+		assert.Equal(t, fakeInstanceID, res.GetResults()[0].GetInstanceId())
+		assert.Equal(t, "success", res.GetResults()[0].GetStatus())
+		assert.Empty(t, res.GetResults()[0].GetErrorCode())
+
+		assert.Equal(t, daprt.ErrorInstanceID, res.GetResults()[1].GetInstanceId())
+		assert.Equal(t, "failed", res.GetResults()[1].GetStatus())
+		assert.Equal(t, "ERR_RAISE_EVENT_WORKFLOW", res.GetResults()[1].GetErrorCode())
+
+		assert.Equal(t, "failed", res.GetResults()[2].GetStatus())
+		assert.Equal(t, "ERR_INSTANCE_ID_PROVIDED_MISSING", res.GetResults()[2].GetErrorCode())
+
+		assert.Equal(t, "success", res.GetResults()[3].GetStatus())
+	})
+}
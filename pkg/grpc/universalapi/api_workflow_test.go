@@ -15,15 +15,21 @@ package universalapi
 
 import (
 	"context"
+	"fmt"
 	"testing"
+	"time"
 
+	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/types/known/timestamppb"
 
 	"github.com/dapr/components-contrib/workflows"
+	diag "github.com/dapr/dapr/pkg/diagnostics"
 	"github.com/dapr/dapr/pkg/messages"
 	runtimev1pb "github.com/dapr/dapr/pkg/proto/runtime/v1"
 	"github.com/dapr/dapr/pkg/resiliency"
 	"github.com/dapr/dapr/pkg/runtime/compstore"
+	"github.com/dapr/dapr/pkg/runtime/wfengine"
 	daprt "github.com/dapr/dapr/pkg/testing"
 	"github.com/dapr/kit/logger"
 )
@@ -517,3 +523,140 @@ func TestResumeWorkflowBeta1Api(t *testing.T) {
 		})
 	}
 }
+
+type fakeWorkflowRecorder struct {
+	events []string
+}
+
+func (f *fakeWorkflowRecorder) WorkflowOperationEvent(ctx context.Context, operation, workflowName, status string, elapsed float64) {
+	f.events = append(f.events, operation+":"+workflowName+":"+status)
+}
+
+// fakeWorkflowLister is a workflow component that lists a single instance.
+type fakeWorkflowLister struct {
+	daprt.MockWorkflow
+
+	lastReq *wfengine.ListWorkflowsRequest
+}
+
+func (w *fakeWorkflowLister) ListWorkflows(ctx context.Context, req *wfengine.ListWorkflowsRequest) (*wfengine.ListWorkflowsResponse, error) {
+	w.lastReq = req
+	if req.ContinuationToken == "bad" {
+		return nil, fmt.Errorf("failed to list workflows: %w", wfengine.ErrInvalidContinuationToken)
+	}
+	return &wfengine.ListWorkflowsResponse{
+		Instances: []wfengine.WorkflowIndexEntry{{
+			InstanceID:    fakeInstanceID,
+			WorkflowName:  "fakeWorkflow",
+			RuntimeStatus: "RUNNING",
+			CreatedAt:     time.Unix(1700000000, 0),
+			LastUpdatedAt: time.Unix(1700000001, 0),
+		}},
+		ContinuationToken: "next",
+	}, nil
+}
+
+func TestListWorkflowsAlpha1API(t *testing.T) {
+	lister := &fakeWorkflowLister{}
+	compStore := compstore.New()
+	compStore.AddWorkflow(fakeComponentName, lister)
+	compStore.AddWorkflow("notLister", &daprt.MockWorkflow{})
+
+	fakeAPI := &UniversalAPI{
+		Logger:     logger.NewLogger("test"),
+		Resiliency: resiliency.New(nil),
+		CompStore:  compStore,
+	}
+	fakeAPI.InitUniversalAPI()
+	fakeAPI.SetActorsInitDone()
+
+	t.Run("No workflow component provided in list request", func(t *testing.T) {
+		_, err := fakeAPI.ListWorkflowsAlpha1(context.Background(), &runtimev1pb.ListWorkflowsRequest{})
+		require.ErrorIs(t, err, messages.ErrNoOrMissingWorkflowComponent)
+	})
+
+	t.Run("workflow component does not support listing", func(t *testing.T) {
+		_, err := fakeAPI.ListWorkflowsAlpha1(context.Background(), &runtimev1pb.ListWorkflowsRequest{
+			WorkflowComponent: "notLister",
+		})
+		require.ErrorIs(t, err, messages.ErrWorkflowListNotSupported.WithFormat("notLister"))
+	})
+
+	t.Run("invalid continuation token", func(t *testing.T) {
+		_, err := fakeAPI.ListWorkflowsAlpha1(context.Background(), &runtimev1pb.ListWorkflowsRequest{
+			WorkflowComponent: fakeComponentName,
+			ContinuationToken: "bad",
+		})
+		require.ErrorIs(t, err, messages.ErrListWorkflowsInvalidToken.WithFormat("bad"))
+	})
+
+	t.Run("All is well in list request", func(t *testing.T) {
+		createdAfter := time.Unix(1600000000, 0).UTC()
+		res, err := fakeAPI.ListWorkflowsAlpha1(context.Background(), &runtimev1pb.ListWorkflowsRequest{
+			WorkflowComponent: fakeComponentName,
+			RuntimeStatus:     []string{"RUNNING", "SUSPENDED"},
+			WorkflowName:      "fakeWorkflow",
+			CreatedAfter:      timestamppb.New(createdAfter),
+			PageSize:          10,
+		})
+		require.NoError(t, err)
+
+		assert.Equal(t, []string{"RUNNING", "SUSPENDED"}, lister.lastReq.RuntimeStatuses)
+		assert.Equal(t, "fakeWorkflow", lister.lastReq.WorkflowName)
+		assert.True(t, createdAfter.Equal(lister.lastReq.CreatedAfter))
+		assert.True(t, lister.lastReq.CreatedBefore.IsZero())
+		assert.Equal(t, 10, lister.lastReq.PageSize)
+
+		require.Len(t, res.GetInstances(), 1)
+		assert.Equal(t, fakeInstanceID, res.GetInstances()[0].GetInstanceId())
+		assert.Equal(t, "RUNNING", res.GetInstances()[0].GetRuntimeStatus())
+		assert.Equal(t, int64(1700000000), res.GetInstances()[0].GetCreatedAt().GetSeconds())
+		assert.Equal(t, "next", res.GetContinuationToken())
+	})
+}
+
+func TestWorkflowOperationMetrics(t *testing.T) {
+	compStore := compstore.New()
+	compStore.AddWorkflow(fakeComponentName, &daprt.MockWorkflow{})
+
+	recorder := &fakeWorkflowRecorder{}
+	fakeAPI := &UniversalAPI{
+		Logger:          logger.NewLogger("test"),
+		Resiliency:      resiliency.New(nil),
+		CompStore:       compStore,
+		WorkflowMetrics: recorder,
+	}
+	fakeAPI.InitUniversalAPI()
+	fakeAPI.SetActorsInitDone()
+
+	ctx := context.Background()
+	_, err := fakeAPI.StartWorkflowBeta1(ctx, &runtimev1pb.StartWorkflowRequest{
+		WorkflowComponent: fakeComponentName,
+		InstanceId:        fakeInstanceID,
+		WorkflowName:      "fakeWorkflow",
+	})
+	require.NoError(t, err)
+	_, err = fakeAPI.RaiseEventWorkflowBeta1(ctx, &runtimev1pb.RaiseEventWorkflowRequest{
+		WorkflowComponent: fakeComponentName,
+		InstanceId:        fakeInstanceID,
+		EventName:         "fake_event_name",
+	})
+	require.NoError(t, err)
+	_, err = fakeAPI.TerminateWorkflowBeta1(ctx, &runtimev1pb.TerminateWorkflowRequest{
+		WorkflowComponent: fakeComponentName,
+		InstanceId:        daprt.ErrorInstanceID,
+	})
+	require.Error(t, err)
+	_, err = fakeAPI.PurgeWorkflowAlpha1(ctx, &runtimev1pb.PurgeWorkflowRequest{
+		WorkflowComponent: fakeComponentName,
+		InstanceId:        "",
+	})
+	require.Error(t, err)
+
+	require.Equal(t, []string{
+		diag.CreateWorkflow + ":fakeWorkflow:" + diag.StatusSuccess,
+		diag.AddEvent + "::" + diag.StatusSuccess,
+		diag.TerminateWorkflow + "::" + diag.StatusFailed,
+		diag.PurgeWorkflow + "::" + diag.StatusFailed,
+	}, recorder.events)
+}
@@ -16,11 +16,18 @@ limitations under the License.
 package universalapi
 
 import (
+	"context"
 	"sync"
 	"sync/atomic"
+	"time"
 
 	"github.com/dapr/dapr/pkg/actors"
+	"github.com/dapr/dapr/pkg/components"
+	stateLoader "github.com/dapr/dapr/pkg/components/state"
 	"github.com/dapr/dapr/pkg/config"
+	diag "github.com/dapr/dapr/pkg/diagnostics"
+	"github.com/dapr/dapr/pkg/leaderelection"
+	"github.com/dapr/dapr/pkg/ratelimit"
 	"github.com/dapr/dapr/pkg/resiliency"
 	"github.com/dapr/dapr/pkg/runtime/compstore"
 	"github.com/dapr/kit/logger"
@@ -34,10 +41,21 @@ type UniversalAPI struct {
 	Actors                      actors.ActorRuntime
 	CompStore                   *compstore.ComponentStore
 	ShutdownFn                  func()
+	ResetAppChannelFn           func() error
+	ReplayBindingDeadLettersFn  func(ctx context.Context, name string) (*BindingDeadLetterReplay, error)
 	GetComponentsCapabilitiesFn func() map[string][]string
+	GetComponentsStatsFn        func() map[string]components.ComponentStats
+	GetBootReportFn             func() *BootReport
 	ExtendedMetadata            map[string]string
 	AppConnectionConfig         config.AppConnectionConfig
 	GlobalConfig                *config.Configuration
+	WorkflowMetrics             diag.WorkflowOperationRecorder
+	AppMetrics                  diag.AppMetricsRecorder
+	RateLimiters                ratelimit.Limiters
+	LeaderElections             leaderelection.Elections
+	StateIdempotency            *stateLoader.IdempotencyTracker
+	// Maximum duration of service invocations set with the dapr-timeout header. If 0, there's no limit.
+	MaxInvokeTimeout time.Duration
 
 	extendedMetadataLock sync.RWMutex
 	actorsReady          atomic.Bool
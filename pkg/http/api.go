@@ -74,8 +74,11 @@ type api struct {
 	sendToOutputBindingFn func(ctx context.Context, name string, req *bindings.InvokeRequest) (*bindings.InvokeResponse, error)
 	readyStatus           bool
 	outboundReadyStatus   bool
+	isWorkflowReadyFn     func() bool
 	tracingSpec           config.TracingSpec
 	maxRequestBodySize    int64 // In bytes
+
+	stateChangeSubscriptions stateChangeSubscriptions
 }
 
 const (
@@ -114,8 +117,10 @@ type APIOpts struct {
 	DirectMessaging       invokev1.DirectMessaging
 	PubsubAdapter         runtimePubsub.Adapter
 	SendToOutputBindingFn func(ctx context.Context, name string, req *bindings.InvokeRequest) (*bindings.InvokeResponse, error)
-	TracingSpec           config.TracingSpec
-	MaxRequestBodySize    int64 // In bytes
+	// Returns true if the workflow engine can accept orchestration traffic. If nil, the workflow engine is never reported as ready.
+	IsWorkflowReadyFn  func() bool
+	TracingSpec        config.TracingSpec
+	MaxRequestBodySize int64 // In bytes
 }
 
 // NewAPI returns a new API.
@@ -128,20 +133,30 @@ func NewAPI(opts APIOpts) API {
 		directMessaging:       opts.DirectMessaging,
 		pubsubAdapter:         opts.PubsubAdapter,
 		sendToOutputBindingFn: opts.SendToOutputBindingFn,
+		isWorkflowReadyFn:     opts.IsWorkflowReadyFn,
 		tracingSpec:           opts.TracingSpec,
 		maxRequestBodySize:    opts.MaxRequestBodySize,
+		stateChangeSubscriptions: stateChangeSubscriptions{
+			subscriptions: map[string]stateChangeSubscription{},
+		},
 	}
 
 	metadataEndpoints := api.constructMetadataEndpoints()
 	healthEndpoints := api.constructHealthzEndpoints()
 
 	api.endpoints = append(api.endpoints, api.constructStateEndpoints()...)
+	api.endpoints = append(api.endpoints, api.constructStateChangesEndpoints()...)
 	api.endpoints = append(api.endpoints, api.constructSecretsEndpoints()...)
 	api.endpoints = append(api.endpoints, api.constructPubSubEndpoints()...)
 	api.endpoints = append(api.endpoints, api.constructActorEndpoints()...)
 	api.endpoints = append(api.endpoints, api.constructDirectMessagingEndpoints()...)
 	api.endpoints = append(api.endpoints, metadataEndpoints...)
 	api.endpoints = append(api.endpoints, api.constructShutdownEndpoints()...)
+	api.endpoints = append(api.endpoints, api.constructAppChannelEndpoints()...)
+	api.endpoints = append(api.endpoints, api.constructMetricsEndpoints()...)
+	api.endpoints = append(api.endpoints, api.constructTracingEndpoints()...)
+	api.endpoints = append(api.endpoints, api.constructRateLimitEndpoints()...)
+	api.endpoints = append(api.endpoints, api.constructLeaderElectionEndpoints()...)
 	api.endpoints = append(api.endpoints, api.constructBindingsEndpoints()...)
 	api.endpoints = append(api.endpoints, api.constructConfigurationEndpoints()...)
 	api.endpoints = append(api.endpoints, api.constructSubtleCryptoEndpoints()...)
@@ -320,6 +335,20 @@ func (a *api) constructBindingsEndpoints() []endpoints.Endpoint {
 				Name: "InvokeBinding",
 			},
 		},
+		{
+			Methods: []string{nethttp.MethodPost},
+			Route:   "bindings/{name}/deadletters/replay",
+			Version: apiVersionV1alpha1,
+			Group: &endpoints.EndpointGroup{
+				Name:                 endpoints.EndpointGroupBindings,
+				Version:              endpoints.EndpointGroupVersion1alpha1,
+				AppendSpanAttributes: appendBindingsSpanAttributes,
+			},
+			Handler: a.onReplayBindingDeadLetters,
+			Settings: endpoints.EndpointSettings{
+				Name: "ReplayBindingDeadLetters",
+			},
+		},
 	}
 }
 
@@ -559,6 +588,12 @@ func (a *api) onOutputBindingMessage(reqCtx *fasthttp.RequestCtx) {
 			req.Metadata[tracestateHeader] = diag.TraceStateToW3CString(sc)
 		}
 	}
+	if bg := diag.BaggageString(reqCtx); bg != "" {
+		if req.Metadata == nil {
+			req.Metadata = map[string]string{}
+		}
+		req.Metadata[diag.BaggageHeader] = bg
+	}
 
 	start := time.Now()
 	resp, err := a.sendToOutputBindingFn(reqCtx, name, &bindings.InvokeRequest{
@@ -569,6 +604,7 @@ func (a *api) onOutputBindingMessage(reqCtx *fasthttp.RequestCtx) {
 	elapsed := diag.ElapsedSince(start)
 
 	diag.DefaultComponentMonitoring.OutputBindingEvent(context.Background(), name, req.Operation, err == nil, elapsed)
+	diag.DefaultComponentMonitoring.OperationFailed(context.Background(), name, req.Operation, err)
 
 	if err != nil {
 		msg := NewErrorResponse("ERR_INVOKE_OUTPUT_BINDING", fmt.Sprintf(messages.ErrInvokeOutputBinding, name, err))
@@ -587,6 +623,15 @@ func (a *api) onOutputBindingMessage(reqCtx *fasthttp.RequestCtx) {
 	}
 }
 
+func (a *api) onReplayBindingDeadLetters(w nethttp.ResponseWriter, r *nethttp.Request) {
+	res, err := a.universal.ReplayBindingDeadLetters(r.Context(), chi.URLParam(r, nameParam))
+	if err != nil {
+		respondWithError(w, err)
+		return
+	}
+	respondWithJSON(w, nethttp.StatusOK, res)
+}
+
 func (a *api) onBulkGetState(reqCtx *fasthttp.RequestCtx) {
 	store, storeName, err := a.getStateStoreWithRequestValidation(reqCtx)
 	if err != nil {
@@ -604,7 +649,10 @@ func (a *api) onBulkGetState(reqCtx *fasthttp.RequestCtx) {
 	}
 
 	// merge metadata from URL query parameters
-	metadata := getMetadataFromFastHTTPRequest(reqCtx)
+	metadata, err := getStateMetadataFromFastHTTPRequest(reqCtx, storeName)
+	if err != nil {
+		return
+	}
 	if req.Metadata == nil {
 		req.Metadata = metadata
 	} else {
@@ -649,6 +697,7 @@ func (a *api) onBulkGetState(reqCtx *fasthttp.RequestCtx) {
 
 	elapsed := diag.ElapsedSince(start)
 	diag.DefaultComponentMonitoring.StateInvoked(context.Background(), storeName, diag.BulkGet, err == nil, elapsed)
+	diag.DefaultComponentMonitoring.OperationFailed(context.Background(), storeName, diag.BulkGet, err)
 
 	if err != nil {
 		msg := NewErrorResponse("ERR_STATE_BULK_GET", err.Error())
@@ -719,7 +768,10 @@ func (a *api) onGetState(reqCtx *fasthttp.RequestCtx) {
 		return
 	}
 
-	metadata := getMetadataFromFastHTTPRequest(reqCtx)
+	metadata, err := getStateMetadataFromFastHTTPRequest(reqCtx, storeName)
+	if err != nil {
+		return
+	}
 
 	key := reqCtx.UserValue(stateKeyParam).(string)
 	consistency := string(reqCtx.QueryArgs().Peek(consistencyParam))
@@ -748,6 +800,7 @@ func (a *api) onGetState(reqCtx *fasthttp.RequestCtx) {
 	elapsed := diag.ElapsedSince(start)
 
 	diag.DefaultComponentMonitoring.StateInvoked(context.Background(), storeName, diag.Get, err == nil, elapsed)
+	diag.DefaultComponentMonitoring.OperationFailed(context.Background(), storeName, diag.Get, err)
 
 	if err != nil {
 		msg := NewErrorResponse("ERR_STATE_GET", fmt.Sprintf(messages.ErrStateGet, key, storeName, err.Error()))
@@ -775,6 +828,12 @@ func (a *api) onGetState(reqCtx *fasthttp.RequestCtx) {
 
 	if resp.ETag != nil {
 		reqCtx.Response.Header.Add(etagHeader, *resp.ETag)
+
+		// If the client already holds the current version of the value, there's no need to send it again
+		if ifNoneMatchHeaderMatches(string(reqCtx.Request.Header.Peek(ifNoneMatchHeader)), *resp.ETag) {
+			fasthttpRespond(reqCtx, fasthttpResponseWithNotModified())
+			return
+		}
 	}
 
 	for k, v := range resp.Metadata {
@@ -784,6 +843,29 @@ func (a *api) onGetState(reqCtx *fasthttp.RequestCtx) {
 	fasthttpRespond(reqCtx, fasthttpResponseWithJSON(nethttp.StatusOK, resp.Data, resp.Metadata))
 }
 
+// ifNoneMatchHeaderMatches returns true if the value of an If-None-Match header matches the given ETag.
+// The header can contain "*", a single ETag (quoted or as returned by the state store), or a comma-separated list of quoted ETags, optionally with the weak validator prefix.
+func ifNoneMatchHeaderMatches(header string, etag string) bool {
+	header = strings.TrimSpace(header)
+	if header == "" {
+		return false
+	}
+	if header == "*" || header == etag {
+		return true
+	}
+
+	for _, candidate := range strings.Split(header, ",") {
+		candidate = strings.TrimPrefix(strings.TrimSpace(candidate), "W/")
+		if len(candidate) >= 2 && candidate[0] == '"' && candidate[len(candidate)-1] == '"' {
+			candidate = candidate[1 : len(candidate)-1]
+		}
+		if candidate == etag {
+			return true
+		}
+	}
+	return false
+}
+
 func (a *api) getConfigurationStoreWithRequestValidation(reqCtx *fasthttp.RequestCtx) (configuration.Store, string, error) {
 	if a.universal.CompStore.ConfigurationsLen() == 0 {
 		msg := NewErrorResponse("ERR_CONFIGURATION_STORE_NOT_CONFIGURED", messages.ErrConfigurationStoresNotConfigured)
@@ -912,6 +994,7 @@ func (a *api) onSubscribeConfiguration(reqCtx *fasthttp.RequestCtx) {
 	elapsed := diag.ElapsedSince(start)
 
 	diag.DefaultComponentMonitoring.ConfigurationInvoked(context.Background(), storeName, diag.ConfigurationSubscribe, err == nil, elapsed)
+	diag.DefaultComponentMonitoring.OperationFailed(context.Background(), storeName, diag.ConfigurationSubscribe, err)
 
 	if err != nil {
 		msg := NewErrorResponse("ERR_CONFIGURATION_SUBSCRIBE", fmt.Sprintf(messages.ErrConfigurationSubscribe, keys, storeName, err.Error()))
@@ -945,6 +1028,7 @@ func (a *api) onUnsubscribeConfiguration(reqCtx *fasthttp.RequestCtx) {
 	})
 	elapsed := diag.ElapsedSince(start)
 	diag.DefaultComponentMonitoring.ConfigurationInvoked(context.Background(), storeName, diag.ConfigurationUnsubscribe, err == nil, elapsed)
+	diag.DefaultComponentMonitoring.OperationFailed(context.Background(), storeName, diag.ConfigurationUnsubscribe, err)
 
 	if err != nil {
 		msg := NewErrorResponse("ERR_CONFIGURATION_UNSUBSCRIBE", fmt.Sprintf(messages.ErrConfigurationUnsubscribe, subscribeID, err.Error()))
@@ -991,6 +1075,7 @@ func (a *api) onGetConfiguration(reqCtx *fasthttp.RequestCtx) {
 	elapsed := diag.ElapsedSince(start)
 
 	diag.DefaultComponentMonitoring.ConfigurationInvoked(context.Background(), storeName, diag.Get, err == nil, elapsed)
+	diag.DefaultComponentMonitoring.OperationFailed(context.Background(), storeName, diag.Get, err)
 
 	if err != nil {
 		msg := NewErrorResponse("ERR_CONFIGURATION_GET", fmt.Sprintf(messages.ErrConfigurationGet, keys, storeName, err.Error()))
@@ -1027,13 +1112,17 @@ func (a *api) onDeleteState(reqCtx *fasthttp.RequestCtx) {
 		log.Debug(err)
 		return
 	}
+	defer a.universal.CompStore.TrackOperation(storeName)()
 
 	key := reqCtx.UserValue(stateKeyParam).(string)
 
 	concurrency := string(reqCtx.QueryArgs().Peek(concurrencyParam))
 	consistency := string(reqCtx.QueryArgs().Peek(consistencyParam))
 
-	metadata := getMetadataFromFastHTTPRequest(reqCtx)
+	metadata, err := getStateMetadataFromFastHTTPRequest(reqCtx, storeName)
+	if err != nil {
+		return
+	}
 	k, err := stateLoader.GetModifiedStateKey(key, storeName, a.universal.AppID)
 	if err != nil {
 		msg := NewErrorResponse("ERR_MALFORMED_REQUEST", err.Error())
@@ -1065,6 +1154,7 @@ func (a *api) onDeleteState(reqCtx *fasthttp.RequestCtx) {
 	elapsed := diag.ElapsedSince(start)
 
 	diag.DefaultComponentMonitoring.StateInvoked(reqCtx, storeName, diag.Delete, err == nil, elapsed)
+	diag.DefaultComponentMonitoring.OperationFailed(reqCtx, storeName, diag.Delete, err)
 
 	if err != nil {
 		statusCode, errMsg, resp := a.stateErrorResponse(err, "ERR_STATE_DELETE")
@@ -1083,6 +1173,7 @@ func (a *api) onPostState(reqCtx *fasthttp.RequestCtx) {
 		log.Debug(err)
 		return
 	}
+	defer a.universal.CompStore.TrackOperation(storeName)()
 
 	reqs := []state.SetRequest{}
 	err = json.Unmarshal(reqCtx.PostBody(), &reqs)
@@ -1097,7 +1188,10 @@ func (a *api) onPostState(reqCtx *fasthttp.RequestCtx) {
 		return
 	}
 
-	metadata := getMetadataFromFastHTTPRequest(reqCtx)
+	metadata, err := getStateMetadataFromFastHTTPRequest(reqCtx, storeName)
+	if err != nil {
+		return
+	}
 
 	for i, r := range reqs {
 		if len(reqs[i].Key) == 0 {
@@ -1140,17 +1234,34 @@ func (a *api) onPostState(reqCtx *fasthttp.RequestCtx) {
 		}
 	}
 
-	start := time.Now()
-	err = stateLoader.PerformBulkStoreOperation(reqCtx, reqs,
-		a.universal.Resiliency.ComponentOutboundPolicy(storeName, resiliency.Statestore),
-		state.BulkStoreOpts{},
-		store.Set,
-		store.BulkSet,
-	)
-	elapsed := diag.ElapsedSince(start)
-
-	diag.DefaultComponentMonitoring.StateInvoked(reqCtx, storeName, diag.Set, err == nil, elapsed)
+	idempotencyKey := string(reqCtx.Request.Header.Peek(stateLoader.IdempotencyKeyHeader))
+	duplicate, err := a.universal.StateIdempotency.Write(reqCtx, storeName, idempotencyKey, reqCtx.PostBody(), func() error {
+		start := time.Now()
+		rErr := stateLoader.PerformBulkStoreOperation(reqCtx, reqs,
+			a.universal.Resiliency.ComponentOutboundPolicy(storeName, resiliency.Statestore),
+			state.BulkStoreOpts{},
+			store.Set,
+			store.BulkSet,
+		)
+		elapsed := diag.ElapsedSince(start)
+
+		diag.DefaultComponentMonitoring.StateInvoked(reqCtx, storeName, diag.Set, rErr == nil, elapsed)
+		diag.DefaultComponentMonitoring.OperationFailed(reqCtx, storeName, diag.Set, rErr)
+		return rErr
+	})
 
+	if errors.Is(err, stateLoader.ErrIdempotencyKeyReused) {
+		err = messages.ErrStateIdempotencyKeyReused.WithFormat(idempotencyKey, storeName)
+		log.Debug(err)
+		universalFastHTTPErrorResponder(reqCtx, err)
+		return
+	}
+	if errors.Is(err, stateLoader.ErrIdempotencyKeyInUse) {
+		err = messages.ErrStateIdempotencyKeyInUse.WithFormat(idempotencyKey, storeName)
+		log.Debug(err)
+		universalFastHTTPErrorResponder(reqCtx, err)
+		return
+	}
 	if err != nil {
 		statusCode, errMsg, resp := a.stateErrorResponse(err, "ERR_STATE_SAVE")
 		resp.Message = fmt.Sprintf(messages.ErrStateSave, storeName, errMsg)
@@ -1159,6 +1270,9 @@ func (a *api) onPostState(reqCtx *fasthttp.RequestCtx) {
 		log.Debug(resp.Message)
 		return
 	}
+	if duplicate {
+		log.Debugf("Request with idempotency key %s was already saved in state store %s", idempotencyKey, storeName)
+	}
 
 	fasthttpRespond(reqCtx, fasthttpResponseWithEmpty())
 }
@@ -1582,6 +1696,7 @@ func (a *api) onPublish(reqCtx *fasthttp.RequestCtx) {
 			Data:            body,
 			TraceID:         corID,
 			TraceState:      traceState,
+			Baggage:         diag.BaggageString(reqCtx),
 			Pubsub:          pubsubName,
 		}, metadata)
 		if err != nil {
@@ -1618,6 +1733,7 @@ func (a *api) onPublish(reqCtx *fasthttp.RequestCtx) {
 	elapsed := diag.ElapsedSince(start)
 
 	diag.DefaultComponentMonitoring.PubsubEgressEvent(context.Background(), pubsubName, topic, err == nil, elapsed)
+	diag.DefaultComponentMonitoring.OperationFailed(context.Background(), pubsubName, diag.Publish, err)
 
 	if err != nil {
 		status := nethttp.StatusInternalServerError
@@ -1637,10 +1753,18 @@ func (a *api) onPublish(reqCtx *fasthttp.RequestCtx) {
 		fasthttpRespond(reqCtx, fasthttpResponseWithError(status, msg))
 		log.Debug(msg)
 	} else {
+		a.setTopicBacklogHeader(reqCtx, pubsubName, topic)
 		fasthttpRespond(reqCtx, fasthttpResponseWithEmpty())
 	}
 }
 
+// setTopicBacklogHeader adds the backlog of the topic to the response, if the pub/sub component reports it.
+func (a *api) setTopicBacklogHeader(reqCtx *fasthttp.RequestCtx, pubsubName, topic string) {
+	if backlog, ok := a.pubsubAdapter.TopicBacklog(reqCtx, pubsubName, topic); ok {
+		reqCtx.Response.Header.Set(runtimePubsub.BacklogHeader, strconv.FormatInt(backlog, 10))
+	}
+}
+
 type bulkPublishMessageEntry struct {
 	EntryID     string            `json:"entryId,omitempty"`
 	Event       interface{}       `json:"event"`
@@ -1740,6 +1864,7 @@ func (a *api) onBulkPublish(reqCtx *fasthttp.RequestCtx) {
 				Data:            entries[i].Event,
 				TraceID:         corID,
 				TraceState:      traceState,
+				Baggage:         diag.BaggageString(reqCtx),
 				Pubsub:          pubsubName,
 			}, entries[i].Metadata)
 			if err != nil {
@@ -1823,6 +1948,7 @@ func (a *api) onBulkPublish(reqCtx *fasthttp.RequestCtx) {
 	}
 
 	// If there are no errors, then an empty response is returned.
+	a.setTopicBacklogHeader(reqCtx, pubsubName, topic)
 	fasthttpRespond(reqCtx, fasthttpResponseWithEmpty(), closeChildSpans)
 }
 
@@ -1899,6 +2025,20 @@ func getMetadataFromFastHTTPRequest(reqCtx *fasthttp.RequestCtx) map[string]stri
 	return metadata
 }
 
+// getStateMetadataFromFastHTTPRequest returns the metadata passed in the query string of a state request, validated against the request metadata contract of the state store.
+// If the metadata is invalid, it sends an error response and returns an error.
+func getStateMetadataFromFastHTTPRequest(reqCtx *fasthttp.RequestCtx, storeName string) (map[string]string, error) {
+	metadata := getMetadataFromFastHTTPRequest(reqCtx)
+	err := stateLoader.GetRequestMetadataContract(storeName).Validate(metadata)
+	if err != nil {
+		err = messages.ErrStateInvalidMetadata.WithFormat(storeName, err)
+		log.Debug(err)
+		universalFastHTTPErrorResponder(reqCtx, err)
+		return nil, err
+	}
+	return metadata, nil
+}
+
 type stateTransactionRequestBody struct {
 	Operations []stateTransactionRequestBodyOperation `json:"operations"`
 	Metadata   map[string]string                      `json:"metadata,omitempty"`
@@ -1909,6 +2049,19 @@ type stateTransactionRequestBodyOperation struct {
 	Request   interface{} `json:"request"`
 }
 
+// decodeStateTransactionRequest decodes the request of an operation in a state transaction.
+// Metadata values can be of any scalar type, such as `"ttlInSeconds": 60`, and are converted to strings.
+func decodeStateTransactionRequest(in any, out any) error {
+	dec, err := mapstructure.NewDecoder(&mapstructure.DecoderConfig{
+		WeaklyTypedInput: true,
+		Result:           out,
+	})
+	if err != nil {
+		return err
+	}
+	return dec.Decode(in)
+}
+
 func (a *api) onPostStateTransaction(reqCtx *fasthttp.RequestCtx) {
 	if a.universal.CompStore.StateStoresLen() == 0 {
 		err := messages.ErrStateStoresNotConfigured
@@ -1925,6 +2078,7 @@ func (a *api) onPostStateTransaction(reqCtx *fasthttp.RequestCtx) {
 		universalFastHTTPErrorResponder(reqCtx, err)
 		return
 	}
+	defer a.universal.CompStore.TrackOperation(storeName)()
 
 	transactionalStore, ok := store.(state.TransactionalStore)
 	if !ok {
@@ -1948,7 +2102,17 @@ func (a *api) onPostStateTransaction(reqCtx *fasthttp.RequestCtx) {
 	}
 
 	// merge metadata from URL query parameters
-	metadata := getMetadataFromFastHTTPRequest(reqCtx)
+	metadata, err := getStateMetadataFromFastHTTPRequest(reqCtx, storeName)
+	if err != nil {
+		return
+	}
+	err = stateLoader.GetRequestMetadataContract(storeName).Validate(req.Metadata)
+	if err != nil {
+		msg := messages.ErrStateInvalidMetadata.WithFormat(storeName, err)
+		universalFastHTTPErrorResponder(reqCtx, msg)
+		log.Debug(msg)
+		return
+	}
 	if req.Metadata == nil {
 		req.Metadata = metadata
 	} else {
@@ -1962,7 +2126,7 @@ func (a *api) onPostStateTransaction(reqCtx *fasthttp.RequestCtx) {
 		switch o.Operation {
 		case string(state.OperationUpsert):
 			var upsertReq state.SetRequest
-			err := mapstructure.Decode(o.Request, &upsertReq)
+			err := decodeStateTransactionRequest(o.Request, &upsertReq)
 			if err != nil {
 				msg := messages.ErrMalformedRequest.WithFormat(err)
 				universalFastHTTPErrorResponder(reqCtx, msg)
@@ -1976,10 +2140,18 @@ func (a *api) onPostStateTransaction(reqCtx *fasthttp.RequestCtx) {
 				log.Debug(err)
 				return
 			}
+			err = stateLoader.ValidateOperationMetadata(storeName, upsertReq.Metadata)
+			if err != nil {
+				msg := messages.ErrStateInvalidMetadata.WithFormat(storeName, err)
+				universalFastHTTPErrorResponder(reqCtx, msg)
+				log.Debug(msg)
+				return
+			}
+			stateLoader.SyncSetRequestContentType(&upsertReq)
 			operations = append(operations, upsertReq)
 		case string(state.OperationDelete):
 			var delReq state.DeleteRequest
-			err := mapstructure.Decode(o.Request, &delReq)
+			err := decodeStateTransactionRequest(o.Request, &delReq)
 			if err != nil {
 				msg := messages.ErrMalformedRequest.WithFormat(err)
 				universalFastHTTPErrorResponder(reqCtx, msg)
@@ -1993,6 +2165,13 @@ func (a *api) onPostStateTransaction(reqCtx *fasthttp.RequestCtx) {
 				log.Debug(msg)
 				return
 			}
+			err = stateLoader.ValidateOperationMetadata(storeName, delReq.Metadata)
+			if err != nil {
+				msg := messages.ErrStateInvalidMetadata.WithFormat(storeName, err)
+				universalFastHTTPErrorResponder(reqCtx, msg)
+				log.Debug(msg)
+				return
+			}
 			operations = append(operations, delReq)
 		default:
 			msg := NewErrorResponse(
@@ -2066,6 +2245,7 @@ func (a *api) onPostStateTransaction(reqCtx *fasthttp.RequestCtx) {
 	elapsed := diag.ElapsedSince(start)
 
 	diag.DefaultComponentMonitoring.StateInvoked(context.Background(), storeName, diag.StateTransaction, err == nil, elapsed)
+	diag.DefaultComponentMonitoring.OperationFailed(context.Background(), storeName, diag.StateTransaction, err)
 
 	if err != nil {
 		msg := NewErrorResponse("ERR_STATE_TRANSACTION", fmt.Sprintf(messages.ErrStateTransaction, err.Error()))
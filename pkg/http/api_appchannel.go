@@ -0,0 +1,49 @@
+/*
+Copyright 2023 The Dapr Authors
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package http
+
+import (
+	"net/http"
+
+	"github.com/dapr/dapr/pkg/http/endpoints"
+)
+
+func (a *api) constructAppChannelEndpoints() []endpoints.Endpoint {
+	return []endpoints.Endpoint{
+		{
+			Methods: []string{http.MethodPost},
+			Route:   "appchannel/reset",
+			Version: apiVersionV1alpha1,
+			Group: &endpoints.EndpointGroup{
+				Name:                 endpoints.EndpointGroupAppChannel,
+				Version:              endpoints.EndpointGroupVersion1alpha1,
+				AppendSpanAttributes: nil, // TODO
+			},
+			Handler: a.onResetAppChannel,
+			Settings: endpoints.EndpointSettings{
+				Name: "ResetAppChannel",
+			},
+		},
+	}
+}
+
+func (a *api) onResetAppChannel(w http.ResponseWriter, r *http.Request) {
+	err := a.universal.ResetAppChannel(r.Context())
+	if err != nil {
+		respondWithError(w, err)
+		return
+	}
+
+	respondWithEmpty(w)
+}
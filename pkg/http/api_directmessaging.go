@@ -145,8 +145,15 @@ func (a *api) onDirectMessage(w http.ResponseWriter, r *http.Request) {
 	}
 	defer req.Close()
 
+	ctx, cancel, err := invokev1.WithInvokeTimeout(r.Context(), req, a.universal.MaxInvokeTimeout)
+	if err != nil {
+		respondWithError(w, messages.ErrDirectInvokeTimeout.WithFormat(err))
+		return
+	}
+	defer cancel()
+
 	policyRunner := resiliency.NewRunnerWithOptions(
-		r.Context(), policyDef,
+		ctx, policyDef,
 		resiliency.RunnerOpts[*invokev1.InvokeMethodResponse]{
 			Disposer: resiliency.DisposerCloser[*invokev1.InvokeMethodResponse],
 		},
@@ -52,6 +52,18 @@ func (a *api) constructHealthzEndpoints() []endpoints.Endpoint {
 				IsHealthCheck: true,
 			},
 		},
+		{
+			Methods: []string{http.MethodGet},
+			Route:   "healthz/workflows",
+			Version: apiVersionV1,
+			Group:   endpointGroupHealthzV1,
+			Handler: a.onGetWorkflowsHealthz,
+			Settings: endpoints.EndpointSettings{
+				Name:          "HealthzWorkflows",
+				AlwaysAllowed: true,
+				IsHealthCheck: true,
+			},
+		},
 	}
 }
 
@@ -86,3 +98,15 @@ func (a *api) onGetOutboundHealthz(w http.ResponseWriter, r *http.Request) {
 
 	respondWithEmpty(w)
 }
+
+func (a *api) onGetWorkflowsHealthz(w http.ResponseWriter, r *http.Request) {
+	// The workflow engine is ready independently of the sidecar, as its backend is started only when the app connects
+	if !a.readyStatus || a.isWorkflowReadyFn == nil || !a.isWorkflowReadyFn() {
+		msg := messages.ErrWorkflowHealthNotReady
+		respondWithError(w, msg)
+		log.Debug(msg)
+		return
+	}
+
+	respondWithEmpty(w)
+}
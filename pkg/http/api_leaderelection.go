@@ -0,0 +1,89 @@
+/*
+Copyright 2023 The Dapr Authors
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package http
+
+import (
+	"net/http"
+
+	"github.com/go-chi/chi/v5"
+	"google.golang.org/protobuf/types/known/emptypb"
+
+	"github.com/dapr/dapr/pkg/http/endpoints"
+	runtimev1pb "github.com/dapr/dapr/pkg/proto/runtime/v1"
+)
+
+func (a *api) constructLeaderElectionEndpoints() []endpoints.Endpoint {
+	return []endpoints.Endpoint{
+		{
+			Methods: []string{http.MethodPost},
+			Route:   "leaderelection/{name}/campaign",
+			Version: apiVersionV1alpha1,
+			Group: &endpoints.EndpointGroup{
+				Name:                 endpoints.EndpointGroupLeaderElection,
+				Version:              endpoints.EndpointGroupVersion1alpha1,
+				AppendSpanAttributes: nil, // TODO
+			},
+			Handler: a.onCampaignLeadership(),
+			Settings: endpoints.EndpointSettings{
+				Name: "CampaignLeadership",
+			},
+		},
+		{
+			Methods: []string{http.MethodPost},
+			Route:   "leaderelection/{name}/resign",
+			Version: apiVersionV1alpha1,
+			Group: &endpoints.EndpointGroup{
+				Name:                 endpoints.EndpointGroupLeaderElection,
+				Version:              endpoints.EndpointGroupVersion1alpha1,
+				AppendSpanAttributes: nil, // TODO
+			},
+			Handler: a.onResignLeadership(),
+			Settings: endpoints.EndpointSettings{
+				Name: "ResignLeadership",
+			},
+		},
+	}
+}
+
+// Route: POST "leaderelection/{name}/campaign"
+func (a *api) onCampaignLeadership() http.HandlerFunc {
+	return UniversalHTTPHandler(
+		a.universal.CampaignLeadershipAlpha1,
+		UniversalHTTPHandlerOpts[*runtimev1pb.CampaignLeadershipRequest, *runtimev1pb.CampaignLeadershipResponse]{
+			InModifier: func(r *http.Request, in *runtimev1pb.CampaignLeadershipRequest) (*runtimev1pb.CampaignLeadershipRequest, error) {
+				in.Election = chi.URLParam(r, nameParam)
+				return in, nil
+			},
+			// We need to emit unpopulated fields in the response
+			ProtoResponseEmitUnpopulated: true,
+		},
+	)
+}
+
+// Route: POST "leaderelection/{name}/resign"
+func (a *api) onResignLeadership() http.HandlerFunc {
+	return UniversalHTTPHandler(
+		a.universal.ResignLeadershipAlpha1,
+		UniversalHTTPHandlerOpts[*runtimev1pb.ResignLeadershipRequest, *emptypb.Empty]{
+			InModifier: func(r *http.Request, in *runtimev1pb.ResignLeadershipRequest) (*runtimev1pb.ResignLeadershipRequest, error) {
+				in.Election = chi.URLParam(r, nameParam)
+				return in, nil
+			},
+			OutModifier: func(out *emptypb.Empty) (any, error) {
+				// Nullify the response so status code is 204
+				return nil, nil
+			},
+		},
+	)
+}
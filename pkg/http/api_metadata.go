@@ -16,6 +16,7 @@ package http
 import (
 	"io"
 	"net/http"
+	"time"
 
 	"github.com/go-chi/chi/v5"
 	"google.golang.org/protobuf/types/known/emptypb"
@@ -43,6 +44,16 @@ func (a *api) constructMetadataEndpoints() []endpoints.Endpoint {
 				Name: "GetMetadata",
 			},
 		},
+		{
+			Methods: []string{http.MethodGet},
+			Route:   "metadata/boot-report",
+			Version: apiVersionV1,
+			Group:   endpointGroupMetadataV1,
+			Handler: a.onGetBootReport,
+			Settings: endpoints.EndpointSettings{
+				Name: "GetBootReport",
+			},
+		},
 		{
 			Methods: []string{http.MethodPut},
 			Route:   "metadata/{key}",
@@ -67,11 +78,29 @@ func (a *api) onGetMetadata() http.HandlerFunc {
 					ID:       out.GetId(),
 					Extended: out.GetExtendedMetadata(),
 					// We can embed the proto object directly only for as long as the protojson key is == json key
-					ActiveActorsCount:    out.GetActiveActorsCount(), //nolint:staticcheck
-					RegisteredComponents: out.GetRegisteredComponents(),
-					HTTPEndpoints:        out.GetHttpEndpoints(),
-					RuntimeVersion:       out.GetRuntimeVersion(),
-					EnabledFeatures:      out.GetEnabledFeatures(),
+					ActiveActorsCount: out.GetActiveActorsCount(), //nolint:staticcheck
+					HTTPEndpoints:     out.GetHttpEndpoints(),
+					RuntimeVersion:    out.GetRuntimeVersion(),
+					EnabledFeatures:   out.GetEnabledFeatures(),
+				}
+
+				// Copy the components into a custom struct, so the stats reported by the components use camelCase keys and the last success time is serialized as a RFC 3339 string
+				if len(out.GetRegisteredComponents()) > 0 {
+					comps := make([]metadataResponseComponent, len(out.GetRegisteredComponents()))
+					for i, v := range out.GetRegisteredComponents() {
+						comps[i] = metadataResponseComponent{
+							Name:            v.GetName(),
+							Type:            v.GetType(),
+							Version:         v.GetVersion(),
+							Capabilities:    v.GetCapabilities(),
+							OpenConnections: v.GetOpenConnections(),
+							LastError:       v.GetLastError(),
+						}
+						if v.GetLastSuccessTime() != nil {
+							comps[i].LastSuccessTime = v.GetLastSuccessTime().AsTime().Format(time.RFC3339Nano)
+						}
+					}
+					res.RegisteredComponents = comps
 				}
 
 				// Copy the app connection properties into a custom struct
@@ -131,6 +160,15 @@ func (a *api) onGetMetadata() http.HandlerFunc {
 	)
 }
 
+func (a *api) onGetBootReport(w http.ResponseWriter, r *http.Request) {
+	report, err := a.universal.GetBootReport()
+	if err != nil {
+		respondWithError(w, err)
+		return
+	}
+	respondWithJSON(w, http.StatusOK, report)
+}
+
 func (a *api) onPutMetadata() http.HandlerFunc {
 	return UniversalHTTPHandler(
 		a.universal.SetMetadata,
@@ -160,7 +198,7 @@ type metadataResponse struct {
 	RuntimeVersion          string                                  `json:"runtimeVersion,omitempty"`
 	EnabledFeatures         []string                                `json:"enabledFeatures,omitempty"`
 	ActiveActorsCount       []*runtimev1pb.ActiveActorsCount        `json:"actors,omitempty"`
-	RegisteredComponents    []*runtimev1pb.RegisteredComponents     `json:"components,omitempty"`
+	RegisteredComponents    []metadataResponseComponent             `json:"components,omitempty"`
 	Extended                map[string]string                       `json:"extended,omitempty"`
 	Subscriptions           []metadataResponsePubsubSubscription    `json:"subscriptions,omitempty"`
 	HTTPEndpoints           []*runtimev1pb.MetadataHTTPEndpoint     `json:"httpEndpoints,omitempty"`
@@ -175,6 +213,16 @@ type metadataActorRuntime struct {
 	Placement    string                           `json:"placement,omitempty"`
 }
 
+type metadataResponseComponent struct {
+	Name            string   `json:"name,omitempty"`
+	Type            string   `json:"type,omitempty"`
+	Version         string   `json:"version,omitempty"`
+	Capabilities    []string `json:"capabilities,omitempty"`
+	OpenConnections int64    `json:"openConnections,omitempty"`
+	LastSuccessTime string   `json:"lastSuccessTime,omitempty"`
+	LastError       string   `json:"lastError,omitempty"`
+}
+
 type metadataResponsePubsubSubscription struct {
 	PubsubName      string                                   `json:"pubsubname"`
 	Topic           string                                   `json:"topic"`
@@ -0,0 +1,55 @@
+/*
+Copyright 2023 The Dapr Authors
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package http
+
+import (
+	"net/http"
+
+	"google.golang.org/protobuf/types/known/emptypb"
+
+	"github.com/dapr/dapr/pkg/http/endpoints"
+	runtimev1pb "github.com/dapr/dapr/pkg/proto/runtime/v1"
+)
+
+func (a *api) constructMetricsEndpoints() []endpoints.Endpoint {
+	return []endpoints.Endpoint{
+		{
+			Methods: []string{http.MethodPost},
+			Route:   "metrics",
+			Version: apiVersionV1alpha1,
+			Group: &endpoints.EndpointGroup{
+				Name:                 endpoints.EndpointGroupMetrics,
+				Version:              endpoints.EndpointGroupVersion1alpha1,
+				AppendSpanAttributes: nil, // TODO
+			},
+			Handler: a.onRecordMetrics(),
+			Settings: endpoints.EndpointSettings{
+				Name: "RecordMetrics",
+			},
+		},
+	}
+}
+
+// Route: POST "metrics"
+func (a *api) onRecordMetrics() http.HandlerFunc {
+	return UniversalHTTPHandler(
+		a.universal.RecordMetricsAlpha1,
+		UniversalHTTPHandlerOpts[*runtimev1pb.RecordMetricsRequest, *emptypb.Empty]{
+			OutModifier: func(out *emptypb.Empty) (any, error) {
+				// Nullify the response so status code is 204
+				return nil, nil
+			},
+		},
+	)
+}
@@ -0,0 +1,239 @@
+/*
+Copyright 2023 The Dapr Authors
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package http
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/google/uuid"
+
+	"github.com/dapr/components-contrib/contenttype"
+	"github.com/dapr/dapr/pkg/http/endpoints"
+	"github.com/dapr/dapr/pkg/messages"
+	invokev1 "github.com/dapr/dapr/pkg/messaging/v1"
+	runtimev1pb "github.com/dapr/dapr/pkg/proto/runtime/v1"
+	"github.com/dapr/dapr/pkg/resiliency"
+)
+
+const (
+	stateChangeSubscriptionIDParam = "subscriptionID"
+	stateChangeKeyPrefixParam      = "keyPrefix"
+)
+
+// stateChangeSubscriptions are the subscriptions to the change feeds of the state stores made with the HTTP API, by ID.
+type stateChangeSubscriptions struct {
+	lock          sync.Mutex
+	subscriptions map[string]stateChangeSubscription
+}
+
+type stateChangeSubscription struct {
+	storeName string
+	cancel    context.CancelFunc
+}
+
+type subscribeStateChangesResponse struct {
+	ID string `json:"id"`
+}
+
+// stateChangeCloudEvent is the CloudEvent of a change of a key, delivered to the app.
+type stateChangeCloudEvent struct {
+	ID              string          `json:"id"`
+	Source          string          `json:"source"`
+	Type            string          `json:"type"`
+	SpecVersion     string          `json:"specversion"`
+	Subject         string          `json:"subject"`
+	Time            string          `json:"time"`
+	DataContentType string          `json:"datacontenttype,omitempty"`
+	Data            json.RawMessage `json:"data,omitempty"`
+	DataBase64      string          `json:"data_base64,omitempty"`
+	ETag            string          `json:"etag,omitempty"`
+}
+
+func (a *api) constructStateChangesEndpoints() []endpoints.Endpoint {
+	return []endpoints.Endpoint{
+		{
+			Methods: []string{http.MethodPost},
+			Route:   "state/{storeName}/changes/subscribe",
+			Version: apiVersionV1alpha1,
+			Group: &endpoints.EndpointGroup{
+				Name:                 endpoints.EndpointGroupState,
+				Version:              endpoints.EndpointGroupVersion1alpha1,
+				AppendSpanAttributes: appendStateSpanAttributes,
+			},
+			Handler: a.onSubscribeStateChanges,
+			Settings: endpoints.EndpointSettings{
+				Name: "SubscribeStateChangesAlpha1",
+			},
+		},
+		{
+			Methods: []string{http.MethodPost},
+			Route:   "state/{storeName}/changes/{subscriptionID}/unsubscribe",
+			Version: apiVersionV1alpha1,
+			Group: &endpoints.EndpointGroup{
+				Name:                 endpoints.EndpointGroupState,
+				Version:              endpoints.EndpointGroupVersion1alpha1,
+				AppendSpanAttributes: appendStateSpanAttributes,
+			},
+			Handler: a.onUnsubscribeStateChanges,
+			Settings: endpoints.EndpointSettings{
+				Name: "UnsubscribeStateChangesAlpha1",
+			},
+		},
+	}
+}
+
+// Route: POST "state/{storeName}/changes/subscribe"
+// The changes are delivered to the app with POST requests to "/state/{storeName}/changes", as CloudEvents, until the app unsubscribes.
+func (a *api) onSubscribeStateChanges(w http.ResponseWriter, r *http.Request) {
+	if a.channels.AppChannel() == nil {
+		err := messages.ErrAppChannelNotConfigured
+		log.Debug(err)
+		respondWithError(w, err)
+		return
+	}
+
+	storeName := chi.URLParam(r, storeNameParam)
+	subscribe, err := a.universal.SubscribeStateChanges(&runtimev1pb.SubscribeStateChangesRequest{
+		StoreName: storeName,
+		KeyPrefix: r.URL.Query().Get(stateChangeKeyPrefixParam),
+		Metadata:  getMetadataFromRequest(r),
+	})
+	if err != nil {
+		// Error has already been logged
+		respondWithError(w, err)
+		return
+	}
+
+	// The subscription outlives the request
+	id := uuid.NewString()
+	ctx, cancel := context.WithCancel(context.Background())
+	a.stateChangeSubscriptions.lock.Lock()
+	a.stateChangeSubscriptions.subscriptions[id] = stateChangeSubscription{storeName: storeName, cancel: cancel}
+	a.stateChangeSubscriptions.lock.Unlock()
+
+	go func() {
+		defer a.removeStateChangeSubscription(id)
+		err := subscribe(ctx, func(event *runtimev1pb.StateChangeEvent) error {
+			a.sendStateChangeEvent(ctx, storeName, event)
+			return nil
+		})
+		if err != nil {
+			log.Errorf("Subscription %s to the changes of state store %s failed: %v", id, storeName, err)
+		}
+	}()
+
+	respondWithJSON(w, http.StatusOK, subscribeStateChangesResponse{ID: id})
+}
+
+// Route: POST "state/{storeName}/changes/{subscriptionID}/unsubscribe"
+func (a *api) onUnsubscribeStateChanges(w http.ResponseWriter, r *http.Request) {
+	storeName := chi.URLParam(r, storeNameParam)
+	id := chi.URLParam(r, stateChangeSubscriptionIDParam)
+
+	a.stateChangeSubscriptions.lock.Lock()
+	subscription, ok := a.stateChangeSubscriptions.subscriptions[id]
+	a.stateChangeSubscriptions.lock.Unlock()
+	if !ok || subscription.storeName != storeName {
+		err := messages.ErrStateSubscriptionNotFound.WithFormat(id, storeName)
+		log.Debug(err)
+		respondWithError(w, err)
+		return
+	}
+
+	a.removeStateChangeSubscription(id)
+	respondWithEmpty(w)
+}
+
+func (a *api) removeStateChangeSubscription(id string) {
+	a.stateChangeSubscriptions.lock.Lock()
+	defer a.stateChangeSubscriptions.lock.Unlock()
+
+	if subscription, ok := a.stateChangeSubscriptions.subscriptions[id]; ok {
+		subscription.cancel()
+		delete(a.stateChangeSubscriptions.subscriptions, id)
+	}
+}
+
+// sendStateChangeEvent delivers the CloudEvent of a change to the app, retrying with the inbound resiliency policy of the state store.
+// Changes that can't be delivered are logged and skipped, like the updates of the configuration stores, so a failing app doesn't stop the subscription.
+func (a *api) sendStateChangeEvent(ctx context.Context, storeName string, event *runtimev1pb.StateChangeEvent) {
+	appChannel := a.channels.AppChannel()
+	if appChannel == nil {
+		log.Errorf("App channel is not initialized: unable to send the change %s of state store %s", event.GetId(), storeName)
+		return
+	}
+
+	body, err := json.Marshal(newStateChangeCloudEvent(event))
+	if err != nil {
+		log.Errorf("Failed to encode the change %s of state store %s: %v", event.GetId(), storeName, err)
+		return
+	}
+
+	policyDef := a.universal.Resiliency.ComponentInboundPolicy(storeName, resiliency.Statestore)
+	req := invokev1.NewInvokeMethodRequest("/state/"+storeName+"/changes").
+		WithHTTPExtension(http.MethodPost, "").
+		WithRawDataBytes(body).
+		WithContentType(contenttype.CloudEventContentType)
+	if policyDef != nil {
+		req.WithReplay(policyDef.HasRetries())
+	}
+	defer req.Close()
+
+	policyRunner := resiliency.NewRunner[struct{}](ctx, policyDef)
+	_, err = policyRunner(func(ctx context.Context) (struct{}, error) {
+		resp, rErr := appChannel.InvokeMethod(ctx, req, "")
+		if rErr != nil {
+			return struct{}{}, rErr
+		}
+		if resp != nil {
+			defer resp.Close()
+			if code := resp.Status().GetCode(); code < http.StatusOK || code >= http.StatusMultipleChoices {
+				return struct{}{}, fmt.Errorf("error sending the change to the app, status %d", code)
+			}
+		}
+		return struct{}{}, nil
+	})
+	if err != nil && ctx.Err() == nil {
+		log.Errorf("Failed to send the change %s of state store %s to the app: %v", event.GetId(), storeName, err)
+	}
+}
+
+func newStateChangeCloudEvent(event *runtimev1pb.StateChangeEvent) stateChangeCloudEvent {
+	ce := stateChangeCloudEvent{
+		ID:              event.GetId(),
+		Source:          event.GetSource(),
+		Type:            event.GetType(),
+		SpecVersion:     event.GetSpecVersion(),
+		Subject:         event.GetSubject(),
+		Time:            event.GetTime().AsTime().Format(time.RFC3339Nano),
+		DataContentType: event.GetDataContentType(),
+		ETag:            event.GetEtag(),
+	}
+	if data := event.GetData(); len(data) > 0 {
+		if contenttype.IsJSONContentType(ce.DataContentType) && json.Valid(data) {
+			ce.Data = bytes.Clone(data)
+		} else {
+			ce.DataBase64 = base64.StdEncoding.EncodeToString(data)
+		}
+	}
+	return ce
+}
@@ -0,0 +1,149 @@
+/*
+Copyright 2023 The Dapr Authors
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package http
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/dapr/components-contrib/state"
+	channelt "github.com/dapr/dapr/pkg/channel/testing"
+	stateLoader "github.com/dapr/dapr/pkg/components/state"
+	"github.com/dapr/dapr/pkg/grpc/universalapi"
+	invokev1 "github.com/dapr/dapr/pkg/messaging/v1"
+	"github.com/dapr/dapr/pkg/resiliency"
+	"github.com/dapr/dapr/pkg/runtime/channels"
+	"github.com/dapr/dapr/pkg/runtime/compstore"
+	daprt "github.com/dapr/dapr/pkg/testing"
+	"github.com/dapr/kit/logger"
+	"github.com/dapr/kit/ptr"
+)
+
+// changeFeedStateStore is a state store that reports the given changes, then waits for the subscription to be canceled.
+type changeFeedStateStore struct {
+	*daprt.FakeStateStore
+	changes []*stateLoader.Change
+	doneCh  chan struct{}
+}
+
+func (s *changeFeedStateStore) Features() []state.Feature {
+	return []state.Feature{stateLoader.FeatureChangeFeed}
+}
+
+func (s *changeFeedStateStore) SubscribeChanges(ctx context.Context, req stateLoader.ChangeFeedRequest, handler stateLoader.ChangeFeedHandler) error {
+	defer close(s.doneCh)
+	for _, c := range s.changes {
+		if err := handler(ctx, c); err != nil {
+			return err
+		}
+	}
+	<-ctx.Done()
+	return nil
+}
+
+func TestV1Alpha1StateChanges(t *testing.T) {
+	feeder := &changeFeedStateStore{
+		FakeStateStore: daprt.NewFakeStateStore(),
+		changes: []*stateLoader.Change{
+			{ID: "1", Key: "fakeAPI||orders-1", Type: stateLoader.ChangeUpsert, Value: []byte(`{"a":"b"}`)},
+			{ID: "2", Key: "fakeAPI||orders-2", Type: stateLoader.ChangeUpsert, Value: []byte("text"), ContentType: ptr.Of("text/plain")},
+		},
+		doneCh: make(chan struct{}),
+	}
+	require.NoError(t, stateLoader.SaveStateConfiguration("feed", map[string]string{"changeFeed": "true"}))
+
+	var (
+		lock     sync.Mutex
+		received []map[string]any
+	)
+	mockAppChannel := new(channelt.MockAppChannel)
+	mockAppChannel.On("InvokeMethod", mock.Anything, mock.Anything, "").
+		Run(func(args mock.Arguments) {
+			req := args.Get(1).(*invokev1.InvokeMethodRequest)
+			assert.Equal(t, "/state/feed/changes", req.Message().GetMethod())
+			assert.Equal(t, "application/cloudevents+json", req.ContentType())
+			data, err := req.RawDataFull()
+			require.NoError(t, err)
+			var event map[string]any
+			require.NoError(t, json.Unmarshal(data, &event))
+			lock.Lock()
+			received = append(received, event)
+			lock.Unlock()
+		}).
+		Return(invokev1.NewInvokeMethodResponse(http.StatusOK, "OK", nil), nil)
+
+	compStore := compstore.New()
+	compStore.AddStateStore("feed", feeder)
+	compStore.AddStateStore("unsupported", daprt.NewFakeStateStore())
+	fakeServer := newFakeHTTPServer()
+	testAPI := &api{
+		universal: &universalapi.UniversalAPI{
+			AppID:      "fakeAPI",
+			Logger:     logger.NewLogger("fakeLogger"),
+			CompStore:  compStore,
+			Resiliency: resiliency.New(nil),
+		},
+		channels: (new(channels.Channels)).WithAppChannel(mockAppChannel),
+		stateChangeSubscriptions: stateChangeSubscriptions{
+			subscriptions: map[string]stateChangeSubscription{},
+		},
+	}
+	fakeServer.StartServer(testAPI.constructStateChangesEndpoints(), nil)
+	defer fakeServer.Shutdown()
+
+	t.Run("changes are delivered to the app until it unsubscribes", func(t *testing.T) {
+		resp := fakeServer.DoRequest(http.MethodPost, "v1.0-alpha1/state/feed/changes/subscribe", nil, map[string]string{"keyPrefix": "orders-"})
+		require.Equal(t, http.StatusOK, resp.StatusCode)
+		id := resp.JSONBody.(map[string]any)["id"].(string)
+		require.NotEmpty(t, id)
+
+		assert.EventuallyWithT(t, func(c *assert.CollectT) {
+			lock.Lock()
+			defer lock.Unlock()
+			if assert.Len(c, received, 2) {
+				assert.Equal(c, "1", received[0]["id"])
+				assert.Equal(c, "com.dapr.state.upsert", received[0]["type"])
+				assert.Equal(c, "orders-1", received[0]["subject"])
+				assert.Equal(c, map[string]any{"a": "b"}, received[0]["data"])
+				assert.Equal(c, "dGV4dA==", received[1]["data_base64"])
+			}
+		}, 5*time.Second, 10*time.Millisecond)
+
+		resp = fakeServer.DoRequest(http.MethodPost, "v1.0-alpha1/state/feed/changes/"+id+"/unsubscribe", nil, nil)
+		assert.Equal(t, http.StatusNoContent, resp.StatusCode)
+		select {
+		case <-feeder.doneCh:
+		case <-time.After(5 * time.Second):
+			t.Fatal("the subscription was not canceled")
+		}
+
+		resp = fakeServer.DoRequest(http.MethodPost, "v1.0-alpha1/state/feed/changes/"+id+"/unsubscribe", nil, nil)
+		assert.Equal(t, http.StatusNotFound, resp.StatusCode)
+		assert.Equal(t, "ERR_STATE_SUBSCRIPTION_NOT_FOUND", resp.ErrorBody["errorCode"])
+	})
+
+	t.Run("change feed not supported", func(t *testing.T) {
+		resp := fakeServer.DoRequest(http.MethodPost, "v1.0-alpha1/state/unsupported/changes/subscribe", nil, nil)
+		assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+		assert.Equal(t, "ERR_STATE_CHANGE_FEED_NOT_SUPPORTED", resp.ErrorBody["errorCode"])
+	})
+}
@@ -23,6 +23,7 @@ import (
 	"io"
 	"net"
 	gohttp "net/http"
+	"strings"
 	"testing"
 	"time"
 
@@ -50,6 +51,7 @@ import (
 	httpEndpointsV1alpha1 "github.com/dapr/dapr/pkg/apis/httpEndpoint/v1alpha1"
 	"github.com/dapr/dapr/pkg/apis/resiliency/v1alpha1"
 	"github.com/dapr/dapr/pkg/channel/http"
+	"github.com/dapr/dapr/pkg/components"
 	httpMiddlewareLoader "github.com/dapr/dapr/pkg/components/middleware/http"
 	"github.com/dapr/dapr/pkg/config"
 	diag "github.com/dapr/dapr/pkg/diagnostics"
@@ -64,6 +66,7 @@ import (
 	"github.com/dapr/dapr/pkg/runtime/channels"
 	"github.com/dapr/dapr/pkg/runtime/compstore"
 	runtimePubsub "github.com/dapr/dapr/pkg/runtime/pubsub"
+	"github.com/dapr/dapr/pkg/runtime/wfengine"
 	daprt "github.com/dapr/dapr/pkg/testing"
 	testtrace "github.com/dapr/dapr/pkg/testing/trace"
 	"github.com/dapr/dapr/utils"
@@ -162,6 +165,12 @@ func TestPubSubEndpoints(t *testing.T) {
 
 				return nil
 			},
+			TopicBacklogFn: func(ctx context.Context, pubsubName, topic string) (int64, bool) {
+				if topic == "backlogtopic" {
+					return 17, true
+				}
+				return 0, false
+			},
 		},
 	}
 
@@ -174,6 +183,16 @@ func TestPubSubEndpoints(t *testing.T) {
 
 	fakeServer.StartServer(testAPI.constructPubSubEndpoints(), nil)
 
+	t.Run("Publish with topic backlog - 204 No Content", func(t *testing.T) {
+		resp := fakeServer.DoRequest("POST", apiVersionV1+"/publish/pubsubname/backlogtopic", []byte(`{"key": "value"}`), nil)
+		assert.Equal(t, 204, resp.StatusCode)
+		assert.Equal(t, "17", resp.RawHeader.Get(runtimePubsub.BacklogHeader))
+
+		resp = fakeServer.DoRequest("POST", apiVersionV1+"/publish/pubsubname/topic", []byte(`{"key": "value"}`), nil)
+		assert.Equal(t, 204, resp.StatusCode)
+		assert.Empty(t, resp.RawHeader.Get(runtimePubsub.BacklogHeader))
+	})
+
 	t.Run("Publish successfully - 204 No Content", func(t *testing.T) {
 		apiPath := fmt.Sprintf("%s/publish/pubsubname/topic", apiVersionV1)
 		testMethods := []string{"POST", "PUT"}
@@ -1833,6 +1852,15 @@ func TestV1MetadataEndpoint(t *testing.T) {
 				capsMap["MockComponent2Name"] = []string{"mock.feat.MockComponent2Name"}
 				return capsMap
 			},
+			GetComponentsStatsFn: func() map[string]components.ComponentStats {
+				return map[string]components.ComponentStats{
+					"MockComponent2Name": {
+						OpenConnections: 3,
+						LastSuccessTime: time.Date(2023, 7, 1, 12, 0, 0, 0, time.UTC),
+						LastError:       errors.New("connection reset"),
+					},
+				}
+			},
 			ExtendedMetadata: map[string]string{
 				"test": "value",
 			},
@@ -1851,7 +1879,7 @@ func TestV1MetadataEndpoint(t *testing.T) {
 		assert.Equal(t, 204, resp.StatusCode)
 	})
 
-	const expectedBody = `{"id":"xyz","runtimeVersion":"edge","actors":[{"type":"abcd","count":10},{"type":"xyz","count":5}],"components":[{"name":"MockComponent1Name","type":"mock.component1Type","version":"v1.0","capabilities":["mock.feat.MockComponent1Name"]},{"name":"MockComponent2Name","type":"mock.component2Type","version":"v1.0","capabilities":["mock.feat.MockComponent2Name"]}],"extended":{"daprRuntimeVersion":"edge","foo":"bar","test":"value"},"subscriptions":[{"pubsubname":"test","topic":"topic","rules":[{"path":"path"}],"deadLetterTopic":"dead"}],"httpEndpoints":[{"name":"MockHTTPEndpoint"}],"appConnectionProperties":{"port":5000,"protocol":"http","channelAddress":"1.2.3.4","maxConcurrency":10,"health":{"healthCheckPath":"/healthz","healthProbeInterval":"10s","healthProbeTimeout":"5s","healthThreshold":3}},"actorRuntime":{"runtimeStatus":"RUNNING","activeActors":[{"type":"abcd","count":10},{"type":"xyz","count":5}],"hostReady":true}}`
+	const expectedBody = `{"id":"xyz","runtimeVersion":"edge","actors":[{"type":"abcd","count":10},{"type":"xyz","count":5}],"components":[{"name":"MockComponent1Name","type":"mock.component1Type","version":"v1.0","capabilities":["mock.feat.MockComponent1Name"]},{"name":"MockComponent2Name","type":"mock.component2Type","version":"v1.0","capabilities":["mock.feat.MockComponent2Name"],"openConnections":3,"lastSuccessTime":"2023-07-01T12:00:00Z","lastError":"connection reset"}],"extended":{"daprCryptoBackend":"go","daprRuntimeVersion":"edge","foo":"bar","test":"value"},"subscriptions":[{"pubsubname":"test","topic":"topic","rules":[{"path":"path"}],"deadLetterTopic":"dead"}],"httpEndpoints":[{"name":"MockHTTPEndpoint"}],"appConnectionProperties":{"port":5000,"protocol":"http","channelAddress":"1.2.3.4","maxConcurrency":10,"health":{"healthCheckPath":"/healthz","healthProbeInterval":"10s","healthProbeTimeout":"5s","healthThreshold":3}},"actorRuntime":{"runtimeStatus":"RUNNING","activeActors":[{"type":"abcd","count":10},{"type":"xyz","count":5}],"hostReady":true}}`
 
 	t.Run("Get Metadata", func(t *testing.T) {
 		resp := fakeServer.DoRequest("GET", "v1.0/metadata", nil, nil)
@@ -1872,6 +1900,55 @@ func TestV1MetadataEndpoint(t *testing.T) {
 	fakeServer.Shutdown()
 }
 
+func TestV1MetadataBootReportEndpoint(t *testing.T) {
+	fakeServer := newFakeHTTPServer()
+
+	testAPI := &api{
+		universal: &universalapi.UniversalAPI{
+			AppID:  "xyz",
+			Logger: logger.NewLogger("fakeLogger"),
+			GetBootReportFn: func() *universalapi.BootReport {
+				return &universalapi.BootReport{
+					AppID:          "xyz",
+					RuntimeVersion: "edge",
+					GoVersion:      "go1.21.5",
+					Mode:           "standalone",
+					Ready:          true,
+					StartTime:      time.Date(2023, 7, 1, 12, 0, 0, 0, time.UTC),
+					InitDurationMs: 1500,
+					Components: []universalapi.BootReportComponent{
+						{Name: "statestore", Type: "state.redis", Version: "v1", InitDurationMs: 120},
+					},
+					EnabledFeatures: []string{"feature1"},
+					Ports: universalapi.BootReportPorts{
+						HTTP:         3500,
+						GRPC:         50001,
+						InternalGRPC: 50002,
+						App:          5000,
+					},
+				}
+			},
+		},
+	}
+	testAPI.universal.InitUniversalAPI()
+
+	fakeServer.StartServer(testAPI.constructMetadataEndpoints(), nil)
+	defer fakeServer.Shutdown()
+
+	t.Run("Get boot report", func(t *testing.T) {
+		resp := fakeServer.DoRequest("GET", "v1.0/metadata/boot-report", nil, nil)
+		assert.Equal(t, 200, resp.StatusCode)
+		assert.JSONEq(t, `{"appID":"xyz","runtimeVersion":"edge","goVersion":"go1.21.5","mode":"standalone","ready":true,"startTime":"2023-07-01T12:00:00Z","initDurationMs":1500,"components":[{"name":"statestore","type":"state.redis","version":"v1","initDurationMs":120}],"enabledFeatures":["feature1"],"ports":{"http":3500,"grpc":50001,"internalGRPC":50002,"app":5000}}`, string(resp.RawBody))
+	})
+
+	t.Run("Boot report not available", func(t *testing.T) {
+		testAPI.universal.GetBootReportFn = nil
+		resp := fakeServer.DoRequest("GET", "v1.0/metadata/boot-report", nil, nil)
+		assert.Equal(t, 500, resp.StatusCode)
+		assert.Equal(t, "ERR_BOOT_REPORT_NOT_AVAILABLE", resp.ErrorBody["errorCode"])
+	})
+}
+
 func createExporters(buffer *string) {
 	exporter := testtrace.NewStringExporter(buffer, logger.NewLogger("fakeLogger"))
 	exporter.Register("fakeID")
@@ -2652,12 +2729,14 @@ func TestV1Beta1Workflow(t *testing.T) {
 	resiliencyConfig := resiliency.FromConfigurations(logger.NewLogger("workflow.test"), testResiliency)
 	compStore := compstore.New()
 	compStore.AddWorkflow(componentName, fakeWorkflowComponent)
+	compStore.AddWorkflow("historian", &fakeWorkflowHistorian{MockWorkflow: &daprt.MockWorkflow{}})
 	testAPI := &api{
 		universal: &universalapi.UniversalAPI{
 			Logger:     logger.NewLogger("fakeLogger"),
 			CompStore:  compStore,
 			Resiliency: resiliencyConfig,
 		},
+		maxRequestBodySize: 1 << 10,
 	}
 	testAPI.universal.InitUniversalAPI()
 	testAPI.universal.SetActorsInitDone()
@@ -2927,6 +3006,105 @@ func TestV1Beta1Workflow(t *testing.T) {
 		// assert
 		assert.Nil(t, resp.ErrorBody)
 	})
+
+	//////////////////////////////
+	// HISTORY AND REPLAY TESTS //
+	//////////////////////////////
+
+	t.Run("History without the built-in workflow engine", func(t *testing.T) {
+		apiPath := "v1.0-alpha1/workflows/dapr/instanceID/history"
+		resp := fakeServer.DoRequest("GET", apiPath, nil, nil)
+		assert.Equal(t, 400, resp.StatusCode)
+
+		// assert
+		assert.NotNil(t, resp.ErrorBody)
+		assert.Equal(t, "ERR_WORKFLOW_HISTORY_NOT_SUPPORTED", resp.ErrorBody["errorCode"])
+	})
+
+	t.Run("Replay with a component that doesn't support it", func(t *testing.T) {
+		apiPath := "v1.0-alpha1/workflows/dapr/instanceID/replay"
+		resp := fakeServer.DoRequest("POST", apiPath, []byte(`{"events":[]}`), nil)
+		assert.Equal(t, 400, resp.StatusCode)
+
+		// assert
+		assert.NotNil(t, resp.ErrorBody)
+		assert.Equal(t, "ERR_WORKFLOW_HISTORY_NOT_SUPPORTED", resp.ErrorBody["errorCode"])
+		assert.Equal(t, messages.ErrWorkflowHistoryNotSupported.WithFormat("dapr").Message(), resp.ErrorBody["message"])
+	})
+
+	t.Run("History with valid API path", func(t *testing.T) {
+		apiPath := "v1.0-alpha1/workflows/historian/instanceID/history"
+		resp := fakeServer.DoRequest("GET", apiPath, nil, nil)
+		assert.Equal(t, 200, resp.StatusCode)
+
+		// assert
+		assert.JSONEq(t, `{"instanceID":"instanceID","events":[{"eventId":1}]}`, string(resp.RawBody))
+	})
+
+	t.Run("Replay with valid API path", func(t *testing.T) {
+		apiPath := "v1.0-alpha1/workflows/historian/instanceID/replay"
+		resp := fakeServer.DoRequest("POST", apiPath, []byte(`{"events":[{"eventId":1},{"eventId":2}]}`), nil)
+		assert.Equal(t, 200, resp.StatusCode)
+
+		// assert
+		assert.JSONEq(t, `{"instanceID":"instanceID","episodes":2,"divergences":[]}`, string(resp.RawBody))
+	})
+
+	t.Run("Replay with malformed history", func(t *testing.T) {
+		apiPath := "v1.0-alpha1/workflows/historian/instanceID/replay"
+		resp := fakeServer.DoRequest("POST", apiPath, []byte(`{"events":`), nil)
+		assert.Equal(t, 400, resp.StatusCode)
+
+		// assert
+		assert.NotNil(t, resp.ErrorBody)
+		assert.Equal(t, "ERR_WORKFLOW_HISTORY_MALFORMED", resp.ErrorBody["errorCode"])
+	})
+
+	t.Run("Replay with history too large", func(t *testing.T) {
+		apiPath := "v1.0-alpha1/workflows/historian/instanceID/replay"
+		body := `{"events":[` + strings.Repeat(`{"eventId":1},`, 100) + `{"eventId":1}]}`
+		resp := fakeServer.DoRequest("POST", apiPath, []byte(body), nil)
+		assert.Equal(t, 413, resp.StatusCode)
+
+		// assert
+		assert.NotNil(t, resp.ErrorBody)
+		assert.Equal(t, "ERR_WORKFLOW_HISTORY_TOO_LARGE", resp.ErrorBody["errorCode"])
+	})
+
+	////////////////
+	// LIST TESTS //
+	////////////////
+
+	t.Run("List with invalid page size", func(t *testing.T) {
+		apiPath := "v1.0-alpha1/workflows/dapr?pageSize=abc"
+		resp := fakeServer.DoRequest("GET", apiPath, nil, nil)
+		assert.Equal(t, 400, resp.StatusCode)
+
+		// assert
+		assert.NotNil(t, resp.ErrorBody)
+		assert.Equal(t, "ERR_BAD_REQUEST", resp.ErrorBody["errorCode"])
+	})
+
+	t.Run("List with invalid creation time", func(t *testing.T) {
+		apiPath := "v1.0-alpha1/workflows/dapr?createdAfter=yesterday"
+		resp := fakeServer.DoRequest("GET", apiPath, nil, nil)
+		assert.Equal(t, 400, resp.StatusCode)
+
+		// assert
+		assert.NotNil(t, resp.ErrorBody)
+		assert.Equal(t, "ERR_BAD_REQUEST", resp.ErrorBody["errorCode"])
+	})
+
+	t.Run("List with a component that doesn't support it", func(t *testing.T) {
+		apiPath := "v1.0-alpha1/workflows/dapr?runtimeStatus=RUNNING&runtimeStatus=SUSPENDED"
+		resp := fakeServer.DoRequest("GET", apiPath, nil, nil)
+		assert.Equal(t, 400, resp.StatusCode)
+
+		// assert
+		assert.NotNil(t, resp.ErrorBody)
+		assert.Equal(t, "ERR_WORKFLOW_LIST_NOT_SUPPORTED", resp.ErrorBody["errorCode"])
+		assert.Equal(t, messages.ErrWorkflowListNotSupported.WithFormat("dapr").Message(), resp.ErrorBody["message"])
+	})
 }
 
 func buildHTTPPineline(spec config.PipelineSpec) httpMiddleware.Pipeline {
@@ -3072,6 +3250,25 @@ func TestSinglePipelineWithNoTracing(t *testing.T) {
 	})
 }
 
+// fakeWorkflowHistorian is a workflow component that can export and replay histories.
+type fakeWorkflowHistorian struct {
+	*daprt.MockWorkflow
+}
+
+func (f *fakeWorkflowHistorian) ExportHistory(ctx context.Context, instanceID string) (*wfengine.WorkflowHistory, error) {
+	return &wfengine.WorkflowHistory{
+		InstanceID: instanceID,
+		Events:     []json.RawMessage{json.RawMessage(`{"eventId":1}`)},
+	}, nil
+}
+
+func (f *fakeWorkflowHistorian) ReplayHistory(ctx context.Context, history *wfengine.WorkflowHistory) (*wfengine.ReplayReport, error) {
+	return &wfengine.ReplayReport{
+		InstanceID: history.InstanceID,
+		Episodes:   len(history.Events),
+	}, nil
+}
+
 // Fake http server and client helpers to simplify endpoints test.
 func newFakeHTTPServer() *fakeHTTPServer {
 	return &fakeHTTPServer{}
@@ -3336,6 +3533,33 @@ func TestV1StateEndpoints(t *testing.T) {
 		assert.Equal(t, etag, resp.RawHeader.Get("ETag"), "failed to read etag")
 	})
 
+	t.Run("Get state - If-None-Match matching ETag", func(t *testing.T) {
+		apiPath := fmt.Sprintf("v1.0/state/%s/good-key", storeName)
+		// act
+		resp := fakeServer.DoRequest("GET", apiPath, nil, nil, "If-None-Match", etag)
+		// assert
+		assert.Equal(t, 304, resp.StatusCode, "reading unchanged key should return 304")
+		assert.Equal(t, etag, resp.RawHeader.Get("ETag"), "failed to read etag")
+		assert.Empty(t, resp.RawBody, "Always give empty body with 304")
+	})
+
+	t.Run("Get state - If-None-Match wildcard", func(t *testing.T) {
+		apiPath := fmt.Sprintf("v1.0/state/%s/good-key", storeName)
+		// act
+		resp := fakeServer.DoRequest("GET", apiPath, nil, nil, "If-None-Match", "*")
+		// assert
+		assert.Equal(t, 304, resp.StatusCode, "reading existing key with wildcard should return 304")
+	})
+
+	t.Run("Get state - If-None-Match stale ETag", func(t *testing.T) {
+		apiPath := fmt.Sprintf("v1.0/state/%s/good-key", storeName)
+		// act
+		resp := fakeServer.DoRequest("GET", apiPath, nil, nil, "If-None-Match", `"stale"`)
+		// assert
+		assert.Equal(t, 200, resp.StatusCode, "reading changed key should succeed")
+		assert.Equal(t, etag, resp.RawHeader.Get("ETag"), "failed to read etag")
+	})
+
 	t.Run("Get state - Upstream error", func(t *testing.T) {
 		apiPath := fmt.Sprintf("v1.0/state/%s/error-key", storeName)
 		// act
@@ -3968,6 +4192,17 @@ func (c fakeStateStoreQuerier) MultiMaxSize() int {
 	return 10
 }
 
+// recordingTransactionalStore is a transactional state store that records the last transaction it received.
+type recordingTransactionalStore struct {
+	fakeStateStoreQuerier
+	lastRequest *state.TransactionalStateRequest
+}
+
+func (c *recordingTransactionalStore) Multi(ctx context.Context, request *state.TransactionalStateRequest) error {
+	c.lastRequest = request
+	return nil
+}
+
 func newFakeStateStoreQuerier() fakeStateStoreQuerier {
 	s := newFakeStateStore()
 	return fakeStateStoreQuerier{
@@ -4403,6 +4638,31 @@ func TestV1HealthzEndpoint(t *testing.T) {
 		assert.Equal(t, 204, resp.StatusCode)
 	})
 
+	t.Run("Healthz workflows - 500 no workflow engine", func(t *testing.T) {
+		apiPath := "v1.0/healthz/workflows"
+		testAPI.MarkStatusAsReady()
+		resp := fakeServer.DoRequest("GET", apiPath, nil, nil)
+		assert.Equal(t, 500, resp.StatusCode)
+		assert.Equal(t, "ERR_WORKFLOW_HEALTH_NOT_READY", resp.ErrorBody["errorCode"])
+	})
+
+	t.Run("Healthz workflows - 500 ERR_WORKFLOW_HEALTH_NOT_READY", func(t *testing.T) {
+		apiPath := "v1.0/healthz/workflows"
+		testAPI.MarkStatusAsReady()
+		testAPI.isWorkflowReadyFn = func() bool { return false }
+		resp := fakeServer.DoRequest("GET", apiPath, nil, nil)
+		assert.Equal(t, 500, resp.StatusCode)
+		assert.Equal(t, "ERR_WORKFLOW_HEALTH_NOT_READY", resp.ErrorBody["errorCode"])
+	})
+
+	t.Run("Healthz workflows - 204 No Content", func(t *testing.T) {
+		apiPath := "v1.0/healthz/workflows"
+		testAPI.MarkStatusAsReady()
+		testAPI.isWorkflowReadyFn = func() bool { return true }
+		resp := fakeServer.DoRequest("GET", apiPath, nil, nil)
+		assert.Equal(t, 204, resp.StatusCode)
+	})
+
 	fakeServer.Shutdown()
 }
 
@@ -4411,8 +4671,10 @@ func TestV1TransactionEndpoints(t *testing.T) {
 	var fakeStore state.Store = newFakeStateStoreQuerier()
 	fakeStoreNonTransactional := new(daprt.MockStateStore)
 	compStore := compstore.New()
+	recordingStore := &recordingTransactionalStore{fakeStateStoreQuerier: newFakeStateStoreQuerier()}
 	compStore.AddStateStore("store1", fakeStore)
 	compStore.AddStateStore("storeNonTransactional", fakeStoreNonTransactional)
+	compStore.AddStateStore("storeRecording", recordingStore)
 
 	testAPI := &api{
 		universal: &universalapi.UniversalAPI{
@@ -4564,6 +4826,68 @@ func TestV1TransactionEndpoints(t *testing.T) {
 		assert.Equal(t, "ERR_STATE_STORE_TOO_MANY_TRANSACTIONS", resp.ErrorBody["errorCode"], apiPath)
 	})
 
+	t.Run("Per-operation metadata is passed to the state store", func(t *testing.T) {
+		apiPath := "v1.0/state/storeRecording/transaction"
+		inputBodyBytes, err := json.Marshal(stateTransactionRequestBody{
+			Operations: []stateTransactionRequestBodyOperation{
+				{
+					Operation: string(state.OperationUpsert),
+					Request: map[string]any{
+						"key":   "fakeKey1",
+						"value": fakeBodyObject,
+						"metadata": map[string]any{
+							"ttlInSeconds": 60,
+							"contentType":  "application/json",
+						},
+					},
+				},
+				{
+					Operation: string(state.OperationDelete),
+					Request: map[string]any{
+						"key":      "fakeKey2",
+						"metadata": map[string]any{"partitionKey": "p1"},
+					},
+				},
+			},
+		})
+		require.NoError(t, err)
+
+		resp := fakeServer.DoRequest("POST", apiPath, inputBodyBytes, nil)
+		require.Equal(t, 204, resp.StatusCode, string(resp.RawBody))
+
+		require.NotNil(t, recordingStore.lastRequest)
+		require.Len(t, recordingStore.lastRequest.Operations, 2)
+		setReq, ok := recordingStore.lastRequest.Operations[0].(state.SetRequest)
+		require.True(t, ok)
+		assert.Equal(t, map[string]string{"ttlInSeconds": "60", "contentType": "application/json"}, setReq.Metadata)
+		require.NotNil(t, setReq.ContentType)
+		assert.Equal(t, "application/json", *setReq.ContentType)
+		delReq, ok := recordingStore.lastRequest.Operations[1].(state.DeleteRequest)
+		require.True(t, ok)
+		assert.Equal(t, map[string]string{"partitionKey": "p1"}, delReq.Metadata)
+	})
+
+	t.Run("Invalid per-operation metadata - 400 ERR_STATE_INVALID_METADATA", func(t *testing.T) {
+		apiPath := fmt.Sprintf("v1.0/state/%s/transaction", storeName)
+		inputBodyBytes, err := json.Marshal(stateTransactionRequestBody{
+			Operations: []stateTransactionRequestBodyOperation{
+				{
+					Operation: string(state.OperationUpsert),
+					Request: map[string]any{
+						"key":      "fakeKey1",
+						"value":    fakeBodyObject,
+						"metadata": map[string]any{"ttlInSeconds": "ten"},
+					},
+				},
+			},
+		})
+		require.NoError(t, err)
+
+		resp := fakeServer.DoRequest("POST", apiPath, inputBodyBytes, nil)
+		assert.Equal(t, 400, resp.StatusCode)
+		assert.Equal(t, "ERR_STATE_INVALID_METADATA", resp.ErrorBody["errorCode"], apiPath)
+	})
+
 	t.Run("Non Transactional State Store - 500 ERR_STATE_STORE_NOT_SUPPORTED", func(t *testing.T) {
 		apiPath := fmt.Sprintf("v1.0/state/%s/transaction", nonTransactionalStoreName)
 		testTransactionalOperations := []stateTransactionRequestBodyOperation{
@@ -4715,3 +5039,27 @@ func matchContextInterface(v any) bool {
 	_, ok := v.(context.Context)
 	return ok
 }
+
+func TestIfNoneMatchHeaderMatches(t *testing.T) {
+	tests := []struct {
+		name   string
+		header string
+		etag   string
+		want   bool
+	}{
+		{name: "empty header", header: "", etag: "1", want: false},
+		{name: "wildcard", header: "*", etag: "1", want: true},
+		{name: "raw etag", header: "1", etag: "1", want: true},
+		{name: "quoted etag", header: `"1"`, etag: "1", want: true},
+		{name: "weak etag", header: `W/"1"`, etag: "1", want: true},
+		{name: "list with match", header: `"0", "1"`, etag: "1", want: true},
+		{name: "list without match", header: `"0", "2"`, etag: "1", want: false},
+		{name: "mismatch", header: `"2"`, etag: "1", want: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, ifNoneMatchHeaderMatches(tt.header, tt.etag))
+		})
+	}
+}
@@ -14,16 +14,22 @@ limitations under the License.
 package http
 
 import (
+	"encoding/json"
+	"errors"
 	"io"
 	"net/http"
+	"strconv"
+	"time"
 
 	"github.com/go-chi/chi/v5"
 	"github.com/google/uuid"
 	"google.golang.org/protobuf/types/known/emptypb"
+	"google.golang.org/protobuf/types/known/timestamppb"
 
 	"github.com/dapr/dapr/pkg/http/endpoints"
 	"github.com/dapr/dapr/pkg/messages"
 	runtimev1pb "github.com/dapr/dapr/pkg/proto/runtime/v1"
+	"github.com/dapr/kit/streams"
 )
 
 var (
@@ -84,6 +90,16 @@ func (a *api) constructWorkflowEndpoints() []endpoints.Endpoint {
 				Name: "RaiseEventWorkflow",
 			},
 		},
+		{
+			Methods: []string{http.MethodPost},
+			Route:   "workflows/{workflowComponent}/raiseEvents",
+			Version: apiVersionV1beta1,
+			Group:   endpointGroupWorkflowV1Beta1,
+			Handler: a.onRaiseEventWorkflowBatch,
+			Settings: endpoints.EndpointSettings{
+				Name: "RaiseEventWorkflowBatch",
+			},
+		},
 		{
 			Methods: []string{http.MethodPost},
 			Route:   "workflows/{workflowComponent}/{workflowName}/start",
@@ -184,6 +200,36 @@ func (a *api) constructWorkflowEndpoints() []endpoints.Endpoint {
 				Name: "PurgeWorkflow",
 			},
 		},
+		{
+			Methods: []string{http.MethodGet},
+			Route:   "workflows/{workflowComponent}",
+			Version: apiVersionV1alpha1,
+			Group:   endpointGroupWorkflowV1Alpha1,
+			Handler: a.onListWorkflowsHandler(),
+			Settings: endpoints.EndpointSettings{
+				Name: "ListWorkflows",
+			},
+		},
+		{
+			Methods: []string{http.MethodGet},
+			Route:   "workflows/{workflowComponent}/{instanceID}/history",
+			Version: apiVersionV1alpha1,
+			Group:   endpointGroupWorkflowV1Alpha1,
+			Handler: a.onGetWorkflowHistoryHandler(),
+			Settings: endpoints.EndpointSettings{
+				Name: "GetWorkflowHistory",
+			},
+		},
+		{
+			Methods: []string{http.MethodPost},
+			Route:   "workflows/{workflowComponent}/{instanceID}/replay",
+			Version: apiVersionV1alpha1,
+			Group:   endpointGroupWorkflowV1Alpha1,
+			Handler: a.onReplayWorkflowHistoryHandler(),
+			Settings: endpoints.EndpointSettings{
+				Name: "ReplayWorkflowHistory",
+			},
+		},
 	}
 }
 
@@ -268,6 +314,76 @@ func (a *api) onRaiseEventWorkflowHandler() http.HandlerFunc {
 		})
 }
 
+// raiseEventWorkflowBatchRequest is the body of the requests to raise events on many workflow instances.
+// The data of the events is JSON, rather than the base64-encoded bytes of the protobuf message.
+type raiseEventWorkflowBatchRequest struct {
+	EventName string                         `json:"eventName,omitempty"`
+	EventData json.RawMessage                `json:"eventData,omitempty"`
+	Events    []raiseEventWorkflowBatchEvent `json:"events"`
+}
+
+type raiseEventWorkflowBatchEvent struct {
+	InstanceID string          `json:"instanceID"`
+	EventName  string          `json:"eventName,omitempty"`
+	EventData  json.RawMessage `json:"eventData,omitempty"`
+}
+
+type raiseEventWorkflowBatchResponse struct {
+	Results []raiseEventWorkflowBatchResult `json:"results"`
+}
+
+type raiseEventWorkflowBatchResult struct {
+	InstanceID string `json:"instanceID"`
+	Status     string `json:"status"`
+	ErrorCode  string `json:"errorCode,omitempty"`
+	Message    string `json:"message,omitempty"`
+}
+
+// Route: POST "workflows/{workflowComponent}/raiseEvents"
+func (a *api) onRaiseEventWorkflowBatch(w http.ResponseWriter, r *http.Request) {
+	var body raiseEventWorkflowBatchRequest
+	err := json.NewDecoder(r.Body).Decode(&body)
+	if err != nil {
+		err = messages.ErrMalformedRequest.WithFormat(err)
+		log.Debug(err)
+		respondWithError(w, err)
+		return
+	}
+
+	in := &runtimev1pb.RaiseEventWorkflowBatchRequest{
+		WorkflowComponent: chi.URLParam(r, workflowComponent),
+		EventName:         body.EventName,
+		EventData:         body.EventData,
+		Events:            make([]*runtimev1pb.RaiseEventWorkflowBatchEvent, len(body.Events)),
+	}
+	for i, ev := range body.Events {
+		in.Events[i] = &runtimev1pb.RaiseEventWorkflowBatchEvent{
+			InstanceId: ev.InstanceID,
+			EventName:  ev.EventName,
+			EventData:  ev.EventData,
+		}
+	}
+
+	res, err := a.universal.RaiseEventWorkflowBatchBeta1(r.Context(), in)
+	if err != nil {
+		respondWithError(w, err)
+		return
+	}
+
+	out := raiseEventWorkflowBatchResponse{
+		Results: make([]raiseEventWorkflowBatchResult, len(res.GetResults())),
+	}
+	for i, result := range res.GetResults() {
+		out.Results[i] = raiseEventWorkflowBatchResult{
+			InstanceID: result.GetInstanceId(),
+			Status:     result.GetStatus(),
+			ErrorCode:  result.GetErrorCode(),
+			Message:    result.GetMessage(),
+		}
+	}
+	respondWithJSON(w, http.StatusOK, out)
+}
+
 // ROUTE: POST "workflows/{workflowComponent}/{instanceID}/pause"
 func (a *api) onPauseWorkflowHandler() http.HandlerFunc {
 	return UniversalHTTPHandler(
@@ -297,6 +413,87 @@ func (a *api) onPurgeWorkflowHandler() http.HandlerFunc {
 		})
 }
 
+// Route: GET "workflows/{workflowComponent}?runtimeStatus={status}&workflowName={name}&createdAfter={time}&createdBefore={time}&pageSize={size}&continuationToken={token}"
+// The runtimeStatus parameter can be repeated, and times are in the RFC3339 format.
+func (a *api) onListWorkflowsHandler() http.HandlerFunc {
+	return UniversalHTTPHandler(
+		a.universal.ListWorkflowsAlpha1,
+		UniversalHTTPHandlerOpts[*runtimev1pb.ListWorkflowsRequest, *runtimev1pb.ListWorkflowsResponse]{
+			SkipInputBody: true,
+			InModifier: func(r *http.Request, in *runtimev1pb.ListWorkflowsRequest) (*runtimev1pb.ListWorkflowsRequest, error) {
+				in.WorkflowComponent = chi.URLParam(r, workflowComponent)
+
+				qs := r.URL.Query()
+				in.RuntimeStatus = qs["runtimeStatus"]
+				in.WorkflowName = qs.Get(workflowName)
+				in.ContinuationToken = qs.Get("continuationToken")
+				if v := qs.Get("pageSize"); v != "" {
+					pageSize, err := strconv.ParseInt(v, 10, 32)
+					if err != nil || pageSize < 0 {
+						return nil, messages.ErrBadRequest.WithFormat("pageSize must be a non-negative integer")
+					}
+					in.PageSize = int32(pageSize)
+				}
+				for param, field := range map[string]**timestamppb.Timestamp{
+					"createdAfter":  &in.CreatedAfter,
+					"createdBefore": &in.CreatedBefore,
+				} {
+					v := qs.Get(param)
+					if v == "" {
+						continue
+					}
+					t, err := time.Parse(time.RFC3339, v)
+					if err != nil {
+						return nil, messages.ErrBadRequest.WithFormat(param + " must be in the RFC3339 format")
+					}
+					*field = timestamppb.New(t)
+				}
+				return in, nil
+			},
+		})
+}
+
+// Route: GET "workflows/{workflowComponent}/{instanceID}/history"
+func (a *api) onGetWorkflowHistoryHandler() http.HandlerFunc {
+	return UniversalHTTPHandler(
+		a.universal.GetWorkflowHistoryAlpha1,
+		UniversalHTTPHandlerOpts[*runtimev1pb.GetWorkflowHistoryRequest, *runtimev1pb.GetWorkflowHistoryResponse]{
+			InModifier: workflowInModifier[*runtimev1pb.GetWorkflowHistoryRequest],
+			OutModifier: func(out *runtimev1pb.GetWorkflowHistoryResponse) (any, error) {
+				// The history is already serialized as JSON
+				return json.RawMessage(out.GetHistory()), nil
+			},
+		})
+}
+
+// Route: POST "workflows/{workflowComponent}/{instanceID}/replay"
+// The body is a history exported with the history endpoint, possibly from another Dapr instance.
+func (a *api) onReplayWorkflowHistoryHandler() http.HandlerFunc {
+	return UniversalHTTPHandler(
+		a.universal.ReplayWorkflowHistoryAlpha1,
+		UniversalHTTPHandlerOpts[*runtimev1pb.ReplayWorkflowHistoryRequest, *runtimev1pb.ReplayWorkflowHistoryResponse]{
+			SkipInputBody: true,
+			InModifier: func(r *http.Request, in *runtimev1pb.ReplayWorkflowHistoryRequest) (*runtimev1pb.ReplayWorkflowHistoryRequest, error) {
+				in, _ = workflowInModifier(r, in)
+
+				body := r.Body
+				if a.maxRequestBodySize > 0 {
+					body = streams.LimitReadCloser(body, a.maxRequestBodySize)
+				}
+				history, err := io.ReadAll(body)
+				if err != nil {
+					if errors.Is(err, streams.ErrStreamTooLarge) {
+						return nil, messages.ErrWorkflowHistoryTooLarge.WithFormat(a.maxRequestBodySize)
+					}
+					return nil, messages.ErrBodyRead.WithFormat(err)
+				}
+				in.History = history
+				return in, nil
+			},
+			ProtoResponseEmitUnpopulated: true,
+		})
+}
+
 // Shared InModifier method for all universal handlers for workflows that adds the "WorkflowComponent" and "InstanceId" properties
 func workflowInModifier[T runtimev1pb.WorkflowRequests](r *http.Request, in T) (T, error) {
 	in.SetWorkflowComponent(chi.URLParam(r, workflowComponent))
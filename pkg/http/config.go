@@ -13,6 +13,11 @@ limitations under the License.
 
 package http
 
+import (
+	"github.com/dapr/dapr/pkg/accesslog"
+	"github.com/dapr/dapr/pkg/config"
+)
+
 // ServerConfig holds config values for an HTTP server.
 type ServerConfig struct {
 	AppID                   string
@@ -22,6 +27,7 @@ type ServerConfig struct {
 	PublicPort              *int
 	ProfilePort             int
 	AllowedOrigins          string
+	CORS                    config.CORSSpec
 	EnableProfiling         bool
 	MaxRequestBodySizeMB    int
 	UnixDomainSocket        string
@@ -29,4 +35,5 @@ type ServerConfig struct {
 	EnableAPILogging        bool
 	APILoggingObfuscateURLs bool
 	APILogHealthChecks      bool
+	AccessLog               *accesslog.Logger
 }
@@ -56,6 +56,11 @@ const (
 	EndpointGroupWorkflow          EndpointGroupName = "workflows"
 	EndpointGroupHealth            EndpointGroupName = "healthz"
 	EndpointGroupShutdown          EndpointGroupName = "shutdown"
+	EndpointGroupAppChannel        EndpointGroupName = "appchannel"
+	EndpointGroupMetrics           EndpointGroupName = "metrics"
+	EndpointGroupTracing           EndpointGroupName = "tracing"
+	EndpointGroupRateLimit         EndpointGroupName = "ratelimit"
+	EndpointGroupLeaderElection    EndpointGroupName = "leaderelection"
 )
 
 // EndpointGroupVersion is the version of an endpoint group.
@@ -62,6 +62,8 @@ func isRouteExcludedFromAPITokenAuth(method string, u *url.URL) bool {
 		return method == http.MethodGet
 	case apiVersionV1 + "/healthz/outbound":
 		return method == http.MethodGet
+	case apiVersionV1 + "/healthz/workflows":
+		return method == http.MethodGet
 	default:
 		return false
 	}
@@ -0,0 +1,105 @@
+/*
+Copyright 2023 The Dapr Authors
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package http
+
+import (
+	"net/http"
+	"strconv"
+	"time"
+
+	"golang.org/x/time/rate"
+
+	"github.com/dapr/dapr/pkg/config"
+	diag "github.com/dapr/dapr/pkg/diagnostics"
+	"github.com/dapr/dapr/pkg/http/endpoints"
+	"github.com/dapr/dapr/pkg/messages"
+	"github.com/dapr/dapr/pkg/ratelimit"
+)
+
+// newRateLimiters returns the rate limiters of the APIs, keyed by endpoint group name.
+// A limiter is shared by all the versions of an API.
+// The APIs limited by a distributed rate limiter are skipped.
+func newRateLimiters(limits []config.APIRateLimit) map[endpoints.EndpointGroupName]*rate.Limiter {
+	if len(limits) == 0 {
+		return nil
+	}
+
+	limiters := make(map[endpoints.EndpointGroupName]*rate.Limiter, len(limits))
+	for _, l := range limits {
+		if l.RateLimiter != "" {
+			continue
+		}
+		if l.Name == "" || l.RequestsPerSecond < 1 {
+			log.Warnf("Ignoring invalid rate limit for API '%s': requestsPerSecond must be greater than 0", l.Name)
+			continue
+		}
+		limiters[endpoints.EndpointGroupName(l.Name)] = rate.NewLimiter(rate.Limit(l.RequestsPerSecond), l.GetBurst())
+	}
+	return limiters
+}
+
+// rateLimitHandler rejects the requests that exceed the rate limit of the API before they reach the handler.
+func rateLimitHandler(name endpoints.EndpointGroupName, limiter *rate.Limiter, next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if !limiter.Allow() {
+			diag.DefaultHTTPMonitoring.ServerRequestRateLimited(r.Context(), string(name))
+			w.Header().Set("Retry-After", "1")
+			msg := messages.ErrAPIRateLimited.WithFormat(name)
+			respondWithError(w, msg)
+			log.Debug(msg)
+			return
+		}
+		next.ServeHTTP(w, r)
+	})
+}
+
+// newDistributedRateLimiters returns the distributed rate limiters of the APIs, keyed by endpoint group name.
+func newDistributedRateLimiters(limits []config.APIRateLimit, rateLimiters ratelimit.Limiters) map[endpoints.EndpointGroupName]*ratelimit.Limiter {
+	var limiters map[endpoints.EndpointGroupName]*ratelimit.Limiter
+	for _, l := range limits {
+		if l.RateLimiter == "" {
+			continue
+		}
+		limiter, ok := rateLimiters[l.RateLimiter]
+		if l.Name == "" || !ok {
+			log.Warnf("Ignoring invalid rate limit for API '%s': rate limiter '%s' not found", l.Name, l.RateLimiter)
+			continue
+		}
+		if limiters == nil {
+			limiters = make(map[endpoints.EndpointGroupName]*ratelimit.Limiter, len(limits))
+		}
+		limiters[endpoints.EndpointGroupName(l.Name)] = limiter
+	}
+	return limiters
+}
+
+// distributedRateLimitHandler rejects the requests that exceed the distributed rate limit of the API before they reach the handler.
+// The name of the API is the key of the limit, which is shared by all the replicas of the app.
+// Requests are let through if the limit can't be checked, so that an unavailable state store doesn't make the API unavailable too.
+func distributedRateLimitHandler(name endpoints.EndpointGroupName, limiter *ratelimit.Limiter, next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		res, err := limiter.Allow(r.Context(), string(name), 1)
+		if err != nil {
+			log.Warnf("Failed to check the rate limit of API '%s', allowing the request: %v", name, err)
+		} else if !res.Allowed {
+			diag.DefaultHTTPMonitoring.ServerRequestRateLimited(r.Context(), string(name))
+			w.Header().Set("Retry-After", strconv.Itoa(int((res.ResetAfter+time.Second-1)/time.Second)))
+			msg := messages.ErrAPIRateLimited.WithFormat(name)
+			respondWithError(w, msg)
+			log.Debug(msg)
+			return
+		}
+		next.ServeHTTP(w, r)
+	})
+}
@@ -27,6 +27,7 @@ import (
 const (
 	jsonContentTypeHeader = "application/json"
 	etagHeader            = "ETag"
+	ifNoneMatchHeader     = "If-None-Match"
 	metadataPrefix        = "metadata."
 	headerContentType     = "content-type"
 	headerContentLength   = "content-length"
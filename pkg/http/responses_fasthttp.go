@@ -48,6 +48,14 @@ func fasthttpResponseWithEmpty() fasthttpResponseOption {
 	}
 }
 
+// fasthttpResponseWithNotModified sets 304 status code.
+func fasthttpResponseWithNotModified() fasthttpResponseOption {
+	return func(ctx *fasthttp.RequestCtx) {
+		ctx.Response.SetBody(nil)
+		ctx.Response.SetStatusCode(fasthttp.StatusNotModified)
+	}
+}
+
 // fasthttpResponseWith sets a default application/json content type if content type is not present.
 func fasthttpResponseWith(code int, obj []byte) fasthttpResponseOption {
 	return func(ctx *fasthttp.RequestCtx) {
@@ -23,8 +23,6 @@ import (
 	"net/url"
 	"os"
 	"regexp"
-	"strconv"
-	"strings"
 	"sync"
 	"time"
 
@@ -34,9 +32,9 @@ import (
 	_ "net/http/pprof"
 
 	chi "github.com/go-chi/chi/v5"
-	"github.com/go-chi/cors"
 	"golang.org/x/net/http2"
 	"golang.org/x/net/http2/h2c"
+	"golang.org/x/time/rate"
 
 	"github.com/dapr/dapr/pkg/config"
 	corsDapr "github.com/dapr/dapr/pkg/cors"
@@ -44,10 +42,12 @@ import (
 	diagUtils "github.com/dapr/dapr/pkg/diagnostics/utils"
 	"github.com/dapr/dapr/pkg/http/endpoints"
 	httpMiddleware "github.com/dapr/dapr/pkg/middleware/http"
+	"github.com/dapr/dapr/pkg/ratelimit"
 	"github.com/dapr/dapr/pkg/responsewriter"
 	"github.com/dapr/dapr/pkg/security"
+	"github.com/dapr/dapr/utils"
 	"github.com/dapr/kit/logger"
-	"github.com/dapr/kit/utils"
+	kitutils "github.com/dapr/kit/utils"
 )
 
 var (
@@ -62,15 +62,17 @@ type Server interface {
 }
 
 type server struct {
-	config             ServerConfig
-	tracingSpec        config.TracingSpec
-	metricSpec         config.MetricSpec
-	pipeline           httpMiddleware.Pipeline
-	api                API
-	apiSpec            config.APISpec
-	servers            []*http.Server
-	profilingListeners []net.Listener
-	wg                 sync.WaitGroup
+	config              ServerConfig
+	tracingSpec         config.TracingSpec
+	metricSpec          config.MetricSpec
+	pipeline            httpMiddleware.Pipeline
+	api                 API
+	apiSpec             config.APISpec
+	rateLimiters        map[endpoints.EndpointGroupName]*rate.Limiter
+	distributedLimiters map[endpoints.EndpointGroupName]*ratelimit.Limiter
+	servers             []*http.Server
+	profilingListeners  []net.Listener
+	wg                  sync.WaitGroup
 }
 
 // NewServerOpts are the options for NewServer.
@@ -81,6 +83,8 @@ type NewServerOpts struct {
 	MetricSpec  config.MetricSpec
 	Pipeline    httpMiddleware.Pipeline
 	APISpec     config.APISpec
+	// RateLimiters are the distributed rate limiters the APIs can be limited by.
+	RateLimiters ratelimit.Limiters
 }
 
 // NewServer returns a new HTTP server.
@@ -93,6 +97,9 @@ func NewServer(opts NewServerOpts) Server {
 		metricSpec:  opts.MetricSpec,
 		pipeline:    opts.Pipeline,
 		apiSpec:     opts.APISpec,
+
+		rateLimiters:        newRateLimiters(opts.APISpec.RateLimits),
+		distributedLimiters: newDistributedRateLimiters(opts.APISpec.RateLimits, opts.RateLimiters),
 	}
 }
 
@@ -103,7 +110,8 @@ func (s *server) StartNonBlocking() error {
 	s.useMaxBodySize(r)
 	s.useContextSetup(r)
 	s.useTracing(r)
-	s.useMetrics(r)
+	s.useMetrics(r, diag.ServerKindAPI)
+	s.useAccessLog(r)
 	s.useAPIAuthentication(r)
 	s.useCors(r)
 	s.useComponents(r)
@@ -124,7 +132,7 @@ func (s *server) StartNonBlocking() error {
 		listeners = append(listeners, l)
 	} else {
 		for _, apiListenAddress := range s.config.APIListenAddresses {
-			addr := apiListenAddress + ":" + strconv.Itoa(s.config.Port)
+			addr := utils.JoinHostPort(apiListenAddress, s.config.Port)
 			l, err := net.Listen("tcp", addr)
 			if err != nil {
 				log.Debugf("Failed to listen for HTTP server on TCP address %s with error: %v", addr, err)
@@ -140,7 +148,7 @@ func (s *server) StartNonBlocking() error {
 
 	// Create a handler with support for HTTP/2 Cleartext
 	var handler http.Handler = r
-	if !utils.IsTruthy(os.Getenv("DAPR_HTTP_DISABLE_H2C")) {
+	if !kitutils.IsTruthy(os.Getenv("DAPR_HTTP_DISABLE_H2C")) {
 		handler = h2c.NewHandler(r, &http2.Server{})
 	}
 
@@ -169,7 +177,7 @@ func (s *server) StartNonBlocking() error {
 		publicR := s.getRouter()
 		s.useContextSetup(publicR)
 		s.useTracing(publicR)
-		s.useMetrics(publicR)
+		s.useMetrics(publicR, diag.ServerKindPublic)
 
 		s.setupRoutes(publicR, s.api.PublicEndpoints())
 
@@ -192,7 +200,7 @@ func (s *server) StartNonBlocking() error {
 
 	if s.config.EnableProfiling {
 		for _, apiListenAddress := range s.config.APIListenAddresses {
-			addr := apiListenAddress + ":" + strconv.Itoa(s.config.ProfilePort)
+			addr := utils.JoinHostPort(apiListenAddress, s.config.ProfilePort)
 			pl, err := net.Listen("tcp", addr)
 			if err != nil {
 				log.Debugf("Failed to listen for profiling server on TCP address %s with error: %v", addr, err)
@@ -280,13 +288,13 @@ func (s *server) useTracing(r chi.Router) {
 	})
 }
 
-func (s *server) useMetrics(r chi.Router) {
+func (s *server) useMetrics(r chi.Router, kind diag.ServerKind) {
 	if !s.metricSpec.GetEnabled() {
 		return
 	}
 
 	log.Info("Enabled metrics HTTP middleware")
-	r.Use(diag.DefaultHTTPMonitoring.HTTPMiddleware)
+	r.Use(diag.DefaultServerMonitoring.HTTPMiddleware(kind))
 }
 
 func (s *server) useMaxBodySize(r chi.Router) {
@@ -352,6 +360,9 @@ func (s *server) useAPILogging(mux chi.Router) {
 				fields["size"] = rw.Size()
 			}
 
+			// Correlate the log entry with the trace of the request
+			diag.AddTraceLogFields(fields, diagUtils.SpanFromContext(r.Context()).SpanContext())
+
 			infoLog.WithFields(fields).Info("HTTP API Called")
 		})
 	})
@@ -365,18 +376,25 @@ func (s *server) useComponents(r chi.Router) {
 	r.Use(s.pipeline.Handlers...)
 }
 
+func (s *server) useAccessLog(r chi.Router) {
+	if s.config.AccessLog == nil {
+		return
+	}
+
+	log.Info("Enabled access logs on HTTP server")
+	r.Use(s.config.AccessLog.HTTPMiddleware)
+}
+
 func (s *server) useCors(r chi.Router) {
-	// TODO: Technically, if "AllowedOrigins" is "*", all origins should be allowed
+	// TODO: Technically, if "AllowedOrigins" is "*" and there's no CORS spec, all origins should be allowed
 	// This behavior is not quite correct as in this case we are disallowing all origins
-	if s.config.AllowedOrigins == corsDapr.DefaultAllowedOrigins {
+	mw := corsDapr.Middleware(s.config.CORS, s.config.AllowedOrigins)
+	if mw == nil {
 		return
 	}
 
 	log.Info("Enabled CORS HTTP middleware")
-	r.Use(cors.New(cors.Options{
-		AllowedOrigins: strings.Split(s.config.AllowedOrigins, ","),
-		Debug:          false,
-	}).Handler)
+	r.Use(mw)
 }
 
 func (s *server) useAPIAuthentication(r chi.Router) {
@@ -458,6 +476,16 @@ func (s *server) handle(e endpoints.Endpoint, path string, r chi.Router, unescap
 		handler = s.unescapeRequestParametersHandler(handler)
 	}
 
+	// Health checks are never rate limited
+	if e.Group != nil && !e.Settings.IsHealthCheck {
+		if limiter, ok := s.rateLimiters[e.Group.Name]; ok {
+			handler = rateLimitHandler(e.Group.Name, limiter, handler)
+		}
+		if limiter, ok := s.distributedLimiters[e.Group.Name]; ok {
+			handler = distributedRateLimitHandler(e.Group.Name, limiter, handler)
+		}
+	}
+
 	handler = s.addEndpointCtx(e, handler)
 
 	// If no method is defined, match any method
@@ -482,3 +482,79 @@ func TestClose(t *testing.T) {
 		require.NoError(t, server.Close())
 	})
 }
+
+func TestRateLimits(t *testing.T) {
+	group := &endpoints.EndpointGroup{
+		Name:    endpoints.EndpointGroupState,
+		Version: endpoints.EndpointGroupVersion1,
+	}
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNoContent)
+	})
+	eps := []endpoints.Endpoint{
+		{
+			Methods: []string{http.MethodGet},
+			Route:   "state/{storeName}/{key}",
+			Version: apiVersionV1,
+			Group:   group,
+			Handler: handler,
+		},
+		{
+			Methods: []string{http.MethodGet},
+			Route:   "secrets/{secretStoreName}/{key}",
+			Version: apiVersionV1,
+			Group: &endpoints.EndpointGroup{
+				Name:    endpoints.EndpointGroupSecrets,
+				Version: endpoints.EndpointGroupVersion1,
+			},
+			Handler: handler,
+		},
+	}
+
+	srv := newServer()
+	srv.rateLimiters = newRateLimiters([]config.APIRateLimit{
+		// The burst is 2 requests, and a new one is allowed every 1000s, so the third request is rejected
+		{Name: "state", RequestsPerSecond: 1, Burst: 2},
+		{Name: "invalid", RequestsPerSecond: 0},
+		// Distributed rate limits are not enforced by local limiters
+		{Name: "secrets", RateLimiter: "shared"},
+	})
+	srv.rateLimiters[endpoints.EndpointGroupState].SetLimit(0.001)
+	require.Len(t, srv.rateLimiters, 1)
+
+	router := chi.NewRouter()
+	srv.setupRoutes(router, eps)
+
+	do := func(path string) *http.Response {
+		rw := httptest.NewRecorder()
+		router.ServeHTTP(rw, httptest.NewRequest(http.MethodGet, path, nil))
+		return rw.Result()
+	}
+
+	t.Run("requests within the limit are allowed", func(t *testing.T) {
+		for i := 0; i < 2; i++ {
+			resp := do("/v1.0/state/store/key")
+			resp.Body.Close()
+			assert.Equal(t, http.StatusNoContent, resp.StatusCode)
+		}
+	})
+
+	t.Run("requests over the limit are rejected", func(t *testing.T) {
+		resp := do("/v1.0/state/store/key")
+		defer resp.Body.Close()
+		assert.Equal(t, http.StatusTooManyRequests, resp.StatusCode)
+		assert.Equal(t, "1", resp.Header.Get("Retry-After"))
+
+		body := map[string]string{}
+		require.NoError(t, json.NewDecoder(resp.Body).Decode(&body))
+		assert.Equal(t, "ERR_TOO_MANY_REQUESTS", body["errorCode"])
+	})
+
+	t.Run("other APIs are not limited", func(t *testing.T) {
+		for i := 0; i < 5; i++ {
+			resp := do("/v1.0/secrets/store/key")
+			resp.Body.Close()
+			assert.Equal(t, http.StatusNoContent, resp.StatusCode)
+		}
+	})
+}
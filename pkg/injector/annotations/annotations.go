@@ -32,6 +32,8 @@ const (
 	KeyAppMaxConcurrency                = "dapr.io/app-max-concurrency"
 	KeyEnableMetrics                    = "dapr.io/enable-metrics"
 	KeyMetricsPort                      = "dapr.io/metrics-port"
+	KeyMetricsPushURL                   = "dapr.io/metrics-push-url"
+	KeyMetricsPushInterval              = "dapr.io/metrics-push-interval"
 	KeyEnableDebug                      = "dapr.io/enable-debug"
 	KeyDebugPort                        = "dapr.io/debug-port"
 	KeyEnv                              = "dapr.io/env"
@@ -54,6 +56,11 @@ const (
 	KeyHTTPReadBufferSize               = "dapr.io/http-read-buffer-size"
 	KeyGracefulShutdownSeconds          = "dapr.io/graceful-shutdown-seconds"
 	KeyBlockShutdownDuration            = "dapr.io/block-shutdown-duration"
+	KeyAppGRPCKeepaliveTime             = "dapr.io/app-grpc-keepalive-time"
+	KeyAppGRPCKeepaliveTimeout          = "dapr.io/app-grpc-keepalive-timeout"
+	KeyAppGRPCMaxConnectionAge          = "dapr.io/app-grpc-max-connection-age"
+	KeyAppChannelRoutes                 = "dapr.io/app-channel-routes"
+	KeyMaxInvokeTimeout                 = "dapr.io/max-invoke-timeout"
 	KeyEnableAPILogging                 = "dapr.io/enable-api-logging"
 	KeyUnixDomainSocketPath             = "dapr.io/unix-domain-socket-path"
 	KeyVolumeMountsReadOnly             = "dapr.io/volume-mounts"
@@ -65,6 +72,7 @@ const (
 	KeyAppHealthProbeTimeout            = "dapr.io/app-health-probe-timeout"
 	KeyAppHealthThreshold               = "dapr.io/app-health-threshold"
 	KeyPlacementHostAddresses           = "dapr.io/placement-host-address"
+	KeyActorVersion                     = "dapr.io/actor-version"
 	KeyPluggableComponents              = "dapr.io/pluggable-components"
 	KeyPluggableComponentsSocketsFolder = "dapr.io/pluggable-components-sockets-folder"
 	KeyPluggableComponentContainer      = "dapr.io/component-container"
@@ -70,6 +70,8 @@ type SidecarConfig struct {
 	AppMaxConcurrency                   *int    `annotation:"dapr.io/app-max-concurrency"`
 	EnableMetrics                       bool    `annotation:"dapr.io/enable-metrics"                          default:"true"`
 	SidecarMetricsPort                  int32   `annotation:"dapr.io/metrics-port"                            default:"9090"`
+	SidecarMetricsPushURL               string  `annotation:"dapr.io/metrics-push-url"`
+	SidecarMetricsPushInterval          string  `annotation:"dapr.io/metrics-push-interval"`
 	EnableDebug                         bool    `annotation:"dapr.io/enable-debug"                            default:"false"`
 	SidecarDebugPort                    int32   `annotation:"dapr.io/debug-port"                              default:"40000"`
 	Env                                 string  `annotation:"dapr.io/env"`
@@ -92,6 +94,11 @@ type SidecarConfig struct {
 	HTTPReadBufferSize                  *int    `annotation:"dapr.io/http-read-buffer-size"`
 	GracefulShutdownSeconds             int     `annotation:"dapr.io/graceful-shutdown-seconds"               default:"-1"`
 	BlockShutdownDuration               *string `annotation:"dapr.io/block-shutdown-duration"`
+	AppGRPCKeepaliveTime                *string `annotation:"dapr.io/app-grpc-keepalive-time"`
+	AppGRPCKeepaliveTimeout             *string `annotation:"dapr.io/app-grpc-keepalive-timeout"`
+	AppGRPCMaxConnectionAge             *string `annotation:"dapr.io/app-grpc-max-connection-age"`
+	AppChannelRoutes                    string  `annotation:"dapr.io/app-channel-routes"`
+	MaxInvokeTimeout                    string  `annotation:"dapr.io/max-invoke-timeout"`
 	EnableAPILogging                    *bool   `annotation:"dapr.io/enable-api-logging"`
 	UnixDomainSocketPath                string  `annotation:"dapr.io/unix-domain-socket-path"`
 	VolumeMounts                        string  `annotation:"dapr.io/volume-mounts"`
@@ -103,6 +110,7 @@ type SidecarConfig struct {
 	AppHealthProbeTimeout               int32   `annotation:"dapr.io/app-health-probe-timeout"                default:"500"` // In milliseconds
 	AppHealthThreshold                  int32   `annotation:"dapr.io/app-health-threshold"                    default:"3"`
 	PlacementAddress                    string  `annotation:"dapr.io/placement-host-address"`
+	ActorVersion                        string  `annotation:"dapr.io/actor-version"`
 	PluggableComponents                 string  `annotation:"dapr.io/pluggable-components"`
 	PluggableComponentsSocketsFolder    string  `annotation:"dapr.io/pluggable-components-sockets-folder"`
 	ComponentContainer                  string  `annotation:"dapr.io/component-container"`
@@ -97,10 +97,19 @@ func (c *SidecarConfig) getSidecarContainer(opts getSidecarContainerOpts) (*core
 			"--enable-metrics",
 			"--metrics-port", strconv.FormatInt(int64(c.SidecarMetricsPort), 10),
 		)
+		if c.SidecarMetricsPushURL != "" {
+			args = append(args, "--metrics-push-url", c.SidecarMetricsPushURL)
+		}
+		if c.SidecarMetricsPushInterval != "" {
+			args = append(args, "--metrics-push-interval", c.SidecarMetricsPushInterval)
+		}
 	}
 
-	if c.Config != "" {
-		args = append(args, "--config", c.Config)
+	// Multiple configurations can be set as a comma-separated list, and are merged in order by the sidecar
+	for _, config := range strings.Split(c.Config, ",") {
+		if config = strings.TrimSpace(config); config != "" {
+			args = append(args, "--config", config)
+		}
 	}
 
 	if c.AppChannelAddress != "" {
@@ -112,6 +121,10 @@ func (c *SidecarConfig) getSidecarContainer(opts getSidecarContainerOpts) (*core
 		args = append(args, "--placement-host-address", c.PlacementAddress)
 	}
 
+	if c.ActorVersion != "" {
+		args = append(args, "--actor-version", c.ActorVersion)
+	}
+
 	// --enable-api-logging is set if and only if there's an explicit value (true or false) for that
 	// This is set explicitly even if "false"
 	// This is because if this CLI flag is missing, the default specified in the Config CRD is used
@@ -173,6 +186,26 @@ func (c *SidecarConfig) getSidecarContainer(opts getSidecarContainerOpts) (*core
 		args = append(args, "--dapr-block-shutdown-duration", *c.BlockShutdownDuration)
 	}
 
+	if c.AppGRPCKeepaliveTime != nil {
+		args = append(args, "--app-grpc-keepalive-time", *c.AppGRPCKeepaliveTime)
+	}
+
+	if c.AppGRPCKeepaliveTimeout != nil {
+		args = append(args, "--app-grpc-keepalive-timeout", *c.AppGRPCKeepaliveTimeout)
+	}
+
+	if c.AppGRPCMaxConnectionAge != nil {
+		args = append(args, "--app-grpc-max-connection-age", *c.AppGRPCMaxConnectionAge)
+	}
+
+	if c.AppChannelRoutes != "" {
+		args = append(args, "--app-channel-route", c.AppChannelRoutes)
+	}
+
+	if c.MaxInvokeTimeout != "" {
+		args = append(args, "--max-invoke-timeout", c.MaxInvokeTimeout)
+	}
+
 	// When debugging is enabled, we need to override the command and the flags
 	if c.EnableDebug {
 		ports = append(ports, corev1.ContainerPort{
@@ -233,6 +266,14 @@ func (c *SidecarConfig) getSidecarContainer(opts getSidecarContainerOpts) (*core
 					},
 				},
 			},
+			{
+				Name: "NODE_NAME",
+				ValueFrom: &corev1.EnvVarSource{
+					FieldRef: &corev1.ObjectFieldSelector{
+						FieldPath: "spec.nodeName",
+					},
+				},
+			},
 			{
 				Name:  securityConsts.TrustAnchorsEnvVar,
 				Value: string(c.CurrentTrustAnchors),
@@ -365,20 +365,22 @@ func TestGetSidecarContainer(t *testing.T) {
 		assert.Equal(t, "dapr-system", container.Env[0].Value)
 		// POD_NAME
 		assert.Equal(t, "metadata.name", container.Env[1].ValueFrom.FieldRef.FieldPath)
+		// NODE_NAME
+		assert.Equal(t, "spec.nodeName", container.Env[2].ValueFrom.FieldRef.FieldPath)
 		// DAPR_CONTROLPLANE_NAMESPACE
-		assert.Equal(t, "my-namespace", container.Env[3].Value)
+		assert.Equal(t, "my-namespace", container.Env[4].Value)
 		// DAPR_CONTROLPLANE_TRUST_DOMAIN
-		assert.Equal(t, "test.example.com", container.Env[4].Value)
+		assert.Equal(t, "test.example.com", container.Env[5].Value)
 		// DAPR_CERT_CHAIN
-		assert.Equal(t, "my-cert-chain", container.Env[5].Value)
+		assert.Equal(t, "my-cert-chain", container.Env[6].Value)
 		// DAPR_CERT_KEY
-		assert.Equal(t, "my-cert-key", container.Env[6].Value)
+		assert.Equal(t, "my-cert-key", container.Env[7].Value)
 		// SENTRY_LOCAL_IDENTITY
-		assert.Equal(t, "pod_identity", container.Env[7].Value)
+		assert.Equal(t, "pod_identity", container.Env[8].Value)
 		// DAPR_API_TOKEN
-		assert.Equal(t, "secret", container.Env[8].ValueFrom.SecretKeyRef.Name)
+		assert.Equal(t, "secret", container.Env[9].ValueFrom.SecretKeyRef.Name)
 		// DAPR_APP_TOKEN
-		assert.Equal(t, "appsecret", container.Env[9].ValueFrom.SecretKeyRef.Name)
+		assert.Equal(t, "appsecret", container.Env[10].ValueFrom.SecretKeyRef.Name)
 		// default image
 		assert.Equal(t, "daprio/dapr", container.Image)
 		assert.EqualValues(t, expectedArgs, container.Args)
@@ -455,20 +457,22 @@ func TestGetSidecarContainer(t *testing.T) {
 		assert.Equal(t, "dapr-system", container.Env[0].Value)
 		// POD_NAME
 		assert.Equal(t, "metadata.name", container.Env[1].ValueFrom.FieldRef.FieldPath)
+		// NODE_NAME
+		assert.Equal(t, "spec.nodeName", container.Env[2].ValueFrom.FieldRef.FieldPath)
 		// DAPR_CONTROLPLANE_NAMESPACE
-		assert.Equal(t, "my-namespace", container.Env[3].Value)
+		assert.Equal(t, "my-namespace", container.Env[4].Value)
 		// DAPR_CONTROLPLANE_TRUST_DOMAIN
-		assert.Equal(t, "test.example.com", container.Env[4].Value)
+		assert.Equal(t, "test.example.com", container.Env[5].Value)
 		// DAPR_CERT_CHAIN
-		assert.Equal(t, "my-cert-chain", container.Env[5].Value)
+		assert.Equal(t, "my-cert-chain", container.Env[6].Value)
 		// DAPR_CERT_KEY
-		assert.Equal(t, "my-cert-key", container.Env[6].Value)
+		assert.Equal(t, "my-cert-key", container.Env[7].Value)
 		// SENTRY_LOCAL_IDENTITY
-		assert.Equal(t, "pod_identity", container.Env[7].Value)
+		assert.Equal(t, "pod_identity", container.Env[8].Value)
 		// DAPR_API_TOKEN
-		assert.Equal(t, "secret", container.Env[8].ValueFrom.SecretKeyRef.Name)
+		assert.Equal(t, "secret", container.Env[9].ValueFrom.SecretKeyRef.Name)
 		// DAPR_APP_TOKEN
-		assert.Equal(t, "appsecret", container.Env[9].ValueFrom.SecretKeyRef.Name)
+		assert.Equal(t, "appsecret", container.Env[10].ValueFrom.SecretKeyRef.Name)
 		// default image
 		assert.Equal(t, "daprio/dapr", container.Image)
 		assert.EqualValues(t, expectedArgs, container.Args)
@@ -597,6 +601,155 @@ func TestGetSidecarContainer(t *testing.T) {
 		},
 	}))
 
+	t.Run("app gRPC keepalive", testSuiteGenerator([]testCase{
+		{
+			name:        "default to empty",
+			annotations: map[string]string{},
+			assertFn: func(t *testing.T, container *corev1.Container) {
+				args := strings.Join(container.Args, " ")
+				assert.NotContains(t, args, "--app-grpc-keepalive-time")
+				assert.NotContains(t, args, "--app-grpc-keepalive-timeout")
+				assert.NotContains(t, args, "--app-grpc-max-connection-age")
+			},
+		},
+		{
+			name: "add keepalive and max connection age",
+			annotations: map[string]string{
+				annotations.KeyAppGRPCKeepaliveTime:    "30s",
+				annotations.KeyAppGRPCKeepaliveTimeout: "5s",
+				annotations.KeyAppGRPCMaxConnectionAge: "10m",
+			},
+			assertFn: func(t *testing.T, container *corev1.Container) {
+				args := strings.Join(container.Args, " ")
+				assert.Contains(t, args, "--app-grpc-keepalive-time 30s")
+				assert.Contains(t, args, "--app-grpc-keepalive-timeout 5s")
+				assert.Contains(t, args, "--app-grpc-max-connection-age 10m")
+			},
+		},
+	}))
+
+	t.Run("app channel routes", testSuiteGenerator([]testCase{
+		{
+			name:        "default to empty",
+			annotations: map[string]string{},
+			assertFn: func(t *testing.T, container *corev1.Container) {
+				args := strings.Join(container.Args, " ")
+				assert.NotContains(t, args, "--app-channel-route")
+			},
+		},
+		{
+			name: "add routes",
+			annotations: map[string]string{
+				annotations.KeyAppChannelRoutes: "pubsub=http:3001,bindings=http:3002",
+			},
+			assertFn: func(t *testing.T, container *corev1.Container) {
+				args := strings.Join(container.Args, " ")
+				assert.Contains(t, args, "--app-channel-route pubsub=http:3001,bindings=http:3002")
+			},
+		},
+	}))
+
+	t.Run("max invoke timeout", testSuiteGenerator([]testCase{
+		{
+			name:        "default to empty",
+			annotations: map[string]string{},
+			assertFn: func(t *testing.T, container *corev1.Container) {
+				args := strings.Join(container.Args, " ")
+				assert.NotContains(t, args, "--max-invoke-timeout")
+			},
+		},
+		{
+			name: "set max timeout",
+			annotations: map[string]string{
+				annotations.KeyMaxInvokeTimeout: "30s",
+			},
+			assertFn: func(t *testing.T, container *corev1.Container) {
+				args := strings.Join(container.Args, " ")
+				assert.Contains(t, args, "--max-invoke-timeout 30s")
+			},
+		},
+	}))
+
+	t.Run("actor version", testSuiteGenerator([]testCase{
+		{
+			name:        "default to empty",
+			annotations: map[string]string{},
+			assertFn: func(t *testing.T, container *corev1.Container) {
+				args := strings.Join(container.Args, " ")
+				assert.NotContains(t, args, "--actor-version")
+			},
+		},
+		{
+			name: "add an actor version",
+			annotations: map[string]string{
+				annotations.KeyActorVersion: "v2",
+			},
+			assertFn: func(t *testing.T, container *corev1.Container) {
+				args := strings.Join(container.Args, " ")
+				assert.Contains(t, args, "--actor-version v2")
+			},
+		},
+	}))
+
+	t.Run("config", testSuiteGenerator([]testCase{
+		{
+			name: "single configuration",
+			annotations: map[string]string{
+				annotations.KeyConfig: "config",
+			},
+			assertFn: func(t *testing.T, container *corev1.Container) {
+				args := strings.Join(container.Args, " ")
+				assert.Contains(t, args, "--config config")
+			},
+		},
+		{
+			name: "multiple configurations",
+			annotations: map[string]string{
+				annotations.KeyConfig: "base, overlay,",
+			},
+			assertFn: func(t *testing.T, container *corev1.Container) {
+				args := strings.Join(container.Args, " ")
+				assert.Contains(t, args, "--config base --config overlay")
+				assert.Equal(t, 2, strings.Count(args, "--config "))
+			},
+		},
+	}))
+
+	t.Run("metrics push", testSuiteGenerator([]testCase{
+		{
+			name:        "default to not pushing",
+			annotations: map[string]string{},
+			assertFn: func(t *testing.T, container *corev1.Container) {
+				args := strings.Join(container.Args, " ")
+				assert.NotContains(t, args, "--metrics-push-url")
+				assert.NotContains(t, args, "--metrics-push-interval")
+			},
+		},
+		{
+			name: "push to a Pushgateway",
+			annotations: map[string]string{
+				annotations.KeyMetricsPushURL:      "http://pushgateway:9091",
+				annotations.KeyMetricsPushInterval: "5s",
+			},
+			assertFn: func(t *testing.T, container *corev1.Container) {
+				args := strings.Join(container.Args, " ")
+				assert.Contains(t, args, "--metrics-push-url http://pushgateway:9091")
+				assert.Contains(t, args, "--metrics-push-interval 5s")
+			},
+		},
+		{
+			name: "metrics disabled",
+			annotations: map[string]string{
+				annotations.KeyEnableMetrics:  "false",
+				annotations.KeyMetricsPushURL: "http://pushgateway:9091",
+			},
+			assertFn: func(t *testing.T, container *corev1.Container) {
+				args := strings.Join(container.Args, " ")
+				assert.NotContains(t, args, "--metrics-push-url")
+			},
+		},
+	}))
+
 	t.Run("sidecar image", testSuiteGenerator([]testCase{
 		{
 			name:        "no annotation",
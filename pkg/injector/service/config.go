@@ -15,6 +15,7 @@ package service
 
 import (
 	"encoding/json"
+	"strings"
 
 	"github.com/kelseyhightower/envconfig"
 	corev1 "k8s.io/api/core/v1"
@@ -36,6 +37,7 @@ type Config struct {
 	RunAsNonRoot                      string `envconfig:"SIDECAR_RUN_AS_NON_ROOT"`
 	ReadOnlyRootFilesystem            string `envconfig:"SIDECAR_READ_ONLY_ROOT_FILESYSTEM"`
 	SidecarDropALLCapabilities        string `envconfig:"SIDECAR_DROP_ALL_CAPABILITIES"`
+	SidecarListenAddressFamily        string `envconfig:"SIDECAR_LISTEN_ADDRESS_FAMILY"`
 
 	TrustAnchorsFile        string `envconfig:"DAPR_TRUST_ANCHORS_FILE"`
 	ControlPlaneTrustDomain string `envconfig:"DAPR_CONTROL_PLANE_TRUST_DOMAIN"`
@@ -118,6 +120,19 @@ func (c *Config) GetDropCapabilities() bool {
 	return kitutils.IsTruthy(c.SidecarDropALLCapabilities)
 }
 
+// GetSidecarListenAddresses returns the default addresses the sidecar listens on, depending on the address family of the cluster.
+// Accepted values are "ipv4", "ipv6" and "dual"; an empty string is returned for dual-stack clusters, which use the sidecar's default.
+func (c *Config) GetSidecarListenAddresses() string {
+	switch strings.ToLower(c.SidecarListenAddressFamily) {
+	case "ipv4":
+		return "127.0.0.1"
+	case "ipv6":
+		return "[::1]"
+	default:
+		return ""
+	}
+}
+
 func (c *Config) GetSkipPlacement() bool {
 	// Default is false if empty
 	return kitutils.IsTruthy(c.SkipPlacement)
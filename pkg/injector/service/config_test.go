@@ -129,6 +129,25 @@ func TestImagePullPolicy(t *testing.T) {
 	}
 }
 
+func TestSidecarListenAddresses(t *testing.T) {
+	testCases := []struct {
+		family   string
+		expected string
+	}{
+		{"", ""},
+		{"dual", ""},
+		{"ipv4", "127.0.0.1"},
+		{"IPv6", "[::1]"},
+	}
+	for _, tc := range testCases {
+		t.Run("family "+tc.family, func(t *testing.T) {
+			c := NewConfigWithDefaults()
+			c.SidecarListenAddressFamily = tc.family
+			assert.Equal(t, tc.expected, c.GetSidecarListenAddresses())
+		})
+	}
+}
+
 func TestTolerationsParsing(t *testing.T) {
 	testCases := []struct {
 		name   string
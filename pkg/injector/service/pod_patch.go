@@ -92,6 +92,11 @@ func (i *injector) getPodPatchOperations(ctx context.Context, ar *admissionv1.Ad
 	// Default value for the sidecar image, which can be overridden by annotations
 	sidecar.SidecarImage = i.config.SidecarImage
 
+	// Default listen addresses for the address family of the cluster, which can be overridden by annotations
+	if listenAddresses := i.config.GetSidecarListenAddresses(); listenAddresses != "" {
+		sidecar.SidecarListenAddresses = listenAddresses
+	}
+
 	// Set the configuration from annotations
 	sidecar.SetFromPodAnnotations()
 
@@ -0,0 +1,132 @@
+/*
+Copyright 2023 The Dapr Authors
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package leaderelection
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	coordinationv1 "k8s.io/api/coordination/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	coordinationv1client "k8s.io/client-go/kubernetes/typed/coordination/v1"
+	"k8s.io/utils/ptr"
+)
+
+const kubernetesLeasePrefix = "dapr-leader-"
+
+// kubernetesLeases keeps the lease of an election in a Kubernetes Lease in the namespace of the app.
+// Updates are rejected by the API server when the Lease was changed since it was read, so only one replica can acquire it.
+// The service account of the app must be allowed to get, create and update Leases.
+type kubernetesLeases struct {
+	client        coordinationv1client.LeaseInterface
+	name          string
+	leaseDuration time.Duration
+	nowFn         func() time.Time
+}
+
+func (l *kubernetesLeases) tryAcquire(ctx context.Context, candidateID string) (string, error) {
+	now := metav1.NewMicroTime(l.nowFn())
+	lease, err := l.client.Get(ctx, l.name, metav1.GetOptions{})
+	if apierrors.IsNotFound(err) {
+		_, err = l.client.Create(ctx, &coordinationv1.Lease{
+			ObjectMeta: metav1.ObjectMeta{Name: l.name},
+			Spec: coordinationv1.LeaseSpec{
+				HolderIdentity:       ptr.To(candidateID),
+				LeaseDurationSeconds: ptr.To(int32(l.leaseDuration.Seconds())),
+				AcquireTime:          &now,
+				RenewTime:            &now,
+			},
+		}, metav1.CreateOptions{})
+		if apierrors.IsAlreadyExists(err) {
+			// Another replica created the Lease first
+			return l.getHolder(ctx)
+		}
+		if err != nil {
+			return "", fmt.Errorf("failed to create the lease: %w", err)
+		}
+		return candidateID, nil
+	}
+	if err != nil {
+		return "", fmt.Errorf("failed to get the lease: %w", err)
+	}
+
+	holder := l.holderOf(lease)
+	if holder != "" && holder != candidateID {
+		return holder, nil
+	}
+	if holder != candidateID {
+		lease.Spec.AcquireTime = &now
+		lease.Spec.LeaseTransitions = ptr.To(ptr.Deref(lease.Spec.LeaseTransitions, 0) + 1)
+	}
+	lease.Spec.HolderIdentity = ptr.To(candidateID)
+	lease.Spec.LeaseDurationSeconds = ptr.To(int32(l.leaseDuration.Seconds()))
+	lease.Spec.RenewTime = &now
+	_, err = l.client.Update(ctx, lease, metav1.UpdateOptions{})
+	if apierrors.IsConflict(err) {
+		// Another replica updated the Lease first
+		return l.getHolder(ctx)
+	}
+	if err != nil {
+		return "", fmt.Errorf("failed to update the lease: %w", err)
+	}
+	return candidateID, nil
+}
+
+func (l *kubernetesLeases) getHolder(ctx context.Context) (string, error) {
+	lease, err := l.client.Get(ctx, l.name, metav1.GetOptions{})
+	if apierrors.IsNotFound(err) {
+		return "", nil
+	}
+	if err != nil {
+		return "", fmt.Errorf("failed to get the lease: %w", err)
+	}
+	return l.holderOf(lease), nil
+}
+
+func (l *kubernetesLeases) release(ctx context.Context, candidateID string) error {
+	lease, err := l.client.Get(ctx, l.name, metav1.GetOptions{})
+	if apierrors.IsNotFound(err) {
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("failed to get the lease: %w", err)
+	}
+	if ptr.Deref(lease.Spec.HolderIdentity, "") != candidateID {
+		return nil
+	}
+
+	lease.Spec.HolderIdentity = nil
+	lease.Spec.AcquireTime = nil
+	lease.Spec.RenewTime = nil
+	_, err = l.client.Update(ctx, lease, metav1.UpdateOptions{})
+	if err != nil && !apierrors.IsConflict(err) {
+		return fmt.Errorf("failed to release the lease: %w", err)
+	}
+	return nil
+}
+
+// holderOf returns the holder of the Lease, or an empty string if it's free or expired.
+func (l *kubernetesLeases) holderOf(lease *coordinationv1.Lease) string {
+	holder := ptr.Deref(lease.Spec.HolderIdentity, "")
+	if holder == "" || lease.Spec.RenewTime == nil {
+		return ""
+	}
+	duration := time.Duration(ptr.Deref(lease.Spec.LeaseDurationSeconds, 0)) * time.Second
+	if !lease.Spec.RenewTime.Add(duration).After(l.nowFn()) {
+		return ""
+	}
+	return holder
+}
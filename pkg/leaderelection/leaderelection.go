@@ -0,0 +1,380 @@
+/*
+Copyright 2023 The Dapr Authors
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package leaderelection implements elections, in which the replicas of an app campaign for a leadership lease through their sidecars.
+package leaderelection
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	coordinationv1client "k8s.io/client-go/kubernetes/typed/coordination/v1"
+
+	"github.com/dapr/components-contrib/state"
+	"github.com/dapr/dapr/pkg/config"
+	"github.com/dapr/kit/logger"
+)
+
+var log = logger.NewLogger("dapr.runtime.leaderelection")
+
+var (
+	// ErrStateStoreNotFound is returned when the state store of an election is not loaded.
+	ErrStateStoreNotFound = errors.New("state store not found")
+	// ErrInvalidCandidate is returned when the ID of a candidate is empty.
+	ErrInvalidCandidate = errors.New("candidate ID is empty")
+)
+
+// Options contains the options for New.
+type Options struct {
+	AppID           string
+	Namespace       string
+	Specs           []config.LeaderElectionSpec
+	GetStateStoreFn func(string) (state.Store, bool)
+	// Client for the Kubernetes Leases of the elections without a state store.
+	// It's nil outside of Kubernetes, where those elections can't be used.
+	KubernetesLeases coordinationv1client.LeasesGetter
+	// nowFn returns the current time. Used in tests.
+	nowFn func() time.Time
+}
+
+// Elections are the elections of the app, keyed by name.
+type Elections map[string]*Election
+
+// New returns the elections configured in the specs.
+func New(opts Options) (Elections, error) {
+	if len(opts.Specs) == 0 {
+		return nil, nil
+	}
+	if opts.nowFn == nil {
+		opts.nowFn = time.Now
+	}
+
+	elections := make(Elections, len(opts.Specs))
+	for _, spec := range opts.Specs {
+		if spec.Name == "" {
+			return nil, errors.New("election name is empty")
+		}
+		if _, ok := elections[spec.Name]; ok {
+			return nil, fmt.Errorf("duplicate election '%s'", spec.Name)
+		}
+		leaseDuration, err := spec.GetLeaseDuration()
+		if err != nil {
+			return nil, err
+		}
+
+		var leases leaseBackend
+		if spec.StateStore != "" {
+			leases = &stateStoreLeases{
+				stateStore:      spec.StateStore,
+				key:             statePrefix + "||" + opts.AppID + "||" + spec.Name,
+				leaseDuration:   leaseDuration,
+				getStateStoreFn: opts.GetStateStoreFn,
+				nowFn:           opts.nowFn,
+			}
+		} else {
+			if opts.KubernetesLeases == nil {
+				return nil, fmt.Errorf("election '%s' has no state store, and Kubernetes Leases are only available in Kubernetes mode", spec.Name)
+			}
+			leases = &kubernetesLeases{
+				client:        opts.KubernetesLeases.Leases(opts.Namespace),
+				name:          kubernetesLeasePrefix + opts.AppID + "-" + spec.Name,
+				leaseDuration: leaseDuration,
+				nowFn:         opts.nowFn,
+			}
+		}
+
+		elections[spec.Name] = newElection(spec.Name, leaseDuration, leases, opts.nowFn)
+	}
+	return elections, nil
+}
+
+// Close stops campaigning in all the elections, releasing the leases held by the candidates of the app.
+func (e Elections) Close() error {
+	for _, election := range e {
+		election.close()
+	}
+	return nil
+}
+
+// leaseBackend keeps the lease of an election.
+type leaseBackend interface {
+	// tryAcquire acquires the lease for the candidate if it's free or expired, or renews it if the candidate already holds it.
+	// It returns the holder of the lease after the attempt.
+	tryAcquire(ctx context.Context, candidateID string) (string, error)
+	// getHolder returns the holder of the lease, or an empty string if the lease is free or expired.
+	getHolder(ctx context.Context) (string, error)
+	// release releases the lease if it's held by the candidate.
+	release(ctx context.Context, candidateID string) error
+}
+
+// Election is an election in which the replicas of the app campaign for a leadership lease.
+// Once a candidate campaigns, the sidecar keeps trying to acquire the lease on its behalf, and renews it while the candidate is the leader, until it resigns.
+// Candidates must campaign again at least once per lease duration as a keepalive: the sidecar stops campaigning for a candidate that didn't, and releases its lease, so an app that crashed doesn't keep the leadership.
+type Election struct {
+	name          string
+	leaseDuration time.Duration
+	retryPeriod   time.Duration
+	leases        leaseBackend
+	nowFn         func() time.Time
+
+	// leaseLock serializes the operations on the lease, so a candidate that resigned doesn't acquire the lease again.
+	leaseLock sync.Mutex
+	lock      sync.Mutex
+	// candidates maps the candidates of the app to the last time they campaigned.
+	candidates map[string]time.Time
+	leader     string
+	renewedAt  time.Time
+	watchers   map[chan string]struct{}
+	running    bool
+	closed     bool
+	closeCh    chan struct{}
+	wg         sync.WaitGroup
+}
+
+func newElection(name string, leaseDuration time.Duration, leases leaseBackend, nowFn func() time.Time) *Election {
+	return &Election{
+		name:          name,
+		leaseDuration: leaseDuration,
+		// The lease is renewed 3 times per lease duration, so a leader doesn't lose it if a renewal fails
+		retryPeriod: leaseDuration / 3,
+		leases:      leases,
+		nowFn:       nowFn,
+		candidates:  map[string]time.Time{},
+		watchers:    map[chan string]struct{}{},
+		closeCh:     make(chan struct{}),
+	}
+}
+
+// Campaign starts campaigning for the leadership on behalf of the candidate, or keeps campaigning if it already did.
+// It returns the leader of the election after the attempt to acquire the lease.
+func (e *Election) Campaign(ctx context.Context, candidateID string) (string, error) {
+	if candidateID == "" {
+		return "", ErrInvalidCandidate
+	}
+
+	e.leaseLock.Lock()
+	defer e.leaseLock.Unlock()
+
+	leaderID, err := e.leases.tryAcquire(ctx, candidateID)
+	if err != nil {
+		return "", err
+	}
+
+	e.lock.Lock()
+	defer e.lock.Unlock()
+	e.candidates[candidateID] = e.nowFn()
+	e.setLeaderLocked(leaderID)
+	e.startLocked()
+	return leaderID, nil
+}
+
+// Resign stops campaigning for the leadership on behalf of the candidate, releasing the lease if the candidate is the leader.
+func (e *Election) Resign(ctx context.Context, candidateID string) error {
+	if candidateID == "" {
+		return ErrInvalidCandidate
+	}
+
+	e.leaseLock.Lock()
+	defer e.leaseLock.Unlock()
+
+	e.lock.Lock()
+	delete(e.candidates, candidateID)
+	e.lock.Unlock()
+
+	if err := e.leases.release(ctx, candidateID); err != nil {
+		return err
+	}
+
+	e.lock.Lock()
+	defer e.lock.Unlock()
+	if e.leader == candidateID {
+		e.setLeaderLocked("")
+	}
+	return nil
+}
+
+// Watch calls fn with the leader of the election, then every time the leader changes, until the context is canceled.
+// The leader is an empty string when there is none.
+func (e *Election) Watch(ctx context.Context, fn func(leaderID string) error) error {
+	// The watcher is registered before reading the leader, so no change is missed.
+	// The channel only keeps the latest leader, so a slow watcher doesn't block the election.
+	ch := make(chan string, 1)
+	e.lock.Lock()
+	e.watchers[ch] = struct{}{}
+	e.startLocked()
+	e.lock.Unlock()
+	defer func() {
+		e.lock.Lock()
+		delete(e.watchers, ch)
+		e.lock.Unlock()
+	}()
+
+	leaderID, err := e.leases.getHolder(ctx)
+	if err != nil {
+		return err
+	}
+	if err = fn(leaderID); err != nil {
+		return err
+	}
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case id := <-ch:
+			if id == leaderID {
+				continue
+			}
+			leaderID = id
+			if err = fn(leaderID); err != nil {
+				return err
+			}
+		}
+	}
+}
+
+// startLocked starts the loop that refreshes the lease, if it's not running yet.
+// It must be called with the lock held.
+func (e *Election) startLocked() {
+	if e.running || e.closed {
+		return
+	}
+	e.running = true
+	e.wg.Add(1)
+	go e.run()
+}
+
+func (e *Election) run() {
+	defer e.wg.Done()
+
+	t := time.NewTicker(e.retryPeriod)
+	defer t.Stop()
+	for {
+		select {
+		case <-e.closeCh:
+			return
+		case <-t.C:
+			e.refresh()
+		}
+	}
+}
+
+// refresh tries to acquire or renew the lease on behalf of the candidates of the app, or reads the leader if the app has no candidates.
+func (e *Election) refresh() {
+	ctx, cancel := context.WithTimeout(context.Background(), e.retryPeriod)
+	defer cancel()
+
+	e.leaseLock.Lock()
+	defer e.leaseLock.Unlock()
+
+	e.lock.Lock()
+	now := e.nowFn()
+	candidates := make([]string, 0, len(e.candidates))
+	var expired []string
+	for c, lastCampaign := range e.candidates {
+		if now.Sub(lastCampaign) >= e.leaseDuration {
+			// The candidate didn't campaign again within the lease duration, so the app may have crashed
+			delete(e.candidates, c)
+			expired = append(expired, c)
+			continue
+		}
+		candidates = append(candidates, c)
+	}
+	e.lock.Unlock()
+
+	for _, c := range expired {
+		log.Infof("Candidate '%s' of election '%s' stopped campaigning without resigning", c, e.name)
+		if err := e.leases.release(ctx, c); err != nil {
+			log.Warnf("Failed to release the lease of election '%s': %v", e.name, err)
+		}
+	}
+
+	var (
+		leaderID string
+		err      error
+	)
+	if len(candidates) == 0 {
+		leaderID, err = e.leases.getHolder(ctx)
+	}
+	for _, c := range candidates {
+		leaderID, err = e.leases.tryAcquire(ctx, c)
+		if err != nil || leaderID == c {
+			break
+		}
+	}
+
+	e.lock.Lock()
+	defer e.lock.Unlock()
+	if err != nil {
+		log.Warnf("Failed to refresh the lease of election '%s': %v", e.name, err)
+
+		// A leader that can't renew the lease steps down when the lease expires, since another replica may acquire it
+		if _, ok := e.candidates[e.leader]; ok && e.nowFn().Sub(e.renewedAt) >= e.leaseDuration {
+			e.setLeaderLocked("")
+		}
+		return
+	}
+	e.setLeaderLocked(leaderID)
+}
+
+// setLeaderLocked records the leader of the election, notifying the watchers if it changed.
+// It must be called with the lock held.
+func (e *Election) setLeaderLocked(leaderID string) {
+	if _, ok := e.candidates[leaderID]; ok {
+		e.renewedAt = e.nowFn()
+	}
+	if leaderID == e.leader {
+		return
+	}
+
+	log.Debugf("Leader of election '%s' changed from '%s' to '%s'", e.name, e.leader, leaderID)
+	e.leader = leaderID
+	for ch := range e.watchers {
+		// Drop the leader the watcher hasn't received yet
+		select {
+		case <-ch:
+		default:
+		}
+		ch <- leaderID
+	}
+}
+
+// close stops the loop that refreshes the lease, and releases it if it's held by a candidate of the app.
+func (e *Election) close() {
+	e.lock.Lock()
+	if e.closed {
+		e.lock.Unlock()
+		return
+	}
+	e.closed = true
+	close(e.closeCh)
+	e.lock.Unlock()
+	e.wg.Wait()
+
+	e.lock.Lock()
+	leaderID := e.leader
+	_, isCandidate := e.candidates[leaderID]
+	e.lock.Unlock()
+	if !isCandidate {
+		return
+	}
+
+	// Releasing the lease lets another replica become the leader without waiting for it to expire
+	ctx, cancel := context.WithTimeout(context.Background(), e.retryPeriod)
+	defer cancel()
+	if err := e.leases.release(ctx, leaderID); err != nil {
+		log.Warnf("Failed to release the lease of election '%s': %v", e.name, err)
+	}
+}
@@ -0,0 +1,429 @@
+/*
+Copyright 2023 The Dapr Authors
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package leaderelection
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"strconv"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"k8s.io/client-go/kubernetes/fake"
+
+	"github.com/dapr/components-contrib/state"
+	"github.com/dapr/dapr/pkg/config"
+	daprt "github.com/dapr/dapr/pkg/testing"
+)
+
+// etagStateStore is a state store that enforces ETags on Set and Delete, like the stores used in production.
+type etagStateStore struct {
+	*daprt.FakeStateStore
+
+	lock  sync.Mutex
+	items map[string]etagItem
+	etag  int
+	// plainErrors makes the store return errors that aren't ETagErrors when a key already exists, like Redis.
+	plainErrors bool
+	// staleReads is the number of Get calls that return no data, as if the key wasn't created yet.
+	staleReads int
+}
+
+type etagItem struct {
+	data []byte
+	etag string
+}
+
+func newETagStateStore() *etagStateStore {
+	return &etagStateStore{
+		FakeStateStore: daprt.NewFakeStateStore(),
+		items:          map[string]etagItem{},
+	}
+}
+
+func (s *etagStateStore) Get(ctx context.Context, req *state.GetRequest) (*state.GetResponse, error) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	item, ok := s.items[req.Key]
+	if !ok || s.staleReads > 0 {
+		s.staleReads = max(s.staleReads-1, 0)
+		return &state.GetResponse{}, nil
+	}
+	etag := item.etag
+	return &state.GetResponse{Data: item.data, ETag: &etag}, nil
+}
+
+func (s *etagStateStore) Set(ctx context.Context, req *state.SetRequest) error {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	if err := s.checkETag(req.Key, req.ETag, req.Options.Concurrency); err != nil {
+		return err
+	}
+	data, err := json.Marshal(req.Value)
+	if err != nil {
+		return err
+	}
+	s.etag++
+	s.items[req.Key] = etagItem{data: data, etag: strconv.Itoa(s.etag)}
+	return nil
+}
+
+func (s *etagStateStore) Delete(ctx context.Context, req *state.DeleteRequest) error {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	if req.ETag != nil {
+		if err := s.checkETag(req.Key, req.ETag, req.Options.Concurrency); err != nil {
+			return err
+		}
+	}
+	delete(s.items, req.Key)
+	return nil
+}
+
+func (s *etagStateStore) checkETag(key string, etag *string, concurrency string) error {
+	item, ok := s.items[key]
+	switch {
+	case etag != nil && (!ok || item.etag != *etag):
+		return state.NewETagError(state.ETagMismatch, errors.New("etag mismatch"))
+	case etag == nil && ok && concurrency == state.FirstWrite:
+		if s.plainErrors {
+			return errors.New("key already exists")
+		}
+		return state.NewETagError(state.ETagMismatch, errors.New("key already exists"))
+	}
+	return nil
+}
+
+// testClock is a clock whose time is set by the test.
+type testClock struct {
+	now atomic.Pointer[time.Time]
+}
+
+func newTestClock() *testClock {
+	c := &testClock{}
+	c.Set(time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC))
+	return c
+}
+
+func (c *testClock) Now() time.Time {
+	return *c.now.Load()
+}
+
+func (c *testClock) Set(t time.Time) {
+	c.now.Store(&t)
+}
+
+func (c *testClock) Step(d time.Duration) {
+	c.Set(c.Now().Add(d))
+}
+
+func TestNew(t *testing.T) {
+	t.Run("no elections", func(t *testing.T) {
+		elections, err := New(Options{})
+		require.NoError(t, err)
+		assert.Nil(t, elections)
+	})
+
+	t.Run("valid elections", func(t *testing.T) {
+		elections, err := New(Options{
+			Specs: []config.LeaderElectionSpec{
+				{Name: "a", StateStore: "store"},
+				{Name: "b", LeaseDuration: "1m"},
+			},
+			KubernetesLeases: fake.NewSimpleClientset().CoordinationV1(),
+		})
+		require.NoError(t, err)
+		require.Len(t, elections, 2)
+		assert.IsType(t, &stateStoreLeases{}, elections["a"].leases)
+		assert.Equal(t, 15*time.Second, elections["a"].leaseDuration)
+		assert.IsType(t, &kubernetesLeases{}, elections["b"].leases)
+		assert.Equal(t, time.Minute, elections["b"].leaseDuration)
+		assert.Equal(t, 20*time.Second, elections["b"].retryPeriod)
+	})
+
+	invalid := map[string]config.LeaderElectionSpec{
+		"empty name":             {StateStore: "store"},
+		"invalid lease duration": {Name: "a", StateStore: "store", LeaseDuration: "foo"},
+		"no kubernetes leases":   {Name: "a"},
+	}
+	for name, spec := range invalid {
+		t.Run(name, func(t *testing.T) {
+			_, err := New(Options{Specs: []config.LeaderElectionSpec{spec}})
+			require.Error(t, err)
+		})
+	}
+
+	t.Run("duplicate names", func(t *testing.T) {
+		_, err := New(Options{
+			Specs: []config.LeaderElectionSpec{
+				{Name: "a", StateStore: "store"},
+				{Name: "a", StateStore: "store"},
+			},
+		})
+		require.Error(t, err)
+	})
+}
+
+func TestLeases(t *testing.T) {
+	clock := newTestClock()
+	store := newETagStateStore()
+	backends := map[string]leaseBackend{
+		"state store": &stateStoreLeases{
+			stateStore:    "store",
+			key:           "lease",
+			leaseDuration: 10 * time.Second,
+			getStateStoreFn: func(name string) (state.Store, bool) {
+				return store, name == "store"
+			},
+			nowFn: clock.Now,
+		},
+		"kubernetes": &kubernetesLeases{
+			client:        fake.NewSimpleClientset().CoordinationV1().Leases("default"),
+			name:          "lease",
+			leaseDuration: 10 * time.Second,
+			nowFn:         clock.Now,
+		},
+	}
+
+	for name, leases := range backends {
+		t.Run(name, func(t *testing.T) {
+			ctx := context.Background()
+
+			holder, err := leases.getHolder(ctx)
+			require.NoError(t, err)
+			assert.Empty(t, holder)
+
+			// The first candidate acquires the lease
+			holder, err = leases.tryAcquire(ctx, "a")
+			require.NoError(t, err)
+			assert.Equal(t, "a", holder)
+			holder, err = leases.tryAcquire(ctx, "b")
+			require.NoError(t, err)
+			assert.Equal(t, "a", holder)
+
+			// The leader renews the lease before it expires
+			clock.Step(8 * time.Second)
+			holder, err = leases.tryAcquire(ctx, "a")
+			require.NoError(t, err)
+			assert.Equal(t, "a", holder)
+			clock.Step(8 * time.Second)
+			holder, err = leases.tryAcquire(ctx, "b")
+			require.NoError(t, err)
+			assert.Equal(t, "a", holder)
+
+			// Another candidate acquires the lease once it expires
+			clock.Step(10 * time.Second)
+			holder, err = leases.getHolder(ctx)
+			require.NoError(t, err)
+			assert.Empty(t, holder)
+			holder, err = leases.tryAcquire(ctx, "b")
+			require.NoError(t, err)
+			assert.Equal(t, "b", holder)
+
+			// Only the leader releases the lease
+			require.NoError(t, leases.release(ctx, "a"))
+			holder, err = leases.getHolder(ctx)
+			require.NoError(t, err)
+			assert.Equal(t, "b", holder)
+			require.NoError(t, leases.release(ctx, "b"))
+			holder, err = leases.getHolder(ctx)
+			require.NoError(t, err)
+			assert.Empty(t, holder)
+			if _, ok := leases.(*stateStoreLeases); ok {
+				// The lease is expired, not deleted
+				assert.Contains(t, store.items, "lease")
+			}
+
+			holder, err = leases.tryAcquire(ctx, "a")
+			require.NoError(t, err)
+			assert.Equal(t, "a", holder)
+		})
+	}
+
+	t.Run("lease created by another replica first", func(t *testing.T) {
+		store := newETagStateStore()
+		store.plainErrors = true
+		leases := &stateStoreLeases{
+			stateStore:    "store",
+			key:           "lease",
+			leaseDuration: 10 * time.Second,
+			getStateStoreFn: func(name string) (state.Store, bool) {
+				return store, name == "store"
+			},
+			nowFn: clock.Now,
+		}
+		ctx := context.Background()
+
+		holder, err := leases.tryAcquire(ctx, "a")
+		require.NoError(t, err)
+		assert.Equal(t, "a", holder)
+
+		// The other replica read the lease before it was created, and its insert-only write fails with a plain error
+		store.staleReads = 1
+		holder, err = leases.tryAcquire(ctx, "b")
+		require.NoError(t, err)
+		assert.Equal(t, "a", holder)
+	})
+
+	t.Run("state store not found", func(t *testing.T) {
+		leases := &stateStoreLeases{
+			stateStore: "other",
+			getStateStoreFn: func(name string) (state.Store, bool) {
+				return store, name == "store"
+			},
+			nowFn: clock.Now,
+		}
+		_, err := leases.tryAcquire(context.Background(), "a")
+		require.ErrorIs(t, err, ErrStateStoreNotFound)
+	})
+}
+
+func TestElection(t *testing.T) {
+	store := newETagStateStore()
+
+	// Two replicas of the app campaign in the same election
+	newReplica := func() *Election {
+		elections, err := New(Options{
+			AppID: "myapp",
+			Specs: []config.LeaderElectionSpec{
+				{Name: "election", StateStore: "store", LeaseDuration: "1s"},
+			},
+			GetStateStoreFn: func(name string) (state.Store, bool) {
+				return store, name == "store"
+			},
+		})
+		require.NoError(t, err)
+		e := elections["election"]
+		e.retryPeriod = 50 * time.Millisecond
+		return e
+	}
+	replica1 := newReplica()
+	replica2 := newReplica()
+	defer replica1.close()
+	defer replica2.close()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	var (
+		leadersLock sync.Mutex
+		leaders     []string
+	)
+	watchErr := make(chan error, 1)
+	go func() {
+		watchErr <- replica2.Watch(ctx, func(leaderID string) error {
+			leadersLock.Lock()
+			leaders = append(leaders, leaderID)
+			leadersLock.Unlock()
+			return nil
+		})
+	}()
+	assertLeaders := func(expected ...string) {
+		t.Helper()
+		assert.EventuallyWithT(t, func(c *assert.CollectT) {
+			leadersLock.Lock()
+			defer leadersLock.Unlock()
+			assert.Equal(c, expected, leaders)
+		}, 5*time.Second, 10*time.Millisecond)
+	}
+	assertLeaders("")
+
+	t.Run("first candidate becomes the leader", func(t *testing.T) {
+		leaderID, err := replica1.Campaign(context.Background(), "pod-1")
+		require.NoError(t, err)
+		assert.Equal(t, "pod-1", leaderID)
+
+		leaderID, err = replica2.Campaign(context.Background(), "pod-2")
+		require.NoError(t, err)
+		assert.Equal(t, "pod-1", leaderID)
+		assertLeaders("", "pod-1")
+	})
+
+	t.Run("other candidate becomes the leader when the leader resigns", func(t *testing.T) {
+		require.NoError(t, replica1.Resign(context.Background(), "pod-1"))
+		assertLeaders("", "pod-1", "pod-2")
+
+		// The candidate that resigned doesn't campaign anymore
+		time.Sleep(200 * time.Millisecond)
+		leaderID, err := replica1.leases.getHolder(context.Background())
+		require.NoError(t, err)
+		assert.Equal(t, "pod-2", leaderID)
+	})
+
+	t.Run("lease is released when the sidecar shuts down", func(t *testing.T) {
+		replica2.close()
+		leaderID, err := replica1.leases.getHolder(context.Background())
+		require.NoError(t, err)
+		assert.Empty(t, leaderID)
+	})
+
+	t.Run("invalid candidate", func(t *testing.T) {
+		_, err := replica1.Campaign(context.Background(), "")
+		require.ErrorIs(t, err, ErrInvalidCandidate)
+	})
+
+	cancel()
+	require.NoError(t, <-watchErr)
+}
+
+func TestElectionKeepalive(t *testing.T) {
+	clock := newTestClock()
+	store := newETagStateStore()
+	elections, err := New(Options{
+		AppID: "myapp",
+		Specs: []config.LeaderElectionSpec{
+			{Name: "election", StateStore: "store", LeaseDuration: "9s"},
+		},
+		GetStateStoreFn: func(name string) (state.Store, bool) {
+			return store, name == "store"
+		},
+		nowFn: clock.Now,
+	})
+	require.NoError(t, err)
+	e := elections["election"]
+	defer e.close()
+
+	leaderID, err := e.Campaign(context.Background(), "pod-1")
+	require.NoError(t, err)
+	assert.Equal(t, "pod-1", leaderID)
+
+	// The candidate keeps the leadership while it campaigns again within the lease duration
+	for i := 0; i < 3; i++ {
+		clock.Step(6 * time.Second)
+		_, err = e.Campaign(context.Background(), "pod-1")
+		require.NoError(t, err)
+		e.refresh()
+		leaderID, err = e.leases.getHolder(context.Background())
+		require.NoError(t, err)
+		assert.Equal(t, "pod-1", leaderID)
+	}
+
+	// The sidecar stops campaigning for a candidate that didn't campaign again, and releases the lease
+	clock.Step(9 * time.Second)
+	e.refresh()
+	leaderID, err = e.leases.getHolder(context.Background())
+	require.NoError(t, err)
+	assert.Empty(t, leaderID)
+	e.lock.Lock()
+	assert.Empty(t, e.candidates)
+	assert.Empty(t, e.leader)
+	e.lock.Unlock()
+}
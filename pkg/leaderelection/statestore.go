@@ -0,0 +1,161 @@
+/*
+Copyright 2023 The Dapr Authors
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package leaderelection
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/dapr/components-contrib/state"
+)
+
+const statePrefix = "dapr-leader"
+
+// leaseRecord is the lease of an election, as saved in a state store.
+type leaseRecord struct {
+	HolderID  string    `json:"holderId"`
+	ExpiresAt time.Time `json:"expiresAt"`
+}
+
+// stateStoreLeases keeps the lease of an election in a state store.
+// The lease is updated with optimistic concurrency, so only one replica can acquire it.
+// The record is created with an insert-only write the first time, and it's never deleted afterwards: releasing the lease saves an expired record, so every later update is guarded by an ETag.
+type stateStoreLeases struct {
+	stateStore      string
+	key             string
+	leaseDuration   time.Duration
+	getStateStoreFn func(string) (state.Store, bool)
+	nowFn           func() time.Time
+}
+
+func (l *stateStoreLeases) tryAcquire(ctx context.Context, candidateID string) (string, error) {
+	store, err := l.getStateStore()
+	if err != nil {
+		return "", err
+	}
+	record, etag, err := l.get(ctx, store)
+	if err != nil {
+		return "", err
+	}
+
+	now := l.nowFn()
+	if record.HolderID != "" && record.HolderID != candidateID && record.ExpiresAt.After(now) {
+		return record.HolderID, nil
+	}
+
+	req := &state.SetRequest{
+		Key: l.key,
+		Value: leaseRecord{
+			HolderID:  candidateID,
+			ExpiresAt: now.Add(l.leaseDuration),
+		},
+		ETag: etag,
+		Options: state.SetStateOption{
+			Concurrency: state.FirstWrite,
+		},
+	}
+	err = store.Set(ctx, req)
+	var etagErr *state.ETagError
+	switch {
+	case err == nil:
+		return candidateID, nil
+	case etag == nil:
+		// Another replica created the lease first.
+		// Not all state stores return an ETagError when the key of an insert-only write already exists, so any error is treated as a conflict.
+		log.Debugf("Failed to create the lease, reading it again: %v", err)
+		return l.getHolder(ctx)
+	case errors.As(err, &etagErr):
+		// Another replica updated the lease first
+		return l.getHolder(ctx)
+	default:
+		return "", fmt.Errorf("failed to update the lease: %w", err)
+	}
+}
+
+func (l *stateStoreLeases) getHolder(ctx context.Context) (string, error) {
+	store, err := l.getStateStore()
+	if err != nil {
+		return "", err
+	}
+	record, _, err := l.get(ctx, store)
+	if err != nil {
+		return "", err
+	}
+	if !record.ExpiresAt.After(l.nowFn()) {
+		return "", nil
+	}
+	return record.HolderID, nil
+}
+
+func (l *stateStoreLeases) release(ctx context.Context, candidateID string) error {
+	store, err := l.getStateStore()
+	if err != nil {
+		return err
+	}
+	record, etag, err := l.get(ctx, store)
+	if err != nil {
+		return err
+	}
+	if record.HolderID != candidateID {
+		return nil
+	}
+
+	// The record is expired rather than deleted, so the lease is never created again with a write that isn't guarded by an ETag
+	err = store.Set(ctx, &state.SetRequest{
+		Key: l.key,
+		Value: leaseRecord{
+			ExpiresAt: l.nowFn(),
+		},
+		ETag: etag,
+		Options: state.SetStateOption{
+			Concurrency: state.FirstWrite,
+		},
+	})
+	var etagErr *state.ETagError
+	if err != nil && !errors.As(err, &etagErr) {
+		return fmt.Errorf("failed to release the lease: %w", err)
+	}
+	return nil
+}
+
+// get returns the lease saved in the state store, and its ETag.
+func (l *stateStoreLeases) get(ctx context.Context, store state.Store) (leaseRecord, *string, error) {
+	var record leaseRecord
+	res, err := store.Get(ctx, &state.GetRequest{Key: l.key})
+	if err != nil {
+		return record, nil, fmt.Errorf("failed to get the lease: %w", err)
+	}
+	if res == nil || len(res.Data) == 0 {
+		return record, nil, nil
+	}
+	if err = json.Unmarshal(res.Data, &record); err != nil {
+		return record, nil, fmt.Errorf("invalid lease: %w", err)
+	}
+	return record, res.ETag, nil
+}
+
+func (l *stateStoreLeases) getStateStore() (state.Store, error) {
+	if l.getStateStoreFn != nil {
+		if store, ok := l.getStateStoreFn(l.stateStore); ok {
+			if !state.FeatureETag.IsPresent(store.Features()) {
+				return nil, fmt.Errorf("state store %s does not support ETags", l.stateStore)
+			}
+			return store, nil
+		}
+	}
+	return nil, fmt.Errorf("%w: %s", ErrStateStoreNotFound, l.stateStore)
+}
@@ -92,18 +92,61 @@ var (
 	ErrDirectInvoke         = APIError{"failed to invoke, id: %s, err: %v", "ERR_DIRECT_INVOKE", http.StatusInternalServerError, grpcCodes.Internal}
 	ErrDirectInvokeNoAppID  = APIError{"failed getting app id either from the URL path or the header dapr-app-id", "ERR_DIRECT_INVOKE", http.StatusNotFound, grpcCodes.NotFound}
 	ErrDirectInvokeNotReady = APIError{"invoke API is not ready", "ERR_DIRECT_INVOKE", http.StatusInternalServerError, grpcCodes.Internal}
+	ErrDirectInvokeTimeout  = APIError{"invalid invocation timeout: %v", "ERR_DIRECT_INVOKE", http.StatusBadRequest, grpcCodes.InvalidArgument}
+
+	// Rate limiting.
+	ErrAPIRateLimited = APIError{"too many requests to the %s API", "ERR_TOO_MANY_REQUESTS", http.StatusTooManyRequests, grpcCodes.ResourceExhausted}
 
 	// Healthz.
 	ErrHealthNotReady         = APIError{"dapr is not ready", "ERR_HEALTH_NOT_READY", http.StatusInternalServerError, grpcCodes.Internal}
 	ErrOutboundHealthNotReady = APIError{"dapr outbound is not ready", "ERR_OUTBOUND_HEALTH_NOT_READY", http.StatusInternalServerError, grpcCodes.Internal}
+	ErrWorkflowHealthNotReady = APIError{"dapr workflow engine is not ready", "ERR_WORKFLOW_HEALTH_NOT_READY", http.StatusInternalServerError, grpcCodes.Internal}
 	ErrHealthAppIDNotMatch    = APIError{"dapr app-id does not match", "ERR_HEALTH_APPID_NOT_MATCH", http.StatusInternalServerError, grpcCodes.Internal}
 
+	// Metadata.
+	ErrBootReportNotAvailable = APIError{"boot report is not available", "ERR_BOOT_REPORT_NOT_AVAILABLE", http.StatusInternalServerError, grpcCodes.Internal}
+
+	// Metrics.
+	ErrMetricsNotEnabled    = APIError{"metrics are not enabled", "ERR_METRICS_NOT_ENABLED", http.StatusBadRequest, grpcCodes.FailedPrecondition}
+	ErrMetricsInvalid       = APIError{"invalid measurement: %v", "ERR_METRICS_INVALID", http.StatusBadRequest, grpcCodes.InvalidArgument}
+	ErrMetricsLimitExceeded = APIError{"%v", "ERR_METRICS_LIMIT_EXCEEDED", http.StatusTooManyRequests, grpcCodes.ResourceExhausted}
+
+	// Tracing.
+	ErrTracingNotEnabled = APIError{"tracing is not enabled", "ERR_TRACING_NOT_ENABLED", http.StatusBadRequest, grpcCodes.FailedPrecondition}
+	ErrTracingInvalid    = APIError{"invalid span: %v", "ERR_TRACING_INVALID", http.StatusBadRequest, grpcCodes.InvalidArgument}
+
+	// Rate limit.
+	ErrRateLimiterNotFound  = APIError{"rate limiter %s is not found", "ERR_RATE_LIMITER_NOT_FOUND", http.StatusBadRequest, grpcCodes.InvalidArgument}
+	ErrRateLimitInvalid     = APIError{"invalid rate limit request: %v", "ERR_RATE_LIMIT_INVALID", http.StatusBadRequest, grpcCodes.InvalidArgument}
+	ErrRateLimitCheckFailed = APIError{"failed to check the limit of rate limiter %s: %v", "ERR_RATE_LIMIT_CHECK_FAILED", http.StatusInternalServerError, grpcCodes.Internal}
+
+	// Leader election.
+	ErrElectionNotFound      = APIError{"election %s is not found", "ERR_ELECTION_NOT_FOUND", http.StatusBadRequest, grpcCodes.InvalidArgument}
+	ErrLeaderElectionInvalid = APIError{"invalid leader election request: %v", "ERR_LEADER_ELECTION_INVALID", http.StatusBadRequest, grpcCodes.InvalidArgument}
+	ErrLeaderElectionFailed  = APIError{"failed to %s in election %s: %v", "ERR_LEADER_ELECTION_FAILED", http.StatusInternalServerError, grpcCodes.Internal}
+
+	// Bindings.
+	ErrBindingNotFound                = APIError{"output binding %s is not found", "ERR_BINDING_NOT_FOUND", http.StatusBadRequest, grpcCodes.InvalidArgument}
+	ErrBindingDeadLetterNotConfigured = APIError{"failed invocations are not stored for output binding %s", "ERR_BINDING_DEAD_LETTER_NOT_CONFIGURED", http.StatusBadRequest, grpcCodes.FailedPrecondition}
+	ErrBindingDeadLetterReplay        = APIError{"failed to replay the failed invocations of output binding %s: %v", "ERR_BINDING_DEAD_LETTER_REPLAY", http.StatusInternalServerError, grpcCodes.Internal}
+
+	// App channel.
+	ErrAppChannelNotConfigured = APIError{"app channel is not configured", "ERR_APP_CHANNEL_NOT_CONFIGURED", http.StatusBadRequest, grpcCodes.FailedPrecondition}
+	ErrAppChannelReset         = APIError{"failed to reset the app channel: %v", "ERR_APP_CHANNEL_RESET", http.StatusInternalServerError, grpcCodes.Internal}
+
 	// State.
 	ErrStateStoresNotConfigured    = APIError{"state store is not configured", "ERR_STATE_STORE_NOT_CONFIGURED", http.StatusInternalServerError, grpcCodes.FailedPrecondition}
 	ErrStateStoreNotFound          = APIError{"state store %s is not found", "ERR_STATE_STORE_NOT_FOUND", http.StatusBadRequest, grpcCodes.InvalidArgument}
 	ErrStateQueryFailed            = APIError{"failed query in state store %s: %s", "ERR_STATE_QUERY", http.StatusInternalServerError, grpcCodes.Internal}
 	ErrStateQueryUnsupported       = APIError{"state store does not support querying", "ERR_STATE_STORE_NOT_SUPPORTED", http.StatusInternalServerError, grpcCodes.Internal}
 	ErrStateTooManyTransactionalOp = APIError{"the transaction contains %d operations, which is more than what the state store supports: %d", "ERR_STATE_STORE_TOO_MANY_TRANSACTIONS", http.StatusBadRequest, grpcCodes.InvalidArgument}
+	ErrStateInvalidMetadata        = APIError{"invalid metadata for state store %s: %s", "ERR_STATE_INVALID_METADATA", http.StatusBadRequest, grpcCodes.InvalidArgument}
+	ErrStateChangeFeedUnsupported  = APIError{"state store %s does not support change feeds", "ERR_STATE_CHANGE_FEED_NOT_SUPPORTED", http.StatusBadRequest, grpcCodes.Unimplemented}
+	ErrStateChangeFeedNotEnabled   = APIError{"change feed is not enabled for state store %s", "ERR_STATE_CHANGE_FEED_NOT_ENABLED", http.StatusBadRequest, grpcCodes.FailedPrecondition}
+	ErrStateChangeFeed             = APIError{"failed to receive the changes of state store %s: %v", "ERR_STATE_CHANGE_FEED", http.StatusInternalServerError, grpcCodes.Internal}
+	ErrStateSubscriptionNotFound   = APIError{"subscription %s to the changes of state store %s is not found", "ERR_STATE_SUBSCRIPTION_NOT_FOUND", http.StatusNotFound, grpcCodes.NotFound}
+	ErrStateIdempotencyKeyReused   = APIError{"idempotency key %s was already used for a different request to state store %s", "ERR_STATE_IDEMPOTENCY_KEY_REUSED", http.StatusUnprocessableEntity, grpcCodes.FailedPrecondition}
+	ErrStateIdempotencyKeyInUse    = APIError{"idempotency key %s is used by a request to state store %s that is in progress", "ERR_STATE_IDEMPOTENCY_KEY_IN_USE", http.StatusConflict, grpcCodes.Aborted}
 
 	// PubSub.
 	ErrPubSubMetadataDeserialize = APIError{"failed deserializing metadata: %v", "ERR_PUBSUB_REQUEST_METADATA", http.StatusBadRequest, grpcCodes.InvalidArgument}
@@ -124,6 +167,7 @@ var (
 	// Actor.
 	ErrActorReminderOpActorNotHosted = APIError{"operations on actor reminders are only possible on hosted actor types", "ERR_ACTOR_REMINDER_NON_HOSTED", http.StatusForbidden, grpcCodes.PermissionDenied}
 	ErrActorRuntimeNotFound          = APIError{`the state store is not configured to use the actor runtime. Have you set the - name: actorStateStore value: "true" in your state store component file?`, "ERR_ACTOR_RUNTIME_NOT_FOUND", http.StatusInternalServerError, grpcCodes.Internal}
+	ErrActorInvokeForbidden          = APIError{"app '%s' is not allowed to invoke actors of type '%s'", "ERR_ACTOR_INVOKE_FORBIDDEN", http.StatusForbidden, grpcCodes.PermissionDenied}
 
 	// Lock.
 	ErrLockStoresNotConfigured    = APIError{"lock store is not configured", "ERR_LOCK_STORE_NOT_CONFIGURED", http.StatusInternalServerError, grpcCodes.FailedPrecondition}
@@ -135,19 +179,30 @@ var (
 	ErrUnlockFailed               = APIError{"failed to release lock: %s", "ERR_UNLOCK", http.StatusInternalServerError, grpcCodes.Internal}
 
 	// Workflow.
-	ErrStartWorkflow                 = APIError{"error starting workflow '%s': %s", "ERR_START_WORKFLOW", http.StatusInternalServerError, grpcCodes.Internal}
-	ErrWorkflowGetResponse           = APIError{"error while getting workflow info on instance '%s': %s", "ERR_GET_WORKFLOW", http.StatusInternalServerError, grpcCodes.Internal}
-	ErrWorkflowNameMissing           = APIError{"workflow name is not configured", "ERR_WORKFLOW_NAME_MISSING", http.StatusBadRequest, grpcCodes.InvalidArgument}
-	ErrInstanceIDTooLong             = APIError{"workflow instance ID exceeds the max length of %d characters", "ERR_INSTANCE_ID_TOO_LONG", http.StatusBadRequest, grpcCodes.InvalidArgument}
-	ErrInvalidInstanceID             = APIError{"workflow instance ID '%s' is invalid: only alphanumeric and underscore characters are allowed", "ERR_INSTANCE_ID_INVALID", http.StatusBadRequest, grpcCodes.InvalidArgument}
-	ErrWorkflowComponentDoesNotExist = APIError{"workflow component '%s' does not exist", "ERR_WORKFLOW_COMPONENT_NOT_FOUND", http.StatusBadRequest, grpcCodes.InvalidArgument}
-	ErrMissingOrEmptyInstance        = APIError{"no instance ID was provided", "ERR_INSTANCE_ID_PROVIDED_MISSING", http.StatusBadRequest, grpcCodes.InvalidArgument}
-	ErrWorkflowInstanceNotFound      = APIError{"unable to find workflow with the provided instance ID: %s", "ERR_INSTANCE_ID_NOT_FOUND", http.StatusNotFound, grpcCodes.NotFound}
-	ErrNoOrMissingWorkflowComponent  = APIError{"no workflow component was provided", "ERR_WORKFLOW_COMPONENT_MISSING", http.StatusBadRequest, grpcCodes.InvalidArgument}
-	ErrTerminateWorkflow             = APIError{"error terminating workflow '%s': %s", "ERR_TERMINATE_WORKFLOW", http.StatusInternalServerError, grpcCodes.Internal}
-	ErrMissingWorkflowEventName      = APIError{"missing workflow event name", "ERR_WORKFLOW_EVENT_NAME_MISSING", http.StatusBadRequest, grpcCodes.InvalidArgument}
-	ErrRaiseEventWorkflow            = APIError{"error raising event on workflow '%s': %s", "ERR_RAISE_EVENT_WORKFLOW", http.StatusInternalServerError, grpcCodes.Internal}
-	ErrPauseWorkflow                 = APIError{"error pausing workflow %s: %s", "ERR_PAUSE_WORKFLOW", http.StatusInternalServerError, grpcCodes.Internal}
-	ErrResumeWorkflow                = APIError{"error resuming workflow %s: %s", "ERR_RESUME_WORKFLOW", http.StatusInternalServerError, grpcCodes.Internal}
-	ErrPurgeWorkflow                 = APIError{"error purging workflow %s: %s", "ERR_PURGE_WORKFLOW", http.StatusInternalServerError, grpcCodes.Internal}
+	ErrStartWorkflow                   = APIError{"error starting workflow '%s': %s", "ERR_START_WORKFLOW", http.StatusInternalServerError, grpcCodes.Internal}
+	ErrWorkflowGetResponse             = APIError{"error while getting workflow info on instance '%s': %s", "ERR_GET_WORKFLOW", http.StatusInternalServerError, grpcCodes.Internal}
+	ErrWorkflowNameMissing             = APIError{"workflow name is not configured", "ERR_WORKFLOW_NAME_MISSING", http.StatusBadRequest, grpcCodes.InvalidArgument}
+	ErrInstanceIDTooLong               = APIError{"workflow instance ID exceeds the max length of %d characters", "ERR_INSTANCE_ID_TOO_LONG", http.StatusBadRequest, grpcCodes.InvalidArgument}
+	ErrInvalidInstanceID               = APIError{"workflow instance ID '%s' is invalid: only alphanumeric and underscore characters are allowed", "ERR_INSTANCE_ID_INVALID", http.StatusBadRequest, grpcCodes.InvalidArgument}
+	ErrWorkflowComponentDoesNotExist   = APIError{"workflow component '%s' does not exist", "ERR_WORKFLOW_COMPONENT_NOT_FOUND", http.StatusBadRequest, grpcCodes.InvalidArgument}
+	ErrMissingOrEmptyInstance          = APIError{"no instance ID was provided", "ERR_INSTANCE_ID_PROVIDED_MISSING", http.StatusBadRequest, grpcCodes.InvalidArgument}
+	ErrWorkflowInstanceNotFound        = APIError{"unable to find workflow with the provided instance ID: %s", "ERR_INSTANCE_ID_NOT_FOUND", http.StatusNotFound, grpcCodes.NotFound}
+	ErrNoOrMissingWorkflowComponent    = APIError{"no workflow component was provided", "ERR_WORKFLOW_COMPONENT_MISSING", http.StatusBadRequest, grpcCodes.InvalidArgument}
+	ErrTerminateWorkflow               = APIError{"error terminating workflow '%s': %s", "ERR_TERMINATE_WORKFLOW", http.StatusInternalServerError, grpcCodes.Internal}
+	ErrMissingWorkflowEventName        = APIError{"missing workflow event name", "ERR_WORKFLOW_EVENT_NAME_MISSING", http.StatusBadRequest, grpcCodes.InvalidArgument}
+	ErrRaiseEventWorkflow              = APIError{"error raising event on workflow '%s': %s", "ERR_RAISE_EVENT_WORKFLOW", http.StatusInternalServerError, grpcCodes.Internal}
+	ErrRaiseEventWorkflowBatchEmpty    = APIError{"no events were provided", "ERR_RAISE_EVENT_WORKFLOW_BATCH_EMPTY", http.StatusBadRequest, grpcCodes.InvalidArgument}
+	ErrRaiseEventWorkflowBatchTooLarge = APIError{"batch contains %d events, exceeding the max of %d", "ERR_RAISE_EVENT_WORKFLOW_BATCH_TOO_LARGE", http.StatusBadRequest, grpcCodes.InvalidArgument}
+	ErrPauseWorkflow                   = APIError{"error pausing workflow %s: %s", "ERR_PAUSE_WORKFLOW", http.StatusInternalServerError, grpcCodes.Internal}
+	ErrResumeWorkflow                  = APIError{"error resuming workflow %s: %s", "ERR_RESUME_WORKFLOW", http.StatusInternalServerError, grpcCodes.Internal}
+	ErrPurgeWorkflow                   = APIError{"error purging workflow %s: %s", "ERR_PURGE_WORKFLOW", http.StatusInternalServerError, grpcCodes.Internal}
+	ErrWorkflowOperationForbidden      = APIError{"app '%s' is not allowed to %s workflow '%s'", "ERR_WORKFLOW_FORBIDDEN", http.StatusForbidden, grpcCodes.PermissionDenied}
+	ErrWorkflowHistoryNotSupported     = APIError{"workflow component '%s' does not support exporting and replaying histories", "ERR_WORKFLOW_HISTORY_NOT_SUPPORTED", http.StatusBadRequest, grpcCodes.InvalidArgument}
+	ErrWorkflowGetHistory              = APIError{"error getting the history of workflow %s: %s", "ERR_GET_WORKFLOW_HISTORY", http.StatusInternalServerError, grpcCodes.Internal}
+	ErrReplayWorkflow                  = APIError{"error replaying workflow %s: %s", "ERR_REPLAY_WORKFLOW", http.StatusInternalServerError, grpcCodes.Internal}
+	ErrWorkflowHistoryMalformed        = APIError{"invalid history of workflow %s: %s", "ERR_WORKFLOW_HISTORY_MALFORMED", http.StatusBadRequest, grpcCodes.InvalidArgument}
+	ErrWorkflowHistoryTooLarge         = APIError{"workflow history exceeds the max size of %d bytes", "ERR_WORKFLOW_HISTORY_TOO_LARGE", http.StatusRequestEntityTooLarge, grpcCodes.ResourceExhausted}
+	ErrWorkflowListNotSupported        = APIError{"workflow component '%s' does not support listing workflow instances", "ERR_WORKFLOW_LIST_NOT_SUPPORTED", http.StatusBadRequest, grpcCodes.InvalidArgument}
+	ErrListWorkflowsInvalidToken       = APIError{"invalid continuation token: %s", "ERR_LIST_WORKFLOWS_INVALID_TOKEN", http.StatusBadRequest, grpcCodes.InvalidArgument}
+	ErrListWorkflows                   = APIError{"error listing workflows: %s", "ERR_LIST_WORKFLOWS", http.StatusInternalServerError, grpcCodes.Internal}
 )
@@ -0,0 +1,123 @@
+/*
+Copyright 2023 The Dapr Authors
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package messaging
+
+import (
+	"strconv"
+	"strings"
+	"sync"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/encoding/gzip"
+	grpcMetadata "google.golang.org/grpc/metadata"
+
+	invokev1 "github.com/dapr/dapr/pkg/messaging/v1"
+)
+
+const (
+	// AcceptEncodingKey is the key of the gRPC header with which Dapr sidecars advertise the compressors they accept on service invocation calls.
+	AcceptEncodingKey = "dapr-accept-encoding"
+	// AcceptedEncodings is the value of the AcceptEncodingKey header sent by this sidecar.
+	// Importing the gzip package registers the compressor with gRPC, so the calls received by the sidecar can be compressed with gzip.
+	AcceptedEncodings = gzip.Name
+)
+
+// invokeCompressor selects the service invocation calls that are compressed.
+// Calls to a sidecar are compressed once it has advertised that it accepts gzip in the headers of a previous response, so sidecars running older versions of Dapr never receive compressed calls.
+type invokeCompressor struct {
+	minSize int64
+
+	lock sync.RWMutex
+	// accepts records whether the sidecars at the addresses accept gzip.
+	accepts map[string]bool
+}
+
+// newInvokeCompressor returns an invokeCompressor for the request bodies of at least minSize bytes.
+// It returns nil if minSize is 0, which disables the compression.
+func newInvokeCompressor(minSize int) *invokeCompressor {
+	if minSize <= 0 {
+		return nil
+	}
+	return &invokeCompressor{
+		minSize: int64(minSize),
+		accepts: map[string]bool{},
+	}
+}
+
+// callOptions returns the options that compress the call to the sidecar at the address, if it accepts gzip and the body of the request is large enough.
+// Bodies whose size is not known in advance, which is -1, are not compressed.
+func (c *invokeCompressor) callOptions(address string, size int64) ([]grpc.CallOption, bool) {
+	if c == nil || size < c.minSize {
+		return nil, false
+	}
+
+	c.lock.RLock()
+	accepts := c.accepts[address]
+	c.lock.RUnlock()
+	if !accepts {
+		return nil, false
+	}
+	return []grpc.CallOption{grpc.UseCompressor(gzip.Name)}, true
+}
+
+// learn records whether the sidecar at the address accepts gzip, from the headers of its response.
+func (c *invokeCompressor) learn(address string, header grpcMetadata.MD) {
+	if c == nil {
+		return
+	}
+
+	accepts := false
+	for _, v := range header.Get(AcceptEncodingKey) {
+		for _, enc := range strings.Split(v, ",") {
+			if strings.TrimSpace(enc) == gzip.Name {
+				accepts = true
+			}
+		}
+	}
+
+	c.lock.Lock()
+	if accepts {
+		c.accepts[address] = true
+	} else {
+		delete(c.accepts, address)
+	}
+	c.lock.Unlock()
+}
+
+// forget stops compressing the calls to the sidecar at the address, until it advertises that it accepts gzip again.
+func (c *invokeCompressor) forget(address string) {
+	if c == nil {
+		return
+	}
+
+	c.lock.Lock()
+	delete(c.accepts, address)
+	c.lock.Unlock()
+}
+
+// requestSize returns the size of the body of the request, or -1 if it's not known in advance.
+func requestSize(req *invokev1.InvokeMethodRequest) int64 {
+	if req.HasMessageData() {
+		return int64(len(req.Message().GetData().GetValue()))
+	}
+	for k, v := range req.Metadata() {
+		if !strings.EqualFold(k, invokev1.ContentLengthHeader) || len(v.GetValues()) == 0 {
+			continue
+		}
+		if n, err := strconv.ParseInt(v.GetValues()[0], 10, 64); err == nil && n >= 0 {
+			return n
+		}
+	}
+	return -1
+}
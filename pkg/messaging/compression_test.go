@@ -0,0 +1,102 @@
+/*
+Copyright 2023 The Dapr Authors
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package messaging
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	grpcMetadata "google.golang.org/grpc/metadata"
+	"google.golang.org/protobuf/types/known/anypb"
+
+	invokev1 "github.com/dapr/dapr/pkg/messaging/v1"
+	commonv1pb "github.com/dapr/dapr/pkg/proto/common/v1"
+)
+
+func TestInvokeCompressor(t *testing.T) {
+	t.Run("disabled", func(t *testing.T) {
+		c := newInvokeCompressor(0)
+		assert.Nil(t, c)
+
+		c.learn("10.0.0.1:50002", grpcMetadata.Pairs(AcceptEncodingKey, "gzip"))
+		opts, compressed := c.callOptions("10.0.0.1:50002", 1<<20)
+		assert.Empty(t, opts)
+		assert.False(t, compressed)
+	})
+
+	t.Run("compresses the calls to sidecars that accept gzip", func(t *testing.T) {
+		c := newInvokeCompressor(1024)
+
+		// Not advertised yet
+		_, compressed := c.callOptions("10.0.0.1:50002", 2048)
+		assert.False(t, compressed)
+
+		c.learn("10.0.0.1:50002", grpcMetadata.Pairs(AcceptEncodingKey, "identity, gzip"))
+		c.learn("10.0.0.2:50002", grpcMetadata.MD{})
+
+		opts, compressed := c.callOptions("10.0.0.1:50002", 2048)
+		assert.True(t, compressed)
+		assert.Len(t, opts, 1)
+
+		_, compressed = c.callOptions("10.0.0.1:50002", -1)
+		assert.False(t, compressed)
+
+		_, compressed = c.callOptions("10.0.0.1:50002", 512)
+		assert.False(t, compressed)
+
+		_, compressed = c.callOptions("10.0.0.2:50002", 2048)
+		assert.False(t, compressed)
+	})
+
+	t.Run("stops compressing when the sidecar doesn't accept gzip anymore", func(t *testing.T) {
+		c := newInvokeCompressor(1024)
+
+		c.learn("10.0.0.1:50002", grpcMetadata.Pairs(AcceptEncodingKey, "gzip"))
+		c.learn("10.0.0.1:50002", grpcMetadata.MD{})
+		_, compressed := c.callOptions("10.0.0.1:50002", 2048)
+		assert.False(t, compressed)
+
+		c.learn("10.0.0.1:50002", grpcMetadata.Pairs(AcceptEncodingKey, "gzip"))
+		c.forget("10.0.0.1:50002")
+		_, compressed = c.callOptions("10.0.0.1:50002", 2048)
+		assert.False(t, compressed)
+	})
+}
+
+func TestRequestSize(t *testing.T) {
+	t.Run("message data", func(t *testing.T) {
+		req := invokev1.FromInvokeRequestMessage(&commonv1pb.InvokeRequest{
+			Method: "method",
+			Data:   &anypb.Any{Value: []byte("hello")},
+		})
+		defer req.Close()
+		assert.Equal(t, int64(5), requestSize(req))
+	})
+
+	t.Run("content length", func(t *testing.T) {
+		req := invokev1.NewInvokeMethodRequest("method").
+			WithMetadata(map[string][]string{"Content-Length": {"2048"}}).
+			WithRawDataString("x")
+		defer req.Close()
+		assert.Equal(t, int64(2048), requestSize(req))
+	})
+
+	t.Run("unknown size", func(t *testing.T) {
+		req := invokev1.NewInvokeMethodRequest("method").
+			WithRawData(strings.NewReader("hello"))
+		defer req.Close()
+		assert.Equal(t, int64(-1), requestSize(req))
+	})
+}
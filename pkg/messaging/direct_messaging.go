@@ -20,16 +20,19 @@ import (
 	"io"
 	"os"
 	"strings"
+	"syscall"
 	"time"
 
 	"github.com/cenkalti/backoff/v4"
 	"github.com/valyala/fasthttp"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/codes"
+	grpcMetadata "google.golang.org/grpc/metadata"
 	"google.golang.org/grpc/status"
 
 	nr "github.com/dapr/components-contrib/nameresolution"
 	"github.com/dapr/dapr/pkg/channel"
+	"github.com/dapr/dapr/pkg/config"
 	diag "github.com/dapr/dapr/pkg/diagnostics"
 	diagUtils "github.com/dapr/dapr/pkg/diagnostics/utils"
 	invokev1 "github.com/dapr/dapr/pkg/messaging/v1"
@@ -68,6 +71,8 @@ type directMessaging struct {
 	readBufferSize               int
 	resiliency                   resiliency.Provider
 	compStore                    *compstore.ComponentStore
+	idempotentMethods            []config.IdempotentMethodSpec
+	compressor                   *invokeCompressor
 }
 
 type remoteApp struct {
@@ -90,6 +95,9 @@ type NewDirectMessagingOpts struct {
 	Proxy              Proxy
 	ReadBufferSize     int
 	Resiliency         resiliency.Provider
+	IdempotentMethods  []config.IdempotentMethodSpec
+	// Minimum size of the request bodies compressed on the calls to other sidecars, or 0 to disable the compression.
+	CompressionMinSize int
 }
 
 // NewDirectMessaging returns a new direct messaging api.
@@ -112,6 +120,8 @@ func NewDirectMessaging(opts NewDirectMessagingOpts) invokev1.DirectMessaging {
 		hostAddress:                  hAddr,
 		hostName:                     hName,
 		compStore:                    opts.CompStore,
+		idempotentMethods:            opts.IdempotentMethods,
+		compressor:                   newInvokeCompressor(opts.CompressionMinSize),
 		resourceHTTPEndpointChannels: map[string]channel.HTTPEndpointAppChannel{},
 	}
 
@@ -185,6 +195,9 @@ func (d *directMessaging) invokeWithRetry(
 	fn func(ctx context.Context, appID, namespace, appAddress string, req *invokev1.InvokeMethodRequest) (*invokev1.InvokeMethodResponse, func(destroy bool), error),
 	req *invokev1.InvokeMethodRequest,
 ) (*invokev1.InvokeMethodResponse, error) {
+	// Idempotent methods can be retried safely when the connection is reset, even if the target has received the request already
+	idempotent := config.IsIdempotentMethod(d.idempotentMethods, app.id, req.Message().GetMethod())
+
 	if !d.resiliency.PolicyDefined(app.id, resiliency.EndpointPolicy{}) {
 		// This policy has built-in retries so enable replay in the request
 		req.WithReplay(true)
@@ -204,7 +217,7 @@ func (d *directMessaging) invokeWithRetry(
 			}
 
 			code := status.Code(rErr)
-			if code == codes.Unavailable || code == codes.Unauthenticated {
+			if code == codes.Unavailable || code == codes.Unauthenticated || (idempotent && isConnectionReset(rErr)) {
 				// Destroy the connection and force a re-connection on the next attempt
 				teardown(true)
 				return rResp, fmt.Errorf("failed to invoke target %s after %d retries. Error: %w", app.id, attempt-1, rErr)
@@ -214,13 +227,48 @@ func (d *directMessaging) invokeWithRetry(
 		})
 	}
 
-	resp, teardown, err := fn(ctx, app.id, app.namespace, app.address, req)
-	teardown(false)
-	return resp, err
+	if !idempotent {
+		resp, teardown, err := fn(ctx, app.id, app.namespace, app.address, req)
+		teardown(false)
+		return resp, err
+	}
+
+	// The resiliency policy of the app is applied by the caller, but it may not retry the call: idempotent methods are retried on connection resets regardless
+	req.WithReplay(true)
+	for attempt := 0; ; attempt++ {
+		resp, teardown, err := fn(ctx, app.id, app.namespace, app.address, req)
+		if err == nil || attempt >= numRetries || !isConnectionReset(err) {
+			teardown(false)
+			return resp, err
+		}
+
+		// Destroy the connection and force a re-connection on the next attempt
+		teardown(true)
+		if resp != nil {
+			resp.Close()
+		}
+		log.Debugf("Connection to target %s was reset while invoking idempotent method %s; retrying", app.id, req.Message().GetMethod())
+
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		case <-time.After(backoffInterval):
+		}
+	}
+}
+
+// isConnectionReset returns true if the error was caused by the connection to the target being reset while the call was in flight.
+// gRPC reports the transport being closed under an in-flight call, like when the connection is reset by the target, with the Unavailable status code.
+// The errors of the HTTP endpoints wrap the errors of the syscalls on the connection.
+func isConnectionReset(err error) bool {
+	if errors.Is(err, syscall.ECONNRESET) || errors.Is(err, syscall.EPIPE) {
+		return true
+	}
+	return status.Code(err) == codes.Unavailable
 }
 
 func (d *directMessaging) invokeLocal(ctx context.Context, req *invokev1.InvokeMethodRequest) (*invokev1.InvokeMethodResponse, error) {
-	appChannel := d.channels.AppChannel()
+	appChannel := d.channels.AppChannelFor(config.AppChannelRouteServiceInvocation)
 	if appChannel == nil {
 		return nil, errors.New("cannot invoke local endpoint: app channel not initialized")
 	}
@@ -257,6 +305,7 @@ func (d *directMessaging) invokeHTTPEndpoint(ctx context.Context, appID, appName
 }
 
 func (d *directMessaging) invokeRemote(ctx context.Context, appID, appNamespace, appAddress string, req *invokev1.InvokeMethodRequest) (*invokev1.InvokeMethodResponse, func(destroy bool), error) {
+	begin := time.Now()
 	conn, teardown, err := d.connectionCreatorFn(ctx, appAddress, appID, appNamespace)
 	if err != nil {
 		if teardown == nil {
@@ -278,16 +327,29 @@ func (d *directMessaging) invokeRemote(ctx context.Context, appID, appNamespace,
 		grpc.MaxCallSendMsgSize(d.maxRequestBodySizeMB << 20),
 	}
 
+	size := requestSize(req)
+	compressOpts, compressed := d.compressor.callOptions(appAddress, size)
+	opts = append(opts, compressOpts...)
+
 	// Set up timers
 	start := time.Now()
 	diag.DefaultMonitoring.ServiceInvocationRequestSent(appID)
+	if compressed {
+		diag.DefaultMonitoring.ServiceInvocationRequestCompressed(appID, size)
+	}
 
 	// Do invoke
-	imr, err := d.invokeRemoteStream(ctx, clientV1, req, appID, opts)
+	var header grpcMetadata.MD
+	imr, err := d.invokeRemoteStream(ctx, clientV1, req, appID, appAddress, opts, &header)
+	if compressed && status.Code(err) == codes.Unimplemented {
+		// The target does not accept compressed calls anymore, for example because it was replaced by a sidecar running an older version of Dapr
+		d.compressor.forget(appAddress)
+	}
 
 	// Diagnostics
 	if imr != nil {
 		diag.DefaultMonitoring.ServiceInvocationResponseReceived(appID, imr.Status().GetCode(), start)
+		recordLatencyBreakdown(appID, header, begin, start, time.Now())
 	}
 
 	return imr, teardown, err
@@ -309,13 +371,13 @@ func (d *directMessaging) invokeRemoteUnaryForHTTPEndpoint(ctx context.Context,
 	return channel.InvokeMethod(ctx, req, appID)
 }
 
-func (d *directMessaging) invokeRemoteUnary(ctx context.Context, clientV1 internalv1pb.ServiceInvocationClient, req *invokev1.InvokeMethodRequest, opts []grpc.CallOption) (*invokev1.InvokeMethodResponse, error) {
+func (d *directMessaging) invokeRemoteUnary(ctx context.Context, clientV1 internalv1pb.ServiceInvocationClient, req *invokev1.InvokeMethodRequest, opts []grpc.CallOption, header *grpcMetadata.MD) (*invokev1.InvokeMethodResponse, error) {
 	pd, err := req.ProtoWithData()
 	if err != nil {
 		return nil, fmt.Errorf("failed to read data from request object: %w", err)
 	}
 
-	resp, err := clientV1.CallLocal(ctx, pd, opts...)
+	resp, err := clientV1.CallLocal(ctx, pd, append(opts, grpc.Header(header))...)
 	if err != nil {
 		return nil, err
 	}
@@ -323,7 +385,8 @@ func (d *directMessaging) invokeRemoteUnary(ctx context.Context, clientV1 intern
 	return invokev1.InternalInvokeResponse(resp)
 }
 
-func (d *directMessaging) invokeRemoteStream(ctx context.Context, clientV1 internalv1pb.ServiceInvocationClient, req *invokev1.InvokeMethodRequest, appID string, opts []grpc.CallOption) (*invokev1.InvokeMethodResponse, error) {
+// invokeRemoteStream invokes the app with CallLocalStream, and stores the headers of the response in header.
+func (d *directMessaging) invokeRemoteStream(ctx context.Context, clientV1 internalv1pb.ServiceInvocationClient, req *invokev1.InvokeMethodRequest, appID string, appAddress string, opts []grpc.CallOption, header *grpcMetadata.MD) (*invokev1.InvokeMethodResponse, error) {
 	stream, err := clientV1.CallLocalStream(ctx, opts...)
 	if err != nil {
 		return nil, err
@@ -428,7 +491,7 @@ func (d *directMessaging) invokeRemoteStream(ctx context.Context, clientV1 inter
 			}
 			if req.CanReplay() {
 				log.Warnf("App %s does not support streaming-based service invocation (most likely because it's using an older version of Dapr); falling back to unary calls", appID)
-				return d.invokeRemoteUnary(ctx, clientV1, req, opts)
+				return d.invokeRemoteUnary(ctx, clientV1, req, opts, header)
 			} else {
 				log.Errorf("App %s does not support streaming-based service invocation (most likely because it's using an older version of Dapr) and the request is not replayable. Please upgrade the Dapr sidecar used by the target app, or use Resiliency policies to add retries", appID)
 				return nil, fmt.Errorf(streamingUnsupportedErr, appID)
@@ -439,6 +502,12 @@ func (d *directMessaging) invokeRemoteStream(ctx context.Context, clientV1 inter
 	if chunk.GetResponse().GetStatus() == nil {
 		return nil, errors.New("response does not contain the required fields in the leading chunk")
 	}
+
+	// The headers are available once the leading chunk is received
+	if md, headerErr := stream.Header(); headerErr == nil {
+		d.compressor.learn(appAddress, md)
+		*header = md
+	}
 	pr, pw := io.Pipe()
 	res, err := invokev1.InternalInvokeResponse(chunk.GetResponse())
 	if err != nil {
@@ -570,6 +639,7 @@ func (d *directMessaging) getRemoteApp(appID string) (remoteApp, error) {
 		if err != nil {
 			return remoteApp{}, err
 		}
+		address = utils.NormalizeHostPort(address)
 	}
 
 	return remoteApp{
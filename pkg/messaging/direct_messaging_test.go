@@ -14,13 +14,20 @@ limitations under the License.
 package messaging
 
 import (
+	"bufio"
 	"context"
 	"crypto/rand"
 	"encoding/hex"
+	"errors"
 	"fmt"
 	"io"
 	"net"
+	"net/http"
+	"os"
 	"path/filepath"
+	"sync"
+	"sync/atomic"
+	"syscall"
 	"testing"
 	"time"
 
@@ -28,13 +35,18 @@ import (
 	"github.com/stretchr/testify/require"
 	"github.com/valyala/fasthttp"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/status"
 	"google.golang.org/protobuf/types/known/anypb"
 
+	resiliencyV1alpha "github.com/dapr/dapr/pkg/apis/resiliency/v1alpha1"
 	"github.com/dapr/dapr/pkg/channel"
+	"github.com/dapr/dapr/pkg/config"
 	invokev1 "github.com/dapr/dapr/pkg/messaging/v1"
 	commonv1pb "github.com/dapr/dapr/pkg/proto/common/v1"
 	internalv1pb "github.com/dapr/dapr/pkg/proto/internals/v1"
+	"github.com/dapr/dapr/pkg/resiliency"
 	"github.com/dapr/dapr/pkg/runtime/channels"
 	"github.com/dapr/kit/logger"
 )
@@ -489,6 +501,229 @@ func TestInvokeRemoteUnaryForHTTPEndpoint(t *testing.T) {
 	})
 }
 
+func TestInvokeWithRetryIdempotentMethods(t *testing.T) {
+	// Get a real error from a connection reset by the target
+	resetErr := httpConnectionResetError(t)
+	require.ErrorIs(t, resetErr, syscall.ECONNRESET)
+
+	withPolicy := resiliency.FromConfigurations(logger.NewLogger("test"), &resiliencyV1alpha.Resiliency{
+		Spec: resiliencyV1alpha.ResiliencySpec{
+			Policies: resiliencyV1alpha.Policies{
+				Timeouts: map[string]string{"appTimeout": "10s"},
+			},
+			Targets: resiliencyV1alpha.Targets{
+				Apps: map[string]resiliencyV1alpha.EndpointPolicyNames{
+					"target": {Timeout: "appTimeout"},
+				},
+			},
+		},
+	})
+
+	invoke := func(t *testing.T, res *resiliency.Resiliency, method string) (int, *destroyTracker, error) {
+		d := &directMessaging{
+			resiliency: res,
+			idempotentMethods: []config.IdempotentMethodSpec{
+				{AppID: "target", Methods: []string{"orders/*", "status"}},
+			},
+		}
+		req := invokev1.NewInvokeMethodRequest(method)
+		defer req.Close()
+
+		calls := 0
+		tracker := &destroyTracker{}
+		resp, err := d.invokeWithRetry(context.Background(), 1, 0, remoteApp{id: "target"},
+			func(ctx context.Context, appID, namespace, appAddress string, req *invokev1.InvokeMethodRequest) (*invokev1.InvokeMethodResponse, func(destroy bool), error) {
+				calls++
+				if calls == 1 {
+					return nil, tracker.teardown, resetErr
+				}
+				return invokev1.NewInvokeMethodResponse(200, "OK", nil), tracker.teardown, nil
+			}, req)
+		if resp != nil {
+			resp.Close()
+		}
+		return calls, tracker, err
+	}
+
+	for name, res := range map[string]*resiliency.Resiliency{
+		"built-in retries":  resiliency.New(nil),
+		"resiliency policy": withPolicy,
+	} {
+		t.Run(name, func(t *testing.T) {
+			t.Run("idempotent method is retried on connection reset", func(t *testing.T) {
+				calls, tracker, err := invoke(t, res, "orders/123")
+				require.NoError(t, err)
+				assert.Equal(t, 2, calls)
+				assert.Equal(t, 1, tracker.destroyed)
+			})
+
+			t.Run("other method is not retried on connection reset", func(t *testing.T) {
+				calls, tracker, err := invoke(t, res, "checkout")
+				require.ErrorIs(t, err, syscall.ECONNRESET)
+				assert.Equal(t, 1, calls)
+				assert.Equal(t, 0, tracker.destroyed)
+			})
+		})
+	}
+
+	t.Run("idempotent method is retried when the target resets the connection", func(t *testing.T) {
+		log.SetOutputLevel(logger.FatalLevel)
+		defer log.SetOutputLevel(logger.InfoLevel)
+
+		lis, err := net.Listen("tcp", "127.0.0.1:0")
+		require.NoError(t, err)
+		rLis := &resettingListener{Listener: lis}
+		srv := &resettingGRPCServer{
+			mockGRPCServerStream: &mockGRPCServerStream{chunks: []string{"🐶"}},
+			lis:                  rLis,
+		}
+		server := grpc.NewServer()
+		server.RegisterService(&grpc.ServiceDesc{
+			ServiceName: "dapr.proto.internals.v1.ServiceInvocation",
+			HandlerType: (*mockGRPCServerStreamI)(nil),
+			Methods:     srv.methods(),
+			Streams:     srv.streams(),
+		}, srv)
+		go server.Serve(rLis)
+		defer server.Stop()
+
+		clientConn, err := grpc.Dial(lis.Addr().String(), grpc.WithTransportCredentials(insecure.NewCredentials()))
+		require.NoError(t, err)
+		defer clientConn.Close()
+
+		d := NewDirectMessaging(NewDirectMessagingOpts{
+			MaxRequestBodySize: 10 << 20,
+			Resiliency:         withPolicy,
+			IdempotentMethods: []config.IdempotentMethodSpec{
+				{AppID: "target", Methods: []string{"orders/*"}},
+			},
+			ClientConnFn: func(ctx context.Context, address string, id string, namespace string, customOpts ...grpc.DialOption) (*grpc.ClientConn, func(destroy bool), error) {
+				return clientConn, func(_ bool) {}, nil
+			},
+		}).(*directMessaging)
+
+		invoke := func(method string) (*invokev1.InvokeMethodResponse, error) {
+			req := invokev1.
+				NewInvokeMethodRequest(method).
+				WithMetadata(map[string][]string{invokev1.DestinationIDHeader: {"target"}})
+			defer req.Close()
+			return d.invokeWithRetry(context.Background(), 3, 10*time.Millisecond, remoteApp{id: "target"}, d.invokeRemote, req)
+		}
+
+		// The first call of a method that isn't idempotent fails
+		srv.resetNext.Store(true)
+		_, err = invoke("checkout")
+		require.Error(t, err)
+		assert.Equal(t, codes.Unavailable, status.Code(err))
+		assert.True(t, isConnectionReset(err))
+
+		// The first call of an idempotent method is retried
+		srv.resetNext.Store(true)
+		resp, err := invoke("orders/123")
+		require.NoError(t, err)
+		defer resp.Close()
+		pd, err := resp.ProtoWithData()
+		require.NoError(t, err)
+		assert.Equal(t, "🐶", string(pd.GetMessage().GetData().GetValue()))
+		assert.False(t, srv.resetNext.Load())
+	})
+}
+
+type destroyTracker struct {
+	destroyed int
+}
+
+func (d *destroyTracker) teardown(destroy bool) {
+	if destroy {
+		d.destroyed++
+	}
+}
+
+// httpConnectionResetError returns the error of an HTTP request whose connection is reset by the server.
+func httpConnectionResetError(t *testing.T) error {
+	t.Helper()
+
+	lis, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	defer lis.Close()
+
+	go func() {
+		conn, err := lis.Accept()
+		if err != nil {
+			return
+		}
+		// Read the request headers, then reset the connection
+		r := bufio.NewReader(conn)
+		for {
+			line, err := r.ReadString('\n')
+			if err != nil || line == "\r\n" {
+				break
+			}
+		}
+		conn.(*net.TCPConn).SetLinger(0)
+		conn.Close()
+	}()
+
+	resp, err := http.Get("http://" + lis.Addr().String())
+	if resp != nil {
+		resp.Body.Close()
+	}
+	require.Error(t, err)
+	return err
+}
+
+// resettingListener is a net.Listener that can reset all the connections it accepted.
+type resettingListener struct {
+	net.Listener
+	lock  sync.Mutex
+	conns []*net.TCPConn
+}
+
+func (l *resettingListener) Accept() (net.Conn, error) {
+	conn, err := l.Listener.Accept()
+	if err != nil {
+		return nil, err
+	}
+	l.lock.Lock()
+	l.conns = append(l.conns, conn.(*net.TCPConn))
+	l.lock.Unlock()
+	return conn, nil
+}
+
+func (l *resettingListener) resetAll() {
+	l.lock.Lock()
+	defer l.lock.Unlock()
+	for _, conn := range l.conns {
+		conn.SetLinger(0)
+		conn.Close()
+	}
+	l.conns = nil
+}
+
+// resettingGRPCServer resets the connections to the server while the call is in flight, if resetNext is set.
+type resettingGRPCServer struct {
+	*mockGRPCServerStream
+	lis       *resettingListener
+	resetNext atomic.Bool
+}
+
+func (m *resettingGRPCServer) CallLocalStream(stream internalv1pb.ServiceInvocation_CallLocalStreamServer) error { //nolint:nosnakecase
+	if m.resetNext.CompareAndSwap(true, false) {
+		m.lis.resetAll()
+		<-stream.Context().Done()
+		return stream.Context().Err()
+	}
+	return m.mockGRPCServerStream.CallLocalStream(stream)
+}
+
+func TestIsConnectionReset(t *testing.T) {
+	assert.True(t, isConnectionReset(httpConnectionResetError(t)))
+	assert.True(t, isConnectionReset(fmt.Errorf("error invoking endpoint: %w", &net.OpError{Op: "write", Net: "tcp", Err: os.NewSyscallError("write", syscall.EPIPE)})))
+	assert.True(t, isConnectionReset(status.Error(codes.Unavailable, "error reading from server")))
+	assert.False(t, isConnectionReset(status.Error(codes.Internal, "connection reset")))
+	assert.False(t, isConnectionReset(errors.New("connection reset by peer")))
+}
+
 type mockChannel struct{}
 
 func (m *mockChannel) InvokeMethod(ctx context.Context, req *invokev1.InvokeMethodRequest, appID string) (*invokev1.InvokeMethodResponse, error) {
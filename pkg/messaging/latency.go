@@ -0,0 +1,82 @@
+/*
+Copyright 2023 The Dapr Authors
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package messaging
+
+import (
+	"strconv"
+	"time"
+
+	grpcMetadata "google.golang.org/grpc/metadata"
+
+	diag "github.com/dapr/dapr/pkg/diagnostics"
+)
+
+const (
+	// CalleeSidecarLatencyKey is the key of the gRPC header with the time, in microseconds, the sidecar of the callee spent processing a service invocation request before sending it to the app.
+	CalleeSidecarLatencyKey = "dapr-callee-sidecar-latency-us"
+	// AppLatencyKey is the key of the gRPC header with the time, in microseconds, the callee app spent handling a service invocation request.
+	AppLatencyKey = "dapr-app-latency-us"
+)
+
+// LatencyHeader returns the headers with which the sidecar of the callee reports the time it spent processing a request, and the time the app spent handling it.
+// The caller uses them to break down the latency of the call.
+func LatencyHeader(sidecar time.Duration, app time.Duration) grpcMetadata.MD {
+	return grpcMetadata.Pairs(
+		CalleeSidecarLatencyKey, strconv.FormatInt(sidecar.Microseconds(), 10),
+		AppLatencyKey, strconv.FormatInt(app.Microseconds(), 10),
+	)
+}
+
+// parseLatencyHeader returns the times reported by the sidecar of the callee in the headers of its response.
+// It returns false if the headers are missing or invalid, for example because the callee runs an older version of Dapr.
+func parseLatencyHeader(header grpcMetadata.MD) (sidecar time.Duration, app time.Duration, ok bool) {
+	parse := func(key string) (time.Duration, bool) {
+		v := header.Get(key)
+		if len(v) == 0 {
+			return 0, false
+		}
+		us, err := strconv.ParseInt(v[0], 10, 64)
+		if err != nil || us < 0 {
+			return 0, false
+		}
+		return time.Duration(us) * time.Microsecond, true
+	}
+
+	sidecar, ok = parse(CalleeSidecarLatencyKey)
+	if !ok {
+		return 0, 0, false
+	}
+	app, ok = parse(AppLatencyKey)
+	if !ok {
+		return 0, 0, false
+	}
+	return sidecar, app, true
+}
+
+// recordLatencyBreakdown records the breakdown of the latency of a call to the app, from the times measured by the caller and those reported by the callee.
+// begin is when the sidecar started processing the call, sent is when the request was sent to the callee, and received is when the response was received.
+// Nothing is recorded if the callee didn't report its times.
+func recordLatencyBreakdown(appID string, header grpcMetadata.MD, begin, sent, received time.Time) {
+	calleeSidecar, app, ok := parseLatencyHeader(header)
+	if !ok {
+		return
+	}
+
+	// The clocks of the two sidecars are never compared: the network time is what's left of the round trip once the time spent by the callee is removed
+	network := received.Sub(sent) - calleeSidecar - app
+	if network < 0 {
+		network = 0
+	}
+	diag.DefaultMonitoring.ServiceInvocationLatencyBreakdown(appID, sent.Sub(begin), network, calleeSidecar, app)
+}
@@ -0,0 +1,50 @@
+/*
+Copyright 2023 The Dapr Authors
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package messaging
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	grpcMetadata "google.golang.org/grpc/metadata"
+)
+
+func TestParseLatencyHeader(t *testing.T) {
+	t.Run("header set by the callee", func(t *testing.T) {
+		sidecar, app, ok := parseLatencyHeader(LatencyHeader(1500*time.Microsecond, 20*time.Millisecond))
+		assert.True(t, ok)
+		assert.Equal(t, 1500*time.Microsecond, sidecar)
+		assert.Equal(t, 20*time.Millisecond, app)
+	})
+
+	t.Run("callee running an older version", func(t *testing.T) {
+		_, _, ok := parseLatencyHeader(grpcMetadata.Pairs(AcceptEncodingKey, "gzip"))
+		assert.False(t, ok)
+		_, _, ok = parseLatencyHeader(nil)
+		assert.False(t, ok)
+	})
+
+	t.Run("partial header", func(t *testing.T) {
+		_, _, ok := parseLatencyHeader(grpcMetadata.Pairs(CalleeSidecarLatencyKey, "100"))
+		assert.False(t, ok)
+	})
+
+	t.Run("invalid values", func(t *testing.T) {
+		_, _, ok := parseLatencyHeader(grpcMetadata.Pairs(CalleeSidecarLatencyKey, "abc", AppLatencyKey, "100"))
+		assert.False(t, ok)
+		_, _, ok = parseLatencyHeader(grpcMetadata.Pairs(CalleeSidecarLatencyKey, "100", AppLatencyKey, "-1"))
+		assert.False(t, ok)
+	})
+}
@@ -0,0 +1,92 @@
+/*
+Copyright 2023 The Dapr Authors
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	internalv1pb "github.com/dapr/dapr/pkg/proto/internals/v1"
+)
+
+// TimeoutHeader is the header (or metadata key) that sets the maximum duration of a service invocation, such as "5s" or "500ms".
+// The deadline applies to the whole chain, from the caller's sidecar to the target app.
+const TimeoutHeader = DaprHeaderPrefix + "timeout"
+
+// Timeout returns the duration set in the dapr-timeout header of the request, or 0 if the header is not set.
+func (imr *InvokeMethodRequest) Timeout() (time.Duration, error) {
+	for k, v := range imr.r.GetMetadata() {
+		if !strings.EqualFold(k, TimeoutHeader) || len(v.GetValues()) == 0 {
+			continue
+		}
+
+		val := v.GetValues()[0]
+		timeout, err := time.ParseDuration(val)
+		if err != nil {
+			return 0, fmt.Errorf("invalid value for the %s header '%s': %w", TimeoutHeader, val, err)
+		}
+		if timeout <= 0 {
+			return 0, fmt.Errorf("invalid value for the %s header '%s': must be greater than zero", TimeoutHeader, val)
+		}
+		return timeout, nil
+	}
+	return 0, nil
+}
+
+// setTimeout sets the dapr-timeout header of the request, replacing any existing value.
+func (imr *InvokeMethodRequest) setTimeout(timeout time.Duration) {
+	if imr.r.GetMetadata() == nil {
+		imr.r.Metadata = make(DaprInternalMetadata, 1)
+	}
+	for k := range imr.r.GetMetadata() {
+		if strings.EqualFold(k, TimeoutHeader) {
+			delete(imr.r.Metadata, k)
+		}
+	}
+	imr.r.Metadata[TimeoutHeader] = &internalv1pb.ListStringValue{
+		Values: []string{strconv.FormatInt(timeout.Milliseconds(), 10) + "ms"},
+	}
+}
+
+// WithInvokeTimeout returns a context with the deadline requested with the dapr-timeout header of the request, capped at maxTimeout if it's greater than 0.
+// The header is updated with the time remaining before the deadline, so it can be propagated downstream.
+// If the request doesn't have a dapr-timeout header, the context is returned unchanged.
+func WithInvokeTimeout(ctx context.Context, req *InvokeMethodRequest, maxTimeout time.Duration) (context.Context, context.CancelFunc, error) {
+	timeout, err := req.Timeout()
+	if err != nil {
+		return ctx, func() {}, err
+	}
+	if timeout == 0 {
+		return ctx, func() {}, nil
+	}
+
+	if maxTimeout > 0 && timeout > maxTimeout {
+		timeout = maxTimeout
+	}
+
+	// The parent context may already have an earlier deadline, for example when it was propagated by the caller's sidecar
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	deadline, _ := ctx.Deadline()
+	remaining := time.Until(deadline)
+	if remaining <= 0 {
+		cancel()
+		return ctx, func() {}, fmt.Errorf("the deadline set with the %s header has already passed", TimeoutHeader)
+	}
+	req.setTimeout(remaining)
+
+	return ctx, cancel, nil
+}
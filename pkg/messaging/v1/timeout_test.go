@@ -0,0 +1,154 @@
+/*
+Copyright 2023 The Dapr Authors
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func remainingTimeout(t *testing.T, req *InvokeMethodRequest) time.Duration {
+	t.Helper()
+	md := req.Metadata()
+	require.Contains(t, md, TimeoutHeader)
+	require.Len(t, md, 1)
+	d, err := time.ParseDuration(md[TimeoutHeader].GetValues()[0])
+	require.NoError(t, err)
+	return d
+}
+
+func TestRequestTimeout(t *testing.T) {
+	t.Run("no header", func(t *testing.T) {
+		req := NewInvokeMethodRequest("test_method")
+		defer req.Close()
+
+		timeout, err := req.Timeout()
+		require.NoError(t, err)
+		assert.Equal(t, time.Duration(0), timeout)
+	})
+
+	t.Run("valid header", func(t *testing.T) {
+		req := NewInvokeMethodRequest("test_method").
+			WithMetadata(map[string][]string{"Dapr-Timeout": {"1500ms"}})
+		defer req.Close()
+
+		timeout, err := req.Timeout()
+		require.NoError(t, err)
+		assert.Equal(t, 1500*time.Millisecond, timeout)
+	})
+
+	t.Run("invalid values", func(t *testing.T) {
+		for _, val := range []string{"abc", "10", "0s", "-1s"} {
+			req := NewInvokeMethodRequest("test_method").
+				WithMetadata(map[string][]string{TimeoutHeader: {val}})
+
+			_, err := req.Timeout()
+			require.Errorf(t, err, "value %q should not be valid", val)
+			req.Close()
+		}
+	})
+}
+
+func TestWithInvokeTimeout(t *testing.T) {
+	t.Run("no header", func(t *testing.T) {
+		req := NewInvokeMethodRequest("test_method")
+		defer req.Close()
+
+		ctx, cancel, err := WithInvokeTimeout(context.Background(), req, time.Minute)
+		require.NoError(t, err)
+		defer cancel()
+
+		_, ok := ctx.Deadline()
+		assert.False(t, ok)
+		assert.Empty(t, req.Metadata())
+	})
+
+	t.Run("deadline is set and the header is rewritten", func(t *testing.T) {
+		req := NewInvokeMethodRequest("test_method").
+			WithMetadata(map[string][]string{"DAPR-TIMEOUT": {"10s"}})
+		defer req.Close()
+
+		ctx, cancel, err := WithInvokeTimeout(context.Background(), req, 0)
+		require.NoError(t, err)
+		defer cancel()
+
+		deadline, ok := ctx.Deadline()
+		require.True(t, ok)
+		assert.InDelta(t, 10*time.Second, time.Until(deadline), float64(time.Second))
+
+		remaining := remainingTimeout(t, req)
+		assert.LessOrEqual(t, remaining, 10*time.Second)
+		assert.Greater(t, remaining, 9*time.Second)
+	})
+
+	t.Run("capped at the maximum", func(t *testing.T) {
+		req := NewInvokeMethodRequest("test_method").
+			WithMetadata(map[string][]string{TimeoutHeader: {"1h"}})
+		defer req.Close()
+
+		ctx, cancel, err := WithInvokeTimeout(context.Background(), req, 5*time.Second)
+		require.NoError(t, err)
+		defer cancel()
+
+		deadline, ok := ctx.Deadline()
+		require.True(t, ok)
+		assert.LessOrEqual(t, time.Until(deadline), 5*time.Second)
+		assert.LessOrEqual(t, remainingTimeout(t, req), 5*time.Second)
+	})
+
+	t.Run("parent deadline is earlier", func(t *testing.T) {
+		req := NewInvokeMethodRequest("test_method").
+			WithMetadata(map[string][]string{TimeoutHeader: {"1h"}})
+		defer req.Close()
+
+		parent, parentCancel := context.WithTimeout(context.Background(), 2*time.Second)
+		defer parentCancel()
+
+		ctx, cancel, err := WithInvokeTimeout(parent, req, 0)
+		require.NoError(t, err)
+		defer cancel()
+
+		deadline, ok := ctx.Deadline()
+		require.True(t, ok)
+		assert.LessOrEqual(t, time.Until(deadline), 2*time.Second)
+		assert.LessOrEqual(t, remainingTimeout(t, req), 2*time.Second)
+	})
+
+	t.Run("parent deadline has passed", func(t *testing.T) {
+		req := NewInvokeMethodRequest("test_method").
+			WithMetadata(map[string][]string{TimeoutHeader: {"1s"}})
+		defer req.Close()
+
+		parent, parentCancel := context.WithDeadline(context.Background(), time.Now().Add(-time.Second))
+		defer parentCancel()
+
+		_, cancel, err := WithInvokeTimeout(parent, req, 0)
+		require.Error(t, err)
+		cancel()
+	})
+
+	t.Run("invalid header", func(t *testing.T) {
+		req := NewInvokeMethodRequest("test_method").
+			WithMetadata(map[string][]string{TimeoutHeader: {"soon"}})
+		defer req.Close()
+
+		_, cancel, err := WithInvokeTimeout(context.Background(), req, 0)
+		require.Error(t, err)
+		cancel()
+	})
+}
@@ -0,0 +1,180 @@
+/*
+Copyright 2023 The Dapr Authors
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package metrics
+
+import (
+	"sort"
+	"strings"
+
+	prom "github.com/prometheus/client_golang/prometheus"
+	dto "github.com/prometheus/client_model/go"
+	"go.opencensus.io/metric/metricdata"
+	"go.opencensus.io/metric/metricproducer"
+	"go.opencensus.io/trace"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+const (
+	exemplarTraceIDLabel = "trace_id"
+	exemplarSpanIDLabel  = "span_id"
+)
+
+// exemplarGatherer adds the exemplars kept by the OpenCensus distributions to the buckets of the histograms
+// gathered from the registry, as the OpenCensus Prometheus exporter drops them.
+// Exemplars are only rendered when the scraper negotiates the OpenMetrics format.
+type exemplarGatherer struct {
+	namespace string
+	gatherer  prom.Gatherer
+}
+
+func newExemplarGatherer(namespace string, gatherer prom.Gatherer) prom.Gatherer {
+	return &exemplarGatherer{
+		namespace: namespace,
+		gatherer:  gatherer,
+	}
+}
+
+// Gather implements prometheus.Gatherer.
+func (g *exemplarGatherer) Gather() ([]*dto.MetricFamily, error) {
+	families, err := g.gatherer.Gather()
+	if err != nil {
+		return families, err
+	}
+
+	exemplars := g.readExemplars()
+	if len(exemplars) == 0 {
+		return families, nil
+	}
+
+	for _, f := range families {
+		if f.GetType() != dto.MetricType_HISTOGRAM {
+			continue
+		}
+		series, ok := exemplars[f.GetName()]
+		if !ok {
+			continue
+		}
+		for _, m := range f.GetMetric() {
+			buckets := series[dtoLabelsKey(m.GetLabel())]
+			for i, b := range m.GetHistogram().GetBucket() {
+				// The buckets of the histogram have the same bounds as the distribution, without the overflow bucket
+				if i < len(buckets) && buckets[i] != nil {
+					b.Exemplar = buckets[i]
+				}
+			}
+		}
+	}
+	return families, nil
+}
+
+// readExemplars returns the exemplars of the buckets of the OpenCensus distributions, keyed by metric name and label values.
+func (g *exemplarGatherer) readExemplars() map[string]map[string][]*dto.Exemplar {
+	res := map[string]map[string][]*dto.Exemplar{}
+	for _, producer := range metricproducer.GlobalManager().GetAll() {
+		for _, m := range producer.Read() {
+			if m.Descriptor.Type != metricdata.TypeCumulativeDistribution {
+				continue
+			}
+			name := g.metricName(m.Descriptor.Name)
+			for _, ts := range m.TimeSeries {
+				var buckets []*dto.Exemplar
+				for _, p := range ts.Points {
+					d, ok := p.Value.(*metricdata.Distribution)
+					if !ok {
+						continue
+					}
+					for i, b := range d.Buckets {
+						e := toDtoExemplar(b.Exemplar)
+						if e == nil {
+							continue
+						}
+						if buckets == nil {
+							buckets = make([]*dto.Exemplar, len(d.Buckets))
+						}
+						buckets[i] = e
+					}
+				}
+				if buckets == nil {
+					continue
+				}
+				if res[name] == nil {
+					res[name] = map[string][]*dto.Exemplar{}
+				}
+				res[name][ocLabelsKey(m.Descriptor.LabelKeys, ts.LabelValues)] = buckets
+			}
+		}
+	}
+	return res
+}
+
+// metricName returns the name of the metric in the Prometheus output, as set by the OpenCensus Prometheus exporter.
+func (g *exemplarGatherer) metricName(name string) string {
+	if g.namespace == "" {
+		return sanitize(name)
+	}
+	return g.namespace + "_" + sanitize(name)
+}
+
+// toDtoExemplar returns the Prometheus exemplar of an OpenCensus exemplar with a span context, or nil.
+func toDtoExemplar(e *metricdata.Exemplar) *dto.Exemplar {
+	if e == nil {
+		return nil
+	}
+	sc, ok := e.Attachments[metricdata.AttachmentKeySpanContext].(trace.SpanContext)
+	if !ok {
+		return nil
+	}
+	return &dto.Exemplar{
+		Label: []*dto.LabelPair{
+			{Name: proto.String(exemplarTraceIDLabel), Value: proto.String(sc.TraceID.String())},
+			{Name: proto.String(exemplarSpanIDLabel), Value: proto.String(sc.SpanID.String())},
+		},
+		Value:     proto.Float64(e.Value),
+		Timestamp: timestamppb.New(e.Timestamp),
+	}
+}
+
+// ocLabelsKey and dtoLabelsKey return the same key for the same set of labels, ignoring the empty ones.
+func ocLabelsKey(keys []metricdata.LabelKey, values []metricdata.LabelValue) string {
+	pairs := make([]string, 0, len(keys))
+	for i, k := range keys {
+		if i < len(values) && values[i].Present && values[i].Value != "" {
+			pairs = append(pairs, sanitize(k.Key)+"="+values[i].Value)
+		}
+	}
+	sort.Strings(pairs)
+	return strings.Join(pairs, "\x00")
+}
+
+func dtoLabelsKey(labels []*dto.LabelPair) string {
+	pairs := make([]string, 0, len(labels))
+	for _, l := range labels {
+		if l.GetValue() != "" {
+			pairs = append(pairs, l.GetName()+"="+l.GetValue())
+		}
+	}
+	sort.Strings(pairs)
+	return strings.Join(pairs, "\x00")
+}
+
+// sanitize replaces the characters that are not allowed in Prometheus names with underscores.
+func sanitize(s string) string {
+	return strings.Map(func(r rune) rune {
+		if (r >= 'a' && r <= 'z') || (r >= 'A' && r <= 'Z') || (r >= '0' && r <= '9') || r == '_' || r == ':' {
+			return r
+		}
+		return '_'
+	}, s)
+}
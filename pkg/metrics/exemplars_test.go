@@ -0,0 +1,95 @@
+/*
+Copyright 2023 The Dapr Authors
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package metrics
+
+import (
+	"context"
+	"testing"
+
+	ocprom "contrib.go.opencensus.io/exporter/prometheus"
+	prom "github.com/prometheus/client_golang/prometheus"
+	dto "github.com/prometheus/client_model/go"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opencensus.io/metric/metricdata"
+	"go.opencensus.io/stats"
+	"go.opencensus.io/stats/view"
+	"go.opencensus.io/tag"
+	"go.opencensus.io/trace"
+)
+
+func TestExemplarGatherer(t *testing.T) {
+	measure := stats.Float64("test/exemplar/latency", "Latency used in unit test", stats.UnitMilliseconds)
+	methodKey := tag.MustNewKey("method")
+	v := &view.View{
+		Name:        measure.Name(),
+		Measure:     measure,
+		TagKeys:     []tag.Key{methodKey},
+		Aggregation: view.Distribution(1, 10, 100),
+	}
+	require.NoError(t, view.Register(v))
+	t.Cleanup(func() {
+		view.Unregister(v)
+	})
+
+	registry := prom.NewRegistry()
+	_, err := ocprom.NewExporter(ocprom.Options{
+		Namespace: "test",
+		Registry:  registry,
+	})
+	require.NoError(t, err)
+
+	sc := trace.SpanContext{
+		TraceID:      trace.TraceID{1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12, 13, 14, 15, 16},
+		SpanID:       trace.SpanID{1, 2, 3, 4, 5, 6, 7, 8},
+		TraceOptions: 1,
+	}
+	require.NoError(t, stats.RecordWithOptions(context.Background(),
+		stats.WithTags(tag.Upsert(methodKey, "GET")),
+		stats.WithMeasurements(measure.M(5)),
+		stats.WithAttachments(metricdata.Attachments{metricdata.AttachmentKeySpanContext: sc}),
+	))
+	// Recorded without a trace, so the bucket has no exemplar
+	require.NoError(t, stats.RecordWithOptions(context.Background(),
+		stats.WithTags(tag.Upsert(methodKey, "GET")),
+		stats.WithMeasurements(measure.M(50)),
+	))
+
+	families, err := newExemplarGatherer("test", registry).Gather()
+	require.NoError(t, err)
+
+	var histogram *dto.Histogram
+	for _, f := range families {
+		if f.GetName() == "test_test_exemplar_latency" {
+			require.Len(t, f.GetMetric(), 1)
+			histogram = f.GetMetric()[0].GetHistogram()
+		}
+	}
+	require.NotNil(t, histogram)
+	require.Len(t, histogram.GetBucket(), 3)
+
+	assert.Nil(t, histogram.GetBucket()[0].GetExemplar())
+	e := histogram.GetBucket()[1].GetExemplar()
+	require.NotNil(t, e)
+	assert.InEpsilon(t, 5, e.GetValue(), 0)
+	labels := map[string]string{}
+	for _, l := range e.GetLabel() {
+		labels[l.GetName()] = l.GetValue()
+	}
+	assert.Equal(t, map[string]string{
+		exemplarTraceIDLabel: sc.TraceID.String(),
+		exemplarSpanIDLabel:  sc.SpanID.String(),
+	}, labels)
+	assert.Nil(t, histogram.GetBucket()[2].GetExemplar())
+}
@@ -9,7 +9,9 @@ import (
 
 	ocprom "contrib.go.opencensus.io/exporter/prometheus"
 	prom "github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
 
+	"github.com/dapr/kit/concurrency"
 	"github.com/dapr/kit/logger"
 )
 
@@ -60,7 +62,10 @@ func (m *exporter) Options() *Options {
 type promMetricsExporter struct {
 	*exporter
 	ocExporter *ocprom.Exporter
-	server     *http.Server
+	// registry is the registry of the OpenCensus exporter.
+	// A new one is created by each run, so the exporter can be run again in the same process without registering its collector twice.
+	registry *prom.Registry
+	server   *http.Server
 }
 
 // Run initializes and runs the opencensus exporter.
@@ -72,15 +77,21 @@ func (m *promMetricsExporter) Run(ctx context.Context) error {
 	}
 
 	var err error
+	m.registry = prom.NewRegistry()
 	if m.ocExporter, err = ocprom.NewExporter(ocprom.Options{
 		Namespace: m.namespace,
-		Registry:  prom.DefaultRegisterer.(*prom.Registry),
+		Registry:  m.registry,
 	}); err != nil {
 		return fmt.Errorf("failed to create Prometheus exporter: %w", err)
 	}
 
-	// start metrics server
-	return m.startMetricServer(ctx)
+	if m.options.PushURL == "" {
+		// start metrics server
+		return m.startMetricServer(ctx)
+	}
+
+	// start metrics server, and push the metrics to the Pushgateway
+	return concurrency.NewRunnerManager(m.startMetricServer, m.runPusher).Run(ctx)
 }
 
 // startMetricServer starts metrics server.
@@ -98,7 +109,10 @@ func (m *promMetricsExporter) startMetricServer(ctx context.Context) error {
 
 	m.exporter.logger.Infof("metrics server started on %s%s", addr, defaultMetricsPath)
 	mux := http.NewServeMux()
-	mux.Handle(defaultMetricsPath, m.ocExporter)
+	// Serves the same registry as the OpenCensus exporter, adding the exemplars that link the latency histograms to traces
+	mux.Handle(defaultMetricsPath, promhttp.HandlerFor(newExemplarGatherer(m.namespace, m.gatherer()), promhttp.HandlerOpts{
+		EnableOpenMetrics: true,
+	}))
 
 	m.server = &http.Server{
 		Addr:        addr,
@@ -127,3 +141,8 @@ func (m *promMetricsExporter) startMetricServer(ctx context.Context) error {
 	defer cancel()
 	return errors.Join(m.server.Shutdown(ctx), err, <-errCh)
 }
+
+// gatherer returns the gatherer of the metrics of the OpenCensus exporter, and of the collectors registered with the default Prometheus registry.
+func (m *promMetricsExporter) gatherer() prom.Gatherer {
+	return prom.Gatherers{prom.DefaultGatherer, m.registry}
+}
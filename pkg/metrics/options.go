@@ -15,11 +15,13 @@ package metrics
 
 import (
 	"strconv"
+	"time"
 )
 
 const (
-	defaultMetricsPort    = "9090"
-	defaultMetricsEnabled = true
+	defaultMetricsPort         = "9090"
+	defaultMetricsEnabled      = true
+	defaultMetricsPushInterval = 15 * time.Second
 )
 
 // Options defines the sets of options for exporting metrics.
@@ -28,12 +30,18 @@ type Options struct {
 	MetricsEnabled bool
 	// Port to start metrics server on.
 	Port string
+	// PushURL is the address of a Prometheus Pushgateway the metrics are pushed to, in addition to being served on Port.
+	// Useful for short-lived processes, such as the sidecars of Jobs, which could exit before they are scraped.
+	PushURL string
+	// PushInterval is the interval at which the metrics are pushed, such as "15s".
+	PushInterval string
 }
 
 func DefaultMetricOptions() *Options {
 	return &Options{
 		Port:           defaultMetricsPort,
 		MetricsEnabled: defaultMetricsEnabled,
+		PushInterval:   defaultMetricsPushInterval.String(),
 	}
 }
 
@@ -48,6 +56,17 @@ func (o *Options) MetricsPort() uint64 {
 	return port
 }
 
+// MetricsPushInterval gets the interval at which the metrics are pushed to the Pushgateway.
+func (o *Options) MetricsPushInterval() time.Duration {
+	interval, err := time.ParseDuration(o.PushInterval)
+	if err != nil || interval <= 0 {
+		// Use default push interval as a fallback
+		return defaultMetricsPushInterval
+	}
+
+	return interval
+}
+
 // AttachCmdFlags attaches metrics options to command flags.
 func (o *Options) AttachCmdFlags(
 	stringVar func(p *string, name string, value string, usage string),
@@ -58,6 +77,16 @@ func (o *Options) AttachCmdFlags(
 		"metrics-port",
 		defaultMetricsPort,
 		"The port for the metrics server")
+	stringVar(
+		&o.PushURL,
+		"metrics-push-url",
+		"",
+		"Address of a Prometheus Pushgateway the metrics are pushed to periodically and on shutdown, such as http://pushgateway:9091")
+	stringVar(
+		&o.PushInterval,
+		"metrics-push-interval",
+		defaultMetricsPushInterval.String(),
+		"The interval at which the metrics are pushed to the Pushgateway")
 	boolVar(
 		&o.MetricsEnabled,
 		"enable-metrics",
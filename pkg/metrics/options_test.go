@@ -16,6 +16,7 @@ package metrics
 import (
 	"strconv"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 )
@@ -71,6 +72,18 @@ func TestOptions(t *testing.T) {
 		assert.Equal(t, defaultPort, o.MetricsPort())
 	})
 
+	t.Run("parse push interval", func(t *testing.T) {
+		o := Options{PushInterval: "1m"}
+
+		assert.Equal(t, time.Minute, o.MetricsPushInterval())
+	})
+
+	t.Run("return default push interval if interval is invalid", func(t *testing.T) {
+		o := Options{PushInterval: "invalid"}
+
+		assert.Equal(t, defaultMetricsPushInterval, o.MetricsPushInterval())
+	})
+
 	t.Run("attaching single metrics related cmd flag", func(t *testing.T) {
 		o := DefaultMetricOptions()
 
@@ -0,0 +1,68 @@
+/*
+Copyright 2023 The Dapr Authors
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package metrics
+
+import (
+	"context"
+	"os"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus/push"
+)
+
+const (
+	// pushTimeout is the timeout of a single push to the Pushgateway.
+	pushTimeout = 10 * time.Second
+	// pushInstanceLabel is the grouping label that distinguishes the processes pushing to the same job.
+	pushInstanceLabel = "instance"
+)
+
+// newPusher returns the pusher of the metrics to the Pushgateway.
+// The metrics are grouped by the namespace as the job, and by the hostname (the pod name on Kubernetes) as the instance.
+func (m *promMetricsExporter) newPusher() *push.Pusher {
+	pusher := push.New(m.options.PushURL, m.namespace).Gatherer(m.gatherer())
+	if hostname, err := os.Hostname(); err == nil && hostname != "" {
+		pusher = pusher.Grouping(pushInstanceLabel, hostname)
+	}
+	return pusher
+}
+
+// runPusher pushes the metrics to the Pushgateway periodically, and once more when the context is canceled,
+// so the metrics recorded right before a graceful shutdown are not lost.
+func (m *promMetricsExporter) runPusher(ctx context.Context) error {
+	pusher := m.newPusher()
+	m.exporter.logger.Infof("pushing metrics to %s every %v", m.options.PushURL, m.options.MetricsPushInterval())
+
+	ticker := time.NewTicker(m.options.MetricsPushInterval())
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			// The context is canceled, so the last push uses a new one
+			m.push(context.Background(), pusher)
+			return nil
+		case <-ticker.C:
+			m.push(ctx, pusher)
+		}
+	}
+}
+
+// push pushes the metrics once. Failures are logged, as the Pushgateway being unavailable must not stop the process.
+func (m *promMetricsExporter) push(ctx context.Context, pusher *push.Pusher) {
+	ctx, cancel := context.WithTimeout(ctx, pushTimeout)
+	defer cancel()
+	if err := pusher.PushContext(ctx); err != nil {
+		m.exporter.logger.Warnf("failed to push metrics to %s: %v", m.options.PushURL, err)
+	}
+}
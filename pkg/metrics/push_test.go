@@ -0,0 +1,123 @@
+/*
+Copyright 2023 The Dapr Authors
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package metrics
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	prom "github.com/prometheus/client_golang/prometheus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/dapr/kit/logger"
+)
+
+// fakePushgateway records the pushes it receives.
+type fakePushgateway struct {
+	lock   sync.Mutex
+	paths  []string
+	bodies []string
+}
+
+func (f *fakePushgateway) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	body, _ := io.ReadAll(r.Body)
+	f.lock.Lock()
+	defer f.lock.Unlock()
+	if r.Method == http.MethodPut {
+		f.paths = append(f.paths, r.URL.Path)
+		f.bodies = append(f.bodies, string(body))
+	}
+	w.WriteHeader(http.StatusOK)
+}
+
+func (f *fakePushgateway) pushes() int {
+	f.lock.Lock()
+	defer f.lock.Unlock()
+	return len(f.paths)
+}
+
+func TestPusher(t *testing.T) {
+	newExporter := func(t *testing.T, url string) *promMetricsExporter {
+		registry := prom.NewRegistry()
+		counter := prom.NewCounter(prom.CounterOpts{Name: "test_pushed_total"})
+		counter.Inc()
+		require.NoError(t, registry.Register(counter))
+
+		options := DefaultMetricOptions()
+		options.PushURL = url
+		options.PushInterval = "10ms"
+		return &promMetricsExporter{
+			exporter: &exporter{
+				namespace: "test",
+				options:   options,
+				logger:    logger.NewLogger("test.logger"),
+			},
+			registry: registry,
+		}
+	}
+
+	t.Run("pushes periodically and on shutdown", func(t *testing.T) {
+		gateway := &fakePushgateway{}
+		server := httptest.NewServer(gateway)
+		defer server.Close()
+
+		e := newExporter(t, server.URL)
+		ctx, cancel := context.WithCancel(context.Background())
+		errCh := make(chan error)
+		go func() {
+			errCh <- e.runPusher(ctx)
+		}()
+
+		assert.Eventually(t, func() bool {
+			return gateway.pushes() >= 2
+		}, 5*time.Second, 10*time.Millisecond)
+
+		cancel()
+		pushes := gateway.pushes()
+		select {
+		case err := <-errCh:
+			require.NoError(t, err)
+		case <-time.After(5 * time.Second):
+			t.Fatal("expected runPusher to return when context is cancelled")
+		}
+
+		gateway.lock.Lock()
+		defer gateway.lock.Unlock()
+		// The metrics are pushed a last time on shutdown
+		assert.Greater(t, len(gateway.paths), pushes)
+		for _, path := range gateway.paths {
+			assert.True(t, strings.HasPrefix(path, "/metrics/job/test"), path)
+		}
+		assert.Contains(t, gateway.bodies[0], "test_pushed_total")
+	})
+
+	t.Run("failed pushes don't stop the pusher", func(t *testing.T) {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusServiceUnavailable)
+		}))
+		defer server.Close()
+
+		e := newExporter(t, server.URL)
+		ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+		defer cancel()
+		require.NoError(t, e.runPusher(ctx))
+	})
+}
@@ -17,6 +17,7 @@ type Config struct {
 	MTLSEnabled             bool
 	ControlPlaneTrustDomain string
 	SentryAddress           string
+	TLS                     *security.TLSOptions
 }
 
 // LoadConfiguration loads the Kubernetes configuration and returns an Operator Config.
@@ -44,9 +45,19 @@ func LoadConfiguration(ctx context.Context, name string, restConfig *rest.Config
 	if err := client.Get(ctx, key, &conf); err != nil {
 		return nil, err
 	}
+
+	var tlsOpts *security.TLSOptions
+	if tlsSpec := conf.Spec.MTLSSpec.TLS; tlsSpec != nil {
+		tlsOpts, err = security.ParseTLSOptions(tlsSpec.MinVersion, tlsSpec.CipherSuites, tlsSpec.CurvePreferences)
+		if err != nil {
+			return nil, fmt.Errorf("invalid TLS options in configuration: %w", err)
+		}
+	}
+
 	return &Config{
 		MTLSEnabled:             conf.Spec.MTLSSpec.GetEnabled(),
 		ControlPlaneTrustDomain: conf.Spec.MTLSSpec.ControlPlaneTrustDomain,
 		SentryAddress:           conf.Spec.MTLSSpec.SentryAddress,
+		TLS:                     tlsOpts,
 	}, nil
 }
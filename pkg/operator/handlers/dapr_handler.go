@@ -41,6 +41,10 @@ const (
 	annotationPrometheusScrape      = "prometheus.io/scrape"
 	annotationPrometheusPort        = "prometheus.io/port"
 	annotationPrometheusPath        = "prometheus.io/path"
+	// annotationServiceHash contains the hash of the desired state of a Dapr service, used to tell changes made by the operator from manual edits.
+	annotationServiceHash = "dapr.io/service-hash"
+	// annotationDisableDriftReconcile can be set to "true" on a Dapr service to prevent the operator from reverting manual edits to it.
+	annotationDisableDriftReconcile = "dapr.io/disable-drift-reconcile"
 )
 
 var log = logger.NewLogger("dapr.operator.handlers")
@@ -215,6 +219,11 @@ func (h *DaprHandler) ensureDaprServicePresent(ctx context.Context, namespace st
 		return err
 	}
 
+	if utils.IsTruthy(daprSvc.Annotations[annotationDisableDriftReconcile]) {
+		log.Debugf("drift reconciliation is disabled for service %s, skipping", daprSvcName)
+		return nil
+	}
+
 	err = h.patchDaprService(ctx, daprSvcName, wrapper, daprSvc)
 	if err != nil {
 		log.Errorf("unable to update service, %s, err: %s", daprSvcName, err)
@@ -233,6 +242,15 @@ func (h *DaprHandler) patchDaprService(ctx context.Context, expectedService type
 		return err
 	}
 
+	// If the hash of the desired state hasn't changed, the service needs to be updated only if it was edited by someone else.
+	if daprSvc.Annotations[annotationServiceHash] == service.Annotations[annotationServiceHash] {
+		if !isServiceDrifted(&daprSvc, service) {
+			return nil
+		}
+		log.Infof("Detected drift in service %s, reverting it to the desired state", expectedService)
+		monitoring.RecordServiceDriftCount(appID)
+	}
+
 	service.ObjectMeta.ResourceVersion = daprSvc.ObjectMeta.ResourceVersion
 
 	err = h.Update(ctx, service)
@@ -278,7 +296,7 @@ func (h *DaprHandler) createDaprServiceValues(ctx context.Context, expectedServi
 		annotationsMap[annotationPrometheusPath] = "/"
 	}
 
-	return &corev1.Service{
+	service := &corev1.Service{
 		ObjectMeta: metaV1.ObjectMeta{
 			Name:        expectedService.Name,
 			Namespace:   expectedService.Namespace,
@@ -316,6 +334,8 @@ func (h *DaprHandler) createDaprServiceValues(ctx context.Context, expectedServi
 			},
 		},
 	}
+	annotationsMap[annotationServiceHash] = serviceHash(service)
+	return service
 }
 
 func (h *DaprHandler) getAppID(wrapper ObjectWrapper) string {
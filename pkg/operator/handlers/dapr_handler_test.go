@@ -175,6 +175,101 @@ func TestPatchDaprService(t *testing.T) {
 	assert.Equal(t, "app", actualService.OwnerReferences[0].Name)
 }
 
+func TestDaprServiceDrift(t *testing.T) {
+	testDaprHandler := getTestDaprHandler()
+
+	s := runtime.NewScheme()
+	err := scheme.AddToScheme(s)
+	require.NoError(t, err)
+	testDaprHandler.Scheme = s
+
+	cli := fake.NewClientBuilder().WithScheme(s).Build()
+	testDaprHandler.Client = cli
+
+	ctx := context.Background()
+	myDaprService := types.NamespacedName{
+		Namespace: "test",
+		Name:      "test-dapr",
+	}
+	deployment := getDeployment("test", "true")
+
+	err = testDaprHandler.ensureDaprServicePresent(ctx, "test", deployment)
+	require.NoError(t, err)
+	var actualService corev1.Service
+	err = cli.Get(ctx, myDaprService, &actualService)
+	require.NoError(t, err)
+	assert.NotEmpty(t, actualService.Annotations[annotationServiceHash])
+	resourceVersion := actualService.ResourceVersion
+
+	t.Run("no drift", func(t *testing.T) {
+		err = testDaprHandler.ensureDaprServicePresent(ctx, "test", deployment)
+		require.NoError(t, err)
+		err = cli.Get(ctx, myDaprService, &actualService)
+		require.NoError(t, err)
+		assert.Equal(t, resourceVersion, actualService.ResourceVersion)
+	})
+
+	t.Run("manual edit is reverted", func(t *testing.T) {
+		actualService.Spec.Ports[0].Port = 8080
+		actualService.Labels["custom"] = "label"
+		require.NoError(t, cli.Update(ctx, &actualService))
+
+		err = testDaprHandler.ensureDaprServicePresent(ctx, "test", deployment)
+		require.NoError(t, err)
+		err = cli.Get(ctx, myDaprService, &actualService)
+		require.NoError(t, err)
+		assert.Equal(t, int32(80), actualService.Spec.Ports[0].Port)
+	})
+
+	t.Run("drift reconciliation disabled", func(t *testing.T) {
+		actualService.Spec.Ports[0].Port = 8080
+		actualService.Annotations[annotationDisableDriftReconcile] = "true"
+		require.NoError(t, cli.Update(ctx, &actualService))
+
+		err = testDaprHandler.ensureDaprServicePresent(ctx, "test", deployment)
+		require.NoError(t, err)
+		err = cli.Get(ctx, myDaprService, &actualService)
+		require.NoError(t, err)
+		assert.Equal(t, int32(8080), actualService.Spec.Ports[0].Port)
+	})
+}
+
+func TestIsServiceDrifted(t *testing.T) {
+	deployment := getDeployment("test", "true")
+	desired := getTestDaprHandler().createDaprServiceValues(context.Background(), types.NamespacedName{Namespace: "test", Name: "test-dapr"}, deployment, "test")
+
+	t.Run("same service", func(t *testing.T) {
+		actual := desired.DeepCopy()
+		assert.False(t, isServiceDrifted(actual, desired))
+	})
+
+	t.Run("extra labels, annotations, and defaulted fields", func(t *testing.T) {
+		actual := desired.DeepCopy()
+		actual.Labels["other"] = "label"
+		actual.Annotations["other"] = "annotation"
+		actual.Spec.SessionAffinity = corev1.ServiceAffinityNone
+		assert.False(t, isServiceDrifted(actual, desired))
+	})
+
+	t.Run("annotation changed", func(t *testing.T) {
+		actual := desired.DeepCopy()
+		actual.Annotations[annotations.KeyAppID] = "other"
+		assert.True(t, isServiceDrifted(actual, desired))
+	})
+
+	t.Run("selector changed", func(t *testing.T) {
+		actual := desired.DeepCopy()
+		actual.Spec.Selector = map[string]string{"app": "other"}
+		assert.True(t, isServiceDrifted(actual, desired))
+	})
+
+	t.Run("port removed", func(t *testing.T) {
+		actual := desired.DeepCopy()
+		actual.Spec.Ports = actual.Spec.Ports[1:]
+		assert.True(t, isServiceDrifted(actual, desired))
+	})
+}
+
 func TestGetMetricsPort(t *testing.T) {
 	testDaprHandler := getTestDaprHandler()
 	t.Run("metrics port override", func(t *testing.T) {
@@ -0,0 +1,62 @@
+package handlers
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"maps"
+
+	corev1 "k8s.io/api/core/v1"
+	metaV1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// serviceHash returns a hash of the labels, annotations, and spec of a desired Dapr service.
+func serviceHash(service *corev1.Service) string {
+	// Errors are not possible when marshaling these types.
+	b, _ := json.Marshal(struct {
+		Labels      map[string]string  `json:"labels"`
+		Annotations map[string]string  `json:"annotations"`
+		Spec        corev1.ServiceSpec `json:"spec"`
+	}{
+		Labels:      service.Labels,
+		Annotations: service.Annotations,
+		Spec:        service.Spec,
+	})
+	h := sha256.Sum256(b)
+	return hex.EncodeToString(h[:8])
+}
+
+// isServiceDrifted returns true if the actual service doesn't match the desired one.
+// Only the fields set by the operator are compared, so fields defaulted by the API server and labels or annotations added by other controllers are not considered drift.
+func isServiceDrifted(actual *corev1.Service, desired *corev1.Service) bool {
+	for k, v := range desired.Labels {
+		if actual.Labels[k] != v {
+			return true
+		}
+	}
+	for k, v := range desired.Annotations {
+		if actual.Annotations[k] != v {
+			return true
+		}
+	}
+
+	desiredOwner := metaV1.GetControllerOf(desired)
+	actualOwner := metaV1.GetControllerOf(actual)
+	if desiredOwner != nil && (actualOwner == nil || actualOwner.UID != desiredOwner.UID) {
+		return true
+	}
+
+	if actual.Spec.ClusterIP != desired.Spec.ClusterIP ||
+		!maps.Equal(actual.Spec.Selector, desired.Spec.Selector) ||
+		len(actual.Spec.Ports) != len(desired.Spec.Ports) {
+		return true
+	}
+	for i, p := range desired.Spec.Ports {
+		a := actual.Spec.Ports[i]
+		if a.Name != p.Name || a.Protocol != p.Protocol || a.Port != p.Port || a.TargetPort != p.TargetPort {
+			return true
+		}
+	}
+
+	return false
+}
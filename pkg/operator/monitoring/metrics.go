@@ -24,7 +24,8 @@ import (
 )
 
 const (
-	appID = "app_id"
+	appID     = "app_id"
+	namespace = "namespace"
 )
 
 var (
@@ -40,9 +41,32 @@ var (
 		"operator/service_updated_total",
 		"The total number of dapr services updated.",
 		stats.UnitDimensionless)
+	serviceDriftTotal = stats.Int64(
+		"operator/service_drift_total",
+		"The total number of manual edits to dapr services detected and reverted.",
+		stats.UnitDimensionless)
+
+	workflowActiveInstances = stats.Int64(
+		"operator/workflow/active_instances",
+		"The number of workflow instances active in the sidecars of an app.",
+		stats.UnitDimensionless)
+	workflowActiveInstancesSidecars = stats.Int64(
+		"operator/workflow/active_instances_sidecars",
+		"The number of sidecars of an app whose active workflow instances were collected. The HTTP API of the sidecars must listen on the IP of their pod.",
+		stats.UnitDimensionless)
+	workflowFailedExecutions = stats.Int64(
+		"operator/workflow/failed_executions_total",
+		"The total number of failed workflow executions reported by the sidecars of an app.",
+		stats.UnitDimensionless)
+	workflowSidecarsScraped = stats.Int64(
+		"operator/workflow/sidecars_scraped",
+		"The number of sidecars of an app whose workflow metrics were collected.",
+		stats.UnitDimensionless)
 
 	// appIDKey is a tag key for App ID.
 	appIDKey = tag.MustNewKey(appID)
+	// namespaceKey is a tag key for the namespace of the app.
+	namespaceKey = tag.MustNewKey(namespace)
 )
 
 // RecordServiceCreatedCount records the number of dapr service created.
@@ -60,12 +84,35 @@ func RecordServiceUpdatedCount(appID string) {
 	stats.RecordWithTags(context.Background(), diagUtils.WithTags(serviceUpdatedTotal.Name(), appIDKey, appID), serviceUpdatedTotal.M(1))
 }
 
+// RecordServiceDriftCount records the number of manual edits to dapr services detected.
+func RecordServiceDriftCount(appID string) {
+	stats.RecordWithTags(context.Background(), diagUtils.WithTags(serviceDriftTotal.Name(), appIDKey, appID), serviceDriftTotal.M(1))
+}
+
+// RecordWorkflowMetrics records the workflow metrics of an app, aggregated from the metrics of its sidecars.
+func RecordWorkflowMetrics(appID, namespace string, activeInstances, activeInstancesSidecars, sidecars int64) {
+	ctx := context.Background()
+	stats.RecordWithTags(ctx, diagUtils.WithTags(workflowActiveInstances.Name(), appIDKey, appID, namespaceKey, namespace), workflowActiveInstances.M(activeInstances))
+	stats.RecordWithTags(ctx, diagUtils.WithTags(workflowActiveInstancesSidecars.Name(), appIDKey, appID, namespaceKey, namespace), workflowActiveInstancesSidecars.M(activeInstancesSidecars))
+	stats.RecordWithTags(ctx, diagUtils.WithTags(workflowSidecarsScraped.Name(), appIDKey, appID, namespaceKey, namespace), workflowSidecarsScraped.M(sidecars))
+}
+
+// RecordWorkflowFailedExecutions adds the failed workflow executions reported by the sidecars of an app since the previous collection.
+func RecordWorkflowFailedExecutions(appID, namespace string, failedExecutions int64) {
+	stats.RecordWithTags(context.Background(), diagUtils.WithTags(workflowFailedExecutions.Name(), appIDKey, appID, namespaceKey, namespace), workflowFailedExecutions.M(failedExecutions))
+}
+
 // InitMetrics initialize the operator service metrics.
 func InitMetrics() error {
 	err := view.Register(
 		diagUtils.NewMeasureView(serviceCreatedTotal, []tag.Key{appIDKey}, view.Count()),
 		diagUtils.NewMeasureView(serviceDeletedTotal, []tag.Key{appIDKey}, view.Count()),
 		diagUtils.NewMeasureView(serviceUpdatedTotal, []tag.Key{appIDKey}, view.Count()),
+		diagUtils.NewMeasureView(serviceDriftTotal, []tag.Key{appIDKey}, view.Count()),
+		diagUtils.NewMeasureView(workflowActiveInstances, []tag.Key{appIDKey, namespaceKey}, view.LastValue()),
+		diagUtils.NewMeasureView(workflowActiveInstancesSidecars, []tag.Key{appIDKey, namespaceKey}, view.LastValue()),
+		diagUtils.NewMeasureView(workflowFailedExecutions, []tag.Key{appIDKey, namespaceKey}, view.Sum()),
+		diagUtils.NewMeasureView(workflowSidecarsScraped, []tag.Key{appIDKey, namespaceKey}, view.LastValue()),
 	)
 
 	return err
@@ -17,6 +17,7 @@ import (
 	"bytes"
 	"context"
 	"crypto/tls"
+	"encoding/base64"
 	"encoding/json"
 	"errors"
 	"fmt"
@@ -30,10 +31,12 @@ import (
 	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/kubernetes"
 	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
 	"k8s.io/client-go/rest"
 	"k8s.io/client-go/tools/cache"
 	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/webhook"
 
 	componentsapi "github.com/dapr/dapr/pkg/apis/components/v1alpha1"
 	configurationapi "github.com/dapr/dapr/pkg/apis/configuration/v1alpha1"
@@ -46,6 +49,7 @@ import (
 	"github.com/dapr/dapr/pkg/operator/api"
 	operatorcache "github.com/dapr/dapr/pkg/operator/cache"
 	"github.com/dapr/dapr/pkg/operator/handlers"
+	"github.com/dapr/dapr/pkg/operator/quota"
 	"github.com/dapr/dapr/pkg/security"
 	"github.com/dapr/kit/concurrency"
 	"github.com/dapr/kit/logger"
@@ -53,6 +57,9 @@ import (
 
 var log = logger.NewLogger("dapr.operator")
 
+// Name of the ValidatingWebhookConfiguration that enforces the namespace quotas.
+const quotaWebhookConfigurationName = "dapr-operator-quota"
+
 // Operator is an Dapr Kubernetes Operator for managing components and sidecar lifecycle.
 type Operator interface {
 	Run(ctx context.Context) error
@@ -69,9 +76,11 @@ type Options struct {
 	ServiceReconcilerEnabled            bool
 	ArgoRolloutServiceReconcilerEnabled bool
 	WatchdogCanPatchPodLabels           bool
+	WorkflowMetricsInterval             time.Duration
 	TrustAnchorsFile                    string
 	APIPort                             int
 	HealthzPort                         int
+	Quotas                              quota.Limits
 }
 
 type operator struct {
@@ -82,6 +91,7 @@ type operator struct {
 	mgr         ctrl.Manager
 	secProvider security.Provider
 	healthzPort int
+	quotas      quota.Limits
 }
 
 // NewOperator returns a new Dapr Operator.
@@ -112,6 +122,7 @@ func NewOperator(ctx context.Context, opts Options) (Operator, error) {
 		MTLSEnabled:    true,
 		WriteSVIDToDir: &certDir,
 		Mode:           modes.KubernetesMode,
+		TLS:            config.TLS,
 	})
 	if err != nil {
 		return nil, err
@@ -170,6 +181,17 @@ func NewOperator(ctx context.Context, opts Options) (Operator, error) {
 		log.Infof("Dapr Watchdog is not enabled")
 	}
 
+	if opts.WorkflowMetricsInterval > 0 {
+		wa := &WorkflowMetricsAggregator{
+			interval:       opts.WorkflowMetricsInterval,
+			watchNamespace: opts.WatchNamespace,
+			reader:         mgr.GetAPIReader(),
+		}
+		if err := mgr.Add(wa); err != nil {
+			return nil, fmt.Errorf("unable to add workflow metrics aggregator: %w", err)
+		}
+	}
+
 	if opts.ServiceReconcilerEnabled {
 		daprHandler := handlers.NewDaprHandlerWithOptions(mgr, &handlers.Options{ArgoRolloutServiceReconcilerEnabled: opts.ArgoRolloutServiceReconcilerEnabled})
 		if err := daprHandler.Init(ctx); err != nil {
@@ -182,6 +204,7 @@ func NewOperator(ctx context.Context, opts Options) (Operator, error) {
 		secProvider: secProvider,
 		config:      config,
 		healthzPort: opts.HealthzPort,
+		quotas:      opts.Quotas,
 		apiServer: api.NewAPIServer(api.Options{
 			Client:   mgrClient,
 			Security: secProvider,
@@ -233,6 +256,14 @@ func (o *operator) Run(ctx context.Context) error {
 		}
 	}
 
+	enableQuotaWebhook := enableConversionWebhooks && o.quotas.Enabled()
+	if enableQuotaWebhook {
+		log.Infof("Enforcing namespace quotas: max components=%d, max subscriptions=%d, max resiliencies=%d", o.quotas.MaxComponents, o.quotas.MaxSubscriptions, o.quotas.MaxResiliencies)
+		o.mgr.GetWebhookServer().Register(quota.WebhookPath, &webhook.Admission{
+			Handler: quota.NewHandler(o.mgr.GetAPIReader(), o.quotas),
+		})
+	}
+
 	caBundleCh := make(chan []byte)
 
 	runner := concurrency.NewRunnerManager(
@@ -293,6 +324,12 @@ func (o *operator) Run(ctx context.Context) error {
 				if rErr != nil {
 					return rErr
 				}
+				if enableQuotaWebhook {
+					rErr = o.patchQuotaWebhook(ctx, caBundle, o.mgr.GetConfig())
+					if rErr != nil {
+						return rErr
+					}
+				}
 
 				select {
 				case caBundle = <-caBundleCh:
@@ -419,6 +456,27 @@ func (o *operator) patchConversionWebhooksInCRDs(ctx context.Context, caBundle [
 	return nil
 }
 
+// Patches the validating webhook that enforces the namespace quotas to set the CA bundle.
+func (o *operator) patchQuotaWebhook(ctx context.Context, caBundle []byte, conf *rest.Config) error {
+	client, err := kubernetes.NewForConfig(conf)
+	if err != nil {
+		return fmt.Errorf("could not get Kubernetes API client: %w", err)
+	}
+
+	_, err = client.AdmissionregistrationV1().ValidatingWebhookConfigurations().Patch(ctx,
+		quotaWebhookConfigurationName,
+		types.JSONPatchType,
+		[]byte(`[{"op":"replace","path":"/webhooks/0/clientConfig/caBundle","value":"`+base64.StdEncoding.EncodeToString(caBundle)+`"}]`),
+		v1.PatchOptions{},
+	)
+	if err != nil {
+		return fmt.Errorf("failed to patch validating webhook configuration %q: %w", quotaWebhookConfigurationName, err)
+	}
+
+	log.Infof("Successfully patched validating webhook configuration %q", quotaWebhookConfigurationName)
+	return nil
+}
+
 func buildScheme(opts Options) (*runtime.Scheme, error) {
 	builders := []func(*runtime.Scheme) error{
 		clientgoscheme.AddToScheme,
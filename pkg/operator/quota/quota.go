@@ -0,0 +1,104 @@
+/*
+Copyright 2023 The Dapr Authors
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package quota
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+
+	admissionv1 "k8s.io/api/admission/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	componentsapi "github.com/dapr/dapr/pkg/apis/components/v1alpha1"
+	resiliencyapi "github.com/dapr/dapr/pkg/apis/resiliency/v1alpha1"
+	subscriptionsapi "github.com/dapr/dapr/pkg/apis/subscriptions/v2alpha1"
+	"github.com/dapr/kit/logger"
+)
+
+// WebhookPath is the path of the validating webhook that enforces the quotas.
+const WebhookPath = "/validate-quota"
+
+var log = logger.NewLogger("dapr.operator.quota")
+
+// Limits contains the maximum number of Dapr resources of each kind that can be created in a namespace.
+// A value of 0 means that the number of resources of that kind is not limited.
+type Limits struct {
+	MaxComponents    int
+	MaxSubscriptions int
+	MaxResiliencies  int
+}
+
+// Enabled returns true if at least one limit is set.
+func (l Limits) Enabled() bool {
+	return l.MaxComponents > 0 || l.MaxSubscriptions > 0 || l.MaxResiliencies > 0
+}
+
+// Handler is an admission handler that rejects the creation of Dapr resources in namespaces that have reached their quota.
+type Handler struct {
+	reader client.Reader
+	limits Limits
+}
+
+// NewHandler returns a new Handler.
+// The reader is used to count the resources that exist in the namespace, and it should not be backed by a cache to avoid admitting resources over the quota.
+func NewHandler(reader client.Reader, limits Limits) *Handler {
+	return &Handler{
+		reader: reader,
+		limits: limits,
+	}
+}
+
+// Handle implements admission.Handler.
+func (h *Handler) Handle(ctx context.Context, req admission.Request) admission.Response {
+	if req.Operation != admissionv1.Create {
+		return admission.Allowed("")
+	}
+
+	var (
+		list  client.ObjectList
+		limit int
+	)
+	switch req.Kind.Kind {
+	case "Component":
+		list = &componentsapi.ComponentList{}
+		limit = h.limits.MaxComponents
+	case "Subscription":
+		list = &subscriptionsapi.SubscriptionList{}
+		limit = h.limits.MaxSubscriptions
+	case "Resiliency":
+		list = &resiliencyapi.ResiliencyList{}
+		limit = h.limits.MaxResiliencies
+	default:
+		return admission.Allowed("")
+	}
+	if limit <= 0 {
+		return admission.Allowed("")
+	}
+
+	err := h.reader.List(ctx, list, client.InNamespace(req.Namespace))
+	if err != nil {
+		log.Errorf("Failed to count resources of kind %s in namespace %s: %v", req.Kind.Kind, req.Namespace, err)
+		return admission.Errored(http.StatusInternalServerError, err)
+	}
+	count := meta.LenList(list)
+	if count >= limit {
+		log.Infof("Rejected creation of %s %s/%s: namespace quota of %d reached", req.Kind.Kind, req.Namespace, req.Name, limit)
+		return admission.Denied(fmt.Sprintf("namespace %s has reached its quota of %d resources of kind %s", req.Namespace, limit, req.Kind.Kind))
+	}
+
+	return admission.Allowed("")
+}
@@ -0,0 +1,97 @@
+/*
+Copyright 2023 The Dapr Authors
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package quota
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	admissionv1 "k8s.io/api/admission/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	componentsapi "github.com/dapr/dapr/pkg/apis/components/v1alpha1"
+	resiliencyapi "github.com/dapr/dapr/pkg/apis/resiliency/v1alpha1"
+	subscriptionsapi "github.com/dapr/dapr/pkg/apis/subscriptions/v2alpha1"
+)
+
+func newRequest(kind string, namespace string, operation admissionv1.Operation) admission.Request {
+	return admission.Request{
+		AdmissionRequest: admissionv1.AdmissionRequest{
+			Kind:      metav1.GroupVersionKind{Group: "dapr.io", Version: "v1alpha1", Kind: kind},
+			Name:      "new",
+			Namespace: namespace,
+			Operation: operation,
+		},
+	}
+}
+
+func TestLimitsEnabled(t *testing.T) {
+	assert.False(t, Limits{}.Enabled())
+	assert.True(t, Limits{MaxComponents: 1}.Enabled())
+	assert.True(t, Limits{MaxSubscriptions: 1}.Enabled())
+	assert.True(t, Limits{MaxResiliencies: 1}.Enabled())
+}
+
+func TestHandle(t *testing.T) {
+	s := runtime.NewScheme()
+	require.NoError(t, componentsapi.AddToScheme(s))
+	require.NoError(t, subscriptionsapi.AddToScheme(s))
+	require.NoError(t, resiliencyapi.AddToScheme(s))
+
+	reader := fake.NewClientBuilder().
+		WithScheme(s).
+		WithObjects(
+			&componentsapi.Component{ObjectMeta: metav1.ObjectMeta{Name: "comp1", Namespace: "ns1"}},
+			&componentsapi.Component{ObjectMeta: metav1.ObjectMeta{Name: "comp2", Namespace: "ns1"}},
+			&componentsapi.Component{ObjectMeta: metav1.ObjectMeta{Name: "comp1", Namespace: "ns2"}},
+			&subscriptionsapi.Subscription{ObjectMeta: metav1.ObjectMeta{Name: "sub1", Namespace: "ns1"}},
+			&resiliencyapi.Resiliency{ObjectMeta: metav1.ObjectMeta{Name: "res1", Namespace: "ns1"}},
+		).
+		Build()
+
+	h := NewHandler(reader, Limits{
+		MaxComponents:    2,
+		MaxSubscriptions: 1,
+	})
+
+	tests := []struct {
+		name    string
+		req     admission.Request
+		allowed bool
+	}{
+		{name: "components over quota", req: newRequest("Component", "ns1", admissionv1.Create), allowed: false},
+		{name: "components under quota", req: newRequest("Component", "ns2", admissionv1.Create), allowed: true},
+		{name: "subscriptions over quota", req: newRequest("Subscription", "ns1", admissionv1.Create), allowed: false},
+		{name: "subscriptions in empty namespace", req: newRequest("Subscription", "ns3", admissionv1.Create), allowed: true},
+		{name: "resiliencies not limited", req: newRequest("Resiliency", "ns1", admissionv1.Create), allowed: true},
+		{name: "updates are always allowed", req: newRequest("Component", "ns1", admissionv1.Update), allowed: true},
+		{name: "deletes are always allowed", req: newRequest("Component", "ns1", admissionv1.Delete), allowed: true},
+		{name: "other kinds are always allowed", req: newRequest("Configuration", "ns1", admissionv1.Create), allowed: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			res := h.Handle(context.Background(), tt.req)
+			assert.Equal(t, tt.allowed, res.Allowed)
+			if !tt.allowed {
+				assert.Contains(t, res.Result.Message, "quota")
+			}
+		})
+	}
+}
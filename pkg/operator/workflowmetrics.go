@@ -0,0 +1,397 @@
+/*
+Copyright 2023 The Dapr Authors
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package operator
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/prometheus/common/expfmt"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/selection"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/dapr/dapr/pkg/client/admin"
+	injectorConsts "github.com/dapr/dapr/pkg/injector/consts"
+	"github.com/dapr/dapr/pkg/operator/monitoring"
+)
+
+const (
+	// Prefix and suffix of the type of the internal actors that run the workflow instances.
+	workflowActorTypePrefix = "dapr.internal."
+	workflowActorTypeSuffix = ".workflow"
+
+	// workflowExecutionCountMetric is the metric of the sidecars with the number of workflow and activity executions, by status.
+	workflowExecutionCountMetric = "dapr_runtime_workflow_execution_count"
+	// sidecarListenAddressesArg is the argument of the sidecar container with the addresses the Dapr API listens on.
+	sidecarListenAddressesArg = "--dapr-listen-addresses"
+
+	workflowMetricsScrapeTimeout = 5 * time.Second
+	// workflowMetricsScrapeConcurrency is the maximum number of sidecars scraped at the same time.
+	workflowMetricsScrapeConcurrency = 16
+)
+
+// workflowAppKey identifies an app in the aggregated workflow metrics.
+type workflowAppKey struct {
+	namespace string
+	appID     string
+}
+
+// workflowAppMetrics are the workflow metrics of an app, summed over its sidecars.
+type workflowAppMetrics struct {
+	activeInstances int64
+	// activeSidecars is the number of sidecars whose active instances were collected.
+	activeSidecars int64
+	// newFailedExecutions is the number of failed executions since the previous collection.
+	newFailedExecutions int64
+	sidecars            int64
+}
+
+// sidecarWorkflowMetrics are the workflow metrics collected from a sidecar.
+type sidecarWorkflowMetrics struct {
+	activeInstances int64
+	// hasActiveInstances is false if the HTTP API of the sidecar isn't reachable from the operator.
+	hasActiveInstances bool
+	failedExecutions   int64
+	// hasFailedExecutions is false if the metrics of the sidecar couldn't be read.
+	hasFailedExecutions bool
+}
+
+// WorkflowMetricsAggregator is a controller that periodically collects the workflow metrics of the sidecars, and exposes them aggregated by app in the metrics of the operator.
+// This allows building cluster-wide dashboards without scraping the sidecars, whose pods are ephemeral.
+// This controller only runs on the cluster's leader.
+type WorkflowMetricsAggregator struct {
+	interval       time.Duration
+	watchNamespace string
+
+	// reader must not be the cached client of the manager, which filters out the pods with a sidecar.
+	reader     client.Reader
+	httpClient *http.Client
+
+	// seen are the apps whose metrics were recorded, so that their metrics are reset when all their pods are gone.
+	seen map[workflowAppKey]struct{}
+	// failedExecutions are the counters of failed executions last read from each pod, so that only their increase is added to the aggregated counter.
+	failedExecutions map[types.UID]int64
+	// collected is true after the first collection, whose counters of failed executions are only used as the baseline.
+	collected bool
+}
+
+// NeedLeaderElection makes it so the controller runs on the leader node only.
+// Implements sigs.k8s.io/controller-runtime/pkg/manager.LeaderElectionRunnable .
+func (wa *WorkflowMetricsAggregator) NeedLeaderElection() bool {
+	return true
+}
+
+// Start the controller. This method blocks until the context is canceled.
+// Implements sigs.k8s.io/controller-runtime/pkg/manager.Runnable .
+func (wa *WorkflowMetricsAggregator) Start(ctx context.Context) error {
+	log.Infof("Workflow metrics aggregator started with interval %v", wa.interval)
+	if wa.httpClient == nil {
+		wa.httpClient = &http.Client{Timeout: workflowMetricsScrapeTimeout}
+	}
+
+	t := time.NewTicker(wa.interval)
+	defer t.Stop()
+	for {
+		wa.collect(ctx)
+
+		select {
+		case <-ctx.Done():
+			log.Infof("Workflow metrics aggregator stopped")
+			return nil
+		case <-t.C:
+		}
+	}
+}
+
+// collect collects the workflow metrics of the running sidecars and records them.
+func (wa *WorkflowMetricsAggregator) collect(ctx context.Context) {
+	req, err := labels.NewRequirement(injectorConsts.SidecarInjectedLabel, selection.Exists, nil)
+	if err != nil {
+		log.Errorf("Failed to create the selector of the pods with a sidecar: %v", err)
+		return
+	}
+	podList := &corev1.PodList{}
+	err = wa.reader.List(ctx, podList, &client.ListOptions{
+		LabelSelector: labels.NewSelector().Add(*req),
+		Namespace:     wa.watchNamespace,
+	})
+	if err != nil {
+		log.Errorf("Failed to list the pods to collect the workflow metrics: %v", err)
+		return
+	}
+
+	pods := make([]*corev1.Pod, 0, len(podList.Items))
+	for i := range podList.Items {
+		pod := &podList.Items[i]
+		if pod.Labels[injectorConsts.SidecarAppIDLabel] == "" || pod.Status.Phase != corev1.PodRunning || pod.Status.PodIP == "" {
+			continue
+		}
+		pods = append(pods, pod)
+	}
+
+	// The sidecars are scraped concurrently, so that a collection doesn't take longer than the interval when there are many pods
+	results := make([]*sidecarWorkflowMetrics, len(pods))
+	var wg sync.WaitGroup
+	sem := make(chan struct{}, workflowMetricsScrapeConcurrency)
+	for i, pod := range pods {
+		wg.Add(1)
+		sem <- struct{}{}
+		go func(i int, pod *corev1.Pod) {
+			defer func() {
+				<-sem
+				wg.Done()
+			}()
+			res, err := wa.scrapeSidecar(ctx, pod)
+			if err != nil {
+				log.Debugf("Failed to collect the workflow metrics of pod %s/%s: %v", pod.Namespace, pod.Name, err)
+				return
+			}
+			results[i] = res
+		}(i, pod)
+	}
+	wg.Wait()
+
+	apps := map[workflowAppKey]*workflowAppMetrics{}
+	failedExecutions := make(map[types.UID]int64, len(pods))
+	for i, pod := range pods {
+		res := results[i]
+		prevFailed, hasPrevFailed := wa.failedExecutions[pod.UID]
+		if res == nil {
+			if hasPrevFailed {
+				failedExecutions[pod.UID] = prevFailed
+			}
+			continue
+		}
+
+		key := workflowAppKey{namespace: pod.Namespace, appID: pod.Labels[injectorConsts.SidecarAppIDLabel]}
+		m, ok := apps[key]
+		if !ok {
+			m = &workflowAppMetrics{}
+			apps[key] = m
+		}
+		m.sidecars++
+		if res.hasActiveInstances {
+			m.activeInstances += res.activeInstances
+			m.activeSidecars++
+		}
+
+		switch {
+		case !res.hasFailedExecutions:
+			if hasPrevFailed {
+				failedExecutions[pod.UID] = prevFailed
+			}
+			continue
+		case !hasPrevFailed && !wa.collected:
+			// The failures of the pods that were already running were counted before the operator became the leader
+		case !hasPrevFailed:
+			m.newFailedExecutions += res.failedExecutions
+		case res.failedExecutions < prevFailed:
+			// The sidecar restarted, and its counter was reset
+			m.newFailedExecutions += res.failedExecutions
+		default:
+			m.newFailedExecutions += res.failedExecutions - prevFailed
+		}
+		failedExecutions[pod.UID] = res.failedExecutions
+	}
+	wa.failedExecutions = failedExecutions
+	wa.collected = true
+
+	for key, m := range apps {
+		monitoring.RecordWorkflowMetrics(key.appID, key.namespace, m.activeInstances, m.activeSidecars, m.sidecars)
+		if m.newFailedExecutions > 0 {
+			monitoring.RecordWorkflowFailedExecutions(key.appID, key.namespace, m.newFailedExecutions)
+		}
+	}
+	// Apps without running sidecars are reset, otherwise their last values would be reported forever
+	for key := range wa.seen {
+		if _, ok := apps[key]; !ok {
+			monitoring.RecordWorkflowMetrics(key.appID, key.namespace, 0, 0, 0)
+		}
+	}
+	wa.seen = make(map[workflowAppKey]struct{}, len(apps))
+	for key := range apps {
+		wa.seen[key] = struct{}{}
+	}
+}
+
+// scrapeSidecar returns the workflow metrics of the sidecar of the pod.
+// The failed executions are read from the metrics endpoint, and the active instances from the metadata API.
+// The HTTP API of the sidecars listens on localhost unless dapr.io/sidecar-listen-addresses is set, so the active instances are collected only if it listens on the IP of the pod.
+// An error is returned only if neither could be read.
+func (wa *WorkflowMetricsAggregator) scrapeSidecar(ctx context.Context, pod *corev1.Pod) (*sidecarWorkflowMetrics, error) {
+	httpPort, metricsPort := sidecarPorts(pod)
+	if !sidecarListensOnPodIP(pod) {
+		httpPort = 0
+	}
+
+	if httpPort == 0 && metricsPort == 0 {
+		return nil, fmt.Errorf("container %s has no port %s, and its port %s isn't reachable", injectorConsts.SidecarContainerName, injectorConsts.SidecarMetricsPortName, injectorConsts.SidecarHTTPPortName)
+	}
+
+	var (
+		res  sidecarWorkflowMetrics
+		err  error
+		errs []error
+	)
+	if metricsPort != 0 {
+		res.failedExecutions, err = wa.scrapeFailedExecutions(ctx, "http://"+net.JoinHostPort(pod.Status.PodIP, strconv.Itoa(int(metricsPort)))+"/metrics")
+		if err != nil {
+			errs = append(errs, fmt.Errorf("failed to get the metrics: %w", err))
+		} else {
+			res.hasFailedExecutions = true
+		}
+	}
+	if httpPort != 0 {
+		res.activeInstances, err = wa.getActiveInstances(ctx, net.JoinHostPort(pod.Status.PodIP, strconv.Itoa(int(httpPort))))
+		if err != nil {
+			errs = append(errs, fmt.Errorf("failed to get the metadata: %w", err))
+		} else {
+			res.hasActiveInstances = true
+		}
+	}
+
+	if !res.hasFailedExecutions && !res.hasActiveInstances {
+		return nil, errors.Join(errs...)
+	}
+	if len(errs) > 0 {
+		log.Debugf("Collected the workflow metrics of pod %s/%s partially: %v", pod.Namespace, pod.Name, errors.Join(errs...))
+	}
+	return &res, nil
+}
+
+// getActiveInstances returns the number of active workflow instances in the metadata of the sidecar at the address.
+func (wa *WorkflowMetricsAggregator) getActiveInstances(ctx context.Context, address string) (int64, error) {
+	adminClient, err := admin.New(address, admin.WithHTTPClient(wa.httpClient))
+	if err != nil {
+		return 0, err
+	}
+	md, err := adminClient.GetMetadata(ctx)
+	if err != nil {
+		return 0, err
+	}
+
+	var active int64
+	for _, a := range md.ActorRuntime.ActiveActors {
+		if strings.HasPrefix(a.Type, workflowActorTypePrefix) && strings.HasSuffix(a.Type, workflowActorTypeSuffix) {
+			active += int64(a.Count)
+		}
+	}
+	return active, nil
+}
+
+// scrapeFailedExecutions returns the number of failed workflow executions in the metrics at the URL.
+func (wa *WorkflowMetricsAggregator) scrapeFailedExecutions(ctx context.Context, url string) (int64, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return 0, err
+	}
+	res, err := wa.httpClient.Do(req)
+	if err != nil {
+		return 0, err
+	}
+	defer res.Body.Close()
+	if res.StatusCode != http.StatusOK {
+		_, _ = io.Copy(io.Discard, res.Body)
+		return 0, fmt.Errorf("metrics endpoint responded with status code %d", res.StatusCode)
+	}
+	return parseFailedWorkflowExecutions(res.Body)
+}
+
+// parseFailedWorkflowExecutions returns the number of failed workflow executions in metrics in the Prometheus text format.
+// Activity executions and the executions that failed with a recoverable error are not counted.
+func parseFailedWorkflowExecutions(r io.Reader) (int64, error) {
+	parser := expfmt.TextParser{}
+	families, err := parser.TextToMetricFamilies(r)
+	if err != nil {
+		return 0, err
+	}
+
+	family, ok := families[workflowExecutionCountMetric]
+	if !ok {
+		return 0, nil
+	}
+	var failed float64
+	for _, m := range family.GetMetric() {
+		var isWorkflow, isFailed bool
+		for _, l := range m.GetLabel() {
+			switch l.GetName() {
+			case "type":
+				isWorkflow = l.GetValue() == "workflow"
+			case "status":
+				isFailed = l.GetValue() == "failed"
+			}
+		}
+		if isWorkflow && isFailed {
+			failed += m.GetCounter().GetValue()
+		}
+	}
+	return int64(failed), nil
+}
+
+// sidecarListensOnPodIP returns true if the HTTP API of the sidecar of the pod listens on the IP of the pod, so that it's reachable from the operator.
+func sidecarListensOnPodIP(pod *corev1.Pod) bool {
+	for _, c := range pod.Spec.Containers {
+		if c.Name != injectorConsts.SidecarContainerName {
+			continue
+		}
+		for i, arg := range c.Args {
+			var addresses string
+			switch {
+			case arg == sidecarListenAddressesArg && i+1 < len(c.Args):
+				addresses = c.Args[i+1]
+			case strings.HasPrefix(arg, sidecarListenAddressesArg+"="):
+				addresses = strings.TrimPrefix(arg, sidecarListenAddressesArg+"=")
+			default:
+				continue
+			}
+			for _, address := range strings.Split(addresses, ",") {
+				host := strings.Trim(strings.TrimSpace(address), "[]")
+				if ip := net.ParseIP(host); host == pod.Status.PodIP || (ip != nil && ip.IsUnspecified()) {
+					return true
+				}
+			}
+			return false
+		}
+	}
+	return false
+}
+
+// sidecarPorts returns the ports of the HTTP API and of the metrics of the sidecar container of the pod, or 0 if it doesn't have them.
+func sidecarPorts(pod *corev1.Pod) (httpPort int32, metricsPort int32) {
+	for _, c := range pod.Spec.Containers {
+		if c.Name != injectorConsts.SidecarContainerName {
+			continue
+		}
+		for _, p := range c.Ports {
+			switch p.Name {
+			case injectorConsts.SidecarHTTPPortName:
+				httpPort = p.ContainerPort
+			case injectorConsts.SidecarMetricsPortName:
+				metricsPort = p.ContainerPort
+			}
+		}
+	}
+	return httpPort, metricsPort
+}
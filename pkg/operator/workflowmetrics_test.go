@@ -0,0 +1,246 @@
+package operator
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"sync/atomic"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	injectorConsts "github.com/dapr/dapr/pkg/injector/consts"
+)
+
+const testSidecarMetrics = `# HELP dapr_runtime_workflow_execution_count The number of successful/failed workflow and activity executions.
+# TYPE dapr_runtime_workflow_execution_count counter
+dapr_runtime_workflow_execution_count{app_id="myapp",namespace="default",status="failed",type="workflow"} 3
+dapr_runtime_workflow_execution_count{app_id="myapp",namespace="default",status="success",type="workflow"} 10
+dapr_runtime_workflow_execution_count{app_id="myapp",namespace="default",status="failed",type="activity"} 5
+dapr_runtime_workflow_execution_count{app_id="myapp",namespace="default",status="recoverable",type="workflow"} 2
+`
+
+func TestParseFailedWorkflowExecutions(t *testing.T) {
+	t.Run("counts the failed workflow executions", func(t *testing.T) {
+		failed, err := parseFailedWorkflowExecutions(strings.NewReader(testSidecarMetrics))
+		require.NoError(t, err)
+		assert.Equal(t, int64(3), failed)
+	})
+
+	t.Run("no workflow metrics", func(t *testing.T) {
+		failed, err := parseFailedWorkflowExecutions(strings.NewReader("# TYPE other counter\nother 1\n"))
+		require.NoError(t, err)
+		assert.Equal(t, int64(0), failed)
+	})
+
+	t.Run("invalid metrics", func(t *testing.T) {
+		_, err := parseFailedWorkflowExecutions(strings.NewReader("not metrics {"))
+		require.Error(t, err)
+	})
+}
+
+func TestWorkflowMetricsAggregatorScrapeSidecar(t *testing.T) {
+	metadataSrv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/v1.0/metadata", r.URL.Path)
+		w.Write([]byte(`{"id":"myapp","actorRuntime":{"activeActors":[` +
+			`{"type":"dapr.internal.default.myapp.workflow","count":4},` +
+			`{"type":"dapr.internal.default.myapp.activity","count":7},` +
+			`{"type":"myactor","count":2}]}}`))
+	}))
+	defer metadataSrv.Close()
+	metricsSrv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(testSidecarMetrics))
+	}))
+	defer metricsSrv.Close()
+
+	wa := &WorkflowMetricsAggregator{httpClient: http.DefaultClient}
+
+	t.Run("metadata and metrics", func(t *testing.T) {
+		pod := createSidecarPod(t, "pod-1", metadataSrv.URL, metricsSrv.URL)
+		res, err := wa.scrapeSidecar(context.Background(), pod)
+		require.NoError(t, err)
+		assert.Equal(t, &sidecarWorkflowMetrics{
+			activeInstances:     4,
+			hasActiveInstances:  true,
+			failedExecutions:    3,
+			hasFailedExecutions: true,
+		}, res)
+	})
+
+	t.Run("HTTP API listening on localhost", func(t *testing.T) {
+		pod := createSidecarPod(t, "pod-1", metadataSrv.URL, metricsSrv.URL)
+		pod.Spec.Containers[0].Args = []string{"--dapr-listen-addresses", "localhost"}
+		res, err := wa.scrapeSidecar(context.Background(), pod)
+		require.NoError(t, err)
+		assert.False(t, res.hasActiveInstances)
+		assert.Equal(t, int64(3), res.failedExecutions)
+	})
+
+	t.Run("metadata not reachable", func(t *testing.T) {
+		closed := httptest.NewServer(http.NotFoundHandler())
+		closed.Close()
+		pod := createSidecarPod(t, "pod-1", closed.URL, metricsSrv.URL)
+		res, err := wa.scrapeSidecar(context.Background(), pod)
+		require.NoError(t, err)
+		assert.False(t, res.hasActiveInstances)
+		assert.True(t, res.hasFailedExecutions)
+		assert.Equal(t, int64(3), res.failedExecutions)
+	})
+
+	t.Run("sidecar not reachable", func(t *testing.T) {
+		closed := httptest.NewServer(http.NotFoundHandler())
+		closed.Close()
+		pod := createSidecarPod(t, "pod-1", closed.URL, closed.URL)
+		_, err := wa.scrapeSidecar(context.Background(), pod)
+		require.Error(t, err)
+	})
+
+	t.Run("no sidecar ports", func(t *testing.T) {
+		pod := createSidecarPod(t, "pod-1", metadataSrv.URL, metricsSrv.URL)
+		pod.Spec.Containers[0].Ports = nil
+		_, err := wa.scrapeSidecar(context.Background(), pod)
+		require.Error(t, err)
+	})
+}
+
+func TestWorkflowMetricsAggregatorCollect(t *testing.T) {
+	metricsSrv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(testSidecarMetrics))
+	}))
+	defer metricsSrv.Close()
+
+	pod1 := createSidecarPod(t, "pod-1", metricsSrv.URL, metricsSrv.URL)
+	pod2 := createSidecarPod(t, "pod-2", metricsSrv.URL, metricsSrv.URL)
+	pending := createSidecarPod(t, "pod-3", metricsSrv.URL, metricsSrv.URL)
+	pending.Status.Phase = corev1.PodPending
+	noSidecar := createSidecarPod(t, "pod-4", metricsSrv.URL, metricsSrv.URL)
+	delete(noSidecar.Labels, injectorConsts.SidecarInjectedLabel)
+
+	cl := fake.NewClientBuilder().WithObjects(pod1, pod2, pending, noSidecar).Build()
+	wa := &WorkflowMetricsAggregator{
+		reader:     cl,
+		httpClient: http.DefaultClient,
+	}
+	wa.collect(context.Background())
+
+	// The metadata API isn't served, so only the metrics of the two running pods with a sidecar are collected
+	assert.Equal(t, map[workflowAppKey]struct{}{
+		{namespace: "default", appID: "myapp"}: {},
+	}, wa.seen)
+	assert.Equal(t, map[types.UID]int64{pod1.UID: 3, pod2.UID: 3}, wa.failedExecutions)
+	assert.True(t, wa.collected)
+
+	// Apps without pods are reset
+	require.NoError(t, cl.Delete(context.Background(), pod1))
+	require.NoError(t, cl.Delete(context.Background(), pod2))
+	wa.collect(context.Background())
+	assert.Empty(t, wa.seen)
+}
+
+func TestSidecarListensOnPodIP(t *testing.T) {
+	tests := map[string]struct {
+		args []string
+		exp  bool
+	}{
+		"no listen addresses":    {args: nil, exp: false},
+		"localhost":              {args: []string{"--dapr-listen-addresses", "127.0.0.1,[::1]"}, exp: false},
+		"all interfaces":         {args: []string{"--dapr-listen-addresses", "0.0.0.0"}, exp: true},
+		"all IPv6 interfaces":    {args: []string{"--dapr-listen-addresses=[::]"}, exp: true},
+		"pod IP":                 {args: []string{"--app-id", "myapp", "--dapr-listen-addresses", "127.0.0.1,10.0.0.1"}, exp: true},
+		"missing argument value": {args: []string{"--dapr-listen-addresses"}, exp: false},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			pod := &corev1.Pod{
+				Spec: corev1.PodSpec{
+					Containers: []corev1.Container{{Name: injectorConsts.SidecarContainerName, Args: tc.args}},
+				},
+				Status: corev1.PodStatus{PodIP: "10.0.0.1"},
+			}
+			assert.Equal(t, tc.exp, sidecarListensOnPodIP(pod))
+		})
+	}
+}
+
+func TestWorkflowMetricsAggregatorFailedExecutions(t *testing.T) {
+	var failed atomic.Int64
+	metricsSrv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprintf(w, "dapr_runtime_workflow_execution_count{status=\"failed\",type=\"workflow\"} %d\n", failed.Load())
+	}))
+	defer metricsSrv.Close()
+
+	pod := createSidecarPod(t, "pod-1", metricsSrv.URL, metricsSrv.URL)
+	cl := fake.NewClientBuilder().WithObjects(pod).Build()
+	wa := &WorkflowMetricsAggregator{
+		reader:     cl,
+		httpClient: http.DefaultClient,
+	}
+
+	// The counter of the first collection is the baseline
+	failed.Store(5)
+	wa.collect(context.Background())
+	assert.Equal(t, int64(5), wa.failedExecutions[pod.UID])
+
+	failed.Store(8)
+	wa.collect(context.Background())
+	assert.Equal(t, int64(8), wa.failedExecutions[pod.UID])
+
+	// The counter of a restarted sidecar is reset
+	failed.Store(2)
+	wa.collect(context.Background())
+	assert.Equal(t, int64(2), wa.failedExecutions[pod.UID])
+
+	// Pods that are gone are forgotten
+	require.NoError(t, cl.Delete(context.Background(), pod))
+	wa.collect(context.Background())
+	assert.Empty(t, wa.failedExecutions)
+}
+
+// createSidecarPod returns a running pod with a sidecar whose HTTP API and metrics endpoint are served at the URLs.
+func createSidecarPod(t *testing.T, name string, httpURL string, metricsURL string) *corev1.Pod {
+	t.Helper()
+
+	port := func(u string) int32 {
+		_, p, err := net.SplitHostPort(strings.TrimPrefix(u, "http://"))
+		require.NoError(t, err)
+		n, err := strconv.ParseInt(p, 10, 32)
+		require.NoError(t, err)
+		return int32(n)
+	}
+
+	return &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: "default",
+			UID:       types.UID(name),
+			Labels: map[string]string{
+				injectorConsts.SidecarInjectedLabel: "true",
+				injectorConsts.SidecarAppIDLabel:    "myapp",
+			},
+		},
+		Spec: corev1.PodSpec{
+			Containers: []corev1.Container{{
+				Name: injectorConsts.SidecarContainerName,
+				Args: []string{"--dapr-listen-addresses", "127.0.0.1"},
+				Ports: []corev1.ContainerPort{
+					{Name: injectorConsts.SidecarHTTPPortName, ContainerPort: port(httpURL)},
+					{Name: injectorConsts.SidecarMetricsPortName, ContainerPort: port(metricsURL)},
+				},
+			}},
+		},
+		Status: corev1.PodStatus{
+			Phase: corev1.PodRunning,
+			PodIP: "127.0.0.1",
+		},
+	}
+}
@@ -50,6 +50,8 @@ type Host struct {
 	Port  int64
 	Load  int64
 	AppID string
+	// ActorVersion is the version of the app running on the host, if any.
+	ActorVersion string
 }
 
 // Consistent represents a data structure for consistent hashing.
@@ -108,6 +110,11 @@ func (c *Consistent) ReadInternals(reader func(map[uint64]string, []uint64, map[
 
 // Add adds a host with port to the table.
 func (c *Consistent) Add(host, id string, port int64) bool {
+	return c.AddWithVersion(host, id, "", port)
+}
+
+// AddWithVersion adds a host with port to the table, recording the version of the app running on the host.
+func (c *Consistent) AddWithVersion(host, id, actorVersion string, port int64) bool {
 	c.Lock()
 	defer c.Unlock()
 
@@ -115,7 +122,7 @@ func (c *Consistent) Add(host, id string, port int64) bool {
 		return true
 	}
 
-	c.loadMap[host] = &Host{Name: host, AppID: id, Load: 0, Port: port}
+	c.loadMap[host] = &Host{Name: host, AppID: id, Load: 0, Port: port, ActorVersion: actorVersion}
 	for i := 0; i < replicationFactor; i++ {
 		h := c.hash(fmt.Sprintf("%s%d", host, i))
 		c.hosts[h] = host
@@ -157,6 +164,28 @@ func (c *Consistent) GetHost(key string) (*Host, error) {
 	return c.loadMap[h], nil
 }
 
+// FilterVersion returns a new consistent hash that contains only the hosts running the given version of the app.
+// Hosts keep their positions in the ring, so the result doesn't depend on which hosts were filtered out.
+func (c *Consistent) FilterVersion(actorVersion string) *Consistent {
+	c.RLock()
+	defer c.RUnlock()
+
+	res := NewConsistentHash()
+	for name, h := range c.loadMap {
+		if h.ActorVersion == actorVersion {
+			res.loadMap[name] = &Host{Name: h.Name, AppID: h.AppID, Load: h.Load, Port: h.Port, ActorVersion: h.ActorVersion}
+		}
+	}
+	for _, k := range c.sortedSet {
+		if _, ok := res.loadMap[c.hosts[k]]; ok {
+			res.hosts[k] = c.hosts[k]
+			res.sortedSet = append(res.sortedSet, k)
+		}
+	}
+
+	return res
+}
+
 // GetLeast uses Consistent Hashing With Bounded loads
 //
 // https://research.googleblog.com/2017/04/consistent-hashing-with-bounded-loads.html
@@ -72,3 +72,29 @@ func TestSetReplicationFactor(t *testing.T) {
 
 	assert.Equal(t, f, replicationFactor)
 }
+
+func TestFilterVersion(t *testing.T) {
+	SetReplicationFactor(10)
+
+	h := NewConsistentHash()
+	h.AddWithVersion("node1", "app", "v1", 1)
+	h.AddWithVersion("node2", "app", "v1", 1)
+	h.AddWithVersion("node3", "app", "v2", 1)
+
+	v1 := h.FilterVersion("v1")
+	for i := 0; i < 100; i++ {
+		host, err := v1.GetHost(strconv.Itoa(i))
+		require.NoError(t, err)
+		assert.Equal(t, "v1", host.ActorVersion)
+
+		// Keys on hosts running the version stay on the same host
+		orig, err := h.GetHost(strconv.Itoa(i))
+		require.NoError(t, err)
+		if orig.ActorVersion == "v1" {
+			assert.Equal(t, orig.Name, host.Name)
+		}
+	}
+
+	_, err := h.FilterVersion("v3").GetHost("1")
+	require.ErrorIs(t, err, ErrNoHosts)
+}
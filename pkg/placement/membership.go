@@ -364,6 +364,7 @@ func (p *Service) performTablesUpdate(ctx context.Context, hosts []placementGRPC
 		if p.maxAPILevel != nil && newTable.GetApiLevel() > *p.maxAPILevel {
 			newTable.ApiLevel = *p.maxAPILevel
 		}
+		p.actorVersionRouting.apply(newTable)
 	}
 
 	ctx, cancel := context.WithTimeout(ctx, 15*time.Second)
@@ -105,6 +105,8 @@ type Service struct {
 	maxAPILevel *uint32
 	// Minimum API level to return
 	minAPILevel uint32
+	// actorVersionRouting is the routing of actor types to the versions of the apps hosting them, disseminated with the tables.
+	actorVersionRouting ActorVersionRouting
 
 	// faultyHostDetectDuration
 	faultyHostDetectDuration *atomic.Int64
@@ -133,6 +135,8 @@ type PlacementServiceOpts struct {
 	MaxAPILevel *uint32
 	MinAPILevel uint32
 	SecProvider security.Provider
+	// Routing of actor types to the versions of the apps hosting them.
+	ActorVersionRouting ActorVersionRouting
 }
 
 // NewPlacementService returns a new placement service.
@@ -147,6 +151,7 @@ func NewPlacementService(opts PlacementServiceOpts) *Service {
 		raftNode:                 opts.RaftNode,
 		maxAPILevel:              opts.MaxAPILevel,
 		minAPILevel:              opts.MinAPILevel,
+		actorVersionRouting:      opts.ActorVersionRouting,
 		clock:                    &clock.RealClock{},
 		closedCh:                 make(chan struct{}),
 		sec:                      opts.SecProvider,
@@ -283,7 +288,7 @@ func (p *Service) ReportDaprStatus(stream placementv1pb.Placement_ReportDaprStat
 			// the existing member info is unmatched with the incoming member info.
 			upsertRequired := true
 			if m, ok := members[req.GetName()]; ok {
-				if m.AppID == req.GetId() && m.Name == req.GetName() && cmp.Equal(m.Entities, req.GetEntities()) {
+				if m.AppID == req.GetId() && m.Name == req.GetName() && m.ActorVersion == req.GetActorVersion() && cmp.Equal(m.Entities, req.GetEntities()) {
 					upsertRequired = false
 				}
 			}
@@ -292,11 +297,12 @@ func (p *Service) ReportDaprStatus(stream placementv1pb.Placement_ReportDaprStat
 				p.membershipCh <- hostMemberChange{
 					cmdType: raft.MemberUpsert,
 					host: raft.DaprHostMember{
-						Name:      req.GetName(),
-						AppID:     req.GetId(),
-						Entities:  req.GetEntities(),
-						UpdatedAt: now.UnixNano(),
-						APILevel:  req.GetApiLevel(),
+						Name:         req.GetName(),
+						AppID:        req.GetId(),
+						Entities:     req.GetEntities(),
+						UpdatedAt:    now.UnixNano(),
+						APILevel:     req.GetApiLevel(),
+						ActorVersion: req.GetActorVersion(),
 					},
 				}
 				log.Debugf("Member changed upserting appid %s with entities %v", req.GetId(), req.GetEntities())
@@ -99,10 +99,11 @@ func (c *FSM) PlacementState() *v1pb.PlacementTables {
 
 			for lk, lv := range loadMap {
 				h := v1pb.Host{
-					Name: lv.Name,
-					Load: lv.Load,
-					Port: lv.Port,
-					Id:   lv.AppID,
+					Name:         lv.Name,
+					Load:         lv.Load,
+					Port:         lv.Port,
+					Id:           lv.AppID,
+					ActorVersion: lv.ActorVersion,
 				}
 				table.LoadMap[lk] = &h
 			}
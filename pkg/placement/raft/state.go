@@ -37,6 +37,9 @@ type DaprHostMember struct {
 
 	// Version of the Actor APIs supported by the Dapr runtime
 	APILevel uint32
+
+	// ActorVersion is the version of the app hosting the actors.
+	ActorVersion string
 }
 
 type DaprHostMemberStateData struct {
@@ -150,11 +153,12 @@ func (s *DaprHostMemberState) clone() *DaprHostMemberState {
 	}
 	for k, v := range s.data.Members {
 		m := &DaprHostMember{
-			Name:      v.Name,
-			AppID:     v.AppID,
-			Entities:  make([]string, len(v.Entities)),
-			UpdatedAt: v.UpdatedAt,
-			APILevel:  v.APILevel,
+			Name:         v.Name,
+			AppID:        v.AppID,
+			Entities:     make([]string, len(v.Entities)),
+			UpdatedAt:    v.UpdatedAt,
+			APILevel:     v.APILevel,
+			ActorVersion: v.ActorVersion,
 		}
 		copy(m.Entities, v.Entities)
 		newMembers.data.Members[k] = m
@@ -169,7 +173,7 @@ func (s *DaprHostMemberState) updateHashingTables(host *DaprHostMember) {
 			s.data.hashingTableMap[e] = hashing.NewConsistentHash()
 		}
 
-		s.data.hashingTableMap[e].Add(host.Name, host.AppID, 0)
+		s.data.hashingTableMap[e].AddWithVersion(host.Name, host.AppID, host.ActorVersion, 0)
 	}
 }
 
@@ -200,7 +204,7 @@ func (s *DaprHostMemberState) upsertMember(host *DaprHostMember) bool {
 
 	if m, ok := s.data.Members[host.Name]; ok {
 		// No need to update consistent hashing table if the same dapr host member exists
-		if m.AppID == host.AppID && m.Name == host.Name && cmp.Equal(m.Entities, host.Entities) {
+		if m.AppID == host.AppID && m.Name == host.Name && m.ActorVersion == host.ActorVersion && cmp.Equal(m.Entities, host.Entities) {
 			m.UpdatedAt = host.UpdatedAt
 			return false
 		}
@@ -211,10 +215,11 @@ func (s *DaprHostMemberState) upsertMember(host *DaprHostMember) bool {
 	}
 
 	s.data.Members[host.Name] = &DaprHostMember{
-		Name:      host.Name,
-		AppID:     host.AppID,
-		UpdatedAt: host.UpdatedAt,
-		APILevel:  host.APILevel,
+		Name:         host.Name,
+		AppID:        host.AppID,
+		UpdatedAt:    host.UpdatedAt,
+		APILevel:     host.APILevel,
+		ActorVersion: host.ActorVersion,
 	}
 
 	// Update hashing table only when host reports actor types
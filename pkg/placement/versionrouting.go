@@ -0,0 +1,101 @@
+/*
+Copyright 2023 The Dapr Authors
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package placement
+
+import (
+	"fmt"
+	"hash/fnv"
+	"sort"
+	"strconv"
+	"strings"
+
+	v1pb "github.com/dapr/dapr/pkg/proto/placement/v1"
+)
+
+// ActorVersionRouting contains the versions of the app that the actors of each type are routed to, with their weights.
+// The placement service is the only source of the routing, which it disseminates with the placement tables, so all the runtimes route actors the same way.
+type ActorVersionRouting map[string][]*v1pb.ActorVersionWeight
+
+// ParseActorVersionRouting parses the routing of actor types to versions of the apps hosting them.
+// Each rule has the format "<actor type>=<version>:<weight>[,<version>:<weight>...]".
+func ParseActorVersionRouting(rules []string) (ActorVersionRouting, error) {
+	if len(rules) == 0 {
+		return nil, nil
+	}
+
+	routing := make(ActorVersionRouting, len(rules))
+	for _, rule := range rules {
+		actorType, weights, ok := strings.Cut(rule, "=")
+		actorType = strings.TrimSpace(actorType)
+		if !ok || actorType == "" {
+			return nil, fmt.Errorf("invalid actor version routing rule '%s': the format is '<actor type>=<version>:<weight>,...'", rule)
+		}
+		if _, ok = routing[actorType]; ok {
+			return nil, fmt.Errorf("duplicate actor version routing rule for actor type '%s'", actorType)
+		}
+
+		var total int
+		for _, w := range strings.Split(weights, ",") {
+			version, weightStr, ok := strings.Cut(w, ":")
+			version = strings.TrimSpace(version)
+			if !ok || version == "" {
+				return nil, fmt.Errorf("invalid weight '%s' in the actor version routing rule for actor type '%s'", w, actorType)
+			}
+			weight, err := strconv.ParseInt(strings.TrimSpace(weightStr), 10, 32)
+			if err != nil || weight < 0 {
+				return nil, fmt.Errorf("invalid weight '%s' in the actor version routing rule for actor type '%s'", w, actorType)
+			}
+			routing[actorType] = append(routing[actorType], &v1pb.ActorVersionWeight{
+				Version: version,
+				Weight:  int32(weight),
+			})
+			total += int(weight)
+		}
+		if total == 0 {
+			return nil, fmt.Errorf("no version has a positive weight in the actor version routing rule for actor type '%s'", actorType)
+		}
+	}
+	return routing, nil
+}
+
+// apply sets the routing in the entries of the placement tables.
+// The routing is included in the version of the tables, so the runtimes update their tables when the routing changes.
+func (r ActorVersionRouting) apply(tables *v1pb.PlacementTables) {
+	if len(r) == 0 || tables == nil {
+		return
+	}
+	for actorType, table := range tables.GetEntries() {
+		table.VersionRouting = r[actorType]
+	}
+	tables.Version += "-" + r.hash()
+}
+
+// hash returns a hash of the routing, which doesn't depend on the order of the actor types.
+func (r ActorVersionRouting) hash() string {
+	actorTypes := make([]string, 0, len(r))
+	for actorType := range r {
+		actorTypes = append(actorTypes, actorType)
+	}
+	sort.Strings(actorTypes)
+
+	h := fnv.New64a()
+	for _, actorType := range actorTypes {
+		h.Write([]byte(actorType))
+		for _, w := range r[actorType] {
+			h.Write([]byte("|" + w.GetVersion() + ":" + strconv.Itoa(int(w.GetWeight()))))
+		}
+		h.Write([]byte{0})
+	}
+	return strconv.FormatUint(h.Sum64(), 16)
+}
@@ -0,0 +1,99 @@
+/*
+Copyright 2023 The Dapr Authors
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package placement
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	v1pb "github.com/dapr/dapr/pkg/proto/placement/v1"
+)
+
+func TestParseActorVersionRouting(t *testing.T) {
+	t.Run("valid rules", func(t *testing.T) {
+		routing, err := ParseActorVersionRouting([]string{"cart=v1:90, v2:10", "order=v3:1"})
+		require.NoError(t, err)
+		require.Len(t, routing, 2)
+		require.Len(t, routing["cart"], 2)
+		assert.Equal(t, "v1", routing["cart"][0].GetVersion())
+		assert.Equal(t, int32(90), routing["cart"][0].GetWeight())
+		assert.Equal(t, "v2", routing["cart"][1].GetVersion())
+		assert.Equal(t, int32(10), routing["cart"][1].GetWeight())
+		require.Len(t, routing["order"], 1)
+		assert.Equal(t, "v3", routing["order"][0].GetVersion())
+	})
+
+	t.Run("no rules", func(t *testing.T) {
+		routing, err := ParseActorVersionRouting(nil)
+		require.NoError(t, err)
+		assert.Empty(t, routing)
+	})
+
+	for name, rule := range map[string]string{
+		"missing actor type": "=v1:1",
+		"missing weights":    "cart",
+		"missing version":    "cart=:1",
+		"invalid weight":     "cart=v1:a",
+		"negative weight":    "cart=v1:-1",
+		"zero total weight":  "cart=v1:0,v2:0",
+	} {
+		t.Run(name, func(t *testing.T) {
+			_, err := ParseActorVersionRouting([]string{rule})
+			require.Error(t, err)
+		})
+	}
+
+	t.Run("duplicate actor type", func(t *testing.T) {
+		_, err := ParseActorVersionRouting([]string{"cart=v1:1", "cart=v2:1"})
+		require.Error(t, err)
+	})
+}
+
+func TestActorVersionRoutingApply(t *testing.T) {
+	newTables := func() *v1pb.PlacementTables {
+		return &v1pb.PlacementTables{
+			Version: "1",
+			Entries: map[string]*v1pb.PlacementTable{
+				"cart":  {},
+				"order": {},
+			},
+		}
+	}
+
+	routing, err := ParseActorVersionRouting([]string{"cart=v1:90,v2:10"})
+	require.NoError(t, err)
+
+	tables := newTables()
+	routing.apply(tables)
+	assert.Len(t, tables.GetEntries()["cart"].GetVersionRouting(), 2)
+	assert.Empty(t, tables.GetEntries()["order"].GetVersionRouting())
+	assert.NotEqual(t, "1", tables.GetVersion())
+
+	t.Run("routing changes update the version of the tables", func(t *testing.T) {
+		other, err := ParseActorVersionRouting([]string{"cart=v1:50,v2:50"})
+		require.NoError(t, err)
+		otherTables := newTables()
+		other.apply(otherTables)
+		assert.NotEqual(t, tables.GetVersion(), otherTables.GetVersion())
+	})
+
+	t.Run("no routing", func(t *testing.T) {
+		var empty ActorVersionRouting
+		emptyTables := newTables()
+		empty.apply(emptyTables)
+		assert.Equal(t, "1", emptyTables.GetVersion())
+	})
+}
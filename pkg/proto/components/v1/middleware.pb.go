@@ -0,0 +1,832 @@
+//
+//Copyright 2023 The Dapr Authors
+//Licensed under the Apache License, Version 2.0 (the "License");
+//you may not use this file except in compliance with the License.
+//You may obtain a copy of the License at
+//http://www.apache.org/licenses/LICENSE-2.0
+//Unless required by applicable law or agreed to in writing, software
+//distributed under the License is distributed on an "AS IS" BASIS,
+//WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//See the License for the specific language governing permissions and
+//limitations under the License.
+
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.28.1
+// 	protoc        v3.21.12
+// source: dapr/proto/components/v1/middleware.proto
+
+package components
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	reflect "reflect"
+	sync "sync"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type HTTPMiddlewareInitRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Metadata *MetadataRequest `protobuf:"bytes,1,opt,name=metadata,proto3" json:"metadata,omitempty"`
+}
+
+func (x *HTTPMiddlewareInitRequest) Reset() {
+	*x = HTTPMiddlewareInitRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_dapr_proto_components_v1_middleware_proto_msgTypes[0]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *HTTPMiddlewareInitRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*HTTPMiddlewareInitRequest) ProtoMessage() {}
+
+func (x *HTTPMiddlewareInitRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_dapr_proto_components_v1_middleware_proto_msgTypes[0]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use HTTPMiddlewareInitRequest.ProtoReflect.Descriptor instead.
+func (*HTTPMiddlewareInitRequest) Descriptor() ([]byte, []int) {
+	return file_dapr_proto_components_v1_middleware_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *HTTPMiddlewareInitRequest) GetMetadata() *MetadataRequest {
+	if x != nil {
+		return x.Metadata
+	}
+	return nil
+}
+
+type HTTPMiddlewareInitResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+}
+
+func (x *HTTPMiddlewareInitResponse) Reset() {
+	*x = HTTPMiddlewareInitResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_dapr_proto_components_v1_middleware_proto_msgTypes[1]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *HTTPMiddlewareInitResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*HTTPMiddlewareInitResponse) ProtoMessage() {}
+
+func (x *HTTPMiddlewareInitResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_dapr_proto_components_v1_middleware_proto_msgTypes[1]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use HTTPMiddlewareInitResponse.ProtoReflect.Descriptor instead.
+func (*HTTPMiddlewareInitResponse) Descriptor() ([]byte, []int) {
+	return file_dapr_proto_components_v1_middleware_proto_rawDescGZIP(), []int{1}
+}
+
+// HTTPHeaderValues holds all values of a single HTTP header.
+type HTTPHeaderValues struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Values []string `protobuf:"bytes,1,rep,name=values,proto3" json:"values,omitempty"`
+}
+
+func (x *HTTPHeaderValues) Reset() {
+	*x = HTTPHeaderValues{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_dapr_proto_components_v1_middleware_proto_msgTypes[2]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *HTTPHeaderValues) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*HTTPHeaderValues) ProtoMessage() {}
+
+func (x *HTTPHeaderValues) ProtoReflect() protoreflect.Message {
+	mi := &file_dapr_proto_components_v1_middleware_proto_msgTypes[2]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use HTTPHeaderValues.ProtoReflect.Descriptor instead.
+func (*HTTPHeaderValues) Descriptor() ([]byte, []int) {
+	return file_dapr_proto_components_v1_middleware_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *HTTPHeaderValues) GetValues() []string {
+	if x != nil {
+		return x.Values
+	}
+	return nil
+}
+
+// HTTPRequestHeaders is the start line and headers of an HTTP request.
+type HTTPRequestHeaders struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// The HTTP method, e.g. GET.
+	Method string `protobuf:"bytes,1,opt,name=method,proto3" json:"method,omitempty"`
+	// The request URI, including the query string.
+	Uri string `protobuf:"bytes,2,opt,name=uri,proto3" json:"uri,omitempty"`
+	// The request headers.
+	Headers map[string]*HTTPHeaderValues `protobuf:"bytes,3,rep,name=headers,proto3" json:"headers,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+	// The network address of the client that sent the request.
+	RemoteAddr string `protobuf:"bytes,4,opt,name=remote_addr,json=remoteAddr,proto3" json:"remote_addr,omitempty"`
+	// The host the request was sent to.
+	Host string `protobuf:"bytes,5,opt,name=host,proto3" json:"host,omitempty"`
+}
+
+func (x *HTTPRequestHeaders) Reset() {
+	*x = HTTPRequestHeaders{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_dapr_proto_components_v1_middleware_proto_msgTypes[3]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *HTTPRequestHeaders) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*HTTPRequestHeaders) ProtoMessage() {}
+
+func (x *HTTPRequestHeaders) ProtoReflect() protoreflect.Message {
+	mi := &file_dapr_proto_components_v1_middleware_proto_msgTypes[3]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use HTTPRequestHeaders.ProtoReflect.Descriptor instead.
+func (*HTTPRequestHeaders) Descriptor() ([]byte, []int) {
+	return file_dapr_proto_components_v1_middleware_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *HTTPRequestHeaders) GetMethod() string {
+	if x != nil {
+		return x.Method
+	}
+	return ""
+}
+
+func (x *HTTPRequestHeaders) GetUri() string {
+	if x != nil {
+		return x.Uri
+	}
+	return ""
+}
+
+func (x *HTTPRequestHeaders) GetHeaders() map[string]*HTTPHeaderValues {
+	if x != nil {
+		return x.Headers
+	}
+	return nil
+}
+
+func (x *HTTPRequestHeaders) GetRemoteAddr() string {
+	if x != nil {
+		return x.RemoteAddr
+	}
+	return ""
+}
+
+func (x *HTTPRequestHeaders) GetHost() string {
+	if x != nil {
+		return x.Host
+	}
+	return ""
+}
+
+// HTTPResponseHeaders is the status code and headers of an HTTP response.
+type HTTPResponseHeaders struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// The HTTP status code.
+	StatusCode int32 `protobuf:"varint,1,opt,name=status_code,json=statusCode,proto3" json:"status_code,omitempty"`
+	// The response headers.
+	Headers map[string]*HTTPHeaderValues `protobuf:"bytes,2,rep,name=headers,proto3" json:"headers,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+}
+
+func (x *HTTPResponseHeaders) Reset() {
+	*x = HTTPResponseHeaders{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_dapr_proto_components_v1_middleware_proto_msgTypes[4]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *HTTPResponseHeaders) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*HTTPResponseHeaders) ProtoMessage() {}
+
+func (x *HTTPResponseHeaders) ProtoReflect() protoreflect.Message {
+	mi := &file_dapr_proto_components_v1_middleware_proto_msgTypes[4]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use HTTPResponseHeaders.ProtoReflect.Descriptor instead.
+func (*HTTPResponseHeaders) Descriptor() ([]byte, []int) {
+	return file_dapr_proto_components_v1_middleware_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *HTTPResponseHeaders) GetStatusCode() int32 {
+	if x != nil {
+		return x.StatusCode
+	}
+	return 0
+}
+
+func (x *HTTPResponseHeaders) GetHeaders() map[string]*HTTPHeaderValues {
+	if x != nil {
+		return x.Headers
+	}
+	return nil
+}
+
+// HTTPBodyChunk is a chunk of a request or response body.
+type HTTPBodyChunk struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Data []byte `protobuf:"bytes,1,opt,name=data,proto3" json:"data,omitempty"`
+}
+
+func (x *HTTPBodyChunk) Reset() {
+	*x = HTTPBodyChunk{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_dapr_proto_components_v1_middleware_proto_msgTypes[5]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *HTTPBodyChunk) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*HTTPBodyChunk) ProtoMessage() {}
+
+func (x *HTTPBodyChunk) ProtoReflect() protoreflect.Message {
+	mi := &file_dapr_proto_components_v1_middleware_proto_msgTypes[5]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use HTTPBodyChunk.ProtoReflect.Descriptor instead.
+func (*HTTPBodyChunk) Descriptor() ([]byte, []int) {
+	return file_dapr_proto_components_v1_middleware_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *HTTPBodyChunk) GetData() []byte {
+	if x != nil {
+		return x.Data
+	}
+	return nil
+}
+
+type HandleHTTPRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Types that are assignable to Message:
+	//	*HandleHTTPRequest_Request
+	//	*HandleHTTPRequest_Body
+	Message isHandleHTTPRequest_Message `protobuf_oneof:"message"`
+}
+
+func (x *HandleHTTPRequest) Reset() {
+	*x = HandleHTTPRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_dapr_proto_components_v1_middleware_proto_msgTypes[6]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *HandleHTTPRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*HandleHTTPRequest) ProtoMessage() {}
+
+func (x *HandleHTTPRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_dapr_proto_components_v1_middleware_proto_msgTypes[6]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use HandleHTTPRequest.ProtoReflect.Descriptor instead.
+func (*HandleHTTPRequest) Descriptor() ([]byte, []int) {
+	return file_dapr_proto_components_v1_middleware_proto_rawDescGZIP(), []int{6}
+}
+
+func (m *HandleHTTPRequest) GetMessage() isHandleHTTPRequest_Message {
+	if m != nil {
+		return m.Message
+	}
+	return nil
+}
+
+func (x *HandleHTTPRequest) GetRequest() *HTTPRequestHeaders {
+	if x, ok := x.GetMessage().(*HandleHTTPRequest_Request); ok {
+		return x.Request
+	}
+	return nil
+}
+
+func (x *HandleHTTPRequest) GetBody() *HTTPBodyChunk {
+	if x, ok := x.GetMessage().(*HandleHTTPRequest_Body); ok {
+		return x.Body
+	}
+	return nil
+}
+
+type isHandleHTTPRequest_Message interface {
+	isHandleHTTPRequest_Message()
+}
+
+type HandleHTTPRequest_Request struct {
+	// The incoming request, always sent first.
+	Request *HTTPRequestHeaders `protobuf:"bytes,1,opt,name=request,proto3,oneof"`
+}
+
+type HandleHTTPRequest_Body struct {
+	// A chunk of the incoming request body.
+	Body *HTTPBodyChunk `protobuf:"bytes,2,opt,name=body,proto3,oneof"`
+}
+
+func (*HandleHTTPRequest_Request) isHandleHTTPRequest_Message() {}
+
+func (*HandleHTTPRequest_Body) isHandleHTTPRequest_Message() {}
+
+type HandleHTTPResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Types that are assignable to Message:
+	//	*HandleHTTPResponse_Next
+	//	*HandleHTTPResponse_Response
+	//	*HandleHTTPResponse_Body
+	Message isHandleHTTPResponse_Message `protobuf_oneof:"message"`
+}
+
+func (x *HandleHTTPResponse) Reset() {
+	*x = HandleHTTPResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_dapr_proto_components_v1_middleware_proto_msgTypes[7]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *HandleHTTPResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*HandleHTTPResponse) ProtoMessage() {}
+
+func (x *HandleHTTPResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_dapr_proto_components_v1_middleware_proto_msgTypes[7]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use HandleHTTPResponse.ProtoReflect.Descriptor instead.
+func (*HandleHTTPResponse) Descriptor() ([]byte, []int) {
+	return file_dapr_proto_components_v1_middleware_proto_rawDescGZIP(), []int{7}
+}
+
+func (m *HandleHTTPResponse) GetMessage() isHandleHTTPResponse_Message {
+	if m != nil {
+		return m.Message
+	}
+	return nil
+}
+
+func (x *HandleHTTPResponse) GetNext() *HTTPRequestHeaders {
+	if x, ok := x.GetMessage().(*HandleHTTPResponse_Next); ok {
+		return x.Next
+	}
+	return nil
+}
+
+func (x *HandleHTTPResponse) GetResponse() *HTTPResponseHeaders {
+	if x, ok := x.GetMessage().(*HandleHTTPResponse_Response); ok {
+		return x.Response
+	}
+	return nil
+}
+
+func (x *HandleHTTPResponse) GetBody() *HTTPBodyChunk {
+	if x, ok := x.GetMessage().(*HandleHTTPResponse_Body); ok {
+		return x.Body
+	}
+	return nil
+}
+
+type isHandleHTTPResponse_Message interface {
+	isHandleHTTPResponse_Message()
+}
+
+type HandleHTTPResponse_Next struct {
+	// Forwards the request to the next handler in the pipeline.
+	Next *HTTPRequestHeaders `protobuf:"bytes,1,opt,name=next,proto3,oneof"`
+}
+
+type HandleHTTPResponse_Response struct {
+	// Writes the response without calling the next handler.
+	Response *HTTPResponseHeaders `protobuf:"bytes,2,opt,name=response,proto3,oneof"`
+}
+
+type HandleHTTPResponse_Body struct {
+	// A chunk of the forwarded request body or of the response body.
+	Body *HTTPBodyChunk `protobuf:"bytes,3,opt,name=body,proto3,oneof"`
+}
+
+func (*HandleHTTPResponse_Next) isHandleHTTPResponse_Message() {}
+
+func (*HandleHTTPResponse_Response) isHandleHTTPResponse_Message() {}
+
+func (*HandleHTTPResponse_Body) isHandleHTTPResponse_Message() {}
+
+var File_dapr_proto_components_v1_middleware_proto protoreflect.FileDescriptor
+
+var file_dapr_proto_components_v1_middleware_proto_rawDesc = []byte{
+	0x0a, 0x29, 0x64, 0x61, 0x70, 0x72, 0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2f, 0x63, 0x6f, 0x6d,
+	0x70, 0x6f, 0x6e, 0x65, 0x6e, 0x74, 0x73, 0x2f, 0x76, 0x31, 0x2f, 0x6d, 0x69, 0x64, 0x64, 0x6c,
+	0x65, 0x77, 0x61, 0x72, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x12, 0x18, 0x64, 0x61, 0x70,
+	0x72, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x63, 0x6f, 0x6d, 0x70, 0x6f, 0x6e, 0x65, 0x6e,
+	0x74, 0x73, 0x2e, 0x76, 0x31, 0x1a, 0x25, 0x64, 0x61, 0x70, 0x72, 0x2f, 0x70, 0x72, 0x6f, 0x74,
+	0x6f, 0x2f, 0x63, 0x6f, 0x6d, 0x70, 0x6f, 0x6e, 0x65, 0x6e, 0x74, 0x73, 0x2f, 0x76, 0x31, 0x2f,
+	0x63, 0x6f, 0x6d, 0x6d, 0x6f, 0x6e, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x22, 0x62, 0x0a, 0x19,
+	0x48, 0x54, 0x54, 0x50, 0x4d, 0x69, 0x64, 0x64, 0x6c, 0x65, 0x77, 0x61, 0x72, 0x65, 0x49, 0x6e,
+	0x69, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x45, 0x0a, 0x08, 0x6d, 0x65, 0x74,
+	0x61, 0x64, 0x61, 0x74, 0x61, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x29, 0x2e, 0x64, 0x61,
+	0x70, 0x72, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x63, 0x6f, 0x6d, 0x70, 0x6f, 0x6e, 0x65,
+	0x6e, 0x74, 0x73, 0x2e, 0x76, 0x31, 0x2e, 0x4d, 0x65, 0x74, 0x61, 0x64, 0x61, 0x74, 0x61, 0x52,
+	0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x52, 0x08, 0x6d, 0x65, 0x74, 0x61, 0x64, 0x61, 0x74, 0x61,
+	0x22, 0x1c, 0x0a, 0x1a, 0x48, 0x54, 0x54, 0x50, 0x4d, 0x69, 0x64, 0x64, 0x6c, 0x65, 0x77, 0x61,
+	0x72, 0x65, 0x49, 0x6e, 0x69, 0x74, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x2a,
+	0x0a, 0x10, 0x48, 0x54, 0x54, 0x50, 0x48, 0x65, 0x61, 0x64, 0x65, 0x72, 0x56, 0x61, 0x6c, 0x75,
+	0x65, 0x73, 0x12, 0x16, 0x0a, 0x06, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x73, 0x18, 0x01, 0x20, 0x03,
+	0x28, 0x09, 0x52, 0x06, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x73, 0x22, 0xb0, 0x02, 0x0a, 0x12, 0x48,
+	0x54, 0x54, 0x50, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x48, 0x65, 0x61, 0x64, 0x65, 0x72,
+	0x73, 0x12, 0x16, 0x0a, 0x06, 0x6d, 0x65, 0x74, 0x68, 0x6f, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x06, 0x6d, 0x65, 0x74, 0x68, 0x6f, 0x64, 0x12, 0x10, 0x0a, 0x03, 0x75, 0x72, 0x69,
+	0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x75, 0x72, 0x69, 0x12, 0x53, 0x0a, 0x07, 0x68,
+	0x65, 0x61, 0x64, 0x65, 0x72, 0x73, 0x18, 0x03, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x39, 0x2e, 0x64,
+	0x61, 0x70, 0x72, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x63, 0x6f, 0x6d, 0x70, 0x6f, 0x6e,
+	0x65, 0x6e, 0x74, 0x73, 0x2e, 0x76, 0x31, 0x2e, 0x48, 0x54, 0x54, 0x50, 0x52, 0x65, 0x71, 0x75,
+	0x65, 0x73, 0x74, 0x48, 0x65, 0x61, 0x64, 0x65, 0x72, 0x73, 0x2e, 0x48, 0x65, 0x61, 0x64, 0x65,
+	0x72, 0x73, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x52, 0x07, 0x68, 0x65, 0x61, 0x64, 0x65, 0x72, 0x73,
+	0x12, 0x1f, 0x0a, 0x0b, 0x72, 0x65, 0x6d, 0x6f, 0x74, 0x65, 0x5f, 0x61, 0x64, 0x64, 0x72, 0x18,
+	0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0a, 0x72, 0x65, 0x6d, 0x6f, 0x74, 0x65, 0x41, 0x64, 0x64,
+	0x72, 0x12, 0x12, 0x0a, 0x04, 0x68, 0x6f, 0x73, 0x74, 0x18, 0x05, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x04, 0x68, 0x6f, 0x73, 0x74, 0x1a, 0x66, 0x0a, 0x0c, 0x48, 0x65, 0x61, 0x64, 0x65, 0x72, 0x73,
+	0x45, 0x6e, 0x74, 0x72, 0x79, 0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18, 0x01, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x03, 0x6b, 0x65, 0x79, 0x12, 0x40, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65,
+	0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x2a, 0x2e, 0x64, 0x61, 0x70, 0x72, 0x2e, 0x70, 0x72,
+	0x6f, 0x74, 0x6f, 0x2e, 0x63, 0x6f, 0x6d, 0x70, 0x6f, 0x6e, 0x65, 0x6e, 0x74, 0x73, 0x2e, 0x76,
+	0x31, 0x2e, 0x48, 0x54, 0x54, 0x50, 0x48, 0x65, 0x61, 0x64, 0x65, 0x72, 0x56, 0x61, 0x6c, 0x75,
+	0x65, 0x73, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x3a, 0x02, 0x38, 0x01, 0x22, 0xf4, 0x01,
+	0x0a, 0x13, 0x48, 0x54, 0x54, 0x50, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x48, 0x65,
+	0x61, 0x64, 0x65, 0x72, 0x73, 0x12, 0x1f, 0x0a, 0x0b, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x5f,
+	0x63, 0x6f, 0x64, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x05, 0x52, 0x0a, 0x73, 0x74, 0x61, 0x74,
+	0x75, 0x73, 0x43, 0x6f, 0x64, 0x65, 0x12, 0x54, 0x0a, 0x07, 0x68, 0x65, 0x61, 0x64, 0x65, 0x72,
+	0x73, 0x18, 0x02, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x3a, 0x2e, 0x64, 0x61, 0x70, 0x72, 0x2e, 0x70,
+	0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x63, 0x6f, 0x6d, 0x70, 0x6f, 0x6e, 0x65, 0x6e, 0x74, 0x73, 0x2e,
+	0x76, 0x31, 0x2e, 0x48, 0x54, 0x54, 0x50, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x48,
+	0x65, 0x61, 0x64, 0x65, 0x72, 0x73, 0x2e, 0x48, 0x65, 0x61, 0x64, 0x65, 0x72, 0x73, 0x45, 0x6e,
+	0x74, 0x72, 0x79, 0x52, 0x07, 0x68, 0x65, 0x61, 0x64, 0x65, 0x72, 0x73, 0x1a, 0x66, 0x0a, 0x0c,
+	0x48, 0x65, 0x61, 0x64, 0x65, 0x72, 0x73, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x12, 0x10, 0x0a, 0x03,
+	0x6b, 0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x6b, 0x65, 0x79, 0x12, 0x40,
+	0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x2a, 0x2e,
+	0x64, 0x61, 0x70, 0x72, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x63, 0x6f, 0x6d, 0x70, 0x6f,
+	0x6e, 0x65, 0x6e, 0x74, 0x73, 0x2e, 0x76, 0x31, 0x2e, 0x48, 0x54, 0x54, 0x50, 0x48, 0x65, 0x61,
+	0x64, 0x65, 0x72, 0x56, 0x61, 0x6c, 0x75, 0x65, 0x73, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65,
+	0x3a, 0x02, 0x38, 0x01, 0x22, 0x23, 0x0a, 0x0d, 0x48, 0x54, 0x54, 0x50, 0x42, 0x6f, 0x64, 0x79,
+	0x43, 0x68, 0x75, 0x6e, 0x6b, 0x12, 0x12, 0x0a, 0x04, 0x64, 0x61, 0x74, 0x61, 0x18, 0x01, 0x20,
+	0x01, 0x28, 0x0c, 0x52, 0x04, 0x64, 0x61, 0x74, 0x61, 0x22, 0xa7, 0x01, 0x0a, 0x11, 0x48, 0x61,
+	0x6e, 0x64, 0x6c, 0x65, 0x48, 0x54, 0x54, 0x50, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12,
+	0x48, 0x0a, 0x07, 0x72, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b,
+	0x32, 0x2c, 0x2e, 0x64, 0x61, 0x70, 0x72, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x63, 0x6f,
+	0x6d, 0x70, 0x6f, 0x6e, 0x65, 0x6e, 0x74, 0x73, 0x2e, 0x76, 0x31, 0x2e, 0x48, 0x54, 0x54, 0x50,
+	0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x48, 0x65, 0x61, 0x64, 0x65, 0x72, 0x73, 0x48, 0x00,
+	0x52, 0x07, 0x72, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x3d, 0x0a, 0x04, 0x62, 0x6f, 0x64,
+	0x79, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x27, 0x2e, 0x64, 0x61, 0x70, 0x72, 0x2e, 0x70,
+	0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x63, 0x6f, 0x6d, 0x70, 0x6f, 0x6e, 0x65, 0x6e, 0x74, 0x73, 0x2e,
+	0x76, 0x31, 0x2e, 0x48, 0x54, 0x54, 0x50, 0x42, 0x6f, 0x64, 0x79, 0x43, 0x68, 0x75, 0x6e, 0x6b,
+	0x48, 0x00, 0x52, 0x04, 0x62, 0x6f, 0x64, 0x79, 0x42, 0x09, 0x0a, 0x07, 0x6d, 0x65, 0x73, 0x73,
+	0x61, 0x67, 0x65, 0x22, 0xef, 0x01, 0x0a, 0x12, 0x48, 0x61, 0x6e, 0x64, 0x6c, 0x65, 0x48, 0x54,
+	0x54, 0x50, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x42, 0x0a, 0x04, 0x6e, 0x65,
+	0x78, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x2c, 0x2e, 0x64, 0x61, 0x70, 0x72, 0x2e,
+	0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x63, 0x6f, 0x6d, 0x70, 0x6f, 0x6e, 0x65, 0x6e, 0x74, 0x73,
+	0x2e, 0x76, 0x31, 0x2e, 0x48, 0x54, 0x54, 0x50, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x48,
+	0x65, 0x61, 0x64, 0x65, 0x72, 0x73, 0x48, 0x00, 0x52, 0x04, 0x6e, 0x65, 0x78, 0x74, 0x12, 0x4b,
+	0x0a, 0x08, 0x72, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b,
+	0x32, 0x2d, 0x2e, 0x64, 0x61, 0x70, 0x72, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x63, 0x6f,
+	0x6d, 0x70, 0x6f, 0x6e, 0x65, 0x6e, 0x74, 0x73, 0x2e, 0x76, 0x31, 0x2e, 0x48, 0x54, 0x54, 0x50,
+	0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x48, 0x65, 0x61, 0x64, 0x65, 0x72, 0x73, 0x48,
+	0x00, 0x52, 0x08, 0x72, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x3d, 0x0a, 0x04, 0x62,
+	0x6f, 0x64, 0x79, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x27, 0x2e, 0x64, 0x61, 0x70, 0x72,
+	0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x63, 0x6f, 0x6d, 0x70, 0x6f, 0x6e, 0x65, 0x6e, 0x74,
+	0x73, 0x2e, 0x76, 0x31, 0x2e, 0x48, 0x54, 0x54, 0x50, 0x42, 0x6f, 0x64, 0x79, 0x43, 0x68, 0x75,
+	0x6e, 0x6b, 0x48, 0x00, 0x52, 0x04, 0x62, 0x6f, 0x64, 0x79, 0x42, 0x09, 0x0a, 0x07, 0x6d, 0x65,
+	0x73, 0x73, 0x61, 0x67, 0x65, 0x32, 0xc9, 0x02, 0x0a, 0x0e, 0x48, 0x54, 0x54, 0x50, 0x4d, 0x69,
+	0x64, 0x64, 0x6c, 0x65, 0x77, 0x61, 0x72, 0x65, 0x12, 0x73, 0x0a, 0x04, 0x49, 0x6e, 0x69, 0x74,
+	0x12, 0x33, 0x2e, 0x64, 0x61, 0x70, 0x72, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x63, 0x6f,
+	0x6d, 0x70, 0x6f, 0x6e, 0x65, 0x6e, 0x74, 0x73, 0x2e, 0x76, 0x31, 0x2e, 0x48, 0x54, 0x54, 0x50,
+	0x4d, 0x69, 0x64, 0x64, 0x6c, 0x65, 0x77, 0x61, 0x72, 0x65, 0x49, 0x6e, 0x69, 0x74, 0x52, 0x65,
+	0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x34, 0x2e, 0x64, 0x61, 0x70, 0x72, 0x2e, 0x70, 0x72, 0x6f,
+	0x74, 0x6f, 0x2e, 0x63, 0x6f, 0x6d, 0x70, 0x6f, 0x6e, 0x65, 0x6e, 0x74, 0x73, 0x2e, 0x76, 0x31,
+	0x2e, 0x48, 0x54, 0x54, 0x50, 0x4d, 0x69, 0x64, 0x64, 0x6c, 0x65, 0x77, 0x61, 0x72, 0x65, 0x49,
+	0x6e, 0x69, 0x74, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x12, 0x69, 0x0a,
+	0x06, 0x48, 0x61, 0x6e, 0x64, 0x6c, 0x65, 0x12, 0x2b, 0x2e, 0x64, 0x61, 0x70, 0x72, 0x2e, 0x70,
+	0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x63, 0x6f, 0x6d, 0x70, 0x6f, 0x6e, 0x65, 0x6e, 0x74, 0x73, 0x2e,
+	0x76, 0x31, 0x2e, 0x48, 0x61, 0x6e, 0x64, 0x6c, 0x65, 0x48, 0x54, 0x54, 0x50, 0x52, 0x65, 0x71,
+	0x75, 0x65, 0x73, 0x74, 0x1a, 0x2c, 0x2e, 0x64, 0x61, 0x70, 0x72, 0x2e, 0x70, 0x72, 0x6f, 0x74,
+	0x6f, 0x2e, 0x63, 0x6f, 0x6d, 0x70, 0x6f, 0x6e, 0x65, 0x6e, 0x74, 0x73, 0x2e, 0x76, 0x31, 0x2e,
+	0x48, 0x61, 0x6e, 0x64, 0x6c, 0x65, 0x48, 0x54, 0x54, 0x50, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e,
+	0x73, 0x65, 0x22, 0x00, 0x28, 0x01, 0x30, 0x01, 0x12, 0x57, 0x0a, 0x04, 0x50, 0x69, 0x6e, 0x67,
+	0x12, 0x25, 0x2e, 0x64, 0x61, 0x70, 0x72, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x63, 0x6f,
+	0x6d, 0x70, 0x6f, 0x6e, 0x65, 0x6e, 0x74, 0x73, 0x2e, 0x76, 0x31, 0x2e, 0x50, 0x69, 0x6e, 0x67,
+	0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x26, 0x2e, 0x64, 0x61, 0x70, 0x72, 0x2e, 0x70,
+	0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x63, 0x6f, 0x6d, 0x70, 0x6f, 0x6e, 0x65, 0x6e, 0x74, 0x73, 0x2e,
+	0x76, 0x31, 0x2e, 0x50, 0x69, 0x6e, 0x67, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22,
+	0x00, 0x42, 0x39, 0x5a, 0x37, 0x67, 0x69, 0x74, 0x68, 0x75, 0x62, 0x2e, 0x63, 0x6f, 0x6d, 0x2f,
+	0x64, 0x61, 0x70, 0x72, 0x2f, 0x64, 0x61, 0x70, 0x72, 0x2f, 0x70, 0x6b, 0x67, 0x2f, 0x70, 0x72,
+	0x6f, 0x74, 0x6f, 0x2f, 0x63, 0x6f, 0x6d, 0x70, 0x6f, 0x6e, 0x65, 0x6e, 0x74, 0x73, 0x2f, 0x76,
+	0x31, 0x3b, 0x63, 0x6f, 0x6d, 0x70, 0x6f, 0x6e, 0x65, 0x6e, 0x74, 0x73, 0x62, 0x06, 0x70, 0x72,
+	0x6f, 0x74, 0x6f, 0x33,
+}
+
+var (
+	file_dapr_proto_components_v1_middleware_proto_rawDescOnce sync.Once
+	file_dapr_proto_components_v1_middleware_proto_rawDescData = file_dapr_proto_components_v1_middleware_proto_rawDesc
+)
+
+func file_dapr_proto_components_v1_middleware_proto_rawDescGZIP() []byte {
+	file_dapr_proto_components_v1_middleware_proto_rawDescOnce.Do(func() {
+		file_dapr_proto_components_v1_middleware_proto_rawDescData = protoimpl.X.CompressGZIP(file_dapr_proto_components_v1_middleware_proto_rawDescData)
+	})
+	return file_dapr_proto_components_v1_middleware_proto_rawDescData
+}
+
+var file_dapr_proto_components_v1_middleware_proto_msgTypes = make([]protoimpl.MessageInfo, 10)
+var file_dapr_proto_components_v1_middleware_proto_goTypes = []interface{}{
+	(*HTTPMiddlewareInitRequest)(nil),  // 0: dapr.proto.components.v1.HTTPMiddlewareInitRequest
+	(*HTTPMiddlewareInitResponse)(nil), // 1: dapr.proto.components.v1.HTTPMiddlewareInitResponse
+	(*HTTPHeaderValues)(nil),           // 2: dapr.proto.components.v1.HTTPHeaderValues
+	(*HTTPRequestHeaders)(nil),         // 3: dapr.proto.components.v1.HTTPRequestHeaders
+	(*HTTPResponseHeaders)(nil),        // 4: dapr.proto.components.v1.HTTPResponseHeaders
+	(*HTTPBodyChunk)(nil),              // 5: dapr.proto.components.v1.HTTPBodyChunk
+	(*HandleHTTPRequest)(nil),          // 6: dapr.proto.components.v1.HandleHTTPRequest
+	(*HandleHTTPResponse)(nil),         // 7: dapr.proto.components.v1.HandleHTTPResponse
+	nil,                                // 8: dapr.proto.components.v1.HTTPRequestHeaders.HeadersEntry
+	nil,                                // 9: dapr.proto.components.v1.HTTPResponseHeaders.HeadersEntry
+	(*MetadataRequest)(nil),            // 10: dapr.proto.components.v1.MetadataRequest
+	(*PingRequest)(nil),                // 11: dapr.proto.components.v1.PingRequest
+	(*PingResponse)(nil),               // 12: dapr.proto.components.v1.PingResponse
+}
+var file_dapr_proto_components_v1_middleware_proto_depIdxs = []int32{
+	10, // 0: dapr.proto.components.v1.HTTPMiddlewareInitRequest.metadata:type_name -> dapr.proto.components.v1.MetadataRequest
+	8,  // 1: dapr.proto.components.v1.HTTPRequestHeaders.headers:type_name -> dapr.proto.components.v1.HTTPRequestHeaders.HeadersEntry
+	9,  // 2: dapr.proto.components.v1.HTTPResponseHeaders.headers:type_name -> dapr.proto.components.v1.HTTPResponseHeaders.HeadersEntry
+	3,  // 3: dapr.proto.components.v1.HandleHTTPRequest.request:type_name -> dapr.proto.components.v1.HTTPRequestHeaders
+	5,  // 4: dapr.proto.components.v1.HandleHTTPRequest.body:type_name -> dapr.proto.components.v1.HTTPBodyChunk
+	3,  // 5: dapr.proto.components.v1.HandleHTTPResponse.next:type_name -> dapr.proto.components.v1.HTTPRequestHeaders
+	4,  // 6: dapr.proto.components.v1.HandleHTTPResponse.response:type_name -> dapr.proto.components.v1.HTTPResponseHeaders
+	5,  // 7: dapr.proto.components.v1.HandleHTTPResponse.body:type_name -> dapr.proto.components.v1.HTTPBodyChunk
+	2,  // 8: dapr.proto.components.v1.HTTPRequestHeaders.HeadersEntry.value:type_name -> dapr.proto.components.v1.HTTPHeaderValues
+	2,  // 9: dapr.proto.components.v1.HTTPResponseHeaders.HeadersEntry.value:type_name -> dapr.proto.components.v1.HTTPHeaderValues
+	0,  // 10: dapr.proto.components.v1.HTTPMiddleware.Init:input_type -> dapr.proto.components.v1.HTTPMiddlewareInitRequest
+	6,  // 11: dapr.proto.components.v1.HTTPMiddleware.Handle:input_type -> dapr.proto.components.v1.HandleHTTPRequest
+	11, // 12: dapr.proto.components.v1.HTTPMiddleware.Ping:input_type -> dapr.proto.components.v1.PingRequest
+	1,  // 13: dapr.proto.components.v1.HTTPMiddleware.Init:output_type -> dapr.proto.components.v1.HTTPMiddlewareInitResponse
+	7,  // 14: dapr.proto.components.v1.HTTPMiddleware.Handle:output_type -> dapr.proto.components.v1.HandleHTTPResponse
+	12, // 15: dapr.proto.components.v1.HTTPMiddleware.Ping:output_type -> dapr.proto.components.v1.PingResponse
+	13, // [13:16] is the sub-list for method output_type
+	10, // [10:13] is the sub-list for method input_type
+	10, // [10:10] is the sub-list for extension type_name
+	10, // [10:10] is the sub-list for extension extendee
+	0,  // [0:10] is the sub-list for field type_name
+}
+
+func init() { file_dapr_proto_components_v1_middleware_proto_init() }
+func file_dapr_proto_components_v1_middleware_proto_init() {
+	if File_dapr_proto_components_v1_middleware_proto != nil {
+		return
+	}
+	file_dapr_proto_components_v1_common_proto_init()
+	if !protoimpl.UnsafeEnabled {
+		file_dapr_proto_components_v1_middleware_proto_msgTypes[0].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*HTTPMiddlewareInitRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_dapr_proto_components_v1_middleware_proto_msgTypes[1].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*HTTPMiddlewareInitResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_dapr_proto_components_v1_middleware_proto_msgTypes[2].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*HTTPHeaderValues); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_dapr_proto_components_v1_middleware_proto_msgTypes[3].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*HTTPRequestHeaders); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_dapr_proto_components_v1_middleware_proto_msgTypes[4].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*HTTPResponseHeaders); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_dapr_proto_components_v1_middleware_proto_msgTypes[5].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*HTTPBodyChunk); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_dapr_proto_components_v1_middleware_proto_msgTypes[6].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*HandleHTTPRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_dapr_proto_components_v1_middleware_proto_msgTypes[7].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*HandleHTTPResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+	}
+	file_dapr_proto_components_v1_middleware_proto_msgTypes[6].OneofWrappers = []interface{}{
+		(*HandleHTTPRequest_Request)(nil),
+		(*HandleHTTPRequest_Body)(nil),
+	}
+	file_dapr_proto_components_v1_middleware_proto_msgTypes[7].OneofWrappers = []interface{}{
+		(*HandleHTTPResponse_Next)(nil),
+		(*HandleHTTPResponse_Response)(nil),
+		(*HandleHTTPResponse_Body)(nil),
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: file_dapr_proto_components_v1_middleware_proto_rawDesc,
+			NumEnums:      0,
+			NumMessages:   10,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_dapr_proto_components_v1_middleware_proto_goTypes,
+		DependencyIndexes: file_dapr_proto_components_v1_middleware_proto_depIdxs,
+		MessageInfos:      file_dapr_proto_components_v1_middleware_proto_msgTypes,
+	}.Build()
+	File_dapr_proto_components_v1_middleware_proto = out.File
+	file_dapr_proto_components_v1_middleware_proto_rawDesc = nil
+	file_dapr_proto_components_v1_middleware_proto_goTypes = nil
+	file_dapr_proto_components_v1_middleware_proto_depIdxs = nil
+}
@@ -0,0 +1,228 @@
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.2.0
+// - protoc             v3.21.12
+// source: dapr/proto/components/v1/middleware.proto
+
+package components
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.32.0 or later.
+const _ = grpc.SupportPackageIsVersion7
+
+// HTTPMiddlewareClient is the client API for HTTPMiddleware service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+type HTTPMiddlewareClient interface {
+	// Initializes the HTTP middleware with the given metadata.
+	Init(ctx context.Context, in *HTTPMiddlewareInitRequest, opts ...grpc.CallOption) (*HTTPMiddlewareInitResponse, error)
+	// Handles a single HTTP request.
+	// The client (daprd) sends the request headers as the first message,
+	// followed by zero or more body chunks, and then closes its sending side.
+	// The server (middleware) answers with either `next`, to forward a possibly
+	// modified request to the next handler in the pipeline, or `response`, to
+	// short-circuit the pipeline, followed by zero or more body chunks for the
+	// forwarded request or the response respectively. The server ends the
+	// stream when the body is complete.
+	Handle(ctx context.Context, opts ...grpc.CallOption) (HTTPMiddleware_HandleClient, error)
+	// Ping the HTTP middleware. Used for liveness porpuses.
+	Ping(ctx context.Context, in *PingRequest, opts ...grpc.CallOption) (*PingResponse, error)
+}
+
+type hTTPMiddlewareClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewHTTPMiddlewareClient(cc grpc.ClientConnInterface) HTTPMiddlewareClient {
+	return &hTTPMiddlewareClient{cc}
+}
+
+func (c *hTTPMiddlewareClient) Init(ctx context.Context, in *HTTPMiddlewareInitRequest, opts ...grpc.CallOption) (*HTTPMiddlewareInitResponse, error) {
+	out := new(HTTPMiddlewareInitResponse)
+	err := c.cc.Invoke(ctx, "/dapr.proto.components.v1.HTTPMiddleware/Init", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *hTTPMiddlewareClient) Handle(ctx context.Context, opts ...grpc.CallOption) (HTTPMiddleware_HandleClient, error) {
+	stream, err := c.cc.NewStream(ctx, &HTTPMiddleware_ServiceDesc.Streams[0], "/dapr.proto.components.v1.HTTPMiddleware/Handle", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &hTTPMiddlewareHandleClient{stream}
+	return x, nil
+}
+
+type HTTPMiddleware_HandleClient interface {
+	Send(*HandleHTTPRequest) error
+	Recv() (*HandleHTTPResponse, error)
+	grpc.ClientStream
+}
+
+type hTTPMiddlewareHandleClient struct {
+	grpc.ClientStream
+}
+
+func (x *hTTPMiddlewareHandleClient) Send(m *HandleHTTPRequest) error {
+	return x.ClientStream.SendMsg(m)
+}
+
+func (x *hTTPMiddlewareHandleClient) Recv() (*HandleHTTPResponse, error) {
+	m := new(HandleHTTPResponse)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *hTTPMiddlewareClient) Ping(ctx context.Context, in *PingRequest, opts ...grpc.CallOption) (*PingResponse, error) {
+	out := new(PingResponse)
+	err := c.cc.Invoke(ctx, "/dapr.proto.components.v1.HTTPMiddleware/Ping", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// HTTPMiddlewareServer is the server API for HTTPMiddleware service.
+// All implementations should embed UnimplementedHTTPMiddlewareServer
+// for forward compatibility
+type HTTPMiddlewareServer interface {
+	// Initializes the HTTP middleware with the given metadata.
+	Init(context.Context, *HTTPMiddlewareInitRequest) (*HTTPMiddlewareInitResponse, error)
+	// Handles a single HTTP request.
+	// The client (daprd) sends the request headers as the first message,
+	// followed by zero or more body chunks, and then closes its sending side.
+	// The server (middleware) answers with either `next`, to forward a possibly
+	// modified request to the next handler in the pipeline, or `response`, to
+	// short-circuit the pipeline, followed by zero or more body chunks for the
+	// forwarded request or the response respectively. The server ends the
+	// stream when the body is complete.
+	Handle(HTTPMiddleware_HandleServer) error
+	// Ping the HTTP middleware. Used for liveness porpuses.
+	Ping(context.Context, *PingRequest) (*PingResponse, error)
+}
+
+// UnimplementedHTTPMiddlewareServer should be embedded to have forward compatible implementations.
+type UnimplementedHTTPMiddlewareServer struct {
+}
+
+func (UnimplementedHTTPMiddlewareServer) Init(context.Context, *HTTPMiddlewareInitRequest) (*HTTPMiddlewareInitResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Init not implemented")
+}
+func (UnimplementedHTTPMiddlewareServer) Handle(HTTPMiddleware_HandleServer) error {
+	return status.Errorf(codes.Unimplemented, "method Handle not implemented")
+}
+func (UnimplementedHTTPMiddlewareServer) Ping(context.Context, *PingRequest) (*PingResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Ping not implemented")
+}
+
+// UnsafeHTTPMiddlewareServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to HTTPMiddlewareServer will
+// result in compilation errors.
+type UnsafeHTTPMiddlewareServer interface {
+	mustEmbedUnimplementedHTTPMiddlewareServer()
+}
+
+func RegisterHTTPMiddlewareServer(s grpc.ServiceRegistrar, srv HTTPMiddlewareServer) {
+	s.RegisterService(&HTTPMiddleware_ServiceDesc, srv)
+}
+
+func _HTTPMiddleware_Init_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(HTTPMiddlewareInitRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(HTTPMiddlewareServer).Init(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/dapr.proto.components.v1.HTTPMiddleware/Init",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(HTTPMiddlewareServer).Init(ctx, req.(*HTTPMiddlewareInitRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _HTTPMiddleware_Handle_Handler(srv interface{}, stream grpc.ServerStream) error {
+	return srv.(HTTPMiddlewareServer).Handle(&hTTPMiddlewareHandleServer{stream})
+}
+
+type HTTPMiddleware_HandleServer interface {
+	Send(*HandleHTTPResponse) error
+	Recv() (*HandleHTTPRequest, error)
+	grpc.ServerStream
+}
+
+type hTTPMiddlewareHandleServer struct {
+	grpc.ServerStream
+}
+
+func (x *hTTPMiddlewareHandleServer) Send(m *HandleHTTPResponse) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func (x *hTTPMiddlewareHandleServer) Recv() (*HandleHTTPRequest, error) {
+	m := new(HandleHTTPRequest)
+	if err := x.ServerStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func _HTTPMiddleware_Ping_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(PingRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(HTTPMiddlewareServer).Ping(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/dapr.proto.components.v1.HTTPMiddleware/Ping",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(HTTPMiddlewareServer).Ping(ctx, req.(*PingRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// HTTPMiddleware_ServiceDesc is the grpc.ServiceDesc for HTTPMiddleware service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var HTTPMiddleware_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "dapr.proto.components.v1.HTTPMiddleware",
+	HandlerType: (*HTTPMiddlewareServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "Init",
+			Handler:    _HTTPMiddleware_Init_Handler,
+		},
+		{
+			MethodName: "Ping",
+			Handler:    _HTTPMiddleware_Ping_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "Handle",
+			Handler:       _HTTPMiddleware_Handle_Handler,
+			ServerStreams: true,
+			ClientStreams: true,
+		},
+	},
+	Metadata: "dapr/proto/components/v1/middleware.proto",
+}
@@ -190,6 +190,119 @@ var TransactionalStateStore_ServiceDesc = grpc.ServiceDesc{
 	Metadata: "dapr/proto/components/v1/state.proto",
 }
 
+// StateStoreChangeFeedClient is the client API for StateStoreChangeFeed service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+type StateStoreChangeFeedClient interface {
+	// SubscribeChanges streams the changes of the keys with the given prefix, in order, until the stream is closed.
+	SubscribeChanges(ctx context.Context, in *SubscribeChangesRequest, opts ...grpc.CallOption) (StateStoreChangeFeed_SubscribeChangesClient, error)
+}
+
+type stateStoreChangeFeedClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewStateStoreChangeFeedClient(cc grpc.ClientConnInterface) StateStoreChangeFeedClient {
+	return &stateStoreChangeFeedClient{cc}
+}
+
+func (c *stateStoreChangeFeedClient) SubscribeChanges(ctx context.Context, in *SubscribeChangesRequest, opts ...grpc.CallOption) (StateStoreChangeFeed_SubscribeChangesClient, error) {
+	stream, err := c.cc.NewStream(ctx, &StateStoreChangeFeed_ServiceDesc.Streams[0], "/dapr.proto.components.v1.StateStoreChangeFeed/SubscribeChanges", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &stateStoreChangeFeedSubscribeChangesClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type StateStoreChangeFeed_SubscribeChangesClient interface {
+	Recv() (*StateChange, error)
+	grpc.ClientStream
+}
+
+type stateStoreChangeFeedSubscribeChangesClient struct {
+	grpc.ClientStream
+}
+
+func (x *stateStoreChangeFeedSubscribeChangesClient) Recv() (*StateChange, error) {
+	m := new(StateChange)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+// StateStoreChangeFeedServer is the server API for StateStoreChangeFeed service.
+// All implementations should embed UnimplementedStateStoreChangeFeedServer
+// for forward compatibility
+type StateStoreChangeFeedServer interface {
+	// SubscribeChanges streams the changes of the keys with the given prefix, in order, until the stream is closed.
+	SubscribeChanges(*SubscribeChangesRequest, StateStoreChangeFeed_SubscribeChangesServer) error
+}
+
+// UnimplementedStateStoreChangeFeedServer should be embedded to have forward compatible implementations.
+type UnimplementedStateStoreChangeFeedServer struct {
+}
+
+func (UnimplementedStateStoreChangeFeedServer) SubscribeChanges(*SubscribeChangesRequest, StateStoreChangeFeed_SubscribeChangesServer) error {
+	return status.Errorf(codes.Unimplemented, "method SubscribeChanges not implemented")
+}
+
+// UnsafeStateStoreChangeFeedServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to StateStoreChangeFeedServer will
+// result in compilation errors.
+type UnsafeStateStoreChangeFeedServer interface {
+	mustEmbedUnimplementedStateStoreChangeFeedServer()
+}
+
+func RegisterStateStoreChangeFeedServer(s grpc.ServiceRegistrar, srv StateStoreChangeFeedServer) {
+	s.RegisterService(&StateStoreChangeFeed_ServiceDesc, srv)
+}
+
+func _StateStoreChangeFeed_SubscribeChanges_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(SubscribeChangesRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(StateStoreChangeFeedServer).SubscribeChanges(m, &stateStoreChangeFeedSubscribeChangesServer{stream})
+}
+
+type StateStoreChangeFeed_SubscribeChangesServer interface {
+	Send(*StateChange) error
+	grpc.ServerStream
+}
+
+type stateStoreChangeFeedSubscribeChangesServer struct {
+	grpc.ServerStream
+}
+
+func (x *stateStoreChangeFeedSubscribeChangesServer) Send(m *StateChange) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+// StateStoreChangeFeed_ServiceDesc is the grpc.ServiceDesc for StateStoreChangeFeed service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var StateStoreChangeFeed_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "dapr.proto.components.v1.StateStoreChangeFeed",
+	HandlerType: (*StateStoreChangeFeedServer)(nil),
+	Methods:     []grpc.MethodDesc{},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "SubscribeChanges",
+			Handler:       _StateStoreChangeFeed_SubscribeChanges_Handler,
+			ServerStreams: true,
+		},
+	},
+	Metadata: "dapr/proto/components/v1/state.proto",
+}
+
 // StateStoreClient is the client API for StateStore service.
 //
 // For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
@@ -160,6 +160,9 @@ type PlacementTable struct {
 	SortedSet []uint64          `protobuf:"varint,2,rep,packed,name=sorted_set,json=sortedSet,proto3" json:"sorted_set,omitempty"`
 	LoadMap   map[string]*Host  `protobuf:"bytes,3,rep,name=load_map,json=loadMap,proto3" json:"load_map,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
 	TotalLoad int64             `protobuf:"varint,4,opt,name=total_load,json=totalLoad,proto3" json:"total_load,omitempty"`
+	// Versions of the app that the actors of the type are routed to, with their weights.
+	// Empty if the actors of the type aren't routed by version.
+	VersionRouting []*ActorVersionWeight `protobuf:"bytes,5,rep,name=version_routing,json=versionRouting,proto3" json:"version_routing,omitempty"`
 }
 
 func (x *PlacementTable) Reset() {
@@ -222,6 +225,69 @@ func (x *PlacementTable) GetTotalLoad() int64 {
 	return 0
 }
 
+func (x *PlacementTable) GetVersionRouting() []*ActorVersionWeight {
+	if x != nil {
+		return x.VersionRouting
+	}
+	return nil
+}
+
+// ActorVersionWeight is the weight of a version of an app in the version routing of an actor type.
+type ActorVersionWeight struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Version string `protobuf:"bytes,1,opt,name=version,proto3" json:"version,omitempty"`
+	Weight  int32  `protobuf:"varint,2,opt,name=weight,proto3" json:"weight,omitempty"`
+}
+
+func (x *ActorVersionWeight) Reset() {
+	*x = ActorVersionWeight{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_dapr_proto_placement_v1_placement_proto_msgTypes[3]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ActorVersionWeight) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ActorVersionWeight) ProtoMessage() {}
+
+func (x *ActorVersionWeight) ProtoReflect() protoreflect.Message {
+	mi := &file_dapr_proto_placement_v1_placement_proto_msgTypes[3]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ActorVersionWeight.ProtoReflect.Descriptor instead.
+func (*ActorVersionWeight) Descriptor() ([]byte, []int) {
+	return file_dapr_proto_placement_v1_placement_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *ActorVersionWeight) GetVersion() string {
+	if x != nil {
+		return x.Version
+	}
+	return ""
+}
+
+func (x *ActorVersionWeight) GetWeight() int32 {
+	if x != nil {
+		return x.Weight
+	}
+	return 0
+}
+
 type Host struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
@@ -235,12 +301,14 @@ type Host struct {
 	Pod      string   `protobuf:"bytes,6,opt,name=pod,proto3" json:"pod,omitempty"`
 	// Version of the Actor APIs supported by the Dapr runtime
 	ApiLevel uint32 `protobuf:"varint,7,opt,name=api_level,json=apiLevel,proto3" json:"api_level,omitempty"`
+	// Version of the app hosting the actors, used to route actors of the same type to different versions of an app
+	ActorVersion string `protobuf:"bytes,8,opt,name=actor_version,json=actorVersion,proto3" json:"actor_version,omitempty"`
 }
 
 func (x *Host) Reset() {
 	*x = Host{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_dapr_proto_placement_v1_placement_proto_msgTypes[3]
+		mi := &file_dapr_proto_placement_v1_placement_proto_msgTypes[4]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -253,7 +321,7 @@ func (x *Host) String() string {
 func (*Host) ProtoMessage() {}
 
 func (x *Host) ProtoReflect() protoreflect.Message {
-	mi := &file_dapr_proto_placement_v1_placement_proto_msgTypes[3]
+	mi := &file_dapr_proto_placement_v1_placement_proto_msgTypes[4]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -266,7 +334,7 @@ func (x *Host) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use Host.ProtoReflect.Descriptor instead.
 func (*Host) Descriptor() ([]byte, []int) {
-	return file_dapr_proto_placement_v1_placement_proto_rawDescGZIP(), []int{3}
+	return file_dapr_proto_placement_v1_placement_proto_rawDescGZIP(), []int{4}
 }
 
 func (x *Host) GetName() string {
@@ -318,6 +386,13 @@ func (x *Host) GetApiLevel() uint32 {
 	return 0
 }
 
+func (x *Host) GetActorVersion() string {
+	if x != nil {
+		return x.ActorVersion
+	}
+	return ""
+}
+
 var File_dapr_proto_placement_v1_placement_proto protoreflect.FileDescriptor
 
 var file_dapr_proto_placement_v1_placement_proto_rawDesc = []byte{
@@ -348,7 +423,7 @@ var file_dapr_proto_placement_v1_placement_proto_rawDesc = []byte{
 	0x0b, 0x32, 0x27, 0x2e, 0x64, 0x61, 0x70, 0x72, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x70,
 	0x6c, 0x61, 0x63, 0x65, 0x6d, 0x65, 0x6e, 0x74, 0x2e, 0x76, 0x31, 0x2e, 0x50, 0x6c, 0x61, 0x63,
 	0x65, 0x6d, 0x65, 0x6e, 0x74, 0x54, 0x61, 0x62, 0x6c, 0x65, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75,
-	0x65, 0x3a, 0x02, 0x38, 0x01, 0x22, 0xfe, 0x02, 0x0a, 0x0e, 0x50, 0x6c, 0x61, 0x63, 0x65, 0x6d,
+	0x65, 0x3a, 0x02, 0x38, 0x01, 0x22, 0xd4, 0x03, 0x0a, 0x0e, 0x50, 0x6c, 0x61, 0x63, 0x65, 0x6d,
 	0x65, 0x6e, 0x74, 0x54, 0x61, 0x62, 0x6c, 0x65, 0x12, 0x48, 0x0a, 0x05, 0x68, 0x6f, 0x73, 0x74,
 	0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x32, 0x2e, 0x64, 0x61, 0x70, 0x72, 0x2e, 0x70,
 	0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x70, 0x6c, 0x61, 0x63, 0x65, 0x6d, 0x65, 0x6e, 0x74, 0x2e, 0x76,
@@ -363,37 +438,49 @@ var file_dapr_proto_placement_v1_placement_proto_rawDesc = []byte{
 	0x64, 0x4d, 0x61, 0x70, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x52, 0x07, 0x6c, 0x6f, 0x61, 0x64, 0x4d,
 	0x61, 0x70, 0x12, 0x1d, 0x0a, 0x0a, 0x74, 0x6f, 0x74, 0x61, 0x6c, 0x5f, 0x6c, 0x6f, 0x61, 0x64,
 	0x18, 0x04, 0x20, 0x01, 0x28, 0x03, 0x52, 0x09, 0x74, 0x6f, 0x74, 0x61, 0x6c, 0x4c, 0x6f, 0x61,
-	0x64, 0x1a, 0x38, 0x0a, 0x0a, 0x48, 0x6f, 0x73, 0x74, 0x73, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x12,
-	0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x04, 0x52, 0x03, 0x6b, 0x65,
-	0x79, 0x12, 0x14, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09,
-	0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x3a, 0x02, 0x38, 0x01, 0x1a, 0x59, 0x0a, 0x0c, 0x4c,
-	0x6f, 0x61, 0x64, 0x4d, 0x61, 0x70, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x12, 0x10, 0x0a, 0x03, 0x6b,
-	0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x6b, 0x65, 0x79, 0x12, 0x33, 0x0a,
-	0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1d, 0x2e, 0x64,
-	0x61, 0x70, 0x72, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x70, 0x6c, 0x61, 0x63, 0x65, 0x6d,
-	0x65, 0x6e, 0x74, 0x2e, 0x76, 0x31, 0x2e, 0x48, 0x6f, 0x73, 0x74, 0x52, 0x05, 0x76, 0x61, 0x6c,
-	0x75, 0x65, 0x3a, 0x02, 0x38, 0x01, 0x22, 0x9d, 0x01, 0x0a, 0x04, 0x48, 0x6f, 0x73, 0x74, 0x12,
-	0x12, 0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6e,
-	0x61, 0x6d, 0x65, 0x12, 0x12, 0x0a, 0x04, 0x70, 0x6f, 0x72, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28,
-	0x03, 0x52, 0x04, 0x70, 0x6f, 0x72, 0x74, 0x12, 0x12, 0x0a, 0x04, 0x6c, 0x6f, 0x61, 0x64, 0x18,
-	0x03, 0x20, 0x01, 0x28, 0x03, 0x52, 0x04, 0x6c, 0x6f, 0x61, 0x64, 0x12, 0x1a, 0x0a, 0x08, 0x65,
-	0x6e, 0x74, 0x69, 0x74, 0x69, 0x65, 0x73, 0x18, 0x04, 0x20, 0x03, 0x28, 0x09, 0x52, 0x08, 0x65,
-	0x6e, 0x74, 0x69, 0x74, 0x69, 0x65, 0x73, 0x12, 0x0e, 0x0a, 0x02, 0x69, 0x64, 0x18, 0x05, 0x20,
-	0x01, 0x28, 0x09, 0x52, 0x02, 0x69, 0x64, 0x12, 0x10, 0x0a, 0x03, 0x70, 0x6f, 0x64, 0x18, 0x06,
-	0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x70, 0x6f, 0x64, 0x12, 0x1b, 0x0a, 0x09, 0x61, 0x70, 0x69,
-	0x5f, 0x6c, 0x65, 0x76, 0x65, 0x6c, 0x18, 0x07, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x08, 0x61, 0x70,
-	0x69, 0x4c, 0x65, 0x76, 0x65, 0x6c, 0x32, 0x6d, 0x0a, 0x09, 0x50, 0x6c, 0x61, 0x63, 0x65, 0x6d,
-	0x65, 0x6e, 0x74, 0x12, 0x60, 0x0a, 0x10, 0x52, 0x65, 0x70, 0x6f, 0x72, 0x74, 0x44, 0x61, 0x70,
-	0x72, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x12, 0x1d, 0x2e, 0x64, 0x61, 0x70, 0x72, 0x2e, 0x70,
-	0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x70, 0x6c, 0x61, 0x63, 0x65, 0x6d, 0x65, 0x6e, 0x74, 0x2e, 0x76,
-	0x31, 0x2e, 0x48, 0x6f, 0x73, 0x74, 0x1a, 0x27, 0x2e, 0x64, 0x61, 0x70, 0x72, 0x2e, 0x70, 0x72,
-	0x6f, 0x74, 0x6f, 0x2e, 0x70, 0x6c, 0x61, 0x63, 0x65, 0x6d, 0x65, 0x6e, 0x74, 0x2e, 0x76, 0x31,
-	0x2e, 0x50, 0x6c, 0x61, 0x63, 0x65, 0x6d, 0x65, 0x6e, 0x74, 0x4f, 0x72, 0x64, 0x65, 0x72, 0x22,
-	0x00, 0x28, 0x01, 0x30, 0x01, 0x42, 0x37, 0x5a, 0x35, 0x67, 0x69, 0x74, 0x68, 0x75, 0x62, 0x2e,
-	0x63, 0x6f, 0x6d, 0x2f, 0x64, 0x61, 0x70, 0x72, 0x2f, 0x64, 0x61, 0x70, 0x72, 0x2f, 0x70, 0x6b,
-	0x67, 0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2f, 0x70, 0x6c, 0x61, 0x63, 0x65, 0x6d, 0x65, 0x6e,
-	0x74, 0x2f, 0x76, 0x31, 0x3b, 0x70, 0x6c, 0x61, 0x63, 0x65, 0x6d, 0x65, 0x6e, 0x74, 0x62, 0x06,
-	0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
+	0x64, 0x12, 0x54, 0x0a, 0x0f, 0x76, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x5f, 0x72, 0x6f, 0x75,
+	0x74, 0x69, 0x6e, 0x67, 0x18, 0x05, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x2b, 0x2e, 0x64, 0x61, 0x70,
+	0x72, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x70, 0x6c, 0x61, 0x63, 0x65, 0x6d, 0x65, 0x6e,
+	0x74, 0x2e, 0x76, 0x31, 0x2e, 0x41, 0x63, 0x74, 0x6f, 0x72, 0x56, 0x65, 0x72, 0x73, 0x69, 0x6f,
+	0x6e, 0x57, 0x65, 0x69, 0x67, 0x68, 0x74, 0x52, 0x0e, 0x76, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e,
+	0x52, 0x6f, 0x75, 0x74, 0x69, 0x6e, 0x67, 0x1a, 0x38, 0x0a, 0x0a, 0x48, 0x6f, 0x73, 0x74, 0x73,
+	0x45, 0x6e, 0x74, 0x72, 0x79, 0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18, 0x01, 0x20, 0x01,
+	0x28, 0x04, 0x52, 0x03, 0x6b, 0x65, 0x79, 0x12, 0x14, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65,
+	0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x3a, 0x02, 0x38,
+	0x01, 0x1a, 0x59, 0x0a, 0x0c, 0x4c, 0x6f, 0x61, 0x64, 0x4d, 0x61, 0x70, 0x45, 0x6e, 0x74, 0x72,
+	0x79, 0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03,
+	0x6b, 0x65, 0x79, 0x12, 0x33, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x02, 0x20, 0x01,
+	0x28, 0x0b, 0x32, 0x1d, 0x2e, 0x64, 0x61, 0x70, 0x72, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e,
+	0x70, 0x6c, 0x61, 0x63, 0x65, 0x6d, 0x65, 0x6e, 0x74, 0x2e, 0x76, 0x31, 0x2e, 0x48, 0x6f, 0x73,
+	0x74, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x3a, 0x02, 0x38, 0x01, 0x22, 0x46, 0x0a, 0x12,
+	0x41, 0x63, 0x74, 0x6f, 0x72, 0x56, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x57, 0x65, 0x69, 0x67,
+	0x68, 0x74, 0x12, 0x18, 0x0a, 0x07, 0x76, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x18, 0x01, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x07, 0x76, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x12, 0x16, 0x0a, 0x06,
+	0x77, 0x65, 0x69, 0x67, 0x68, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x05, 0x52, 0x06, 0x77, 0x65,
+	0x69, 0x67, 0x68, 0x74, 0x22, 0xc2, 0x01, 0x0a, 0x04, 0x48, 0x6f, 0x73, 0x74, 0x12, 0x12, 0x0a,
+	0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d,
+	0x65, 0x12, 0x12, 0x0a, 0x04, 0x70, 0x6f, 0x72, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x03, 0x52,
+	0x04, 0x70, 0x6f, 0x72, 0x74, 0x12, 0x12, 0x0a, 0x04, 0x6c, 0x6f, 0x61, 0x64, 0x18, 0x03, 0x20,
+	0x01, 0x28, 0x03, 0x52, 0x04, 0x6c, 0x6f, 0x61, 0x64, 0x12, 0x1a, 0x0a, 0x08, 0x65, 0x6e, 0x74,
+	0x69, 0x74, 0x69, 0x65, 0x73, 0x18, 0x04, 0x20, 0x03, 0x28, 0x09, 0x52, 0x08, 0x65, 0x6e, 0x74,
+	0x69, 0x74, 0x69, 0x65, 0x73, 0x12, 0x0e, 0x0a, 0x02, 0x69, 0x64, 0x18, 0x05, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x02, 0x69, 0x64, 0x12, 0x10, 0x0a, 0x03, 0x70, 0x6f, 0x64, 0x18, 0x06, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x03, 0x70, 0x6f, 0x64, 0x12, 0x1b, 0x0a, 0x09, 0x61, 0x70, 0x69, 0x5f, 0x6c,
+	0x65, 0x76, 0x65, 0x6c, 0x18, 0x07, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x08, 0x61, 0x70, 0x69, 0x4c,
+	0x65, 0x76, 0x65, 0x6c, 0x12, 0x23, 0x0a, 0x0d, 0x61, 0x63, 0x74, 0x6f, 0x72, 0x5f, 0x76, 0x65,
+	0x72, 0x73, 0x69, 0x6f, 0x6e, 0x18, 0x08, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0c, 0x61, 0x63, 0x74,
+	0x6f, 0x72, 0x56, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x32, 0x6d, 0x0a, 0x09, 0x50, 0x6c, 0x61,
+	0x63, 0x65, 0x6d, 0x65, 0x6e, 0x74, 0x12, 0x60, 0x0a, 0x10, 0x52, 0x65, 0x70, 0x6f, 0x72, 0x74,
+	0x44, 0x61, 0x70, 0x72, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x12, 0x1d, 0x2e, 0x64, 0x61, 0x70,
+	0x72, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x70, 0x6c, 0x61, 0x63, 0x65, 0x6d, 0x65, 0x6e,
+	0x74, 0x2e, 0x76, 0x31, 0x2e, 0x48, 0x6f, 0x73, 0x74, 0x1a, 0x27, 0x2e, 0x64, 0x61, 0x70, 0x72,
+	0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x70, 0x6c, 0x61, 0x63, 0x65, 0x6d, 0x65, 0x6e, 0x74,
+	0x2e, 0x76, 0x31, 0x2e, 0x50, 0x6c, 0x61, 0x63, 0x65, 0x6d, 0x65, 0x6e, 0x74, 0x4f, 0x72, 0x64,
+	0x65, 0x72, 0x22, 0x00, 0x28, 0x01, 0x30, 0x01, 0x42, 0x37, 0x5a, 0x35, 0x67, 0x69, 0x74, 0x68,
+	0x75, 0x62, 0x2e, 0x63, 0x6f, 0x6d, 0x2f, 0x64, 0x61, 0x70, 0x72, 0x2f, 0x64, 0x61, 0x70, 0x72,
+	0x2f, 0x70, 0x6b, 0x67, 0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2f, 0x70, 0x6c, 0x61, 0x63, 0x65,
+	0x6d, 0x65, 0x6e, 0x74, 0x2f, 0x76, 0x31, 0x3b, 0x70, 0x6c, 0x61, 0x63, 0x65, 0x6d, 0x65, 0x6e,
+	0x74, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
 }
 
 var (
@@ -408,30 +495,32 @@ func file_dapr_proto_placement_v1_placement_proto_rawDescGZIP() []byte {
 	return file_dapr_proto_placement_v1_placement_proto_rawDescData
 }
 
-var file_dapr_proto_placement_v1_placement_proto_msgTypes = make([]protoimpl.MessageInfo, 7)
+var file_dapr_proto_placement_v1_placement_proto_msgTypes = make([]protoimpl.MessageInfo, 8)
 var file_dapr_proto_placement_v1_placement_proto_goTypes = []interface{}{
-	(*PlacementOrder)(nil),  // 0: dapr.proto.placement.v1.PlacementOrder
-	(*PlacementTables)(nil), // 1: dapr.proto.placement.v1.PlacementTables
-	(*PlacementTable)(nil),  // 2: dapr.proto.placement.v1.PlacementTable
-	(*Host)(nil),            // 3: dapr.proto.placement.v1.Host
-	nil,                     // 4: dapr.proto.placement.v1.PlacementTables.EntriesEntry
-	nil,                     // 5: dapr.proto.placement.v1.PlacementTable.HostsEntry
-	nil,                     // 6: dapr.proto.placement.v1.PlacementTable.LoadMapEntry
+	(*PlacementOrder)(nil),     // 0: dapr.proto.placement.v1.PlacementOrder
+	(*PlacementTables)(nil),    // 1: dapr.proto.placement.v1.PlacementTables
+	(*PlacementTable)(nil),     // 2: dapr.proto.placement.v1.PlacementTable
+	(*ActorVersionWeight)(nil), // 3: dapr.proto.placement.v1.ActorVersionWeight
+	(*Host)(nil),               // 4: dapr.proto.placement.v1.Host
+	nil,                        // 5: dapr.proto.placement.v1.PlacementTables.EntriesEntry
+	nil,                        // 6: dapr.proto.placement.v1.PlacementTable.HostsEntry
+	nil,                        // 7: dapr.proto.placement.v1.PlacementTable.LoadMapEntry
 }
 var file_dapr_proto_placement_v1_placement_proto_depIdxs = []int32{
 	1, // 0: dapr.proto.placement.v1.PlacementOrder.tables:type_name -> dapr.proto.placement.v1.PlacementTables
-	4, // 1: dapr.proto.placement.v1.PlacementTables.entries:type_name -> dapr.proto.placement.v1.PlacementTables.EntriesEntry
-	5, // 2: dapr.proto.placement.v1.PlacementTable.hosts:type_name -> dapr.proto.placement.v1.PlacementTable.HostsEntry
-	6, // 3: dapr.proto.placement.v1.PlacementTable.load_map:type_name -> dapr.proto.placement.v1.PlacementTable.LoadMapEntry
-	2, // 4: dapr.proto.placement.v1.PlacementTables.EntriesEntry.value:type_name -> dapr.proto.placement.v1.PlacementTable
-	3, // 5: dapr.proto.placement.v1.PlacementTable.LoadMapEntry.value:type_name -> dapr.proto.placement.v1.Host
-	3, // 6: dapr.proto.placement.v1.Placement.ReportDaprStatus:input_type -> dapr.proto.placement.v1.Host
-	0, // 7: dapr.proto.placement.v1.Placement.ReportDaprStatus:output_type -> dapr.proto.placement.v1.PlacementOrder
-	7, // [7:8] is the sub-list for method output_type
-	6, // [6:7] is the sub-list for method input_type
-	6, // [6:6] is the sub-list for extension type_name
-	6, // [6:6] is the sub-list for extension extendee
-	0, // [0:6] is the sub-list for field type_name
+	5, // 1: dapr.proto.placement.v1.PlacementTables.entries:type_name -> dapr.proto.placement.v1.PlacementTables.EntriesEntry
+	6, // 2: dapr.proto.placement.v1.PlacementTable.hosts:type_name -> dapr.proto.placement.v1.PlacementTable.HostsEntry
+	7, // 3: dapr.proto.placement.v1.PlacementTable.load_map:type_name -> dapr.proto.placement.v1.PlacementTable.LoadMapEntry
+	3, // 4: dapr.proto.placement.v1.PlacementTable.version_routing:type_name -> dapr.proto.placement.v1.ActorVersionWeight
+	2, // 5: dapr.proto.placement.v1.PlacementTables.EntriesEntry.value:type_name -> dapr.proto.placement.v1.PlacementTable
+	4, // 6: dapr.proto.placement.v1.PlacementTable.LoadMapEntry.value:type_name -> dapr.proto.placement.v1.Host
+	4, // 7: dapr.proto.placement.v1.Placement.ReportDaprStatus:input_type -> dapr.proto.placement.v1.Host
+	0, // 8: dapr.proto.placement.v1.Placement.ReportDaprStatus:output_type -> dapr.proto.placement.v1.PlacementOrder
+	8, // [8:9] is the sub-list for method output_type
+	7, // [7:8] is the sub-list for method input_type
+	7, // [7:7] is the sub-list for extension type_name
+	7, // [7:7] is the sub-list for extension extendee
+	0, // [0:7] is the sub-list for field type_name
 }
 
 func init() { file_dapr_proto_placement_v1_placement_proto_init() }
@@ -477,6 +566,18 @@ func file_dapr_proto_placement_v1_placement_proto_init() {
 			}
 		}
 		file_dapr_proto_placement_v1_placement_proto_msgTypes[3].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ActorVersionWeight); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_dapr_proto_placement_v1_placement_proto_msgTypes[4].Exporter = func(v interface{}, i int) interface{} {
 			switch v := v.(*Host); i {
 			case 0:
 				return &v.state
@@ -495,7 +596,7 @@ func file_dapr_proto_placement_v1_placement_proto_init() {
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: file_dapr_proto_placement_v1_placement_proto_rawDesc,
 			NumEnums:      0,
-			NumMessages:   7,
+			NumMessages:   8,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
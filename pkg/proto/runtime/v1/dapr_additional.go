@@ -88,6 +88,30 @@ func (x *PurgeWorkflowRequest) SetInstanceId(val string) {
 	}
 }
 
+func (x *GetWorkflowHistoryRequest) SetWorkflowComponent(val string) {
+	if x != nil {
+		x.WorkflowComponent = val
+	}
+}
+
+func (x *GetWorkflowHistoryRequest) SetInstanceId(val string) {
+	if x != nil {
+		x.InstanceId = val
+	}
+}
+
+func (x *ReplayWorkflowHistoryRequest) SetWorkflowComponent(val string) {
+	if x != nil {
+		x.WorkflowComponent = val
+	}
+}
+
+func (x *ReplayWorkflowHistoryRequest) SetInstanceId(val string) {
+	if x != nil {
+		x.InstanceId = val
+	}
+}
+
 // SubtleCryptoRequests is an interface for all Subtle*Request structs.
 type SubtleCryptoRequests interface {
 	// SetComponentName sets the value of the ComponentName property.
@@ -35,6 +35,8 @@ type DaprClient interface {
 	SaveState(ctx context.Context, in *SaveStateRequest, opts ...grpc.CallOption) (*emptypb.Empty, error)
 	// Queries the state.
 	QueryStateAlpha1(ctx context.Context, in *QueryStateRequest, opts ...grpc.CallOption) (*QueryStateResponse, error)
+	// Subscribes to the changes of the keys in a state store that supports change feeds, delivered as CloudEvents in a gRPC stream.
+	SubscribeStateChangesAlpha1(ctx context.Context, in *SubscribeStateChangesRequest, opts ...grpc.CallOption) (Dapr_SubscribeStateChangesAlpha1Client, error)
 	// Deletes the state for a specific key.
 	DeleteState(ctx context.Context, in *DeleteStateRequest, opts ...grpc.CallOption) (*emptypb.Empty, error)
 	// Deletes a bulk of state items for a list of keys
@@ -131,8 +133,30 @@ type DaprClient interface {
 	ResumeWorkflowBeta1(ctx context.Context, in *ResumeWorkflowRequest, opts ...grpc.CallOption) (*emptypb.Empty, error)
 	// Raise an event to a running workflow instance
 	RaiseEventWorkflowBeta1(ctx context.Context, in *RaiseEventWorkflowRequest, opts ...grpc.CallOption) (*emptypb.Empty, error)
+	// Raise events to many workflow instances in a single call, reporting the outcome for each instance
+	RaiseEventWorkflowBatchBeta1(ctx context.Context, in *RaiseEventWorkflowBatchRequest, opts ...grpc.CallOption) (*RaiseEventWorkflowBatchResponse, error)
+	// Lists the workflow instances, filtered by status, name and creation time
+	ListWorkflowsAlpha1(ctx context.Context, in *ListWorkflowsRequest, opts ...grpc.CallOption) (*ListWorkflowsResponse, error)
+	// Exports the history of a workflow instance, to replay it with ReplayWorkflowHistoryAlpha1
+	GetWorkflowHistoryAlpha1(ctx context.Context, in *GetWorkflowHistoryRequest, opts ...grpc.CallOption) (*GetWorkflowHistoryResponse, error)
+	// Replays the history of a workflow instance against the workflow code of the app, reporting where the code diverges from the history
+	ReplayWorkflowHistoryAlpha1(ctx context.Context, in *ReplayWorkflowHistoryRequest, opts ...grpc.CallOption) (*ReplayWorkflowHistoryResponse, error)
 	// Shutdown the sidecar
 	Shutdown(ctx context.Context, in *ShutdownRequest, opts ...grpc.CallOption) (*emptypb.Empty, error)
+	// Records measurements of custom metrics of the app, exposed on the metrics endpoint of the sidecar.
+	RecordMetricsAlpha1(ctx context.Context, in *RecordMetricsRequest, opts ...grpc.CallOption) (*emptypb.Empty, error)
+	// Records spans of the app, and events of the current trace, exported with the tracing configuration of the sidecar.
+	RecordSpansAlpha1(ctx context.Context, in *RecordSpansRequest, opts ...grpc.CallOption) (*RecordSpansResponse, error)
+	// Consumes units of a distributed rate limiter, whose limit is shared by all the replicas of the app.
+	CheckRateLimitAlpha1(ctx context.Context, in *CheckRateLimitRequest, opts ...grpc.CallOption) (*CheckRateLimitResponse, error)
+	// Campaigns for the leadership of an election on behalf of a candidate.
+	// The sidecar keeps campaigning, and renews the lease while the candidate is the leader, until the candidate resigns.
+	// The candidate must call this method again at least once per lease duration as a keepalive, otherwise the sidecar stops campaigning on its behalf and releases the lease.
+	CampaignLeadershipAlpha1(ctx context.Context, in *CampaignLeadershipRequest, opts ...grpc.CallOption) (*CampaignLeadershipResponse, error)
+	// Stops campaigning for the leadership of an election on behalf of a candidate, releasing the lease if the candidate is the leader.
+	ResignLeadershipAlpha1(ctx context.Context, in *ResignLeadershipRequest, opts ...grpc.CallOption) (*emptypb.Empty, error)
+	// Streams the leader of an election, then every change of leader, until the client disconnects.
+	WatchLeadershipAlpha1(ctx context.Context, in *WatchLeadershipRequest, opts ...grpc.CallOption) (Dapr_WatchLeadershipAlpha1Client, error)
 }
 
 type daprClient struct {
@@ -188,6 +212,38 @@ func (c *daprClient) QueryStateAlpha1(ctx context.Context, in *QueryStateRequest
 	return out, nil
 }
 
+func (c *daprClient) SubscribeStateChangesAlpha1(ctx context.Context, in *SubscribeStateChangesRequest, opts ...grpc.CallOption) (Dapr_SubscribeStateChangesAlpha1Client, error) {
+	stream, err := c.cc.NewStream(ctx, &Dapr_ServiceDesc.Streams[0], "/dapr.proto.runtime.v1.Dapr/SubscribeStateChangesAlpha1", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &daprSubscribeStateChangesAlpha1Client{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type Dapr_SubscribeStateChangesAlpha1Client interface {
+	Recv() (*StateChangeEvent, error)
+	grpc.ClientStream
+}
+
+type daprSubscribeStateChangesAlpha1Client struct {
+	grpc.ClientStream
+}
+
+func (x *daprSubscribeStateChangesAlpha1Client) Recv() (*StateChangeEvent, error) {
+	m := new(StateChangeEvent)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
 func (c *daprClient) DeleteState(ctx context.Context, in *DeleteStateRequest, opts ...grpc.CallOption) (*emptypb.Empty, error) {
 	out := new(emptypb.Empty)
 	err := c.cc.Invoke(ctx, "/dapr.proto.runtime.v1.Dapr/DeleteState", in, out, opts...)
@@ -342,7 +398,7 @@ func (c *daprClient) GetConfiguration(ctx context.Context, in *GetConfigurationR
 }
 
 func (c *daprClient) SubscribeConfigurationAlpha1(ctx context.Context, in *SubscribeConfigurationRequest, opts ...grpc.CallOption) (Dapr_SubscribeConfigurationAlpha1Client, error) {
-	stream, err := c.cc.NewStream(ctx, &Dapr_ServiceDesc.Streams[0], "/dapr.proto.runtime.v1.Dapr/SubscribeConfigurationAlpha1", opts...)
+	stream, err := c.cc.NewStream(ctx, &Dapr_ServiceDesc.Streams[1], "/dapr.proto.runtime.v1.Dapr/SubscribeConfigurationAlpha1", opts...)
 	if err != nil {
 		return nil, err
 	}
@@ -374,7 +430,7 @@ func (x *daprSubscribeConfigurationAlpha1Client) Recv() (*SubscribeConfiguration
 }
 
 func (c *daprClient) SubscribeConfiguration(ctx context.Context, in *SubscribeConfigurationRequest, opts ...grpc.CallOption) (Dapr_SubscribeConfigurationClient, error) {
-	stream, err := c.cc.NewStream(ctx, &Dapr_ServiceDesc.Streams[1], "/dapr.proto.runtime.v1.Dapr/SubscribeConfiguration", opts...)
+	stream, err := c.cc.NewStream(ctx, &Dapr_ServiceDesc.Streams[2], "/dapr.proto.runtime.v1.Dapr/SubscribeConfiguration", opts...)
 	if err != nil {
 		return nil, err
 	}
@@ -442,7 +498,7 @@ func (c *daprClient) UnlockAlpha1(ctx context.Context, in *UnlockRequest, opts .
 }
 
 func (c *daprClient) EncryptAlpha1(ctx context.Context, opts ...grpc.CallOption) (Dapr_EncryptAlpha1Client, error) {
-	stream, err := c.cc.NewStream(ctx, &Dapr_ServiceDesc.Streams[2], "/dapr.proto.runtime.v1.Dapr/EncryptAlpha1", opts...)
+	stream, err := c.cc.NewStream(ctx, &Dapr_ServiceDesc.Streams[3], "/dapr.proto.runtime.v1.Dapr/EncryptAlpha1", opts...)
 	if err != nil {
 		return nil, err
 	}
@@ -473,7 +529,7 @@ func (x *daprEncryptAlpha1Client) Recv() (*EncryptResponse, error) {
 }
 
 func (c *daprClient) DecryptAlpha1(ctx context.Context, opts ...grpc.CallOption) (Dapr_DecryptAlpha1Client, error) {
-	stream, err := c.cc.NewStream(ctx, &Dapr_ServiceDesc.Streams[3], "/dapr.proto.runtime.v1.Dapr/DecryptAlpha1", opts...)
+	stream, err := c.cc.NewStream(ctx, &Dapr_ServiceDesc.Streams[4], "/dapr.proto.runtime.v1.Dapr/DecryptAlpha1", opts...)
 	if err != nil {
 		return nil, err
 	}
@@ -710,6 +766,42 @@ func (c *daprClient) RaiseEventWorkflowBeta1(ctx context.Context, in *RaiseEvent
 	return out, nil
 }
 
+func (c *daprClient) RaiseEventWorkflowBatchBeta1(ctx context.Context, in *RaiseEventWorkflowBatchRequest, opts ...grpc.CallOption) (*RaiseEventWorkflowBatchResponse, error) {
+	out := new(RaiseEventWorkflowBatchResponse)
+	err := c.cc.Invoke(ctx, "/dapr.proto.runtime.v1.Dapr/RaiseEventWorkflowBatchBeta1", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *daprClient) ListWorkflowsAlpha1(ctx context.Context, in *ListWorkflowsRequest, opts ...grpc.CallOption) (*ListWorkflowsResponse, error) {
+	out := new(ListWorkflowsResponse)
+	err := c.cc.Invoke(ctx, "/dapr.proto.runtime.v1.Dapr/ListWorkflowsAlpha1", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *daprClient) GetWorkflowHistoryAlpha1(ctx context.Context, in *GetWorkflowHistoryRequest, opts ...grpc.CallOption) (*GetWorkflowHistoryResponse, error) {
+	out := new(GetWorkflowHistoryResponse)
+	err := c.cc.Invoke(ctx, "/dapr.proto.runtime.v1.Dapr/GetWorkflowHistoryAlpha1", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *daprClient) ReplayWorkflowHistoryAlpha1(ctx context.Context, in *ReplayWorkflowHistoryRequest, opts ...grpc.CallOption) (*ReplayWorkflowHistoryResponse, error) {
+	out := new(ReplayWorkflowHistoryResponse)
+	err := c.cc.Invoke(ctx, "/dapr.proto.runtime.v1.Dapr/ReplayWorkflowHistoryAlpha1", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *daprClient) Shutdown(ctx context.Context, in *ShutdownRequest, opts ...grpc.CallOption) (*emptypb.Empty, error) {
 	out := new(emptypb.Empty)
 	err := c.cc.Invoke(ctx, "/dapr.proto.runtime.v1.Dapr/Shutdown", in, out, opts...)
@@ -719,6 +811,83 @@ func (c *daprClient) Shutdown(ctx context.Context, in *ShutdownRequest, opts ...
 	return out, nil
 }
 
+func (c *daprClient) RecordMetricsAlpha1(ctx context.Context, in *RecordMetricsRequest, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+	out := new(emptypb.Empty)
+	err := c.cc.Invoke(ctx, "/dapr.proto.runtime.v1.Dapr/RecordMetricsAlpha1", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *daprClient) RecordSpansAlpha1(ctx context.Context, in *RecordSpansRequest, opts ...grpc.CallOption) (*RecordSpansResponse, error) {
+	out := new(RecordSpansResponse)
+	err := c.cc.Invoke(ctx, "/dapr.proto.runtime.v1.Dapr/RecordSpansAlpha1", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *daprClient) CheckRateLimitAlpha1(ctx context.Context, in *CheckRateLimitRequest, opts ...grpc.CallOption) (*CheckRateLimitResponse, error) {
+	out := new(CheckRateLimitResponse)
+	err := c.cc.Invoke(ctx, "/dapr.proto.runtime.v1.Dapr/CheckRateLimitAlpha1", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *daprClient) CampaignLeadershipAlpha1(ctx context.Context, in *CampaignLeadershipRequest, opts ...grpc.CallOption) (*CampaignLeadershipResponse, error) {
+	out := new(CampaignLeadershipResponse)
+	err := c.cc.Invoke(ctx, "/dapr.proto.runtime.v1.Dapr/CampaignLeadershipAlpha1", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *daprClient) ResignLeadershipAlpha1(ctx context.Context, in *ResignLeadershipRequest, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+	out := new(emptypb.Empty)
+	err := c.cc.Invoke(ctx, "/dapr.proto.runtime.v1.Dapr/ResignLeadershipAlpha1", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *daprClient) WatchLeadershipAlpha1(ctx context.Context, in *WatchLeadershipRequest, opts ...grpc.CallOption) (Dapr_WatchLeadershipAlpha1Client, error) {
+	stream, err := c.cc.NewStream(ctx, &Dapr_ServiceDesc.Streams[5], "/dapr.proto.runtime.v1.Dapr/WatchLeadershipAlpha1", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &daprWatchLeadershipAlpha1Client{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type Dapr_WatchLeadershipAlpha1Client interface {
+	Recv() (*WatchLeadershipResponse, error)
+	grpc.ClientStream
+}
+
+type daprWatchLeadershipAlpha1Client struct {
+	grpc.ClientStream
+}
+
+func (x *daprWatchLeadershipAlpha1Client) Recv() (*WatchLeadershipResponse, error) {
+	m := new(WatchLeadershipResponse)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
 // DaprServer is the server API for Dapr service.
 // All implementations should embed UnimplementedDaprServer
 // for forward compatibility
@@ -734,6 +903,8 @@ type DaprServer interface {
 	SaveState(context.Context, *SaveStateRequest) (*emptypb.Empty, error)
 	// Queries the state.
 	QueryStateAlpha1(context.Context, *QueryStateRequest) (*QueryStateResponse, error)
+	// Subscribes to the changes of the keys in a state store that supports change feeds, delivered as CloudEvents in a gRPC stream.
+	SubscribeStateChangesAlpha1(*SubscribeStateChangesRequest, Dapr_SubscribeStateChangesAlpha1Server) error
 	// Deletes the state for a specific key.
 	DeleteState(context.Context, *DeleteStateRequest) (*emptypb.Empty, error)
 	// Deletes a bulk of state items for a list of keys
@@ -830,8 +1001,30 @@ type DaprServer interface {
 	ResumeWorkflowBeta1(context.Context, *ResumeWorkflowRequest) (*emptypb.Empty, error)
 	// Raise an event to a running workflow instance
 	RaiseEventWorkflowBeta1(context.Context, *RaiseEventWorkflowRequest) (*emptypb.Empty, error)
+	// Raise events to many workflow instances in a single call, reporting the outcome for each instance
+	RaiseEventWorkflowBatchBeta1(context.Context, *RaiseEventWorkflowBatchRequest) (*RaiseEventWorkflowBatchResponse, error)
+	// Lists the workflow instances, filtered by status, name and creation time
+	ListWorkflowsAlpha1(context.Context, *ListWorkflowsRequest) (*ListWorkflowsResponse, error)
+	// Exports the history of a workflow instance, to replay it with ReplayWorkflowHistoryAlpha1
+	GetWorkflowHistoryAlpha1(context.Context, *GetWorkflowHistoryRequest) (*GetWorkflowHistoryResponse, error)
+	// Replays the history of a workflow instance against the workflow code of the app, reporting where the code diverges from the history
+	ReplayWorkflowHistoryAlpha1(context.Context, *ReplayWorkflowHistoryRequest) (*ReplayWorkflowHistoryResponse, error)
 	// Shutdown the sidecar
 	Shutdown(context.Context, *ShutdownRequest) (*emptypb.Empty, error)
+	// Records measurements of custom metrics of the app, exposed on the metrics endpoint of the sidecar.
+	RecordMetricsAlpha1(context.Context, *RecordMetricsRequest) (*emptypb.Empty, error)
+	// Records spans of the app, and events of the current trace, exported with the tracing configuration of the sidecar.
+	RecordSpansAlpha1(context.Context, *RecordSpansRequest) (*RecordSpansResponse, error)
+	// Consumes units of a distributed rate limiter, whose limit is shared by all the replicas of the app.
+	CheckRateLimitAlpha1(context.Context, *CheckRateLimitRequest) (*CheckRateLimitResponse, error)
+	// Campaigns for the leadership of an election on behalf of a candidate.
+	// The sidecar keeps campaigning, and renews the lease while the candidate is the leader, until the candidate resigns.
+	// The candidate must call this method again at least once per lease duration as a keepalive, otherwise the sidecar stops campaigning on its behalf and releases the lease.
+	CampaignLeadershipAlpha1(context.Context, *CampaignLeadershipRequest) (*CampaignLeadershipResponse, error)
+	// Stops campaigning for the leadership of an election on behalf of a candidate, releasing the lease if the candidate is the leader.
+	ResignLeadershipAlpha1(context.Context, *ResignLeadershipRequest) (*emptypb.Empty, error)
+	// Streams the leader of an election, then every change of leader, until the client disconnects.
+	WatchLeadershipAlpha1(*WatchLeadershipRequest, Dapr_WatchLeadershipAlpha1Server) error
 }
 
 // UnimplementedDaprServer should be embedded to have forward compatible implementations.
@@ -853,6 +1046,9 @@ func (UnimplementedDaprServer) SaveState(context.Context, *SaveStateRequest) (*e
 func (UnimplementedDaprServer) QueryStateAlpha1(context.Context, *QueryStateRequest) (*QueryStateResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method QueryStateAlpha1 not implemented")
 }
+func (UnimplementedDaprServer) SubscribeStateChangesAlpha1(*SubscribeStateChangesRequest, Dapr_SubscribeStateChangesAlpha1Server) error {
+	return status.Errorf(codes.Unimplemented, "method SubscribeStateChangesAlpha1 not implemented")
+}
 func (UnimplementedDaprServer) DeleteState(context.Context, *DeleteStateRequest) (*emptypb.Empty, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method DeleteState not implemented")
 }
@@ -997,9 +1193,39 @@ func (UnimplementedDaprServer) ResumeWorkflowBeta1(context.Context, *ResumeWorkf
 func (UnimplementedDaprServer) RaiseEventWorkflowBeta1(context.Context, *RaiseEventWorkflowRequest) (*emptypb.Empty, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method RaiseEventWorkflowBeta1 not implemented")
 }
+func (UnimplementedDaprServer) RaiseEventWorkflowBatchBeta1(context.Context, *RaiseEventWorkflowBatchRequest) (*RaiseEventWorkflowBatchResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RaiseEventWorkflowBatchBeta1 not implemented")
+}
+func (UnimplementedDaprServer) ListWorkflowsAlpha1(context.Context, *ListWorkflowsRequest) (*ListWorkflowsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListWorkflowsAlpha1 not implemented")
+}
+func (UnimplementedDaprServer) GetWorkflowHistoryAlpha1(context.Context, *GetWorkflowHistoryRequest) (*GetWorkflowHistoryResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetWorkflowHistoryAlpha1 not implemented")
+}
+func (UnimplementedDaprServer) ReplayWorkflowHistoryAlpha1(context.Context, *ReplayWorkflowHistoryRequest) (*ReplayWorkflowHistoryResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ReplayWorkflowHistoryAlpha1 not implemented")
+}
 func (UnimplementedDaprServer) Shutdown(context.Context, *ShutdownRequest) (*emptypb.Empty, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method Shutdown not implemented")
 }
+func (UnimplementedDaprServer) RecordMetricsAlpha1(context.Context, *RecordMetricsRequest) (*emptypb.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RecordMetricsAlpha1 not implemented")
+}
+func (UnimplementedDaprServer) RecordSpansAlpha1(context.Context, *RecordSpansRequest) (*RecordSpansResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RecordSpansAlpha1 not implemented")
+}
+func (UnimplementedDaprServer) CheckRateLimitAlpha1(context.Context, *CheckRateLimitRequest) (*CheckRateLimitResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CheckRateLimitAlpha1 not implemented")
+}
+func (UnimplementedDaprServer) CampaignLeadershipAlpha1(context.Context, *CampaignLeadershipRequest) (*CampaignLeadershipResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CampaignLeadershipAlpha1 not implemented")
+}
+func (UnimplementedDaprServer) ResignLeadershipAlpha1(context.Context, *ResignLeadershipRequest) (*emptypb.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ResignLeadershipAlpha1 not implemented")
+}
+func (UnimplementedDaprServer) WatchLeadershipAlpha1(*WatchLeadershipRequest, Dapr_WatchLeadershipAlpha1Server) error {
+	return status.Errorf(codes.Unimplemented, "method WatchLeadershipAlpha1 not implemented")
+}
 
 // UnsafeDaprServer may be embedded to opt out of forward compatibility for this service.
 // Use of this interface is not recommended, as added methods to DaprServer will
@@ -1102,6 +1328,27 @@ func _Dapr_QueryStateAlpha1_Handler(srv interface{}, ctx context.Context, dec fu
 	return interceptor(ctx, in, info, handler)
 }
 
+func _Dapr_SubscribeStateChangesAlpha1_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(SubscribeStateChangesRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(DaprServer).SubscribeStateChangesAlpha1(m, &daprSubscribeStateChangesAlpha1Server{stream})
+}
+
+type Dapr_SubscribeStateChangesAlpha1Server interface {
+	Send(*StateChangeEvent) error
+	grpc.ServerStream
+}
+
+type daprSubscribeStateChangesAlpha1Server struct {
+	grpc.ServerStream
+}
+
+func (x *daprSubscribeStateChangesAlpha1Server) Send(m *StateChangeEvent) error {
+	return x.ServerStream.SendMsg(m)
+}
+
 func _Dapr_DeleteState_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(DeleteStateRequest)
 	if err := dec(in); err != nil {
@@ -1988,6 +2235,78 @@ func _Dapr_RaiseEventWorkflowBeta1_Handler(srv interface{}, ctx context.Context,
 	return interceptor(ctx, in, info, handler)
 }
 
+func _Dapr_RaiseEventWorkflowBatchBeta1_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RaiseEventWorkflowBatchRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DaprServer).RaiseEventWorkflowBatchBeta1(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/dapr.proto.runtime.v1.Dapr/RaiseEventWorkflowBatchBeta1",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DaprServer).RaiseEventWorkflowBatchBeta1(ctx, req.(*RaiseEventWorkflowBatchRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Dapr_ListWorkflowsAlpha1_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListWorkflowsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DaprServer).ListWorkflowsAlpha1(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/dapr.proto.runtime.v1.Dapr/ListWorkflowsAlpha1",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DaprServer).ListWorkflowsAlpha1(ctx, req.(*ListWorkflowsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Dapr_GetWorkflowHistoryAlpha1_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetWorkflowHistoryRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DaprServer).GetWorkflowHistoryAlpha1(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/dapr.proto.runtime.v1.Dapr/GetWorkflowHistoryAlpha1",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DaprServer).GetWorkflowHistoryAlpha1(ctx, req.(*GetWorkflowHistoryRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Dapr_ReplayWorkflowHistoryAlpha1_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ReplayWorkflowHistoryRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DaprServer).ReplayWorkflowHistoryAlpha1(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/dapr.proto.runtime.v1.Dapr/ReplayWorkflowHistoryAlpha1",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DaprServer).ReplayWorkflowHistoryAlpha1(ctx, req.(*ReplayWorkflowHistoryRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _Dapr_Shutdown_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(ShutdownRequest)
 	if err := dec(in); err != nil {
@@ -2006,6 +2325,117 @@ func _Dapr_Shutdown_Handler(srv interface{}, ctx context.Context, dec func(inter
 	return interceptor(ctx, in, info, handler)
 }
 
+func _Dapr_RecordMetricsAlpha1_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RecordMetricsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DaprServer).RecordMetricsAlpha1(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/dapr.proto.runtime.v1.Dapr/RecordMetricsAlpha1",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DaprServer).RecordMetricsAlpha1(ctx, req.(*RecordMetricsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Dapr_RecordSpansAlpha1_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RecordSpansRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DaprServer).RecordSpansAlpha1(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/dapr.proto.runtime.v1.Dapr/RecordSpansAlpha1",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DaprServer).RecordSpansAlpha1(ctx, req.(*RecordSpansRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Dapr_CheckRateLimitAlpha1_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CheckRateLimitRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DaprServer).CheckRateLimitAlpha1(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/dapr.proto.runtime.v1.Dapr/CheckRateLimitAlpha1",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DaprServer).CheckRateLimitAlpha1(ctx, req.(*CheckRateLimitRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Dapr_CampaignLeadershipAlpha1_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CampaignLeadershipRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DaprServer).CampaignLeadershipAlpha1(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/dapr.proto.runtime.v1.Dapr/CampaignLeadershipAlpha1",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DaprServer).CampaignLeadershipAlpha1(ctx, req.(*CampaignLeadershipRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Dapr_ResignLeadershipAlpha1_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ResignLeadershipRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DaprServer).ResignLeadershipAlpha1(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/dapr.proto.runtime.v1.Dapr/ResignLeadershipAlpha1",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DaprServer).ResignLeadershipAlpha1(ctx, req.(*ResignLeadershipRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Dapr_WatchLeadershipAlpha1_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(WatchLeadershipRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(DaprServer).WatchLeadershipAlpha1(m, &daprWatchLeadershipAlpha1Server{stream})
+}
+
+type Dapr_WatchLeadershipAlpha1Server interface {
+	Send(*WatchLeadershipResponse) error
+	grpc.ServerStream
+}
+
+type daprWatchLeadershipAlpha1Server struct {
+	grpc.ServerStream
+}
+
+func (x *daprWatchLeadershipAlpha1Server) Send(m *WatchLeadershipResponse) error {
+	return x.ServerStream.SendMsg(m)
+}
+
 // Dapr_ServiceDesc is the grpc.ServiceDesc for Dapr service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -2209,12 +2639,53 @@ var Dapr_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "RaiseEventWorkflowBeta1",
 			Handler:    _Dapr_RaiseEventWorkflowBeta1_Handler,
 		},
+		{
+			MethodName: "RaiseEventWorkflowBatchBeta1",
+			Handler:    _Dapr_RaiseEventWorkflowBatchBeta1_Handler,
+		},
+		{
+			MethodName: "ListWorkflowsAlpha1",
+			Handler:    _Dapr_ListWorkflowsAlpha1_Handler,
+		},
+		{
+			MethodName: "GetWorkflowHistoryAlpha1",
+			Handler:    _Dapr_GetWorkflowHistoryAlpha1_Handler,
+		},
+		{
+			MethodName: "ReplayWorkflowHistoryAlpha1",
+			Handler:    _Dapr_ReplayWorkflowHistoryAlpha1_Handler,
+		},
 		{
 			MethodName: "Shutdown",
 			Handler:    _Dapr_Shutdown_Handler,
 		},
+		{
+			MethodName: "RecordMetricsAlpha1",
+			Handler:    _Dapr_RecordMetricsAlpha1_Handler,
+		},
+		{
+			MethodName: "RecordSpansAlpha1",
+			Handler:    _Dapr_RecordSpansAlpha1_Handler,
+		},
+		{
+			MethodName: "CheckRateLimitAlpha1",
+			Handler:    _Dapr_CheckRateLimitAlpha1_Handler,
+		},
+		{
+			MethodName: "CampaignLeadershipAlpha1",
+			Handler:    _Dapr_CampaignLeadershipAlpha1_Handler,
+		},
+		{
+			MethodName: "ResignLeadershipAlpha1",
+			Handler:    _Dapr_ResignLeadershipAlpha1_Handler,
+		},
 	},
 	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "SubscribeStateChangesAlpha1",
+			Handler:       _Dapr_SubscribeStateChangesAlpha1_Handler,
+			ServerStreams: true,
+		},
 		{
 			StreamName:    "SubscribeConfigurationAlpha1",
 			Handler:       _Dapr_SubscribeConfigurationAlpha1_Handler,
@@ -2237,6 +2708,11 @@ var Dapr_ServiceDesc = grpc.ServiceDesc{
 			ServerStreams: true,
 			ClientStreams: true,
 		},
+		{
+			StreamName:    "WatchLeadershipAlpha1",
+			Handler:       _Dapr_WatchLeadershipAlpha1_Handler,
+			ServerStreams: true,
+		},
 	},
 	Metadata: "dapr/proto/runtime/v1/dapr.proto",
 }
@@ -0,0 +1,216 @@
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.2.0
+// - protoc             v3.21.12
+// source: dapr/proto/scheduler/v1/scheduler.proto
+
+package scheduler
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.32.0 or later.
+const _ = grpc.SupportPackageIsVersion7
+
+// SchedulerClient is the client API for Scheduler service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+type SchedulerClient interface {
+	// Schedules a job. A job with the same name replaces the existing one.
+	ScheduleJob(ctx context.Context, in *ScheduleJobRequest, opts ...grpc.CallOption) (*ScheduleJobResponse, error)
+	// Deletes a job.
+	DeleteJob(ctx context.Context, in *DeleteJobRequest, opts ...grpc.CallOption) (*DeleteJobResponse, error)
+	// Streams the jobs of the app to the sidecar when they are triggered.
+	// The sidecar acknowledges each triggered job once it has been processed.
+	WatchJobs(ctx context.Context, opts ...grpc.CallOption) (Scheduler_WatchJobsClient, error)
+}
+
+type schedulerClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewSchedulerClient(cc grpc.ClientConnInterface) SchedulerClient {
+	return &schedulerClient{cc}
+}
+
+func (c *schedulerClient) ScheduleJob(ctx context.Context, in *ScheduleJobRequest, opts ...grpc.CallOption) (*ScheduleJobResponse, error) {
+	out := new(ScheduleJobResponse)
+	err := c.cc.Invoke(ctx, "/dapr.proto.scheduler.v1.Scheduler/ScheduleJob", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *schedulerClient) DeleteJob(ctx context.Context, in *DeleteJobRequest, opts ...grpc.CallOption) (*DeleteJobResponse, error) {
+	out := new(DeleteJobResponse)
+	err := c.cc.Invoke(ctx, "/dapr.proto.scheduler.v1.Scheduler/DeleteJob", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *schedulerClient) WatchJobs(ctx context.Context, opts ...grpc.CallOption) (Scheduler_WatchJobsClient, error) {
+	stream, err := c.cc.NewStream(ctx, &Scheduler_ServiceDesc.Streams[0], "/dapr.proto.scheduler.v1.Scheduler/WatchJobs", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &schedulerWatchJobsClient{stream}
+	return x, nil
+}
+
+type Scheduler_WatchJobsClient interface {
+	Send(*WatchJobsRequest) error
+	Recv() (*WatchJobsResponse, error)
+	grpc.ClientStream
+}
+
+type schedulerWatchJobsClient struct {
+	grpc.ClientStream
+}
+
+func (x *schedulerWatchJobsClient) Send(m *WatchJobsRequest) error {
+	return x.ClientStream.SendMsg(m)
+}
+
+func (x *schedulerWatchJobsClient) Recv() (*WatchJobsResponse, error) {
+	m := new(WatchJobsResponse)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+// SchedulerServer is the server API for Scheduler service.
+// All implementations should embed UnimplementedSchedulerServer
+// for forward compatibility
+type SchedulerServer interface {
+	// Schedules a job. A job with the same name replaces the existing one.
+	ScheduleJob(context.Context, *ScheduleJobRequest) (*ScheduleJobResponse, error)
+	// Deletes a job.
+	DeleteJob(context.Context, *DeleteJobRequest) (*DeleteJobResponse, error)
+	// Streams the jobs of the app to the sidecar when they are triggered.
+	// The sidecar acknowledges each triggered job once it has been processed.
+	WatchJobs(Scheduler_WatchJobsServer) error
+}
+
+// UnimplementedSchedulerServer should be embedded to have forward compatible implementations.
+type UnimplementedSchedulerServer struct {
+}
+
+func (UnimplementedSchedulerServer) ScheduleJob(context.Context, *ScheduleJobRequest) (*ScheduleJobResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ScheduleJob not implemented")
+}
+func (UnimplementedSchedulerServer) DeleteJob(context.Context, *DeleteJobRequest) (*DeleteJobResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DeleteJob not implemented")
+}
+func (UnimplementedSchedulerServer) WatchJobs(Scheduler_WatchJobsServer) error {
+	return status.Errorf(codes.Unimplemented, "method WatchJobs not implemented")
+}
+
+// UnsafeSchedulerServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to SchedulerServer will
+// result in compilation errors.
+type UnsafeSchedulerServer interface {
+	mustEmbedUnimplementedSchedulerServer()
+}
+
+func RegisterSchedulerServer(s grpc.ServiceRegistrar, srv SchedulerServer) {
+	s.RegisterService(&Scheduler_ServiceDesc, srv)
+}
+
+func _Scheduler_ScheduleJob_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ScheduleJobRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SchedulerServer).ScheduleJob(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/dapr.proto.scheduler.v1.Scheduler/ScheduleJob",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SchedulerServer).ScheduleJob(ctx, req.(*ScheduleJobRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Scheduler_DeleteJob_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeleteJobRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SchedulerServer).DeleteJob(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/dapr.proto.scheduler.v1.Scheduler/DeleteJob",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SchedulerServer).DeleteJob(ctx, req.(*DeleteJobRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Scheduler_WatchJobs_Handler(srv interface{}, stream grpc.ServerStream) error {
+	return srv.(SchedulerServer).WatchJobs(&schedulerWatchJobsServer{stream})
+}
+
+type Scheduler_WatchJobsServer interface {
+	Send(*WatchJobsResponse) error
+	Recv() (*WatchJobsRequest, error)
+	grpc.ServerStream
+}
+
+type schedulerWatchJobsServer struct {
+	grpc.ServerStream
+}
+
+func (x *schedulerWatchJobsServer) Send(m *WatchJobsResponse) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func (x *schedulerWatchJobsServer) Recv() (*WatchJobsRequest, error) {
+	m := new(WatchJobsRequest)
+	if err := x.ServerStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+// Scheduler_ServiceDesc is the grpc.ServiceDesc for Scheduler service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var Scheduler_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "dapr.proto.scheduler.v1.Scheduler",
+	HandlerType: (*SchedulerServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "ScheduleJob",
+			Handler:    _Scheduler_ScheduleJob_Handler,
+		},
+		{
+			MethodName: "DeleteJob",
+			Handler:    _Scheduler_DeleteJob_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "WatchJobs",
+			Handler:       _Scheduler_WatchJobs_Handler,
+			ServerStreams: true,
+			ClientStreams: true,
+		},
+	},
+	Metadata: "dapr/proto/scheduler/v1/scheduler.proto",
+}
@@ -0,0 +1,57 @@
+/*
+Copyright 2023 The Dapr Authors
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package ratelimit
+
+import (
+	"net"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/dapr/kit/logger"
+)
+
+const (
+	// MiddlewareType is the type of the rate limit middleware components.
+	MiddlewareType = "middleware.http.ratelimit"
+	// MiddlewareMetadataKey is the metadata property of rate limit middleware components with the name of the distributed rate limiter to use.
+	// Rate limit middlewares without it keep their limits in memory, separately for each replica of the app.
+	MiddlewareMetadataKey = "rateLimiter"
+)
+
+var log = logger.NewLogger("dapr.ratelimit")
+
+// Middleware returns an HTTP middleware that limits the requests of each client, identified by its IP address, with the limiter.
+// name is the name of the middleware component, and it's part of the keys of the limits, so middlewares sharing a limiter have separate limits.
+// Requests are let through if the limit can't be checked, so that an unavailable state store doesn't make the app unavailable too.
+func (l *Limiter) Middleware(name string) func(next http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			client, _, err := net.SplitHostPort(r.RemoteAddr)
+			if err != nil {
+				client = r.RemoteAddr
+			}
+
+			res, err := l.Allow(r.Context(), "middleware||"+name+"||"+client, 1)
+			if err != nil {
+				log.Warnf("Failed to check the rate limit of middleware '%s', allowing the request: %v", name, err)
+			} else if !res.Allowed {
+				w.Header().Set("Retry-After", strconv.Itoa(int((res.ResetAfter+time.Second-1)/time.Second)))
+				http.Error(w, http.StatusText(http.StatusTooManyRequests), http.StatusTooManyRequests)
+				return
+			}
+			next.ServeHTTP(w, r)
+		})
+	}
+}
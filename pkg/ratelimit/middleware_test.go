@@ -0,0 +1,79 @@
+/*
+Copyright 2023 The Dapr Authors
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package ratelimit
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/dapr/components-contrib/state"
+	"github.com/dapr/dapr/pkg/config"
+)
+
+func TestLimiterMiddleware(t *testing.T) {
+	store := newETagStateStore()
+	limiters, err := New(Options{
+		AppID: "myapp",
+		Specs: []config.RateLimiterSpec{
+			{Name: "clients", StateStore: "store", Limit: 2, Window: "1m"},
+			{Name: "missing", StateStore: "other", Limit: 2},
+		},
+		GetStateStoreFn: func(name string) (state.Store, bool) {
+			return store, name == "store"
+		},
+		nowFn: func() time.Time {
+			return time.Date(2023, 1, 1, 0, 0, 30, 0, time.UTC)
+		},
+	})
+	require.NoError(t, err)
+
+	next := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	})
+	do := func(handler http.Handler, remoteAddr string) *httptest.ResponseRecorder {
+		r := httptest.NewRequest(http.MethodGet, "/", nil)
+		r.RemoteAddr = remoteAddr
+		w := httptest.NewRecorder()
+		handler.ServeHTTP(w, r)
+		return w
+	}
+
+	t.Run("requests over the limit of the client are rejected", func(t *testing.T) {
+		handler := limiters["clients"].Middleware("ratelimit")(next)
+		for i := 0; i < 2; i++ {
+			assert.Equal(t, http.StatusOK, do(handler, "10.0.0.1:1234").Code)
+		}
+		w := do(handler, "10.0.0.1:5678")
+		assert.Equal(t, http.StatusTooManyRequests, w.Code)
+		assert.Equal(t, "30", w.Header().Get("Retry-After"))
+
+		// Clients have separate limits
+		assert.Equal(t, http.StatusOK, do(handler, "10.0.0.2:1234").Code)
+	})
+
+	t.Run("middlewares sharing a limiter have separate limits", func(t *testing.T) {
+		handler := limiters["clients"].Middleware("other")(next)
+		assert.Equal(t, http.StatusOK, do(handler, "10.0.0.1:1234").Code)
+	})
+
+	t.Run("requests are allowed if the limit can't be checked", func(t *testing.T) {
+		handler := limiters["missing"].Middleware("ratelimit")(next)
+		assert.Equal(t, http.StatusOK, do(handler, "10.0.0.1:1234").Code)
+	})
+}
@@ -0,0 +1,220 @@
+/*
+Copyright 2023 The Dapr Authors
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package ratelimit implements distributed rate limiters, whose limits are shared by all the replicas of an app.
+package ratelimit
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	contribMetadata "github.com/dapr/components-contrib/metadata"
+	"github.com/dapr/components-contrib/state"
+	"github.com/dapr/dapr/pkg/config"
+)
+
+const (
+	// statePrefix is the prefix of the keys of the counters. It's reserved in pkg/components/state, so the apps can't write these keys.
+	statePrefix = "dapr-ratelimit"
+
+	// maxAttempts is the number of times the counter of a key is updated when other replicas update it concurrently.
+	maxAttempts = 10
+)
+
+var (
+	// ErrStateStoreNotFound is returned when the state store of a rate limiter is not loaded.
+	ErrStateStoreNotFound = errors.New("state store not found")
+	// ErrInvalidUnits is returned when the number of units to check is not positive.
+	ErrInvalidUnits = errors.New("units must be greater than 0")
+)
+
+// Options contains the options for New.
+type Options struct {
+	AppID           string
+	Specs           []config.RateLimiterSpec
+	GetStateStoreFn func(string) (state.Store, bool)
+	// nowFn returns the current time. Used in tests.
+	nowFn func() time.Time
+}
+
+// Limiters are the rate limiters of the app, keyed by name.
+type Limiters map[string]*Limiter
+
+// New returns the rate limiters configured in the specs.
+func New(opts Options) (Limiters, error) {
+	if len(opts.Specs) == 0 {
+		return nil, nil
+	}
+	if opts.nowFn == nil {
+		opts.nowFn = time.Now
+	}
+
+	limiters := make(Limiters, len(opts.Specs))
+	for _, spec := range opts.Specs {
+		if spec.Name == "" {
+			return nil, errors.New("rate limiter name is empty")
+		}
+		if _, ok := limiters[spec.Name]; ok {
+			return nil, fmt.Errorf("duplicate rate limiter '%s'", spec.Name)
+		}
+		if spec.StateStore == "" {
+			return nil, fmt.Errorf("state store of rate limiter '%s' is empty", spec.Name)
+		}
+		if spec.Limit < 1 {
+			return nil, fmt.Errorf("limit of rate limiter '%s' must be greater than 0", spec.Name)
+		}
+		window, err := spec.GetWindow()
+		if err != nil {
+			return nil, err
+		}
+
+		limiters[spec.Name] = &Limiter{
+			name:            spec.Name,
+			appID:           opts.AppID,
+			stateStore:      spec.StateStore,
+			limit:           spec.Limit,
+			window:          window,
+			getStateStoreFn: opts.GetStateStoreFn,
+			nowFn:           opts.nowFn,
+		}
+	}
+	return limiters, nil
+}
+
+// Result is the result of a rate limit check.
+type Result struct {
+	// Allowed is true if the units were within the limit, and were consumed.
+	Allowed bool
+	// Remaining is the number of units left in the current window.
+	Remaining int64
+	// ResetAfter is the time until the current window ends, and the units are available again.
+	ResetAfter time.Duration
+}
+
+// Limiter is a distributed rate limiter that allows a number of units per key in fixed windows of time.
+// The counters are kept in a state store, and are updated with optimistic concurrency, so the limit is the same for all the replicas of the app.
+// Windows are aligned on the Unix epoch, so the replicas agree on them as long as their clocks are synchronized.
+type Limiter struct {
+	name            string
+	appID           string
+	stateStore      string
+	limit           int64
+	window          time.Duration
+	getStateStoreFn func(string) (state.Store, bool)
+	nowFn           func() time.Time
+}
+
+// Allow consumes n units for the key if they're within the limit of the current window.
+// Units are consumed only if they're all allowed: a request that would exceed the limit consumes nothing.
+func (l *Limiter) Allow(ctx context.Context, key string, n int64) (Result, error) {
+	if n < 1 {
+		return Result{}, ErrInvalidUnits
+	}
+	store, ok := l.getStateStore()
+	if !ok {
+		return Result{}, fmt.Errorf("%w: %s", ErrStateStoreNotFound, l.stateStore)
+	}
+	features := store.Features()
+	if !state.FeatureETag.IsPresent(features) {
+		return Result{}, fmt.Errorf("state store %s does not support ETags", l.stateStore)
+	}
+	// Without TTLs the counters of the past windows would never be deleted
+	if !state.FeatureTTL.IsPresent(features) {
+		return Result{}, fmt.Errorf("state store %s does not support TTLs", l.stateStore)
+	}
+
+	now := l.nowFn()
+	windowStart := now.Truncate(l.window)
+	resetAfter := windowStart.Add(l.window).Sub(now)
+	stateKey := statePrefix + "||" + l.appID + "||" + l.name + "||" + key + "||" + strconv.FormatInt(windowStart.UnixMilli(), 10)
+
+	// Counters expire with their window, with a margin for the clock skew between replicas
+	ttl := strconv.Itoa(int((l.window + time.Second).Seconds()) + 1)
+
+	var insertErr error
+	for i := 0; i < maxAttempts; i++ {
+		res, err := store.Get(ctx, &state.GetRequest{Key: stateKey})
+		if err != nil {
+			return Result{}, fmt.Errorf("failed to get the counter: %w", err)
+		}
+		exists := res != nil && res.ETag != nil
+		if !exists && insertErr != nil {
+			// The counter couldn't be created, and not because another replica created it first
+			return Result{}, fmt.Errorf("failed to create the counter: %w", insertErr)
+		}
+		var count int64
+		if res != nil && len(res.Data) > 0 {
+			count, err = strconv.ParseInt(strings.Trim(strings.TrimSpace(string(res.Data)), `"`), 10, 64)
+			if err != nil {
+				return Result{}, fmt.Errorf("invalid counter: %w", err)
+			}
+		}
+
+		if count+n > l.limit {
+			return Result{
+				Allowed:    false,
+				Remaining:  max(l.limit-count, 0),
+				ResetAfter: resetAfter,
+			}, nil
+		}
+
+		// The first write of the window is insert-only, as FirstWrite without an ETag fails if the key exists,
+		// so concurrent replicas can't overwrite each other's counts
+		req := &state.SetRequest{
+			Key:   stateKey,
+			Value: count + n,
+			Metadata: map[string]string{
+				contribMetadata.TTLMetadataKey: ttl,
+			},
+			Options: state.SetStateOption{
+				Concurrency: state.FirstWrite,
+			},
+		}
+		if exists {
+			req.ETag = res.ETag
+		}
+		err = store.Set(ctx, req)
+		var etagErr *state.ETagError
+		switch {
+		case err == nil:
+			return Result{
+				Allowed:    true,
+				Remaining:  l.limit - count - n,
+				ResetAfter: resetAfter,
+			}, nil
+		case errors.As(err, &etagErr):
+			// Another replica updated the counter: read it again
+			continue
+		case !exists:
+			// Not all state stores return an ETagError when the key of an insert-only write exists already:
+			// read the counter again, to find out whether another replica created it
+			insertErr = err
+			continue
+		default:
+			return Result{}, fmt.Errorf("failed to update the counter: %w", err)
+		}
+	}
+
+	return Result{}, fmt.Errorf("failed to update the counter after %d attempts because of concurrent updates", maxAttempts)
+}
+
+func (l *Limiter) getStateStore() (state.Store, bool) {
+	if l.getStateStoreFn == nil {
+		return nil, false
+	}
+	return l.getStateStoreFn(l.stateStore)
+}
@@ -0,0 +1,306 @@
+/*
+Copyright 2023 The Dapr Authors
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package ratelimit
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"strconv"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/dapr/components-contrib/state"
+	"github.com/dapr/dapr/pkg/config"
+	daprt "github.com/dapr/dapr/pkg/testing"
+)
+
+// etagStateStore is a state store that enforces ETags on Set, like the stores used in production.
+type etagStateStore struct {
+	*daprt.FakeStateStore
+
+	lock  sync.Mutex
+	items map[string]etagItem
+	etag  int
+	// If true, insert-only writes of keys that exist fail with a plain error instead of an ETagError, like in Redis.
+	plainErrors bool
+}
+
+type etagItem struct {
+	data []byte
+	etag string
+}
+
+func newETagStateStore() *etagStateStore {
+	return &etagStateStore{
+		FakeStateStore: daprt.NewFakeStateStore(),
+		items:          map[string]etagItem{},
+	}
+}
+
+func (s *etagStateStore) Get(ctx context.Context, req *state.GetRequest) (*state.GetResponse, error) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	item, ok := s.items[req.Key]
+	if !ok {
+		return &state.GetResponse{}, nil
+	}
+	etag := item.etag
+	return &state.GetResponse{Data: item.data, ETag: &etag}, nil
+}
+
+func (s *etagStateStore) Features() []state.Feature {
+	return []state.Feature{state.FeatureETag, state.FeatureTTL}
+}
+
+func (s *etagStateStore) Set(ctx context.Context, req *state.SetRequest) error {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	item, ok := s.items[req.Key]
+	switch {
+	case req.ETag != nil && (!ok || item.etag != *req.ETag):
+		return state.NewETagError(state.ETagMismatch, errors.New("etag mismatch"))
+	case req.ETag == nil && ok && req.Options.Concurrency == state.FirstWrite:
+		if s.plainErrors {
+			return errors.New("key already exists")
+		}
+		return state.NewETagError(state.ETagMismatch, errors.New("key already exists"))
+	}
+	data, err := json.Marshal(req.Value)
+	if err != nil {
+		return err
+	}
+	s.etag++
+	s.items[req.Key] = etagItem{data: data, etag: strconv.Itoa(s.etag)}
+	return nil
+}
+
+// failingStateStore is a state store whose writes always fail.
+type failingStateStore struct {
+	*etagStateStore
+
+	sets int
+}
+
+func (s *failingStateStore) Set(ctx context.Context, req *state.SetRequest) error {
+	s.sets++
+	return errors.New("simulated")
+}
+
+func TestNew(t *testing.T) {
+	t.Run("no rate limiters", func(t *testing.T) {
+		limiters, err := New(Options{})
+		require.NoError(t, err)
+		assert.Nil(t, limiters)
+	})
+
+	t.Run("valid rate limiters", func(t *testing.T) {
+		limiters, err := New(Options{
+			Specs: []config.RateLimiterSpec{
+				{Name: "a", StateStore: "store", Limit: 10},
+				{Name: "b", StateStore: "store", Limit: 100, Window: "1m"},
+			},
+		})
+		require.NoError(t, err)
+		require.Len(t, limiters, 2)
+		assert.Equal(t, time.Second, limiters["a"].window)
+		assert.Equal(t, time.Minute, limiters["b"].window)
+	})
+
+	invalid := map[string]config.RateLimiterSpec{
+		"empty name":         {StateStore: "store", Limit: 10},
+		"empty state store":  {Name: "a", Limit: 10},
+		"limit not positive": {Name: "a", StateStore: "store"},
+		"invalid window":     {Name: "a", StateStore: "store", Limit: 10, Window: "foo"},
+		"window too short":   {Name: "a", StateStore: "store", Limit: 10, Window: "1us"},
+	}
+	for name, spec := range invalid {
+		t.Run(name, func(t *testing.T) {
+			_, err := New(Options{Specs: []config.RateLimiterSpec{spec}})
+			require.Error(t, err)
+		})
+	}
+
+	t.Run("duplicate names", func(t *testing.T) {
+		_, err := New(Options{
+			Specs: []config.RateLimiterSpec{
+				{Name: "a", StateStore: "store", Limit: 10},
+				{Name: "a", StateStore: "store", Limit: 20},
+			},
+		})
+		require.Error(t, err)
+	})
+}
+
+func TestLimiterAllow(t *testing.T) {
+	store := newETagStateStore()
+	var now atomic.Pointer[time.Time]
+	setNow := func(t time.Time) {
+		now.Store(&t)
+	}
+	setNow(time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC))
+
+	limiters, err := New(Options{
+		AppID: "myapp",
+		Specs: []config.RateLimiterSpec{
+			{Name: "orders", StateStore: "store", Limit: 5, Window: "1m"},
+			{Name: "missing", StateStore: "other", Limit: 5},
+			{Name: "nottl", StateStore: "nottl", Limit: 5},
+		},
+		GetStateStoreFn: func(name string) (state.Store, bool) {
+			switch name {
+			case "store":
+				return store, true
+			case "nottl":
+				return daprt.NewFakeStateStore(), true
+			default:
+				return nil, false
+			}
+		},
+		nowFn: func() time.Time {
+			return *now.Load()
+		},
+	})
+	require.NoError(t, err)
+	l := limiters["orders"]
+
+	t.Run("units within the limit are allowed", func(t *testing.T) {
+		res, err := l.Allow(context.Background(), "customer-1", 2)
+		require.NoError(t, err)
+		assert.True(t, res.Allowed)
+		assert.Equal(t, int64(3), res.Remaining)
+		assert.Equal(t, time.Minute, res.ResetAfter)
+
+		res, err = l.Allow(context.Background(), "customer-1", 3)
+		require.NoError(t, err)
+		assert.True(t, res.Allowed)
+		assert.Equal(t, int64(0), res.Remaining)
+	})
+
+	t.Run("units over the limit are rejected", func(t *testing.T) {
+		setNow(time.Date(2023, 1, 1, 0, 0, 20, 0, time.UTC))
+		res, err := l.Allow(context.Background(), "customer-1", 1)
+		require.NoError(t, err)
+		assert.False(t, res.Allowed)
+		assert.Equal(t, int64(0), res.Remaining)
+		assert.Equal(t, 40*time.Second, res.ResetAfter)
+	})
+
+	t.Run("keys have separate limits", func(t *testing.T) {
+		res, err := l.Allow(context.Background(), "customer-2", 5)
+		require.NoError(t, err)
+		assert.True(t, res.Allowed)
+
+		// A request that would exceed the limit consumes nothing
+		res, err = l.Allow(context.Background(), "customer-3", 6)
+		require.NoError(t, err)
+		assert.False(t, res.Allowed)
+		assert.Equal(t, int64(5), res.Remaining)
+	})
+
+	t.Run("limit is reset in the next window", func(t *testing.T) {
+		setNow(time.Date(2023, 1, 1, 0, 1, 0, 0, time.UTC))
+		res, err := l.Allow(context.Background(), "customer-1", 1)
+		require.NoError(t, err)
+		assert.True(t, res.Allowed)
+		assert.Equal(t, int64(4), res.Remaining)
+	})
+
+	t.Run("concurrent requests don't exceed the limit", func(t *testing.T) {
+		setNow(time.Date(2023, 1, 1, 0, 2, 0, 0, time.UTC))
+		var (
+			wg      sync.WaitGroup
+			allowed atomic.Int32
+		)
+		for i := 0; i < 5; i++ {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				res, err := l.Allow(context.Background(), "customer-4", 2)
+				if assert.NoError(t, err) && res.Allowed {
+					allowed.Add(1)
+				}
+			}()
+		}
+		wg.Wait()
+		assert.Equal(t, int32(2), allowed.Load())
+	})
+
+	t.Run("concurrent first writes don't exceed the limit", func(t *testing.T) {
+		store.lock.Lock()
+		store.plainErrors = true
+		store.lock.Unlock()
+		defer func() {
+			store.lock.Lock()
+			store.plainErrors = false
+			store.lock.Unlock()
+		}()
+
+		setNow(time.Date(2023, 1, 1, 0, 3, 0, 0, time.UTC))
+		var (
+			wg      sync.WaitGroup
+			allowed atomic.Int32
+		)
+		for i := 0; i < 5; i++ {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				res, err := l.Allow(context.Background(), "customer-5", 2)
+				if assert.NoError(t, err) && res.Allowed {
+					allowed.Add(1)
+				}
+			}()
+		}
+		wg.Wait()
+		assert.Equal(t, int32(2), allowed.Load())
+	})
+
+	t.Run("counter can't be created", func(t *testing.T) {
+		failing := &failingStateStore{etagStateStore: newETagStateStore()}
+		limiters, err := New(Options{
+			Specs: []config.RateLimiterSpec{
+				{Name: "failing", StateStore: "store", Limit: 5},
+			},
+			GetStateStoreFn: func(name string) (state.Store, bool) {
+				return failing, true
+			},
+		})
+		require.NoError(t, err)
+		_, err = limiters["failing"].Allow(context.Background(), "customer-1", 1)
+		require.ErrorContains(t, err, "failed to create the counter")
+		assert.Equal(t, 1, failing.sets)
+	})
+
+	t.Run("invalid units", func(t *testing.T) {
+		_, err := l.Allow(context.Background(), "customer-1", 0)
+		require.ErrorIs(t, err, ErrInvalidUnits)
+	})
+
+	t.Run("state store not found", func(t *testing.T) {
+		_, err := limiters["missing"].Allow(context.Background(), "customer-1", 1)
+		require.ErrorIs(t, err, ErrStateStoreNotFound)
+	})
+
+	t.Run("state store without TTLs", func(t *testing.T) {
+		_, err := limiters["nottl"].Allow(context.Background(), "customer-1", 1)
+		require.ErrorContains(t, err, "does not support TTLs")
+	})
+}
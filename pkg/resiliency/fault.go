@@ -0,0 +1,100 @@
+/*
+Copyright 2023 The Dapr Authors
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package resiliency
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/rand"
+	"time"
+
+	resiliencyV1alpha "github.com/dapr/dapr/pkg/apis/resiliency/v1alpha1"
+)
+
+// ErrFaultInjected is the error returned by operations that failed because of a fault injection policy.
+var ErrFaultInjected = errors.New("fault injected by resiliency policy")
+
+// FaultPolicy injects latency and errors into operations, so resiliency policies can be tested without external chaos tooling.
+type FaultPolicy struct {
+	Delay           time.Duration
+	DelayPercentage int
+	ErrorPercentage int
+
+	// Returns a number in the [0,100) range; can be overridden in tests.
+	randFn func() int
+}
+
+func decodeFaultPolicy(f resiliencyV1alpha.Fault) (*FaultPolicy, error) {
+	fp := &FaultPolicy{
+		DelayPercentage: f.DelayPercentage,
+		ErrorPercentage: f.ErrorPercentage,
+	}
+
+	if f.Delay != "" {
+		var err error
+		fp.Delay, err = parseDuration(f.Delay)
+		if err != nil {
+			return nil, fmt.Errorf("invalid delay %q: %w", f.Delay, err)
+		}
+		// If a delay is set without a percentage, it's applied to all operations
+		if fp.DelayPercentage == 0 {
+			fp.DelayPercentage = 100
+		}
+	}
+
+	if fp.DelayPercentage < 0 || fp.DelayPercentage > 100 {
+		return nil, fmt.Errorf("invalid delayPercentage %d: must be between 0 and 100", fp.DelayPercentage)
+	}
+	if fp.ErrorPercentage < 0 || fp.ErrorPercentage > 100 {
+		return nil, fmt.Errorf("invalid errorPercentage %d: must be between 0 and 100", fp.ErrorPercentage)
+	}
+
+	return fp, nil
+}
+
+// inject applies the fault policy before an operation is executed.
+// It returns ErrFaultInjected if the operation should fail, or the context's error if the context is canceled while the delay is in progress.
+func (f *FaultPolicy) inject(ctx context.Context) error {
+	if f.Delay > 0 && f.hit(f.DelayPercentage) {
+		t := time.NewTimer(f.Delay)
+		select {
+		case <-t.C:
+		case <-ctx.Done():
+			if !t.Stop() {
+				<-t.C
+			}
+			return ctx.Err()
+		}
+	}
+
+	if f.hit(f.ErrorPercentage) {
+		return ErrFaultInjected
+	}
+	return nil
+}
+
+func (f *FaultPolicy) hit(percentage int) bool {
+	if percentage <= 0 {
+		return false
+	}
+	if percentage >= 100 {
+		return true
+	}
+	if f.randFn != nil {
+		return f.randFn() < percentage
+	}
+	//nolint:gosec
+	return rand.Intn(100) < percentage
+}
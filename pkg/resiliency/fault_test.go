@@ -0,0 +1,130 @@
+/*
+Copyright 2023 The Dapr Authors
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package resiliency
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	resiliencyV1alpha "github.com/dapr/dapr/pkg/apis/resiliency/v1alpha1"
+)
+
+func TestDecodeFaultPolicy(t *testing.T) {
+	t.Run("delay defaults to all operations", func(t *testing.T) {
+		fp, err := decodeFaultPolicy(resiliencyV1alpha.Fault{Delay: "2s"})
+		require.NoError(t, err)
+		assert.Equal(t, 2*time.Second, fp.Delay)
+		assert.Equal(t, 100, fp.DelayPercentage)
+		assert.Equal(t, 0, fp.ErrorPercentage)
+	})
+
+	t.Run("invalid delay", func(t *testing.T) {
+		_, err := decodeFaultPolicy(resiliencyV1alpha.Fault{Delay: "foo"})
+		require.Error(t, err)
+	})
+
+	t.Run("invalid percentages", func(t *testing.T) {
+		_, err := decodeFaultPolicy(resiliencyV1alpha.Fault{ErrorPercentage: 101})
+		require.Error(t, err)
+		_, err = decodeFaultPolicy(resiliencyV1alpha.Fault{Delay: "1s", DelayPercentage: -1})
+		require.Error(t, err)
+	})
+}
+
+func TestFaultPolicyInject(t *testing.T) {
+	t.Run("error percentage", func(t *testing.T) {
+		fp := &FaultPolicy{
+			ErrorPercentage: 50,
+			randFn:          func() int { return 49 },
+		}
+		require.ErrorIs(t, fp.inject(context.Background()), ErrFaultInjected)
+
+		fp.randFn = func() int { return 50 }
+		require.NoError(t, fp.inject(context.Background()))
+	})
+
+	t.Run("delay is interrupted by context", func(t *testing.T) {
+		fp := &FaultPolicy{
+			Delay:           time.Minute,
+			DelayPercentage: 100,
+		}
+		ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+		defer cancel()
+		require.ErrorIs(t, fp.inject(ctx), context.DeadlineExceeded)
+	})
+}
+
+func TestFaultInjectionPolicies(t *testing.T) {
+	config := &resiliencyV1alpha.Resiliency{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "resiliency",
+		},
+		Spec: resiliencyV1alpha.ResiliencySpec{
+			Policies: resiliencyV1alpha.Policies{
+				Timeouts: map[string]string{
+					"fast": "100ms",
+				},
+				Faults: map[string]resiliencyV1alpha.Fault{
+					"failAll": {ErrorPercentage: 100},
+					"slow":    {Delay: "1m"},
+				},
+			},
+			Targets: resiliencyV1alpha.Targets{
+				Apps: map[string]resiliencyV1alpha.EndpointPolicyNames{
+					"app1": {Fault: "failAll"},
+				},
+				Components: map[string]resiliencyV1alpha.ComponentPolicyNames{
+					"statestore": {
+						Outbound: resiliencyV1alpha.PolicyNames{
+							Timeout: "fast",
+							Fault:   "slow",
+						},
+					},
+				},
+			},
+		},
+	}
+
+	run := func(def *PolicyDefinition) error {
+		_, err := NewRunner[any](context.Background(), def)(func(ctx context.Context) (any, error) {
+			return nil, nil
+		})
+		return err
+	}
+
+	t.Run("disabled by default", func(t *testing.T) {
+		r := FromConfigurations(log, config)
+		require.NoError(t, run(r.EndpointPolicy("app1", "method")))
+		require.NoError(t, run(r.ComponentOutboundPolicy("statestore", Statestore)))
+	})
+
+	t.Run("enabled", func(t *testing.T) {
+		r := FromConfigurations(log, config)
+		r.EnableFaultInjection()
+		require.ErrorIs(t, run(r.EndpointPolicy("app1", "method")), ErrFaultInjected)
+
+		// The injected delay is interrupted by the timeout policy
+		start := time.Now()
+		require.ErrorIs(t, run(r.ComponentOutboundPolicy("statestore", Statestore)), context.DeadlineExceeded)
+		assert.Less(t, time.Since(start), 10*time.Second)
+
+		// Other targets are unaffected
+		require.NoError(t, run(r.EndpointPolicy("app2", "method")))
+	})
+}
@@ -23,6 +23,7 @@ import (
 	"time"
 
 	"github.com/cenkalti/backoff/v4"
+	"go.opentelemetry.io/otel/attribute"
 
 	"github.com/dapr/dapr/pkg/resiliency/breaker"
 	"github.com/dapr/kit/logger"
@@ -51,6 +52,7 @@ type PolicyDefinition struct {
 	t                         time.Duration
 	r                         *retry.Config
 	cb                        *breaker.CircuitBreaker
+	fault                     *FaultPolicy
 	addTimeoutActivatedMetric func()
 	addRetryActivatedMetric   func()
 	addCBStateChangedMetric   func()
@@ -70,8 +72,8 @@ func NewPolicyDefinition(log logger.Logger, name string, t time.Duration, r *ret
 // String implements fmt.Stringer and is used for debugging.
 func (p PolicyDefinition) String() string {
 	return fmt.Sprintf(
-		"Policy: name='%s' timeout='%v' retry=(%v) circuitBreaker=(%v)",
-		p.name, p.t, p.r, p.cb,
+		"Policy: name='%s' timeout='%v' retry=(%v) circuitBreaker=(%v) fault=(%v)",
+		p.name, p.t, p.r, p.cb, p.fault,
 	)
 }
 
@@ -112,6 +114,18 @@ func NewRunnerWithOptions[T any](ctx context.Context, def *PolicyDefinition, opt
 	timeoutMetricsActivated := atomic.Bool{}
 	return func(oper Operation[T]) (T, error) {
 		operation := oper
+		if def.fault != nil {
+			// Inject faults before the other policies are applied, so they can react to them
+			operCopy := operation
+			operation = func(ctx context.Context) (T, error) {
+				if fErr := def.fault.inject(ctx); fErr != nil {
+					def.addSpanEvent(ctx, SpanEventFault, attribute.String(SpanAttributeError, fErr.Error()))
+					return zero, fErr
+				}
+				return operCopy(ctx)
+			}
+		}
+
 		if def.t > 0 {
 			// Handle timeout
 			operCopy := operation
@@ -159,6 +173,7 @@ func NewRunnerWithOptions[T any](ctx context.Context, def *PolicyDefinition, opt
 					if def.addTimeoutActivatedMetric != nil && timeoutMetricsActivated.CompareAndSwap(false, true) {
 						def.addTimeoutActivatedMetric()
 					}
+					def.addSpanEvent(ctx, SpanEventTimeout, attribute.String(SpanAttributeTimeout, def.t.String()))
 					return zero, ctx.Err()
 				}
 			}
@@ -182,12 +197,21 @@ func NewRunnerWithOptions[T any](ctx context.Context, def *PolicyDefinition, opt
 				resAny, err := def.cb.Execute(func() (any, error) {
 					return operCopy(ctx)
 				})
-				if def.addCBStateChangedMetric != nil && prevState != def.cb.State() {
-					def.addCBStateChangedMetric()
+				if newState := def.cb.State(); prevState != newState {
+					if def.addCBStateChangedMetric != nil {
+						def.addCBStateChangedMetric()
+					}
+					def.addSpanEvent(ctx, SpanEventCircuitBreakerState,
+						attribute.String(SpanAttributeCircuitBreakerPreviousState, string(prevState)),
+						attribute.String(SpanAttributeCircuitBreakerState, string(newState)),
+					)
 				}
-				if def.r != nil && breaker.IsErrorPermanent(err) {
-					// Break out of retry
-					err = backoff.Permanent(err)
+				if breaker.IsErrorPermanent(err) {
+					def.addSpanEvent(ctx, SpanEventCircuitBreakerBlocked, attribute.String(SpanAttributeCircuitBreakerState, string(def.cb.State())))
+					if def.r != nil {
+						// Break out of retry
+						err = backoff.Permanent(err)
+					}
 				}
 				res, ok := resAny.(T)
 				if !ok && resAny != nil {
@@ -225,6 +249,11 @@ func NewRunnerWithOptions[T any](ctx context.Context, def *PolicyDefinition, opt
 				if def.addRetryActivatedMetric != nil {
 					def.addRetryActivatedMetric()
 				}
+				def.addSpanEvent(ctx, SpanEventRetry,
+					attribute.Int(SpanAttributeRetryAttempt, int(attempts.Load())),
+					attribute.String(SpanAttributeRetryDelay, d.String()),
+					attribute.String(SpanAttributeError, opErr.Error()),
+				)
 				def.log.Infof("Error processing operation %s. Retrying in %v…", def.name, d)
 				def.log.Debugf("Error for operation %s was: %v", def.name, opErr)
 			},
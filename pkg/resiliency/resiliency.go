@@ -113,6 +113,10 @@ type (
 		timeouts        map[string]time.Duration
 		retries         map[string]*retry.Config
 		circuitBreakers map[string]*breaker.CircuitBreaker
+		faults          map[string]*FaultPolicy
+
+		// Fault injection policies are applied only if this is enabled with the "FaultInjection" preview feature.
+		faultInjectionEnabled bool
 
 		actorCBCaches    map[string]*lru.Cache[string, *breaker.CircuitBreaker]
 		actorCBsCachesMu sync.RWMutex
@@ -139,12 +143,13 @@ type (
 		Outbound PolicyNames
 	}
 
-	// PolicyNames contains the policy names for a timeout, retry, and circuit breaker.
+	// PolicyNames contains the policy names for a timeout, retry, circuit breaker, and fault injection.
 	// Empty values mean that no policy is configured.
 	PolicyNames struct {
 		Timeout        string
 		Retry          string
 		CircuitBreaker string
+		Fault          string
 	}
 
 	// Actors have different behavior before and after locking.
@@ -190,29 +195,41 @@ var _ = (Provider)((*Resiliency)(nil))
 
 // LoadLocalResiliency loads resiliency configurations from local folders.
 func LoadLocalResiliency(log logger.Logger, runtimeID string, paths ...string) []*resiliencyV1alpha.Resiliency {
+	configs, errs := LoadLocalResiliencyWithErrors(log, runtimeID, paths...)
+	for _, err := range errs {
+		log.Error(err)
+	}
+	return configs
+}
+
+// LoadLocalResiliencyWithErrors loads resiliency configurations from local folders.
+// Errors encountered while reading or parsing individual files are returned instead of being logged, and those files are skipped.
+func LoadLocalResiliencyWithErrors(log logger.Logger, runtimeID string, paths ...string) ([]*resiliencyV1alpha.Resiliency, []error) {
 	configs := []*resiliencyV1alpha.Resiliency{}
+	errs := []error{}
 	for _, path := range paths {
-		loaded := loadLocalResiliencyPath(log, runtimeID, path)
+		loaded, loadErrs := loadLocalResiliencyPath(log, runtimeID, path)
 		if len(loaded) > 0 {
 			configs = append(configs, loaded...)
 		}
+		errs = append(errs, loadErrs...)
 	}
-	return configs
+	return configs, errs
 }
 
-func loadLocalResiliencyPath(log logger.Logger, runtimeID string, path string) []*resiliencyV1alpha.Resiliency {
+func loadLocalResiliencyPath(log logger.Logger, runtimeID string, path string) ([]*resiliencyV1alpha.Resiliency, []error) {
 	_, err := os.Stat(path)
 	if os.IsNotExist(err) {
-		return nil
+		return nil, nil
 	}
 
 	files, err := os.ReadDir(path)
 	if err != nil {
-		log.Errorf("Failed to read resiliency files from path %s: %v", path, err)
-		return nil
+		return nil, []error{fmt.Errorf("failed to read resiliency files from path %s: %w", path, err)}
 	}
 
 	configs := make([]*resiliencyV1alpha.Resiliency, 0, len(files))
+	errs := []error{}
 
 	type typeInfo struct {
 		metav1.TypeMeta `json:",inline"`
@@ -226,13 +243,13 @@ func loadLocalResiliencyPath(log logger.Logger, runtimeID string, path string) [
 		filePath := filepath.Join(path, file.Name())
 		b, err := os.ReadFile(filePath)
 		if err != nil {
-			log.Errorf("Could not read resiliency file %s: %v", file.Name(), err)
+			errs = append(errs, fmt.Errorf("could not read resiliency file %s: %w", file.Name(), err))
 			continue
 		}
 
 		var ti typeInfo
 		if err = yaml.Unmarshal(b, &ti); err != nil {
-			log.Errorf("Could not determine resource type: %v", err)
+			errs = append(errs, fmt.Errorf("could not determine resource type of file %s: %w", file.Name(), err))
 			continue
 		}
 
@@ -242,13 +259,13 @@ func loadLocalResiliencyPath(log logger.Logger, runtimeID string, path string) [
 
 		var resiliency resiliencyV1alpha.Resiliency
 		if err = yaml.Unmarshal(b, &resiliency); err != nil {
-			log.Errorf("Could not parse resiliency file %s: %v", file.Name(), err)
+			errs = append(errs, fmt.Errorf("could not parse resiliency file %s: %w", file.Name(), err))
 			continue
 		}
 		configs = append(configs, &resiliency)
 	}
 
-	return filterResiliencyConfigs(configs, runtimeID)
+	return filterResiliencyConfigs(configs, runtimeID), errs
 }
 
 // LoadKubernetesResiliency loads resiliency configurations from the Kubernetes operator.
@@ -307,6 +324,7 @@ func New(log logger.Logger) *Resiliency {
 		timeouts:        make(map[string]time.Duration),
 		retries:         make(map[string]*retry.Config),
 		circuitBreakers: make(map[string]*breaker.CircuitBreaker),
+		faults:          make(map[string]*FaultPolicy),
 		actorCBCaches:   make(map[string]*lru.Cache[string, *breaker.CircuitBreaker]),
 		serviceCBs:      make(map[string]*lru.Cache[string, *breaker.CircuitBreaker]),
 		componentCBs: &circuitBreakerInstances{
@@ -332,6 +350,20 @@ func (r *Resiliency) DecodeConfiguration(c *resiliencyV1alpha.Resiliency) error
 	return r.decodeTargets(c)
 }
 
+// EnableFaultInjection enables applying the fault injection policies.
+// This must be invoked before the provider is used.
+func (r *Resiliency) EnableFaultInjection() {
+	r.faultInjectionEnabled = true
+}
+
+// faultPolicy returns the fault injection policy with the given name, or nil if fault injection is disabled.
+func (r *Resiliency) faultPolicy(name string) *FaultPolicy {
+	if !r.faultInjectionEnabled || name == "" {
+		return nil
+	}
+	return r.faults[name]
+}
+
 // Adds policies that cover the existing retries in Dapr like service invocation.
 func (r *Resiliency) addBuiltInPolicies() {
 	// Cover retries for remote service invocation, but don't overwrite anything that is already present.
@@ -433,6 +465,12 @@ func (r *Resiliency) decodePolicies(c *resiliencyV1alpha.Resiliency) (err error)
 		r.circuitBreakers[name] = &cb
 	}
 
+	for name, f := range policies.Faults {
+		if r.faults[name], err = decodeFaultPolicy(f); err != nil {
+			return fmt.Errorf("invalid fault configuration %q: %w", name, err)
+		}
+	}
+
 	return nil
 }
 
@@ -444,6 +482,7 @@ func (r *Resiliency) decodeTargets(c *resiliencyV1alpha.Resiliency) (err error)
 			Timeout:        t.Timeout,
 			Retry:          t.Retry,
 			CircuitBreaker: t.CircuitBreaker,
+			Fault:          t.Fault,
 		}
 		if t.CircuitBreakerCacheSize == 0 {
 			t.CircuitBreakerCacheSize = defaultEndpointCacheSize
@@ -502,11 +541,13 @@ func (r *Resiliency) decodeTargets(c *resiliencyV1alpha.Resiliency) (err error)
 				Timeout:        t.Inbound.Timeout,
 				Retry:          t.Inbound.Retry,
 				CircuitBreaker: t.Inbound.CircuitBreaker,
+				Fault:          t.Inbound.Fault,
 			},
 			Outbound: PolicyNames{
 				Timeout:        t.Outbound.Timeout,
 				Retry:          t.Outbound.Retry,
 				CircuitBreaker: t.Outbound.CircuitBreaker,
+				Fault:          t.Outbound.Fault,
 			},
 		}
 	}
@@ -574,6 +615,7 @@ func (r *Resiliency) EndpointPolicy(app string, endpoint string) *PolicyDefiniti
 		if policyNames.Timeout != "" {
 			policyDef.t = r.timeouts[policyNames.Timeout]
 		}
+		policyDef.fault = r.faultPolicy(policyNames.Fault)
 		if policyNames.Retry != "" {
 			policyDef.r = r.retries[policyNames.Retry]
 		}
@@ -791,6 +833,7 @@ func (r *Resiliency) ComponentOutboundPolicy(name string, componentType Componen
 		if componentPolicies.Outbound.Timeout != "" {
 			policyDef.t = r.timeouts[componentPolicies.Outbound.Timeout]
 		}
+		policyDef.fault = r.faultPolicy(componentPolicies.Outbound.Fault)
 		if componentPolicies.Outbound.Retry != "" {
 			policyDef.r = r.retries[componentPolicies.Outbound.Retry]
 		}
@@ -830,6 +873,7 @@ func (r *Resiliency) ComponentInboundPolicy(name string, componentType Component
 		if componentPolicies.Inbound.Timeout != "" {
 			policyDef.t = r.timeouts[componentPolicies.Inbound.Timeout]
 		}
+		policyDef.fault = r.faultPolicy(componentPolicies.Inbound.Fault)
 		if componentPolicies.Inbound.Retry != "" {
 			policyDef.r = r.retries[componentPolicies.Inbound.Retry]
 		}
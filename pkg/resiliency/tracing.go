@@ -0,0 +1,57 @@
+/*
+Copyright 2023 The Dapr Authors
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package resiliency
+
+import (
+	"context"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+
+	diagUtils "github.com/dapr/dapr/pkg/diagnostics/utils"
+)
+
+// Events added to the active span when a resiliency policy changes the outcome or the duration of an operation.
+const (
+	SpanEventTimeout               = "dapr.resiliency.timeout"
+	SpanEventRetry                 = "dapr.resiliency.retry"
+	SpanEventCircuitBreakerState   = "dapr.resiliency.circuit_breaker.state_changed"
+	SpanEventCircuitBreakerBlocked = "dapr.resiliency.circuit_breaker.blocked"
+	SpanEventFault                 = "dapr.resiliency.fault"
+)
+
+// Attributes of the resiliency span events.
+// The policy attribute is also set on the span, so the spans affected by resiliency policies can be searched.
+const (
+	SpanAttributePolicy                      = "dapr.resiliency.policy"
+	SpanAttributeTimeout                     = "timeout"
+	SpanAttributeRetryAttempt                = "retry_attempt"
+	SpanAttributeRetryDelay                  = "retry_delay"
+	SpanAttributeCircuitBreakerState         = "circuit_breaker_state"
+	SpanAttributeCircuitBreakerPreviousState = "circuit_breaker_previous_state"
+	SpanAttributeError                       = "error"
+)
+
+// addSpanEvent adds an event to the span in the context, if it's recorded.
+func (p *PolicyDefinition) addSpanEvent(ctx context.Context, name string, attrs ...attribute.KeyValue) {
+	span := diagUtils.SpanFromContext(ctx)
+	if span == nil || !span.IsRecording() {
+		return
+	}
+
+	span.SetAttributes(attribute.String(SpanAttributePolicy, p.name))
+	span.AddEvent(name, trace.WithAttributes(
+		append([]attribute.KeyValue{attribute.String(SpanAttributePolicy, p.name)}, attrs...)...,
+	))
+}
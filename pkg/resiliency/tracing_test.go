@@ -0,0 +1,150 @@
+/*
+Copyright 2023 The Dapr Authors
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package resiliency
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel/attribute"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+
+	"github.com/dapr/dapr/pkg/resiliency/breaker"
+	"github.com/dapr/kit/retry"
+)
+
+// runTraced runs the operation with the policy in a recorded span, and returns the span once it has ended.
+func runTraced(t *testing.T, def *PolicyDefinition, oper Operation[any]) sdktrace.ReadOnlySpan {
+	t.Helper()
+
+	recorder := tracetest.NewSpanRecorder()
+	tp := sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder))
+	ctx, span := tp.Tracer("test").Start(context.Background(), "operation")
+	NewRunner[any](ctx, def)(oper)
+	span.End()
+
+	ended := recorder.Ended()
+	require.Len(t, ended, 1)
+	return ended[0]
+}
+
+func eventAttributes(span sdktrace.ReadOnlySpan, name string) [][]attribute.KeyValue {
+	var res [][]attribute.KeyValue
+	for _, e := range span.Events() {
+		if e.Name == name {
+			res = append(res, e.Attributes)
+		}
+	}
+	return res
+}
+
+func TestSpanEventsRetry(t *testing.T) {
+	def := &PolicyDefinition{
+		log:  testLog,
+		name: "retry-policy",
+		r:    &retry.Config{MaxRetries: 2},
+	}
+
+	span := runTraced(t, def, func(ctx context.Context) (any, error) {
+		return nil, errors.New("failed")
+	})
+
+	assert.Contains(t, span.Attributes(), attribute.String(SpanAttributePolicy, "retry-policy"))
+	events := eventAttributes(span, SpanEventRetry)
+	require.Len(t, events, 2)
+	for i, attrs := range events {
+		assert.Contains(t, attrs, attribute.String(SpanAttributePolicy, "retry-policy"))
+		assert.Contains(t, attrs, attribute.Int(SpanAttributeRetryAttempt, i+1))
+		assert.Contains(t, attrs, attribute.String(SpanAttributeError, "failed"))
+	}
+}
+
+func TestSpanEventsTimeout(t *testing.T) {
+	def := &PolicyDefinition{
+		log:  testLog,
+		name: "timeout-policy",
+		t:    10 * time.Millisecond,
+	}
+
+	span := runTraced(t, def, func(ctx context.Context) (any, error) {
+		<-ctx.Done()
+		return nil, ctx.Err()
+	})
+
+	events := eventAttributes(span, SpanEventTimeout)
+	require.Len(t, events, 1)
+	assert.Contains(t, events[0], attribute.String(SpanAttributeTimeout, "10ms"))
+}
+
+func TestSpanEventsCircuitBreaker(t *testing.T) {
+	cb := &breaker.CircuitBreaker{
+		Name:    "test",
+		Timeout: time.Minute,
+	}
+	cb.Initialize(testLog)
+	def := &PolicyDefinition{
+		log:  testLog,
+		name: "cb-policy",
+		cb:   cb,
+	}
+
+	// The default trip condition opens the circuit breaker after more than 5 consecutive failures.
+	for i := 0; i < 5; i++ {
+		span := runTraced(t, def, func(ctx context.Context) (any, error) {
+			return nil, errors.New("failed")
+		})
+		assert.Empty(t, span.Events())
+	}
+
+	span := runTraced(t, def, func(ctx context.Context) (any, error) {
+		return nil, errors.New("failed")
+	})
+	events := eventAttributes(span, SpanEventCircuitBreakerState)
+	require.Len(t, events, 1)
+	assert.Contains(t, events[0], attribute.String(SpanAttributeCircuitBreakerPreviousState, string(breaker.StateClosed)))
+	assert.Contains(t, events[0], attribute.String(SpanAttributeCircuitBreakerState, string(breaker.StateOpen)))
+
+	called := false
+	span = runTraced(t, def, func(ctx context.Context) (any, error) {
+		called = true
+		return nil, nil
+	})
+	assert.False(t, called)
+	events = eventAttributes(span, SpanEventCircuitBreakerBlocked)
+	require.Len(t, events, 1)
+	assert.Contains(t, events[0], attribute.String(SpanAttributeCircuitBreakerState, string(breaker.StateOpen)))
+}
+
+func TestSpanEventsNotRecording(t *testing.T) {
+	def := &PolicyDefinition{
+		log:  testLog,
+		name: "retry-policy",
+		r:    &retry.Config{MaxRetries: 1},
+	}
+
+	tp := sdktrace.NewTracerProvider(sdktrace.WithSampler(sdktrace.NeverSample()))
+	ctx, span := tp.Tracer("test").Start(context.Background(), "operation")
+	defer span.End()
+	require.False(t, span.IsRecording())
+
+	_, err := NewRunner[any](ctx, def)(func(ctx context.Context) (any, error) {
+		return nil, errors.New("failed")
+	})
+	require.Error(t, err)
+}
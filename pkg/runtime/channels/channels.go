@@ -14,15 +14,19 @@ limitations under the License.
 package channels
 
 import (
+	"context"
 	"crypto/tls"
 	"crypto/x509"
+	"errors"
 	"fmt"
 	"net"
 	"net/http"
-	"strconv"
+	"net/url"
+	"strings"
 	"sync"
 	"time"
 
+	"golang.org/x/net/http/httpproxy"
 	"golang.org/x/net/http2"
 
 	contribmiddle "github.com/dapr/components-contrib/middleware"
@@ -30,20 +34,31 @@ import (
 	httpendpapi "github.com/dapr/dapr/pkg/apis/httpEndpoint/v1alpha1"
 	"github.com/dapr/dapr/pkg/channel"
 	channelhttp "github.com/dapr/dapr/pkg/channel/http"
+	"github.com/dapr/dapr/pkg/channel/mock"
+	"github.com/dapr/dapr/pkg/channel/transcoding"
 	compmiddlehttp "github.com/dapr/dapr/pkg/components/middleware/http"
 	"github.com/dapr/dapr/pkg/config"
 	"github.com/dapr/dapr/pkg/config/protocol"
+	diag "github.com/dapr/dapr/pkg/diagnostics"
 	"github.com/dapr/dapr/pkg/grpc/manager"
 	middlehttp "github.com/dapr/dapr/pkg/middleware/http"
+	"github.com/dapr/dapr/pkg/ratelimit"
 	"github.com/dapr/dapr/pkg/runtime/compstore"
 	"github.com/dapr/dapr/pkg/runtime/meta"
 	"github.com/dapr/dapr/pkg/runtime/registry"
+	"github.com/dapr/dapr/utils"
 	"github.com/dapr/kit/logger"
 )
 
+// appChannelDrainTimeout is the time allowed to requests in-flight on the previous connections to the app to complete, when the app channel is reset.
+const appChannelDrainTimeout = 30 * time.Second
+
 var log = logger.NewLogger("dapr.runtime.channels")
 
 type Options struct {
+	// AppID is the ID of the app.
+	AppID string
+
 	// Registry is the all-component registry.
 	Registry *registry.Registry
 
@@ -66,9 +81,16 @@ type Options struct {
 	ReadBufferSize int
 
 	GRPC *manager.Manager
+
+	// MockAppChannel is the configuration of the mock app channel, which is used instead of connecting to the app if set.
+	MockAppChannel *mock.Config
+
+	// RateLimiters are the distributed rate limiters that rate limit middlewares can use.
+	RateLimiters ratelimit.Limiters
 }
 
 type Channels struct {
+	appID               string
 	registry            *compmiddlehttp.Registry
 	compStore           *compstore.ComponentStore
 	meta                *meta.Meta
@@ -78,15 +100,33 @@ type Channels struct {
 	appHTTPPipelineSpec *config.PipelineSpec
 	httpClient          *http.Client
 	grpc                *manager.Manager
+	mockAppChannel      *mock.Config
+	routeHTTPClients    map[string]*http.Client
+	appHTTPConns        *connCounter
+	transcodingSpec     *config.TranscodingSpec
+	rateLimiters        ratelimit.Limiters
 
 	appChannel      channel.AppChannel
+	routeChannels   map[string]channel.AppChannel
 	endpChannels    map[string]channel.HTTPEndpointAppChannel
 	httpEndpChannel channel.AppChannel
+	appConnRotation *time.Timer
+	transcoder      *transcoding.Transcoder
+	closed          bool
 	lock            sync.RWMutex
 }
 
 func New(opts Options) *Channels {
+	appHTTPConns := new(connCounter)
+	routeHTTPClients := make(map[string]*http.Client, len(opts.AppConnectionConfig.Routes))
+	for buildingBlock, route := range opts.AppConnectionConfig.Routes {
+		connConfig := opts.AppConnectionConfig
+		connConfig.Protocol = route.Protocol
+		routeHTTPClients[buildingBlock] = appHTTPClient(connConfig, opts.GlobalConfig, opts.ReadBufferSize, appHTTPConns)
+	}
+
 	return &Channels{
+		appID:               opts.AppID,
 		registry:            opts.Registry.HTTPMiddlewares(),
 		compStore:           opts.ComponentStore,
 		meta:                opts.Meta,
@@ -95,8 +135,13 @@ func New(opts Options) *Channels {
 		maxRequestBodySize:  opts.MaxRequestBodySize,
 		appHTTPPipelineSpec: opts.GlobalConfig.Spec.AppHTTPPipelineSpec,
 		grpc:                opts.GRPC,
-		httpClient:          appHTTPClient(opts.AppConnectionConfig, opts.GlobalConfig, opts.ReadBufferSize),
+		mockAppChannel:      opts.MockAppChannel,
+		httpClient:          appHTTPClient(opts.AppConnectionConfig, opts.GlobalConfig, opts.ReadBufferSize, appHTTPConns),
+		appHTTPConns:        appHTTPConns,
 		endpChannels:        make(map[string]channel.HTTPEndpointAppChannel),
+		routeHTTPClients:    routeHTTPClients,
+		transcodingSpec:     opts.GlobalConfig.GetTranscodingSpec(),
+		rateLimiters:        opts.RateLimiters,
 	}
 }
 
@@ -107,7 +152,7 @@ func (c *Channels) Refresh() error {
 	log.Debug("Refreshing channels")
 
 	// Create a HTTP channel for external HTTP endpoint invocation
-	pipeline, err := c.buildHTTPPipelineForSpec(c.appHTTPPipelineSpec, "app channel")
+	pipeline, err := c.buildHTTPPipelineForSpec(c.appHTTPPipelineSpec, diag.MiddlewarePipelineAppChannel)
 	if err != nil {
 		return fmt.Errorf("failed to build app HTTP pipeline: %w", err)
 	}
@@ -122,8 +167,26 @@ func (c *Channels) Refresh() error {
 		return fmt.Errorf("failed to create HTTP endpoints channels: %w", err)
 	}
 
+	transcoder, err := transcoding.New(c.transcodingSpec)
+	if err != nil {
+		return fmt.Errorf("failed to load the transcoding configuration: %w", err)
+	}
+
 	c.httpEndpChannel = httpEndpChannel
 	c.endpChannels = endpChannels
+	c.transcoder = transcoder
+
+	if c.mockAppChannel != nil {
+		c.appChannel = mock.New(c.mockAppChannel)
+		log.Debug("Channels refreshed with the mock app channel")
+		return nil
+	}
+
+	routeChannels, err := c.initRouteChannels(pipeline)
+	if err != nil {
+		return fmt.Errorf("failed to create dedicated app channels: %w", err)
+	}
+	c.routeChannels = routeChannels
 
 	if c.appConnectionConfig.Port == 0 {
 		log.Warn("App channel is not initialized. Did you configure an app-port?")
@@ -147,11 +210,66 @@ func (c *Channels) Refresh() error {
 	}
 
 	c.appChannel = appChannel
+	if !c.appConnectionConfig.Protocol.IsHTTP() && c.appConnectionConfig.GRPCMaxConnectionAge > 0 && c.appConnRotation == nil && !c.closed {
+		c.appConnRotation = time.AfterFunc(c.appConnectionConfig.GRPCMaxConnectionAge, c.rotateAppConnection)
+	}
 	log.Debug("Channels refreshed")
 
 	return nil
 }
 
+// ResetAppChannel closes the connections to the app and re-creates the app channel, without restarting the runtime.
+// This allows recovering from connections to the app that are stuck, such as HTTP/2 connections that stopped responding.
+// Requests that are in-flight on the previous connections can complete for up to appChannelDrainTimeout.
+func (c *Channels) ResetAppChannel() error {
+	if c.appConnectionConfig.Port == 0 {
+		return errors.New("app channel is not initialized")
+	}
+
+	log.Info("Resetting the app channel")
+
+	if c.appConnectionConfig.Protocol.IsHTTP() {
+		if tr, ok := c.httpClient.Transport.(*resettableTransport); ok {
+			tr.Reset(appChannelDrainTimeout)
+		}
+	} else {
+		c.grpc.ResetAppClient(appChannelDrainTimeout)
+	}
+	for _, client := range c.routeHTTPClients {
+		if tr, ok := client.Transport.(*resettableTransport); ok {
+			tr.Reset(appChannelDrainTimeout)
+		}
+	}
+
+	return c.Refresh()
+}
+
+// rotateAppConnection re-establishes the gRPC connection to the app when it reaches its maximum age.
+func (c *Channels) rotateAppConnection() {
+	c.lock.Lock()
+	c.appConnRotation = nil
+	c.lock.Unlock()
+
+	log.Debug("gRPC connection to the app reached its maximum age")
+	if err := c.ResetAppChannel(); err != nil {
+		log.Errorf("Failed to re-establish the gRPC connection to the app: %v", err)
+	}
+}
+
+// Close stops the rotation of the gRPC connection to the app.
+func (c *Channels) Close() error {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	c.closed = true
+	if c.appConnRotation != nil {
+		c.appConnRotation.Stop()
+		c.appConnRotation = nil
+	}
+
+	return nil
+}
+
 func (c *Channels) BuildHTTPPipeline(spec *config.PipelineSpec) (middlehttp.Pipeline, error) {
 	c.lock.RLock()
 	defer c.lock.RUnlock()
@@ -159,7 +277,7 @@ func (c *Channels) BuildHTTPPipeline(spec *config.PipelineSpec) (middlehttp.Pipe
 }
 
 func (c *Channels) buildHTTPPipeline(spec *config.PipelineSpec) (middlehttp.Pipeline, error) {
-	return c.buildHTTPPipelineForSpec(spec, "http")
+	return c.buildHTTPPipelineForSpec(spec, diag.MiddlewarePipelineHTTP)
 }
 
 func (c *Channels) AppChannel() channel.AppChannel {
@@ -168,6 +286,31 @@ func (c *Channels) AppChannel() channel.AppChannel {
 	return c.appChannel
 }
 
+// AppChannelFor returns the channel to the app for the building block.
+// This is the dedicated channel of the building block if one is configured, or the main app channel otherwise.
+// The channel for service invocation transcodes the bodies of the requests if transcoding is configured.
+func (c *Channels) AppChannelFor(buildingBlock string) channel.AppChannel {
+	c.lock.RLock()
+	defer c.lock.RUnlock()
+	ch, ok := c.routeChannels[buildingBlock]
+	if !ok {
+		ch = c.appChannel
+	}
+	if buildingBlock == config.AppChannelRouteServiceInvocation {
+		return c.transcoder.WrapChannel(ch)
+	}
+	return ch
+}
+
+// HasRoute returns true if the building block has a dedicated channel to the app.
+func (c *Channels) HasRoute(buildingBlock string) bool {
+	if c == nil {
+		return false
+	}
+	_, ok := c.appConnectionConfig.Routes[buildingBlock]
+	return ok
+}
+
 func (c *Channels) HTTPEndpointsAppChannel() channel.HTTPEndpointAppChannel {
 	c.lock.RLock()
 	defer c.lock.RUnlock()
@@ -186,13 +329,17 @@ func (c *Channels) AppHTTPClient() *http.Client {
 
 // AppHTTPEndpoint Returns the HTTP endpoint for the app.
 func (c *Channels) AppHTTPEndpoint() string {
+	return appHTTPEndpoint(c.appConnectionConfig.ChannelAddress, c.appConnectionConfig.Port, c.appConnectionConfig.Protocol)
+}
+
+func appHTTPEndpoint(channelAddress string, port int, proto protocol.Protocol) string {
 	// Application protocol is "http" or "https"
-	port := strconv.Itoa(c.appConnectionConfig.Port)
-	switch c.appConnectionConfig.Protocol {
+	addr := utils.JoinHostPort(channelAddress, port)
+	switch proto {
 	case protocol.HTTPProtocol, protocol.H2CProtocol:
-		return "http://" + c.appConnectionConfig.ChannelAddress + ":" + port
+		return "http://" + addr
 	case protocol.HTTPSProtocol:
-		return "https://" + c.appConnectionConfig.ChannelAddress + ":" + port
+		return "https://" + addr
 	default:
 		return ""
 	}
@@ -221,7 +368,7 @@ func (c *Channels) buildHTTPPipelineForSpec(spec *config.PipelineSpec, targetPip
 			return middlehttp.Pipeline{}, err
 		}
 		md := contribmiddle.Metadata{Base: meta}
-		handler, err := c.registry.Create(handlerSpec.Type, handlerSpec.Version, md, handlerSpec.LogName())
+		handler, err := c.createHTTPMiddleware(handlerSpec, md)
 		if err != nil {
 			err = fmt.Errorf("process component %s error: %w", comp.Name, err)
 			if !comp.Spec.IgnoreErrors {
@@ -232,12 +379,27 @@ func (c *Channels) buildHTTPPipelineForSpec(spec *config.PipelineSpec, targetPip
 		}
 
 		log.Infof("enabled %s/%s %s middleware", handlerSpec.Type, targetPipeline, handlerSpec.Version)
-		pipeline.Handlers = append(pipeline.Handlers, handler)
+		pipeline.Handlers = append(pipeline.Handlers, diag.DefaultMiddlewareMonitoring.Instrument(handlerSpec.Name, targetPipeline, handler))
 	}
 
 	return pipeline, nil
 }
 
+// createHTTPMiddleware creates the middleware of the handler.
+// Rate limit middlewares that refer to a distributed rate limiter share their limits across the replicas of the app.
+func (c *Channels) createHTTPMiddleware(handlerSpec config.HandlerSpec, md contribmiddle.Metadata) (middlehttp.Middleware, error) {
+	limiterName, ok := md.Properties[ratelimit.MiddlewareMetadataKey]
+	if !ok || !strings.EqualFold(handlerSpec.Type, ratelimit.MiddlewareType) {
+		return c.registry.Create(handlerSpec.Type, handlerSpec.Version, md, handlerSpec.LogName())
+	}
+
+	limiter, ok := c.rateLimiters[limiterName]
+	if !ok {
+		return nil, fmt.Errorf("rate limiter '%s' not found", limiterName)
+	}
+	return limiter.Middleware(handlerSpec.Name), nil
+}
+
 func (c *Channels) appHTTPChannelConfig(pipeline middlehttp.Pipeline) channelhttp.ChannelConfiguration {
 	conf := channelhttp.ChannelConfiguration{
 		CompStore:            c.compStore,
@@ -253,6 +415,30 @@ func (c *Channels) appHTTPChannelConfig(pipeline middlehttp.Pipeline) channelhtt
 	return conf
 }
 
+// initRouteChannels creates the dedicated channels to the app for building blocks.
+func (c *Channels) initRouteChannels(pipeline middlehttp.Pipeline) (map[string]channel.AppChannel, error) {
+	if len(c.appConnectionConfig.Routes) == 0 {
+		return nil, nil
+	}
+
+	channels := make(map[string]channel.AppChannel, len(c.appConnectionConfig.Routes))
+	for buildingBlock, route := range c.appConnectionConfig.Routes {
+		conf := c.appHTTPChannelConfig(pipeline)
+		conf.Endpoint = appHTTPEndpoint(c.appConnectionConfig.ChannelAddress, route.Port, route.Protocol)
+		conf.Client = c.routeHTTPClients[buildingBlock]
+
+		ch, err := channelhttp.CreateHTTPChannel(conf)
+		if err != nil {
+			return nil, fmt.Errorf("failed to create app channel for building block '%s': %w", buildingBlock, err)
+		}
+		ch.(*channelhttp.Channel).SetAppHealthCheckPath(c.appConnectionConfig.HealthCheckHTTPPath)
+		channels[buildingBlock] = ch
+		log.Infof("Requests to the app for building block '%s' use a dedicated %s channel on port %d", buildingBlock, route.Protocol, route.Port)
+	}
+
+	return channels, nil
+}
+
 func (c *Channels) initEndpointChannels() (map[string]channel.HTTPEndpointAppChannel, error) {
 	// Create dedicated app channels for known app endpoints
 	endpoints := c.compStore.ListHTTPEndpoints()
@@ -265,6 +451,12 @@ func (c *Channels) initEndpointChannels() (map[string]channel.HTTPEndpointAppCha
 		}
 
 		for _, e := range endpoints {
+			// The endpoints are loaded only if they're scoped to the app, but this is checked again since requests to them bypass the scopes otherwise
+			if !e.IsAppScoped(c.appID) {
+				log.Warnf("HTTP endpoint %s is not scoped to app %s: skipping it", e.ObjectMeta.Name, c.appID)
+				continue
+			}
+
 			conf, err := c.getHTTPEndpointAppChannel(pipeline, e)
 			if err != nil {
 				return nil, err
@@ -340,26 +532,96 @@ func (c *Channels) getHTTPEndpointAppChannel(pipeline middlehttp.Pipeline, endpo
 	tr.TLSHandshakeTimeout = 15 * time.Second
 	tr.TLSClientConfig = tlsConfig
 	tr.DialContext = dialer.DialContext
+	tr.Proxy = httpEndpointProxy(endpoint)
 
 	conf.Client = &http.Client{
 		Timeout:   0,
-		Transport: tr,
+		Transport: proxyHeadersFilter{next: tr},
 	}
 
 	return conf, nil
 }
 
+// httpEndpointProxy returns the function that selects the proxy for requests to the HTTP endpoint.
+// Settings are read from the environment (HTTP_PROXY, HTTPS_PROXY and NO_PROXY), and can be overridden by the endpoint's spec.
+func httpEndpointProxy(endpoint httpendpapi.HTTPEndpoint) func(*http.Request) (*url.URL, error) {
+	proxyConfig := httpproxy.FromEnvironment()
+	if endpoint.HasProxy() {
+		if endpoint.Spec.Proxy.Bypass {
+			return nil
+		}
+		if endpoint.Spec.Proxy.HTTPProxy != "" {
+			proxyConfig.HTTPProxy = endpoint.Spec.Proxy.HTTPProxy
+		}
+		if endpoint.Spec.Proxy.HTTPSProxy != "" {
+			proxyConfig.HTTPSProxy = endpoint.Spec.Proxy.HTTPSProxy
+		}
+		if endpoint.Spec.Proxy.NoProxy != "" {
+			proxyConfig.NoProxy = endpoint.Spec.Proxy.NoProxy
+		}
+	}
+
+	proxyFn := proxyConfig.ProxyFunc()
+	return func(req *http.Request) (*url.URL, error) {
+		return proxyFn(req.URL)
+	}
+}
+
+// proxyHeaders are the headers of the requests to the HTTP endpoints that are reserved for the proxy.
+// The transport sets them from the proxy settings of the endpoint, so the callers can't authenticate to the proxy with their own credentials, nor leak them to the endpoint when the proxy is bypassed.
+var proxyHeaders = []string{"Proxy-Authorization", "Proxy-Connection"}
+
+// proxyHeadersFilter is a RoundTripper that removes the proxy headers set by the callers from the requests to the HTTP endpoints.
+type proxyHeadersFilter struct {
+	next http.RoundTripper
+}
+
+func (f proxyHeadersFilter) RoundTrip(req *http.Request) (*http.Response, error) {
+	for _, h := range proxyHeaders {
+		if _, ok := req.Header[h]; ok {
+			// RoundTrippers must not modify the request
+			req = req.Clone(req.Context())
+			break
+		}
+	}
+	for _, h := range proxyHeaders {
+		req.Header.Del(h)
+	}
+	return f.next.RoundTrip(req)
+}
+
+// AppHTTPConnections returns the number of open HTTP connections to the app.
+func (c *Channels) AppHTTPConnections() int {
+	return c.appHTTPConns.Open()
+}
+
 // appHTTPClient Initializes the appHTTPClient property.
-func appHTTPClient(connConfig config.AppConnectionConfig, globalConfig *config.Configuration, readBufferSize int) *http.Client {
+func appHTTPClient(connConfig config.AppConnectionConfig, globalConfig *config.Configuration, readBufferSize int, conns *connCounter) *http.Client {
+	// Initialize this property in the object, and then pass it to the HTTP channel and the actors runtime (for health checks)
+	// We want to re-use the same client so TCP sockets can be re-used efficiently across everything that communicates with the app
+	// This is especially useful if the app supports HTTP/2
+	return &http.Client{
+		Transport: newResettableTransport(func() http.RoundTripper {
+			return appHTTPTransport(connConfig, readBufferSize, conns)
+		}),
+		CheckRedirect: func(req *http.Request, via []*http.Request) error {
+			return http.ErrUseLastResponse
+		},
+	}
+}
+
+// appHTTPTransport returns a new transport for the connections to the app.
+// The connections it opens are counted by conns.
+func appHTTPTransport(connConfig config.AppConnectionConfig, readBufferSize int, conns *connCounter) http.RoundTripper {
 	var transport http.RoundTripper
 
 	if connConfig.Protocol == protocol.H2CProtocol {
 		// Enable HTTP/2 Cleartext transport
 		transport = &http2.Transport{
 			AllowHTTP: true, // To enable using "http" as protocol
-			DialTLS: func(network, addr string, _ *tls.Config) (net.Conn, error) {
+			DialTLSContext: func(ctx context.Context, network, addr string, _ *tls.Config) (net.Conn, error) {
 				// Return the TCP socket without TLS
-				return net.Dial(network, addr)
+				return conns.dialContext(new(net.Dialer).DialContext)(ctx, network, addr)
 			},
 			// TODO: This may not be exactly the same as "MaxResponseHeaderBytes" so check before enabling this
 			// MaxHeaderListSize: uint32(a.runtimeConfig.readBufferSize << 10),
@@ -375,6 +637,7 @@ func appHTTPClient(connConfig config.AppConnectionConfig, globalConfig *config.C
 
 		transport = &http.Transport{
 			TLSClientConfig:        tlsConfig,
+			DialContext:            conns.dialContext(new(net.Dialer).DialContext),
 			ReadBufferSize:         readBufferSize << 10,
 			MaxResponseHeaderBytes: int64(readBufferSize) << 10,
 			MaxConnsPerHost:        1024,
@@ -383,13 +646,5 @@ func appHTTPClient(connConfig config.AppConnectionConfig, globalConfig *config.C
 		}
 	}
 
-	// Initialize this property in the object, and then pass it to the HTTP channel and the actors runtime (for health checks)
-	// We want to re-use the same client so TCP sockets can be re-used efficiently across everything that communicates with the app
-	// This is especially useful if the app supports HTTP/2
-	return &http.Client{
-		Transport: transport,
-		CheckRedirect: func(req *http.Request, via []*http.Request) error {
-			return http.ErrUseLastResponse
-		},
-	}
+	return transport
 }
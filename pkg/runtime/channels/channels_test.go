@@ -39,6 +39,7 @@ import (
 	"github.com/dapr/dapr/pkg/config"
 	httpMiddleware "github.com/dapr/dapr/pkg/middleware/http"
 	"github.com/dapr/dapr/pkg/modes"
+	"github.com/dapr/dapr/pkg/ratelimit"
 	"github.com/dapr/dapr/pkg/runtime/compstore"
 	"github.com/dapr/dapr/pkg/runtime/meta"
 	"github.com/dapr/dapr/pkg/runtime/registry"
@@ -215,6 +216,76 @@ func TestMiddlewareBuildPipeline(t *testing.T) {
 
 	t.Run("one components fails to init", testInitFail(false))
 	t.Run("one components fails to init but ignoreErrors is true", testInitFail(true))
+
+	t.Run("rate limit middleware with a distributed rate limiter", func(t *testing.T) {
+		compStore := compstore.New()
+		for name, limiter := range map[string]string{"ratelimit": "clients", "missing": "other"} {
+			require.NoError(t, compStore.AddPendingComponentForCommit(componentsapi.Component{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: name,
+				},
+				Spec: componentsapi.ComponentSpec{
+					Type:    "middleware.http.ratelimit",
+					Version: "v1",
+					Metadata: []commonapi.NameValuePair{
+						{Name: "rateLimiter", Value: commonapi.DynamicValue{JSON: v1.JSON{Raw: []byte(`"` + limiter + `"`)}}},
+					},
+				},
+			}))
+			require.NoError(t, compStore.CommitPendingComponent())
+		}
+		rateLimiters, err := ratelimit.New(ratelimit.Options{
+			Specs: []config.RateLimiterSpec{
+				{Name: "clients", StateStore: "store", Limit: 10},
+			},
+		})
+		require.NoError(t, err)
+
+		ch := &Channels{
+			compStore: compStore,
+			meta:      meta.New(meta.Options{}),
+			registry: registry.New(registry.NewOptions().WithHTTPMiddlewares(
+				httpMiddlewareLoader.NewRegistry(),
+			)).HTTPMiddlewares(),
+			rateLimiters: rateLimiters,
+		}
+		called := 0
+		ch.registry.RegisterComponent(
+			func(_ logger.Logger) httpMiddlewareLoader.FactoryMethod {
+				called++
+				return func(metadata middleware.Metadata) (httpMiddleware.Middleware, error) {
+					return func(next http.Handler) http.Handler {
+						return next
+					}, nil
+				}
+			},
+			"ratelimit",
+		)
+
+		pipeline, err := ch.buildHTTPPipelineForSpec(&config.PipelineSpec{
+			Handlers: []config.HandlerSpec{
+				{
+					Name:    "ratelimit",
+					Type:    "middleware.http.ratelimit",
+					Version: "v1",
+				},
+			},
+		}, "test")
+		require.NoError(t, err)
+		assert.Len(t, pipeline.Handlers, 1)
+		assert.Equal(t, 0, called)
+
+		_, err = ch.buildHTTPPipelineForSpec(&config.PipelineSpec{
+			Handlers: []config.HandlerSpec{
+				{
+					Name:    "missing",
+					Type:    "middleware.http.ratelimit",
+					Version: "v1",
+				},
+			},
+		}, "test")
+		require.ErrorContains(t, err, "rate limiter 'other' not found")
+	})
 }
 
 func TestGetAppHTTPChannelConfigWithCustomChannel(t *testing.T) {
@@ -286,7 +357,7 @@ func TestGetHTTPEndpointAppChannel(t *testing.T) {
 		})
 
 		require.NoError(t, err)
-		assert.Nil(t, conf.Client.Transport.(*http.Transport).TLSClientConfig)
+		assert.Nil(t, conf.Client.Transport.(proxyHeadersFilter).next.(*http.Transport).TLSClientConfig)
 	})
 
 	t.Run("TLS channel with Root CA", func(t *testing.T) {
@@ -329,7 +400,7 @@ func TestGetHTTPEndpointAppChannel(t *testing.T) {
 		})
 
 		require.NoError(t, err)
-		assert.NotNil(t, conf.Client.Transport.(*http.Transport).TLSClientConfig)
+		assert.NotNil(t, conf.Client.Transport.(proxyHeadersFilter).next.(*http.Transport).TLSClientConfig)
 	})
 
 	t.Run("TLS channel without Root CA", func(t *testing.T) {
@@ -367,7 +438,7 @@ func TestGetHTTPEndpointAppChannel(t *testing.T) {
 		})
 
 		require.NoError(t, err)
-		assert.NotNil(t, conf.Client.Transport.(*http.Transport).TLSClientConfig)
+		assert.NotNil(t, conf.Client.Transport.(proxyHeadersFilter).next.(*http.Transport).TLSClientConfig)
 	})
 
 	t.Run("TLS channel with invalid Root CA", func(t *testing.T) {
@@ -412,3 +483,177 @@ func TestGetHTTPEndpointAppChannel(t *testing.T) {
 		require.Error(t, err)
 	})
 }
+
+func TestHTTPEndpointProxy(t *testing.T) {
+	t.Setenv("HTTP_PROXY", "http://envproxy:8080")
+	t.Setenv("NO_PROXY", "")
+
+	req, err := http.NewRequest(http.MethodGet, "http://example.com/path", nil)
+	require.NoError(t, err)
+
+	t.Run("proxy from the environment", func(t *testing.T) {
+		proxyFn := httpEndpointProxy(httpendpapi.HTTPEndpoint{})
+		require.NotNil(t, proxyFn)
+		u, err := proxyFn(req)
+		require.NoError(t, err)
+		assert.Equal(t, "envproxy:8080", u.Host)
+	})
+
+	t.Run("proxy overridden by the endpoint", func(t *testing.T) {
+		proxyFn := httpEndpointProxy(httpendpapi.HTTPEndpoint{
+			Spec: httpendpapi.HTTPEndpointSpec{
+				Proxy: &httpendpapi.HTTPEndpointProxy{HTTPProxy: "http://endpointproxy:3128"},
+			},
+		})
+		u, err := proxyFn(req)
+		require.NoError(t, err)
+		assert.Equal(t, "endpointproxy:3128", u.Host)
+	})
+
+	t.Run("no proxy for the host", func(t *testing.T) {
+		proxyFn := httpEndpointProxy(httpendpapi.HTTPEndpoint{
+			Spec: httpendpapi.HTTPEndpointSpec{
+				Proxy: &httpendpapi.HTTPEndpointProxy{NoProxy: "example.com"},
+			},
+		})
+		u, err := proxyFn(req)
+		require.NoError(t, err)
+		assert.Nil(t, u)
+	})
+
+	t.Run("bypass the proxy", func(t *testing.T) {
+		proxyFn := httpEndpointProxy(httpendpapi.HTTPEndpoint{
+			Spec: httpendpapi.HTTPEndpointSpec{
+				Proxy: &httpendpapi.HTTPEndpointProxy{Bypass: true},
+			},
+		})
+		assert.Nil(t, proxyFn)
+	})
+}
+
+func TestAppChannelRoutes(t *testing.T) {
+	ch := New(Options{
+		Registry: registry.New(registry.NewOptions().WithHTTPMiddlewares(
+			httpMiddlewareLoader.NewRegistry(),
+		)),
+		ComponentStore: compstore.New(),
+		Meta:           meta.New(meta.Options{Mode: modes.StandaloneMode}),
+		GlobalConfig:   new(config.Configuration),
+		AppConnectionConfig: config.AppConnectionConfig{
+			ChannelAddress: "127.0.0.1",
+			Protocol:       "grpc",
+			Port:           3000,
+			Routes: map[string]config.AppChannelRoute{
+				config.AppChannelRoutePubSub: {Port: 3001, Protocol: "h2c"},
+			},
+		},
+	})
+
+	assert.True(t, ch.HasRoute(config.AppChannelRoutePubSub))
+	assert.False(t, ch.HasRoute(config.AppChannelRouteBindings))
+	require.Len(t, ch.routeHTTPClients, 1)
+
+	routeChannels, err := ch.initRouteChannels(httpMiddleware.Pipeline{})
+	require.NoError(t, err)
+	ch.routeChannels = routeChannels
+
+	require.NotNil(t, ch.AppChannelFor(config.AppChannelRoutePubSub))
+	assert.Same(t, routeChannels[config.AppChannelRoutePubSub], ch.AppChannelFor(config.AppChannelRoutePubSub))
+	// Building blocks without a dedicated channel use the main app channel, which is not initialized in this test
+	assert.Nil(t, ch.AppChannelFor(config.AppChannelRouteBindings))
+
+	t.Run("nil channels", func(t *testing.T) {
+		var ch *Channels
+		assert.False(t, ch.HasRoute(config.AppChannelRoutePubSub))
+	})
+}
+
+func TestCloseStopsAppConnectionRotation(t *testing.T) {
+	ch := New(Options{
+		Registry: registry.New(registry.NewOptions().WithHTTPMiddlewares(
+			httpMiddlewareLoader.NewRegistry(),
+		)),
+		ComponentStore: compstore.New(),
+		Meta:           meta.New(meta.Options{Mode: modes.StandaloneMode}),
+		GlobalConfig:   new(config.Configuration),
+	})
+
+	rotated := make(chan struct{})
+	ch.appConnRotation = time.AfterFunc(50*time.Millisecond, func() {
+		close(rotated)
+	})
+
+	require.NoError(t, ch.Close())
+	assert.Nil(t, ch.appConnRotation)
+	assert.True(t, ch.closed)
+
+	select {
+	case <-rotated:
+		t.Fatal("connection to the app should not have been rotated after closing")
+	case <-time.After(100 * time.Millisecond):
+	}
+}
+
+func TestEndpointChannelsScopes(t *testing.T) {
+	compStore := compstore.New()
+	for _, e := range []struct {
+		name   string
+		scopes []string
+	}{
+		{name: "unscoped"},
+		{name: "scoped", scopes: []string{"myapp"}},
+		{name: "other", scopes: []string{"otherapp"}},
+	} {
+		compStore.AddHTTPEndpoint(httpendpapi.HTTPEndpoint{
+			ObjectMeta: metav1.ObjectMeta{Name: e.name},
+			Spec:       httpendpapi.HTTPEndpointSpec{BaseURL: "http://api.example.com"},
+			Scoped:     commonapi.Scoped{Scopes: e.scopes},
+		})
+	}
+
+	ch := New(Options{
+		AppID: "myapp",
+		Registry: registry.New(registry.NewOptions().WithHTTPMiddlewares(
+			httpMiddlewareLoader.NewRegistry(),
+		)),
+		ComponentStore: compStore,
+		Meta:           meta.New(meta.Options{Mode: modes.StandaloneMode}),
+		GlobalConfig:   new(config.Configuration),
+	})
+
+	channels, err := ch.initEndpointChannels()
+	require.NoError(t, err)
+	assert.Len(t, channels, 2)
+	assert.Contains(t, channels, "unscoped")
+	assert.Contains(t, channels, "scoped")
+	assert.NotContains(t, channels, "other")
+}
+
+func TestProxyHeadersFilter(t *testing.T) {
+	var received http.Header
+	f := proxyHeadersFilter{next: roundTripperFn(func(req *http.Request) (*http.Response, error) {
+		received = req.Header
+		return &http.Response{StatusCode: http.StatusOK}, nil
+	})}
+
+	req, err := http.NewRequest(http.MethodGet, "http://example.com/path", nil)
+	require.NoError(t, err)
+	req.Header.Set("Proxy-Authorization", "Basic Zm9vOmJhcg==")
+	req.Header.Set("Proxy-Connection", "keep-alive")
+	req.Header.Set("X-Custom", "value")
+
+	_, err = f.RoundTrip(req)
+	require.NoError(t, err)
+	assert.Empty(t, received.Get("Proxy-Authorization"))
+	assert.Empty(t, received.Get("Proxy-Connection"))
+	assert.Equal(t, "value", received.Get("X-Custom"))
+
+	// The request of the caller is not modified
+	assert.Equal(t, "Basic Zm9vOmJhcg==", req.Header.Get("Proxy-Authorization"))
+}
+
+type roundTripperFn func(*http.Request) (*http.Response, error)
+
+func (fn roundTripperFn) RoundTrip(req *http.Request) (*http.Response, error) {
+	return fn(req)
+}
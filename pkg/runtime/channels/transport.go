@@ -0,0 +1,109 @@
+/*
+Copyright 2023 The Dapr Authors
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package channels
+
+import (
+	"context"
+	"net"
+	"net/http"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// resettableTransport is a RoundTripper whose underlying transport can be replaced with a new one.
+// The HTTP client for the app is shared with other parts of the runtime, so the connection pool is replaced without replacing the client.
+type resettableTransport struct {
+	newFn   func() http.RoundTripper
+	current http.RoundTripper
+	lock    sync.RWMutex
+}
+
+func newResettableTransport(newFn func() http.RoundTripper) *resettableTransport {
+	return &resettableTransport{
+		newFn:   newFn,
+		current: newFn(),
+	}
+}
+
+// RoundTrip implements http.RoundTripper.
+func (t *resettableTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	t.lock.RLock()
+	current := t.current
+	t.lock.RUnlock()
+	return current.RoundTrip(req)
+}
+
+// CloseIdleConnections closes the idle connections of the current transport.
+func (t *resettableTransport) CloseIdleConnections() {
+	t.lock.RLock()
+	current := t.current
+	t.lock.RUnlock()
+	closeIdleConnections(current)
+}
+
+// Reset replaces the underlying transport, so new requests are sent on new connections.
+// Requests that are in-flight on the previous transport can complete for up to the drain timeout, after which the previous transport's connections are closed as they become idle.
+func (t *resettableTransport) Reset(drainTimeout time.Duration) {
+	t.lock.Lock()
+	prev := t.current
+	t.current = t.newFn()
+	t.lock.Unlock()
+
+	closeIdleConnections(prev)
+	time.AfterFunc(drainTimeout, func() {
+		closeIdleConnections(prev)
+	})
+}
+
+func closeIdleConnections(rt http.RoundTripper) {
+	if c, ok := rt.(interface{ CloseIdleConnections() }); ok {
+		c.CloseIdleConnections()
+	}
+}
+
+// connCounter counts the open connections created by the transports to the app.
+type connCounter struct {
+	open atomic.Int64
+}
+
+// dialContext wraps a dial function so the connections it creates are counted until they're closed.
+func (c *connCounter) dialContext(dial func(ctx context.Context, network, addr string) (net.Conn, error)) func(ctx context.Context, network, addr string) (net.Conn, error) {
+	return func(ctx context.Context, network, addr string) (net.Conn, error) {
+		conn, err := dial(ctx, network, addr)
+		if err != nil {
+			return nil, err
+		}
+		c.open.Add(1)
+		return &countedConn{Conn: conn, counter: c}, nil
+	}
+}
+
+// Open returns the number of open connections.
+func (c *connCounter) Open() int {
+	return int(c.open.Load())
+}
+
+type countedConn struct {
+	net.Conn
+	counter *connCounter
+	once    sync.Once
+}
+
+func (c *countedConn) Close() error {
+	c.once.Do(func() {
+		c.counter.open.Add(-1)
+	})
+	return c.Conn.Close()
+}
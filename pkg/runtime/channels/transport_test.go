@@ -0,0 +1,76 @@
+/*
+Copyright 2023 The Dapr Authors
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package channels
+
+import (
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestResettableTransport(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+	t.Cleanup(srv.Close)
+
+	var created atomic.Int32
+	tr := newResettableTransport(func() http.RoundTripper {
+		created.Add(1)
+		return &http.Transport{}
+	})
+	client := &http.Client{Transport: tr}
+	assert.Equal(t, int32(1), created.Load())
+
+	first := tr.current
+	res, err := client.Get(srv.URL)
+	require.NoError(t, err)
+	res.Body.Close()
+
+	tr.Reset(time.Millisecond)
+	assert.Equal(t, int32(2), created.Load())
+	assert.NotSame(t, first, tr.current)
+
+	res, err = client.Get(srv.URL)
+	require.NoError(t, err)
+	res.Body.Close()
+	assert.Equal(t, http.StatusOK, res.StatusCode)
+}
+
+func TestConnCounter(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+	t.Cleanup(srv.Close)
+
+	counter := new(connCounter)
+	tr := &http.Transport{
+		DialContext: counter.dialContext(new(net.Dialer).DialContext),
+	}
+	client := &http.Client{Transport: tr}
+
+	res, err := client.Get(srv.URL)
+	require.NoError(t, err)
+	res.Body.Close()
+	assert.Equal(t, 1, counter.Open())
+
+	tr.CloseIdleConnections()
+	assert.Equal(t, 0, counter.Open())
+}
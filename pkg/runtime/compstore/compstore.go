@@ -54,6 +54,9 @@ type ComponentStore struct {
 
 	compPendingLock sync.Mutex
 	compPending     *compsv1alpha1.Component
+
+	operationsLock sync.Mutex
+	operations     map[string]*inflightOperations
 }
 
 func New() *ComponentStore {
@@ -71,5 +74,6 @@ func New() *ComponentStore {
 		workflowComponents:      make(map[string]workflows.Workflow),
 		cryptoProviders:         make(map[string]crypto.SubtleCrypto),
 		topicRoutes:             make(map[string]TopicRoutes),
+		operations:              make(map[string]*inflightOperations),
 	}
 }
@@ -0,0 +1,112 @@
+/*
+Copyright 2023 The Dapr Authors
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package compstore
+
+import (
+	"context"
+	"sync"
+)
+
+// inflightOperations counts the operations in-flight on a component.
+type inflightOperations struct {
+	lock  sync.Mutex
+	count int
+	// idle is closed when count drops to zero, if anyone is waiting for it.
+	idle chan struct{}
+}
+
+// TrackOperation records the start of an operation on the component, so it can be drained before the component is closed.
+// The returned function must be called when the operation completes.
+func (c *ComponentStore) TrackOperation(name string) (done func()) {
+	c.operationsLock.Lock()
+	if c.operations == nil {
+		c.operations = make(map[string]*inflightOperations)
+	}
+	ops, ok := c.operations[name]
+	if !ok {
+		ops = &inflightOperations{}
+		c.operations[name] = ops
+	}
+	c.operationsLock.Unlock()
+
+	ops.lock.Lock()
+	ops.count++
+	ops.lock.Unlock()
+
+	var once sync.Once
+	return func() {
+		once.Do(func() {
+			ops.lock.Lock()
+			defer ops.lock.Unlock()
+			ops.count--
+			if ops.count == 0 && ops.idle != nil {
+				close(ops.idle)
+				ops.idle = nil
+			}
+		})
+	}
+}
+
+// InflightOperations returns the number of operations in-flight on the component.
+func (c *ComponentStore) InflightOperations(name string) int {
+	c.operationsLock.Lock()
+	ops, ok := c.operations[name]
+	c.operationsLock.Unlock()
+	if !ok {
+		return 0
+	}
+
+	ops.lock.Lock()
+	defer ops.lock.Unlock()
+	return ops.count
+}
+
+// DrainOperations waits for the operations in-flight on the component to complete, until the context is done.
+// It returns the number of operations that completed, and the number of operations still in-flight when the context was done.
+func (c *ComponentStore) DrainOperations(ctx context.Context, name string) (drained int, aborted int) {
+	c.operationsLock.Lock()
+	ops, ok := c.operations[name]
+	c.operationsLock.Unlock()
+	if !ok {
+		return 0, 0
+	}
+
+	ops.lock.Lock()
+	inflight := ops.count
+	if inflight == 0 {
+		ops.lock.Unlock()
+		return 0, 0
+	}
+	if ops.idle == nil {
+		ops.idle = make(chan struct{})
+	}
+	idle := ops.idle
+	ops.lock.Unlock()
+
+	select {
+	case <-idle:
+		return inflight, 0
+	case <-ctx.Done():
+	}
+
+	// Operations that started while draining are counted as aborted too.
+	ops.lock.Lock()
+	defer ops.lock.Unlock()
+	aborted = ops.count
+	drained = inflight - aborted
+	if drained < 0 {
+		drained = 0
+	}
+	return drained, aborted
+}
@@ -27,6 +27,12 @@ type PubsubItem struct {
 	AllowedTopics       []string
 	ProtectedTopics     []string
 	NamespaceScoped     bool
+	// ClaimCheck is set if the data of large messages is stored in a state store.
+	ClaimCheck *rtpubsub.ClaimCheck
+	// Compression is set if the data of the messages is compressed above a size threshold.
+	Compression *rtpubsub.Compression
+	// Encryption is set if the data of the messages is encrypted with a crypto component.
+	Encryption *rtpubsub.Encryption
 }
 
 type TopicRoutes map[string]TopicRouteElem
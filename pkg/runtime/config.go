@@ -23,6 +23,7 @@ import (
 	"time"
 
 	"github.com/dapr/dapr/pkg/acl"
+	"github.com/dapr/dapr/pkg/channel/mock"
 	"github.com/dapr/dapr/pkg/config"
 	env "github.com/dapr/dapr/pkg/config/env"
 	configmodes "github.com/dapr/dapr/pkg/config/modes"
@@ -65,6 +66,8 @@ const (
 	DefaultAppHealthCheckPath = "/healthz"
 	// DefaultChannelAddress is the default local network address that user application listen on.
 	DefaultChannelAddress = "127.0.0.1"
+	// DefaultAppGRPCKeepaliveTimeout is the default time to wait for a response to a keepalive ping on the gRPC connection to the app.
+	DefaultAppGRPCKeepaliveTimeout = 20 * time.Second
 )
 
 // Config holds the Dapr Runtime configuration.
@@ -92,6 +95,8 @@ type Config struct {
 	DaprGracefulShutdownSeconds  int
 	DaprBlockShutdownDuration    *time.Duration
 	PlacementServiceHostAddr     string
+	SchedulerServiceHostAddr     string
+	ActorVersion                 string
 	DaprAPIListenAddresses       string
 	AppHealthProbeInterval       int
 	AppHealthProbeTimeout        int
@@ -104,6 +109,13 @@ type Config struct {
 	DisableBuiltinK8sSecretStore bool
 	AppHealthCheckPath           string
 	AppChannelAddress            string
+	AppChannel                   string
+	AppChannelMockConfig         string
+	AppChannelRoutes             []string
+	AppGRPCKeepaliveTime         time.Duration
+	AppGRPCKeepaliveTimeout      time.Duration
+	AppGRPCMaxConnectionAge      time.Duration
+	MaxInvokeTimeout             time.Duration
 	Metrics                      *metrics.Options
 	Registry                     *registry.Options
 	Security                     security.Handler
@@ -121,6 +133,8 @@ type internalConfig struct {
 	appConnectionConfig          config.AppConnectionConfig
 	mode                         modes.DaprMode
 	placementAddresses           []string
+	schedulerAddress             string
+	actorVersion                 string
 	allowedOrigins               string
 	standalone                   configmodes.StandaloneConfig
 	kubernetes                   configmodes.KubernetesConfig
@@ -131,11 +145,13 @@ type internalConfig struct {
 	readBufferSize               int
 	gracefulShutdownDuration     time.Duration
 	blockShutdownDuration        *time.Duration
+	maxInvokeTimeout             time.Duration
 	enableAPILogging             *bool
 	disableBuiltinK8sSecretStore bool
 	config                       []string
 	registry                     *registry.Registry
 	metricsExporter              metrics.Exporter
+	mockAppChannel               *mock.Config
 }
 
 func (i internalConfig) ActorsEnabled() bool {
@@ -194,12 +210,8 @@ func FromConfig(ctx context.Context, cfg *Config) (*DaprRuntime, error) {
 	if len(intc.config) > 0 {
 		switch intc.mode {
 		case modes.KubernetesMode:
-			if len(intc.config) > 1 {
-				// We are not returning an error here because in Kubernetes mode, the injector itself doesn't allow multiple configuration flags to be added, so this should never happen in normal environments
-				log.Warnf("Multiple configurations are not supported in Kubernetes mode; only the first one will be loaded")
-			}
-			log.Debug("Loading Kubernetes config resource: " + intc.config[0])
-			globalConfig, configErr = config.LoadKubernetesConfiguration(intc.config[0], namespace, podName, operatorClient)
+			log.Debug("Loading Kubernetes config resource(s): " + strings.Join(intc.config, ", "))
+			globalConfig, configErr = config.LoadKubernetesConfiguration(intc.config, namespace, podName, operatorClient)
 		case modes.StandaloneMode:
 			log.Debug("Loading config from file(s): " + strings.Join(intc.config, ", "))
 			globalConfig, configErr = config.LoadStandaloneConfiguration(intc.config...)
@@ -223,7 +235,7 @@ func FromConfig(ctx context.Context, cfg *Config) (*DaprRuntime, error) {
 	// Initialize metrics only if MetricSpec is enabled.
 	metricsSpec := globalConfig.GetMetricsSpec()
 	if metricsSpec.GetEnabled() {
-		if mErr := diag.InitMetrics(intc.id, namespace, metricsSpec.Rules); mErr != nil {
+		if mErr := diag.InitMetrics(intc.id, namespace, metricsSpec); mErr != nil {
 			log.Errorf(rterrors.NewInit(rterrors.InitFailure, "metrics", mErr).Error())
 		}
 	}
@@ -244,10 +256,14 @@ func FromConfig(ctx context.Context, cfg *Config) (*DaprRuntime, error) {
 			resiliencyProvider = resiliencyConfig.FromConfigurations(log)
 		}
 	}
+	if globalConfig.IsFeatureEnabled(config.FaultInjection) {
+		log.Warn("Fault injection is enabled: fault policies defined in Resiliency resources will inject delays and errors")
+		resiliencyProvider.EnableFaultInjection()
+	}
 
 	accessControlList, err := acl.ParseAccessControlSpec(
 		globalConfig.Spec.AccessControlSpec,
-		intc.appConnectionConfig.Protocol.IsHTTP(),
+		intc.appConnectionConfig.ProtocolFor(config.AppChannelRouteServiceInvocation).IsHTTP(),
 	)
 	if err != nil {
 		return nil, err
@@ -281,14 +297,20 @@ func (c *Config) toInternal() (*internalConfig, error) {
 		maxRequestBodySize:           c.DaprHTTPMaxRequestSize,
 		readBufferSize:               c.DaprHTTPReadBufferSize,
 		enableAPILogging:             c.EnableAPILogging,
+		actorVersion:                 c.ActorVersion,
+		schedulerAddress:             c.SchedulerServiceHostAddr,
 		appConnectionConfig: config.AppConnectionConfig{
-			ChannelAddress:      c.AppChannelAddress,
-			HealthCheckHTTPPath: c.AppHealthCheckPath,
-			MaxConcurrency:      c.AppMaxConcurrency,
+			ChannelAddress:       c.AppChannelAddress,
+			HealthCheckHTTPPath:  c.AppHealthCheckPath,
+			MaxConcurrency:       c.AppMaxConcurrency,
+			GRPCKeepaliveTime:    c.AppGRPCKeepaliveTime,
+			GRPCKeepaliveTimeout: c.AppGRPCKeepaliveTimeout,
+			GRPCMaxConnectionAge: c.AppGRPCMaxConnectionAge,
 		},
 		registry:              registry.New(c.Registry),
 		metricsExporter:       metrics.NewExporterWithOptions(log, metrics.DefaultMetricNamespace, c.Metrics),
 		blockShutdownDuration: c.DaprBlockShutdownDuration,
+		maxInvokeTimeout:      c.MaxInvokeTimeout,
 	}
 
 	if len(intc.standalone.ResourcesPath) == 0 && c.ComponentsPath != "" {
@@ -410,6 +432,31 @@ func (c *Config) toInternal() (*internalConfig, error) {
 		return nil, fmt.Errorf("invalid value for 'app-protocol': %v", c.AppProtocol)
 	}
 
+	intc.appConnectionConfig.Routes, err = config.ParseAppChannelRoutes(c.AppChannelRoutes)
+	if err != nil {
+		return nil, fmt.Errorf("invalid value for 'app-channel-route': %w", err)
+	}
+	for buildingBlock, route := range intc.appConnectionConfig.Routes {
+		if route.Port == intc.httpPort || route.Port == intc.apiGRPCPort {
+			return nil, fmt.Errorf("the port %d of the app channel route for building block '%s' conflicts with a Dapr API port", route.Port, buildingBlock)
+		}
+	}
+
+	switch c.AppChannel {
+	case "":
+	case mock.AppChannelName:
+		if !intc.appConnectionConfig.Protocol.IsHTTP() {
+			return nil, fmt.Errorf("the mock app channel requires 'app-protocol' to be http, got: %v", intc.appConnectionConfig.Protocol)
+		}
+		intc.mockAppChannel, err = mock.LoadConfig(c.AppChannelMockConfig)
+		if err != nil {
+			return nil, err
+		}
+		log.Warn("Using the mock app channel: requests to the app are served with canned responses. This is meant for local development only")
+	default:
+		return nil, fmt.Errorf("invalid value for 'app-channel': %v", c.AppChannel)
+	}
+
 	intc.apiListenAddresses = strings.Split(c.DaprAPIListenAddresses, ",")
 	if len(intc.apiListenAddresses) == 0 {
 		intc.apiListenAddresses = []string{DefaultAPIListenAddress}
@@ -62,6 +62,7 @@ func Test_toInternal(t *testing.T) {
 
 	assert.Equal(t, "app1", intc.id)
 	assert.Equal(t, "localhost:5050", intc.placementAddresses[0])
+	assert.Equal(t, "localhost:50006", intc.schedulerAddress)
 	assert.Equal(t, "localhost:5051", intc.kubernetes.ControlPlaneAddress)
 	assert.Equal(t, "*", intc.allowedOrigins)
 	_ = assert.Len(t, intc.standalone.ResourcesPath, 1) &&
@@ -89,6 +90,38 @@ func Test_toInternal(t *testing.T) {
 	assert.Equal(t, "1.1.1.1", intc.appConnectionConfig.ChannelAddress)
 }
 
+func TestToInternalMockAppChannel(t *testing.T) {
+	t.Run("disabled by default", func(t *testing.T) {
+		cfg := defaultTestConfig()
+		intc, err := cfg.toInternal()
+		require.NoError(t, err)
+		assert.Nil(t, intc.mockAppChannel)
+	})
+
+	t.Run("mock app channel", func(t *testing.T) {
+		cfg := defaultTestConfig()
+		cfg.AppChannel = "mock"
+		intc, err := cfg.toInternal()
+		require.NoError(t, err)
+		assert.NotNil(t, intc.mockAppChannel)
+	})
+
+	t.Run("mock app channel requires http", func(t *testing.T) {
+		cfg := defaultTestConfig()
+		cfg.AppChannel = "mock"
+		cfg.AppProtocol = "grpc"
+		_, err := cfg.toInternal()
+		require.Error(t, err)
+	})
+
+	t.Run("invalid app channel", func(t *testing.T) {
+		cfg := defaultTestConfig()
+		cfg.AppChannel = "foo"
+		_, err := cfg.toInternal()
+		require.ErrorContains(t, err, "invalid value for 'app-channel'")
+	})
+}
+
 func TestStandaloneWasmStrictSandbox(t *testing.T) {
 	global, err := config.LoadStandaloneConfiguration("../config/testdata/wasm_strict_sandbox.yaml")
 
@@ -100,6 +133,7 @@ func defaultTestConfig() Config {
 	return Config{
 		AppID:                        "app1",
 		PlacementServiceHostAddr:     "localhost:5050",
+		SchedulerServiceHostAddr:     "localhost:50006",
 		ControlPlaneAddress:          "localhost:5051",
 		AllowedOrigins:               "*",
 		ResourcesPath:                []string{"components"},
@@ -0,0 +1,295 @@
+/*
+Copyright 2023 The Dapr Authors
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package multiapp reads self-hosted multi-app run profiles and starts one logical sidecar per app in the current process.
+// This is meant for embedded and development scenarios, such as integration tests.
+package multiapp
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"time"
+
+	"sigs.k8s.io/yaml"
+
+	"github.com/dapr/dapr/pkg/config"
+	"github.com/dapr/dapr/pkg/config/protocol"
+	"github.com/dapr/dapr/pkg/cors"
+	"github.com/dapr/dapr/pkg/metrics"
+	"github.com/dapr/dapr/pkg/modes"
+	"github.com/dapr/dapr/pkg/ports"
+	"github.com/dapr/dapr/pkg/runtime"
+)
+
+// supportedProfileVersion is the only version of the multi-app run profile format that is supported.
+const supportedProfileVersion = 1
+
+// Start of the ranges used to assign "stable random" ports to apps that don't set them explicitly, since all sidecars share the same host.
+const (
+	stableHTTPPortStart    = 33000
+	stableGRPCPortStart    = 35100
+	stableMetricsPortStart = 37200
+	stableProfilePortStart = 39300
+)
+
+// Profile is a multi-app run profile.
+// The format is compatible with the multi-app run template used by the Dapr CLI.
+type Profile struct {
+	Version int `json:"version"`
+	// Common contains the settings shared by all apps, such as the resources folder.
+	Common App `json:"common"`
+	// Apps contains the per-app settings, which override the ones in Common.
+	Apps []App `json:"apps"`
+}
+
+// App contains the settings for a single app in a multi-app run profile.
+type App struct {
+	AppID                string   `json:"appID"`
+	AppPort              int      `json:"appPort"`
+	AppProtocol          string   `json:"appProtocol"`
+	AppChannelAddress    string   `json:"appChannelAddress"`
+	AppHealthCheckPath   string   `json:"appHealthCheckPath"`
+	EnableAppHealthCheck *bool    `json:"enableAppHealthCheck"`
+	ResourcesPath        string   `json:"resourcesPath"`
+	ResourcesPaths       []string `json:"resourcesPaths"`
+	ConfigFilePath       string   `json:"configFilePath"`
+	DaprHTTPPort         int      `json:"daprHTTPPort"`
+	DaprGRPCPort         int      `json:"daprGRPCPort"`
+	DaprInternalGRPCPort int      `json:"daprInternalGRPCPort"`
+	MetricsPort          int      `json:"metricsPort"`
+	ProfilePort          int      `json:"profilePort"`
+	EnableAPILogging     *bool    `json:"enableApiLogging"`
+	PlacementHostAddress string   `json:"placementHostAddress"`
+}
+
+// LoadProfile loads a multi-app run profile from a file.
+// Relative paths in the profile are resolved against the folder containing the file.
+func LoadProfile(path string) (*Profile, error) {
+	b, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read multi-app run profile %s: %w", path, err)
+	}
+
+	var p Profile
+	err = yaml.Unmarshal(b, &p)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse multi-app run profile %s: %w", path, err)
+	}
+
+	baseDir := filepath.Dir(path)
+	p.Common.resolvePaths(baseDir)
+	for i := range p.Apps {
+		p.Apps[i].resolvePaths(baseDir)
+	}
+
+	err = p.Validate()
+	if err != nil {
+		return nil, err
+	}
+	return &p, nil
+}
+
+// Validate returns an error if the profile is not valid.
+func (p *Profile) Validate() error {
+	if p.Version != supportedProfileVersion {
+		return fmt.Errorf("unsupported multi-app run profile version %d", p.Version)
+	}
+	if len(p.Apps) == 0 {
+		return errors.New("multi-app run profile doesn't contain any app")
+	}
+
+	appIDs := make(map[string]struct{}, len(p.Apps))
+	ports := map[int]string{}
+	for _, app := range p.Apps {
+		if app.AppID == "" {
+			return errors.New("appID is required for all apps in a multi-app run profile")
+		}
+		if _, ok := appIDs[app.AppID]; ok {
+			return fmt.Errorf("duplicate appID '%s' in multi-app run profile", app.AppID)
+		}
+		appIDs[app.AppID] = struct{}{}
+
+		// All sidecars run in the same process, so they can't share any port
+		for _, port := range []int{app.DaprHTTPPort, app.DaprGRPCPort, app.DaprInternalGRPCPort, app.MetricsPort, app.ProfilePort} {
+			if port == 0 {
+				continue
+			}
+			if other, ok := ports[port]; ok {
+				return fmt.Errorf("port %d is used by both '%s' and '%s' in multi-app run profile", port, other, app.AppID)
+			}
+			ports[port] = app.AppID
+		}
+	}
+
+	return nil
+}
+
+// RuntimeConfigs returns the runtime configuration for each app in the profile, with the common settings applied.
+// The Registry and Security fields are not set and must be populated by the caller.
+func (p *Profile) RuntimeConfigs() ([]*runtime.Config, error) {
+	cfgs := make([]*runtime.Config, len(p.Apps))
+	for i, app := range p.Apps {
+		cfg, err := p.Common.merge(app).runtimeConfig()
+		if err != nil {
+			return nil, fmt.Errorf("failed to create runtime configuration for app '%s': %w", app.AppID, err)
+		}
+		cfgs[i] = cfg
+	}
+	return cfgs, nil
+}
+
+// merge returns a copy of a with the non-empty fields of override applied on top.
+func (a App) merge(override App) App {
+	res := a
+	res.AppID = override.AppID
+	if override.AppPort != 0 {
+		res.AppPort = override.AppPort
+	}
+	if override.AppProtocol != "" {
+		res.AppProtocol = override.AppProtocol
+	}
+	if override.AppChannelAddress != "" {
+		res.AppChannelAddress = override.AppChannelAddress
+	}
+	if override.AppHealthCheckPath != "" {
+		res.AppHealthCheckPath = override.AppHealthCheckPath
+	}
+	if override.EnableAppHealthCheck != nil {
+		res.EnableAppHealthCheck = override.EnableAppHealthCheck
+	}
+	if override.ResourcesPath != "" || len(override.ResourcesPaths) > 0 {
+		res.ResourcesPath = override.ResourcesPath
+		res.ResourcesPaths = override.ResourcesPaths
+	}
+	if override.ConfigFilePath != "" {
+		res.ConfigFilePath = override.ConfigFilePath
+	}
+	if override.EnableAPILogging != nil {
+		res.EnableAPILogging = override.EnableAPILogging
+	}
+	if override.PlacementHostAddress != "" {
+		res.PlacementHostAddress = override.PlacementHostAddress
+	}
+
+	// Ports are never inherited from the common section, as they can't be shared
+	res.DaprHTTPPort = override.DaprHTTPPort
+	res.DaprGRPCPort = override.DaprGRPCPort
+	res.DaprInternalGRPCPort = override.DaprInternalGRPCPort
+	res.MetricsPort = override.MetricsPort
+	res.ProfilePort = override.ProfilePort
+
+	return res
+}
+
+func (a *App) resolvePaths(baseDir string) {
+	resolve := func(p string) string {
+		if p == "" || filepath.IsAbs(p) {
+			return p
+		}
+		return filepath.Join(baseDir, p)
+	}
+
+	a.ResourcesPath = resolve(a.ResourcesPath)
+	for i := range a.ResourcesPaths {
+		a.ResourcesPaths[i] = resolve(a.ResourcesPaths[i])
+	}
+	a.ConfigFilePath = resolve(a.ConfigFilePath)
+}
+
+func (a App) runtimeConfig() (*runtime.Config, error) {
+	portOrStable := func(port int, start int) (string, error) {
+		if port == 0 {
+			var err error
+			port, err = ports.GetStablePort(start, a.AppID)
+			if err != nil {
+				return "", err
+			}
+		}
+		return strconv.Itoa(port), nil
+	}
+
+	httpPort, err := portOrStable(a.DaprHTTPPort, stableHTTPPortStart)
+	if err != nil {
+		return nil, err
+	}
+	grpcPort, err := portOrStable(a.DaprGRPCPort, stableGRPCPortStart)
+	if err != nil {
+		return nil, err
+	}
+	metricsPort, err := portOrStable(a.MetricsPort, stableMetricsPortStart)
+	if err != nil {
+		return nil, err
+	}
+	profilePort, err := portOrStable(a.ProfilePort, stableProfilePortStart)
+	if err != nil {
+		return nil, err
+	}
+
+	cfg := &runtime.Config{
+		AppID:                       a.AppID,
+		Mode:                        string(modes.StandaloneMode),
+		AllowedOrigins:              cors.DefaultAllowedOrigins,
+		AppProtocol:                 string(protocol.HTTPProtocol),
+		AppChannelAddress:           runtime.DefaultChannelAddress,
+		AppHealthCheckPath:          runtime.DefaultAppHealthCheckPath,
+		AppHealthProbeInterval:      int(config.AppHealthConfigDefaultProbeInterval / time.Second),
+		AppHealthProbeTimeout:       int(config.AppHealthConfigDefaultProbeTimeout / time.Millisecond),
+		AppHealthThreshold:          int(config.AppHealthConfigDefaultThreshold),
+		AppMaxConcurrency:           -1,
+		DaprHTTPPort:                httpPort,
+		DaprAPIGRPCPort:             grpcPort,
+		ProfilePort:                 profilePort,
+		DaprAPIListenAddresses:      runtime.DefaultAPIListenAddress,
+		DaprHTTPMaxRequestSize:      runtime.DefaultMaxRequestBodySize,
+		DaprHTTPReadBufferSize:      runtime.DefaultReadBufferSize,
+		DaprGracefulShutdownSeconds: int(runtime.DefaultGracefulShutdownDuration / time.Second),
+		PlacementServiceHostAddr:    a.PlacementHostAddress,
+		EnableAPILogging:            a.EnableAPILogging,
+		Metrics: &metrics.Options{
+			Port:           metricsPort,
+			MetricsEnabled: true,
+		},
+	}
+
+	if a.AppPort != 0 {
+		cfg.ApplicationPort = strconv.Itoa(a.AppPort)
+	}
+	if a.DaprInternalGRPCPort != 0 {
+		cfg.DaprInternalGRPCPort = strconv.Itoa(a.DaprInternalGRPCPort)
+	}
+	if a.AppProtocol != "" {
+		cfg.AppProtocol = a.AppProtocol
+	}
+	if a.AppChannelAddress != "" {
+		cfg.AppChannelAddress = a.AppChannelAddress
+	}
+	if a.AppHealthCheckPath != "" {
+		cfg.AppHealthCheckPath = a.AppHealthCheckPath
+	}
+	if a.EnableAppHealthCheck != nil {
+		cfg.EnableAppHealthCheck = *a.EnableAppHealthCheck
+	}
+	if len(a.ResourcesPaths) > 0 {
+		cfg.ResourcesPath = a.ResourcesPaths
+	} else if a.ResourcesPath != "" {
+		cfg.ResourcesPath = []string{a.ResourcesPath}
+	}
+	if a.ConfigFilePath != "" {
+		cfg.Config = []string{a.ConfigFilePath}
+	}
+
+	return cfg, nil
+}
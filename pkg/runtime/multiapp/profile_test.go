@@ -0,0 +1,127 @@
+/*
+Copyright 2023 The Dapr Authors
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package multiapp
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const testProfile = `version: 1
+common:
+  resourcesPath: ./resources
+  appProtocol: grpc
+  daprHTTPPort: 3500
+apps:
+- appID: app1
+  appPort: 6001
+  daprHTTPPort: 3601
+  daprGRPCPort: 50001
+- appID: app2
+  appProtocol: http
+  resourcesPath: /abs/resources
+  configFilePath: ./config.yaml
+`
+
+func writeProfile(t *testing.T, content string) string {
+	t.Helper()
+	p := filepath.Join(t.TempDir(), "dapr.yaml")
+	require.NoError(t, os.WriteFile(p, []byte(content), 0o600))
+	return p
+}
+
+func TestLoadProfile(t *testing.T) {
+	t.Run("valid profile", func(t *testing.T) {
+		path := writeProfile(t, testProfile)
+		dir := filepath.Dir(path)
+
+		p, err := LoadProfile(path)
+		require.NoError(t, err)
+		require.Len(t, p.Apps, 2)
+		assert.Equal(t, filepath.Join(dir, "resources"), p.Common.ResourcesPath)
+		assert.Equal(t, "/abs/resources", p.Apps[1].ResourcesPath)
+		assert.Equal(t, filepath.Join(dir, "config.yaml"), p.Apps[1].ConfigFilePath)
+
+		cfgs, err := p.RuntimeConfigs()
+		require.NoError(t, err)
+		require.Len(t, cfgs, 2)
+
+		app1 := cfgs[0]
+		assert.Equal(t, "app1", app1.AppID)
+		assert.Equal(t, "6001", app1.ApplicationPort)
+		assert.Equal(t, "grpc", app1.AppProtocol)
+		assert.Equal(t, "3601", app1.DaprHTTPPort)
+		assert.Equal(t, "50001", app1.DaprAPIGRPCPort)
+		assert.Equal(t, []string{filepath.Join(dir, "resources")}, app1.ResourcesPath)
+		assert.Empty(t, app1.Config)
+
+		app2 := cfgs[1]
+		assert.Equal(t, "app2", app2.AppID)
+		assert.Empty(t, app2.ApplicationPort)
+		assert.Equal(t, "http", app2.AppProtocol)
+		assert.Equal(t, []string{"/abs/resources"}, app2.ResourcesPath)
+		assert.Equal(t, []string{filepath.Join(dir, "config.yaml")}, app2.Config)
+
+		// Ports are not inherited from the common section
+		assert.NotEqual(t, "3500", app2.DaprHTTPPort)
+		assert.NotEqual(t, app1.DaprHTTPPort, app2.DaprHTTPPort)
+		assert.NotEqual(t, app2.DaprHTTPPort, app2.DaprAPIGRPCPort)
+		assert.NotEqual(t, app2.DaprHTTPPort, app2.Metrics.Port)
+	})
+
+	t.Run("file not found", func(t *testing.T) {
+		_, err := LoadProfile(filepath.Join(t.TempDir(), "missing.yaml"))
+		require.Error(t, err)
+	})
+
+	t.Run("invalid profiles", func(t *testing.T) {
+		tests := map[string]struct {
+			profile string
+			errMsg  string
+		}{
+			"unsupported version": {
+				profile: "version: 2\napps:\n- appID: app1\n",
+				errMsg:  "unsupported multi-app run profile version 2",
+			},
+			"no apps": {
+				profile: "version: 1\n",
+				errMsg:  "doesn't contain any app",
+			},
+			"missing app ID": {
+				profile: "version: 1\napps:\n- appPort: 3000\n",
+				errMsg:  "appID is required",
+			},
+			"duplicate app ID": {
+				profile: "version: 1\napps:\n- appID: app1\n- appID: app1\n",
+				errMsg:  "duplicate appID 'app1'",
+			},
+			"duplicate port": {
+				profile: "version: 1\napps:\n- appID: app1\n  daprHTTPPort: 3500\n- appID: app2\n  metricsPort: 3500\n",
+				errMsg:  "port 3500 is used by both 'app1' and 'app2'",
+			},
+		}
+
+		for name, tc := range tests {
+			t.Run(name, func(t *testing.T) {
+				_, err := LoadProfile(writeProfile(t, tc.profile))
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tc.errMsg)
+			})
+		}
+	})
+}
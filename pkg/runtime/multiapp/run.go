@@ -0,0 +1,87 @@
+/*
+Copyright 2023 The Dapr Authors
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package multiapp
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/dapr/dapr/pkg/modes"
+	"github.com/dapr/dapr/pkg/runtime"
+	"github.com/dapr/dapr/pkg/runtime/registry"
+	"github.com/dapr/dapr/pkg/security"
+	"github.com/dapr/kit/concurrency"
+	"github.com/dapr/kit/logger"
+)
+
+var log = logger.NewLogger("dapr.runtime.multiapp")
+
+// newRuntimeFn creates the runtime of a sidecar and returns the function that runs it.
+// It's replaced in tests.
+var newRuntimeFn = func(ctx context.Context, cfg *runtime.Config) (concurrency.Runner, error) {
+	rt, err := runtime.FromConfig(ctx, cfg)
+	if err != nil {
+		return nil, err
+	}
+	return rt.Run, nil
+}
+
+// Run starts one sidecar for each app in the profile and blocks until the context is canceled or any of the sidecars returns.
+// All sidecars share the same component registry and run without mTLS.
+//
+// Note that some runtime settings are process-wide (for example, the diagnostics exporters and the environment variables set by the runtime), so the last sidecar to start wins for those.
+// This makes the multi-app mode suitable for development and integration testing only.
+func Run(ctx context.Context, profile *Profile, reg *registry.Options) error {
+	cfgs, err := profile.RuntimeConfigs()
+	if err != nil {
+		return err
+	}
+
+	runners := make([]concurrency.Runner, 0, 2*len(cfgs))
+	for _, cfg := range cfgs {
+		cfg := cfg
+		cfg.Registry = reg
+
+		secProvider, err := security.New(ctx, security.Options{
+			ControlPlaneTrustDomain: "localhost",
+			ControlPlaneNamespace:   "default",
+			AppID:                   cfg.AppID,
+			MTLSEnabled:             false,
+			Mode:                    modes.StandaloneMode,
+		})
+		if err != nil {
+			return fmt.Errorf("failed to create security provider for app '%s': %w", cfg.AppID, err)
+		}
+
+		runners = append(runners,
+			secProvider.Run,
+			func(ctx context.Context) error {
+				sec, err := secProvider.Handler(ctx)
+				if err != nil {
+					return err
+				}
+				cfg.Security = sec
+
+				log.Infof("Starting sidecar for app '%s' (HTTP port %s, gRPC port %s)", cfg.AppID, cfg.DaprHTTPPort, cfg.DaprAPIGRPCPort)
+				run, err := newRuntimeFn(ctx, cfg)
+				if err != nil {
+					return fmt.Errorf("failed to create runtime for app '%s': %w", cfg.AppID, err)
+				}
+				return run(ctx)
+			},
+		)
+	}
+
+	return concurrency.NewRunnerManager(runners...).Run(ctx)
+}
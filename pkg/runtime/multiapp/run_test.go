@@ -0,0 +1,120 @@
+/*
+Copyright 2023 The Dapr Authors
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package multiapp
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/dapr/dapr/pkg/runtime"
+	"github.com/dapr/dapr/pkg/runtime/registry"
+	"github.com/dapr/kit/concurrency"
+)
+
+func TestRun(t *testing.T) {
+	// fakeRuntimes replaces the runtimes of the sidecars with runners that block until the context is canceled, or return runErr for the app runErrAppID.
+	fakeRuntimes := func(t *testing.T, runErrAppID string, runErr error) func() map[string]*runtime.Config {
+		var (
+			lock    sync.Mutex
+			started = make(map[string]*runtime.Config)
+		)
+
+		orig := newRuntimeFn
+		t.Cleanup(func() { newRuntimeFn = orig })
+		newRuntimeFn = func(ctx context.Context, cfg *runtime.Config) (concurrency.Runner, error) {
+			lock.Lock()
+			started[cfg.AppID] = cfg
+			lock.Unlock()
+
+			return func(ctx context.Context) error {
+				if cfg.AppID == runErrAppID {
+					return runErr
+				}
+				<-ctx.Done()
+				return nil
+			}, nil
+		}
+
+		return func() map[string]*runtime.Config {
+			lock.Lock()
+			defer lock.Unlock()
+			res := make(map[string]*runtime.Config, len(started))
+			for k, v := range started {
+				res[k] = v
+			}
+			return res
+		}
+	}
+
+	t.Run("starts a sidecar for each app", func(t *testing.T) {
+		started := fakeRuntimes(t, "", nil)
+
+		profile, err := LoadProfile(writeProfile(t, testProfile))
+		require.NoError(t, err)
+		reg := registry.NewOptions()
+
+		ctx, cancel := context.WithCancel(context.Background())
+		errCh := make(chan error, 1)
+		go func() {
+			errCh <- Run(ctx, profile, reg)
+		}()
+
+		assert.Eventually(t, func() bool {
+			return len(started()) == 2
+		}, 5*time.Second, 10*time.Millisecond)
+
+		for _, appID := range []string{"app1", "app2"} {
+			cfg := started()[appID]
+			require.NotNil(t, cfg, appID)
+			assert.Same(t, reg, cfg.Registry)
+			assert.NotNil(t, cfg.Security)
+			assert.False(t, cfg.EnableMTLS)
+		}
+		assert.Equal(t, "3601", started()["app1"].DaprHTTPPort)
+
+		cancel()
+		select {
+		case err := <-errCh:
+			require.NoError(t, err)
+		case <-time.After(5 * time.Second):
+			t.Fatal("multi-app run did not stop")
+		}
+	})
+
+	t.Run("stops all sidecars when one fails", func(t *testing.T) {
+		fakeRuntimes(t, "app2", errors.New("simulated"))
+
+		profile, err := LoadProfile(writeProfile(t, testProfile))
+		require.NoError(t, err)
+
+		errCh := make(chan error, 1)
+		go func() {
+			errCh <- Run(context.Background(), profile, registry.NewOptions())
+		}()
+
+		select {
+		case err := <-errCh:
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), "simulated")
+		case <-time.After(5 * time.Second):
+			t.Fatal("multi-app run did not stop")
+		}
+	})
+}
@@ -21,6 +21,8 @@ import (
 	"strings"
 	"sync"
 
+	"k8s.io/utils/clock"
+
 	"github.com/dapr/components-contrib/bindings"
 	"github.com/dapr/dapr/pkg/apis/common"
 	compapi "github.com/dapr/dapr/pkg/apis/components/v1alpha1"
@@ -49,6 +51,7 @@ const (
 var log = logger.NewLogger("dapr.runtime.processor.binding")
 
 type Options struct {
+	AppID  string
 	IsHTTP bool
 
 	Registry       *compbindings.Registry
@@ -61,6 +64,7 @@ type Options struct {
 }
 
 type binding struct {
+	appID  string
 	isHTTP bool
 
 	registry    *compbindings.Registry
@@ -77,10 +81,19 @@ type binding struct {
 	subscribeBindingList []string
 	inputCancels         map[string]context.CancelFunc
 	wg                   sync.WaitGroup
+
+	// Options for storing the failed invocations of output bindings, keyed by binding name.
+	deadLetters     map[string]*deadLetter
+	deadLettersLock sync.RWMutex
+
+	// Stop functions of the output bindings invoked on a schedule, keyed by binding name.
+	schedules map[string]func()
+	clock     clock.Clock
 }
 
 func New(opts Options) *binding {
 	return &binding{
+		appID:        opts.AppID,
 		registry:     opts.Registry,
 		compStore:    opts.ComponentStore,
 		meta:         opts.Meta,
@@ -90,6 +103,9 @@ func New(opts Options) *binding {
 		grpc:         opts.GRPC,
 		channels:     opts.Channels,
 		inputCancels: make(map[string]context.CancelFunc),
+		deadLetters:  make(map[string]*deadLetter),
+		schedules:    make(map[string]func()),
+		clock:        clock.RealClock{},
 	}
 }
 
@@ -142,6 +158,10 @@ func (b *binding) Close(comp compapi.Component) error {
 	outbinding, ok := b.compStore.GetOutputBinding(comp.Name)
 	if ok {
 		defer b.compStore.DeleteOutputBinding(comp.Name)
+		b.stopSchedule(comp.Name)
+		b.deadLettersLock.Lock()
+		delete(b.deadLetters, comp.Name)
+		b.deadLettersLock.Unlock()
 		if err := b.closeOutputBinding(outbinding); err != nil {
 			errs = append(errs, err)
 		}
@@ -219,6 +239,18 @@ func (b *binding) initOutputBinding(ctx context.Context, comp compapi.Component)
 			return rterrors.NewInit(rterrors.InitComponentFailure, fName, err)
 		}
 
+		deadLetter, err := newDeadLetter(meta.Properties)
+		if err != nil {
+			diag.DefaultMonitoring.ComponentInitFailed(comp.Spec.Type, "init", comp.ObjectMeta.Name)
+			return rterrors.NewInit(rterrors.InitComponentFailure, fName, err)
+		}
+
+		sched, err := newSchedule(meta.Properties)
+		if err != nil {
+			diag.DefaultMonitoring.ComponentInitFailed(comp.Spec.Type, "init", comp.ObjectMeta.Name)
+			return rterrors.NewInit(rterrors.InitComponentFailure, fName, err)
+		}
+
 		err = binding.Init(ctx, bindings.Metadata{Base: meta})
 		if err != nil {
 			diag.DefaultMonitoring.ComponentInitFailed(comp.Spec.Type, "init", comp.ObjectMeta.Name)
@@ -226,6 +258,18 @@ func (b *binding) initOutputBinding(ctx context.Context, comp compapi.Component)
 		}
 		log.Infof("successful init for output binding (%s)", comp.LogName())
 		b.compStore.AddOutputBinding(comp.ObjectMeta.Name, binding)
+		b.deadLettersLock.Lock()
+		if deadLetter != nil {
+			b.deadLetters[comp.ObjectMeta.Name] = deadLetter
+		} else {
+			delete(b.deadLetters, comp.ObjectMeta.Name)
+		}
+		b.deadLettersLock.Unlock()
+		if sched != nil {
+			b.startSchedule(comp.ObjectMeta.Name, sched)
+		} else {
+			b.stopSchedule(comp.ObjectMeta.Name)
+		}
 		diag.DefaultMonitoring.ComponentInitialized(comp.Spec.Type)
 	}
 	return nil
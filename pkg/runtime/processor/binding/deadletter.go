@@ -0,0 +1,362 @@
+/*
+Copyright 2023 The Dapr Authors
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package binding
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"sync/atomic"
+	"time"
+
+	"github.com/cenkalti/backoff/v4"
+
+	"github.com/dapr/components-contrib/bindings"
+	contribMetadata "github.com/dapr/components-contrib/metadata"
+	"github.com/dapr/components-contrib/state"
+	"github.com/dapr/dapr/pkg/grpc/universalapi"
+	"github.com/dapr/dapr/pkg/messages"
+	"github.com/dapr/kit/ptr"
+)
+
+const (
+	// Metadata properties of output bindings that enable storing the invocations that failed.
+	deadLetterStateStoreKey = "deadLetterStateStore"
+	deadLetterTTLKey        = "deadLetterTTL"
+
+	deadLetterStatePrefix = "dapr-binding-dead-letter"
+	// Suffixes of the keys with the first and the next slot of the failed invocations of a binding.
+	deadLetterHeadSuffix = "head"
+	deadLetterNextSuffix = "next"
+
+	// Default time the failed invocations are kept in the state store.
+	defaultDeadLetterTTL = 7 * 24 * time.Hour
+	// Timeout for storing a failed invocation, which is done even if the context of the request is canceled.
+	deadLetterStoreTimeout = 10 * time.Second
+	// Time a failed invocation is reserved for the replay that claimed it.
+	// If the replay doesn't complete in this time, for example because the sidecar was stopped, the invocation can be claimed by another replay.
+	deadLetterClaimTimeout = 5 * time.Minute
+)
+
+// deadLetter contains the options for storing the invocations of an output binding that failed after the resiliency policies were applied, so they can be replayed later.
+// Each failed invocation is stored in its own key, in the first free slot of a sequence, so invocations stored concurrently never overwrite each other.
+type deadLetter struct {
+	// Name of the state store where the failed invocations are stored.
+	stateStore string
+	// Time the failed invocations are kept in the state store.
+	ttl time.Duration
+	// Slot from which this runtime looks for a free slot, which is shared through the state store too.
+	next atomic.Uint64
+}
+
+// DeadLetterEntry is an invocation of an output binding that failed, stored in the state store.
+type DeadLetterEntry struct {
+	Operation string            `json:"operation"`
+	Data      []byte            `json:"data,omitempty"`
+	Metadata  map[string]string `json:"metadata,omitempty"`
+	Error     string            `json:"error"`
+	FailedAt  time.Time         `json:"failedAt"`
+	// Attempts is the number of times the invocation failed, including the replays.
+	Attempts int `json:"attempts"`
+	// ExpiresAt is the time after which the invocation is not replayed anymore.
+	// It's used for state stores that don't support TTLs, where the invocation is removed by the next replay.
+	ExpiresAt time.Time `json:"expiresAt"`
+	// ClaimedUntil is set while the invocation is being replayed.
+	ClaimedUntil *time.Time `json:"claimedUntil,omitempty"`
+}
+
+// newDeadLetter returns the dead-letter options from the metadata of an output binding.
+// It returns nil if failed invocations are not stored for the binding.
+func newDeadLetter(properties map[string]string) (*deadLetter, error) {
+	stateStore := strings.TrimSpace(properties[deadLetterStateStoreKey])
+	if stateStore == "" {
+		return nil, nil
+	}
+
+	d := &deadLetter{
+		stateStore: stateStore,
+		ttl:        defaultDeadLetterTTL,
+	}
+
+	if v := strings.TrimSpace(properties[deadLetterTTLKey]); v != "" {
+		ttl, err := time.ParseDuration(v)
+		if err != nil || ttl <= 0 {
+			return nil, fmt.Errorf("invalid value for metadata property '%s': must be a positive duration", deadLetterTTLKey)
+		}
+		d.ttl = ttl
+	}
+
+	return d, nil
+}
+
+func deadLetterKey(name string, suffix string) string {
+	return deadLetterStatePrefix + "||" + name + "||" + suffix
+}
+
+func deadLetterEntryKey(name string, slot uint64) string {
+	return deadLetterKey(name, strconv.FormatUint(slot, 10))
+}
+
+func (b *binding) getDeadLetter(name string) (*deadLetter, bool) {
+	b.deadLettersLock.RLock()
+	defer b.deadLettersLock.RUnlock()
+	d, ok := b.deadLetters[name]
+	return d, ok
+}
+
+func (b *binding) deadLetterStore(d *deadLetter) (state.Store, error) {
+	store, ok := b.compStore.GetStateStore(d.stateStore)
+	if !ok {
+		return nil, fmt.Errorf("state store %s used to store the failed invocations not found", d.stateStore)
+	}
+	return store, nil
+}
+
+// isDeadLetterError returns true if the error of an invocation of an output binding can be solved by replaying the invocation.
+// Invocations that are invalid, or that failed with a permanent error, fail again when replayed.
+func isDeadLetterError(err error) bool {
+	var permanent *backoff.PermanentError
+	return !errors.As(err, &permanent)
+}
+
+// storeDeadLetter stores an invocation of an output binding that failed in the first free slot in the state store.
+func (b *binding) storeDeadLetter(ctx context.Context, name string, d *deadLetter, req *bindings.InvokeRequest, invokeErr error) error {
+	store, err := b.deadLetterStore(d)
+	if err != nil {
+		return err
+	}
+
+	now := time.Now().UTC()
+	entry := DeadLetterEntry{
+		Operation: string(req.Operation),
+		Data:      req.Data,
+		Metadata:  req.Metadata,
+		Error:     invokeErr.Error(),
+		FailedAt:  now,
+		Attempts:  1,
+		ExpiresAt: now.Add(d.ttl),
+	}
+	data, err := json.Marshal(entry)
+	if err != nil {
+		return err
+	}
+
+	// Slots before the first one are not replayed, so they're never used
+	slot := max(
+		d.next.Load(),
+		b.getDeadLetterSlot(ctx, store, name, deadLetterNextSuffix),
+		b.getDeadLetterSlot(ctx, store, name, deadLetterHeadSuffix),
+	)
+	for {
+		// The entry is saved with an insert-only write, so it doesn't overwrite entries saved concurrently
+		err = store.Set(ctx, &state.SetRequest{
+			Key:      deadLetterEntryKey(name, slot),
+			Value:    data,
+			Metadata: deadLetterMetadata(store, d),
+			Options: state.SetStateOption{
+				Concurrency: state.FirstWrite,
+			},
+		})
+		if err == nil {
+			break
+		}
+
+		// Not all state stores return an ETagError on conflicts, so the slot is read to check if it's taken
+		res, getErr := store.Get(ctx, &state.GetRequest{Key: deadLetterEntryKey(name, slot)})
+		if getErr != nil || res == nil || len(res.Data) == 0 || ctx.Err() != nil {
+			return fmt.Errorf("failed to save the failed invocation in state store %s: %w", d.stateStore, err)
+		}
+		slot++
+	}
+
+	if slot+1 > d.next.Load() {
+		d.next.Store(slot + 1)
+	}
+	b.saveDeadLetterSlot(ctx, store, name, d, deadLetterNextSuffix, slot+1)
+	return nil
+}
+
+// deadLetterMetadata returns the metadata of the requests that save the failed invocations.
+// The TTL is set only if the state store supports it; otherwise, the expired invocations are removed when replaying them.
+func deadLetterMetadata(store state.Store, d *deadLetter) map[string]string {
+	if !state.FeatureTTL.IsPresent(store.Features()) {
+		return nil
+	}
+	return map[string]string{
+		contribMetadata.TTLMetadataKey: strconv.Itoa(max(int(d.ttl.Seconds()), 1)),
+	}
+}
+
+// getDeadLetterSlot returns the slot saved in the key with the suffix.
+// Slots are only hints for finding the failed invocations, so errors are ignored.
+func (b *binding) getDeadLetterSlot(ctx context.Context, store state.Store, name string, suffix string) uint64 {
+	res, err := store.Get(ctx, &state.GetRequest{Key: deadLetterKey(name, suffix)})
+	if err != nil || res == nil || len(res.Data) == 0 {
+		return 0
+	}
+	slot, _ := strconv.ParseUint(strings.Trim(string(res.Data), `"`), 10, 64)
+	return slot
+}
+
+// saveDeadLetterSlot saves the slot in the key with the suffix.
+// The key expires together with the failed invocations, so no keys are left in the state store after all failed invocations expired.
+func (b *binding) saveDeadLetterSlot(ctx context.Context, store state.Store, name string, d *deadLetter, suffix string, slot uint64) {
+	err := store.Set(ctx, &state.SetRequest{
+		Key:      deadLetterKey(name, suffix),
+		Value:    []byte(strconv.FormatUint(slot, 10)),
+		Metadata: deadLetterMetadata(store, d),
+	})
+	if err != nil {
+		log.Debugf("Failed to save the %s slot of the failed invocations of binding %s: %v", suffix, name, err)
+	}
+}
+
+// ReplayDeadLetters invokes the output binding again with the invocations that failed and were stored in the state store.
+// Each invocation is claimed before it's replayed, so concurrent replays don't invoke the binding twice with the same invocation.
+// The invocations that succeed, or that have expired, are removed from the state store; the others are kept for the next replay.
+func (b *binding) ReplayDeadLetters(ctx context.Context, name string) (*universalapi.BindingDeadLetterReplay, error) {
+	if _, ok := b.compStore.GetOutputBinding(name); !ok {
+		return nil, messages.ErrBindingNotFound.WithFormat(name)
+	}
+	d, ok := b.getDeadLetter(name)
+	if !ok {
+		return nil, messages.ErrBindingDeadLetterNotConfigured.WithFormat(name)
+	}
+
+	store, err := b.deadLetterStore(d)
+	if err != nil {
+		return nil, messages.ErrBindingDeadLetterReplay.WithFormat(name, err)
+	}
+
+	head := b.getDeadLetterSlot(ctx, store, name, deadLetterHeadSuffix)
+	next := max(d.next.Load(), b.getDeadLetterSlot(ctx, store, name, deadLetterNextSuffix))
+
+	res := &universalapi.BindingDeadLetterReplay{}
+	// First slot with an invocation that is kept in the state store, which is where the next replay starts from
+	var (
+		slot      uint64
+		firstKept *uint64
+	)
+	// Slots after the next one are checked too, in case the next slot wasn't saved, until a free slot is found
+	for slot = head; ctx.Err() == nil; slot++ {
+		kept, found, err := b.replayDeadLetter(ctx, store, name, d, slot, res)
+		if err != nil {
+			return nil, messages.ErrBindingDeadLetterReplay.WithFormat(name, err)
+		}
+		if kept && firstKept == nil {
+			firstKept = ptr.Of(slot)
+		}
+		if !found && slot >= next {
+			break
+		}
+	}
+
+	// If the replay was interrupted, the slots that weren't checked may contain invocations to keep
+	if firstKept == nil && ctx.Err() == nil {
+		firstKept = &slot
+	}
+	if firstKept != nil && *firstKept != head {
+		b.saveDeadLetterSlot(ctx, store, name, d, deadLetterHeadSuffix, *firstKept)
+	}
+
+	return res, nil
+}
+
+// replayDeadLetter replays the failed invocation in the slot, if any, and updates the result of the replay.
+// It returns whether the slot still contains an invocation after the replay, and whether the slot contained one.
+func (b *binding) replayDeadLetter(ctx context.Context, store state.Store, name string, d *deadLetter, slot uint64, res *universalapi.BindingDeadLetterReplay) (kept bool, found bool, err error) {
+	key := deadLetterEntryKey(name, slot)
+	getRes, err := store.Get(ctx, &state.GetRequest{Key: key})
+	if err != nil {
+		return false, false, err
+	}
+	if getRes == nil || len(getRes.Data) == 0 {
+		return false, false, nil
+	}
+
+	var entry DeadLetterEntry
+	if err = json.Unmarshal(getRes.Data, &entry); err != nil {
+		log.Warnf("Removing the invalid failed invocation %d of binding %s: %v", slot, name, err)
+		return false, true, store.Delete(ctx, &state.DeleteRequest{Key: key})
+	}
+
+	now := time.Now().UTC()
+	if !entry.ExpiresAt.IsZero() && now.After(entry.ExpiresAt) {
+		res.Expired++
+		return false, true, store.Delete(ctx, &state.DeleteRequest{Key: key})
+	}
+	if entry.ClaimedUntil != nil && now.Before(*entry.ClaimedUntil) {
+		// Another replay is replaying the invocation
+		return true, true, nil
+	}
+
+	// Claim the invocation: the write fails if another replay claimed it after it was read
+	claimedUntil := now.Add(deadLetterClaimTimeout)
+	entry.ClaimedUntil = &claimedUntil
+	err = b.saveDeadLetterEntry(ctx, store, d, key, entry, getRes.ETag)
+	if err != nil {
+		var etagErr *state.ETagError
+		if errors.As(err, &etagErr) {
+			return true, true, nil
+		}
+		return false, true, err
+	}
+
+	_, err = b.invokeOutputBinding(ctx, name, &bindings.InvokeRequest{
+		Operation: bindings.OperationKind(entry.Operation),
+		Data:      entry.Data,
+		Metadata:  entry.Metadata,
+	})
+	if err != nil {
+		log.Debugf("Replay of the failed invocation %d of binding %s failed: %v", slot, name, err)
+		res.Failed++
+		entry.Error = err.Error()
+		entry.FailedAt = time.Now().UTC()
+		entry.Attempts++
+		entry.ClaimedUntil = nil
+		if err = b.saveDeadLetterEntry(ctx, store, d, key, entry, nil); err != nil {
+			log.Warnf("Failed to update the failed invocation %d of binding %s: %v", slot, name, err)
+		}
+		return true, true, nil
+	}
+
+	res.Replayed++
+	if err = store.Delete(ctx, &state.DeleteRequest{Key: key}); err != nil {
+		log.Warnf("Failed to delete the replayed invocation %d of binding %s: %v", slot, name, err)
+		return true, true, nil
+	}
+	return false, true, nil
+}
+
+// saveDeadLetterEntry saves a failed invocation that is already stored in the state store.
+// If etag is not nil, the write fails if the invocation was modified since it was read.
+func (b *binding) saveDeadLetterEntry(ctx context.Context, store state.Store, d *deadLetter, key string, entry DeadLetterEntry, etag *string) error {
+	data, err := json.Marshal(entry)
+	if err != nil {
+		return err
+	}
+	req := &state.SetRequest{
+		Key:      key,
+		Value:    data,
+		Metadata: deadLetterMetadata(store, d),
+	}
+	if etag != nil {
+		req.ETag = etag
+		req.Options.Concurrency = state.FirstWrite
+	}
+	return store.Set(ctx, req)
+}
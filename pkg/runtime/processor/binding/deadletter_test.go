@@ -0,0 +1,330 @@
+/*
+Copyright 2023 The Dapr Authors
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package binding
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/dapr/components-contrib/bindings"
+	contribMetadata "github.com/dapr/components-contrib/metadata"
+	"github.com/dapr/components-contrib/state"
+	"github.com/dapr/dapr/pkg/grpc/universalapi"
+	"github.com/dapr/dapr/pkg/messages"
+	"github.com/dapr/dapr/pkg/resiliency"
+	"github.com/dapr/dapr/pkg/runtime/compstore"
+	"github.com/dapr/dapr/pkg/runtime/meta"
+	daprt "github.com/dapr/dapr/pkg/testing"
+	"github.com/dapr/kit/ptr"
+)
+
+// deadLetterStateStore stores values as raw bytes and checks the ETags, like most state stores do.
+type deadLetterStateStore struct {
+	*daprt.FakeStateStore
+	items    map[string][]byte
+	etags    map[string]int
+	metadata map[string]map[string]string
+	ttl      bool
+	// onGet is invoked after an item is read.
+	onGet func(key string)
+}
+
+func newDeadLetterStateStore() *deadLetterStateStore {
+	return &deadLetterStateStore{
+		FakeStateStore: daprt.NewFakeStateStore(),
+		items:          map[string][]byte{},
+		etags:          map[string]int{},
+		metadata:       map[string]map[string]string{},
+		ttl:            true,
+	}
+}
+
+func (s *deadLetterStateStore) Features() []state.Feature {
+	if s.ttl {
+		return []state.Feature{state.FeatureETag, state.FeatureTTL}
+	}
+	return []state.Feature{state.FeatureETag}
+}
+
+func (s *deadLetterStateStore) Set(ctx context.Context, req *state.SetRequest) error {
+	_, exists := s.items[req.Key]
+	if req.ETag != nil && *req.ETag != s.etag(req.Key) {
+		return state.NewETagError(state.ETagMismatch, nil)
+	}
+	if req.ETag == nil && req.Options.Concurrency == state.FirstWrite && exists {
+		return state.NewETagError(state.ETagMismatch, nil)
+	}
+	s.items[req.Key] = req.Value.([]byte)
+	s.etags[req.Key]++
+	s.metadata[req.Key] = req.Metadata
+	return nil
+}
+
+func (s *deadLetterStateStore) Get(ctx context.Context, req *state.GetRequest) (*state.GetResponse, error) {
+	if s.onGet != nil {
+		defer s.onGet(req.Key)
+	}
+	data, ok := s.items[req.Key]
+	if !ok {
+		return &state.GetResponse{}, nil
+	}
+	etag := s.etag(req.Key)
+	return &state.GetResponse{Data: data, ETag: &etag}, nil
+}
+
+func (s *deadLetterStateStore) Delete(ctx context.Context, req *state.DeleteRequest) error {
+	delete(s.items, req.Key)
+	delete(s.metadata, req.Key)
+	return nil
+}
+
+func (s *deadLetterStateStore) etag(key string) string {
+	return string(rune('a' + s.etags[key]))
+}
+
+// entries returns the failed invocations of the binding, keyed by slot.
+func (s *deadLetterStateStore) entries(t *testing.T, name string) map[uint64]DeadLetterEntry {
+	t.Helper()
+	entries := map[uint64]DeadLetterEntry{}
+	for slot := uint64(0); slot < 100; slot++ {
+		data, ok := s.items[deadLetterEntryKey(name, slot)]
+		if !ok {
+			continue
+		}
+		var entry DeadLetterEntry
+		require.NoError(t, json.Unmarshal(data, &entry))
+		entries[slot] = entry
+	}
+	return entries
+}
+
+type flakyOutputBinding struct {
+	fail     bool
+	requests []*bindings.InvokeRequest
+}
+
+func (b *flakyOutputBinding) Init(ctx context.Context, metadata bindings.Metadata) error {
+	return nil
+}
+
+func (b *flakyOutputBinding) Operations() []bindings.OperationKind {
+	return []bindings.OperationKind{bindings.CreateOperation}
+}
+
+func (b *flakyOutputBinding) Invoke(ctx context.Context, req *bindings.InvokeRequest) (*bindings.InvokeResponse, error) {
+	b.requests = append(b.requests, req)
+	if b.fail {
+		return nil, errors.New("connection refused")
+	}
+	return nil, nil
+}
+
+func TestNewDeadLetter(t *testing.T) {
+	t.Run("not enabled", func(t *testing.T) {
+		d, err := newDeadLetter(map[string]string{})
+		require.NoError(t, err)
+		assert.Nil(t, d)
+	})
+
+	t.Run("defaults", func(t *testing.T) {
+		d, err := newDeadLetter(map[string]string{deadLetterStateStoreKey: "store"})
+		require.NoError(t, err)
+		assert.Equal(t, &deadLetter{stateStore: "store", ttl: defaultDeadLetterTTL}, d)
+	})
+
+	t.Run("ttl", func(t *testing.T) {
+		d, err := newDeadLetter(map[string]string{deadLetterStateStoreKey: "store", deadLetterTTLKey: "1h"})
+		require.NoError(t, err)
+		assert.Equal(t, time.Hour, d.ttl)
+	})
+
+	t.Run("invalid ttl", func(t *testing.T) {
+		_, err := newDeadLetter(map[string]string{deadLetterStateStoreKey: "store", deadLetterTTLKey: "-1h"})
+		require.Error(t, err)
+	})
+}
+
+func TestDeadLetters(t *testing.T) {
+	newBinding := func() (*binding, *flakyOutputBinding, *deadLetterStateStore) {
+		b := New(Options{
+			Resiliency:     resiliency.New(log),
+			ComponentStore: compstore.New(),
+			Meta:           meta.New(meta.Options{}),
+		})
+		out := &flakyOutputBinding{fail: true}
+		store := newDeadLetterStateStore()
+		b.compStore.AddOutputBinding("out", out)
+		b.compStore.AddStateStore("store", store)
+		b.deadLetters["out"] = &deadLetter{stateStore: "store", ttl: time.Hour}
+		return b, out, store
+	}
+	req := &bindings.InvokeRequest{
+		Operation: bindings.CreateOperation,
+		Data:      []byte(`{"id":1}`),
+		Metadata:  map[string]string{"key": "value"},
+	}
+
+	t.Run("failed invocation is stored", func(t *testing.T) {
+		b, _, store := newBinding()
+
+		_, err := b.SendToOutputBinding(context.Background(), "out", req)
+		require.EqualError(t, err, "connection refused")
+
+		entries := store.entries(t, "out")
+		require.Len(t, entries, 1)
+		entry := entries[0]
+		assert.Equal(t, string(bindings.CreateOperation), entry.Operation)
+		assert.Equal(t, req.Data, entry.Data)
+		assert.Equal(t, req.Metadata, entry.Metadata)
+		assert.Equal(t, "connection refused", entry.Error)
+		assert.Equal(t, 1, entry.Attempts)
+		assert.Nil(t, entry.ClaimedUntil)
+		assert.Equal(t, "3600", store.metadata[deadLetterEntryKey("out", 0)][contribMetadata.TTLMetadataKey])
+		assert.Equal(t, "1", string(store.items[deadLetterKey("out", deadLetterNextSuffix)]))
+	})
+
+	t.Run("failed invocations are stored in separate slots", func(t *testing.T) {
+		b, _, store := newBinding()
+
+		_, err := b.SendToOutputBinding(context.Background(), "out", req)
+		require.Error(t, err)
+		// Another runtime stored an invocation in the next slot, without saving the next slot
+		store.items[deadLetterEntryKey("out", 1)] = store.items[deadLetterEntryKey("out", 0)]
+		_, err = b.SendToOutputBinding(context.Background(), "out", req)
+		require.Error(t, err)
+
+		assert.Len(t, store.entries(t, "out"), 3)
+		assert.Contains(t, store.items, deadLetterEntryKey("out", 2))
+	})
+
+	t.Run("TTL is not set if the state store doesn't support it", func(t *testing.T) {
+		b, _, store := newBinding()
+		store.ttl = false
+
+		_, err := b.SendToOutputBinding(context.Background(), "out", req)
+		require.Error(t, err)
+		assert.Nil(t, store.metadata[deadLetterEntryKey("out", 0)])
+		assert.WithinDuration(t, time.Now().Add(time.Hour), store.entries(t, "out")[0].ExpiresAt, time.Minute)
+	})
+
+	t.Run("invalid invocations are not stored", func(t *testing.T) {
+		b, _, store := newBinding()
+
+		_, err := b.SendToOutputBinding(context.Background(), "out", &bindings.InvokeRequest{Operation: bindings.GetOperation})
+		require.Error(t, err)
+		_, err = b.SendToOutputBinding(context.Background(), "out", &bindings.InvokeRequest{})
+		require.Error(t, err)
+		assert.Empty(t, store.items)
+	})
+
+	t.Run("invocation is not stored if the binding is not configured", func(t *testing.T) {
+		b, _, store := newBinding()
+		delete(b.deadLetters, "out")
+
+		_, err := b.SendToOutputBinding(context.Background(), "out", req)
+		require.Error(t, err)
+		assert.Empty(t, store.items)
+	})
+
+	t.Run("replay", func(t *testing.T) {
+		b, out, store := newBinding()
+
+		for i := 0; i < 3; i++ {
+			_, err := b.SendToOutputBinding(context.Background(), "out", req)
+			require.Error(t, err)
+		}
+		require.Len(t, store.entries(t, "out"), 3)
+
+		// Remove one of the entries, as if it was replayed, and make another one expire
+		delete(store.items, deadLetterEntryKey("out", 1))
+		expired := store.entries(t, "out")[2]
+		expired.ExpiresAt = time.Now().Add(-time.Minute)
+		store.items[deadLetterEntryKey("out", 2)], _ = json.Marshal(expired)
+
+		res, err := b.ReplayDeadLetters(context.Background(), "out")
+		require.NoError(t, err)
+		assert.Equal(t, &universalapi.BindingDeadLetterReplay{Failed: 1, Expired: 1}, res)
+		entries := store.entries(t, "out")
+		require.Len(t, entries, 1)
+		assert.Equal(t, 2, entries[0].Attempts)
+		assert.Nil(t, entries[0].ClaimedUntil)
+		assert.NotContains(t, store.items, deadLetterKey("out", deadLetterHeadSuffix))
+
+		out.fail = false
+		out.requests = nil
+		res, err = b.ReplayDeadLetters(context.Background(), "out")
+		require.NoError(t, err)
+		assert.Equal(t, &universalapi.BindingDeadLetterReplay{Replayed: 1}, res)
+		assert.Empty(t, store.entries(t, "out"))
+		require.Len(t, out.requests, 1)
+		assert.Equal(t, req.Data, out.requests[0].Data)
+		assert.Equal(t, req.Metadata, out.requests[0].Metadata)
+
+		// The next replay starts after the slots that were replayed
+		assert.Equal(t, "3", string(store.items[deadLetterKey("out", deadLetterHeadSuffix)]))
+		out.fail = true
+		_, err = b.SendToOutputBinding(context.Background(), "out", req)
+		require.Error(t, err)
+		assert.Contains(t, store.items, deadLetterEntryKey("out", 3))
+	})
+
+	t.Run("claimed invocations are not replayed", func(t *testing.T) {
+		b, out, store := newBinding()
+
+		_, err := b.SendToOutputBinding(context.Background(), "out", req)
+		require.Error(t, err)
+		_, err = b.SendToOutputBinding(context.Background(), "out", req)
+		require.Error(t, err)
+
+		// The first invocation is being replayed by another runtime
+		claimed := store.entries(t, "out")[0]
+		claimed.ClaimedUntil = ptr.Of(time.Now().Add(time.Minute))
+		store.items[deadLetterEntryKey("out", 0)], _ = json.Marshal(claimed)
+		// The second invocation is claimed by another runtime after it's read
+		store.onGet = func(key string) {
+			if key == deadLetterEntryKey("out", 1) {
+				store.etags[key]++
+			}
+		}
+
+		out.fail = false
+		out.requests = nil
+		res, err := b.ReplayDeadLetters(context.Background(), "out")
+		require.NoError(t, err)
+		assert.Equal(t, &universalapi.BindingDeadLetterReplay{}, res)
+		assert.Empty(t, out.requests)
+		assert.Len(t, store.entries(t, "out"), 2)
+	})
+
+	t.Run("replay without dead letters configured", func(t *testing.T) {
+		b, _, _ := newBinding()
+		delete(b.deadLetters, "out")
+
+		_, err := b.ReplayDeadLetters(context.Background(), "out")
+		require.ErrorIs(t, err, messages.ErrBindingDeadLetterNotConfigured)
+	})
+
+	t.Run("replay of a binding that doesn't exist", func(t *testing.T) {
+		b, _, _ := newBinding()
+
+		_, err := b.ReplayDeadLetters(context.Background(), "notfound")
+		require.ErrorIs(t, err, messages.ErrBindingNotFound)
+	})
+}
@@ -0,0 +1,274 @@
+/*
+Copyright 2023 The Dapr Authors
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package binding
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+	"sync"
+	"text/template"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/dapr/components-contrib/bindings"
+	"github.com/dapr/components-contrib/state"
+	"github.com/dapr/kit/cron"
+)
+
+const (
+	// Metadata properties of output bindings that enable invoking them on a schedule.
+	scheduleKey               = "schedule"
+	scheduleOperationKey      = "scheduleOperation"
+	scheduleDataKey           = "scheduleData"
+	scheduleMetadataKeyPrefix = "scheduleMetadata."
+	scheduleStateStoreKey     = "scheduleStateStore"
+
+	scheduleStatePrefix = "dapr-binding-schedule"
+
+	// Timeout for a single scheduled invocation, including the resiliency policies.
+	scheduledInvokeTimeout = 5 * time.Minute
+)
+
+// scheduleParser accepts the standard cron format with optional seconds, and descriptors such as @daily or @every 1h.
+var scheduleParser = cron.NewParser(
+	cron.SecondOptional | cron.Minute | cron.Hour | cron.Dom | cron.Month | cron.Dow | cron.Descriptor,
+)
+
+// schedule contains the options for invoking an output binding on a recurring schedule, without any request from the app.
+type schedule struct {
+	// Cron expression of the schedule.
+	spec      cron.Schedule
+	operation bindings.OperationKind
+	// Template used to render the data of each invocation.
+	data     *template.Template
+	metadata map[string]string
+	// Name of the state store used to make sure that each scheduled invocation is sent by a single replica of the app.
+	// If empty, every replica sends each invocation.
+	stateStore string
+}
+
+// scheduleTemplateData is the data available to the template of the scheduled invocations.
+type scheduleTemplateData struct {
+	// Name of the output binding.
+	Name string
+	// Time the invocation was scheduled at.
+	Time time.Time
+	// Unique ID of the invocation.
+	ID string
+}
+
+// newSchedule returns the schedule options from the metadata of an output binding.
+// It returns nil if the binding is not invoked on a schedule.
+func newSchedule(properties map[string]string) (*schedule, error) {
+	spec := strings.TrimSpace(properties[scheduleKey])
+	if spec == "" {
+		return nil, nil
+	}
+
+	parsed, err := scheduleParser.Parse(spec)
+	if err != nil {
+		return nil, fmt.Errorf("invalid value for metadata property '%s': %w", scheduleKey, err)
+	}
+
+	s := &schedule{
+		spec:       parsed,
+		operation:  bindings.CreateOperation,
+		metadata:   map[string]string{},
+		stateStore: strings.TrimSpace(properties[scheduleStateStoreKey]),
+	}
+
+	if v := strings.TrimSpace(properties[scheduleOperationKey]); v != "" {
+		s.operation = bindings.OperationKind(v)
+	}
+
+	s.data, err = template.New(scheduleDataKey).Option("missingkey=error").Parse(properties[scheduleDataKey])
+	if err != nil {
+		return nil, fmt.Errorf("invalid value for metadata property '%s': %w", scheduleDataKey, err)
+	}
+
+	for k, v := range properties {
+		if key, ok := strings.CutPrefix(k, scheduleMetadataKeyPrefix); ok && key != "" {
+			s.metadata[key] = v
+		}
+	}
+
+	return s, nil
+}
+
+// request returns the invocation of the output binding for the given time.
+func (s *schedule) request(name string, t time.Time) (*bindings.InvokeRequest, error) {
+	uid, err := uuid.NewRandom()
+	if err != nil {
+		return nil, err
+	}
+
+	var buf bytes.Buffer
+	err = s.data.Execute(&buf, scheduleTemplateData{
+		Name: name,
+		Time: t.UTC(),
+		ID:   uid.String(),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to render the data of the scheduled invocation: %w", err)
+	}
+
+	md := make(map[string]string, len(s.metadata))
+	for k, v := range s.metadata {
+		md[k] = v
+	}
+
+	return &bindings.InvokeRequest{
+		Data:      buf.Bytes(),
+		Metadata:  md,
+		Operation: s.operation,
+	}, nil
+}
+
+// scheduledTime returns the time of the invocation that's due at now, the last one of the schedule after prev.
+// It's computed from the schedule rather than from the time the invocation runs at, so all the replicas of the app claim the same time for each invocation.
+func (s *schedule) scheduledTime(prev time.Time, now time.Time) time.Time {
+	tick := s.spec.Next(prev)
+	for next := s.spec.Next(tick); !next.After(now); next = s.spec.Next(tick) {
+		tick = next
+	}
+	return tick
+}
+
+// startSchedule starts invoking the output binding on the given schedule, replacing any previous schedule of the binding.
+// It must be called with b.lock held.
+func (b *binding) startSchedule(name string, s *schedule) {
+	b.stopSchedule(name)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	var (
+		prevLock sync.Mutex
+		prev     = b.clock.Now()
+	)
+	c := cron.New(cron.WithClock(b.clock), cron.WithLogger(cron.DiscardLogger))
+	c.Schedule(s.spec, cron.FuncJob(func() {
+		prevLock.Lock()
+		tick := s.scheduledTime(prev, b.clock.Now())
+		prev = tick
+		prevLock.Unlock()
+
+		b.invokeScheduled(ctx, name, s, tick)
+	}))
+	c.Start()
+
+	b.schedules[name] = func() {
+		cancel()
+		<-c.Stop().Done()
+	}
+	if s.stateStore == "" {
+		log.Infof("Invoking output binding %s on a schedule; without the '%s' metadata property, every replica of the app invokes it", name, scheduleStateStoreKey)
+	} else {
+		log.Infof("Invoking output binding %s on a schedule, coordinated through state store %s", name, s.stateStore)
+	}
+}
+
+// stopSchedule stops invoking the output binding on a schedule and waits for the running invocation to complete.
+// It must be called with b.lock held.
+func (b *binding) stopSchedule(name string) {
+	if stop, ok := b.schedules[name]; ok {
+		stop()
+		delete(b.schedules, name)
+	}
+}
+
+// invokeScheduled sends the invocation of the output binding scheduled at the given time.
+func (b *binding) invokeScheduled(ctx context.Context, name string, s *schedule, tick time.Time) {
+	if s.stateStore != "" {
+		claimed, err := b.claimScheduledTick(ctx, name, s, tick)
+		if err != nil {
+			log.Errorf("Failed to claim the scheduled invocation of output binding %s: %v", name, err)
+			return
+		}
+		if !claimed {
+			log.Debugf("Scheduled invocation of output binding %s is sent by another replica", name)
+			return
+		}
+	}
+
+	req, err := s.request(name, tick)
+	if err != nil {
+		log.Errorf("Failed to create the scheduled invocation of output binding %s: %v", name, err)
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, scheduledInvokeTimeout)
+	defer cancel()
+	if _, err = b.SendToOutputBinding(ctx, name, req); err != nil {
+		log.Errorf("Scheduled invocation of output binding %s failed: %v", name, err)
+		return
+	}
+	log.Debugf("Scheduled invocation of output binding %s succeeded", name)
+}
+
+// claimScheduledTick claims the scheduled invocation at the given time for this replica of the app.
+// The state store contains the time of the last claimed invocation of the binding, which is updated with optimistic concurrency, so only one replica can claim each invocation.
+// It returns false if another replica claimed the invocation.
+func (b *binding) claimScheduledTick(ctx context.Context, name string, s *schedule, tick time.Time) (bool, error) {
+	store, ok := b.compStore.GetStateStore(s.stateStore)
+	if !ok {
+		return false, fmt.Errorf("state store %s not found", s.stateStore)
+	}
+
+	key := scheduleStatePrefix + "||" + b.appID + "||" + name
+	last, etag, err := getScheduledTick(ctx, store, key)
+	if err != nil {
+		return false, err
+	}
+	if !last.Before(tick) {
+		return false, nil
+	}
+
+	// If there's no last invocation yet, the key is created with an insert-only write
+	err = store.Set(ctx, &state.SetRequest{
+		Key:   key,
+		Value: []byte(strconv.FormatInt(tick.Unix(), 10)),
+		ETag:  etag,
+		Options: state.SetStateOption{
+			Concurrency: state.FirstWrite,
+		},
+	})
+	if err == nil {
+		return true, nil
+	}
+
+	// Not all state stores return an ETagError on conflicts, so the last invocation is read again
+	last, _, getErr := getScheduledTick(ctx, store, key)
+	if getErr == nil && !last.Before(tick) {
+		return false, nil
+	}
+	return false, err
+}
+
+func getScheduledTick(ctx context.Context, store state.Store, key string) (time.Time, *string, error) {
+	res, err := store.Get(ctx, &state.GetRequest{Key: key})
+	if err != nil {
+		return time.Time{}, nil, err
+	}
+	if res == nil || len(res.Data) == 0 {
+		return time.Time{}, nil, nil
+	}
+	sec, err := strconv.ParseInt(strings.Trim(string(res.Data), `"`), 10, 64)
+	if err != nil {
+		return time.Time{}, nil, fmt.Errorf("invalid time of the last scheduled invocation: %w", err)
+	}
+	return time.Unix(sec, 0), res.ETag, nil
+}
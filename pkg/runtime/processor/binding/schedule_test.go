@@ -0,0 +1,217 @@
+/*
+Copyright 2023 The Dapr Authors
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package binding
+
+import (
+	"context"
+	"strconv"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	clocktesting "k8s.io/utils/clock/testing"
+
+	"github.com/dapr/components-contrib/bindings"
+	"github.com/dapr/dapr/pkg/resiliency"
+	"github.com/dapr/dapr/pkg/runtime/compstore"
+)
+
+// scheduledOutputBinding records the requests it receives.
+type scheduledOutputBinding struct {
+	lock     sync.Mutex
+	requests []*bindings.InvokeRequest
+}
+
+func (b *scheduledOutputBinding) Init(ctx context.Context, metadata bindings.Metadata) error {
+	return nil
+}
+
+func (b *scheduledOutputBinding) Operations() []bindings.OperationKind {
+	return []bindings.OperationKind{bindings.CreateOperation, "publish"}
+}
+
+func (b *scheduledOutputBinding) Invoke(ctx context.Context, req *bindings.InvokeRequest) (*bindings.InvokeResponse, error) {
+	b.lock.Lock()
+	defer b.lock.Unlock()
+	b.requests = append(b.requests, req)
+	return nil, nil
+}
+
+func (b *scheduledOutputBinding) received() []*bindings.InvokeRequest {
+	b.lock.Lock()
+	defer b.lock.Unlock()
+	return append([]*bindings.InvokeRequest(nil), b.requests...)
+}
+
+func TestNewSchedule(t *testing.T) {
+	t.Run("not enabled", func(t *testing.T) {
+		s, err := newSchedule(map[string]string{})
+		require.NoError(t, err)
+		assert.Nil(t, s)
+	})
+
+	t.Run("defaults", func(t *testing.T) {
+		s, err := newSchedule(map[string]string{scheduleKey: "@daily"})
+		require.NoError(t, err)
+		require.NotNil(t, s)
+		assert.Equal(t, bindings.CreateOperation, s.operation)
+		assert.Empty(t, s.metadata)
+
+		req, err := s.request("out", time.Now())
+		require.NoError(t, err)
+		assert.Empty(t, req.Data)
+	})
+
+	t.Run("operation, data and metadata", func(t *testing.T) {
+		s, err := newSchedule(map[string]string{
+			scheduleKey:                           "0 30 * * * *",
+			scheduleOperationKey:                  "publish",
+			scheduleDataKey:                       `{"binding":"{{.Name}}","at":"{{.Time.Format "2006-01-02"}}"}`,
+			scheduleMetadataKeyPrefix + "ttl":     "60",
+			scheduleMetadataKeyPrefix:             "ignored",
+			"deadLetterStateStore":                "store",
+			scheduleMetadataKeyPrefix + "subject": "nightly",
+		})
+		require.NoError(t, err)
+		assert.Equal(t, bindings.OperationKind("publish"), s.operation)
+		assert.Equal(t, map[string]string{"ttl": "60", "subject": "nightly"}, s.metadata)
+
+		req, err := s.request("out", time.Date(2023, 10, 1, 0, 30, 0, 0, time.UTC))
+		require.NoError(t, err)
+		assert.Equal(t, `{"binding":"out","at":"2023-10-01"}`, string(req.Data))
+		assert.Equal(t, bindings.OperationKind("publish"), req.Operation)
+		assert.Equal(t, map[string]string{"ttl": "60", "subject": "nightly"}, req.Metadata)
+	})
+
+	t.Run("scheduled time", func(t *testing.T) {
+		s, err := newSchedule(map[string]string{scheduleKey: "0 * * * * *"})
+		require.NoError(t, err)
+
+		prev := time.Date(2023, 10, 1, 0, 29, 20, 0, time.UTC)
+		// The invocation runs after its scheduled time
+		assert.Equal(t, time.Date(2023, 10, 1, 0, 30, 0, 0, time.UTC), s.scheduledTime(prev, time.Date(2023, 10, 1, 0, 30, 1, 500, time.UTC)))
+		// Invocations that were missed are skipped
+		assert.Equal(t, time.Date(2023, 10, 1, 0, 32, 0, 0, time.UTC), s.scheduledTime(prev, time.Date(2023, 10, 1, 0, 32, 0, 200, time.UTC)))
+	})
+
+	t.Run("invalid schedule", func(t *testing.T) {
+		_, err := newSchedule(map[string]string{scheduleKey: "every day"})
+		require.Error(t, err)
+	})
+
+	t.Run("invalid data template", func(t *testing.T) {
+		_, err := newSchedule(map[string]string{scheduleKey: "@daily", scheduleDataKey: "{{.Name"})
+		require.Error(t, err)
+	})
+}
+
+func TestScheduledInvocations(t *testing.T) {
+	fakeClock := clocktesting.NewFakeClock(time.Now())
+	b := New(Options{
+		ComponentStore: compstore.New(),
+		Resiliency:     resiliency.New(log),
+	})
+	b.clock = fakeClock
+	out := &scheduledOutputBinding{}
+	b.compStore.AddOutputBinding("out", out)
+
+	s, err := newSchedule(map[string]string{scheduleKey: "@every 1m", scheduleDataKey: "{{.Name}}"})
+	require.NoError(t, err)
+
+	b.lock.Lock()
+	b.startSchedule("out", s)
+	b.lock.Unlock()
+
+	for i := 1; i <= 2; i++ {
+		assert.Eventually(t, fakeClock.HasWaiters, time.Second, 10*time.Millisecond)
+		fakeClock.Step(time.Minute)
+		assert.Eventually(t, func() bool {
+			return len(out.received()) == i
+		}, time.Second, 10*time.Millisecond)
+	}
+	assert.Equal(t, "out", string(out.received()[0].Data))
+	assert.Equal(t, bindings.CreateOperation, out.received()[0].Operation)
+
+	b.lock.Lock()
+	b.stopSchedule("out")
+	b.lock.Unlock()
+	assert.Empty(t, b.schedules)
+
+	fakeClock.Step(time.Minute)
+	time.Sleep(50 * time.Millisecond)
+	assert.Len(t, out.received(), 2)
+}
+
+func TestScheduledInvocationsClaims(t *testing.T) {
+	fakeClock := clocktesting.NewFakeClock(time.Date(2023, 10, 1, 0, 30, 0, 300, time.UTC))
+	store := newDeadLetterStateStore()
+	out := &scheduledOutputBinding{}
+	newReplica := func() *binding {
+		b := New(Options{
+			AppID:          "myapp",
+			ComponentStore: compstore.New(),
+			Resiliency:     resiliency.New(log),
+		})
+		b.clock = fakeClock
+		b.compStore.AddOutputBinding("out", out)
+		b.compStore.AddStateStore("store", store)
+		return b
+	}
+	s, err := newSchedule(map[string]string{scheduleKey: "@every 1m", scheduleStateStoreKey: "store"})
+	require.NoError(t, err)
+	key := scheduleStatePrefix + "||myapp||out"
+
+	t.Run("each invocation is sent by one replica", func(t *testing.T) {
+		replicas := []*binding{newReplica(), newReplica()}
+		for _, b := range replicas {
+			b.invokeScheduled(context.Background(), "out", s, fakeClock.Now().Truncate(time.Second))
+		}
+		assert.Len(t, out.received(), 1)
+		assert.Equal(t, "1696120200", string(store.items[key]))
+
+		fakeClock.Step(time.Minute)
+		for _, b := range replicas {
+			b.invokeScheduled(context.Background(), "out", s, fakeClock.Now().Truncate(time.Second))
+		}
+		assert.Len(t, out.received(), 2)
+	})
+
+	t.Run("invocation claimed concurrently by another replica", func(t *testing.T) {
+		fakeClock.Step(time.Minute)
+		tick := strconv.FormatInt(fakeClock.Now().Unix(), 10)
+		store.onGet = func(k string) {
+			if k == key {
+				store.items[key] = []byte(tick)
+				store.etags[key]++
+			}
+		}
+		defer func() {
+			store.onGet = nil
+		}()
+
+		newReplica().invokeScheduled(context.Background(), "out", s, fakeClock.Now().Truncate(time.Second))
+		assert.Len(t, out.received(), 2)
+	})
+
+	t.Run("invocation is not sent if it can't be claimed", func(t *testing.T) {
+		fakeClock.Step(time.Minute)
+		s, err := newSchedule(map[string]string{scheduleKey: "@every 1m", scheduleStateStoreKey: "notfound"})
+		require.NoError(t, err)
+
+		newReplica().invokeScheduled(context.Background(), "out", s, fakeClock.Now().Truncate(time.Second))
+		assert.Len(t, out.received(), 2)
+	})
+}
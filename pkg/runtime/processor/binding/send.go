@@ -22,6 +22,7 @@ import (
 	"strings"
 	"time"
 
+	"github.com/cenkalti/backoff/v4"
 	"go.opentelemetry.io/otel/trace"
 	md "google.golang.org/grpc/metadata"
 	"google.golang.org/grpc/status"
@@ -32,6 +33,7 @@ import (
 	componentsV1alpha1 "github.com/dapr/dapr/pkg/apis/components/v1alpha1"
 	"github.com/dapr/dapr/pkg/components"
 	stateLoader "github.com/dapr/dapr/pkg/components/state"
+	"github.com/dapr/dapr/pkg/config"
 	diag "github.com/dapr/dapr/pkg/diagnostics"
 	diagUtils "github.com/dapr/dapr/pkg/diagnostics/utils"
 	invokev1 "github.com/dapr/dapr/pkg/messaging/v1"
@@ -45,7 +47,7 @@ func (b *binding) StartReadingFromBindings(ctx context.Context) error {
 
 	b.readingBindings = true
 
-	if b.channels.AppChannel() == nil {
+	if b.channels.AppChannelFor(config.AppChannelRouteBindings) == nil {
 		return errors.New("app channel not initialized")
 	}
 
@@ -154,8 +156,27 @@ func (b *binding) sendBatchOutputBindingsSequential(ctx context.Context, to []st
 }
 
 func (b *binding) SendToOutputBinding(ctx context.Context, name string, req *bindings.InvokeRequest) (*bindings.InvokeResponse, error) {
+	resp, err := b.invokeOutputBinding(ctx, name, req)
+	if err != nil && isDeadLetterError(err) {
+		if d, ok := b.getDeadLetter(name); ok {
+			// Store the failed invocation even if the request was canceled, since that's a common reason for the failure
+			storeCtx, cancel := context.WithTimeout(context.WithoutCancel(ctx), deadLetterStoreTimeout)
+			defer cancel()
+			if dlErr := b.storeDeadLetter(storeCtx, name, d, req, err); dlErr != nil {
+				log.Errorf("Failed to store the failed invocation of output binding %s: %v", name, dlErr)
+			} else {
+				log.Debugf("Stored the failed invocation of output binding %s in state store %s", name, d.stateStore)
+			}
+		}
+	}
+	return resp, err
+}
+
+// invokeOutputBinding invokes the output binding with the resiliency policies.
+// Errors of invocations that are invalid are permanent, since the invocations fail again if they're retried.
+func (b *binding) invokeOutputBinding(ctx context.Context, name string, req *bindings.InvokeRequest) (*bindings.InvokeResponse, error) {
 	if req.Operation == "" {
-		return nil, errors.New("operation field is missing from request")
+		return nil, backoff.Permanent(errors.New("operation field is missing from request"))
 	}
 
 	if binding, ok := b.compStore.GetOutputBinding(name); ok {
@@ -174,9 +195,9 @@ func (b *binding) SendToOutputBinding(ctx context.Context, name string, req *bin
 		for _, o := range ops {
 			supported = append(supported, string(o))
 		}
-		return nil, fmt.Errorf("binding %s does not support operation %s. supported operations:%s", name, req.Operation, strings.Join(supported, " "))
+		return nil, backoff.Permanent(fmt.Errorf("binding %s does not support operation %s. supported operations:%s", name, req.Operation, strings.Join(supported, " ")))
 	}
-	return nil, fmt.Errorf("couldn't find output binding %s", name)
+	return nil, backoff.Permanent(fmt.Errorf("couldn't find output binding %s", name))
 }
 
 func (b *binding) onAppResponse(ctx context.Context, response *bindings.AppResponse) error {
@@ -242,6 +263,9 @@ func (b *binding) sendBindingEventToApp(ctx context.Context, bindingName string,
 	}
 	// span is nil if tracing is disabled (sampling rate is 0)
 	ctx, span := diag.StartInternalCallbackSpan(ctx, spanName, spanContext, b.tracingSpec)
+	if val, ok := metadata[diag.BaggageHeader]; ok {
+		ctx = diag.ContextWithBaggageString(ctx, val)
+	}
 
 	var appResponseBody []byte
 	path, _ := b.compStore.GetInputBindingRoute(bindingName)
@@ -253,6 +277,7 @@ func (b *binding) sendBindingEventToApp(ctx context.Context, bindingName string,
 		if span != nil {
 			ctx = diag.SpanContextToGRPCMetadata(ctx, span.SpanContext())
 		}
+		ctx = diag.BaggageToGRPCMetadata(ctx)
 
 		// Add workaround to fallback on checking traceparent header.
 		// As grpc-trace-bin is not yet there in OpenTelemetry unlike OpenCensus, tracking issue https://github.com/open-telemetry/opentelemetry-specification/issues/639
@@ -349,7 +374,7 @@ func (b *binding) sendBindingEventToApp(ctx context.Context, bindingName string,
 			},
 		)
 		resp, err := policyRunner(func(ctx context.Context) (*invokev1.InvokeMethodResponse, error) {
-			rResp, rErr := b.channels.AppChannel().InvokeMethod(ctx, req, "")
+			rResp, rErr := b.channels.AppChannelFor(config.AppChannelRouteBindings).InvokeMethod(ctx, req, "")
 			if rErr != nil {
 				return rResp, rErr
 			}
@@ -456,7 +481,7 @@ func (b *binding) isAppSubscribedToBinding(ctx context.Context, binding string)
 			WithContentType(invokev1.JSONContentType)
 		defer req.Close()
 
-		resp, err := b.channels.AppChannel().InvokeMethod(ctx, req, "")
+		resp, err := b.channels.AppChannelFor(config.AppChannelRouteBindings).InvokeMethod(ctx, req, "")
 		if err != nil {
 			return false, fmt.Errorf("could not invoke OPTIONS method on input binding subscription endpoint %q: %v", path, err)
 		}
@@ -20,6 +20,7 @@ import (
 	"github.com/dapr/components-contrib/bindings"
 	contribpubsub "github.com/dapr/components-contrib/pubsub"
 	componentsapi "github.com/dapr/dapr/pkg/apis/components/v1alpha1"
+	"github.com/dapr/dapr/pkg/grpc/universalapi"
 	"github.com/dapr/dapr/pkg/outbox"
 	"github.com/dapr/dapr/pkg/runtime/meta"
 )
@@ -52,6 +53,7 @@ type PubsubManager interface {
 
 type BindingManager interface {
 	SendToOutputBinding(context.Context, string, *bindings.InvokeRequest) (*bindings.InvokeResponse, error)
+	ReplayDeadLetters(context.Context, string) (*universalapi.BindingDeadLetterReplay, error)
 
 	StartReadingFromBindings(context.Context) error
 	StopReadingFromBindings()
@@ -17,6 +17,7 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"maps"
 	"strings"
 	"sync"
 	"sync/atomic"
@@ -55,7 +56,8 @@ import (
 )
 
 const (
-	defaultComponentInitTimeout = time.Second * 5
+	defaultComponentInitTimeout  = time.Second * 5
+	defaultComponentDrainTimeout = time.Second * 5
 )
 
 var log = logger.NewLogger("dapr.runtime.processor")
@@ -102,6 +104,9 @@ type Options struct {
 	Channels *channels.Channels
 
 	OperatorClient operatorv1.OperatorClient
+
+	// MaxRequestBodySize is the maximum size of request bodies, in bytes.
+	MaxRequestBodySize int64
 }
 
 // Processor manages the lifecycle of all components categories.
@@ -117,6 +122,11 @@ type Processor struct {
 	pendingComponents          chan componentsapi.Component
 	pendingComponentsWaiting   sync.WaitGroup
 	pendingComponentDependents map[string][]componentsapi.Component
+	// Name of the component each pending component is waiting for, used to detect dependency cycles.
+	pendingComponentWaitingOn map[string]string
+
+	initDurationsLock sync.RWMutex
+	initDurations     map[string]time.Duration
 
 	lock     sync.RWMutex
 	chlock   sync.RWMutex
@@ -131,7 +141,7 @@ func New(opts Options) *Processor {
 		Namespace:      opts.Namespace,
 		Mode:           opts.Mode,
 		PodName:        opts.PodName,
-		IsHTTP:         opts.IsHTTP,
+		IsHTTP:         opts.IsHTTP || opts.Channels.HasRoute(config.AppChannelRoutePubSub),
 		Registry:       opts.Registry.PubSubs(),
 		ComponentStore: opts.ComponentStore,
 		Meta:           opts.Meta,
@@ -141,6 +151,9 @@ func New(opts Options) *Processor {
 		Channels:       opts.Channels,
 		OperatorClient: opts.OperatorClient,
 		ResourcesPath:  opts.Standalone.ResourcesPath,
+
+		PublishDeduplication: opts.GlobalConfig.GetPublishDeduplicationSpec(),
+		MaxRequestBodySize:   opts.MaxRequestBodySize,
 	})
 
 	state := state.New(state.Options{
@@ -159,10 +172,11 @@ func New(opts Options) *Processor {
 	})
 
 	binding := binding.New(binding.Options{
+		AppID:          opts.ID,
 		Registry:       opts.Registry.Bindings(),
 		ComponentStore: opts.ComponentStore,
 		Meta:           opts.Meta,
-		IsHTTP:         opts.IsHTTP,
+		IsHTTP:         opts.IsHTTP || opts.Channels.HasRoute(config.AppChannelRouteBindings),
 		Resiliency:     opts.Resiliency,
 		GRPC:           opts.GRPC,
 		TracingSpec:    opts.GlobalConfig.Spec.TracingSpec,
@@ -173,6 +187,8 @@ func New(opts Options) *Processor {
 		pendingHTTPEndpoints:       make(chan httpendpointsapi.HTTPEndpoint),
 		pendingComponents:          make(chan componentsapi.Component),
 		pendingComponentDependents: make(map[string][]componentsapi.Component),
+		pendingComponentWaitingOn:  make(map[string]string),
+		initDurations:              make(map[string]time.Duration),
 		closedCh:                   make(chan struct{}),
 		compStore:                  opts.ComponentStore,
 		state:                      state,
@@ -231,7 +247,10 @@ func (p *Processor) Init(ctx context.Context, comp componentsapi.Component) erro
 }
 
 // Close closes the component.
+// The in-flight operations on the component are given up to the drain timeout of the component to complete before it's closed.
 func (p *Processor) Close(comp componentsapi.Component) error {
+	p.drain(comp)
+
 	p.lock.Lock()
 	defer p.lock.Unlock()
 
@@ -246,11 +265,42 @@ func (p *Processor) Close(comp componentsapi.Component) error {
 
 	p.compStore.DeleteComponent(comp.Name)
 
+	p.initDurationsLock.Lock()
+	delete(p.initDurations, comp.Name)
+	p.initDurationsLock.Unlock()
+
 	return nil
 }
 
+// drain waits for the in-flight operations on the component to complete, up to its drain timeout.
+func (p *Processor) drain(comp componentsapi.Component) {
+	timeout := defaultComponentDrainTimeout
+	if comp.Spec.DrainTimeout != "" {
+		var err error
+		timeout, err = time.ParseDuration(comp.Spec.DrainTimeout)
+		if err != nil {
+			log.Warnf("Invalid drain timeout '%s' for component %s; using the default of %s", comp.Spec.DrainTimeout, comp.LogName(), defaultComponentDrainTimeout)
+			timeout = defaultComponentDrainTimeout
+		}
+	}
+	if timeout <= 0 || p.compStore.InflightOperations(comp.Name) == 0 {
+		return
+	}
+
+	log.Infof("Waiting up to %s for the in-flight operations on component %s to complete", timeout, comp.LogName())
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+	drained, aborted := p.compStore.DrainOperations(ctx, comp.Name)
+	if aborted > 0 {
+		log.Warnf("Closing component %s with %d in-flight operations that didn't complete within %s", comp.LogName(), aborted, timeout)
+	}
+	diag.DefaultMonitoring.ComponentDrained(comp.Spec.Type, comp.Name, drained, aborted)
+}
+
 type componentPreprocessRes struct {
 	unreadyDependency string
+	// Name of the component the unready dependency refers to.
+	unreadyComponent string
 }
 
 func (p *Processor) Process(ctx context.Context) error {
@@ -353,6 +403,13 @@ func (p *Processor) processHTTPEndpoints(ctx context.Context) error {
 	return nil
 }
 
+// ComponentInitDurations returns the time each loaded component took to initialize.
+func (p *Processor) ComponentInitDurations() map[string]time.Duration {
+	p.initDurationsLock.RLock()
+	defer p.initDurationsLock.RUnlock()
+	return maps.Clone(p.initDurations)
+}
+
 // WaitForEmptyComponentQueue waits for the component queue to be empty.
 func (p *Processor) WaitForEmptyComponentQueue() {
 	p.pendingComponentsWaiting.Wait()
@@ -362,9 +419,15 @@ func (p *Processor) processComponentAndDependents(ctx context.Context, comp comp
 	log.Debug("Loading component: " + comp.LogName())
 	res := p.preprocessOneComponent(ctx, &comp)
 	if res.unreadyDependency != "" {
+		if cycle := p.dependencyCycle(comp.Name, res.unreadyComponent); cycle != "" {
+			return fmt.Errorf("dependency cycle detected: %s", cycle)
+		}
+		log.Debugf("Component %s is waiting for component %s to be initialized", comp.LogName(), res.unreadyComponent)
 		p.pendingComponentDependents[res.unreadyDependency] = append(p.pendingComponentDependents[res.unreadyDependency], comp)
+		p.pendingComponentWaitingOn[comp.Name] = res.unreadyComponent
 		return nil
 	}
+	delete(p.pendingComponentWaitingOn, comp.Name)
 
 	compCategory := p.category(comp)
 	if compCategory == "" {
@@ -380,6 +443,7 @@ func (p *Processor) processComponentAndDependents(ctx context.Context, comp comp
 	ctx, cancel := context.WithTimeout(ctx, timeout)
 	defer cancel()
 
+	start := time.Now()
 	err = p.Init(ctx, comp)
 	// If the context is canceled, we want  to return an init error.
 	if errors.Is(ctx.Err(), context.DeadlineExceeded) {
@@ -388,14 +452,24 @@ func (p *Processor) processComponentAndDependents(ctx context.Context, comp comp
 	if err != nil {
 		log.Errorf("Failed to init component %s: %s", comp.LogName(), err)
 		diag.DefaultMonitoring.ComponentInitFailed(comp.Spec.Type, "init", comp.ObjectMeta.Name)
+		diag.DefaultComponentMonitoring.ComponentInitialized(context.Background(), comp.Name, comp.Spec.Type, false)
 		return rterrors.NewInit(rterrors.InitComponentFailure, comp.LogName(), err)
 	}
 
 	log.Info("Component loaded: " + comp.LogName())
 	diag.DefaultMonitoring.ComponentLoaded()
+	diag.DefaultComponentMonitoring.ComponentInitialized(context.Background(), comp.Name, comp.Spec.Type, true)
 
-	dependency := componentDependency(compCategory, comp.Name)
-	if deps, ok := p.pendingComponentDependents[dependency]; ok {
+	p.initDurationsLock.Lock()
+	p.initDurations[comp.Name] = time.Since(start)
+	p.initDurationsLock.Unlock()
+
+	// Dependents can refer to the component by category and name (e.g. secret stores referenced by secretKeyRef), or by name only (dependsOn)
+	for _, dependency := range []string{componentDependency(compCategory, comp.Name), comp.Name} {
+		deps, ok := p.pendingComponentDependents[dependency]
+		if !ok {
+			continue
+		}
 		delete(p.pendingComponentDependents, dependency)
 		for _, dependent := range deps {
 			if err := p.processComponentAndDependents(ctx, dependent); err != nil {
@@ -407,6 +481,22 @@ func (p *Processor) processComponentAndDependents(ctx context.Context, comp comp
 	return nil
 }
 
+// dependencyCycle returns the chain of dependencies, formatted as "a -> b -> a", if making the component wait for dependency would create a cycle.
+func (p *Processor) dependencyCycle(name, dependency string) string {
+	chain := []string{name}
+	for cur := dependency; cur != ""; cur = p.pendingComponentWaitingOn[cur] {
+		chain = append(chain, cur)
+		if cur == name {
+			return strings.Join(chain, " -> ")
+		}
+		// The chain can't be longer than the number of pending components, unless it contains a cycle that doesn't include this component
+		if len(chain) > len(p.pendingComponentWaitingOn)+1 {
+			return ""
+		}
+	}
+	return ""
+}
+
 func (p *Processor) processHTTPEndpointSecrets(ctx context.Context, endpoint *httpendpointsapi.HTTPEndpoint) {
 	_, _ = p.secret.ProcessResource(ctx, endpoint)
 
@@ -485,6 +575,16 @@ func (p *Processor) preprocessOneComponent(ctx context.Context, comp *components
 	if unreadySecretsStore != "" {
 		return componentPreprocessRes{
 			unreadyDependency: componentDependency(components.CategorySecretStore, unreadySecretsStore),
+			unreadyComponent:  unreadySecretsStore,
+		}
+	}
+
+	for _, dependency := range comp.Spec.DependsOn {
+		if _, ok := p.compStore.GetComponent(dependency); !ok {
+			return componentPreprocessRes{
+				unreadyDependency: dependency,
+				unreadyComponent:  dependency,
+			}
 		}
 	}
 	return componentPreprocessRes{}
@@ -163,6 +163,142 @@ func TestInitSecretStores(t *testing.T) {
 	})
 }
 
+func TestComponentDependsOn(t *testing.T) {
+	newProc := func() *Processor {
+		proc, reg := newTestProc()
+		reg.SecretStores().RegisterComponent(
+			func(_ logger.Logger) secretstores.SecretStore {
+				return rtmock.NewMockKubernetesStore()
+			},
+			"kubernetesMock",
+		)
+		return proc
+	}
+	newComp := func(name string, dependsOn ...string) componentsapi.Component {
+		return componentsapi.Component{
+			ObjectMeta: metav1.ObjectMeta{
+				Name: name,
+			},
+			Spec: componentsapi.ComponentSpec{
+				Type:      "secretstores.kubernetesMock",
+				Version:   "v1",
+				DependsOn: dependsOn,
+			},
+		}
+	}
+
+	t.Run("components are initialized after their dependencies", func(t *testing.T) {
+		proc := newProc()
+
+		require.NoError(t, proc.processComponentAndDependents(context.Background(), newComp("c", "a", "b")))
+		require.NoError(t, proc.processComponentAndDependents(context.Background(), newComp("b", "a")))
+		for _, name := range []string{"b", "c"} {
+			_, ok := proc.compStore.GetComponent(name)
+			assert.Falsef(t, ok, "component %s should not be initialized", name)
+		}
+
+		require.NoError(t, proc.processComponentAndDependents(context.Background(), newComp("a")))
+		for _, name := range []string{"a", "b", "c"} {
+			_, ok := proc.compStore.GetComponent(name)
+			assert.Truef(t, ok, "component %s should be initialized", name)
+		}
+		assert.Empty(t, proc.pendingComponentDependents)
+		assert.Empty(t, proc.pendingComponentWaitingOn)
+	})
+
+	t.Run("dependency cycle", func(t *testing.T) {
+		proc := newProc()
+
+		require.NoError(t, proc.processComponentAndDependents(context.Background(), newComp("a", "b")))
+		require.NoError(t, proc.processComponentAndDependents(context.Background(), newComp("b", "c")))
+		err := proc.processComponentAndDependents(context.Background(), newComp("c", "a"))
+		require.ErrorContains(t, err, "dependency cycle detected: c -> a -> b -> c")
+	})
+
+	t.Run("component depends on itself", func(t *testing.T) {
+		proc := newProc()
+
+		err := proc.processComponentAndDependents(context.Background(), newComp("a", "a"))
+		require.ErrorContains(t, err, "dependency cycle detected: a -> a")
+	})
+}
+
+func TestCloseDrainsComponent(t *testing.T) {
+	newComp := func(drainTimeout string) componentsapi.Component {
+		return componentsapi.Component{
+			ObjectMeta: metav1.ObjectMeta{
+				Name: "kubernetesMock",
+			},
+			Spec: componentsapi.ComponentSpec{
+				Type:         "secretstores.kubernetesMock",
+				Version:      "v1",
+				DrainTimeout: drainTimeout,
+			},
+		}
+	}
+	newProc := func(t *testing.T, comp componentsapi.Component) *Processor {
+		proc, reg := newTestProc()
+		reg.SecretStores().RegisterComponent(
+			func(_ logger.Logger) secretstores.SecretStore {
+				return rtmock.NewMockKubernetesStore()
+			},
+			"kubernetesMock",
+		)
+		require.NoError(t, proc.processComponentAndDependents(context.Background(), comp))
+		return proc
+	}
+
+	t.Run("waits for in-flight operations", func(t *testing.T) {
+		comp := newComp("5s")
+		proc := newProc(t, comp)
+
+		done := proc.compStore.TrackOperation(comp.Name)
+		completed := make(chan struct{})
+		go func() {
+			time.Sleep(50 * time.Millisecond)
+			close(completed)
+			done()
+		}()
+
+		require.NoError(t, proc.Close(comp))
+		select {
+		case <-completed:
+		default:
+			t.Fatal("component closed before the in-flight operation completed")
+		}
+		assert.Zero(t, proc.compStore.InflightOperations(comp.Name))
+	})
+
+	t.Run("closes after the drain timeout", func(t *testing.T) {
+		comp := newComp("50ms")
+		proc := newProc(t, comp)
+
+		done := proc.compStore.TrackOperation(comp.Name)
+		defer done()
+
+		start := time.Now()
+		require.NoError(t, proc.Close(comp))
+		assert.GreaterOrEqual(t, time.Since(start), 50*time.Millisecond)
+		assert.Less(t, time.Since(start), 5*time.Second)
+
+		drained, aborted := proc.compStore.DrainOperations(context.Background(), "other")
+		assert.Zero(t, drained)
+		assert.Zero(t, aborted)
+	})
+
+	t.Run("draining disabled", func(t *testing.T) {
+		comp := newComp("0s")
+		proc := newProc(t, comp)
+
+		done := proc.compStore.TrackOperation(comp.Name)
+		defer done()
+
+		start := time.Now()
+		require.NoError(t, proc.Close(comp))
+		assert.Less(t, time.Since(start), time.Second)
+	})
+}
+
 func TestExtractComponentCategory(t *testing.T) {
 	compCategoryTests := []struct {
 		specType string
@@ -31,6 +31,7 @@ import (
 	"github.com/dapr/components-contrib/contenttype"
 	"github.com/dapr/components-contrib/metadata"
 	contribpubsub "github.com/dapr/components-contrib/pubsub"
+	"github.com/dapr/dapr/pkg/config"
 	diag "github.com/dapr/dapr/pkg/diagnostics"
 	invokev1 "github.com/dapr/dapr/pkg/messaging/v1"
 	runtimev1pb "github.com/dapr/dapr/pkg/proto/runtime/v1"
@@ -182,6 +183,14 @@ func (p *pubsub) bulkSubscribeTopic(ctx context.Context, policyDef *resiliency.P
 					hasAnyError = true
 					continue
 				}
+				_, err = p.decodeCloudEvent(ctx, ps, cloudEvent)
+				if err != nil {
+					log.Errorf("error decoding one of the messages in bulk cloud event in pubsub %s and topic %s: %s", psName, topic, err)
+					bulkResponses[i].Error = err
+					bulkResponses[i].EntryId = message.EntryId
+					hasAnyError = true
+					continue
+				}
 				if contribpubsub.HasExpired(cloudEvent) {
 					log.Warnf("dropping expired pub/sub event %v as of %v", cloudEvent[contribpubsub.IDField], cloudEvent[contribpubsub.ExpirationField])
 					bulkSubDiag.statusWiseDiag[string(contribpubsub.Drop)]++
@@ -191,7 +200,7 @@ func (p *pubsub) bulkSubscribeTopic(ctx context.Context, policyDef *resiliency.P
 							Topic:       topic,
 							Metadata:    message.Metadata,
 							ContentType: &msg.Entries[i].ContentType,
-						}, route.DeadLetterTopic)
+						}, route.DeadLetterTopic, deadLetterReasonExpired)
 					}
 					bulkResponses[i].EntryId = message.EntryId
 					bulkResponses[i].Error = nil
@@ -285,7 +294,7 @@ func (p *pubsub) getRouteIfProcessable(ctx context.Context, bulkSubCallData *bul
 				Topic:       bscData.topic,
 				Metadata:    message.Metadata,
 				ContentType: &message.ContentType,
-			}, route.DeadLetterTopic)
+			}, route.DeadLetterTopic, deadLetterReasonNoRoute)
 		}
 		setBulkResponseEntry(bscData.bulkResponses, i, message.EntryId, nil)
 		return "", nil
@@ -389,7 +398,7 @@ func (p *pubsub) publishBulkMessageHTTP(ctx context.Context, bulkSubCallData *bu
 	spans = spans[:n]
 	defer endSpans(spans)
 	start := time.Now()
-	resp, err := p.channels.AppChannel().InvokeMethod(ctx, req, "")
+	resp, err := p.channels.AppChannelFor(config.AppChannelRoutePubSub).InvokeMethod(ctx, req, "")
 	elapsed := diag.ElapsedSince(start)
 	if err != nil {
 		bscData.bulkSubDiag.statusWiseDiag[string(contribpubsub.Retry)] += int64(len(rawMsgEntries))
@@ -447,7 +456,7 @@ func (p *pubsub) publishBulkMessageHTTP(ctx context.Context, bulkSubCallData *bu
 							Topic:       bscData.topic,
 							Metadata:    msg.entry.Metadata,
 							ContentType: &msg.entry.ContentType,
-						}, deadLetterTopic)
+						}, deadLetterTopic, deadLetterReasonDropped)
 					}
 				default:
 					// Consider unknown status field as error and retry
@@ -627,7 +636,7 @@ func (p *pubsub) publishBulkMessageGRPC(ctx context.Context, bulkSubCallData *bu
 						Topic:       bscData.topic,
 						Metadata:    msg.entry.Metadata,
 						ContentType: &msg.entry.ContentType,
-					}, deadLetterTopic)
+					}, deadLetterTopic, deadLetterReasonDropped)
 				}
 			default:
 				// Consider unknown status field as error and retry
@@ -693,8 +702,17 @@ func (p *pubsub) sendBulkToDeadLetter(ctx context.Context,
 	if bscData.bulkSubDiag.retryReported {
 		bscData.bulkSubDiag.statusWiseDiag[string(contribpubsub.Retry)] -= int64(len(data))
 	}
+	entries := make([]contribpubsub.BulkMessageEntry, len(data))
+	for i, entry := range data {
+		reason := deadLetterReasonBulk
+		if idx, ok := (*bscData.entryIdIndexMap)[entry.EntryId]; ok && (*bscData.bulkResponses)[idx].Error != nil {
+			reason = (*bscData.bulkResponses)[idx].Error.Error()
+		}
+		entries[i] = entry
+		entries[i].Event = deadLetterData(ctx, entry.Event, msg.Topic, reason)
+	}
 	req := &contribpubsub.BulkPublishRequest{
-		Entries:    data,
+		Entries:    entries,
 		PubsubName: bscData.psName,
 		Topic:      deadLetterTopic,
 		Metadata:   msg.Metadata,
@@ -0,0 +1,78 @@
+/*
+Copyright 2023 The Dapr Authors
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package pubsub
+
+import (
+	"context"
+	"encoding/json"
+
+	"go.opentelemetry.io/otel/trace"
+
+	contribpubsub "github.com/dapr/components-contrib/pubsub"
+	diag "github.com/dapr/dapr/pkg/diagnostics"
+	diagConsts "github.com/dapr/dapr/pkg/diagnostics/consts"
+)
+
+// CloudEvent extension attributes added to the messages sent to a dead-letter topic.
+const (
+	// DeadLetterReasonField is the reason the message was sent to the dead-letter topic.
+	DeadLetterReasonField = "deadletterreason"
+	// DeadLetterTopicField is the topic the message was originally delivered on.
+	DeadLetterTopicField = "deadlettertopic"
+)
+
+// Reasons for sending a message to the dead-letter topic, besides the errors delivering it.
+const (
+	deadLetterReasonExpired = "message expired"
+	deadLetterReasonNoRoute = "no matching route"
+	deadLetterReasonDropped = "message dropped by the app"
+	deadLetterReasonBulk    = "failed to deliver the bulk message"
+)
+
+// deadLetterData returns the data of a message sent to the dead-letter topic.
+// If the message is a CloudEvent, the reason and the original topic are added to it, and so is the trace context in ctx if the event doesn't have one, so the trace is resumed when the dead-letter topic is consumed.
+// Other messages, such as raw payloads, are returned as-is.
+func deadLetterData(ctx context.Context, data []byte, topic string, reason string) []byte {
+	var cloudEvent map[string]interface{}
+	if err := json.Unmarshal(data, &cloudEvent); err != nil || cloudEvent[contribpubsub.SpecVersionField] == nil {
+		return data
+	}
+
+	if cloudEvent[contribpubsub.TraceParentField] == nil && cloudEvent[contribpubsub.TraceIDField] == nil {
+		if sc := trace.SpanContextFromContext(ctx); sc.IsValid() {
+			cloudEvent[contribpubsub.TraceParentField] = diag.SpanContextToW3CString(sc)
+			if ts := diag.TraceStateToW3CString(sc); ts != "" {
+				cloudEvent[contribpubsub.TraceStateField] = ts
+			}
+		}
+	}
+	cloudEvent[DeadLetterReasonField] = reason
+	cloudEvent[DeadLetterTopicField] = topic
+
+	res, err := json.Marshal(cloudEvent)
+	if err != nil {
+		return data
+	}
+	return res
+}
+
+// addDeadLetterSpanAttributes adds the reason and the original topic of an event consumed from a dead-letter topic to the attributes of its span.
+func addDeadLetterSpanAttributes(m map[string]string, cloudEvent map[string]interface{}) {
+	if reason, ok := cloudEvent[DeadLetterReasonField].(string); ok {
+		m[diagConsts.DaprPubsubDeadLetterReason] = reason
+	}
+	if topic, ok := cloudEvent[DeadLetterTopicField].(string); ok {
+		m[diagConsts.DaprPubsubDeadLetterTopic] = topic
+	}
+}
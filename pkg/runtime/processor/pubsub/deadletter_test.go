@@ -0,0 +1,79 @@
+/*
+Copyright 2023 The Dapr Authors
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package pubsub
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel/trace"
+
+	contribpubsub "github.com/dapr/components-contrib/pubsub"
+	diag "github.com/dapr/dapr/pkg/diagnostics"
+	diagConsts "github.com/dapr/dapr/pkg/diagnostics/consts"
+)
+
+func TestDeadLetterData(t *testing.T) {
+	const (
+		originalTraceparent = "00-0af7651916cd43dd8448eb211c80319c-b7ad6b7169203331-01"
+		ctxTraceparent      = "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01"
+	)
+	sc, ok := diag.SpanContextFromW3CString(ctxTraceparent)
+	require.True(t, ok)
+	ctx := trace.ContextWithSpanContext(context.Background(), sc)
+
+	t.Run("keeps the original trace context", func(t *testing.T) {
+		data := deadLetterData(ctx, []byte(`{"specversion":"1.0","id":"1","traceparent":"`+originalTraceparent+`"}`), "orders", "failed")
+
+		var cloudEvent map[string]interface{}
+		require.NoError(t, json.Unmarshal(data, &cloudEvent))
+		assert.Equal(t, originalTraceparent, cloudEvent[contribpubsub.TraceParentField])
+		assert.Equal(t, "failed", cloudEvent[DeadLetterReasonField])
+		assert.Equal(t, "orders", cloudEvent[DeadLetterTopicField])
+		assert.Equal(t, "1", cloudEvent[contribpubsub.IDField])
+	})
+
+	t.Run("adds the trace context of the context", func(t *testing.T) {
+		data := deadLetterData(ctx, []byte(`{"specversion":"1.0","id":"1"}`), "orders", deadLetterReasonExpired)
+
+		var cloudEvent map[string]interface{}
+		require.NoError(t, json.Unmarshal(data, &cloudEvent))
+		assert.Equal(t, ctxTraceparent, cloudEvent[contribpubsub.TraceParentField])
+		assert.Equal(t, deadLetterReasonExpired, cloudEvent[DeadLetterReasonField])
+	})
+
+	t.Run("raw payloads are unchanged", func(t *testing.T) {
+		assert.Equal(t, []byte(`{"id":"1"}`), deadLetterData(ctx, []byte(`{"id":"1"}`), "orders", "failed"))
+		assert.Equal(t, []byte("raw"), deadLetterData(ctx, []byte("raw"), "orders", "failed"))
+	})
+}
+
+func TestAddDeadLetterSpanAttributes(t *testing.T) {
+	m := map[string]string{}
+	addDeadLetterSpanAttributes(m, map[string]interface{}{
+		DeadLetterReasonField: "failed",
+		DeadLetterTopicField:  "orders",
+	})
+	assert.Equal(t, map[string]string{
+		diagConsts.DaprPubsubDeadLetterReason: "failed",
+		diagConsts.DaprPubsubDeadLetterTopic:  "orders",
+	}, m)
+
+	m = map[string]string{}
+	addDeadLetterSpanAttributes(m, map[string]interface{}{contribpubsub.IDField: "1"})
+	assert.Empty(t, m)
+}
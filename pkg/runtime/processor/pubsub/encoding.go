@@ -0,0 +1,200 @@
+/*
+Copyright 2023 The Dapr Authors
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package pubsub
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/lestrrat-go/jwx/v2/jwk"
+
+	contribcrypto "github.com/dapr/components-contrib/crypto"
+	"github.com/dapr/components-contrib/metadata"
+	diag "github.com/dapr/dapr/pkg/diagnostics"
+	"github.com/dapr/dapr/pkg/resiliency"
+	"github.com/dapr/dapr/pkg/runtime/compstore"
+	rtpubsub "github.com/dapr/dapr/pkg/runtime/pubsub"
+	encv1 "github.com/dapr/kit/schemes/enc/v1"
+)
+
+// encodeMessage compresses, encrypts and checks in the data of a CloudEvent published to a pubsub component, if enabled for the component.
+// Data is compressed before it's encrypted, since encrypted data can't be compressed, and it's checked in last, so data stored in the state store is encrypted too.
+func (p *pubsub) encodeMessage(ctx context.Context, ps compstore.PubsubItem, data []byte, md map[string]string) ([]byte, error) {
+	if ps.Compression == nil && ps.Encryption == nil && ps.ClaimCheck == nil {
+		return data, nil
+	}
+
+	rawPayload, err := metadata.IsRawPayload(md)
+	if err != nil {
+		return nil, err
+	}
+	if rawPayload {
+		if ps.Encryption != nil {
+			return nil, errors.New("raw payloads cannot be published to a pubsub component with encryption enabled")
+		}
+		// Raw payloads are not wrapped in a CloudEvent, so they're never compressed nor checked in
+		return data, nil
+	}
+
+	var cloudEvent map[string]interface{}
+	err = json.Unmarshal(data, &cloudEvent)
+	if err != nil {
+		return nil, fmt.Errorf("error deserializing cloud event: %w", err)
+	}
+
+	if ps.Compression != nil {
+		err = ps.Compression.CompressCloudEvent(cloudEvent)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if ps.Encryption != nil {
+		var cryptoComponent contribcrypto.SubtleCrypto
+		cryptoComponent, err = p.getCryptoComponent(ps.Encryption.CryptoComponent)
+		if err != nil {
+			return nil, err
+		}
+		err = ps.Encryption.EncryptCloudEvent(cloudEvent, p.cryptoWrapKeyFn(ctx, ps.Encryption.CryptoComponent, cryptoComponent))
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if ps.ClaimCheck != nil {
+		store, ok := p.compStore.GetStateStore(ps.ClaimCheck.StateStore)
+		if !ok {
+			return nil, fmt.Errorf("state store %s used to store the data of messages not found", ps.ClaimCheck.StateStore)
+		}
+		err = ps.ClaimCheck.CheckIn(ctx, cloudEvent, store)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return json.Marshal(cloudEvent)
+}
+
+// decodeCloudEvent checks out, decrypts and decompresses the data of a CloudEvent delivered by a pubsub component, reversing encodeMessage.
+// It returns false if the event was left unchanged.
+func (p *pubsub) decodeCloudEvent(ctx context.Context, ps compstore.PubsubItem, cloudEvent map[string]interface{}) (bool, error) {
+	checkedIn := rtpubsub.IsClaimCheck(cloudEvent)
+	if checkedIn {
+		if ps.ClaimCheck == nil {
+			return false, errors.New("received an event whose data is stored in a state store, but the claim-check pattern is not enabled for the pubsub component")
+		}
+		store, ok := p.compStore.GetStateStore(ps.ClaimCheck.StateStore)
+		if !ok {
+			return false, fmt.Errorf("state store %s used to store the data of messages not found", ps.ClaimCheck.StateStore)
+		}
+		err := ps.ClaimCheck.CheckOut(ctx, cloudEvent, store)
+		if err != nil {
+			return false, err
+		}
+	}
+
+	enc := ps.Encryption
+	encrypted := rtpubsub.IsEncrypted(cloudEvent)
+	if encrypted {
+		if enc == nil {
+			return false, errors.New("received an encrypted event, but encryption is not enabled for the pubsub component")
+		}
+
+		cryptoComponent, err := p.getCryptoComponent(enc.CryptoComponent)
+		if err != nil {
+			return false, err
+		}
+		err = rtpubsub.DecryptCloudEvent(cloudEvent, p.cryptoUnwrapKeyFn(ctx, enc.CryptoComponent, cryptoComponent))
+		if err != nil {
+			return false, err
+		}
+	}
+
+	if !rtpubsub.IsCompressed(cloudEvent) {
+		return checkedIn || encrypted, nil
+	}
+	err := rtpubsub.DecompressCloudEvent(cloudEvent, p.maxRequestBodySize)
+	if err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+func (p *pubsub) getCryptoComponent(name string) (contribcrypto.SubtleCrypto, error) {
+	component, ok := p.compStore.GetCryptoProvider(name)
+	if !ok {
+		return nil, fmt.Errorf("crypto component '%s' used to encrypt messages not found", name)
+	}
+	return component, nil
+}
+
+func (p *pubsub) cryptoWrapKeyFn(ctx context.Context, componentName string, component contribcrypto.SubtleCrypto) encv1.WrapKeyFn {
+	return func(plaintextKeyBytes []byte, algorithm, keyName string, nonce []byte) ([]byte, []byte, error) {
+		plaintextKey, err := jwk.FromRaw(plaintextKeyBytes)
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to import key: %w", err)
+		}
+
+		type wrapKeyRes struct {
+			wrappedKey []byte
+			tag        []byte
+		}
+		policyRunner := resiliency.NewRunner[wrapKeyRes](ctx,
+			p.resiliency.ComponentOutboundPolicy(componentName, resiliency.Crypto),
+		)
+		start := time.Now()
+		res, err := policyRunner(func(ctx context.Context) (r wrapKeyRes, rErr error) {
+			r.wrappedKey, r.tag, rErr = component.WrapKey(ctx, plaintextKey, algorithm, keyName, nonce, nil)
+			return
+		})
+		elapsed := diag.ElapsedSince(start)
+
+		diag.DefaultComponentMonitoring.CryptoInvoked(ctx, componentName, diag.CryptoOp, err == nil, elapsed)
+		diag.DefaultComponentMonitoring.OperationFailed(ctx, componentName, diag.CryptoOp, err)
+
+		if err != nil {
+			return nil, nil, err
+		}
+		return res.wrappedKey, res.tag, nil
+	}
+}
+
+func (p *pubsub) cryptoUnwrapKeyFn(ctx context.Context, componentName string, component contribcrypto.SubtleCrypto) encv1.UnwrapKeyFn {
+	return func(wrappedKey []byte, algorithm, keyName string, nonce, tag []byte) (plaintextKeyBytes []byte, err error) {
+		policyRunner := resiliency.NewRunner[jwk.Key](ctx,
+			p.resiliency.ComponentOutboundPolicy(componentName, resiliency.Crypto),
+		)
+		start := time.Now()
+		plaintextKey, err := policyRunner(func(ctx context.Context) (jwk.Key, error) {
+			return component.UnwrapKey(ctx, wrappedKey, algorithm, keyName, nonce, tag, nil)
+		})
+		elapsed := diag.ElapsedSince(start)
+
+		diag.DefaultComponentMonitoring.CryptoInvoked(ctx, componentName, diag.CryptoOp, err == nil, elapsed)
+		diag.DefaultComponentMonitoring.OperationFailed(ctx, componentName, diag.CryptoOp, err)
+
+		if err != nil {
+			return nil, err
+		}
+
+		err = plaintextKey.Raw(&plaintextKeyBytes)
+		if err != nil {
+			return nil, fmt.Errorf("failed to extract key: %w", err)
+		}
+		return plaintextKeyBytes, nil
+	}
+}
@@ -29,8 +29,10 @@ import (
 	"google.golang.org/protobuf/encoding/protojson"
 	"google.golang.org/protobuf/types/known/structpb"
 
+	"github.com/dapr/components-contrib/bindings"
 	"github.com/dapr/components-contrib/contenttype"
 	contribpubsub "github.com/dapr/components-contrib/pubsub"
+	"github.com/dapr/dapr/pkg/config"
 	diag "github.com/dapr/dapr/pkg/diagnostics"
 	invokev1 "github.com/dapr/dapr/pkg/messaging/v1"
 	runtimev1 "github.com/dapr/dapr/pkg/proto/runtime/v1"
@@ -55,16 +57,30 @@ func (p *pubsub) Publish(ctx context.Context, req *contribpubsub.PublishRequest)
 		return rtpubsub.NotAllowedError{Topic: req.Topic, ID: p.id}
 	}
 
+	defer p.compStore.TrackOperation(req.PubsubName)()
+
 	if ps.NamespaceScoped {
 		req.Topic = p.namespace + req.Topic
 	}
 
+	data, err := p.encodeMessage(ctx, ps, req.Data, req.Metadata)
+	if err != nil {
+		return fmt.Errorf("error encoding message for pubsub %s: %w", req.PubsubName, err)
+	}
+	req.Data = data
+
 	policyRunner := resiliency.NewRunner[any](ctx,
 		p.resiliency.ComponentOutboundPolicy(req.PubsubName, resiliency.Pubsub),
 	)
-	_, err := policyRunner(func(ctx context.Context) (any, error) {
-		return nil, ps.Component.Publish(ctx, req)
+	duplicate, err := p.dedup.Publish(ctx, req, func() error {
+		_, rErr := policyRunner(func(ctx context.Context) (any, error) {
+			return nil, ps.Component.Publish(ctx, req)
+		})
+		return rErr
 	})
+	if duplicate {
+		log.Debugf("Skipped publishing message with duplicate idempotency key to topic %s on pubsub %s", req.Topic, req.PubsubName)
+	}
 	return err
 }
 
@@ -81,6 +97,17 @@ func (p *pubsub) BulkPublish(ctx context.Context, req *contribpubsub.BulkPublish
 		return contribpubsub.BulkPublishResponse{}, rtpubsub.NotAllowedError{Topic: req.Topic, ID: p.id}
 	}
 
+	defer p.compStore.TrackOperation(req.PubsubName)()
+
+	for i, entry := range req.Entries {
+		data, err := p.encodeMessage(ctx, ps, entry.Event, req.Metadata)
+		if err != nil {
+			err = fmt.Errorf("error encoding message for pubsub %s: %w", req.PubsubName, err)
+			return contribpubsub.NewBulkPublishResponse(req.Entries, err), err
+		}
+		req.Entries[i].Event = data
+	}
+
 	policyDef := p.resiliency.ComponentOutboundPolicy(req.PubsubName, resiliency.Pubsub)
 
 	if contribpubsub.FeatureBulkPublish.IsPresent(ps.Component.Features()) {
@@ -93,6 +120,84 @@ func (p *pubsub) BulkPublish(ctx context.Context, req *contribpubsub.BulkPublish
 	return rtpubsub.ApplyBulkPublishResiliency(ctx, req, policyDef, defaultBulkPublisher)
 }
 
+const (
+	// Minimum interval between two requests to the pub/sub component for the backlog of the same topic.
+	topicBacklogRefreshInterval = 5 * time.Second
+
+	// Timeout of a request to the pub/sub component for the backlog of a topic.
+	topicBacklogTimeout = 5 * time.Second
+)
+
+// topicBacklog is the last backlog of a topic reported by the pub/sub component.
+type topicBacklog struct {
+	value      int64
+	valid      bool
+	updated    time.Time
+	refreshing bool
+}
+
+// TopicBacklog returns the number of messages waiting to be delivered on the topic, if the pub/sub component reports it.
+// To keep the component out of the publish path, it returns the last backlog reported, and the backlog is refreshed in the background at most once every topicBacklogRefreshInterval.
+func (p *pubsub) TopicBacklog(ctx context.Context, pubsubName, topic string) (int64, bool) {
+	p.lock.RLock()
+	ps, ok := p.compStore.GetPubSub(pubsubName)
+	p.lock.RUnlock()
+	if !ok {
+		return 0, false
+	}
+
+	reporter, ok := ps.Component.(rtpubsub.BacklogReporter)
+	if !ok {
+		return 0, false
+	}
+
+	brokerTopic := topic
+	if ps.NamespaceScoped {
+		brokerTopic = p.namespace + topic
+	}
+
+	p.backlogLock.Lock()
+	defer p.backlogLock.Unlock()
+
+	if p.backlogs == nil {
+		p.backlogs = make(map[string]*topicBacklog)
+	}
+	key := pubsubName + "||" + topic
+	backlog, ok := p.backlogs[key]
+	if !ok {
+		backlog = &topicBacklog{}
+		p.backlogs[key] = backlog
+	}
+
+	if !backlog.refreshing && time.Since(backlog.updated) >= topicBacklogRefreshInterval {
+		backlog.refreshing = true
+		go p.refreshTopicBacklog(reporter, backlog, pubsubName, topic, brokerTopic)
+	}
+
+	return backlog.value, backlog.valid
+}
+
+// refreshTopicBacklog retrieves the backlog of a topic from the pub/sub component and records it in the metrics.
+func (p *pubsub) refreshTopicBacklog(reporter rtpubsub.BacklogReporter, backlog *topicBacklog, pubsubName, topic, brokerTopic string) {
+	ctx, cancel := context.WithTimeout(context.Background(), topicBacklogTimeout)
+	defer cancel()
+
+	value, err := reporter.TopicBacklog(ctx, brokerTopic)
+	if err != nil {
+		log.Debugf("Failed to get the backlog of topic %s on pubsub %s: %v", topic, pubsubName, err)
+	} else {
+		diag.DefaultComponentMonitoring.PubsubEgressBacklog(ctx, pubsubName, topic, value)
+	}
+
+	p.backlogLock.Lock()
+	defer p.backlogLock.Unlock()
+
+	backlog.refreshing = false
+	backlog.updated = time.Now()
+	backlog.value = value
+	backlog.valid = err == nil
+}
+
 func (p *pubsub) publishMessageHTTP(ctx context.Context, msg *subscribedMessage) error {
 	cloudEvent := msg.cloudEvent
 
@@ -114,9 +219,11 @@ func (p *pubsub) publishMessageHTTP(ctx context.Context, msg *subscribedMessage)
 		sc, _ := diag.SpanContextFromW3CString(traceID)
 		ctx, span = diag.StartInternalCallbackSpan(ctx, "pubsub/"+msg.topic, sc, p.tracingSpec)
 	}
+	// The baggage is sent to the app in the baggage header by the app channel
+	ctx = contextWithCloudEventBaggage(ctx, cloudEvent)
 
 	start := time.Now()
-	resp, err := p.channels.AppChannel().InvokeMethod(ctx, req, "")
+	resp, err := p.channels.AppChannelFor(config.AppChannelRoutePubSub).InvokeMethod(ctx, req, "")
 	elapsed := diag.ElapsedSince(start)
 
 	if err != nil {
@@ -129,6 +236,7 @@ func (p *pubsub) publishMessageHTTP(ctx context.Context, msg *subscribedMessage)
 
 	if span != nil {
 		m := diag.ConstructSubscriptionSpanAttributes(msg.topic)
+		addDeadLetterSpanAttributes(m, cloudEvent)
 		diag.AddAttributesToSpan(span, m)
 		diag.UpdateSpanStatusFromHTTPStatus(span, statusCode)
 		span.End()
@@ -248,6 +356,7 @@ func (p *pubsub) publishMessageGRPC(ctx context.Context, msg *subscribedMessage)
 			log.Warnf("ignored non-string traceid value: %v", iTraceID)
 		}
 	}
+	ctx = diag.BaggageToGRPCMetadata(contextWithCloudEventBaggage(ctx, cloudEvent))
 
 	extensions, extensionsErr := extractCloudEventExtensions(cloudEvent)
 	if extensionsErr != nil {
@@ -270,6 +379,7 @@ func (p *pubsub) publishMessageGRPC(ctx context.Context, msg *subscribedMessage)
 
 	if span != nil {
 		m := diag.ConstructSubscriptionSpanAttributes(envelope.GetTopic())
+		addDeadLetterSpanAttributes(m, cloudEvent)
 		diag.AddAttributesToSpan(span, m)
 		diag.UpdateSpanStatusFromGRPCError(span, err)
 		span.End()
@@ -336,3 +446,46 @@ func extractCloudEventExtensions(cloudEvent map[string]interface{}) (*structpb.S
 	}
 	return &extensionsStruct, nil
 }
+
+// publishMessageBinding sends a subscribed message to the output binding of the routing rule it matched, without involving the app.
+// The message is sent as a JSON-encoded CloudEvent, and the outbound resiliency policy of the binding is applied.
+func (p *pubsub) publishMessageBinding(ctx context.Context, msg *subscribedMessage) error {
+	cloudEvent := msg.cloudEvent
+
+	binding, ok := p.compStore.GetOutputBinding(msg.binding.Name)
+	if !ok {
+		diag.DefaultComponentMonitoring.PubsubIngressEvent(ctx, msg.pubsub, strings.ToLower(string(contribpubsub.Retry)), msg.topic, 0)
+		return fmt.Errorf("couldn't find output binding %s to route pub/sub event %v: %w", msg.binding.Name, cloudEvent[contribpubsub.IDField], rterrors.NewRetriable(nil))
+	}
+
+	req := &bindings.InvokeRequest{
+		Data:      msg.data,
+		Metadata:  msg.binding.Metadata,
+		Operation: bindings.OperationKind(msg.binding.Operation),
+	}
+
+	start := time.Now()
+	policyRunner := resiliency.NewRunner[*bindings.InvokeResponse](ctx,
+		p.resiliency.ComponentOutboundPolicy(msg.binding.Name, resiliency.Binding),
+	)
+	_, err := policyRunner(func(ctx context.Context) (*bindings.InvokeResponse, error) {
+		return binding.Invoke(ctx, req)
+	})
+	elapsed := diag.ElapsedSince(start)
+
+	if err != nil {
+		diag.DefaultComponentMonitoring.PubsubIngressEvent(ctx, msg.pubsub, strings.ToLower(string(contribpubsub.Retry)), msg.topic, elapsed)
+		return fmt.Errorf("error returned from output binding %s while processing pub/sub event %v: %w", msg.binding.Name, cloudEvent[contribpubsub.IDField], rterrors.NewRetriable(err))
+	}
+
+	diag.DefaultComponentMonitoring.PubsubIngressEvent(ctx, msg.pubsub, strings.ToLower(string(contribpubsub.Success)), msg.topic, elapsed)
+	return nil
+}
+
+// contextWithCloudEventBaggage returns a context carrying the baggage of the publisher, which is stored in the baggage extension of the cloudevent.
+func contextWithCloudEventBaggage(ctx context.Context, cloudEvent map[string]interface{}) context.Context {
+	if bg, ok := cloudEvent[rtpubsub.BaggageField].(string); ok {
+		return diag.ContextWithBaggageString(ctx, bg)
+	}
+	return ctx
+}
@@ -21,7 +21,10 @@ import (
 	"fmt"
 	"net/http"
 	"reflect"
+	"strings"
+	"sync/atomic"
 	"testing"
+	"time"
 
 	"github.com/phayes/freeport"
 	"github.com/stretchr/testify/assert"
@@ -31,6 +34,7 @@ import (
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
 
+	"github.com/dapr/components-contrib/bindings"
 	"github.com/dapr/components-contrib/contenttype"
 	contribpubsub "github.com/dapr/components-contrib/pubsub"
 	channelt "github.com/dapr/dapr/pkg/channel/testing"
@@ -44,8 +48,10 @@ import (
 	"github.com/dapr/dapr/pkg/runtime/compstore"
 	rterrors "github.com/dapr/dapr/pkg/runtime/errors"
 	"github.com/dapr/dapr/pkg/runtime/meta"
+	rtmock "github.com/dapr/dapr/pkg/runtime/mock"
 	runtimePubsub "github.com/dapr/dapr/pkg/runtime/pubsub"
 	"github.com/dapr/dapr/pkg/runtime/registry"
+	daprt "github.com/dapr/dapr/pkg/testing"
 	testinggrpc "github.com/dapr/dapr/pkg/testing/grpc"
 	"github.com/dapr/kit/logger"
 	"github.com/dapr/kit/ptr"
@@ -793,3 +799,230 @@ func TestOnNewPublishedMessageGRPC(t *testing.T) {
 		})
 	}
 }
+
+type routedOutputBinding struct {
+	rtmock.Binding
+	err  error
+	reqs []*bindings.InvokeRequest
+}
+
+func (b *routedOutputBinding) Invoke(ctx context.Context, req *bindings.InvokeRequest) (*bindings.InvokeResponse, error) {
+	b.reqs = append(b.reqs, req)
+	return nil, b.err
+}
+
+func TestPublishMessageBinding(t *testing.T) {
+	newPubsub := func(b bindings.OutputBinding) *pubsub {
+		ps := &pubsub{
+			compStore:  compstore.New(),
+			resiliency: resiliency.New(logger.NewLogger("test")),
+		}
+		if b != nil {
+			ps.compStore.AddOutputBinding("s3", b)
+		}
+		return ps
+	}
+	msg := func() *subscribedMessage {
+		return &subscribedMessage{
+			cloudEvent: map[string]interface{}{contribpubsub.IDField: "1"},
+			data:       []byte(`{"id":"1","data":"hello"}`),
+			topic:      "topic1",
+			pubsub:     "pubsub",
+			binding: &runtimePubsub.BindingRoute{
+				Name:      "s3",
+				Operation: "create",
+				Metadata:  map[string]string{"key": "archive"},
+			},
+		}
+	}
+
+	t.Run("event is sent to the binding", func(t *testing.T) {
+		b := &routedOutputBinding{}
+		err := newPubsub(b).publishMessageBinding(context.Background(), msg())
+		require.NoError(t, err)
+		require.Len(t, b.reqs, 1)
+		assert.Equal(t, []byte(`{"id":"1","data":"hello"}`), b.reqs[0].Data)
+		assert.Equal(t, bindings.CreateOperation, b.reqs[0].Operation)
+		assert.Equal(t, map[string]string{"key": "archive"}, b.reqs[0].Metadata)
+	})
+
+	t.Run("error from the binding is retriable", func(t *testing.T) {
+		b := &routedOutputBinding{err: errors.New("bucket not found")}
+		err := newPubsub(b).publishMessageBinding(context.Background(), msg())
+		var rErr *rterrors.RetriableError
+		require.ErrorAs(t, err, &rErr)
+		assert.ErrorContains(t, err, "bucket not found")
+	})
+
+	t.Run("binding not found", func(t *testing.T) {
+		err := newPubsub(nil).publishMessageBinding(context.Background(), msg())
+		var rErr *rterrors.RetriableError
+		require.ErrorAs(t, err, &rErr)
+	})
+}
+
+type backlogPubSub struct {
+	contribpubsub.PubSub
+	backlog map[string]int64
+	err     error
+	calls   atomic.Int32
+}
+
+func (b *backlogPubSub) TopicBacklog(ctx context.Context, topic string) (int64, error) {
+	b.calls.Add(1)
+	return b.backlog[topic], b.err
+}
+
+func TestTopicBacklog(t *testing.T) {
+	p := &pubsub{compStore: compstore.New(), namespace: "ns."}
+	reporter := &backlogPubSub{backlog: map[string]int64{"orders": 12, "ns.orders": 30}}
+	p.compStore.AddPubSub("reporter", compstore.PubsubItem{
+		Component: reporter,
+	})
+	p.compStore.AddPubSub("scoped", compstore.PubsubItem{
+		Component:       &backlogPubSub{backlog: map[string]int64{"orders": 12, "ns.orders": 30}},
+		NamespaceScoped: true,
+	})
+	failing := &backlogPubSub{err: errors.New("unavailable")}
+	p.compStore.AddPubSub("failing", compstore.PubsubItem{
+		Component: failing,
+	})
+	p.compStore.AddPubSub("other", compstore.PubsubItem{
+		Component: &daprt.MockPubSub{},
+	})
+
+	// The backlog is retrieved in the background, so it is returned once the component has reported it
+	assertBacklog := func(pubsubName string, expected int64) {
+		t.Helper()
+		assert.EventuallyWithT(t, func(c *assert.CollectT) {
+			backlog, ok := p.TopicBacklog(context.Background(), pubsubName, "orders")
+			assert.True(c, ok)
+			assert.Equal(c, expected, backlog)
+		}, time.Second, 10*time.Millisecond)
+	}
+	assertBacklog("reporter", 12)
+	assertBacklog("scoped", 30)
+
+	// The component is not called again before the refresh interval
+	for i := 0; i < 10; i++ {
+		p.TopicBacklog(context.Background(), "reporter", "orders")
+	}
+	assert.Equal(t, int32(1), reporter.calls.Load())
+
+	assert.Eventually(t, func() bool {
+		p.TopicBacklog(context.Background(), "failing", "orders")
+		return failing.calls.Load() == 1
+	}, time.Second, 10*time.Millisecond)
+	assert.Eventually(t, func() bool {
+		p.backlogLock.Lock()
+		defer p.backlogLock.Unlock()
+		return !p.backlogs["failing||orders"].refreshing
+	}, time.Second, 10*time.Millisecond)
+	_, ok := p.TopicBacklog(context.Background(), "failing", "orders")
+	assert.False(t, ok)
+
+	_, ok = p.TopicBacklog(context.Background(), "other", "orders")
+	assert.False(t, ok)
+
+	_, ok = p.TopicBacklog(context.Background(), "notfound", "orders")
+	assert.False(t, ok)
+}
+
+func TestBindingOnlySubscriptions(t *testing.T) {
+	subs := []runtimePubsub.Subscription{
+		{Topic: "app", Rules: []*runtimePubsub.Rule{{Path: "orders"}}},
+		{Topic: "mixed", Rules: []*runtimePubsub.Rule{{Path: "orders"}, {Binding: &runtimePubsub.BindingRoute{Name: "s3"}}}},
+		{Topic: "binding", Rules: []*runtimePubsub.Rule{{Binding: &runtimePubsub.BindingRoute{Name: "s3"}}}},
+		{Topic: "empty"},
+	}
+
+	res := bindingOnlySubscriptions(subs)
+	require.Len(t, res, 1)
+	assert.Equal(t, "binding", res[0].Topic)
+}
+
+func TestEncodeMessage(t *testing.T) {
+	p := &pubsub{compStore: compstore.New()}
+	event := []byte(`{"id":"1","datacontenttype":"text/plain","data":"` + strings.Repeat("hello ", 100) + `"}`)
+
+	t.Run("not enabled", func(t *testing.T) {
+		data, err := p.encodeMessage(context.Background(), compstore.PubsubItem{}, event, nil)
+		require.NoError(t, err)
+		assert.Equal(t, event, data)
+	})
+
+	t.Run("compression", func(t *testing.T) {
+		ps := compstore.PubsubItem{
+			Compression: &runtimePubsub.Compression{Algorithm: runtimePubsub.CompressionGzip, Threshold: 100},
+		}
+		data, err := p.encodeMessage(context.Background(), ps, event, nil)
+		require.NoError(t, err)
+		assert.Less(t, len(data), len(event))
+
+		var cloudEvent map[string]interface{}
+		require.NoError(t, json.Unmarshal(data, &cloudEvent))
+		assert.Equal(t, runtimePubsub.CompressionGzip, cloudEvent[runtimePubsub.ContentEncodingExtension])
+
+		decoded, err := p.decodeCloudEvent(context.Background(), ps, cloudEvent)
+		require.NoError(t, err)
+		assert.True(t, decoded)
+		data, err = json.Marshal(cloudEvent)
+		require.NoError(t, err)
+		assert.JSONEq(t, string(event), string(data))
+	})
+
+	t.Run("raw payloads are not compressed", func(t *testing.T) {
+		ps := compstore.PubsubItem{
+			Compression: &runtimePubsub.Compression{Algorithm: runtimePubsub.CompressionGzip},
+		}
+		data, err := p.encodeMessage(context.Background(), ps, []byte("hello"), map[string]string{"rawPayload": "true"})
+		require.NoError(t, err)
+		assert.Equal(t, []byte("hello"), data)
+	})
+
+	t.Run("raw payloads cannot be encrypted", func(t *testing.T) {
+		ps := compstore.PubsubItem{
+			Encryption: &runtimePubsub.Encryption{CryptoComponent: "mycrypto", KeyName: "mykey"},
+		}
+		_, err := p.encodeMessage(context.Background(), ps, []byte("hello"), map[string]string{"rawPayload": "true"})
+		require.Error(t, err)
+	})
+
+	t.Run("crypto component not found", func(t *testing.T) {
+		ps := compstore.PubsubItem{
+			Encryption: &runtimePubsub.Encryption{CryptoComponent: "mycrypto", KeyName: "mykey"},
+		}
+		_, err := p.encodeMessage(context.Background(), ps, event, nil)
+		require.ErrorContains(t, err, "mycrypto")
+	})
+}
+
+func TestDecodeCloudEvent(t *testing.T) {
+	p := &pubsub{compStore: compstore.New()}
+
+	t.Run("not encoded", func(t *testing.T) {
+		cloudEvent := map[string]interface{}{"data": "hello"}
+		decoded, err := p.decodeCloudEvent(context.Background(), compstore.PubsubItem{}, cloudEvent)
+		require.NoError(t, err)
+		assert.False(t, decoded)
+		assert.Equal(t, map[string]interface{}{"data": "hello"}, cloudEvent)
+	})
+
+	t.Run("encrypted event without encryption enabled", func(t *testing.T) {
+		cloudEvent := map[string]interface{}{
+			runtimePubsub.EncryptionKeyExtension: "mykey",
+			"data_base64":                        "aGVsbG8=",
+		}
+		_, err := p.decodeCloudEvent(context.Background(), compstore.PubsubItem{}, cloudEvent)
+		require.Error(t, err)
+	})
+
+	t.Run("claim check without claim check enabled", func(t *testing.T) {
+		cloudEvent := map[string]interface{}{
+			runtimePubsub.ClaimCheckStoreExtension: "mystore",
+			runtimePubsub.ClaimCheckKeyExtension:   "dapr-claim-check||1",
+		}
+		_, err := p.decodeCloudEvent(context.Background(), compstore.PubsubItem{}, cloudEvent)
+		require.ErrorContains(t, err, "claim-check pattern is not enabled")
+	})
+}
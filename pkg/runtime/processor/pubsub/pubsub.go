@@ -30,6 +30,7 @@ import (
 	"github.com/dapr/dapr/pkg/config"
 	diag "github.com/dapr/dapr/pkg/diagnostics"
 	"github.com/dapr/dapr/pkg/grpc/manager"
+	invokev1 "github.com/dapr/dapr/pkg/messaging/v1"
 	"github.com/dapr/dapr/pkg/modes"
 	"github.com/dapr/dapr/pkg/outbox"
 	operatorv1 "github.com/dapr/dapr/pkg/proto/operator/v1"
@@ -78,6 +79,11 @@ type Options struct {
 	GRPC           *manager.Manager
 	Channels       *channels.Channels
 	OperatorClient operatorv1.OperatorClient
+
+	PublishDeduplication config.PublishDeduplicationSpec
+
+	// MaxRequestBodySize is the maximum size, in bytes, of the data of the compressed messages once decompressed.
+	MaxRequestBodySize int64
 }
 
 type pubsub struct {
@@ -97,11 +103,17 @@ type pubsub struct {
 	channels       *channels.Channels
 	operatorClient operatorv1.OperatorClient
 
+	maxRequestBodySize int64
+
 	lock        sync.RWMutex
 	subscribing bool
 
 	topicCancels map[string]context.CancelFunc
 	outbox       outbox.Outbox
+	dedup        *rtpubsub.Deduplicator
+
+	backlogLock sync.Mutex
+	backlogs    map[string]*topicBacklog
 }
 
 type subscribedMessage struct {
@@ -111,6 +123,8 @@ type subscribedMessage struct {
 	metadata   map[string]string
 	path       string
 	pubsub     string
+	// binding is set if the message is sent to an output binding instead of the app.
+	binding *rtpubsub.BindingRoute
 }
 
 func New(opts Options) *pubsub {
@@ -130,9 +144,22 @@ func New(opts Options) *pubsub {
 		channels:       opts.Channels,
 		operatorClient: opts.OperatorClient,
 		topicCancels:   make(map[string]context.CancelFunc),
+
+		maxRequestBodySize: opts.MaxRequestBodySize,
 	}
 
 	ps.outbox = rtpubsub.NewOutbox(ps.Publish, opts.ComponentStore.GetPubSubComponent, opts.ComponentStore.GetStateStore, ExtractCloudEventProperty, opts.Namespace)
+
+	dedup, err := rtpubsub.NewDeduplicator(rtpubsub.DeduplicatorOptions{
+		AppID:           opts.ID,
+		Spec:            opts.PublishDeduplication,
+		GetStateStoreFn: opts.ComponentStore.GetStateStore,
+	})
+	if err != nil {
+		log.Errorf("Publish deduplication is disabled: %v", err)
+	}
+	ps.dedup = dedup
+
 	return ps
 }
 
@@ -160,6 +187,24 @@ func (p *pubsub) Init(ctx context.Context, comp compapi.Component) error {
 	}
 	properties["consumerID"] = consumerID
 
+	claimCheck, err := rtpubsub.NewClaimCheck(properties)
+	if err != nil {
+		diag.DefaultMonitoring.ComponentInitFailed(comp.Spec.Type, "init", comp.ObjectMeta.Name)
+		return rterrors.NewInit(rterrors.InitComponentFailure, fName, err)
+	}
+
+	compression, err := rtpubsub.NewCompression(properties)
+	if err != nil {
+		diag.DefaultMonitoring.ComponentInitFailed(comp.Spec.Type, "init", comp.ObjectMeta.Name)
+		return rterrors.NewInit(rterrors.InitComponentFailure, fName, err)
+	}
+
+	encryption, err := rtpubsub.NewEncryption(properties)
+	if err != nil {
+		diag.DefaultMonitoring.ComponentInitFailed(comp.Spec.Type, "init", comp.ObjectMeta.Name)
+		return rterrors.NewInit(rterrors.InitComponentFailure, fName, err)
+	}
+
 	err = pubSub.Init(ctx, contribpubsub.Metadata{Base: baseMetadata})
 	if err != nil {
 		diag.DefaultMonitoring.ComponentInitFailed(comp.Spec.Type, "init", comp.ObjectMeta.Name)
@@ -175,6 +220,9 @@ func (p *pubsub) Init(ctx context.Context, comp compapi.Component) error {
 		AllowedTopics:       scopes.GetAllowedTopics(properties),
 		ProtectedTopics:     scopes.GetProtectedTopics(properties),
 		NamespaceScoped:     meta.ContainsNamespace(comp.Spec.Metadata),
+		ClaimCheck:          claimCheck,
+		Compression:         compression,
+		Encryption:          encryption,
 	})
 	diag.DefaultMonitoring.ComponentInitialized(comp.Spec.Type)
 
@@ -216,21 +264,44 @@ func (p *pubsub) Outbox() outbox.Outbox {
 // findMatchingRoute selects the path based on routing rules. If there are
 // no matching rules, the route-level path is used.
 func findMatchingRoute(rules []*rtpubsub.Rule, cloudEvent interface{}) (path string, shouldProcess bool, err error) {
-	hasRules := len(rules) > 0
-	if hasRules {
-		data := map[string]interface{}{
-			"event": cloudEvent,
-		}
-		rule, err := matchRoutingRule(rules, data)
-		if err != nil {
-			return "", false, err
-		}
-		if rule != nil {
-			return rule.Path, true, nil
-		}
+	rule, err := findMatchingRule(rules, cloudEvent)
+	if err != nil || rule == nil {
+		return "", false, err
+	}
+	return rule.Path, true, nil
+}
+
+// findMatchingRule selects the rule the event matches, if any.
+func findMatchingRule(rules []*rtpubsub.Rule, cloudEvent interface{}) (*rtpubsub.Rule, error) {
+	if len(rules) == 0 {
+		return nil, nil
+	}
+	data := map[string]interface{}{
+		"event": cloudEvent,
+	}
+	return matchRoutingRule(rules, data)
+}
+
+// transformCloudEvent replaces the data of the CloudEvent with the result of the transform expression of the rule it matched.
+// It returns the CloudEvent encoded as JSON.
+func transformCloudEvent(transform rtpubsub.TransformExpr, cloudEvent map[string]interface{}) ([]byte, error) {
+	res, err := transform.EvalJSON(map[string]interface{}{
+		"event": cloudEvent,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("error evaluating transform expression %s: %w", transform, err)
+	}
+
+	var data interface{}
+	err = json.Unmarshal(res, &data)
+	if err != nil {
+		return nil, fmt.Errorf("error decoding the result of transform expression %s: %w", transform, err)
 	}
 
-	return "", false, nil
+	cloudEvent[contribpubsub.DataField] = data
+	cloudEvent[contribpubsub.DataContentTypeField] = invokev1.JSONContentType
+	delete(cloudEvent, contribpubsub.DataBase64Field)
+	return json.Marshal(cloudEvent)
 }
 
 func matchRoutingRule(rules []*rtpubsub.Rule, data map[string]interface{}) (*rtpubsub.Rule, error) {
@@ -1868,6 +1868,51 @@ func TestFindMatchingRoute(t *testing.T) {
 	assert.True(t, shouldProcess)
 }
 
+func TestTransformCloudEvent(t *testing.T) {
+	t.Run("replaces the data of the event", func(t *testing.T) {
+		transform := &expr.Expr{}
+		require.NoError(t, transform.DecodeString(`{"orderId": event.data.id, "total": event.data.total}`))
+
+		cloudEvent := map[string]interface{}{
+			contribpubsub.IDField:              "1",
+			contribpubsub.DataContentTypeField: "application/json",
+			contribpubsub.DataField: map[string]interface{}{
+				"id":       "order1",
+				"total":    42,
+				"customer": map[string]interface{}{"name": "Alice"},
+			},
+		}
+		data, err := transformCloudEvent(transform, cloudEvent)
+		require.NoError(t, err)
+		assert.JSONEq(t, `{"id":"1","datacontenttype":"application/json","data":{"orderId":"order1","total":42}}`, string(data))
+		assert.Equal(t, map[string]interface{}{"orderId": "order1", "total": float64(42)}, cloudEvent[contribpubsub.DataField])
+	})
+
+	t.Run("replaces binary data", func(t *testing.T) {
+		transform := &expr.Expr{}
+		require.NoError(t, transform.DecodeString(`event.type`))
+
+		cloudEvent := map[string]interface{}{
+			contribpubsub.IDField:         "1",
+			contribpubsub.TypeField:       "order.created",
+			contribpubsub.DataBase64Field: "aGVsbG8=",
+		}
+		data, err := transformCloudEvent(transform, cloudEvent)
+		require.NoError(t, err)
+		assert.JSONEq(t, `{"id":"1","type":"order.created","datacontenttype":"application/json","data":"order.created"}`, string(data))
+	})
+
+	t.Run("error evaluating the expression", func(t *testing.T) {
+		transform := &expr.Expr{}
+		require.NoError(t, transform.DecodeString(`event.data.missing`))
+
+		_, err := transformCloudEvent(transform, map[string]interface{}{
+			contribpubsub.DataField: map[string]interface{}{},
+		})
+		require.Error(t, err)
+	})
+}
+
 func createRoutingRule(match, path string) (*runtimePubsub.Rule, error) {
 	var e *expr.Expr
 	matchTrimmed := strings.TrimSpace(match)
@@ -20,6 +20,7 @@ import (
 
 	"google.golang.org/grpc"
 
+	"github.com/dapr/dapr/pkg/config"
 	"github.com/dapr/dapr/pkg/modes"
 	runtimev1pb "github.com/dapr/dapr/pkg/proto/runtime/v1"
 	"github.com/dapr/dapr/pkg/runtime/compstore"
@@ -89,11 +90,6 @@ func (p *pubsub) topicRoutes(ctx context.Context) (map[string]compstore.TopicRou
 
 	topicRoutes := make(map[string]compstore.TopicRoutes)
 
-	if p.channels.AppChannel() == nil {
-		log.Warn("app channel not initialized, make sure -app-port is specified if pubsub subscription is required")
-		return topicRoutes, nil
-	}
-
 	subscriptions, err := p.subscriptions(ctx)
 	if err != nil {
 		return nil, err
@@ -136,10 +132,11 @@ func (p *pubsub) subscriptions(ctx context.Context) ([]rtpubsub.Subscription, er
 		return subs, nil
 	}
 
-	appChannel := p.channels.AppChannel()
+	appChannel := p.channels.AppChannelFor(config.AppChannelRoutePubSub)
 	if appChannel == nil {
 		log.Warn("app channel not initialized, make sure -app-port is specified if pubsub subscription is required")
-		return nil, nil
+		// Subscriptions that route all events to output bindings don't need the app
+		return bindingOnlySubscriptions(p.declarativeSubscriptions(ctx)), nil
 	}
 
 	var (
@@ -192,6 +189,27 @@ func (p *pubsub) subscriptions(ctx context.Context) ([]rtpubsub.Subscription, er
 	return subscriptions, nil
 }
 
+// bindingOnlySubscriptions returns the subscriptions whose routing rules all send events to output bindings.
+func bindingOnlySubscriptions(subs []rtpubsub.Subscription) []rtpubsub.Subscription {
+	res := make([]rtpubsub.Subscription, 0, len(subs))
+	for _, s := range subs {
+		if len(s.Rules) == 0 {
+			continue
+		}
+		bindingOnly := true
+		for _, r := range s.Rules {
+			if r.Binding == nil {
+				bindingOnly = false
+				break
+			}
+		}
+		if bindingOnly {
+			res = append(res, s)
+		}
+	}
+	return res
+}
+
 // Refer for state store api decision
 // https://github.com/dapr/dapr/blob/master/docs/decision_records/api/API-008-multi-state-store-api-design.md
 func (p *pubsub) declarativeSubscriptions(ctx context.Context) []rtpubsub.Subscription {
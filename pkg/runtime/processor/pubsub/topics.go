@@ -92,7 +92,7 @@ func (p *pubsub) subscribeTopic(name, topic string, route compstore.TopicRouteEl
 		if err != nil {
 			log.Errorf("error deserializing pubsub metadata: %s", err)
 			if route.DeadLetterTopic != "" {
-				if dlqErr := p.sendToDeadLetter(ctx, name, msg, route.DeadLetterTopic); dlqErr == nil {
+				if dlqErr := p.sendToDeadLetter(ctx, name, msg, route.DeadLetterTopic, err.Error()); dlqErr == nil {
 					// dlq has been configured and message is successfully sent to dlq.
 					diag.DefaultComponentMonitoring.PubsubIngressEvent(ctx, name, strings.ToLower(string(contribpubsub.Drop)), msgTopic, 0)
 					return nil
@@ -110,7 +110,7 @@ func (p *pubsub) subscribeTopic(name, topic string, route compstore.TopicRouteEl
 			if err != nil {
 				log.Errorf("error serializing cloud event in pubsub %s and topic %s: %s", name, msgTopic, err)
 				if route.DeadLetterTopic != "" {
-					if dlqErr := p.sendToDeadLetter(ctx, name, msg, route.DeadLetterTopic); dlqErr == nil {
+					if dlqErr := p.sendToDeadLetter(ctx, name, msg, route.DeadLetterTopic, err.Error()); dlqErr == nil {
 						// dlq has been configured and message is successfully sent to dlq.
 						diag.DefaultComponentMonitoring.PubsubIngressEvent(ctx, name, strings.ToLower(string(contribpubsub.Drop)), msgTopic, 0)
 						return nil
@@ -124,7 +124,26 @@ func (p *pubsub) subscribeTopic(name, topic string, route compstore.TopicRouteEl
 			if err != nil {
 				log.Errorf("error deserializing cloud event in pubsub %s and topic %s: %s", name, msgTopic, err)
 				if route.DeadLetterTopic != "" {
-					if dlqErr := p.sendToDeadLetter(ctx, name, msg, route.DeadLetterTopic); dlqErr == nil {
+					if dlqErr := p.sendToDeadLetter(ctx, name, msg, route.DeadLetterTopic, err.Error()); dlqErr == nil {
+						// dlq has been configured and message is successfully sent to dlq.
+						diag.DefaultComponentMonitoring.PubsubIngressEvent(ctx, name, strings.ToLower(string(contribpubsub.Drop)), msgTopic, 0)
+						return nil
+					}
+				}
+				diag.DefaultComponentMonitoring.PubsubIngressEvent(ctx, name, strings.ToLower(string(contribpubsub.Retry)), msgTopic, 0)
+				return err
+			}
+		}
+
+		if decoded, decodeErr := p.decodeCloudEvent(ctx, pubSub, cloudEvent); decoded || decodeErr != nil {
+			err = decodeErr
+			if err == nil {
+				data, err = json.Marshal(cloudEvent)
+			}
+			if err != nil {
+				log.Errorf("error decoding cloud event %v in pubsub %s and topic %s: %s", cloudEvent[contribpubsub.IDField], name, msgTopic, err)
+				if route.DeadLetterTopic != "" {
+					if dlqErr := p.sendToDeadLetter(ctx, name, msg, route.DeadLetterTopic, err.Error()); dlqErr == nil {
 						// dlq has been configured and message is successfully sent to dlq.
 						diag.DefaultComponentMonitoring.PubsubIngressEvent(ctx, name, strings.ToLower(string(contribpubsub.Drop)), msgTopic, 0)
 						return nil
@@ -140,16 +159,16 @@ func (p *pubsub) subscribeTopic(name, topic string, route compstore.TopicRouteEl
 			diag.DefaultComponentMonitoring.PubsubIngressEvent(ctx, name, strings.ToLower(string(contribpubsub.Drop)), msgTopic, 0)
 
 			if route.DeadLetterTopic != "" {
-				_ = p.sendToDeadLetter(ctx, name, msg, route.DeadLetterTopic)
+				_ = p.sendToDeadLetter(ctx, name, msg, route.DeadLetterTopic, deadLetterReasonExpired)
 			}
 			return nil
 		}
 
-		routePath, shouldProcess, err := findMatchingRoute(route.Rules, cloudEvent)
+		rule, err := findMatchingRule(route.Rules, cloudEvent)
 		if err != nil {
 			log.Errorf("error finding matching route for event %v in pubsub %s and topic %s: %s", cloudEvent[contribpubsub.IDField], name, msgTopic, err)
 			if route.DeadLetterTopic != "" {
-				if dlqErr := p.sendToDeadLetter(ctx, name, msg, route.DeadLetterTopic); dlqErr == nil {
+				if dlqErr := p.sendToDeadLetter(ctx, name, msg, route.DeadLetterTopic, err.Error()); dlqErr == nil {
 					// dlq has been configured and message is successfully sent to dlq.
 					diag.DefaultComponentMonitoring.PubsubIngressEvent(ctx, name, strings.ToLower(string(contribpubsub.Drop)), msgTopic, 0)
 					return nil
@@ -158,28 +177,47 @@ func (p *pubsub) subscribeTopic(name, topic string, route compstore.TopicRouteEl
 			diag.DefaultComponentMonitoring.PubsubIngressEvent(ctx, name, strings.ToLower(string(contribpubsub.Retry)), msgTopic, 0)
 			return err
 		}
-		if !shouldProcess {
+		if rule == nil {
 			// The event does not match any route specified so ignore it.
 			log.Debugf("no matching route for event %v in pubsub %s and topic %s; skipping", cloudEvent[contribpubsub.IDField], name, msgTopic)
 			diag.DefaultComponentMonitoring.PubsubIngressEvent(ctx, name, strings.ToLower(string(contribpubsub.Drop)), msgTopic, 0)
 			if route.DeadLetterTopic != "" {
-				_ = p.sendToDeadLetter(ctx, name, msg, route.DeadLetterTopic)
+				_ = p.sendToDeadLetter(ctx, name, msg, route.DeadLetterTopic, deadLetterReasonNoRoute)
 			}
 			return nil
 		}
 
+		if rule.Transform != nil {
+			data, err = transformCloudEvent(rule.Transform, cloudEvent)
+			if err != nil {
+				log.Errorf("error transforming event %v in pubsub %s and topic %s: %s", cloudEvent[contribpubsub.IDField], name, msgTopic, err)
+				if route.DeadLetterTopic != "" {
+					if dlqErr := p.sendToDeadLetter(ctx, name, msg, route.DeadLetterTopic, err.Error()); dlqErr == nil {
+						// dlq has been configured and message is successfully sent to dlq.
+						diag.DefaultComponentMonitoring.PubsubIngressEvent(ctx, name, strings.ToLower(string(contribpubsub.Drop)), msgTopic, 0)
+						return nil
+					}
+				}
+				diag.DefaultComponentMonitoring.PubsubIngressEvent(ctx, name, strings.ToLower(string(contribpubsub.Retry)), msgTopic, 0)
+				return err
+			}
+		}
+
 		sm := &subscribedMessage{
 			cloudEvent: cloudEvent,
 			data:       data,
 			topic:      msgTopic,
 			metadata:   msg.Metadata,
-			path:       routePath,
+			path:       rule.Path,
 			pubsub:     name,
+			binding:    rule.Binding,
 		}
 		policyRunner := resiliency.NewRunner[any](ctx, policyDef)
 		_, err = policyRunner(func(ctx context.Context) (any, error) {
 			var pErr error
-			if p.isHTTP {
+			if sm.binding != nil {
+				pErr = p.publishMessageBinding(ctx, sm)
+			} else if p.isHTTP {
 				pErr = p.publishMessageHTTP(ctx, sm)
 			} else {
 				pErr = p.publishMessageGRPC(ctx, sm)
@@ -190,7 +228,7 @@ func (p *pubsub) subscribeTopic(name, topic string, route compstore.TopicRouteEl
 			} else if errors.Is(pErr, runtimePubsub.ErrMessageDropped) {
 				// send dropped message to dead letter queue if configured
 				if route.DeadLetterTopic != "" {
-					derr := p.sendToDeadLetter(ctx, name, msg, route.DeadLetterTopic)
+					derr := p.sendToDeadLetter(ctx, name, msg, route.DeadLetterTopic, deadLetterReasonDropped)
 					if derr != nil {
 						log.Warnf("failed to send dropped message to dead letter queue for topic %s: %v", msgTopic, derr)
 					}
@@ -207,7 +245,7 @@ func (p *pubsub) subscribeTopic(name, topic string, route compstore.TopicRouteEl
 			if route.DeadLetterTopic == "" {
 				return err
 			}
-			_ = p.sendToDeadLetter(ctx, name, msg, route.DeadLetterTopic)
+			_ = p.sendToDeadLetter(ctx, name, msg, route.DeadLetterTopic, err.Error())
 			return nil
 		}
 		return err
@@ -289,9 +327,10 @@ func topicKey(componentName, topicName string) string {
 	return componentName + "||" + topicName
 }
 
-func (p *pubsub) sendToDeadLetter(ctx context.Context, name string, msg *contribpubsub.NewMessage, deadLetterTopic string) error {
+// sendToDeadLetter sends the message to the dead-letter topic, with the reason it couldn't be delivered.
+func (p *pubsub) sendToDeadLetter(ctx context.Context, name string, msg *contribpubsub.NewMessage, deadLetterTopic string, reason string) error {
 	req := &contribpubsub.PublishRequest{
-		Data:        msg.Data,
+		Data:        deadLetterData(ctx, msg.Data, msg.Topic, reason),
 		PubsubName:  name,
 		Topic:       deadLetterTopic,
 		Metadata:    msg.Metadata,
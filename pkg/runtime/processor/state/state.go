@@ -116,6 +116,9 @@ func (s *state) Init(ctx context.Context, comp compapi.Component) error {
 			wrapError := fmt.Errorf("failed to save lock keyprefix: %s", err.Error())
 			return rterrors.NewInit(rterrors.InitComponentFailure, fName, wrapError)
 		}
+		if _, ok := compstate.GetChangeFeeder(store); !ok && compstate.IsChangeFeedEnabled(comp.ObjectMeta.Name) {
+			log.Warnf("Change feed is enabled for state store %s, but the state store doesn't support change feeds", comp.ObjectMeta.Name)
+		}
 
 		s.outbox.AddOrUpdateOutbox(comp)
 
@@ -25,4 +25,7 @@ type Adapter interface {
 	Publish(context.Context, *contribPubsub.PublishRequest) error
 	BulkPublish(context.Context, *contribPubsub.BulkPublishRequest) (contribPubsub.BulkPublishResponse, error)
 	Outbox() outbox.Outbox
+	// TopicBacklog returns the number of messages waiting to be delivered on the topic, if the pub/sub component reports it.
+	// It doesn't block on the component: the value returned is the last one reported, so it can be a few seconds old, and no value is returned until the component has reported it once.
+	TopicBacklog(ctx context.Context, pubsubName, topic string) (int64, bool)
 }
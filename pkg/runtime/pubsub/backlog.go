@@ -0,0 +1,27 @@
+/*
+Copyright 2023 The Dapr Authors
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package pubsub
+
+import (
+	"context"
+)
+
+// BacklogHeader is the response header (or gRPC metadata key) with the backlog of the topic after a publish.
+// Producers can use it to slow down when consumers are falling behind.
+const BacklogHeader = "dapr-pubsub-backlog"
+
+// BacklogReporter is implemented by pub/sub components whose broker exposes the number of messages waiting to be delivered on a topic.
+type BacklogReporter interface {
+	TopicBacklog(ctx context.Context, topic string) (int64, error)
+}
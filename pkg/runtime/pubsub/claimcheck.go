@@ -0,0 +1,170 @@
+/*
+Copyright 2023 The Dapr Authors
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package pubsub
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+
+	contribMetadata "github.com/dapr/components-contrib/metadata"
+	contribPubsub "github.com/dapr/components-contrib/pubsub"
+	"github.com/dapr/components-contrib/state"
+)
+
+const (
+	// Metadata properties of pubsub components that enable the claim-check pattern.
+	claimCheckStateStoreKey = "claimCheckStateStore"
+	claimCheckThresholdKey  = "claimCheckThreshold"
+	claimCheckTTLKey        = "claimCheckTTL"
+
+	// ClaimCheckStoreExtension is the CloudEvent extension that contains the name of the state store where the data of the event is stored.
+	ClaimCheckStoreExtension = "daprclaimcheckstore"
+	// ClaimCheckKeyExtension is the CloudEvent extension that contains the key of the data of the event in the state store.
+	ClaimCheckKeyExtension = "daprclaimcheckkey"
+
+	claimCheckStatePrefix = "dapr-claim-check||"
+
+	// Default size of the data, in bytes, above which events are stored in the state store.
+	defaultClaimCheckThreshold = 256 * 1024
+	// Default time the data of events is kept in the state store.
+	defaultClaimCheckTTL = 24 * time.Hour
+)
+
+// ClaimCheck contains the options for the claim-check pattern: the data of messages that are too large for the broker is stored in a state store, and only a reference to it is published.
+// The subscribing sidecar retrieves the data from the state store before delivering the message.
+type ClaimCheck struct {
+	// Name of the state store where the data is stored.
+	StateStore string
+	// Size of the serialized data, in bytes, above which it's stored in the state store.
+	Threshold int
+	// Time the data is kept in the state store.
+	// The data is not deleted after the message is delivered, since it can be delivered to multiple subscribers.
+	TTL time.Duration
+}
+
+// NewClaimCheck returns the claim-check options from the metadata of a pubsub component.
+// It returns nil if the claim-check pattern is not enabled for the component.
+func NewClaimCheck(properties map[string]string) (*ClaimCheck, error) {
+	stateStore := strings.TrimSpace(properties[claimCheckStateStoreKey])
+	if stateStore == "" {
+		return nil, nil
+	}
+
+	c := &ClaimCheck{
+		StateStore: stateStore,
+		Threshold:  defaultClaimCheckThreshold,
+		TTL:        defaultClaimCheckTTL,
+	}
+
+	if v := strings.TrimSpace(properties[claimCheckThresholdKey]); v != "" {
+		threshold, err := strconv.Atoi(v)
+		if err != nil || threshold < 0 {
+			return nil, fmt.Errorf("invalid value for metadata property '%s': must be a non-negative number of bytes", claimCheckThresholdKey)
+		}
+		c.Threshold = threshold
+	}
+
+	if v := strings.TrimSpace(properties[claimCheckTTLKey]); v != "" {
+		ttl, err := time.ParseDuration(v)
+		if err != nil || ttl <= 0 {
+			return nil, fmt.Errorf("invalid value for metadata property '%s': must be a positive duration", claimCheckTTLKey)
+		}
+		c.TTL = ttl
+	}
+
+	return c, nil
+}
+
+// IsClaimCheck returns true if the data of the CloudEvent is stored in a state store.
+func IsClaimCheck(cloudEvent map[string]interface{}) bool {
+	_, ok := cloudEvent[ClaimCheckKeyExtension]
+	return ok
+}
+
+// CheckIn stores the data of a CloudEvent in the state store if its size is above the threshold, and replaces it with a reference.
+// The state store must support TTLs, so the data expires.
+func (c *ClaimCheck) CheckIn(ctx context.Context, cloudEvent map[string]interface{}, store state.Store) error {
+	if IsClaimCheck(cloudEvent) {
+		return errors.New("the data of the event is already stored in a state store")
+	}
+	if !state.FeatureTTL.IsPresent(store.Features()) {
+		return fmt.Errorf("state store %s used to store the data of messages does not support TTLs", c.StateStore)
+	}
+
+	data, err := marshalCloudEventData(cloudEvent)
+	if err != nil {
+		return err
+	}
+	if len(data) <= c.Threshold {
+		return nil
+	}
+
+	uid, err := uuid.NewRandom()
+	if err != nil {
+		return err
+	}
+	key := claimCheckStatePrefix + uid.String()
+
+	err = store.Set(ctx, &state.SetRequest{
+		Key:   key,
+		Value: data,
+		Metadata: map[string]string{
+			contribMetadata.TTLMetadataKey: strconv.Itoa(max(int(c.TTL.Seconds()), 1)),
+		},
+	})
+	if err != nil {
+		return fmt.Errorf("failed to save the data of the event in state store %s: %w", c.StateStore, err)
+	}
+
+	delete(cloudEvent, contribPubsub.DataField)
+	delete(cloudEvent, contribPubsub.DataBase64Field)
+	cloudEvent[ClaimCheckStoreExtension] = c.StateStore
+	cloudEvent[ClaimCheckKeyExtension] = key
+	return nil
+}
+
+// CheckOut retrieves the data of a CloudEvent from the state store.
+// Events are delivered by the broker, so their extensions can't be trusted: the data is only read from the state store configured for the component, and from the keys written by CheckIn.
+func (c *ClaimCheck) CheckOut(ctx context.Context, cloudEvent map[string]interface{}, store state.Store) error {
+	storeName, _ := cloudEvent[ClaimCheckStoreExtension].(string)
+	key, _ := cloudEvent[ClaimCheckKeyExtension].(string)
+	if storeName != c.StateStore {
+		return fmt.Errorf("the data of the event is stored in state store '%s', but the pubsub component uses state store '%s'", storeName, c.StateStore)
+	}
+	if !strings.HasPrefix(key, claimCheckStatePrefix) || len(key) == len(claimCheckStatePrefix) {
+		return fmt.Errorf("invalid value for the CloudEvent extension '%s'", ClaimCheckKeyExtension)
+	}
+
+	res, err := store.Get(ctx, &state.GetRequest{Key: key})
+	if err != nil {
+		return fmt.Errorf("failed to retrieve the data of the event from state store %s: %w", storeName, err)
+	}
+	if res == nil || len(res.Data) == 0 {
+		return fmt.Errorf("the data of the event was not found in state store %s, and it may have expired", storeName)
+	}
+
+	err = unmarshalCloudEventData(cloudEvent, res.Data)
+	if err != nil {
+		return err
+	}
+	delete(cloudEvent, ClaimCheckStoreExtension)
+	delete(cloudEvent, ClaimCheckKeyExtension)
+	return nil
+}
@@ -0,0 +1,176 @@
+/*
+Copyright 2023 The Dapr Authors
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package pubsub
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	contribMetadata "github.com/dapr/components-contrib/metadata"
+	contribPubsub "github.com/dapr/components-contrib/pubsub"
+	"github.com/dapr/components-contrib/state"
+	daprt "github.com/dapr/dapr/pkg/testing"
+)
+
+// claimCheckStore stores values as raw bytes, like most state stores do with []byte values.
+type claimCheckStore struct {
+	*daprt.FakeStateStore
+	items    map[string][]byte
+	metadata map[string]map[string]string
+}
+
+func newClaimCheckStore() *claimCheckStore {
+	return &claimCheckStore{
+		FakeStateStore: daprt.NewFakeStateStore(),
+		items:          map[string][]byte{},
+		metadata:       map[string]map[string]string{},
+	}
+}
+
+func (s *claimCheckStore) Features() []state.Feature {
+	return []state.Feature{state.FeatureTTL}
+}
+
+func (s *claimCheckStore) Set(ctx context.Context, req *state.SetRequest) error {
+	s.items[req.Key] = req.Value.([]byte)
+	s.metadata[req.Key] = req.Metadata
+	return nil
+}
+
+func (s *claimCheckStore) Get(ctx context.Context, req *state.GetRequest) (*state.GetResponse, error) {
+	return &state.GetResponse{Data: s.items[req.Key]}, nil
+}
+
+func TestNewClaimCheck(t *testing.T) {
+	t.Run("not enabled", func(t *testing.T) {
+		c, err := NewClaimCheck(map[string]string{})
+		require.NoError(t, err)
+		assert.Nil(t, c)
+	})
+
+	t.Run("defaults", func(t *testing.T) {
+		c, err := NewClaimCheck(map[string]string{
+			"claimCheckStateStore": "mystore",
+		})
+		require.NoError(t, err)
+		assert.Equal(t, &ClaimCheck{StateStore: "mystore", Threshold: 256 * 1024, TTL: 24 * time.Hour}, c)
+	})
+
+	t.Run("custom options", func(t *testing.T) {
+		c, err := NewClaimCheck(map[string]string{
+			"claimCheckStateStore": "mystore",
+			"claimCheckThreshold":  "1000",
+			"claimCheckTTL":        "1h",
+		})
+		require.NoError(t, err)
+		assert.Equal(t, &ClaimCheck{StateStore: "mystore", Threshold: 1000, TTL: time.Hour}, c)
+	})
+
+	t.Run("invalid threshold", func(t *testing.T) {
+		_, err := NewClaimCheck(map[string]string{
+			"claimCheckStateStore": "mystore",
+			"claimCheckThreshold":  "big",
+		})
+		require.Error(t, err)
+	})
+
+	t.Run("invalid TTL", func(t *testing.T) {
+		_, err := NewClaimCheck(map[string]string{
+			"claimCheckStateStore": "mystore",
+			"claimCheckTTL":        "0s",
+		})
+		require.Error(t, err)
+	})
+}
+
+func TestClaimCheck(t *testing.T) {
+	store := newClaimCheckStore()
+	c := &ClaimCheck{StateStore: "mystore", Threshold: 100, TTL: time.Hour}
+	message := strings.Repeat("hello ", 100)
+
+	t.Run("round trip", func(t *testing.T) {
+		cloudEvent := map[string]interface{}{
+			contribPubsub.IDField:   "1",
+			contribPubsub.DataField: map[string]interface{}{"message": message},
+		}
+
+		require.NoError(t, c.CheckIn(context.Background(), cloudEvent, store))
+		assert.True(t, IsClaimCheck(cloudEvent))
+		assert.Equal(t, "mystore", cloudEvent[ClaimCheckStoreExtension])
+		assert.NotContains(t, cloudEvent, contribPubsub.DataField)
+
+		key := cloudEvent[ClaimCheckKeyExtension].(string)
+		require.Contains(t, store.items, key)
+		assert.Equal(t, "3600", store.metadata[key][contribMetadata.TTLMetadataKey])
+
+		require.NoError(t, c.CheckOut(context.Background(), cloudEvent, store))
+		assert.False(t, IsClaimCheck(cloudEvent))
+		assert.Equal(t, map[string]interface{}{
+			contribPubsub.IDField:   "1",
+			contribPubsub.DataField: map[string]interface{}{"message": message},
+		}, cloudEvent)
+	})
+
+	t.Run("below threshold", func(t *testing.T) {
+		cloudEvent := map[string]interface{}{
+			contribPubsub.DataField: "hello",
+		}
+
+		require.NoError(t, c.CheckIn(context.Background(), cloudEvent, store))
+		assert.False(t, IsClaimCheck(cloudEvent))
+		assert.Equal(t, "hello", cloudEvent[contribPubsub.DataField])
+	})
+
+	t.Run("state store without TTLs", func(t *testing.T) {
+		cloudEvent := map[string]interface{}{
+			contribPubsub.DataField: message,
+		}
+
+		err := c.CheckIn(context.Background(), cloudEvent, daprt.NewFakeStateStore())
+		require.ErrorContains(t, err, "does not support TTLs")
+		assert.False(t, IsClaimCheck(cloudEvent))
+	})
+
+	t.Run("expired data", func(t *testing.T) {
+		err := c.CheckOut(context.Background(), map[string]interface{}{
+			ClaimCheckStoreExtension: "mystore",
+			ClaimCheckKeyExtension:   "dapr-claim-check||missing",
+		}, store)
+		require.ErrorContains(t, err, "may have expired")
+	})
+
+	t.Run("other state store", func(t *testing.T) {
+		err := c.CheckOut(context.Background(), map[string]interface{}{
+			ClaimCheckStoreExtension: "otherstore",
+			ClaimCheckKeyExtension:   "dapr-claim-check||missing",
+		}, store)
+		require.ErrorContains(t, err, "otherstore")
+	})
+
+	t.Run("key not written by the claim check", func(t *testing.T) {
+		store.items["myapp||secret"] = []byte(`"secret"`)
+		for _, key := range []string{"myapp||secret", "dapr-claim-check||", ""} {
+			err := c.CheckOut(context.Background(), map[string]interface{}{
+				ClaimCheckStoreExtension: "mystore",
+				ClaimCheckKeyExtension:   key,
+			}, store)
+			require.Error(t, err, key)
+		}
+	})
+}
@@ -14,6 +14,9 @@ limitations under the License.
 package pubsub
 
 import (
+	"encoding/json"
+	"fmt"
+
 	"github.com/mitchellh/mapstructure"
 
 	contribContenttype "github.com/dapr/components-contrib/contenttype"
@@ -33,12 +36,24 @@ type CloudEvent struct {
 	Source          string `mapstructure:"cloudevent.source"`
 	Type            string `mapstructure:"cloudevent.type"`
 	TraceParent     string `mapstructure:"cloudevent.traceparent"`
+	Baggage         string `mapstructure:"cloudevent.baggage"`
 }
 
+// BaggageField is the CloudEvent extension that carries the W3C baggage of the publisher to the subscribers.
+const BaggageField = "baggage"
+
 // NewCloudEvent encapsulates the creation of a Dapr cloudevent from an existing cloudevent or a raw payload.
 func NewCloudEvent(req *CloudEvent, metadata map[string]string) (map[string]interface{}, error) {
 	if contribContenttype.IsCloudEventContentType(req.DataContentType) {
-		return contribPubsub.FromCloudEvent(req.Data, req.Topic, req.Pubsub, req.TraceID, req.TraceState)
+		envelope, err := contribPubsub.FromCloudEvent(req.Data, req.Topic, req.Pubsub, req.TraceID, req.TraceState)
+		if err != nil {
+			return nil, err
+		}
+		// the baggage of a cloudevent created by the app is only set if the app didn't set it
+		if _, ok := envelope[BaggageField]; !ok && req.Baggage != "" {
+			envelope[BaggageField] = req.Baggage
+		}
+		return envelope, nil
 	}
 
 	// certain metadata beginning with "cloudevent." are considered overrides to the cloudevent envelope
@@ -52,6 +67,41 @@ func NewCloudEvent(req *CloudEvent, metadata map[string]string) (map[string]inte
 	if req.TraceParent != "" {
 		req.TraceID = req.TraceParent
 	}
-	return contribPubsub.NewCloudEventsEnvelope(req.ID, req.Source, req.Type,
-		"", req.Topic, req.Pubsub, req.DataContentType, req.Data, req.TraceID, req.TraceState), nil
+	envelope := contribPubsub.NewCloudEventsEnvelope(req.ID, req.Source, req.Type,
+		"", req.Topic, req.Pubsub, req.DataContentType, req.Data, req.TraceID, req.TraceState)
+	if req.Baggage != "" {
+		envelope[BaggageField] = req.Baggage
+	}
+	return envelope, nil
+}
+
+// marshalCloudEventData serializes the data of a CloudEvent, including both the data and data_base64 fields, so the type of the data is preserved by unmarshalCloudEventData.
+func marshalCloudEventData(cloudEvent map[string]interface{}) ([]byte, error) {
+	payload := make(map[string]interface{}, 1)
+	for _, k := range []string{contribPubsub.DataField, contribPubsub.DataBase64Field} {
+		if v, ok := cloudEvent[k]; ok {
+			payload[k] = v
+		}
+	}
+	b, err := json.Marshal(payload)
+	if err != nil {
+		return nil, fmt.Errorf("failed to serialize the data of the event: %w", err)
+	}
+	return b, nil
+}
+
+// unmarshalCloudEventData replaces the data of a CloudEvent with the data serialized by marshalCloudEventData.
+func unmarshalCloudEventData(cloudEvent map[string]interface{}, b []byte) error {
+	var payload map[string]interface{}
+	err := json.Unmarshal(b, &payload)
+	if err != nil {
+		return fmt.Errorf("failed to deserialize the data of the event: %w", err)
+	}
+
+	delete(cloudEvent, contribPubsub.DataField)
+	delete(cloudEvent, contribPubsub.DataBase64Field)
+	for k, v := range payload {
+		cloudEvent[k] = v
+	}
+	return nil
 }
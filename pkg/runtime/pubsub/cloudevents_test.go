@@ -122,6 +122,53 @@ func TestNewCloudEvent(t *testing.T) {
 		assert.Equal(t, "trace1", ce["traceid"].(string))
 		assert.Equal(t, "pubsub", ce["pubsubname"].(string))
 	})
+
+	t.Run("baggage", func(t *testing.T) {
+		ce, err := NewCloudEvent(&CloudEvent{
+			Data:    []byte("hello"),
+			Topic:   "topic1",
+			Pubsub:  "pubsub",
+			Baggage: "userId=alice",
+		}, map[string]string{})
+		require.NoError(t, err)
+		assert.Equal(t, "userId=alice", ce[BaggageField])
+
+		ce, err = NewCloudEvent(&CloudEvent{
+			Data:    []byte("hello"),
+			Topic:   "topic1",
+			Pubsub:  "pubsub",
+			Baggage: "userId=alice",
+		}, map[string]string{"cloudevent.baggage": "userId=bob"})
+		require.NoError(t, err)
+		assert.Equal(t, "userId=bob", ce[BaggageField])
+
+		ce, err = NewCloudEvent(&CloudEvent{
+			Data:   []byte("hello"),
+			Topic:  "topic1",
+			Pubsub: "pubsub",
+		}, map[string]string{})
+		require.NoError(t, err)
+		assert.NotContains(t, ce, BaggageField)
+	})
+
+	t.Run("custom cloudevent with baggage", func(t *testing.T) {
+		b, _ := json.Marshal(map[string]interface{}{
+			"specversion": "1.0",
+			"id":          "event",
+			"data":        "world",
+			"baggage":     "userId=bob",
+		})
+
+		ce, err := NewCloudEvent(&CloudEvent{
+			Data:            b,
+			DataContentType: "application/cloudevents+json",
+			Topic:           "topic1",
+			Pubsub:          "pubsub",
+			Baggage:         "userId=alice",
+		}, map[string]string{})
+		require.NoError(t, err)
+		assert.Equal(t, "userId=bob", ce[BaggageField])
+	})
 }
 
 func validUUID(u string) bool {
@@ -0,0 +1,195 @@
+/*
+Copyright 2023 The Dapr Authors
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package pubsub
+
+import (
+	"bytes"
+	"compress/gzip"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+
+	"github.com/klauspost/compress/zstd"
+
+	contribPubsub "github.com/dapr/components-contrib/pubsub"
+)
+
+const (
+	// Metadata properties of pubsub components that enable the compression of messages.
+	compressionAlgorithmKey = "compressionAlgorithm"
+	compressionThresholdKey = "compressionThreshold"
+
+	// ContentEncodingExtension is the CloudEvent extension that contains the algorithm used to compress the data of the event.
+	ContentEncodingExtension = "daprcontentencoding"
+
+	// CompressionGzip compresses the data of the events with gzip.
+	CompressionGzip = "gzip"
+	// CompressionZstd compresses the data of the events with zstd.
+	CompressionZstd = "zstd"
+
+	// Default size of the data, in bytes, above which events are compressed.
+	defaultCompressionThreshold = 1024
+	// Default maximum size of the decompressed data, in bytes, which is the default maximum size of request bodies.
+	defaultMaxDecompressedSize = 4 << 20
+)
+
+// Compression contains the options for compressing the data of the messages published to a pubsub component.
+// Compressed messages are decompressed by the delivering sidecar, regardless of the options of the component it receives them from.
+type Compression struct {
+	// Algorithm used to compress the data: "gzip" or "zstd".
+	Algorithm string
+	// Size of the serialized data, in bytes, above which events are compressed.
+	Threshold int
+}
+
+// NewCompression returns the compression options from the metadata of a pubsub component.
+// It returns nil if compression is not enabled for the component.
+func NewCompression(properties map[string]string) (*Compression, error) {
+	algorithm := strings.ToLower(strings.TrimSpace(properties[compressionAlgorithmKey]))
+	switch algorithm {
+	case "":
+		return nil, nil
+	case CompressionGzip, CompressionZstd:
+		// Valid
+	default:
+		return nil, fmt.Errorf("invalid value for metadata property '%s': compression algorithm '%s' is not supported", compressionAlgorithmKey, algorithm)
+	}
+
+	threshold := defaultCompressionThreshold
+	if v := strings.TrimSpace(properties[compressionThresholdKey]); v != "" {
+		var err error
+		threshold, err = strconv.Atoi(v)
+		if err != nil || threshold < 0 {
+			return nil, fmt.Errorf("invalid value for metadata property '%s': must be a non-negative number of bytes", compressionThresholdKey)
+		}
+	}
+
+	return &Compression{
+		Algorithm: algorithm,
+		Threshold: threshold,
+	}, nil
+}
+
+// IsCompressed returns true if the data of the CloudEvent is compressed.
+func IsCompressed(cloudEvent map[string]interface{}) bool {
+	_, ok := cloudEvent[ContentEncodingExtension]
+	return ok
+}
+
+// CompressCloudEvent compresses the data of a CloudEvent in place, if its size is above the threshold.
+// The compressed data is stored in the data_base64 field, and the algorithm is stored in the ContentEncodingExtension.
+func (c *Compression) CompressCloudEvent(cloudEvent map[string]interface{}) error {
+	if IsCompressed(cloudEvent) {
+		return errors.New("the data of the event is already compressed")
+	}
+
+	data, err := marshalCloudEventData(cloudEvent)
+	if err != nil {
+		return err
+	}
+	if len(data) <= c.Threshold {
+		return nil
+	}
+
+	var buf bytes.Buffer
+	var w io.WriteCloser
+	switch c.Algorithm {
+	case CompressionGzip:
+		w = gzip.NewWriter(&buf)
+	case CompressionZstd:
+		w, err = zstd.NewWriter(&buf)
+		if err != nil {
+			return fmt.Errorf("failed to compress the data of the event: %w", err)
+		}
+	default:
+		return fmt.Errorf("compression algorithm '%s' is not supported", c.Algorithm)
+	}
+	_, err = w.Write(data)
+	if err == nil {
+		err = w.Close()
+	}
+	if err != nil {
+		return fmt.Errorf("failed to compress the data of the event: %w", err)
+	}
+
+	delete(cloudEvent, contribPubsub.DataField)
+	cloudEvent[contribPubsub.DataBase64Field] = base64.StdEncoding.EncodeToString(buf.Bytes())
+	cloudEvent[ContentEncodingExtension] = c.Algorithm
+	return nil
+}
+
+// DecompressCloudEvent decompresses the data of a CloudEvent in place, using the algorithm in its ContentEncodingExtension.
+// Events are delivered by the broker, so the size of the decompressed data is limited to maxSize bytes, to protect from decompression bombs.
+// If maxSize is not positive, the default maximum size of request bodies is used.
+func DecompressCloudEvent(cloudEvent map[string]interface{}, maxSize int64) error {
+	if maxSize <= 0 {
+		maxSize = defaultMaxDecompressedSize
+	}
+	algorithm, _ := cloudEvent[ContentEncodingExtension].(string)
+	dataB64, ok := cloudEvent[contribPubsub.DataBase64Field].(string)
+	if !ok {
+		return errors.New("the compressed data of the event is missing")
+	}
+	compressed, err := base64.StdEncoding.DecodeString(dataB64)
+	if err != nil {
+		return fmt.Errorf("failed to decode the compressed data of the event: %w", err)
+	}
+
+	var data []byte
+	switch algorithm {
+	case CompressionGzip:
+		var r *gzip.Reader
+		r, err = gzip.NewReader(bytes.NewReader(compressed))
+		if err == nil {
+			data, err = readAllLimited(r, maxSize)
+		}
+	case CompressionZstd:
+		var r *zstd.Decoder
+		r, err = zstd.NewReader(bytes.NewReader(compressed),
+			zstd.WithDecoderConcurrency(1),
+			zstd.WithDecoderMaxMemory(uint64(maxSize)),
+		)
+		if err == nil {
+			data, err = readAllLimited(r, maxSize)
+			r.Close()
+		}
+	default:
+		return fmt.Errorf("invalid value for the CloudEvent extension '%s': compression algorithm '%s' is not supported", ContentEncodingExtension, algorithm)
+	}
+	if err != nil {
+		return fmt.Errorf("failed to decompress the data of the event: %w", err)
+	}
+
+	err = unmarshalCloudEventData(cloudEvent, data)
+	if err != nil {
+		return err
+	}
+	delete(cloudEvent, ContentEncodingExtension)
+	return nil
+}
+
+// readAllLimited reads r until EOF, and returns an error if it contains more than maxSize bytes.
+func readAllLimited(r io.Reader, maxSize int64) ([]byte, error) {
+	data, err := io.ReadAll(io.LimitReader(r, maxSize+1))
+	if err != nil {
+		return nil, err
+	}
+	if int64(len(data)) > maxSize {
+		return nil, fmt.Errorf("the decompressed data is larger than the maximum size of %d bytes", maxSize)
+	}
+	return data, nil
+}
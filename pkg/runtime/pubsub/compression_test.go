@@ -0,0 +1,151 @@
+/*
+Copyright 2023 The Dapr Authors
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package pubsub
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	contribPubsub "github.com/dapr/components-contrib/pubsub"
+)
+
+func TestNewCompression(t *testing.T) {
+	t.Run("not enabled", func(t *testing.T) {
+		c, err := NewCompression(map[string]string{})
+		require.NoError(t, err)
+		assert.Nil(t, c)
+	})
+
+	t.Run("default threshold", func(t *testing.T) {
+		c, err := NewCompression(map[string]string{
+			"compressionAlgorithm": "GZIP",
+		})
+		require.NoError(t, err)
+		assert.Equal(t, &Compression{Algorithm: CompressionGzip, Threshold: 1024}, c)
+	})
+
+	t.Run("custom threshold", func(t *testing.T) {
+		c, err := NewCompression(map[string]string{
+			"compressionAlgorithm": "zstd",
+			"compressionThreshold": "0",
+		})
+		require.NoError(t, err)
+		assert.Equal(t, &Compression{Algorithm: CompressionZstd, Threshold: 0}, c)
+	})
+
+	t.Run("invalid algorithm", func(t *testing.T) {
+		_, err := NewCompression(map[string]string{
+			"compressionAlgorithm": "brotli",
+		})
+		require.Error(t, err)
+	})
+
+	t.Run("invalid threshold", func(t *testing.T) {
+		_, err := NewCompression(map[string]string{
+			"compressionAlgorithm": "gzip",
+			"compressionThreshold": "-1",
+		})
+		require.Error(t, err)
+	})
+}
+
+func TestCompressCloudEvent(t *testing.T) {
+	message := strings.Repeat("hello ", 100)
+
+	for _, algorithm := range []string{CompressionGzip, CompressionZstd} {
+		t.Run(algorithm, func(t *testing.T) {
+			c := &Compression{Algorithm: algorithm, Threshold: 100}
+			cloudEvent := map[string]interface{}{
+				contribPubsub.IDField:              "1",
+				contribPubsub.DataContentTypeField: "application/json",
+				contribPubsub.DataField:            map[string]interface{}{"message": message},
+			}
+
+			require.NoError(t, c.CompressCloudEvent(cloudEvent))
+			assert.True(t, IsCompressed(cloudEvent))
+			assert.Equal(t, algorithm, cloudEvent[ContentEncodingExtension])
+			assert.NotContains(t, cloudEvent, contribPubsub.DataField)
+			assert.Less(t, len(cloudEvent[contribPubsub.DataBase64Field].(string)), len(message))
+
+			require.Error(t, c.CompressCloudEvent(cloudEvent))
+
+			require.NoError(t, DecompressCloudEvent(cloudEvent, 0))
+			assert.False(t, IsCompressed(cloudEvent))
+			assert.Equal(t, map[string]interface{}{
+				contribPubsub.IDField:              "1",
+				contribPubsub.DataContentTypeField: "application/json",
+				contribPubsub.DataField:            map[string]interface{}{"message": message},
+			}, cloudEvent)
+		})
+	}
+
+	t.Run("below threshold", func(t *testing.T) {
+		c := &Compression{Algorithm: CompressionGzip, Threshold: 1024}
+		cloudEvent := map[string]interface{}{
+			contribPubsub.DataField: "hello",
+		}
+
+		require.NoError(t, c.CompressCloudEvent(cloudEvent))
+		assert.False(t, IsCompressed(cloudEvent))
+		assert.Equal(t, map[string]interface{}{
+			contribPubsub.DataField: "hello",
+		}, cloudEvent)
+	})
+
+	t.Run("binary data", func(t *testing.T) {
+		c := &Compression{Algorithm: CompressionZstd, Threshold: 0}
+		cloudEvent := map[string]interface{}{
+			contribPubsub.DataBase64Field: "aGVsbG8=",
+		}
+
+		require.NoError(t, c.CompressCloudEvent(cloudEvent))
+		require.NoError(t, DecompressCloudEvent(cloudEvent, 0))
+		assert.Equal(t, map[string]interface{}{
+			contribPubsub.DataBase64Field: "aGVsbG8=",
+		}, cloudEvent)
+	})
+
+	t.Run("unsupported algorithm", func(t *testing.T) {
+		err := DecompressCloudEvent(map[string]interface{}{
+			ContentEncodingExtension:      "brotli",
+			contribPubsub.DataBase64Field: "aGVsbG8=",
+		}, 0)
+		require.Error(t, err)
+	})
+
+	for _, algorithm := range []string{CompressionGzip, CompressionZstd} {
+		t.Run("decompressed data too large with "+algorithm, func(t *testing.T) {
+			c := &Compression{Algorithm: algorithm, Threshold: 0}
+			cloudEvent := map[string]interface{}{
+				contribPubsub.DataField: strings.Repeat("a", 10_000),
+			}
+
+			require.NoError(t, c.CompressCloudEvent(cloudEvent))
+			err := DecompressCloudEvent(cloudEvent, 1_000)
+			require.ErrorContains(t, err, "larger than the maximum size")
+			assert.True(t, IsCompressed(cloudEvent))
+		})
+	}
+
+	t.Run("invalid data", func(t *testing.T) {
+		err := DecompressCloudEvent(map[string]interface{}{
+			ContentEncodingExtension:      CompressionGzip,
+			contribPubsub.DataBase64Field: "aGVsbG8=",
+		}, 0)
+		require.Error(t, err)
+	})
+}
@@ -0,0 +1,157 @@
+/*
+Copyright 2023 The Dapr Authors
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package pubsub
+
+import (
+	"context"
+	"strconv"
+	"time"
+
+	"github.com/hashicorp/golang-lru/v2/expirable"
+
+	contribMetadata "github.com/dapr/components-contrib/metadata"
+	contribPubsub "github.com/dapr/components-contrib/pubsub"
+	"github.com/dapr/components-contrib/state"
+	"github.com/dapr/dapr/pkg/config"
+	"github.com/dapr/dapr/utils"
+	"github.com/dapr/kit/logger"
+)
+
+const (
+	// MetadataIdempotencyKey is the metadata key of publish requests that contains the idempotency key set by the client.
+	MetadataIdempotencyKey = "idempotencyKey"
+
+	dedupStatePrefix = "dapr-publish-dedup"
+)
+
+var dedupLogger = logger.NewLogger("dapr.pubsub.dedup")
+
+// DeduplicatorOptions contains the options for NewDeduplicator.
+type DeduplicatorOptions struct {
+	AppID           string
+	Spec            config.PublishDeduplicationSpec
+	GetStateStoreFn func(string) (state.Store, bool)
+}
+
+// Deduplicator suppresses publish requests whose idempotency key was already published to the same topic.
+// Idempotency keys are remembered in a bounded in-memory cache and, optionally, in a state store.
+type Deduplicator struct {
+	appID           string
+	ttl             time.Duration
+	stateStore      string
+	getStateStoreFn func(string) (state.Store, bool)
+
+	cache *expirable.LRU[string, struct{}]
+	locks utils.KeyedMutex[string]
+}
+
+// NewDeduplicator returns a new Deduplicator.
+func NewDeduplicator(opts DeduplicatorOptions) (*Deduplicator, error) {
+	ttl, err := opts.Spec.GetTTL()
+	if err != nil {
+		return nil, err
+	}
+
+	return &Deduplicator{
+		appID:           opts.AppID,
+		ttl:             ttl,
+		stateStore:      opts.Spec.StateStore,
+		getStateStoreFn: opts.GetStateStoreFn,
+		cache:           expirable.NewLRU[string, struct{}](opts.Spec.GetMaxKeys(), nil, ttl),
+	}, nil
+}
+
+// Publish invokes publishFn, unless the request has an idempotency key that was already published to the same pubsub and topic.
+// It returns true if the request was a duplicate and publishFn was not invoked.
+// Keys are remembered only after a successful publish, so clients can retry failed requests with the same key.
+func (d *Deduplicator) Publish(ctx context.Context, req *contribPubsub.PublishRequest, publishFn func() error) (bool, error) {
+	idempotencyKey := req.Metadata[MetadataIdempotencyKey]
+	if d == nil || idempotencyKey == "" {
+		return false, publishFn()
+	}
+
+	key := d.appID + "||" + req.PubsubName + "||" + req.Topic + "||" + idempotencyKey
+
+	// Concurrent requests with the same key wait for each other, so only one of them is published
+	unlock := d.locks.Lock(key)
+	defer unlock()
+
+	if d.isPublished(ctx, key) {
+		return true, nil
+	}
+
+	err := publishFn()
+	if err != nil {
+		return false, err
+	}
+
+	d.markPublished(ctx, key)
+	return false, nil
+}
+
+func (d *Deduplicator) isPublished(ctx context.Context, key string) bool {
+	if d.cache.Contains(key) {
+		return true
+	}
+
+	store, ok := d.getStore()
+	if !ok {
+		return false
+	}
+
+	// If the state store can't be reached, the request is published: duplicates are preferred over lost messages
+	res, err := store.Get(ctx, &state.GetRequest{Key: dedupStatePrefix + "||" + key})
+	if err != nil {
+		dedupLogger.Warnf("Failed to look up idempotency key in state store %s: %v", d.stateStore, err)
+		return false
+	}
+	if res == nil || len(res.Data) == 0 {
+		return false
+	}
+
+	d.cache.Add(key, struct{}{})
+	return true
+}
+
+func (d *Deduplicator) markPublished(ctx context.Context, key string) {
+	d.cache.Add(key, struct{}{})
+
+	store, ok := d.getStore()
+	if !ok {
+		return
+	}
+
+	err := store.Set(ctx, &state.SetRequest{
+		Key:   dedupStatePrefix + "||" + key,
+		Value: time.Now().UTC().Format(time.RFC3339),
+		Metadata: map[string]string{
+			contribMetadata.TTLMetadataKey: strconv.Itoa(max(int(d.ttl.Seconds()), 1)),
+		},
+	})
+	if err != nil {
+		dedupLogger.Warnf("Failed to save idempotency key in state store %s: %v", d.stateStore, err)
+	}
+}
+
+func (d *Deduplicator) getStore() (state.Store, bool) {
+	if d.stateStore == "" || d.getStateStoreFn == nil {
+		return nil, false
+	}
+
+	store, ok := d.getStateStoreFn(d.stateStore)
+	if !ok {
+		dedupLogger.Warnf("State store %s for idempotency keys not found", d.stateStore)
+	}
+	return store, ok
+}
@@ -0,0 +1,192 @@
+/*
+Copyright 2023 The Dapr Authors
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package pubsub
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"sync/atomic"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	contribPubsub "github.com/dapr/components-contrib/pubsub"
+	"github.com/dapr/components-contrib/state"
+	"github.com/dapr/dapr/pkg/config"
+	daprt "github.com/dapr/dapr/pkg/testing"
+)
+
+func TestDeduplicator(t *testing.T) {
+	newReq := func(topic, key string) *contribPubsub.PublishRequest {
+		req := &contribPubsub.PublishRequest{
+			PubsubName: "mypubsub",
+			Topic:      topic,
+			Metadata:   map[string]string{},
+		}
+		if key != "" {
+			req.Metadata[MetadataIdempotencyKey] = key
+		}
+		return req
+	}
+
+	t.Run("invalid TTL", func(t *testing.T) {
+		_, err := NewDeduplicator(DeduplicatorOptions{
+			Spec: config.PublishDeduplicationSpec{TTL: "foo"},
+		})
+		require.Error(t, err)
+	})
+
+	t.Run("requests without idempotency key are always published", func(t *testing.T) {
+		d, err := NewDeduplicator(DeduplicatorOptions{AppID: "myapp"})
+		require.NoError(t, err)
+
+		var published atomic.Int32
+		publishFn := func() error {
+			published.Add(1)
+			return nil
+		}
+		for i := 0; i < 2; i++ {
+			duplicate, err := d.Publish(context.Background(), newReq("mytopic", ""), publishFn)
+			require.NoError(t, err)
+			assert.False(t, duplicate)
+		}
+		assert.Equal(t, int32(2), published.Load())
+	})
+
+	t.Run("duplicate requests are suppressed", func(t *testing.T) {
+		d, err := NewDeduplicator(DeduplicatorOptions{AppID: "myapp"})
+		require.NoError(t, err)
+
+		var published atomic.Int32
+		publishFn := func() error {
+			published.Add(1)
+			return nil
+		}
+
+		duplicate, err := d.Publish(context.Background(), newReq("mytopic", "key1"), publishFn)
+		require.NoError(t, err)
+		assert.False(t, duplicate)
+
+		duplicate, err = d.Publish(context.Background(), newReq("mytopic", "key1"), publishFn)
+		require.NoError(t, err)
+		assert.True(t, duplicate)
+
+		// Keys are scoped to the topic
+		duplicate, err = d.Publish(context.Background(), newReq("othertopic", "key1"), publishFn)
+		require.NoError(t, err)
+		assert.False(t, duplicate)
+
+		assert.Equal(t, int32(2), published.Load())
+	})
+
+	t.Run("failed requests can be retried", func(t *testing.T) {
+		d, err := NewDeduplicator(DeduplicatorOptions{AppID: "myapp"})
+		require.NoError(t, err)
+
+		duplicate, err := d.Publish(context.Background(), newReq("mytopic", "key1"), func() error {
+			return errors.New("simulated")
+		})
+		require.Error(t, err)
+		assert.False(t, duplicate)
+
+		var published bool
+		duplicate, err = d.Publish(context.Background(), newReq("mytopic", "key1"), func() error {
+			published = true
+			return nil
+		})
+		require.NoError(t, err)
+		assert.False(t, duplicate)
+		assert.True(t, published)
+	})
+
+	t.Run("concurrent requests are published once", func(t *testing.T) {
+		d, err := NewDeduplicator(DeduplicatorOptions{AppID: "myapp"})
+		require.NoError(t, err)
+
+		var published atomic.Int32
+		var wg sync.WaitGroup
+		for i := 0; i < 10; i++ {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				_, pErr := d.Publish(context.Background(), newReq("mytopic", "key1"), func() error {
+					published.Add(1)
+					return nil
+				})
+				assert.NoError(t, pErr)
+			}()
+		}
+		wg.Wait()
+		assert.Equal(t, int32(1), published.Load())
+	})
+
+	t.Run("keys are evicted when the cache is full", func(t *testing.T) {
+		d, err := NewDeduplicator(DeduplicatorOptions{
+			AppID: "myapp",
+			Spec:  config.PublishDeduplicationSpec{MaxKeys: 1},
+		})
+		require.NoError(t, err)
+
+		publishFn := func() error { return nil }
+		_, err = d.Publish(context.Background(), newReq("mytopic", "key1"), publishFn)
+		require.NoError(t, err)
+		_, err = d.Publish(context.Background(), newReq("mytopic", "key2"), publishFn)
+		require.NoError(t, err)
+
+		duplicate, err := d.Publish(context.Background(), newReq("mytopic", "key1"), publishFn)
+		require.NoError(t, err)
+		assert.False(t, duplicate)
+	})
+
+	t.Run("keys are shared through the state store", func(t *testing.T) {
+		store := daprt.NewFakeStateStore()
+		opts := DeduplicatorOptions{
+			AppID: "myapp",
+			Spec:  config.PublishDeduplicationSpec{StateStore: "mystore"},
+			GetStateStoreFn: func(name string) (state.Store, bool) {
+				return store, name == "mystore"
+			},
+		}
+
+		d1, err := NewDeduplicator(opts)
+		require.NoError(t, err)
+		duplicate, err := d1.Publish(context.Background(), newReq("mytopic", "key1"), func() error { return nil })
+		require.NoError(t, err)
+		assert.False(t, duplicate)
+		assert.Contains(t, store.GetItems(), "dapr-publish-dedup||myapp||mypubsub||mytopic||key1")
+
+		// Another instance, such as a different replica, sees the key too
+		d2, err := NewDeduplicator(opts)
+		require.NoError(t, err)
+		duplicate, err = d2.Publish(context.Background(), newReq("mytopic", "key1"), func() error {
+			return errors.New("should not be published")
+		})
+		require.NoError(t, err)
+		assert.True(t, duplicate)
+	})
+
+	t.Run("nil deduplicator", func(t *testing.T) {
+		var d *Deduplicator
+		var published bool
+		duplicate, err := d.Publish(context.Background(), newReq("mytopic", "key1"), func() error {
+			published = true
+			return nil
+		})
+		require.NoError(t, err)
+		assert.False(t, duplicate)
+		assert.True(t, published)
+	})
+}
@@ -17,9 +17,8 @@ import (
 	"context"
 	"errors"
 
-	"golang.org/x/sync/errgroup"
-
 	contribPubsub "github.com/dapr/components-contrib/pubsub"
+	"github.com/dapr/dapr/utils"
 )
 
 const (
@@ -46,8 +45,7 @@ func NewDefaultBulkPublisher(p contribPubsub.PubSub) contribPubsub.BulkPublisher
 func (p *defaultBulkPublisher) BulkPublish(ctx context.Context, req *contribPubsub.BulkPublishRequest) (contribPubsub.BulkPublishResponse, error) {
 	failedEntries := make([]contribPubsub.BulkPublishResponseFailedEntry, 0, len(req.Entries))
 
-	var eg errgroup.Group
-	eg.SetLimit(defaultBulkPublishMaxConcurrency)
+	eg := utils.NewErrGroup(defaultBulkPublishMaxConcurrency)
 
 	faileEntryChan := make(chan contribPubsub.BulkPublishResponseFailedEntry, len(req.Entries))
 
@@ -0,0 +1,152 @@
+/*
+Copyright 2023 The Dapr Authors
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package pubsub
+
+import (
+	"bytes"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+
+	contribPubsub "github.com/dapr/components-contrib/pubsub"
+	encv1 "github.com/dapr/kit/schemes/enc/v1"
+)
+
+const (
+	// Metadata properties of pubsub components that enable the encryption of messages.
+	encryptionCryptoComponentKey  = "encryptionCryptoComponent"
+	encryptionKeyNameKey          = "encryptionKeyName"
+	encryptionKeyWrapAlgorithmKey = "encryptionKeyWrapAlgorithm"
+
+	// EncryptionKeyExtension is the CloudEvent extension that contains the name of the key used to encrypt the data of the event.
+	EncryptionKeyExtension = "daprencryptionkey"
+
+	defaultEncryptionKeyWrapAlgorithm = encv1.KeyAlgorithmAES256KW
+)
+
+// Encryption contains the options for encrypting the data of the messages published to a pubsub component, and decrypting them on delivery.
+// The data is encrypted with a key stored in a crypto component, so brokers never see plaintext.
+type Encryption struct {
+	// Name of the crypto component that contains the key.
+	CryptoComponent string
+	// Name of the key used to encrypt messages.
+	// Messages are decrypted with the key named in their EncryptionKeyExtension, so keys can be rotated while messages encrypted with the previous key are still in flight.
+	KeyName string
+	// Algorithm used to wrap the key.
+	Algorithm encv1.KeyAlgorithm
+}
+
+// NewEncryption returns the encryption options from the metadata of a pubsub component.
+// It returns nil if encryption is not enabled for the component.
+func NewEncryption(properties map[string]string) (*Encryption, error) {
+	component := strings.TrimSpace(properties[encryptionCryptoComponentKey])
+	keyName := strings.TrimSpace(properties[encryptionKeyNameKey])
+	if component == "" && keyName == "" {
+		return nil, nil
+	}
+	if component == "" || keyName == "" {
+		return nil, fmt.Errorf("metadata properties '%s' and '%s' must both be set to enable encryption", encryptionCryptoComponentKey, encryptionKeyNameKey)
+	}
+
+	algorithm := defaultEncryptionKeyWrapAlgorithm
+	if v := strings.TrimSpace(properties[encryptionKeyWrapAlgorithmKey]); v != "" {
+		algorithm = encv1.KeyAlgorithm(strings.ToUpper(v))
+	}
+	algorithm, err := algorithm.Validate()
+	if err != nil {
+		return nil, fmt.Errorf("invalid value for metadata property '%s': %w", encryptionKeyWrapAlgorithmKey, err)
+	}
+
+	return &Encryption{
+		CryptoComponent: component,
+		KeyName:         keyName,
+		Algorithm:       algorithm,
+	}, nil
+}
+
+// IsEncrypted returns true if the data of the CloudEvent is encrypted.
+func IsEncrypted(cloudEvent map[string]interface{}) bool {
+	_, ok := cloudEvent[EncryptionKeyExtension]
+	return ok
+}
+
+// EncryptCloudEvent encrypts the data of a CloudEvent in place.
+// The encrypted data is stored in the data_base64 field, and the name of the key is stored in the EncryptionKeyExtension.
+func (e *Encryption) EncryptCloudEvent(cloudEvent map[string]interface{}, wrapKeyFn encv1.WrapKeyFn) error {
+	if IsEncrypted(cloudEvent) {
+		return errors.New("the data of the event is already encrypted")
+	}
+
+	plaintext, err := marshalCloudEventData(cloudEvent)
+	if err != nil {
+		return err
+	}
+
+	enc, err := encv1.Encrypt(bytes.NewReader(plaintext), encv1.EncryptOptions{
+		KeyName:   e.KeyName,
+		Algorithm: e.Algorithm,
+		WrapKeyFn: wrapKeyFn,
+		// The key name is stored in the CloudEvent extension
+		OmitKeyName: true,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to encrypt the data of the event: %w", err)
+	}
+	ciphertext, err := io.ReadAll(enc)
+	if err != nil {
+		return fmt.Errorf("failed to encrypt the data of the event: %w", err)
+	}
+
+	delete(cloudEvent, contribPubsub.DataField)
+	cloudEvent[contribPubsub.DataBase64Field] = base64.StdEncoding.EncodeToString(ciphertext)
+	cloudEvent[EncryptionKeyExtension] = e.KeyName
+	return nil
+}
+
+// DecryptCloudEvent decrypts the data of a CloudEvent in place, using the key named in its EncryptionKeyExtension.
+func DecryptCloudEvent(cloudEvent map[string]interface{}, unwrapKeyFn encv1.UnwrapKeyFn) error {
+	keyName, ok := cloudEvent[EncryptionKeyExtension].(string)
+	if !ok || keyName == "" {
+		return fmt.Errorf("invalid value for the CloudEvent extension '%s'", EncryptionKeyExtension)
+	}
+	dataB64, ok := cloudEvent[contribPubsub.DataBase64Field].(string)
+	if !ok {
+		return errors.New("the encrypted data of the event is missing")
+	}
+	ciphertext, err := base64.StdEncoding.DecodeString(dataB64)
+	if err != nil {
+		return fmt.Errorf("failed to decode the encrypted data of the event: %w", err)
+	}
+
+	dec, err := encv1.Decrypt(bytes.NewReader(ciphertext), encv1.DecryptOptions{
+		KeyName:     keyName,
+		UnwrapKeyFn: unwrapKeyFn,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to decrypt the data of the event with key '%s': %w", keyName, err)
+	}
+	plaintext, err := io.ReadAll(dec)
+	if err != nil {
+		return fmt.Errorf("failed to decrypt the data of the event with key '%s': %w", keyName, err)
+	}
+
+	err = unmarshalCloudEventData(cloudEvent, plaintext)
+	if err != nil {
+		return err
+	}
+	delete(cloudEvent, EncryptionKeyExtension)
+	return nil
+}
@@ -0,0 +1,166 @@
+/*
+Copyright 2023 The Dapr Authors
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package pubsub
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	contribPubsub "github.com/dapr/components-contrib/pubsub"
+	encv1 "github.com/dapr/kit/schemes/enc/v1"
+)
+
+// fakeKeys "wraps" keys by storing them in a map keyed by the name of the wrapping key.
+type fakeKeys map[string][]byte
+
+func (f fakeKeys) wrap(plaintextKey []byte, algorithm, keyName string, nonce []byte) ([]byte, []byte, error) {
+	f[keyName] = plaintextKey
+	return []byte(keyName), nil, nil
+}
+
+func (f fakeKeys) unwrap(wrappedKey []byte, algorithm, keyName string, nonce, tag []byte) ([]byte, error) {
+	key, ok := f[keyName]
+	if !ok || string(wrappedKey) != keyName {
+		return nil, errors.New("key not found")
+	}
+	return key, nil
+}
+
+func TestNewEncryption(t *testing.T) {
+	t.Run("not enabled", func(t *testing.T) {
+		enc, err := NewEncryption(map[string]string{})
+		require.NoError(t, err)
+		assert.Nil(t, enc)
+	})
+
+	t.Run("default algorithm", func(t *testing.T) {
+		enc, err := NewEncryption(map[string]string{
+			"encryptionCryptoComponent": "mycrypto",
+			"encryptionKeyName":         "mykey",
+		})
+		require.NoError(t, err)
+		assert.Equal(t, &Encryption{CryptoComponent: "mycrypto", KeyName: "mykey", Algorithm: encv1.KeyAlgorithmAES256KW}, enc)
+	})
+
+	t.Run("custom algorithm", func(t *testing.T) {
+		enc, err := NewEncryption(map[string]string{
+			"encryptionCryptoComponent":  "mycrypto",
+			"encryptionKeyName":          "mykey",
+			"encryptionKeyWrapAlgorithm": "rsa",
+		})
+		require.NoError(t, err)
+		assert.Equal(t, encv1.KeyAlgorithmRSAOAEP256, enc.Algorithm)
+	})
+
+	t.Run("invalid algorithm", func(t *testing.T) {
+		_, err := NewEncryption(map[string]string{
+			"encryptionCryptoComponent":  "mycrypto",
+			"encryptionKeyName":          "mykey",
+			"encryptionKeyWrapAlgorithm": "foo",
+		})
+		require.Error(t, err)
+	})
+
+	t.Run("missing key name", func(t *testing.T) {
+		_, err := NewEncryption(map[string]string{
+			"encryptionCryptoComponent": "mycrypto",
+		})
+		require.Error(t, err)
+	})
+}
+
+func TestEncryptCloudEvent(t *testing.T) {
+	keys := fakeKeys{}
+
+	t.Run("round trip", func(t *testing.T) {
+		enc := &Encryption{CryptoComponent: "mycrypto", KeyName: "key1", Algorithm: encv1.KeyAlgorithmAES256KW}
+		cloudEvent := map[string]interface{}{
+			contribPubsub.IDField:              "1",
+			contribPubsub.DataContentTypeField: "application/json",
+			contribPubsub.DataField:            map[string]interface{}{"message": "hello"},
+		}
+
+		require.NoError(t, enc.EncryptCloudEvent(cloudEvent, keys.wrap))
+		assert.True(t, IsEncrypted(cloudEvent))
+		assert.Equal(t, "key1", cloudEvent[EncryptionKeyExtension])
+		assert.NotContains(t, cloudEvent, contribPubsub.DataField)
+		assert.NotEmpty(t, cloudEvent[contribPubsub.DataBase64Field])
+		assert.NotContains(t, cloudEvent[contribPubsub.DataBase64Field], "hello")
+
+		require.Error(t, enc.EncryptCloudEvent(cloudEvent, keys.wrap))
+
+		require.NoError(t, DecryptCloudEvent(cloudEvent, keys.unwrap))
+		assert.False(t, IsEncrypted(cloudEvent))
+		assert.Equal(t, map[string]interface{}{
+			contribPubsub.IDField:              "1",
+			contribPubsub.DataContentTypeField: "application/json",
+			contribPubsub.DataField:            map[string]interface{}{"message": "hello"},
+		}, cloudEvent)
+	})
+
+	t.Run("binary data", func(t *testing.T) {
+		enc := &Encryption{CryptoComponent: "mycrypto", KeyName: "key1", Algorithm: encv1.KeyAlgorithmAES256KW}
+		cloudEvent := map[string]interface{}{
+			contribPubsub.IDField:         "1",
+			contribPubsub.DataBase64Field: "aGVsbG8=",
+		}
+
+		require.NoError(t, enc.EncryptCloudEvent(cloudEvent, keys.wrap))
+		assert.NotEqual(t, "aGVsbG8=", cloudEvent[contribPubsub.DataBase64Field])
+
+		require.NoError(t, DecryptCloudEvent(cloudEvent, keys.unwrap))
+		assert.Equal(t, map[string]interface{}{
+			contribPubsub.IDField:         "1",
+			contribPubsub.DataBase64Field: "aGVsbG8=",
+		}, cloudEvent)
+	})
+
+	t.Run("key rotation", func(t *testing.T) {
+		cloudEvent := map[string]interface{}{
+			contribPubsub.DataField: "hello",
+		}
+		old := &Encryption{CryptoComponent: "mycrypto", KeyName: "key1", Algorithm: encv1.KeyAlgorithmAES256KW}
+		require.NoError(t, old.EncryptCloudEvent(cloudEvent, keys.wrap))
+
+		// Messages encrypted with the previous key are decrypted with the key named in the event
+		var unwrappedWith string
+		require.NoError(t, DecryptCloudEvent(cloudEvent, func(wrappedKey []byte, algorithm, keyName string, nonce, tag []byte) ([]byte, error) {
+			unwrappedWith = keyName
+			return keys.unwrap(wrappedKey, algorithm, keyName, nonce, tag)
+		}))
+		assert.Equal(t, "key1", unwrappedWith)
+		assert.Equal(t, "hello", cloudEvent[contribPubsub.DataField])
+	})
+
+	t.Run("unknown key", func(t *testing.T) {
+		cloudEvent := map[string]interface{}{
+			contribPubsub.DataField: "hello",
+		}
+		enc := &Encryption{CryptoComponent: "mycrypto", KeyName: "key1", Algorithm: encv1.KeyAlgorithmAES256KW}
+		require.NoError(t, enc.EncryptCloudEvent(cloudEvent, keys.wrap))
+
+		cloudEvent[EncryptionKeyExtension] = "key2"
+		require.Error(t, DecryptCloudEvent(cloudEvent, keys.unwrap))
+	})
+
+	t.Run("missing data", func(t *testing.T) {
+		err := DecryptCloudEvent(map[string]interface{}{
+			EncryptionKeyExtension: "key1",
+		}, keys.unwrap)
+		require.Error(t, err)
+	})
+}
@@ -21,6 +21,17 @@ type BulkSubscribe struct {
 type Rule struct {
 	Match Expr   `json:"match"`
 	Path  string `json:"path"`
+	// Binding is set if the events matching the rule are sent to an output binding instead of the app.
+	Binding *BindingRoute `json:"binding,omitempty"`
+	// Transform is set if the data of the events matching the rule is replaced with the result of an expression before delivery.
+	Transform TransformExpr `json:"transform,omitempty"`
+}
+
+// BindingRoute is the output binding the events matching a rule are sent to.
+type BindingRoute struct {
+	Name      string            `json:"name"`
+	Operation string            `json:"operation"`
+	Metadata  map[string]string `json:"metadata,omitempty"`
 }
 
 type Expr interface {
@@ -28,3 +39,9 @@ type Expr interface {
 
 	Eval(variables map[string]interface{}) (interface{}, error)
 }
+
+type TransformExpr interface {
+	fmt.Stringer
+
+	EvalJSON(variables map[string]interface{}) ([]byte, error)
+}
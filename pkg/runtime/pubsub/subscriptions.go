@@ -4,6 +4,7 @@ import (
 	"bytes"
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"net/http"
 	"os"
@@ -16,6 +17,7 @@ import (
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"sigs.k8s.io/yaml"
 
+	"github.com/dapr/components-contrib/bindings"
 	subscriptionsapiV1alpha1 "github.com/dapr/dapr/pkg/apis/subscriptions/v1alpha1"
 	subscriptionsapiV2alpha1 "github.com/dapr/dapr/pkg/apis/subscriptions/v2alpha1"
 	"github.com/dapr/dapr/pkg/channel"
@@ -231,25 +233,35 @@ func GetSubscriptionsGRPC(ctx context.Context, channel runtimev1pb.AppCallbackCl
 
 // DeclarativeLocal loads subscriptions from the given local resources path.
 func DeclarativeLocal(resourcesPaths []string, namespace string, log logger.Logger) (subs []Subscription) {
+	subs, errs := DeclarativeLocalWithErrors(resourcesPaths, namespace, log)
+	for _, err := range errs {
+		log.Warn(err)
+	}
+	return subs
+}
+
+// DeclarativeLocalWithErrors loads subscriptions from the given local resources path.
+// Errors encountered while reading or parsing individual subscriptions are returned instead of being logged, and those subscriptions are skipped.
+func DeclarativeLocalWithErrors(resourcesPaths []string, namespace string, log logger.Logger) (subs []Subscription, errs []error) {
 	for _, path := range resourcesPaths {
-		res := declarativeFile(path, namespace, log)
+		res, resErrs := declarativeFile(path, namespace, log)
 		if len(res) > 0 {
 			subs = append(subs, res...)
 		}
+		errs = append(errs, resErrs...)
 	}
-	return subs
+	return subs, errs
 }
 
-// Used by DeclarativeLocal to load a single path.
-func declarativeFile(resourcesPath string, namespace string, log logger.Logger) (subs []Subscription) {
+// Used by DeclarativeLocalWithErrors to load a single path.
+func declarativeFile(resourcesPath string, namespace string, log logger.Logger) (subs []Subscription, errs []error) {
 	if _, err := os.Stat(resourcesPath); os.IsNotExist(err) {
-		return subs
+		return subs, nil
 	}
 
 	files, err := os.ReadDir(resourcesPath)
 	if err != nil {
-		log.Errorf("Failed to read subscriptions from path %s: %s", resourcesPath, err)
-		return subs
+		return subs, []error{fmt.Errorf("failed to read subscriptions from path %s: %w", resourcesPath, err)}
 	}
 
 	for _, f := range files {
@@ -265,7 +277,7 @@ func declarativeFile(resourcesPath string, namespace string, log logger.Logger)
 		filePath := filepath.Join(resourcesPath, f.Name())
 		b, err := os.ReadFile(filePath)
 		if err != nil {
-			log.Warnf("Failed to read file %s: %v", f.Name(), err)
+			errs = append(errs, fmt.Errorf("failed to read file %s: %w", f.Name(), err))
 			continue
 		}
 
@@ -279,13 +291,13 @@ func declarativeFile(resourcesPath string, namespace string, log logger.Logger)
 
 			subs, err = appendSubscription(subs, item, namespace)
 			if err != nil {
-				log.Warnf("Failed to add subscription from file %s: %v", f.Name(), err)
+				errs = append(errs, fmt.Errorf("failed to add subscription from file %s: %w", f.Name(), err))
 				continue
 			}
 		}
 	}
 
-	return subs
+	return subs, errs
 }
 
 func unmarshalSubscription(b []byte, namespace string) (*Subscription, error) {
@@ -319,6 +331,12 @@ func unmarshalSubscription(b []byte, namespace string) (*Subscription, error) {
 		if err != nil {
 			return nil, err
 		}
+		if sub.Spec.BulkSubscribe.Enabled && hasBindingRoutes(rules) {
+			return nil, fmt.Errorf("subscription %s: routing events to output bindings is not supported with bulk subscriptions", sub.Name)
+		}
+		if sub.Spec.BulkSubscribe.Enabled && hasTransforms(rules) {
+			return nil, fmt.Errorf("subscription %s: transforming events is not supported with bulk subscriptions", sub.Name)
+		}
 
 		return &Subscription{
 			Topic:           sub.Spec.Topic,
@@ -377,6 +395,19 @@ func parseRoutingRulesYAML(routes subscriptionsapiV2alpha1.Routes) ([]*Rule, err
 		if err != nil {
 			return nil, err
 		}
+		if rule.Binding != nil {
+			r[n].Binding, err = createBindingRoute(rule)
+			if err != nil {
+				return nil, err
+			}
+		}
+		if transform := strings.TrimSpace(rule.Transform); transform != "" {
+			e := &expr.Expr{}
+			if err = e.DecodeString(transform); err != nil {
+				return nil, fmt.Errorf("invalid transform expression for route %s: %w", rule.Path, err)
+			}
+			r[n].Transform = e
+		}
 		n++
 	}
 
@@ -445,6 +476,43 @@ func createRoutingRule(match, path string) (*Rule, error) {
 	}, nil
 }
 
+func createBindingRoute(rule subscriptionsapiV2alpha1.Rule) (*BindingRoute, error) {
+	if rule.Path != "" {
+		return nil, fmt.Errorf("routing rule for binding %s cannot have a path", rule.Binding.Name)
+	}
+	if rule.Binding.Name == "" {
+		return nil, errors.New("routing rule for a binding must have the name of the binding")
+	}
+
+	operation := rule.Binding.Operation
+	if operation == "" {
+		operation = string(bindings.CreateOperation)
+	}
+	return &BindingRoute{
+		Name:      rule.Binding.Name,
+		Operation: operation,
+		Metadata:  rule.Binding.Metadata,
+	}, nil
+}
+
+func hasBindingRoutes(rules []*Rule) bool {
+	for _, r := range rules {
+		if r.Binding != nil {
+			return true
+		}
+	}
+	return false
+}
+
+func hasTransforms(rules []*Rule) bool {
+	for _, r := range rules {
+		if r.Transform != nil {
+			return true
+		}
+	}
+	return false
+}
+
 // DeclarativeKubernetes loads subscriptions from the operator when running in Kubernetes.
 func DeclarativeKubernetes(ctx context.Context, client operatorv1pb.OperatorClient, podName string, namespace string, log logger.Logger) []Subscription {
 	var subs []Subscription
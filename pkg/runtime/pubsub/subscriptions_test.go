@@ -437,6 +437,110 @@ func TestDeclarativeSubscriptionsV2(t *testing.T) {
 		}
 	})
 
+	t.Run("load subscription with binding routes", func(t *testing.T) {
+		s := testDeclarativeSubscriptionV2()
+		s.Spec.Routes.Rules = append(s.Spec.Routes.Rules, subscriptionsapiV2alpha1.Rule{
+			Match: `event.type == "myevent.archive"`,
+			Binding: &subscriptionsapiV2alpha1.RuleBinding{
+				Name:     "s3",
+				Metadata: map[string]string{"key": "archive"},
+			},
+		}, subscriptionsapiV2alpha1.Rule{
+			Match: `event.type == "myevent.delete"`,
+			Binding: &subscriptionsapiV2alpha1.RuleBinding{
+				Name:      "s3",
+				Operation: "delete",
+			},
+		})
+
+		filePath := filepath.Join(dir, "sub.yaml")
+		writeSubscriptionToDisk(s, filePath)
+		defer os.RemoveAll(filePath)
+
+		subs, errs := DeclarativeLocalWithErrors([]string{dir}, "", log)
+		require.Empty(t, errs)
+		require.Len(t, subs, 1)
+		require.Len(t, subs[0].Rules, 5)
+		assert.Nil(t, subs[0].Rules[0].Binding)
+		assert.Equal(t, &BindingRoute{Name: "s3", Operation: "create", Metadata: map[string]string{"key": "archive"}}, subs[0].Rules[2].Binding)
+		assert.Equal(t, &BindingRoute{Name: "s3", Operation: "delete"}, subs[0].Rules[3].Binding)
+		assert.Equal(t, "myroute", subs[0].Rules[4].Path)
+	})
+
+	t.Run("load subscription with transforms", func(t *testing.T) {
+		s := testDeclarativeSubscriptionV2()
+		s.Spec.Routes.Rules[0].Transform = `{"id": event.data.id}`
+
+		filePath := filepath.Join(dir, "sub.yaml")
+		writeSubscriptionToDisk(s, filePath)
+		defer os.RemoveAll(filePath)
+
+		subs, errs := DeclarativeLocalWithErrors([]string{dir}, "", log)
+		require.Empty(t, errs)
+		require.Len(t, subs, 1)
+		require.Len(t, subs[0].Rules, 3)
+		require.NotNil(t, subs[0].Rules[0].Transform)
+		assert.Equal(t, `{"id": event.data.id}`, subs[0].Rules[0].Transform.String())
+		assert.Nil(t, subs[0].Rules[1].Transform)
+	})
+
+	t.Run("invalid transforms", func(t *testing.T) {
+		for name, mutate := range map[string]func(s *subscriptionsapiV2alpha1.Subscription){
+			"invalid expression": func(s *subscriptionsapiV2alpha1.Subscription) {
+				s.Spec.Routes.Rules[0].Transform = `{"id": `
+			},
+			"bulk subscription": func(s *subscriptionsapiV2alpha1.Subscription) {
+				s.Spec.BulkSubscribe.Enabled = true
+				s.Spec.Routes.Rules[0].Transform = `event.data`
+			},
+		} {
+			t.Run(name, func(t *testing.T) {
+				s := testDeclarativeSubscriptionV2()
+				mutate(&s)
+
+				filePath := filepath.Join(dir, "sub.yaml")
+				writeSubscriptionToDisk(s, filePath)
+				defer os.RemoveAll(filePath)
+
+				subs, errs := DeclarativeLocalWithErrors([]string{dir}, "", log)
+				assert.Empty(t, subs)
+				assert.Len(t, errs, 1)
+			})
+		}
+	})
+
+	t.Run("invalid binding routes", func(t *testing.T) {
+		for name, mutate := range map[string]func(s *subscriptionsapiV2alpha1.Subscription){
+			"binding with path": func(s *subscriptionsapiV2alpha1.Subscription) {
+				s.Spec.Routes.Rules[0].Binding = &subscriptionsapiV2alpha1.RuleBinding{Name: "s3"}
+			},
+			"binding without name": func(s *subscriptionsapiV2alpha1.Subscription) {
+				s.Spec.Routes.Rules = append(s.Spec.Routes.Rules, subscriptionsapiV2alpha1.Rule{
+					Binding: &subscriptionsapiV2alpha1.RuleBinding{},
+				})
+			},
+			"bulk subscription": func(s *subscriptionsapiV2alpha1.Subscription) {
+				s.Spec.BulkSubscribe.Enabled = true
+				s.Spec.Routes.Rules = append(s.Spec.Routes.Rules, subscriptionsapiV2alpha1.Rule{
+					Binding: &subscriptionsapiV2alpha1.RuleBinding{Name: "s3"},
+				})
+			},
+		} {
+			t.Run(name, func(t *testing.T) {
+				s := testDeclarativeSubscriptionV2()
+				mutate(&s)
+
+				filePath := filepath.Join(dir, "sub.yaml")
+				writeSubscriptionToDisk(s, filePath)
+				defer os.RemoveAll(filePath)
+
+				subs, errs := DeclarativeLocalWithErrors([]string{dir}, "", log)
+				assert.Empty(t, subs)
+				assert.Len(t, errs, 1)
+			})
+		}
+	})
+
 	t.Run("no subscriptions loaded", func(t *testing.T) {
 		os.RemoveAll(dir)
 
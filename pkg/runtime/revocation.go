@@ -0,0 +1,82 @@
+/*
+Copyright 2023 The Dapr Authors
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package runtime
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+
+	configurationapi "github.com/dapr/dapr/pkg/apis/configuration/v1alpha1"
+	operatorv1pb "github.com/dapr/dapr/pkg/proto/operator/v1"
+	"github.com/dapr/dapr/pkg/security"
+)
+
+const (
+	// Name of the Configuration resource of the control plane, which contains the list of revoked identities.
+	daprSystemConfigName = "daprsystem"
+
+	// Interval at which the list of revoked identities is refreshed from the operator.
+	revokedIdentitiesRefreshInterval = 30 * time.Second
+)
+
+// applyRevokedIdentities sets the list of revoked identities on the security handler.
+func (a *DaprRuntime) applyRevokedIdentities(ids []string) {
+	revoked, err := security.ParseRevokedIdentities(ids)
+	if err != nil {
+		log.Errorf("Failed to parse the list of revoked identities: %v", err)
+		return
+	}
+	a.sec.SetRevokedIdentities(revoked)
+}
+
+// watchRevokedIdentities periodically retrieves the list of revoked identities from the control plane configuration, via the operator.
+// Blocks until the context is canceled.
+func (a *DaprRuntime) watchRevokedIdentities(ctx context.Context) error {
+	for {
+		a.refreshRevokedIdentities(ctx)
+
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-a.clock.After(revokedIdentitiesRefreshInterval):
+		}
+	}
+}
+
+func (a *DaprRuntime) refreshRevokedIdentities(ctx context.Context) {
+	res, err := a.operatorClient.GetConfiguration(ctx, &operatorv1pb.GetConfigurationRequest{
+		Name:      daprSystemConfigName,
+		Namespace: a.sec.ControlPlaneNamespace(),
+		PodName:   a.podName,
+	})
+	if err != nil {
+		// Keep the current list if the operator can't be reached.
+		log.Debugf("Failed to retrieve the list of revoked identities from the operator: %v", err)
+		return
+	}
+
+	var conf configurationapi.Configuration
+	err = json.Unmarshal(res.GetConfiguration(), &conf)
+	if err != nil {
+		log.Errorf("Failed to parse the control plane configuration: %v", err)
+		return
+	}
+
+	var ids []string
+	if conf.Spec.MTLSSpec != nil {
+		ids = conf.Spec.MTLSSpec.RevokedIdentities
+	}
+	a.applyRevokedIdentities(ids)
+}
@@ -32,21 +32,24 @@ import (
 	otlptracegrpc "go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracegrpc"
 	otlptracehttp "go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracehttp"
 	"go.opentelemetry.io/otel/exporters/zipkin"
-	"go.opentelemetry.io/otel/sdk/resource"
 	sdktrace "go.opentelemetry.io/otel/sdk/trace"
-	semconv "go.opentelemetry.io/otel/semconv/v1.10.0"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	coordinationv1client "k8s.io/client-go/kubernetes/typed/coordination/v1"
+	"k8s.io/client-go/rest"
 	"k8s.io/utils/clock"
 
 	nr "github.com/dapr/components-contrib/nameresolution"
 	"github.com/dapr/components-contrib/state"
+	"github.com/dapr/dapr/pkg/accesslog"
 	"github.com/dapr/dapr/pkg/actors"
 	componentsV1alpha1 "github.com/dapr/dapr/pkg/apis/components/v1alpha1"
 	httpEndpointV1alpha1 "github.com/dapr/dapr/pkg/apis/httpEndpoint/v1alpha1"
 	"github.com/dapr/dapr/pkg/apphealth"
+	"github.com/dapr/dapr/pkg/buildinfo"
 	"github.com/dapr/dapr/pkg/components"
 	"github.com/dapr/dapr/pkg/components/pluggable"
 	secretstoresLoader "github.com/dapr/dapr/pkg/components/secretstores"
+	stateLoader "github.com/dapr/dapr/pkg/components/state"
 	"github.com/dapr/dapr/pkg/config"
 	"github.com/dapr/dapr/pkg/config/protocol"
 	diag "github.com/dapr/dapr/pkg/diagnostics"
@@ -56,12 +59,14 @@ import (
 	"github.com/dapr/dapr/pkg/grpc/universalapi"
 	"github.com/dapr/dapr/pkg/http"
 	"github.com/dapr/dapr/pkg/httpendpoint"
+	"github.com/dapr/dapr/pkg/leaderelection"
 	"github.com/dapr/dapr/pkg/messaging"
 	invokev1 "github.com/dapr/dapr/pkg/messaging/v1"
 	httpMiddleware "github.com/dapr/dapr/pkg/middleware/http"
 	"github.com/dapr/dapr/pkg/modes"
 	"github.com/dapr/dapr/pkg/operator/client"
 	operatorv1pb "github.com/dapr/dapr/pkg/proto/operator/v1"
+	"github.com/dapr/dapr/pkg/ratelimit"
 	"github.com/dapr/dapr/pkg/resiliency"
 	"github.com/dapr/dapr/pkg/runtime/authorizer"
 	"github.com/dapr/dapr/pkg/runtime/channels"
@@ -72,6 +77,7 @@ import (
 	"github.com/dapr/dapr/pkg/runtime/processor"
 	"github.com/dapr/dapr/pkg/runtime/registry"
 	"github.com/dapr/dapr/pkg/runtime/wfengine"
+	schedulerclient "github.com/dapr/dapr/pkg/scheduler/client"
 	"github.com/dapr/dapr/pkg/security"
 	"github.com/dapr/dapr/utils"
 	"github.com/dapr/kit/concurrency"
@@ -112,17 +118,32 @@ type DaprRuntime struct {
 	runnerCloser        *concurrency.RunnerCloserManager
 	clock               clock.Clock
 	reloader            *hotreload.Reloader
+	leaderElections     leaderelection.Elections
 
 	// Used for testing.
 	initComplete chan struct{}
 
+	// Time the startup sequence started, and its duration; the duration is set before initComplete is closed.
+	startTime    time.Time
+	initDuration time.Duration
+
+	// Results of the connectivity checks of the components, performed before the sidecar is marked as ready.
+	warmupResults map[string]*universalapi.BootReportWarmup
+	warmupLock    sync.RWMutex
+
 	proxy messaging.Proxy
 
 	resiliency resiliency.Provider
 
 	tracerProvider *sdktrace.TracerProvider
+	otelMetrics    *diag.OtelMetrics
+	otelLogs       *diag.OtelLogs
+	restoreLogs    func()
+
+	accessLog *accesslog.Logger
 
 	workflowEngine *wfengine.WorkflowEngine
+	rateLimiters   ratelimit.Limiters
 
 	wg sync.WaitGroup
 }
@@ -148,6 +169,14 @@ func newDaprRuntime(ctx context.Context,
 		Mode:          runtimeConfig.mode,
 	})
 
+	if tlsSpec := globalConfig.GetMTLSSpec().TLS; tlsSpec != nil {
+		tlsOpts, err := security.ParseTLSOptions(tlsSpec.MinVersion, tlsSpec.CipherSuites, tlsSpec.CurvePreferences)
+		if err != nil {
+			return nil, fmt.Errorf("invalid TLS options in configuration: %w", err)
+		}
+		sec.SetTLSOptions(tlsOpts)
+	}
+
 	operatorClient, err := getOperatorClient(ctx, sec, runtimeConfig)
 	if err != nil {
 		return nil, err
@@ -164,7 +193,17 @@ func newDaprRuntime(ctx context.Context,
 		GlobalConfig: globalConfig,
 	})
 
+	rateLimiters, err := ratelimit.New(ratelimit.Options{
+		AppID:           runtimeConfig.id,
+		Specs:           globalConfig.Spec.RateLimiters,
+		GetStateStoreFn: compStore.GetStateStore,
+	})
+	if err != nil {
+		log.Warnf("failed to load rate limiters: %s", err)
+	}
+
 	channels := channels.New(channels.Options{
+		AppID:               runtimeConfig.id,
 		Registry:            runtimeConfig.registry,
 		ComponentStore:      compStore,
 		Meta:                meta,
@@ -173,6 +212,8 @@ func newDaprRuntime(ctx context.Context,
 		MaxRequestBodySize:  runtimeConfig.maxRequestBodySize,
 		ReadBufferSize:      runtimeConfig.readBufferSize,
 		GRPC:                grpc,
+		MockAppChannel:      runtimeConfig.mockAppChannel,
+		RateLimiters:        rateLimiters,
 	})
 
 	processor := processor.New(processor.Options{
@@ -191,6 +232,8 @@ func newDaprRuntime(ctx context.Context,
 		OperatorClient:   operatorClient,
 		GRPC:             grpc,
 		Channels:         channels,
+
+		MaxRequestBodySize: int64(runtimeConfig.maxRequestBodySize) << 20, // Convert from MB to bytes
 	})
 
 	var reloader *hotreload.Reloader
@@ -220,6 +263,7 @@ func newDaprRuntime(ctx context.Context,
 		tracerProvider:    nil,
 		resiliency:        resiliencyProvider,
 		workflowEngine:    wfe,
+		rateLimiters:      rateLimiters,
 		appHealthReady:    nil,
 		compStore:         compStore,
 		meta:              meta,
@@ -232,6 +276,7 @@ func newDaprRuntime(ctx context.Context,
 		namespace:         namespace,
 		podName:           podName,
 		initComplete:      make(chan struct{}),
+		startTime:         time.Now(),
 		isAppHealthy:      make(chan struct{}),
 		clock:             new(clock.RealClock),
 	}
@@ -242,11 +287,16 @@ func newDaprRuntime(ctx context.Context,
 		gracePeriod = &duration
 	}
 
+	diag.DefaultRuntimeMetrics.SetConnectionPoolSizeFn(diag.PoolGRPCApp, grpc.AppConnectionCount)
+	diag.DefaultRuntimeMetrics.SetConnectionPoolSizeFn(diag.PoolGRPCRemote, grpc.RemoteConnectionCount)
+	diag.DefaultRuntimeMetrics.SetConnectionPoolSizeFn(diag.PoolHTTPApp, channels.AppHTTPConnections)
+
 	rt.runnerCloser = concurrency.NewRunnerCloserManager(gracePeriod,
 		rt.runtimeConfig.metricsExporter.Run,
+		diag.DefaultRuntimeMetrics.Run,
 		rt.processor.Process,
 		func(ctx context.Context) error {
-			start := time.Now()
+			start := rt.startTime
 			log.Infof("%s mode configured", rt.runtimeConfig.mode)
 			log.Infof("app id: %s", rt.runtimeConfig.id)
 
@@ -254,8 +304,12 @@ func newDaprRuntime(ctx context.Context,
 				return err
 			}
 
-			d := time.Since(start).Milliseconds()
-			log.Infof("dapr initialized. Status: Running. Init Elapsed %vms", d)
+			if err := rt.warmupComponents(ctx); err != nil {
+				return err
+			}
+
+			rt.initDuration = time.Since(start)
+			log.Infof("dapr initialized. Status: Running. Init Elapsed %vms", rt.initDuration.Milliseconds())
 
 			if rt.daprHTTPAPI != nil {
 				// Setting the status only when runtime is initialized.
@@ -269,6 +323,14 @@ func newDaprRuntime(ctx context.Context,
 		},
 	)
 
+	if rt.operatorClient != nil {
+		if err := rt.runnerCloser.Add(rt.watchRevokedIdentities); err != nil {
+			return nil, err
+		}
+	} else if mtlsSpec := globalConfig.GetMTLSSpec(); len(mtlsSpec.RevokedIdentities) > 0 {
+		rt.applyRevokedIdentities(mtlsSpec.RevokedIdentities)
+	}
+
 	if rt.reloader != nil {
 		if err := rt.runnerCloser.Add(rt.reloader.Run); err != nil {
 			return nil, err
@@ -281,6 +343,10 @@ func newDaprRuntime(ctx context.Context,
 	if err := rt.runnerCloser.AddCloser(
 		func() error {
 			log.Info("Dapr is shutting down")
+
+			// The leases of the elections are released before the state stores that keep them are closed
+			rt.leaderElections.Close()
+
 			comps := rt.compStore.ListComponents()
 			errCh := make(chan error)
 			for _, comp := range comps {
@@ -303,6 +369,8 @@ func newDaprRuntime(ctx context.Context,
 		rt.stopWorkflow,
 		rt.stopActor,
 		rt.stopTrace,
+		rt.stopMetrics,
+		rt.stopOtelLogs,
 		rt.grpc,
 	); err != nil {
 		return nil, err
@@ -415,15 +483,33 @@ func (a *DaprRuntime) setupTracing(ctx context.Context, hostAddress string, tpSt
 	}
 
 	// Register a resource
-	r := resource.NewWithAttributes(
-		semconv.SchemaURL,
-		semconv.ServiceNameKey.String(a.runtimeConfig.id),
-	)
+	tpStore.RegisterResource(diag.NewOtelResource(a.runtimeConfig.id, tracingSpec.Resource))
 
-	tpStore.RegisterResource(r)
+	// Register the attributes set on every span; the ones read from the headers are set by the tracing middlewares
+	if err := diag.ValidateSpanAttributes(tracingSpec.SpanAttributes); err != nil {
+		return err
+	}
+	tpStore.RegisterSpanAttributes(diag.StaticSpanAttributes(tracingSpec.SpanAttributes))
+
+	// Set the formats of the trace context propagated in the headers of requests
+	propagationFormats, err := diag.ParsePropagationFormats(tracingSpec.Propagation)
+	if err != nil {
+		return err
+	}
+	diag.SetPropagationFormats(propagationFormats)
 
 	// Register a trace sampler based on Sampling settings
 	daprTraceSampler := diag.NewDaprTraceSampler(tracingSpec.SamplingRate)
+
+	tailPolicy, err := diag.NewTailSamplingPolicy(tracingSpec.TailSampling)
+	if err != nil {
+		return err
+	}
+	if tailPolicy != nil {
+		daprTraceSampler.TailSampling = true
+		tpStore.RegisterTailSamplingPolicy(tailPolicy)
+		log.Infof("Dapr trace tail sampling enabled: %s", tailPolicy)
+	}
 	log.Infof("Dapr trace sampler initialized: %s", daprTraceSampler.Description())
 
 	tpStore.RegisterSampler(daprTraceSampler)
@@ -432,6 +518,45 @@ func (a *DaprRuntime) setupTracing(ctx context.Context, hostAddress string, tpSt
 	return nil
 }
 
+// setupOtelMetrics starts the OpenTelemetry metrics pipeline if an OTLP endpoint is configured in the metric spec.
+// The pipeline runs side by side with the Prometheus endpoint, and is enabled with the OTelMetrics feature.
+func (a *DaprRuntime) setupOtelMetrics(ctx context.Context) error {
+	metricSpec := a.globalConfig.GetMetricsSpec()
+	if !metricSpec.GetEnabled() || metricSpec.Otel == nil {
+		return nil
+	}
+	if !a.globalConfig.IsFeatureEnabled(config.OTelMetrics) {
+		log.Warnf("Otel metrics exporter is configured, but the %s feature is not enabled", config.OTelMetrics)
+		return nil
+	}
+
+	otelMetrics, err := diag.NewOtelMetrics(ctx, diag.NewOtelResource(a.runtimeConfig.id, metricSpec.Resource), *metricSpec.Otel)
+	if err != nil {
+		return err
+	}
+	a.otelMetrics = otelMetrics
+	log.Infof("Otel metrics exporter initialized with endpoint %s", metricSpec.Otel.EndpointAddress)
+	return nil
+}
+
+// setupOtelLogs starts exporting the sidecar logs if an OTLP endpoint is configured in the logging spec.
+// The logs are still written to the standard output, and they're copied to the exporter.
+func (a *DaprRuntime) setupOtelLogs() error {
+	otelSpec := a.globalConfig.GetLoggingSpec().Otel
+	if otelSpec == nil {
+		return nil
+	}
+
+	otelLogs, err := diag.NewOtelLogs(a.runtimeConfig.id, *otelSpec)
+	if err != nil {
+		return err
+	}
+	a.otelLogs = otelLogs
+	a.restoreLogs = diag.TeeLogs(otelLogs)
+	log.Infof("Otel logs exporter initialized with endpoint %s", otelSpec.EndpointAddress)
+	return nil
+}
+
 func (a *DaprRuntime) initRuntime(ctx context.Context) error {
 	var err error
 	if a.hostAddress, err = utils.GetHostAddress(); err != nil {
@@ -440,6 +565,12 @@ func (a *DaprRuntime) initRuntime(ctx context.Context) error {
 	if err = a.setupTracing(ctx, a.hostAddress, newOpentelemetryTracerProviderStore()); err != nil {
 		return fmt.Errorf("failed to setup tracing: %w", err)
 	}
+	if err = a.setupOtelMetrics(ctx); err != nil {
+		return fmt.Errorf("failed to setup Otel metrics: %w", err)
+	}
+	if err = a.setupOtelLogs(); err != nil {
+		return fmt.Errorf("failed to setup Otel logs: %w", err)
+	}
 	// Register and initialize name resolution for service discovery.
 	err = a.initNameResolution(ctx)
 	if err != nil {
@@ -471,6 +602,9 @@ func (a *DaprRuntime) initRuntime(ctx context.Context) error {
 	if err = a.channels.Refresh(); err != nil {
 		log.Warnf("failed to open %s channel to app: %s", string(a.runtimeConfig.appConnectionConfig.Protocol), err)
 	}
+	if err = a.runnerCloser.AddCloser(a.channels); err != nil {
+		return err
+	}
 
 	pipeline, err := a.channels.BuildHTTPPipeline(a.globalConfig.Spec.HTTPPipelineSpec)
 	if err != nil {
@@ -480,6 +614,11 @@ func (a *DaprRuntime) initRuntime(ctx context.Context) error {
 	// Setup allow/deny list for secrets
 	a.populateSecretsConfiguration()
 
+	a.leaderElections, err = a.initLeaderElections()
+	if err != nil {
+		log.Warnf("failed to load leader elections: %s", err)
+	}
+
 	// Create and start the external gRPC server
 	a.daprUniversalAPI = &universalapi.UniversalAPI{
 		AppID:                       a.runtimeConfig.id,
@@ -488,9 +627,24 @@ func (a *DaprRuntime) initRuntime(ctx context.Context) error {
 		Resiliency:                  a.resiliency,
 		Actors:                      a.actor,
 		GetComponentsCapabilitiesFn: a.getComponentsCapabilitesMap,
+		GetComponentsStatsFn:        a.getComponentsStatsMap,
+		GetBootReportFn:             a.getBootReport,
 		ShutdownFn:                  a.ShutdownWithWait,
+		ResetAppChannelFn:           a.channels.ResetAppChannel,
+		ReplayBindingDeadLettersFn:  a.processor.Binding().ReplayDeadLetters,
 		AppConnectionConfig:         a.runtimeConfig.appConnectionConfig,
 		GlobalConfig:                a.globalConfig,
+		WorkflowMetrics:             diag.DefaultWorkflowMonitoring,
+		AppMetrics:                  diag.DefaultAppMetrics,
+		RateLimiters:                a.rateLimiters,
+		LeaderElections:             a.leaderElections,
+		StateIdempotency:            stateLoader.NewIdempotencyTracker(a.runtimeConfig.id, a.compStore.GetStateStore),
+		MaxInvokeTimeout:            a.runtimeConfig.maxInvokeTimeout,
+	}
+
+	a.accessLog, err = accesslog.New(a.runtimeConfig.id, a.globalConfig.GetAccessLogSpec())
+	if err != nil {
+		return fmt.Errorf("failed to initialize access logs: %w", err)
 	}
 
 	// Create and start internal and external gRPC servers
@@ -617,6 +771,9 @@ func (a *DaprRuntime) appHealthReadyInit(ctx context.Context) (err error) {
 func (a *DaprRuntime) initWorkflowEngine(ctx context.Context) {
 	wfComponentFactory := wfengine.BuiltinWorkflowFactory(a.workflowEngine)
 
+	if err := a.initWorkflowScheduler(ctx); err != nil {
+		log.Warnf("Failed to schedule the workflow reminders with the scheduler service, falling back to actor reminders: %v", err)
+	}
 	a.workflowEngine.SetActorRuntime(a.actor)
 	if reg := a.runtimeConfig.registry.Workflows(); reg != nil {
 		log.Infof("Registering component for dapr workflow engine...")
@@ -629,6 +786,31 @@ func (a *DaprRuntime) initWorkflowEngine(ctx context.Context) {
 	}
 }
 
+// initWorkflowScheduler connects the workflow engine to the scheduler service, if the workflows are configured to schedule their reminders with it.
+func (a *DaprRuntime) initWorkflowScheduler(ctx context.Context) error {
+	spec := a.globalConfig.GetWorkflowSpec()
+	useScheduler, err := spec.UseScheduler()
+	if err != nil || !useScheduler {
+		return err
+	}
+	if a.runtimeConfig.schedulerAddress == "" {
+		return errors.New("the workflows are configured with the scheduler reminders backend, but the address of the scheduler service is not set")
+	}
+
+	client, conn, err := schedulerclient.GetSchedulerClient(ctx, a.runtimeConfig.schedulerAddress, a.sec)
+	if err != nil {
+		return fmt.Errorf("failed to create the scheduler client: %w", err)
+	}
+	if err = a.runnerCloser.AddCloser(conn); err != nil {
+		conn.Close()
+		return err
+	}
+
+	log.Infof("Workflow reminders are scheduled with the scheduler service at %s", a.runtimeConfig.schedulerAddress)
+	a.workflowEngine.SetSchedulerClient(client)
+	return nil
+}
+
 // initPluggableComponents discover pluggable components and initialize with their respective registries.
 func (a *DaprRuntime) initPluggableComponents(ctx context.Context) {
 	if runtime.GOOS == "windows" {
@@ -713,6 +895,8 @@ func (a *DaprRuntime) initDirectMessaging(resolver nr.Resolver) {
 		ReadBufferSize:     a.runtimeConfig.readBufferSize,
 		Resiliency:         a.resiliency,
 		CompStore:          a.compStore,
+		IdempotentMethods:  a.globalConfig.GetIdempotentMethods(),
+		CompressionMinSize: a.globalConfig.GetInvokeCompressionSpec().GetMinSize(),
 	})
 }
 
@@ -734,6 +918,7 @@ func (a *DaprRuntime) startHTTPServer(port int, publicPort *int, profilePort int
 		DirectMessaging:       a.directMessaging,
 		PubsubAdapter:         a.processor.PubSub(),
 		SendToOutputBindingFn: a.processor.Binding().SendToOutputBinding,
+		IsWorkflowReadyFn:     a.workflowEngine.IsReady,
 		TracingSpec:           a.globalConfig.GetTracingSpec(),
 		MaxRequestBodySize:    int64(a.runtimeConfig.maxRequestBodySize) << 20, // Convert from MB to bytes
 	})
@@ -746,6 +931,7 @@ func (a *DaprRuntime) startHTTPServer(port int, publicPort *int, profilePort int
 		PublicPort:              publicPort,
 		ProfilePort:             profilePort,
 		AllowedOrigins:          allowedOrigins,
+		CORS:                    a.globalConfig.GetCORSSpec(),
 		EnableProfiling:         a.runtimeConfig.enableProfiling,
 		MaxRequestBodySizeMB:    a.runtimeConfig.maxRequestBodySize,
 		UnixDomainSocket:        a.runtimeConfig.unixDomainSocket,
@@ -753,15 +939,17 @@ func (a *DaprRuntime) startHTTPServer(port int, publicPort *int, profilePort int
 		EnableAPILogging:        *a.runtimeConfig.enableAPILogging,
 		APILoggingObfuscateURLs: a.globalConfig.GetAPILoggingSpec().ObfuscateURLs,
 		APILogHealthChecks:      !a.globalConfig.GetAPILoggingSpec().OmitHealthChecks,
+		AccessLog:               a.accessLog,
 	}
 
 	server := http.NewServer(http.NewServerOpts{
-		API:         a.daprHTTPAPI,
-		Config:      serverConf,
-		TracingSpec: a.globalConfig.GetTracingSpec(),
-		MetricSpec:  a.globalConfig.GetMetricsSpec(),
-		Pipeline:    pipeline,
-		APISpec:     a.globalConfig.GetAPISpec(),
+		API:          a.daprHTTPAPI,
+		Config:       serverConf,
+		TracingSpec:  a.globalConfig.GetTracingSpec(),
+		MetricSpec:   a.globalConfig.GetMetricsSpec(),
+		Pipeline:     pipeline,
+		APISpec:      a.globalConfig.GetAPISpec(),
+		RateLimiters: a.daprUniversalAPI.RateLimiters,
 	})
 	if err := server.StartNonBlocking(); err != nil {
 		return err
@@ -825,6 +1013,7 @@ func (a *DaprRuntime) getNewServerConfig(apiListenAddresses []string, port int)
 		UnixDomainSocket:     a.runtimeConfig.unixDomainSocket,
 		ReadBufferSizeKB:     a.runtimeConfig.readBufferSize,
 		EnableAPILogging:     *a.runtimeConfig.enableAPILogging,
+		AccessLog:            a.accessLog,
 	}
 }
 
@@ -908,16 +1097,18 @@ func (a *DaprRuntime) initActors(ctx context.Context) error {
 		log.Info("actors: state store is not configured - this is okay for clients but services with hosted actors will fail to initialize!")
 	}
 	actorConfig := actors.NewConfig(actors.ConfigOpts{
-		HostAddress:        a.hostAddress,
-		AppID:              a.runtimeConfig.id,
-		PlacementAddresses: a.runtimeConfig.placementAddresses,
-		Port:               a.runtimeConfig.internalGRPCPort,
-		Namespace:          a.namespace,
-		AppConfig:          a.appConfig,
-		HealthHTTPClient:   a.channels.AppHTTPClient(),
-		HealthEndpoint:     a.channels.AppHTTPEndpoint(),
-		AppChannelAddress:  a.runtimeConfig.appConnectionConfig.ChannelAddress,
-		PodName:            getPodName(),
+		HostAddress:         a.hostAddress,
+		AppID:               a.runtimeConfig.id,
+		PlacementAddresses:  a.runtimeConfig.placementAddresses,
+		Port:                a.runtimeConfig.internalGRPCPort,
+		Namespace:           a.namespace,
+		AppConfig:           a.appConfig,
+		HealthHTTPClient:    a.channels.AppHTTPClient(),
+		HealthEndpoint:      a.channels.AppHTTPEndpoint(),
+		AppChannelAddress:   a.runtimeConfig.appConnectionConfig.ChannelAddress,
+		PodName:             getPodName(),
+		ActorVersion:        a.runtimeConfig.actorVersion,
+		FailoverMaxAttempts: a.globalConfig.GetActorFailoverSpec().GetMaxAttempts(),
 	})
 
 	act := actors.NewActors(actors.ActorsOpts{
@@ -935,6 +1126,7 @@ func (a *DaprRuntime) initActors(ctx context.Context) error {
 	err = act.Init(ctx)
 	if err == nil {
 		a.actor = act
+		diag.DefaultRuntimeMetrics.SetPlacementTableSizeFn(act.PlacementTableSize)
 		return nil
 	}
 	return rterrors.NewInit(rterrors.InitFailure, "actors", err)
@@ -1047,6 +1239,37 @@ func (a *DaprRuntime) stopWorkflow(ctx context.Context) error {
 	return nil
 }
 
+// initLeaderElections returns the elections configured in the Configuration.
+// In Kubernetes mode, the elections without a state store keep their lease in a Kubernetes Lease.
+func (a *DaprRuntime) initLeaderElections() (leaderelection.Elections, error) {
+	opts := leaderelection.Options{
+		AppID:           a.runtimeConfig.id,
+		Namespace:       a.namespace,
+		Specs:           a.globalConfig.Spec.LeaderElections,
+		GetStateStoreFn: a.compStore.GetStateStore,
+	}
+
+	needsKubernetes := false
+	for _, spec := range opts.Specs {
+		if spec.StateStore == "" {
+			needsKubernetes = true
+			break
+		}
+	}
+	if needsKubernetes && a.runtimeConfig.mode == modes.KubernetesMode {
+		restConfig, err := rest.InClusterConfig()
+		if err != nil {
+			return nil, fmt.Errorf("failed to get the Kubernetes configuration: %w", err)
+		}
+		opts.KubernetesLeases, err = coordinationv1client.NewForConfig(restConfig)
+		if err != nil {
+			return nil, fmt.Errorf("failed to create the Kubernetes client: %w", err)
+		}
+	}
+
+	return leaderelection.New(opts)
+}
+
 // ShutdownWithWait will gracefully stop runtime and wait outstanding operations.
 func (a *DaprRuntime) ShutdownWithWait() {
 	a.runnerCloser.Close()
@@ -1079,7 +1302,7 @@ func (a *DaprRuntime) blockUntilAppIsReady(ctx context.Context) error {
 
 	log.Infof("application protocol: %s. waiting on port %v.  This will block until the app is listening on that port.", string(a.runtimeConfig.appConnectionConfig.Protocol), a.runtimeConfig.appConnectionConfig.Port)
 
-	dialAddr := a.runtimeConfig.appConnectionConfig.ChannelAddress + ":" + strconv.Itoa(a.runtimeConfig.appConnectionConfig.Port)
+	dialAddr := utils.JoinHostPort(a.runtimeConfig.appConnectionConfig.ChannelAddress, a.runtimeConfig.appConnectionConfig.Port)
 
 	for {
 		var (
@@ -1182,6 +1405,95 @@ func (a *DaprRuntime) getComponentsCapabilitesMap() map[string][]string {
 	return capabilities
 }
 
+// getComponentsStatsMap returns the runtime statistics of the components that report them.
+func (a *DaprRuntime) getComponentsStatsMap() map[string]components.ComponentStats {
+	stats := make(map[string]components.ComponentStats)
+	add := func(name string, comp any) {
+		if s, ok := components.GetStats(comp); ok {
+			stats[name] = s
+		}
+	}
+	for key, store := range a.compStore.ListStateStores() {
+		add(key, store)
+	}
+	for key, pubSubItem := range a.compStore.ListPubSubs() {
+		add(key, pubSubItem.Component)
+	}
+	for key, binding := range a.compStore.ListInputBindings() {
+		add(key, binding)
+	}
+	for key, binding := range a.compStore.ListOutputBindings() {
+		add(key, binding)
+	}
+	for key, store := range a.compStore.ListSecretStores() {
+		add(key, store)
+	}
+	for key, store := range a.compStore.ListConfigurations() {
+		add(key, store)
+	}
+	for key, store := range a.compStore.ListLocks() {
+		add(key, store)
+	}
+	for key, provider := range a.compStore.ListCryptoProviders() {
+		add(key, provider)
+	}
+	for key, wf := range a.compStore.ListWorkflows() {
+		add(key, wf)
+	}
+	return stats
+}
+
+// getBootReport returns a summary of the startup sequence of the sidecar.
+func (a *DaprRuntime) getBootReport() *universalapi.BootReport {
+	report := &universalapi.BootReport{
+		AppID:           a.runtimeConfig.id,
+		RuntimeVersion:  buildinfo.Version(),
+		GitCommit:       buildinfo.Commit(),
+		GoVersion:       runtime.Version(),
+		Mode:            string(a.runtimeConfig.mode),
+		StartTime:       a.startTime,
+		EnabledFeatures: a.globalConfig.EnabledFeatures(),
+		Ports: universalapi.BootReportPorts{
+			HTTP:         a.runtimeConfig.httpPort,
+			GRPC:         a.runtimeConfig.apiGRPCPort,
+			InternalGRPC: a.runtimeConfig.internalGRPCPort,
+			App:          a.runtimeConfig.appConnectionConfig.Port,
+		},
+	}
+
+	select {
+	case <-a.initComplete:
+		report.Ready = true
+		report.InitDurationMs = a.initDuration.Milliseconds()
+	default:
+	}
+
+	if a.runtimeConfig.publicPort != nil {
+		report.Ports.Public = *a.runtimeConfig.publicPort
+	}
+	if a.runtimeConfig.enableProfiling {
+		report.Ports.Profile = a.runtimeConfig.profilePort
+	}
+	if opts := a.runtimeConfig.metricsExporter.Options(); opts.MetricsEnabled {
+		report.Ports.Metrics, _ = strconv.Atoi(opts.Port)
+	}
+
+	durations := a.processor.ComponentInitDurations()
+	comps := a.compStore.ListComponents()
+	report.Components = make([]universalapi.BootReportComponent, len(comps))
+	for i, comp := range comps {
+		report.Components[i] = universalapi.BootReportComponent{
+			Name:           comp.Name,
+			Type:           comp.Spec.Type,
+			Version:        comp.Spec.Version,
+			InitDurationMs: durations[comp.Name].Milliseconds(),
+			Warmup:         a.getWarmupResult(comp.Name),
+		}
+	}
+
+	return report
+}
+
 // converts components Features from FeatureType to string
 func featureTypeToString(features interface{}) []string {
 	featureStr := make([]string, 0)
@@ -1207,13 +1519,52 @@ func createGRPCManager(sec security.Handler, runtimeConfig *internalConfig, glob
 		grpcAppChannelConfig.MaxRequestBodySizeMB = runtimeConfig.maxRequestBodySize
 		grpcAppChannelConfig.ReadBufferSizeKB = runtimeConfig.readBufferSize
 		grpcAppChannelConfig.BaseAddress = runtimeConfig.appConnectionConfig.ChannelAddress
+		grpcAppChannelConfig.KeepaliveTime = runtimeConfig.appConnectionConfig.GRPCKeepaliveTime
+		grpcAppChannelConfig.KeepaliveTimeout = runtimeConfig.appConnectionConfig.GRPCKeepaliveTimeout
 	}
 
 	m := manager.NewManager(sec, runtimeConfig.mode, grpcAppChannelConfig)
+	if globalConfig != nil {
+		ttl, err := globalConfig.Spec.DNSCacheSpec.GetTTL()
+		if err != nil {
+			log.Warnf("DNS cache is disabled: %v", err)
+		} else if ttl > 0 {
+			log.Infof("DNS cache enabled for connections to other Dapr sidecars, with TTL %v", ttl)
+			m.EnableDNSCache(ttl)
+		}
+	}
 	m.StartCollector()
 	return m
 }
 
+func (a *DaprRuntime) stopMetrics(ctx context.Context) error {
+	// The views are unregistered after the Otel pipeline is shut down, as it exports them through the OpenCensus bridge
+	defer diag.ShutdownMetrics()
+
+	if a.otelMetrics == nil {
+		return nil
+	}
+	// Shutting down the meter provider flushes the pending metrics.
+	if err := a.otelMetrics.Shutdown(ctx); err != nil && !errors.Is(err, context.Canceled) {
+		return fmt.Errorf("error shutting down Otel metrics: %w", err)
+	}
+	a.otelMetrics = nil
+	return nil
+}
+
+func (a *DaprRuntime) stopOtelLogs(ctx context.Context) error {
+	if a.otelLogs == nil {
+		return nil
+	}
+	// Restoring the output of the loggers first ensures that no entries are written after the exporter is shut down.
+	a.restoreLogs()
+	if err := a.otelLogs.Shutdown(ctx); err != nil && !errors.Is(err, context.Canceled) {
+		return fmt.Errorf("error shutting down Otel logs: %w", err)
+	}
+	a.otelLogs = nil
+	return nil
+}
+
 func (a *DaprRuntime) stopTrace(ctx context.Context) error {
 	if a.tracerProvider == nil {
 		return nil
@@ -627,6 +627,22 @@ func TestSetupTracing(t *testing.T) {
 			Stdout: true,
 		},
 		expectedExporters: []sdktrace.SpanExporter{&diagUtils.StdoutExporter{}},
+	}, {
+		name: "invalid span attribute",
+		tracingConfig: config.TracingSpec{
+			SamplingRate: "1",
+			SpanAttributes: []config.SpanAttributeSpec{
+				{Name: "pod", Value: "static", EnvVar: "POD_NAME"},
+			},
+		},
+		expectedErr: "exactly one of value, envVar and header must be set",
+	}, {
+		name: "invalid propagation format",
+		tracingConfig: config.TracingSpec{
+			SamplingRate: "1",
+			Propagation:  "w3c,jaeger",
+		},
+		expectedErr: "invalid trace propagation format 'jaeger'",
 	}, {
 		name: "all trace exporters",
 		tracingConfig: config.TracingSpec{
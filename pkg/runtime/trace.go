@@ -15,8 +15,11 @@ package runtime
 
 import (
 	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
 	"go.opentelemetry.io/otel/sdk/resource"
 	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+
+	diag "github.com/dapr/dapr/pkg/diagnostics"
 )
 
 // tracerProviderStore allows us to capture the trace provider options
@@ -29,6 +32,8 @@ type tracerProviderStore interface {
 	RegisterExporter(exporter sdktrace.SpanExporter)
 	RegisterResource(res *resource.Resource)
 	RegisterSampler(sampler sdktrace.Sampler)
+	RegisterTailSamplingPolicy(policy *diag.TailSamplingPolicy)
+	RegisterSpanAttributes(attrs []attribute.KeyValue)
 	RegisterTracerProvider() *sdktrace.TracerProvider
 	HasExporter() bool
 }
@@ -36,14 +41,16 @@ type tracerProviderStore interface {
 // newOpentelemetryTracerProviderStore returns an opentelemetryOptionsStore
 func newOpentelemetryTracerProviderStore() *opentelemetryTracerProviderStore {
 	exps := []sdktrace.SpanExporter{}
-	return &opentelemetryTracerProviderStore{exps, nil, nil}
+	return &opentelemetryTracerProviderStore{exps, nil, nil, nil, nil}
 }
 
 // opentelemetryOptionsStore is an implementation of traceOptionsStore
 type opentelemetryTracerProviderStore struct {
-	exporters []sdktrace.SpanExporter
-	res       *resource.Resource
-	sampler   sdktrace.Sampler
+	exporters  []sdktrace.SpanExporter
+	res        *resource.Resource
+	sampler    sdktrace.Sampler
+	tailPolicy *diag.TailSamplingPolicy
+	spanAttrs  []attribute.KeyValue
 }
 
 // RegisterExporter adds a Span Exporter for registration with open telemetry global trace provider
@@ -66,11 +73,32 @@ func (s *opentelemetryTracerProviderStore) RegisterSampler(sampler sdktrace.Samp
 	s.sampler = sampler
 }
 
+// RegisterTailSamplingPolicy adds a tail sampling policy applied before the spans are exported
+func (s *opentelemetryTracerProviderStore) RegisterTailSamplingPolicy(policy *diag.TailSamplingPolicy) {
+	s.tailPolicy = policy
+}
+
+// RegisterSpanAttributes adds attributes set on every span when it starts
+func (s *opentelemetryTracerProviderStore) RegisterSpanAttributes(attrs []attribute.KeyValue) {
+	s.spanAttrs = attrs
+}
+
 // RegisterTraceProvider registers a trace provider as per the tracer options in the store
 func (s *opentelemetryTracerProviderStore) RegisterTracerProvider() *sdktrace.TracerProvider {
 	if len(s.exporters) != 0 {
-		tracerOptions := []sdktrace.TracerProviderOption{}
+		tracerOptions := []sdktrace.TracerProviderOption{
+			sdktrace.WithIDGenerator(diag.NewIDGenerator()),
+		}
+		// The attributes are set before the spans reach the processors that export them
+		if len(s.spanAttrs) > 0 {
+			tracerOptions = append(tracerOptions, sdktrace.WithSpanProcessor(diag.NewSpanAttributesProcessor(s.spanAttrs)))
+		}
 		for _, exporter := range s.exporters {
+			if s.tailPolicy != nil {
+				processor := diag.NewTailSamplingProcessor(sdktrace.NewBatchSpanProcessor(exporter), s.tailPolicy)
+				tracerOptions = append(tracerOptions, sdktrace.WithSpanProcessor(processor))
+				continue
+			}
 			tracerOptions = append(tracerOptions, sdktrace.WithBatcher(exporter))
 		}
 
@@ -95,15 +123,17 @@ func (s *opentelemetryTracerProviderStore) RegisterTracerProvider() *sdktrace.Tr
 //
 // This is only for use in unit tests.
 type fakeTracerProviderStore struct {
-	exporters []sdktrace.SpanExporter
-	res       *resource.Resource
-	sampler   sdktrace.Sampler
+	exporters  []sdktrace.SpanExporter
+	res        *resource.Resource
+	sampler    sdktrace.Sampler
+	tailPolicy *diag.TailSamplingPolicy
+	spanAttrs  []attribute.KeyValue
 }
 
 // newFakeTracerProviderStore returns an opentelemetryOptionsStore
 func newFakeTracerProviderStore() *fakeTracerProviderStore {
 	exps := []sdktrace.SpanExporter{}
-	return &fakeTracerProviderStore{exps, nil, nil}
+	return &fakeTracerProviderStore{exps, nil, nil, nil, nil}
 }
 
 // RegisterExporter adds a Span Exporter for registration with open telemetry global trace provider
@@ -121,6 +151,16 @@ func (s *fakeTracerProviderStore) RegisterSampler(sampler sdktrace.Sampler) {
 	s.sampler = sampler
 }
 
+// RegisterTailSamplingPolicy adds a tail sampling policy applied before the spans are exported
+func (s *fakeTracerProviderStore) RegisterTailSamplingPolicy(policy *diag.TailSamplingPolicy) {
+	s.tailPolicy = policy
+}
+
+// RegisterSpanAttributes adds attributes set on every span when it starts
+func (s *fakeTracerProviderStore) RegisterSpanAttributes(attrs []attribute.KeyValue) {
+	s.spanAttrs = attrs
+}
+
 // RegisterTraceProvider does nothing
 func (s *fakeTracerProviderStore) RegisterTracerProvider() *sdktrace.TracerProvider { return nil }
 
@@ -0,0 +1,246 @@
+/*
+Copyright 2023 The Dapr Authors
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package validate implements the "validate-only" mode of daprd, which loads all resources and runs static validation on them without starting the runtime.
+package validate
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+
+	componentsV1alpha1 "github.com/dapr/dapr/pkg/apis/components/v1alpha1"
+	"github.com/dapr/dapr/pkg/components"
+	"github.com/dapr/dapr/pkg/config"
+	"github.com/dapr/dapr/pkg/resiliency"
+	rtpubsub "github.com/dapr/dapr/pkg/runtime/pubsub"
+	"github.com/dapr/kit/logger"
+)
+
+const (
+	kindConfiguration = "Configuration"
+	kindComponent     = "Component"
+	kindSubscription  = "Subscription"
+	kindResiliency    = "Resiliency"
+)
+
+var log = logger.NewLogger("dapr.runtime.validate")
+
+// knownCategories contains the component categories supported by the runtime.
+var knownCategories = []components.Category{
+	components.CategoryBindings,
+	components.CategoryPubSub,
+	components.CategorySecretStore,
+	components.CategoryStateStore,
+	components.CategoryWorkflow,
+	components.CategoryMiddleware,
+	components.CategoryConfiguration,
+	components.CategoryCryptoProvider,
+	components.CategoryLock,
+	components.CategoryNameResolution,
+}
+
+// Options contains the options for validating resources.
+type Options struct {
+	AppID          string
+	Namespace      string
+	ConfigPaths    []string
+	ResourcesPaths []string
+}
+
+// Report is the machine-readable result of the validation.
+type Report struct {
+	Valid     bool             `json:"valid"`
+	Resources []ResourceReport `json:"resources"`
+}
+
+// ResourceReport contains the validation result for a single resource.
+// Errors that could not be attributed to a named resource (for example, files that could not be parsed) are reported with an empty name.
+type ResourceReport struct {
+	Kind   string   `json:"kind"`
+	Name   string   `json:"name,omitempty"`
+	Valid  bool     `json:"valid"`
+	Errors []string `json:"errors,omitempty"`
+}
+
+// WriteJSON writes the report as JSON to the given writer.
+func (r *Report) WriteJSON(w io.Writer) error {
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(r)
+}
+
+func (r *Report) add(kind, name string, errs ...error) {
+	res := ResourceReport{
+		Kind:  kind,
+		Name:  name,
+		Valid: len(errs) == 0,
+	}
+	for _, err := range errs {
+		res.Errors = append(res.Errors, err.Error())
+	}
+	if !res.Valid {
+		r.Valid = false
+	}
+	r.Resources = append(r.Resources, res)
+}
+
+// Run loads the Configuration, Components, Subscriptions and Resiliency resources and validates them.
+func Run(opts Options) *Report {
+	report := &Report{
+		Valid:     true,
+		Resources: []ResourceReport{},
+	}
+
+	validateConfiguration(report, opts)
+	comps := validateComponents(report, opts)
+	validateSubscriptions(report, opts, comps)
+	validateResiliency(report, opts)
+
+	return report
+}
+
+func validateConfiguration(report *Report, opts Options) {
+	for _, path := range opts.ConfigPaths {
+		_, err := config.LoadStandaloneConfiguration(path)
+		if err != nil {
+			report.add(kindConfiguration, path, err)
+			continue
+		}
+		report.add(kindConfiguration, path)
+	}
+}
+
+func validateComponents(report *Report, opts Options) []componentsV1alpha1.Component {
+	loader := components.NewDiskManifestLoader[componentsV1alpha1.Component](opts.ResourcesPaths...)
+	comps, loadErrs, err := loader.LoadWithErrors()
+	if err != nil {
+		report.add(kindComponent, "", err)
+		return nil
+	}
+	if len(loadErrs) > 0 {
+		report.add(kindComponent, "", loadErrs...)
+	}
+
+	// Index secret stores by name to verify secret references
+	secretStores := make(map[string]struct{})
+	for _, comp := range comps {
+		if strings.HasPrefix(comp.Spec.Type, string(components.CategorySecretStore)+".") {
+			secretStores[comp.Name] = struct{}{}
+		}
+	}
+
+	seen := make(map[string]struct{}, len(comps))
+	for _, comp := range comps {
+		var errs []error
+
+		if _, ok := seen[comp.Name]; ok {
+			errs = append(errs, fmt.Errorf("duplicate component name '%s'", comp.Name))
+		}
+		seen[comp.Name] = struct{}{}
+
+		if err := validateComponentType(comp.Spec.Type); err != nil {
+			errs = append(errs, err)
+		}
+
+		errs = append(errs, validateSecretReferences(comp, secretStores)...)
+
+		report.add(kindComponent, comp.Name, errs...)
+	}
+
+	return comps
+}
+
+func validateComponentType(typ string) error {
+	category, name, ok := strings.Cut(typ, ".")
+	if !ok || name == "" {
+		return fmt.Errorf("invalid component type '%s': must be in the format '<category>.<name>'", typ)
+	}
+	for _, c := range knownCategories {
+		if category == string(c) {
+			return nil
+		}
+	}
+	return fmt.Errorf("invalid component type '%s': unknown category '%s'", typ, category)
+}
+
+func validateSecretReferences(comp componentsV1alpha1.Component, secretStores map[string]struct{}) []error {
+	var errs []error
+	for _, md := range comp.NameValuePairs() {
+		if md.SecretKeyRef.Name == "" {
+			continue
+		}
+
+		secretStore := comp.GetSecretStore()
+		if secretStore == "" {
+			errs = append(errs, fmt.Errorf("metadata '%s' references secret '%s' but no secret store is set in auth.secretStore", md.Name, md.SecretKeyRef.Name))
+			continue
+		}
+		if _, ok := secretStores[secretStore]; !ok {
+			errs = append(errs, fmt.Errorf("metadata '%s' references secret store '%s' which is not defined", md.Name, secretStore))
+		}
+	}
+	return errs
+}
+
+func validateSubscriptions(report *Report, opts Options, comps []componentsV1alpha1.Component) {
+	pubsubs := make(map[string]struct{})
+	for _, comp := range comps {
+		if strings.HasPrefix(comp.Spec.Type, string(components.CategoryPubSub)+".") {
+			pubsubs[comp.Name] = struct{}{}
+		}
+	}
+
+	subs, loadErrs := rtpubsub.DeclarativeLocalWithErrors(opts.ResourcesPaths, opts.Namespace, log)
+	if len(loadErrs) > 0 {
+		report.add(kindSubscription, "", loadErrs...)
+	}
+
+	for _, sub := range subs {
+		var errs []error
+		if sub.Topic == "" {
+			errs = append(errs, errors.New("topic is required"))
+		}
+		if _, ok := pubsubs[sub.PubsubName]; !ok {
+			errs = append(errs, fmt.Errorf("pubsub component '%s' is not defined", sub.PubsubName))
+		}
+		if len(sub.Rules) == 0 {
+			errs = append(errs, errors.New("at least one route is required"))
+		}
+		for _, rule := range sub.Rules {
+			if rule.Path == "" {
+				errs = append(errs, errors.New("route path is required"))
+				break
+			}
+		}
+		report.add(kindSubscription, sub.PubsubName+"/"+sub.Topic, errs...)
+	}
+}
+
+func validateResiliency(report *Report, opts Options) {
+	configs, loadErrs := resiliency.LoadLocalResiliencyWithErrors(log, opts.AppID, opts.ResourcesPaths...)
+	if len(loadErrs) > 0 {
+		report.add(kindResiliency, "", loadErrs...)
+	}
+
+	for _, c := range configs {
+		err := resiliency.New(log).DecodeConfiguration(c)
+		if err != nil {
+			report.add(kindResiliency, c.Name, err)
+			continue
+		}
+		report.add(kindResiliency, c.Name)
+	}
+}
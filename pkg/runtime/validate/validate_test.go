@@ -0,0 +1,166 @@
+/*
+Copyright 2023 The Dapr Authors
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package validate
+
+import (
+	"bytes"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const validResources = `apiVersion: dapr.io/v1alpha1
+kind: Component
+metadata:
+  name: secretstore
+spec:
+  type: secretstores.local.env
+  version: v1
+---
+apiVersion: dapr.io/v1alpha1
+kind: Component
+metadata:
+  name: pubsub
+spec:
+  type: pubsub.in-memory
+  version: v1
+  metadata:
+  - name: password
+    secretKeyRef:
+      name: mysecret
+auth:
+  secretStore: secretstore
+---
+apiVersion: dapr.io/v2alpha1
+kind: Subscription
+metadata:
+  name: mysub
+spec:
+  pubsubname: pubsub
+  topic: orders
+  routes:
+    default: /orders
+---
+apiVersion: dapr.io/v1alpha1
+kind: Resiliency
+metadata:
+  name: myresiliency
+spec:
+  policies:
+    timeouts:
+      general: 5s
+  targets: {}
+`
+
+const invalidResources = `apiVersion: dapr.io/v1alpha1
+kind: Component
+metadata:
+  name: statestore
+spec:
+  type: foo.redis
+  version: v1
+  metadata:
+  - name: redisPassword
+    secretKeyRef:
+      name: redis
+      key: password
+auth:
+  secretStore: missing
+---
+apiVersion: dapr.io/v1alpha1
+kind: Subscription
+metadata:
+  name: mysub
+spec:
+  pubsubname: nopubsub
+  topic: orders
+`
+
+func writeFile(t *testing.T, dir, name, content string) string {
+	t.Helper()
+	p := filepath.Join(dir, name)
+	require.NoError(t, os.WriteFile(p, []byte(content), 0o600))
+	return p
+}
+
+func TestRun(t *testing.T) {
+	t.Run("valid resources", func(t *testing.T) {
+		dir := t.TempDir()
+		writeFile(t, dir, "resources.yaml", validResources)
+		configPath := writeFile(t, t.TempDir(), "config.yaml", "apiVersion: dapr.io/v1alpha1\nkind: Configuration\nmetadata:\n  name: config\n")
+
+		report := Run(Options{
+			AppID:          "myapp",
+			ConfigPaths:    []string{configPath},
+			ResourcesPaths: []string{dir},
+		})
+		assert.True(t, report.Valid, "%+v", report.Resources)
+		require.Len(t, report.Resources, 5)
+		for _, r := range report.Resources {
+			assert.True(t, r.Valid)
+			assert.Empty(t, r.Errors)
+		}
+	})
+
+	t.Run("invalid resources", func(t *testing.T) {
+		dir := t.TempDir()
+		writeFile(t, dir, "resources.yaml", invalidResources)
+
+		report := Run(Options{
+			AppID:          "myapp",
+			ConfigPaths:    []string{filepath.Join(dir, "doesnotexist.yaml")},
+			ResourcesPaths: []string{dir},
+		})
+		assert.False(t, report.Valid)
+
+		byKind := map[string]ResourceReport{}
+		for _, r := range report.Resources {
+			byKind[r.Kind] = r
+		}
+
+		require.Contains(t, byKind, kindConfiguration)
+		assert.False(t, byKind[kindConfiguration].Valid)
+
+		require.Contains(t, byKind, kindComponent)
+		comp := byKind[kindComponent]
+		assert.Equal(t, "statestore", comp.Name)
+		assert.False(t, comp.Valid)
+		require.Len(t, comp.Errors, 2)
+		assert.Contains(t, comp.Errors[0], "unknown category 'foo'")
+		assert.Contains(t, comp.Errors[1], "secret store 'missing' which is not defined")
+
+		require.Contains(t, byKind, kindSubscription)
+		sub := byKind[kindSubscription]
+		assert.Equal(t, "nopubsub/orders", sub.Name)
+		assert.False(t, sub.Valid)
+		require.Len(t, sub.Errors, 2)
+		assert.Contains(t, sub.Errors[0], "pubsub component 'nopubsub' is not defined")
+		assert.Contains(t, sub.Errors[1], "route path is required")
+	})
+
+	t.Run("report is serialized as JSON", func(t *testing.T) {
+		report := Run(Options{})
+		buf := &bytes.Buffer{}
+		require.NoError(t, report.WriteJSON(buf))
+
+		var decoded Report
+		require.NoError(t, json.Unmarshal(buf.Bytes(), &decoded))
+		assert.True(t, decoded.Valid)
+		assert.Empty(t, decoded.Resources)
+	})
+}
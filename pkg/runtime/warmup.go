@@ -0,0 +1,165 @@
+/*
+Copyright 2023 The Dapr Authors
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package runtime
+
+import (
+	"context"
+	"time"
+
+	"golang.org/x/exp/slices"
+
+	"github.com/dapr/dapr/pkg/grpc/universalapi"
+)
+
+const (
+	// Interval between the connectivity checks of the components that haven't passed yet.
+	warmupProbeInterval = time.Second
+	// Timeout of a single connectivity check.
+	warmupProbeTimeout = 5 * time.Second
+)
+
+// warmupProbe checks the connectivity of a component with a lightweight operation.
+type warmupProbe = func(ctx context.Context) error
+
+// componentWarmupProbe returns the probe of a component that implements a ping operation, or nil.
+func componentWarmupProbe(comp any) warmupProbe {
+	switch c := comp.(type) {
+	case interface{ Ping(context.Context) error }:
+		return c.Ping
+	case interface{ Ping() error }:
+		return func(context.Context) error {
+			return c.Ping()
+		}
+	default:
+		return nil
+	}
+}
+
+// warmupComponents checks the connectivity of the pubsub and binding components before the sidecar is marked as ready.
+// The checks are repeated until all the components pass or the timeout expires; in that case, the sidecar is marked as ready anyway and the failures are reported in the boot report.
+func (a *DaprRuntime) warmupComponents(ctx context.Context) error {
+	spec := a.globalConfig.GetComponentWarmupSpec()
+	if !spec.Enabled {
+		return nil
+	}
+	timeout, err := spec.GetTimeout()
+	if err != nil {
+		return err
+	}
+
+	pending := a.warmupProbes(spec.Skip)
+	if len(pending) == 0 {
+		return nil
+	}
+
+	log.Infof("Checking the connectivity of %d components before marking the sidecar as ready (timeout: %v)", len(pending), timeout)
+	deadline := a.clock.Now().Add(timeout)
+	for {
+		for name, probe := range pending {
+			probeCtx, cancel := context.WithTimeout(ctx, warmupProbeTimeout)
+			err := probe(probeCtx)
+			cancel()
+			a.recordWarmupAttempt(name, err)
+			if err == nil {
+				delete(pending, name)
+			}
+		}
+
+		if len(pending) == 0 {
+			log.Info("All components passed the connectivity checks")
+			return nil
+		}
+		if !a.clock.Now().Before(deadline) {
+			for name := range pending {
+				log.Warnf("Component %s did not pass the connectivity check before the timeout: %s", name, a.getWarmupResult(name).Error)
+			}
+			return nil
+		}
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-a.clock.After(warmupProbeInterval):
+		}
+	}
+}
+
+// warmupProbes returns the probes of the pubsub and binding components, keyed by component name.
+// Components that are skipped or that don't implement a ping operation are recorded as such, and are not returned.
+func (a *DaprRuntime) warmupProbes(skip []string) map[string]warmupProbe {
+	comps := map[string]any{}
+	for name, ps := range a.compStore.ListPubSubs() {
+		comps[name] = ps.Component
+	}
+	for name, binding := range a.compStore.ListInputBindings() {
+		comps[name] = binding
+	}
+	for name, binding := range a.compStore.ListOutputBindings() {
+		// Bindings with both directions are checked once
+		if _, ok := comps[name]; !ok {
+			comps[name] = binding
+		}
+	}
+
+	a.warmupLock.Lock()
+	defer a.warmupLock.Unlock()
+	a.warmupResults = make(map[string]*universalapi.BootReportWarmup, len(comps))
+
+	probes := make(map[string]warmupProbe, len(comps))
+	for name, comp := range comps {
+		if slices.Contains(skip, name) {
+			a.warmupResults[name] = &universalapi.BootReportWarmup{Status: universalapi.WarmupSkipped}
+			continue
+		}
+		probe := componentWarmupProbe(comp)
+		if probe == nil {
+			a.warmupResults[name] = &universalapi.BootReportWarmup{Status: universalapi.WarmupUnsupported}
+			continue
+		}
+		probes[name] = probe
+	}
+	return probes
+}
+
+func (a *DaprRuntime) recordWarmupAttempt(name string, err error) {
+	a.warmupLock.Lock()
+	defer a.warmupLock.Unlock()
+
+	res, ok := a.warmupResults[name]
+	if !ok {
+		res = &universalapi.BootReportWarmup{}
+		a.warmupResults[name] = res
+	}
+	res.Attempts++
+	if err != nil {
+		res.Status = universalapi.WarmupFailed
+		res.Error = err.Error()
+	} else {
+		res.Status = universalapi.WarmupPassed
+		res.Error = ""
+	}
+}
+
+// getWarmupResult returns a copy of the result of the connectivity check of a component, or nil if it wasn't checked.
+func (a *DaprRuntime) getWarmupResult(name string) *universalapi.BootReportWarmup {
+	a.warmupLock.RLock()
+	defer a.warmupLock.RUnlock()
+
+	res, ok := a.warmupResults[name]
+	if !ok {
+		return nil
+	}
+	c := *res
+	return &c
+}
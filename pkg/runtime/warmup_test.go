@@ -0,0 +1,112 @@
+/*
+Copyright 2023 The Dapr Authors
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package runtime
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"k8s.io/utils/clock"
+	clocktesting "k8s.io/utils/clock/testing"
+
+	"github.com/dapr/components-contrib/bindings"
+	"github.com/dapr/dapr/pkg/config"
+	"github.com/dapr/dapr/pkg/grpc/universalapi"
+	"github.com/dapr/dapr/pkg/runtime/compstore"
+	rtmock "github.com/dapr/dapr/pkg/runtime/mock"
+)
+
+// pingBinding is an output binding whose ping fails a number of times before succeeding.
+type pingBinding struct {
+	rtmock.Binding
+	failures int
+	pingFn   func()
+}
+
+func (b *pingBinding) Ping(ctx context.Context) error {
+	if b.pingFn != nil {
+		b.pingFn()
+	}
+	if b.failures > 0 {
+		b.failures--
+		return errors.New("connection refused")
+	}
+	return nil
+}
+
+func TestWarmupComponents(t *testing.T) {
+	newRuntime := func(spec config.ComponentWarmupSpec, clk clock.Clock) *DaprRuntime {
+		return &DaprRuntime{
+			globalConfig: &config.Configuration{
+				Spec: config.ConfigurationSpec{
+					ComponentsSpec: &config.ComponentsSpec{Warmup: &spec},
+				},
+			},
+			compStore: compstore.New(),
+			clock:     clk,
+		}
+	}
+
+	t.Run("disabled", func(t *testing.T) {
+		rt := newRuntime(config.ComponentWarmupSpec{}, &clock.RealClock{})
+		rt.compStore.AddOutputBinding("out", &pingBinding{failures: 100})
+
+		require.NoError(t, rt.warmupComponents(context.Background()))
+		assert.Nil(t, rt.getWarmupResult("out"))
+	})
+
+	t.Run("components are retried until they pass", func(t *testing.T) {
+		rt := newRuntime(config.ComponentWarmupSpec{Enabled: true, Skip: []string{"skipped"}}, &clock.RealClock{})
+		rt.compStore.AddOutputBinding("out", &pingBinding{failures: 1})
+		rt.compStore.AddOutputBinding("skipped", &pingBinding{failures: 100})
+		rt.compStore.AddOutputBinding("noping", &rtmock.Binding{})
+
+		require.NoError(t, rt.warmupComponents(context.Background()))
+		assert.Equal(t, &universalapi.BootReportWarmup{Status: universalapi.WarmupPassed, Attempts: 2}, rt.getWarmupResult("out"))
+		assert.Equal(t, &universalapi.BootReportWarmup{Status: universalapi.WarmupSkipped}, rt.getWarmupResult("skipped"))
+		assert.Equal(t, &universalapi.BootReportWarmup{Status: universalapi.WarmupUnsupported}, rt.getWarmupResult("noping"))
+	})
+
+	t.Run("readiness is not delayed past the timeout", func(t *testing.T) {
+		fakeClock := clocktesting.NewFakeClock(time.Now())
+		rt := newRuntime(config.ComponentWarmupSpec{Enabled: true, Timeout: "10s"}, fakeClock)
+		rt.compStore.AddOutputBinding("out", &pingBinding{
+			failures: 100,
+			pingFn: func() {
+				fakeClock.Step(10 * time.Second)
+			},
+		})
+
+		require.NoError(t, rt.warmupComponents(context.Background()))
+		assert.Equal(t, &universalapi.BootReportWarmup{Status: universalapi.WarmupFailed, Error: "connection refused", Attempts: 1}, rt.getWarmupResult("out"))
+	})
+
+	t.Run("invalid timeout", func(t *testing.T) {
+		rt := newRuntime(config.ComponentWarmupSpec{Enabled: true, Timeout: "soon"}, &clock.RealClock{})
+
+		require.Error(t, rt.warmupComponents(context.Background()))
+	})
+}
+
+func TestComponentWarmupProbe(t *testing.T) {
+	assert.NotNil(t, componentWarmupProbe(&pingBinding{}))
+	assert.Nil(t, componentWarmupProbe(&rtmock.Binding{}))
+
+	var out bindings.OutputBinding = &pingBinding{}
+	require.NoError(t, componentWarmupProbe(out)(context.Background()))
+}
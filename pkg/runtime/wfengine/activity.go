@@ -28,6 +28,7 @@ import (
 	"github.com/microsoft/durabletask-go/backend"
 
 	"github.com/dapr/dapr/pkg/actors"
+	diag "github.com/dapr/dapr/pkg/diagnostics"
 	invokev1 "github.com/dapr/dapr/pkg/messaging/v1"
 )
 
@@ -43,6 +44,7 @@ type activityActor struct {
 	defaultTimeout   time.Duration
 	reminderInterval time.Duration
 	config           actorsBackendConfig
+	workItems        *workItemTracker
 }
 
 // ActivityRequest represents a request by a worklow to invoke an activity.
@@ -52,6 +54,8 @@ type ActivityRequest struct {
 
 type activityState struct {
 	EventPayload []byte
+	// Baggage is the W3C baggage of the workflow that scheduled the activity, which is restored when the activity executes.
+	Baggage string
 }
 
 // activityScheduler is a func interface for pushing activity work items into the backend
@@ -96,6 +100,7 @@ func (a *activityActor) InvokeMethod(ctx context.Context, actorID string, method
 	// Save the request details to the state store in case we need it after recovering from a failure.
 	state := activityState{
 		EventPayload: ar.HistoryEvent,
+		Baggage:      diag.BaggageString(ctx),
 	}
 
 	if err := a.saveActivityState(ctx, actorID, state); err != nil {
@@ -110,14 +115,29 @@ func (a *activityActor) InvokeMethod(ctx context.Context, actorID string, method
 // InvokeReminder implements actors.InternalActor and executes the activity logic.
 func (a *activityActor) InvokeReminder(ctx context.Context, actorID string, reminderName string, data []byte, dueTime string, period string) error {
 	wfLogger.Debugf("Activity actor '%s': invoking reminder '%s'", actorID, reminderName)
+	firedAt := time.Now()
+
+	execCtx, finish, ok := a.workItems.start(ctx, diag.ActivityReminder)
+	if !ok {
+		wfLogger.Debugf("Activity actor '%s': workflow engine is shutting down, execution of '%s' will be retried later", actorID, reminderName)
+
+		// Returning nil keeps the reminder, so the execution is retried in the next period interval
+		return nil
+	}
 
 	state, _ := a.loadActivityState(ctx, actorID)
 	// TODO: On error, reply with a failure - this requires support from durabletask-go to produce TaskFailure results
 
-	timeoutCtx, cancelTimeout := context.WithTimeout(ctx, a.defaultTimeout)
+	timeoutCtx, cancelTimeout := context.WithTimeout(diag.ContextWithBaggageString(execCtx, state.Baggage), a.defaultTimeout)
 	defer cancelTimeout()
 
-	if err := a.executeActivity(timeoutCtx, actorID, reminderName, state.EventPayload); err != nil {
+	start := time.Now()
+	activityName, err := a.executeActivity(timeoutCtx, actorID, reminderName, state.EventPayload, firedAt)
+	elapsed := diag.ElapsedSince(start)
+	finish(err)
+	recordReminderEvent(ctx, diag.ActivityReminder, diag.FireReminder, err)
+	recordExecutionEvent(ctx, diag.ActivityReminder, activityName, err, elapsed)
+	if err != nil {
 		var recoverableErr *recoverableError
 		switch {
 		case errors.Is(err, context.DeadlineExceeded):
@@ -142,18 +162,31 @@ func (a *activityActor) InvokeReminder(ctx context.Context, actorID string, remi
 	return actors.ErrReminderCanceled
 }
 
-func (a *activityActor) executeActivity(ctx context.Context, actorID string, name string, eventPayload []byte) error {
+// executeActivity executes the activity in response to a reminder that fired at firedAt, and returns the name of the activity if it is known.
+func (a *activityActor) executeActivity(ctx context.Context, actorID string, name string, eventPayload []byte, firedAt time.Time) (activityName string, err error) {
 	taskEvent, err := backend.UnmarshalHistoryEvent(eventPayload)
 	if err != nil {
-		return err
+		return activityName, err
+	}
+	activityName = taskEvent.GetTaskScheduled().GetName()
+
+	// Restore the trace context stamped by the workflow, so the activity is parented to the request that started the workflow
+	if tc := taskEvent.GetTaskScheduled().GetParentTraceContext(); tc != nil {
+		ctx = contextWithParentTraceContext(ctx, tc.GetTraceParent(), tc.GetTraceState().GetValue())
 	}
 
 	endIndex := strings.Index(actorID, "::")
 	if endIndex < 0 {
-		return fmt.Errorf("invalid activity actor ID: '%s'", actorID)
+		return activityName, fmt.Errorf("invalid activity actor ID: '%s'", actorID)
 	}
 	workflowID := actorID[0:endIndex]
 
+	ctx, span := startActivitySpan(ctx, workflowID, taskEvent)
+	var result *backend.HistoryEvent
+	defer func() {
+		endActivitySpan(span, result, err)
+	}()
+
 	wi := &backend.ActivityWorkItem{
 		SequenceNumber: int64(taskEvent.GetEventId()),
 		InstanceID:     api.InstanceID(workflowID),
@@ -171,10 +204,12 @@ func (a *activityActor) executeActivity(ctx context.Context, actorID string, nam
 	wfLogger.Debugf("Activity actor '%s': scheduling activity '%s' for workflow with instanceId '%s'", actorID, name, wi.InstanceID)
 	if err = a.scheduler(ctx, wi); err != nil {
 		if errors.Is(err, context.DeadlineExceeded) {
-			return newRecoverableError(fmt.Errorf("timed-out trying to schedule an activity execution - this can happen if too many activities are running in parallel or if the workflow engine isn't running: %w", err))
+			return activityName, newRecoverableError(fmt.Errorf("timed-out trying to schedule an activity execution - this can happen if too many activities are running in parallel or if the workflow engine isn't running: %w", err))
 		}
-		return newRecoverableError(fmt.Errorf("failed to schedule an activity execution: %w", err))
+		return activityName, newRecoverableError(fmt.Errorf("failed to schedule an activity execution: %w", err))
 	}
+	// The scheduler returns when the engine picks up the work item, so this is the time between the reminder firing and the execution starting
+	diag.DefaultWorkflowMonitoring.WorkflowSchedulingEvent(ctx, diag.ActivityReminder, diag.ElapsedSince(firedAt))
 
 loop:
 	for {
@@ -184,7 +219,7 @@ loop:
 			if !t.Stop() {
 				<-t.C
 			}
-			return ctx.Err() // will be retried
+			return activityName, ctx.Err() // will be retried
 		case <-t.C:
 			if deadline, ok := ctx.Deadline(); ok {
 				wfLogger.Warnf("Activity actor '%s': '%s' is still running - will keep waiting until '%v'", actorID, name, deadline)
@@ -198,16 +233,17 @@ loop:
 			if completed {
 				break loop
 			} else {
-				return newRecoverableError(errExecutionAborted) // AbandonActivityWorkItem was called
+				return activityName, newRecoverableError(errExecutionAborted) // AbandonActivityWorkItem was called
 			}
 		}
 	}
 	wfLogger.Debugf("Activity actor '%s': activity '%s' completed for workflow with instanceId '%s' ", actorID, name, wi.InstanceID)
+	result = wi.Result
 
 	// publish the result back to the workflow actor as a new event to be processed
 	resultData, err := backend.MarshalHistoryEvent(wi.Result)
 	if err != nil {
-		return err
+		return activityName, err
 	}
 	req := invokev1.
 		NewInvokeMethodRequest(AddWorkflowEventMethod).
@@ -218,10 +254,10 @@ loop:
 
 	resp, err := a.actorRuntime.Call(ctx, req)
 	if err != nil {
-		return newRecoverableError(fmt.Errorf("failed to invoke '%s' method on workflow actor: %w", AddWorkflowEventMethod, err))
+		return activityName, newRecoverableError(fmt.Errorf("failed to invoke '%s' method on workflow actor: %w", AddWorkflowEventMethod, err))
 	}
 	defer resp.Close()
-	return nil
+	return activityName, nil
 }
 
 // InvokeTimer implements actors.InternalActor
@@ -316,7 +352,7 @@ func (a *activityActor) createReliableReminder(ctx context.Context, actorID stri
 	if err != nil {
 		return fmt.Errorf("failed to encode data as JSON: %w", err)
 	}
-	return a.actorRuntime.CreateReminder(ctx, &actors.CreateReminderRequest{
+	err = a.actorRuntime.CreateReminder(ctx, &actors.CreateReminderRequest{
 		ActorType: a.config.activityActorType,
 		ActorID:   actorID,
 		Data:      dataEnc,
@@ -324,4 +360,6 @@ func (a *activityActor) createReliableReminder(ctx context.Context, actorID stri
 		Name:      reminderName,
 		Period:    a.reminderInterval.String(),
 	})
+	recordReminderEvent(ctx, diag.ActivityReminder, diag.CreateReminder, err)
+	return err
 }
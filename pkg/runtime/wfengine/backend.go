@@ -34,23 +34,36 @@ type actorsBackendConfig struct {
 	AppID             string
 	workflowActorType string
 	activityActorType string
+	indexActorType    string
 }
 
 // NewActorsBackendConfig creates a new workflow engine configuration
 func NewActorsBackendConfig(appID string) actorsBackendConfig {
 	return actorsBackendConfig{
 		AppID:             appID,
-		workflowActorType: actors.InternalActorTypePrefix + utils.GetNamespaceOrDefault(defaultNamespace) + utils.DotDelimiter + appID + utils.DotDelimiter + WorkflowNameLabelKey,
-		activityActorType: actors.InternalActorTypePrefix + utils.GetNamespaceOrDefault(defaultNamespace) + utils.DotDelimiter + appID + utils.DotDelimiter + ActivityNameLabelKey,
+		workflowActorType: getInternalActorType(appID, WorkflowNameLabelKey),
+		activityActorType: getInternalActorType(appID, ActivityNameLabelKey),
+		indexActorType:    getInternalActorType(appID, IndexNameLabelKey),
 	}
 }
 
+// getInternalActorType returns the type of the internal actors of the workflow engine of an app, such as its workflow actors.
+// The actors of the other apps in the same namespace are invoked with their type.
+func getInternalActorType(appID string, label string) string {
+	return actors.InternalActorTypePrefix + utils.GetNamespaceOrDefault(defaultNamespace) + utils.DotDelimiter + appID + utils.DotDelimiter + label
+}
+
+// GetWorkflowActorType returns the type of the internal actors that run the workflows of the app with the given ID.
+func GetWorkflowActorType(appID string) string {
+	return getInternalActorType(appID, WorkflowNameLabelKey)
+}
+
 // String implements fmt.Stringer and is primarily used for debugging purposes.
 func (c *actorsBackendConfig) String() string {
 	if c == nil {
 		return "(nil)"
 	}
-	return fmt.Sprintf("AppID:'%s', workflowActorType:'%s', activityActorType:'%s'", c.AppID, c.workflowActorType, c.activityActorType)
+	return fmt.Sprintf("AppID:'%s', workflowActorType:'%s', activityActorType:'%s', indexActorType:'%s'", c.AppID, c.workflowActorType, c.activityActorType, c.indexActorType)
 }
 
 type actorBackend struct {
@@ -61,6 +74,8 @@ type actorBackend struct {
 	config                    actorsBackendConfig
 	workflowActor             *workflowActor
 	activityActor             *activityActor
+	indexActor                *workflowIndexActor
+	workItems                 *workItemTracker
 }
 
 func NewActorBackend(appID string) *actorBackend {
@@ -70,12 +85,21 @@ func NewActorBackend(appID string) *actorBackend {
 	orchestrationWorkItemChan := make(chan *backend.OrchestrationWorkItem)
 	activityWorkItemChan := make(chan *backend.ActivityWorkItem)
 
+	// Executions of workflows and activities are tracked, so they can be quiesced on shutdown
+	workItems := newWorkItemTracker()
+	workflowActor := NewWorkflowActor(getWorkflowScheduler(orchestrationWorkItemChan), backendConfig)
+	workflowActor.workItems = workItems
+	activityActor := NewActivityActor(getActivityScheduler(activityWorkItemChan), backendConfig)
+	activityActor.workItems = workItems
+
 	return &actorBackend{
 		orchestrationWorkItemChan: orchestrationWorkItemChan,
 		activityWorkItemChan:      activityWorkItemChan,
 		config:                    backendConfig,
-		workflowActor:             NewWorkflowActor(getWorkflowScheduler(orchestrationWorkItemChan), backendConfig),
-		activityActor:             NewActivityActor(getActivityScheduler(activityWorkItemChan), backendConfig),
+		workflowActor:             workflowActor,
+		activityActor:             activityActor,
+		indexActor:                NewWorkflowIndexActor(backendConfig),
+		workItems:                 workItems,
 	}
 }
 
@@ -114,6 +138,7 @@ func (be *actorBackend) GetInternalActorsMap() map[string]actors.InternalActor {
 	internalActors := make(map[string]actors.InternalActor)
 	internalActors[be.config.workflowActorType] = be.workflowActor
 	internalActors[be.config.activityActorType] = be.activityActor
+	internalActors[be.config.indexActorType] = be.indexActor
 	return internalActors
 }
 
@@ -198,6 +223,27 @@ func (be *actorBackend) GetOrchestrationMetadata(ctx context.Context, id api.Ins
 	return &metadata, nil
 }
 
+// GetWorkflowHistory returns the history of the workflow instance, which is exported by the corresponding actor.
+func (be *actorBackend) GetWorkflowHistory(ctx context.Context, id api.InstanceID) (*WorkflowHistory, error) {
+	req := invokev1.
+		NewInvokeMethodRequest(GetWorkflowHistoryMethod).
+		WithActor(be.config.workflowActorType, string(id)).
+		WithContentType(invokev1.OctetStreamContentType)
+	defer req.Close()
+
+	res, err := be.actors.Call(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	defer res.Close()
+	var history WorkflowHistory
+	if err := actors.DecodeInternalActorData(res.RawData(), &history); err != nil {
+		return nil, fmt.Errorf("failed to decode the internal actor response: %w", err)
+	}
+	return &history, nil
+}
+
 // AbandonActivityWorkItem implements backend.Backend. It gets called by durabletask-go when there is
 // an unexpected failure in the workflow activity execution pipeline.
 func (*actorBackend) AbandonActivityWorkItem(ctx context.Context, wi *backend.ActivityWorkItem) error {
@@ -306,17 +352,8 @@ func (be *actorBackend) GetOrchestrationWorkItem(ctx context.Context) (*backend.
 
 // PurgeOrchestrationState deletes all saved state for the specific orchestration instance.
 func (be *actorBackend) PurgeOrchestrationState(ctx context.Context, id api.InstanceID) error {
-	req := invokev1.
-		NewInvokeMethodRequest(PurgeWorkflowStateMethod).
-		WithActor(be.config.workflowActorType, string(id))
-	defer req.Close()
-
-	resp, err := be.actors.Call(ctx, req)
-	if err != nil {
-		return err
-	}
-	defer resp.Close()
-	return nil
+	_, err := purgeWorkflowInstance(ctx, be.actors, be.config, string(id))
+	return err
 }
 
 // Start implements backend.Backend
@@ -59,15 +59,19 @@ var statusMap = map[int32]string{
 func BuiltinWorkflowFactory(engine *WorkflowEngine) func(logger.Logger) workflows.Workflow {
 	return func(logger logger.Logger) workflows.Workflow {
 		return &workflowEngineComponent{
-			logger: logger,
-			client: backend.NewTaskHubClient(engine.backend),
+			logger:  logger,
+			client:  backend.NewTaskHubClient(engine.backend),
+			backend: engine.backend,
+			engine:  engine,
 		}
 	}
 }
 
 type workflowEngineComponent struct {
-	logger logger.Logger
-	client backend.TaskHubClient
+	logger  logger.Logger
+	client  backend.TaskHubClient
+	backend *actorBackend
+	engine  *WorkflowEngine
 }
 
 func (c *workflowEngineComponent) Init(metadata workflows.Metadata) error {
@@ -102,7 +106,8 @@ func (c *workflowEngineComponent) Start(ctx context.Context, req *workflows.Star
 		}
 	}
 
-	workflowID, err := c.client.ScheduleNewOrchestration(ctx, req.WorkflowName, opts...)
+	// The trace context of the request is stamped into the workflow history, so the workflow and its activities are parented to it
+	workflowID, err := c.client.ScheduleNewOrchestration(contextWithSpan(ctx), req.WorkflowName, opts...)
 	if err != nil {
 		return nil, fmt.Errorf("unable to start workflow: %w", err)
 	}
@@ -252,6 +257,25 @@ func (c *workflowEngineComponent) PurgeWorkflow(ctx context.Context, req *workfl
 	return nil
 }
 
+// ListWorkflows lists the workflow instances, from the index of the workflow instances maintained by the engine.
+func (c *workflowEngineComponent) ListWorkflows(ctx context.Context, req *ListWorkflowsRequest) (*ListWorkflowsResponse, error) {
+	res, err := c.backend.ListWorkflows(ctx, req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list workflows: %w", err)
+	}
+	return res, nil
+}
+
+// ExportHistory returns the history of the workflow instance.
+func (c *workflowEngineComponent) ExportHistory(ctx context.Context, instanceID string) (*WorkflowHistory, error) {
+	return c.engine.ExportHistory(ctx, instanceID)
+}
+
+// ReplayHistory replays the history of a workflow instance against the workflow code of the app.
+func (c *workflowEngineComponent) ReplayHistory(ctx context.Context, history *WorkflowHistory) (*ReplayReport, error) {
+	return c.engine.ReplayHistory(ctx, history)
+}
+
 func getStatusString(status int32) string {
 	if statusStr, ok := statusMap[status]; ok {
 		return statusStr
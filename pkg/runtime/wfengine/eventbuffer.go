@@ -0,0 +1,189 @@
+/*
+Copyright 2023 The Dapr Authors
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package wfengine
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/microsoft/durabletask-go/backend"
+
+	"github.com/dapr/dapr/pkg/actors"
+	diag "github.com/dapr/dapr/pkg/diagnostics"
+)
+
+const (
+	// bufferedEventsKey is the key of the state of the workflow actor with the events raised on the instance before it was created.
+	bufferedEventsKey = "bufferedEvents"
+	// bufferExpiryReminderName is the name of the reminder that drops the buffered events of a workflow actor when they expire.
+	// It's created with the first buffered event, and fires until all the events expired or were delivered.
+	bufferExpiryReminderName = "buffer-expiry"
+	// maxBufferedEvents is the maximum number of events buffered for a workflow instance.
+	maxBufferedEvents = 100
+)
+
+// errEventBufferFull is returned when an event is raised on a workflow instance that doesn't exist, and the buffer of its events is full.
+var errEventBufferFull = fmt.Errorf("too many events were raised on the workflow instance before it was created: at most %d events are buffered", maxBufferedEvents)
+
+// bufferedEvent is an event raised on a workflow instance before it was created.
+type bufferedEvent struct {
+	Bytes     []byte    `json:"bytes"`
+	ExpiresAt time.Time `json:"expiresAt"`
+}
+
+// bufferWorkflowEvent saves an event raised on a workflow instance that doesn't exist yet, so it's delivered if the instance is created before it expires.
+// Durable Task already keeps the events a running workflow isn't waiting on yet, so only the events that arrive before the workflow is created need to be buffered.
+func (wf *workflowActor) bufferWorkflowEvent(ctx context.Context, actorID string, historyEventBytes []byte) error {
+	events, err := wf.loadBufferedEvents(ctx, actorID)
+	if err != nil {
+		return err
+	}
+
+	now := time.Now()
+	events, expired := removeExpiredEvents(events, now)
+	if len(events) >= maxBufferedEvents {
+		return errEventBufferFull
+	}
+
+	// The reminder is created with the first event, before the event is saved, so a buffered event is never left without one
+	if len(events) == 0 {
+		if err = wf.createBufferExpiryReminder(ctx, actorID); err != nil {
+			return err
+		}
+	}
+
+	events = append(events, bufferedEvent{
+		Bytes:     historyEventBytes,
+		ExpiresAt: now.Add(wf.eventBufferTTL),
+	})
+	wfLogger.Debugf("Workflow actor '%s': workflow doesn't exist, buffering event for %v", actorID, wf.eventBufferTTL)
+	if err = wf.saveBufferedEvents(ctx, actorID, events); err != nil {
+		return err
+	}
+	diag.DefaultWorkflowMonitoring.WorkflowBufferedEvents(ctx, diag.EventBuffered, 1)
+	diag.DefaultWorkflowMonitoring.WorkflowBufferedEvents(ctx, diag.EventExpired, int64(expired))
+	return nil
+}
+
+// createBufferExpiryReminder creates the reminder that drops the buffered events of the workflow actor when they expire.
+// It first fires when the first event expires, then every reminder interval until no events are left.
+func (wf *workflowActor) createBufferExpiryReminder(ctx context.Context, actorID string) error {
+	err := wf.actors.CreateReminder(ctx, &actors.CreateReminderRequest{
+		ActorType: wf.config.workflowActorType,
+		ActorID:   actorID,
+		Name:      bufferExpiryReminderName,
+		DueTime:   wf.eventBufferTTL.String(),
+		Period:    wf.reminderInterval.String(),
+	})
+	recordReminderEvent(ctx, diag.WorkflowReminder, diag.CreateReminder, err)
+	return err
+}
+
+// expireBufferedEvents drops the buffered events of the workflow actor that expired.
+// It returns the number of events that are still buffered.
+func (wf *workflowActor) expireBufferedEvents(ctx context.Context, actorID string) (int, error) {
+	events, err := wf.loadBufferedEvents(ctx, actorID)
+	if err != nil {
+		return 0, err
+	}
+	events, expired := removeExpiredEvents(events, time.Now())
+	if expired == 0 {
+		return len(events), nil
+	}
+
+	wfLogger.Debugf("Workflow actor '%s': dropping %d buffered events that expired before the workflow was created", actorID, expired)
+	if err = wf.saveBufferedEvents(ctx, actorID, events); err != nil {
+		return 0, err
+	}
+	diag.DefaultWorkflowMonitoring.WorkflowBufferedEvents(ctx, diag.EventExpired, int64(expired))
+	return len(events), nil
+}
+
+// addBufferedEventsToInbox adds the buffered events of the workflow actor that didn't expire to the inbox of the workflow that is being created.
+// The buffered events are deleted when the state is saved.
+// It returns the number of events that were delivered, and of those that expired.
+func (wf *workflowActor) addBufferedEventsToInbox(ctx context.Context, actorID string, state *workflowState) (delivered int, expired int, err error) {
+	events, err := wf.loadBufferedEvents(ctx, actorID)
+	if err != nil || len(events) == 0 {
+		return 0, 0, err
+	}
+	events, expired = removeExpiredEvents(events, time.Now())
+
+	for _, be := range events {
+		e, err := backend.UnmarshalHistoryEvent(be.Bytes)
+		if err != nil {
+			return 0, 0, fmt.Errorf("failed to unmarshal buffered event: %w", err)
+		}
+		state.AddToInbox(e)
+	}
+	state.clearBufferedEvents = true
+	wfLogger.Debugf("Workflow actor '%s': delivering %d buffered events to the workflow", actorID, len(events))
+	return len(events), expired, nil
+}
+
+func (wf *workflowActor) loadBufferedEvents(ctx context.Context, actorID string) ([]bufferedEvent, error) {
+	res, err := wf.actors.GetState(ctx, &actors.GetStateRequest{
+		ActorType: wf.config.workflowActorType,
+		ActorID:   actorID,
+		Key:       bufferedEventsKey,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to load the buffered events: %w", err)
+	}
+
+	var events []bufferedEvent
+	if len(res.Data) > 0 {
+		if err = json.Unmarshal(res.Data, &events); err != nil {
+			return nil, fmt.Errorf("failed to unmarshal the buffered events: %w", err)
+		}
+	}
+	return events, nil
+}
+
+func (wf *workflowActor) saveBufferedEvents(ctx context.Context, actorID string, events []bufferedEvent) error {
+	op := actors.TransactionalOperation{
+		Operation: actors.Upsert,
+		Request:   actors.TransactionalUpsert{Key: bufferedEventsKey, Value: events},
+	}
+	if len(events) == 0 {
+		op = actors.TransactionalOperation{
+			Operation: actors.Delete,
+			Request:   actors.TransactionalDelete{Key: bufferedEventsKey},
+		}
+	}
+
+	err := wf.actors.TransactionalStateOperation(ctx, &actors.TransactionalRequest{
+		ActorType:  wf.config.workflowActorType,
+		ActorID:    actorID,
+		Operations: []actors.TransactionalOperation{op},
+	})
+	if err != nil {
+		return fmt.Errorf("failed to save the buffered events: %w", err)
+	}
+	return nil
+}
+
+// removeExpiredEvents returns the events that didn't expire at now, and the number of events that expired.
+func removeExpiredEvents(events []bufferedEvent, now time.Time) ([]bufferedEvent, int) {
+	n := 0
+	for _, e := range events {
+		if e.ExpiresAt.After(now) {
+			events[n] = e
+			n++
+		}
+	}
+	return events[:n], len(events) - n
+}
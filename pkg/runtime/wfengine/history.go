@@ -0,0 +1,237 @@
+/*
+Copyright 2023 The Dapr Authors
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package wfengine
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/google/uuid"
+	"github.com/microsoft/durabletask-go/api"
+	"github.com/microsoft/durabletask-go/backend"
+	"google.golang.org/protobuf/encoding/protojson"
+)
+
+// WorkflowHistory is the history of a workflow instance, exported in a portable format.
+// Each event is the JSON representation of a durabletask history event.
+type WorkflowHistory struct {
+	InstanceID string            `json:"instanceID"`
+	Events     []json.RawMessage `json:"events"`
+}
+
+// ReplayReport is the result of replaying the history of a workflow instance against the workflow code of the app.
+type ReplayReport struct {
+	InstanceID string `json:"instanceID"`
+	// Number of workflow executions ("episodes") found in the history and replayed.
+	Episodes    int                `json:"episodes"`
+	Divergences []ReplayDivergence `json:"divergences,omitempty"`
+}
+
+// ReplayDivergence is a point at which the workflow code of the app did not produce the actions recorded in the history.
+type ReplayDivergence struct {
+	// Index of the episode in which the replay diverged, starting at 0.
+	Episode int `json:"episode"`
+	// Index of the action within the episode.
+	Action int `json:"action"`
+	// Action recorded in the history, empty if the workflow code produced more actions.
+	Expected string `json:"expected,omitempty"`
+	// Action produced by the workflow code, empty if it produced fewer actions.
+	Actual string `json:"actual,omitempty"`
+}
+
+// NewWorkflowHistory exports the given history events.
+func NewWorkflowHistory(instanceID string, events []*backend.HistoryEvent) (*WorkflowHistory, error) {
+	h := &WorkflowHistory{
+		InstanceID: instanceID,
+		Events:     make([]json.RawMessage, len(events)),
+	}
+	for i, e := range events {
+		b, err := protojson.Marshal(e)
+		if err != nil {
+			return nil, fmt.Errorf("failed to marshal history event %d: %w", i, err)
+		}
+		h.Events[i] = b
+	}
+	return h, nil
+}
+
+// HistoryEvents returns the history events.
+func (h *WorkflowHistory) HistoryEvents() ([]*backend.HistoryEvent, error) {
+	events := make([]*backend.HistoryEvent, len(h.Events))
+	for i, b := range h.Events {
+		e := &backend.HistoryEvent{}
+		if err := protojson.Unmarshal(b, e); err != nil {
+			return nil, fmt.Errorf("failed to unmarshal history event %d: %w", i, err)
+		}
+		events[i] = e
+	}
+	return events, nil
+}
+
+// ExportHistory returns the history of the workflow instance.
+func (wfe *WorkflowEngine) ExportHistory(ctx context.Context, instanceID string) (*WorkflowHistory, error) {
+	if wfe.backend.actors == nil {
+		return nil, errors.New("actor runtime is not configured")
+	}
+	return wfe.backend.GetWorkflowHistory(ctx, api.InstanceID(instanceID))
+}
+
+// ReplayHistory re-executes the history of a workflow instance against the workflow code of the connected app, in a
+// diagnostic mode: each episode of the history is sent to the app with the events that preceded it, and the actions
+// returned by the app are compared to the ones recorded in the history. Differences indicate non-deterministic code.
+// Nothing is persisted and no activity is executed. The history is replayed with a new, random instance ID, so the replay
+// doesn't interfere with an execution of the instance in progress.
+func (wfe *WorkflowEngine) ReplayHistory(ctx context.Context, history *WorkflowHistory) (*ReplayReport, error) {
+	if !wfe.IsReady() {
+		return nil, errors.New("workflow engine is not ready: the workflow app must be connected")
+	}
+
+	events, err := history.HistoryEvents()
+	if err != nil {
+		return nil, err
+	}
+
+	replayID := api.InstanceID("replay-" + uuid.NewString())
+	report := &ReplayReport{InstanceID: history.InstanceID}
+	for _, ep := range splitEpisodes(events) {
+		report.Episodes++
+		results, err := wfe.executor.ExecuteOrchestrator(ctx, replayID, events[:ep.start], ep.inbound)
+		if err != nil {
+			if ctx.Err() != nil {
+				return nil, ctx.Err()
+			}
+			report.Divergences = append(report.Divergences, ReplayDivergence{
+				Episode:  report.Episodes - 1,
+				Expected: describeEvents(ep.outbound),
+				Actual:   "execution failed: " + err.Error(),
+			})
+			continue
+		}
+
+		actual := make([]string, 0, len(ep.outbound))
+		for _, a := range results.Response.GetActions() {
+			var desc string
+			switch {
+			case a.GetScheduleTask() != nil:
+				desc = "ScheduleTask(" + a.GetScheduleTask().GetName() + ")#" + strconv.Itoa(int(a.GetId()))
+			case a.GetCreateTimer() != nil:
+				desc = "CreateTimer#" + strconv.Itoa(int(a.GetId()))
+			case a.GetCreateSubOrchestration() != nil:
+				desc = "CreateSubOrchestration(" + a.GetCreateSubOrchestration().GetName() + ")#" + strconv.Itoa(int(a.GetId()))
+			case a.GetSendEvent() != nil:
+				desc = "SendEvent(" + a.GetSendEvent().GetName() + ")#" + strconv.Itoa(int(a.GetId()))
+			case a.GetCompleteOrchestration() != nil:
+				desc = "CompleteOrchestration(" + a.GetCompleteOrchestration().GetOrchestrationStatus().String() + ")"
+			default:
+				desc = fmt.Sprintf("%T", a.GetOrchestratorActionType())
+			}
+			actual = append(actual, desc)
+		}
+
+		expected := make([]string, len(ep.outbound))
+		for i, e := range ep.outbound {
+			expected[i] = describeEvent(e)
+		}
+
+		// Only the first divergence of each episode is reported, as the actions that follow it are likely to differ too
+		for i := 0; i < len(expected) || i < len(actual); i++ {
+			var exp, act string
+			if i < len(expected) {
+				exp = expected[i]
+			}
+			if i < len(actual) {
+				act = actual[i]
+			}
+			if exp != act {
+				report.Divergences = append(report.Divergences, ReplayDivergence{
+					Episode:  report.Episodes - 1,
+					Action:   i,
+					Expected: exp,
+					Actual:   act,
+				})
+				break
+			}
+		}
+	}
+
+	return report, nil
+}
+
+// episode is a single execution of the workflow code recorded in the history.
+type episode struct {
+	// Index in the history of the first event of the episode.
+	start int
+	// Events delivered to the workflow code in the episode.
+	inbound []*backend.HistoryEvent
+	// Events that record the actions returned by the workflow code in the episode.
+	outbound []*backend.HistoryEvent
+}
+
+// splitEpisodes splits the history in episodes, each starting with an OrchestratorStarted event.
+func splitEpisodes(events []*backend.HistoryEvent) []episode {
+	var episodes []episode
+	for i, e := range events {
+		if e.GetOrchestratorStarted() != nil {
+			episodes = append(episodes, episode{start: i})
+		}
+		if len(episodes) == 0 {
+			continue
+		}
+		ep := &episodes[len(episodes)-1]
+		switch {
+		case e.GetTaskScheduled() != nil,
+			e.GetTimerCreated() != nil,
+			e.GetSubOrchestrationInstanceCreated() != nil,
+			e.GetEventSent() != nil,
+			e.GetExecutionCompleted() != nil:
+			ep.outbound = append(ep.outbound, e)
+		case e.GetOrchestratorCompleted() != nil:
+			// Not delivered to the workflow code
+		default:
+			ep.inbound = append(ep.inbound, e)
+		}
+	}
+	return episodes
+}
+
+// describeEvent returns the description of the action recorded by the event, in the same format as the actions returned by the workflow code.
+func describeEvent(e *backend.HistoryEvent) string {
+	id := strconv.Itoa(int(e.GetEventId()))
+	switch {
+	case e.GetTaskScheduled() != nil:
+		return "ScheduleTask(" + e.GetTaskScheduled().GetName() + ")#" + id
+	case e.GetTimerCreated() != nil:
+		return "CreateTimer#" + id
+	case e.GetSubOrchestrationInstanceCreated() != nil:
+		return "CreateSubOrchestration(" + e.GetSubOrchestrationInstanceCreated().GetName() + ")#" + id
+	case e.GetEventSent() != nil:
+		return "SendEvent(" + e.GetEventSent().GetName() + ")#" + id
+	case e.GetExecutionCompleted() != nil:
+		return "CompleteOrchestration(" + e.GetExecutionCompleted().GetOrchestrationStatus().String() + ")"
+	default:
+		return fmt.Sprintf("%T", e.GetEventType())
+	}
+}
+
+func describeEvents(events []*backend.HistoryEvent) string {
+	res := make([]string, len(events))
+	for i, e := range events {
+		res[i] = describeEvent(e)
+	}
+	return strings.Join(res, ", ")
+}
@@ -0,0 +1,724 @@
+/*
+Copyright 2023 The Dapr Authors
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package wfengine
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"hash/fnv"
+	"math"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/microsoft/durabletask-go/api"
+
+	"github.com/dapr/dapr/pkg/actors"
+	diag "github.com/dapr/dapr/pkg/diagnostics"
+	invokev1 "github.com/dapr/dapr/pkg/messaging/v1"
+)
+
+const (
+	UpdateWorkflowIndexMethod = "UpdateWorkflowIndex"
+
+	// workflowIndexEntryKeyPrefix is the prefix of the keys of the entries in the state of the index actors.
+	// Each entry is saved under its own key, so a change of the status of an instance rewrites a single entry.
+	workflowIndexEntryKeyPrefix = "entry"
+	// workflowIndexBucketKeyPrefix is the prefix of the keys of the buckets in the state of the index actors.
+	// Each bucket lists the instances of the shard created in a time window, so the instances can be listed in order of creation without loading the whole index.
+	workflowIndexBucketKeyPrefix = "bucket"
+	// workflowIndexBucketsKey is the key of the list of the non-empty buckets of a shard.
+	workflowIndexBucketsKey = "buckets"
+	// workflowIndexBucketSize is the time window of the creation of the instances listed in a bucket.
+	workflowIndexBucketSize = 10 * time.Minute
+	// workflowIndexShards is the number of index actors among which the index of the workflow instances is spread,
+	// so that updating the index isn't a bottleneck when many workflows run at the same time.
+	workflowIndexShards = 16
+	// workflowIndexLoadBatchSize is the maximum number of entries loaded with a single request when reading the index.
+	workflowIndexLoadBatchSize = 100
+
+	// indexUpdateReminderName is the name of the reminder of the workflow actors that retries a failed update of the index.
+	indexUpdateReminderName = "index-update"
+
+	defaultListWorkflowsPageSize = 100
+	maxListWorkflowsPageSize     = 1000
+)
+
+// ErrInvalidContinuationToken is returned when listing the workflow instances with a continuation token that wasn't returned by a previous call.
+var ErrInvalidContinuationToken = errors.New("invalid continuation token")
+
+// WorkflowIndexEntry is the entry of a workflow instance in the index of the workflow instances.
+type WorkflowIndexEntry struct {
+	InstanceID    string    `json:"instanceID"`
+	WorkflowName  string    `json:"workflowName"`
+	RuntimeStatus string    `json:"runtimeStatus"`
+	CreatedAt     time.Time `json:"createdAt"`
+	// The last time at which the status of the workflow instance changed.
+	LastUpdatedAt time.Time `json:"lastUpdatedAt"`
+}
+
+// ListWorkflowsRequest is the request to list the workflow instances.
+type ListWorkflowsRequest struct {
+	// Statuses of the instances to list, such as "RUNNING". If empty, instances with any status are listed.
+	RuntimeStatuses []string
+	// Name of the workflow of the instances to list. If empty, instances of any workflow are listed.
+	WorkflowName string
+	// If not zero, only the instances created at or after this time are listed.
+	CreatedAfter time.Time
+	// If not zero, only the instances created before this time are listed.
+	CreatedBefore time.Time
+	// Maximum number of instances to return. If 0, the default of 100 is used.
+	PageSize int
+	// Token returned by a previous call, to get the next page.
+	ContinuationToken string
+}
+
+// ListWorkflowsResponse is the response with the workflow instances matching a ListWorkflowsRequest, ordered by creation time.
+type ListWorkflowsResponse struct {
+	Instances []WorkflowIndexEntry
+	// Token to list the next page of instances, empty if there are no more instances.
+	ContinuationToken string
+}
+
+// workflowIndexUpdate is the request of the UpdateWorkflowIndex method.
+type workflowIndexUpdate struct {
+	Entry WorkflowIndexEntry `json:"entry"`
+	// If true, the entry of the instance is removed from the index.
+	Delete bool `json:"delete,omitempty"`
+}
+
+// workflowIndexActor is an internal actor that maintains a shard of the index of the workflow instances in the actor state store.
+// The index is what allows listing the instances, since the state of the workflows is stored under the keys of each instance.
+// Updates of a shard are serialized by the turn-based concurrency of the actors.
+// The actor with the ID workflowRetentionActorID also purges the completed workflow instances, when a retention policy is configured.
+type workflowIndexActor struct {
+	actorRuntime actors.Actors
+	config       actorsBackendConfig
+	retention    workflowRetention
+}
+
+// NewWorkflowIndexActor creates an internal actor that maintains the index of the workflow instances.
+func NewWorkflowIndexActor(config actorsBackendConfig) *workflowIndexActor {
+	return &workflowIndexActor{
+		config:    config,
+		retention: workflowRetention{interval: defaultRetentionInterval},
+	}
+}
+
+// SetActorRuntime implements actors.InternalActor
+func (ia *workflowIndexActor) SetActorRuntime(actorRuntime actors.Actors) {
+	ia.actorRuntime = actorRuntime
+}
+
+// InvokeMethod implements actors.InternalActor
+func (ia *workflowIndexActor) InvokeMethod(ctx context.Context, actorID string, methodName string, data []byte) (any, error) {
+	if methodName != UpdateWorkflowIndexMethod {
+		return nil, fmt.Errorf("no such method: %s", methodName)
+	}
+
+	var update workflowIndexUpdate
+	if err := json.Unmarshal(data, &update); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal the index update: %w", err)
+	}
+
+	shard := newWorkflowIndexShard(ia.actorRuntime, ia.config, actorID)
+	existing, err := shard.getEntry(ctx, update.Entry.InstanceID)
+	if err != nil {
+		return nil, err
+	}
+
+	var (
+		ops     []actors.TransactionalOperation
+		updated *WorkflowIndexEntry
+	)
+	if update.Delete {
+		if existing == nil {
+			return nil, nil
+		}
+		ops = append(ops, actors.TransactionalOperation{
+			Operation: actors.Delete,
+			Request:   actors.TransactionalDelete{Key: workflowIndexEntryKey(update.Entry.InstanceID)},
+		})
+		if err = shard.removeRef(ctx, *existing); err != nil {
+			return nil, err
+		}
+	} else {
+		updated = &update.Entry
+		ops = append(ops, actors.TransactionalOperation{
+			Operation: actors.Upsert,
+			Request:   actors.TransactionalUpsert{Key: workflowIndexEntryKey(update.Entry.InstanceID), Value: update.Entry},
+		})
+		// The buckets change only when the instance is added, or created again with the same ID
+		if existing == nil || !existing.CreatedAt.Equal(update.Entry.CreatedAt) {
+			if existing != nil {
+				if err = shard.removeRef(ctx, *existing); err != nil {
+					return nil, err
+				}
+			}
+			if err = shard.addRef(ctx, update.Entry); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	if delta := suspendedDelta(existing, updated); delta != 0 {
+		op, err := shard.updateSuspended(ctx, delta)
+		if err != nil {
+			return nil, err
+		}
+		ops = append(ops, op)
+	}
+
+	return nil, ia.actorRuntime.TransactionalStateOperation(ctx, &actors.TransactionalRequest{
+		ActorType:  ia.config.indexActorType,
+		ActorID:    actorID,
+		Operations: append(ops, shard.operations()...),
+	})
+}
+
+// InvokeReminder implements actors.InternalActor
+func (ia *workflowIndexActor) InvokeReminder(ctx context.Context, actorID string, reminderName string, data []byte, dueTime string, period string) error {
+	if actorID == workflowIndexStatsActorID && reminderName == workflowIndexStatsReminderName {
+		if !diag.DefaultWorkflowMonitoring.IsEnabled() {
+			wfLogger.Info("Workflow metrics are not enabled anymore: deleting the workflow index stats reminder")
+			return actors.ErrReminderCanceled
+		}
+		if err := ia.recordIndexStats(ctx); err != nil {
+			wfLogger.Warnf("Failed to record the metrics of the workflow index: %v", err)
+		}
+		return nil
+	}
+
+	if actorID != workflowRetentionActorID {
+		return fmt.Errorf("unknown reminder '%s'", reminderName)
+	}
+	switch reminderName {
+	case workflowRetentionReminderName, workflowRetentionBatchReminderName, workflowRetentionNextBatchReminderName:
+	default:
+		return fmt.Errorf("unknown reminder '%s'", reminderName)
+	}
+	if !ia.retention.enabled() {
+		wfLogger.Info("Workflow retention policy is not configured anymore: deleting the retention reminder")
+		return actors.ErrReminderCanceled
+	}
+
+	if err := ia.enforceRetention(ctx, reminderName); err != nil {
+		// Returning nil keeps the reminder, so the purge is retried in the next period interval
+		wfLogger.Warnf("Failed to purge the completed workflow instances with the retention policy: %v", err)
+	}
+	return nil
+}
+
+// InvokeTimer implements actors.InternalActor
+func (ia *workflowIndexActor) InvokeTimer(ctx context.Context, actorID string, timerName string, params []byte) error {
+	return errors.New("timers are not implemented")
+}
+
+// DeactivateActor implements actors.InternalActor
+func (ia *workflowIndexActor) DeactivateActor(ctx context.Context, actorID string) error {
+	if actorID == workflowIndexStatsActorID {
+		// The metrics are recorded by the host the actor is activated on next, so this host stops reporting them
+		diag.DefaultWorkflowMonitoring.WorkflowSuspendedInstances(ctx, 0)
+	}
+	return nil
+}
+
+// workflowIndexRef is the reference to an entry of the index in a bucket.
+type workflowIndexRef struct {
+	InstanceID string    `json:"instanceID"`
+	CreatedAt  time.Time `json:"createdAt"`
+}
+
+// workflowIndexShard loads and changes the buckets of a shard of the index, so they're saved in the same transaction as the entries.
+type workflowIndexShard struct {
+	actorRuntime actors.Actors
+	config       actorsBackendConfig
+	id           string
+
+	buckets        []int64
+	bucketsLoaded  bool
+	bucketsChanged bool
+	refs           map[int64][]workflowIndexRef
+}
+
+func newWorkflowIndexShard(actorRuntime actors.Actors, config actorsBackendConfig, id string) *workflowIndexShard {
+	return &workflowIndexShard{
+		actorRuntime: actorRuntime,
+		config:       config,
+		id:           id,
+		refs:         map[int64][]workflowIndexRef{},
+	}
+}
+
+// getEntry returns the entry of the instance, or nil if the instance isn't in the shard.
+func (s *workflowIndexShard) getEntry(ctx context.Context, instanceID string) (*WorkflowIndexEntry, error) {
+	var entry WorkflowIndexEntry
+	found, err := loadWorkflowIndexState(ctx, s.actorRuntime, s.config, s.id, workflowIndexEntryKey(instanceID), &entry)
+	if err != nil || !found {
+		return nil, err
+	}
+	return &entry, nil
+}
+
+func (s *workflowIndexShard) loadBuckets(ctx context.Context) error {
+	if s.bucketsLoaded {
+		return nil
+	}
+	if _, err := loadWorkflowIndexState(ctx, s.actorRuntime, s.config, s.id, workflowIndexBucketsKey, &s.buckets); err != nil {
+		return err
+	}
+	s.bucketsLoaded = true
+	return nil
+}
+
+func (s *workflowIndexShard) loadRefs(ctx context.Context, bucket int64) ([]workflowIndexRef, error) {
+	if refs, ok := s.refs[bucket]; ok {
+		return refs, nil
+	}
+	var refs []workflowIndexRef
+	if _, err := loadWorkflowIndexState(ctx, s.actorRuntime, s.config, s.id, workflowIndexBucketKey(bucket), &refs); err != nil {
+		return nil, err
+	}
+	return refs, nil
+}
+
+// addRef adds the reference to the entry to its bucket.
+func (s *workflowIndexShard) addRef(ctx context.Context, entry WorkflowIndexEntry) error {
+	bucket := workflowIndexBucket(entry.CreatedAt)
+	refs, err := s.loadRefs(ctx, bucket)
+	if err != nil {
+		return err
+	}
+	if len(refs) == 0 {
+		if err = s.loadBuckets(ctx); err != nil {
+			return err
+		}
+		i := sort.Search(len(s.buckets), func(i int) bool { return s.buckets[i] >= bucket })
+		if i == len(s.buckets) || s.buckets[i] != bucket {
+			s.buckets = append(s.buckets[:i], append([]int64{bucket}, s.buckets[i:]...)...)
+			s.bucketsChanged = true
+		}
+	}
+	s.refs[bucket] = append(refs, workflowIndexRef{InstanceID: entry.InstanceID, CreatedAt: entry.CreatedAt})
+	return nil
+}
+
+// removeRef removes the reference to the entry from its bucket.
+func (s *workflowIndexShard) removeRef(ctx context.Context, entry WorkflowIndexEntry) error {
+	bucket := workflowIndexBucket(entry.CreatedAt)
+	refs, err := s.loadRefs(ctx, bucket)
+	if err != nil {
+		return err
+	}
+	kept := make([]workflowIndexRef, 0, len(refs))
+	for _, r := range refs {
+		if r.InstanceID != entry.InstanceID {
+			kept = append(kept, r)
+		}
+	}
+	if len(kept) == 0 {
+		if err = s.loadBuckets(ctx); err != nil {
+			return err
+		}
+		i := sort.Search(len(s.buckets), func(i int) bool { return s.buckets[i] >= bucket })
+		if i < len(s.buckets) && s.buckets[i] == bucket {
+			s.buckets = append(s.buckets[:i], s.buckets[i+1:]...)
+			s.bucketsChanged = true
+		}
+	}
+	s.refs[bucket] = kept
+	return nil
+}
+
+// operations returns the operations that save the changed buckets.
+func (s *workflowIndexShard) operations() []actors.TransactionalOperation {
+	ops := make([]actors.TransactionalOperation, 0, len(s.refs)+1)
+	for bucket, refs := range s.refs {
+		if len(refs) == 0 {
+			ops = append(ops, actors.TransactionalOperation{
+				Operation: actors.Delete,
+				Request:   actors.TransactionalDelete{Key: workflowIndexBucketKey(bucket)},
+			})
+			continue
+		}
+		ops = append(ops, actors.TransactionalOperation{
+			Operation: actors.Upsert,
+			Request:   actors.TransactionalUpsert{Key: workflowIndexBucketKey(bucket), Value: refs},
+		})
+	}
+	if s.bucketsChanged {
+		ops = append(ops, actors.TransactionalOperation{
+			Operation: actors.Upsert,
+			Request:   actors.TransactionalUpsert{Key: workflowIndexBucketsKey, Value: s.buckets},
+		})
+	}
+	return ops
+}
+
+// loadWorkflowIndexState loads a key of the state of an index actor into v, and returns false if the key doesn't exist.
+func loadWorkflowIndexState(ctx context.Context, actorRuntime actors.Actors, config actorsBackendConfig, shard string, key string, v any) (bool, error) {
+	res, err := actorRuntime.GetState(ctx, &actors.GetStateRequest{
+		ActorType: config.indexActorType,
+		ActorID:   shard,
+		Key:       key,
+	})
+	if err != nil {
+		return false, fmt.Errorf("failed to load the workflow index: %w", err)
+	}
+	if len(res.Data) == 0 {
+		return false, nil
+	}
+	if err = json.Unmarshal(res.Data, v); err != nil {
+		return false, fmt.Errorf("failed to unmarshal the workflow index: %w", err)
+	}
+	return true, nil
+}
+
+func workflowIndexEntryKey(instanceID string) string {
+	return workflowIndexEntryKeyPrefix + "||" + instanceID
+}
+
+func workflowIndexBucketKey(bucket int64) string {
+	return workflowIndexBucketKeyPrefix + "||" + strconv.FormatInt(bucket, 10)
+}
+
+// workflowIndexBucket returns the bucket of the instances created at t, as the Unix time of the start of its time window.
+func workflowIndexBucket(t time.Time) int64 {
+	return t.Truncate(workflowIndexBucketSize).Unix()
+}
+
+// iterateWorkflowIndex calls fn with the entries of the index created in [from, before), in order of creation, until fn returns false.
+// Entries ordered before the after entry are skipped. Zero values of from and before and a nil after don't bound the entries.
+// Only the buckets in the range are loaded, and entries are loaded in batches, so that reading the beginning of the index doesn't load all of it.
+func iterateWorkflowIndex(ctx context.Context, actorRuntime actors.Actors, config actorsBackendConfig, from, before time.Time, after *WorkflowIndexEntry, fn func(WorkflowIndexEntry) bool) error {
+	// Shards with entries in each bucket
+	bucketShards := map[int64][]string{}
+	for i := 0; i < workflowIndexShards; i++ {
+		shard := strconv.Itoa(i)
+		var buckets []int64
+		if _, err := loadWorkflowIndexState(ctx, actorRuntime, config, shard, workflowIndexBucketsKey, &buckets); err != nil {
+			return err
+		}
+		for _, b := range buckets {
+			bucketShards[b] = append(bucketShards[b], shard)
+		}
+	}
+	buckets := make([]int64, 0, len(bucketShards))
+	for b := range bucketShards {
+		buckets = append(buckets, b)
+	}
+	sort.Slice(buckets, func(i, j int) bool { return buckets[i] < buckets[j] })
+
+	var first int64 = math.MinInt64
+	if !from.IsZero() {
+		first = workflowIndexBucket(from)
+	}
+	if after != nil {
+		first = max(first, workflowIndexBucket(after.CreatedAt))
+	}
+
+	type shardRef struct {
+		workflowIndexRef
+		shard string
+	}
+	for _, bucket := range buckets {
+		if bucket < first {
+			continue
+		}
+		if !before.IsZero() && !time.Unix(bucket, 0).Before(before) {
+			return nil
+		}
+
+		refs := []shardRef{}
+		for _, shard := range bucketShards[bucket] {
+			var shardRefs []workflowIndexRef
+			if _, err := loadWorkflowIndexState(ctx, actorRuntime, config, shard, workflowIndexBucketKey(bucket), &shardRefs); err != nil {
+				return err
+			}
+			for _, r := range shardRefs {
+				if (!from.IsZero() && r.CreatedAt.Before(from)) ||
+					(!before.IsZero() && !r.CreatedAt.Before(before)) ||
+					(after != nil && !indexEntryLess(*after, WorkflowIndexEntry{InstanceID: r.InstanceID, CreatedAt: r.CreatedAt})) {
+					continue
+				}
+				refs = append(refs, shardRef{workflowIndexRef: r, shard: shard})
+			}
+		}
+		sort.Slice(refs, func(i, j int) bool {
+			return indexEntryLess(
+				WorkflowIndexEntry{InstanceID: refs[i].InstanceID, CreatedAt: refs[i].CreatedAt},
+				WorkflowIndexEntry{InstanceID: refs[j].InstanceID, CreatedAt: refs[j].CreatedAt},
+			)
+		})
+
+		for len(refs) > 0 {
+			batch := refs[:min(len(refs), workflowIndexLoadBatchSize)]
+			refs = refs[len(batch):]
+
+			keys := map[string][]string{}
+			for _, r := range batch {
+				keys[r.shard] = append(keys[r.shard], workflowIndexEntryKey(r.InstanceID))
+			}
+			data := make(map[string]actors.BulkStateResponse, len(keys))
+			for shard, shardKeys := range keys {
+				res, err := actorRuntime.GetBulkState(ctx, &actors.GetBulkStateRequest{
+					ActorType: config.indexActorType,
+					ActorID:   shard,
+					Keys:      shardKeys,
+				})
+				if err != nil {
+					return fmt.Errorf("failed to load the workflow index: %w", err)
+				}
+				data[shard] = res
+			}
+
+			for _, r := range batch {
+				raw := data[r.shard][workflowIndexEntryKey(r.InstanceID)]
+				if len(raw) == 0 {
+					continue
+				}
+				var entry WorkflowIndexEntry
+				if err := json.Unmarshal(raw, &entry); err != nil {
+					return fmt.Errorf("failed to unmarshal the workflow index: %w", err)
+				}
+				if !fn(entry) {
+					return nil
+				}
+			}
+		}
+	}
+	return nil
+}
+
+// getWorkflowIndexShard returns the ID of the index actor with the entry of the workflow instance.
+func getWorkflowIndexShard(instanceID string) string {
+	h := fnv.New32a()
+	h.Write([]byte(instanceID))
+	return strconv.FormatUint(uint64(h.Sum32()%workflowIndexShards), 10)
+}
+
+// getWorkflowIndexEntry returns the entry of the workflow instance in the index, or false if the workflow hasn't been scheduled yet.
+func getWorkflowIndexEntry(actorID string, state *workflowState) (WorkflowIndexEntry, bool) {
+	runtimeState := getRuntimeState(actorID, state)
+	if name, err := runtimeState.Name(); err == nil {
+		createdAt, _ := runtimeState.CreatedTime()
+		lastUpdated, _ := runtimeState.LastUpdatedTime()
+		return WorkflowIndexEntry{
+			InstanceID:    actorID,
+			WorkflowName:  name,
+			RuntimeStatus: getStatusString(int32(getRuntimeStatus(runtimeState, state))),
+			CreatedAt:     createdAt,
+			LastUpdatedAt: lastUpdated,
+		}, true
+	}
+
+	// Workflows that haven't started executing yet only have the start event in their inbox
+	for _, e := range state.Inbox {
+		if es := e.GetExecutionStarted(); es != nil {
+			createdAt := e.GetTimestamp().AsTime()
+			return WorkflowIndexEntry{
+				InstanceID:    actorID,
+				WorkflowName:  es.GetName(),
+				RuntimeStatus: getStatusString(int32(api.RUNTIME_STATUS_PENDING)),
+				CreatedAt:     createdAt,
+				LastUpdatedAt: createdAt,
+			}, true
+		}
+	}
+	return WorkflowIndexEntry{}, false
+}
+
+// pendingIndexUpdate returns the entry of the workflow instance to save in the index, or false if its status didn't change since the last update.
+// The status is recorded in the state of the workflow, so it's saved with the state before the index is updated.
+func pendingIndexUpdate(actorID string, state *workflowState) (WorkflowIndexEntry, bool) {
+	entry, ok := getWorkflowIndexEntry(actorID, state)
+	if !ok || entry.RuntimeStatus == state.indexedStatus {
+		return WorkflowIndexEntry{}, false
+	}
+	state.indexedStatus = entry.RuntimeStatus
+	return entry, true
+}
+
+// updateIndex updates the entry of the workflow instance in the index.
+// Failures don't fail the workflow: the update is retried by a reminder of the workflow actor until it succeeds.
+func (wf *workflowActor) updateIndex(ctx context.Context, actorID string, entry WorkflowIndexEntry) {
+	err := wf.sendIndexUpdate(ctx, workflowIndexUpdate{Entry: entry})
+	if err == nil {
+		return
+	}
+	wfLogger.Warnf("Workflow actor '%s': failed to update the workflow index, will retry later: %v", actorID, err)
+
+	err = wf.actors.CreateReminder(ctx, &actors.CreateReminderRequest{
+		ActorType: wf.config.workflowActorType,
+		ActorID:   actorID,
+		Name:      indexUpdateReminderName,
+		DueTime:   wf.reminderInterval.String(),
+		Period:    wf.reminderInterval.String(),
+	})
+	recordReminderEvent(ctx, diag.WorkflowReminder, diag.CreateReminder, err)
+	if err != nil {
+		wfLogger.Errorf("Workflow actor '%s': failed to create the reminder to retry the update of the workflow index: %v", actorID, err)
+	}
+}
+
+// retryIndexUpdate saves the current entry of the workflow instance in the index, after an update failed.
+func (wf *workflowActor) retryIndexUpdate(ctx context.Context, actorID string) error {
+	state, err := wf.loadInternalState(ctx, actorID)
+	if err != nil {
+		return err
+	}
+	if state == nil {
+		// The instance was purged, and removed from the index
+		return nil
+	}
+	entry, ok := getWorkflowIndexEntry(actorID, state)
+	if !ok {
+		return nil
+	}
+	return wf.sendIndexUpdate(ctx, workflowIndexUpdate{Entry: entry})
+}
+
+// removeFromIndex removes the entry of the purged workflow instance from the index.
+func (wf *workflowActor) removeFromIndex(ctx context.Context, actorID string) {
+	err := wf.sendIndexUpdate(ctx, workflowIndexUpdate{
+		Entry:  WorkflowIndexEntry{InstanceID: actorID},
+		Delete: true,
+	})
+	if err != nil {
+		wfLogger.Warnf("Workflow actor '%s': failed to remove the workflow from the workflow index: %v", actorID, err)
+	}
+}
+
+func (wf *workflowActor) sendIndexUpdate(ctx context.Context, update workflowIndexUpdate) error {
+	return sendWorkflowIndexUpdate(ctx, wf.actors, wf.config, update)
+}
+
+// sendWorkflowIndexUpdate sends the update to the index actor of the shard of the instance.
+func sendWorkflowIndexUpdate(ctx context.Context, actorRuntime actors.Actors, config actorsBackendConfig, update workflowIndexUpdate) error {
+	data, err := json.Marshal(update)
+	if err != nil {
+		return err
+	}
+
+	req := invokev1.
+		NewInvokeMethodRequest(UpdateWorkflowIndexMethod).
+		WithActor(config.indexActorType, getWorkflowIndexShard(update.Entry.InstanceID)).
+		WithRawDataBytes(data).
+		WithContentType(invokev1.JSONContentType)
+	defer req.Close()
+
+	resp, err := actorRuntime.Call(ctx, req)
+	if err != nil {
+		return err
+	}
+	defer resp.Close()
+	return nil
+}
+
+// ListWorkflows returns the workflow instances matching the request, from the index of the workflow instances.
+// The index is updated after the state of the workflows is saved, so it may briefly lag behind their actual status.
+func (be *actorBackend) ListWorkflows(ctx context.Context, req *ListWorkflowsRequest) (*ListWorkflowsResponse, error) {
+	if err := be.validateConfiguration(); err != nil {
+		return nil, err
+	}
+
+	pageSize := req.PageSize
+	switch {
+	case pageSize <= 0:
+		pageSize = defaultListWorkflowsPageSize
+	case pageSize > maxListWorkflowsPageSize:
+		pageSize = maxListWorkflowsPageSize
+	}
+
+	var (
+		after    *WorkflowIndexEntry
+		statuses map[string]struct{}
+	)
+	if req.ContinuationToken != "" {
+		entry, err := decodeContinuationToken(req.ContinuationToken)
+		if err != nil {
+			return nil, err
+		}
+		after = &entry
+	}
+	if len(req.RuntimeStatuses) > 0 {
+		statuses = make(map[string]struct{}, len(req.RuntimeStatuses))
+		for _, s := range req.RuntimeStatuses {
+			statuses[strings.ToUpper(s)] = struct{}{}
+		}
+	}
+
+	// One instance more than the page is listed, to know if there's a next page
+	instances := make([]WorkflowIndexEntry, 0, pageSize+1)
+	err := iterateWorkflowIndex(ctx, be.actors, be.config, req.CreatedAfter, req.CreatedBefore, after, func(e WorkflowIndexEntry) bool {
+		if statuses != nil {
+			if _, ok := statuses[e.RuntimeStatus]; !ok {
+				return true
+			}
+		}
+		if req.WorkflowName != "" && e.WorkflowName != req.WorkflowName {
+			return true
+		}
+		instances = append(instances, e)
+		return len(instances) <= pageSize
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	res := &ListWorkflowsResponse{}
+	if len(instances) > pageSize {
+		instances = instances[:pageSize]
+		res.ContinuationToken = encodeContinuationToken(instances[pageSize-1])
+	}
+	res.Instances = instances
+	return res, nil
+}
+
+// indexEntryLess orders the entries of the index by creation time, then by instance ID.
+func indexEntryLess(a, b WorkflowIndexEntry) bool {
+	if !a.CreatedAt.Equal(b.CreatedAt) {
+		return a.CreatedAt.Before(b.CreatedAt)
+	}
+	return a.InstanceID < b.InstanceID
+}
+
+// encodeContinuationToken returns a token that resumes listing after the entry.
+// The token contains the position of the entry in the ordering of the index, so pages are stable when instances are added or removed.
+func encodeContinuationToken(last WorkflowIndexEntry) string {
+	return base64.RawURLEncoding.EncodeToString([]byte(strconv.FormatInt(last.CreatedAt.UnixNano(), 10) + "/" + last.InstanceID))
+}
+
+func decodeContinuationToken(token string) (WorkflowIndexEntry, error) {
+	data, err := base64.RawURLEncoding.DecodeString(token)
+	if err != nil {
+		return WorkflowIndexEntry{}, ErrInvalidContinuationToken
+	}
+	createdAt, instanceID, ok := strings.Cut(string(data), "/")
+	if !ok {
+		return WorkflowIndexEntry{}, ErrInvalidContinuationToken
+	}
+	nanos, err := strconv.ParseInt(createdAt, 10, 64)
+	if err != nil {
+		return WorkflowIndexEntry{}, ErrInvalidContinuationToken
+	}
+	return WorkflowIndexEntry{
+		InstanceID: instanceID,
+		CreatedAt:  time.Unix(0, nanos),
+	}, nil
+}
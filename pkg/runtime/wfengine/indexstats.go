@@ -0,0 +1,101 @@
+/*
+Copyright 2023 The Dapr Authors
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package wfengine
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/microsoft/durabletask-go/api"
+
+	"github.com/dapr/dapr/pkg/actors"
+	diag "github.com/dapr/dapr/pkg/diagnostics"
+)
+
+const (
+	// workflowIndexSuspendedKey is the key of the number of suspended instances in the state of the index actors.
+	// It's updated in the same transaction as the entries, so it matches the index.
+	workflowIndexSuspendedKey = "suspended"
+
+	// workflowIndexStatsActorID is the ID of the index actor that periodically records the metrics computed from the index.
+	workflowIndexStatsActorID = "stats"
+	// workflowIndexStatsReminderName is the name of the reminder that periodically records the metrics computed from the index.
+	workflowIndexStatsReminderName = "stats"
+	workflowIndexStatsInterval     = time.Minute
+)
+
+var suspendedStatus = getStatusString(int32(api.RUNTIME_STATUS_SUSPENDED))
+
+// suspendedDelta returns the change in the number of suspended instances of the shard when the entry existing is replaced with updated.
+// A nil entry is an entry that isn't in the index.
+func suspendedDelta(existing *WorkflowIndexEntry, updated *WorkflowIndexEntry) int64 {
+	var delta int64
+	if existing != nil && existing.RuntimeStatus == suspendedStatus {
+		delta--
+	}
+	if updated != nil && updated.RuntimeStatus == suspendedStatus {
+		delta++
+	}
+	return delta
+}
+
+// updateSuspended returns the operation that adds delta to the number of suspended instances of the shard.
+func (s *workflowIndexShard) updateSuspended(ctx context.Context, delta int64) (actors.TransactionalOperation, error) {
+	var suspended int64
+	if _, err := loadWorkflowIndexState(ctx, s.actorRuntime, s.config, s.id, workflowIndexSuspendedKey, &suspended); err != nil {
+		return actors.TransactionalOperation{}, err
+	}
+	return actors.TransactionalOperation{
+		Operation: actors.Upsert,
+		Request:   actors.TransactionalUpsert{Key: workflowIndexSuspendedKey, Value: max(suspended+delta, 0)},
+	}, nil
+}
+
+// recordIndexStats records the number of suspended workflow instances, summed over the shards of the index.
+// The metric is recorded by a single actor, so the number is the same regardless of the hosts on which the instances were suspended.
+func (ia *workflowIndexActor) recordIndexStats(ctx context.Context) error {
+	var total int64
+	for i := 0; i < workflowIndexShards; i++ {
+		var suspended int64
+		if _, err := loadWorkflowIndexState(ctx, ia.actorRuntime, ia.config, strconv.Itoa(i), workflowIndexSuspendedKey, &suspended); err != nil {
+			return err
+		}
+		total += suspended
+	}
+	diag.DefaultWorkflowMonitoring.WorkflowSuspendedInstances(ctx, total)
+	return nil
+}
+
+// startIndexStats creates the reminder that periodically records the metrics computed from the index, if the workflow metrics are enabled.
+// The reminder has the same name on every replica of the app, so it's created once and fires on a single replica.
+func (wfe *WorkflowEngine) startIndexStats(ctx context.Context) error {
+	if !diag.DefaultWorkflowMonitoring.IsEnabled() {
+		// A reminder created when the metrics were enabled is deleted the next time it fires
+		return nil
+	}
+
+	err := wfe.actorRuntime.CreateReminder(ctx, &actors.CreateReminderRequest{
+		ActorType: wfe.backend.config.indexActorType,
+		ActorID:   workflowIndexStatsActorID,
+		Name:      workflowIndexStatsReminderName,
+		DueTime:   "0s",
+		Period:    workflowIndexStatsInterval.String(),
+	})
+	if err != nil {
+		return fmt.Errorf("failed to create the workflow index stats reminder: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,45 @@
+/*
+Copyright 2023 The Dapr Authors
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package wfengine
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSuspendedDelta(t *testing.T) {
+	running := &WorkflowIndexEntry{RuntimeStatus: "RUNNING"}
+	suspended := &WorkflowIndexEntry{RuntimeStatus: "SUSPENDED"}
+
+	tests := map[string]struct {
+		existing *WorkflowIndexEntry
+		updated  *WorkflowIndexEntry
+		exp      int64
+	}{
+		"added running":     {existing: nil, updated: running, exp: 0},
+		"added suspended":   {existing: nil, updated: suspended, exp: 1},
+		"suspended":         {existing: running, updated: suspended, exp: 1},
+		"still suspended":   {existing: suspended, updated: suspended, exp: 0},
+		"resumed":           {existing: suspended, updated: running, exp: -1},
+		"removed suspended": {existing: suspended, updated: nil, exp: -1},
+		"removed running":   {existing: running, updated: nil, exp: 0},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			assert.Equal(t, tc.exp, suspendedDelta(tc.existing, tc.updated))
+		})
+	}
+}
@@ -0,0 +1,98 @@
+/*
+Copyright 2023 The Dapr Authors
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package wfengine
+
+import (
+	"context"
+	"sync"
+
+	diag "github.com/dapr/dapr/pkg/diagnostics"
+)
+
+// workItemTracker tracks the workflow and activity executions in-flight, so the engine can quiesce on shutdown.
+// A nil workItemTracker never quiesces.
+type workItemTracker struct {
+	// lock protects quiescing, so no execution starts after quiesce has begun waiting.
+	lock      sync.RWMutex
+	quiescing bool
+	inflight  sync.WaitGroup
+
+	// preemptCtx is canceled when the in-flight executions are preempted.
+	preemptCtx context.Context
+	preempt    context.CancelFunc
+}
+
+func newWorkItemTracker() *workItemTracker {
+	ctx, cancel := context.WithCancel(context.Background())
+	return &workItemTracker{
+		preemptCtx: ctx,
+		preempt:    cancel,
+	}
+}
+
+// start registers a new execution of the given type.
+// It returns false if the engine is quiescing, in which case the execution must not be started. The reminder that triggered it must be kept, so it's retried later, possibly by another instance.
+// Otherwise, the execution must use the returned context, which is canceled if the execution is preempted, and call finish when it's done.
+func (t *workItemTracker) start(ctx context.Context, workItemType string) (_ context.Context, finish func(err error), ok bool) {
+	if t == nil {
+		return ctx, func(error) {}, true
+	}
+
+	t.lock.RLock()
+	defer t.lock.RUnlock()
+
+	if t.quiescing {
+		diag.DefaultWorkflowMonitoring.WorkflowWorkItemPreempted(ctx, workItemType)
+		return nil, nil, false
+	}
+
+	t.inflight.Add(1)
+	execCtx, cancel := context.WithCancel(ctx)
+	stop := context.AfterFunc(t.preemptCtx, cancel)
+	return execCtx, func(err error) {
+		if err != nil && t.preemptCtx.Err() != nil {
+			diag.DefaultWorkflowMonitoring.WorkflowWorkItemPreempted(ctx, workItemType)
+		}
+		stop()
+		cancel()
+		t.inflight.Done()
+	}, true
+}
+
+// quiesce stops new executions from starting, and waits for the in-flight ones to complete and save their state.
+// When the context is done, the executions that are still in-flight are preempted: they're canceled and retried later, possibly by another instance.
+// It returns false if any execution was preempted.
+func (t *workItemTracker) quiesce(ctx context.Context) bool {
+	if t == nil {
+		return true
+	}
+
+	t.lock.Lock()
+	t.quiescing = true
+	t.lock.Unlock()
+
+	doneCh := make(chan struct{})
+	go func() {
+		t.inflight.Wait()
+		close(doneCh)
+	}()
+
+	select {
+	case <-doneCh:
+		return true
+	case <-ctx.Done():
+		t.preempt()
+		return false
+	}
+}
@@ -0,0 +1,72 @@
+/*
+Copyright 2023 The Dapr Authors
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package wfengine
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	diag "github.com/dapr/dapr/pkg/diagnostics"
+)
+
+func TestWorkItemTracker(t *testing.T) {
+	t.Run("nil tracker never quiesces", func(t *testing.T) {
+		var tracker *workItemTracker
+		ctx, finish, ok := tracker.start(context.Background(), diag.WorkflowReminder)
+		require.True(t, ok)
+		require.NoError(t, ctx.Err())
+		finish(nil)
+		assert.True(t, tracker.quiesce(context.Background()))
+	})
+
+	t.Run("waits for in-flight executions", func(t *testing.T) {
+		tracker := newWorkItemTracker()
+		_, finish, ok := tracker.start(context.Background(), diag.WorkflowReminder)
+		require.True(t, ok)
+
+		go func() {
+			time.Sleep(50 * time.Millisecond)
+			finish(nil)
+		}()
+
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		assert.True(t, tracker.quiesce(ctx))
+
+		// New executions are not started
+		_, _, ok = tracker.start(context.Background(), diag.ActivityReminder)
+		assert.False(t, ok)
+	})
+
+	t.Run("preempts executions after the deadline", func(t *testing.T) {
+		tracker := newWorkItemTracker()
+		execCtx, finish, ok := tracker.start(context.Background(), diag.ActivityReminder)
+		require.True(t, ok)
+
+		ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+		defer cancel()
+		assert.False(t, tracker.quiesce(ctx))
+
+		select {
+		case <-execCtx.Done():
+		case <-time.After(5 * time.Second):
+			t.Fatal("execution context was not canceled")
+		}
+		finish(execCtx.Err())
+	})
+}
@@ -0,0 +1,207 @@
+/*
+Copyright 2023 The Dapr Authors
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package wfengine
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/microsoft/durabletask-go/api"
+
+	"github.com/dapr/dapr/pkg/actors"
+	diag "github.com/dapr/dapr/pkg/diagnostics"
+	invokev1 "github.com/dapr/dapr/pkg/messaging/v1"
+)
+
+const (
+	// workflowRetentionActorID is the ID of the index actor that purges the completed workflow instances.
+	// It's not the ID of a shard, so the purge doesn't block the updates of the index by the purged workflows.
+	workflowRetentionActorID = "retention"
+	// workflowRetentionReminderName is the name of the reminder that periodically purges the completed workflow instances.
+	workflowRetentionReminderName = "retention"
+	// Names of the one-off reminders that purge the next batch of instances, when a run has more instances to purge than workflowRetentionBatchSize.
+	// A one-off reminder is deleted after it fires, so each batch creates the reminder with the other name.
+	workflowRetentionBatchReminderName     = "retention-batch"
+	workflowRetentionNextBatchReminderName = "retention-batch-next"
+	// workflowRetentionBatchSize is the maximum number of instances purged in a turn of the retention actor.
+	workflowRetentionBatchSize = 100
+
+	defaultRetentionInterval = 5 * time.Minute
+)
+
+// completedStatuses are the statuses of the workflow instances that can be purged.
+var completedStatuses = map[string]struct{}{
+	getStatusString(int32(api.RUNTIME_STATUS_COMPLETED)):  {},
+	getStatusString(int32(api.RUNTIME_STATUS_FAILED)):     {},
+	getStatusString(int32(api.RUNTIME_STATUS_TERMINATED)): {},
+	getStatusString(int32(api.RUNTIME_STATUS_CANCELED)):   {},
+}
+
+// workflowRetention is the retention policy of the completed workflow instances.
+type workflowRetention struct {
+	// maxAge is the time after which completed instances are purged, or 0 if they're not purged by age.
+	maxAge time.Duration
+	// maxCompleted is the number of completed instances that are retained, or 0 if they're not purged by number.
+	maxCompleted int
+	// interval is the time between two runs of the purge.
+	interval time.Duration
+}
+
+func (r workflowRetention) enabled() bool {
+	return r.maxAge > 0 || r.maxCompleted > 0
+}
+
+// enforceRetention purges the completed workflow instances that exceed the retention policy.
+// Instances older than the max age are purged first, then the oldest instances beyond the max number of completed instances.
+// At most workflowRetentionBatchSize instances are purged in a turn, and the next batch is purged by a one-off reminder, so the actor isn't blocked for long.
+// Failures to purge an instance are logged, and the instance is purged again in the next run.
+// reminderName is the name of the reminder that runs the purge.
+func (ia *workflowIndexActor) enforceRetention(ctx context.Context, reminderName string) error {
+	completed := []WorkflowIndexEntry{}
+	err := iterateWorkflowIndex(ctx, ia.actorRuntime, ia.config, time.Time{}, time.Time{}, nil, func(e WorkflowIndexEntry) bool {
+		if _, ok := completedStatuses[e.RuntimeStatus]; ok {
+			completed = append(completed, e)
+		}
+		return true
+	})
+	if err != nil {
+		return err
+	}
+
+	// Instances that completed first are purged first
+	sort.Slice(completed, func(i, j int) bool {
+		if !completed[i].LastUpdatedAt.Equal(completed[j].LastUpdatedAt) {
+			return completed[i].LastUpdatedAt.Before(completed[j].LastUpdatedAt)
+		}
+		return completed[i].InstanceID < completed[j].InstanceID
+	})
+
+	expired := 0
+	if ia.retention.maxAge > 0 {
+		cutoff := time.Now().Add(-ia.retention.maxAge)
+		for expired < len(completed) && completed[expired].LastUpdatedAt.Before(cutoff) {
+			expired++
+		}
+	}
+	excess := 0
+	if ia.retention.maxCompleted > 0 && len(completed)-expired > ia.retention.maxCompleted {
+		excess = len(completed) - expired - ia.retention.maxCompleted
+	}
+
+	batchExpired := min(expired, workflowRetentionBatchSize)
+	batchExcess := min(excess, workflowRetentionBatchSize-batchExpired)
+	ia.purgeInstances(ctx, completed[:batchExpired], diag.RetentionMaxAge)
+	ia.purgeInstances(ctx, completed[expired:expired+batchExcess], diag.RetentionMaxInstances)
+
+	if remaining := expired + excess - batchExpired - batchExcess; remaining > 0 {
+		wfLogger.Debugf("%d completed workflow instances remain to be purged with the retention policy", remaining)
+		name := workflowRetentionBatchReminderName
+		if reminderName == workflowRetentionBatchReminderName {
+			name = workflowRetentionNextBatchReminderName
+		}
+		err = ia.actorRuntime.CreateReminder(ctx, &actors.CreateReminderRequest{
+			ActorType: ia.config.indexActorType,
+			ActorID:   workflowRetentionActorID,
+			Name:      name,
+			DueTime:   "0s",
+		})
+		if err != nil {
+			return fmt.Errorf("failed to create the reminder to purge the next batch of workflow instances: %w", err)
+		}
+	}
+	return nil
+}
+
+func (ia *workflowIndexActor) purgeInstances(ctx context.Context, entries []WorkflowIndexEntry, reason string) {
+	var purged, keys int64
+	for _, e := range entries {
+		n, err := purgeWorkflowInstance(ctx, ia.actorRuntime, ia.config, e.InstanceID)
+		if errors.Is(err, api.ErrInstanceNotFound) {
+			// The instance was purged, but its entry couldn't be removed from the index
+			ia.removeStaleEntry(ctx, e.InstanceID)
+			continue
+		}
+		if err != nil {
+			wfLogger.Warnf("Failed to purge workflow instance '%s' with the retention policy: %v", e.InstanceID, err)
+			continue
+		}
+		purged++
+		keys += int64(n)
+	}
+	if purged > 0 {
+		wfLogger.Infof("Purged %d completed workflow instances with the retention policy (%s)", purged, reason)
+	}
+	diag.DefaultWorkflowMonitoring.WorkflowRetentionPurged(ctx, reason, purged, keys)
+}
+
+// removeStaleEntry removes the entry of an instance that doesn't exist anymore from the index, so it doesn't count against the retention policy.
+func (ia *workflowIndexActor) removeStaleEntry(ctx context.Context, instanceID string) {
+	err := sendWorkflowIndexUpdate(ctx, ia.actorRuntime, ia.config, workflowIndexUpdate{
+		Entry:  WorkflowIndexEntry{InstanceID: instanceID},
+		Delete: true,
+	})
+	if err != nil {
+		wfLogger.Warnf("Failed to remove the entry of the purged workflow instance '%s' from the workflow index: %v", instanceID, err)
+		return
+	}
+	wfLogger.Debugf("Removed the entry of the purged workflow instance '%s' from the workflow index", instanceID)
+}
+
+// purgeWorkflowInstance purges the state of the completed workflow instance, and returns the number of state keys that were deleted.
+func purgeWorkflowInstance(ctx context.Context, actorRuntime actors.Actors, config actorsBackendConfig, instanceID string) (int, error) {
+	req := invokev1.
+		NewInvokeMethodRequest(PurgeWorkflowStateMethod).
+		WithActor(config.workflowActorType, instanceID)
+	defer req.Close()
+
+	resp, err := actorRuntime.Call(ctx, req)
+	if err != nil {
+		return 0, err
+	}
+	defer resp.Close()
+
+	var keys int
+	if data := resp.RawData(); len(data) > 0 {
+		if err = actors.DecodeInternalActorData(data, &keys); err != nil {
+			return 0, fmt.Errorf("failed to decode the internal actor response: %w", err)
+		}
+	}
+	return keys, nil
+}
+
+// startRetention creates the reminder that periodically purges the completed workflow instances, if a retention policy is configured.
+// The reminder has the same name on every replica of the app, so it's created once and fires on a single replica.
+func (wfe *WorkflowEngine) startRetention(ctx context.Context) error {
+	retention := wfe.backend.indexActor.retention
+	if !retention.enabled() {
+		// A reminder created when retention was enabled is deleted the next time it fires
+		return nil
+	}
+
+	err := wfe.actorRuntime.CreateReminder(ctx, &actors.CreateReminderRequest{
+		ActorType: wfe.backend.config.indexActorType,
+		ActorID:   workflowRetentionActorID,
+		Name:      workflowRetentionReminderName,
+		DueTime:   retention.interval.String(),
+		Period:    retention.interval.String(),
+	})
+	if err != nil {
+		return fmt.Errorf("failed to create the workflow retention reminder: %w", err)
+	}
+	wfLogger.Infof("Workflow retention policy enabled: max age %v, max completed instances %d", retention.maxAge, retention.maxCompleted)
+	return nil
+}
@@ -0,0 +1,317 @@
+/*
+Copyright 2023 The Dapr Authors
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package wfengine
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/cenkalti/backoff/v4"
+	"google.golang.org/protobuf/types/known/anypb"
+	"google.golang.org/protobuf/types/known/wrapperspb"
+
+	"github.com/dapr/dapr/pkg/actors"
+	invokev1 "github.com/dapr/dapr/pkg/messaging/v1"
+	schedulerv1pb "github.com/dapr/dapr/pkg/proto/scheduler/v1"
+)
+
+const (
+	// ExecuteSchedulerJobMethod is the method of the internal actors of the workflow engine that runs a job of the scheduler service as a reminder of the actor.
+	ExecuteSchedulerJobMethod = "ExecuteSchedulerJob"
+
+	schedulerReconnectMinInterval = 500 * time.Millisecond
+	schedulerReconnectMaxInterval = 30 * time.Second
+)
+
+// schedulerReminders schedules the reminders of the internal actors of the workflow engine as jobs of the scheduler service.
+// The jobs aren't saved in the actor state store, unlike the actor reminders.
+type schedulerReminders struct {
+	client    schedulerv1pb.SchedulerClient
+	appID     string
+	namespace string
+}
+
+// schedulerJob is the data of the job scheduled for a reminder, which the target actor receives when the job is triggered.
+type schedulerJob struct {
+	Name    string          `json:"name"`
+	Data    json.RawMessage `json:"data,omitempty"`
+	DueTime string          `json:"dueTime,omitempty"`
+	Period  string          `json:"period,omitempty"`
+}
+
+// schedulerJobResult is the result of a job run by an internal actor.
+type schedulerJobResult struct {
+	// Canceled is true if the actor canceled the reminder, in which case the job is deleted.
+	Canceled bool
+}
+
+// createReminder schedules a job that triggers the reminder after its due time, then every period if it's set.
+// The name of the job is the key of the reminder, so a reminder with the same name replaces the existing one like an actor reminder does.
+func (s *schedulerReminders) createReminder(ctx context.Context, req *actors.CreateReminderRequest) error {
+	jobData, err := json.Marshal(schedulerJob{
+		Name:    req.Name,
+		Data:    req.Data,
+		DueTime: req.DueTime,
+		Period:  req.Period,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to encode the job data: %w", err)
+	}
+	data, err := anypb.New(wrapperspb.Bytes(jobData))
+	if err != nil {
+		return fmt.Errorf("failed to encode the job data: %w", err)
+	}
+
+	var schedule string
+	if req.Period != "" {
+		schedule = "@every " + req.Period
+	}
+
+	_, err = s.client.ScheduleJob(ctx, &schedulerv1pb.ScheduleJobRequest{
+		Job: &schedulerv1pb.Job{
+			Name:     req.Key(),
+			Schedule: schedule,
+			DueTime:  req.DueTime,
+			Data:     data,
+		},
+		Metadata: s.jobMetadata(req.ActorType, req.ActorID),
+	})
+	if err != nil {
+		return fmt.Errorf("failed to schedule the job for reminder '%s': %w", req.Key(), err)
+	}
+	return nil
+}
+
+// deleteReminder deletes the job scheduled for a reminder.
+func (s *schedulerReminders) deleteReminder(ctx context.Context, req *actors.DeleteReminderRequest) error {
+	_, err := s.client.DeleteJob(ctx, &schedulerv1pb.DeleteJobRequest{
+		Name:     req.Key(),
+		Metadata: s.jobMetadata(req.ActorType, req.ActorID),
+	})
+	if err != nil {
+		return fmt.Errorf("failed to delete the job of reminder '%s': %w", req.Key(), err)
+	}
+	return nil
+}
+
+func (s *schedulerReminders) jobMetadata(actorType, actorID string) *schedulerv1pb.JobMetadata {
+	return &schedulerv1pb.JobMetadata{
+		AppId:     s.appID,
+		Namespace: s.namespace,
+		Target: &schedulerv1pb.JobTargetMetadata{
+			Type: &schedulerv1pb.JobTargetMetadata_Actor{
+				Actor: &schedulerv1pb.TargetActorReminder{
+					Type: actorType,
+					Id:   actorID,
+				},
+			},
+		},
+	}
+}
+
+// watchJobs receives the triggered jobs of the internal actors from the scheduler service and runs them, until the context is canceled.
+// The stream is opened again with an exponential backoff when it fails.
+func (s *schedulerReminders) watchJobs(ctx context.Context, actorRuntime actors.Actors, actorTypes []string) {
+	bo := backoff.NewExponentialBackOff()
+	bo.InitialInterval = schedulerReconnectMinInterval
+	bo.MaxInterval = schedulerReconnectMaxInterval
+	bo.MaxElapsedTime = 0 // Retry forever
+
+	for {
+		err := s.watchJobsStream(ctx, actorRuntime, actorTypes, bo)
+		if ctx.Err() != nil {
+			return
+		}
+
+		wait := bo.NextBackOff()
+		wfLogger.Warnf("Failed to watch the jobs of the scheduler service, retrying in %v: %v", wait, err)
+		select {
+		case <-time.After(wait):
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
+// watchJobsStream opens a stream with the scheduler service and runs the jobs it receives concurrently, until the stream fails.
+func (s *schedulerReminders) watchJobsStream(ctx context.Context, actorRuntime actors.Actors, actorTypes []string, bo backoff.BackOff) error {
+	stream, err := s.client.WatchJobs(ctx)
+	if err != nil {
+		return err
+	}
+	err = stream.Send(&schedulerv1pb.WatchJobsRequest{
+		WatchJobRequestType: &schedulerv1pb.WatchJobsRequest_Initial{
+			Initial: &schedulerv1pb.WatchJobsRequestInitial{
+				AppId:      s.appID,
+				Namespace:  s.namespace,
+				ActorTypes: actorTypes,
+			},
+		},
+	})
+	if err != nil {
+		return err
+	}
+
+	var (
+		sendLock sync.Mutex
+		wg       sync.WaitGroup
+	)
+	defer wg.Wait()
+
+	for {
+		job, err := stream.Recv()
+		if err != nil {
+			return err
+		}
+		bo.Reset()
+
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			s.executeJob(ctx, actorRuntime, job)
+
+			// The job is acknowledged even if it failed: like an actor reminder, a job with a schedule is triggered again in the next period
+			sendLock.Lock()
+			err := stream.Send(&schedulerv1pb.WatchJobsRequest{
+				WatchJobRequestType: &schedulerv1pb.WatchJobsRequest_Result{
+					Result: &schedulerv1pb.WatchJobsRequestResult{Id: job.GetId()},
+				},
+			})
+			sendLock.Unlock()
+			if err != nil {
+				wfLogger.Debugf("Failed to acknowledge job '%s': %v", job.GetName(), err)
+			}
+		}()
+	}
+}
+
+// executeJob invokes the target actor of a triggered job, which runs the job as a reminder.
+// The job is deleted if the actor cancels the reminder.
+func (s *schedulerReminders) executeJob(ctx context.Context, actorRuntime actors.Actors, job *schedulerv1pb.WatchJobsResponse) {
+	target := job.GetMetadata().GetTarget().GetActor()
+	if target == nil {
+		wfLogger.Warnf("Ignoring job '%s' of the scheduler service: the job doesn't target an actor", job.GetName())
+		return
+	}
+
+	var jobData wrapperspb.BytesValue
+	if err := job.GetData().UnmarshalTo(&jobData); err != nil {
+		wfLogger.Warnf("Ignoring job '%s' of the scheduler service: failed to decode the job data: %v", job.GetName(), err)
+		return
+	}
+
+	req := invokev1.
+		NewInvokeMethodRequest(ExecuteSchedulerJobMethod).
+		WithActor(target.GetType(), target.GetId()).
+		WithRawDataBytes(jobData.GetValue()).
+		WithContentType(invokev1.JSONContentType)
+	defer req.Close()
+
+	res, err := actorRuntime.Call(ctx, req)
+	if err != nil {
+		wfLogger.Warnf("Failed to run job '%s' of the scheduler service: %v", job.GetName(), err)
+		return
+	}
+	defer res.Close()
+
+	var result schedulerJobResult
+	if err = actors.DecodeInternalActorData(res.RawData(), &result); err != nil {
+		wfLogger.Warnf("Failed to decode the result of job '%s' of the scheduler service: %v", job.GetName(), err)
+		return
+	}
+	if !result.Canceled {
+		return
+	}
+
+	_, err = s.client.DeleteJob(ctx, &schedulerv1pb.DeleteJobRequest{
+		Name:     job.GetName(),
+		Metadata: job.GetMetadata(),
+	})
+	if err != nil {
+		wfLogger.Warnf("Failed to delete canceled job '%s' of the scheduler service: %v", job.GetName(), err)
+	}
+}
+
+// schedulerActors replaces the reminders of the actor runtime with jobs of the scheduler service.
+// It's the actor runtime of the internal actors of the workflow engine when the reminders are scheduled with the scheduler service.
+type schedulerActors struct {
+	actors.Actors
+	reminders *schedulerReminders
+}
+
+// CreateReminder implements actors.Actors.
+func (s *schedulerActors) CreateReminder(ctx context.Context, req *actors.CreateReminderRequest) error {
+	return s.reminders.createReminder(ctx, req)
+}
+
+// DeleteReminder implements actors.Actors.
+func (s *schedulerActors) DeleteReminder(ctx context.Context, req *actors.DeleteReminderRequest) error {
+	return s.reminders.deleteReminder(ctx, req)
+}
+
+// schedulerActorRuntime is the counterpart of schedulerActors for the actor runtime used by the workflow engine itself.
+type schedulerActorRuntime struct {
+	actors.ActorRuntime
+	reminders *schedulerReminders
+}
+
+// CreateReminder implements actors.Actors.
+func (s *schedulerActorRuntime) CreateReminder(ctx context.Context, req *actors.CreateReminderRequest) error {
+	return s.reminders.createReminder(ctx, req)
+}
+
+// DeleteReminder implements actors.Actors.
+func (s *schedulerActorRuntime) DeleteReminder(ctx context.Context, req *actors.DeleteReminderRequest) error {
+	return s.reminders.deleteReminder(ctx, req)
+}
+
+// schedulerInternalActor wraps an internal actor of the workflow engine, to schedule its reminders with the scheduler service
+// and run the triggered jobs as its reminders.
+type schedulerInternalActor struct {
+	actors.InternalActor
+	reminders *schedulerReminders
+}
+
+// SetActorRuntime implements actors.InternalActor.
+func (a *schedulerInternalActor) SetActorRuntime(actorRuntime actors.Actors) {
+	a.InternalActor.SetActorRuntime(&schedulerActors{
+		Actors:    actorRuntime,
+		reminders: a.reminders,
+	})
+}
+
+// InvokeMethod implements actors.InternalActor.
+// The triggered jobs are invoked as a method rather than a reminder, so they can be routed to the actor hosted by another sidecar.
+func (a *schedulerInternalActor) InvokeMethod(ctx context.Context, actorID string, methodName string, data []byte) (any, error) {
+	if methodName != ExecuteSchedulerJobMethod {
+		return a.InternalActor.InvokeMethod(ctx, actorID, methodName, data)
+	}
+
+	var job schedulerJob
+	if err := json.Unmarshal(data, &job); err != nil {
+		return nil, fmt.Errorf("failed to decode the job data: %w", err)
+	}
+	err := a.InternalActor.InvokeReminder(ctx, actorID, job.Name, job.Data, job.DueTime, job.Period)
+	if errors.Is(err, actors.ErrReminderCanceled) {
+		return &schedulerJobResult{Canceled: true}, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &schedulerJobResult{}, nil
+}
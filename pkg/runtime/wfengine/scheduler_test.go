@@ -0,0 +1,329 @@
+/*
+Copyright 2023 The Dapr Authors
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package wfengine
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/protobuf/types/known/anypb"
+	"google.golang.org/protobuf/types/known/wrapperspb"
+
+	"github.com/dapr/dapr/pkg/actors"
+	invokev1 "github.com/dapr/dapr/pkg/messaging/v1"
+	schedulerv1pb "github.com/dapr/dapr/pkg/proto/scheduler/v1"
+)
+
+func TestSchedulerReminders(t *testing.T) {
+	client := newFakeSchedulerClient()
+	reminders := &schedulerReminders{client: client, appID: "app1", namespace: "ns1"}
+
+	t.Run("periodic reminder", func(t *testing.T) {
+		err := reminders.createReminder(context.Background(), &actors.CreateReminderRequest{
+			ActorType: "wf",
+			ActorID:   "abc",
+			Name:      "start-1234",
+			Data:      json.RawMessage(`"foo"`),
+			DueTime:   "0s",
+			Period:    "1m0s",
+		})
+		require.NoError(t, err)
+
+		req := client.lastScheduled()
+		require.NotNil(t, req)
+		assert.Equal(t, "wf||abc||start-1234", req.GetJob().GetName())
+		assert.Equal(t, "@every 1m0s", req.GetJob().GetSchedule())
+		assert.Equal(t, "0s", req.GetJob().GetDueTime())
+		assert.Equal(t, "app1", req.GetMetadata().GetAppId())
+		assert.Equal(t, "ns1", req.GetMetadata().GetNamespace())
+		assert.Equal(t, "wf", req.GetMetadata().GetTarget().GetActor().GetType())
+		assert.Equal(t, "abc", req.GetMetadata().GetTarget().GetActor().GetId())
+
+		var data wrapperspb.BytesValue
+		require.NoError(t, req.GetJob().GetData().UnmarshalTo(&data))
+		var job schedulerJob
+		require.NoError(t, json.Unmarshal(data.GetValue(), &job))
+		assert.Equal(t, schedulerJob{Name: "start-1234", Data: json.RawMessage(`"foo"`), DueTime: "0s", Period: "1m0s"}, job)
+	})
+
+	t.Run("one-off reminder", func(t *testing.T) {
+		err := reminders.createReminder(context.Background(), &actors.CreateReminderRequest{
+			ActorType: "wf",
+			ActorID:   "abc",
+			Name:      "retention-batch",
+			DueTime:   "1s",
+		})
+		require.NoError(t, err)
+		assert.Empty(t, client.lastScheduled().GetJob().GetSchedule())
+	})
+
+	t.Run("delete reminder", func(t *testing.T) {
+		err := reminders.deleteReminder(context.Background(), &actors.DeleteReminderRequest{
+			ActorType: "wf",
+			ActorID:   "abc",
+			Name:      "start-1234",
+		})
+		require.NoError(t, err)
+		assert.Equal(t, []string{"wf||abc||start-1234"}, client.deletedJobs())
+	})
+
+	t.Run("scheduler error", func(t *testing.T) {
+		client.err = errors.New("simulated")
+		defer func() { client.err = nil }()
+
+		err := reminders.createReminder(context.Background(), &actors.CreateReminderRequest{ActorType: "wf", ActorID: "abc", Name: "start-1234"})
+		require.ErrorContains(t, err, "simulated")
+	})
+}
+
+func TestSchedulerInternalActor(t *testing.T) {
+	client := newFakeSchedulerClient()
+	inner := &fakeInternalActor{}
+	actor := &schedulerInternalActor{
+		InternalActor: inner,
+		reminders:     &schedulerReminders{client: client, appID: "app1", namespace: "ns1"},
+	}
+
+	t.Run("reminders of the actor are scheduled with the scheduler", func(t *testing.T) {
+		actor.SetActorRuntime(&fakeActorRuntime{})
+		err := inner.actorRuntime.CreateReminder(context.Background(), &actors.CreateReminderRequest{ActorType: "wf", ActorID: "abc", Name: "timer-1234"})
+		require.NoError(t, err)
+		assert.Equal(t, "wf||abc||timer-1234", client.lastScheduled().GetJob().GetName())
+	})
+
+	t.Run("job runs as a reminder", func(t *testing.T) {
+		data, err := json.Marshal(schedulerJob{Name: "timer-1234", Data: json.RawMessage(`"foo"`), DueTime: "1m0s", Period: "1m0s"})
+		require.NoError(t, err)
+
+		res, err := actor.InvokeMethod(context.Background(), "abc", ExecuteSchedulerJobMethod, data)
+		require.NoError(t, err)
+		assert.Equal(t, &schedulerJobResult{}, res)
+		assert.Equal(t, "abc", inner.reminderActorID)
+		assert.Equal(t, "timer-1234", inner.reminderName)
+		assert.Equal(t, `"foo"`, string(inner.reminderData))
+	})
+
+	t.Run("canceled reminder", func(t *testing.T) {
+		inner.reminderErr = actors.ErrReminderCanceled
+		defer func() { inner.reminderErr = nil }()
+
+		res, err := actor.InvokeMethod(context.Background(), "abc", ExecuteSchedulerJobMethod, []byte(`{"name":"timer-1234"}`))
+		require.NoError(t, err)
+		assert.Equal(t, &schedulerJobResult{Canceled: true}, res)
+	})
+
+	t.Run("failed reminder", func(t *testing.T) {
+		inner.reminderErr = errors.New("simulated")
+		defer func() { inner.reminderErr = nil }()
+
+		_, err := actor.InvokeMethod(context.Background(), "abc", ExecuteSchedulerJobMethod, []byte(`{"name":"timer-1234"}`))
+		require.ErrorContains(t, err, "simulated")
+	})
+
+	t.Run("other methods are invoked on the actor", func(t *testing.T) {
+		res, err := actor.InvokeMethod(context.Background(), "abc", GetWorkflowMetadataMethod, nil)
+		require.NoError(t, err)
+		assert.Equal(t, GetWorkflowMetadataMethod, res)
+	})
+}
+
+func TestSchedulerWatchJobs(t *testing.T) {
+	client := newFakeSchedulerClient()
+	reminders := &schedulerReminders{client: client, appID: "app1", namespace: "ns1"}
+
+	jobData, err := json.Marshal(schedulerJob{Name: "timer-1234"})
+	require.NoError(t, err)
+	data, err := anypb.New(wrapperspb.Bytes(jobData))
+	require.NoError(t, err)
+	metadata := reminders.jobMetadata("wf", "abc")
+
+	actorRuntime := &fakeActorRuntime{
+		callFn: func(req *invokev1.InvokeMethodRequest) (any, error) {
+			assert.Equal(t, ExecuteSchedulerJobMethod, req.Message().GetMethod())
+			assert.Equal(t, "wf", req.Actor().GetActorType())
+			assert.Equal(t, "abc", req.Actor().GetActorId())
+			return &schedulerJobResult{Canceled: req.Actor().GetActorId() == "abc"}, nil
+		},
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		reminders.watchJobs(ctx, actorRuntime, []string{"wf"})
+	}()
+
+	stream := <-client.streams
+	initial := <-stream.sent
+	assert.Equal(t, "app1", initial.GetInitial().GetAppId())
+	assert.Equal(t, "ns1", initial.GetInitial().GetNamespace())
+	assert.Equal(t, []string{"wf"}, initial.GetInitial().GetActorTypes())
+
+	stream.jobs <- &schedulerv1pb.WatchJobsResponse{Name: "wf||abc||timer-1234", Id: 42, Data: data, Metadata: metadata}
+	select {
+	case ack := <-stream.sent:
+		assert.Equal(t, uint64(42), ack.GetResult().GetId())
+	case <-time.After(5 * time.Second):
+		t.Fatal("job was not acknowledged")
+	}
+	assert.Equal(t, []string{"wf||abc||timer-1234"}, client.deletedJobs())
+
+	t.Run("stream is opened again when it fails", func(t *testing.T) {
+		stream.err <- io.EOF
+		select {
+		case stream = <-client.streams:
+		case <-time.After(5 * time.Second):
+			t.Fatal("stream was not opened again")
+		}
+		assert.NotNil(t, (<-stream.sent).GetInitial())
+	})
+
+	cancel()
+	select {
+	case <-done:
+	case <-time.After(5 * time.Second):
+		t.Fatal("watch did not stop")
+	}
+}
+
+type fakeSchedulerClient struct {
+	lock      sync.Mutex
+	scheduled []*schedulerv1pb.ScheduleJobRequest
+	deleted   []string
+	err       error
+	streams   chan *fakeWatchJobsStream
+}
+
+func newFakeSchedulerClient() *fakeSchedulerClient {
+	return &fakeSchedulerClient{streams: make(chan *fakeWatchJobsStream, 1)}
+}
+
+func (c *fakeSchedulerClient) ScheduleJob(ctx context.Context, in *schedulerv1pb.ScheduleJobRequest, opts ...grpc.CallOption) (*schedulerv1pb.ScheduleJobResponse, error) {
+	if c.err != nil {
+		return nil, c.err
+	}
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	c.scheduled = append(c.scheduled, in)
+	return &schedulerv1pb.ScheduleJobResponse{}, nil
+}
+
+func (c *fakeSchedulerClient) DeleteJob(ctx context.Context, in *schedulerv1pb.DeleteJobRequest, opts ...grpc.CallOption) (*schedulerv1pb.DeleteJobResponse, error) {
+	if c.err != nil {
+		return nil, c.err
+	}
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	c.deleted = append(c.deleted, in.GetName())
+	return &schedulerv1pb.DeleteJobResponse{}, nil
+}
+
+func (c *fakeSchedulerClient) WatchJobs(ctx context.Context, opts ...grpc.CallOption) (schedulerv1pb.Scheduler_WatchJobsClient, error) {
+	stream := &fakeWatchJobsStream{
+		ctx:  ctx,
+		sent: make(chan *schedulerv1pb.WatchJobsRequest, 10),
+		jobs: make(chan *schedulerv1pb.WatchJobsResponse),
+		err:  make(chan error),
+	}
+	c.streams <- stream
+	return stream, nil
+}
+
+func (c *fakeSchedulerClient) lastScheduled() *schedulerv1pb.ScheduleJobRequest {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	if len(c.scheduled) == 0 {
+		return nil
+	}
+	return c.scheduled[len(c.scheduled)-1]
+}
+
+func (c *fakeSchedulerClient) deletedJobs() []string {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	return append([]string(nil), c.deleted...)
+}
+
+type fakeWatchJobsStream struct {
+	grpc.ClientStream
+	ctx  context.Context
+	sent chan *schedulerv1pb.WatchJobsRequest
+	jobs chan *schedulerv1pb.WatchJobsResponse
+	err  chan error
+}
+
+func (s *fakeWatchJobsStream) Send(req *schedulerv1pb.WatchJobsRequest) error {
+	s.sent <- req
+	return nil
+}
+
+func (s *fakeWatchJobsStream) Recv() (*schedulerv1pb.WatchJobsResponse, error) {
+	select {
+	case job := <-s.jobs:
+		return job, nil
+	case err := <-s.err:
+		return nil, err
+	case <-s.ctx.Done():
+		return nil, s.ctx.Err()
+	}
+}
+
+type fakeActorRuntime struct {
+	actors.ActorRuntime
+	callFn func(req *invokev1.InvokeMethodRequest) (any, error)
+}
+
+func (f *fakeActorRuntime) Call(ctx context.Context, req *invokev1.InvokeMethodRequest) (*invokev1.InvokeMethodResponse, error) {
+	result, err := f.callFn(req)
+	if err != nil {
+		return nil, err
+	}
+	data, err := actors.EncodeInternalActorData(result)
+	if err != nil {
+		return nil, err
+	}
+	return invokev1.NewInvokeMethodResponse(200, "OK", nil).WithRawDataBytes(data), nil
+}
+
+type fakeInternalActor struct {
+	actors.InternalActor
+	actorRuntime    actors.Actors
+	reminderActorID string
+	reminderName    string
+	reminderData    []byte
+	reminderErr     error
+}
+
+func (f *fakeInternalActor) SetActorRuntime(actorRuntime actors.Actors) {
+	f.actorRuntime = actorRuntime
+}
+
+func (f *fakeInternalActor) InvokeMethod(ctx context.Context, actorID string, methodName string, data []byte) (any, error) {
+	return methodName, nil
+}
+
+func (f *fakeInternalActor) InvokeReminder(ctx context.Context, actorID string, reminderName string, data []byte, dueTime string, period string) error {
+	f.reminderActorID = actorID
+	f.reminderName = reminderName
+	f.reminderData = data
+	return f.reminderErr
+}
@@ -0,0 +1,202 @@
+/*
+Copyright 2023 The Dapr Authors
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package wfengine
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/binary"
+	"time"
+
+	"github.com/microsoft/durabletask-go/api"
+	"github.com/microsoft/durabletask-go/backend"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+	"google.golang.org/protobuf/proto"
+
+	diag "github.com/dapr/dapr/pkg/diagnostics"
+	diagConsts "github.com/dapr/dapr/pkg/diagnostics/consts"
+	diagUtils "github.com/dapr/dapr/pkg/diagnostics/utils"
+)
+
+// Prefixes of the names of the spans of the workflows, followed by the name of the workflow, activity or event.
+const (
+	workflowSpanNamePrefix = "orchestration||"
+	activitySpanNamePrefix = "activity||"
+	eventSpanNamePrefix    = "event||"
+	timerSpanName          = "timer"
+)
+
+var tracer = otel.Tracer("dapr-workflows")
+
+// contextWithSpan returns a context carrying the span of the request in ctx, so durabletask stamps its trace context into the history events it creates.
+// This is needed because the Dapr HTTP server doesn't store the span in the context the way OpenTelemetry does.
+func contextWithSpan(ctx context.Context) context.Context {
+	span := diagUtils.SpanFromContext(ctx)
+	if span == nil || !span.SpanContext().IsValid() {
+		return ctx
+	}
+	return trace.ContextWithSpan(ctx, span)
+}
+
+// workflowStartEvent returns the event that started the current generation of the workflow, either from its history or from its inbox if the workflow hasn't run yet.
+func workflowStartEvent(state *workflowState) *backend.HistoryEvent {
+	for _, events := range [][]*backend.HistoryEvent{state.History, state.Inbox} {
+		for _, e := range events {
+			if e.GetExecutionStarted() != nil {
+				return e
+			}
+		}
+	}
+	return nil
+}
+
+// workflowSpanContext returns the span context of the span of the workflow instance started by startEvent.
+// The span ID is derived from the instance ID and the start time, so it's the same on every execution of the workflow, even by another instance of the sidecar: this allows using the span as parent of the spans of the activities, timers and events, and emitting it only when the workflow completes.
+// The span context is invalid if the workflow wasn't started with a trace context.
+func workflowSpanContext(instanceID string, startEvent *backend.HistoryEvent) trace.SpanContext {
+	tc := startEvent.GetExecutionStarted().GetParentTraceContext()
+	parent, ok := diag.SpanContextFromW3CString(tc.GetTraceParent())
+	if !ok {
+		return trace.SpanContext{}
+	}
+
+	h := sha256.New()
+	h.Write([]byte(instanceID))
+	_ = binary.Write(h, binary.BigEndian, startEvent.GetTimestamp().AsTime().UnixNano())
+	var sid trace.SpanID
+	copy(sid[:], h.Sum(nil))
+	return parent.WithSpanID(sid).WithTraceState(*diag.TraceStateFromW3CString(tc.GetTraceState().GetValue()))
+}
+
+// stampParentTraceContext stamps the trace context of the workflow into the activities and child workflows it schedules, if they don't have one already.
+// The trace context is saved in the workflow history, so the spans of the activities and child workflows are parented to the span of the workflow instance, see workflowSpanContext, even when the workflow is replayed by another instance.
+func stampParentTraceContext(startEvent *backend.HistoryEvent, instanceSpan trace.SpanContext, e *backend.HistoryEvent) {
+	tc := startEvent.GetExecutionStarted().GetParentTraceContext()
+	if tc == nil {
+		return
+	}
+	if instanceSpan.IsValid() {
+		tc = cloneProto(tc)
+		tc.TraceParent = diag.SpanContextToW3CString(instanceSpan)
+	}
+
+	if ts := e.GetTaskScheduled(); ts != nil && ts.GetParentTraceContext() == nil {
+		ts.ParentTraceContext = tc
+	} else if es := e.GetExecutionStarted(); es != nil && es.GetParentTraceContext() == nil {
+		es.ParentTraceContext = tc
+	}
+}
+
+// contextWithParentTraceContext returns a context carrying the trace context stamped in the workflow history, so the spans created while processing the event, and the calls made to other Dapr sidecars, are parented to the request that originated it.
+// The context is returned unchanged if the trace context is missing or invalid.
+func contextWithParentTraceContext(ctx context.Context, traceParent, traceState string) context.Context {
+	sc, ok := diag.SpanContextFromW3CString(traceParent)
+	if !ok {
+		return ctx
+	}
+	sc = sc.WithTraceState(*diag.TraceStateFromW3CString(traceState))
+	return trace.ContextWithRemoteSpanContext(ctx, sc)
+}
+
+// cloneProto returns a deep copy of the message, with its type, which may not be exported.
+func cloneProto[T proto.Message](m T) T {
+	return proto.Clone(m).(T)
+}
+
+// emitWorkflowSpans emits the spans of the timers that fired and the events that were received in the new events of an execution of the workflow, and the span of the workflow instance if the execution completed it.
+// history contains the events of the previous executions, and ctx must carry the trace context of the request that started the workflow, which is the parent of the span of the workflow instance.
+func emitWorkflowSpans(ctx context.Context, instanceID string, startEvent *backend.HistoryEvent, history []*backend.HistoryEvent, newEvents []*backend.HistoryEvent, status api.OrchestrationStatus) {
+	instanceSpan := workflowSpanContext(instanceID, startEvent)
+	if !instanceSpan.IsValid() {
+		return
+	}
+	childCtx := trace.ContextWithRemoteSpanContext(ctx, instanceSpan)
+	instanceAttr := attribute.String(diagConsts.DaprWorkflowInstanceID, instanceID)
+
+	var completed *backend.HistoryEvent
+	for _, e := range newEvents {
+		switch {
+		case e.GetTimerFired() != nil:
+			tf := e.GetTimerFired()
+			start := e.GetTimestamp().AsTime()
+			for _, h := range history {
+				if h.GetTimerCreated() != nil && h.GetEventId() == tf.GetTimerId() {
+					start = h.GetTimestamp().AsTime()
+					break
+				}
+			}
+			fireAt := tf.GetFireAt().AsTime()
+			if fireAt.Before(start) {
+				fireAt = start
+			}
+			_, span := tracer.Start(childCtx, timerSpanName,
+				trace.WithTimestamp(start),
+				trace.WithAttributes(instanceAttr, attribute.String(diagConsts.DaprWorkflowTimerFireAt, fireAt.UTC().Format(time.RFC3339))),
+			)
+			span.End(trace.WithTimestamp(fireAt))
+		case e.GetEventRaised() != nil:
+			name := e.GetEventRaised().GetName()
+			ts := e.GetTimestamp().AsTime()
+			_, span := tracer.Start(childCtx, eventSpanNamePrefix+name,
+				trace.WithTimestamp(ts),
+				trace.WithAttributes(instanceAttr, attribute.String(diagConsts.DaprWorkflowEventName, name)),
+			)
+			span.End(trace.WithTimestamp(ts))
+		case e.GetExecutionCompleted() != nil:
+			completed = e
+		}
+	}
+	if completed == nil {
+		return
+	}
+
+	name := startEvent.GetExecutionStarted().GetName()
+	_, span := tracer.Start(diag.ContextWithSpanID(ctx, instanceSpan.SpanID()), workflowSpanNamePrefix+name,
+		trace.WithTimestamp(startEvent.GetTimestamp().AsTime()),
+		trace.WithAttributes(
+			instanceAttr,
+			attribute.String(diagConsts.DaprWorkflowName, name),
+			attribute.String(diagConsts.DaprWorkflowStatus, status.String()),
+		),
+	)
+	if status == api.RUNTIME_STATUS_FAILED {
+		span.SetStatus(codes.Error, completed.GetExecutionCompleted().GetFailureDetails().GetErrorMessage())
+	}
+	span.End(trace.WithTimestamp(completed.GetTimestamp().AsTime()))
+}
+
+// startActivitySpan starts the span of the execution of an activity, which is a child of the span of the workflow instance stamped in the task event.
+func startActivitySpan(ctx context.Context, instanceID string, taskEvent *backend.HistoryEvent) (context.Context, trace.Span) {
+	return tracer.Start(ctx, activitySpanNamePrefix+taskEvent.GetTaskScheduled().GetName(),
+		trace.WithAttributes(
+			attribute.String(diagConsts.DaprWorkflowInstanceID, instanceID),
+			attribute.String(diagConsts.DaprWorkflowActivityName, taskEvent.GetTaskScheduled().GetName()),
+			attribute.Int64(diagConsts.DaprWorkflowActivityTaskID, int64(taskEvent.GetEventId())),
+		),
+	)
+}
+
+// endActivitySpan ends the span of the execution of an activity, with the error returned by the execution or the failure reported by the activity.
+func endActivitySpan(span trace.Span, result *backend.HistoryEvent, err error) {
+	switch {
+	case err != nil:
+		span.SetStatus(codes.Error, err.Error())
+	case result.GetTaskFailed() != nil:
+		span.SetStatus(codes.Error, result.GetTaskFailed().GetFailureDetails().GetErrorMessage())
+	}
+	span.End()
+}
@@ -0,0 +1,180 @@
+/*
+Copyright 2023 The Dapr Authors
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package wfengine
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/microsoft/durabletask-go/api"
+	"github.com/microsoft/durabletask-go/backend"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/codes"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"go.opentelemetry.io/otel/trace"
+	"google.golang.org/protobuf/encoding/protojson"
+
+	diag "github.com/dapr/dapr/pkg/diagnostics"
+)
+
+func TestContextWithParentTraceContext(t *testing.T) {
+	const traceParent = "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01"
+
+	t.Run("restores the span context", func(t *testing.T) {
+		ctx := contextWithParentTraceContext(context.Background(), traceParent, "congo=t61rcWkgMzE")
+
+		sc := trace.SpanContextFromContext(ctx)
+		require.True(t, sc.IsValid())
+		assert.True(t, sc.IsRemote())
+		assert.Equal(t, traceParent, diag.SpanContextToW3CString(sc))
+		assert.Equal(t, "congo=t61rcWkgMzE", diag.TraceStateToW3CString(sc))
+	})
+
+	t.Run("invalid trace context", func(t *testing.T) {
+		ctx := context.Background()
+		assert.Equal(t, ctx, contextWithParentTraceContext(ctx, "", ""))
+		assert.Equal(t, ctx, contextWithParentTraceContext(ctx, "not-a-trace-parent", ""))
+	})
+}
+
+func TestContextWithSpan(t *testing.T) {
+	t.Run("no span", func(t *testing.T) {
+		ctx := context.Background()
+		assert.Equal(t, ctx, contextWithSpan(ctx))
+	})
+
+	t.Run("span is preserved", func(t *testing.T) {
+		ctx := contextWithParentTraceContext(context.Background(), "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01", "")
+		sc := trace.SpanContextFromContext(contextWithSpan(ctx))
+		assert.Equal(t, trace.SpanContextFromContext(ctx), sc)
+	})
+}
+
+func TestWorkflowStartEvent(t *testing.T) {
+	state := NewWorkflowState(actorsBackendConfig{})
+	assert.Nil(t, workflowStartEvent(state))
+
+	state.AddToInbox(&backend.HistoryEvent{EventId: 1})
+	assert.Nil(t, workflowStartEvent(state))
+
+	// Events without a trace context are left untouched
+	e := &backend.HistoryEvent{EventId: 2}
+	stampParentTraceContext(nil, trace.SpanContext{}, e)
+	assert.Nil(t, e.GetTaskScheduled())
+}
+
+// historyEvent returns the history event in the JSON format, since the types of the events aren't exported by durabletask.
+func historyEvent(t *testing.T, event string) *backend.HistoryEvent {
+	t.Helper()
+
+	e := &backend.HistoryEvent{}
+	require.NoError(t, protojson.Unmarshal([]byte(event), e))
+	return e
+}
+
+func TestWorkflowSpanContext(t *testing.T) {
+	startEvent := historyEvent(t, `{
+		"eventId": -1,
+		"timestamp": "2023-10-01T12:00:00Z",
+		"executionStarted": {"name": "wf", "parentTraceContext": {"traceParent": "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01"}}
+	}`)
+
+	sc := workflowSpanContext("instance1", startEvent)
+	require.True(t, sc.IsValid())
+	assert.Equal(t, "4bf92f3577b34da6a3ce929d0e0e4736", sc.TraceID().String())
+	assert.NotEqual(t, "00f067aa0ba902b7", sc.SpanID().String())
+	assert.True(t, sc.IsSampled())
+
+	// The span ID is the same on every execution of the workflow
+	assert.Equal(t, sc, workflowSpanContext("instance1", startEvent))
+	assert.NotEqual(t, sc.SpanID(), workflowSpanContext("instance2", startEvent).SpanID())
+
+	// Workflows started without a trace context have no span
+	assert.False(t, workflowSpanContext("instance1", historyEvent(t, `{"executionStarted": {"name": "wf"}}`)).IsValid())
+}
+
+func TestStampParentTraceContext(t *testing.T) {
+	const traceParent = "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01"
+	startEvent := historyEvent(t, `{"executionStarted": {"name": "wf", "parentTraceContext": {"traceParent": "`+traceParent+`"}}}`)
+	instanceSpan := workflowSpanContext("instance1", startEvent)
+
+	e := historyEvent(t, `{"eventId": 1, "taskScheduled": {"name": "activity"}}`)
+	stampParentTraceContext(startEvent, instanceSpan, e)
+	assert.Equal(t, diag.SpanContextToW3CString(instanceSpan), e.GetTaskScheduled().GetParentTraceContext().GetTraceParent())
+	assert.Equal(t, traceParent, startEvent.GetExecutionStarted().GetParentTraceContext().GetTraceParent())
+
+	// Without the span of the workflow instance, the trace context of the request is stamped
+	e = historyEvent(t, `{"eventId": 2, "taskScheduled": {"name": "activity"}}`)
+	stampParentTraceContext(startEvent, trace.SpanContext{}, e)
+	assert.Equal(t, traceParent, e.GetTaskScheduled().GetParentTraceContext().GetTraceParent())
+}
+
+func TestEmitWorkflowSpans(t *testing.T) {
+	recorder := tracetest.NewSpanRecorder()
+	oldTracerProvider := otel.GetTracerProvider()
+	otel.SetTracerProvider(sdktrace.NewTracerProvider(
+		sdktrace.WithSpanProcessor(recorder),
+		sdktrace.WithIDGenerator(diag.NewIDGenerator()),
+	))
+	t.Cleanup(func() {
+		otel.SetTracerProvider(oldTracerProvider)
+	})
+
+	const traceParent = "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01"
+	start := time.Date(2023, 10, 1, 12, 0, 0, 0, time.UTC)
+	startEvent := historyEvent(t, `{
+		"eventId": -1,
+		"timestamp": "2023-10-01T12:00:00Z",
+		"executionStarted": {"name": "wf", "parentTraceContext": {"traceParent": "`+traceParent+`"}}
+	}`)
+	history := []*backend.HistoryEvent{
+		startEvent,
+		historyEvent(t, `{"eventId": 3, "timestamp": "2023-10-01T12:00:01Z", "timerCreated": {"fireAt": "2023-10-01T12:01:01Z"}}`),
+	}
+	newEvents := []*backend.HistoryEvent{
+		historyEvent(t, `{"eventId": -1, "timestamp": "2023-10-01T12:00:01Z", "timerFired": {"timerId": 3, "fireAt": "2023-10-01T12:01:01Z"}}`),
+		historyEvent(t, `{"eventId": -1, "timestamp": "2023-10-01T12:00:30Z", "eventRaised": {"name": "approval"}}`),
+		historyEvent(t, `{"eventId": 4, "timestamp": "2023-10-01T12:02:00Z", "executionCompleted": {"orchestrationStatus": "ORCHESTRATION_STATUS_FAILED", "failureDetails": {"errorMessage": "boom"}}}`),
+	}
+
+	ctx := contextWithParentTraceContext(context.Background(), traceParent, "")
+	emitWorkflowSpans(ctx, "instance1", startEvent, history, newEvents, api.RUNTIME_STATUS_FAILED)
+
+	instanceSpan := workflowSpanContext("instance1", startEvent)
+	ended := recorder.Ended()
+	require.Len(t, ended, 3)
+
+	timer := ended[0]
+	assert.Equal(t, timerSpanName, timer.Name())
+	assert.Equal(t, instanceSpan.SpanID(), timer.Parent().SpanID())
+	assert.Equal(t, start.Add(time.Second), timer.StartTime())
+	assert.Equal(t, start.Add(time.Minute+time.Second), timer.EndTime())
+
+	event := ended[1]
+	assert.Equal(t, "event||approval", event.Name())
+	assert.Equal(t, instanceSpan.SpanID(), event.Parent().SpanID())
+
+	workflow := ended[2]
+	assert.Equal(t, "orchestration||wf", workflow.Name())
+	assert.Equal(t, instanceSpan.SpanID(), workflow.SpanContext().SpanID())
+	assert.Equal(t, "00f067aa0ba902b7", workflow.Parent().SpanID().String())
+	assert.Equal(t, start, workflow.StartTime())
+	assert.Equal(t, start.Add(2*time.Minute), workflow.EndTime())
+	assert.Equal(t, codes.Error, workflow.Status().Code)
+	assert.Equal(t, "boom", workflow.Status().Description)
+}
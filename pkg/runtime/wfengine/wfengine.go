@@ -27,6 +27,8 @@ import (
 
 	"github.com/dapr/dapr/pkg/actors"
 	"github.com/dapr/dapr/pkg/config"
+	schedulerv1pb "github.com/dapr/dapr/pkg/proto/scheduler/v1"
+	"github.com/dapr/dapr/utils"
 	"github.com/dapr/kit/logger"
 )
 
@@ -41,16 +43,24 @@ type WorkflowEngine struct {
 	actorRuntime  actors.ActorRuntime
 	actorsReady   atomic.Bool
 	actorsReadyCh chan struct{}
+	ready         atomic.Bool
 
-	startMutex     sync.Mutex
-	disconnectChan chan any
-	spec           config.WorkflowSpec
+	startMutex      sync.Mutex
+	disconnectChan  chan any
+	spec            config.WorkflowSpec
+	shutdownTimeout time.Duration
+
+	// schedulerReminders is set if the reminders of the internal actors are scheduled with the scheduler service instead of the actor runtime.
+	schedulerReminders *schedulerReminders
+	stopSchedulerWatch context.CancelFunc
+	schedulerWatchDone chan struct{}
 }
 
 const (
 	defaultNamespace     = "default"
 	WorkflowNameLabelKey = "workflow"
 	ActivityNameLabelKey = "activity"
+	IndexNameLabelKey    = "workflowindex"
 )
 
 var (
@@ -64,19 +74,57 @@ func IsWorkflowRequest(path string) bool {
 }
 
 func NewWorkflowEngine(appID string, spec config.WorkflowSpec) *WorkflowEngine {
+	shutdownTimeout, err := spec.GetShutdownTimeout()
+	if err != nil {
+		wfLogger.Warnf("Ignoring workflow shutdown timeout: %v", err)
+		shutdownTimeout, _ = (&config.WorkflowSpec{}).GetShutdownTimeout()
+	}
+
 	engine := &WorkflowEngine{
-		spec:          spec,
-		actorsReadyCh: make(chan struct{}),
+		spec:            spec,
+		shutdownTimeout: shutdownTimeout,
+		actorsReadyCh:   make(chan struct{}),
 	}
 	be := NewActorBackend(appID)
 	engine.backend = be
 
+	maxAge, maxCompleted, err := spec.GetRetention()
+	if err != nil {
+		wfLogger.Warnf("Ignoring workflow retention policy: %v", err)
+	} else {
+		be.indexActor.retention.maxAge = maxAge
+		be.indexActor.retention.maxCompleted = maxCompleted
+	}
+
+	eventBufferTTL, err := spec.GetEventBufferTTL()
+	if err != nil {
+		wfLogger.Warnf("Ignoring workflow event buffer TTL: %v", err)
+	} else {
+		be.workflowActor.eventBufferTTL = eventBufferTTL
+	}
+
+	remoteWorkflows, err := spec.GetRemoteWorkflows()
+	if err != nil {
+		wfLogger.Warnf("Ignoring remote workflows: %v", err)
+	} else {
+		be.workflowActor.remoteWorkflows = remoteWorkflows
+	}
+
 	return engine
 }
 
 // GetInternalActorsMap returns a map of internal actors that are used to implement workflows
 func (wfe *WorkflowEngine) GetInternalActorsMap() map[string]actors.InternalActor {
-	return wfe.backend.GetInternalActorsMap()
+	internalActors := wfe.backend.GetInternalActorsMap()
+	if wfe.schedulerReminders != nil {
+		for actorType, actor := range internalActors {
+			internalActors[actorType] = &schedulerInternalActor{
+				InternalActor: actor,
+				reminders:     wfe.schedulerReminders,
+			}
+		}
+	}
+	return internalActors
 }
 
 func (wfe *WorkflowEngine) RegisterGrpcServer(grpcServer *grpc.Server) {
@@ -109,9 +157,28 @@ func (wfe *WorkflowEngine) SetExecutor(fn func(be backend.Backend) backend.Execu
 	wfe.executor = fn(wfe.backend)
 }
 
+// SetSchedulerClient configures the workflow engine to schedule the reminders of its internal actors, such as the durable timers
+// of the workflows, as jobs of the scheduler service instead of actor reminders.
+// It must be called before SetActorRuntime.
+func (wfe *WorkflowEngine) SetSchedulerClient(client schedulerv1pb.SchedulerClient) {
+	wfe.schedulerReminders = &schedulerReminders{
+		client:    client,
+		appID:     wfe.backend.config.AppID,
+		namespace: utils.GetNamespaceOrDefault(defaultNamespace),
+	}
+}
+
 func (wfe *WorkflowEngine) SetActorRuntime(actorRuntime actors.ActorRuntime) {
 	if actorRuntime != nil {
-		wfLogger.Info("Configuring workflow engine with actors backend")
+		if wfe.schedulerReminders != nil {
+			wfLogger.Info("Configuring workflow engine with actors backend and scheduler reminders")
+			actorRuntime = &schedulerActorRuntime{
+				ActorRuntime: actorRuntime,
+				reminders:    wfe.schedulerReminders,
+			}
+		} else {
+			wfLogger.Info("Configuring workflow engine with actors backend")
+		}
 		wfe.actorRuntime = actorRuntime
 		wfe.backend.SetActorRuntime(actorRuntime)
 	}
@@ -131,6 +198,13 @@ func (wfe *WorkflowEngine) WaitForActorsReady(ctx context.Context) {
 	}
 }
 
+// IsReady returns true if the workflow engine can accept orchestration traffic.
+// This is the case once the internal actors are registered with the actor runtime and the backend
+// worker is started, which happens when the first workflow app connects to fetch work items.
+func (wfe *WorkflowEngine) IsReady() bool {
+	return wfe.ready.Load()
+}
+
 // DisableActorCaching turns off the default caching done by the workflow and activity actors.
 // This method is primarily intended to be used for testing to ensure correct behavior
 // when actors are newly activated on nodes, but without requiring the actor to actually
@@ -162,6 +236,12 @@ func (wfe *WorkflowEngine) SetActorReminderInterval(interval time.Duration) {
 	wfe.backend.activityActor.reminderInterval = interval
 }
 
+// SetRetentionInterval sets the interval between two purges of the completed workflow instances with the retention policy.
+// This function is only intended to be used for testing.
+func (wfe *WorkflowEngine) SetRetentionInterval(interval time.Duration) {
+	wfe.backend.indexActor.retention.interval = interval
+}
+
 // SetLogLevel sets the logging level for the workflow engine.
 // This function is only intended to be used for testing.
 func SetLogLevel(level logger.LogLevel) {
@@ -187,13 +267,21 @@ func (wfe *WorkflowEngine) Start(ctx context.Context) (err error) {
 		return errors.New("gRPC executor is not yet configured")
 	}
 
-	for actorType, actor := range wfe.GetInternalActorsMap() {
+	internalActors := wfe.GetInternalActorsMap()
+	for actorType, actor := range internalActors {
 		err = wfe.actorRuntime.RegisterInternalActor(ctx, actorType, actor, time.Minute*1)
 		if err != nil {
 			return fmt.Errorf("failed to register workflow actor %s: %w", actorType, err)
 		}
 	}
 
+	if err = wfe.startRetention(ctx); err != nil {
+		return err
+	}
+	if err = wfe.startIndexStats(ctx); err != nil {
+		return err
+	}
+
 	// There are separate "workers" for executing orchestrations (workflows) and activities
 	orchestrationWorker := backend.NewOrchestrationWorker(
 		wfe.backend,
@@ -212,12 +300,34 @@ func (wfe *WorkflowEngine) Start(ctx context.Context) (err error) {
 		return fmt.Errorf("failed to start workflow engine: %w", err)
 	}
 
+	if wfe.schedulerReminders != nil {
+		wfe.startSchedulerWatch(internalActors)
+	}
+
 	wfe.IsRunning = true
+	wfe.ready.Store(true)
 	wfLogger.Info("Workflow engine started")
 
 	return nil
 }
 
+// startSchedulerWatch starts receiving the jobs of the internal actors from the scheduler service in background, until the engine is closed.
+func (wfe *WorkflowEngine) startSchedulerWatch(internalActors map[string]actors.InternalActor) {
+	actorTypes := make([]string, 0, len(internalActors))
+	for actorType := range internalActors {
+		actorTypes = append(actorTypes, actorType)
+	}
+
+	// The engine is started with the context of the first connection of the app, so the watch isn't bound to it
+	ctx, cancel := context.WithCancel(context.Background())
+	wfe.stopSchedulerWatch = cancel
+	wfe.schedulerWatchDone = make(chan struct{})
+	go func() {
+		defer close(wfe.schedulerWatchDone)
+		wfe.schedulerReminders.watchJobs(ctx, wfe.actorRuntime, actorTypes)
+	}()
+}
+
 func (wfe *WorkflowEngine) Close(ctx context.Context) error {
 	wfe.startMutex.Lock()
 	defer wfe.startMutex.Unlock()
@@ -226,7 +336,23 @@ func (wfe *WorkflowEngine) Close(ctx context.Context) error {
 		return nil
 	}
 
+	wfe.ready.Store(false)
+
+	// Stop running the jobs of the scheduler service before the in-flight executions are quiesced
+	if wfe.stopSchedulerWatch != nil {
+		wfe.stopSchedulerWatch()
+		<-wfe.schedulerWatchDone
+		wfe.stopSchedulerWatch = nil
+	}
+
 	if wfe.worker != nil {
+		// Stop starting new executions, and give the in-flight ones time to complete and save their state before the worker is stopped
+		quiesceCtx, cancel := context.WithTimeout(ctx, wfe.shutdownTimeout)
+		if !wfe.backend.workItems.quiesce(quiesceCtx) {
+			wfLogger.Warnf("Workflow executions still in-flight after %v were preempted and will be retried later", wfe.shutdownTimeout)
+		}
+		cancel()
+
 		if wfe.disconnectChan != nil {
 			// Signals to the durabletask-go gRPC service to disconnect the app client.
 			// This is important to complete the graceful shutdown sequence in a timely manner.
@@ -22,6 +22,7 @@ package wfengine_test
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 	"sort"
 	"strconv"
@@ -458,9 +459,165 @@ func TestRecreateCompletedWorkflow(t *testing.T) {
 func TestInternalActorsSetupForWF(t *testing.T) {
 	ctx := context.Background()
 	_, engine := startEngine(ctx, t, task.NewTaskRegistry())
-	assert.Len(t, engine.GetInternalActorsMap(), 2)
+	assert.Len(t, engine.GetInternalActorsMap(), 3)
 	assert.Contains(t, engine.GetInternalActorsMap(), workflowActorType)
 	assert.Contains(t, engine.GetInternalActorsMap(), activityActorType)
+	assert.Contains(t, engine.GetInternalActorsMap(), indexActorType)
+}
+
+// TestListWorkflows verifies that the workflow instances are listed from the index, with filters and pagination.
+func TestListWorkflows(t *testing.T) {
+	r := task.NewTaskRegistry()
+	r.AddOrchestratorN("ListedA", func(*task.OrchestrationContext) (any, error) {
+		return nil, nil
+	})
+	r.AddOrchestratorN("ListedB", func(*task.OrchestrationContext) (any, error) {
+		return nil, nil
+	})
+	r.AddOrchestratorN("ListedWaiting", func(ctx *task.OrchestrationContext) (any, error) {
+		return nil, ctx.WaitForSingleEvent("Never", 30*time.Second).Await(nil)
+	})
+
+	ctx := context.Background()
+	client, engine, store := startEngineAndGetStore(ctx, t, r)
+	lister := wfengine.BuiltinWorkflowFactory(engine)(logger.NewLogger("test")).(interface {
+		ListWorkflows(ctx context.Context, req *wfengine.ListWorkflowsRequest) (*wfengine.ListWorkflowsResponse, error)
+	})
+
+	preStartTime := time.Now().UTC()
+	ids := make([]api.InstanceID, 0, 3)
+	for _, name := range []string{"ListedA", "ListedB", "ListedA"} {
+		id, err := client.ScheduleNewOrchestration(ctx, name)
+		require.NoError(t, err)
+		_, err = client.WaitForOrchestrationCompletion(ctx, id)
+		require.NoError(t, err)
+		ids = append(ids, id)
+	}
+	waitingID, err := client.ScheduleNewOrchestration(ctx, "ListedWaiting")
+	require.NoError(t, err)
+	_, err = client.WaitForOrchestrationStart(ctx, waitingID)
+	require.NoError(t, err)
+
+	// The index is updated after the state of the workflows is saved
+	listIDs := func(req *wfengine.ListWorkflowsRequest) []string {
+		res, err := lister.ListWorkflows(ctx, req)
+		require.NoError(t, err)
+		listed := make([]string, len(res.Instances))
+		for i, instance := range res.Instances {
+			listed[i] = instance.InstanceID
+		}
+		return listed
+	}
+	assert.EventuallyWithT(t, func(c *assert.CollectT) {
+		res, err := lister.ListWorkflows(ctx, &wfengine.ListWorkflowsRequest{RuntimeStatuses: []string{"running"}})
+		if assert.NoError(c, err) && assert.Len(c, res.Instances, 1) {
+			assert.Equal(c, string(waitingID), res.Instances[0].InstanceID)
+			assert.Equal(c, "ListedWaiting", res.Instances[0].WorkflowName)
+			assert.False(c, res.Instances[0].CreatedAt.Before(preStartTime))
+		}
+	}, 5*time.Second, 50*time.Millisecond)
+
+	t.Run("filter by status and name", func(t *testing.T) {
+		assert.Equal(t, []string{string(ids[0]), string(ids[2])}, listIDs(&wfengine.ListWorkflowsRequest{
+			RuntimeStatuses: []string{"COMPLETED"},
+			WorkflowName:    "ListedA",
+		}))
+	})
+
+	t.Run("filter by creation time", func(t *testing.T) {
+		assert.Empty(t, listIDs(&wfengine.ListWorkflowsRequest{CreatedBefore: preStartTime}))
+		assert.Len(t, listIDs(&wfengine.ListWorkflowsRequest{CreatedAfter: preStartTime}), 4)
+	})
+
+	t.Run("pagination", func(t *testing.T) {
+		req := &wfengine.ListWorkflowsRequest{PageSize: 3}
+		res, err := lister.ListWorkflows(ctx, req)
+		require.NoError(t, err)
+		require.Len(t, res.Instances, 3)
+		require.NotEmpty(t, res.ContinuationToken)
+		assert.Equal(t, string(ids[0]), res.Instances[0].InstanceID)
+
+		req.ContinuationToken = res.ContinuationToken
+		res, err = lister.ListWorkflows(ctx, req)
+		require.NoError(t, err)
+		require.Len(t, res.Instances, 1)
+		assert.Equal(t, string(waitingID), res.Instances[0].InstanceID)
+		assert.Empty(t, res.ContinuationToken)
+	})
+
+	t.Run("each instance has its own entry in the index", func(t *testing.T) {
+		for _, id := range append(ids, waitingID) {
+			found := false
+			for key := range store.GetItems() {
+				if strings.HasSuffix(key, "||entry||"+string(id)) {
+					found = true
+					break
+				}
+			}
+			assert.Truef(t, found, "no index entry for instance '%s'", id)
+		}
+	})
+
+	t.Run("invalid continuation token", func(t *testing.T) {
+		_, err := lister.ListWorkflows(ctx, &wfengine.ListWorkflowsRequest{ContinuationToken: "!!!"})
+		require.ErrorIs(t, err, wfengine.ErrInvalidContinuationToken)
+	})
+
+	t.Run("purged instances are removed", func(t *testing.T) {
+		require.NoError(t, client.PurgeOrchestrationState(ctx, ids[1]))
+		assert.Equal(t, []string{string(ids[0]), string(ids[2])}, listIDs(&wfengine.ListWorkflowsRequest{
+			RuntimeStatuses: []string{"COMPLETED"},
+		}))
+	})
+}
+
+// TestWorkflowRetention verifies that the completed workflow instances beyond the retention policy are purged.
+func TestWorkflowRetention(t *testing.T) {
+	r := task.NewTaskRegistry()
+	r.AddOrchestratorN("Retained", func(*task.OrchestrationContext) (any, error) {
+		return nil, nil
+	})
+
+	ctx := context.Background()
+	engine, store := getEngineAndStateStoreWithSpec(t, config.WorkflowSpec{
+		MaxConcurrentWorkflowInvocations: 100,
+		MaxConcurrentActivityInvocations: 100,
+		Retention:                        &config.WorkflowRetentionSpec{MaxCompletedInstances: 1},
+	})
+	engine.SetRetentionInterval(200 * time.Millisecond)
+	var client backend.TaskHubClient
+	engine.SetExecutor(func(be backend.Backend) backend.Executor {
+		client = backend.NewTaskHubClient(be)
+		return task.NewTaskExecutor(r)
+	})
+	require.NoError(t, engine.Start(ctx))
+	lister := wfengine.BuiltinWorkflowFactory(engine)(logger.NewLogger("test")).(interface {
+		ListWorkflows(ctx context.Context, req *wfengine.ListWorkflowsRequest) (*wfengine.ListWorkflowsResponse, error)
+	})
+
+	ids := make([]api.InstanceID, 0, 3)
+	for i := 0; i < 3; i++ {
+		id, err := client.ScheduleNewOrchestration(ctx, "Retained")
+		require.NoError(t, err)
+		_, err = client.WaitForOrchestrationCompletion(ctx, id)
+		require.NoError(t, err)
+		ids = append(ids, id)
+	}
+
+	// Only the instance that completed last is retained
+	assert.EventuallyWithT(t, func(c *assert.CollectT) {
+		res, err := lister.ListWorkflows(ctx, &wfengine.ListWorkflowsRequest{})
+		if assert.NoError(c, err) && assert.Len(c, res.Instances, 1) {
+			assert.Equal(c, string(ids[2]), res.Instances[0].InstanceID)
+		}
+	}, 10*time.Second, 100*time.Millisecond)
+
+	for key := range store.GetItems() {
+		assert.NotContains(t, key, "||"+string(ids[0])+"||")
+		assert.NotContains(t, key, "||"+string(ids[1])+"||")
+	}
+	_, err := client.FetchOrchestrationMetadata(ctx, ids[2])
+	require.NoError(t, err)
 }
 
 // TestRecreateRunningWorkflowFails verifies that a workflow can't be recreated if it's in a running state.
@@ -652,6 +809,161 @@ func TestRaiseEvent(t *testing.T) {
 	}
 }
 
+// TestRaiseEventBeforeStart verifies that the events raised on a workflow before it's created are buffered until it starts, and dropped when they expire.
+func TestRaiseEventBeforeStart(t *testing.T) {
+	r := task.NewTaskRegistry()
+	r.AddOrchestratorN("WorkflowForBufferedEvent", func(ctx *task.OrchestrationContext) (any, error) {
+		var nameInput string
+		if err := ctx.WaitForSingleEvent("NameOfEventBeingRaised", time.Second).Await(&nameInput); err != nil {
+			return "timed out", nil
+		}
+		return fmt.Sprintf("Hello, %s!", nameInput), nil
+	})
+
+	ctx := context.Background()
+	engine, store := getEngineAndStateStoreWithSpec(t, config.WorkflowSpec{
+		MaxConcurrentWorkflowInvocations: 100,
+		MaxConcurrentActivityInvocations: 100,
+		EventBufferTTL:                   "2s",
+	})
+	var client backend.TaskHubClient
+	engine.SetExecutor(func(be backend.Backend) backend.Executor {
+		client = backend.NewTaskHubClient(be)
+		return task.NewTaskExecutor(r)
+	})
+	require.NoError(t, engine.Start(ctx))
+
+	hasBufferedEvents := func(id api.InstanceID) bool {
+		for key := range store.GetItems() {
+			if strings.HasSuffix(key, "||"+string(id)+"||bufferedEvents") {
+				return true
+			}
+		}
+		return false
+	}
+
+	t.Run("buffered events are delivered when the workflow starts", func(t *testing.T) {
+		id := api.InstanceID("buffered")
+		require.NoError(t, client.RaiseEvent(ctx, id, "NameOfEventBeingRaised", api.WithEventPayload("NameOfInput")))
+		assert.True(t, hasBufferedEvents(id))
+
+		_, err := client.ScheduleNewOrchestration(ctx, "WorkflowForBufferedEvent", api.WithInstanceID(id))
+		require.NoError(t, err)
+		metadata, err := client.WaitForOrchestrationCompletion(ctx, id)
+		require.NoError(t, err)
+		assert.Equal(t, `"Hello, NameOfInput!"`, metadata.SerializedOutput)
+		assert.False(t, hasBufferedEvents(id))
+	})
+
+	t.Run("buffered events expire", func(t *testing.T) {
+		id := api.InstanceID("expired")
+		require.NoError(t, client.RaiseEvent(ctx, id, "NameOfEventBeingRaised", api.WithEventPayload("NameOfInput")))
+		assert.Eventually(t, func() bool {
+			return !hasBufferedEvents(id)
+		}, 10*time.Second, 100*time.Millisecond)
+
+		_, err := client.ScheduleNewOrchestration(ctx, "WorkflowForBufferedEvent", api.WithInstanceID(id))
+		require.NoError(t, err)
+		metadata, err := client.WaitForOrchestrationCompletion(ctx, id)
+		require.NoError(t, err)
+		assert.Equal(t, `"timed out"`, metadata.SerializedOutput)
+	})
+
+	t.Run("buffered events are capped", func(t *testing.T) {
+		id := api.InstanceID("capped")
+		for i := 0; i < 100; i++ {
+			require.NoError(t, client.RaiseEvent(ctx, id, "NameOfEventBeingRaised"))
+		}
+		err := client.RaiseEvent(ctx, id, "NameOfEventBeingRaised")
+		require.ErrorContains(t, err, "at most 100 events are buffered")
+	})
+
+	t.Run("events are not buffered when disabled", func(t *testing.T) {
+		client, _ := startEngine(ctx, t, r)
+		err := client.RaiseEvent(ctx, api.InstanceID("not-buffered"), "NameOfEventBeingRaised")
+		require.Error(t, err)
+	})
+}
+
+// TestChildWorkflow verifies that a workflow can start and await child workflows hosted by the same app and by another app.
+func TestChildWorkflow(t *testing.T) {
+	r := task.NewTaskRegistry()
+	r.AddOrchestratorN("Parent", func(ctx *task.OrchestrationContext) (any, error) {
+		var name string
+		if err := ctx.GetInput(&name); err != nil {
+			return nil, err
+		}
+		var output string
+		if err := ctx.CallSubOrchestrator(name, task.WithSubOrchestratorInput("parent")).Await(&output); err != nil {
+			return nil, err
+		}
+		return output, nil
+	})
+	r.AddOrchestratorN("LocalChild", func(ctx *task.OrchestrationContext) (any, error) {
+		var input string
+		if err := ctx.GetInput(&input); err != nil {
+			return nil, err
+		}
+		return "local child of " + input, nil
+	})
+
+	// The remote child workflow is hosted by another app, whose workflow actors are registered in the same actor runtime
+	remoteRegistry := task.NewTaskRegistry()
+	remoteRegistry.AddOrchestratorN("RemoteChild", func(ctx *task.OrchestrationContext) (any, error) {
+		var input string
+		if err := ctx.GetInput(&input); err != nil {
+			return nil, err
+		}
+		return "remote child of " + input, nil
+	})
+
+	ctx := context.Background()
+	actorRuntime, _ := getActorRuntimeAndStateStore(t)
+	engine := wfengine.NewWorkflowEngine(testAppID, config.WorkflowSpec{
+		MaxConcurrentWorkflowInvocations: 100,
+		MaxConcurrentActivityInvocations: 100,
+		RemoteWorkflows: []config.RemoteWorkflowSpec{
+			{Name: "RemoteChild", AppID: "wf-app-remote"},
+		},
+	})
+	engine.SetActorRuntime(actorRuntime)
+	var client backend.TaskHubClient
+	engine.SetExecutor(func(be backend.Backend) backend.Executor {
+		client = backend.NewTaskHubClient(be)
+		return task.NewTaskExecutor(r)
+	})
+	require.NoError(t, engine.Start(ctx))
+
+	remoteEngine := wfengine.NewWorkflowEngine("wf-app-remote", config.WorkflowSpec{
+		MaxConcurrentWorkflowInvocations: 100,
+		MaxConcurrentActivityInvocations: 100,
+	})
+	remoteEngine.SetActorRuntime(actorRuntime)
+	remoteEngine.SetExecutor(func(be backend.Backend) backend.Executor {
+		return task.NewTaskExecutor(remoteRegistry)
+	})
+	require.NoError(t, remoteEngine.Start(ctx))
+	t.Cleanup(func() {
+		require.NoError(t, remoteEngine.Close(ctx))
+	})
+
+	expected := map[string]string{
+		"LocalChild":  `"local child of parent"`,
+		"RemoteChild": `"remote child of parent"`,
+	}
+	for child, output := range expected {
+		t.Run(child, func(t *testing.T) {
+			id, err := client.ScheduleNewOrchestration(ctx, "Parent", api.WithInput(child))
+			require.NoError(t, err)
+			metadata, err := client.WaitForOrchestrationCompletion(ctx, id)
+			require.NoError(t, err)
+			assert.True(t, metadata.IsComplete())
+			assert.Nil(t, metadata.FailureDetails)
+			assert.Equal(t, output, metadata.SerializedOutput)
+		})
+	}
+}
+
 // TestContinueAsNew_WithEvents verifies that a workflow can continue as new and process any received events
 // in subsequent iterations.
 func TestContinueAsNew_WithEvents(t *testing.T) {
@@ -835,10 +1147,22 @@ func TestPauseResumeWorkflow(t *testing.T) {
 			metadata, err := client.WaitForOrchestrationStart(ctx, id)
 			require.NoError(t, err)
 			assert.Equal(t, id, metadata.InstanceID)
-			client.SuspendOrchestration(ctx, id, "PauseWFReasonTest")
-			client.RaiseEvent(ctx, id, "WaitForThisEvent")
-			assert.True(t, metadata.IsRunning())
-			client.ResumeOrchestration(ctx, id, "ResumeWFReasonTest")
+			require.NoError(t, client.SuspendOrchestration(ctx, id, "PauseWFReasonTest"))
+			assert.EventuallyWithT(t, func(c *assert.CollectT) {
+				metadata, err = client.FetchOrchestrationMetadata(ctx, id)
+				if assert.NoError(c, err) {
+					assert.Equal(c, api.RUNTIME_STATUS_SUSPENDED, metadata.RuntimeStatus)
+				}
+			}, 5*time.Second, 100*time.Millisecond)
+
+			// The event is held until the workflow is resumed
+			require.NoError(t, client.RaiseEvent(ctx, id, "WaitForThisEvent"))
+			time.Sleep(500 * time.Millisecond)
+			metadata, err = client.FetchOrchestrationMetadata(ctx, id)
+			require.NoError(t, err)
+			assert.Equal(t, api.RUNTIME_STATUS_SUSPENDED, metadata.RuntimeStatus)
+
+			require.NoError(t, client.ResumeOrchestration(ctx, id, "ResumeWFReasonTest"))
 			metadata, _ = client.WaitForOrchestrationCompletion(ctx, id)
 			assert.True(t, metadata.IsComplete())
 			assert.Nil(t, metadata.FailureDetails)
@@ -846,6 +1170,73 @@ func TestPauseResumeWorkflow(t *testing.T) {
 	}
 }
 
+// TestReplayHistory exports the history of a completed workflow and replays it, first against the same
+// workflow code, then against code that calls a different activity, which must be reported as a divergence.
+func TestReplayHistory(t *testing.T) {
+	var changed atomic.Bool
+	r := task.NewTaskRegistry()
+	r.AddOrchestratorN("ReplayWorkflow", func(ctx *task.OrchestrationContext) (any, error) {
+		if err := ctx.CallActivity("A").Await(nil); err != nil {
+			return nil, err
+		}
+		next := "B"
+		if changed.Load() {
+			next = "C"
+		}
+		return nil, ctx.CallActivity(next).Await(nil)
+	})
+	for _, name := range []string{"A", "B", "C"} {
+		r.AddActivityN(name, func(ctx task.ActivityContext) (any, error) {
+			return nil, nil
+		})
+	}
+
+	ctx := context.Background()
+	client, engine := startEngine(ctx, t, r)
+
+	id, err := client.ScheduleNewOrchestration(ctx, "ReplayWorkflow")
+	require.NoError(t, err)
+	metadata, err := client.WaitForOrchestrationCompletion(ctx, id)
+	require.NoError(t, err)
+	require.True(t, metadata.IsComplete())
+
+	history, err := engine.ExportHistory(ctx, string(id))
+	require.NoError(t, err)
+	assert.Equal(t, string(id), history.InstanceID)
+	assert.NotEmpty(t, history.Events)
+
+	// The exported history must survive a round trip through JSON
+	b, err := json.Marshal(history)
+	require.NoError(t, err)
+	var imported wfengine.WorkflowHistory
+	require.NoError(t, json.Unmarshal(b, &imported))
+
+	t.Run("same code", func(t *testing.T) {
+		report, err := engine.ReplayHistory(ctx, &imported)
+		require.NoError(t, err)
+		assert.Equal(t, 3, report.Episodes)
+		assert.Empty(t, report.Divergences)
+	})
+
+	t.Run("non-deterministic code", func(t *testing.T) {
+		changed.Store(true)
+		defer changed.Store(false)
+
+		report, err := engine.ReplayHistory(ctx, &imported)
+		require.NoError(t, err)
+		require.NotEmpty(t, report.Divergences)
+		assert.Equal(t, 1, report.Divergences[0].Episode)
+		assert.Equal(t, 0, report.Divergences[0].Action)
+		assert.Contains(t, report.Divergences[0].Expected, "ScheduleTask(B)")
+		assert.Contains(t, report.Divergences[0].Actual, "ScheduleTask(C)")
+	})
+
+	t.Run("unknown instance", func(t *testing.T) {
+		_, err := engine.ExportHistory(ctx, "does-not-exist")
+		require.Error(t, err)
+	})
+}
+
 func startEngine(ctx context.Context, t *testing.T, r *task.TaskRegistry) (backend.TaskHubClient, *wfengine.WorkflowEngine) {
 	client, engine, _ := startEngineAndGetStore(ctx, t, r)
 	return client, engine
@@ -889,8 +1280,17 @@ func getEngine(t *testing.T) *wfengine.WorkflowEngine {
 }
 
 func getEngineAndStateStore(t *testing.T) (*wfengine.WorkflowEngine, *daprt.FakeStateStore) {
-	spec := config.WorkflowSpec{MaxConcurrentWorkflowInvocations: 100, MaxConcurrentActivityInvocations: 100}
+	return getEngineAndStateStoreWithSpec(t, config.WorkflowSpec{MaxConcurrentWorkflowInvocations: 100, MaxConcurrentActivityInvocations: 100})
+}
+
+func getEngineAndStateStoreWithSpec(t *testing.T, spec config.WorkflowSpec) (*wfengine.WorkflowEngine, *daprt.FakeStateStore) {
 	engine := wfengine.NewWorkflowEngine(testAppID, spec)
+	actors, store := getActorRuntimeAndStateStore(t)
+	engine.SetActorRuntime(actors)
+	return engine, store
+}
+
+func getActorRuntimeAndStateStore(t *testing.T) (actors.ActorRuntime, *daprt.FakeStateStore) {
 	store := fakeStore().(*daprt.FakeStateStore)
 	cfg := actors.NewConfig(actors.ConfigOpts{
 		AppID:              testAppID,
@@ -909,6 +1309,5 @@ func getEngineAndStateStore(t *testing.T) (*wfengine.WorkflowEngine, *daprt.Fake
 	})
 
 	require.NoError(t, actors.Init(context.Background()))
-	engine.SetActorRuntime(actors)
-	return engine, store
+	return actors, store
 }
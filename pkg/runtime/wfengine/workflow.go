@@ -28,8 +28,10 @@ import (
 	"github.com/google/uuid"
 	"github.com/microsoft/durabletask-go/api"
 	"github.com/microsoft/durabletask-go/backend"
+	"golang.org/x/exp/slices"
 
 	"github.com/dapr/dapr/pkg/actors"
+	diag "github.com/dapr/dapr/pkg/diagnostics"
 	invokev1 "github.com/dapr/dapr/pkg/messaging/v1"
 )
 
@@ -38,6 +40,7 @@ const (
 
 	CreateWorkflowInstanceMethod = "CreateWorkflowInstance"
 	GetWorkflowMetadataMethod    = "GetWorkflowMetadata"
+	GetWorkflowHistoryMethod     = "GetWorkflowHistory"
 	AddWorkflowEventMethod       = "AddWorkflowEvent"
 	PurgeWorkflowStateMethod     = "PurgeWorkflowState"
 )
@@ -51,6 +54,11 @@ type workflowActor struct {
 	reminderInterval      time.Duration
 	config                actorsBackendConfig
 	activityResultAwaited atomic.Bool
+	workItems             *workItemTracker
+	// eventBufferTTL is the time the events raised on workflow instances that don't exist yet are buffered for, or 0 if they're not buffered.
+	eventBufferTTL time.Duration
+	// remoteWorkflows are the IDs of the apps that host the workflows this app can start as child workflows, keyed by workflow name.
+	remoteWorkflows map[string]string
 }
 
 type durableTimer struct {
@@ -65,6 +73,8 @@ type recoverableError struct {
 type CreateWorkflowInstanceRequest struct {
 	Policy          *api.OrchestrationIdReusePolicy `json:"policy"`
 	StartEventBytes []byte                          `json:"startEventBytes"`
+	// ParentAppID is the ID of the app that hosts the parent workflow, when it's in another app than the child workflow.
+	ParentAppID string `json:"parentAppId,omitempty"`
 }
 
 // workflowScheduler is a func interface for pushing workflow (orchestration) work items into the backend
@@ -105,10 +115,12 @@ func (wf *workflowActor) InvokeMethod(ctx context.Context, actorID string, metho
 		err = wf.createWorkflowInstance(ctx, actorID, request)
 	case GetWorkflowMetadataMethod:
 		result, err = wf.getWorkflowMetadata(ctx, actorID)
+	case GetWorkflowHistoryMethod:
+		result, err = wf.getWorkflowHistory(ctx, actorID)
 	case AddWorkflowEventMethod:
 		err = wf.addWorkflowEvent(ctx, actorID, request)
 	case PurgeWorkflowStateMethod:
-		err = wf.purgeWorkflowState(ctx, actorID)
+		result, err = wf.purgeWorkflowState(ctx, actorID)
 	default:
 		err = fmt.Errorf("no such method: %s", methodName)
 	}
@@ -118,11 +130,50 @@ func (wf *workflowActor) InvokeMethod(ctx context.Context, actorID string, metho
 // InvokeReminder implements actors.InternalActor
 func (wf *workflowActor) InvokeReminder(ctx context.Context, actorID string, reminderName string, data []byte, dueTime string, period string) error {
 	wfLogger.Debugf("Workflow actor '%s': invoking reminder '%s'", actorID, reminderName)
+	firedAt := time.Now()
+
+	if reminderName == bufferExpiryReminderName {
+		remaining, err := wf.expireBufferedEvents(ctx, actorID)
+		if err != nil {
+			wfLogger.Warnf("Workflow actor '%s': failed to drop the expired buffered events, will retry later: %v", actorID, err)
+
+			// Returning nil signals that we want the execution to be retried in the next period interval
+			return nil
+		}
+		if remaining > 0 {
+			// The reminder fires again in the next period interval, to drop the events that expire later
+			return nil
+		}
+		return actors.ErrReminderCanceled
+	}
+
+	if reminderName == indexUpdateReminderName {
+		if err := wf.retryIndexUpdate(ctx, actorID); err != nil {
+			wfLogger.Warnf("Workflow actor '%s': failed to update the workflow index, will retry later: %v", actorID, err)
+
+			// Returning nil signals that we want the execution to be retried in the next period interval
+			return nil
+		}
+		return actors.ErrReminderCanceled
+	}
+
+	execCtx, finish, ok := wf.workItems.start(ctx, diag.WorkflowReminder)
+	if !ok {
+		wfLogger.Debugf("Workflow actor '%s': workflow engine is shutting down, execution will be retried later", actorID)
+
+		// Returning nil keeps the reminder, so the execution is retried in the next period interval
+		return nil
+	}
 
 	// Workflow executions should never take longer than a few seconds at the most
-	timeoutCtx, cancelTimeout := context.WithTimeout(ctx, wf.defaultTimeout)
+	timeoutCtx, cancelTimeout := context.WithTimeout(execCtx, wf.defaultTimeout)
 	defer cancelTimeout()
-	err := wf.runWorkflow(timeoutCtx, actorID, reminderName, data)
+	start := time.Now()
+	workflowName, err := wf.runWorkflow(timeoutCtx, actorID, reminderName, data, firedAt)
+	elapsed := diag.ElapsedSince(start)
+	finish(err)
+	recordReminderEvent(ctx, diag.WorkflowReminder, diag.FireReminder, err)
+	recordExecutionEvent(ctx, diag.WorkflowReminder, workflowName, err, elapsed)
 	if err != nil {
 		var re recoverableError
 		if errors.Is(err, context.DeadlineExceeded) {
@@ -180,6 +231,7 @@ func (wf *workflowActor) createWorkflowInstance(ctx context.Context, actorID str
 	}
 	reuseIDPolicy := createWorkflowInstanceRequest.Policy
 	startEventBytes := createWorkflowInstanceRequest.StartEventBytes
+	parentAppID := createWorkflowInstanceRequest.ParentAppID
 
 	// Ensure that the start event payload is a valid durabletask execution-started event
 	startEvent, err := backend.UnmarshalHistoryEvent(startEventBytes)
@@ -192,13 +244,13 @@ func (wf *workflowActor) createWorkflowInstance(ctx context.Context, actorID str
 		if es.GetParentInstance() == nil {
 			wfLogger.Debugf("Workflow actor '%s': creating workflow '%s' with instanceId '%s'", actorID, es.GetName(), es.GetOrchestrationInstance().GetInstanceId())
 		} else {
-			wfLogger.Debugf("Workflow actor '%s': creating child workflow '%s' with instanceId '%s' parentWorkflow '%s' parentWorkflowId '%s'", es.GetName(), es.GetOrchestrationInstance().GetInstanceId(), es.GetParentInstance().GetName(), es.GetParentInstance().GetOrchestrationInstance().GetInstanceId())
+			wfLogger.Debugf("Workflow actor '%s': creating child workflow '%s' with instanceId '%s' parentWorkflow '%s' parentWorkflowId '%s'", actorID, es.GetName(), es.GetOrchestrationInstance().GetInstanceId(), es.GetParentInstance().GetName(), es.GetParentInstance().GetOrchestrationInstance().GetInstanceId())
 		}
 	}
 
 	// orchestration didn't exist and was just created
 	if created {
-		return wf.scheduleWorkflowStart(ctx, actorID, startEvent, state)
+		return wf.scheduleWorkflowStart(ctx, actorID, startEvent, parentAppID, state)
 	}
 
 	// orchestration already existed: apply reuse id policy
@@ -206,7 +258,7 @@ func (wf *workflowActor) createWorkflowInstance(ctx context.Context, actorID str
 	runtimeStatus := runtimeState.RuntimeStatus()
 	// if target status doesn't match, fall back to original logic, create instance only if previous one is completed
 	if !isStatusMatch(reuseIDPolicy.GetOperationStatus(), runtimeStatus) {
-		return wf.createIfCompleted(ctx, runtimeState, actorID, state, startEvent)
+		return wf.createIfCompleted(ctx, runtimeState, actorID, state, startEvent, parentAppID)
 	}
 
 	switch reuseIDPolicy.GetAction() {
@@ -216,16 +268,16 @@ func (wf *workflowActor) createWorkflowInstance(ctx context.Context, actorID str
 		return nil
 	case api.REUSE_ID_ACTION_TERMINATE:
 		// terminate existing instance
-		if err := wf.cleanupWorkflowStateInternal(ctx, actorID, state, false); err != nil {
+		if _, err := wf.cleanupWorkflowStateInternal(ctx, actorID, state, false); err != nil {
 			return fmt.Errorf("failed to terminate existing instance with ID '%s'", actorID)
 		}
 
 		// created a new instance
 		state.Reset()
-		return wf.scheduleWorkflowStart(ctx, actorID, startEvent, state)
+		return wf.scheduleWorkflowStart(ctx, actorID, startEvent, parentAppID, state)
 	}
 	// default Action ERROR, fall back to original logic
-	return wf.createIfCompleted(ctx, runtimeState, actorID, state, startEvent)
+	return wf.createIfCompleted(ctx, runtimeState, actorID, state, startEvent, parentAppID)
 }
 
 func isStatusMatch(statuses []api.OrchestrationStatus, runtimeStatus api.OrchestrationStatus) bool {
@@ -237,7 +289,7 @@ func isStatusMatch(statuses []api.OrchestrationStatus, runtimeStatus api.Orchest
 	return false
 }
 
-func (wf *workflowActor) createIfCompleted(ctx context.Context, runtimeState *backend.OrchestrationRuntimeState, actorID string, state *workflowState, startEvent *backend.HistoryEvent) error {
+func (wf *workflowActor) createIfCompleted(ctx context.Context, runtimeState *backend.OrchestrationRuntimeState, actorID string, state *workflowState, startEvent *backend.HistoryEvent, parentAppID string) error {
 	// We block (re)creation of existing workflows unless they are in a completed state
 	// Or if they still have any pending activity result awaited.
 	if !runtimeState.IsCompleted() {
@@ -248,10 +300,10 @@ func (wf *workflowActor) createIfCompleted(ctx context.Context, runtimeState *ba
 	}
 	wfLogger.Infof("Workflow actor '%s': workflow was previously completed and is being recreated", actorID)
 	state.Reset()
-	return wf.scheduleWorkflowStart(ctx, actorID, startEvent, state)
+	return wf.scheduleWorkflowStart(ctx, actorID, startEvent, parentAppID, state)
 }
 
-func (wf *workflowActor) scheduleWorkflowStart(ctx context.Context, actorID string, startEvent *backend.HistoryEvent, state *workflowState) error {
+func (wf *workflowActor) scheduleWorkflowStart(ctx context.Context, actorID string, startEvent *backend.HistoryEvent, parentAppID string, state *workflowState) error {
 	// Schedule a reminder to execute immediately after this operation. The reminder will trigger the actual
 	// workflow execution. This is preferable to using the current thread so that we don't block the client
 	// while the workflow logic is running.
@@ -259,35 +311,56 @@ func (wf *workflowActor) scheduleWorkflowStart(ctx context.Context, actorID stri
 		return err
 	}
 
+	// The baggage of the request that creates the workflow is saved with it, since the workflow runs asynchronously
+	state.Baggage = diag.BaggageString(ctx)
+	state.ParentAppID = parentAppID
 	state.AddToInbox(startEvent)
-	return wf.saveInternalState(ctx, actorID, state)
+
+	// The events raised before the workflow was created are delivered right after it starts
+	var delivered, expired int
+	if wf.eventBufferTTL > 0 {
+		var err error
+		delivered, expired, err = wf.addBufferedEventsToInbox(ctx, actorID, state)
+		if err != nil {
+			return err
+		}
+	}
+
+	if err := wf.saveInternalState(ctx, actorID, state); err != nil {
+		return err
+	}
+	diag.DefaultWorkflowMonitoring.WorkflowBufferedEvents(ctx, diag.EventDelivered, int64(delivered))
+	diag.DefaultWorkflowMonitoring.WorkflowBufferedEvents(ctx, diag.EventExpired, int64(expired))
+	return nil
 }
 
-// This method cleans up a workflow associated with the given actorID
-func (wf *workflowActor) cleanupWorkflowStateInternal(ctx context.Context, actorID string, state *workflowState, requiredAndNotCompleted bool) error {
+// This method cleans up a workflow associated with the given actorID, and returns the number of state keys that were deleted
+func (wf *workflowActor) cleanupWorkflowStateInternal(ctx context.Context, actorID string, state *workflowState, requiredAndNotCompleted bool) (int, error) {
 	// If the workflow is required to complete but it's not yet completed then return [ErrNotCompleted]
 	// This check is used by purging workflow
 	if requiredAndNotCompleted {
-		return api.ErrNotCompleted
+		return 0, api.ErrNotCompleted
 	}
 
-	err := wf.removeCompletedStateData(ctx, state, actorID)
+	keys, err := wf.removeCompletedStateData(ctx, state, actorID)
 	if err != nil {
-		return err
+		return keys, err
 	}
 
 	// This will create a request to purge everything
 	req, err := state.GetPurgeRequest(actorID)
 	if err != nil {
-		return err
+		return keys, err
 	}
 	// This will do the purging
 	err = wf.actors.TransactionalStateOperation(ctx, req)
 	if err != nil {
-		return err
+		return keys, err
 	}
+	keys += len(req.Operations)
 	wf.states.Delete(actorID)
-	return nil
+	wf.removeFromIndex(ctx, actorID)
+	return keys, nil
 }
 
 func (wf *workflowActor) getWorkflowMetadata(ctx context.Context, actorID string) (*api.OrchestrationMetadata, error) {
@@ -311,7 +384,7 @@ func (wf *workflowActor) getWorkflowMetadata(ctx context.Context, actorID string
 	metadata := api.NewOrchestrationMetadata(
 		runtimeState.InstanceID(),
 		name,
-		runtimeState.RuntimeStatus(),
+		getRuntimeStatus(runtimeState, state),
 		createdAt,
 		lastUpdated,
 		input,
@@ -322,14 +395,27 @@ func (wf *workflowActor) getWorkflowMetadata(ctx context.Context, actorID string
 	return metadata, nil
 }
 
-// This method purges all the completed activity data from a workflow associated with the given actorID
-func (wf *workflowActor) purgeWorkflowState(ctx context.Context, actorID string) error {
+func (wf *workflowActor) getWorkflowHistory(ctx context.Context, actorID string) (*WorkflowHistory, error) {
 	state, err := wf.loadInternalState(ctx, actorID)
 	if err != nil {
-		return err
+		return nil, err
 	}
 	if state == nil {
-		return api.ErrInstanceNotFound
+		return nil, api.ErrInstanceNotFound
+	}
+
+	return NewWorkflowHistory(actorID, state.History)
+}
+
+// This method purges all the completed activity data from a workflow associated with the given actorID.
+// It returns the number of state keys that were deleted.
+func (wf *workflowActor) purgeWorkflowState(ctx context.Context, actorID string) (int, error) {
+	state, err := wf.loadInternalState(ctx, actorID)
+	if err != nil {
+		return 0, err
+	}
+	if state == nil {
+		return 0, api.ErrInstanceNotFound
 	}
 	runtimeState := getRuntimeState(actorID, state)
 	return wf.cleanupWorkflowStateInternal(ctx, actorID, state, !runtimeState.IsCompleted())
@@ -340,17 +426,21 @@ func (wf *workflowActor) addWorkflowEvent(ctx context.Context, actorID string, h
 	if err != nil {
 		return err
 	}
+
+	e, err := backend.UnmarshalHistoryEvent(historyEventBytes)
+	if err != nil {
+		return err
+	}
 	if state == nil {
+		// Events raised before the workflow is created are buffered, if enabled
+		if wf.eventBufferTTL > 0 && e.GetEventRaised() != nil {
+			return wf.bufferWorkflowEvent(ctx, actorID, historyEventBytes)
+		}
 		return api.ErrInstanceNotFound
 	}
-
-	e, err := backend.UnmarshalHistoryEvent(historyEventBytes)
 	if e.GetTaskCompleted() != nil || e.GetTaskFailed() != nil {
 		wf.activityResultAwaited.CompareAndSwap(true, false)
 	}
-	if err != nil {
-		return err
-	}
 	wfLogger.Debugf("Workflow actor '%s': adding event '%v' to the workflow inbox", actorID, e)
 	state.AddToInbox(e)
 
@@ -360,40 +450,74 @@ func (wf *workflowActor) addWorkflowEvent(ctx context.Context, actorID string, h
 	return wf.saveInternalState(ctx, actorID, state)
 }
 
-func (wf *workflowActor) runWorkflow(ctx context.Context, actorID string, reminderName string, reminderData []byte) error {
+// runWorkflow executes the workflow in response to a reminder that fired at firedAt, and returns the name of the workflow if it is known.
+func (wf *workflowActor) runWorkflow(ctx context.Context, actorID string, reminderName string, reminderData []byte, firedAt time.Time) (workflowName string, err error) {
 	state, err := wf.loadInternalState(ctx, actorID)
 	if err != nil {
-		return fmt.Errorf("error loading internal state: %w", err)
+		return workflowName, fmt.Errorf("error loading internal state: %w", err)
 	}
 	if state == nil {
 		// The assumption is that someone manually deleted the workflow state. This is non-recoverable.
-		return errors.New("no workflow state found")
+		return workflowName, errors.New("no workflow state found")
 	}
+	startEvent := workflowStartEvent(state)
+	workflowName = startEvent.GetExecutionStarted().GetName()
 
+	var timerEvent *backend.HistoryEvent
 	if strings.HasPrefix(reminderName, "timer-") {
 		var timerData durableTimer
 		if err = actors.DecodeInternalActorReminderData(reminderData, &timerData); err != nil {
 			// Likely the result of an incompatible durable task timer format change. This is non-recoverable.
-			return err
+			return workflowName, err
 		}
 		if timerData.Generation < state.Generation {
 			wfLogger.Infof("Workflow actor '%s': ignoring durable timer from previous generation '%v'", actorID, timerData.Generation)
-			return nil
+			return workflowName, nil
 		} else {
-			e, eventErr := backend.UnmarshalHistoryEvent(timerData.Bytes)
-			if eventErr != nil {
+			timerEvent, err = backend.UnmarshalHistoryEvent(timerData.Bytes)
+			if err != nil {
 				// Likely the result of an incompatible durable task timer format change. This is non-recoverable.
-				return fmt.Errorf("failed to unmarshal timer data %w", eventErr)
+				return workflowName, fmt.Errorf("failed to unmarshal timer data %w", err)
 			}
-			state.Inbox = append(state.Inbox, e)
 		}
 	}
 
+	// Suspended workflows don't process timers and events until they're resumed or terminated
+	// They're kept in the inbox, and delivered with the event that resumes the workflow
+	wasSuspended := isSuspended(state.History)
+	if wasSuspended && !slices.ContainsFunc(state.Inbox, endsSuspension) {
+		if timerEvent != nil {
+			wfLogger.Debugf("Workflow actor '%s': workflow is suspended, holding the durable timer until it's resumed", actorID)
+			state.AddToInbox(timerEvent)
+			if err = wf.saveInternalState(ctx, actorID, state); err != nil {
+				return workflowName, err
+			}
+		}
+		wfLogger.Debugf("Workflow actor '%s': ignoring run request for reminder '%s' because the workflow is suspended", actorID, reminderName)
+		return workflowName, nil
+	}
+	if timerEvent != nil {
+		state.Inbox = append(state.Inbox, timerEvent)
+	}
+	if wasSuspended {
+		// The events held while the workflow was suspended are processed after it's resumed
+		slices.SortStableFunc(state.Inbox, func(a, b *backend.HistoryEvent) int {
+			switch {
+			case endsSuspension(a) && !endsSuspension(b):
+				return -1
+			case !endsSuspension(a) && endsSuspension(b):
+				return 1
+			default:
+				return 0
+			}
+		})
+	}
+
 	if len(state.Inbox) == 0 {
 		// This can happen after multiple events are processed in batches; there may still be reminders around
 		// for some of those already processed events.
 		wfLogger.Debugf("Workflow actor '%s': ignoring run request for reminder '%s' because the workflow inbox is empty", actorID, reminderName)
-		return nil
+		return workflowName, nil
 	}
 
 	// The logic/for loop below purges/removes any leftover state from a completed or failed activity
@@ -428,10 +552,17 @@ func (wf *workflowActor) runWorkflow(ctx context.Context, actorID string, remind
 			Operations: operations,
 		})
 		if err != nil {
-			return fmt.Errorf("failed to delete activity state for activity actor '%s' with error: %w", activityActorID, err)
+			return workflowName, fmt.Errorf("failed to delete activity state for activity actor '%s' with error: %w", activityActorID, err)
 		}
 	}
 
+	// Restore the trace context of the request that started the workflow, so the span of the workflow instance is parented to it
+	if tc := startEvent.GetExecutionStarted().GetParentTraceContext(); tc != nil {
+		ctx = contextWithParentTraceContext(ctx, tc.GetTraceParent(), tc.GetTraceState().GetValue())
+	}
+	ctx = diag.ContextWithBaggageString(ctx, state.Baggage)
+	instanceSpan := workflowSpanContext(actorID, startEvent)
+
 	runtimeState := getRuntimeState(actorID, state)
 	wi := &backend.OrchestrationWorkItem{
 		InstanceID: runtimeState.InstanceID(),
@@ -450,17 +581,19 @@ func (wf *workflowActor) runWorkflow(ctx context.Context, actorID string, remind
 	err = wf.scheduler(ctx, wi)
 	if err != nil {
 		if errors.Is(err, context.DeadlineExceeded) {
-			return newRecoverableError(fmt.Errorf("timed-out trying to schedule a workflow execution - this can happen if there are too many in-flight workflows or if the workflow engine isn't running: %w", err))
+			return workflowName, newRecoverableError(fmt.Errorf("timed-out trying to schedule a workflow execution - this can happen if there are too many in-flight workflows or if the workflow engine isn't running: %w", err))
 		}
-		return newRecoverableError(fmt.Errorf("failed to schedule a workflow execution: %w", err))
+		return workflowName, newRecoverableError(fmt.Errorf("failed to schedule a workflow execution: %w", err))
 	}
+	// The scheduler returns when the engine picks up the work item, so this is the time between the reminder firing and the execution starting
+	diag.DefaultWorkflowMonitoring.WorkflowSchedulingEvent(ctx, diag.WorkflowReminder, diag.ElapsedSince(firedAt))
 
 	select {
 	case <-ctx.Done(): // caller is responsible for timeout management
-		return ctx.Err()
+		return workflowName, ctx.Err()
 	case completed := <-callback:
 		if !completed {
-			return newRecoverableError(errExecutionAborted)
+			return workflowName, newRecoverableError(errExecutionAborted)
 		}
 	}
 	wfLogger.Debugf("Workflow actor '%s': workflow execution returned with status '%s' instanceId '%s'", actorID, runtimeState.RuntimeStatus().String(), wi.InstanceID)
@@ -477,11 +610,11 @@ func (wf *workflowActor) runWorkflow(ctx context.Context, actorID string, remind
 		for _, t := range runtimeState.PendingTimers() {
 			tf := t.GetTimerFired()
 			if tf == nil {
-				return errors.New("invalid event in the PendingTimers list")
+				return workflowName, errors.New("invalid event in the PendingTimers list")
 			}
 			timerBytes, err := backend.MarshalHistoryEvent(t)
 			if err != nil {
-				return fmt.Errorf("failed to marshal pending timer data: %w", err)
+				return workflowName, fmt.Errorf("failed to marshal pending timer data: %w", err)
 			}
 			delay := time.Until(tf.GetFireAt().AsTime())
 			if delay < 0 {
@@ -491,7 +624,7 @@ func (wf *workflowActor) runWorkflow(ctx context.Context, actorID string, remind
 			data := NewDurableTimer(timerBytes, state.Generation)
 			wfLogger.Debugf("Workflow actor '%s': creating reminder '%s' for the durable timer", actorID, reminderPrefix)
 			if _, err := wf.createReliableReminder(ctx, actorID, reminderPrefix, data, delay); err != nil {
-				return newRecoverableError(fmt.Errorf("actor '%s' failed to create reminder for timer: %w", actorID, err))
+				return workflowName, newRecoverableError(fmt.Errorf("actor '%s' failed to create reminder for timer: %w", actorID, err))
 			}
 		}
 	}
@@ -517,15 +650,16 @@ func (wf *workflowActor) runWorkflow(ctx context.Context, actorID string, remind
 			continue
 		}
 
+		stampParentTraceContext(startEvent, instanceSpan, e)
 		eventData, err := backend.MarshalHistoryEvent(e)
 		if err != nil {
-			return err
+			return workflowName, err
 		}
 		activityRequestBytes, err := actors.EncodeInternalActorData(ActivityRequest{
 			HistoryEvent: eventData,
 		})
 		if err != nil {
-			return err
+			return workflowName, err
 		}
 		targetActorID := getActivityActorID(actorID, e.GetEventId(), state.Generation)
 
@@ -545,7 +679,7 @@ func (wf *workflowActor) runWorkflow(ctx context.Context, actorID string, remind
 				wfLogger.Warnf("Workflow actor '%s': activity invocation '%s::%d' was flagged as a duplicate and will be skipped", actorID, ts.GetName(), e.GetEventId())
 				continue
 			}
-			return newRecoverableError(fmt.Errorf("failed to invoke activity actor '%s' to execute '%s': %w", targetActorID, ts.GetName(), err))
+			return workflowName, newRecoverableError(fmt.Errorf("failed to invoke activity actor '%s' to execute '%s': %w", targetActorID, ts.GetName(), err))
 		}
 		resp.Close()
 	}
@@ -553,32 +687,75 @@ func (wf *workflowActor) runWorkflow(ctx context.Context, actorID string, remind
 	// TODO: Do these in parallel?
 	for method, msgList := range reqsByName {
 		for _, msg := range msgList {
+			stampParentTraceContext(startEvent, instanceSpan, msg.HistoryEvent)
 			eventData, err := backend.MarshalHistoryEvent(msg.HistoryEvent)
 			if err != nil {
-				return err
+				return workflowName, err
+			}
+
+			// Child workflows hosted by other apps are created by the workflow actors of those apps, which report back to this app when they complete
+			targetActorType := wf.config.workflowActorType
+			requestBytes := eventData
+			contentType := invokev1.OctetStreamContentType
+			switch method {
+			case CreateWorkflowInstanceMethod:
+				childAppID, isRemote := wf.remoteWorkflows[msg.HistoryEvent.GetExecutionStarted().GetName()]
+				createReq := CreateWorkflowInstanceRequest{
+					Policy:          &api.OrchestrationIdReusePolicy{},
+					StartEventBytes: eventData,
+				}
+				if isRemote && childAppID != wf.config.AppID {
+					targetActorType = getInternalActorType(childAppID, WorkflowNameLabelKey)
+					createReq.ParentAppID = wf.config.AppID
+				}
+				requestBytes, err = json.Marshal(createReq)
+				if err != nil {
+					return workflowName, fmt.Errorf("failed to marshal createWorkflowInstanceRequest: %w", err)
+				}
+				contentType = invokev1.JSONContentType
+			case AddWorkflowEventMethod:
+				if state.ParentAppID != "" {
+					targetActorType = getInternalActorType(state.ParentAppID, WorkflowNameLabelKey)
+				}
 			}
 
-			wfLogger.Debugf("Workflow actor '%s': invoking method '%s' on workflow actor '%s'", actorID, method, msg.TargetInstanceID)
+			wfLogger.Debugf("Workflow actor '%s': invoking method '%s' on workflow actor '%s' of type '%s'", actorID, method, msg.TargetInstanceID, targetActorType)
 			req := invokev1.
 				NewInvokeMethodRequest(method).
-				WithActor(wf.config.workflowActorType, msg.TargetInstanceID).
-				WithRawDataBytes(eventData).
-				WithContentType(invokev1.OctetStreamContentType)
+				WithActor(targetActorType, msg.TargetInstanceID).
+				WithRawDataBytes(requestBytes).
+				WithContentType(contentType)
 			defer req.Close()
 
 			resp, err := wf.actors.Call(ctx, req)
 			if err != nil {
 				// workflow-related actor methods are never expected to return errors
-				return newRecoverableError(fmt.Errorf("method %s on actor '%s' returned an error: %w", method, msg.TargetInstanceID, err))
+				return workflowName, newRecoverableError(fmt.Errorf("method %s on actor '%s' returned an error: %w", method, msg.TargetInstanceID, err))
 			}
 			defer resp.Close()
 		}
 	}
 
+	history := state.History
 	state.ApplyRuntimeStateChanges(runtimeState)
 	state.ClearInbox()
 
-	return wf.saveInternalState(ctx, actorID, state)
+	err = wf.saveInternalState(ctx, actorID, state)
+	if err != nil {
+		return workflowName, err
+	}
+
+	if suspended := isSuspended(state.History); suspended != wasSuspended {
+		if suspended {
+			wfLogger.Infof("Workflow actor '%s': workflow suspended", actorID)
+		} else {
+			wfLogger.Infof("Workflow actor '%s': workflow no longer suspended", actorID)
+		}
+	}
+
+	// The spans are emitted once the execution is saved, so they're not emitted again if the execution is retried
+	emitWorkflowSpans(ctx, actorID, startEvent, history, runtimeState.NewEvents(), runtimeState.RuntimeStatus())
+	return workflowName, nil
 }
 
 func (wf *workflowActor) loadInternalState(ctx context.Context, actorID string) (*workflowState, error) {
@@ -608,19 +785,27 @@ func (wf *workflowActor) loadInternalState(ctx context.Context, actorID string)
 }
 
 func (wf *workflowActor) saveInternalState(ctx context.Context, actorID string, state *workflowState) error {
+	indexedStatus := state.indexedStatus
+	indexEntry, updateIndex := pendingIndexUpdate(actorID, state)
+
 	// generate and run a state store operation that saves all changes
 	req, err := state.GetSaveRequest(actorID)
 	if err != nil {
+		state.indexedStatus = indexedStatus
 		return err
 	}
 
 	wfLogger.Debugf("Workflow actor '%s': saving %d keys to actor state store", actorID, len(req.Operations))
 	if err = wf.actors.TransactionalStateOperation(ctx, req); err != nil {
+		state.indexedStatus = indexedStatus
 		return err
 	}
 
 	// ResetChangeTracking should always be called after a save operation succeeds
 	state.ResetChangeTracking()
+	if updateIndex {
+		wf.updateIndex(ctx, actorID, indexEntry)
+	}
 
 	if !wf.cachingDisabled {
 		// update cached state
@@ -639,7 +824,7 @@ func (wf *workflowActor) createReliableReminder(ctx context.Context, actorID str
 		return reminderName, fmt.Errorf("failed to encode data as JSON: %w", err)
 	}
 
-	return reminderName, wf.actors.CreateReminder(ctx, &actors.CreateReminderRequest{
+	err = wf.actors.CreateReminder(ctx, &actors.CreateReminderRequest{
 		ActorType: wf.config.workflowActorType,
 		ActorID:   actorID,
 		Data:      dataEnc,
@@ -647,6 +832,33 @@ func (wf *workflowActor) createReliableReminder(ctx context.Context, actorID str
 		Name:      reminderName,
 		Period:    wf.reminderInterval.String(),
 	})
+	recordReminderEvent(ctx, diag.WorkflowReminder, diag.CreateReminder, err)
+	return reminderName, err
+}
+
+// recordReminderEvent records the creation or execution of a reminder used by the workflow engine.
+func recordReminderEvent(ctx context.Context, reminderType, operation string, err error) {
+	status := diag.StatusSuccess
+	if err != nil {
+		status = diag.StatusFailed
+	}
+	diag.DefaultWorkflowMonitoring.WorkflowReminderEvent(ctx, reminderType, operation, status)
+}
+
+// recordExecutionEvent records the outcome and the latency of a workflow or activity execution.
+// Executions that failed with an error that causes them to be retried are recorded as recoverable.
+func recordExecutionEvent(ctx context.Context, executionType, name string, err error, elapsed float64) {
+	var re recoverableError
+	status := diag.StatusSuccess
+	switch {
+	case err == nil:
+		// Nop
+	case errors.Is(err, context.DeadlineExceeded), errors.Is(err, context.Canceled), errors.As(err, &re):
+		status = diag.StatusRecoverable
+	default:
+		status = diag.StatusFailed
+	}
+	diag.DefaultWorkflowMonitoring.WorkflowExecutionEvent(ctx, executionType, name, status, elapsed)
 }
 
 func getRuntimeState(actorID string, state *workflowState) *backend.OrchestrationRuntimeState {
@@ -654,15 +866,43 @@ func getRuntimeState(actorID string, state *workflowState) *backend.Orchestratio
 	return backend.NewOrchestrationRuntimeState(api.InstanceID(actorID), state.History)
 }
 
+// getRuntimeStatus returns the status of the workflow, which is suspended if it's running and the last suspend or resume event in its history suspended it.
+func getRuntimeStatus(runtimeState *backend.OrchestrationRuntimeState, state *workflowState) api.OrchestrationStatus {
+	status := runtimeState.RuntimeStatus()
+	if status == api.RUNTIME_STATUS_RUNNING && isSuspended(state.History) {
+		return api.RUNTIME_STATUS_SUSPENDED
+	}
+	return status
+}
+
+// isSuspended returns true if the workflow with the history was suspended and hasn't been resumed or completed since.
+func isSuspended(history []*backend.HistoryEvent) bool {
+	suspended := false
+	for _, e := range history {
+		switch {
+		case e.GetExecutionSuspended() != nil:
+			suspended = true
+		case e.GetExecutionResumed() != nil, e.GetExecutionCompleted() != nil:
+			suspended = false
+		}
+	}
+	return suspended
+}
+
+// endsSuspension returns true if the event must be processed by a suspended workflow, because it resumes or terminates it.
+func endsSuspension(e *backend.HistoryEvent) bool {
+	return e.GetExecutionResumed() != nil || e.GetExecutionTerminated() != nil
+}
+
 func getActivityActorID(workflowActorID string, taskID int32, generation uint64) string {
 	// An activity can be identified by its name followed by its task ID and generation. Example: SayHello::0::1, SayHello::1::1, etc.
 	return workflowActorID + "::" + strconv.Itoa(int(taskID)) + "::" + strconv.FormatUint(generation, 10)
 }
 
-func (wf *workflowActor) removeCompletedStateData(ctx context.Context, state *workflowState, actorID string) error {
+func (wf *workflowActor) removeCompletedStateData(ctx context.Context, state *workflowState, actorID string) (int, error) {
 	// The logic/for loop below purges/removes any leftover state from a completed or failed activity
 	// TODO: for optimization make multiple go routines and run them in parallel
-	var err error
+	var keys int
 	for _, e := range state.Inbox {
 		var taskID int32
 		if ts := e.GetTaskCompleted(); ts != nil {
@@ -682,10 +922,11 @@ func (wf *workflowActor) removeCompletedStateData(ctx context.Context, state *wo
 				},
 			}},
 		}
-		if err = wf.actors.TransactionalStateOperation(ctx, &req); err != nil {
-			return fmt.Errorf("failed to delete activity state with error: %w", err)
+		if err := wf.actors.TransactionalStateOperation(ctx, &req); err != nil {
+			return keys, fmt.Errorf("failed to delete activity state with error: %w", err)
 		}
+		keys++
 	}
 
-	return err
+	return keys, nil
 }
@@ -38,19 +38,31 @@ type workflowState struct {
 	History      []*backend.HistoryEvent
 	CustomStatus string
 	Generation   uint64
+	// Baggage is the W3C baggage of the request that started the workflow, which is propagated to its activities and child workflows.
+	Baggage string
+	// ParentAppID is the ID of the app that hosts the parent workflow, if this is the child workflow of a workflow in another app.
+	ParentAppID string
 
 	// change tracking
 	inboxAddedCount     int
 	inboxRemovedCount   int
 	historyAddedCount   int
 	historyRemovedCount int
+	// clearBufferedEvents is true if the events buffered before the workflow was created were added to the inbox, and must be deleted.
+	clearBufferedEvents bool
 	config              actorsBackendConfig
+	// indexedStatus is the status of the workflow last sent to the workflow index.
+	// It's saved with the state, so the index isn't updated again after the state is loaded.
+	indexedStatus string
 }
 
 type workflowStateMetadata struct {
 	InboxLength   int
 	HistoryLength int
 	Generation    uint64
+	Baggage       string
+	ParentAppID   string `json:",omitempty"`
+	IndexedStatus string `json:",omitempty"`
 }
 
 func NewWorkflowState(config actorsBackendConfig) *workflowState {
@@ -68,6 +80,9 @@ func (s *workflowState) Reset() {
 	s.historyRemovedCount += len(s.History)
 	s.History = nil
 	s.CustomStatus = ""
+	s.Baggage = ""
+	s.ParentAppID = ""
+	s.indexedStatus = ""
 	s.Generation++
 }
 
@@ -77,6 +92,7 @@ func (s *workflowState) ResetChangeTracking() {
 	s.inboxRemovedCount = 0
 	s.historyAddedCount = 0
 	s.historyRemovedCount = 0
+	s.clearBufferedEvents = false
 }
 
 func (s *workflowState) ApplyRuntimeStateChanges(runtimeState *backend.OrchestrationRuntimeState) {
@@ -136,12 +152,23 @@ func (s *workflowState) GetSaveRequest(actorID string) (*actors.TransactionalReq
 		})
 	}
 
+	// The events buffered before the workflow was created are deleted in the same transaction that adds them to the inbox
+	if s.clearBufferedEvents {
+		req.Operations = append(req.Operations, actors.TransactionalOperation{
+			Operation: actors.Delete,
+			Request:   actors.TransactionalDelete{Key: bufferedEventsKey},
+		})
+	}
+
 	// Every time we save, we also update the metadata with information about the size of the history and inbox,
 	// as well as the generation of the workflow.
 	metadata := workflowStateMetadata{
 		InboxLength:   len(s.Inbox),
 		HistoryLength: len(s.History),
 		Generation:    s.Generation,
+		Baggage:       s.Baggage,
+		ParentAppID:   s.ParentAppID,
+		IndexedStatus: s.indexedStatus,
 	}
 	req.Operations = append(req.Operations, actors.TransactionalOperation{
 		Operation: actors.Upsert,
@@ -245,6 +272,9 @@ func LoadWorkflowState(ctx context.Context, actorRuntime actors.Actors, actorID
 	// Load inbox, history, and custom status using a bulk request
 	state := NewWorkflowState(config)
 	state.Generation = metadata.Generation
+	state.Baggage = metadata.Baggage
+	state.ParentAppID = metadata.ParentAppID
+	state.indexedStatus = metadata.IndexedStatus
 	state.Inbox = make([]*backend.HistoryEvent, metadata.InboxLength)
 	state.History = make([]*backend.HistoryEvent, metadata.HistoryLength)
 
@@ -38,6 +38,7 @@ import (
 const (
 	workflowActorType = "dapr.internal.default.wf-app.workflow"
 	activityActorType = "dapr.internal.default.wf-app.activity"
+	indexActorType    = "dapr.internal.default.wf-app.workflowindex"
 )
 
 func TestNoWorkflowState(t *testing.T) {
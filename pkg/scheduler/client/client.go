@@ -0,0 +1,57 @@
+/*
+Copyright 2023 The Dapr Authors
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package client
+
+import (
+	"context"
+
+	grpcMiddleware "github.com/grpc-ecosystem/go-grpc-middleware"
+	grpcRetry "github.com/grpc-ecosystem/go-grpc-middleware/retry"
+	"github.com/spiffe/go-spiffe/v2/spiffeid"
+	"google.golang.org/grpc"
+
+	diag "github.com/dapr/dapr/pkg/diagnostics"
+	schedulerv1pb "github.com/dapr/dapr/pkg/proto/scheduler/v1"
+	"github.com/dapr/dapr/pkg/security"
+)
+
+// GetSchedulerClient returns a new scheduler client and the underlying connection.
+// The connection is established with mTLS, using the identity of the scheduler in the control plane namespace.
+// The connection is established in the background, so the sidecar can start while the scheduler is unavailable: calls fail until it's reachable.
+func GetSchedulerClient(ctx context.Context, address string, sec security.Handler) (schedulerv1pb.SchedulerClient, *grpc.ClientConn, error) {
+	unaryClientInterceptor := grpcRetry.UnaryClientInterceptor()
+
+	if diag.DefaultGRPCMonitoring.IsEnabled() {
+		unaryClientInterceptor = grpcMiddleware.ChainUnaryClient(
+			unaryClientInterceptor,
+			diag.DefaultGRPCMonitoring.UnaryClientInterceptor(),
+		)
+	}
+
+	schedulerID, err := spiffeid.FromSegments(sec.ControlPlaneTrustDomain(), "ns", sec.ControlPlaneNamespace(), "dapr-scheduler")
+	if err != nil {
+		return nil, nil, err
+	}
+
+	opts := []grpc.DialOption{
+		grpc.WithUnaryInterceptor(unaryClientInterceptor),
+		sec.GRPCDialOptionMTLS(schedulerID),
+	}
+
+	conn, err := grpc.DialContext(ctx, address, opts...)
+	if err != nil {
+		return nil, nil, err
+	}
+	return schedulerv1pb.NewSchedulerClient(conn), conn, nil
+}
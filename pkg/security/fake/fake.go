@@ -46,6 +46,10 @@ type Fake struct {
 	grpcDialOptionUnknownTrustDomainFn func(ns, appID string) grpc.DialOption
 	grpcServerOptionMTLSFn             func() grpc.ServerOption
 	grpcServerOptionNoClientAuthFn     func() grpc.ServerOption
+
+	setRevokedIdentitiesFn func([]spiffeid.ID)
+	isRevokedFn            func(spiffeid.ID) bool
+	setTLSOptionsFn        func(*security.TLSOptions)
 }
 
 func New() *Fake {
@@ -87,6 +91,11 @@ func New() *Fake {
 		netDialerIDFn: func(context.Context, spiffeid.ID, time.Duration) func(network, addr string) (net.Conn, error) {
 			return net.Dial
 		},
+		setRevokedIdentitiesFn: func([]spiffeid.ID) {},
+		setTLSOptionsFn:        func(*security.TLSOptions) {},
+		isRevokedFn: func(spiffeid.ID) bool {
+			return false
+		},
 		mtls: false,
 	}
 }
@@ -222,6 +231,33 @@ func (f *Fake) NetDialerID(ctx context.Context, id spiffeid.ID, timeout time.Dur
 	return f.netDialerIDFn(ctx, id, timeout)
 }
 
+func (f *Fake) WithSetRevokedIdentitiesFn(fn func([]spiffeid.ID)) *Fake {
+	f.setRevokedIdentitiesFn = fn
+	return f
+}
+
+func (f *Fake) WithIsRevokedFn(fn func(spiffeid.ID) bool) *Fake {
+	f.isRevokedFn = fn
+	return f
+}
+
+func (f *Fake) SetRevokedIdentities(ids []spiffeid.ID) {
+	f.setRevokedIdentitiesFn(ids)
+}
+
+func (f *Fake) IsRevoked(id spiffeid.ID) bool {
+	return f.isRevokedFn(id)
+}
+
+func (f *Fake) WithSetTLSOptionsFn(fn func(*security.TLSOptions)) *Fake {
+	f.setTLSOptionsFn = fn
+	return f
+}
+
+func (f *Fake) SetTLSOptions(opts *security.TLSOptions) {
+	f.setTLSOptionsFn(opts)
+}
+
 func (f *Fake) MTLSEnabled() bool {
 	return f.mtls
 }
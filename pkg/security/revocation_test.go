@@ -0,0 +1,69 @@
+/*
+Copyright 2023 The Dapr Authors
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package security
+
+import (
+	"crypto/x509"
+	"errors"
+	"testing"
+
+	"github.com/spiffe/go-spiffe/v2/spiffeid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseRevokedIdentities(t *testing.T) {
+	t.Run("valid identities", func(t *testing.T) {
+		ids, err := ParseRevokedIdentities([]string{
+			"spiffe://example.org/ns/default/app1",
+			"spiffe://example.org/ns/other/app2",
+		})
+		require.NoError(t, err)
+		assert.Equal(t, []spiffeid.ID{
+			spiffeid.RequireFromString("spiffe://example.org/ns/default/app1"),
+			spiffeid.RequireFromString("spiffe://example.org/ns/other/app2"),
+		}, ids)
+	})
+
+	t.Run("invalid identity", func(t *testing.T) {
+		_, err := ParseRevokedIdentities([]string{"app1"})
+		require.Error(t, err)
+	})
+}
+
+func TestRevokedIdentities(t *testing.T) {
+	app1 := spiffeid.RequireFromString("spiffe://example.org/ns/default/app1")
+	app2 := spiffeid.RequireFromString("spiffe://example.org/ns/default/app2")
+
+	s := &security{}
+	assert.False(t, s.IsRevoked(app1))
+
+	s.SetRevokedIdentities([]spiffeid.ID{app1})
+	assert.True(t, s.IsRevoked(app1))
+	assert.False(t, s.IsRevoked(app2))
+
+	authorizeErr := errors.New("not authorized")
+	authorizer := s.authorizer(func(id spiffeid.ID, _ [][]*x509.Certificate) error {
+		if id == app2 {
+			return authorizeErr
+		}
+		return nil
+	})
+	require.ErrorContains(t, authorizer(app1, nil), "revoked")
+	require.ErrorIs(t, authorizer(app2, nil), authorizeErr)
+
+	s.SetRevokedIdentities(nil)
+	assert.False(t, s.IsRevoked(app1))
+	require.NoError(t, authorizer(app1, nil))
+}
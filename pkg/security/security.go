@@ -25,7 +25,6 @@ import (
 	"sync/atomic"
 	"time"
 
-	"github.com/spiffe/go-spiffe/v2/spiffegrpc/grpccredentials"
 	"github.com/spiffe/go-spiffe/v2/spiffeid"
 	"github.com/spiffe/go-spiffe/v2/spiffetls/tlsconfig"
 	"google.golang.org/grpc"
@@ -64,6 +63,10 @@ type Handler interface {
 
 	MTLSEnabled() bool
 	WatchTrustAnchors(context.Context, chan<- []byte)
+
+	SetRevokedIdentities([]spiffeid.ID)
+	IsRevoked(spiffeid.ID) bool
+	SetTLSOptions(*TLSOptions)
 }
 
 // Provider is the security provider.
@@ -113,6 +116,10 @@ type Options struct {
 	// Mode is the operation mode of this security instance (self-hosted or
 	// Kubernetes).
 	Mode modes.DaprMode
+
+	// TLS restricts the TLS versions, cipher suites, and curves used by servers
+	// and clients. Optional.
+	TLS *TLSOptions
 }
 
 type provider struct {
@@ -134,6 +141,11 @@ type security struct {
 
 	source *x509source
 	mtls   bool
+
+	// revoked contains the identities whose certificates are revoked.
+	revoked atomic.Pointer[map[spiffeid.ID]struct{}]
+	// tlsOptions restricts the parameters of the TLS connections.
+	tlsOptions atomic.Pointer[TLSOptions]
 }
 
 func New(ctx context.Context, opts Options) (Provider, error) {
@@ -168,16 +180,19 @@ func New(ctx context.Context, opts Options) (Provider, error) {
 		log.Warn("mTLS is disabled. Skipping certificate request and tls validation")
 	}
 
+	sec := &security{
+		source:                  source,
+		mtls:                    opts.MTLSEnabled,
+		controlPlaneTrustDomain: td,
+		controlPlaneNamespace:   opts.ControlPlaneNamespace,
+	}
+	sec.SetTLSOptions(opts.TLS)
+
 	return &provider{
 		fswatcherInterval: time.Millisecond * 500,
 		readyCh:           make(chan struct{}),
 		trustAnchorsFile:  opts.TrustAnchorsFile,
-		sec: &security{
-			source:                  source,
-			mtls:                    opts.MTLSEnabled,
-			controlPlaneTrustDomain: td,
-			controlPlaneNamespace:   opts.ControlPlaneNamespace,
-		},
+		sec:               sec,
 	}, nil
 }
 
@@ -275,7 +290,7 @@ func (s *security) GRPCDialOptionMTLS(appID spiffeid.ID) grpc.DialOption {
 		return grpc.WithTransportCredentials(insecure.NewCredentials())
 	}
 	return grpc.WithTransportCredentials(credentials.NewTLS(
-		legacy.NewDialClient(s.source, s.source, tlsconfig.AuthorizeID(appID)),
+		s.withTLSOptions(legacy.NewDialClient(s.source, s.source, s.authorizer(tlsconfig.AuthorizeID(appID)))),
 	))
 }
 
@@ -289,7 +304,9 @@ func (s *security) GRPCServerOptionMTLS() grpc.ServerOption {
 	return grpc.Creds(
 		// TODO: It would be better if we could give a subset of trust domains in
 		// which this server authorizes.
-		grpccredentials.MTLSServerCredentials(s.source, s.source, tlsconfig.AuthorizeAny()),
+		credentials.NewTLS(
+			s.withTLSOptions(tlsconfig.MTLSServerConfig(s.source, s.source, s.authorizer(tlsconfig.AuthorizeAny()))),
+		),
 	)
 }
 
@@ -297,7 +314,7 @@ func (s *security) GRPCServerOptionMTLS() grpc.ServerOption {
 // authentication of clients using the current trust anchors. Doesn't require
 // clients to present a certificate.
 func (s *security) GRPCServerOptionNoClientAuth() grpc.ServerOption {
-	return grpc.Creds(grpccredentials.TLSServerCredentials(s.source))
+	return grpc.Creds(credentials.NewTLS(s.withTLSOptions(tlsconfig.TLSServerConfig(s.source))))
 }
 
 // GRPCDialOptionMTLSUnknownTrustDomain returns a gRPC dial option which
@@ -319,7 +336,7 @@ func (s *security) GRPCDialOptionMTLSUnknownTrustDomain(ns, appID string) grpc.D
 	}
 
 	return grpc.WithTransportCredentials(credentials.NewTLS(
-		legacy.NewDialClient(s.source, s.source, tlsconfig.AdaptMatcher(matcher)),
+		s.withTLSOptions(legacy.NewDialClient(s.source, s.source, s.authorizer(tlsconfig.AdaptMatcher(matcher)))),
 	))
 }
 
@@ -379,7 +396,7 @@ func (s *security) WatchTrustAnchors(ctx context.Context, trustAnchors chan<- []
 // using the current signed server certificate. Authorizes client certificate
 // chains against the trust anchors.
 func (s *security) TLSServerConfigNoClientAuth() *tls.Config {
-	return tlsconfig.TLSServerConfig(s.source)
+	return s.withTLSOptions(tlsconfig.TLSServerConfig(s.source))
 }
 
 // NetListenerID returns a mTLS net listener which instruments using the
@@ -390,7 +407,7 @@ func (s *security) NetListenerID(lis net.Listener, id spiffeid.ID) net.Listener
 		return lis
 	}
 	return tls.NewListener(lis,
-		tlsconfig.MTLSServerConfig(s.source, s.source, tlsconfig.AuthorizeID(id)),
+		s.withTLSOptions(tlsconfig.MTLSServerConfig(s.source, s.source, s.authorizer(tlsconfig.AuthorizeID(id)))),
 	)
 }
 
@@ -403,7 +420,7 @@ func (s *security) NetDialerID(ctx context.Context, spiffeID spiffeid.ID, timeou
 	}
 	return (&tls.Dialer{
 		NetDialer: (&net.Dialer{Timeout: timeout, Cancel: ctx.Done()}),
-		Config:    tlsconfig.MTLSClientConfig(s.source, s.source, tlsconfig.AuthorizeID(spiffeID)),
+		Config:    s.withTLSOptions(tlsconfig.MTLSClientConfig(s.source, s.source, s.authorizer(tlsconfig.AuthorizeID(spiffeID)))),
 	}).Dial
 }
 
@@ -0,0 +1,118 @@
+/*
+Copyright 2023 The Dapr Authors
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package security
+
+import (
+	"crypto/tls"
+	"fmt"
+	"strings"
+
+	"github.com/dapr/dapr/pkg/buildinfo"
+)
+
+// TLSOptions restricts the TLS parameters negotiated by servers and clients, for example to comply with FIPS requirements.
+type TLSOptions struct {
+	// Minimum TLS version. If 0, the default of TLS 1.2 is used.
+	MinVersion uint16
+	// Cipher suites allowed for TLS 1.2 connections. If empty, Go's default list is used.
+	// The cipher suites of TLS 1.3 are not configurable.
+	CipherSuites []uint16
+	// Elliptic curves used in ECDHE handshakes, in order of preference. If empty, Go's default list is used.
+	CurvePreferences []tls.CurveID
+}
+
+var tlsCurves = map[string]tls.CurveID{
+	"x25519": tls.X25519,
+	"p256":   tls.CurveP256,
+	"p384":   tls.CurveP384,
+	"p521":   tls.CurveP521,
+}
+
+// ParseTLSOptions parses the TLS options from their names.
+// The min version can be "1.2" or "1.3"; cipher suites use their IANA names, such as "TLS_ECDHE_ECDSA_WITH_AES_128_GCM_SHA256"; and curves can be "X25519", "P256", "P384", or "P521".
+// Only cipher suites without known security issues are allowed.
+func ParseTLSOptions(minVersion string, cipherSuites []string, curvePreferences []string) (*TLSOptions, error) {
+	opts := &TLSOptions{}
+
+	switch strings.TrimPrefix(strings.ToUpper(strings.TrimSpace(minVersion)), "TLS") {
+	case "":
+		// Use the default
+	case "1.2":
+		opts.MinVersion = tls.VersionTLS12
+	case "1.3":
+		opts.MinVersion = tls.VersionTLS13
+	default:
+		return nil, fmt.Errorf("invalid minimum TLS version %q: supported values are '1.2' and '1.3'", minVersion)
+	}
+
+	if len(cipherSuites) > 0 {
+		supported := make(map[string]uint16)
+		for _, cs := range tls.CipherSuites() {
+			supported[cs.Name] = cs.ID
+		}
+		opts.CipherSuites = make([]uint16, len(cipherSuites))
+		for i, name := range cipherSuites {
+			id, ok := supported[strings.ToUpper(strings.TrimSpace(name))]
+			if !ok {
+				return nil, fmt.Errorf("invalid or insecure cipher suite %q", name)
+			}
+			opts.CipherSuites[i] = id
+		}
+	}
+
+	if len(curvePreferences) > 0 {
+		opts.CurvePreferences = make([]tls.CurveID, len(curvePreferences))
+		for i, name := range curvePreferences {
+			n := strings.ToLower(strings.TrimSpace(name))
+			n = strings.TrimPrefix(strings.ReplaceAll(n, "-", ""), "curve")
+			curve, ok := tlsCurves[n]
+			if !ok {
+				return nil, fmt.Errorf("invalid curve %q: supported values are 'X25519', 'P256', 'P384', and 'P521'", name)
+			}
+			if curve == tls.X25519 && buildinfo.FIPSEnabled() {
+				return nil, fmt.Errorf("curve %q is not allowed in FIPS mode", name)
+			}
+			opts.CurvePreferences[i] = curve
+		}
+	}
+
+	return opts, nil
+}
+
+// Apply sets the TLS options on the TLS configuration, and returns it.
+func (o *TLSOptions) Apply(cfg *tls.Config) *tls.Config {
+	if o == nil || cfg == nil {
+		return cfg
+	}
+	if o.MinVersion > cfg.MinVersion {
+		cfg.MinVersion = o.MinVersion
+	}
+	if len(o.CipherSuites) > 0 {
+		cfg.CipherSuites = o.CipherSuites
+	}
+	if len(o.CurvePreferences) > 0 {
+		cfg.CurvePreferences = o.CurvePreferences
+	}
+	return cfg
+}
+
+// SetTLSOptions sets the options used for TLS configurations that are created afterwards.
+func (s *security) SetTLSOptions(opts *TLSOptions) {
+	s.tlsOptions.Store(opts)
+}
+
+// withTLSOptions applies the TLS options to the TLS configuration, if any.
+func (s *security) withTLSOptions(cfg *tls.Config) *tls.Config {
+	return s.tlsOptions.Load().Apply(cfg)
+}
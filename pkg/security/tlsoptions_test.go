@@ -0,0 +1,84 @@
+/*
+Copyright 2023 The Dapr Authors
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package security
+
+import (
+	"crypto/tls"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseTLSOptions(t *testing.T) {
+	t.Run("empty options", func(t *testing.T) {
+		opts, err := ParseTLSOptions("", nil, nil)
+		require.NoError(t, err)
+		assert.Equal(t, &TLSOptions{}, opts)
+	})
+
+	t.Run("valid options", func(t *testing.T) {
+		opts, err := ParseTLSOptions("TLS1.3",
+			[]string{"TLS_ECDHE_ECDSA_WITH_AES_128_GCM_SHA256", "tls_ecdhe_rsa_with_aes_256_gcm_sha384"},
+			[]string{"X25519", "P-256", "CurveP384"},
+		)
+		require.NoError(t, err)
+		assert.Equal(t, &TLSOptions{
+			MinVersion:       tls.VersionTLS13,
+			CipherSuites:     []uint16{tls.TLS_ECDHE_ECDSA_WITH_AES_128_GCM_SHA256, tls.TLS_ECDHE_RSA_WITH_AES_256_GCM_SHA384},
+			CurvePreferences: []tls.CurveID{tls.X25519, tls.CurveP256, tls.CurveP384},
+		}, opts)
+	})
+
+	t.Run("invalid min version", func(t *testing.T) {
+		_, err := ParseTLSOptions("1.1", nil, nil)
+		require.ErrorContains(t, err, "minimum TLS version")
+	})
+
+	t.Run("insecure cipher suite", func(t *testing.T) {
+		_, err := ParseTLSOptions("", []string{"TLS_RSA_WITH_RC4_128_SHA"}, nil)
+		require.ErrorContains(t, err, "cipher suite")
+	})
+
+	t.Run("invalid curve", func(t *testing.T) {
+		_, err := ParseTLSOptions("", nil, []string{"P224"})
+		require.ErrorContains(t, err, "curve")
+	})
+}
+
+func TestTLSOptionsApply(t *testing.T) {
+	t.Run("nil options", func(t *testing.T) {
+		var opts *TLSOptions
+		cfg := &tls.Config{MinVersion: tls.VersionTLS12}
+		assert.Equal(t, &tls.Config{MinVersion: tls.VersionTLS12}, opts.Apply(cfg))
+	})
+
+	t.Run("options are applied", func(t *testing.T) {
+		opts := &TLSOptions{
+			MinVersion:       tls.VersionTLS13,
+			CipherSuites:     []uint16{tls.TLS_ECDHE_ECDSA_WITH_AES_128_GCM_SHA256},
+			CurvePreferences: []tls.CurveID{tls.CurveP384},
+		}
+		cfg := opts.Apply(&tls.Config{MinVersion: tls.VersionTLS12})
+		assert.Equal(t, uint16(tls.VersionTLS13), cfg.MinVersion)
+		assert.Equal(t, opts.CipherSuites, cfg.CipherSuites)
+		assert.Equal(t, opts.CurvePreferences, cfg.CurvePreferences)
+	})
+
+	t.Run("min version is never lowered", func(t *testing.T) {
+		opts := &TLSOptions{MinVersion: tls.VersionTLS12}
+		cfg := opts.Apply(&tls.Config{MinVersion: tls.VersionTLS13})
+		assert.Equal(t, uint16(tls.VersionTLS13), cfg.MinVersion)
+	})
+}
@@ -21,6 +21,7 @@ import (
 	"strings"
 	"time"
 
+	"github.com/spiffe/go-spiffe/v2/spiffeid"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	metaV1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 
@@ -56,6 +57,8 @@ const (
 
 // Config holds the configuration for the Certificate Authority.
 type Config struct {
+	// Name of the configuration the Config is loaded from.
+	ConfigName       string
 	Port             int
 	TrustDomain      string
 	CAStore          string
@@ -67,6 +70,10 @@ type Config struct {
 	Validators       map[sentryv1pb.SignCertificateRequest_TokenValidator]map[string]string
 	DefaultValidator sentryv1pb.SignCertificateRequest_TokenValidator
 	Features         []daprGlobalConfig.FeatureSpec
+	// Identities for which certificates are not issued.
+	RevokedIdentities []spiffeid.ID
+	// Restrictions on the TLS parameters of the server.
+	TLS *security.TLSOptions
 }
 
 // FromConfigName returns a Sentry configuration based on a configuration spec.
@@ -82,10 +89,30 @@ func FromConfigName(configName string) (conf Config, err error) {
 		err = fmt.Errorf("loading default config. couldn't find config name %q: %w", configName, err)
 		conf = getDefaultConfig()
 	}
+	conf.ConfigName = configName
 
 	return conf, err
 }
 
+// RevokedIdentitiesFromConfigName returns the identities revoked in the configuration with the given name.
+// Unlike FromConfigName, an error is returned if the configuration can't be loaded.
+func RevokedIdentitiesFromConfigName(configName string) ([]spiffeid.ID, error) {
+	var (
+		conf Config
+		err  error
+	)
+	if IsKubernetesHosted() {
+		conf, err = getKubernetesConfig(configName)
+	} else {
+		conf, err = getSelfhostedConfig(configName)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return conf.RevokedIdentities, nil
+}
+
 func IsKubernetesHosted() bool {
 	return os.Getenv(kubernetesServiceHostEnvVar) != ""
 }
@@ -180,6 +207,22 @@ func parseConfiguration(conf Config, daprConfig *daprGlobalConfig.Configuration)
 
 	conf.Features = daprConfig.Spec.Features
 
+	if mtlsSpec != nil && len(mtlsSpec.RevokedIdentities) > 0 {
+		revoked, err := security.ParseRevokedIdentities(mtlsSpec.RevokedIdentities)
+		if err != nil {
+			return conf, err
+		}
+		conf.RevokedIdentities = revoked
+	}
+
+	if mtlsSpec != nil && mtlsSpec.TLS != nil {
+		tlsOpts, err := security.ParseTLSOptions(mtlsSpec.TLS.MinVersion, mtlsSpec.TLS.CipherSuites, mtlsSpec.TLS.CurvePreferences)
+		if err != nil {
+			return conf, err
+		}
+		conf.TLS = tlsOpts
+	}
+
 	// Get token validators
 	// In Kubernetes mode, we always allow the built-in "kubernetes" validator
 	// In self-hosted mode, the built-in "insecure" validator is enabled only if no other validator is configured
@@ -14,6 +14,8 @@ limitations under the License.
 package config
 
 import (
+	"os"
+	"path/filepath"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -56,6 +58,48 @@ func TestConfig(t *testing.T) {
 		assert.Equal(t, "1h0m0s", conf.AllowedClockSkew.String())
 	})
 
+	t.Run("parse revoked identities", func(t *testing.T) {
+		daprConfig := daprDaprConfig.Configuration{
+			Spec: daprDaprConfig.ConfigurationSpec{
+				MTLSSpec: &daprDaprConfig.MTLSSpec{
+					Enabled:           true,
+					RevokedIdentities: []string{"spiffe://cluster.local/ns/default/myapp"},
+				},
+			},
+		}
+
+		conf, err := parseConfiguration(getDefaultConfig(), &daprConfig)
+		require.NoError(t, err)
+		require.Len(t, conf.RevokedIdentities, 1)
+		assert.Equal(t, "spiffe://cluster.local/ns/default/myapp", conf.RevokedIdentities[0].String())
+
+		daprConfig.Spec.MTLSSpec.RevokedIdentities = []string{"myapp"}
+		_, err = parseConfiguration(getDefaultConfig(), &daprConfig)
+		require.Error(t, err)
+	})
+
+	t.Run("reload revoked identities, self hosted", func(t *testing.T) {
+		configPath := filepath.Join(t.TempDir(), "config.yaml")
+		require.NoError(t, os.WriteFile(configPath, []byte(`apiVersion: dapr.io/v1alpha1
+kind: Configuration
+metadata:
+  name: daprsystem
+spec:
+  mtls:
+    enabled: true
+    revokedIdentities:
+    - spiffe://cluster.local/ns/default/myapp
+`), 0o600))
+
+		revoked, err := RevokedIdentitiesFromConfigName(configPath)
+		require.NoError(t, err)
+		require.Len(t, revoked, 1)
+		assert.Equal(t, "spiffe://cluster.local/ns/default/myapp", revoked[0].String())
+
+		_, err = RevokedIdentitiesFromConfigName(filepath.Join(t.TempDir(), "missing.yaml"))
+		require.Error(t, err)
+	})
+
 	t.Run("set validators", func(t *testing.T) {
 		daprConfig := daprDaprConfig.Configuration{
 			Spec: daprDaprConfig.ConfigurationSpec{
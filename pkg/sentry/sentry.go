@@ -21,6 +21,7 @@ import (
 	"fmt"
 	"strings"
 	"sync/atomic"
+	"time"
 
 	"github.com/spiffe/go-spiffe/v2/spiffeid"
 
@@ -41,6 +42,9 @@ import (
 
 var log = logger.NewLogger("dapr.sentry")
 
+// Interval at which the list of revoked identities is reloaded from the configuration.
+const revokedIdentitiesReloadInterval = 30 * time.Second
+
 // CertificateAuthority is the interface for the Sentry Certificate Authority.
 // Starts the Sentry gRPC server and signs workload certificates.
 type CertificateAuthority interface {
@@ -83,6 +87,7 @@ func (s *sentry) Start(parentCtx context.Context) error {
 		AppID:                   "dapr-sentry",
 		TrustAnchors:            camngr.TrustAnchors(),
 		MTLSEnabled:             true,
+		TLS:                     s.conf.TLS,
 		// Override the request source to our in memory CA since _we_ are sentry!
 		OverrideCertRequestSource: func(ctx context.Context, csrDER []byte) ([]*x509.Certificate, error) {
 			csr, csrErr := x509.ParseCertificateRequest(csrDER)
@@ -126,6 +131,8 @@ func (s *sentry) Start(parentCtx context.Context) error {
 				return secErr
 			}
 
+			sec.SetRevokedIdentities(s.conf.RevokedIdentities)
+
 			return server.Start(ctx, server.Options{
 				Port:             s.conf.Port,
 				Security:         sec,
@@ -134,6 +141,14 @@ func (s *sentry) Start(parentCtx context.Context) error {
 				CA:               camngr,
 			})
 		},
+		func(ctx context.Context) error {
+			sec, secErr := provider.Handler(ctx)
+			if secErr != nil {
+				return secErr
+			}
+
+			return s.watchRevokedIdentities(ctx, sec)
+		},
 	)
 	for name, val := range vals {
 		log.Infof("Using validator '%s'", strings.ToLower(name.String()))
@@ -148,6 +163,28 @@ func (s *sentry) Start(parentCtx context.Context) error {
 	return nil
 }
 
+// watchRevokedIdentities periodically reloads the identities revoked in the configuration, so revocations are applied without restarting Sentry.
+// Blocks until the context is canceled.
+func (s *sentry) watchRevokedIdentities(ctx context.Context, sec security.Handler) error {
+	ticker := time.NewTicker(revokedIdentitiesReloadInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+			revoked, err := config.RevokedIdentitiesFromConfigName(s.conf.ConfigName)
+			if err != nil {
+				// Keep the current list if the configuration can't be loaded.
+				log.Debugf("Failed to reload the list of revoked identities: %v", err)
+				continue
+			}
+			sec.SetRevokedIdentities(revoked)
+		}
+	}
+}
+
 func (s *sentry) getValidators(ctx context.Context) (map[sentryv1pb.SignCertificateRequest_TokenValidator]validator.Validator, error) {
 	validators := make(map[sentryv1pb.SignCertificateRequest_TokenValidator]validator.Validator, len(s.conf.Validators))
 
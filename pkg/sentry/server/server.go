@@ -20,6 +20,7 @@ import (
 	"fmt"
 	"net"
 
+	"github.com/spiffe/go-spiffe/v2/spiffeid"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
@@ -59,6 +60,7 @@ type server struct {
 	vals             map[sentryv1pb.SignCertificateRequest_TokenValidator]validator.Validator
 	defaultValidator sentryv1pb.SignCertificateRequest_TokenValidator
 	ca               ca.Signer
+	sec              security.Handler
 }
 
 // Start starts the server. Blocks until the context is cancelled.
@@ -75,6 +77,7 @@ func Start(ctx context.Context, opts Options) error {
 		vals:             opts.Validators,
 		defaultValidator: opts.DefaultValidator,
 		ca:               opts.CA,
+		sec:              opts.Security,
 	}
 	sentryv1pb.RegisterCAServer(srv, s)
 
@@ -129,6 +132,13 @@ func (s *server) signCertificate(ctx context.Context, req *sentryv1pb.SignCertif
 		return nil, status.Error(codes.PermissionDenied, err.Error())
 	}
 
+	if id, idErr := spiffeid.FromSegments(trustDomain, "ns", namespace, req.GetId()); idErr == nil {
+		if s.sec.IsRevoked(id) {
+			log.Warnf("Refusing to sign certificate for revoked identity %s", id)
+			return nil, status.Error(codes.PermissionDenied, "the identity has been revoked")
+		}
+	}
+
 	der, _ := pem.Decode(req.GetCertificateSigningRequest())
 	if der == nil {
 		log.Debugf("Invalid CSR: PEM block is nil for %s/%s", namespace, req.GetId())
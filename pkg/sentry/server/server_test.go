@@ -126,6 +126,32 @@ func TestRun(t *testing.T) {
 			expErr:  true,
 			expCode: codes.Internal,
 		},
+		"request for a revoked identity should fail": {
+			sec: securityfake.New().WithGRPCServerOptionNoClientAuthFn(func() grpc.ServerOption {
+				return grpc.Creds(insecure.NewCredentials())
+			}).WithIsRevokedFn(func(id spiffeid.ID) bool {
+				return id.String() == "spiffe://my-trust-domain/ns/my-namespace/my-id"
+			}),
+			val: validatorfake.New().WithValidateFn(func(ctx context.Context, req *sentryv1pb.SignCertificateRequest) (spiffeid.TrustDomain, bool, error) {
+				return spiffeid.RequireTrustDomainFromString("my-trust-domain"), false, nil
+			}),
+			ca: cafake.New().WithSignIdentity(func(ctx context.Context, req *ca.SignRequest, override bool) ([]*x509.Certificate, error) {
+				return []*x509.Certificate{crtX509}, nil
+			}).WithTrustAnchors(func() []byte {
+				return []byte("my-trust-anchors")
+			}),
+			req: &sentryv1pb.SignCertificateRequest{
+				Id:                        "my-id",
+				Token:                     "my-token",
+				TrustDomain:               "my-trust-domain",
+				Namespace:                 "my-namespace",
+				CertificateSigningRequest: csrPEM,
+				TokenValidator:            sentryv1pb.SignCertificateRequest_TokenValidator(-1),
+			},
+			expResp: nil,
+			expErr:  true,
+			expCode: codes.PermissionDenied,
+		},
 		"request with a bad csr should fail": {
 			sec: securityfake.New().WithGRPCServerOptionNoClientAuthFn(func() grpc.ServerOption {
 				return grpc.Creds(insecure.NewCredentials())
@@ -213,24 +213,37 @@ func (k *kubernetes) Validate(ctx context.Context, req *sentryv1pb.SignCertifica
 		return k.controlPlaneTD, overrideDuration, nil
 	}
 
-	configName, ok := pod.GetAnnotations()[annotations.KeyConfig]
+	configNames, ok := pod.GetAnnotations()[annotations.KeyConfig]
 	if !ok {
 		// Return early with default trust domain if no config annotation is found.
 		return spiffeid.RequireTrustDomainFromString("public"), overrideDuration, nil
 	}
 
-	var config configv1alpha1.Configuration
-	err = k.client.Get(ctx, types.NamespacedName{Namespace: req.GetNamespace(), Name: configName}, &config)
-	if err != nil {
-		log.Errorf("Failed to get configuration %q: %v", configName, err)
-		return spiffeid.TrustDomain{}, false, errors.New("failed to get configuration")
+	// The annotation can contain multiple configurations, which are merged in order, so the last trust domain set wins
+	var trustDomain string
+	for _, configName := range strings.Split(configNames, ",") {
+		configName = strings.TrimSpace(configName)
+		if configName == "" {
+			continue
+		}
+
+		var config configv1alpha1.Configuration
+		err = k.client.Get(ctx, types.NamespacedName{Namespace: req.GetNamespace(), Name: configName}, &config)
+		if err != nil {
+			log.Errorf("Failed to get configuration %q: %v", configName, err)
+			return spiffeid.TrustDomain{}, false, errors.New("failed to get configuration")
+		}
+
+		if config.Spec.AccessControlSpec != nil && len(config.Spec.AccessControlSpec.TrustDomain) > 0 {
+			trustDomain = config.Spec.AccessControlSpec.TrustDomain
+		}
 	}
 
-	if config.Spec.AccessControlSpec == nil || len(config.Spec.AccessControlSpec.TrustDomain) == 0 {
+	if len(trustDomain) == 0 {
 		return spiffeid.RequireTrustDomainFromString("public"), overrideDuration, nil
 	}
 
-	td, err := spiffeid.TrustDomainFromString(config.Spec.AccessControlSpec.TrustDomain)
+	td, err := spiffeid.TrustDomainFromString(trustDomain)
 	return td, overrideDuration, err
 }
 
@@ -26,8 +26,9 @@ import (
 
 // MockPubSubAdapter is mock for PubSubAdapter
 type MockPubSubAdapter struct {
-	PublishFn     func(ctx context.Context, req *pubsub.PublishRequest) error
-	BulkPublishFn func(ctx context.Context, req *pubsub.BulkPublishRequest) (pubsub.BulkPublishResponse, error)
+	PublishFn      func(ctx context.Context, req *pubsub.PublishRequest) error
+	BulkPublishFn  func(ctx context.Context, req *pubsub.BulkPublishRequest) (pubsub.BulkPublishResponse, error)
+	TopicBacklogFn func(ctx context.Context, pubsubName, topic string) (int64, bool)
 }
 
 // Publish is an adapter method for the runtime to pre-validate publish requests
@@ -44,6 +45,14 @@ func (a *MockPubSubAdapter) BulkPublish(ctx context.Context, req *pubsub.BulkPub
 	return a.BulkPublishFn(ctx, req)
 }
 
+// TopicBacklog returns the backlog of the topic, if TopicBacklogFn is set.
+func (a *MockPubSubAdapter) TopicBacklog(ctx context.Context, pubsubName, topic string) (int64, bool) {
+	if a.TopicBacklogFn == nil {
+		return 0, false
+	}
+	return a.TopicBacklogFn(ctx, pubsubName, topic)
+}
+
 func (a *MockPubSubAdapter) Outbox() outbox.Outbox {
 	return &outboxMock{}
 }
@@ -105,6 +105,7 @@ func validateResponse(t *testing.T, appID string, appPort int, body io.Reader) {
 	extended, ok := bodyMap["extended"].(map[string]interface{})
 	require.True(t, ok)
 	require.Equal(t, "edge", extended["daprRuntimeVersion"])
+	require.Equal(t, "go", extended["daprCryptoBackend"])
 
 	appConnectionProperties, ok := bodyMap["appConnectionProperties"].(map[string]interface{})
 	require.True(t, ok)
@@ -0,0 +1,84 @@
+/*
+Copyright 2023 The Dapr Authors
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package utils
+
+import (
+	"sync"
+	"sync/atomic"
+)
+
+// AtomicMap is a typed wrapper around sync.Map, which is safe for concurrent use and offers lock-free reads.
+// It's optimized for maps where keys are written once and read many times, such as caches.
+// The zero value is an empty map ready to use.
+type AtomicMap[K comparable, V any] struct {
+	m   sync.Map
+	len atomic.Int64
+}
+
+// Load returns the value stored in the map for a key.
+func (m *AtomicMap[K, V]) Load(key K) (value V, ok bool) {
+	v, ok := m.m.Load(key)
+	if !ok {
+		return value, false
+	}
+	return v.(V), true
+}
+
+// Store sets the value for a key.
+func (m *AtomicMap[K, V]) Store(key K, value V) {
+	_, loaded := m.m.Swap(key, value)
+	if !loaded {
+		m.len.Add(1)
+	}
+}
+
+// LoadOrStore returns the existing value for the key if present.
+// Otherwise, it stores and returns the given value.
+// The loaded result is true if the value was loaded, false if stored.
+func (m *AtomicMap[K, V]) LoadOrStore(key K, value V) (actual V, loaded bool) {
+	v, loaded := m.m.LoadOrStore(key, value)
+	if !loaded {
+		m.len.Add(1)
+	}
+	return v.(V), loaded
+}
+
+// Delete deletes the value for a key.
+func (m *AtomicMap[K, V]) Delete(key K) {
+	_, loaded := m.m.LoadAndDelete(key)
+	if loaded {
+		m.len.Add(-1)
+	}
+}
+
+// Range calls f sequentially for each key and value present in the map.
+// If f returns false, range stops the iteration.
+func (m *AtomicMap[K, V]) Range(f func(key K, value V) bool) {
+	m.m.Range(func(key, value any) bool {
+		return f(key.(K), value.(V))
+	})
+}
+
+// Clear deletes all values from the map.
+func (m *AtomicMap[K, V]) Clear() {
+	m.m.Range(func(key, _ any) bool {
+		m.Delete(key.(K))
+		return true
+	})
+}
+
+// Len returns the number of values in the map.
+func (m *AtomicMap[K, V]) Len() int {
+	return int(m.len.Load())
+}
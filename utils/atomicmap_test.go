@@ -0,0 +1,62 @@
+/*
+Copyright 2023 The Dapr Authors
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package utils
+
+import (
+	"strconv"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAtomicMap(t *testing.T) {
+	var m AtomicMap[string, int]
+
+	_, ok := m.Load("a")
+	assert.False(t, ok)
+
+	m.Store("a", 1)
+	m.Store("a", 2)
+	v, ok := m.Load("a")
+	assert.True(t, ok)
+	assert.Equal(t, 2, v)
+	assert.Equal(t, 1, m.Len())
+
+	actual, loaded := m.LoadOrStore("a", 3)
+	assert.True(t, loaded)
+	assert.Equal(t, 2, actual)
+	actual, loaded = m.LoadOrStore("b", 3)
+	assert.False(t, loaded)
+	assert.Equal(t, 3, actual)
+	assert.Equal(t, 2, m.Len())
+
+	m.Delete("a")
+	m.Delete("a")
+	assert.Equal(t, 1, m.Len())
+
+	var wg sync.WaitGroup
+	for i := 0; i < 100; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			m.Store(strconv.Itoa(i%10), i)
+		}(i)
+	}
+	wg.Wait()
+	assert.Equal(t, 11, m.Len())
+
+	m.Clear()
+	assert.Equal(t, 0, m.Len())
+}
@@ -0,0 +1,142 @@
+/*
+Copyright 2023 The Dapr Authors
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package utils
+
+import (
+	"errors"
+	"sync"
+
+	"golang.org/x/sync/singleflight"
+)
+
+// ErrGroup runs functions in background goroutines, with an optional limit on the number of goroutines that are active at the same time.
+// Unlike errgroup.Group, it runs all functions even if some fail, and it returns all errors.
+// The zero value is an ErrGroup with no limit.
+type ErrGroup struct {
+	wg   sync.WaitGroup
+	sem  chan struct{}
+	errs []error
+	lock sync.Mutex
+}
+
+// NewErrGroup returns an ErrGroup that runs at most limit functions at the same time.
+// If limit is not positive, there's no limit.
+func NewErrGroup(limit int) *ErrGroup {
+	g := &ErrGroup{}
+	if limit > 0 {
+		g.sem = make(chan struct{}, limit)
+	}
+	return g
+}
+
+// Go runs fn in a background goroutine.
+// If the limit has been reached, it blocks until one of the active functions returns.
+func (g *ErrGroup) Go(fn func() error) {
+	if g.sem != nil {
+		g.sem <- struct{}{}
+	}
+
+	g.wg.Add(1)
+	go func() {
+		defer func() {
+			if g.sem != nil {
+				<-g.sem
+			}
+			g.wg.Done()
+		}()
+
+		if err := fn(); err != nil {
+			g.lock.Lock()
+			g.errs = append(g.errs, err)
+			g.lock.Unlock()
+		}
+	}()
+}
+
+// Wait blocks until all functions have returned, and returns all errors they returned, joined.
+func (g *ErrGroup) Wait() error {
+	g.wg.Wait()
+
+	g.lock.Lock()
+	defer g.lock.Unlock()
+	return errors.Join(g.errs...)
+}
+
+// KeyedMutex is a set of mutexes that are identified by a key.
+// Mutexes are allocated when first locked, and are removed when no goroutine holds or waits for them.
+// The zero value is ready to use.
+type KeyedMutex[K comparable] struct {
+	locks map[K]*keyedMutexEntry
+	lock  sync.Mutex
+}
+
+type keyedMutexEntry struct {
+	mu   sync.Mutex
+	refs int
+}
+
+// Lock locks the mutex for the key, and returns a function that unlocks it.
+func (m *KeyedMutex[K]) Lock(key K) (unlock func()) {
+	m.lock.Lock()
+	if m.locks == nil {
+		m.locks = make(map[K]*keyedMutexEntry)
+	}
+	e, ok := m.locks[key]
+	if !ok {
+		e = &keyedMutexEntry{}
+		m.locks[key] = e
+	}
+	e.refs++
+	m.lock.Unlock()
+
+	e.mu.Lock()
+
+	var once sync.Once
+	return func() {
+		once.Do(func() {
+			e.mu.Unlock()
+
+			m.lock.Lock()
+			e.refs--
+			if e.refs == 0 {
+				delete(m.locks, key)
+			}
+			m.lock.Unlock()
+		})
+	}
+}
+
+// Len returns the number of keys that currently have a mutex allocated.
+func (m *KeyedMutex[K]) Len() int {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+	return len(m.locks)
+}
+
+// SingleFlight is a typed wrapper around singleflight.Group, which suppresses duplicate concurrent calls for the same key.
+// The zero value is ready to use.
+type SingleFlight[T any] struct {
+	group singleflight.Group
+}
+
+// Do executes fn, making sure that only one execution is in-flight for a given key at a time.
+// If a duplicate call comes in, the caller waits for the original one to complete and receives the same results.
+// Because results are shared, callers must not modify them.
+func (s *SingleFlight[T]) Do(key string, fn func() (T, error)) (T, error) {
+	v, err, _ := s.group.Do(key, func() (any, error) {
+		return fn()
+	})
+	res, _ := v.(T)
+	return res, err
+}
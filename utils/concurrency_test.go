@@ -0,0 +1,121 @@
+/*
+Copyright 2023 The Dapr Authors
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package utils
+
+import (
+	"errors"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestErrGroup(t *testing.T) {
+	t.Run("returns all errors", func(t *testing.T) {
+		err1 := errors.New("err1")
+		err2 := errors.New("err2")
+		eg := NewErrGroup(0)
+		eg.Go(func() error { return err1 })
+		eg.Go(func() error { return nil })
+		eg.Go(func() error { return err2 })
+
+		err := eg.Wait()
+		require.ErrorIs(t, err, err1)
+		require.ErrorIs(t, err, err2)
+	})
+
+	t.Run("respects the limit", func(t *testing.T) {
+		var active, maxActive atomic.Int32
+		eg := NewErrGroup(2)
+		for i := 0; i < 10; i++ {
+			eg.Go(func() error {
+				n := active.Add(1)
+				for {
+					m := maxActive.Load()
+					if n <= m || maxActive.CompareAndSwap(m, n) {
+						break
+					}
+				}
+				time.Sleep(5 * time.Millisecond)
+				active.Add(-1)
+				return nil
+			})
+		}
+
+		require.NoError(t, eg.Wait())
+		assert.LessOrEqual(t, maxActive.Load(), int32(2))
+	})
+}
+
+func TestKeyedMutex(t *testing.T) {
+	var m KeyedMutex[string]
+
+	var counter int
+	var wg sync.WaitGroup
+	for i := 0; i < 50; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			unlock := m.Lock("key")
+			defer unlock()
+			counter++
+		}()
+	}
+	wg.Wait()
+	assert.Equal(t, 50, counter)
+
+	// Different keys don't block each other
+	unlock1 := m.Lock("key1")
+	unlock2 := m.Lock("key2")
+	assert.Equal(t, 2, m.Len())
+	unlock1()
+	unlock2()
+
+	// Mutexes are released when unused, and unlocking twice is a no-op
+	unlock2()
+	assert.Equal(t, 0, m.Len())
+}
+
+func TestSingleFlight(t *testing.T) {
+	var sf SingleFlight[int]
+	var calls atomic.Int32
+	start := make(chan struct{})
+
+	var wg sync.WaitGroup
+	results := make([]int, 5)
+	for i := range results {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			res, err := sf.Do("key", func() (int, error) {
+				calls.Add(1)
+				<-start
+				return 42, nil
+			})
+			assert.NoError(t, err)
+			results[i] = res
+		}(i)
+	}
+
+	// Give goroutines the time to join the in-flight call
+	time.Sleep(50 * time.Millisecond)
+	close(start)
+	wg.Wait()
+
+	assert.Equal(t, int32(1), calls.Load())
+	assert.Equal(t, []int{42, 42, 42, 42, 42}, results)
+}
@@ -18,6 +18,8 @@ import (
 	"fmt"
 	"net"
 	"os"
+	"strconv"
+	"strings"
 )
 
 const (
@@ -26,6 +28,7 @@ const (
 )
 
 // GetHostAddress selects a valid outbound IP address for the host.
+// IPv4 addresses are preferred; on IPv6-only hosts, an IPv6 address is returned.
 func GetHostAddress() (string, error) {
 	if val, ok := os.LookupEnv(HostIPEnvVar); ok && val != "" {
 		return val, nil
@@ -33,25 +36,64 @@ func GetHostAddress() (string, error) {
 
 	// Use udp so no handshake is made.
 	// Any IP can be used, since connection is not established, but we used a known DNS IP.
-	conn, err := net.Dial("udp", "8.8.8.8:80")
-	if err != nil {
-		// Could not find one via a  UDP connection, so we fallback to the "old" way: try first non-loopback IPv4:
-		addrs, err := net.InterfaceAddrs()
+	for _, target := range []string{"8.8.8.8:80", "[2001:4860:4860::8888]:80"} {
+		conn, err := net.Dial("udp", target)
 		if err != nil {
-			return "", fmt.Errorf("error getting interface IP addresses: %w", err)
+			continue
 		}
+		ip := conn.LocalAddr().(*net.UDPAddr).IP.String()
+		conn.Close()
+		return ip, nil
+	}
+
+	// Could not find one via a  UDP connection, so we fallback to the "old" way: try first non-loopback IPv4, then the first global unicast IPv6
+	addrs, err := net.InterfaceAddrs()
+	if err != nil {
+		return "", fmt.Errorf("error getting interface IP addresses: %w", err)
+	}
 
-		for _, addr := range addrs {
-			if ipnet, ok := addr.(*net.IPNet); ok && !ipnet.IP.IsLoopback() {
-				if ipnet.IP.To4() != nil {
-					return ipnet.IP.String(), nil
-				}
-			}
+	var ipv6 string
+	for _, addr := range addrs {
+		ipnet, ok := addr.(*net.IPNet)
+		if !ok || ipnet.IP.IsLoopback() {
+			continue
+		}
+		if ipnet.IP.To4() != nil {
+			return ipnet.IP.String(), nil
 		}
+		if ipv6 == "" && ipnet.IP.IsGlobalUnicast() {
+			ipv6 = ipnet.IP.String()
+		}
+	}
+	if ipv6 != "" {
+		return ipv6, nil
+	}
+
+	return "", errors.New("could not determine host IP address")
+}
 
-		return "", errors.New("could not determine host IP address")
+// JoinHostPort combines a host and a port into an address, enclosing IPv6 hosts in square brackets.
+// Hosts that are already enclosed in square brackets, such as "[::1]", are accepted too.
+func JoinHostPort(host string, port int) string {
+	host = strings.TrimSuffix(strings.TrimPrefix(host, "["), "]")
+	return net.JoinHostPort(host, strconv.Itoa(port))
+}
+
+// NormalizeHostPort returns the address with IPv6 hosts enclosed in square brackets.
+// Name resolvers may return addresses such as "fd00::1:50002", with an IPv6 host that is not enclosed in brackets: in this case, the last segment is treated as the port.
+// Other addresses are returned as-is.
+func NormalizeHostPort(addr string) string {
+	if strings.Count(addr, ":") < 2 || strings.Contains(addr, "[") {
+		return addr
 	}
 
-	defer conn.Close()
-	return conn.LocalAddr().(*net.UDPAddr).IP.String(), nil
+	idx := strings.LastIndexByte(addr, ':')
+	host, port := addr[:idx], addr[idx+1:]
+	if net.ParseIP(host) == nil {
+		return addr
+	}
+	if _, err := strconv.ParseUint(port, 10, 16); err != nil {
+		return addr
+	}
+	return net.JoinHostPort(host, port)
 }
@@ -36,3 +36,27 @@ func TestGetHostAdress(t *testing.T) {
 		assert.NotEmpty(t, address)
 	})
 }
+
+func TestJoinHostPort(t *testing.T) {
+	assert.Equal(t, "127.0.0.1:3500", JoinHostPort("127.0.0.1", 3500))
+	assert.Equal(t, "localhost:3500", JoinHostPort("localhost", 3500))
+	assert.Equal(t, "[::1]:3500", JoinHostPort("::1", 3500))
+	assert.Equal(t, "[::1]:3500", JoinHostPort("[::1]", 3500))
+	assert.Equal(t, ":3500", JoinHostPort("", 3500))
+}
+
+func TestNormalizeHostPort(t *testing.T) {
+	testCases := map[string]string{
+		"127.0.0.1:50002":     "127.0.0.1:50002",
+		"myapp.default:50002": "myapp.default:50002",
+		"[fd00::1]:50002":     "[fd00::1]:50002",
+		"fd00::1:50002":       "[fd00::1]:50002",
+		"2001:db8::a:b:50002": "[2001:db8::a:b]:50002",
+		"fd00::1:notaport":    "fd00::1:notaport",
+		"http://localhost:80": "http://localhost:80",
+		"::1":                 "::1",
+	}
+	for in, expected := range testCases {
+		assert.Equal(t, expected, NormalizeHostPort(in), in)
+	}
+}
@@ -0,0 +1,97 @@
+/*
+Copyright 2023 The Dapr Authors
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package streams
+
+import (
+	"fmt"
+	"io"
+
+	kitstreams "github.com/dapr/kit/streams"
+)
+
+// MaxBytesError is returned by MaxBytesReader when the stream is larger than the limit.
+// It matches kitstreams.ErrStreamTooLarge with errors.Is.
+type MaxBytesError struct {
+	// Limit is the maximum number of bytes that could be read from the stream.
+	Limit int64
+}
+
+func (e *MaxBytesError) Error() string {
+	return fmt.Sprintf("stream too large: exceeded the limit of %d bytes", e.Limit)
+}
+
+// Is allows MaxBytesError to be compared to kitstreams.ErrStreamTooLarge.
+func (e *MaxBytesError) Is(target error) bool {
+	return target == kitstreams.ErrStreamTooLarge //nolint:errorlint
+}
+
+// MaxBytesReader returns a ReadCloser that reads from r, but stops with a *MaxBytesError after n bytes.
+// Unlike http.MaxBytesReader, it doesn't require a ResponseWriter, and the stream is closed as soon as the limit is exceeded.
+func MaxBytesReader(r io.ReadCloser, n int64) io.ReadCloser {
+	if n < 0 {
+		n = 0
+	}
+	return &maxBytesReader{
+		r:     r,
+		limit: n,
+		n:     n,
+	}
+}
+
+type maxBytesReader struct {
+	r      io.ReadCloser
+	limit  int64
+	n      int64
+	err    error
+	closed bool
+}
+
+func (l *maxBytesReader) Read(p []byte) (n int, err error) {
+	if l.err != nil {
+		return 0, l.err
+	}
+	if len(p) == 0 {
+		return 0, nil
+	}
+
+	// Read one byte more than the limit, to know if the stream is larger than that
+	if int64(len(p)) > l.n+1 {
+		p = p[:l.n+1]
+	}
+	n, err = l.r.Read(p)
+
+	if int64(n) <= l.n {
+		l.n -= int64(n)
+		l.err = err
+		return n, err
+	}
+
+	n = int(l.n)
+	l.n = 0
+	l.err = &MaxBytesError{Limit: l.limit}
+	l.close()
+	return n, l.err
+}
+
+func (l *maxBytesReader) Close() error {
+	return l.close()
+}
+
+func (l *maxBytesReader) close() error {
+	if l.closed {
+		return nil
+	}
+	l.closed = true
+	return l.r.Close()
+}
@@ -0,0 +1,179 @@
+/*
+Copyright 2023 The Dapr Authors
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package streams
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"sync"
+)
+
+// DefaultReplayableReaderMemoryLimit is the default number of bytes that a ReplayableReader keeps in memory before spilling to disk.
+const DefaultReplayableReaderMemoryLimit = 4 << 20 // 4MB
+
+// ErrReplayableReaderClosed is returned when reading from a ReplayableReader that was closed.
+var ErrReplayableReaderClosed = errors.New("replayable reader is closed")
+
+// ReplayableReaderOpts contains the options for NewReplayableReader.
+type ReplayableReaderOpts struct {
+	// MemoryLimit is the number of bytes that are buffered in memory.
+	// When the data read exceeds this limit, it's moved to a temporary file.
+	// Defaults to DefaultReplayableReaderMemoryLimit if zero; if negative, data is always spilled to disk.
+	MemoryLimit int64
+	// TempDir is the directory where the temporary file is created.
+	// Defaults to the default directory for temporary files.
+	TempDir string
+}
+
+// ReplayableReader is a stream that records the data read from a source, so it can be replayed.
+// Data is kept in memory up to a limit, after which it's spilled into a temporary file, so large bodies can be inspected without being fully buffered in memory.
+// Callers must invoke Close to release the resources, including the temporary file.
+type ReplayableReader struct {
+	src  io.ReadCloser
+	opts ReplayableReaderOpts
+
+	mem    []byte
+	file   *os.File
+	size   int64
+	eof    bool
+	closed bool
+	lock   sync.Mutex
+}
+
+// NewReplayableReader returns a ReplayableReader that reads from src.
+func NewReplayableReader(src io.ReadCloser, opts ReplayableReaderOpts) *ReplayableReader {
+	if opts.MemoryLimit == 0 {
+		opts.MemoryLimit = DefaultReplayableReaderMemoryLimit
+	}
+	return &ReplayableReader{
+		src:  src,
+		opts: opts,
+	}
+}
+
+// Read reads from the source stream, recording the data.
+func (rr *ReplayableReader) Read(p []byte) (n int, err error) {
+	rr.lock.Lock()
+	defer rr.lock.Unlock()
+
+	if rr.closed {
+		return 0, ErrReplayableReaderClosed
+	}
+	if rr.eof {
+		return 0, io.EOF
+	}
+
+	n, err = rr.src.Read(p)
+	if n > 0 {
+		if rErr := rr.record(p[:n]); rErr != nil {
+			return n, rErr
+		}
+	}
+	if errors.Is(err, io.EOF) {
+		rr.eof = true
+	}
+	return n, err
+}
+
+// Size returns the number of bytes that have been recorded.
+func (rr *ReplayableReader) Size() int64 {
+	rr.lock.Lock()
+	defer rr.lock.Unlock()
+	return rr.size
+}
+
+// Spilled returns true if the recorded data has been moved to a temporary file.
+func (rr *ReplayableReader) Spilled() bool {
+	rr.lock.Lock()
+	defer rr.lock.Unlock()
+	return rr.file != nil
+}
+
+// Replay returns a stream that returns all data recorded so far, and then continues reading from the source through the ReplayableReader, recording it.
+// The returned stream is valid until the ReplayableReader is closed.
+func (rr *ReplayableReader) Replay() (io.Reader, error) {
+	rr.lock.Lock()
+	defer rr.lock.Unlock()
+
+	if rr.closed {
+		return nil, ErrReplayableReaderClosed
+	}
+
+	var recorded io.Reader
+	if rr.file != nil {
+		recorded = io.NewSectionReader(rr.file, 0, rr.size)
+	} else {
+		// Data is only ever appended to the slice, so this is not modified by further reads
+		recorded = bytes.NewReader(rr.mem[:len(rr.mem):len(rr.mem)])
+	}
+	return io.MultiReader(recorded, rr), nil
+}
+
+// Close closes the source stream and removes the temporary file, if any.
+func (rr *ReplayableReader) Close() error {
+	rr.lock.Lock()
+	defer rr.lock.Unlock()
+
+	if rr.closed {
+		return nil
+	}
+	rr.closed = true
+	rr.mem = nil
+
+	errs := []error{rr.src.Close()}
+	if rr.file != nil {
+		errs = append(errs, rr.file.Close(), os.Remove(rr.file.Name()))
+		rr.file = nil
+	}
+	return errors.Join(errs...)
+}
+
+func (rr *ReplayableReader) record(p []byte) error {
+	if rr.file == nil && rr.size+int64(len(p)) > rr.opts.MemoryLimit {
+		if err := rr.spill(); err != nil {
+			return err
+		}
+	}
+
+	if rr.file != nil {
+		// Writes go at the end of the file, while replays use ReadAt
+		if _, err := rr.file.Write(p); err != nil {
+			return fmt.Errorf("failed to write to temporary file: %w", err)
+		}
+	} else {
+		rr.mem = append(rr.mem, p...)
+	}
+	rr.size += int64(len(p))
+	return nil
+}
+
+func (rr *ReplayableReader) spill() error {
+	f, err := os.CreateTemp(rr.opts.TempDir, "dapr-replay-*")
+	if err != nil {
+		return fmt.Errorf("failed to create temporary file: %w", err)
+	}
+	if _, err = f.Write(rr.mem); err != nil {
+		f.Close()
+		os.Remove(f.Name())
+		return fmt.Errorf("failed to write to temporary file: %w", err)
+	}
+
+	rr.file = f
+	// Do not reset the slice, as it may still be used by replays that were started before
+	rr.mem = nil
+	return nil
+}
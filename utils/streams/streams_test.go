@@ -0,0 +1,124 @@
+/*
+Copyright 2023 The Dapr Authors
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package streams
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	kitstreams "github.com/dapr/kit/streams"
+)
+
+type errWriter struct{}
+
+func (errWriter) Write(p []byte) (int, error) {
+	return 0, errors.New("write failed")
+}
+
+func TestTeeTransformer(t *testing.T) {
+	t.Run("copies the stream", func(t *testing.T) {
+		buf := &bytes.Buffer{}
+		tt := NewTeeTransformer(buf)
+		read, err := io.ReadAll(tt.Transform(strings.NewReader("hello world")))
+		require.NoError(t, err)
+		assert.Equal(t, "hello world", string(read))
+		assert.Equal(t, "hello world", buf.String())
+		require.NoError(t, tt.Err())
+	})
+
+	t.Run("write errors do not interrupt the stream", func(t *testing.T) {
+		tt := NewTeeTransformer(errWriter{})
+		read, err := io.ReadAll(tt.Transform(strings.NewReader("hello world")))
+		require.NoError(t, err)
+		assert.Equal(t, "hello world", string(read))
+		require.Error(t, tt.Err())
+	})
+}
+
+func TestMaxBytesReader(t *testing.T) {
+	t.Run("within the limit", func(t *testing.T) {
+		read, err := io.ReadAll(MaxBytesReader(io.NopCloser(strings.NewReader("hello")), 5))
+		require.NoError(t, err)
+		assert.Equal(t, "hello", string(read))
+	})
+
+	t.Run("exceeds the limit", func(t *testing.T) {
+		read, err := io.ReadAll(MaxBytesReader(io.NopCloser(strings.NewReader("hello world")), 5))
+		var maxErr *MaxBytesError
+		require.ErrorAs(t, err, &maxErr)
+		assert.Equal(t, int64(5), maxErr.Limit)
+		require.ErrorIs(t, err, kitstreams.ErrStreamTooLarge)
+		assert.Equal(t, "hello", string(read))
+	})
+}
+
+func TestReplayableReader(t *testing.T) {
+	t.Run("in memory", func(t *testing.T) {
+		rr := NewReplayableReader(io.NopCloser(strings.NewReader("hello world")), ReplayableReaderOpts{})
+		defer rr.Close()
+
+		p := make([]byte, 5)
+		_, err := io.ReadFull(rr, p)
+		require.NoError(t, err)
+		assert.Equal(t, "hello", string(p))
+
+		replay, err := rr.Replay()
+		require.NoError(t, err)
+		read, err := io.ReadAll(replay)
+		require.NoError(t, err)
+		assert.Equal(t, "hello world", string(read))
+		assert.False(t, rr.Spilled())
+		assert.Equal(t, int64(11), rr.Size())
+
+		replay, err = rr.Replay()
+		require.NoError(t, err)
+		read, err = io.ReadAll(replay)
+		require.NoError(t, err)
+		assert.Equal(t, "hello world", string(read))
+	})
+
+	t.Run("spills to disk", func(t *testing.T) {
+		dir := t.TempDir()
+		rr := NewReplayableReader(io.NopCloser(strings.NewReader("hello world")), ReplayableReaderOpts{
+			MemoryLimit: 4,
+			TempDir:     dir,
+		})
+
+		read, err := io.ReadAll(rr)
+		require.NoError(t, err)
+		assert.Equal(t, "hello world", string(read))
+		assert.True(t, rr.Spilled())
+
+		replay, err := rr.Replay()
+		require.NoError(t, err)
+		read, err = io.ReadAll(replay)
+		require.NoError(t, err)
+		assert.Equal(t, "hello world", string(read))
+
+		require.NoError(t, rr.Close())
+		entries, err := os.ReadDir(dir)
+		require.NoError(t, err)
+		assert.Empty(t, entries)
+
+		_, err = rr.Replay()
+		require.ErrorIs(t, err, ErrReplayableReaderClosed)
+	})
+}
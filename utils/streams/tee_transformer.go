@@ -0,0 +1,77 @@
+/*
+Copyright 2023 The Dapr Authors
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package streams contains utilities for working with streams that are used by the runtime, in addition to the ones in dapr/kit.
+package streams
+
+import (
+	"io"
+	"sync"
+)
+
+// TeeTransformer forwards a stream unchanged while copying it into a writer, for example to inspect a request body.
+// Failures to write the copy don't interrupt the forwarded stream: they stop the copy, and are reported by Err.
+type TeeTransformer struct {
+	w    io.Writer
+	err  error
+	lock sync.Mutex
+}
+
+// NewTeeTransformer returns a TeeTransformer that copies the streams into w.
+func NewTeeTransformer(w io.Writer) *TeeTransformer {
+	return &TeeTransformer{
+		w: w,
+	}
+}
+
+// Transform returns a stream that reads from r and copies what it reads into the writer.
+func (t *TeeTransformer) Transform(r io.Reader) io.Reader {
+	return &teeTransformerReader{
+		r: r,
+		t: t,
+	}
+}
+
+// Err returns the error, if any, that was returned while writing the copy.
+func (t *TeeTransformer) Err() error {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+	return t.err
+}
+
+func (t *TeeTransformer) write(p []byte) {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+
+	if t.err != nil {
+		return
+	}
+	n, err := t.w.Write(p)
+	if err == nil && n < len(p) {
+		err = io.ErrShortWrite
+	}
+	t.err = err
+}
+
+type teeTransformerReader struct {
+	r io.Reader
+	t *TeeTransformer
+}
+
+func (tr *teeTransformerReader) Read(p []byte) (n int, err error) {
+	n, err = tr.r.Read(p)
+	if n > 0 {
+		tr.t.write(p[:n])
+	}
+	return n, err
+}